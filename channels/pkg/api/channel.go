@@ -68,6 +68,16 @@ type AddonSpec struct {
 	// NeedsPKI determines if channels should provision a CA and a cert-manager issuer for the addon.
 	NeedsPKI bool `json:"needsPKI,omitempty"`
 
+	// NeedsRollout determines if channels should wait for the Deployments and DaemonSets of the addon to become ready after applying it.
+	NeedsRollout bool `json:"needsRollout,omitempty"`
+
+	// RolloutTimeout is how long channels waits for the rollout when NeedsRollout is set. Defaults to 5 minutes.
+	RolloutTimeout *metav1.Duration `json:"rolloutTimeout,omitempty"`
+
+	// Needs are the names of addons that must be installed, and whose Deployments and DaemonSets must be ready,
+	// before channels applies this addon.
+	Needs []string `json:"needs,omitempty"`
+
 	Version string `json:"version,omitempty"`
 
 	// PruneSpec specifies how old objects should be removed (pruned).
@@ -73,7 +73,7 @@ func (m *AddonMenu) MergeAddons(o *AddonMenu) {
 		if existing == nil {
 			m.Addons[k] = v
 		} else {
-			if v.ChannelVersion().replaces(k, existing.ChannelVersion()) {
+			if v.ChannelVersion().Replaces(k, existing.ChannelVersion()) {
 				m.Addons[k] = v
 			}
 		}
@@ -85,6 +85,7 @@ func (a *Addon) ChannelVersion() *ChannelVersion {
 		Channel:          &a.ChannelName,
 		Id:               a.Spec.Id,
 		ManifestHash:     a.Spec.ManifestHash,
+		Version:          a.Spec.Version,
 		SystemGeneration: CurrentSystemGeneration,
 	}
 }
@@ -120,7 +121,7 @@ func (a *Addon) GetRequiredUpdates(ctx context.Context, k8sClient kubernetes.Int
 		pkiInstalled = needsPKI
 	}
 
-	if existingVersion != nil && !newVersion.replaces(a.Name, existingVersion) {
+	if existingVersion != nil && !newVersion.Replaces(a.Name, existingVersion) {
 		newVersion = nil
 	}
 
@@ -152,7 +153,7 @@ func (a *Addon) GetManifestFullUrl() (*url.URL, error) {
 	return manifestURL, nil
 }
 
-func (a *Addon) EnsureUpdated(ctx context.Context, k8sClient kubernetes.Interface, cmClient certmanager.Interface, pruner *Pruner, existingVersion *ChannelVersion) (*AddonUpdate, error) {
+func (a *Addon) EnsureUpdated(ctx context.Context, k8sClient kubernetes.Interface, cmClient certmanager.Interface, applier *Applier, pruner *Pruner, existingVersion *ChannelVersion) (*AddonUpdate, error) {
 	required, err := a.GetRequiredUpdates(ctx, k8sClient, cmClient, existingVersion)
 	if err != nil {
 		return nil, err
@@ -168,13 +169,23 @@ func (a *Addon) EnsureUpdated(ctx context.Context, k8sClient kubernetes.Interfac
 		}
 		klog.Infof("Applying update from %q", manifestURL)
 
-		// We copy the manifest to a temp file because it is likely e.g. an s3 URL, which kubectl can't read
 		data, err := vfs.Context.ReadFile(manifestURL.String())
 		if err != nil {
 			return nil, fmt.Errorf("error reading manifest: %w", err)
 		}
 
-		if err := Apply(data); err != nil {
+		channel := a.buildChannel()
+
+		inventory, err := BuildInventory(data)
+		if err != nil {
+			return nil, fmt.Errorf("error building inventory of manifest from %q: %w", manifestURL, err)
+		}
+		previousInventory, err := channel.GetInventory(ctx, k8sClient)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := applier.Apply(ctx, data); err != nil {
 			return nil, fmt.Errorf("error applying update from %q: %w", manifestURL, err)
 		}
 
@@ -182,11 +193,26 @@ func (a *Addon) EnsureUpdated(ctx context.Context, k8sClient kubernetes.Interfac
 			return nil, fmt.Errorf("error pruning manifest from %q: %w", manifestURL, err)
 		}
 
+		if err := pruner.PruneInventory(ctx, a.Name, previousInventory, inventory); err != nil {
+			return nil, fmt.Errorf("error pruning objects removed from manifest %q: %w", manifestURL, err)
+		}
+
+		if err := channel.SetInventory(ctx, k8sClient, inventory); err != nil {
+			return nil, fmt.Errorf("error recording inventory of %q: %w", a.Name, err)
+		}
+
+		if a.Spec.NeedsRollout {
+			timeout := a.rolloutTimeout()
+			klog.Infof("Waiting up to %v for rollout of %q", timeout, a.Name)
+			if err := WaitForRollout(ctx, k8sClient, inventory, timeout); err != nil {
+				return nil, fmt.Errorf("error waiting for rollout of %q: %w", a.Name, err)
+			}
+		}
+
 		if err := a.AddNeedsUpdateLabel(ctx, k8sClient, required); err != nil {
 			return nil, fmt.Errorf("error adding needs-update label: %v", err)
 		}
 
-		channel := a.buildChannel()
 		err = channel.SetInstalledVersion(ctx, k8sClient, a.ChannelVersion())
 		if err != nil {
 			return nil, fmt.Errorf("error applying annotation to record addon installation: %v", err)
@@ -74,7 +74,7 @@ func (a *Addons) GetCurrent(kubernetesVersion semver.Version) (*AddonMenu, error
 		name := addon.Name
 
 		existing := menu.Addons[name]
-		if existing == nil || addon.ChannelVersion().replaces(name, existing.ChannelVersion()) {
+		if existing == nil || addon.ChannelVersion().Replaces(name, existing.ChannelVersion()) {
 			menu.Addons[name] = addon
 		}
 	}
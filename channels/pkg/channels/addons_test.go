@@ -126,7 +126,7 @@ func Test_Replacement(t *testing.T) {
 		},
 	}
 	for _, g := range grid {
-		actual := g.New.replaces(t.Name(), g.Old)
+		actual := g.New.Replaces(t.Name(), g.Old)
 		if actual != g.Replaces {
 			t.Errorf("unexpected result from %v -> %v, expect %t.  actual %v", g.Old, g.New, g.Replaces, actual)
 		}
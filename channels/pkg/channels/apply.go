@@ -17,72 +17,211 @@ limitations under the License.
 package channels
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"os"
-	"os/exec"
-	"path"
-	"strings"
+	"sort"
+	"time"
 
+	"go.uber.org/multierr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
 	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/kubemanifest"
 )
 
-// Apply calls kubectl apply to apply the manifest.
-// We will likely in future change this to create things directly (or more likely embed this logic into kubectl itself)
-func Apply(data []byte) error {
-	// We copy the manifest to a temp file because it is likely e.g. an s3 URL, which kubectl can't read
-	tmpDir, err := os.MkdirTemp("", "channel")
-	if err != nil {
-		return fmt.Errorf("error creating temp dir: %v", err)
+// fieldManager is the field manager we use for server-side apply.
+const fieldManager = "kops"
+
+// applyBackoff controls the retries when applying an object fails with a transient error,
+// for example when a custom resource is applied before its CRD has been established.
+var applyBackoff = wait.Backoff{
+	Duration: time.Second,
+	Factor:   2,
+	Steps:    6,
+}
+
+// Applier applies manifests using server-side apply.
+type Applier struct {
+	Client     dynamic.Interface
+	RESTMapper *restmapper.DeferredDiscoveryRESTMapper
+}
+
+// ApplyObjectError is returned when an object in a manifest could not be applied.
+type ApplyObjectError struct {
+	GroupKind schema.GroupKind
+	Namespace string
+	Name      string
+	Err       error
+}
+
+func (e *ApplyObjectError) Error() string {
+	if e.Namespace == "" {
+		return fmt.Sprintf("error applying %s %s: %v", e.GroupKind, e.Name, e.Err)
 	}
+	return fmt.Sprintf("error applying %s %s/%s: %v", e.GroupKind, e.Namespace, e.Name, e.Err)
+}
 
-	defer func() {
-		if err := os.RemoveAll(tmpDir); err != nil {
-			klog.Warningf("error deleting temp dir %q: %v", tmpDir, err)
-		}
-	}()
+func (e *ApplyObjectError) Unwrap() error {
+	return e.Err
+}
 
-	localManifestFile := path.Join(tmpDir, "manifest.yaml")
-	if err := os.WriteFile(localManifestFile, data, 0o600); err != nil {
-		return fmt.Errorf("error writing temp file: %v", err)
+// Apply applies all the objects in the manifest, with the "kops" field manager.
+// Every object is attempted; the errors of the objects that failed are combined in the returned error.
+func (a *Applier) Apply(ctx context.Context, manifest []byte) error {
+	objects, err := kubemanifest.LoadObjectsFrom(manifest)
+	if err != nil {
+		return fmt.Errorf("failed to parse objects: %w", err)
 	}
-	{
-		_, err := execKubectl("apply", "-f", localManifestFile, "--server-side", "--force-conflicts", "--field-manager=kops")
-		if err != nil {
-			klog.Errorf("failed to apply the manifest: %v", err)
+
+	var merr error
+	for _, object := range sortObjectsForApply(objects) {
+		if err := a.applyObjectWithRetries(ctx, object); err != nil {
+			merr = multierr.Append(merr, err)
 		}
 	}
-	{
-		_, err := execKubectl("replace", "-f", localManifestFile, "--field-manager=kops")
-		if err != nil {
-			return fmt.Errorf("failed to replace manifest: %w", err)
+	return merr
+}
+
+func (a *Applier) applyObjectWithRetries(ctx context.Context, object *kubemanifest.Object) error {
+	var lastErr error
+	err := wait.ExponentialBackoff(applyBackoff, func() (bool, error) {
+		_, lastErr = a.applyObject(ctx, object, false)
+		if lastErr == nil {
+			return true, nil
 		}
+		if !isRetryableApplyError(lastErr) {
+			return false, lastErr
+		}
+		klog.Warningf("retrying after error: %v", lastErr)
+		if meta.IsNoMatchError(errorCause(lastErr)) {
+			// The kind may be defined by a CRD we just applied
+			a.RESTMapper.Reset()
+		}
+		return false, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		err = lastErr
 	}
+	return err
+}
 
-	// Remove this one. Just to show that apply works properly after replace
-	{
-		_, err := execKubectl("apply", "-f", localManifestFile, "--server-side", "--force-conflicts", "--field-manager=kops")
-		if err != nil {
-			return fmt.Errorf("failed to apply the manifest: %w", err)
-		}
+// applyObject applies the object with server-side apply, and returns the object as stored by the server.
+// If dryRun is set, the server computes the result of the apply without persisting it.
+func (a *Applier) applyObject(ctx context.Context, object *kubemanifest.Object, dryRun bool) (*unstructured.Unstructured, error) {
+	u, resource, err := a.resourceForObject(object)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(u)
+	if err != nil {
+		return nil, objectError(u, fmt.Errorf("failed to marshal object: %w", err))
 	}
 
-	return nil
+	klog.V(2).Infof("applying %s %s/%s", u.GroupVersionKind().GroupKind(), u.GetNamespace(), u.GetName())
+
+	// We force the apply so that we take ownership of fields set by other managers, as kubectl apply --force-conflicts does
+	force := true
+	patchOptions := metav1.PatchOptions{
+		FieldManager: fieldManager,
+		Force:        &force,
+	}
+	if dryRun {
+		patchOptions.DryRun = []string{metav1.DryRunAll}
+	}
+	applied, err := resource.Patch(ctx, u.GetName(), types.ApplyPatchType, data, patchOptions)
+	if err != nil {
+		return nil, objectError(u, err)
+	}
+	return applied, nil
 }
 
-func execKubectl(args ...string) (string, error) {
-	kubectlPath := "kubectl" // Assume in PATH
-	cmd := exec.Command(kubectlPath, args...)
-	env := os.Environ()
-	cmd.Env = env
+// resourceForObject returns the object as unstructured, and the client for its resource.
+// Namespaced objects without a namespace are placed in the default namespace.
+func (a *Applier) resourceForObject(object *kubemanifest.Object) (*unstructured.Unstructured, dynamic.ResourceInterface, error) {
+	gv, err := schema.ParseGroupVersion(object.APIVersion())
+	if err != nil || gv.Version == "" {
+		return nil, nil, fmt.Errorf("failed to parse apiVersion %q", object.APIVersion())
+	}
+	kind := object.Kind()
+	if kind == "" {
+		return nil, nil, fmt.Errorf("failed to find kind in object")
+	}
+	gvk := gv.WithKind(kind)
+
+	u := object.ToUnstructured()
 
-	human := strings.Join(cmd.Args, " ")
-	klog.V(2).Infof("Running command: %s", human)
-	output, err := cmd.CombinedOutput()
+	restMapping, err := a.RESTMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
 	if err != nil {
-		klog.Infof("error running %s", human)
-		klog.Info(string(output))
-		return string(output), fmt.Errorf("error running kubectl: %v", err)
+		return nil, nil, objectError(u, err)
+	}
+
+	if restMapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		if u.GetNamespace() == "" {
+			u.SetNamespace(metav1.NamespaceDefault)
+		}
+		return u, a.Client.Resource(restMapping.Resource).Namespace(u.GetNamespace()), nil
 	}
+	return u, a.Client.Resource(restMapping.Resource), nil
+}
 
-	return string(output), err
+func objectError(u *unstructured.Unstructured, err error) *ApplyObjectError {
+	return &ApplyObjectError{
+		GroupKind: u.GroupVersionKind().GroupKind(),
+		Namespace: u.GetNamespace(),
+		Name:      u.GetName(),
+		Err:       err,
+	}
+}
+
+// errorCause returns the error that caused an ApplyObjectError.
+func errorCause(err error) error {
+	var objectErr *ApplyObjectError
+	if errors.As(err, &objectErr) {
+		return objectErr.Err
+	}
+	return err
+}
+
+// isRetryableApplyError returns true if the error may go away if we try again.
+func isRetryableApplyError(err error) bool {
+	err = errorCause(err)
+	return meta.IsNoMatchError(err) ||
+		apierrors.IsConflict(err) ||
+		apierrors.IsServerTimeout(err) ||
+		apierrors.IsTimeout(err) ||
+		apierrors.IsTooManyRequests(err) ||
+		apierrors.IsInternalError(err) ||
+		apierrors.IsServiceUnavailable(err)
+}
+
+// sortObjectsForApply returns the objects in the order they should be applied:
+// namespaces and CRDs first, so that the objects that depend on them can be created.
+func sortObjectsForApply(objects kubemanifest.ObjectList) []*kubemanifest.Object {
+	sorted := make([]*kubemanifest.Object, len(objects))
+	copy(sorted, objects)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return applyPriority(sorted[i]) < applyPriority(sorted[j])
+	})
+	return sorted
+}
+
+func applyPriority(object *kubemanifest.Object) int {
+	switch object.Kind() {
+	case "Namespace":
+		return 0
+	case "CustomResourceDefinition":
+		return 1
+	default:
+		return 2
+	}
 }
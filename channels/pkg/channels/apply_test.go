@@ -0,0 +1,125 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channels
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kops/pkg/kubemanifest"
+)
+
+func TestSortObjectsForApply(t *testing.T) {
+	manifest := `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: controller
+  namespace: example
+---
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: widget
+  namespace: example
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: controller
+  namespace: example
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: example
+`
+	objects, err := kubemanifest.LoadObjectsFrom([]byte(manifest))
+	if err != nil {
+		t.Fatalf("error parsing manifest: %v", err)
+	}
+
+	var actual []string
+	for _, object := range sortObjectsForApply(objects) {
+		actual = append(actual, object.Kind())
+	}
+
+	expected := "Namespace,CustomResourceDefinition,Deployment,Widget,ServiceAccount"
+	if strings.Join(actual, ",") != expected {
+		t.Errorf("unexpected order, got %v, want %v", strings.Join(actual, ","), expected)
+	}
+}
+
+func TestIsRetryableApplyError(t *testing.T) {
+	gk := schema.GroupKind{Group: "example.com", Kind: "Widget"}
+	gr := schema.GroupResource{Group: "example.com", Resource: "widgets"}
+
+	grid := []struct {
+		err      error
+		expected bool
+	}{
+		{
+			err:      &meta.NoKindMatchError{GroupKind: gk, SearchedVersions: []string{"v1"}},
+			expected: true,
+		},
+		{
+			err:      apierrors.NewConflict(gr, "widget", fmt.Errorf("conflict")),
+			expected: true,
+		},
+		{
+			err:      apierrors.NewServiceUnavailable("unavailable"),
+			expected: true,
+		},
+		{
+			err:      apierrors.NewInvalid(gk, "widget", nil),
+			expected: false,
+		},
+		{
+			err:      apierrors.NewForbidden(gr, "widget", fmt.Errorf("forbidden")),
+			expected: false,
+		},
+	}
+
+	for _, g := range grid {
+		objectErr := &ApplyObjectError{GroupKind: gk, Namespace: "example", Name: "widget", Err: g.err}
+		if actual := isRetryableApplyError(objectErr); actual != g.expected {
+			t.Errorf("unexpected result for %v: got %v, want %v", objectErr, actual, g.expected)
+		}
+	}
+}
+
+func TestApplyObjectErrorMessage(t *testing.T) {
+	err := &ApplyObjectError{
+		GroupKind: schema.GroupKind{Group: "apps", Kind: "Deployment"},
+		Namespace: "kube-system",
+		Name:      "coredns",
+		Err:       fmt.Errorf("boom"),
+	}
+	expected := "error applying Deployment.apps kube-system/coredns: boom"
+	if err.Error() != expected {
+		t.Errorf("unexpected message, got %q, want %q", err.Error(), expected)
+	}
+}
@@ -41,8 +41,9 @@ type Channel struct {
 
 // CurrentSystemGeneration holds our current SystemGeneration value.
 // Version history:
-//   0  Pre-history (and the default value); versions prior to prune.
-//   1  Prune functionality introduced.
+//
+//	0  Pre-history (and the default value); versions prior to prune.
+//	1  Prune functionality introduced.
 const CurrentSystemGeneration = 1
 
 type ChannelVersion struct {
@@ -50,6 +51,10 @@ type ChannelVersion struct {
 	Id           string  `json:"id,omitempty"`
 	ManifestHash string  `json:"manifestHash,omitempty"`
 
+	// Version is the version of the addon, as declared in the channel.
+	// It is informational only and is not considered when deciding whether to replace an addon.
+	Version string `json:"version,omitempty"`
+
 	// SystemGeneration holds the generation of the channels functionality.
 	// It is used so that we reapply when we introduce new features, such as prune.
 	SystemGeneration int `json:"systemGeneration,omitempty"`
@@ -70,6 +75,9 @@ func (c *ChannelVersion) String() string {
 	if c.ManifestHash != "" {
 		s += " ManifestHash=" + c.ManifestHash
 	}
+	if c.Version != "" {
+		s += " Version=" + c.Version
+	}
 	s += " SystemGeneration=" + strconv.Itoa(c.SystemGeneration)
 	return s
 }
@@ -114,7 +122,8 @@ func (c *Channel) AnnotationName() string {
 	return AnnotationPrefix + c.Name
 }
 
-func (c *ChannelVersion) replaces(name string, existing *ChannelVersion) bool {
+// Replaces returns true if applying this version of the addon would replace the existing version.
+func (c *ChannelVersion) Replaces(name string, existing *ChannelVersion) bool {
 	klog.V(6).Infof("Checking existing config for %q: %v compared to new channel: %v", name, existing, c)
 
 	if c.Id != existing.Id {
@@ -0,0 +1,180 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channels
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/multierr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/kops/pkg/diff"
+	"k8s.io/kops/pkg/kubemanifest"
+	"k8s.io/kops/util/pkg/vfs"
+	"sigs.k8s.io/yaml"
+)
+
+// ObjectDiff holds the live state of an object in a manifest, and the state it would have after the manifest is applied.
+type ObjectDiff struct {
+	ObjectReference
+
+	// Live is the object in the cluster, or nil if the object does not exist.
+	Live *unstructured.Unstructured
+	// Applied is the object as it would be stored after applying the manifest.
+	Applied *unstructured.Unstructured
+}
+
+// IsNew returns true if the object does not exist in the cluster.
+func (d *ObjectDiff) IsNew() bool {
+	return d.Live == nil
+}
+
+// Diff returns a textual diff of the live object against the applied object, or an empty string if they are the same.
+// Fields maintained by the server, such as the status and the managed fields, are ignored.
+func (d *ObjectDiff) Diff() (string, error) {
+	live := ""
+	if d.Live != nil {
+		b, err := yaml.Marshal(normalizeForDiff(d.Live).Object)
+		if err != nil {
+			return "", fmt.Errorf("error serializing %s: %w", d.ObjectReference, err)
+		}
+		live = string(b)
+	}
+	b, err := yaml.Marshal(normalizeForDiff(d.Applied).Object)
+	if err != nil {
+		return "", fmt.Errorf("error serializing %s: %w", d.ObjectReference, err)
+	}
+	applied := string(b)
+
+	if live == applied {
+		return "", nil
+	}
+	return diff.FormatDiff(live, applied), nil
+}
+
+// normalizeForDiff returns a copy of the object without the fields that are maintained by the server.
+func normalizeForDiff(u *unstructured.Unstructured) *unstructured.Unstructured {
+	u = u.DeepCopy()
+	unstructured.RemoveNestedField(u.Object, "status")
+	for _, field := range []string{"managedFields", "resourceVersion", "generation", "uid", "creationTimestamp", "selfLink"} {
+		unstructured.RemoveNestedField(u.Object, "metadata", field)
+	}
+	return u
+}
+
+// Diff compares the objects in the manifest with the objects in the cluster.
+// The changes to existing objects are computed with a server-side dry-run apply, so they include defaulting and
+// the fields owned by other managers.  Every object is compared; the errors are combined in the returned error.
+func (a *Applier) Diff(ctx context.Context, manifest []byte) ([]*ObjectDiff, error) {
+	objects, err := kubemanifest.LoadObjectsFrom(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse objects: %w", err)
+	}
+
+	var diffs []*ObjectDiff
+	var merr error
+	for _, object := range sortObjectsForApply(objects) {
+		d, err := a.diffObject(ctx, object)
+		if err != nil {
+			merr = multierr.Append(merr, err)
+			continue
+		}
+		diffs = append(diffs, d)
+	}
+	return diffs, merr
+}
+
+func (a *Applier) diffObject(ctx context.Context, object *kubemanifest.Object) (*ObjectDiff, error) {
+	u, resource, err := a.resourceForObject(object)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &ObjectDiff{
+		ObjectReference: ObjectReference{
+			Group:     u.GroupVersionKind().Group,
+			Kind:      u.GetKind(),
+			Namespace: u.GetNamespace(),
+			Name:      u.GetName(),
+		},
+	}
+
+	live, err := resource.Get(ctx, u.GetName(), metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, objectError(u, err)
+		}
+		// A dry-run would fail for objects in namespaces that don't exist yet, and we would only learn the defaults
+		d.Applied = u
+		return d, nil
+	}
+	d.Live = live
+
+	applied, err := a.applyObject(ctx, object, true)
+	if err != nil {
+		return nil, err
+	}
+	d.Applied = applied
+	return d, nil
+}
+
+// AddonDiff holds the changes that applying an addon would make to the cluster.
+type AddonDiff struct {
+	// Objects are the differences for the objects in the manifest.
+	Objects []*ObjectDiff
+	// Pruned are the objects that were removed from the manifest, and would be pruned if they are still labeled as belonging to the addon.
+	Pruned []ObjectReference
+}
+
+// Diff computes the changes that applying the addon would make to the cluster.
+func (a *Addon) Diff(ctx context.Context, k8sClient kubernetes.Interface, applier *Applier) (*AddonDiff, error) {
+	manifestURL, err := a.GetManifestFullUrl()
+	if err != nil {
+		return nil, err
+	}
+	data, err := vfs.Context.ReadFile(manifestURL.String())
+	if err != nil {
+		return nil, fmt.Errorf("error reading manifest: %w", err)
+	}
+
+	result := &AddonDiff{}
+
+	inventory, err := BuildInventory(data)
+	if err != nil {
+		return nil, fmt.Errorf("error building inventory of manifest from %q: %w", manifestURL, err)
+	}
+	previousInventory, err := a.buildChannel().GetInventory(ctx, k8sClient)
+	if err != nil {
+		return nil, err
+	}
+	if previousInventory != nil {
+		for _, ref := range previousInventory.Removed(inventory) {
+			if !neverPruneGroupKinds[ref.GroupKind()] {
+				result.Pruned = append(result.Pruned, ref)
+			}
+		}
+	}
+
+	result.Objects, err = applier.Diff(ctx, data)
+	if err != nil {
+		return nil, fmt.Errorf("error comparing manifest from %q: %w", manifestURL, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channels
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestObjectDiff(t *testing.T) {
+	configMap := func(data string, resourceVersion string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":      "coredns",
+				"namespace": "kube-system",
+			},
+			"data": map[string]interface{}{
+				"Corefile": data,
+			},
+		}}
+		if resourceVersion != "" {
+			u.SetResourceVersion(resourceVersion)
+			u.SetUID("1234")
+			u.SetManagedFields([]metav1.ManagedFieldsEntry{{Manager: "kops"}})
+		}
+		return u
+	}
+	ref := ObjectReference{Kind: "ConfigMap", Namespace: "kube-system", Name: "coredns"}
+
+	grid := []struct {
+		name     string
+		diff     *ObjectDiff
+		isNew    bool
+		expected string
+	}{
+		{
+			name:     "unchanged",
+			diff:     &ObjectDiff{ObjectReference: ref, Live: configMap(".:53", "1"), Applied: configMap(".:53", "2")},
+			expected: "",
+		},
+		{
+			name: "changed",
+			diff: &ObjectDiff{ObjectReference: ref, Live: configMap(".:53", "1"), Applied: configMap(".:5353", "2")},
+			expected: `  apiVersion: v1
+  data:
++   Corefile: .:5353
+-   Corefile: .:53
+  kind: ConfigMap
+  metadata:
+...
+`,
+		},
+		{
+			name:  "new",
+			diff:  &ObjectDiff{ObjectReference: ref, Applied: configMap(".:53", "")},
+			isNew: true,
+			expected: `+ apiVersion: v1
++ data:
++   Corefile: .:53
++ kind: ConfigMap
++ metadata:
++   name: coredns
++   namespace: kube-system
+`,
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			if g.diff.IsNew() != g.isNew {
+				t.Errorf("unexpected IsNew: %v", g.diff.IsNew())
+			}
+			actual, err := g.diff.Diff()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual != g.expected {
+				t.Errorf("unexpected diff, got:\n%s\nwant:\n%s", actual, g.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channels
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/kubemanifest"
+)
+
+const (
+	// inventoryConfigMapPrefix is the prefix of the name of the ConfigMaps holding the addon inventories.
+	inventoryConfigMapPrefix = "kops-addon-inventory."
+	// inventoryKey is the ConfigMap key holding the inventory.
+	inventoryKey = "inventory"
+
+	// LabelAddonName is the label kOps adds to all the objects of an addon.
+	LabelAddonName = "addon.kops.k8s.io/name"
+)
+
+// Inventory is the list of objects that were applied for an addon.
+type Inventory struct {
+	Objects []ObjectReference `json:"objects,omitempty"`
+}
+
+// ObjectReference identifies an object in an addon manifest.
+type ObjectReference struct {
+	Group     string `json:"group,omitempty"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+func (r ObjectReference) GroupKind() schema.GroupKind {
+	return schema.GroupKind{Group: r.Group, Kind: r.Kind}
+}
+
+func (r ObjectReference) String() string {
+	if r.Namespace == "" {
+		return fmt.Sprintf("%s %s", r.GroupKind(), r.Name)
+	}
+	return fmt.Sprintf("%s %s/%s", r.GroupKind(), r.Namespace, r.Name)
+}
+
+// BuildInventory returns the inventory of the objects in the manifest.
+func BuildInventory(manifest []byte) (*Inventory, error) {
+	objects, err := kubemanifest.LoadObjectsFrom(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse objects: %w", err)
+	}
+
+	inventory := &Inventory{}
+	for _, object := range objects {
+		gv, err := schema.ParseGroupVersion(object.APIVersion())
+		if err != nil || gv.Version == "" {
+			return nil, fmt.Errorf("failed to parse apiVersion %q", object.APIVersion())
+		}
+		kind := object.Kind()
+		if kind == "" {
+			return nil, fmt.Errorf("failed to find kind in object")
+		}
+
+		inventory.Objects = append(inventory.Objects, ObjectReference{
+			Group:     gv.Group,
+			Kind:      kind,
+			Namespace: object.GetNamespace(),
+			Name:      object.GetName(),
+		})
+	}
+
+	sort.Slice(inventory.Objects, func(i, j int) bool {
+		return inventory.Objects[i].String() < inventory.Objects[j].String()
+	})
+	return inventory, nil
+}
+
+// Removed returns the objects in the inventory that are not in the newer inventory.
+func (i *Inventory) Removed(newer *Inventory) []ObjectReference {
+	keep := make(map[ObjectReference]bool)
+	for _, o := range newer.Objects {
+		keep[o] = true
+	}
+
+	var removed []ObjectReference
+	for _, o := range i.Objects {
+		if !keep[o] {
+			removed = append(removed, o)
+		}
+	}
+	return removed
+}
+
+func (c *Channel) inventoryConfigMapName() string {
+	return inventoryConfigMapPrefix + c.Name
+}
+
+// GetInventory returns the inventory recorded when the addon was last applied, or nil if there is none.
+func (c *Channel) GetInventory(ctx context.Context, k8sClient kubernetes.Interface) (*Inventory, error) {
+	configMap, err := k8sClient.CoreV1().ConfigMaps(c.Namespace).Get(ctx, c.inventoryConfigMapName(), metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error querying inventory of %q: %w", c.Name, err)
+	}
+
+	data, ok := configMap.Data[inventoryKey]
+	if !ok {
+		return nil, nil
+	}
+
+	inventory := &Inventory{}
+	if err := json.Unmarshal([]byte(data), inventory); err != nil {
+		return nil, fmt.Errorf("error parsing inventory of %q: %w", c.Name, err)
+	}
+	return inventory, nil
+}
+
+// SetInventory records the inventory of the objects applied for the addon.
+func (c *Channel) SetInventory(ctx context.Context, k8sClient kubernetes.Interface, inventory *Inventory) error {
+	data, err := json.Marshal(inventory)
+	if err != nil {
+		return fmt.Errorf("error encoding inventory: %w", err)
+	}
+
+	configMaps := k8sClient.CoreV1().ConfigMaps(c.Namespace)
+	configMap, err := configMaps.Get(ctx, c.inventoryConfigMapName(), metav1.GetOptions{})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("error querying inventory of %q: %w", c.Name, err)
+		}
+
+		configMap = &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      c.inventoryConfigMapName(),
+				Namespace: c.Namespace,
+				Labels: map[string]string{
+					"app.kubernetes.io/managed-by": "kops",
+				},
+			},
+			Data: map[string]string{
+				inventoryKey: string(data),
+			},
+		}
+		klog.V(2).Infof("creating inventory of %q", c.Name)
+		if _, err := configMaps.Create(ctx, configMap, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("error creating inventory of %q: %w", c.Name, err)
+		}
+		return nil
+	}
+
+	if configMap.Data == nil {
+		configMap.Data = make(map[string]string)
+	}
+	configMap.Data[inventoryKey] = string(data)
+	klog.V(2).Infof("updating inventory of %q", c.Name)
+	if _, err := configMaps.Update(ctx, configMap, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("error updating inventory of %q: %w", c.Name, err)
+	}
+	return nil
+}
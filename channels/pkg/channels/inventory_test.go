@@ -0,0 +1,112 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channels
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	fakekubernetes "k8s.io/client-go/kubernetes/fake"
+)
+
+const inventoryTestManifestV1 = `
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: controller
+  namespace: kube-system
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: controller
+  namespace: kube-system
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: controller
+`
+
+const inventoryTestManifestV2 = `
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: controller
+  namespace: kube-system
+---
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: controller
+  namespace: kube-system
+`
+
+func TestInventoryRemoved(t *testing.T) {
+	v1, err := BuildInventory([]byte(inventoryTestManifestV1))
+	if err != nil {
+		t.Fatalf("error building inventory: %v", err)
+	}
+	v2, err := BuildInventory([]byte(inventoryTestManifestV2))
+	if err != nil {
+		t.Fatalf("error building inventory: %v", err)
+	}
+
+	expected := []ObjectReference{
+		{Group: "rbac.authorization.k8s.io", Kind: "ClusterRole", Name: "controller"},
+		{Group: "apps", Kind: "Deployment", Namespace: "kube-system", Name: "controller"},
+	}
+	if actual := v1.Removed(v2); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("unexpected removed objects, got %v, want %v", actual, expected)
+	}
+
+	if actual := v2.Removed(v2); len(actual) != 0 {
+		t.Errorf("unexpected removed objects for unchanged manifest: %v", actual)
+	}
+}
+
+func TestInventoryRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	k8sClient := fakekubernetes.NewSimpleClientset()
+	channel := &Channel{Namespace: "kube-system", Name: "controller.addons.k8s.io"}
+
+	inventory, err := channel.GetInventory(ctx, k8sClient)
+	if err != nil {
+		t.Fatalf("error getting inventory: %v", err)
+	}
+	if inventory != nil {
+		t.Fatalf("expected no inventory, got %v", inventory)
+	}
+
+	for _, manifest := range []string{inventoryTestManifestV1, inventoryTestManifestV2} {
+		expected, err := BuildInventory([]byte(manifest))
+		if err != nil {
+			t.Fatalf("error building inventory: %v", err)
+		}
+		if err := channel.SetInventory(ctx, k8sClient, expected); err != nil {
+			t.Fatalf("error setting inventory: %v", err)
+		}
+		actual, err := channel.GetInventory(ctx, k8sClient)
+		if err != nil {
+			t.Fatalf("error getting inventory: %v", err)
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("unexpected inventory, got %v, want %v", actual, expected)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channels
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// SortedAddons returns the addons in the menu ordered so that every addon comes after the addons it needs.
+// Addons that don't need each other are ordered by name, so the order is stable.
+// Needs that refer to addons which are not in the menu are ignored here; see CheckNeeds.
+func (m *AddonMenu) SortedAddons() ([]*Addon, error) {
+	var names []string
+	for name := range m.Addons {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int)
+	var sorted []*Addon
+	var path []string
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("addons have circular needs: %s -> %s", strings.Join(path, " -> "), name)
+		}
+		state[name] = visiting
+		path = append(path, name)
+
+		addon := m.Addons[name]
+		needs := append([]string(nil), addon.Spec.Needs...)
+		sort.Strings(needs)
+		for _, need := range needs {
+			if m.Addons[need] == nil {
+				continue
+			}
+			if err := visit(need); err != nil {
+				return err
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[name] = visited
+		sorted = append(sorted, addon)
+		return nil
+	}
+
+	for _, name := range names {
+		if err := visit(name); err != nil {
+			return nil, err
+		}
+	}
+	return sorted, nil
+}
+
+// CheckNeeds verifies that the addons needed by the addon are ready to be depended on: they must be in the menu,
+// must not be in failed (the addons that could not be updated), and their Deployments and DaemonSets must have rolled out.
+// It waits for the rollouts for up to the rollout timeout of each needed addon.
+func (m *AddonMenu) CheckNeeds(ctx context.Context, k8sClient kubernetes.Interface, addon *Addon, failed map[string]bool) error {
+	for _, need := range addon.Spec.Needs {
+		needed := m.Addons[need]
+		if needed == nil {
+			return fmt.Errorf("addon %q needs %q, which is not in the channels", addon.Name, need)
+		}
+		if failed[need] {
+			return fmt.Errorf("addon %q needs %q, which could not be updated", addon.Name, need)
+		}
+		if err := needed.WaitForReady(ctx, k8sClient); err != nil {
+			return fmt.Errorf("addon %q needs %q, which is not ready: %w", addon.Name, need, err)
+		}
+	}
+	return nil
+}
+
+// WaitForReady waits until the Deployments and DaemonSets that were last applied for the addon have rolled out.
+// Addons that have not recorded an inventory of their objects are considered ready.
+func (a *Addon) WaitForReady(ctx context.Context, k8sClient kubernetes.Interface) error {
+	inventory, err := a.buildChannel().GetInventory(ctx, k8sClient)
+	if err != nil {
+		return err
+	}
+	if inventory == nil {
+		return nil
+	}
+	return WaitForRollout(ctx, k8sClient, inventory, a.rolloutTimeout())
+}
+
+func (a *Addon) rolloutTimeout() time.Duration {
+	if a.Spec.RolloutTimeout != nil {
+		return a.Spec.RolloutTimeout.Duration
+	}
+	return DefaultRolloutTimeout
+}
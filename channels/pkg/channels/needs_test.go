@@ -0,0 +1,107 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channels
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	fakekubernetes "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/kops/channels/pkg/api"
+)
+
+func buildMenuWithNeeds(needs map[string][]string) *AddonMenu {
+	menu := NewAddonMenu()
+	for name, n := range needs {
+		menu.Addons[name] = &Addon{
+			Name: name,
+			Spec: &api.AddonSpec{Needs: n},
+		}
+	}
+	return menu
+}
+
+func TestSortedAddons(t *testing.T) {
+	menu := buildMenuWithNeeds(map[string][]string{
+		"calico.projectcalico.org":         nil,
+		"coredns.addons.k8s.io":            {"calico.projectcalico.org"},
+		"dns-controller.addons.k8s.io":     {"coredns.addons.k8s.io"},
+		"aws-ebs-csi-driver.addons.k8s.io": nil,
+		"cluster-autoscaler.addons.k8s.io": {"coredns.addons.k8s.io", "not-in-menu"},
+	})
+
+	sorted, err := menu.SortedAddons()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var names []string
+	for _, addon := range sorted {
+		names = append(names, addon.Name)
+	}
+	expected := []string{
+		"aws-ebs-csi-driver.addons.k8s.io",
+		"calico.projectcalico.org",
+		"coredns.addons.k8s.io",
+		"cluster-autoscaler.addons.k8s.io",
+		"dns-controller.addons.k8s.io",
+	}
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Errorf("unexpected order: got %v, want %v", names, expected)
+	}
+}
+
+func TestSortedAddonsCycle(t *testing.T) {
+	menu := buildMenuWithNeeds(map[string][]string{
+		"a": {"b"},
+		"b": {"c"},
+		"c": {"a"},
+	})
+
+	_, err := menu.SortedAddons()
+	if err == nil {
+		t.Fatalf("expected error for circular needs")
+	}
+	expected := "addons have circular needs: a -> b -> c -> a"
+	if err.Error() != expected {
+		t.Errorf("unexpected error: got %q, want %q", err.Error(), expected)
+	}
+}
+
+func TestCheckNeeds(t *testing.T) {
+	menu := buildMenuWithNeeds(map[string][]string{
+		"cni":     nil,
+		"coredns": {"cni"},
+		"metrics": {"missing"},
+	})
+	ctx := context.Background()
+	k8sClient := fakekubernetes.NewSimpleClientset()
+
+	if err := menu.CheckNeeds(ctx, k8sClient, menu.Addons["coredns"], map[string]bool{}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	err := menu.CheckNeeds(ctx, k8sClient, menu.Addons["coredns"], map[string]bool{"cni": true})
+	if err == nil || !strings.Contains(err.Error(), "could not be updated") {
+		t.Errorf("expected error for failed addon, got %v", err)
+	}
+
+	err = menu.CheckNeeds(ctx, k8sClient, menu.Addons["metrics"], map[string]bool{})
+	if err == nil || !strings.Contains(err.Error(), "not in the channels") {
+		t.Errorf("expected error for missing addon, got %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channels
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/klog/v2"
+	"oras.land/oras-go/pkg/content"
+	"oras.land/oras-go/pkg/oras"
+)
+
+// OCIScheme is the scheme of channel locations that reference an OCI artifact in a container registry,
+// for example oci://registry.example.com/kops/addons:v1.
+const OCIScheme = "oci"
+
+// DefaultOCIChannelFile is the file in an OCI artifact that holds the channel,
+// unless the location names another file in its fragment.
+const DefaultOCIChannelFile = "addon.yaml"
+
+// ParseOCIReference returns the artifact reference and the path of the channel file in the artifact for an oci:// location.
+func ParseOCIReference(location *url.URL) (string, string, error) {
+	if location.Scheme != OCIScheme {
+		return "", "", fmt.Errorf("%q is not an %s:// location", location, OCIScheme)
+	}
+	if location.Host == "" || strings.Trim(location.Path, "/") == "" {
+		return "", "", fmt.Errorf("%q does not reference an artifact in a registry", location)
+	}
+	ref := location.Host + location.Path
+
+	channelFile := DefaultOCIChannelFile
+	if location.Fragment != "" {
+		channelFile = path.Clean(location.Fragment)
+		if path.IsAbs(channelFile) || channelFile == ".." || strings.HasPrefix(channelFile, "../") {
+			return "", "", fmt.Errorf("channel file %q in %q must be a relative path in the artifact", location.Fragment, location)
+		}
+	}
+	return ref, channelFile, nil
+}
+
+// PullOCIChannel pulls the files of the OCI artifact referenced by an oci:// location into dir,
+// and returns the location of the channel file in dir.  The manifests of the addons are resolved
+// relative to the channel file, so they must be part of the same artifact.
+// Credentials for the registry are read from the docker configuration file, if there is one.
+func PullOCIChannel(ctx context.Context, location *url.URL, dir string) (*url.URL, error) {
+	ref, channelFile, err := ParseOCIReference(location)
+	if err != nil {
+		return nil, err
+	}
+
+	registry, err := content.NewRegistry(content.RegistryOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error building registry client: %w", err)
+	}
+
+	store := content.NewFile(dir)
+	defer store.Close()
+
+	klog.V(2).Infof("Pulling addons channel from %q", ref)
+	desc, err := oras.Copy(ctx, registry, ref, store, "")
+	if err != nil {
+		return nil, fmt.Errorf("error pulling %q: %w", ref, err)
+	}
+	klog.V(2).Infof("Pulled %q with digest %s", ref, desc.Digest)
+
+	p := filepath.Join(dir, filepath.FromSlash(channelFile))
+	if _, err := os.Stat(p); err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("artifact %q does not contain the channel file %q", ref, channelFile)
+		}
+		return nil, err
+	}
+	return &url.URL{Path: p}, nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channels
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestParseOCIReference(t *testing.T) {
+	grid := []struct {
+		location    string
+		ref         string
+		channelFile string
+		wantErr     bool
+	}{
+		{
+			location:    "oci://registry.example.com/kops/addons:v1",
+			ref:         "registry.example.com/kops/addons:v1",
+			channelFile: "addon.yaml",
+		},
+		{
+			location:    "oci://localhost:5000/addons@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef#channels/stable.yaml",
+			ref:         "localhost:5000/addons@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+			channelFile: "channels/stable.yaml",
+		},
+		{
+			location: "oci://registry.example.com",
+			wantErr:  true,
+		},
+		{
+			location: "oci://registry.example.com/addons:v1#../addons.yaml",
+			wantErr:  true,
+		},
+		{
+			location: "https://registry.example.com/addons:v1",
+			wantErr:  true,
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.location, func(t *testing.T) {
+			u, err := url.Parse(g.location)
+			if err != nil {
+				t.Fatalf("error parsing %q: %v", g.location, err)
+			}
+			ref, channelFile, err := ParseOCIReference(u)
+			if g.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %q %q", ref, channelFile)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ref != g.ref || channelFile != g.channelFile {
+				t.Errorf("unexpected result: got (%q, %q), want (%q, %q)", ref, channelFile, g.ref, g.channelFile)
+			}
+		})
+	}
+}
@@ -20,6 +20,8 @@ import (
 	"context"
 	"fmt"
 
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -147,3 +149,71 @@ func (p *Pruner) pruneObjects(ctx context.Context, gvr schema.GroupVersionResour
 
 	return nil
 }
+
+// neverPruneGroupKinds are the kinds that are too risky to delete when they are removed from a manifest:
+// deleting a Namespace deletes everything in it, and deleting a CRD deletes all its instances.
+var neverPruneGroupKinds = map[schema.GroupKind]bool{
+	{Group: "", Kind: "Namespace"}:                                    true,
+	{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}: true,
+}
+
+// PruneInventory deletes the objects of the addon that were in the previous inventory but not in the current one.
+// Objects are only deleted if they are still labeled as belonging to the addon.
+func (p *Pruner) PruneInventory(ctx context.Context, addonName string, previous, current *Inventory) error {
+	if previous == nil {
+		return nil
+	}
+
+	for _, ref := range previous.Removed(current) {
+		if neverPruneGroupKinds[ref.GroupKind()] {
+			klog.Infof("not pruning %s, which was removed from addon %s", ref, addonName)
+			continue
+		}
+		if err := p.pruneRemovedObject(ctx, addonName, ref); err != nil {
+			return fmt.Errorf("failed to prune %s: %w", ref, err)
+		}
+	}
+
+	return nil
+}
+
+func (p *Pruner) pruneRemovedObject(ctx context.Context, addonName string, ref ObjectReference) error {
+	restMapping, err := p.RESTMapper.RESTMapping(ref.GroupKind())
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			// The kind no longer exists, so neither does the object
+			return nil
+		}
+		return fmt.Errorf("unable to find resource for %s: %w", ref.GroupKind(), err)
+	}
+
+	var resource dynamic.ResourceInterface
+	if restMapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = v1.NamespaceDefault
+		}
+		resource = p.Client.Resource(restMapping.Resource).Namespace(namespace)
+	} else {
+		resource = p.Client.Resource(restMapping.Resource)
+	}
+
+	actual, err := resource.Get(ctx, ref.Name, v1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("error getting object: %w", err)
+	}
+
+	if actual.GetLabels()[LabelAddonName] != addonName {
+		klog.Infof("not pruning %s, which is not labeled as belonging to addon %s", ref, addonName)
+		return nil
+	}
+
+	klog.Infof("pruning %s, which was removed from addon %s", ref, addonName)
+	if err := resource.Delete(ctx, ref.Name, v1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("error deleting object: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channels
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+// DefaultRolloutTimeout is how long we wait for the workloads of an addon to become ready, if the addon doesn't specify a timeout.
+const DefaultRolloutTimeout = 5 * time.Minute
+
+// rolloutPollInterval is how often we check the status of the workloads.
+var rolloutPollInterval = 5 * time.Second
+
+// RolloutError is returned when the workloads of an addon did not become ready.
+type RolloutError struct {
+	// Pending maps the workloads that are not ready to the reason they are not ready.
+	Pending map[ObjectReference]string
+}
+
+func (e *RolloutError) Error() string {
+	var reasons []string
+	for ref, reason := range e.Pending {
+		reasons = append(reasons, fmt.Sprintf("%s: %s", ref, reason))
+	}
+	// Map iteration order is random, keep the message stable
+	sort.Strings(reasons)
+	return fmt.Sprintf("rollout did not complete: %s", strings.Join(reasons, "; "))
+}
+
+// WaitForRollout waits until the Deployments and DaemonSets in the inventory have been rolled out.
+// It returns a RolloutError describing the workloads that are not ready if the timeout expires,
+// and fails early if a Deployment exceeds its progress deadline.
+func WaitForRollout(ctx context.Context, k8sClient kubernetes.Interface, inventory *Inventory, timeout time.Duration) error {
+	var workloads []ObjectReference
+	for _, ref := range inventory.Objects {
+		if ref.Group != "apps" || (ref.Kind != "Deployment" && ref.Kind != "DaemonSet") {
+			continue
+		}
+		if ref.Namespace == "" {
+			ref.Namespace = metav1.NamespaceDefault
+		}
+		workloads = append(workloads, ref)
+	}
+	if len(workloads) == 0 {
+		return nil
+	}
+
+	var pending map[ObjectReference]string
+	err := wait.PollImmediateWithContext(ctx, rolloutPollInterval, timeout, func(ctx context.Context) (bool, error) {
+		pending = make(map[ObjectReference]string)
+		for _, ref := range workloads {
+			done, reason, err := rolloutStatus(ctx, k8sClient, ref)
+			if err != nil {
+				return false, err
+			}
+			if !done {
+				klog.V(2).Infof("waiting for rollout of %s: %s", ref, reason)
+				pending[ref] = reason
+			}
+		}
+		return len(pending) == 0, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		return &RolloutError{Pending: pending}
+	}
+	return err
+}
+
+func rolloutStatus(ctx context.Context, k8sClient kubernetes.Interface, ref ObjectReference) (bool, string, error) {
+	switch ref.Kind {
+	case "Deployment":
+		deployment, err := k8sClient.AppsV1().Deployments(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return false, "not found", nil
+			}
+			return false, "", fmt.Errorf("error getting %s: %w", ref, err)
+		}
+		return deploymentRolloutStatus(ref, deployment)
+	case "DaemonSet":
+		daemonSet, err := k8sClient.AppsV1().DaemonSets(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return false, "not found", nil
+			}
+			return false, "", fmt.Errorf("error getting %s: %w", ref, err)
+		}
+		done, reason := daemonSetRolloutStatus(daemonSet)
+		return done, reason, nil
+	default:
+		return false, "", fmt.Errorf("unhandled kind %q", ref.Kind)
+	}
+}
+
+// deploymentRolloutStatus mirrors the checks done by kubectl rollout status.
+func deploymentRolloutStatus(ref ObjectReference, d *appsv1.Deployment) (bool, string, error) {
+	if d.Generation > d.Status.ObservedGeneration {
+		return false, "waiting for the deployment spec update to be observed", nil
+	}
+	for _, condition := range d.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.Reason == "ProgressDeadlineExceeded" {
+			return false, "", fmt.Errorf("%s exceeded its progress deadline: %s", ref, condition.Message)
+		}
+	}
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+	if d.Status.UpdatedReplicas < replicas {
+		return false, fmt.Sprintf("%d out of %d new replicas have been updated", d.Status.UpdatedReplicas, replicas), nil
+	}
+	if d.Status.Replicas > d.Status.UpdatedReplicas {
+		return false, fmt.Sprintf("%d old replicas are pending termination", d.Status.Replicas-d.Status.UpdatedReplicas), nil
+	}
+	if d.Status.AvailableReplicas < d.Status.UpdatedReplicas {
+		return false, fmt.Sprintf("%d of %d updated replicas are available", d.Status.AvailableReplicas, d.Status.UpdatedReplicas), nil
+	}
+	return true, "", nil
+}
+
+// daemonSetRolloutStatus mirrors the checks done by kubectl rollout status.
+func daemonSetRolloutStatus(d *appsv1.DaemonSet) (bool, string) {
+	if d.Spec.UpdateStrategy.Type == appsv1.OnDeleteDaemonSetStrategyType {
+		// Pods are only updated when they are deleted, so there is no rollout to wait for
+		return true, ""
+	}
+	if d.Generation > d.Status.ObservedGeneration {
+		return false, "waiting for the daemon set spec update to be observed"
+	}
+	if d.Status.UpdatedNumberScheduled < d.Status.DesiredNumberScheduled {
+		return false, fmt.Sprintf("%d out of %d new pods have been updated", d.Status.UpdatedNumberScheduled, d.Status.DesiredNumberScheduled)
+	}
+	if d.Status.NumberAvailable < d.Status.DesiredNumberScheduled {
+		return false, fmt.Sprintf("%d of %d updated pods are available", d.Status.NumberAvailable, d.Status.DesiredNumberScheduled)
+	}
+	return true, ""
+}
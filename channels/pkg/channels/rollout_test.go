@@ -0,0 +1,194 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channels
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakekubernetes "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestDeploymentRolloutStatus(t *testing.T) {
+	ref := ObjectReference{Group: "apps", Kind: "Deployment", Namespace: "kube-system", Name: "coredns"}
+	replicas := int32(2)
+
+	grid := []struct {
+		name       string
+		generation int64
+		status     appsv1.DeploymentStatus
+		done       bool
+		reason     string
+		wantErr    string
+	}{
+		{
+			name:       "complete",
+			generation: 1,
+			status:     appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2},
+			done:       true,
+		},
+		{
+			name:       "not observed",
+			generation: 2,
+			status:     appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2},
+			reason:     "waiting for the deployment spec update to be observed",
+		},
+		{
+			name:       "updating",
+			generation: 1,
+			status:     appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 2, UpdatedReplicas: 1, AvailableReplicas: 2},
+			reason:     "1 out of 2 new replicas have been updated",
+		},
+		{
+			name:       "old replicas",
+			generation: 1,
+			status:     appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 3, UpdatedReplicas: 2, AvailableReplicas: 2},
+			reason:     "1 old replicas are pending termination",
+		},
+		{
+			name:       "unavailable",
+			generation: 1,
+			status:     appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 1},
+			reason:     "1 of 2 updated replicas are available",
+		},
+		{
+			name:       "progress deadline exceeded",
+			generation: 1,
+			status: appsv1.DeploymentStatus{
+				ObservedGeneration: 1,
+				Conditions: []appsv1.DeploymentCondition{
+					{Type: appsv1.DeploymentProgressing, Reason: "ProgressDeadlineExceeded", Message: "ReplicaSet \"coredns-abc\" has timed out progressing."},
+				},
+			},
+			wantErr: "exceeded its progress deadline: ReplicaSet \"coredns-abc\" has timed out progressing.",
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			d := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Generation: g.generation},
+				Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+				Status:     g.status,
+			}
+			done, reason, err := deploymentRolloutStatus(ref, d)
+			if g.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), g.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", g.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if done != g.done || reason != g.reason {
+				t.Errorf("unexpected status: got (%v, %q), want (%v, %q)", done, reason, g.done, g.reason)
+			}
+		})
+	}
+}
+
+func TestDaemonSetRolloutStatus(t *testing.T) {
+	grid := []struct {
+		name     string
+		strategy appsv1.DaemonSetUpdateStrategyType
+		status   appsv1.DaemonSetStatus
+		done     bool
+		reason   string
+	}{
+		{
+			name:   "complete",
+			status: appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberAvailable: 3},
+			done:   true,
+		},
+		{
+			name:   "updating",
+			status: appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, UpdatedNumberScheduled: 1, NumberAvailable: 3},
+			reason: "1 out of 3 new pods have been updated",
+		},
+		{
+			name:   "unavailable",
+			status: appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberAvailable: 2},
+			reason: "2 of 3 updated pods are available",
+		},
+		{
+			name:     "on delete",
+			strategy: appsv1.OnDeleteDaemonSetStrategyType,
+			status:   appsv1.DaemonSetStatus{DesiredNumberScheduled: 3},
+			done:     true,
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			d := &appsv1.DaemonSet{
+				Spec:   appsv1.DaemonSetSpec{UpdateStrategy: appsv1.DaemonSetUpdateStrategy{Type: g.strategy}},
+				Status: g.status,
+			}
+			done, reason := daemonSetRolloutStatus(d)
+			if done != g.done || reason != g.reason {
+				t.Errorf("unexpected status: got (%v, %q), want (%v, %q)", done, reason, g.done, g.reason)
+			}
+		})
+	}
+}
+
+func TestWaitForRollout(t *testing.T) {
+	rolloutPollInterval = 10 * time.Millisecond
+
+	inventory := &Inventory{
+		Objects: []ObjectReference{
+			{Group: "apps", Kind: "DaemonSet", Namespace: "kube-system", Name: "agent"},
+			{Group: "apps", Kind: "Deployment", Namespace: "kube-system", Name: "controller"},
+			{Kind: "ServiceAccount", Namespace: "kube-system", Name: "controller"},
+		},
+	}
+
+	ctx := context.Background()
+	k8sClient := fakekubernetes.NewSimpleClientset(
+		&appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "agent"},
+			Status:     appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberAvailable: 3},
+		},
+	)
+
+	err := WaitForRollout(ctx, k8sClient, inventory, 50*time.Millisecond)
+	var rolloutErr *RolloutError
+	if !errors.As(err, &rolloutErr) {
+		t.Fatalf("expected RolloutError, got %v", err)
+	}
+	expected := "rollout did not complete: Deployment.apps kube-system/controller: not found"
+	if err.Error() != expected {
+		t.Errorf("unexpected error: got %q, want %q", err.Error(), expected)
+	}
+
+	if _, err := k8sClient.AppsV1().Deployments("kube-system").Create(ctx, &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "controller"},
+		Status:     appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+	}, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error creating deployment: %v", err)
+	}
+
+	if err := WaitForRollout(ctx, k8sClient, inventory, 50*time.Millisecond); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
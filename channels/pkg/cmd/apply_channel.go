@@ -95,13 +95,20 @@ func RunApplyChannel(ctx context.Context, f Factory, out io.Writer, options *App
 	// Remove Pre and Patch, as they make semver comparisons impractical
 	kubernetesVersion.Pre = nil
 
+	// workDir holds the channels pulled from OCI registries until they are applied.
+	workDir, err := os.MkdirTemp("", "channels")
+	if err != nil {
+		return fmt.Errorf("error creating temporary directory: %w", err)
+	}
+	defer os.RemoveAll(workDir)
+
 	// menu is the expected list of addons in the cluster and their configurations.
-	menu, err := buildMenu(kubernetesVersion, args, false)
+	menu, err := buildMenu(ctx, kubernetesVersion, args, false, workDir)
 	if err != nil {
 		return fmt.Errorf("cannot build the addon menu from args: %w", err)
 	}
 
-	filesMenu, err := buildMenu(kubernetesVersion, options.Files, true)
+	filesMenu, err := buildMenu(ctx, kubernetesVersion, options.Files, true, workDir)
 	if err != nil {
 		return fmt.Errorf("cannot build the addon menu from files: %w", err)
 	}
@@ -164,6 +171,11 @@ func applyMenu(ctx context.Context, menu *channels.AddonMenu, k8sClient kubernet
 		return nil
 	}
 
+	applier := &channels.Applier{
+		Client:     dynamicClient,
+		RESTMapper: restMapper,
+	}
+
 	pruner := &channels.Pruner{
 		Client:     dynamicClient,
 		RESTMapper: restMapper,
@@ -171,9 +183,19 @@ func applyMenu(ctx context.Context, menu *channels.AddonMenu, k8sClient kubernet
 
 	var merr error
 
+	// failed holds the addons that were not updated, so that the addons that need them are not updated either
+	failed := make(map[string]bool)
+
+	// needUpdates are ordered so that addons come after the addons they need
 	for _, needUpdate := range needUpdates {
-		update, err := needUpdate.EnsureUpdated(ctx, k8sClient, cmClient, pruner, channelVersions[needUpdate.GetNamespace()+":"+needUpdate.Name])
+		if err := menu.CheckNeeds(ctx, k8sClient, needUpdate, failed); err != nil {
+			failed[needUpdate.Name] = true
+			merr = multierr.Append(merr, fmt.Errorf("updating %q: %w", needUpdate.Name, err))
+			continue
+		}
+		update, err := needUpdate.EnsureUpdated(ctx, k8sClient, cmClient, applier, pruner, channelVersions[needUpdate.GetNamespace()+":"+needUpdate.Name])
 		if err != nil {
+			failed[needUpdate.Name] = true
 			merr = multierr.Append(merr, fmt.Errorf("updating %q: %w", needUpdate.Name, err))
 		} else if update != nil {
 			fmt.Printf("Updated %q\n", update.Name)
@@ -184,9 +206,14 @@ func applyMenu(ctx context.Context, menu *channels.AddonMenu, k8sClient kubernet
 }
 
 func getUpdates(ctx context.Context, menu *channels.AddonMenu, k8sClient kubernetes.Interface, cmClient versioned.Interface, channelVersions map[string]*channels.ChannelVersion) ([]*channels.AddonUpdate, []*channels.Addon, error) {
+	addons, err := menu.SortedAddons()
+	if err != nil {
+		return nil, nil, err
+	}
+
 	var updates []*channels.AddonUpdate
 	var needUpdates []*channels.Addon
-	for _, addon := range menu.Addons {
+	for _, addon := range addons {
 		update, err := addon.GetRequiredUpdates(ctx, k8sClient, cmClient, channelVersions[addon.GetNamespace()+":"+addon.Name])
 		if err != nil {
 			return nil, nil, fmt.Errorf("error checking for required update: %v", err)
@@ -216,7 +243,9 @@ func getChannelVersions(ctx context.Context, k8sClient kubernetes.Interface) (ma
 	return channelVersions, nil
 }
 
-func buildMenu(kubernetesVersion semver.Version, args []string, localFiles bool) (*channels.AddonMenu, error) {
+// buildMenu loads the addons from the channels in args.
+// Channels in OCI registries are pulled into subdirectories of workDir, which must be kept until the addons are applied.
+func buildMenu(ctx context.Context, kubernetesVersion semver.Version, args []string, localFiles bool, workDir string) (*channels.AddonMenu, error) {
 	menu := channels.NewAddonMenu()
 
 	for _, name := range args {
@@ -255,6 +284,16 @@ func buildMenu(kubernetesVersion semver.Version, args []string, localFiles bool)
 				location = baseURL.ResolveReference(location)
 			}
 		}
+		if location.Scheme == channels.OCIScheme {
+			dir, err := os.MkdirTemp(workDir, "oci")
+			if err != nil {
+				return nil, fmt.Errorf("error creating directory for channel %q: %w", name, err)
+			}
+			location, err = channels.PullOCIChannel(ctx, location, dir)
+			if err != nil {
+				return nil, fmt.Errorf("error loading channel %q: %w", name, err)
+			}
+		}
 		o, err := channels.LoadAddons(name, location)
 		if err != nil {
 			return nil, fmt.Errorf("error loading channel %q: %v", location, err)
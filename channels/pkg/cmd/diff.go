@@ -0,0 +1,174 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/blang/semver/v4"
+	"github.com/spf13/cobra"
+	"go.uber.org/multierr"
+	"k8s.io/kops/channels/pkg/channels"
+)
+
+type DiffOptions struct {
+	Channels []string
+	Files    []string
+}
+
+func NewCmdDiff(f Factory, out io.Writer) *cobra.Command {
+	var options DiffOptions
+
+	cmd := &cobra.Command{
+		Use:   "diff [ADDON]...",
+		Short: "Show the changes that applying addons would make",
+		Long: `Show the changes that applying the addons from a channel would make to the objects in the cluster.
+The changes to existing objects are computed with a server-side dry-run apply. If no addons are named, all the addons of the channels are compared.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.TODO()
+			return RunDiff(ctx, f, out, &options, args)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&options.Channels, "channel", []string{}, "Channel to read the addons from")
+	cmd.Flags().StringSliceVarP(&options.Files, "filename", "f", []string{}, "Read the addons from a local file")
+
+	return cmd
+}
+
+func RunDiff(ctx context.Context, f Factory, out io.Writer, options *DiffOptions, args []string) error {
+	if len(options.Channels) == 0 && len(options.Files) == 0 {
+		return fmt.Errorf("must specify --channel or --filename")
+	}
+
+	k8sClient, err := f.KubernetesClient()
+	if err != nil {
+		return err
+	}
+
+	dynamicClient, err := f.DynamicClient()
+	if err != nil {
+		return err
+	}
+
+	restMapper, err := f.RESTMapper()
+	if err != nil {
+		return err
+	}
+
+	kubernetesVersionInfo, err := k8sClient.Discovery().ServerVersion()
+	if err != nil {
+		return fmt.Errorf("error querying kubernetes version: %v", err)
+	}
+
+	kubernetesVersion, err := semver.ParseTolerant(kubernetesVersionInfo.GitVersion)
+	if err != nil {
+		return fmt.Errorf("cannot parse kubernetes version %q", kubernetesVersionInfo.GitVersion)
+	}
+
+	// Remove Pre and Patch, as they make semver comparisons impractical
+	kubernetesVersion.Pre = nil
+
+	workDir, err := os.MkdirTemp("", "channels")
+	if err != nil {
+		return fmt.Errorf("error creating temporary directory: %w", err)
+	}
+	defer os.RemoveAll(workDir)
+
+	menu, err := buildMenu(ctx, kubernetesVersion, options.Channels, false, workDir)
+	if err != nil {
+		return fmt.Errorf("cannot build the addon menu from channels: %w", err)
+	}
+
+	filesMenu, err := buildMenu(ctx, kubernetesVersion, options.Files, true, workDir)
+	if err != nil {
+		return fmt.Errorf("cannot build the addon menu from files: %w", err)
+	}
+	menu.MergeAddons(filesMenu)
+
+	addons, err := selectAddons(menu, args)
+	if err != nil {
+		return err
+	}
+
+	applier := &channels.Applier{
+		Client:     dynamicClient,
+		RESTMapper: restMapper,
+	}
+
+	var merr error
+	for _, addon := range addons {
+		addonDiff, err := addon.Diff(ctx, k8sClient, applier)
+		if err != nil {
+			merr = multierr.Append(merr, fmt.Errorf("comparing %q: %w", addon.Name, err))
+			continue
+		}
+		if err := printAddonDiff(out, addon, addonDiff); err != nil {
+			return err
+		}
+	}
+	return merr
+}
+
+// selectAddons returns the named addons from the menu, or all of them if no names are given, sorted by name.
+func selectAddons(menu *channels.AddonMenu, names []string) ([]*channels.Addon, error) {
+	var addons []*channels.Addon
+	if len(names) == 0 {
+		for _, addon := range menu.Addons {
+			addons = append(addons, addon)
+		}
+	} else {
+		for _, name := range names {
+			addon := menu.Addons[name]
+			if addon == nil {
+				return nil, fmt.Errorf("addon %q not found in channels", name)
+			}
+			addons = append(addons, addon)
+		}
+	}
+	sort.Slice(addons, func(i, j int) bool {
+		return addons[i].Name < addons[j].Name
+	})
+	return addons, nil
+}
+
+func printAddonDiff(out io.Writer, addon *channels.Addon, addonDiff *channels.AddonDiff) error {
+	fmt.Fprintf(out, "=== %s\n", addon.Name)
+	for _, objectDiff := range addonDiff.Objects {
+		text, err := objectDiff.Diff()
+		if err != nil {
+			return err
+		}
+		switch {
+		case objectDiff.IsNew():
+			fmt.Fprintf(out, "%s: new\n%s", objectDiff.ObjectReference, text)
+		case text != "":
+			fmt.Fprintf(out, "%s: changed\n%s", objectDiff.ObjectReference, text)
+		default:
+			fmt.Fprintf(out, "%s: unchanged\n", objectDiff.ObjectReference)
+		}
+	}
+	for _, ref := range addonDiff.Pruned {
+		fmt.Fprintf(out, "%s: removed\n", ref)
+	}
+	fmt.Fprintf(out, "\n")
+	return nil
+}
@@ -55,6 +55,7 @@ func NewCmdRoot(f Factory, out io.Writer) *cobra.Command {
 
 	// create subcommands
 	cmd.AddCommand(NewCmdApply(f, out))
+	cmd.AddCommand(NewCmdDiff(f, out))
 	cmd.AddCommand(NewCmdGet(f, out))
 
 	return cmd
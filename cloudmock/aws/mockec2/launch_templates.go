@@ -282,7 +282,9 @@ func responseLaunchTemplateData(req *ec2.RequestLaunchTemplateData) *ec2.Respons
 				Description:                    x.Description,
 				DeviceIndex:                    x.DeviceIndex,
 				Groups:                         x.Groups,
+				InterfaceType:                  x.InterfaceType,
 				Ipv6AddressCount:               x.Ipv6AddressCount,
+				NetworkCardIndex:               x.NetworkCardIndex,
 				NetworkInterfaceId:             x.NetworkInterfaceId,
 				PrivateIpAddress:               x.PrivateIpAddress,
 				PrivateIpAddresses:             x.PrivateIpAddresses,
@@ -266,5 +266,8 @@ func (m *MockEC2) ModifySubnetAttribute(request *ec2.ModifySubnetAttributeInput)
 	if request.PrivateDnsHostnameTypeOnLaunch != nil {
 		subnet.main.PrivateDnsNameOptionsOnLaunch.HostnameType = request.PrivateDnsHostnameTypeOnLaunch
 	}
+	if request.EnableDns64 != nil {
+		subnet.main.EnableDns64 = request.EnableDns64.Value
+	}
 	return &ec2.ModifySubnetAttributeOutput{}, nil
 }
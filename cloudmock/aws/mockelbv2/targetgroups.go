@@ -100,6 +100,9 @@ func (m *MockELBV2) CreateTargetGroup(request *elbv2.CreateTargetGroupInput) (*e
 		VpcId:                   request.VpcId,
 		HealthyThresholdCount:   request.HealthyThresholdCount,
 		UnhealthyThresholdCount: request.UnhealthyThresholdCount,
+		HealthCheckProtocol:     request.HealthCheckProtocol,
+		HealthCheckPort:         request.HealthCheckPort,
+		HealthCheckPath:         request.HealthCheckPath,
 	}
 
 	m.tgCount++
@@ -131,3 +134,34 @@ func (m *MockELBV2) DeleteTargetGroup(request *elbv2.DeleteTargetGroupInput) (*e
 	delete(m.TargetGroups, arn)
 	return &elbv2.DeleteTargetGroupOutput{}, nil
 }
+
+func (m *MockELBV2) ModifyTargetGroup(request *elbv2.ModifyTargetGroupInput) (*elbv2.ModifyTargetGroupOutput, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	klog.Infof("ModifyTargetGroup %v", request)
+
+	arn := aws.StringValue(request.TargetGroupArn)
+	tg := m.TargetGroups[arn]
+	if tg == nil {
+		return nil, fmt.Errorf("TargetGroup %q not found", arn)
+	}
+
+	if request.HealthCheckProtocol != nil {
+		tg.description.HealthCheckProtocol = request.HealthCheckProtocol
+	}
+	if request.HealthCheckPort != nil {
+		tg.description.HealthCheckPort = request.HealthCheckPort
+	}
+	if request.HealthCheckPath != nil {
+		tg.description.HealthCheckPath = request.HealthCheckPath
+	}
+	if request.HealthyThresholdCount != nil {
+		tg.description.HealthyThresholdCount = request.HealthyThresholdCount
+	}
+	if request.UnhealthyThresholdCount != nil {
+		tg.description.UnhealthyThresholdCount = request.UnhealthyThresholdCount
+	}
+
+	return &elbv2.ModifyTargetGroupOutput{TargetGroups: []*elbv2.TargetGroup{&tg.description}}, nil
+}
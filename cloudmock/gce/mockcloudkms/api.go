@@ -0,0 +1,79 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mockcloudkms
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	cloudkms "google.golang.org/api/cloudkms/v1"
+	option "google.golang.org/api/option"
+	"k8s.io/klog/v2"
+)
+
+// mockCloudKMSService represents a mocked cloudkms client.
+type mockCloudKMSService struct {
+	svc *cloudkms.Service
+
+	cryptoKeys cryptoKeys
+}
+
+// New creates a new mock cloudkms client.
+func New() *cloudkms.Service {
+	ctx := context.Background()
+
+	s := &mockCloudKMSService{}
+
+	s.cryptoKeys.Init()
+
+	httpClient := &http.Client{Transport: s}
+	svc, err := cloudkms.NewService(ctx, option.WithHTTPClient(httpClient))
+	if err != nil {
+		klog.Fatalf("failed to build mock cloudkms service: %v", err)
+	}
+	s.svc = svc
+	return svc
+}
+
+func (s *mockCloudKMSService) RoundTrip(request *http.Request) (*http.Response, error) {
+	url := request.URL
+	if url.Host != "cloudkms.googleapis.com" {
+		return nil, fmt.Errorf("unexpected host in request %#v", request)
+	}
+
+	// Crypto keys are named projects/*/locations/*/keyRings/*/cryptoKeys/*
+	pathTokens := strings.Split(strings.TrimPrefix(url.Path, "/"), "/")
+	if len(pathTokens) == 9 && pathTokens[0] == "v1" && pathTokens[1] == "projects" && pathTokens[7] == "cryptoKeys" {
+		keyTokens := strings.Split(pathTokens[8], ":")
+		if len(keyTokens) == 2 {
+			cryptoKey := strings.Join(pathTokens[1:8], "/") + "/" + keyTokens[0]
+			verb := keyTokens[1]
+
+			if request.Method == "GET" && verb == "getIamPolicy" {
+				return s.cryptoKeys.getIAMPolicy(cryptoKey, request)
+			}
+
+			if request.Method == "POST" && verb == "setIamPolicy" {
+				return s.cryptoKeys.setIAMPolicy(cryptoKey, request)
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("unhandled request %#v", request)
+}
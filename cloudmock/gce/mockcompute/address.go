@@ -66,6 +66,7 @@ func (c *addressClient) Insert(project, region string, addr *compute.Address) (*
 		addrs = map[string]*compute.Address{}
 		regions[region] = addrs
 	}
+	addr.Region = fmt.Sprintf("https://www.googleapis.com/compute/v1/projects/%s/regions/%s", project, region)
 	addr.SelfLink = fmt.Sprintf("https://www.googleapis.com/compute/v1/projects/%s/regions/%s/addrs/%s", project, region, addr.Name)
 	addrs[addr.Name] = addr
 	return doneOperation(), nil
@@ -25,8 +25,11 @@ import (
 // MockClient represents a mocked compute client.
 type MockClient struct {
 	projectClient *projectClient
+	regionClient  *regionClient
 	zoneClient    *zoneClient
 
+	machineTypeClient *machineTypeClient
+
 	networkClient          *networkClient
 	subnetworkClient       *subnetworkClient
 	backendServiceClient   *backendServiceClient
@@ -38,6 +41,7 @@ type MockClient struct {
 	firewallClient         *firewallClient
 	routerClient           *routerClient
 
+	instanceClient             *instanceClient
 	instanceTemplateClient     *instanceTemplateClient
 	instanceGroupManagerClient *instanceGroupManagerClient
 	targetPoolClient           *targetPoolClient
@@ -51,8 +55,11 @@ var _ gce.ComputeClient = &MockClient{}
 func NewMockClient(project string) *MockClient {
 	return &MockClient{
 		projectClient: newProjectClient(project),
+		regionClient:  newRegionClient(project),
 		zoneClient:    newZoneClient(project),
 
+		machineTypeClient: newMachineTypeClient(project),
+
 		networkClient:          newNetworkClient(),
 		subnetworkClient:       newSubnetworkClient(),
 		backendServiceClient:   newBackendServiceClient(),
@@ -64,6 +71,7 @@ func NewMockClient(project string) *MockClient {
 		firewallClient:         newFirewallClient(),
 		routerClient:           newRouterClient(),
 
+		instanceClient:             newInstanceClient(),
 		instanceTemplateClient:     newInstanceTemplateClient(),
 		instanceGroupManagerClient: newInstanceGroupManagerClient(),
 		targetPoolClient:           newTargetPoolClient(),
@@ -88,6 +96,7 @@ func (c *MockClient) AllResources() map[string]interface{} {
 		c.addressClient.All,
 		c.firewallClient.All,
 		c.routerClient.All,
+		c.instanceClient.All,
 		c.instanceTemplateClient.All,
 		c.instanceGroupManagerClient.All,
 		c.targetPoolClient.All,
@@ -109,14 +118,17 @@ func (c *MockClient) Projects() gce.ProjectClient {
 }
 
 func (c *MockClient) Regions() gce.RegionClient {
-	// Not implemented.
-	return nil
+	return c.regionClient
 }
 
 func (c *MockClient) Zones() gce.ZoneClient {
 	return c.zoneClient
 }
 
+func (c *MockClient) MachineTypes() gce.MachineTypeClient {
+	return c.machineTypeClient
+}
+
 func (c *MockClient) Networks() gce.NetworkClient {
 	return c.networkClient
 }
@@ -158,8 +170,7 @@ func (c *MockClient) Routers() gce.RouterClient {
 }
 
 func (c *MockClient) Instances() gce.InstanceClient {
-	// Not implemented.
-	return nil
+	return c.instanceClient
 }
 
 func (c *MockClient) InstanceTemplates() gce.InstanceTemplateClient {
@@ -59,6 +59,7 @@ func (c *backendServiceClient) Insert(project, region string, backendService *co
 		backendServices = map[string]*compute.BackendService{}
 		c.backendServices[project] = backendServices
 	}
+	backendService.Region = fmt.Sprintf("https://www.googleapis.com/compute/v1/projects/%s/regions/%s", project, region)
 	backendService.SelfLink = fmt.Sprintf("https://www.googleapis.com/compute/v1/projects/%s/regions/%s/backendServices/%s", project, region, backendService.Name)
 	backendServices[backendService.Name] = backendService
 	return doneOperation(), nil
@@ -66,6 +66,7 @@ func (c *forwardingRuleClient) Insert(project, region string, fr *compute.Forwar
 		frs = map[string]*compute.ForwardingRule{}
 		regions[region] = frs
 	}
+	fr.Region = fmt.Sprintf("https://www.googleapis.com/compute/v1/projects/%s/regions/%s", project, region)
 	fr.SelfLink = fmt.Sprintf("https://www.googleapis.com/compute/v1/projects/%s/regions/%s/forwardingRules/%s", project, region, fr.Name)
 	frs[fr.Name] = fr
 	return doneOperation(), nil
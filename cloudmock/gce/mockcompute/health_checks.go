@@ -59,6 +59,7 @@ func (c *healthCheckClient) Insert(project, region string, healthCheck *compute.
 		healthChecks = map[string]*compute.HealthCheck{}
 		c.healthChecks[project] = healthChecks
 	}
+	healthCheck.Region = fmt.Sprintf("https://www.googleapis.com/compute/v1/projects/%s/regions/%s", project, region)
 	healthCheck.SelfLink = fmt.Sprintf("https://www.googleapis.com/compute/v1/projects/%s/regions/%s/healthChecks/%s", project, region, healthCheck.Name)
 	healthChecks[healthCheck.Name] = healthCheck
 	return doneOperation(), nil
@@ -66,6 +66,7 @@ func (c *instanceGroupManagerClient) Insert(project, zone string, igm *compute.I
 		igms = map[string]*compute.InstanceGroupManager{}
 		zones[zone] = igms
 	}
+	igm.Zone = fmt.Sprintf("https://www.googleapis.com/compute/v1/projects/%s/zones/%s", project, zone)
 	igm.SelfLink = fmt.Sprintf("https://www.googleapis.com/compute/v1/projects/%s/zones/%s/instanceGroupManagers/%s", project, zone, igm.Name)
 	igms[igm.Name] = igm
 	return doneOperation(), nil
@@ -135,13 +136,51 @@ func (c *instanceGroupManagerClient) RecreateInstances(project, zone, name, id s
 }
 
 func (c *instanceGroupManagerClient) SetTargetPools(project, zone, name string, targetPools []string) (*compute.Operation, error) {
+	c.Lock()
+	defer c.Unlock()
+	igm, err := c.find(project, zone, name)
+	if err != nil {
+		return nil, err
+	}
+	igm.TargetPools = targetPools
 	return doneOperation(), nil
 }
 
 func (c *instanceGroupManagerClient) SetInstanceTemplate(project, zone, name, instanceTemplateURL string) (*compute.Operation, error) {
+	c.Lock()
+	defer c.Unlock()
+	igm, err := c.find(project, zone, name)
+	if err != nil {
+		return nil, err
+	}
+	igm.InstanceTemplate = instanceTemplateURL
 	return doneOperation(), nil
 }
 
 func (c *instanceGroupManagerClient) Resize(project, zone, name string, newSize int64) (*compute.Operation, error) {
+	c.Lock()
+	defer c.Unlock()
+	igm, err := c.find(project, zone, name)
+	if err != nil {
+		return nil, err
+	}
+	igm.TargetSize = newSize
 	return doneOperation(), nil
 }
+
+// find returns the instanceGroupManager with the given name. The caller must hold the lock.
+func (c *instanceGroupManagerClient) find(project, zone, name string) (*compute.InstanceGroupManager, error) {
+	zones, ok := c.instanceGroupManagers[project]
+	if !ok {
+		return nil, notFoundError()
+	}
+	igms, ok := zones[zone]
+	if !ok {
+		return nil, notFoundError()
+	}
+	igm, ok := igms[name]
+	if !ok {
+		return nil, notFoundError()
+	}
+	return igm, nil
+}
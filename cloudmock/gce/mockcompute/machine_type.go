@@ -0,0 +1,65 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mockcompute
+
+import (
+	"context"
+
+	compute "google.golang.org/api/compute/v1"
+	"k8s.io/kops/upup/pkg/fi/cloudup/gce"
+)
+
+type machineTypeClient struct {
+	// machineTypes are the machine types available in every zone of a project.
+	machineTypes map[string][]*compute.MachineType
+}
+
+var _ gce.MachineTypeClient = &machineTypeClient{}
+
+func newMachineTypeClient(project string) *machineTypeClient {
+	return &machineTypeClient{
+		machineTypes: map[string][]*compute.MachineType{
+			project: {
+				{Name: "e2-medium", GuestCpus: 2, MemoryMb: 4096, IsSharedCpu: true},
+				{Name: "e2-standard-2", GuestCpus: 2, MemoryMb: 8192},
+				{Name: "n1-standard-1", GuestCpus: 1, MemoryMb: 3840},
+				{Name: "n1-standard-2", GuestCpus: 2, MemoryMb: 7680},
+				{Name: "n2-standard-2", GuestCpus: 2, MemoryMb: 8192},
+				{Name: "n2-standard-4", GuestCpus: 4, MemoryMb: 16384},
+				{Name: "t2a-standard-2", GuestCpus: 2, MemoryMb: 8192},
+				{
+					Name:      "a2-highgpu-1g",
+					GuestCpus: 12,
+					MemoryMb:  87040,
+					Accelerators: []*compute.MachineTypeAccelerators{
+						{GuestAcceleratorType: "nvidia-tesla-a100", GuestAcceleratorCount: 1},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (c *machineTypeClient) List(ctx context.Context, project, zone string) ([]*compute.MachineType, error) {
+	var l []*compute.MachineType
+	for _, mt := range c.machineTypes[project] {
+		machineType := *mt
+		machineType.Zone = zone
+		l = append(l, &machineType)
+	}
+	return l, nil
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mockcompute
+
+import (
+	"context"
+	"fmt"
+
+	compute "google.golang.org/api/compute/v1"
+	"k8s.io/kops/upup/pkg/fi/cloudup/gce"
+)
+
+type regionClient struct {
+	// regions are regions keyed by project and region name.
+	regions map[string]map[string]*compute.Region
+}
+
+var _ gce.RegionClient = &regionClient{}
+
+func newRegionClient(project string) *regionClient {
+	zoneURL := func(zone string) string {
+		return fmt.Sprintf("https://www.googleapis.com/compute/v1/projects/%s/zones/%s", project, zone)
+	}
+	return &regionClient{
+		regions: map[string]map[string]*compute.Region{
+			project: {
+				"us-test1": {
+					Name:     "us-test1",
+					SelfLink: fmt.Sprintf("https://www.googleapis.com/compute/v1/projects/%s/regions/us-test1", project),
+					Zones:    []string{zoneURL("us-test1-a"), zoneURL("us-test1-b"), zoneURL("us-test1-c")},
+				},
+			},
+		},
+	}
+}
+
+func (c *regionClient) List(ctx context.Context, project string) ([]*compute.Region, error) {
+	regions, ok := c.regions[project]
+	if !ok {
+		return nil, nil
+	}
+	var l []*compute.Region
+	for _, r := range regions {
+		l = append(l, r)
+	}
+	return l, nil
+}
@@ -66,6 +66,7 @@ func (c *routerClient) Insert(project, region string, r *compute.Router) (*compu
 		rs = map[string]*compute.Router{}
 		regions[region] = rs
 	}
+	r.Region = fmt.Sprintf("https://www.googleapis.com/compute/v1/projects/%s/regions/%s", project, region)
 	r.SelfLink = fmt.Sprintf("https://www.googleapis.com/compute/v1/projects/%s/regions/%s/routers/%s", project, region, r.Name)
 	rs[r.Name] = r
 	return doneOperation(), nil
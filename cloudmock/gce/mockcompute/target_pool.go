@@ -66,6 +66,7 @@ func (c *targetPoolClient) Insert(project, region string, tp *compute.TargetPool
 		tps = map[string]*compute.TargetPool{}
 		regions[region] = tps
 	}
+	tp.Region = fmt.Sprintf("https://www.googleapis.com/compute/v1/projects/%s/regions/%s", project, region)
 	tp.SelfLink = fmt.Sprintf("https://www.googleapis.com/compute/v1/projects/%s/regions/%s/targetPools/%s", project, region, tp.Name)
 	tps[tp.Name] = tp
 	return doneOperation(), nil
@@ -126,6 +127,22 @@ func (c *targetPoolClient) List(ctx context.Context, project, region string) ([]
 }
 
 func (c *targetPoolClient) AddHealthCheck(project, region, name string, req *compute.TargetPoolsAddHealthCheckRequest) (*compute.Operation, error) {
-	// TODO: AddHealthCheck test
+	c.Lock()
+	defer c.Unlock()
+	regions, ok := c.targetPools[project]
+	if !ok {
+		return nil, notFoundError()
+	}
+	tps, ok := regions[region]
+	if !ok {
+		return nil, notFoundError()
+	}
+	tp, ok := tps[name]
+	if !ok {
+		return nil, notFoundError()
+	}
+	for _, hc := range req.HealthChecks {
+		tp.HealthChecks = append(tp.HealthChecks, hc.HealthCheck)
+	}
 	return doneOperation(), nil
 }
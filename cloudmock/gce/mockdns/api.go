@@ -31,10 +31,11 @@ var _ gce.DNSClient = &MockClient{}
 
 // NewMockClient creates a new mock client.
 func NewMockClient() *MockClient {
+	resourceRecordSetClient := newResourceRecordSetClient()
 	return &MockClient{
 		managedZoneClient:       newManagedZoneClient(),
-		resourceRecordSetClient: newResourceRecordSetClient(),
-		changeClient:            newChangeClient(),
+		resourceRecordSetClient: resourceRecordSetClient,
+		changeClient:            newChangeClient(resourceRecordSetClient),
 	}
 }
 
@@ -21,14 +21,23 @@ import (
 	"k8s.io/kops/upup/pkg/fi/cloudup/gce"
 )
 
-type changeClient struct{}
+type changeClient struct {
+	// resourceRecordSets is the client whose record sets are modified by changes.
+	resourceRecordSets *resourceRecordSetClient
+}
 
 var _ gce.ChangeClient = &changeClient{}
 
-func newChangeClient() *changeClient {
-	return &changeClient{}
+func newChangeClient(resourceRecordSets *resourceRecordSetClient) *changeClient {
+	return &changeClient{
+		resourceRecordSets: resourceRecordSets,
+	}
 }
 
 func (c *changeClient) Create(project, zone string, ch *dns.Change) (*dns.Change, error) {
+	if err := c.resourceRecordSets.apply(project, zone, ch.Deletions, ch.Additions); err != nil {
+		return nil, err
+	}
+	ch.Status = "done"
 	return ch, nil
 }
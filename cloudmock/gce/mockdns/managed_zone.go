@@ -17,6 +17,8 @@ limitations under the License.
 package mockdns
 
 import (
+	"sync"
+
 	dns "google.golang.org/api/dns/v1"
 	"k8s.io/kops/upup/pkg/fi/cloudup/gce"
 )
@@ -24,6 +26,7 @@ import (
 type managedZoneClient struct {
 	// managedZones are managedZones keyed by project and managedZone name.
 	managedZones map[string]map[string]*dns.ManagedZone
+	sync.Mutex
 }
 
 var _ gce.ManagedZoneClient = &managedZoneClient{}
@@ -35,6 +38,8 @@ func newManagedZoneClient() *managedZoneClient {
 }
 
 func (c *managedZoneClient) List(project string) ([]*dns.ManagedZone, error) {
+	c.Lock()
+	defer c.Unlock()
 	mzs, ok := c.managedZones[project]
 	if !ok {
 		return nil, nil
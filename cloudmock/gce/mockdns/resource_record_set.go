@@ -17,13 +17,18 @@ limitations under the License.
 package mockdns
 
 import (
+	"fmt"
+	"sync"
+
 	dns "google.golang.org/api/dns/v1"
+	"google.golang.org/api/googleapi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/gce"
 )
 
 type resourceRecordSetClient struct {
-	// resourceRecordSets are resourceRecordSets keyed by project,zone and resourceRecordSet name.
+	// resourceRecordSets are resourceRecordSets keyed by project, zone, and resourceRecordSet name and type.
 	resourceRecordSets map[string]map[string]map[string]*dns.ResourceRecordSet
+	sync.Mutex
 }
 
 var _ gce.ResourceRecordSetClient = &resourceRecordSetClient{}
@@ -35,6 +40,8 @@ func newResourceRecordSetClient() *resourceRecordSetClient {
 }
 
 func (c *resourceRecordSetClient) List(project, zone string) ([]*dns.ResourceRecordSet, error) {
+	c.Lock()
+	defer c.Unlock()
 	zones, ok := c.resourceRecordSets[project]
 	if !ok {
 		return nil, nil
@@ -49,3 +56,52 @@ func (c *resourceRecordSetClient) List(project, zone string) ([]*dns.ResourceRec
 	}
 	return l, nil
 }
+
+// apply removes the deletions from and adds the additions to the record sets of a zone.
+// As with Cloud DNS, the whole change is rejected if a deletion does not match an existing
+// record set or an addition conflicts with one.
+func (c *resourceRecordSetClient) apply(project, zone string, deletions, additions []*dns.ResourceRecordSet) error {
+	c.Lock()
+	defer c.Unlock()
+	zones, ok := c.resourceRecordSets[project]
+	if !ok {
+		zones = map[string]map[string]*dns.ResourceRecordSet{}
+		c.resourceRecordSets[project] = zones
+	}
+	rs, ok := zones[zone]
+	if !ok {
+		rs = map[string]*dns.ResourceRecordSet{}
+		zones[zone] = rs
+	}
+
+	remaining := map[string]*dns.ResourceRecordSet{}
+	for k, r := range rs {
+		remaining[k] = r
+	}
+	for _, r := range deletions {
+		k := resourceRecordSetKey(r)
+		if _, ok := remaining[k]; !ok {
+			return &googleapi.Error{
+				Code:    404,
+				Message: fmt.Sprintf("resource record set %q of type %q not found", r.Name, r.Type),
+			}
+		}
+		delete(remaining, k)
+	}
+	for _, r := range additions {
+		k := resourceRecordSetKey(r)
+		if _, ok := remaining[k]; ok {
+			return &googleapi.Error{
+				Code:    409,
+				Message: fmt.Sprintf("resource record set %q of type %q already exists", r.Name, r.Type),
+			}
+		}
+		remaining[k] = r
+	}
+	zones[zone] = remaining
+	return nil
+}
+
+func resourceRecordSetKey(r *dns.ResourceRecordSet) string {
+	return r.Name + "/" + r.Type
+}
@@ -29,7 +29,9 @@ import (
 type serviceAccountClient struct {
 	// serviceaccounts are keyed by name.
 	serviceaccounts map[string]*iam.ServiceAccount
-	project         string
+	// policies are keyed by the name of the serviceaccount.
+	policies map[string]*iam.Policy
+	project  string
 	sync.Mutex
 }
 
@@ -38,6 +40,7 @@ var _ gce.ServiceAccountClient = &serviceAccountClient{}
 func newServiceAccounts(project string) *serviceAccountClient {
 	return &serviceAccountClient{
 		serviceaccounts: map[string]*iam.ServiceAccount{},
+		policies:        map[string]*iam.Policy{},
 		project:         project,
 	}
 }
@@ -91,3 +94,32 @@ func (s *serviceAccountClient) List(ctx context.Context, project string) ([]*iam
 	}
 	return r, nil
 }
+
+func (s *serviceAccountClient) GetIamPolicy(ctx context.Context, name string) (*iam.Policy, error) {
+	s.Lock()
+	defer s.Unlock()
+	if _, ok := s.serviceaccounts[name]; !ok {
+		return nil, notFoundError()
+	}
+	// Return a copy, as callers modify the policy they get
+	result := &iam.Policy{}
+	if policy, ok := s.policies[name]; ok {
+		for _, binding := range policy.Bindings {
+			result.Bindings = append(result.Bindings, &iam.Binding{
+				Members: append([]string(nil), binding.Members...),
+				Role:    binding.Role,
+			})
+		}
+	}
+	return result, nil
+}
+
+func (s *serviceAccountClient) SetIamPolicy(ctx context.Context, name string, policy *iam.Policy) (*iam.Policy, error) {
+	s.Lock()
+	defer s.Unlock()
+	if _, ok := s.serviceaccounts[name]; !ok {
+		return nil, notFoundError()
+	}
+	s.policies[name] = policy
+	return policy, nil
+}
@@ -35,6 +35,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/klog/v2"
+	"k8s.io/kops/cmd/kops-controller/pkg/metrics"
 	"k8s.io/kops/upup/pkg/fi"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -124,7 +125,7 @@ func (r *AWSIPAMReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 			return ctrl.Result{}, err
 		}
 		instanceID := strings.Split(providerURL.Path, "/")[2]
-		eni, err := r.ec2Client.DescribeNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{
+		eni, err := r.ec2Client.DescribeNetworkInterfacesWithContext(ctx, &ec2.DescribeNetworkInterfacesInput{
 			Filters: []*ec2.Filter{
 				{
 					Name: fi.String("attachment.instance-id"),
@@ -138,16 +139,30 @@ func (r *AWSIPAMReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 			return ctrl.Result{}, err
 		}
 
-		if len(eni.NetworkInterfaces) != 1 {
-			return ctrl.Result{}, fmt.Errorf("unexpected number of network interfaces for instance %q: %v", instanceID, len(eni.NetworkInterfaces))
+		primary := primaryNetworkInterface(eni.NetworkInterfaces)
+		if primary == nil {
+			r.recordNodeEvent(ctx, node, corev1.EventTypeWarning, "IPAMPending", fmt.Sprintf("No primary network interface found for instance %q", instanceID))
+			return ctrl.Result{Requeue: true}, nil
 		}
 
-		if len(eni.NetworkInterfaces[0].Ipv6Prefixes) != 1 {
-			return ctrl.Result{}, fmt.Errorf("unexpected amount of ipv6 prefixes on interface %q: %v", *eni.NetworkInterfaces[0].NetworkInterfaceId, len(eni.NetworkInterfaces[0].Ipv6Prefixes))
+		if len(primary.Ipv6Prefixes) == 0 {
+			// The prefix is assigned asynchronously; requeue with the controller's exponential backoff
+			klog.Infof("no ipv6 prefix assigned yet to interface %q of node %q", aws.StringValue(primary.NetworkInterfaceId), node.Name)
+			r.recordNodeEvent(ctx, node, corev1.EventTypeNormal, "IPAMPending", fmt.Sprintf("Waiting for an IPv6 prefix to be assigned to interface %q", aws.StringValue(primary.NetworkInterfaceId)))
+			return ctrl.Result{Requeue: true}, nil
 		}
 
-		patchNodePodCIDRs(r.coreV1Client, ctx, node, *eni.NetworkInterfaces[0].Ipv6Prefixes[0].Ipv6Prefix)
+		if len(primary.Ipv6Prefixes) != 1 {
+			r.recordNodeEvent(ctx, node, corev1.EventTypeWarning, "IPAMFailed", fmt.Sprintf("Interface %q has %d IPv6 prefixes, expected 1", aws.StringValue(primary.NetworkInterfaceId), len(primary.Ipv6Prefixes)))
+			return ctrl.Result{}, fmt.Errorf("unexpected amount of ipv6 prefixes on interface %q: %v", aws.StringValue(primary.NetworkInterfaceId), len(primary.Ipv6Prefixes))
+		}
 
+		cidr := aws.StringValue(primary.Ipv6Prefixes[0].Ipv6Prefix)
+		if err := patchNodePodCIDRs(r.coreV1Client, ctx, node, cidr); err != nil {
+			r.recordNodeEvent(ctx, node, corev1.EventTypeWarning, "IPAMFailed", fmt.Sprintf("Failed to assign pod CIDR %q: %v", cidr, err))
+			return ctrl.Result{}, err
+		}
+		r.recordNodeEvent(ctx, node, corev1.EventTypeNormal, "IPAMAssigned", fmt.Sprintf("Assigned pod CIDR %q", cidr))
 	}
 
 	return ctrl.Result{}, nil
@@ -159,9 +174,50 @@ func (r *AWSIPAMReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Complete(r)
 }
 
+// recordNodeEvent records an event against the node. Failures are only logged, as the events are informational.
+// Events for nodes are recorded in kube-system, where kops-controller is allowed to create them.
+func (r *AWSIPAMReconciler) recordNodeEvent(ctx context.Context, node *corev1.Node, eventType, reason, message string) {
+	now := metav1.Now()
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: node.Name + ".",
+			Namespace:    metav1.NamespaceSystem,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "Node",
+			Name:       node.Name,
+			UID:        node.UID,
+		},
+		Reason:         reason,
+		Message:        message,
+		Type:           eventType,
+		Count:          1,
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Source: corev1.EventSource{
+			Component: "kops-controller",
+		},
+	}
+	if _, err := r.coreV1Client.Events(metav1.NamespaceSystem).Create(ctx, event, metav1.CreateOptions{}); err != nil {
+		klog.Warningf("failed to record event %q for node %q: %v", reason, node.Name, err)
+	}
+}
+
+// primaryNetworkInterface returns the network interface attached at device index 0.
+func primaryNetworkInterface(enis []*ec2.NetworkInterface) *ec2.NetworkInterface {
+	for _, eni := range enis {
+		if eni.Attachment != nil && aws.Int64Value(eni.Attachment.DeviceIndex) == 0 {
+			return eni
+		}
+	}
+	return nil
+}
+
 type nodePatchSpec struct {
-	PodCIDR  string   `json:"podCIDR,omitempty"`
-	PodCIDRs []string `json:"podCIDRs,omitempty"`
+	PodCIDR  string         `json:"podCIDR,omitempty"`
+	PodCIDRs []string       `json:"podCIDRs,omitempty"`
+	Taints   []corev1.Taint `json:"taints,omitempty"`
 }
 
 // patchNodeLabels patches the node labels to set the specified labels
@@ -182,6 +238,7 @@ func patchNodePodCIDRs(client *corev1client.CoreV1Client, ctx context.Context, n
 	klog.V(2).Infof("sending patch for node %q: %q", node.Name, string(nodePatchJson))
 
 	_, err = client.Nodes().Patch(ctx, node.Name, types.StrategicMergePatchType, nodePatchJson, metav1.PatchOptions{})
+	metrics.IPAMPatches.WithLabelValues(metrics.Result(err)).Inc()
 	if err != nil {
 		return fmt.Errorf("error applying patch to node: %v", err)
 	}
@@ -0,0 +1,59 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+func TestPrimaryNetworkInterface(t *testing.T) {
+	eni := func(id string, deviceIndex int64) *ec2.NetworkInterface {
+		return &ec2.NetworkInterface{
+			NetworkInterfaceId: aws.String(id),
+			Attachment:         &ec2.NetworkInterfaceAttachment{DeviceIndex: aws.Int64(deviceIndex)},
+		}
+	}
+
+	grid := []struct {
+		enis     []*ec2.NetworkInterface
+		expected string
+	}{
+		{
+			enis:     []*ec2.NetworkInterface{eni("eni-primary", 0)},
+			expected: "eni-primary",
+		},
+		{
+			enis:     []*ec2.NetworkInterface{eni("eni-secondary", 1), eni("eni-primary", 0), eni("eni-efa", 2)},
+			expected: "eni-primary",
+		},
+		{
+			enis: []*ec2.NetworkInterface{eni("eni-secondary", 1), {NetworkInterfaceId: aws.String("eni-detached")}},
+		},
+	}
+	for i, g := range grid {
+		actual := ""
+		if eni := primaryNetworkInterface(g.enis); eni != nil {
+			actual = aws.StringValue(eni.NetworkInterfaceId)
+		}
+		if actual != g.expected {
+			t.Errorf("case %d: expected %q, got %q", i, g.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-06-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure/auth"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/upup/pkg/fi"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// NewAzureIPAMReconciler is the constructor for a AzureIPAMReconciler
+func NewAzureIPAMReconciler(mgr manager.Manager) (*AzureIPAMReconciler, error) {
+	klog.Info("Starting azure ipam controller")
+	r := &AzureIPAMReconciler{
+		client: mgr.GetClient(),
+		log:    ctrl.Log.WithName("controllers").WithName("IPAM"),
+	}
+
+	coreClient, err := corev1client.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return nil, fmt.Errorf("error building corev1 client: %v", err)
+	}
+	r.coreV1Client = coreClient
+
+	authorizer, err := auth.NewAuthorizerFromEnvironment()
+	if err != nil {
+		return nil, fmt.Errorf("error creating an authorizer: %v", err)
+	}
+	r.authorizer = authorizer
+
+	return r, nil
+}
+
+// AzureIPAMReconciler observes Node objects, and assigns each node the pod CIDR derived from the IPv6 address of its network interface.
+type AzureIPAMReconciler struct {
+	// client is the controller-runtime client
+	client client.Client
+
+	// log is a logr
+	log logr.Logger
+
+	// coreV1Client is a client-go client for patching nodes
+	coreV1Client *corev1client.CoreV1Client
+
+	// authorizer authorizes the requests to the Azure API
+	authorizer autorest.Authorizer
+}
+
+// +kubebuilder:rbac:groups=,resources=nodes,verbs=get;list;watch;patch
+// Reconcile is the main reconciler function that observes node changes.
+func (r *AzureIPAMReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	_ = r.log.WithValues("ipam-controller", req.NamespacedName)
+
+	node := &corev1.Node{}
+	if err := r.client.Get(ctx, req.NamespacedName, node); err != nil {
+		klog.Warningf("unable to fetch node %s: %v", node.Name, err)
+		if apierrors.IsNotFound(err) {
+			// we'll ignore not-found errors, since they can't be fixed by an immediate
+			// requeue (we'll need to wait for a new notification), and we can get them
+			// on deleted requests.
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if len(node.Spec.PodCIDRs) == 0 {
+		// CCM Node Controller has not done its thing yet
+		if node.Spec.ProviderID == "" {
+			klog.Infof("Node %q has empty provider ID", node.Name)
+			return ctrl.Result{}, nil
+		}
+
+		vm, err := parseAzureProviderID(node.Spec.ProviderID)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		interfacesClient := network.NewInterfacesClient(vm.subscriptionID)
+		interfacesClient.Authorizer = r.authorizer
+
+		var nics []network.Interface
+		iter, err := interfacesClient.ListVirtualMachineScaleSetVMNetworkInterfacesComplete(ctx, vm.resourceGroup, vm.scaleSet, vm.instanceID)
+		for ; err == nil && iter.NotDone(); err = iter.NextWithContext(ctx) {
+			nics = append(nics, iter.Value())
+		}
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("error listing network interfaces of %q: %v", node.Spec.ProviderID, err)
+		}
+
+		address, err := primaryIPv6Address(nics)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("error finding the IPv6 address of %q: %v", node.Spec.ProviderID, err)
+		}
+
+		cidr, err := podCIDRFromIPv6Address(address)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		if err := patchNodePodCIDRs(r.coreV1Client, ctx, node, cidr); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *AzureIPAMReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Node{}).
+		Complete(r)
+}
+
+// azureScaleSetVM identifies a VM ScaleSet instance.
+type azureScaleSetVM struct {
+	subscriptionID string
+	resourceGroup  string
+	scaleSet       string
+	instanceID     string
+}
+
+// parseAzureProviderID parses a provider ID such as
+// azure:///subscriptions/<subscription>/resourceGroups/<group>/providers/Microsoft.Compute/virtualMachineScaleSets/<vmss>/virtualMachines/<id>
+func parseAzureProviderID(providerID string) (*azureScaleSetVM, error) {
+	if !strings.HasPrefix(providerID, "azure://") {
+		return nil, fmt.Errorf("providerID %q not recognized", providerID)
+	}
+
+	l := strings.Split(strings.TrimPrefix(providerID, "azure://"), "/")
+	if len(l) != 11 || !strings.EqualFold(l[1], "subscriptions") || !strings.EqualFold(l[3], "resourceGroups") ||
+		!strings.EqualFold(l[7], "virtualMachineScaleSets") || !strings.EqualFold(l[9], "virtualMachines") {
+		return nil, fmt.Errorf("unexpected format of providerID %q", providerID)
+	}
+
+	return &azureScaleSetVM{
+		subscriptionID: l[2],
+		resourceGroup:  l[4],
+		scaleSet:       l[8],
+		instanceID:     l[10],
+	}, nil
+}
+
+// primaryIPv6Address returns the IPv6 address of the primary network interface.
+func primaryIPv6Address(nics []network.Interface) (net.IP, error) {
+	for _, nic := range nics {
+		if nic.InterfacePropertiesFormat == nil || nic.IPConfigurations == nil {
+			continue
+		}
+		if len(nics) > 1 && !fi.BoolValue(nic.Primary) {
+			continue
+		}
+		for _, ipConfig := range *nic.IPConfigurations {
+			if ipConfig.InterfaceIPConfigurationPropertiesFormat == nil || ipConfig.PrivateIPAddressVersion != network.IPv6 {
+				continue
+			}
+			ip := net.ParseIP(fi.StringValue(ipConfig.PrivateIPAddress))
+			if ip == nil {
+				return nil, fmt.Errorf("invalid IPv6 address %q on interface %q", fi.StringValue(ipConfig.PrivateIPAddress), fi.StringValue(nic.Name))
+			}
+			return ip, nil
+		}
+		return nil, fmt.Errorf("no IPv6 address on interface %q", fi.StringValue(nic.Name))
+	}
+	return nil, fmt.Errorf("no primary network interface found")
+}
+
+// podCIDRFromIPv6Address returns the /80 prefix reserved for the pods of a node.
+// Azure IPv6 subnets are always /64 and allocate interface addresses from the start of the subnet,
+// so the low 16 bits of the interface address identify the node within the subnet.
+func podCIDRFromIPv6Address(address net.IP) (string, error) {
+	ip := address.To16()
+	if ip == nil || address.To4() != nil {
+		return "", fmt.Errorf("address %q is not an IPv6 address", address)
+	}
+
+	prefix := make(net.IP, net.IPv6len)
+	copy(prefix[:8], ip[:8])
+	binary.BigEndian.PutUint16(prefix[8:10], binary.BigEndian.Uint16(ip[14:16]))
+
+	cidr := net.IPNet{IP: prefix, Mask: net.CIDRMask(80, 128)}
+	return cidr.String(), nil
+}
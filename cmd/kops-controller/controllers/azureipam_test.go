@@ -0,0 +1,153 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-06-01/network"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func TestParseAzureProviderID(t *testing.T) {
+	grid := []struct {
+		providerID string
+		expected   *azureScaleSetVM
+	}{
+		{
+			providerID: "azure:///subscriptions/7e232992-6f42-4554-a685-3e02278c3a8b/resourceGroups/my-group/providers/Microsoft.Compute/virtualMachineScaleSets/nodes.my-cluster.k8s.local/virtualMachines/3",
+			expected: &azureScaleSetVM{
+				subscriptionID: "7e232992-6f42-4554-a685-3e02278c3a8b",
+				resourceGroup:  "my-group",
+				scaleSet:       "nodes.my-cluster.k8s.local",
+				instanceID:     "3",
+			},
+		},
+		{
+			providerID: "azure:///subscriptions/7e232992-6f42-4554-a685-3e02278c3a8b/resourceGroups/my-group/providers/Microsoft.Compute/virtualMachines/node-0",
+		},
+		{
+			providerID: "aws:///us-east-1a/i-07577a7bcf3e576f2",
+		},
+	}
+	for _, g := range grid {
+		actual, err := parseAzureProviderID(g.providerID)
+		if g.expected == nil {
+			if err == nil {
+				t.Errorf("%s: expected error, got %v", g.providerID, actual)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", g.providerID, err)
+			continue
+		}
+		if !reflect.DeepEqual(actual, g.expected) {
+			t.Errorf("%s: expected %v, got %v", g.providerID, g.expected, actual)
+		}
+	}
+}
+
+func TestPrimaryIPv6Address(t *testing.T) {
+	nic := func(name string, primary bool, addresses ...string) network.Interface {
+		var ipConfigs []network.InterfaceIPConfiguration
+		for _, address := range addresses {
+			version := network.IPv4
+			if net.ParseIP(address).To4() == nil {
+				version = network.IPv6
+			}
+			ipConfigs = append(ipConfigs, network.InterfaceIPConfiguration{
+				InterfaceIPConfigurationPropertiesFormat: &network.InterfaceIPConfigurationPropertiesFormat{
+					PrivateIPAddress:        fi.String(address),
+					PrivateIPAddressVersion: version,
+				},
+			})
+		}
+		return network.Interface{
+			Name: fi.String(name),
+			InterfacePropertiesFormat: &network.InterfacePropertiesFormat{
+				Primary:          fi.Bool(primary),
+				IPConfigurations: &ipConfigs,
+			},
+		}
+	}
+
+	grid := []struct {
+		nics     []network.Interface
+		expected string
+	}{
+		{
+			nics:     []network.Interface{nic("nic-0", false, "10.0.0.4", "fd00::4")},
+			expected: "fd00::4",
+		},
+		{
+			nics:     []network.Interface{nic("nic-0", false, "10.0.1.4", "fd00:1::4"), nic("nic-1", true, "10.0.0.4", "fd00::4")},
+			expected: "fd00::4",
+		},
+		{
+			nics: []network.Interface{nic("nic-0", true, "10.0.0.4")},
+		},
+		{
+			nics: nil,
+		},
+	}
+	for i, g := range grid {
+		actual, err := primaryIPv6Address(g.nics)
+		if g.expected == "" {
+			if err == nil {
+				t.Errorf("case %d: expected error, got %v", i, actual)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("case %d: unexpected error: %v", i, err)
+			continue
+		}
+		if actual.String() != g.expected {
+			t.Errorf("case %d: expected %s, got %s", i, g.expected, actual)
+		}
+	}
+}
+
+func TestPodCIDRFromIPv6Address(t *testing.T) {
+	grid := []struct {
+		address  string
+		expected string
+	}{
+		{address: "fd00::4", expected: "fd00::4:0:0:0/80"},
+		{address: "2001:db8:1234:5678::1a", expected: "2001:db8:1234:5678:1a::/80"},
+		{address: "10.0.0.4"},
+	}
+	for _, g := range grid {
+		actual, err := podCIDRFromIPv6Address(net.ParseIP(g.address))
+		if g.expected == "" {
+			if err == nil {
+				t.Errorf("%s: expected error, got %s", g.address, actual)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", g.address, err)
+			continue
+		}
+		if actual != g.expected {
+			t.Errorf("%s: expected %s, got %s", g.address, g.expected, actual)
+		}
+	}
+}
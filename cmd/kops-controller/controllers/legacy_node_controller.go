@@ -26,6 +26,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/klog/v2"
+	"k8s.io/kops/cmd/kops-controller/pkg/metrics"
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/apis/kops/registry"
 	"k8s.io/kops/pkg/kopscodecs"
@@ -86,7 +87,11 @@ type LegacyNodeReconciler struct {
 
 // +kubebuilder:rbac:groups=,resources=nodes,verbs=get;list;watch;patch
 // Reconcile is the main reconciler function that observes node changes.
-func (r *LegacyNodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *LegacyNodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
+	defer func(start time.Time) {
+		metrics.NodeReconcileDuration.WithLabelValues("legacy-node", metrics.Result(err)).Observe(time.Since(start).Seconds())
+	}(time.Now())
+
 	_ = r.log.WithValues("nodecontroller", req.NamespacedName)
 
 	node := &corev1.Node{}
@@ -20,26 +20,43 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/klog/v2"
+	kopsbase "k8s.io/kops"
+	"k8s.io/kops/cmd/kops-controller/pkg/config"
+	"k8s.io/kops/cmd/kops-controller/pkg/metrics"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/kops/registry"
+	"k8s.io/kops/pkg/apis/kops/util"
+	"k8s.io/kops/pkg/bootstrap/metal"
+	"k8s.io/kops/pkg/kopscodecs"
 	"k8s.io/kops/pkg/nodeidentity"
+	"k8s.io/kops/pkg/nodelabels"
+	"k8s.io/kops/upup/pkg/fi/utils"
+	"k8s.io/kops/util/pkg/vfs"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
 // NewNodeReconciler is the constructor for a NodeReconciler
-func NewNodeReconciler(mgr manager.Manager, identifier nodeidentity.Identifier) (*NodeReconciler, error) {
+// configPath is only required by identifiers that return the instance group of the node.
+func NewNodeReconciler(mgr manager.Manager, configPath string, identifier nodeidentity.Identifier, tagLabels *config.NodeLabelsFromCloudTagsOptions) (*NodeReconciler, error) {
 	r := &NodeReconciler{
 		client:     mgr.GetClient(),
 		log:        ctrl.Log.WithName("controllers").WithName("Node"),
 		identifier: identifier,
+		tagLabels:  tagLabels,
+		cache:      vfs.NewCache(),
 	}
 
 	coreClient, err := corev1client.NewForConfig(mgr.GetConfig())
@@ -48,6 +65,14 @@ func NewNodeReconciler(mgr manager.Manager, identifier nodeidentity.Identifier)
 	}
 	r.coreV1Client = coreClient
 
+	if configPath != "" {
+		configBase, err := vfs.Context.BuildVfsPath(configPath)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse ConfigBase %q: %v", configPath, err)
+		}
+		r.configBase = configBase
+	}
+
 	return r, nil
 }
 
@@ -65,11 +90,24 @@ type NodeReconciler struct {
 
 	// identifier is a provider that can securely map node ProviderIDs to labels
 	identifier nodeidentity.Identifier
+
+	// tagLabels configures the cloud tags that are copied onto the node as labels
+	tagLabels *config.NodeLabelsFromCloudTagsOptions
+
+	// configBase is the parsed path to the base location of our configuration files
+	configBase vfs.Path
+
+	// cache caches the instancegroup and cluster values, to avoid repeated GCS/S3 calls
+	cache *vfs.Cache
 }
 
 // +kubebuilder:rbac:groups=,resources=nodes,verbs=get;list;watch;patch
 // Reconcile is the main reconciler function that observes node changes.
-func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
+	defer func(start time.Time) {
+		metrics.NodeReconcileDuration.WithLabelValues("node", metrics.Result(err)).Observe(time.Since(start).Seconds())
+	}(time.Now())
+
 	_ = r.log.WithValues("nodecontroller", req.NamespacedName)
 
 	node := &corev1.Node{}
@@ -84,12 +122,49 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 		return ctrl.Result{}, err
 	}
 
-	info, err := r.identifier.IdentifyNode(ctx, node)
-	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("error identifying node %q: %v", node.Name, err)
+	var info *nodeidentity.Info
+	instanceGroupName, isMetal := metal.InstanceGroupFromProviderID(node.Spec.ProviderID)
+	if isMetal {
+		// Metal machines are not known to the cloud; their provider ID records their instance group
+		info = &nodeidentity.Info{InstanceGroup: instanceGroupName}
+	} else {
+		info, err = r.identifier.IdentifyNode(ctx, node)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("error identifying node %q: %v", node.Name, err)
+		}
 	}
 
-	labels := info.Labels
+	labels := make(map[string]string)
+	var taints []corev1.Taint
+	if info.InstanceGroup != "" {
+		cluster, ig, err := r.loadClusterAndInstanceGroup(info.InstanceGroup)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("unable to load instance group object for node %s: %v", node.Name, err)
+		}
+		// The kubelet sets the provider ID, so only trust it for the labels of metal instance groups
+		if isMetal && ig.Spec.Manager != kops.InstanceManagerMetal {
+			return ctrl.Result{}, fmt.Errorf("node %s claims non-metal instance group %q", node.Name, ig.Name)
+		}
+		for k, v := range nodelabels.BuildNodeLabels(cluster, ig) {
+			labels[k] = v
+		}
+		taints, err = parseTaints(ig.Spec.Taints)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("unable to parse taints of instance group %q: %v", ig.Name, err)
+		}
+	}
+	// The labels from the cloud take precedence over those from the instance group
+	for k, v := range info.Labels {
+		labels[k] = v
+	}
+	for k, v := range labelsFromCloudTags(r.tagLabels, info.Tags) {
+		labels[k] = v
+	}
+
+	// The version of kops is only recorded when the node is first labeled, so it is the version the node joined with
+	if _, found := node.Labels[nodelabels.KopsVersionLabel]; !found && len(validation.IsValidLabelValue(kopsbase.Version)) == 0 {
+		labels[nodelabels.KopsVersionLabel] = kopsbase.Version
+	}
 
 	updateLabels := make(map[string]string)
 	for k, v := range labels {
@@ -99,14 +174,24 @@ func (r *NodeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 		}
 	}
 
-	if len(updateLabels) == 0 {
-		klog.V(4).Infof("no label changes needed for %s", node.Name)
+	// We only add missing taints; other controllers (and users) add and remove taints of their own.
+	addTaints := missingTaints(node.Spec.Taints, taints)
+
+	if len(updateLabels) == 0 && len(addTaints) == 0 {
+		klog.V(4).Infof("no label or taint changes needed for %s", node.Name)
 		return ctrl.Result{}, nil
 	}
 
-	if err := patchNodeLabels(r.coreV1Client, ctx, node, updateLabels); err != nil {
-		klog.Warningf("failed to patch node labels on %s: %v", node.Name, err)
-		return ctrl.Result{}, err
+	if len(addTaints) == 0 {
+		if err := patchNodeLabels(r.coreV1Client, ctx, node, updateLabels); err != nil {
+			klog.Warningf("failed to patch node labels on %s: %v", node.Name, err)
+			return ctrl.Result{}, err
+		}
+	} else {
+		if err := patchNodeLabelsAndTaints(r.coreV1Client, ctx, node, updateLabels, append(node.Spec.Taints, addTaints...)); err != nil {
+			klog.Warningf("failed to patch node labels and taints on %s: %v", node.Name, err)
+			return ctrl.Result{}, err
+		}
 	}
 
 	return ctrl.Result{}, nil
@@ -118,13 +203,110 @@ func (r *NodeReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Complete(r)
 }
 
+// loadClusterAndInstanceGroup loads the cluster and the named instance group from the state store
+func (r *NodeReconciler) loadClusterAndInstanceGroup(name string) (*kops.Cluster, *kops.InstanceGroup, error) {
+	if r.configBase == nil {
+		return nil, nil, fmt.Errorf("configBase is required to load instance group %q", name)
+	}
+
+	ttl := time.Hour
+
+	clusterPath := r.configBase.Join(registry.PathClusterCompleted)
+	b, err := r.cache.Read(clusterPath, ttl)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error loading Cluster %q: %v", clusterPath, err)
+	}
+	o, _, err := kopscodecs.Decode(b, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing Cluster %q: %v", clusterPath, err)
+	}
+	cluster, ok := o.(*kops.Cluster)
+	if !ok {
+		return nil, nil, fmt.Errorf("unexpected object type for Cluster %q: %T", clusterPath, o)
+	}
+
+	igPath := r.configBase.Join("instancegroup", name)
+	b, err = r.cache.Read(igPath, ttl)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error loading InstanceGroup %q: %v", igPath, err)
+	}
+	ig := &kops.InstanceGroup{}
+	if err := utils.YamlUnmarshal(b, ig); err != nil {
+		return nil, nil, fmt.Errorf("error parsing InstanceGroup %q: %v", igPath, err)
+	}
+
+	return cluster, ig, nil
+}
+
+// parseTaints parses the taints of the instance group spec.
+// Taints without an effect cannot be applied to a node, and are skipped.
+// labelsFromCloudTags returns the labels for the cloud tags that are configured to be copied onto the node.
+// Tags whose values are not valid label values are skipped.
+func labelsFromCloudTags(options *config.NodeLabelsFromCloudTagsOptions, tags map[string]string) map[string]string {
+	labels := make(map[string]string)
+	if options == nil {
+		return labels
+	}
+	for _, key := range options.Tags {
+		value, found := tags[key]
+		if !found {
+			continue
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) != 0 {
+			klog.Warningf("not copying tag %q to node label, value %q is not a valid label value: %s", key, value, strings.Join(errs, "; "))
+			continue
+		}
+		labels[options.Prefix+key] = value
+	}
+	return labels
+}
+
+func parseTaints(specs []string) ([]corev1.Taint, error) {
+	var taints []corev1.Taint
+	for _, spec := range specs {
+		parsed, err := util.ParseTaint(spec)
+		if err != nil {
+			return nil, err
+		}
+		if parsed["effect"] == "" {
+			klog.Warningf("ignoring taint %q without effect", spec)
+			continue
+		}
+		taints = append(taints, corev1.Taint{
+			Key:    parsed["key"],
+			Value:  parsed["value"],
+			Effect: corev1.TaintEffect(parsed["effect"]),
+		})
+	}
+	return taints, nil
+}
+
+// missingTaints returns the expected taints that are not present on the node, matching on key and effect
+func missingTaints(actual, expected []corev1.Taint) []corev1.Taint {
+	var missing []corev1.Taint
+	for _, e := range expected {
+		found := false
+		for _, a := range actual {
+			if a.Key == e.Key && a.Effect == e.Effect {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, e)
+		}
+	}
+	return missing
+}
+
 type nodePatch struct {
 	Spec     *nodePatchSpec     `json:"spec,omitempty"`
 	Metadata *nodePatchMetadata `json:"metadata,omitempty"`
 }
 
 type nodePatchMetadata struct {
-	Labels map[string]string `json:"labels,omitempty"`
+	Labels          map[string]string `json:"labels,omitempty"`
+	ResourceVersion string            `json:"resourceVersion,omitempty"`
 }
 
 // patchNodeLabels patches the node labels to set the specified labels
@@ -149,3 +331,30 @@ func patchNodeLabels(client *corev1client.CoreV1Client, ctx context.Context, nod
 
 	return nil
 }
+
+// patchNodeLabelsAndTaints patches the node to set the specified labels and replace its taints
+func patchNodeLabelsAndTaints(client *corev1client.CoreV1Client, ctx context.Context, node *corev1.Node, setLabels map[string]string, taints []corev1.Taint) error {
+	nodePatch := &nodePatch{
+		Metadata: &nodePatchMetadata{
+			Labels: setLabels,
+			// The taints are replaced as a whole, so guard against concurrent changes.
+			ResourceVersion: node.ResourceVersion,
+		},
+		Spec: &nodePatchSpec{
+			Taints: taints,
+		},
+	}
+	nodePatchJson, err := json.Marshal(nodePatch)
+	if err != nil {
+		return fmt.Errorf("error building node patch: %v", err)
+	}
+
+	klog.V(2).Infof("sending patch for node %q: %q", node.Name, string(nodePatchJson))
+
+	_, err = client.Nodes().Patch(ctx, node.Name, types.StrategicMergePatchType, nodePatchJson, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("error applying patch to node: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/kops/cmd/kops-controller/pkg/config"
+)
+
+func TestParseTaints(t *testing.T) {
+	taints, err := parseTaints([]string{
+		"dedicated=gpu:NoSchedule",
+		"spot:PreferNoSchedule",
+		"no-effect",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []corev1.Taint{
+		{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule},
+		{Key: "spot", Effect: corev1.TaintEffectPreferNoSchedule},
+	}
+	if !reflect.DeepEqual(taints, expected) {
+		t.Errorf("unexpected taints: %v", taints)
+	}
+
+	if _, err := parseTaints([]string{"a=b=c:NoSchedule"}); err == nil {
+		t.Errorf("expected error for invalid taint")
+	}
+}
+
+func TestMissingTaints(t *testing.T) {
+	actual := []corev1.Taint{
+		{Key: "node.kubernetes.io/not-ready", Effect: corev1.TaintEffectNoSchedule},
+		{Key: "dedicated", Value: "other", Effect: corev1.TaintEffectNoSchedule},
+	}
+	expected := []corev1.Taint{
+		{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule},
+		{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoExecute},
+	}
+
+	missing := missingTaints(actual, expected)
+	want := []corev1.Taint{
+		{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoExecute},
+	}
+	if !reflect.DeepEqual(missing, want) {
+		t.Errorf("unexpected missing taints: %v", missing)
+	}
+
+	if missing := missingTaints(actual, nil); len(missing) != 0 {
+		t.Errorf("expected no missing taints, got %v", missing)
+	}
+}
+
+func TestLabelsFromCloudTags(t *testing.T) {
+	options := &config.NodeLabelsFromCloudTagsOptions{
+		Tags:   []string{"team", "cost-center", "missing"},
+		Prefix: "tag.kops.k8s.io/",
+	}
+	tags := map[string]string{
+		"team":        "platform",
+		"cost-center": "not a valid label value",
+		"other":       "ignored",
+	}
+
+	labels := labelsFromCloudTags(options, tags)
+	expected := map[string]string{
+		"tag.kops.k8s.io/team": "platform",
+	}
+	if !reflect.DeepEqual(labels, expected) {
+		t.Errorf("unexpected labels: %v", labels)
+	}
+
+	if labels := labelsFromCloudTags(nil, tags); len(labels) != 0 {
+		t.Errorf("expected no labels when not configured, got %v", labels)
+	}
+}
@@ -0,0 +1,293 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/autoscaling/autoscalingiface"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/cmd/kops-controller/pkg/config"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	// spotFallbackTag is the autoscaling group tag recording the fallback state of the group and when it was entered.
+	// Because it is stored on the autoscaling group, the state survives kops-controller restarts.
+	spotFallbackTag = awsup.TagNameSpotFallback
+
+	spotFallbackStateOnDemand = awsup.SpotFallbackStateOnDemand
+	spotFallbackStateReverted = awsup.SpotFallbackStateReverted
+
+	// spotFallbackInterval is how often the scaling activities are checked.
+	spotFallbackInterval = time.Minute
+
+	// spotFallbackNamespace is the namespace in which events are recorded.
+	spotFallbackNamespace = "kube-system"
+)
+
+// NewSpotFallbackController is the constructor for a SpotFallbackController
+func NewSpotFallbackController(mgr manager.Manager, opt *config.SpotFallbackOptions) (*SpotFallbackController, error) {
+	klog.Info("Starting spot fallback controller")
+	c := &SpotFallbackController{
+		log:            ctrl.Log.WithName("controllers").WithName("SpotFallback"),
+		instanceGroups: opt.InstanceGroups,
+		now:            time.Now,
+	}
+
+	coreClient, err := corev1client.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return nil, fmt.Errorf("error building corev1 client: %v", err)
+	}
+	c.coreV1Client = coreClient
+
+	awsConfig := aws.NewConfig()
+	awsConfig = awsConfig.WithCredentialsChainVerboseErrors(true)
+	awsConfig = awsConfig.WithRegion(opt.Region)
+
+	s, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error starting new AWS session: %v", err)
+	}
+	s.Handlers.Send.PushFront(func(r *request.Request) {
+		// Log requests
+		klog.V(4).Infof("AWS API Request: %s/%s", r.ClientInfo.ServiceName, r.Operation.Name)
+	})
+
+	c.autoscaling = autoscaling.New(s, awsConfig)
+
+	return c, nil
+}
+
+// SpotFallbackController watches the scaling activities of instance groups that use Spot instances.
+// When Spot launches keep failing, it switches the instance group to On-Demand instances,
+// and switches it back to Spot once RevertAfter has passed.
+type SpotFallbackController struct {
+	// log is a logr
+	log logr.Logger
+
+	// coreV1Client is a client-go client for recording events
+	coreV1Client corev1client.CoreV1Interface
+
+	autoscaling autoscalingiface.AutoScalingAPI
+
+	// instanceGroups is the list of instance groups with spot fallback enabled
+	instanceGroups []config.SpotFallbackInstanceGroup
+
+	// now returns the current time, and can be replaced in tests
+	now func() time.Time
+}
+
+// Start runs the controller until the context is cancelled. It implements manager.Runnable;
+// as it does not implement LeaderElectionRunnable, it only runs on the leader.
+func (c *SpotFallbackController) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		for i := range c.instanceGroups {
+			ig := &c.instanceGroups[i]
+			if err := c.reconcile(ctx, ig); err != nil {
+				c.log.Error(err, "error reconciling spot fallback", "instanceGroup", ig.Name)
+			}
+		}
+	}, spotFallbackInterval)
+	return nil
+}
+
+func (c *SpotFallbackController) reconcile(ctx context.Context, ig *config.SpotFallbackInstanceGroup) error {
+	response, err := c.autoscaling.DescribeAutoScalingGroupsWithContext(ctx, &autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: []*string{aws.String(ig.AutoscalingGroupName)},
+	})
+	if err != nil {
+		return fmt.Errorf("error describing autoscaling group %q: %v", ig.AutoscalingGroupName, err)
+	}
+	if len(response.AutoScalingGroups) == 0 {
+		klog.V(2).Infof("autoscaling group %q not found", ig.AutoscalingGroupName)
+		return nil
+	}
+	asg := response.AutoScalingGroups[0]
+	if asg.MixedInstancesPolicy == nil {
+		klog.V(2).Infof("autoscaling group %q does not have a mixed instances policy", ig.AutoscalingGroupName)
+		return nil
+	}
+
+	now := c.now()
+
+	state, stateSince, found := spotFallbackState(asg)
+	if found && state == spotFallbackStateOnDemand {
+		if now.Sub(stateSince) < ig.RevertAfter.Duration {
+			return nil
+		}
+
+		if err := c.updateInstancesDistribution(ctx, ig, ig.OnDemandBase, ig.OnDemandAboveBase); err != nil {
+			return err
+		}
+		if err := c.setSpotFallbackState(ctx, ig, spotFallbackStateReverted, now); err != nil {
+			return err
+		}
+
+		c.recordEvent(ctx, ig, corev1.EventTypeNormal, "SpotFallbackReverted",
+			fmt.Sprintf("Switched back to Spot instances after %v on On-Demand instances", now.Sub(stateSince).Round(time.Second)))
+		return nil
+	}
+
+	// Failures from before the last revert have already been acted upon
+	since := now.Add(-ig.FailureWindow.Duration)
+	if found && state == spotFallbackStateReverted && stateSince.After(since) {
+		since = stateSince
+	}
+
+	activities, err := c.autoscaling.DescribeScalingActivitiesWithContext(ctx, &autoscaling.DescribeScalingActivitiesInput{
+		AutoScalingGroupName: aws.String(ig.AutoscalingGroupName),
+	})
+	if err != nil {
+		return fmt.Errorf("error describing scaling activities of autoscaling group %q: %v", ig.AutoscalingGroupName, err)
+	}
+
+	failures := countSpotLaunchFailures(activities.Activities, since)
+	if failures < ig.FailureThreshold {
+		return nil
+	}
+
+	if err := c.updateInstancesDistribution(ctx, ig, ig.OnDemandBase, 100); err != nil {
+		return err
+	}
+	if err := c.setSpotFallbackState(ctx, ig, spotFallbackStateOnDemand, now); err != nil {
+		return err
+	}
+
+	c.recordEvent(ctx, ig, corev1.EventTypeWarning, "SpotFallback",
+		fmt.Sprintf("Switched to On-Demand instances after %d failed Spot launches; Spot will be tried again in %v", failures, ig.RevertAfter.Duration))
+	return nil
+}
+
+// updateInstancesDistribution sets the On-Demand base capacity and percentage of the autoscaling group.
+func (c *SpotFallbackController) updateInstancesDistribution(ctx context.Context, ig *config.SpotFallbackInstanceGroup, onDemandBase, onDemandAboveBase int64) error {
+	klog.Infof("setting on-demand base %d and on-demand above base %d%% for autoscaling group %q", onDemandBase, onDemandAboveBase, ig.AutoscalingGroupName)
+	_, err := c.autoscaling.UpdateAutoScalingGroupWithContext(ctx, &autoscaling.UpdateAutoScalingGroupInput{
+		AutoScalingGroupName: aws.String(ig.AutoscalingGroupName),
+		MixedInstancesPolicy: &autoscaling.MixedInstancesPolicy{
+			InstancesDistribution: &autoscaling.InstancesDistribution{
+				OnDemandBaseCapacity:                aws.Int64(onDemandBase),
+				OnDemandPercentageAboveBaseCapacity: aws.Int64(onDemandAboveBase),
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("error updating autoscaling group %q: %v", ig.AutoscalingGroupName, err)
+	}
+	return nil
+}
+
+// setSpotFallbackState records the fallback state of the autoscaling group, and when it was entered, in the spot fallback tag.
+func (c *SpotFallbackController) setSpotFallbackState(ctx context.Context, ig *config.SpotFallbackInstanceGroup, state string, since time.Time) error {
+	if _, err := c.autoscaling.CreateOrUpdateTagsWithContext(ctx, &autoscaling.CreateOrUpdateTagsInput{
+		Tags: []*autoscaling.Tag{buildSpotFallbackTag(ig.AutoscalingGroupName, state+"/"+since.UTC().Format(time.RFC3339))},
+	}); err != nil {
+		return fmt.Errorf("error tagging autoscaling group %q: %v", ig.AutoscalingGroupName, err)
+	}
+	return nil
+}
+
+// recordEvent records an event against the instance group. Failures are only logged,
+// as the events are informational.
+func (c *SpotFallbackController) recordEvent(ctx context.Context, ig *config.SpotFallbackInstanceGroup, eventType, reason, message string) {
+	now := metav1.NewTime(c.now())
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: ig.Name + ".",
+			Namespace:    spotFallbackNamespace,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion: "kops.k8s.io/v1alpha2",
+			Kind:       "InstanceGroup",
+			Name:       ig.Name,
+			Namespace:  spotFallbackNamespace,
+		},
+		Reason:         reason,
+		Message:        message,
+		Type:           eventType,
+		Count:          1,
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Source: corev1.EventSource{
+			Component: "kops-controller",
+		},
+	}
+	if _, err := c.coreV1Client.Events(spotFallbackNamespace).Create(ctx, event, metav1.CreateOptions{}); err != nil {
+		klog.Warningf("failed to record event %q for instance group %q: %v", reason, ig.Name, err)
+	}
+}
+
+// spotFallbackState returns the fallback state recorded in the spot fallback tag of the autoscaling group,
+// and when that state was entered.
+func spotFallbackState(asg *autoscaling.Group) (string, time.Time, bool) {
+	for _, tag := range asg.Tags {
+		if aws.StringValue(tag.Key) != spotFallbackTag {
+			continue
+		}
+		state, value, _ := strings.Cut(aws.StringValue(tag.Value), "/")
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil || (state != spotFallbackStateOnDemand && state != spotFallbackStateReverted) {
+			klog.Warningf("ignoring invalid value %q of tag %q on autoscaling group %q", aws.StringValue(tag.Value), spotFallbackTag, aws.StringValue(asg.AutoScalingGroupName))
+			return "", time.Time{}, false
+		}
+		return state, t, true
+	}
+	return "", time.Time{}, false
+}
+
+func buildSpotFallbackTag(asgName string, value string) *autoscaling.Tag {
+	return &autoscaling.Tag{
+		Key:               aws.String(spotFallbackTag),
+		Value:             aws.String(value),
+		ResourceId:        aws.String(asgName),
+		ResourceType:      aws.String("auto-scaling-group"),
+		PropagateAtLaunch: aws.Bool(false),
+	}
+}
+
+// countSpotLaunchFailures counts the failed Spot launches that started after since.
+func countSpotLaunchFailures(activities []*autoscaling.Activity, since time.Time) int {
+	count := 0
+	for _, activity := range activities {
+		if aws.StringValue(activity.StatusCode) != autoscaling.ScalingActivityStatusCodeFailed {
+			continue
+		}
+		if activity.StartTime == nil || activity.StartTime.Before(since) {
+			continue
+		}
+		// e.g. "Could not launch Spot Instances. InsufficientInstanceCapacity - There is no Spot capacity available that matches your request."
+		if !strings.Contains(aws.StringValue(activity.StatusMessage), "Spot") {
+			continue
+		}
+		count++
+	}
+	return count
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+)
+
+func TestCountSpotLaunchFailures(t *testing.T) {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	activity := func(statusCode string, message string, age time.Duration) *autoscaling.Activity {
+		return &autoscaling.Activity{
+			StatusCode:    aws.String(statusCode),
+			StatusMessage: aws.String(message),
+			StartTime:     aws.Time(now.Add(-age)),
+		}
+	}
+	spotFailure := "Could not launch Spot Instances. InsufficientInstanceCapacity - There is no Spot capacity available that matches your request. Launching EC2 instance failed."
+
+	activities := []*autoscaling.Activity{
+		activity(autoscaling.ScalingActivityStatusCodeFailed, spotFailure, time.Minute),
+		activity(autoscaling.ScalingActivityStatusCodeFailed, spotFailure, 5*time.Minute),
+		activity(autoscaling.ScalingActivityStatusCodeSuccessful, "", 6*time.Minute),
+		activity(autoscaling.ScalingActivityStatusCodeFailed, "Launching a new EC2 instance. Status Reason: The requested configuration is currently not supported.", 7*time.Minute),
+		activity(autoscaling.ScalingActivityStatusCodeFailed, spotFailure, 30*time.Minute),
+		{StatusCode: aws.String(autoscaling.ScalingActivityStatusCodeFailed), StatusMessage: aws.String(spotFailure)},
+	}
+
+	grid := []struct {
+		since    time.Time
+		expected int
+	}{
+		{since: now.Add(-15 * time.Minute), expected: 2},
+		{since: now.Add(-3 * time.Minute), expected: 1},
+		{since: now.Add(-time.Hour), expected: 3},
+		{since: now, expected: 0},
+	}
+	for _, g := range grid {
+		actual := countSpotLaunchFailures(activities, g.since)
+		if actual != g.expected {
+			t.Errorf("since %v: expected %d failures, got %d", g.since, g.expected, actual)
+		}
+	}
+}
+
+func TestSpotFallbackState(t *testing.T) {
+	since := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	grid := []struct {
+		tags          []*autoscaling.TagDescription
+		expectedState string
+		expectedFound bool
+	}{
+		{
+			tags: nil,
+		},
+		{
+			tags: []*autoscaling.TagDescription{
+				{Key: aws.String("KubernetesCluster"), Value: aws.String("minimal.example.com")},
+			},
+		},
+		{
+			tags: []*autoscaling.TagDescription{
+				{Key: aws.String(spotFallbackTag), Value: aws.String("on-demand/yesterday")},
+			},
+		},
+		{
+			tags: []*autoscaling.TagDescription{
+				{Key: aws.String(spotFallbackTag), Value: aws.String(since.Format(time.RFC3339))},
+			},
+		},
+		{
+			tags: []*autoscaling.TagDescription{
+				{Key: aws.String(spotFallbackTag), Value: aws.String("spot/" + since.Format(time.RFC3339))},
+			},
+		},
+		{
+			tags: []*autoscaling.TagDescription{
+				{Key: aws.String("KubernetesCluster"), Value: aws.String("minimal.example.com")},
+				{Key: aws.String(spotFallbackTag), Value: aws.String("on-demand/" + since.Format(time.RFC3339))},
+			},
+			expectedState: spotFallbackStateOnDemand,
+			expectedFound: true,
+		},
+		{
+			tags: []*autoscaling.TagDescription{
+				{Key: aws.String(spotFallbackTag), Value: aws.String("reverted/" + since.Format(time.RFC3339))},
+			},
+			expectedState: spotFallbackStateReverted,
+			expectedFound: true,
+		},
+	}
+	for i, g := range grid {
+		state, actual, found := spotFallbackState(&autoscaling.Group{AutoScalingGroupName: aws.String("nodes.minimal.example.com"), Tags: g.tags})
+		if found != g.expectedFound {
+			t.Errorf("case %d: expected found=%v, got %v", i, g.expectedFound, found)
+			continue
+		}
+		if state != g.expectedState {
+			t.Errorf("case %d: expected state %q, got %q", i, g.expectedState, state)
+		}
+		if found && !actual.Equal(since) {
+			t.Errorf("case %d: expected %v, got %v", i, since, actual)
+		}
+	}
+}
@@ -32,6 +32,7 @@ import (
 	"k8s.io/kops/cmd/kops-controller/pkg/config"
 	"k8s.io/kops/cmd/kops-controller/pkg/server"
 	"k8s.io/kops/pkg/bootstrap"
+	"k8s.io/kops/pkg/bootstrap/metal"
 	"k8s.io/kops/pkg/nodeidentity"
 	nodeidentityaws "k8s.io/kops/pkg/nodeidentity/aws"
 	nodeidentityazure "k8s.io/kops/pkg/nodeidentity/azure"
@@ -40,6 +41,8 @@ import (
 	nodeidentityhetzner "k8s.io/kops/pkg/nodeidentity/hetzner"
 	nodeidentityos "k8s.io/kops/pkg/nodeidentity/openstack"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	"k8s.io/kops/upup/pkg/fi/cloudup/gce/gceidentity"
+	gcetpm "k8s.io/kops/upup/pkg/fi/cloudup/gce/tpm"
 	"k8s.io/kops/upup/pkg/fi/cloudup/gce/tpm/gcetpmverifier"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -59,10 +62,6 @@ func init() {
 func main() {
 	klog.InitFlags(nil)
 
-	// Disable metrics by default (avoid port conflicts, also risky because we are host network)
-	metricsAddress := ":0"
-	// flag.StringVar(&metricsAddr, "metrics-addr", metricsAddress, "The address the metric endpoint binds to.")
-
 	configPath := "/etc/kubernetes/kops-controller/config.yaml"
 	flag.StringVar(&configPath, "conf", configPath, "Location of yaml configuration file")
 
@@ -93,6 +92,12 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Disable metrics by default (avoid port conflicts, also risky because we are host network)
+	metricsAddress := "0"
+	if opt.MetricsBindAddress != "" {
+		metricsAddress = opt.MetricsBindAddress
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:             scheme,
 		MetricsBindAddress: metricsAddress,
@@ -114,14 +119,34 @@ func main() {
 				os.Exit(1)
 			}
 		} else if opt.Server.Provider.GCE != nil {
-			verifier, err = gcetpmverifier.NewTPMVerifier(opt.Server.Provider.GCE)
+			tpmVerifier, err := gcetpmverifier.NewTPMVerifier(opt.Server.Provider.GCE)
 			if err != nil {
 				setupLog.Error(err, "unable to create verifier")
 				os.Exit(1)
 			}
+			identityVerifier, err := gceidentity.NewIdentityVerifier(opt.Server.Provider.GCE)
+			if err != nil {
+				setupLog.Error(err, "unable to create verifier")
+				os.Exit(1)
+			}
+			verifier = bootstrap.NewPrefixVerifier(map[string]bootstrap.Verifier{
+				gcetpm.GCETPMAuthenticationTokenPrefix:           tpmVerifier,
+				gceidentity.GCEIdentityAuthenticationTokenPrefix: identityVerifier,
+			})
 		} else {
 			klog.Fatalf("server cloud provider config not provided")
 		}
+		if opt.Server.Provider.Metal != nil {
+			metalVerifier, err := metal.NewVerifier(opt.Server.Provider.Metal)
+			if err != nil {
+				setupLog.Error(err, "unable to create verifier")
+				os.Exit(1)
+			}
+			verifier = bootstrap.NewPrefixVerifier(map[string]bootstrap.Verifier{
+				awsup.AWSAuthenticationTokenPrefix:   verifier,
+				metal.MetalAuthenticationTokenPrefix: metalVerifier,
+			})
+		}
 
 		srv, err := server.NewServer(&opt, verifier)
 		if err != nil {
@@ -133,11 +158,18 @@ func main() {
 
 	if opt.EnableCloudIPAM {
 		setupLog.Info("enabling IPAM controller")
-		if opt.Cloud != "aws" {
-			klog.Error("IPAM controller only supported by aws")
+		var ipamController interface {
+			SetupWithManager(ctrl.Manager) error
+		}
+		switch opt.Cloud {
+		case "aws":
+			ipamController, err = controllers.NewAWSIPAMReconciler(mgr)
+		case "azure":
+			ipamController, err = controllers.NewAzureIPAMReconciler(mgr)
+		default:
+			klog.Error("IPAM controller only supported by aws and azure")
 			os.Exit(1)
 		}
-		ipamController, err := controllers.NewAWSIPAMReconciler(mgr)
 		if err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "IPAMController")
 			os.Exit(1)
@@ -148,6 +180,23 @@ func main() {
 		}
 	}
 
+	if opt.SpotFallback != nil {
+		setupLog.Info("enabling spot fallback controller")
+		if opt.Cloud != "aws" {
+			klog.Error("spot fallback controller only supported by aws")
+			os.Exit(1)
+		}
+		spotFallbackController, err := controllers.NewSpotFallbackController(mgr, opt.SpotFallback)
+		if err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "SpotFallbackController")
+			os.Exit(1)
+		}
+		if err := mgr.Add(spotFallbackController); err != nil {
+			setupLog.Error(err, "unable to add controller", "controller", "SpotFallbackController")
+			os.Exit(1)
+		}
+	}
+
 	if err := addNodeController(mgr, &opt); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "NodeController")
 		os.Exit(1)
@@ -202,7 +251,7 @@ func addNodeController(mgr manager.Manager, opt *config.Options) error {
 		}
 
 	case "digitalocean":
-		legacyIdentifier, err = nodeidentitydo.New()
+		identifier, err = nodeidentitydo.New()
 		if err != nil {
 			return fmt.Errorf("error building identifier: %v", err)
 		}
@@ -227,7 +276,7 @@ func addNodeController(mgr manager.Manager, opt *config.Options) error {
 	}
 
 	if identifier != nil {
-		nodeController, err := controllers.NewNodeReconciler(mgr, identifier)
+		nodeController, err := controllers.NewNodeReconciler(mgr, opt.ConfigBase, identifier, opt.NodeLabelsFromCloudTags)
 		if err != nil {
 			return err
 		}
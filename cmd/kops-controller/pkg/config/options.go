@@ -17,6 +17,8 @@ limitations under the License.
 package config
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/pkg/bootstrap/metal"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	gcetpm "k8s.io/kops/upup/pkg/fi/cloudup/gce/tpm"
 )
@@ -32,6 +34,15 @@ type Options struct {
 
 	// Discovery configures options relating to discovery, particularly for gossip mode.
 	Discovery *DiscoveryOptions `json:"discovery,omitempty"`
+
+	// SpotFallback configures the controller that switches instance groups to On-Demand capacity when Spot capacity is unavailable.
+	SpotFallback *SpotFallbackOptions `json:"spotFallback,omitempty"`
+
+	// NodeLabelsFromCloudTags configures the cloud tags that the node controller copies onto Node objects as labels.
+	NodeLabelsFromCloudTags *NodeLabelsFromCloudTagsOptions `json:"nodeLabelsFromCloudTags,omitempty"`
+
+	// MetricsBindAddress is the address where the Prometheus metrics are served; metrics are disabled if empty.
+	MetricsBindAddress string `json:"metricsBindAddress,omitempty"`
 }
 
 func (o *Options) PopulateDefaults() {
@@ -58,11 +69,18 @@ type ServerOptions struct {
 
 	// UseInstanceIDForNodeName uses the instance ID instead of the hostname for the node name.
 	UseInstanceIDForNodeName bool `json:"useInstanceIDForNodeName,omitempty"`
+
+	// NodeCertificateValidity is the lifetime of the kubelet certificates issued to nodes.
+	// If not set, they are valid for about 15 months.
+	NodeCertificateValidity *metav1.Duration `json:"nodeCertificateValidity,omitempty"`
 }
 
 type ServerProviderOptions struct {
 	AWS *awsup.AWSVerifierOptions  `json:"aws,omitempty"`
 	GCE *gcetpm.TPMVerifierOptions `json:"gce,omitempty"`
+
+	// Metal configures the verifier for machines of metal instance groups, in addition to the cloud verifier.
+	Metal *metal.VerifierOptions `json:"metal,omitempty"`
 }
 
 // DiscoveryOptions configures our support for discovery, particularly gossip DNS (i.e. k8s.local)
@@ -70,3 +88,37 @@ type DiscoveryOptions struct {
 	// Enabled specifies whether support for discovery population is enabled.
 	Enabled bool `json:"enabled"`
 }
+
+// NodeLabelsFromCloudTagsOptions configures the cloud tags that are copied onto Node objects as labels.
+type NodeLabelsFromCloudTagsOptions struct {
+	// Tags are the keys of the cloud tags to copy.
+	Tags []string `json:"tags"`
+	// Prefix is prepended to the tag keys to build the label keys.
+	Prefix string `json:"prefix"`
+}
+
+// SpotFallbackOptions configures the automated fallback from Spot to On-Demand capacity (AWS only).
+type SpotFallbackOptions struct {
+	// Region is the AWS region of the cluster.
+	Region string `json:"region"`
+	// InstanceGroups is the list of instance groups with Spot fallback enabled.
+	InstanceGroups []SpotFallbackInstanceGroup `json:"instanceGroups"`
+}
+
+// SpotFallbackInstanceGroup is an instance group with Spot fallback enabled.
+type SpotFallbackInstanceGroup struct {
+	// Name is the name of the instance group.
+	Name string `json:"name"`
+	// AutoscalingGroupName is the name of the AWS autoscaling group backing the instance group.
+	AutoscalingGroupName string `json:"autoscalingGroupName"`
+	// OnDemandBase is the configured On-Demand base capacity, which is restored when Spot is tried again.
+	OnDemandBase int64 `json:"onDemandBase"`
+	// OnDemandAboveBase is the configured On-Demand percentage above base capacity, which is restored when Spot is tried again.
+	OnDemandAboveBase int64 `json:"onDemandAboveBase"`
+	// FailureThreshold is the number of failed Spot launches within FailureWindow that triggers the fallback.
+	FailureThreshold int `json:"failureThreshold"`
+	// FailureWindow is the period in which failed Spot launches are counted.
+	FailureWindow metav1.Duration `json:"failureWindow"`
+	// RevertAfter is how long the instance group runs On-Demand before Spot is tried again.
+	RevertAfter metav1.Duration `json:"revertAfter"`
+}
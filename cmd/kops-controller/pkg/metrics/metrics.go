@@ -0,0 +1,75 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const namespace = "kops_controller"
+
+// Values of the result label.
+const (
+	ResultAccepted = "accepted"
+	ResultRejected = "rejected"
+	ResultSuccess  = "success"
+	ResultError    = "error"
+)
+
+var (
+	// BootstrapRequests counts the node bootstrap requests, by result and by the reason they were rejected.
+	BootstrapRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "bootstrap_requests_total",
+		Help:      "Number of node bootstrap requests, partitioned by result and rejection reason.",
+	}, []string{"result", "reason"})
+
+	// RenewalRequests counts the node certificate renewal requests, by result and by the reason they were rejected.
+	RenewalRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "renewal_requests_total",
+		Help:      "Number of node certificate renewal requests, partitioned by result and rejection reason.",
+	}, []string{"result", "reason"})
+
+	// IPAMPatches counts the patches setting the pod CIDRs of nodes.
+	IPAMPatches = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "ipam_patches_total",
+		Help:      "Number of patches assigning pod CIDRs to nodes, partitioned by result.",
+	}, []string{"result"})
+
+	// NodeReconcileDuration records how long the node controllers take to reconcile a node.
+	NodeReconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "node_reconcile_duration_seconds",
+		Help:      "Time taken to reconcile a node, partitioned by controller and result.",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 2, 12),
+	}, []string{"controller", "result"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(BootstrapRequests, RenewalRequests, IPAMPatches, NodeReconcileDuration)
+}
+
+// Result returns the result label for an operation that returned err.
+func Result(err error) string {
+	if err != nil {
+		return ResultError
+	}
+	return ResultSuccess
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+func TestResult(t *testing.T) {
+	if actual := Result(nil); actual != ResultSuccess {
+		t.Errorf("expected %q for a nil error, got %q", ResultSuccess, actual)
+	}
+	if actual := Result(errors.New("failed")); actual != ResultError {
+		t.Errorf("expected %q for an error, got %q", ResultError, actual)
+	}
+}
+
+func TestCollectorsRegistered(t *testing.T) {
+	for _, collector := range []prometheus.Collector{BootstrapRequests, RenewalRequests, IPAMPatches, NodeReconcileDuration} {
+		err := metrics.Registry.Register(collector)
+		if err == nil {
+			t.Errorf("collector %v was not registered", collector)
+			metrics.Registry.Unregister(collector)
+			continue
+		}
+		if _, ok := err.(prometheus.AlreadyRegisteredError); !ok {
+			t.Errorf("unexpected error registering collector again: %v", err)
+		}
+	}
+}
+
+func TestLint(t *testing.T) {
+	for _, collector := range []prometheus.Collector{BootstrapRequests, RenewalRequests, IPAMPatches, NodeReconcileDuration} {
+		problems, err := testutil.CollectAndLint(collector)
+		if err != nil {
+			t.Fatalf("unexpected error linting metrics: %v", err)
+		}
+		for _, problem := range problems {
+			t.Errorf("metric %s: %s", problem.Metric, problem.Text)
+		}
+	}
+}
+
+func TestCounters(t *testing.T) {
+	BootstrapRequests.Reset()
+	RenewalRequests.Reset()
+	IPAMPatches.Reset()
+	defer func() {
+		BootstrapRequests.Reset()
+		RenewalRequests.Reset()
+		IPAMPatches.Reset()
+	}()
+
+	BootstrapRequests.WithLabelValues(ResultAccepted, "").Inc()
+	BootstrapRequests.WithLabelValues(ResultAccepted, "").Inc()
+	BootstrapRequests.WithLabelValues(ResultRejected, "verify_token").Inc()
+	RenewalRequests.WithLabelValues(ResultAccepted, "").Inc()
+	IPAMPatches.WithLabelValues(Result(nil)).Inc()
+	IPAMPatches.WithLabelValues(Result(errors.New("failed"))).Inc()
+	IPAMPatches.WithLabelValues(Result(nil)).Inc()
+
+	if actual := testutil.ToFloat64(BootstrapRequests.WithLabelValues(ResultAccepted, "")); actual != 2 {
+		t.Errorf("expected 2 accepted bootstrap requests, got %v", actual)
+	}
+	if actual := testutil.CollectAndCount(BootstrapRequests); actual != 2 {
+		t.Errorf("expected 2 bootstrap request series, got %d", actual)
+	}
+
+	expected := `
+# HELP kops_controller_bootstrap_requests_total Number of node bootstrap requests, partitioned by result and rejection reason.
+# TYPE kops_controller_bootstrap_requests_total counter
+kops_controller_bootstrap_requests_total{reason="",result="accepted"} 2
+kops_controller_bootstrap_requests_total{reason="verify_token",result="rejected"} 1
+# HELP kops_controller_ipam_patches_total Number of patches assigning pod CIDRs to nodes, partitioned by result.
+# TYPE kops_controller_ipam_patches_total counter
+kops_controller_ipam_patches_total{result="error"} 1
+kops_controller_ipam_patches_total{result="success"} 2
+# HELP kops_controller_renewal_requests_total Number of node certificate renewal requests, partitioned by result and rejection reason.
+# TYPE kops_controller_renewal_requests_total counter
+kops_controller_renewal_requests_total{reason="",result="accepted"} 1
+`
+	err := testutil.GatherAndCompare(metrics.Registry, strings.NewReader(expected),
+		"kops_controller_bootstrap_requests_total",
+		"kops_controller_renewal_requests_total",
+		"kops_controller_ipam_patches_total",
+	)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestNodeReconcileDuration(t *testing.T) {
+	NodeReconcileDuration.Reset()
+	defer NodeReconcileDuration.Reset()
+
+	NodeReconcileDuration.WithLabelValues("node", ResultSuccess).Observe(0.05)
+	NodeReconcileDuration.WithLabelValues("node", ResultSuccess).Observe(0.3)
+	NodeReconcileDuration.WithLabelValues("legacy-node", ResultError).Observe(1)
+
+	if actual := testutil.CollectAndCount(NodeReconcileDuration); actual != 2 {
+		t.Errorf("expected 2 node reconcile duration series, got %d", actual)
+	}
+
+	count, err := testutil.GatherAndCount(metrics.Registry, "kops_controller_node_reconcile_duration_seconds")
+	if err != nil {
+		t.Fatalf("unexpected error gathering metrics: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 gathered node reconcile duration series, got %d", count)
+	}
+}
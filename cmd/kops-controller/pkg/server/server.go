@@ -33,6 +33,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 	"k8s.io/kops/cmd/kops-controller/pkg/config"
+	"k8s.io/kops/cmd/kops-controller/pkg/metrics"
 	"k8s.io/kops/pkg/apis/nodeup"
 	"k8s.io/kops/pkg/bootstrap"
 	"k8s.io/kops/pkg/pki"
@@ -77,6 +78,7 @@ func NewServer(opt *config.Options, verifier bootstrap.Verifier) (*Server, error
 
 	r := http.NewServeMux()
 	r.Handle("/bootstrap", http.HandlerFunc(s.bootstrap))
+	r.Handle("/renew", http.HandlerFunc(s.renew))
 	server.Handler = recovery(r)
 
 	return s, nil
@@ -108,18 +110,39 @@ func (s *Server) Start(ctx context.Context) error {
 	return s.server.ListenAndServeTLS(s.opt.Server.ServerCertificatePath, s.opt.Server.ServerKeyPath)
 }
 
+// bootstrap issues the certificates of a node that is joining the cluster.
 func (s *Server) bootstrap(w http.ResponseWriter, r *http.Request) {
+	s.issueCerts(w, r, false)
+}
+
+// renew issues new certificates to a node that has already bootstrapped, before its certificates expire.
+func (s *Server) renew(w http.ResponseWriter, r *http.Request) {
+	s.issueCerts(w, r, true)
+}
+
+func (s *Server) issueCerts(w http.ResponseWriter, r *http.Request, renewal bool) {
+	operation, requests := "bootstrap", metrics.BootstrapRequests
+	if renewal {
+		operation, requests = "renew", metrics.RenewalRequests
+	}
+	// reject records a request that was rejected for the given reason.
+	reject := func(reason string) {
+		requests.WithLabelValues(metrics.ResultRejected, reason).Inc()
+	}
+
 	if r.Body == nil {
-		klog.Infof("bootstrap %s no body", r.RemoteAddr)
+		klog.Infof("%s %s no body", operation, r.RemoteAddr)
+		reject("no_body")
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
-		klog.Infof("bootstrap %s read err: %v", r.RemoteAddr, err)
+		klog.Infof("%s %s read err: %v", operation, r.RemoteAddr, err)
+		reject("read_body")
 		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte(fmt.Sprintf("bootstrap %s failed to read body: %v", r.RemoteAddr, err)))
+		_, _ = w.Write([]byte(fmt.Sprintf("%s %s failed to read body: %v", operation, r.RemoteAddr, err)))
 		return
 	}
 
@@ -127,7 +150,8 @@ func (s *Server) bootstrap(w http.ResponseWriter, r *http.Request) {
 
 	id, err := s.verifier.VerifyToken(ctx, r.Header.Get("Authorization"), body, s.opt.Server.UseInstanceIDForNodeName)
 	if err != nil {
-		klog.Infof("bootstrap %s verify err: %v", r.RemoteAddr, err)
+		klog.Infof("%s %s verify err: %v", operation, r.RemoteAddr, err)
+		reject("verify_token")
 		w.WriteHeader(http.StatusForbidden)
 		_, _ = w.Write([]byte(fmt.Sprintf("failed to verify token: %v", err)))
 		return
@@ -135,14 +159,16 @@ func (s *Server) bootstrap(w http.ResponseWriter, r *http.Request) {
 
 	req := &nodeup.BootstrapRequest{}
 	if err := json.Unmarshal(body, req); err != nil {
-		klog.Infof("bootstrap %s decode err: %v", r.RemoteAddr, err)
+		klog.Infof("%s %s decode err: %v", operation, r.RemoteAddr, err)
+		reject("decode")
 		w.WriteHeader(http.StatusBadRequest)
 		_, _ = w.Write([]byte(fmt.Sprintf("failed to decode: %v", err)))
 		return
 	}
 
 	if req.APIVersion != nodeup.BootstrapAPIVersion {
-		klog.Infof("bootstrap %s wrong APIVersion", r.RemoteAddr)
+		klog.Infof("%s %s wrong APIVersion", operation, r.RemoteAddr)
+		reject("api_version")
 		w.WriteHeader(http.StatusBadRequest)
 		_, _ = w.Write([]byte("unexpected APIVersion"))
 		return
@@ -152,11 +178,20 @@ func (s *Server) bootstrap(w http.ResponseWriter, r *http.Request) {
 		Certs: map[string]string{},
 	}
 
+	if renewal && req.IncludeNodeConfig {
+		klog.Infof("%s %s requested node config", operation, r.RemoteAddr)
+		reject("node_config")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("node config is not renewed"))
+		return
+	}
+
 	// Support for nodes that have no access to the state store
 	if req.IncludeNodeConfig {
 		nodeConfig, err := s.getNodeConfig(r.Context(), req, id)
 		if err != nil {
-			klog.Infof("bootstrap failed to build node config: %v", err)
+			klog.Infof("%s failed to build node config: %v", operation, err)
+			reject("node_config")
 			w.WriteHeader(http.StatusBadRequest)
 			_, _ = w.Write([]byte("failed to build node config"))
 			return
@@ -172,9 +207,15 @@ func (s *Server) bootstrap(w http.ResponseWriter, r *http.Request) {
 	validHours := (455 * 24) + (hash.Sum32() % (30 * 24))
 
 	for name, pubKey := range req.Certs {
-		cert, err := s.issueCert(name, pubKey, id, validHours, req.KeypairIDs)
+		validity := time.Hour * time.Duration(validHours)
+		if s.opt.Server.NodeCertificateValidity != nil && (name == "kubelet" || name == "kubelet-server") {
+			// Short-lived certificates are renewed by the nodes well before they expire, so they aren't skewed.
+			validity = s.opt.Server.NodeCertificateValidity.Duration
+		}
+		cert, err := s.issueCert(name, pubKey, id, validity, req.KeypairIDs)
 		if err != nil {
-			klog.Infof("bootstrap %s cert %q issue err: %v", r.RemoteAddr, name, err)
+			klog.Infof("%s %s cert %q issue err: %v", operation, r.RemoteAddr, name, err)
+			reject("issue_cert")
 			w.WriteHeader(http.StatusBadRequest)
 			_, _ = w.Write([]byte(fmt.Sprintf("failed to issue %q: %v", name, err)))
 			return
@@ -184,10 +225,11 @@ func (s *Server) bootstrap(w http.ResponseWriter, r *http.Request) {
 
 	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(resp)
-	klog.Infof("bootstrap %s %s success", r.RemoteAddr, id.NodeName)
+	klog.Infof("%s %s %s success", operation, r.RemoteAddr, id.NodeName)
+	requests.WithLabelValues(metrics.ResultAccepted, "").Inc()
 }
 
-func (s *Server) issueCert(name string, pubKey string, id *bootstrap.VerifyResult, validHours uint32, keypairIDs map[string]string) (string, error) {
+func (s *Server) issueCert(name string, pubKey string, id *bootstrap.VerifyResult, validity time.Duration, keypairIDs map[string]string) (string, error) {
 	block, _ := pem.Decode([]byte(pubKey))
 	if block.Type != "RSA PUBLIC KEY" {
 		return "", fmt.Errorf("unexpected key type %q", block.Type)
@@ -201,7 +243,7 @@ func (s *Server) issueCert(name string, pubKey string, id *bootstrap.VerifyResul
 		Signer:    fi.CertificateIDCA,
 		Type:      "client",
 		PublicKey: key,
-		Validity:  time.Hour * time.Duration(validHours),
+		Validity:  validity,
 	}
 
 	if !s.certNames.Has(name) {
@@ -0,0 +1,111 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/kops/cmd/kops-controller/pkg/config"
+	"k8s.io/kops/cmd/kops-controller/pkg/metrics"
+	"k8s.io/kops/pkg/apis/nodeup"
+	"k8s.io/kops/pkg/bootstrap"
+)
+
+type fakeVerifier struct{}
+
+func (fakeVerifier) VerifyToken(ctx context.Context, token string, body []byte, useInstanceIDForNodeName bool) (*bootstrap.VerifyResult, error) {
+	return &bootstrap.VerifyResult{NodeName: "node-1"}, nil
+}
+
+func TestIssueCertsMetrics(t *testing.T) {
+	s := &Server{
+		opt:      &config.Options{Server: &config.ServerOptions{}},
+		verifier: fakeVerifier{},
+	}
+
+	send := func(handler http.HandlerFunc, apiVersion string) int {
+		body, err := json.Marshal(&nodeup.BootstrapRequest{APIVersion: apiVersion})
+		if err != nil {
+			t.Fatalf("encoding request: %v", err)
+		}
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body)))
+		return w.Code
+	}
+
+	counts := func() (bootstrapAccepted, renewalAccepted, renewalRejected float64) {
+		return testutil.ToFloat64(metrics.BootstrapRequests.WithLabelValues(metrics.ResultAccepted, "")),
+			testutil.ToFloat64(metrics.RenewalRequests.WithLabelValues(metrics.ResultAccepted, "")),
+			testutil.ToFloat64(metrics.RenewalRequests.WithLabelValues(metrics.ResultRejected, "api_version"))
+	}
+
+	grid := []struct {
+		name             string
+		handler          http.HandlerFunc
+		apiVersion       string
+		expectedCode     int
+		bootstrapDelta   float64
+		renewalDelta     float64
+		renewRejectDelta float64
+	}{
+		{
+			name:           "bootstrap",
+			handler:        s.bootstrap,
+			apiVersion:     nodeup.BootstrapAPIVersion,
+			expectedCode:   http.StatusOK,
+			bootstrapDelta: 1,
+		},
+		{
+			name:         "renew",
+			handler:      s.renew,
+			apiVersion:   nodeup.BootstrapAPIVersion,
+			expectedCode: http.StatusOK,
+			renewalDelta: 1,
+		},
+		{
+			name:             "renew with wrong api version",
+			handler:          s.renew,
+			apiVersion:       "unknown",
+			expectedCode:     http.StatusBadRequest,
+			renewRejectDelta: 1,
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			bootstrapBefore, renewalBefore, rejectBefore := counts()
+			if code := send(g.handler, g.apiVersion); code != g.expectedCode {
+				t.Fatalf("expected status %d, got %d", g.expectedCode, code)
+			}
+			bootstrapAfter, renewalAfter, rejectAfter := counts()
+			if delta := bootstrapAfter - bootstrapBefore; delta != g.bootstrapDelta {
+				t.Errorf("expected bootstrap accepted counter to increase by %v, got %v", g.bootstrapDelta, delta)
+			}
+			if delta := renewalAfter - renewalBefore; delta != g.renewalDelta {
+				t.Errorf("expected renewal accepted counter to increase by %v, got %v", g.renewalDelta, delta)
+			}
+			if delta := rejectAfter - rejectBefore; delta != g.renewRejectDelta {
+				t.Errorf("expected renewal rejected counter to increase by %v, got %v", g.renewRejectDelta, delta)
+			}
+		})
+	}
+}
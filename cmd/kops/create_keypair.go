@@ -59,6 +59,10 @@ var (
 
 	If the keyset is specified as "all", a newly generated secondary
 	certificate and private key will be added to each rotatable keyset.
+
+	If a cloud KMS key is specified, the private key is wrapped with it in
+	the state store. A private key already wrapped with a KMS key, as stored
+	by kOps, can be provided and keeps its KMS key.
 	`))
 
 	createKeypairExample = templates.Examples(i18n.T(`
@@ -67,6 +71,12 @@ var (
 		--cert ~/ca.pem --key ~/ca-key.pem \
 		--name k8s-cluster.example.com --state s3://my-state-store
 
+	# Add a CA certificate and private key, wrapping the private key with an AWS KMS key.
+	kops create keypair kubernetes-ca \
+		--cert ~/ca.pem --key ~/ca-key.pem \
+		--kms-key arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab \
+		--name k8s-cluster.example.com --state s3://my-state-store
+
 	# Add a newly generated certificate and private key to each rotatable keyset.
 	kops create keypair all \
 		--name k8s-cluster.example.com --state s3://my-state-store
@@ -81,6 +91,7 @@ type CreateKeypairOptions struct {
 	PrivateKeyPath string
 	CertPath       string
 	Primary        bool
+	KMSKey         string
 }
 
 func rotatableKeysetFilter(name string, _ *fi.Keyset) bool {
@@ -138,6 +149,7 @@ func NewCmdCreateKeypair(f *util.Factory, out io.Writer) *cobra.Command {
 	cmd.Flags().StringVar(&options.CertPath, "cert", options.CertPath, "Path to CA certificate")
 	cmd.Flags().StringVar(&options.PrivateKeyPath, "key", options.PrivateKeyPath, "Path to CA private key")
 	cmd.Flags().BoolVar(&options.Primary, "primary", options.Primary, "Make the keypair the one used to issue certificates")
+	cmd.Flags().StringVar(&options.KMSKey, "kms-key", options.KMSKey, "Cloud KMS key to wrap the private key with in the state store: an AWS KMS key ARN or a GCP KMS key resource name")
 
 	return cmd
 }
@@ -186,6 +198,7 @@ func RunCreateKeypair(ctx context.Context, f *util.Factory, out io.Writer, optio
 func createKeypair(out io.Writer, options *CreateKeypairOptions, name string, keyStore fi.CAStore) error {
 	var err error
 	var privateKey *pki.PrivateKey
+	kmsKey := options.KMSKey
 	if options.PrivateKeyPath != "" {
 		options.PrivateKeyPath = utils.ExpandPath(options.PrivateKeyPath)
 		privateKeyBytes, err := os.ReadFile(options.PrivateKeyPath)
@@ -193,9 +206,20 @@ func createKeypair(out io.Writer, options *CreateKeypairOptions, name string, ke
 			return fmt.Errorf("error reading user provided private key %q: %v", options.PrivateKeyPath, err)
 		}
 
-		privateKey, err = pki.ParsePEMPrivateKey(privateKeyBytes)
-		if err != nil {
-			return fmt.Errorf("error loading private key %q: %v", privateKeyBytes, err)
+		if fi.IsKMSWrappedPrivateKey(privateKeyBytes) {
+			var wrappedKMSKey string
+			privateKey, wrappedKMSKey, err = fi.UnwrapPrivateKey(privateKeyBytes)
+			if err != nil {
+				return fmt.Errorf("error loading private key %q: %v", options.PrivateKeyPath, err)
+			}
+			if kmsKey == "" {
+				kmsKey = wrappedKMSKey
+			}
+		} else {
+			privateKey, err = pki.ParsePEMPrivateKey(privateKeyBytes)
+			if err != nil {
+				return fmt.Errorf("error loading private key %q: %v", privateKeyBytes, err)
+			}
 		}
 	}
 
@@ -208,16 +232,9 @@ func createKeypair(out io.Writer, options *CreateKeypairOptions, name string, ke
 			}
 		}
 
-		serial := pki.BuildPKISerial(time.Now().UnixNano())
-		req := pki.IssueCertRequest{
-			Type:       "ca",
-			Subject:    pkix.Name{CommonName: name, SerialNumber: serial.String()},
-			Serial:     serial,
-			PrivateKey: privateKey,
-		}
-		cert, _, _, err = pki.IssueCert(&req, nil)
+		cert, err = issueCACertificate(name, privateKey)
 		if err != nil {
-			return fmt.Errorf("error issuing certificate: %v", err)
+			return err
 		}
 	} else {
 		options.CertPath = utils.ExpandPath(options.CertPath)
@@ -251,6 +268,9 @@ func createKeypair(out io.Writer, options *CreateKeypairOptions, name string, ke
 	if err != nil {
 		return err
 	}
+	if privateKey != nil {
+		item.KMSKey = kmsKey
+	}
 
 	err = keyStore.StoreKeyset(name, keyset)
 	if err != nil {
@@ -267,6 +287,22 @@ func createKeypair(out io.Writer, options *CreateKeypairOptions, name string, ke
 	return nil
 }
 
+// issueCACertificate issues a self-signed CA certificate for the private key.
+func issueCACertificate(name string, privateKey *pki.PrivateKey) (*pki.Certificate, error) {
+	serial := pki.BuildPKISerial(time.Now().UnixNano())
+	req := pki.IssueCertRequest{
+		Type:       "ca",
+		Subject:    pkix.Name{CommonName: name, SerialNumber: serial.String()},
+		Serial:     serial,
+		PrivateKey: privateKey,
+	}
+	cert, _, _, err := pki.IssueCert(&req, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error issuing certificate: %v", err)
+	}
+	return cert, nil
+}
+
 func completeKeyset(cluster *kopsapi.Cluster, clientSet simple.Clientset, args []string, filter func(name string, keyset *fi.Keyset) bool) (keyset *fi.Keyset, keyStore fi.CAStore, completions []string, directive cobra.ShellCompDirective) {
 	keyStore, err := clientSet.KeyStore(cluster)
 	if err != nil {
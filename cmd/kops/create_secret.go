@@ -36,6 +36,8 @@ func NewCmdCreateSecret(f *util.Factory, out io.Writer) *cobra.Command {
 	cmd.AddCommand(NewCmdCreateSecretCiliumPassword(f, out))
 	cmd.AddCommand(NewCmdCreateSecretDockerConfig(f, out))
 	cmd.AddCommand(NewCmdCreateSecretEncryptionConfig(f, out))
+	cmd.AddCommand(NewCmdCreateSecretGossipEncryption(f, out))
+	cmd.AddCommand(NewCmdCreateSecretRegistryPassword(f, out))
 	cmd.AddCommand(NewCmdCreateSecretWeavePassword(f, out))
 
 	sshPublicKey := NewCmdCreateSSHPublicKey(f, out)
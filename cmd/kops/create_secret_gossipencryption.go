@@ -0,0 +1,148 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/pkg/commands/commandutils"
+
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	createSecretGossipEncryptionLong = templates.LongDesc(i18n.T(`
+	Create a new gossip encryption secret and store it in the state store.
+	Used to encrypt memberlist gossip when spec.gossipConfig.encryption is enabled.
+
+	The secret holds one passphrase per line. The first passphrase encrypts gossip;
+	all of the passphrases are accepted when decrypting gossip, which allows rotating
+	the passphrase without interrupting gossip.
+
+	If no passphrase is provided, kOps will generate one at random.`))
+
+	createSecretGossipEncryptionExample = templates.Examples(i18n.T(`
+	# Create a new random gossip encryption passphrase.
+	kops create secret gossipencryption \
+		--name k8s-cluster.k8s.local --state s3://my-state-store
+
+	# Install specific gossip encryption passphrases.
+	kops create secret gossipencryption -f /path/to/passphrases \
+		--name k8s-cluster.k8s.local --state s3://my-state-store
+
+	# Replace the existing gossip encryption passphrases, for example to rotate them.
+	kops create secret gossipencryption -f /path/to/passphrases --force \
+		--name k8s-cluster.k8s.local --state s3://my-state-store
+	`))
+
+	createSecretGossipEncryptionShort = i18n.T(`Create gossip encryption passphrases.`)
+)
+
+type CreateSecretGossipEncryptionOptions struct {
+	ClusterName string
+	FilePath    string
+	Force       bool
+}
+
+func NewCmdCreateSecretGossipEncryption(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &CreateSecretGossipEncryptionOptions{}
+
+	cmd := &cobra.Command{
+		Use:               "gossipencryption [CLUSTER]",
+		Short:             createSecretGossipEncryptionShort,
+		Long:              createSecretGossipEncryptionLong,
+		Example:           createSecretGossipEncryptionExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunCreateSecretGossipEncryption(context.TODO(), f, out, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.FilePath, "filename", "f", "", "Path to a file with the gossip encryption passphrases, one per line")
+	cmd.Flags().BoolVar(&options.Force, "force", options.Force, "Force replace the secret if it already exists")
+
+	return cmd
+}
+
+func RunCreateSecretGossipEncryption(ctx context.Context, f *util.Factory, out io.Writer, options *CreateSecretGossipEncryptionOptions) error {
+	secret, err := fi.CreateSecret()
+	if err != nil {
+		return fmt.Errorf("creating gossip encryption passphrase: %v", err)
+	}
+
+	cluster, err := GetCluster(ctx, f, options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	clientset, err := f.Clientset()
+	if err != nil {
+		return err
+	}
+
+	secretStore, err := clientset.SecretStore(cluster)
+	if err != nil {
+		return err
+	}
+
+	if options.FilePath != "" {
+		var data []byte
+		if options.FilePath == "-" {
+			data, err = ConsumeStdin()
+			if err != nil {
+				return fmt.Errorf("reading gossip encryption passphrases from stdin: %v", err)
+			}
+		} else {
+			data, err = os.ReadFile(options.FilePath)
+			if err != nil {
+				return fmt.Errorf("reading gossip encryption passphrases file %v: %v", options.FilePath, err)
+			}
+		}
+
+		secret.Data = data
+	}
+
+	if _, err := cloudup.BuildGossipEncryptionKeys(secret); err != nil {
+		return err
+	}
+
+	if !options.Force {
+		_, created, err := secretStore.GetOrCreateSecret(cloudup.GossipEncryptionSecretName, secret)
+		if err != nil {
+			return fmt.Errorf("adding %s secret: %v", cloudup.GossipEncryptionSecretName, err)
+		}
+		if !created {
+			return fmt.Errorf("failed to create the %s secret as it already exists. Pass the `--force` flag to replace an existing secret", cloudup.GossipEncryptionSecretName)
+		}
+	} else {
+		_, err := secretStore.ReplaceSecret(cloudup.GossipEncryptionSecretName, secret)
+		if err != nil {
+			return fmt.Errorf("updating %s secret: %v", cloudup.GossipEncryptionSecretName, err)
+		}
+	}
+
+	return nil
+}
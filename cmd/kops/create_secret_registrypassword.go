@@ -0,0 +1,145 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	createSecretRegistryPasswordLong = templates.LongDesc(i18n.T(`
+	Create a new registry password secret and store it in the state store.
+	Used by containerd to authenticate to the registry mirrors whose auth.passwordSecret
+	references the secret.`))
+
+	createSecretRegistryPasswordExample = templates.Examples(i18n.T(`
+	# Install a registry password.
+	kops create secret registrypassword --secret-name mirror-password -f /path/to/password \
+		--name k8s-cluster.example.com --state s3://my-state-store
+
+	# Install a registry password via stdin.
+	kops create secret registrypassword --secret-name mirror-password -f - \
+		--name k8s-cluster.example.com --state s3://my-state-store
+
+	# Replace an existing registry password.
+	kops create secret registrypassword --secret-name mirror-password -f /path/to/password --force \
+		--name k8s-cluster.example.com --state s3://my-state-store
+	`))
+
+	createSecretRegistryPasswordShort = i18n.T(`Create a registry password.`)
+)
+
+type CreateSecretRegistryPasswordOptions struct {
+	ClusterName      string
+	SecretName       string
+	PasswordFilePath string
+	Force            bool
+}
+
+func NewCmdCreateSecretRegistryPassword(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &CreateSecretRegistryPasswordOptions{}
+
+	cmd := &cobra.Command{
+		Use:               "registrypassword [CLUSTER]",
+		Short:             createSecretRegistryPasswordShort,
+		Long:              createSecretRegistryPasswordLong,
+		Example:           createSecretRegistryPasswordExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunCreateSecretRegistryPassword(context.TODO(), f, out, options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.SecretName, "secret-name", "", "Name of the secret, as referenced by auth.passwordSecret")
+	cmd.MarkFlagRequired("secret-name")
+	cmd.Flags().StringVarP(&options.PasswordFilePath, "filename", "f", "", "Path to registry password file")
+	cmd.MarkFlagRequired("filename")
+	cmd.Flags().BoolVar(&options.Force, "force", options.Force, "Force replace the secret if it already exists")
+
+	return cmd
+}
+
+func RunCreateSecretRegistryPassword(ctx context.Context, f *util.Factory, out io.Writer, options *CreateSecretRegistryPasswordOptions) error {
+	if errs := validation.IsDNS1123Subdomain(options.SecretName); len(errs) != 0 {
+		return fmt.Errorf("invalid secret name %q: %v", options.SecretName, errs)
+	}
+
+	var data []byte
+	var err error
+	if options.PasswordFilePath == "-" {
+		data, err = ConsumeStdin()
+		if err != nil {
+			return fmt.Errorf("reading registry password file from stdin: %v", err)
+		}
+	} else {
+		data, err = os.ReadFile(options.PasswordFilePath)
+		if err != nil {
+			return fmt.Errorf("reading registry password file %v: %v", options.PasswordFilePath, err)
+		}
+	}
+	// Files usually end with a newline, which is not part of the password.
+	data = bytes.TrimRight(data, "\r\n")
+	if len(data) == 0 {
+		return fmt.Errorf("registry password must not be empty")
+	}
+
+	cluster, err := GetCluster(ctx, f, options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	clientset, err := f.Clientset()
+	if err != nil {
+		return err
+	}
+
+	secretStore, err := clientset.SecretStore(cluster)
+	if err != nil {
+		return err
+	}
+
+	secret := &fi.Secret{Data: data}
+	if !options.Force {
+		_, created, err := secretStore.GetOrCreateSecret(options.SecretName, secret)
+		if err != nil {
+			return fmt.Errorf("adding %s secret: %v", options.SecretName, err)
+		}
+		if !created {
+			return fmt.Errorf("failed to create the %s secret as it already exists. Pass the `--force` flag to replace an existing secret", options.SecretName)
+		}
+	} else {
+		_, err := secretStore.ReplaceSecret(options.SecretName, secret)
+		if err != nil {
+			return fmt.Errorf("updating %s secret: %v", options.SecretName, err)
+		}
+	}
+
+	return nil
+}
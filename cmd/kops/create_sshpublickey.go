@@ -26,6 +26,7 @@ import (
 	"github.com/spf13/pflag"
 	"k8s.io/kops/cmd/kops/util"
 	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/sshcredentials"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
 )
@@ -33,11 +34,19 @@ import (
 var (
 	createSSHPublicKeyLong = templates.LongDesc(i18n.T(`
 	Create a new SSH public key, and store the key in the state store.  The
-	key is not updated by this command.`))
+	key is not updated by this command.
+
+	A cluster can have several SSH public keys. The primary key is the one
+	configured as the cloud provider's key pair; on AWS, the other keys are
+	added to the authorized keys of the instances through their user-data.
+	The first key added is the primary key, unless --primary is used.`))
 
 	createSSHPublicKeyExample = templates.Examples(i18n.T(`
 	# Create a new SSH public key from the file ""~/.ssh/id_rsa.pub".
 	kops create sshpublickey k8s-cluster.example.com -i ~/.ssh/id_rsa.pub
+
+	# Add a new SSH public key and make it the primary key.
+	kops create sshpublickey k8s-cluster.example.com -i ~/.ssh/id_ed25519.pub --primary
 	`))
 
 	createSSHPublicKeyShort = i18n.T(`Create an SSH public key.`)
@@ -46,6 +55,7 @@ var (
 type CreateSSHPublicKeyOptions struct {
 	ClusterName   string
 	PublicKeyPath string
+	Primary       bool
 }
 
 func NewCmdCreateSSHPublicKey(f *util.Factory, out io.Writer) *cobra.Command {
@@ -69,6 +79,8 @@ func NewCmdCreateSSHPublicKey(f *util.Factory, out io.Writer) *cobra.Command {
 		return []string{"pub"}, cobra.ShellCompDirectiveFilterFileExt
 	})
 
+	cmd.Flags().BoolVar(&options.Primary, "primary", false, "Make the SSH public key the primary key")
+
 	cmd.Flags().SetNormalizeFunc(func(f *pflag.FlagSet, name string) pflag.NormalizedName {
 		switch name {
 		case "pubkey":
@@ -106,5 +118,15 @@ func RunCreateSSHPublicKey(ctx context.Context, f *util.Factory, out io.Writer,
 		return fmt.Errorf("error adding SSH public key: %v", err)
 	}
 
+	if options.Primary {
+		id, err := sshcredentials.Fingerprint(string(data))
+		if err != nil {
+			return fmt.Errorf("error fingerprinting SSH public key %v: %v", options.PublicKeyPath, err)
+		}
+		if err := sshCredentialStore.SetPrimarySSHPublicKey(id); err != nil {
+			return fmt.Errorf("error making SSH public key primary: %v", err)
+		}
+	}
+
 	return nil
 }
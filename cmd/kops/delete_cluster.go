@@ -44,6 +44,8 @@ type DeleteClusterOptions struct {
 	External    bool
 	Unregister  bool
 	ClusterName string
+	// Prune deletes only the cloud resources which are no longer in the cluster configuration
+	Prune bool
 }
 
 var (
@@ -57,6 +59,12 @@ var (
 	# The --yes option runs the command immediately.
 	kops delete cluster --name=k8s.cluster.site --yes
 
+	# Report the load balancers, volumes and network interfaces of a cluster
+	# which are no longer in its configuration, without deleting the cluster.
+	kops delete cluster --name=k8s.cluster.site --prune
+
+	# Delete those orphaned resources.
+	kops delete cluster --name=k8s.cluster.site --prune --yes
 	`))
 
 	deleteClusterShort = i18n.T("Delete a cluster.")
@@ -80,6 +88,7 @@ func NewCmdDeleteCluster(f *util.Factory, out io.Writer) *cobra.Command {
 	cmd.Flags().BoolVarP(&options.Yes, "yes", "y", options.Yes, "Specify --yes to delete the cluster")
 	cmd.Flags().BoolVar(&options.Unregister, "unregister", options.Unregister, "Don't delete cloud resources, just unregister the cluster")
 	cmd.Flags().BoolVar(&options.External, "external", options.External, "Delete an external cluster")
+	cmd.Flags().BoolVar(&options.Prune, "prune", options.Prune, "Don't delete the cluster, just the cloud resources it owns which are no longer in its configuration (AWS only)")
 
 	cmd.Flags().StringVar(&options.Region, "region", options.Region, "External cluster's cloud region")
 	cmd.RegisterFlagCompletionFunc("region", completeRegion)
@@ -93,6 +102,13 @@ func RunDeleteCluster(ctx context.Context, f *util.Factory, out io.Writer, optio
 		return fmt.Errorf("--name is required (for safety)")
 	}
 
+	if options.Prune {
+		if options.External || options.Unregister {
+			return fmt.Errorf("--prune cannot be used with --external or --unregister")
+		}
+		return runPruneCluster(ctx, f, out, options)
+	}
+
 	var cloud fi.Cloud
 	var cluster *kopsapi.Cluster
 	var err error
@@ -207,6 +223,75 @@ func RunDeleteCluster(ctx context.Context, f *util.Factory, out io.Writer, optio
 	return nil
 }
 
+// runPruneCluster deletes the cloud resources owned by the cluster which are not represented in the tasks
+// built from its configuration, after printing them for review
+func runPruneCluster(ctx context.Context, f *util.Factory, out io.Writer, options *DeleteClusterOptions) error {
+	// A dry-run builds the tasks from the cluster configuration, without changing anything
+	results, err := RunUpdateCluster(ctx, f, out, &UpdateClusterOptions{
+		Target:      cloudup.TargetDryRun,
+		ClusterName: options.ClusterName,
+		Quiet:       true,
+	})
+	if err != nil {
+		return err
+	}
+
+	cloud, err := cloudup.BuildCloud(results.Cluster)
+	if err != nil {
+		return err
+	}
+
+	allResources, err := resourceops.ListResources(cloud, results.Cluster, options.Region)
+	if err != nil {
+		return err
+	}
+
+	orphans, err := resourceops.ListOrphanedResources(cloud, results.Cluster, allResources, results.TaskMap)
+	if err != nil {
+		return err
+	}
+
+	if len(orphans) == 0 {
+		fmt.Fprintf(out, "No orphaned cloud resources to delete\n")
+		return nil
+	}
+
+	t := &tables.Table{}
+	t.AddColumn("TYPE", func(r *resources.OrphanedResource) string {
+		return r.Type
+	})
+	t.AddColumn("ID", func(r *resources.OrphanedResource) string {
+		return r.ID
+	})
+	t.AddColumn("NAME", func(r *resources.OrphanedResource) string {
+		return r.Name
+	})
+	t.AddColumn("REASON", func(r *resources.OrphanedResource) string {
+		return r.Reason
+	})
+	if err := t.Render(orphans, out, "TYPE", "NAME", "ID", "REASON"); err != nil {
+		return err
+	}
+
+	if !options.Yes {
+		fmt.Fprintf(out, "\nMust specify --yes to delete the orphaned cloud resources\n")
+		return nil
+	}
+
+	fmt.Fprintf(out, "\n")
+
+	orphanResources := make(map[string]*resources.Resource)
+	for _, orphan := range orphans {
+		orphanResources[orphan.Type+":"+orphan.ID] = orphan.Resource
+	}
+	if err := resourceops.DeleteResources(cloud, orphanResources); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\nDeleted %d orphaned cloud resources of cluster %q\n", len(orphans), options.ClusterName)
+	return nil
+}
+
 func completeRegion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	// TODO call into cloud provider(s) to get list of valid regions
 	return nil, cobra.ShellCompDirectiveNoFileComp
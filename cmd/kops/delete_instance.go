@@ -313,58 +313,82 @@ func completeInstanceOrNode(f commandutils.Factory, options *DeleteInstanceOptio
 		commandutils.ConfigureKlogForCompletion()
 		ctx := context.TODO()
 
-		cluster, clientSet, completions, directive := GetClusterForCompletion(ctx, f, nil)
-		if cluster == nil {
+		clusterName, completions, directive := GetClusterNameForCompletion(nil)
+		if clusterName == "" {
 			return completions, directive
 		}
 
-		var nodes []v1.Node
-		var err error
-		if !options.CloudOnly {
-			_, _, nodes, err = getNodes(ctx, cluster, false)
-			if err != nil {
-				cobra.CompErrorln(err.Error())
-			}
+		key := []string{"instances", clusterName}
+		if options.CloudOnly {
+			key = append(key, "cloudonly")
 		}
-
-		list, err := clientSet.InstanceGroupsFor(cluster).List(ctx, metav1.ListOptions{})
+		completions, err := commandutils.CachedCompletions(f, key, func() ([]string, error) {
+			return listInstanceCompletions(ctx, f, clusterName, options.CloudOnly)
+		})
 		if err != nil {
-			return commandutils.CompletionError("listing instance groups", err)
+			return commandutils.CompletionError("completing instances", err)
 		}
 
-		var instanceGroups []*kopsapi.InstanceGroup
-		for i := range list.Items {
-			instanceGroups = append(instanceGroups, &list.Items[i])
-		}
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
 
-		cloud, err := cloudup.BuildCloud(cluster)
-		if err != nil {
-			return commandutils.CompletionError("initializing cloud", err)
-		}
+// listInstanceCompletions lists the instances of the cluster, and their nodes unless cloudOnly is set.
+func listInstanceCompletions(ctx context.Context, f commandutils.Factory, clusterName string, cloudOnly bool) ([]string, error) {
+	cluster, err := GetCluster(ctx, f, clusterName)
+	if err != nil {
+		return nil, fmt.Errorf("getting cluster: %w", err)
+	}
 
-		groups, err := cloud.GetCloudGroups(cluster, instanceGroups, false, nodes)
+	clientSet, err := f.Clientset()
+	if err != nil {
+		return nil, fmt.Errorf("getting clientset: %w", err)
+	}
+
+	var nodes []v1.Node
+	if !cloudOnly {
+		_, _, nodes, err = getNodes(ctx, cluster, false)
 		if err != nil {
-			return commandutils.CompletionError("listing instances", err)
+			cobra.CompErrorln(err.Error())
 		}
+	}
 
-		completions = nil
-		longestGroup := 0
-		for _, group := range groups {
-			if group.InstanceGroup != nil && longestGroup < len(group.InstanceGroup.Name) {
-				longestGroup = len(group.InstanceGroup.Name)
-			}
+	list, err := clientSet.InstanceGroupsFor(cluster).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing instance groups: %w", err)
+	}
+
+	var instanceGroups []*kopsapi.InstanceGroup
+	for i := range list.Items {
+		instanceGroups = append(instanceGroups, &list.Items[i])
+	}
+
+	cloud, err := cloudup.BuildCloud(cluster)
+	if err != nil {
+		return nil, fmt.Errorf("initializing cloud: %w", err)
+	}
+
+	groups, err := cloud.GetCloudGroups(cluster, instanceGroups, false, nodes)
+	if err != nil {
+		return nil, fmt.Errorf("listing instances: %w", err)
+	}
+
+	var completions []string
+	longestGroup := 0
+	for _, group := range groups {
+		if group.InstanceGroup != nil && longestGroup < len(group.InstanceGroup.Name) {
+			longestGroup = len(group.InstanceGroup.Name)
 		}
-		for _, group := range groups {
-			for _, instance := range group.Ready {
-				completions = appendInstance(completions, instance, longestGroup)
-			}
-			for _, instance := range group.NeedUpdate {
-				completions = appendInstance(completions, instance, longestGroup)
-			}
+	}
+	for _, group := range groups {
+		for _, instance := range group.Ready {
+			completions = appendInstance(completions, instance, longestGroup)
+		}
+		for _, instance := range group.NeedUpdate {
+			completions = appendInstance(completions, instance, longestGroup)
 		}
-
-		return completions, cobra.ShellCompDirectiveNoFileComp
 	}
+	return completions, nil
 }
 
 func appendInstance(completions []string, instance *cloudinstances.CloudInstance, longestGroup int) []string {
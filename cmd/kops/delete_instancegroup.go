@@ -21,7 +21,9 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -46,6 +48,11 @@ var (
 		# The --yes option runs the command immediately.
 		# Note that the cloud resources will be deleted immediately, without running "kops update cluster"
 		kops delete ig --name=k8s-cluster.example.com node-example --yes
+
+		# Drain the nodes of the instancegroup and scale down a workload before deleting it.
+		kops delete ig --name=k8s-cluster.example.com node-example \
+		  --drain --drain-timeout=10m \
+		  --pre-delete-hook="kubectl scale deployment/batch --replicas=0" --yes
 		`))
 
 	deleteInstanceGroupShort = i18n.T(`Delete instance group.`)
@@ -55,10 +62,27 @@ type DeleteInstanceGroupOptions struct {
 	Yes         bool
 	ClusterName string
 	GroupName   string
+
+	// Drain cordons and drains the nodes of the instance group before deleting it.
+	Drain bool
+	// DrainTimeout is the maximum time to wait while draining a node.
+	DrainTimeout time.Duration
+	// FailOnDrainError aborts the deletion if a node fails to drain.
+	FailOnDrainError bool
+
+	// PreDeleteHook is a shell command run after draining and before the cloud resources are deleted.
+	PreDeleteHook string
+}
+
+func (o *DeleteInstanceGroupOptions) InitDefaults() {
+	o.Drain = false
+	o.DrainTimeout = 15 * time.Minute
+	o.FailOnDrainError = true
 }
 
 func NewCmdDeleteInstanceGroup(f *util.Factory, out io.Writer) *cobra.Command {
 	options := &DeleteInstanceGroupOptions{}
+	options.InitDefaults()
 
 	cmd := &cobra.Command{
 		Use:     "instancegroup INSTANCE_GROUP",
@@ -115,14 +139,16 @@ func NewCmdDeleteInstanceGroup(f *util.Factory, out io.Writer) *cobra.Command {
 	}
 
 	cmd.Flags().BoolVarP(&options.Yes, "yes", "y", options.Yes, "Specify --yes to immediately delete the instance group")
+	cmd.Flags().BoolVar(&options.Drain, "drain", options.Drain, "Cordon and drain the nodes of the instance group before deleting it")
+	cmd.Flags().DurationVar(&options.DrainTimeout, "drain-timeout", options.DrainTimeout, "Maximum time to wait for a node to drain")
+	cmd.Flags().BoolVar(&options.FailOnDrainError, "fail-on-drain-error", options.FailOnDrainError, "Abort the deletion if a node fails to drain")
+	cmd.Flags().StringVar(&options.PreDeleteHook, "pre-delete-hook", options.PreDeleteHook, "Shell command to run after draining and before deleting the cloud resources")
 
 	return cmd
 }
 
 // RunDeleteInstanceGroup runs the deletion of an instance group
 func RunDeleteInstanceGroup(ctx context.Context, f *util.Factory, out io.Writer, options *DeleteInstanceGroupOptions) error {
-	// TODO make this drain and validate the ig?
-	// TODO implement drain and validate logic
 	groupName := options.GroupName
 	if groupName == "" {
 		return fmt.Errorf("GroupName is required")
@@ -182,6 +208,29 @@ func RunDeleteInstanceGroup(ctx context.Context, f *util.Factory, out io.Writer,
 	d.Cloud = cloud
 	d.Clientset = clientset
 
+	if options.Drain {
+		k8sClient, _, _, err := getNodes(ctx, cluster, false)
+		if err != nil {
+			return err
+		}
+
+		d.Drainer = &instancegroups.RollingUpdateCluster{
+			Ctx:              ctx,
+			Cluster:          cluster,
+			Cloud:            cloud,
+			K8sClient:        k8sClient,
+			ClusterName:      options.ClusterName,
+			FailOnDrainError: options.FailOnDrainError,
+			DrainTimeout:     options.DrainTimeout,
+		}
+	}
+
+	if options.PreDeleteHook != "" {
+		d.PreDeleteHook = func(group *kops.InstanceGroup) error {
+			return runPreDeleteHook(ctx, out, options.PreDeleteHook, cluster.ObjectMeta.Name, group.ObjectMeta.Name)
+		}
+	}
+
 	err = d.DeleteInstanceGroup(group)
 	if err != nil {
 		return err
@@ -191,3 +240,18 @@ func RunDeleteInstanceGroup(ctx context.Context, f *util.Factory, out io.Writer,
 
 	return nil
 }
+
+// runPreDeleteHook runs a user supplied shell command, exposing the cluster and instance group names to it
+func runPreDeleteHook(ctx context.Context, out io.Writer, command string, clusterName string, groupName string) error {
+	fmt.Fprintf(out, "Running pre-delete hook for InstanceGroup %q\n", groupName)
+
+	cmd := exec.CommandContext(ctx, "/bin/sh", "-c", command)
+	cmd.Env = append(os.Environ(),
+		"KOPS_CLUSTER_NAME="+clusterName,
+		"KOPS_INSTANCE_GROUP="+groupName,
+	)
+	cmd.Stdout = out
+	cmd.Stderr = os.Stderr
+
+	return cmd.Run()
+}
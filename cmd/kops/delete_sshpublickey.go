@@ -30,9 +30,12 @@ import (
 
 var (
 	deleteSSHPublicKeyExample = templates.Examples(i18n.T(`
-	# Delete the SSH public key for a cluster
+	# Delete the SSH public keys for a cluster
 	kops delete sshpublickey k8s-cluster.example.com
 
+	# Delete a single SSH public key, using the ID shown by "kops get sshpublickeys"
+	kops delete sshpublickey k8s-cluster.example.com --id 10:73:7a:e8:1d:b1:2c:06:f1:97:84:e2:93:2a:b4:9b
+
 	`))
 
 	deleteSSHPublicKeyShort = i18n.T(`Delete an SSH public key.`)
@@ -40,6 +43,7 @@ var (
 
 type DeleteSSHPublicKeyOptions struct {
 	ClusterName string
+	ID          string
 }
 
 func NewCmdDeleteSSHPublicKey(f *util.Factory, out io.Writer) *cobra.Command {
@@ -58,6 +62,8 @@ func NewCmdDeleteSSHPublicKey(f *util.Factory, out io.Writer) *cobra.Command {
 		},
 	}
 
+	cmd.Flags().StringVar(&options.ID, "id", "", "ID of the SSH public key to delete; all keys are deleted if not specified")
+
 	return cmd
 }
 
@@ -77,6 +83,13 @@ func RunDeleteSSHPublicKey(ctx context.Context, f *util.Factory, out io.Writer,
 		return err
 	}
 
+	if options.ID != "" {
+		if err := sshCredentialStore.DeleteSSHPublicKey(options.ID); err != nil {
+			return fmt.Errorf("error deleting SSH public key: %v", err)
+		}
+		return nil
+	}
+
 	if err := sshCredentialStore.DeleteSSHCredential(); err != nil {
 		return fmt.Errorf("error deleting SSH public key: %v", err)
 	}
@@ -33,6 +33,7 @@ func NewCmdExport(f *util.Factory, out io.Writer) *cobra.Command {
 	}
 
 	// create subcommands
+	cmd.AddCommand(NewCmdExportInstanceGroup(f, out))
 	cmd.AddCommand(NewCmdExportKubeconfig(f, out))
 
 	return cmd
@@ -0,0 +1,160 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/commands"
+	"k8s.io/kops/upup/pkg/fi/cloudup"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	exportInstanceGroupLong = templates.LongDesc(i18n.T(`
+	Export the artifacts needed to launch instances into an instance group: the launch template,
+	the AMI, the instance type, the IAM instance profile, the security groups, the subnets and the user data.
+
+	This is intended for instance groups with the External manager, whose instances are launched by a provisioner
+	outside of kOps. kOps maintains their launch template, but doesn't create an autoscaling group for them.
+	The cluster should have been updated with "kops update cluster --yes" first, so the IDs of the security groups
+	and subnets are known.`))
+
+	exportInstanceGroupExample = templates.Examples(i18n.T(`
+	# Export the artifacts of an externally managed instance group
+	kops export instancegroup --name k8s-cluster.example.com batch
+
+	# Export the artifacts as JSON
+	kops export instancegroup --name k8s-cluster.example.com batch -o json
+	`))
+
+	exportInstanceGroupShort = i18n.T(`Export the artifacts needed to launch instances into an instance group.`)
+)
+
+type ExportInstanceGroupOptions struct {
+	ClusterName string
+	GroupName   string
+	Output      string
+}
+
+func NewCmdExportInstanceGroup(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &ExportInstanceGroupOptions{
+		Output: OutputYaml,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "instancegroup INSTANCE_GROUP",
+		Aliases: []string{"instancegroups", "ig"},
+		Short:   exportInstanceGroupShort,
+		Long:    exportInstanceGroupLong,
+		Example: exportInstanceGroupExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			options.ClusterName = rootCommand.ClusterName(true)
+
+			if options.ClusterName == "" {
+				return fmt.Errorf("--name is required")
+			}
+
+			if len(args) != 1 {
+				return fmt.Errorf("must specify the name of one instance group to export")
+			}
+
+			options.GroupName = args[0]
+			return nil
+		},
+		ValidArgsFunction: completeInstanceGroup(f, nil, nil),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunExportInstanceGroup(context.TODO(), f, out, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.Output, "output", "o", options.Output, "output format. One of: yaml, json")
+	cmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{OutputYaml, OutputJSON}, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	return cmd
+}
+
+func RunExportInstanceGroup(ctx context.Context, f *util.Factory, out io.Writer, options *ExportInstanceGroupOptions) error {
+	if options.Output != OutputYaml && options.Output != OutputJSON {
+		return fmt.Errorf("unsupported output format: %q", options.Output)
+	}
+
+	cluster, err := GetCluster(ctx, f, options.ClusterName)
+	if err != nil {
+		return err
+	}
+	if cluster.Spec.GetCloudProvider() != kops.CloudProviderAWS {
+		return fmt.Errorf("exporting instance groups is only supported on AWS")
+	}
+
+	clientset, err := f.Clientset()
+	if err != nil {
+		return err
+	}
+
+	ig, err := clientset.InstanceGroupsFor(cluster).Get(ctx, options.GroupName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error reading InstanceGroup %q: %v", options.GroupName, err)
+	}
+
+	// A dry-run renders the user data and looks up the IDs of the existing resources, without changing anything
+	results, err := RunUpdateCluster(ctx, f, out, &UpdateClusterOptions{
+		Target:      cloudup.TargetDryRun,
+		ClusterName: options.ClusterName,
+		Quiet:       true,
+	})
+	if err != nil {
+		return err
+	}
+
+	cloud, err := cloudup.BuildCloud(results.Cluster)
+	if err != nil {
+		return err
+	}
+
+	artifacts, err := commands.BuildInstanceGroupArtifacts(cloud.(awsup.AWSCloud), ig, results.TaskMap)
+	if err != nil {
+		return err
+	}
+
+	var b []byte
+	switch options.Output {
+	case OutputYaml:
+		b, err = yaml.Marshal(artifacts)
+	case OutputJSON:
+		b, err = json.MarshalIndent(artifacts, "", "  ")
+	}
+	if err != nil {
+		return fmt.Errorf("error serializing artifacts: %v", err)
+	}
+	if _, err := out.Write(b); err != nil {
+		return fmt.Errorf("error writing to output: %v", err)
+	}
+	return nil
+}
@@ -17,19 +17,24 @@ limitations under the License.
 package main
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/jsonpath"
 	"k8s.io/kops/cmd/kops/util"
 	api "k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/commands/commandutils"
 	"k8s.io/kops/pkg/kopscodecs"
+	"k8s.io/kops/util/pkg/tables"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
 )
@@ -87,6 +92,7 @@ func NewCmdGet(f *util.Factory, out io.Writer) *cobra.Command {
 	})
 
 	// create subcommands
+	cmd.AddCommand(NewCmdGetAddons(f, out, options))
 	cmd.AddCommand(NewCmdGetAssets(f, out, options))
 	cmd.AddCommand(NewCmdGetCluster(f, out, options))
 	cmd.AddCommand(NewCmdGetInstanceGroups(f, out, options))
@@ -165,12 +171,12 @@ func RunGet(ctx context.Context, f commandutils.Factory, out io.Writer, options
 
 	case OutputTable:
 		fmt.Fprintf(out, "Cluster\n")
-		err = clusterOutputTable([]*api.Cluster{cluster}, out)
+		err = clusterOutputTable([]*api.Cluster{cluster}, out, nil)
 		if err != nil {
 			return err
 		}
 		fmt.Fprintf(out, "\nInstance Groups\n")
-		err = igOutputTable(cluster, instancegroups, out)
+		err = igOutputTable(cluster, instancegroups, out, nil)
 		if err != nil {
 			return err
 		}
@@ -228,3 +234,60 @@ func marshalJSON(obj runtime.Object) ([]byte, error) {
 	}
 	return j, nil
 }
+
+// columnsFlagHelp describes the values of the --columns flag of the get commands
+const columnsFlagHelp = "Columns of the table output. Each column is either one of %s, or HEADER:{.json.path} " +
+	"to show a field of the object, as in kubectl custom-columns"
+
+// renderTable writes the items as a table with the given columns, or with the default columns if none are given.
+// A column is either the name of a column of the table, or "HEADER:{.json.path}", which shows the result of
+// the JSONPath template against the versioned JSON of the item.
+func renderTable(t *tables.Table, items interface{}, out io.Writer, columns []string, defaultColumns ...string) error {
+	if len(columns) == 0 {
+		return t.Render(items, out, defaultColumns...)
+	}
+
+	var names []string
+	for _, column := range columns {
+		header, template, custom := strings.Cut(column, ":")
+		if !custom {
+			names = append(names, strings.ToUpper(column))
+			continue
+		}
+
+		if header == "" || template == "" {
+			return fmt.Errorf("invalid column %q, must be HEADER:{.json.path}", column)
+		}
+		if !strings.HasPrefix(template, "{") {
+			template = "{" + template + "}"
+		}
+		parser := jsonpath.New(header).AllowMissingKeys(true)
+		if err := parser.Parse(template); err != nil {
+			return fmt.Errorf("error parsing column %q: %v", column, err)
+		}
+		t.AddColumn(header, func(obj runtime.Object) string {
+			return jsonPathValue(parser, obj)
+		})
+		names = append(names, header)
+	}
+
+	return t.Render(items, out, names...)
+}
+
+// jsonPathValue returns the result of a JSONPath template against the versioned JSON of an object
+func jsonPathValue(parser *jsonpath.JSONPath, obj runtime.Object) string {
+	j, err := marshalJSON(obj)
+	if err != nil {
+		return "<error>"
+	}
+	var data interface{}
+	if err := json.Unmarshal(j, &data); err != nil {
+		return "<error>"
+	}
+
+	var b bytes.Buffer
+	if err := parser.Execute(&b, data); err != nil || b.Len() == 0 {
+		return "<none>"
+	}
+	return b.String()
+}
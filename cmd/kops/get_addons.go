@@ -17,13 +17,241 @@ limitations under the License.
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"io"
+	"net/url"
+	"sort"
 
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/kops/channels/pkg/channels"
+	"k8s.io/kops/cmd/kops/util"
 	api "k8s.io/kops/pkg/apis/kops"
+	apiutil "k8s.io/kops/pkg/apis/kops/util"
+	"k8s.io/kops/pkg/commands/commandutils"
 	"k8s.io/kops/util/pkg/tables"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+	"sigs.k8s.io/yaml"
 )
 
+var (
+	getAddonsLong = templates.LongDesc(i18n.T(`
+	Display the addons managed by kOps.
+
+	For each addon in the bootstrap channel written by the last kops update cluster,
+	this shows the version deployed to the cluster, the version in the channel,
+	and whether the channel version will be applied to the cluster.`))
+
+	getAddonsExample = templates.Examples(i18n.T(`
+	# Display the managed addons of a cluster.
+	kops get addons --name k8s-cluster.example.com
+
+	# Display the managed addons as JSON.
+	kops get addons --name k8s-cluster.example.com -o json
+	`))
+
+	getAddonsShort = i18n.T(`Display managed addons.`)
+)
+
+// renderableAddon is the status of a managed addon, as displayed by kops get addons.
+type renderableAddon struct {
+	Name                 string `json:"name"`
+	Namespace            string `json:"namespace"`
+	DeployedVersion      string `json:"deployedVersion,omitempty"`
+	DeployedManifestHash string `json:"deployedManifestHash,omitempty"`
+	ChannelVersion       string `json:"channelVersion,omitempty"`
+	ChannelManifestHash  string `json:"channelManifestHash,omitempty"`
+	NeedsUpdate          bool   `json:"needsUpdate"`
+}
+
+func NewCmdGetAddons(f *util.Factory, out io.Writer, options *GetOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "addons [CLUSTER]",
+		Aliases:           []string{"addon"},
+		Short:             getAddonsShort,
+		Long:              getAddonsLong,
+		Example:           getAddonsExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunGetAddons(context.TODO(), f, out, options)
+		},
+	}
+
+	return cmd
+}
+
+func RunGetAddons(ctx context.Context, f *util.Factory, out io.Writer, options *GetOptions) error {
+	clientset, err := f.Clientset()
+	if err != nil {
+		return err
+	}
+
+	cluster, err := clientset.GetCluster(ctx, options.ClusterName)
+	if err != nil {
+		return err
+	}
+	if cluster == nil {
+		return fmt.Errorf("cluster not found %q", options.ClusterName)
+	}
+
+	kubernetesVersion, err := apiutil.ParseKubernetesVersion(cluster.Spec.KubernetesVersion)
+	if err != nil {
+		return fmt.Errorf("unable to parse kubernetes version %q: %v", cluster.Spec.KubernetesVersion, err)
+	}
+
+	configBase, err := clientset.ConfigBaseFor(cluster)
+	if err != nil {
+		return err
+	}
+	channelPath := configBase.Join("addons", "bootstrap-channel.yaml")
+	channelData, err := channelPath.ReadFile()
+	if err != nil {
+		return fmt.Errorf("error reading bootstrap channel %q: %v", channelPath, err)
+	}
+	channelLocation, err := url.Parse(channelPath.Path())
+	if err != nil {
+		return fmt.Errorf("unable to parse channel location %q: %v", channelPath.Path(), err)
+	}
+	channel, err := channels.ParseAddons(channelPath.Path(), channelLocation, channelData)
+	if err != nil {
+		return err
+	}
+	menu, err := channel.GetCurrent(*kubernetesVersion)
+	if err != nil {
+		return err
+	}
+
+	k8sClient, err := createK8sClient(cluster)
+	if err != nil {
+		return err
+	}
+	namespaces, err := k8sClient.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("error listing namespaces: %v", err)
+	}
+
+	addons := buildAddonStatus(menu, namespaces.Items)
+
+	switch options.Output {
+	case OutputTable:
+		return addonStatusOutputTable(addons, out)
+	case OutputYaml:
+		y, err := yaml.Marshal(addons)
+		if err != nil {
+			return fmt.Errorf("unable to marshal YAML: %v", err)
+		}
+		if _, err := out.Write(y); err != nil {
+			return fmt.Errorf("error writing to output: %v", err)
+		}
+		return nil
+	case OutputJSON:
+		j, err := json.MarshalIndent(addons, "", "  ")
+		if err != nil {
+			return fmt.Errorf("unable to marshal JSON: %v", err)
+		}
+		if _, err := out.Write(j); err != nil {
+			return fmt.Errorf("error writing to output: %v", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format: %q", options.Output)
+	}
+}
+
+// buildAddonStatus compares the addons in the channel with the versions recorded on the namespaces of the cluster.
+// Addons that are deployed but no longer in the channel are included, without a channel version.
+func buildAddonStatus(menu *channels.AddonMenu, namespaces []v1.Namespace) []*renderableAddon {
+	deployed := make(map[string]map[string]*channels.ChannelVersion)
+	for i := range namespaces {
+		ns := &namespaces[i]
+		deployed[ns.Name] = channels.FindChannelVersions(ns)
+	}
+
+	var addons []*renderableAddon
+	for name, addon := range menu.Addons {
+		namespace := addon.GetNamespace()
+		channelVersion := addon.ChannelVersion()
+
+		a := &renderableAddon{
+			Name:                name,
+			Namespace:           namespace,
+			ChannelVersion:      channelVersion.Version,
+			ChannelManifestHash: channelVersion.ManifestHash,
+			NeedsUpdate:         true,
+		}
+		if existing := deployed[namespace][name]; existing != nil {
+			a.DeployedVersion = existing.Version
+			a.DeployedManifestHash = existing.ManifestHash
+			a.NeedsUpdate = channelVersion.Replaces(name, existing)
+			delete(deployed[namespace], name)
+		}
+		addons = append(addons, a)
+	}
+
+	for namespace, versions := range deployed {
+		for name, existing := range versions {
+			addons = append(addons, &renderableAddon{
+				Name:                 name,
+				Namespace:            namespace,
+				DeployedVersion:      existing.Version,
+				DeployedManifestHash: existing.ManifestHash,
+			})
+		}
+	}
+
+	sort.Slice(addons, func(i, j int) bool {
+		if addons[i].Namespace != addons[j].Namespace {
+			return addons[i].Namespace < addons[j].Namespace
+		}
+		return addons[i].Name < addons[j].Name
+	})
+	return addons
+}
+
+func addonStatusOutputTable(addons []*renderableAddon, out io.Writer) error {
+	t := &tables.Table{}
+	t.AddColumn("NAME", func(a *renderableAddon) string {
+		return a.Name
+	})
+	t.AddColumn("NAMESPACE", func(a *renderableAddon) string {
+		return a.Namespace
+	})
+	t.AddColumn("DEPLOYED", func(a *renderableAddon) string {
+		return versionOrHash(a.DeployedVersion, a.DeployedManifestHash)
+	})
+	t.AddColumn("CHANNEL", func(a *renderableAddon) string {
+		return versionOrHash(a.ChannelVersion, a.ChannelManifestHash)
+	})
+	t.AddColumn("UPDATE", func(a *renderableAddon) string {
+		if a.NeedsUpdate {
+			return "yes"
+		}
+		return "no"
+	})
+	return t.Render(addons, out, "NAMESPACE", "NAME", "DEPLOYED", "CHANNEL", "UPDATE")
+}
+
+// versionOrHash returns the version of an addon, falling back to a shortened manifest hash
+// for addons deployed by versions of channels that did not record the version.
+func versionOrHash(version, manifestHash string) string {
+	if version != "" {
+		return version
+	}
+	if manifestHash == "" {
+		return "-"
+	}
+	if len(manifestHash) > 12 {
+		manifestHash = manifestHash[:12]
+	}
+	return "sha256:" + manifestHash
+}
+
 func addonsOutputTable(cluster *api.Cluster, addons []*unstructured.Unstructured, out io.Writer) error {
 	t := &tables.Table{}
 	t.AddColumn("NAME", func(o *unstructured.Unstructured) string {
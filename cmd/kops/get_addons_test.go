@@ -0,0 +1,143 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/channels/pkg/channels"
+)
+
+func TestBuildAddonStatus(t *testing.T) {
+	channel := `
+kind: Addons
+metadata:
+  name: bootstrap
+spec:
+  addons:
+  - name: coredns.addons.k8s.io
+    version: 9.99.0
+    manifestHash: aaaa
+  - name: dns-controller.addons.k8s.io
+    version: 1.25.0
+    manifestHash: bbbb
+  - name: kops-controller.addons.k8s.io
+    version: 1.25.0
+    manifestHash: cccc
+  - name: example.user.addons.kops.k8s.io
+    namespace: example
+    manifestHash: dddd
+`
+	location, _ := url.Parse("memfs://clusters.example.com/minimal.example.com/addons/bootstrap-channel.yaml")
+	addons, err := channels.ParseAddons("bootstrap", location, []byte(channel))
+	if err != nil {
+		t.Fatalf("error parsing channel: %v", err)
+	}
+	menu, err := addons.GetCurrent(semver.MustParse("1.25.0"))
+	if err != nil {
+		t.Fatalf("error building addon menu: %v", err)
+	}
+
+	namespaces := []v1.Namespace{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "kube-system",
+				Annotations: map[string]string{
+					"addons.k8s.io/coredns.addons.k8s.io":        `{"version":"9.99.0","manifestHash":"aaaa","systemGeneration":1}`,
+					"addons.k8s.io/dns-controller.addons.k8s.io": `{"version":"1.24.0","manifestHash":"0000","systemGeneration":1}`,
+					"addons.k8s.io/removed.addons.k8s.io":        `{"manifestHash":"eeee","systemGeneration":1}`,
+					"unrelated":                                  "value",
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "default",
+			},
+		},
+	}
+
+	actual := buildAddonStatus(menu, namespaces)
+	expected := []*renderableAddon{
+		{
+			Name:                "example.user.addons.kops.k8s.io",
+			Namespace:           "example",
+			ChannelManifestHash: "dddd",
+			NeedsUpdate:         true,
+		},
+		{
+			Name:                 "coredns.addons.k8s.io",
+			Namespace:            "kube-system",
+			DeployedVersion:      "9.99.0",
+			DeployedManifestHash: "aaaa",
+			ChannelVersion:       "9.99.0",
+			ChannelManifestHash:  "aaaa",
+			NeedsUpdate:          false,
+		},
+		{
+			Name:                 "dns-controller.addons.k8s.io",
+			Namespace:            "kube-system",
+			DeployedVersion:      "1.24.0",
+			DeployedManifestHash: "0000",
+			ChannelVersion:       "1.25.0",
+			ChannelManifestHash:  "bbbb",
+			NeedsUpdate:          true,
+		},
+		{
+			Name:                "kops-controller.addons.k8s.io",
+			Namespace:           "kube-system",
+			ChannelVersion:      "1.25.0",
+			ChannelManifestHash: "cccc",
+			NeedsUpdate:         true,
+		},
+		{
+			Name:                 "removed.addons.k8s.io",
+			Namespace:            "kube-system",
+			DeployedManifestHash: "eeee",
+		},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		for _, a := range actual {
+			t.Logf("actual: %+v", a)
+		}
+		t.Errorf("unexpected addon status")
+	}
+}
+
+func TestVersionOrHash(t *testing.T) {
+	grid := []struct {
+		version      string
+		manifestHash string
+		expected     string
+	}{
+		{version: "1.25.0", manifestHash: "0123456789abcdef", expected: "1.25.0"},
+		{manifestHash: "0123456789abcdef", expected: "sha256:0123456789ab"},
+		{manifestHash: "0123", expected: "sha256:0123"},
+		{expected: "-"},
+	}
+	for _, g := range grid {
+		actual := versionOrHash(g.version, g.manifestHash)
+		if actual != g.expected {
+			t.Errorf("versionOrHash(%q, %q) = %q, expected %q", g.version, g.manifestHash, actual, g.expected)
+		}
+	}
+}
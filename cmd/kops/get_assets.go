@@ -22,7 +22,10 @@ import (
 	"fmt"
 	"io"
 
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/assets"
+	"k8s.io/kops/pkg/commands"
 	"k8s.io/kops/pkg/commands/commandutils"
 	"k8s.io/kops/pkg/pretty"
 	"k8s.io/kubectl/pkg/util/i18n"
@@ -33,6 +36,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup"
 )
 
@@ -42,14 +46,26 @@ var (
 	(original) and download (local repository) locations.
 
 	When invoked with the ` + pretty.Bash("--copy") + ` flag, will copy each asset from the
-	canonical to the download location.`))
+	canonical to the download location. This includes the files nodeup downloads, such as nodeup itself,
+	containerd, runc and the CNI plugins, and the container images of the control plane and the addons.
+	Assets that are already present at their download location are skipped, so an interrupted copy
+	resumes when the command is run again.
+
+	The ` + pretty.Bash("--file-repository") + ` and ` + pretty.Bash("--container-registry") + ` flags set the
+	locations to mirror the assets to. Once all assets have been copied, they are written to the cluster spec,
+	so that the cluster only uses the mirrored assets.`))
 
 	getAssetsExample = templates.Examples(i18n.T(`
 	# Display all assets.
 	kops get assets
 
 	# Copy assets to the local repositories configured in the cluster spec.
-	kops get assets --copy 
+	kops get assets --copy
+
+	# Mirror all assets for an air-gapped cluster, and use the mirrors in the cluster spec.
+	kops get assets --copy --copy-parallelism 10 \
+	  --file-repository https://s3.amazonaws.com/my-assets/kops \
+	  --container-registry registry.example.com/kops
 	`))
 
 	getAssetsShort = i18n.T(`Display assets for cluster.`)
@@ -58,6 +74,13 @@ var (
 type GetAssetsOptions struct {
 	*GetOptions
 	Copy bool
+	// CopyParallelism is the number of assets copied at the same time.
+	CopyParallelism int
+
+	// FileRepository overrides the fileRepository of the cluster spec, and is written to it after a successful copy.
+	FileRepository string
+	// ContainerRegistry overrides the containerRegistry of the cluster spec, and is written to it after a successful copy.
+	ContainerRegistry string
 }
 
 type Image struct {
@@ -80,7 +103,8 @@ type AssetResult struct {
 
 func NewCmdGetAssets(f *util.Factory, out io.Writer, getOptions *GetOptions) *cobra.Command {
 	options := GetAssetsOptions{
-		GetOptions: getOptions,
+		GetOptions:      getOptions,
+		CopyParallelism: 5,
 	}
 
 	cmd := &cobra.Command{
@@ -96,15 +120,44 @@ func NewCmdGetAssets(f *util.Factory, out io.Writer, getOptions *GetOptions) *co
 	}
 
 	cmd.Flags().BoolVar(&options.Copy, "copy", options.Copy, "copy assets to local repository")
+	cmd.Flags().IntVar(&options.CopyParallelism, "copy-parallelism", options.CopyParallelism, "number of assets to copy at the same time")
+	cmd.Flags().StringVar(&options.FileRepository, "file-repository", options.FileRepository, "URL of the file repository to mirror file assets to")
+	cmd.Flags().StringVar(&options.ContainerRegistry, "container-registry", options.ContainerRegistry, "container registry to mirror image assets to")
 
 	return cmd
 }
 
 func RunGetAssets(ctx context.Context, f *util.Factory, out io.Writer, options *GetAssetsOptions) error {
+	if options.CopyParallelism < 1 {
+		return fmt.Errorf("--copy-parallelism must be at least 1")
+	}
+
+	var assetsOverride *kops.Assets
+	if options.FileRepository != "" || options.ContainerRegistry != "" {
+		cluster, err := GetCluster(ctx, f, options.ClusterName)
+		if err != nil {
+			return err
+		}
+		assetsOverride = &kops.Assets{}
+		if cluster.Spec.Assets != nil {
+			assetsOverride = cluster.Spec.Assets.DeepCopy()
+		}
+		if options.FileRepository != "" {
+			assetsOverride.FileRepository = fi.String(options.FileRepository)
+		}
+		if options.ContainerRegistry != "" {
+			if assetsOverride.ContainerProxy != nil {
+				return fmt.Errorf("cannot mirror images to --container-registry while the cluster uses a containerProxy")
+			}
+			assetsOverride.ContainerRegistry = fi.String(options.ContainerRegistry)
+		}
+	}
+
 	updateClusterResults, err := RunUpdateCluster(ctx, f, out, &UpdateClusterOptions{
 		Target:      cloudup.TargetDryRun,
 		GetAssets:   true,
 		ClusterName: options.ClusterName,
+		Assets:      assetsOverride,
 	})
 	if err != nil {
 		return err
@@ -141,10 +194,17 @@ func RunGetAssets(ctx context.Context, f *util.Factory, out io.Writer, options *
 	}
 
 	if options.Copy {
-		err := assets.Copy(updateClusterResults.ImageAssets, updateClusterResults.FileAssets, updateClusterResults.Cluster)
+		err := assets.Copy(updateClusterResults.ImageAssets, updateClusterResults.FileAssets, updateClusterResults.Cluster, options.CopyParallelism)
 		if err != nil {
 			return err
 		}
+
+		if assetsOverride != nil {
+			if err := updateClusterAssets(ctx, f, options.ClusterName, assetsOverride); err != nil {
+				return err
+			}
+			klog.Infof("updated the assets of cluster %q to use the mirrored assets", updateClusterResults.Cluster.ObjectMeta.Name)
+		}
 	}
 
 	switch options.Output {
@@ -176,6 +236,35 @@ func RunGetAssets(ctx context.Context, f *util.Factory, out io.Writer, options *
 	return nil
 }
 
+// updateClusterAssets writes the locations of the mirrored assets to the cluster spec.
+func updateClusterAssets(ctx context.Context, f *util.Factory, clusterName string, mirroredAssets *kops.Assets) error {
+	clientset, err := f.Clientset()
+	if err != nil {
+		return err
+	}
+
+	oldCluster, err := GetCluster(ctx, f, clusterName)
+	if err != nil {
+		return err
+	}
+	newCluster := oldCluster.DeepCopy()
+	newCluster.Spec.Assets = mirroredAssets
+
+	instanceGroups, err := commands.ReadAllInstanceGroups(ctx, clientset, newCluster)
+	if err != nil {
+		return err
+	}
+
+	failure, err := updateCluster(ctx, clientset, oldCluster, newCluster, instanceGroups)
+	if err != nil {
+		return err
+	}
+	if failure != "" {
+		return fmt.Errorf("updating the assets of the cluster spec: %s", failure)
+	}
+	return nil
+}
+
 func imageOutputTable(images []*Image, out io.Writer) error {
 	fmt.Println("")
 	t := &tables.Table{}
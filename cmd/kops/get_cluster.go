@@ -53,6 +53,12 @@ var (
 
 	# Save a cluster desired configuration to YAML file
 	kops get cluster k8s-cluster.example.com -o yaml > cluster-desired-config.yaml
+
+	# Get the Kubernetes version and network CIDR of all clusters
+	kops get clusters --columns NAME,VERSION,NETWORKCIDR
+
+	# Get the networking of all clusters
+	kops get clusters --columns NAME,NETWORKING:.spec.networking
 	`))
 
 	getClusterShort = i18n.T(`Get one or many clusters.`)
@@ -81,6 +87,9 @@ type GetClusterOptions struct {
 
 	// ClusterNames is a list of cluster names to show; if not specified all clusters will be shown
 	ClusterNames []string
+
+	// Columns are the columns of the table output; if not specified the default columns will be shown
+	Columns []string
 }
 
 func NewCmdGetCluster(f *util.Factory, out io.Writer, getOptions *GetOptions) *cobra.Command {
@@ -113,6 +122,7 @@ func NewCmdGetCluster(f *util.Factory, out io.Writer, getOptions *GetOptions) *c
 	}
 
 	cmd.Flags().BoolVar(&options.FullSpec, "full", options.FullSpec, "Show fully populated configuration")
+	cmd.Flags().StringSliceVar(&options.Columns, "columns", options.Columns, fmt.Sprintf(columnsFlagHelp, "NAME, CLOUD, ZONES, VERSION, NETWORKCIDR"))
 
 	return cmd
 }
@@ -174,7 +184,7 @@ func RunGetClusters(ctx context.Context, f commandutils.Factory, out io.Writer,
 
 	switch options.Output {
 	case OutputTable:
-		return clusterOutputTable(clusters, out)
+		return clusterOutputTable(clusters, out, options.Columns)
 	case OutputYaml:
 		return fullOutputYAML(out, obj...)
 	case OutputJSON:
@@ -212,7 +222,7 @@ func filterClustersByName(clusterNames []string, clusters []*kopsapi.Cluster) ([
 	return clusters, nil
 }
 
-func clusterOutputTable(clusters []*kopsapi.Cluster, out io.Writer) error {
+func clusterOutputTable(clusters []*kopsapi.Cluster, out io.Writer, columns []string) error {
 	t := &tables.Table{}
 	t.AddColumn("NAME", func(c *kopsapi.Cluster) string {
 		return c.ObjectMeta.Name
@@ -229,8 +239,14 @@ func clusterOutputTable(clusters []*kopsapi.Cluster, out io.Writer) error {
 		}
 		return strings.Join(zones.List(), ",")
 	})
+	t.AddColumn("VERSION", func(c *kopsapi.Cluster) string {
+		return c.Spec.KubernetesVersion
+	})
+	t.AddColumn("NETWORKCIDR", func(c *kopsapi.Cluster) string {
+		return c.Spec.NetworkCIDR
+	})
 
-	return t.Render(clusters, out, "NAME", "CLOUD", "ZONES")
+	return renderTable(t, clusters, out, columns, "NAME", "CLOUD", "ZONES")
 }
 
 // fullOutputJSON outputs the marshalled JSON of a list of clusters and instance groups.  It will handle
@@ -0,0 +1,72 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+)
+
+func TestGetClustersColumns(t *testing.T) {
+	cluster := &kopsapi.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "minimal.example.com"},
+		Spec: kopsapi.ClusterSpec{
+			CloudProvider:     kopsapi.CloudProviderSpec{AWS: &kopsapi.AWSSpec{}},
+			KubernetesVersion: "1.24.0",
+			NetworkCIDR:       "172.20.0.0/16",
+			Subnets: []kopsapi.ClusterSubnetSpec{
+				{Name: "us-test-1a", Zone: "us-test-1a"},
+			},
+		},
+	}
+
+	grid := []struct {
+		columns  []string
+		expected string
+	}{
+		{
+			expected: "NAME\t\t\tCLOUD\tZONES\nminimal.example.com\taws\tus-test-1a\n",
+		},
+		{
+			columns:  []string{"name", "VERSION", "NETWORKCIDR"},
+			expected: "NAME\t\t\tVERSION\tNETWORKCIDR\nminimal.example.com\t1.24.0\t172.20.0.0/16\n",
+		},
+		{
+			columns:  []string{"NAME", "SUBNET:.spec.subnets[*].name", "DNS:{.spec.topology.dns.type}"},
+			expected: "NAME\t\t\tSUBNET\t\tDNS\nminimal.example.com\tus-test-1a\t<none>\n",
+		},
+	}
+	for _, g := range grid {
+		var b bytes.Buffer
+		if err := clusterOutputTable([]*kopsapi.Cluster{cluster}, &b, g.columns); err != nil {
+			t.Errorf("columns %v: unexpected error: %v", g.columns, err)
+			continue
+		}
+		if b.String() != g.expected {
+			t.Errorf("columns %v: expected %q, got %q", g.columns, g.expected, b.String())
+		}
+	}
+
+	for _, columns := range [][]string{{"BAD:{.spec"}, {":.spec"}} {
+		if err := clusterOutputTable([]*kopsapi.Cluster{cluster}, &bytes.Buffer{}, columns); err == nil {
+			t.Errorf("columns %v: expected an error", columns)
+		}
+	}
+}
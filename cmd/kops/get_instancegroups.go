@@ -47,6 +47,9 @@ var (
 
 	# Save a cluster's instancegroups desired configuration to YAML file
 	kops get instancegroups --name k8s-cluster.example.com -o yaml > instancegroups-desired-config.yaml
+
+	# Get the image and node counts of a cluster's instancegroups
+	kops get instancegroups --name k8s-cluster.example.com --columns NAME,MIN,MAX,IMAGE:.spec.image
 	`))
 
 	getInstancegroupsShort = i18n.T(`Get one or many instance groups.`)
@@ -55,6 +58,9 @@ var (
 type GetInstanceGroupsOptions struct {
 	*GetOptions
 	InstanceGroupNames []string
+
+	// Columns are the columns of the table output; if not specified the default columns will be shown
+	Columns []string
 }
 
 func NewCmdGetInstanceGroups(f *util.Factory, out io.Writer, getOptions *GetOptions) *cobra.Command {
@@ -85,6 +91,8 @@ func NewCmdGetInstanceGroups(f *util.Factory, out io.Writer, getOptions *GetOpti
 		},
 	}
 
+	cmd.Flags().StringSliceVar(&options.Columns, "columns", options.Columns, fmt.Sprintf(columnsFlagHelp, "NAME, ROLE, MACHINETYPE, MIN, MAX, ZONES, SUBNETS"))
+
 	return cmd
 }
 
@@ -130,7 +138,7 @@ func RunGetInstanceGroups(ctx context.Context, f commandutils.Factory, out io.Wr
 
 	switch options.Output {
 	case OutputTable:
-		return igOutputTable(cluster, instancegroups, out)
+		return igOutputTable(cluster, instancegroups, out, options.Columns)
 	case OutputYaml:
 		return fullOutputYAML(out, obj...)
 	case OutputJSON:
@@ -167,7 +175,7 @@ func filterInstanceGroupsByName(instanceGroupNames []string, list []api.Instance
 	return instancegroups, nil
 }
 
-func igOutputTable(cluster *api.Cluster, instancegroups []*api.InstanceGroup, out io.Writer) error {
+func igOutputTable(cluster *api.Cluster, instancegroups []*api.InstanceGroup, out io.Writer, columns []string) error {
 	t := &tables.Table{}
 	t.AddColumn("NAME", func(c *api.InstanceGroup) string {
 		return c.ObjectMeta.Name
@@ -187,7 +195,7 @@ func igOutputTable(cluster *api.Cluster, instancegroups []*api.InstanceGroup, ou
 		return int32PointerToString(c.Spec.MaxSize)
 	})
 	// SUBNETS is not selected by default - not as useful as ZONES
-	return t.Render(instancegroups, out, "NAME", "ROLE", "MACHINETYPE", "MIN", "MAX", "ZONES")
+	return renderTable(t, instancegroups, out, columns, "NAME", "ROLE", "MACHINETYPE", "MIN", "MAX", "ZONES")
 }
 
 func int32PointerToString(v *int32) string {
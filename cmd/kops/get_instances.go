@@ -39,6 +39,8 @@ import (
 	"k8s.io/kops/util/pkg/tables"
 
 	"k8s.io/kops/pkg/apis/kops"
+	apiModel "k8s.io/kops/pkg/apis/kops/model"
+	"k8s.io/kops/pkg/nodelabels"
 
 	"github.com/spf13/cobra"
 	"k8s.io/kops/cmd/kops/util"
@@ -55,16 +57,28 @@ var (
 )
 
 type renderableCloudInstance struct {
-	ID            string   `json:"id"`
-	NodeName      string   `json:"nodeName,omitempty"`
-	Status        string   `json:"status"`
-	Roles         []string `json:"roles"`
-	InternalIP    string   `json:"internalIP"`
-	InstanceGroup string   `json:"instanceGroup"`
-	MachineType   string   `json:"machineType"`
-	State         string   `json:"state"`
+	ID             string   `json:"id"`
+	NodeName       string   `json:"nodeName,omitempty"`
+	Status         string   `json:"status"`
+	Roles          []string `json:"roles"`
+	InternalIP     string   `json:"internalIP"`
+	InstanceGroup  string   `json:"instanceGroup"`
+	MachineType    string   `json:"machineType"`
+	State          string   `json:"state"`
+	LifecycleState string   `json:"lifecycleState,omitempty"`
+	HealthStatus   string   `json:"healthStatus,omitempty"`
+	Lifecycle      string   `json:"lifecycle,omitempty"`
+	KopsVersion    string   `json:"kopsVersion,omitempty"`
+	Spec           string   `json:"spec,omitempty"`
 }
 
+const (
+	// specCurrent means the cloud group was last applied from the spec in the state store.
+	specCurrent = "Current"
+	// specStale means the spec in the state store changed since the cloud group was last applied.
+	specStale = "Stale"
+)
+
 func NewCmdGetInstances(f *util.Factory, out io.Writer, options *GetOptions) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:               "instances [CLUSTER]",
@@ -133,11 +147,16 @@ func RunGetInstances(ctx context.Context, f *util.Factory, out io.Writer, option
 		cg.AdjustNeedUpdate()
 	}
 
+	specs, err := cloudGroupSpecs(cluster, cloudGroups)
+	if err != nil {
+		return err
+	}
+
 	switch options.Output {
 	case OutputTable:
-		return instanceOutputTable(cloudInstances, out)
+		return instanceOutputTable(cloudInstances, specs, out)
 	case OutputYaml:
-		y, err := yaml.Marshal(asRenderable(cloudInstances))
+		y, err := yaml.Marshal(asRenderable(cloudInstances, specs))
 		if err != nil {
 			return fmt.Errorf("unable to marshal YAML: %v", err)
 		}
@@ -146,7 +165,7 @@ func RunGetInstances(ctx context.Context, f *util.Factory, out io.Writer, option
 		}
 		return nil
 	case OutputJSON:
-		j, err := json.Marshal(asRenderable(cloudInstances))
+		j, err := json.Marshal(asRenderable(cloudInstances, specs))
 		if err != nil {
 			return fmt.Errorf("unable to marshal JSON: %v", err)
 		}
@@ -159,7 +178,28 @@ func RunGetInstances(ctx context.Context, f *util.Factory, out io.Writer, option
 	}
 }
 
-func instanceOutputTable(instances []*cloudinstances.CloudInstance, out io.Writer) error {
+// cloudGroupSpecs compares the spec hashes recorded on the cloud groups with the specs in the state store.
+// Groups without a recorded hash are left out.
+func cloudGroupSpecs(cluster *kops.Cluster, cloudGroups map[string]*cloudinstances.CloudInstanceGroup) (map[*cloudinstances.CloudInstanceGroup]string, error) {
+	specs := make(map[*cloudinstances.CloudInstanceGroup]string)
+	for _, cg := range cloudGroups {
+		if cg.SpecHash == "" || cg.InstanceGroup == nil {
+			continue
+		}
+		hash, err := apiModel.InstanceGroupSpecHash(cluster, cg.InstanceGroup)
+		if err != nil {
+			return nil, err
+		}
+		if hash == cg.SpecHash {
+			specs[cg] = specCurrent
+		} else {
+			specs[cg] = specStale
+		}
+	}
+	return specs, nil
+}
+
+func instanceOutputTable(instances []*cloudinstances.CloudInstance, specs map[*cloudinstances.CloudInstanceGroup]string, out io.Writer) error {
 	fmt.Println("")
 	t := &tables.Table{}
 	t.AddColumn("ID", func(i *cloudinstances.CloudInstance) string {
@@ -191,8 +231,26 @@ func instanceOutputTable(instances []*cloudinstances.CloudInstance, out io.Write
 	t.AddColumn("STATE", func(i *cloudinstances.CloudInstance) string {
 		return string(i.State)
 	})
+	t.AddColumn("LIFECYCLE-STATE", func(i *cloudinstances.CloudInstance) string {
+		return i.LifecycleState
+	})
+	t.AddColumn("HEALTH", func(i *cloudinstances.CloudInstance) string {
+		return i.HealthStatus
+	})
+	t.AddColumn("LIFECYCLE", func(i *cloudinstances.CloudInstance) string {
+		return i.Lifecycle
+	})
+	t.AddColumn("KOPS-VERSION", func(i *cloudinstances.CloudInstance) string {
+		return instanceKopsVersion(i)
+	})
+	t.AddColumn("SPEC", func(i *cloudinstances.CloudInstance) string {
+		return specs[i.CloudInstanceGroup]
+	})
 
-	columns := []string{"ID", "NODE-NAME", "STATUS", "ROLES", "STATE", "INTERNAL-IP", "INSTANCE-GROUP", "MACHINE-TYPE"}
+	columns := []string{"ID", "NODE-NAME", "STATUS", "ROLES", "STATE", "INTERNAL-IP", "INSTANCE-GROUP", "MACHINE-TYPE", "LIFECYCLE-STATE", "HEALTH", "LIFECYCLE", "KOPS-VERSION"}
+	if len(specs) != 0 {
+		columns = append(columns, "SPEC")
+	}
 	return t.Render(instances, out, columns...)
 }
 
@@ -212,17 +270,22 @@ func createK8sClient(cluster *kops.Cluster) (*kubernetes.Clientset, error) {
 	return k8sClient, nil
 }
 
-func asRenderable(instances []*cloudinstances.CloudInstance) []*renderableCloudInstance {
+func asRenderable(instances []*cloudinstances.CloudInstance, specs map[*cloudinstances.CloudInstanceGroup]string) []*renderableCloudInstance {
 	arr := make([]*renderableCloudInstance, len(instances))
 	for i, ci := range instances {
 		arr[i] = &renderableCloudInstance{
-			ID:            ci.ID,
-			Status:        ci.Status,
-			Roles:         ci.Roles,
-			InternalIP:    ci.PrivateIP,
-			InstanceGroup: ci.CloudInstanceGroup.HumanName,
-			MachineType:   ci.MachineType,
-			State:         string(ci.State),
+			ID:             ci.ID,
+			Status:         ci.Status,
+			Roles:          ci.Roles,
+			InternalIP:     ci.PrivateIP,
+			InstanceGroup:  ci.CloudInstanceGroup.HumanName,
+			MachineType:    ci.MachineType,
+			State:          string(ci.State),
+			LifecycleState: ci.LifecycleState,
+			HealthStatus:   ci.HealthStatus,
+			Lifecycle:      ci.Lifecycle,
+			KopsVersion:    instanceKopsVersion(ci),
+			Spec:           specs[ci.CloudInstanceGroup],
 		}
 		if ci.Node != nil {
 			arr[i].NodeName = ci.Node.Name
@@ -230,3 +293,11 @@ func asRenderable(instances []*cloudinstances.CloudInstance) []*renderableCloudI
 	}
 	return arr
 }
+
+// instanceKopsVersion returns the version of kops recorded on the node of the instance, if it joined the cluster
+func instanceKopsVersion(ci *cloudinstances.CloudInstance) string {
+	if ci.Node == nil {
+		return ""
+	}
+	return ci.Node.Labels[nodelabels.KopsVersionLabel]
+}
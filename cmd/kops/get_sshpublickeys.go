@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"strconv"
 
 	"github.com/spf13/cobra"
 	"k8s.io/klog/v2"
@@ -67,6 +68,7 @@ func NewCmdGetSSHPublicKeys(f *util.Factory, out io.Writer, getOptions *GetOptio
 type SSHKeyItem struct {
 	ID        string `json:"id"`
 	PublicKey string `json:"publicKey"`
+	Primary   bool   `json:"primary"`
 }
 
 func RunGetSSHPublicKeys(ctx context.Context, f *util.Factory, out io.Writer, options *GetSSHPublicKeysOptions) error {
@@ -92,7 +94,7 @@ func RunGetSSHPublicKeys(ctx context.Context, f *util.Factory, out io.Writer, op
 		return fmt.Errorf("listing SSH credentials %v", err)
 	}
 
-	for _, key := range l {
+	for i, key := range l {
 		id, err := sshcredentials.Fingerprint(key.Spec.PublicKey)
 		if err != nil {
 			klog.Warningf("unable to compute fingerprint for public key")
@@ -100,6 +102,8 @@ func RunGetSSHPublicKeys(ctx context.Context, f *util.Factory, out io.Writer, op
 		item := &SSHKeyItem{
 			ID:        id,
 			PublicKey: key.Spec.PublicKey,
+			// The primary key is returned first
+			Primary: i == 0,
 		}
 
 		items = append(items, item)
@@ -115,7 +119,10 @@ func RunGetSSHPublicKeys(ctx context.Context, f *util.Factory, out io.Writer, op
 		t.AddColumn("ID", func(i *SSHKeyItem) string {
 			return i.ID
 		})
-		return t.Render(items, out, "ID")
+		t.AddColumn("PRIMARY", func(i *SSHKeyItem) string {
+			return strconv.FormatBool(i.Primary)
+		})
+		return t.Render(items, out, "ID", "PRIMARY")
 
 	case OutputYaml:
 		y, err := yaml.Marshal(items)
@@ -87,6 +87,42 @@ func TestLifecycleMinimalGCE(t *testing.T) {
 	})
 }
 
+// TestLifecycleMinimalGCEPrivate runs the test on a GCE cluster with a private topology
+func TestLifecycleMinimalGCEPrivate(t *testing.T) {
+	runLifecycleTestGCE(&LifecycleTestOptions{
+		t:           t,
+		SrcDir:      "minimal_gce_private",
+		ClusterName: "minimal-gce-private.example.com",
+	})
+}
+
+// TestLifecycleMinimalGCEInternalLoadBalancer runs the test on a GCE cluster with an internal API load balancer
+func TestLifecycleMinimalGCEInternalLoadBalancer(t *testing.T) {
+	runLifecycleTestGCE(&LifecycleTestOptions{
+		t:           t,
+		SrcDir:      "minimal_gce_ilb",
+		ClusterName: "minimal-gce-ilb.example.com",
+	})
+}
+
+// TestLifecycleMinimalGCELongClusterName runs the test on a GCE cluster whose name must be truncated in resource names
+func TestLifecycleMinimalGCELongClusterName(t *testing.T) {
+	runLifecycleTestGCE(&LifecycleTestOptions{
+		t:           t,
+		SrcDir:      "minimal_gce_longclustername",
+		ClusterName: "minimal-gce-with-a-very-very-very-long-name.example.com",
+	})
+}
+
+// TestLifecycleHighAvailabilityGCE runs the test on a GCE cluster with control plane nodes in three zones
+func TestLifecycleHighAvailabilityGCE(t *testing.T) {
+	runLifecycleTestGCE(&LifecycleTestOptions{
+		t:           t,
+		SrcDir:      "ha_gce",
+		ClusterName: "ha-gce.example.com",
+	})
+}
+
 func TestLifecycleFloatingIPOpenstack(t *testing.T) {
 	runLifecycleTestOpenstack(&LifecycleTestOptions{
 		t:           t,
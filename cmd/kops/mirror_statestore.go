@@ -0,0 +1,167 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/commands"
+	"k8s.io/kops/util/pkg/vfs"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	mirrorStateStoreLong = templates.LongDesc(i18n.T(`
+	Mirror the state store, including cluster specs, keysets and secrets, to a replica,
+	typically a bucket in another region.
+
+	Every file written to the replica is read back and checked against the source.
+	The configBase of each cluster is rewritten to point at the replica, so that during
+	an outage of the primary state store, kOps commands can be pointed at the replica
+	by setting --state or KOPS_STATE_STORE to the replica location.
+
+	With --interval, the state store is mirrored continuously until the command is stopped.`))
+
+	mirrorStateStoreExample = templates.Examples(i18n.T(`
+	# Preview the changes needed to mirror the state store to a bucket in another region
+	kops mirror state-store --state s3://kops-state --to s3://kops-state-replica
+
+	# Mirror the state store every five minutes, removing files deleted from the state store
+	kops mirror state-store --state s3://kops-state --to s3://kops-state-replica --interval 5m --delete --yes
+
+	# Use the replica during an outage of the primary state store
+	kops get clusters --state s3://kops-state-replica
+	`))
+
+	mirrorStateStoreShort = i18n.T(`Mirror the state store to a replica.`)
+)
+
+type MirrorStateStoreOptions struct {
+	// To is the location of the replica
+	To string
+	// Interval is the time between mirror passes; if zero the state store is mirrored once
+	Interval time.Duration
+	// Delete removes files from the replica that no longer exist in the state store
+	Delete bool
+	Yes    bool
+}
+
+func NewCmdMirrorStateStore(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &MirrorStateStoreOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "state-store",
+		Short:   mirrorStateStoreShort,
+		Long:    mirrorStateStoreLong,
+		Example: mirrorStateStoreExample,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunMirrorStateStore(context.TODO(), f, out, options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.To, "to", options.To, "Location of the replica, for example s3://kops-state-replica")
+	cmd.MarkFlagRequired("to")
+	cmd.Flags().DurationVar(&options.Interval, "interval", options.Interval, "Mirror continuously, waiting this long between passes")
+	cmd.Flags().BoolVar(&options.Delete, "delete", options.Delete, "Delete files from the replica that no longer exist in the state store")
+	cmd.Flags().BoolVarP(&options.Yes, "yes", "y", options.Yes, "Specify --yes to write to the replica")
+
+	return cmd
+}
+
+func RunMirrorStateStore(ctx context.Context, f *util.Factory, out io.Writer, options *MirrorStateStoreOptions) error {
+	registryPath := f.KopsStateStore()
+	if registryPath == "" {
+		return fmt.Errorf("--state is required")
+	}
+	if strings.HasPrefix(registryPath, "k8s://") || strings.HasPrefix(options.To, "k8s://") {
+		return fmt.Errorf("mirroring is not supported for kops-server state stores")
+	}
+
+	source, err := vfs.Context.BuildVfsPath(registryPath)
+	if err != nil {
+		return fmt.Errorf("error building path for %q: %v", registryPath, err)
+	}
+	replica, err := vfs.Context.BuildVfsPath(options.To)
+	if err != nil {
+		return fmt.Errorf("error building path for %q: %v", options.To, err)
+	}
+	if source.Path() == replica.Path() {
+		return fmt.Errorf("the replica must be a different location from the state store")
+	}
+
+	mirrorOptions := commands.MirrorStateStoreOptions{
+		DryRun: !options.Yes,
+		Delete: options.Delete,
+	}
+
+	for {
+		result, err := commands.MirrorStateStore(source, replica, mirrorOptions)
+		if err != nil {
+			if options.Interval == 0 {
+				return err
+			}
+			klog.Warningf("error mirroring state store: %v", err)
+		} else {
+			printMirrorResult(out, result, options)
+		}
+
+		if options.Interval == 0 || !options.Yes {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(options.Interval):
+		}
+	}
+
+	if !options.Yes {
+		fmt.Fprintf(out, "\nMust specify --yes to mirror the state store\n")
+	}
+
+	return nil
+}
+
+func printMirrorResult(out io.Writer, result *commands.MirrorStateStoreResult, options *MirrorStateStoreOptions) {
+	if !options.Yes {
+		for _, p := range result.Copied {
+			fmt.Fprintf(out, "Will copy %s\n", p)
+		}
+		for _, p := range result.Deleted {
+			fmt.Fprintf(out, "Will delete %s\n", p)
+		}
+		return
+	}
+
+	for _, p := range result.Copied {
+		fmt.Fprintf(out, "Copied %s\n", p)
+	}
+	for _, p := range result.Deleted {
+		fmt.Fprintf(out, "Deleted %s\n", p)
+	}
+	fmt.Fprintf(out, "%s mirrored state store: %d copied, %d deleted, %d unchanged\n", time.Now().Format(time.RFC3339), len(result.Copied), len(result.Deleted), result.Unchanged)
+}
@@ -86,6 +86,16 @@ var (
 		# Update only the "nodes-1a" instance group of the k8s-cluster.example.com kOps cluster.
 		kops rolling-update cluster k8s-cluster.example.com --yes \
 		  --instance-group nodes-1a
+
+		# Update the nodes of the k8s-cluster.example.com kOps cluster without losing capacity,
+		# by launching all the replacements in a temporary autoscaling group first (AWS only).
+		kops rolling-update cluster k8s-cluster.example.com --yes \
+		  --strategy surge
+
+		# Update the k8s-cluster.example.com kOps cluster, writing a JSON progress event
+		# for each node that is cordoned, drained, terminated or validated.
+		kops rolling-update cluster k8s-cluster.example.com --yes \
+		  --output json
 		`))
 
 	rollingupdateShort = i18n.T(`Rolling update a cluster.`)
@@ -140,6 +150,12 @@ type RollingUpdateOptions struct {
 	// InstanceGroupRoles is the list of roles we should rolling-update
 	// if not specified, all instance groups will be updated
 	InstanceGroupRoles []string
+
+	// Strategy is the way the instances of the node instance groups are replaced.
+	Strategy string
+
+	// Output is the format of the progress output: table, or json for a stream of progress events.
+	Output string
 }
 
 func (o *RollingUpdateOptions) InitDefaults() {
@@ -159,6 +175,10 @@ func (o *RollingUpdateOptions) InitDefaults() {
 	o.ValidateCount = 2
 
 	o.DrainTimeout = 15 * time.Minute
+
+	o.Strategy = string(instancegroups.RollingUpdateStrategyRolling)
+
+	o.Output = OutputTable
 }
 
 func NewCmdRollingUpdateCluster(f *util.Factory, out io.Writer) *cobra.Command {
@@ -194,6 +214,14 @@ func NewCmdRollingUpdateCluster(f *util.Factory, out io.Writer) *cobra.Command {
 	cmd.Flags().DurationVar(&options.BastionInterval, "bastion-interval", options.BastionInterval, "Time to wait between restarting bastions")
 	cmd.Flags().DurationVar(&options.PostDrainDelay, "post-drain-delay", options.PostDrainDelay, "Time to wait after draining each node")
 	cmd.Flags().BoolVarP(&options.Interactive, "interactive", "i", options.Interactive, "Prompt to continue after each instance is updated")
+	cmd.Flags().StringVar(&options.Strategy, "strategy", options.Strategy, "Strategy used to replace the instances of the node instance groups (rolling, surge)")
+	cmd.RegisterFlagCompletionFunc("strategy", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{string(instancegroups.RollingUpdateStrategyRolling), string(instancegroups.RollingUpdateStrategySurge)}, cobra.ShellCompDirectiveNoFileComp
+	})
+	cmd.Flags().StringVarP(&options.Output, "output", "o", options.Output, "Output format. One of: table, json. With json, a progress event is written for each node that is cordoned, drained, terminated or validated")
+	cmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{OutputTable, OutputJSON}, cobra.ShellCompDirectiveNoFileComp
+	})
 	cmd.Flags().StringSliceVar(&options.InstanceGroups, "instance-group", options.InstanceGroups, "Instance groups to update (defaults to all if not specified)")
 	cmd.RegisterFlagCompletionFunc("instance-group", completeInstanceGroup(f, &options.InstanceGroups, &options.InstanceGroupRoles))
 	cmd.Flags().StringSliceVar(&options.InstanceGroupRoles, "instance-group-roles", options.InstanceGroupRoles, "Instance group roles to update ("+strings.Join(allRoles, ",")+")")
@@ -218,6 +246,24 @@ func NewCmdRollingUpdateCluster(f *util.Factory, out io.Writer) *cobra.Command {
 }
 
 func RunRollingUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer, options *RollingUpdateOptions) error {
+	strategy := instancegroups.RollingUpdateStrategy(options.Strategy)
+	switch strategy {
+	case instancegroups.RollingUpdateStrategyRolling, instancegroups.RollingUpdateStrategySurge:
+	default:
+		return fmt.Errorf("invalid strategy %q, must be one of: %s, %s", options.Strategy, instancegroups.RollingUpdateStrategyRolling, instancegroups.RollingUpdateStrategySurge)
+	}
+
+	// With json, the standard output only has the progress events
+	var progress io.Writer
+	switch options.Output {
+	case OutputTable:
+	case OutputJSON:
+		progress = out
+		out = os.Stderr
+	default:
+		return fmt.Errorf("unsupported output format: %q", options.Output)
+	}
+
 	clientset, err := f.Clientset()
 	if err != nil {
 		return err
@@ -343,6 +389,8 @@ func RunRollingUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer
 		ValidationTimeout: options.ValidationTimeout,
 		ValidateCount:     int(options.ValidateCount),
 		DrainTimeout:      options.DrainTimeout,
+		Strategy:          strategy,
+		Progress:          progress,
 		// TODO should we expose this to the UI?
 		ValidateTickDuration:    30 * time.Second,
 		ValidateSuccessDuration: 10 * time.Second,
@@ -413,12 +461,12 @@ func RunRollingUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer
 	}
 
 	if !needUpdate && !options.Force {
-		fmt.Printf("\nNo rolling-update required.\n")
+		fmt.Fprintf(out, "\nNo rolling-update required.\n")
 		return nil
 	}
 
 	if !options.Yes {
-		fmt.Printf("\nMust specify --yes to rolling-update.\n")
+		fmt.Fprintf(out, "\nMust specify --yes to rolling-update.\n")
 		return nil
 	}
 
@@ -439,14 +487,33 @@ func completeInstanceGroup(f commandutils.Factory, selectedInstanceGroups *[]str
 		commandutils.ConfigureKlogForCompletion()
 		ctx := context.TODO()
 
-		cluster, clientSet, completions, directive := GetClusterForCompletion(ctx, f, args)
-		if cluster == nil {
+		clusterName, completions, directive := GetClusterNameForCompletion(args)
+		if clusterName == "" {
 			return completions, directive
 		}
 
-		list, err := clientSet.InstanceGroupsFor(cluster).List(ctx, metav1.ListOptions{})
+		// The completions are cached as "name<TAB>role", so they can be filtered by role.
+		list, err := commandutils.CachedCompletions(f, []string{"instancegroups", clusterName}, func() ([]string, error) {
+			cluster, err := GetCluster(ctx, f, clusterName)
+			if err != nil {
+				return nil, fmt.Errorf("getting cluster: %w", err)
+			}
+			clientSet, err := f.Clientset()
+			if err != nil {
+				return nil, fmt.Errorf("getting clientset: %w", err)
+			}
+			list, err := clientSet.InstanceGroupsFor(cluster).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, fmt.Errorf("listing instance groups: %w", err)
+			}
+			var igs []string
+			for _, ig := range list.Items {
+				igs = append(igs, ig.Name+"\t"+strings.ToLower(string(ig.Spec.Role)))
+			}
+			return igs, nil
+		})
 		if err != nil {
-			return commandutils.CompletionError("listing instance groups", err)
+			return commandutils.CompletionError("completing instance groups", err)
 		}
 
 		alreadySelected := sets.NewString()
@@ -458,9 +525,10 @@ func completeInstanceGroup(f commandutils.Factory, selectedInstanceGroups *[]str
 			alreadySelectedRoles = alreadySelectedRoles.Insert(*selectedInstanceGroupRoles...)
 		}
 		var igs []string
-		for _, ig := range list.Items {
-			if !alreadySelected.Has(ig.Name) && !alreadySelectedRoles.Has(strings.ToLower(string(ig.Spec.Role))) {
-				igs = append(igs, ig.Name)
+		for _, ig := range list {
+			name, role, _ := strings.Cut(ig, "\t")
+			if !alreadySelected.Has(name) && !alreadySelectedRoles.Has(role) {
+				igs = append(igs, name)
 			}
 		}
 
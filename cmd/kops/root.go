@@ -37,6 +37,7 @@ import (
 	"k8s.io/kops/pkg/client/simple"
 	"k8s.io/kops/pkg/commands"
 	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/util/pkg/vfs"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
 )
@@ -86,6 +87,11 @@ var rootCommand = RootCmd{
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
 			cmd.SilenceUsage = true
 		},
+		PersistentPostRun: func(cmd *cobra.Command, args []string) {
+			if klog.V(2).Enabled() {
+				vfs.LogOperationStats()
+			}
+		},
 	},
 }
 
@@ -148,11 +154,16 @@ func NewCmdRoot(f *util.Factory, out io.Writer) *cobra.Command {
 	cmd.AddCommand(NewCmdGenCLIDocs(f, out))
 	cmd.AddCommand(NewCmdGet(f, out))
 	cmd.AddCommand(commands.NewCmdHelpers(f, out))
+	cmd.AddCommand(NewCmdMirror(f, out))
 	cmd.AddCommand(NewCmdPromote(f, out))
 	cmd.AddCommand(NewCmdReplace(f, out))
 	cmd.AddCommand(NewCmdRollingUpdate(f, out))
+	cmd.AddCommand(NewCmdRotate(f, out))
+	cmd.AddCommand(NewCmdStart(f, out))
+	cmd.AddCommand(NewCmdStop(f, out))
 	cmd.AddCommand(NewCmdToolbox(f, out))
 	cmd.AddCommand(NewCmdTrust(f, out))
+	cmd.AddCommand(NewCmdUnset(f, out))
 	cmd.AddCommand(NewCmdUpdate(f, out))
 	cmd.AddCommand(NewCmdUpgrade(f, out))
 	cmd.AddCommand(NewCmdValidate(f, out))
@@ -342,17 +353,22 @@ func GetClusterNameForCompletionNoKubeconfig(clusterArgs []string) (clusterName
 	return "", []string{"--name"}, cobra.ShellCompDirectiveNoFileComp
 }
 
-func GetClusterForCompletion(ctx context.Context, factory commandutils.Factory, clusterArgs []string) (cluster *kopsapi.Cluster, clientSet simple.Clientset, completions []string, directive cobra.ShellCompDirective) {
-	clusterName := ""
-
+func GetClusterNameForCompletion(clusterArgs []string) (clusterName string, completions []string, directive cobra.ShellCompDirective) {
 	if len(clusterArgs) > 0 {
-		clusterName = clusterArgs[0]
-	} else {
-		clusterName = rootCommand.ClusterName(false)
+		return clusterArgs[0], nil, 0
 	}
 
+	clusterName = rootCommand.ClusterName(false)
 	if clusterName == "" {
-		return nil, nil, []string{"--name"}, cobra.ShellCompDirectiveNoFileComp
+		return "", []string{"--name"}, cobra.ShellCompDirectiveNoFileComp
+	}
+	return clusterName, nil, 0
+}
+
+func GetClusterForCompletion(ctx context.Context, factory commandutils.Factory, clusterArgs []string) (cluster *kopsapi.Cluster, clientSet simple.Clientset, completions []string, directive cobra.ShellCompDirective) {
+	clusterName, completions, directive := GetClusterNameForCompletion(clusterArgs)
+	if clusterName == "" {
+		return nil, nil, completions, directive
 	}
 
 	cluster, err := GetCluster(ctx, factory, clusterName)
@@ -0,0 +1,389 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/kops/cmd/kops/util"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/pki"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/util/pkg/tables"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	rotateCALong = templates.LongDesc(i18n.T(`
+	Rotate the keypairs of the rotatable keysets, such as the CAs and "service-account".
+
+	The rotation has three phases, run in order. Each phase changes the keysets,
+	then updates the cluster and performs a rolling update, which replaces the
+	control plane nodes before the worker nodes.
+
+	In the "stage" phase, a new keypair is added to each keyset. It is trusted,
+	but not yet used to issue certificates.
+
+	In the "promote" phase, the new keypairs become primary. The previous keypairs
+	are still trusted, so existing credentials remain valid.
+
+	In the "complete" phase, the previous keypairs are distrusted.
+
+	The phase of the rotation is recorded in each keyset. A phase can be run again
+	if the update of the cluster failed, but phases cannot be run out of order.
+	`))
+
+	rotateCAExample = templates.Examples(i18n.T(`
+	# Rotate all rotatable keysets, one phase at a time.
+	kops rotate ca --phase=stage --name k8s-cluster.example.com --yes
+	kops rotate ca --phase=promote --name k8s-cluster.example.com --yes
+	kops rotate ca --phase=complete --name k8s-cluster.example.com --yes
+
+	# Stage a new keypair for the Kubernetes general CA only.
+	kops rotate ca kubernetes-ca --phase=stage --name k8s-cluster.example.com --yes
+	`))
+
+	rotateCAShort = i18n.T(`Rotate the CA and service-account keypairs of a cluster.`)
+)
+
+const (
+	rotateCAPhaseStage    = "stage"
+	rotateCAPhasePromote  = "promote"
+	rotateCAPhaseComplete = "complete"
+)
+
+type RotateCAOptions struct {
+	ClusterName string
+	Keyset      string
+	Phase       string
+	Yes         bool
+}
+
+// NewCmdRotateCA returns a rotate ca command.
+func NewCmdRotateCA(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &RotateCAOptions{
+		Keyset: "all",
+	}
+
+	cmd := &cobra.Command{
+		Use:     "ca [KEYSET | all]",
+		Short:   rotateCAShort,
+		Long:    rotateCALong,
+		Example: rotateCAExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			options.ClusterName = rootCommand.ClusterName(true)
+
+			if options.ClusterName == "" {
+				return fmt.Errorf("--name is required")
+			}
+
+			if len(args) > 1 {
+				return fmt.Errorf("can only rotate one keyset at a time")
+			}
+			if len(args) == 1 {
+				options.Keyset = args[0]
+			}
+
+			switch options.Phase {
+			case rotateCAPhaseStage, rotateCAPhasePromote, rotateCAPhaseComplete:
+			case "":
+				return fmt.Errorf("--phase is required")
+			default:
+				return fmt.Errorf("unknown phase %q, must be one of: %s, %s, %s", options.Phase, rotateCAPhaseStage, rotateCAPhasePromote, rotateCAPhaseComplete)
+			}
+
+			return nil
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			commandutils.ConfigureKlogForCompletion()
+			cluster, clientSet, completions, directive := GetClusterForCompletion(context.TODO(), f, nil)
+			if cluster == nil {
+				return completions, directive
+			}
+			_, _, completions, directive = completeKeyset(cluster, clientSet, args, rotatableKeysetFilter)
+			return completions, directive
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunRotateCA(context.TODO(), f, out, options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.Phase, "phase", options.Phase, "Phase of the rotation to run: stage, promote or complete")
+	cmd.RegisterFlagCompletionFunc("phase", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{rotateCAPhaseStage, rotateCAPhasePromote, rotateCAPhaseComplete}, cobra.ShellCompDirectiveNoFileComp
+	})
+	cmd.Flags().BoolVarP(&options.Yes, "yes", "y", options.Yes, "Rotate the keysets and update the cluster; without --yes only the changes to the keysets are shown")
+
+	return cmd
+}
+
+// RunRotateCA runs a phase of the rotation of the keypairs of a cluster.
+func RunRotateCA(ctx context.Context, f *util.Factory, out io.Writer, options *RotateCAOptions) error {
+	if !rotatableKeysetFilter(options.Keyset, nil) {
+		return fmt.Errorf("rotating %q is not supported", options.Keyset)
+	}
+
+	cluster, err := GetCluster(ctx, f, options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	clientSet, err := f.Clientset()
+	if err != nil {
+		return err
+	}
+
+	keyStore, err := clientSet.KeyStore(cluster)
+	if err != nil {
+		return fmt.Errorf("getting keystore: %v", err)
+	}
+
+	var names []string
+	if options.Keyset == "all" {
+		keysets, err := keyStore.ListKeysets()
+		if err != nil {
+			return fmt.Errorf("listing keysets: %v", err)
+		}
+		for name := range keysets {
+			if rotatableKeysetFilter(name, nil) {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+	} else {
+		names = []string{options.Keyset}
+	}
+
+	var rotations []*keysetRotation
+	for _, name := range names {
+		keyset, err := keyStore.FindKeyset(name)
+		if err != nil {
+			return fmt.Errorf("reading keyset %q: %v", name, err)
+		}
+		if keyset == nil {
+			return fmt.Errorf("keyset %q not found", name)
+		}
+
+		changed, err := rotateKeyset(name, keyset, options.Phase)
+		if err != nil {
+			return err
+		}
+		rotations = append(rotations, &keysetRotation{
+			Name:    name,
+			Keyset:  keyset,
+			Changed: changed,
+		})
+	}
+
+	if err := renderKeysetRotations(out, rotations); err != nil {
+		return err
+	}
+
+	if !options.Yes {
+		fmt.Fprintf(out, "\nMust specify --yes to run the %s phase\n", options.Phase)
+		return nil
+	}
+
+	if err := applyKeysetRotations(ctx, f, out, cluster, keyStore, rotations, options.Phase); err != nil {
+		return err
+	}
+
+	switch options.Phase {
+	case rotateCAPhaseStage:
+		fmt.Fprintf(out, "\nThe new keypairs are staged. Distribute the certificate-authority-data of a kubeconfig exported with `kops export kubecfg` to the clients of the cluster, then run the %s phase.\n", rotateCAPhasePromote)
+	case rotateCAPhasePromote:
+		fmt.Fprintf(out, "\nThe new keypairs are primary. Distribute admin credentials exported with `kops export kubecfg --admin` to the clients that need them, then run the %s phase.\n", rotateCAPhaseComplete)
+	case rotateCAPhaseComplete:
+		fmt.Fprintf(out, "\nThe previous keypairs are distrusted. Distribute the certificate-authority-data of a kubeconfig exported with `kops export kubecfg` to the clients of the cluster.\n")
+	}
+	return nil
+}
+
+// applyKeysetRotations stores the changed keysets, then updates the cluster and performs a rolling update
+// of the instance groups which use the rotated keysets.
+func applyKeysetRotations(ctx context.Context, f *util.Factory, out io.Writer, cluster *kopsapi.Cluster, keyStore fi.CAStore, rotations []*keysetRotation, phase string) error {
+	roles := sets.NewString()
+	allRoles := false
+	for _, rotation := range rotations {
+		if len(rotation.Roles) == 0 {
+			allRoles = true
+		}
+		roles.Insert(rotation.Roles...)
+
+		if !rotation.Changed {
+			continue
+		}
+		if err := keyStore.StoreKeyset(rotation.Name, rotation.Keyset); err != nil {
+			return fmt.Errorf("writing keyset %q: %v", rotation.Name, err)
+		}
+	}
+
+	updateClusterOptions := &UpdateClusterOptions{}
+	updateClusterOptions.InitDefaults()
+	updateClusterOptions.Yes = true
+	updateClusterOptions.ClusterName = cluster.ObjectMeta.Name
+	updateClusterOptions.Quiet = true
+	if _, err := RunUpdateCluster(ctx, f, out, updateClusterOptions); err != nil {
+		return err
+	}
+
+	rollingUpdateOptions := &RollingUpdateOptions{}
+	rollingUpdateOptions.InitDefaults()
+	rollingUpdateOptions.Yes = true
+	rollingUpdateOptions.ClusterName = cluster.ObjectMeta.Name
+	rollingUpdateOptions.FailOnDrainError = true
+	if !allRoles {
+		rollingUpdateOptions.InstanceGroupRoles = roles.List()
+	}
+	if err := RunRollingUpdateCluster(ctx, f, out, rollingUpdateOptions); err != nil {
+		return fmt.Errorf("error updating the instances: %v; run the %s phase again to resume", err, phase)
+	}
+
+	return nil
+}
+
+// keysetRotation is a keyset changed by a phase of the rotation.
+type keysetRotation struct {
+	Name    string
+	Keyset  *fi.Keyset
+	Changed bool
+	// Roles are the roles of the instance groups which use the keyset, or empty if all instance groups use it.
+	Roles []string
+}
+
+func renderKeysetRotations(out io.Writer, rotations []*keysetRotation) error {
+	t := &tables.Table{}
+	t.AddColumn("KEYSET", func(r *keysetRotation) string {
+		return r.Name
+	})
+	t.AddColumn("PHASE", func(r *keysetRotation) string {
+		return string(r.Keyset.Rotation.Phase)
+	})
+	t.AddColumn("PRIMARY", func(r *keysetRotation) string {
+		return r.Keyset.Primary.Id
+	})
+	t.AddColumn("NEW", func(r *keysetRotation) string {
+		return r.Keyset.Rotation.NewID
+	})
+	t.AddColumn("PREVIOUS", func(r *keysetRotation) string {
+		return r.Keyset.Rotation.PreviousID
+	})
+	t.AddColumn("CHANGED", func(r *keysetRotation) string {
+		if r.Changed {
+			return "yes"
+		}
+		return "no"
+	})
+	return t.Render(rotations, out, "KEYSET", "PHASE", "PRIMARY", "NEW", "PREVIOUS", "CHANGED")
+}
+
+// rotateKeyset applies a phase of the rotation to a keyset, returning whether the keyset changed.
+// The keyset is left as is if the phase was already applied, so that the phase can be run again.
+func rotateKeyset(name string, keyset *fi.Keyset, phase string) (bool, error) {
+	rotation := keyset.Rotation
+
+	switch phase {
+	case rotateCAPhaseStage:
+		if rotation != nil {
+			switch rotation.Phase {
+			case kopsapi.KeysetRotationPhaseStaged:
+				return false, nil
+			case kopsapi.KeysetRotationPhasePromoted:
+				return false, fmt.Errorf("the rotation of keyset %q is promoted; run the %s phase", name, rotateCAPhaseComplete)
+			}
+		}
+		if keyset.Primary == nil {
+			return false, fmt.Errorf("keyset %q has no primary keypair", name)
+		}
+
+		privateKey, err := pki.GeneratePrivateKey()
+		if err != nil {
+			return false, fmt.Errorf("error generating private key: %v", err)
+		}
+		cert, err := issueCACertificate(name, privateKey)
+		if err != nil {
+			return false, err
+		}
+		item, err := keyset.AddItem(cert, privateKey, false)
+		if err != nil {
+			return false, err
+		}
+
+		keyset.Rotation = &kopsapi.KeysetRotation{
+			Phase:      kopsapi.KeysetRotationPhaseStaged,
+			NewID:      item.Id,
+			PreviousID: keyset.Primary.Id,
+		}
+		return true, nil
+
+	case rotateCAPhasePromote:
+		if rotation == nil || rotation.Phase == kopsapi.KeysetRotationPhaseCompleted {
+			return false, fmt.Errorf("keyset %q is not being rotated; run the %s phase", name, rotateCAPhaseStage)
+		}
+		if rotation.Phase == kopsapi.KeysetRotationPhasePromoted {
+			return false, nil
+		}
+
+		item := keyset.Items[rotation.NewID]
+		if item == nil {
+			return false, fmt.Errorf("new keypair %s of keyset %q not found", rotation.NewID, name)
+		}
+		if item.DistrustTimestamp != nil {
+			return false, fmt.Errorf("new keypair %s of keyset %q is distrusted", rotation.NewID, name)
+		}
+		if item.PrivateKey == nil {
+			return false, fmt.Errorf("new keypair %s of keyset %q has no private key", rotation.NewID, name)
+		}
+
+		keyset.Primary = item
+		rotation.Phase = kopsapi.KeysetRotationPhasePromoted
+		return true, nil
+
+	case rotateCAPhaseComplete:
+		if rotation == nil {
+			return false, fmt.Errorf("keyset %q is not being rotated; run the %s phase", name, rotateCAPhaseStage)
+		}
+		switch rotation.Phase {
+		case kopsapi.KeysetRotationPhaseCompleted:
+			return false, nil
+		case kopsapi.KeysetRotationPhaseStaged:
+			return false, fmt.Errorf("the rotation of keyset %q is staged; run the %s phase", name, rotateCAPhasePromote)
+		}
+
+		now := time.Now().UTC().Round(0)
+		primarySerial := keyset.Primary.Certificate.Certificate.SerialNumber
+		for _, item := range keyset.Items {
+			if item.DistrustTimestamp == nil && item.Certificate.Certificate.SerialNumber.Cmp(primarySerial) < 0 {
+				item.DistrustTimestamp = &now
+			}
+		}
+
+		rotation.Phase = kopsapi.KeysetRotationPhaseCompleted
+		return true, nil
+
+	default:
+		return false, fmt.Errorf("unknown phase %q", phase)
+	}
+}
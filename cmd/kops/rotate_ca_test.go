@@ -0,0 +1,98 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"testing"
+
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/pki"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func TestRotateKeyset(t *testing.T) {
+	privateKey, err := pki.GeneratePrivateKey()
+	if err != nil {
+		t.Fatalf("error generating private key: %v", err)
+	}
+	cert, err := issueCACertificate("kubernetes-ca", privateKey)
+	if err != nil {
+		t.Fatalf("error issuing certificate: %v", err)
+	}
+	keyset, err := fi.NewKeyset(cert, privateKey)
+	if err != nil {
+		t.Fatalf("error building keyset: %v", err)
+	}
+	previousID := keyset.Primary.Id
+
+	for _, phase := range []string{rotateCAPhasePromote, rotateCAPhaseComplete} {
+		if _, err := rotateKeyset("kubernetes-ca", keyset, phase); err == nil {
+			t.Errorf("expected an error running the %s phase before the stage phase", phase)
+		}
+	}
+
+	if changed, err := rotateKeyset("kubernetes-ca", keyset, rotateCAPhaseStage); err != nil || !changed {
+		t.Fatalf("stage: expected a change, got %v, %v", changed, err)
+	}
+	rotation := keyset.Rotation
+	if rotation == nil || rotation.Phase != kopsapi.KeysetRotationPhaseStaged || rotation.PreviousID != previousID {
+		t.Fatalf("stage: unexpected rotation %+v", rotation)
+	}
+	newID := rotation.NewID
+	if keyset.Primary.Id != previousID || len(keyset.Items) != 2 || keyset.Items[newID] == nil {
+		t.Errorf("stage: expected a new secondary keypair, got primary %s and %d keypairs", keyset.Primary.Id, len(keyset.Items))
+	}
+	if changed, err := rotateKeyset("kubernetes-ca", keyset, rotateCAPhaseStage); err != nil || changed {
+		t.Errorf("stage again: expected no change, got %v, %v", changed, err)
+	}
+	if _, err := rotateKeyset("kubernetes-ca", keyset, rotateCAPhaseComplete); err == nil {
+		t.Errorf("expected an error completing a staged rotation")
+	}
+
+	if changed, err := rotateKeyset("kubernetes-ca", keyset, rotateCAPhasePromote); err != nil || !changed {
+		t.Fatalf("promote: expected a change, got %v, %v", changed, err)
+	}
+	if keyset.Primary.Id != newID || keyset.Rotation.Phase != kopsapi.KeysetRotationPhasePromoted {
+		t.Errorf("promote: expected primary %s in phase Promoted, got %s in phase %s", newID, keyset.Primary.Id, keyset.Rotation.Phase)
+	}
+	if keyset.Items[previousID].DistrustTimestamp != nil {
+		t.Errorf("promote: expected the previous keypair to be trusted")
+	}
+	if _, err := rotateKeyset("kubernetes-ca", keyset, rotateCAPhaseStage); err == nil {
+		t.Errorf("expected an error staging during a promoted rotation")
+	}
+
+	if changed, err := rotateKeyset("kubernetes-ca", keyset, rotateCAPhaseComplete); err != nil || !changed {
+		t.Fatalf("complete: expected a change, got %v, %v", changed, err)
+	}
+	if keyset.Items[previousID].DistrustTimestamp == nil || keyset.Items[newID].DistrustTimestamp != nil {
+		t.Errorf("complete: expected only the previous keypair to be distrusted")
+	}
+	if keyset.Rotation.Phase != kopsapi.KeysetRotationPhaseCompleted {
+		t.Errorf("complete: expected phase Completed, got %s", keyset.Rotation.Phase)
+	}
+	if changed, err := rotateKeyset("kubernetes-ca", keyset, rotateCAPhaseComplete); err != nil || changed {
+		t.Errorf("complete again: expected no change, got %v, %v", changed, err)
+	}
+
+	if changed, err := rotateKeyset("kubernetes-ca", keyset, rotateCAPhaseStage); err != nil || !changed {
+		t.Fatalf("stage after complete: expected a change, got %v, %v", changed, err)
+	}
+	if keyset.Rotation.PreviousID != newID {
+		t.Errorf("stage after complete: expected previous keypair %s, got %s", newID, keyset.Rotation.PreviousID)
+	}
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/cmd/kops/util"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/model"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	rotateServiceAccountKeyLong = templates.LongDesc(i18n.T(`
+	Rotate the keypair that signs the service account tokens of a cluster.
+
+	The rotation has the same three phases as the rotation of the CAs. Each phase
+	changes the "service-account" keyset, then updates the cluster and performs a
+	rolling update of the control plane and API server nodes.
+
+	While the keyset is rotated, the OIDC discovery documents published to the
+	serviceAccountIssuerDiscovery discoveryStore list both the previous and the new
+	keys, so that the tokens signed by either key are trusted by the consumers of
+	the issuer, such as the AWS OIDC provider.
+
+	In the "stage" phase, the new key is published and trusted by the API servers.
+
+	In the "promote" phase, the API servers sign tokens with the new key.
+
+	In the "complete" phase, the previous key is distrusted and removed from the
+	published keys.
+	`))
+
+	rotateServiceAccountKeyExample = templates.Examples(i18n.T(`
+	# Rotate the service account signing key, one phase at a time.
+	kops rotate service-account-key --phase=stage --name k8s-cluster.example.com --yes
+	kops rotate service-account-key --phase=promote --name k8s-cluster.example.com --yes
+	kops rotate service-account-key --phase=complete --name k8s-cluster.example.com --yes
+	`))
+
+	rotateServiceAccountKeyShort = i18n.T(`Rotate the service account signing key of a cluster.`)
+)
+
+// serviceAccountKeyset is the keyset that signs the service account tokens.
+const serviceAccountKeyset = "service-account"
+
+type RotateServiceAccountKeyOptions struct {
+	ClusterName string
+	Phase       string
+	Yes         bool
+}
+
+// NewCmdRotateServiceAccountKey returns a rotate service-account-key command.
+func NewCmdRotateServiceAccountKey(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &RotateServiceAccountKeyOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "service-account-key",
+		Short:   rotateServiceAccountKeyShort,
+		Long:    rotateServiceAccountKeyLong,
+		Example: rotateServiceAccountKeyExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			options.ClusterName = rootCommand.ClusterName(true)
+
+			if options.ClusterName == "" {
+				return fmt.Errorf("--name is required")
+			}
+
+			if len(args) != 0 {
+				return fmt.Errorf("unexpected arguments")
+			}
+
+			switch options.Phase {
+			case rotateCAPhaseStage, rotateCAPhasePromote, rotateCAPhaseComplete:
+			case "":
+				return fmt.Errorf("--phase is required")
+			default:
+				return fmt.Errorf("unknown phase %q, must be one of: %s, %s, %s", options.Phase, rotateCAPhaseStage, rotateCAPhasePromote, rotateCAPhaseComplete)
+			}
+
+			return nil
+		},
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunRotateServiceAccountKey(context.TODO(), f, out, options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.Phase, "phase", options.Phase, "Phase of the rotation to run: stage, promote or complete")
+	cmd.RegisterFlagCompletionFunc("phase", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{rotateCAPhaseStage, rotateCAPhasePromote, rotateCAPhaseComplete}, cobra.ShellCompDirectiveNoFileComp
+	})
+	cmd.Flags().BoolVarP(&options.Yes, "yes", "y", options.Yes, "Rotate the keyset and update the cluster; without --yes only the changes to the keyset are shown")
+
+	return cmd
+}
+
+// RunRotateServiceAccountKey runs a phase of the rotation of the service account signing key of a cluster.
+func RunRotateServiceAccountKey(ctx context.Context, f *util.Factory, out io.Writer, options *RotateServiceAccountKeyOptions) error {
+	cluster, err := GetCluster(ctx, f, options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	clientSet, err := f.Clientset()
+	if err != nil {
+		return err
+	}
+
+	keyStore, err := clientSet.KeyStore(cluster)
+	if err != nil {
+		return fmt.Errorf("getting keystore: %v", err)
+	}
+
+	keyset, err := keyStore.FindKeyset(serviceAccountKeyset)
+	if err != nil {
+		return fmt.Errorf("reading keyset %q: %v", serviceAccountKeyset, err)
+	}
+	if keyset == nil {
+		return fmt.Errorf("keyset %q not found", serviceAccountKeyset)
+	}
+
+	changed, err := rotateKeyset(serviceAccountKeyset, keyset, options.Phase)
+	if err != nil {
+		return err
+	}
+	rotations := []*keysetRotation{
+		{
+			Name:    serviceAccountKeyset,
+			Keyset:  keyset,
+			Changed: changed,
+			// Only the control plane signs and verifies service account tokens
+			Roles: []string{string(kopsapi.InstanceGroupRoleMaster), string(kopsapi.InstanceGroupRoleAPIServer)},
+		},
+	}
+	if err := renderKeysetRotations(out, rotations); err != nil {
+		return err
+	}
+
+	if said := cluster.Spec.ServiceAccountIssuerDiscovery; said != nil && said.DiscoveryStore != "" {
+		keyIDs, err := publishedKeyIDs(keyset)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "\nKeys published to %s/openid/v1/jwks: %s\n", strings.TrimSuffix(said.DiscoveryStore, "/"), strings.Join(keyIDs, ", "))
+	}
+
+	if !options.Yes {
+		fmt.Fprintf(out, "\nMust specify --yes to run the %s phase\n", options.Phase)
+		return nil
+	}
+
+	if err := applyKeysetRotations(ctx, f, out, cluster, keyStore, rotations, options.Phase); err != nil {
+		return err
+	}
+
+	switch options.Phase {
+	case rotateCAPhaseStage:
+		fmt.Fprintf(out, "\nThe new key is staged. Once the consumers of the service account issuer have refreshed the published keys, run the %s phase.\n", rotateCAPhasePromote)
+	case rotateCAPhasePromote:
+		fmt.Fprintf(out, "\nTokens are signed with the new key. Once the tokens signed with the previous key have expired or been replaced, run the %s phase.\n", rotateCAPhaseComplete)
+	case rotateCAPhaseComplete:
+		fmt.Fprintf(out, "\nThe previous key is distrusted and no longer published.\n")
+	}
+	return nil
+}
+
+// publishedKeyIDs returns the IDs of the keys in the JSON Web Key Set published for the keyset.
+func publishedKeyIDs(keyset *fi.Keyset) ([]string, error) {
+	jwks, err := model.BuildJWKS(keyset)
+	if err != nil {
+		return nil, err
+	}
+	var keyIDs []string
+	for _, key := range jwks.Keys {
+		keyIDs = append(keyIDs, key.KeyID)
+	}
+	return keyIDs, nil
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"testing"
+
+	"k8s.io/kops/pkg/pki"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func TestPublishedKeyIDsDuringRotation(t *testing.T) {
+	privateKey, err := pki.GeneratePrivateKey()
+	if err != nil {
+		t.Fatalf("error generating private key: %v", err)
+	}
+	cert, err := issueCACertificate(serviceAccountKeyset, privateKey)
+	if err != nil {
+		t.Fatalf("error issuing certificate: %v", err)
+	}
+	keyset, err := fi.NewKeyset(cert, privateKey)
+	if err != nil {
+		t.Fatalf("error building keyset: %v", err)
+	}
+
+	expectKeys := func(phase string, expected int) []string {
+		keyIDs, err := publishedKeyIDs(keyset)
+		if err != nil {
+			t.Fatalf("%s: error building published keys: %v", phase, err)
+		}
+		if len(keyIDs) != expected {
+			t.Errorf("%s: expected %d published keys, got %v", phase, expected, keyIDs)
+		}
+		return keyIDs
+	}
+
+	previous := expectKeys("before", 1)
+	for _, phase := range []string{rotateCAPhaseStage, rotateCAPhasePromote} {
+		if _, err := rotateKeyset(serviceAccountKeyset, keyset, phase); err != nil {
+			t.Fatalf("%s: unexpected error: %v", phase, err)
+		}
+		expectKeys(phase, 2)
+	}
+	if _, err := rotateKeyset(serviceAccountKeyset, keyset, rotateCAPhaseComplete); err != nil {
+		t.Fatalf("complete: unexpected error: %v", err)
+	}
+	if completed := expectKeys(rotateCAPhaseComplete, 1); len(completed) == 1 && completed[0] == previous[0] {
+		t.Errorf("complete: expected the new key to be published, got the previous key %s", previous[0])
+	}
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/commands"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/dns"
+	"k8s.io/kops/pkg/pretty"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	startClusterLong = pretty.LongDesc(i18n.T(`
+	Starts a cluster stopped by ` + pretty.Bash("kops stop cluster") + `, restoring the sizes its instance groups had
+	before it was stopped.
+
+	The control plane comes back with new addresses. Clusters using a load balancer for the API are reachable
+	as soon as the control plane is healthy. Otherwise the DNS records of the control plane are updated once it
+	boots, which can take a few minutes to propagate, and gossip clusters need their kubeconfig exported again.
+	Use --wait to wait for the cluster to pass validation.
+	`))
+
+	startClusterExample = templates.Examples(i18n.T(`
+	# Start a stopped cluster and wait for it to become ready
+	kops start cluster k8s-cluster.example.com --yes --wait 15m
+	`))
+
+	startClusterShort = i18n.T("Start a cluster stopped by kops stop cluster.")
+)
+
+type StartClusterOptions struct {
+	ClusterName string
+	Yes         bool
+	// Wait is how long to wait for the cluster to pass validation once started; zero doesn't validate.
+	Wait time.Duration
+}
+
+func NewCmdStartCluster(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &StartClusterOptions{}
+
+	cmd := &cobra.Command{
+		Use:               "cluster [CLUSTER]",
+		Short:             startClusterShort,
+		Long:              startClusterLong,
+		Example:           startClusterExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunStartCluster(context.TODO(), f, out, options)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&options.Yes, "yes", "y", options.Yes, "Start the cluster")
+	cmd.Flags().DurationVar(&options.Wait, "wait", options.Wait, "Amount of time to wait for the cluster to pass validation once started")
+
+	return cmd
+}
+
+func RunStartCluster(ctx context.Context, f *util.Factory, out io.Writer, options *StartClusterOptions) error {
+	cluster, err := GetCluster(ctx, f, options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	clientset, err := f.Clientset()
+	if err != nil {
+		return err
+	}
+
+	instanceGroups, err := commands.ReadAllInstanceGroups(ctx, clientset, cluster)
+	if err != nil {
+		return err
+	}
+
+	sizes := buildInstanceGroupSizes(instanceGroups)
+	if err := commands.StartCluster(cluster, instanceGroups); err != nil {
+		return err
+	}
+	if err := renderInstanceGroupSizes(out, sizes, instanceGroups); err != nil {
+		return err
+	}
+
+	if !options.Yes {
+		fmt.Fprintf(out, "\nMust specify --yes to start the cluster\n")
+		return nil
+	}
+
+	// The instance groups are written first, so the cluster stays stopped
+	// and "kops start cluster" can be retried if writing one of them fails.
+	for _, ig := range instanceGroups {
+		if _, err := clientset.InstanceGroupsFor(cluster).Update(ctx, ig, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("error writing InstanceGroup %q: %v", ig.ObjectMeta.Name, err)
+		}
+	}
+	if err := commands.UpdateCluster(ctx, clientset, cluster, instanceGroups); err != nil {
+		return err
+	}
+
+	if err := applyClusterSizes(ctx, f, out, cluster.ObjectMeta.Name); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\nCluster %q is starting.\n", cluster.ObjectMeta.Name)
+	if !hasAPILoadBalancer(cluster) {
+		if dns.IsGossipHostname(cluster.ObjectMeta.Name) {
+			fmt.Fprintf(out, "The control plane has new addresses: once it is running, export the kubeconfig again with `kops export kubeconfig %s --admin`.\n", cluster.ObjectMeta.Name)
+		} else {
+			fmt.Fprintf(out, "The DNS records of the control plane are updated once it boots; stale records may be cached for a few minutes.\n")
+		}
+	}
+
+	if options.Wait == 0 {
+		fmt.Fprintf(out, "Validate the cluster with `kops validate cluster %s --wait 15m`.\n", cluster.ObjectMeta.Name)
+		return nil
+	}
+
+	validateClusterOptions := &ValidateClusterOptions{}
+	validateClusterOptions.InitDefaults()
+	validateClusterOptions.ClusterName = cluster.ObjectMeta.Name
+	validateClusterOptions.wait = options.Wait
+	result, err := RunValidateCluster(ctx, f, out, validateClusterOptions)
+	if err != nil {
+		return err
+	}
+	if len(result.Failures) != 0 {
+		return fmt.Errorf("cluster %q did not pass validation", cluster.ObjectMeta.Name)
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/cmd/kops/util"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/commands"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/dns"
+	"k8s.io/kops/pkg/pretty"
+	"k8s.io/kops/util/pkg/tables"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	stopClusterLong = pretty.LongDesc(i18n.T(`
+	Stops a cluster by scaling all of its instance groups, including the control plane, to zero.
+	The size of each instance group is recorded, so ` + pretty.Bash("kops start cluster") + ` can restore it.
+
+	The etcd volumes, the load balancers and the rest of the cloud resources of the cluster are kept,
+	so the cluster resumes with its state intact. Instance groups whose size isn't controlled by kOps,
+	such as those managed by Karpenter, are left running.
+	`))
+
+	stopClusterExample = templates.Examples(i18n.T(`
+	# Stop a cluster at the end of the day
+	kops stop cluster k8s-cluster.example.com --yes
+	`))
+
+	stopClusterShort = i18n.T("Stop a cluster by scaling its instance groups to zero.")
+)
+
+type StopClusterOptions struct {
+	ClusterName string
+	Yes         bool
+}
+
+func NewCmdStopCluster(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &StopClusterOptions{}
+
+	cmd := &cobra.Command{
+		Use:               "cluster [CLUSTER]",
+		Short:             stopClusterShort,
+		Long:              stopClusterLong,
+		Example:           stopClusterExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunStopCluster(context.TODO(), f, out, options)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&options.Yes, "yes", "y", options.Yes, "Stop the cluster")
+
+	return cmd
+}
+
+func RunStopCluster(ctx context.Context, f *util.Factory, out io.Writer, options *StopClusterOptions) error {
+	cluster, err := GetCluster(ctx, f, options.ClusterName)
+	if err != nil {
+		return err
+	}
+	if cluster.ObjectMeta.Annotations[kopsapi.AnnotationNameManagement] == kopsapi.AnnotationValueManagementImported {
+		return fmt.Errorf("stop is not for use with imported clusters")
+	}
+
+	clientset, err := f.Clientset()
+	if err != nil {
+		return err
+	}
+
+	instanceGroups, err := commands.ReadAllInstanceGroups(ctx, clientset, cluster)
+	if err != nil {
+		return err
+	}
+
+	sizes := buildInstanceGroupSizes(instanceGroups)
+	if err := commands.StopCluster(cluster, instanceGroups, time.Now()); err != nil {
+		return err
+	}
+	if err := renderInstanceGroupSizes(out, sizes, instanceGroups); err != nil {
+		return err
+	}
+
+	for _, ig := range instanceGroups {
+		if !commands.CanStopInstanceGroup(ig) {
+			fmt.Fprintf(out, "\nInstanceGroup %q is managed by %s and will keep running.\n", ig.ObjectMeta.Name, ig.Spec.Manager)
+		}
+	}
+	if !dns.IsGossipHostname(cluster.ObjectMeta.Name) && !hasAPILoadBalancer(cluster) {
+		fmt.Fprintf(out, "\nThe DNS records of the control plane will point at addresses that no longer exist until the cluster is started.\n")
+	}
+
+	if !options.Yes {
+		fmt.Fprintf(out, "\nMust specify --yes to stop the cluster\n")
+		return nil
+	}
+
+	// The cluster is written first: if writing an instance group fails,
+	// "kops start cluster" still restores the instance groups that were stopped.
+	if err := commands.UpdateCluster(ctx, clientset, cluster, instanceGroups); err != nil {
+		return err
+	}
+	for _, ig := range instanceGroups {
+		if _, err := clientset.InstanceGroupsFor(cluster).Update(ctx, ig, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("error writing InstanceGroup %q: %v", ig.ObjectMeta.Name, err)
+		}
+	}
+
+	if err := applyClusterSizes(ctx, f, out, cluster.ObjectMeta.Name); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\nCluster %q is stopping. Use `kops start cluster %s --yes` to start it again.\n", cluster.ObjectMeta.Name, cluster.ObjectMeta.Name)
+	return nil
+}
+
+// instanceGroupSize is a row of the table of instance group sizes printed by stop and start.
+type instanceGroupSize struct {
+	Name string
+	Role kopsapi.InstanceGroupRole
+	Old  string
+}
+
+func buildInstanceGroupSizes(instanceGroups []*kopsapi.InstanceGroup) []*instanceGroupSize {
+	var sizes []*instanceGroupSize
+	for _, ig := range instanceGroups {
+		sizes = append(sizes, &instanceGroupSize{
+			Name: ig.ObjectMeta.Name,
+			Role: ig.Spec.Role,
+			Old:  formatInstanceGroupSize(ig),
+		})
+	}
+	return sizes
+}
+
+func renderInstanceGroupSizes(out io.Writer, sizes []*instanceGroupSize, instanceGroups []*kopsapi.InstanceGroup) error {
+	newSizes := make(map[string]string)
+	for _, ig := range instanceGroups {
+		newSizes[ig.ObjectMeta.Name] = formatInstanceGroupSize(ig)
+	}
+
+	t := &tables.Table{}
+	t.AddColumn("INSTANCEGROUP", func(s *instanceGroupSize) string {
+		return s.Name
+	})
+	t.AddColumn("ROLE", func(s *instanceGroupSize) string {
+		return string(s.Role)
+	})
+	t.AddColumn("OLD", func(s *instanceGroupSize) string {
+		return s.Old
+	})
+	t.AddColumn("NEW", func(s *instanceGroupSize) string {
+		return newSizes[s.Name]
+	})
+	return t.Render(sizes, out, "INSTANCEGROUP", "ROLE", "OLD", "NEW")
+}
+
+// formatInstanceGroupSize formats the size of an instance group as min/max.
+func formatInstanceGroupSize(ig *kopsapi.InstanceGroup) string {
+	format := func(size *int32) string {
+		if size == nil {
+			return "default"
+		}
+		return strconv.Itoa(int(*size))
+	}
+	return format(ig.Spec.MinSize) + "/" + format(ig.Spec.MaxSize)
+}
+
+func hasAPILoadBalancer(cluster *kopsapi.Cluster) bool {
+	return cluster.Spec.API != nil && cluster.Spec.API.LoadBalancer != nil
+}
+
+// applyClusterSizes applies the new sizes of the instance groups to the cloud.
+func applyClusterSizes(ctx context.Context, f *util.Factory, out io.Writer, clusterName string) error {
+	updateClusterOptions := &UpdateClusterOptions{}
+	updateClusterOptions.InitDefaults()
+	updateClusterOptions.Yes = true
+	updateClusterOptions.ClusterName = clusterName
+	updateClusterOptions.CreateKubecfg = false
+	updateClusterOptions.Quiet = true
+
+	_, err := RunUpdateCluster(ctx, f, out, updateClusterOptions)
+	return err
+}
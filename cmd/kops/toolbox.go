@@ -32,7 +32,12 @@ func NewCmdToolbox(f *util.Factory, out io.Writer) *cobra.Command {
 		Short: toolboxShort,
 	}
 
+	cmd.AddCommand(NewCmdToolboxBenchmark(f, out))
 	cmd.AddCommand(NewCmdToolboxDump(f, out))
+	cmd.AddCommand(NewCmdToolboxEnroll(f, out))
+	cmd.AddCommand(NewCmdToolboxEtcdRestore(f, out))
+	cmd.AddCommand(NewCmdToolboxExpandCIDR(f, out))
+	cmd.AddCommand(NewCmdToolboxIAMAudit(f, out))
 	cmd.AddCommand(NewCmdToolboxTemplate(f, out))
 	cmd.AddCommand(NewCmdToolboxInstanceSelector(f, out))
 
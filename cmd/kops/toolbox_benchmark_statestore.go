@@ -0,0 +1,186 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/commands"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/util/pkg/tables"
+	"k8s.io/kops/util/pkg/vfs"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	toolboxBenchmarkStateStoreLong = templates.LongDesc(i18n.T(`
+	Measure the latency of the state store operations kOps performs for a cluster.
+
+	Clusters are listed and read, objects are written, read back and listed under the cluster's
+	configBase, and the cluster's secrets and CA keyset are read. The objects written are removed afterwards.
+
+	The timings of the individual requests made to the state store are also displayed, broken down by
+	storage backend. Running any kOps command with -v=2 logs the same breakdown, which helps tell whether a
+	slow command is waiting on the state store or on the cloud provider.`))
+
+	toolboxBenchmarkStateStoreExample = templates.Examples(i18n.T(`
+	# Benchmark the state store of a cluster
+	kops toolbox benchmark state-store --name k8s-cluster.example.com
+
+	# Benchmark with more iterations and larger objects
+	kops toolbox benchmark state-store --name k8s-cluster.example.com --iterations 50 --object-size 1048576
+	`))
+
+	toolboxBenchmarkStateStoreShort = i18n.T(`Measure the latency of state store operations`)
+)
+
+type ToolboxBenchmarkStateStoreOptions struct {
+	ClusterName string
+
+	Iterations int
+	ObjectSize int
+}
+
+func NewCmdToolboxBenchmarkStateStore(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &ToolboxBenchmarkStateStoreOptions{
+		Iterations: 10,
+		ObjectSize: 16384,
+	}
+
+	cmd := &cobra.Command{
+		Use:               "state-store [CLUSTER]",
+		Short:             toolboxBenchmarkStateStoreShort,
+		Long:              toolboxBenchmarkStateStoreLong,
+		Example:           toolboxBenchmarkStateStoreExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunToolboxBenchmarkStateStore(context.TODO(), f, out, options)
+		},
+	}
+
+	cmd.Flags().IntVar(&options.Iterations, "iterations", options.Iterations, "Number of times each operation is performed")
+	cmd.Flags().IntVar(&options.ObjectSize, "object-size", options.ObjectSize, "Size in bytes of the objects written and read back")
+
+	return cmd
+}
+
+func RunToolboxBenchmarkStateStore(ctx context.Context, f *util.Factory, out io.Writer, options *ToolboxBenchmarkStateStoreOptions) error {
+	clientset, err := f.Clientset()
+	if err != nil {
+		return err
+	}
+
+	cluster, err := GetCluster(ctx, f, options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	vfs.ResetOperationStats()
+	results, err := commands.BenchmarkStateStore(ctx, clientset, cluster, &commands.BenchmarkStateStoreOptions{
+		Iterations: options.Iterations,
+		ObjectSize: options.ObjectSize,
+	})
+	if err != nil {
+		return err
+	}
+
+	t := &tables.Table{}
+	t.AddColumn("OPERATION", func(r *commands.BenchmarkResult) string {
+		return r.Operation
+	})
+	t.AddColumn("COUNT", func(r *commands.BenchmarkResult) string {
+		return strconv.Itoa(r.Count)
+	})
+	t.AddColumn("ERRORS", func(r *commands.BenchmarkResult) string {
+		return strconv.Itoa(r.Errors)
+	})
+	t.AddColumn("MIN", func(r *commands.BenchmarkResult) string {
+		return formatBenchmarkDuration(r.Min)
+	})
+	t.AddColumn("AVG", func(r *commands.BenchmarkResult) string {
+		return formatBenchmarkDuration(r.Average())
+	})
+	t.AddColumn("MAX", func(r *commands.BenchmarkResult) string {
+		return formatBenchmarkDuration(r.Max)
+	})
+	t.AddColumn("THROUGHPUT", func(r *commands.BenchmarkResult) string {
+		throughput := r.Throughput()
+		if throughput == 0 {
+			return "-"
+		}
+		return fmt.Sprintf("%.1f KiB/s", throughput/1024)
+	})
+	if err := t.Render(results, out, "OPERATION", "COUNT", "ERRORS", "MIN", "AVG", "MAX", "THROUGHPUT"); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\nState store requests:\n")
+	stats := vfs.GetOperationStats()
+	t = &tables.Table{}
+	t.AddColumn("BACKEND", func(s vfs.OperationStats) string {
+		return s.Backend
+	})
+	t.AddColumn("REQUEST", func(s vfs.OperationStats) string {
+		return s.Operation
+	})
+	t.AddColumn("COUNT", func(s vfs.OperationStats) string {
+		return strconv.Itoa(s.Count)
+	})
+	t.AddColumn("TOTAL", func(s vfs.OperationStats) string {
+		return formatBenchmarkDuration(s.Total)
+	})
+	t.AddColumn("AVG", func(s vfs.OperationStats) string {
+		return formatBenchmarkDuration(s.Average())
+	})
+	t.AddColumn("MAX", func(s vfs.OperationStats) string {
+		return formatBenchmarkDuration(s.Max)
+	})
+	if err := t.Render(stats, out, "BACKEND", "REQUEST", "COUNT", "TOTAL", "AVG", "MAX"); err != nil {
+		return err
+	}
+
+	if errors := countBenchmarkErrors(results); errors != 0 {
+		return fmt.Errorf("%d state store operations failed", errors)
+	}
+	return nil
+}
+
+func formatBenchmarkDuration(d time.Duration) string {
+	if d == 0 {
+		return "-"
+	}
+	if d < time.Millisecond {
+		return d.Round(time.Microsecond / 10).String()
+	}
+	return d.Round(10 * time.Microsecond).String()
+}
+
+func countBenchmarkErrors(results []*commands.BenchmarkResult) int {
+	errors := 0
+	for _, result := range results {
+		errors += result.Errors
+	}
+	return errors
+}
@@ -24,6 +24,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"text/template"
 
 	"github.com/spf13/cobra"
 	"golang.org/x/crypto/ssh"
@@ -50,11 +51,29 @@ var (
 	toolboxDumpExample = templates.Examples(i18n.T(`
 	# Dump cluster information
 	kops toolbox dump --name k8s-cluster.example.com
+
+	# Write a script that imports the cloud resources of the cluster into the state of the terraform target
+	kops toolbox dump --name k8s-cluster.example.com -o terraform > import.sh
 	`))
 
 	toolboxDumpShort = i18n.T(`Dump cluster information`)
 )
 
+// OutputTerraform writes a script of terraform import commands
+const OutputTerraform = "terraform"
+
+var terraformImportTemplate = template.Must(template.New("terraform-import").Parse(`#!/bin/sh
+# Imports the cloud resources of cluster {{ .ClusterName }} into the state of the terraform configuration
+# written by "kops update cluster --target=terraform". Run it from the directory of the configuration,
+# after checking that the resource addresses match those of the configuration.
+set -e
+{{- range .Imports }}
+
+# {{ .Type }} {{ .Name }}
+terraform import '{{ .Address }}' '{{ .ID }}'
+{{- end }}
+`))
+
 type ToolboxDumpOptions struct {
 	Output string
 
@@ -87,9 +106,9 @@ func NewCmdToolboxDump(f *util.Factory, out io.Writer) *cobra.Command {
 		},
 	}
 
-	cmd.Flags().StringVarP(&options.Output, "output", "o", options.Output, "Output format.  One of json or yaml")
+	cmd.Flags().StringVarP(&options.Output, "output", "o", options.Output, "Output format.  One of json, yaml or terraform")
 	cmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		return []string{"json", "yaml"}, cobra.ShellCompDirectiveNoFileComp
+		return []string{OutputJSON, OutputYaml, OutputTerraform}, cobra.ShellCompDirectiveNoFileComp
 	})
 
 	cmd.Flags().StringVar(&options.Dir, "dir", options.Dir, "Target directory; if specified will collect logs and other information.")
@@ -219,7 +238,29 @@ func RunToolboxDump(ctx context.Context, f *util.Factory, out io.Writer, options
 		}
 		return nil
 
+	case OutputTerraform:
+		imports, err := resourceops.ListTerraformImports(cloud, resourceMap)
+		if err != nil {
+			return err
+		}
+		return writeTerraformImports(out, cluster.ObjectMeta.Name, imports)
+
 	default:
 		return fmt.Errorf("unsupported output format: %q", options.Output)
 	}
 }
+
+// writeTerraformImports writes a shell script importing the resources into the state of the terraform target
+func writeTerraformImports(out io.Writer, clusterName string, imports []*resources.TerraformImport) error {
+	data := struct {
+		ClusterName string
+		Imports     []*resources.TerraformImport
+	}{
+		ClusterName: clusterName,
+		Imports:     imports,
+	}
+	if err := terraformImportTemplate.Execute(out, data); err != nil {
+		return fmt.Errorf("error writing terraform imports: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"k8s.io/kops/pkg/resources"
+)
+
+func TestWriteTerraformImports(t *testing.T) {
+	imports := []*resources.TerraformImport{
+		{Type: "subnet", ID: "subnet-1", Name: "us-test-1a.minimal.example.com", Address: "aws_subnet.us-test-1a-minimal-example-com"},
+		{Type: "vpc", ID: "vpc-1", Name: "minimal.example.com", Address: "aws_vpc.minimal-example-com"},
+	}
+
+	var b bytes.Buffer
+	if err := writeTerraformImports(&b, "minimal.example.com", imports); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `#!/bin/sh
+# Imports the cloud resources of cluster minimal.example.com into the state of the terraform configuration
+# written by "kops update cluster --target=terraform". Run it from the directory of the configuration,
+# after checking that the resource addresses match those of the configuration.
+set -e
+
+# subnet us-test-1a.minimal.example.com
+terraform import 'aws_subnet.us-test-1a-minimal-example-com' 'subnet-1'
+
+# vpc minimal.example.com
+terraform import 'aws_vpc.minimal-example-com' 'vpc-1'
+`
+	if b.String() != expected {
+		t.Errorf("expected %q, got %q", expected, b.String())
+	}
+}
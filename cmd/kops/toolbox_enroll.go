@@ -0,0 +1,315 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/bootstrap/metal"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/model"
+	"k8s.io/kops/pkg/pki"
+	"k8s.io/kops/util/pkg/vfs"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	toolboxEnrollLong = templates.LongDesc(i18n.T(`
+	Enroll an existing machine as a node of a metal instance group.
+
+	The machine is reached over SSH. kOps issues a host certificate for the machine, which nodeup uses to
+	authenticate to kops-controller, copies it to the machine together with the bootstrap script of the
+	instance group and runs the bootstrap script. The machine then joins the cluster as a node named after its hostname.
+
+	The host key of the machine is verified against the known_hosts file, as the node credentials are sent over the connection.
+
+	The instance group must have the "Metal" manager, and "kops update cluster --yes" must have been run
+	since it was created. The machine must be able to resolve and reach the kops-controller and API server names of the cluster.`))
+
+	toolboxEnrollExample = templates.Examples(i18n.T(`
+	# Enroll a machine as a node of the metal instance group
+	kops toolbox enroll --name k8s-cluster.example.com --instance-group metal-nodes --host 192.0.2.10
+
+	# Enroll a machine using a specific SSH user and key
+	kops toolbox enroll --name k8s-cluster.example.com --instance-group metal-nodes --host 192.0.2.10 \
+	  --ssh-user ubuntu --private-key ~/.ssh/metal_rsa
+	`))
+
+	toolboxEnrollShort = i18n.T(`Enroll an existing machine as a node of a metal instance group`)
+)
+
+type ToolboxEnrollOptions struct {
+	ClusterName       string
+	InstanceGroupName string
+
+	// Host is the IP address or DNS name of the machine to enroll.
+	Host string
+	// SSHPort is the port of the SSH server of the machine.
+	SSHPort int
+	// SSHUser is the remote user for SSH access to the machine; it must be root or able to run sudo without a password.
+	SSHUser string
+	// PrivateKey is the file containing the private key for SSH access to the machine.
+	PrivateKey string
+	// SSHKnownHosts is the known_hosts file used to verify the host key of the machine.
+	SSHKnownHosts string
+	// InsecureIgnoreHostKey skips the verification of the host key of the machine.
+	InsecureIgnoreHostKey bool
+}
+
+func (o *ToolboxEnrollOptions) InitDefaults() {
+	o.SSHPort = 22
+	o.SSHUser = "root"
+	o.PrivateKey = "~/.ssh/id_rsa"
+	o.SSHKnownHosts = "~/.ssh/known_hosts"
+}
+
+func NewCmdToolboxEnroll(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &ToolboxEnrollOptions{}
+	options.InitDefaults()
+
+	cmd := &cobra.Command{
+		Use:               "enroll [CLUSTER]",
+		Short:             toolboxEnrollShort,
+		Long:              toolboxEnrollLong,
+		Example:           toolboxEnrollExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunToolboxEnroll(context.TODO(), f, out, options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.InstanceGroupName, "instance-group", options.InstanceGroupName, "Name of the metal instance group to enroll the machine in")
+	cmd.RegisterFlagCompletionFunc("instance-group", completeInstanceGroup(f, nil, nil))
+	cmd.Flags().StringVar(&options.Host, "host", options.Host, "IP address or DNS name of the machine to enroll")
+	cmd.RegisterFlagCompletionFunc("host", cobra.NoFileCompletions)
+	cmd.Flags().IntVar(&options.SSHPort, "ssh-port", options.SSHPort, "Port of the SSH server of the machine")
+	cmd.Flags().StringVar(&options.SSHUser, "ssh-user", options.SSHUser, "The remote user for SSH access to the machine")
+	cmd.RegisterFlagCompletionFunc("ssh-user", cobra.NoFileCompletions)
+	cmd.Flags().StringVar(&options.PrivateKey, "private-key", options.PrivateKey, "File containing private key to use for SSH access to the machine")
+	cmd.Flags().StringVar(&options.SSHKnownHosts, "ssh-known-hosts", options.SSHKnownHosts, "File of known host keys used to verify the SSH host key of the machine")
+	cmd.Flags().BoolVar(&options.InsecureIgnoreHostKey, "insecure-ignore-host-key", options.InsecureIgnoreHostKey, "Do not verify the SSH host key of the machine. The node credentials can then be intercepted")
+
+	return cmd
+}
+
+func RunToolboxEnroll(ctx context.Context, f *util.Factory, out io.Writer, options *ToolboxEnrollOptions) error {
+	if options.InstanceGroupName == "" {
+		return fmt.Errorf("--instance-group is required")
+	}
+	if options.Host == "" {
+		return fmt.Errorf("--host is required")
+	}
+
+	clientset, err := f.Clientset()
+	if err != nil {
+		return err
+	}
+
+	cluster, err := GetCluster(ctx, f, options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	ig, err := clientset.InstanceGroupsFor(cluster).Get(ctx, options.InstanceGroupName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error reading instance group %q: %w", options.InstanceGroupName, err)
+	}
+	if ig.Spec.Manager != kops.InstanceManagerMetal {
+		return fmt.Errorf("instance group %q is not a metal instance group", ig.ObjectMeta.Name)
+	}
+
+	configBase, err := vfs.Context.BuildVfsPath(cluster.Spec.ConfigBase)
+	if err != nil {
+		return fmt.Errorf("error parsing config base %q: %w", cluster.Spec.ConfigBase, err)
+	}
+	bootstrapScriptPath := configBase.Join(model.MetalBootstrapScriptLocation(ig.ObjectMeta.Name))
+	bootstrapScript, err := bootstrapScriptPath.ReadFile()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("bootstrap script %s not found; run \"kops update cluster --yes\" first", bootstrapScriptPath)
+		}
+		return fmt.Errorf("error reading bootstrap script %s: %w", bootstrapScriptPath, err)
+	}
+
+	sshClient, err := dialEnrollHost(options)
+	if err != nil {
+		return err
+	}
+	defer sshClient.Close()
+
+	hostname, err := runEnrollCommand(sshClient, "hostname", nil)
+	if err != nil {
+		return fmt.Errorf("error getting hostname of %s: %w", options.Host, err)
+	}
+	// The kubelet registers the node with the lower-cased hostname
+	nodeName := strings.ToLower(strings.TrimSpace(string(hostname)))
+	if nodeName == "" {
+		return fmt.Errorf("machine %s has an empty hostname", options.Host)
+	}
+
+	keyStore, err := clientset.KeyStore(cluster)
+	if err != nil {
+		return err
+	}
+	req := metal.IssueHostCertificateRequest(nodeName, ig.ObjectMeta.Name, []string{options.Host})
+	certificate, privateKey, _, err := pki.IssueCert(req, keyStore)
+	if err != nil {
+		return fmt.Errorf("error issuing host certificate: %w", err)
+	}
+	certificateBytes, err := certificate.AsBytes()
+	if err != nil {
+		return err
+	}
+	privateKeyBytes, err := privateKey.AsBytes()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Enrolling %s as node %q of instance group %q\n", options.Host, nodeName, ig.ObjectMeta.Name)
+
+	bootstrapScriptRemotePath := path.Join(path.Dir(metal.HostCertificatePath), "bootstrap.sh")
+	for _, file := range []struct {
+		path     string
+		contents []byte
+		mode     string
+	}{
+		{path: metal.HostCertificatePath, contents: certificateBytes, mode: "0644"},
+		{path: metal.HostKeyPath, contents: privateKeyBytes, mode: "0600"},
+		{path: bootstrapScriptRemotePath, contents: bootstrapScript, mode: "0700"},
+	} {
+		klog.V(2).Infof("writing %s on %s", file.path, options.Host)
+		command := fmt.Sprintf("mkdir -p %s && touch %s && chmod %s %s && cat > %s", path.Dir(file.path), file.path, file.mode, file.path, file.path)
+		if _, err := runEnrollCommand(sshClient, sudo(options.SSHUser, command), file.contents); err != nil {
+			return fmt.Errorf("error writing %s on %s: %w", file.path, options.Host, err)
+		}
+	}
+
+	fmt.Fprintf(out, "Running the bootstrap script on %s\n", options.Host)
+	if _, err := runEnrollCommand(sshClient, sudo(options.SSHUser, "/bin/bash "+bootstrapScriptRemotePath), nil); err != nil {
+		return fmt.Errorf("error running bootstrap script on %s: %w", options.Host, err)
+	}
+
+	fmt.Fprintf(out, "\nMachine %s was enrolled; node %q will join the cluster once nodeup completes\n", options.Host, nodeName)
+	return nil
+}
+
+// dialEnrollHost opens an SSH connection to the machine being enrolled.
+func dialEnrollHost(options *ToolboxEnrollOptions) (*ssh.Client, error) {
+	privateKeyPath := expandHomePath(options.PrivateKey)
+	key, err := os.ReadFile(privateKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading private key %q: %v", privateKeyPath, err)
+	}
+
+	signer, err := ssh.ParsePrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing private key %q: %v", privateKeyPath, err)
+	}
+
+	sshConfig := &ssh.ClientConfig{
+		Config: ssh.Config{},
+		User:   options.SSHUser,
+		Auth: []ssh.AuthMethod{
+			ssh.PublicKeys(signer),
+		},
+	}
+
+	// The node credentials are sent over this connection, so the host must be verified
+	sshConfig.HostKeyCallback, err = buildEnrollHostKeyCallback(options)
+	if err != nil {
+		return nil, err
+	}
+
+	addr := net.JoinHostPort(options.Host, strconv.Itoa(options.SSHPort))
+	client, err := ssh.Dial("tcp", addr, sshConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to %s: %w", addr, err)
+	}
+	return client, nil
+}
+
+// buildEnrollHostKeyCallback verifies the host key of the machine against the known_hosts file,
+// unless verification was explicitly disabled.
+func buildEnrollHostKeyCallback(options *ToolboxEnrollOptions) (ssh.HostKeyCallback, error) {
+	if options.InsecureIgnoreHostKey {
+		klog.Warningf("not verifying the SSH host key of %s", options.Host)
+		return ssh.InsecureIgnoreHostKey(), nil
+	}
+
+	knownHostsPath := expandHomePath(options.SSHKnownHosts)
+	callback, err := knownhosts.New(knownHostsPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading known hosts %q (use --ssh-known-hosts to specify the file): %w", knownHostsPath, err)
+	}
+	return callback, nil
+}
+
+// expandHomePath expands a leading ~/ to the home directory of the user.
+func expandHomePath(p string) string {
+	if strings.HasPrefix(p, "~/") {
+		return filepath.Join(os.Getenv("HOME"), p[2:])
+	}
+	return p
+}
+
+// runEnrollCommand runs a command on the machine being enrolled, returning its output.
+func runEnrollCommand(client *ssh.Client, command string, stdin []byte) ([]byte, error) {
+	session, err := client.NewSession()
+	if err != nil {
+		return nil, fmt.Errorf("error creating ssh session: %w", err)
+	}
+	defer session.Close()
+
+	klog.V(2).Infof("running SSH command: %v", command)
+
+	var stdout, stderr bytes.Buffer
+	session.Stdout = &stdout
+	session.Stderr = &stderr
+	if stdin != nil {
+		session.Stdin = bytes.NewReader(stdin)
+	}
+	if err := session.Run(command); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, stderr.String())
+	}
+	return stdout.Bytes(), nil
+}
+
+// sudo wraps a shell command to run as root, unless the SSH user is already root.
+func sudo(sshUser string, command string) string {
+	if sshUser == "root" {
+		return "sh -c '" + command + "'"
+	}
+	return "sudo sh -c '" + command + "'"
+}
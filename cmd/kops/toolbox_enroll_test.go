@@ -0,0 +1,106 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+func TestBuildEnrollHostKeyCallback(t *testing.T) {
+	newHostKey := func() ssh.PublicKey {
+		pub, _, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatalf("error generating key: %v", err)
+		}
+		key, err := ssh.NewPublicKey(pub)
+		if err != nil {
+			t.Fatalf("error converting key: %v", err)
+		}
+		return key
+	}
+	hostKey := newHostKey()
+	otherKey := newHostKey()
+
+	knownHostsPath := filepath.Join(t.TempDir(), "known_hosts")
+	line := knownhosts.Line([]string{knownhosts.Normalize("192.0.2.10:22")}, hostKey) + "\n"
+	if err := os.WriteFile(knownHostsPath, []byte(line), 0o600); err != nil {
+		t.Fatalf("error writing known hosts: %v", err)
+	}
+
+	remote := &net.TCPAddr{IP: net.ParseIP("192.0.2.10"), Port: 22}
+	grid := []struct {
+		Description string
+		Options     ToolboxEnrollOptions
+		Hostname    string
+		Key         ssh.PublicKey
+		ExpectError bool
+	}{
+		{
+			Description: "known host",
+			Options:     ToolboxEnrollOptions{Host: "192.0.2.10", SSHKnownHosts: knownHostsPath},
+			Hostname:    "192.0.2.10:22",
+			Key:         hostKey,
+		},
+		{
+			Description: "changed host key",
+			Options:     ToolboxEnrollOptions{Host: "192.0.2.10", SSHKnownHosts: knownHostsPath},
+			Hostname:    "192.0.2.10:22",
+			Key:         otherKey,
+			ExpectError: true,
+		},
+		{
+			Description: "unknown host",
+			Options:     ToolboxEnrollOptions{Host: "192.0.2.11", SSHKnownHosts: knownHostsPath},
+			Hostname:    "192.0.2.11:22",
+			Key:         hostKey,
+			ExpectError: true,
+		},
+		{
+			Description: "insecure",
+			Options:     ToolboxEnrollOptions{Host: "192.0.2.11", SSHKnownHosts: knownHostsPath, InsecureIgnoreHostKey: true},
+			Hostname:    "192.0.2.11:22",
+			Key:         otherKey,
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.Description, func(t *testing.T) {
+			callback, err := buildEnrollHostKeyCallback(&g.Options)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			err = callback(g.Hostname, remote, g.Key)
+			if g.ExpectError && err == nil {
+				t.Errorf("expected host key to be rejected")
+			}
+			if !g.ExpectError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+
+	if _, err := buildEnrollHostKeyCallback(&ToolboxEnrollOptions{SSHKnownHosts: filepath.Join(t.TempDir(), "missing")}); err == nil {
+		t.Errorf("expected error for missing known hosts file")
+	}
+}
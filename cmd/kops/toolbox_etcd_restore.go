@@ -0,0 +1,202 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/commands"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/util/pkg/tables"
+	"k8s.io/kops/util/pkg/vfs"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	toolboxEtcdRestoreLong = templates.LongDesc(i18n.T(`
+	List the backups etcd-manager has made in the state store, or restore the etcd clusters from a backup.
+
+	Without --backup, the backups of the etcd clusters are listed. With --backup, a restore command is
+	added to the etcd-manager control store of the etcd cluster holding that backup, and the control plane
+	instances are rolled so that etcd-manager picks up the command. Backups of several etcd clusters, such as
+	main and events, can be restored at once by repeating --backup.
+
+	The restore involves downtime of the API server, and resources created after the backup are lost.
+	The control plane is rolled without validating the cluster, as it is unavailable during the restore.`))
+
+	toolboxEtcdRestoreExample = templates.Examples(i18n.T(`
+	# List the backups of all etcd clusters
+	kops toolbox etcd-restore --name k8s-cluster.example.com
+
+	# List the backups of the main etcd cluster
+	kops toolbox etcd-restore --name k8s-cluster.example.com --etcd-cluster main
+
+	# Restore the main and events etcd clusters from backups
+	kops toolbox etcd-restore --name k8s-cluster.example.com \
+		--backup 2022-06-01T10:15:00Z-000001 --backup 2022-06-01T10:15:04Z-000001 --yes
+	`))
+
+	toolboxEtcdRestoreShort = i18n.T(`List etcd backups, or restore etcd from a backup`)
+)
+
+type ToolboxEtcdRestoreOptions struct {
+	ClusterName string
+
+	// EtcdClusters is the list of etcd clusters to consider; if not specified, all etcd clusters are considered
+	EtcdClusters []string
+
+	// Backups is the list of backups to restore, at most one per etcd cluster
+	Backups []string
+
+	Yes bool
+}
+
+func NewCmdToolboxEtcdRestore(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &ToolboxEtcdRestoreOptions{}
+
+	cmd := &cobra.Command{
+		Use:               "etcd-restore [CLUSTER]",
+		Short:             toolboxEtcdRestoreShort,
+		Long:              toolboxEtcdRestoreLong,
+		Example:           toolboxEtcdRestoreExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunToolboxEtcdRestore(context.TODO(), f, out, options)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&options.EtcdClusters, "etcd-cluster", options.EtcdClusters, "Etcd clusters to list or restore the backups of (defaults to all if not specified)")
+	cmd.Flags().StringSliceVar(&options.Backups, "backup", options.Backups, "Backup to restore; may be repeated to restore several etcd clusters")
+	cmd.Flags().BoolVarP(&options.Yes, "yes", "y", options.Yes, "Restore the backups and roll the control plane immediately; without --yes the restore is a dry-run")
+
+	return cmd
+}
+
+func RunToolboxEtcdRestore(ctx context.Context, f *util.Factory, out io.Writer, options *ToolboxEtcdRestoreOptions) error {
+	cluster, err := GetCluster(ctx, f, options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	var etcdClusters []*kops.EtcdClusterSpec
+	for i := range cluster.Spec.EtcdClusters {
+		etcdClusters = append(etcdClusters, &cluster.Spec.EtcdClusters[i])
+	}
+	if len(options.EtcdClusters) != 0 {
+		var filtered []*kops.EtcdClusterSpec
+		for _, name := range options.EtcdClusters {
+			var found *kops.EtcdClusterSpec
+			for _, etcdCluster := range etcdClusters {
+				if etcdCluster.Name == name {
+					found = etcdCluster
+				}
+			}
+			if found == nil {
+				return fmt.Errorf("etcd cluster %q not found", name)
+			}
+			filtered = append(filtered, found)
+		}
+		etcdClusters = filtered
+	}
+
+	backupStores := make(map[string]vfs.Path)
+	var backups []commands.EtcdBackup
+	for _, etcdCluster := range etcdClusters {
+		backupStore, err := commands.EtcdBackupStore(etcdCluster)
+		if err != nil {
+			return err
+		}
+		backupStores[etcdCluster.Name] = backupStore
+
+		etcdBackups, err := commands.ListEtcdBackups(etcdCluster.Name, backupStore)
+		if err != nil {
+			return err
+		}
+		backups = append(backups, etcdBackups...)
+	}
+
+	if len(options.Backups) == 0 {
+		t := &tables.Table{}
+		t.AddColumn("ETCD CLUSTER", func(b commands.EtcdBackup) string {
+			return b.EtcdCluster
+		})
+		t.AddColumn("BACKUP", func(b commands.EtcdBackup) string {
+			return b.Name
+		})
+		return t.Render(backups, out, "ETCD CLUSTER", "BACKUP")
+	}
+
+	restores := make(map[string]string)
+	var restoreOrder []string
+	for _, name := range options.Backups {
+		var found []string
+		for _, backup := range backups {
+			if backup.Name == name {
+				found = append(found, backup.EtcdCluster)
+			}
+		}
+		switch len(found) {
+		case 0:
+			return fmt.Errorf("backup %q not found", name)
+		case 1:
+		default:
+			return fmt.Errorf("backup %q found in etcd clusters %s; use --etcd-cluster to select one", name, strings.Join(found, ", "))
+		}
+
+		etcdClusterName := found[0]
+		if existing, ok := restores[etcdClusterName]; ok {
+			return fmt.Errorf("cannot restore both backups %q and %q of etcd cluster %q", existing, name, etcdClusterName)
+		}
+		restores[etcdClusterName] = name
+		restoreOrder = append(restoreOrder, etcdClusterName)
+	}
+
+	now := time.Now()
+	for _, etcdClusterName := range restoreOrder {
+		backup := restores[etcdClusterName]
+		if !options.Yes {
+			fmt.Fprintf(out, "Will restore backup %q of etcd cluster %q\n", backup, etcdClusterName)
+			continue
+		}
+		if err := commands.AddEtcdRestoreCommand(backupStores[etcdClusterName], backup, now); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Added command to restore backup %q of etcd cluster %q\n", backup, etcdClusterName)
+	}
+	fmt.Fprintf(out, "\n")
+
+	// etcd-manager reads its control store when it starts, so roll the control plane to apply the restore
+	rollingUpdateOptions := &RollingUpdateOptions{}
+	rollingUpdateOptions.InitDefaults()
+	rollingUpdateOptions.ClusterName = cluster.ObjectMeta.Name
+	rollingUpdateOptions.Yes = options.Yes
+	rollingUpdateOptions.Force = true
+	rollingUpdateOptions.CloudOnly = true
+	rollingUpdateOptions.FailOnValidate = false
+	rollingUpdateOptions.InstanceGroupRoles = []string{strings.ToLower(string(kops.InstanceGroupRoleMaster))}
+
+	return RunRollingUpdateCluster(ctx, f, out, rollingUpdateOptions)
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/commands"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	toolboxExpandCIDRLong = templates.LongDesc(i18n.T(`
+	Expand the nonMasqueradeCIDR and podCIDR of a cluster.
+
+	Each new CIDR must contain the current one, so that the pod CIDRs already allocated to nodes remain valid.
+	The serviceClusterIPRange cannot be changed; it is pinned to its current value in the cluster spec.
+	The podCIDR can only be expanded into address space that is not used by the serviceClusterIPRange.
+
+	Expansion is supported with kubenet, kopeio, flannel, kube-router and cilium (with the "kubernetes" IPAM mode)
+	networking, which use the pod CIDRs that kube-controller-manager allocates to nodes.
+
+	Without --yes, the changes to the cluster spec are only displayed. With --yes, the cluster spec is validated and
+	updated, and the steps needed to roll out the change are displayed.`))
+
+	toolboxExpandCIDRExample = templates.Examples(i18n.T(`
+	# Preview expanding the internal network of a cluster
+	kops toolbox expand-cidr --name k8s-cluster.example.com --non-masquerade-cidr 100.0.0.0/9
+
+	# Expand the internal network and the pod CIDR of a cluster
+	kops toolbox expand-cidr --name k8s-cluster.example.com --non-masquerade-cidr 10.0.0.0/13 --pod-cidr 10.2.0.0/15 --yes
+	`))
+
+	toolboxExpandCIDRShort = i18n.T(`Expand the pod and non-masquerade CIDRs of a cluster`)
+)
+
+type ToolboxExpandCIDROptions struct {
+	ClusterName string
+
+	NonMasqueradeCIDR string
+	PodCIDR           string
+
+	Yes bool
+}
+
+func NewCmdToolboxExpandCIDR(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &ToolboxExpandCIDROptions{}
+
+	cmd := &cobra.Command{
+		Use:               "expand-cidr [CLUSTER]",
+		Short:             toolboxExpandCIDRShort,
+		Long:              toolboxExpandCIDRLong,
+		Example:           toolboxExpandCIDRExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunToolboxExpandCIDR(context.TODO(), f, out, options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.NonMasqueradeCIDR, "non-masquerade-cidr", options.NonMasqueradeCIDR, "New nonMasqueradeCIDR, which must contain the current one")
+	cmd.Flags().StringVar(&options.PodCIDR, "pod-cidr", options.PodCIDR, "New podCIDR, which must contain the current one")
+	cmd.Flags().BoolVarP(&options.Yes, "yes", "y", options.Yes, "Update the cluster spec")
+
+	return cmd
+}
+
+func RunToolboxExpandCIDR(ctx context.Context, f *util.Factory, out io.Writer, options *ToolboxExpandCIDROptions) error {
+	if options.NonMasqueradeCIDR == "" && options.PodCIDR == "" {
+		return fmt.Errorf("must specify --non-masquerade-cidr or --pod-cidr")
+	}
+
+	oldCluster, err := GetCluster(ctx, f, options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	if err := oldCluster.FillDefaults(); err != nil {
+		return err
+	}
+
+	clientset, err := f.Clientset()
+	if err != nil {
+		return err
+	}
+
+	newCluster := oldCluster.DeepCopy()
+	changes, err := commands.ExpandCIDRs(newCluster, commands.ExpandCIDROptions{
+		NonMasqueradeCIDR: options.NonMasqueradeCIDR,
+		PodCIDR:           options.PodCIDR,
+	})
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		fmt.Fprintf(out, "No changes needed\n")
+		return nil
+	}
+
+	fmt.Fprintf(out, "Changes to the cluster spec:\n")
+	for _, change := range changes {
+		fmt.Fprintf(out, "  %s\n", change)
+	}
+
+	if !options.Yes {
+		fmt.Fprintf(out, "\nMust specify --yes to update the cluster spec\n")
+		return nil
+	}
+
+	instanceGroups, err := commands.ReadAllInstanceGroups(ctx, clientset, newCluster)
+	if err != nil {
+		return err
+	}
+
+	failure, err := updateCluster(ctx, clientset, oldCluster, newCluster, instanceGroups)
+	if err != nil {
+		return err
+	}
+	if failure != "" {
+		return fmt.Errorf("%s", failure)
+	}
+
+	clusterName := newCluster.ObjectMeta.Name
+	fmt.Fprintf(out, "\nThe cluster spec has been updated. To roll out the change:\n")
+	fmt.Fprintf(out, " 1. Apply the new configuration, including the networking addon:\n")
+	fmt.Fprintf(out, "      kops update cluster --name %s --yes\n", clusterName)
+	fmt.Fprintf(out, " 2. Roll the control plane, so that kube-controller-manager allocates pod CIDRs from the new podCIDR:\n")
+	fmt.Fprintf(out, "      kops rolling-update cluster --name %s --instance-group-roles=master,apiserver --yes\n", clusterName)
+	fmt.Fprintf(out, " 3. Roll the remaining nodes, so that the kubelet, kube-proxy and networking pick up the new CIDRs:\n")
+	fmt.Fprintf(out, "      kops rolling-update cluster --name %s --yes\n", clusterName)
+
+	return nil
+}
@@ -0,0 +1,231 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/assets"
+	"k8s.io/kops/pkg/commands"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/upup/pkg/fi/cloudup"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	"k8s.io/kops/util/pkg/tables"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	toolboxIAMAuditLong = templates.LongDesc(i18n.T(`
+	Audit the IAM policies that kops attaches to the instance group roles of a cluster.
+
+	The policies are rendered as "kops update cluster" would, and each statement is cross-referenced
+	with the actions the roles are known to use. Statements granting only unused actions are suggested
+	for removal, and the unused actions of the other statements are listed.
+
+	Used actions are read from the CloudTrail event history of the cluster's region, from a static allowlist,
+	or from both. CloudTrail only records management events, so data events such as reads of S3 objects
+	should be listed in the allowlist. Each line of the allowlist holds an action, which applies to all roles,
+	or a role name followed by an action. Actions may contain the wildcards "*" and "?".
+
+	Only AWS is supported.`))
+
+	toolboxIAMAuditExample = templates.Examples(i18n.T(`
+	# Audit the policies against the actions used in the last week
+	kops toolbox iam-audit --name k8s-cluster.example.com --cloudtrail
+
+	# Audit the policies against the actions used in the last 30 days and a static allowlist
+	kops toolbox iam-audit --name k8s-cluster.example.com --cloudtrail --since 720h --allowlist allowlist.txt
+	`))
+
+	toolboxIAMAuditShort = i18n.T(`Audit the IAM policies of a cluster for unused permissions`)
+)
+
+type ToolboxIAMAuditOptions struct {
+	ClusterName string
+
+	Allowlist  string
+	CloudTrail bool
+	Since      time.Duration
+
+	Output string
+}
+
+func (o *ToolboxIAMAuditOptions) InitDefaults() {
+	o.Since = 7 * 24 * time.Hour
+	o.Output = OutputTable
+}
+
+func NewCmdToolboxIAMAudit(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &ToolboxIAMAuditOptions{}
+	options.InitDefaults()
+
+	cmd := &cobra.Command{
+		Use:               "iam-audit [CLUSTER]",
+		Short:             toolboxIAMAuditShort,
+		Long:              toolboxIAMAuditLong,
+		Example:           toolboxIAMAuditExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunToolboxIAMAudit(context.TODO(), f, out, options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.Allowlist, "allowlist", options.Allowlist, "File listing the IAM actions known to be used")
+	cmd.Flags().BoolVar(&options.CloudTrail, "cloudtrail", options.CloudTrail, "Read the IAM actions used from the CloudTrail event history")
+	cmd.Flags().DurationVar(&options.Since, "since", options.Since, "How far back to read the CloudTrail event history")
+	cmd.Flags().StringVarP(&options.Output, "output", "o", options.Output, "Output format.  One of table, json or yaml")
+	cmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{OutputTable, OutputJSON, OutputYaml}, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	return cmd
+}
+
+func RunToolboxIAMAudit(ctx context.Context, f *util.Factory, out io.Writer, options *ToolboxIAMAuditOptions) error {
+	if options.Allowlist == "" && !options.CloudTrail {
+		return fmt.Errorf("must specify --allowlist or --cloudtrail")
+	}
+
+	cluster, err := GetCluster(ctx, f, options.ClusterName)
+	if err != nil {
+		return err
+	}
+	if cluster.Spec.GetCloudProvider() != kops.CloudProviderAWS {
+		return fmt.Errorf("IAM audit is only supported on AWS")
+	}
+
+	clientset, err := f.Clientset()
+	if err != nil {
+		return err
+	}
+
+	cloud, err := cloudup.BuildCloud(cluster)
+	if err != nil {
+		return err
+	}
+	awsCloud := cloud.(awsup.AWSCloud)
+
+	accountID, partition, err := awsCloud.AccountInfo()
+	if err != nil {
+		return err
+	}
+
+	instanceGroups, err := commands.ReadAllInstanceGroups(ctx, clientset, cluster)
+	if err != nil {
+		return err
+	}
+
+	assetBuilder := assets.NewAssetBuilder(cluster, false)
+	fullCluster, err := cloudup.PopulateClusterSpec(clientset, cluster, cloud, assetBuilder)
+	if err != nil {
+		return err
+	}
+
+	roles, err := commands.BuildIAMAuditRoles(fullCluster, instanceGroups, awsCloud.Region(), partition, accountID)
+	if err != nil {
+		return err
+	}
+
+	usage := commands.IAMUsage{}
+	if options.Allowlist != "" {
+		file, err := os.Open(options.Allowlist)
+		if err != nil {
+			return fmt.Errorf("error opening allowlist: %w", err)
+		}
+		allowlist, err := commands.ParseIAMAllowlist(file)
+		file.Close()
+		if err != nil {
+			return fmt.Errorf("error parsing allowlist %q: %w", options.Allowlist, err)
+		}
+		usage.Merge(allowlist)
+	}
+	if options.CloudTrail {
+		var roleNames []string
+		for _, role := range roles {
+			roleNames = append(roleNames, role.Name)
+		}
+		cloudTrailUsage, err := commands.CollectCloudTrailUsage(ctx, awsCloud.CloudTrail(), roleNames, time.Now().Add(-options.Since))
+		if err != nil {
+			return err
+		}
+		usage.Merge(cloudTrailUsage)
+	}
+
+	results := commands.AuditIAMPolicies(roles, usage)
+
+	switch options.Output {
+	case OutputTable:
+		t := &tables.Table{}
+		t.AddColumn("ROLE", func(r *commands.IAMAuditStatement) string {
+			return r.Role
+		})
+		t.AddColumn("STATEMENT", func(r *commands.IAMAuditStatement) string {
+			return fmt.Sprintf("%d", r.Statement)
+		})
+		t.AddColumn("RESOURCES", func(r *commands.IAMAuditStatement) string {
+			return strings.Join(r.Resources, ",")
+		})
+		t.AddColumn("SUGGESTION", func(r *commands.IAMAuditStatement) string {
+			if r.Removable {
+				return "remove statement"
+			}
+			if len(r.UnusedActions) > 0 {
+				return "remove unused actions"
+			}
+			return ""
+		})
+		t.AddColumn("UNUSED ACTIONS", func(r *commands.IAMAuditStatement) string {
+			return strings.Join(r.UnusedActions, ",")
+		})
+		return t.Render(results, out, "ROLE", "STATEMENT", "RESOURCES", "SUGGESTION", "UNUSED ACTIONS")
+
+	case OutputYaml:
+		y, err := yaml.Marshal(results)
+		if err != nil {
+			return fmt.Errorf("unable to marshal YAML: %v", err)
+		}
+		if _, err := out.Write(y); err != nil {
+			return fmt.Errorf("error writing to output: %v", err)
+		}
+
+	case OutputJSON:
+		j, err := json.Marshal(results)
+		if err != nil {
+			return fmt.Errorf("unable to marshal JSON: %v", err)
+		}
+		if _, err := out.Write(j); err != nil {
+			return fmt.Errorf("error writing to output: %v", err)
+		}
+
+	default:
+		return fmt.Errorf("Unknown output format: %q", options.Output)
+	}
+
+	return nil
+}
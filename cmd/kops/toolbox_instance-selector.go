@@ -54,6 +54,7 @@ const (
 	allowList              = "allow-list"
 	denyList               = "deny-list"
 	maxResults             = "max-results"
+	family                 = "family"
 )
 
 // Aggregate Filter Flag Constants
@@ -95,8 +96,11 @@ type InstanceSelectorOptions struct {
 
 var (
 	toolboxInstanceSelectorLong = templates.LongDesc(i18n.T(`
-	Generate AWS EC2 instance groups by providing resource specs,
-	such as vcpus and memory, rather than instance types.`))
+	Generate instance groups by providing resource specs,
+	such as vcpus and memory, rather than instance types.
+
+	On AWS, the instance group uses a MixedInstancesPolicy of the matching EC2 instance types.
+	On GCE and Azure, the instance group uses the matching machine type with the fewest vcpus and memory.`))
 
 	toolboxInstanceSelectorExample = templates.Examples(i18n.T(`
 	## Create a spot instance group using a MixInstancesPolicy and Capacity-Optimized spot allocation strategy.
@@ -105,6 +109,9 @@ var (
 
 	## Create an on-demand instance group with custom vcpu and memory range filters.
 	kops toolbox instance-selector ondemand-ig --vcpus-min=2 --vcpus-max=4 --memory-min 2gb --memory-max 4gb
+
+	## Create an instance group of GCE A2 machines with at least one GPU.
+	kops toolbox instance-selector gpu-ig --family a2 --gpus-min 1
 	`))
 
 	toolboxInstanceSelectorShort = i18n.T(`Generate instance-group specs by providing resource specs such as vcpus and memory.`)
@@ -219,6 +226,8 @@ func NewCmdToolboxInstanceSelector(f *util.Factory, out io.Writer) *cobra.Comman
 	commandline.Command.RegisterFlagCompletionFunc(allowList, cobra.NoFileCompletions)
 	commandline.RegexFlag(denyList, nil, nil, "List of instance types which should be excluded w/ regex syntax (Example: m[1-2]\\.*)")
 	commandline.Command.RegisterFlagCompletionFunc(denyList, cobra.NoFileCompletions)
+	commandline.StringSliceFlag(family, nil, nil, "Machine families to select from, on GCE and Azure (Example: n2,e2)")
+	commandline.Command.RegisterFlagCompletionFunc(family, cobra.NoFileCompletions)
 
 	// Output Flags
 
@@ -239,13 +248,6 @@ func RunToolboxInstanceSelector(ctx context.Context, f *util.Factory, out io.Wri
 		return err
 	}
 
-	if cluster.Spec.GetCloudProvider() != kops.CloudProviderAWS {
-		return fmt.Errorf("cannot select instance types from non-aws cluster")
-	}
-
-	firstClusterSubnet := strings.ReplaceAll(cluster.Spec.Subnets[0].Name, "utility-", "")
-	region := firstClusterSubnet[:len(firstClusterSubnet)-1]
-
 	igSubnets := []string{}
 	for _, clusterSubnet := range cluster.Spec.Subnets {
 		igSubnets = append(igSubnets, clusterSubnet.Name)
@@ -261,6 +263,60 @@ func RunToolboxInstanceSelector(ctx context.Context, f *util.Factory, out io.Wri
 		igSubnets = userSubnets
 	}
 
+	var newInstanceGroups []*kops.InstanceGroup
+	switch cluster.Spec.GetCloudProvider() {
+	case kops.CloudProviderAWS:
+		newInstanceGroups, err = selectAWSInstanceGroups(cluster, channel, commandline, options, igSubnets)
+	case kops.CloudProviderGCE, kops.CloudProviderAzure:
+		newInstanceGroups, err = selectMachineTypeInstanceGroups(ctx, clientset, cluster, channel, commandline, options, igSubnets)
+	default:
+		return fmt.Errorf("cannot select instance types from %s cluster", cluster.Spec.GetCloudProvider())
+	}
+	if err != nil {
+		return err
+	}
+
+	if options.DryRun {
+		for _, ig := range newInstanceGroups {
+			switch options.Output {
+			case OutputYaml:
+				if err := fullOutputYAML(out, ig); err != nil {
+					return fmt.Errorf("error writing cluster yaml to stdout: %v", err)
+				}
+			case OutputJSON:
+				if err := fullOutputJSON(out, true, ig); err != nil {
+					return fmt.Errorf("error writing cluster json to stdout: %v", err)
+				}
+			default:
+				return fmt.Errorf("unsupported output type %q", options.Output)
+			}
+		}
+		return nil
+	}
+
+	for _, ig := range newInstanceGroups {
+		_, err = clientset.InstanceGroupsFor(cluster).Create(ctx, ig, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("error storing InstanceGroup: %v", err)
+		}
+
+		if err := fullOutputYAML(out, ig); err != nil {
+			return fmt.Errorf("error writing cluster yaml to stdout: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// selectAWSInstanceGroups creates instance groups for AWS clusters, with a MixedInstancesPolicy of the EC2 instance types matching the filters
+func selectAWSInstanceGroups(cluster *kops.Cluster, channel *kops.Channel, commandline *cli.CommandLineInterface, options *InstanceSelectorOptions, igSubnets []string) ([]*kops.InstanceGroup, error) {
+	if commandline.Flags[family] != nil {
+		return nil, fmt.Errorf("--%s is only supported on GCE and Azure, use --%s instead", family, allowList)
+	}
+
+	firstClusterSubnet := strings.ReplaceAll(cluster.Spec.Subnets[0].Name, "utility-", "")
+	region := firstClusterSubnet[:len(firstClusterSubnet)-1]
+
 	zones := []string{}
 	for _, igSubnet := range igSubnets {
 		zones = append(zones, strings.ReplaceAll(igSubnet, "utility-", ""))
@@ -269,7 +325,7 @@ func RunToolboxInstanceSelector(ctx context.Context, f *util.Factory, out io.Wri
 	tags := map[string]string{"KubernetesCluster": options.ClusterName}
 	cloud, err := awsup.NewAWSCloud(region, tags)
 	if err != nil {
-		return fmt.Errorf("error initializing AWS client: %v", err)
+		return nil, fmt.Errorf("error initializing AWS client: %v", err)
 	}
 
 	instanceSelector := selector.Selector{
@@ -295,10 +351,10 @@ func RunToolboxInstanceSelector(ctx context.Context, f *util.Factory, out io.Wri
 		}
 		selectedInstanceTypes, err := instanceSelector.Filter(mutatedFilters)
 		if err != nil {
-			return fmt.Errorf("error finding matching instance types: %w", err)
+			return nil, fmt.Errorf("error finding matching instance types: %w", err)
 		}
 		if len(selectedInstanceTypes) == 0 {
-			return fmt.Errorf("no instance types were returned because the criteria specified was too narrow")
+			return nil, fmt.Errorf("no instance types were returned because the criteria specified was too narrow")
 		}
 		usageClass := *filters.UsageClass
 
@@ -306,14 +362,14 @@ func RunToolboxInstanceSelector(ctx context.Context, f *util.Factory, out io.Wri
 		ig = decorateWithInstanceGroupSpecs(ig, options)
 		ig, err = decorateWithMixedInstancesPolicy(ig, usageClass, selectedInstanceTypes)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		if options.ClusterAutoscaler {
 			ig = decorateWithClusterAutoscalerLabels(ig, options.ClusterName)
 		}
 		ig, err = cloudup.PopulateInstanceGroupSpec(cluster, ig, cloud, channel)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		newInstanceGroups = append(newInstanceGroups, ig)
@@ -324,36 +380,7 @@ func RunToolboxInstanceSelector(ctx context.Context, f *util.Factory, out io.Wri
 		}
 	}
 
-	if options.DryRun {
-		for _, ig := range newInstanceGroups {
-			switch options.Output {
-			case OutputYaml:
-				if err := fullOutputYAML(out, ig); err != nil {
-					return fmt.Errorf("error writing cluster yaml to stdout: %v", err)
-				}
-			case OutputJSON:
-				if err := fullOutputJSON(out, true, ig); err != nil {
-					return fmt.Errorf("error writing cluster json to stdout: %v", err)
-				}
-			default:
-				return fmt.Errorf("unsupported output type %q", options.Output)
-			}
-		}
-		return nil
-	}
-
-	for _, ig := range newInstanceGroups {
-		_, err = clientset.InstanceGroupsFor(cluster).Create(ctx, ig, metav1.CreateOptions{})
-		if err != nil {
-			return fmt.Errorf("error storing InstanceGroup: %v", err)
-		}
-
-		if err := fullOutputYAML(out, ig); err != nil {
-			return fmt.Errorf("error writing cluster yaml to stdout: %v", err)
-		}
-	}
-
-	return nil
+	return newInstanceGroups, nil
 }
 
 func processAndValidateFlags(commandline *cli.CommandLineInterface) error {
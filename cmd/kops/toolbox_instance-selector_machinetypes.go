@@ -0,0 +1,375 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
+	"github.com/aws/amazon-ec2-instance-selector/v2/pkg/cli"
+	"github.com/aws/amazon-ec2-instance-selector/v2/pkg/selector"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/client/simple"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup"
+	"k8s.io/kops/upup/pkg/fi/cloudup/azure"
+	"k8s.io/kops/upup/pkg/fi/cloudup/gce"
+)
+
+// awsOnlyFlags are the flags for filters which only the AWS instance selector supports
+var awsOnlyFlags = []string{
+	placementGroupStrategy,
+	enaSupport,
+	networkInterfaces,
+	networkPerformance,
+	gpuMemory,
+	instanceTypeBase,
+	flexible,
+	nodeSecurityGroups,
+}
+
+// gceARM64Families are the GCE machine families with Arm CPUs
+var gceARM64Families = sets.NewString("t2a")
+
+// machineTypeInfo describes the resources of a GCE or Azure machine type
+type machineTypeInfo struct {
+	Name            string
+	Family          string
+	CPUArchitecture string
+	VCPUs           int
+	MemoryMiB       uint64
+	GPUs            int
+	Burstable       bool
+}
+
+// selectMachineTypeInstanceGroups creates instance groups for GCE and Azure clusters, using the machine type
+// with the fewest resources which matches the filters
+func selectMachineTypeInstanceGroups(ctx context.Context, clientset simple.Clientset, cluster *kops.Cluster, channel *kops.Channel, commandline *cli.CommandLineInterface, options *InstanceSelectorOptions, igSubnets []string) ([]*kops.InstanceGroup, error) {
+	for _, flag := range awsOnlyFlags {
+		if commandline.Flags[flag] != nil {
+			return nil, fmt.Errorf("--%s is only supported on AWS", flag)
+		}
+	}
+
+	filters := getFilters(commandline, "", nil)
+	if filters.UsageClass != nil && *filters.UsageClass == usageClassSpot {
+		return nil, fmt.Errorf("spot instance groups are only supported on AWS")
+	}
+	var families []string
+	if commandline.Flags[family] != nil {
+		families = *commandline.StringSliceMe(commandline.Flags[family])
+	}
+
+	cloud, err := cloudup.BuildCloud(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	zones, err := instanceGroupZones(ctx, clientset, cluster, igSubnets)
+	if err != nil {
+		return nil, err
+	}
+
+	var machineTypes []machineTypeInfo
+	switch c := cloud.(type) {
+	case gce.GCECloud:
+		if len(zones) == 0 {
+			zones, err = c.Zones()
+			if err != nil {
+				return nil, err
+			}
+		}
+		machineTypes, err = listGCEMachineTypes(ctx, c, zones)
+	case azure.AzureCloud:
+		machineTypes, err = listAzureMachineTypes(ctx, c)
+	default:
+		return nil, fmt.Errorf("cannot select machine types from %s cluster", cluster.Spec.GetCloudProvider())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if commandline.Flags[instanceGroupCount] != nil && filters.VCpusToMemoryRatio == nil {
+		defaultStartRatio := float64(2.0)
+		filters.VCpusToMemoryRatio = &defaultStartRatio
+	}
+
+	var newInstanceGroups []*kops.InstanceGroup
+	for i := 0; i < options.InstanceGroupCount; i++ {
+		igNameForRun := options.InstanceGroupName
+		if options.InstanceGroupCount != 1 {
+			igNameForRun = fmt.Sprintf("%s%d", options.InstanceGroupName, i+1)
+		}
+		selectedMachineTypes := filterMachineTypes(machineTypes, filters, families)
+		if len(selectedMachineTypes) == 0 {
+			return nil, fmt.Errorf("no machine types were returned because the criteria specified was too narrow")
+		}
+		klog.V(2).Infof("Machine types matching the criteria of instance group %q: %v", igNameForRun, selectedMachineTypes)
+
+		ig := createInstanceGroup(igNameForRun, options.ClusterName, igSubnets)
+		ig.Spec.Zones = zones
+		ig.Spec.MachineType = selectedMachineTypes[0]
+		ig = decorateWithInstanceGroupSpecs(ig, options)
+		if options.ClusterAutoscaler {
+			ig = decorateWithClusterAutoscalerLabels(ig, options.ClusterName)
+		}
+		ig, err = cloudup.PopulateInstanceGroupSpec(cluster, ig, cloud, channel)
+		if err != nil {
+			return nil, err
+		}
+
+		newInstanceGroups = append(newInstanceGroups, ig)
+
+		if options.InstanceGroupCount != 1 {
+			doubledRatio := (*filters.VCpusToMemoryRatio) * 2
+			filters.VCpusToMemoryRatio = &doubledRatio
+		}
+	}
+
+	return newInstanceGroups, nil
+}
+
+// instanceGroupZones returns the zones of the subnets of the instance group.
+// Subnets on GCE and Azure are regional, so it falls back to the zones of the existing instance groups in the subnets.
+func instanceGroupZones(ctx context.Context, clientset simple.Clientset, cluster *kops.Cluster, igSubnets []string) ([]string, error) {
+	subnets := sets.NewString(igSubnets...)
+	zones := sets.NewString()
+	for _, subnet := range cluster.Spec.Subnets {
+		if subnets.Has(subnet.Name) && subnet.Zone != "" {
+			zones.Insert(subnet.Zone)
+		}
+	}
+	if zones.Len() != 0 {
+		return zones.List(), nil
+	}
+
+	list, err := clientset.InstanceGroupsFor(cluster).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, ig := range list.Items {
+		if subnets.HasAny(ig.Spec.Subnets...) {
+			zones.Insert(ig.Spec.Zones...)
+		}
+	}
+	return zones.List(), nil
+}
+
+// listGCEMachineTypes returns the machine types which are available in all the zones
+func listGCEMachineTypes(ctx context.Context, cloud gce.GCECloud, zones []string) ([]machineTypeInfo, error) {
+	available := map[string]int{}
+	var machineTypes []machineTypeInfo
+	for _, zone := range zones {
+		zoneMachineTypes, err := cloud.Compute().MachineTypes().List(ctx, cloud.Project(), zone)
+		if err != nil {
+			return nil, fmt.Errorf("error listing machine types in zone %q: %v", zone, err)
+		}
+		for _, mt := range zoneMachineTypes {
+			if mt.Deprecated != nil && mt.Deprecated.State != "" {
+				continue
+			}
+			available[mt.Name]++
+			if available[mt.Name] > 1 {
+				continue
+			}
+
+			info := machineTypeInfo{
+				Name:            mt.Name,
+				Family:          strings.Split(mt.Name, "-")[0],
+				CPUArchitecture: cpuArchitectureAMD64,
+				VCPUs:           int(mt.GuestCpus),
+				MemoryMiB:       uint64(mt.MemoryMb),
+				Burstable:       mt.IsSharedCpu,
+			}
+			if gceARM64Families.Has(info.Family) {
+				info.CPUArchitecture = cpuArchitectureARM64
+			}
+			for _, accelerator := range mt.Accelerators {
+				info.GPUs += int(accelerator.GuestAcceleratorCount)
+			}
+			machineTypes = append(machineTypes, info)
+		}
+	}
+
+	var result []machineTypeInfo
+	for _, info := range machineTypes {
+		if available[info.Name] == len(zones) {
+			result = append(result, info)
+		}
+	}
+	return result, nil
+}
+
+// listAzureMachineTypes returns the virtual machine sizes which are available in the location of the cluster
+func listAzureMachineTypes(ctx context.Context, cloud azure.AzureCloud) ([]machineTypeInfo, error) {
+	location := cloud.Region()
+	skus, err := cloud.ResourceSku().List(ctx, fmt.Sprintf("location eq '%s'", location))
+	if err != nil {
+		return nil, fmt.Errorf("error listing virtual machine sizes in location %q: %v", location, err)
+	}
+
+	var machineTypes []machineTypeInfo
+	for _, sku := range skus {
+		if fi.StringValue(sku.ResourceType) != "virtualMachines" || azureSkuRestricted(sku, location) {
+			continue
+		}
+
+		info := machineTypeInfo{
+			Name:            fi.StringValue(sku.Name),
+			Family:          fi.StringValue(sku.Family),
+			CPUArchitecture: cpuArchitectureAMD64,
+			Burstable:       strings.EqualFold(fi.StringValue(sku.Family), "standardBSFamily"),
+		}
+		if sku.Capabilities != nil {
+			for _, capability := range *sku.Capabilities {
+				value := fi.StringValue(capability.Value)
+				switch fi.StringValue(capability.Name) {
+				case "vCPUs":
+					info.VCPUs, _ = strconv.Atoi(value)
+				case "MemoryGB":
+					memoryGB, _ := strconv.ParseFloat(value, 64)
+					info.MemoryMiB = uint64(memoryGB * 1024)
+				case "GPUs":
+					info.GPUs, _ = strconv.Atoi(value)
+				case "CpuArchitectureType":
+					if strings.EqualFold(value, "Arm64") {
+						info.CPUArchitecture = cpuArchitectureARM64
+					}
+				}
+			}
+		}
+		machineTypes = append(machineTypes, info)
+	}
+	return machineTypes, nil
+}
+
+// azureSkuRestricted returns true if the SKU cannot be used in the location
+func azureSkuRestricted(sku compute.ResourceSku, location string) bool {
+	if sku.Restrictions == nil {
+		return false
+	}
+	for _, restriction := range *sku.Restrictions {
+		if restriction.Type != compute.Location || restriction.Values == nil {
+			continue
+		}
+		for _, value := range *restriction.Values {
+			if strings.EqualFold(value, location) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// filterMachineTypes returns the names of the machine types which match the filters,
+// ordered from the fewest to the most vcpus and memory
+func filterMachineTypes(machineTypes []machineTypeInfo, filters selector.Filters, families []string) []string {
+	var matching []machineTypeInfo
+	for _, info := range machineTypes {
+		if machineTypeMatches(info, filters, families) {
+			matching = append(matching, info)
+		}
+	}
+
+	sort.Slice(matching, func(i, j int) bool {
+		if matching[i].VCPUs != matching[j].VCPUs {
+			return matching[i].VCPUs < matching[j].VCPUs
+		}
+		if matching[i].MemoryMiB != matching[j].MemoryMiB {
+			return matching[i].MemoryMiB < matching[j].MemoryMiB
+		}
+		return matching[i].Name < matching[j].Name
+	})
+
+	var names []string
+	for _, info := range matching {
+		if filters.MaxResults != nil && len(names) >= *filters.MaxResults {
+			break
+		}
+		names = append(names, info.Name)
+	}
+	return names
+}
+
+func machineTypeMatches(info machineTypeInfo, filters selector.Filters, families []string) bool {
+	if filters.VCpusRange != nil && (info.VCPUs < filters.VCpusRange.LowerBound || info.VCPUs > filters.VCpusRange.UpperBound) {
+		return false
+	}
+	if filters.MemoryRange != nil && (info.MemoryMiB < filters.MemoryRange.LowerBound.Quantity || info.MemoryMiB > filters.MemoryRange.UpperBound.Quantity) {
+		return false
+	}
+	if filters.VCpusToMemoryRatio != nil {
+		if info.VCPUs == 0 {
+			return false
+		}
+		// Same rounding as the AWS instance selector
+		ratio := math.Ceil(float64(info.MemoryMiB) / float64(info.VCPUs*1024))
+		if math.Floor(ratio*100) != math.Floor(*filters.VCpusToMemoryRatio*100) {
+			return false
+		}
+	}
+	if filters.CPUArchitecture != nil {
+		architecture := *filters.CPUArchitecture
+		if architecture == cpuArchitectureX8664 {
+			architecture = cpuArchitectureAMD64
+		}
+		if info.CPUArchitecture != architecture {
+			return false
+		}
+	}
+	if filters.GpusRange != nil && (info.GPUs < filters.GpusRange.LowerBound || info.GPUs > filters.GpusRange.UpperBound) {
+		return false
+	}
+	if filters.Burstable != nil && info.Burstable != *filters.Burstable {
+		return false
+	}
+	if len(families) != 0 && !machineFamilyMatches(info.Family, families) {
+		return false
+	}
+	if filters.AllowList != nil && !filters.AllowList.MatchString(info.Name) {
+		return false
+	}
+	if filters.DenyList != nil && filters.DenyList.MatchString(info.Name) {
+		return false
+	}
+	return true
+}
+
+// machineFamilyMatches compares families case-insensitively, ignoring the decorations of Azure family names,
+// so that "DSv3" matches "standardDSv3Family"
+func machineFamilyMatches(machineFamily string, families []string) bool {
+	normalize := func(s string) string {
+		s = strings.ToLower(s)
+		s = strings.TrimPrefix(s, "standard")
+		s = strings.TrimSuffix(s, "family")
+		return s
+	}
+	for _, f := range families {
+		if normalize(f) == normalize(machineFamily) {
+			return true
+		}
+	}
+	return false
+}
@@ -17,12 +17,19 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"reflect"
 	"regexp"
 	"testing"
 
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
+	"github.com/aws/amazon-ec2-instance-selector/v2/pkg/bytequantity"
 	"github.com/aws/amazon-ec2-instance-selector/v2/pkg/cli"
+	"github.com/aws/amazon-ec2-instance-selector/v2/pkg/selector"
+	gcemock "k8s.io/kops/cloudmock/gce"
 	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/azuretasks"
 )
 
 func TestGetFilters(t *testing.T) {
@@ -205,3 +212,170 @@ func TestDecorateWithClusterAutoscalerLabels(t *testing.T) {
 		t.Fatalf("cluster cloudLabel for cluster autoscaler should have been added to the instance group spec")
 	}
 }
+
+func TestFilterMachineTypes(t *testing.T) {
+	machineTypes := []machineTypeInfo{
+		{Name: "e2-medium", Family: "e2", CPUArchitecture: "amd64", VCPUs: 2, MemoryMiB: 4096, Burstable: true},
+		{Name: "n2-standard-4", Family: "n2", CPUArchitecture: "amd64", VCPUs: 4, MemoryMiB: 16384},
+		{Name: "n2-standard-2", Family: "n2", CPUArchitecture: "amd64", VCPUs: 2, MemoryMiB: 8192},
+		{Name: "t2a-standard-2", Family: "t2a", CPUArchitecture: "arm64", VCPUs: 2, MemoryMiB: 8192},
+		{Name: "a2-highgpu-1g", Family: "a2", CPUArchitecture: "amd64", VCPUs: 12, MemoryMiB: 87040, GPUs: 1},
+		{Name: "Standard_D2s_v3", Family: "standardDSv3Family", CPUArchitecture: "amd64", VCPUs: 2, MemoryMiB: 8192},
+	}
+	amd64 := "amd64"
+	arm64 := "arm64"
+	ratio := float64(4)
+	notBurstable := false
+	maxResults := 1
+
+	grid := []struct {
+		name     string
+		filters  selector.Filters
+		families []string
+		expected []string
+	}{
+		{
+			name:     "no filters",
+			expected: []string{"e2-medium", "Standard_D2s_v3", "n2-standard-2", "t2a-standard-2", "n2-standard-4", "a2-highgpu-1g"},
+		},
+		{
+			name: "vcpus and memory",
+			filters: selector.Filters{
+				VCpusRange:  &selector.IntRangeFilter{LowerBound: 2, UpperBound: 4},
+				MemoryRange: &selector.ByteQuantityRangeFilter{LowerBound: bytequantity.FromGiB(8), UpperBound: bytequantity.FromGiB(16)},
+			},
+			expected: []string{"Standard_D2s_v3", "n2-standard-2", "t2a-standard-2", "n2-standard-4"},
+		},
+		{
+			name: "ratio and architecture",
+			filters: selector.Filters{
+				VCpusToMemoryRatio: &ratio,
+				CPUArchitecture:    &amd64,
+			},
+			expected: []string{"Standard_D2s_v3", "n2-standard-2", "n2-standard-4"},
+		},
+		{
+			name:     "arm64",
+			filters:  selector.Filters{CPUArchitecture: &arm64},
+			expected: []string{"t2a-standard-2"},
+		},
+		{
+			name:     "gpus",
+			filters:  selector.Filters{GpusRange: &selector.IntRangeFilter{LowerBound: 1, UpperBound: 8}},
+			expected: []string{"a2-highgpu-1g"},
+		},
+		{
+			name:     "families",
+			families: []string{"E2", "DSv3"},
+			expected: []string{"e2-medium", "Standard_D2s_v3"},
+		},
+		{
+			name: "not burstable, deny list and max results",
+			filters: selector.Filters{
+				Burstable:  &notBurstable,
+				DenyList:   regexp.MustCompile("^Standard_"),
+				MaxResults: &maxResults,
+			},
+			expected: []string{"n2-standard-2"},
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			actual := filterMachineTypes(machineTypes, g.filters, g.families)
+			if !reflect.DeepEqual(actual, g.expected) {
+				t.Errorf("expected %v, got %v", g.expected, actual)
+			}
+		})
+	}
+}
+
+func TestListGCEMachineTypes(t *testing.T) {
+	cloud := gcemock.InstallMockGCECloud("us-test1", "testproject")
+
+	machineTypes, err := listGCEMachineTypes(context.Background(), cloud, []string{"us-test1-a", "us-test1-b"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	found := map[string]machineTypeInfo{}
+	for _, info := range machineTypes {
+		if _, ok := found[info.Name]; ok {
+			t.Errorf("machine type %q is listed more than once", info.Name)
+		}
+		found[info.Name] = info
+	}
+	expected := map[string]machineTypeInfo{
+		"e2-medium":      {Name: "e2-medium", Family: "e2", CPUArchitecture: "amd64", VCPUs: 2, MemoryMiB: 4096, Burstable: true},
+		"t2a-standard-2": {Name: "t2a-standard-2", Family: "t2a", CPUArchitecture: "arm64", VCPUs: 2, MemoryMiB: 8192},
+		"a2-highgpu-1g":  {Name: "a2-highgpu-1g", Family: "a2", CPUArchitecture: "amd64", VCPUs: 12, MemoryMiB: 87040, GPUs: 1},
+	}
+	for name, info := range expected {
+		if !reflect.DeepEqual(found[name], info) {
+			t.Errorf("expected machine type %v, got %v", info, found[name])
+		}
+	}
+}
+
+func TestListAzureMachineTypes(t *testing.T) {
+	cloud := azuretasks.NewMockAzureCloud("eastus")
+	capabilities := func(vcpus, memoryGB, architecture string) *[]compute.ResourceSkuCapabilities {
+		return &[]compute.ResourceSkuCapabilities{
+			{Name: fi.String("vCPUs"), Value: fi.String(vcpus)},
+			{Name: fi.String("MemoryGB"), Value: fi.String(memoryGB)},
+			{Name: fi.String("CpuArchitectureType"), Value: fi.String(architecture)},
+		}
+	}
+	cloud.ResourceSkusClient.SKUs = []compute.ResourceSku{
+		{
+			ResourceType: fi.String("virtualMachines"),
+			Name:         fi.String("Standard_B2s"),
+			Family:       fi.String("standardBSFamily"),
+			Capabilities: capabilities("2", "4", "x64"),
+		},
+		{
+			ResourceType: fi.String("virtualMachines"),
+			Name:         fi.String("Standard_D2ps_v5"),
+			Family:       fi.String("standardDPSv5Family"),
+			Capabilities: capabilities("2", "8", "Arm64"),
+		},
+		{
+			ResourceType: fi.String("virtualMachines"),
+			Name:         fi.String("Standard_NC6"),
+			Family:       fi.String("standardNCFamily"),
+			Capabilities: &[]compute.ResourceSkuCapabilities{
+				{Name: fi.String("vCPUs"), Value: fi.String("6")},
+				{Name: fi.String("MemoryGB"), Value: fi.String("56")},
+				{Name: fi.String("GPUs"), Value: fi.String("1")},
+			},
+			Restrictions: &[]compute.ResourceSkuRestrictions{
+				{Type: compute.Location, Values: &[]string{"westus"}},
+			},
+		},
+		{
+			ResourceType: fi.String("virtualMachines"),
+			Name:         fi.String("Standard_D2s_v3"),
+			Family:       fi.String("standardDSv3Family"),
+			Capabilities: capabilities("2", "8", "x64"),
+			Restrictions: &[]compute.ResourceSkuRestrictions{
+				{Type: compute.Location, Values: &[]string{"eastus"}},
+			},
+		},
+		{
+			ResourceType: fi.String("disks"),
+			Name:         fi.String("Premium_LRS"),
+		},
+	}
+
+	machineTypes, err := listAzureMachineTypes(context.Background(), cloud)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []machineTypeInfo{
+		{Name: "Standard_B2s", Family: "standardBSFamily", CPUArchitecture: "amd64", VCPUs: 2, MemoryMiB: 4096, Burstable: true},
+		{Name: "Standard_D2ps_v5", Family: "standardDPSv5Family", CPUArchitecture: "arm64", VCPUs: 2, MemoryMiB: 8192},
+		{Name: "Standard_NC6", Family: "standardNCFamily", CPUArchitecture: "amd64", VCPUs: 6, MemoryMiB: 57344, GPUs: 1},
+	}
+	if !reflect.DeepEqual(machineTypes, expected) {
+		t.Errorf("expected %v, got %v", expected, machineTypes)
+	}
+}
@@ -26,7 +26,6 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
-	"helm.sh/helm/v3/pkg/strvals"
 	"k8s.io/kops/pkg/commands/commandutils"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
@@ -44,6 +43,9 @@ import (
 var (
 	toolboxTemplatingLong = templates.LongDesc(i18n.T(`
 	Generate cluster.yaml from values input yaml file and apply template.
+
+	Templates can use the functions of the sprig library, as well as toYaml, fromYaml and required as in Helm.
+	Values files are merged in order, then the --set and --set-string values are applied in order.
 	`))
 
 	toolboxTemplatingExample = templates.Examples(i18n.T(`
@@ -54,6 +56,9 @@ var (
 		--snippets file_or_directory --snippets=another.dir \
 		--template file_or_directory --template=directory  \
 		--output cluster.yaml
+
+	# Fail if the template references a value that is missing or null
+	kops toolbox template --values values.yaml --template cluster.tmpl.yaml --strict
 	`))
 
 	toolboxTemplatingShort = i18n.T(`Generate cluster.yaml from template`)
@@ -65,6 +70,7 @@ type ToolboxTemplateOptions struct {
 	configValue   string
 	failOnMissing bool
 	formatYAML    bool
+	strict        bool
 	outputPath    string
 	snippetsPath  []string
 	templatePath  []string
@@ -98,7 +104,7 @@ func NewCmdToolboxTemplate(f *util.Factory, out io.Writer) *cobra.Command {
 	cmd.Flags().StringArrayVar(&options.values, "set", options.values, "Set values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
 	cmd.RegisterFlagCompletionFunc("set", cobra.NoFileCompletions)
 	cmd.Flags().StringArrayVar(&options.stringValues, "set-string", options.stringValues, "Set STRING values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
-	cmd.RegisterFlagCompletionFunc("set", cobra.NoFileCompletions)
+	cmd.RegisterFlagCompletionFunc("set-string", cobra.NoFileCompletions)
 	cmd.Flags().StringSliceVar(&options.templatePath, "template", options.templatePath, "Path to template file or directory of templates to render")
 	cmd.Flags().StringSliceVar(&options.snippetsPath, "snippets", options.snippetsPath, "Path to directory containing snippets used for templating")
 	cmd.MarkFlagDirname("snippets")
@@ -109,6 +115,7 @@ func NewCmdToolboxTemplate(f *util.Factory, out io.Writer) *cobra.Command {
 	cmd.RegisterFlagCompletionFunc("config-value", cobra.NoFileCompletions)
 	cmd.Flags().BoolVar(&options.failOnMissing, "fail-on-missing", true, "Fail on referencing unset variables in templates")
 	cmd.Flags().BoolVar(&options.formatYAML, "format-yaml", false, "Attempt to format the generated yaml content before output")
+	cmd.Flags().BoolVar(&options.strict, "strict", false, "Fail on referencing unset variables, or variables set to null, in templates")
 	cmd.Flags().SetNormalizeFunc(func(f *pflag.FlagSet, name string) pflag.NormalizedName {
 		// Old flag name for outputPath
 		if name == "output" {
@@ -188,10 +195,14 @@ func RunToolBoxTemplate(f *util.Factory, out io.Writer, options *ToolboxTemplate
 			return fmt.Errorf("unable to read template: %s, error: %s", x, err)
 		}
 
-		rendered, err := r.Render(string(content), context, snippets, options.failOnMissing)
+		rendered, err := r.Render(string(content), context, snippets, options.failOnMissing || options.strict)
 		if err != nil {
 			return fmt.Errorf("unable to render template: %s, error: %s", x, err)
 		}
+		// @check null values are rendered as "<no value>" by text/template
+		if options.strict && strings.Contains(rendered, templater.NoValue) {
+			return fmt.Errorf("unable to render template: %s, error: template references a value that is null", x)
+		}
 		// @check if the content is zero ignore it
 		if len(rendered) <= 0 {
 			continue
@@ -240,52 +251,23 @@ func RunToolBoxTemplate(f *util.Factory, out io.Writer, options *ToolboxTemplate
 
 // newTemplateContext is responsible for loading the --values and build a context for the template
 func newTemplateContext(files []string, values []string, stringValues []string) (map[string]interface{}, error) {
-	context := make(map[string]interface{})
-
+	var valueFiles []string
 	for _, x := range files {
 		list, err := expandFiles(utils.ExpandPath(x))
 		if err != nil {
 			return nil, err
 		}
-		for _, j := range list {
-			content, err := os.ReadFile(j)
-			if err != nil {
-				return nil, fmt.Errorf("unable to configuration file: %s, error: %s", j, err)
-			}
-
-			ctx := make(map[string]interface{})
-			if err := utils.YamlUnmarshal(content, &ctx); err != nil {
-				return nil, fmt.Errorf("unable decode the configuration file: %s, error: %v", j, err)
-			}
-
-			valueOpts := &helmvalues.Options{
-				Values:       values,
-				ValueFiles:   files,
-				StringValues: stringValues,
-			}
-
-			context, err = valueOpts.MergeValues(nil)
-			if err != nil {
-				return nil, err
-			}
-		}
+		valueFiles = append(valueFiles, list...)
 	}
 
-	// User specified a value via --set
-	for _, value := range values {
-		if err := strvals.ParseInto(value, context); err != nil {
-			return nil, fmt.Errorf("failed parsing --set data: %s", err)
-		}
-	}
-
-	// User specified a value via --set-string
-	for _, value := range stringValues {
-		if err := strvals.ParseIntoString(value, context); err != nil {
-			return nil, fmt.Errorf("failed parsing --set-string data: %s", err)
-		}
+	// @step: merge the values files in order, then apply the --set and --set-string values in order
+	valueOpts := &helmvalues.Options{
+		ValueFiles:   valueFiles,
+		Values:       values,
+		StringValues: stringValues,
 	}
 
-	return context, nil
+	return valueOpts.MergeValues(nil)
 }
 
 // expandFiles is responsible for resolving any references to directories
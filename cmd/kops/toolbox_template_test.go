@@ -17,6 +17,10 @@ limitations under the License.
 package main
 
 import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -26,3 +30,90 @@ func TestNewTemplateContext(t *testing.T) {
 		t.Errorf("Got %v, expected baz", context["foo"])
 	}
 }
+
+func TestNewTemplateContextOverrides(t *testing.T) {
+	dir := t.TempDir()
+	override := filepath.Join(dir, "override.yaml")
+	if err := os.WriteFile(override, []byte("Bar:\n  Baz: qux\n"), 0o644); err != nil {
+		t.Fatalf("error writing values: %v", err)
+	}
+
+	context, err := newTemplateContext([]string{"test/values.yaml", override}, []string{"Foo=baz", "Foo=qux,Count=1"}, []string{"Count=2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if context["Foo"] != "qux" {
+		t.Errorf("Got %v, expected qux", context["Foo"])
+	}
+	if context["Count"] != "2" {
+		t.Errorf("Got %v, expected the string 2", context["Count"])
+	}
+	bar := context["Bar"].(map[string]interface{})
+	if bar["Baz"] != "qux" || bar["Foo"] == nil {
+		t.Errorf("Got %v, expected the values files to be merged", bar)
+	}
+}
+
+func TestToolboxTemplateStrict(t *testing.T) {
+	dir := t.TempDir()
+	template := filepath.Join(dir, "cluster.tmpl.yaml")
+	if err := os.WriteFile(template, []byte("name: {{ .Name }}\n"), 0o644); err != nil {
+		t.Fatalf("error writing template: %v", err)
+	}
+
+	channel, err := filepath.Abs("../../tests/integration/channel/simple/channel.yaml")
+	if err != nil {
+		t.Fatalf("error finding channel: %v", err)
+	}
+
+	grid := []struct {
+		values   []string
+		strict   bool
+		expected string
+		err      string
+	}{
+		{
+			values:   []string{"Name=test"},
+			strict:   true,
+			expected: "name: test\n",
+		},
+		{
+			values:   []string{"Name=null"},
+			expected: "name: <no value>\n",
+		},
+		{
+			values: []string{"Name=null"},
+			strict: true,
+			err:    "template references a value that is null",
+		},
+		{
+			strict: true,
+			err:    "map has no entry for key",
+		},
+	}
+	for _, g := range grid {
+		options := &ToolboxTemplateOptions{
+			ClusterName:  "test.example.com",
+			channel:      "file://" + channel,
+			templatePath: []string{template},
+			values:       g.values,
+			strict:       g.strict,
+		}
+
+		var out bytes.Buffer
+		err := RunToolBoxTemplate(nil, &out, options)
+		if g.err != "" {
+			if err == nil || !strings.Contains(err.Error(), g.err) {
+				t.Errorf("values %v: expected error %q, got %v", g.values, g.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("values %v: unexpected error: %v", g.values, err)
+			continue
+		}
+		if out.String() != g.expected {
+			t.Errorf("values %v: expected %q, got %q", g.values, g.expected, out.String())
+		}
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/commands"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/pretty"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	unsetClusterLong = pretty.LongDesc(i18n.T(`
+	Unset fields of the cluster configuration, so they take their default values.
+
+	Fields are given as paths in the cluster spec, such as spec.kubelet.maxPods.
+	This command changes the desired cluster configuration in the registry;
+	to apply the changes use ` + pretty.Bash("kops update cluster") + `.`))
+
+	unsetClusterExample = templates.Examples(i18n.T(`
+	# Unset the maximum number of pods of the kubelet
+	kops unset cluster k8s-cluster.example.com spec.kubelet.maxPods
+
+	# Unset a feature gate of the API server and the runtime config
+	kops unset cluster --name k8s-cluster.example.com spec.kubeAPIServer.featureGates.Foo spec.kubeAPIServer.runtimeConfig
+	`))
+
+	unsetClusterShort = i18n.T("Unset cluster fields.")
+)
+
+type UnsetClusterOptions struct {
+	ClusterName string
+	Fields      []string
+}
+
+func NewCmdUnsetCluster(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &UnsetClusterOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "cluster [CLUSTER] FIELD...",
+		Short:   unsetClusterShort,
+		Long:    unsetClusterLong,
+		Example: unsetClusterExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			options.Fields = args
+			if len(args) > 0 && !isSpecField(args[0], "cluster.") {
+				options.ClusterName = args[0]
+				options.Fields = args[1:]
+			}
+			if options.ClusterName == "" {
+				options.ClusterName = rootCommand.ClusterName(true)
+			}
+			if options.ClusterName == "" {
+				return fmt.Errorf("--name is required")
+			}
+
+			if len(options.Fields) == 0 {
+				return fmt.Errorf("must specify the fields to unset")
+			}
+			return nil
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) == 0 && rootCommand.clusterName == "" {
+				return commandutils.CompleteClusterName(f, true, false)(cmd, args, toComplete)
+			}
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunUnsetCluster(context.TODO(), f, out, options)
+		},
+	}
+
+	return cmd
+}
+
+func RunUnsetCluster(ctx context.Context, f *util.Factory, out io.Writer, options *UnsetClusterOptions) error {
+	oldCluster, err := GetCluster(ctx, f, options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	err = oldCluster.FillDefaults()
+	if err != nil {
+		return err
+	}
+
+	clientset, err := f.Clientset()
+	if err != nil {
+		return err
+	}
+
+	instanceGroups, err := commands.ReadAllInstanceGroups(ctx, clientset, oldCluster)
+	if err != nil {
+		return err
+	}
+
+	newCluster := oldCluster.DeepCopy()
+	if err := commands.UnsetClusterFields(options.Fields, newCluster); err != nil {
+		return err
+	}
+
+	failure, err := updateCluster(ctx, clientset, oldCluster, newCluster, instanceGroups)
+	if err != nil {
+		return err
+	}
+	if failure != "" {
+		return fmt.Errorf("%s", failure)
+	}
+	return nil
+}
+
+// isSpecField returns true if the argument is the path of a field, rather than the name of the object
+func isSpecField(arg string, prefix string) bool {
+	arg = strings.TrimPrefix(arg, prefix)
+	return strings.HasPrefix(arg, "spec.") || strings.HasPrefix(arg, "metadata.")
+}
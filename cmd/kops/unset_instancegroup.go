@@ -0,0 +1,138 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/commands"
+	"k8s.io/kops/pkg/pretty"
+	"k8s.io/kops/upup/pkg/fi/cloudup"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	unsetInstancegroupLong = pretty.LongDesc(i18n.T(`
+	Unset fields of an instance group configuration, so they take their default values.
+
+	Fields are given as paths in the instance group spec, such as spec.rootVolumeSize.
+	This command changes the desired instance group configuration in the registry;
+	to apply the changes use ` + pretty.Bash("kops update cluster") + `.`))
+
+	unsetInstancegroupExample = templates.Examples(i18n.T(`
+	# Unset the root volume size and a node label of an instance group
+	kops unset instancegroup --name k8s-cluster.example.com nodes spec.rootVolumeSize spec.nodeLabels.team
+	`))
+
+	unsetInstancegroupShort = i18n.T("Unset instancegroup fields.")
+)
+
+type UnsetInstanceGroupOptions struct {
+	ClusterName string
+	GroupName   string
+	Fields      []string
+}
+
+func NewCmdUnsetInstanceGroup(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &UnsetInstanceGroupOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "instancegroup INSTANCE_GROUP FIELD...",
+		Aliases: []string{"instancegroups", "ig"},
+		Short:   unsetInstancegroupShort,
+		Long:    unsetInstancegroupLong,
+		Example: unsetInstancegroupExample,
+		Args: func(cmd *cobra.Command, args []string) error {
+			options.ClusterName = rootCommand.ClusterName(true)
+
+			if options.ClusterName == "" {
+				return fmt.Errorf("--name is required")
+			}
+
+			if len(args) == 0 {
+				return fmt.Errorf("must specify the name of the instance group to unset fields of")
+			}
+
+			options.GroupName = args[0]
+			options.Fields = args[1:]
+
+			if len(options.Fields) == 0 {
+				return fmt.Errorf("must specify the fields to unset")
+			}
+			return nil
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) == 0 {
+				return completeInstanceGroup(f, nil, nil)(cmd, args, toComplete)
+			}
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunUnsetInstanceGroup(context.TODO(), f, out, options)
+		},
+	}
+
+	return cmd
+}
+
+func RunUnsetInstanceGroup(ctx context.Context, f *util.Factory, out io.Writer, options *UnsetInstanceGroupOptions) error {
+	groupName := options.GroupName
+
+	cluster, err := GetCluster(ctx, f, options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	channel, err := cloudup.ChannelForCluster(cluster)
+	if err != nil {
+		klog.Warningf("%v", err)
+	}
+
+	clientset, err := f.Clientset()
+	if err != nil {
+		return err
+	}
+
+	oldGroup, err := clientset.InstanceGroupsFor(cluster).Get(ctx, groupName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error reading InstanceGroup %q: %v", groupName, err)
+	}
+	if oldGroup == nil {
+		return fmt.Errorf("InstanceGroup %q not found", groupName)
+	}
+
+	newGroup := oldGroup.DeepCopy()
+	if err := commands.UnsetInstancegroupFields(options.Fields, newGroup); err != nil {
+		return err
+	}
+
+	failure, err := updateInstanceGroup(ctx, clientset, channel, cluster, oldGroup, newGroup)
+	if err != nil {
+		return err
+	}
+	if failure != "" {
+		return fmt.Errorf("%s", failure)
+	}
+	return nil
+}
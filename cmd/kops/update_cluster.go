@@ -39,6 +39,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi/cloudup"
 	"k8s.io/kops/upup/pkg/fi/utils"
 	"k8s.io/kops/upup/pkg/kutil"
+	"k8s.io/kops/util/pkg/vfs"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
 )
@@ -69,6 +70,11 @@ type UpdateClusterOptions struct {
 	AllowKopsDowngrade bool
 	// GetAssets is whether this is invoked from the CmdGetAssets.
 	GetAssets bool
+	// Assets overrides the assets of the cluster spec, without changing the cluster in the state store.
+	Assets *kops.Assets
+	// Quiet suppresses the report of the changes a dry-run would make,
+	// and the suggestions printed once changes are applied.
+	Quiet bool
 
 	ClusterName string
 
@@ -79,6 +85,9 @@ type UpdateClusterOptions struct {
 
 	Phase string
 
+	// CloudformationNestedStacks splits cloudformation output into nested stacks
+	CloudformationNestedStacks bool
+
 	// LifecycleOverrides is a slice of taskName=lifecycle name values.  This slice is used
 	// to populate the LifecycleOverrides struct member in ApplyClusterCmd struct.
 	LifecycleOverrides []string
@@ -114,11 +123,12 @@ func NewCmdUpdateCluster(f *util.Factory, out io.Writer) *cobra.Command {
 	}
 
 	cmd.Flags().BoolVarP(&options.Yes, "yes", "y", options.Yes, "Create cloud resources, without --yes update is in dry run mode")
-	cmd.Flags().StringVar(&options.Target, "target", options.Target, "Target - direct, terraform, cloudformation")
+	cmd.Flags().StringVar(&options.Target, "target", options.Target, "Target - direct, terraform, cloudformation, pulumi")
 	cmd.RegisterFlagCompletionFunc("target", completeUpdateClusterTarget(f, options))
 	cmd.Flags().StringVar(&options.SSHPublicKey, "ssh-public-key", options.SSHPublicKey, "SSH public key to use (deprecated: use kops create secret instead)")
 	cmd.Flags().StringVar(&options.OutDir, "out", options.OutDir, "Path to write any local output")
 	cmd.MarkFlagDirname("out")
+	cmd.Flags().BoolVar(&options.CloudformationNestedStacks, "cloudformation-nested-stacks", options.CloudformationNestedStacks, "Split cloudformation output into nested stacks (network, IAM, cluster & one per instance group)")
 	cmd.Flags().BoolVar(&options.CreateKubecfg, "create-kube-config", options.CreateKubecfg, "Will control automatically creating the kube config file on your local filesystem")
 	cmd.Flags().DurationVar(&options.admin, "admin", options.admin, "Also export a cluster admin user credential with the specified lifetime and add it to the cluster context")
 	cmd.Flags().Lookup("admin").NoOptDefVal = kubeconfig.DefaultKubecfgAdminLifetime.String()
@@ -195,6 +205,8 @@ func RunUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer, c *Up
 			c.OutDir = "out/terraform"
 		} else if c.Target == cloudup.TargetCloudformation {
 			c.OutDir = "out/cloudformation"
+		} else if c.Target == cloudup.TargetPulumi {
+			c.OutDir = "out/pulumi"
 		} else {
 			c.OutDir = "out"
 		}
@@ -204,12 +216,23 @@ func RunUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer, c *Up
 	if err != nil {
 		return results, err
 	}
+	if c.Assets != nil {
+		cluster.Spec.Assets = c.Assets
+	}
 
 	clientset, err := f.Clientset()
 	if err != nil {
 		return results, err
 	}
 
+	configBase, err := clientset.ConfigBaseFor(cluster)
+	if err != nil {
+		return results, err
+	}
+	if err := vfs.VerifyStateStoreRequirements(configBase); err != nil {
+		return results, err
+	}
+
 	keyStore, err := clientset.KeyStore(cluster)
 	if err != nil {
 		return results, err
@@ -280,17 +303,19 @@ func RunUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer, c *Up
 	}
 
 	applyCmd := &cloudup.ApplyClusterCmd{
-		Cloud:              cloud,
-		Clientset:          clientset,
-		Cluster:            cluster,
-		DryRun:             isDryrun,
-		AllowKopsDowngrade: c.AllowKopsDowngrade,
-		RunTasksOptions:    &c.RunTasksOptions,
-		OutDir:             c.OutDir,
-		Phase:              phase,
-		TargetName:         targetName,
-		LifecycleOverrides: lifecycleOverrideMap,
-		GetAssets:          c.GetAssets,
+		Cloud:                      cloud,
+		Clientset:                  clientset,
+		Cluster:                    cluster,
+		DryRun:                     isDryrun,
+		AllowKopsDowngrade:         c.AllowKopsDowngrade,
+		RunTasksOptions:            &c.RunTasksOptions,
+		OutDir:                     c.OutDir,
+		Phase:                      phase,
+		CloudformationNestedStacks: c.CloudformationNestedStacks,
+		TargetName:                 targetName,
+		LifecycleOverrides:         lifecycleOverrideMap,
+		GetAssets:                  c.GetAssets,
+		Quiet:                      c.Quiet,
 	}
 
 	if err := applyCmd.Run(ctx); err != nil {
@@ -303,7 +328,7 @@ func RunUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer, c *Up
 	results.FileAssets = applyCmd.FileAssets
 	results.Cluster = cluster
 
-	if isDryrun && !c.GetAssets {
+	if isDryrun && !c.GetAssets && !c.Quiet {
 		target := applyCmd.Target.(*fi.DryRunTarget)
 		if target.HasChanges() {
 			fmt.Fprintf(out, "Must specify --yes to apply changes\n")
@@ -351,7 +376,7 @@ func RunUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer, c *Up
 		}
 	}
 
-	if !isDryrun {
+	if !isDryrun && !c.Quiet {
 		sb := new(bytes.Buffer)
 
 		if c.Target == cloudup.TargetTerraform {
@@ -372,8 +397,26 @@ func RunUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer, c *Up
 			if firstRun {
 				cfName := "kubernetes-" + strings.Replace(c.ClusterName, ".", "-", -1)
 				cfPath := filepath.Join(c.OutDir, "kubernetes.json")
-				fmt.Fprintf(sb, "Run this command to apply the configuration:\n")
-				fmt.Fprintf(sb, "   aws cloudformation create-stack --capabilities CAPABILITY_NAMED_IAM --stack-name %s --template-body file://%s\n", cfName, cfPath)
+				if c.CloudformationNestedStacks {
+					packagedPath := filepath.Join(c.OutDir, "packaged.json")
+					fmt.Fprintf(sb, "Run these commands to upload the nested stacks to an S3 bucket and apply the configuration:\n")
+					fmt.Fprintf(sb, "   aws cloudformation package --template-file %s --s3-bucket <bucket> --output-template-file %s\n", cfPath, packagedPath)
+					fmt.Fprintf(sb, "   aws cloudformation deploy --capabilities CAPABILITY_NAMED_IAM CAPABILITY_AUTO_EXPAND --stack-name %s --template-file %s\n", cfName, packagedPath)
+				} else {
+					fmt.Fprintf(sb, "Run this command to apply the configuration:\n")
+					fmt.Fprintf(sb, "   aws cloudformation create-stack --capabilities CAPABILITY_NAMED_IAM --stack-name %s --template-body file://%s\n", cfName, cfPath)
+				}
+				fmt.Fprintf(sb, "\n")
+			}
+		} else if c.Target == cloudup.TargetPulumi {
+			fmt.Fprintf(sb, "\n")
+			fmt.Fprintf(sb, "Pulumi output has been placed into %s\n", c.OutDir)
+
+			if firstRun {
+				fmt.Fprintf(sb, "Run these commands to apply the configuration:\n")
+				fmt.Fprintf(sb, "   cd %s\n", c.OutDir)
+				fmt.Fprintf(sb, "   pulumi preview\n")
+				fmt.Fprintf(sb, "   pulumi up\n")
 				fmt.Fprintf(sb, "\n")
 			}
 		} else if firstRun {
@@ -479,6 +522,7 @@ func completeUpdateClusterTarget(f commandutils.Factory, options *UpdateClusterO
 				cloudup.TargetDirect,
 				cloudup.TargetDryRun,
 				cloudup.TargetCloudformation,
+				cloudup.TargetPulumi,
 				cloudup.TargetTerraform,
 			}, directive
 		}
@@ -493,7 +537,7 @@ func completeUpdateClusterTarget(f commandutils.Factory, options *UpdateClusterO
 			}
 		}
 		if cluster.Spec.GetCloudProvider() == kops.CloudProviderAWS {
-			completions = append(completions, cloudup.TargetCloudformation)
+			completions = append(completions, cloudup.TargetCloudformation, cloudup.TargetPulumi)
 		}
 		return completions, cobra.ShellCompDirectiveNoFileComp
 	}
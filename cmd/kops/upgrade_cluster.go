@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 
 	"github.com/blang/semver/v4"
 	"github.com/spf13/cobra"
@@ -44,6 +45,10 @@ var (
 	Automates checking for and applying Kubernetes updates. This upgrades a cluster to the latest recommended
 	production ready Kubernetes version. After this command is run, use ` + pretty.Bash("kops update cluster") + ` and ` + pretty.Bash("kops rolling-update cluster") + `
 	to finish a cluster upgrade.
+
+	Settings left over from old versions of kOps are converted to their modern equivalents, and instance groups
+	still using launch configurations are listed. The instance groups that must be rolled for the conversions
+	to take effect are listed, as are legacy settings that can't safely be converted automatically.
 	`))
 
 	upgradeClusterExample = templates.Examples(i18n.T(`
@@ -225,6 +230,32 @@ func RunUpgradeCluster(ctx context.Context, f *util.Factory, out io.Writer, opti
 		}
 	}
 
+	// Convert constructs from old versions of kOps
+	launchConfigurations, err := commands.FindLaunchConfigurations(cloud, cluster, instanceGroups)
+	if err != nil {
+		klog.Warningf("unable to check for launch configurations: %v", err)
+	}
+	var plannedKubernetesVersion semver.Version
+	if proposedKubernetesVersion != nil {
+		plannedKubernetesVersion = *proposedKubernetesVersion
+	}
+	migrations := commands.PlanLegacyMigrations(cluster, instanceGroups, plannedKubernetesVersion, launchConfigurations)
+	for _, migration := range migrations.Migrations {
+		actions = append(actions, &upgradeAction{
+			Item:     migration.Item,
+			Property: migration.Property,
+			Old:      migration.Old,
+			New:      migration.New,
+			apply:    migration.Apply,
+		})
+	}
+	if len(migrations.Blockers) > 0 {
+		fmt.Fprintf(out, "\nThe following legacy settings can't be migrated automatically:\n")
+		for _, blocker := range migrations.Blockers {
+			fmt.Fprintf(out, "  * %s\n", blocker)
+		}
+	}
+
 	if len(actions) == 0 {
 		// TODO: Allow --force option to force even if not needed?
 		// Note stderr - we try not to print to stdout if no update is needed
@@ -253,12 +284,36 @@ func RunUpgradeCluster(ctx context.Context, f *util.Factory, out io.Writer, opti
 		}
 	}
 
+	rollingUpdates := migrations.RollingUpdates(instanceGroups)
+	if len(rollingUpdates) > 0 {
+		fmt.Fprintf(out, "\nOnce the changes are applied with `kops update cluster %s --yes`, these instance groups must be rolled:\n\n", cluster.ObjectMeta.Name)
+
+		t := &tables.Table{}
+		t.AddColumn("INSTANCEGROUP", func(u *commands.RollingUpdate) string {
+			return u.InstanceGroup
+		})
+		t.AddColumn("REASONS", func(u *commands.RollingUpdate) string {
+			return strings.Join(u.Reasons, ", ")
+		})
+		if err := t.Render(rollingUpdates, out, "INSTANCEGROUP", "REASONS"); err != nil {
+			return err
+		}
+
+		var names []string
+		for _, u := range rollingUpdates {
+			names = append(names, u.InstanceGroup)
+		}
+		fmt.Fprintf(out, "\nkops rolling-update cluster %s --instance-group %s --force --yes\n", cluster.ObjectMeta.Name, strings.Join(names, ","))
+	}
+
 	if !options.Yes {
 		fmt.Printf("\nMust specify --yes to perform upgrade\n")
 		return nil
 	}
 	for _, action := range actions {
-		action.apply()
+		if action.apply != nil {
+			action.apply()
+		}
 	}
 
 	if err := commands.UpdateCluster(ctx, clientset, cluster, instanceGroups); err != nil {
@@ -21,6 +21,7 @@ import (
 	"net/url"
 	"strings"
 
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/klog/v2"
@@ -70,20 +71,26 @@ func (f *Factory) Clientset() (simple.Clientset, error) {
 			return nil, field.Required(field.NewPath("State Store"), STATE_ERROR)
 		}
 
-		// We recognize a `k8s` scheme; this might change in future so we won't document it yet
-		// In practice nobody is going to hit this accidentally, so I don't think we need a feature flag.
+		// The `k8s` scheme stores the objects as custom resources in a kubernetes cluster: k8s://<context>/<namespace>
+		// Both the kubeconfig context and the namespace are optional.
 		if strings.HasPrefix(registryPath, "k8s://") {
 			loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
 
 			configOverrides := &clientcmd.ConfigOverrides{}
 
-			if registryPath == "k8s://" {
-			} else {
+			namespace := ""
+			if registryPath != "k8s://" {
 				u, err := url.Parse(registryPath)
 				if err != nil {
 					return nil, fmt.Errorf("invalid kops server url: %q", registryPath)
 				}
 				configOverrides.CurrentContext = u.Host
+				namespace = strings.Trim(u.Path, "/")
+				if namespace != "" {
+					if errs := validation.IsDNS1123Label(namespace); len(errs) != 0 {
+						return nil, field.Invalid(field.NewPath("State Store"), registryPath, fmt.Sprintf("invalid namespace %q: %s", namespace, strings.Join(errs, ", ")))
+					}
+				}
 			}
 
 			kubeConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, configOverrides)
@@ -102,6 +109,7 @@ func (f *Factory) Clientset() (simple.Clientset, error) {
 					Scheme: "k8s",
 				},
 				KopsClient: kopsClient.Kops(),
+				Namespace:  namespace,
 			}
 		} else if strings.HasPrefix(registryPath, "vault://") {
 			return nil, field.Invalid(field.NewPath("State Store"), registryPath, "Vault is not supported as registry path")
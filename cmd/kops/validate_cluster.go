@@ -19,6 +19,7 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"io"
 	"os"
@@ -56,11 +57,17 @@ var (
 	validateClusterExample = templates.Examples(i18n.T(`
 	# Validate the cluster set as the current context of the kube config.
 	# Kops will try for 10 minutes to validate the cluster 3 times.
-	kops validate cluster --wait 10m --count 3`))
+	kops validate cluster --wait 10m --count 3
+
+	# Write the validation failures as JUnit XML test cases for a CI system.
+	kops validate cluster --output junit > junit_validate.xml`))
 
 	validateClusterShort = i18n.T(`Validate a kOps cluster.`)
 )
 
+// OutputJUnit is the JUnit XML output format of kops validate cluster, for CI systems
+const OutputJUnit = "junit"
+
 type ValidateClusterOptions struct {
 	ClusterName string
 	output      string
@@ -99,9 +106,9 @@ func NewCmdValidateCluster(f *util.Factory, out io.Writer) *cobra.Command {
 		},
 	}
 
-	cmd.Flags().StringVarP(&options.output, "output", "o", options.output, "Output format. One of json|yaml|table.")
+	cmd.Flags().StringVarP(&options.output, "output", "o", options.output, "Output format. One of json|yaml|table|junit.")
 	cmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		return []string{"json", "yaml", "table"}, cobra.ShellCompDirectiveNoFileComp
+		return []string{"json", "yaml", "table", "junit"}, cobra.ShellCompDirectiveNoFileComp
 	})
 	cmd.Flags().DurationVar(&options.wait, "wait", options.wait, "Amount of time to wait for the cluster to become ready")
 	cmd.Flags().IntVar(&options.count, "count", options.count, "Number of consecutive successful validations required")
@@ -210,6 +217,10 @@ func RunValidateCluster(ctx context.Context, f *util.Factory, out io.Writer, opt
 			if _, err := out.Write(j); err != nil {
 				return nil, fmt.Errorf("error writing to output: %v", err)
 			}
+		case OutputJUnit:
+			if err := validateClusterOutputJUnit(result, cluster, out); err != nil {
+				return nil, err
+			}
 		default:
 			return nil, fmt.Errorf("unknown output format: %q", options.output)
 		}
@@ -311,3 +322,71 @@ func validateClusterOutputTable(result *validation.ValidationCluster, cluster *k
 
 	return nil
 }
+
+type junitTestSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message  string `xml:"message,attr"`
+	Contents string `xml:",chardata"`
+}
+
+// validateClusterOutputJUnit writes a JUnit test suite with a failed test case for each validation failure,
+// and a passed test case for each ready node
+func validateClusterOutputJUnit(result *validation.ValidationCluster, cluster *kopsapi.Cluster, out io.Writer) error {
+	suite := junitTestSuite{
+		Name: "kops validate cluster " + cluster.Name,
+	}
+
+	failedNodes := make(map[string]bool)
+	for _, failure := range result.Failures {
+		if failure.Kind == "Node" {
+			failedNodes[failure.Name] = true
+		}
+		suite.TestCases = append(suite.TestCases, junitTestCase{
+			Name:      failure.Kind + " " + failure.Name,
+			ClassName: failure.Kind,
+			Failure: &junitFailure{
+				Message:  failure.Message,
+				Contents: failure.Message,
+			},
+		})
+		suite.Failures++
+	}
+	for _, node := range result.Nodes {
+		if node.Status != v1.ConditionTrue || failedNodes[node.Name] {
+			continue
+		}
+		suite.TestCases = append(suite.TestCases, junitTestCase{
+			Name:      "Node " + node.Name,
+			ClassName: "Node",
+		})
+	}
+	if len(suite.TestCases) == 0 {
+		suite.TestCases = append(suite.TestCases, junitTestCase{
+			Name:      "Cluster " + cluster.Name,
+			ClassName: "Cluster",
+		})
+	}
+	suite.Tests = len(suite.TestCases)
+
+	x, err := xml.MarshalIndent(suite, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshal JUnit XML: %v", err)
+	}
+	if _, err := fmt.Fprintf(out, "%s%s\n", xml.Header, x); err != nil {
+		return fmt.Errorf("error writing to output: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/validation"
+)
+
+func TestValidateClusterOutputJUnit(t *testing.T) {
+	cluster := &kopsapi.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "minimal.example.com"},
+	}
+
+	grid := []struct {
+		name     string
+		result   *validation.ValidationCluster
+		expected string
+	}{
+		{
+			name: "failures",
+			result: &validation.ValidationCluster{
+				Failures: []*validation.ValidationError{
+					{Kind: "Node", Name: "node-b", Message: `node "node-b" of role "node" is not ready`},
+					{Kind: "Pod", Name: "kube-system/coredns-1", Message: `system-cluster-critical pod "coredns-1" is pending`},
+				},
+				Nodes: []*validation.ValidationNode{
+					{Name: "node-a", Role: "node", Status: v1.ConditionTrue},
+					{Name: "node-b", Role: "node", Status: v1.ConditionFalse},
+				},
+			},
+			expected: `<?xml version="1.0" encoding="UTF-8"?>
+<testsuite name="kops validate cluster minimal.example.com" tests="3" failures="2">
+  <testcase name="Node node-b" classname="Node">
+    <failure message="node &#34;node-b&#34; of role &#34;node&#34; is not ready">node &#34;node-b&#34; of role &#34;node&#34; is not ready</failure>
+  </testcase>
+  <testcase name="Pod kube-system/coredns-1" classname="Pod">
+    <failure message="system-cluster-critical pod &#34;coredns-1&#34; is pending">system-cluster-critical pod &#34;coredns-1&#34; is pending</failure>
+  </testcase>
+  <testcase name="Node node-a" classname="Node"></testcase>
+</testsuite>
+`,
+		},
+		{
+			name:   "empty",
+			result: &validation.ValidationCluster{},
+			expected: `<?xml version="1.0" encoding="UTF-8"?>
+<testsuite name="kops validate cluster minimal.example.com" tests="1" failures="0">
+  <testcase name="Cluster minimal.example.com" classname="Cluster"></testcase>
+</testsuite>
+`,
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := validateClusterOutputJUnit(g.result, cluster, &out); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if out.String() != g.expected {
+				t.Errorf("unexpected output, got:\n%s\nexpected:\n%s", out.String(), g.expected)
+			}
+		})
+	}
+}
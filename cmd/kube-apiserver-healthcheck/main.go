@@ -25,6 +25,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"strings"
 
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/wellknownports"
@@ -33,8 +34,15 @@ import (
 // healthCheckServer is the http server
 type healthCheckServer struct {
 	transport *http.Transport
+
+	// additionalChecks are individual apiserver checks (e.g. etcd) that must also pass
+	// before we report one of the health endpoints as healthy
+	additionalChecks []string
 }
 
+// healthPaths are the apiserver health endpoints that we proxy
+var healthPaths = []string{"/livez", "/healthz", "/readyz"}
+
 // handler processes a single http request
 func (s *healthCheckServer) handler(w http.ResponseWriter, r *http.Request) {
 	if r.Method == "GET" && r.URL.Path == "/.kube-apiserver-healthcheck/healthz" {
@@ -44,7 +52,14 @@ func (s *healthCheckServer) handler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if proxyRequest := mapToProxyRequest(r); proxyRequest != nil {
+	if proxyRequest := mapToProxyRequest(r, s.additionalChecks); proxyRequest != nil {
+		if isHealthPath(proxyRequest.URL.Path) {
+			if failed := s.failedAdditionalChecks(proxyRequest.URL.Path); len(failed) != 0 {
+				klog.Infof("additional checks failed for %s: %s", proxyRequest.URL.Path, strings.Join(failed, ","))
+				http.Error(w, fmt.Sprintf("failed checks: %s", strings.Join(failed, ",")), http.StatusServiceUnavailable)
+				return
+			}
+		}
 		s.proxyRequest(w, proxyRequest)
 		return
 	}
@@ -58,19 +73,69 @@ func (s *healthCheckServer) httpClient() *http.Client {
 	return &http.Client{Transport: s.transport}
 }
 
+// isHealthPath returns true if the path is one of the aggregate apiserver health endpoints
+func isHealthPath(p string) bool {
+	for _, healthPath := range healthPaths {
+		if p == healthPath {
+			return true
+		}
+	}
+	return false
+}
+
 // mapToProxyRequest returns the request we should make to the apiserver,
 // or nil if the query is not on the safelist
-func mapToProxyRequest(r *http.Request) *http.Request {
-	if r.Method == "GET" {
-		switch r.URL.Path {
-		case "/livez", "/healthz", "/readyz":
-			// This is a health-check we will proxy
-			return sanitizeRequest(r, []string{"exclude"})
+func mapToProxyRequest(r *http.Request, additionalChecks []string) *http.Request {
+	if r.Method != "GET" {
+		return nil
+	}
+
+	if isHealthPath(r.URL.Path) {
+		// This is a health-check we will proxy
+		return sanitizeRequest(r, []string{"exclude"})
+	}
+
+	// Individual checks are only proxied if they are configured as additional checks
+	for _, healthPath := range healthPaths {
+		for _, check := range additionalChecks {
+			if r.URL.Path == healthPath+"/"+check {
+				return sanitizeRequest(r, nil)
+			}
 		}
 	}
 	return nil
 }
 
+// failedAdditionalChecks queries each of the additional checks under healthPath,
+// returning the names of the checks that did not succeed
+func (s *healthCheckServer) failedAdditionalChecks(healthPath string) []string {
+	var failed []string
+
+	httpClient := s.httpClient()
+	for _, check := range s.additionalChecks {
+		u := &url.URL{
+			Scheme: "https",
+			Host:   "127.0.0.1",
+			Path:   healthPath + "/" + check,
+		}
+
+		resp, err := httpClient.Get(u.String())
+		if err != nil {
+			klog.Infof("error from %s: %v", u, err)
+			failed = append(failed, check)
+			continue
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			klog.Infof("check %s returned %s", u, resp.Status)
+			failed = append(failed, check)
+		}
+	}
+
+	return failed
+}
+
 // sanitizeRequest builds the request we should pass to the target apiserver,
 // passing through only allowedQueryParameters
 func sanitizeRequest(r *http.Request, allowedQueryParameters []string) *http.Request {
@@ -129,20 +194,25 @@ func (s *healthCheckServer) proxyRequest(w http.ResponseWriter, forwardRequest *
 }
 
 func run() error {
-	listen := fmt.Sprintf(":%d", wellknownports.KubeAPIServerHealthCheck)
+	port := wellknownports.KubeAPIServerHealthCheck
 
 	clientCert := ""
 	clientKey := ""
 	caCert := ""
+	additionalChecks := ""
 
+	flag.IntVar(&port, "port", port, "port on which to listen")
 	flag.StringVar(&clientCert, "client-cert", clientCert, "path to client certificate")
 	flag.StringVar(&clientKey, "client-key", clientKey, "path to client key")
 	flag.StringVar(&caCert, "ca-cert", caCert, "path to ca certificate")
+	flag.StringVar(&additionalChecks, "additional-checks", additionalChecks, "comma-separated list of individual apiserver checks (e.g. etcd) that must also pass for the health endpoints to report healthy")
 
 	klog.InitFlags(nil)
 
 	flag.Parse()
 
+	listen := fmt.Sprintf(":%d", port)
+
 	tlsConfig := &tls.Config{}
 
 	if caCert != "" {
@@ -171,6 +241,9 @@ func run() error {
 	s := &healthCheckServer{
 		transport: transport,
 	}
+	if additionalChecks != "" {
+		s.additionalChecks = strings.Split(additionalChecks, ",")
+	}
 
 	http.HandleFunc("/", s.handler)
 
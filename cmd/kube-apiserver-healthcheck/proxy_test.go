@@ -24,8 +24,9 @@ import (
 
 func TestBuildProxyRequest(t *testing.T) {
 	grid := []struct {
-		In  string
-		Out string
+		In               string
+		AdditionalChecks []string
+		Out              string
 	}{
 		{In: "http://127.0.0.1:3990/readyz", Out: "https://127.0.0.1/readyz"},
 		{In: "http://127.0.0.1:3990/livez", Out: "https://127.0.0.1/livez"},
@@ -40,6 +41,12 @@ func TestBuildProxyRequest(t *testing.T) {
 		{In: "http://127.0.0.1:3990/readyz?exclude=1&exclude=2", Out: "https://127.0.0.1/readyz?exclude=1&exclude=2"},
 		{In: "http://127.0.0.1:3990/readyz?exclude=1&verbose", Out: "https://127.0.0.1/readyz?exclude=1"},
 		{In: "http://127.0.0.1:3990/readyz?exclude", Out: "https://127.0.0.1/readyz?exclude="},
+		{In: "http://127.0.0.1:3990/readyz/etcd", Out: ""},
+		{In: "http://127.0.0.1:3990/readyz/etcd", AdditionalChecks: []string{"etcd"}, Out: "https://127.0.0.1/readyz/etcd"},
+		{In: "http://127.0.0.1:3990/livez/etcd?exclude=1", AdditionalChecks: []string{"etcd"}, Out: "https://127.0.0.1/livez/etcd"},
+		{In: "http://127.0.0.1:3990/readyz/foo", AdditionalChecks: []string{"etcd"}, Out: ""},
+		{In: "http://127.0.0.1:3990/metrics/etcd", AdditionalChecks: []string{"etcd"}, Out: ""},
+		{In: "http://127.0.0.1:3990/readyz?exclude=1", AdditionalChecks: []string{"etcd"}, Out: "https://127.0.0.1/readyz?exclude=1"},
 	}
 
 	for _, g := range grid {
@@ -53,7 +60,7 @@ func TestBuildProxyRequest(t *testing.T) {
 				Method: "GET",
 				URL:    u,
 			}
-			out := mapToProxyRequest(req)
+			out := mapToProxyRequest(req, g.AdditionalChecks)
 			actual := ""
 			if out != nil {
 				if out.Method != "GET" {
@@ -54,6 +54,8 @@ func main() {
 	fmt.Printf("dns-controller version %s\n", BuildVersion)
 	var dnsServer, dnsProviderID, gossipListen, gossipSecret, watchNamespace, metricsListen, gossipProtocol, gossipSecretSecondary, gossipListenSecondary, gossipProtocolSecondary string
 	var gossipSeeds, gossipSeedsSecondary, zones []string
+	var gossipEncryptionKeysFile string
+	var gossipIntervals gossip.Intervals
 	var internalIpv4, internalIpv6 bool
 	var watchIngress bool
 	var updateInterval int
@@ -74,6 +76,10 @@ func main() {
 	flag.StringVar(&gossipListenSecondary, "gossip-listen-secondary", fmt.Sprintf("0.0.0.0:%d", wellknownports.DNSControllerGossipMemberlist), "address:port on which to bind for gossip")
 	flags.StringVar(&gossipSecretSecondary, "gossip-secret-secondary", gossipSecret, "Secret to use to secure gossip")
 	flags.StringSliceVar(&gossipSeedsSecondary, "gossip-seed-secondary", gossipSeedsSecondary, "If set, will enable gossip zones and seed using the provided addresses")
+	flags.StringVar(&gossipEncryptionKeysFile, "gossip-encryption-keys-file", gossipEncryptionKeysFile, "File with the keys to use to encrypt memberlist gossip")
+	flags.DurationVar(&gossipIntervals.ProbeInterval, "gossip-probe-interval", gossipIntervals.ProbeInterval, "Interval between memberlist failure detection probes")
+	flags.DurationVar(&gossipIntervals.GossipInterval, "gossip-interval", gossipIntervals.GossipInterval, "Interval between memberlist gossip messages")
+	flags.DurationVar(&gossipIntervals.PushPullInterval, "gossip-push-pull-interval", gossipIntervals.PushPullInterval, "Interval between memberlist full state syncs")
 	flags.BoolVar(&internalIpv4, "internal-ipv4", internalIpv4, "Internal network has IPv4")
 	flags.BoolVar(&internalIpv6, "internal-ipv6", internalIpv6, "Internal network has IPv6")
 	flags.StringVar(&watchNamespace, "watch-namespace", "", "Limits the functionality for pods, services and ingress to specific namespace, by default all")
@@ -149,10 +155,19 @@ func main() {
 		}
 		gossipName := "dns-controller." + id
 
+		var gossipKeys [][]byte
+		if gossipEncryptionKeysFile != "" {
+			gossipKeys, err = gossip.ReadEncryptionKeys(gossipEncryptionKeysFile)
+			if err != nil {
+				klog.Errorf("Error initializing gossip: %v", err)
+				os.Exit(1)
+			}
+		}
+
 		channelName := "dns"
 		var gossipState gossip.GossipState
 
-		gossipState, err = gossip.GetGossipState(gossipProtocol, gossipListen, channelName, gossipName, []byte(gossipSecret), gossipSeeds)
+		gossipState, err = gossip.GetGossipState(gossipProtocol, gossipListen, channelName, gossipName, []byte(gossipSecret), gossipKeys, gossipSeeds, gossipIntervals)
 		if err != nil {
 			klog.Errorf("Error initializing gossip: %v", err)
 			os.Exit(1)
@@ -160,7 +175,7 @@ func main() {
 
 		if gossipProtocolSecondary != "" {
 
-			secondaryGossipState, err := gossip.GetGossipState(gossipProtocolSecondary, gossipListenSecondary, channelName, gossipName, []byte(gossipSecretSecondary), gossip.NewStaticSeedProvider(gossipSeedsSecondary))
+			secondaryGossipState, err := gossip.GetGossipState(gossipProtocolSecondary, gossipListenSecondary, channelName, gossipName, []byte(gossipSecretSecondary), gossipKeys, gossip.NewStaticSeedProvider(gossipSeedsSecondary), gossipIntervals)
 			if err != nil {
 				klog.Errorf("Error initializing secondary gossip: %v", err)
 				os.Exit(1)
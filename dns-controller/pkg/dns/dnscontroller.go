@@ -148,6 +148,7 @@ type snapshot struct {
 	aliasTargets map[string][]Record
 
 	recordValues map[recordKey][]string
+	recordTTLs   map[recordKey]int64
 }
 
 func (c *DNSController) snapshotIfChangedAndReady() *snapshot {
@@ -196,6 +197,17 @@ func (c *DNSController) snapshotIfChangedAndReady() *snapshot {
 	return s
 }
 
+// setRecordTTL records the TTL for the records with the given key.
+// If records for the same name specify different TTLs, the lowest one is used.
+func setRecordTTL(ttls map[recordKey]int64, key recordKey, ttl int64) {
+	if ttl == 0 {
+		return
+	}
+	if existing := ttls[key]; existing == 0 || ttl < existing {
+		ttls[key] = ttl
+	}
+}
+
 type recordKey struct {
 	RecordType RecordType
 	FQDN       string
@@ -211,6 +223,7 @@ func (c *DNSController) runOnce() error {
 	}
 
 	newValueMap := make(map[recordKey][]string)
+	newTTLMap := make(map[recordKey]int64)
 	{
 		// Resolve and build map
 		for _, r := range snapshot.records {
@@ -226,6 +239,7 @@ func (c *DNSController) runOnce() error {
 					}
 					// TODO: Support chains: alias of alias (etc)
 					newValueMap[key] = append(newValueMap[key], aliasRecord.Value)
+					setRecordTTL(newTTLMap, key, r.TTL)
 				}
 				continue
 			} else {
@@ -234,6 +248,7 @@ func (c *DNSController) runOnce() error {
 					FQDN:       r.FQDN,
 				}
 				newValueMap[key] = append(newValueMap[key], r.Value)
+				setRecordTTL(newTTLMap, key, r.TTL)
 				continue
 			}
 		}
@@ -244,11 +259,14 @@ func (c *DNSController) runOnce() error {
 			newValueMap[k] = values
 		}
 		snapshot.recordValues = newValueMap
+		snapshot.recordTTLs = newTTLMap
 	}
 
 	var oldValueMap map[recordKey][]string
+	var oldTTLMap map[recordKey]int64
 	if c.lastSuccessfulSnapshot != nil {
 		oldValueMap = c.lastSuccessfulSnapshot.recordValues
+		oldTTLMap = c.lastSuccessfulSnapshot.recordTTLs
 	}
 
 	op, err := newDNSOp(c.zoneRules, c.dnsCache)
@@ -266,13 +284,17 @@ func (c *DNSController) runOnce() error {
 		}
 		oldValues := oldValueMap[k]
 
-		if util.StringSlicesEqual(newValues, oldValues) {
+		ttl := DefaultTTL
+		if newTTLMap[k] != 0 {
+			ttl = time.Duration(newTTLMap[k]) * time.Second
+		}
+
+		if util.StringSlicesEqual(newValues, oldValues) && newTTLMap[k] == oldTTLMap[k] {
 			klog.V(4).Infof("no change to records for %s", k)
 			continue
 		}
 
-		ttl := DefaultTTL
-		klog.Infof("Using default TTL of %v", ttl)
+		klog.Infof("Using TTL of %v for %s", ttl, k)
 
 		klog.V(4).Infof("updating records for %s: %v -> %v", k, oldValues, newValues)
 
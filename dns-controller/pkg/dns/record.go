@@ -16,6 +16,8 @@ limitations under the License.
 
 package dns
 
+import "strconv"
+
 type RecordType string
 
 const (
@@ -39,6 +41,10 @@ type Record struct {
 	// but will be used as an expansion for Records with type=RecordTypeAlias,
 	// where the referring record has Value = our FQDN
 	AliasTarget bool
+
+	// TTL is the time to live of the record in seconds; if zero, DefaultTTL is used.
+	// For records with type=RecordTypeAlias, the TTL applies to the expanded records.
+	TTL int64
 }
 
 // AliasForNodesInRole returns the alias for nodes in the given role
@@ -53,6 +59,10 @@ func (r *Record) String() string {
 		s += ",AliasTarget"
 	}
 
+	if r.TTL != 0 {
+		s += ",TTL=" + strconv.FormatInt(r.TTL, 10)
+	}
+
 	s += "]"
 
 	return s
@@ -24,4 +24,10 @@ const (
 	// AnnotationNameDNSInternal is used to set up a DNS name for accessing the resource from inside the cluster
 	// This is only supported on Pods currently, and maps to the Internal address
 	AnnotationNameDNSInternal = "dns.alpha.kubernetes.io/internal"
+
+	// AnnotationNameDNSExternalTTL sets the TTL, in seconds, of the records for the external DNS names
+	AnnotationNameDNSExternalTTL = "dns.alpha.kubernetes.io/external-ttl"
+
+	// AnnotationNameDNSInternalTTL sets the TTL, in seconds, of the records for the internal DNS names
+	AnnotationNameDNSInternalTTL = "dns.alpha.kubernetes.io/internal-ttl"
 )
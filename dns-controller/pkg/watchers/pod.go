@@ -19,6 +19,7 @@ package watchers
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -157,6 +158,8 @@ func (c *PodController) updatePodRecords(pod *v1.Pod) string {
 			klog.V(4).Infof("Pod %q had %s=%s, but was not HostNetwork", pod.Name, AnnotationNameDNSExternal, specExternal)
 		}
 
+		ttl := parseTTLAnnotation(pod, AnnotationNameDNSExternalTTL)
+
 		tokens := strings.Split(specExternal, ",")
 		for _, token := range tokens {
 			token = strings.TrimSpace(token)
@@ -167,6 +170,7 @@ func (c *PodController) updatePodRecords(pod *v1.Pod) string {
 					RecordType: dns.RecordTypeAlias,
 					FQDN:       fqdn,
 					Value:      alias,
+					TTL:        ttl,
 				})
 			}
 		}
@@ -185,6 +189,8 @@ func (c *PodController) updatePodRecords(pod *v1.Pod) string {
 			klog.V(4).Infof("Pod %q had %s=%s, but was not HostNetwork", pod.Name, AnnotationNameDNSInternal, specInternal)
 		}
 
+		ttl := parseTTLAnnotation(pod, AnnotationNameDNSInternalTTL)
+
 		tokens := strings.Split(specInternal, ",")
 		for _, token := range tokens {
 			token = strings.TrimSpace(token)
@@ -195,6 +201,7 @@ func (c *PodController) updatePodRecords(pod *v1.Pod) string {
 					RecordType: dns.RecordTypeAlias,
 					FQDN:       fqdn,
 					Value:      alias,
+					TTL:        ttl,
 				})
 			}
 		}
@@ -206,3 +213,17 @@ func (c *PodController) updatePodRecords(pod *v1.Pod) string {
 	c.scope.Replace(key, records)
 	return key
 }
+
+// parseTTLAnnotation returns the TTL in seconds set by the annotation, or 0 if it is not set or not valid.
+func parseTTLAnnotation(pod *v1.Pod, annotation string) int64 {
+	s := pod.Annotations[annotation]
+	if s == "" {
+		return 0
+	}
+	ttl, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || ttl <= 0 {
+		klog.Warningf("Pod %q had invalid %s=%s, using the default TTL", pod.Name, annotation, s)
+		return 0
+	}
+	return ttl
+}
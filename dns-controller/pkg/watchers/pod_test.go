@@ -35,8 +35,9 @@ func TestPodController(t *testing.T) {
 			Name:      "somepod",
 			Namespace: "kube-system",
 			Annotations: map[string]string{
-				"dns.alpha.kubernetes.io/internal": "internal.a.foo.com",
-				"dns.alpha.kubernetes.io/external": "a.foo.com",
+				"dns.alpha.kubernetes.io/internal":     "internal.a.foo.com",
+				"dns.alpha.kubernetes.io/external":     "a.foo.com",
+				"dns.alpha.kubernetes.io/internal-ttl": "300",
 			},
 		},
 		Spec: corev1.PodSpec{
@@ -88,7 +89,7 @@ func TestPodController(t *testing.T) {
 	want := map[string][]dns.Record{
 		"kube-system/somepod": {
 			{RecordType: "_alias", FQDN: "a.foo.com.", Value: "node/my-node/external"},
-			{RecordType: "_alias", FQDN: "internal.a.foo.com.", Value: "node/my-node/internal"},
+			{RecordType: "_alias", FQDN: "internal.a.foo.com.", Value: "node/my-node/internal", TTL: 300},
 		},
 	}
 	if diff := cmp.Diff(scope.records, want); diff != "" {
@@ -115,6 +115,13 @@ func (i *Installation) buildEnvFile() *nodetasks.File {
 		envVars["S3_SECRET_ACCESS_KEY"] = os.Getenv("S3_SECRET_ACCESS_KEY")
 	}
 
+	// Pass in the credentials of the S3 compatible state stores
+	for _, envVar := range vfs.S3EndpointEnvVars {
+		if os.Getenv(envVar) != "" {
+			envVars[envVar] = os.Getenv(envVar)
+		}
+	}
+
 	// Pass in required credentials when using user-defined swift endpoint
 	if os.Getenv("OS_AUTH_URL") != "" {
 		for _, envVar := range []string{
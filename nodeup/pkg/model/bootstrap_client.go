@@ -20,10 +20,12 @@ import (
 	"fmt"
 	"net"
 	"net/url"
+	"os"
 	"strconv"
 
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/bootstrap"
+	"k8s.io/kops/pkg/bootstrap/metal"
 	"k8s.io/kops/pkg/wellknownports"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
@@ -47,9 +49,11 @@ func (b BootstrapClientBuilder) Build(c *fi.ModelBuilderContext) error {
 	case kops.CloudProviderAWS:
 		authenticator, err = awsup.NewAWSAuthenticator(b.Cloud.Region())
 	case kops.CloudProviderGCE:
-		authenticator, err = gcetpmsigner.NewTPMAuthenticator()
+		authenticator, err = gcetpmsigner.NewAuthenticator()
 		// We don't use the custom resolver here in gossip mode (though we could);
 		// instead we use this as a check that protokube has now started.
+	case kops.CloudProviderMetal:
+		authenticator, err = metal.NewAuthenticator()
 
 	default:
 		return fmt.Errorf("unsupported cloud provider for authenticator %q", b.CloudProvider)
@@ -77,6 +81,13 @@ func (b BootstrapClientBuilder) Build(c *fi.ModelBuilderContext) error {
 		KeypairIDs: b.bootstrapKeypairIDs,
 	}
 
+	// The certificates of nodes that have already bootstrapped are renewed when nodeup reruns
+	if b.Cluster.Spec.NodeCertificates != nil {
+		if _, err := os.Stat(b.KubeletKubeConfig()); err == nil {
+			bootstrapClientTask.Renew = true
+		}
+	}
+
 	for _, cert := range b.bootstrapCerts {
 		cert.Cert.Task = bootstrapClientTask
 		cert.Key.Task = bootstrapClientTask
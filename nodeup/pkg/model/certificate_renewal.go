@@ -0,0 +1,72 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/systemd"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
+)
+
+// CertificateRenewalBuilder installs a timer that reruns nodeup to renew the certificates kops-controller issues to the node.
+type CertificateRenewalBuilder struct {
+	*NodeupModelContext
+}
+
+var _ fi.ModelBuilder = &CertificateRenewalBuilder{}
+
+const certificateRenewalTimer = "kops-certificate-renewal.timer"
+
+// Build is responsible for configuring the renewal of the node certificates.
+func (b *CertificateRenewalBuilder) Build(c *fi.ModelBuilderContext) error {
+	if b.IsMaster || !b.UseKopsControllerForNodeBootstrap() || b.Cluster.Spec.NodeCertificates == nil {
+		return nil
+	}
+
+	spec := b.Cluster.Spec.NodeCertificates
+	if spec.Validity == nil {
+		return fmt.Errorf("nodeCertificates must specify a validity")
+	}
+	renewBefore := spec.RenewBeforeDuration()
+	interval := spec.Validity.Duration - renewBefore
+
+	// kops-configuration.service reruns nodeup, which requests new certificates from kops-controller
+	// and restarts the kubelet. The delay spreads the renewals of nodes started at the same time.
+	manifest := &systemd.Manifest{}
+	manifest.Set("Unit", "Description", "Renew the kubelet certificates periodically")
+	manifest.Set("Unit", "Documentation", "https://kops.sigs.k8s.io")
+	manifest.Set("Timer", "OnBootSec", fmt.Sprintf("%d", int64(interval.Seconds())))
+	manifest.Set("Timer", "OnUnitActiveSec", fmt.Sprintf("%d", int64(interval.Seconds())))
+	manifest.Set("Timer", "RandomizedDelaySec", fmt.Sprintf("%d", int64(renewBefore.Seconds()/2)))
+	manifest.Set("Timer", "Unit", "kops-configuration.service")
+	manifest.Set("Install", "WantedBy", "timers.target")
+
+	manifestString := manifest.Render()
+	klog.V(8).Infof("Built timer manifest %q\n%s", certificateRenewalTimer, manifestString)
+
+	service := &nodetasks.Service{
+		Name:       certificateRenewalTimer,
+		Definition: s(manifestString),
+	}
+	service.InitDefaults()
+	c.AddTask(service)
+
+	return nil
+}
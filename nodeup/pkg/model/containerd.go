@@ -17,9 +17,11 @@ limitations under the License.
 package model
 
 import (
+	"encoding/base64"
 	"fmt"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/blang/semver/v4"
@@ -36,6 +38,9 @@ import (
 	"k8s.io/kops/util/pkg/distributions"
 )
 
+// containerdRegistryHostsDir is the directory containerd reads the hosts.toml files of the registries from.
+const containerdRegistryHostsDir = "/etc/containerd/certs.d"
+
 // ContainerdBuilder install containerd (just the packages at the moment)
 type ContainerdBuilder struct {
 	*NodeupModelContext
@@ -45,6 +50,10 @@ var _ fi.ModelBuilder = &ContainerdBuilder{}
 
 // Build is responsible for configuring the containerd daemon
 func (b *ContainerdBuilder) Build(c *fi.ModelBuilderContext) error {
+	// CRI-O doesn't need containerd, unlike Docker
+	if b.Cluster.Spec.ContainerRuntime == "crio" {
+		return nil
+	}
 	if b.skipInstall() {
 		klog.Infof("SkipInstall is set to true; won't install containerd")
 		return nil
@@ -83,6 +92,12 @@ func (b *ContainerdBuilder) Build(c *fi.ModelBuilderContext) error {
 		return err
 	}
 
+	if b.Cluster.Spec.ContainerRuntime == "containerd" && b.useRegistryHosts() {
+		if err := b.buildRegistryHostsFiles(c); err != nil {
+			return err
+		}
+	}
+
 	if installContainerd {
 		if err := b.installContainerd(c); err != nil {
 			return err
@@ -494,8 +509,12 @@ func (b *ContainerdBuilder) buildContainerdConfig() (string, error) {
 	if cluster.Spec.Kubelet != nil && cluster.Spec.Kubelet.PodInfraContainerImage != "" {
 		config.SetPath([]string{"plugins", "io.containerd.grpc.v1.cri", "sandbox_image"}, cluster.Spec.Kubelet.PodInfraContainerImage)
 	}
-	for name, endpoints := range containerd.RegistryMirrors {
-		config.SetPath([]string{"plugins", "io.containerd.grpc.v1.cri", "registry", "mirrors", name, "endpoint"}, endpoints)
+	if b.useRegistryHosts() {
+		config.SetPath([]string{"plugins", "io.containerd.grpc.v1.cri", "registry", "config_path"}, containerdRegistryHostsDir)
+	} else {
+		for name, endpoints := range containerd.RegistryMirrors {
+			config.SetPath([]string{"plugins", "io.containerd.grpc.v1.cri", "registry", "mirrors", name, "endpoint"}, endpoints)
+		}
 	}
 	config.SetPath([]string{"plugins", "io.containerd.grpc.v1.cri", "containerd", "runtimes", "runc", "runtime_type"}, "io.containerd.runc.v2")
 	// only enable systemd cgroups for kubernetes >= 1.20
@@ -512,9 +531,124 @@ func (b *ContainerdBuilder) buildContainerdConfig() (string, error) {
 			return "", err
 		}
 	}
+
+	if fi.StringValue(containerd.ConfigAdditions) != "" {
+		additions, err := toml.Load(*containerd.ConfigAdditions)
+		if err != nil {
+			return "", fmt.Errorf("error parsing containerd configAdditions: %v", err)
+		}
+		mergeTOML(config, additions)
+	}
+
 	return config.String(), nil
 }
 
+// mergeTOML deep-merges src into dst: tables are merged recursively, and other values of src replace those of dst.
+func mergeTOML(dst, src *toml.Tree) {
+	for _, key := range src.Keys() {
+		value := src.GetPath([]string{key})
+		if srcTree, ok := value.(*toml.Tree); ok {
+			if dstTree, ok := dst.GetPath([]string{key}).(*toml.Tree); ok {
+				mergeTOML(dstTree, srcTree)
+				continue
+			}
+		}
+		dst.SetPath([]string{key}, value)
+	}
+}
+
+// useRegistryHosts returns true if the registry mirrors are configured in containerd's hosts directory,
+// instead of the deprecated mirrors section of the config file.
+func (b *ContainerdBuilder) useRegistryHosts() bool {
+	containerd := b.NodeupConfig.ContainerdConfig
+	if containerd == nil || containerd.ConfigOverride != nil {
+		return false
+	}
+	if len(containerd.RegistryMirrors) == 0 && len(containerd.Registries) == 0 {
+		return false
+	}
+
+	// The hosts directory requires containerd 1.5
+	sv, err := semver.ParseTolerant(fi.StringValue(containerd.Version))
+	if err != nil {
+		klog.Warningf("unable to parse containerd version %q, assuming it supports the registry hosts directory", fi.StringValue(containerd.Version))
+		return true
+	}
+	return sv.GTE(semver.MustParse("1.5.0"))
+}
+
+// buildRegistryHostsFiles writes the hosts.toml file of each registry with mirrors to containerd's hosts directory.
+func (b *ContainerdBuilder) buildRegistryHostsFiles(c *fi.ModelBuilderContext) error {
+	containerd := b.NodeupConfig.ContainerdConfig
+
+	registries := make(map[string][]kops.ContainerdRegistryMirror)
+	for name, endpoints := range containerd.RegistryMirrors {
+		for _, endpoint := range endpoints {
+			registries[name] = append(registries[name], kops.ContainerdRegistryMirror{Endpoint: endpoint})
+		}
+	}
+	for name, registry := range containerd.Registries {
+		registries[name] = append(registries[name], registry.Mirrors...)
+	}
+
+	for name, mirrors := range registries {
+		dir := name
+		if name == "*" {
+			dir = "_default"
+		}
+
+		mode := "0644"
+		for _, mirror := range mirrors {
+			if mirror.Auth != nil {
+				mode = "0600"
+			}
+		}
+
+		contents, err := buildRegistryHostsFile(mirrors, b.SecretStore)
+		if err != nil {
+			return fmt.Errorf("building hosts file of registry %q: %v", name, err)
+		}
+
+		c.AddTask(&nodetasks.File{
+			Path:     filepath.Join(containerdRegistryHostsDir, dir, "hosts.toml"),
+			Contents: fi.NewStringResource(contents),
+			Type:     nodetasks.FileType_File,
+			Mode:     fi.String(mode),
+		})
+	}
+
+	return nil
+}
+
+// buildRegistryHostsFile builds the hosts.toml file of a registry, which lists its mirrors in the order they are tried.
+// The passwords of the mirrors that require authentication are read from the secret store.
+// See https://github.com/containerd/containerd/blob/main/docs/hosts.md
+func buildRegistryHostsFile(mirrors []kops.ContainerdRegistryMirror, secretStore fi.SecretStore) (string, error) {
+	var sb strings.Builder
+	sb.WriteString("# Built by kOps - do not edit\n")
+	for _, mirror := range mirrors {
+		host := strconv.Quote(mirror.Endpoint)
+		fmt.Fprintf(&sb, "\n[host.%s]\n", host)
+		sb.WriteString("  capabilities = [\"pull\", \"resolve\"]\n")
+		if mirror.SkipVerify {
+			sb.WriteString("  skip_verify = true\n")
+		}
+		if mirror.Auth != nil {
+			if secretStore == nil {
+				return "", fmt.Errorf("no secret store to read the password of mirror %q from", mirror.Endpoint)
+			}
+			password, err := secretStore.Secret(mirror.Auth.PasswordSecret)
+			if err != nil {
+				return "", fmt.Errorf("reading password of mirror %q: %v", mirror.Endpoint, err)
+			}
+			credentials := base64.StdEncoding.EncodeToString([]byte(mirror.Auth.Username + ":" + string(password.Data)))
+			fmt.Fprintf(&sb, "  [host.%s.header]\n", host)
+			fmt.Fprintf(&sb, "    Authorization = [%s]\n", strconv.Quote("Basic "+credentials))
+		}
+	}
+	return sb.String(), nil
+}
+
 func appendNvidiaGPURuntimeConfig(config *toml.Tree) error {
 	gpuConfig, err := toml.TreeFromMap(
 		map[string]interface{}{
@@ -29,7 +29,9 @@ import (
 	"k8s.io/kops/pkg/flagbuilder"
 	"k8s.io/kops/pkg/testutils"
 	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/secrets"
 	"k8s.io/kops/util/pkg/distributions"
+	"k8s.io/kops/util/pkg/vfs"
 )
 
 func TestContainerdBuilder_Docker_19_03_13(t *testing.T) {
@@ -270,3 +272,100 @@ func TestAppendGPURuntimeContainerdConfig(t *testing.T) {
 		t.Error("new config did not match expected new config")
 	}
 }
+
+func TestContainerdConfigRegistriesAndAdditions(t *testing.T) {
+	cluster := &kops.Cluster{
+		Spec: kops.ClusterSpec{
+			ContainerRuntime:  "containerd",
+			Containerd:        &kops.ContainerdConfig{},
+			KubernetesVersion: "1.24.0",
+			Networking: &kops.NetworkingSpec{
+				Calico: &kops.CalicoNetworkingSpec{},
+			},
+		},
+	}
+
+	b := &ContainerdBuilder{
+		NodeupModelContext: &NodeupModelContext{
+			Cluster: cluster,
+			NodeupConfig: &nodeup.Config{
+				ContainerdConfig: &kops.ContainerdConfig{
+					Version: fi.String("1.6.6"),
+					Registries: map[string]kops.ContainerdRegistryConfig{
+						"docker.io": {Mirrors: []kops.ContainerdRegistryMirror{{Endpoint: "https://mirror.example.com"}}},
+					},
+					ConfigAdditions: fi.String(`[plugins."io.containerd.grpc.v1.cri"]
+  max_concurrent_downloads = 10
+  [plugins."io.containerd.grpc.v1.cri".containerd.runtimes.runc.options]
+    SystemdCgroup = false
+`),
+				},
+			},
+		},
+	}
+
+	s, err := b.buildContainerdConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	config, err := toml.Load(s)
+	if err != nil {
+		t.Fatalf("unexpected error parsing config: %v", err)
+	}
+
+	for _, g := range []struct {
+		path     []string
+		expected interface{}
+	}{
+		{path: []string{"plugins", "io.containerd.grpc.v1.cri", "registry", "config_path"}, expected: "/etc/containerd/certs.d"},
+		{path: []string{"plugins", "io.containerd.grpc.v1.cri", "max_concurrent_downloads"}, expected: int64(10)},
+		{path: []string{"plugins", "io.containerd.grpc.v1.cri", "containerd", "runtimes", "runc", "options", "SystemdCgroup"}, expected: false},
+		{path: []string{"plugins", "io.containerd.grpc.v1.cri", "containerd", "runtimes", "runc", "runtime_type"}, expected: "io.containerd.runc.v2"},
+	} {
+		if actual := config.GetPath(g.path); actual != g.expected {
+			t.Errorf("unexpected value of %v: expected %v, got %v", g.path, g.expected, actual)
+		}
+	}
+	if config.HasPath([]string{"plugins", "io.containerd.grpc.v1.cri", "registry", "mirrors"}) {
+		t.Errorf("unexpected registry mirrors in config file")
+	}
+}
+
+func TestBuildRegistryHostsFile(t *testing.T) {
+	secretStore := secrets.NewVFSSecretStore(&kops.Cluster{}, vfs.NewMemFSPath(vfs.NewMemFSContext(), "secrets"))
+	if _, err := secretStore.ReplaceSecret("mirror-password", &fi.Secret{Data: []byte("secret")}); err != nil {
+		t.Fatalf("unexpected error storing secret: %v", err)
+	}
+
+	mirrors := []kops.ContainerdRegistryMirror{
+		{Endpoint: "https://mirror.example.com"},
+		{Endpoint: "https://10.0.0.1:5000", SkipVerify: true, Auth: &kops.ContainerdRegistryAuth{Username: "user", PasswordSecret: "mirror-password"}},
+	}
+
+	expected := `# Built by kOps - do not edit
+
+[host."https://mirror.example.com"]
+  capabilities = ["pull", "resolve"]
+
+[host."https://10.0.0.1:5000"]
+  capabilities = ["pull", "resolve"]
+  skip_verify = true
+  [host."https://10.0.0.1:5000".header]
+    Authorization = ["Basic dXNlcjpzZWNyZXQ="]
+`
+	actual, err := buildRegistryHostsFile(mirrors, secretStore)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if actual != expected {
+		t.Errorf("unexpected hosts file\n%s", diff.FormatDiff(expected, actual))
+	}
+	if _, err := toml.Load(actual); err != nil {
+		t.Errorf("hosts file is not valid TOML: %v", err)
+	}
+
+	mirrors[1].Auth.PasswordSecret = "missing"
+	if _, err := buildRegistryHostsFile(mirrors, secretStore); err == nil {
+		t.Errorf("expected error when the password secret does not exist")
+	}
+}
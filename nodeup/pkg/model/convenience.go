@@ -20,6 +20,8 @@ import (
 	"fmt"
 	"sort"
 
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/upup/pkg/fi"
 )
 
@@ -49,3 +51,23 @@ func sortedStrings(list []string) []string {
 
 	return list
 }
+
+// overrideProbe applies the settings configured in the cluster spec to a probe of a static pod
+func overrideProbe(probe *v1.Probe, spec *kops.ProbeSpec) *v1.Probe {
+	if spec == nil {
+		return probe
+	}
+	if spec.InitialDelaySeconds != nil {
+		probe.InitialDelaySeconds = *spec.InitialDelaySeconds
+	}
+	if spec.TimeoutSeconds != nil {
+		probe.TimeoutSeconds = *spec.TimeoutSeconds
+	}
+	if spec.PeriodSeconds != nil {
+		probe.PeriodSeconds = *spec.PeriodSeconds
+	}
+	if spec.FailureThreshold != nil {
+		probe.FailureThreshold = *spec.FailureThreshold
+	}
+	return probe
+}
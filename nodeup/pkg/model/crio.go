@@ -0,0 +1,196 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pelletier/go-toml"
+	"k8s.io/klog/v2"
+
+	"k8s.io/kops/pkg/systemd"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
+	"k8s.io/kops/util/pkg/distributions"
+)
+
+const (
+	// crioSocket is the path of the CRI socket of CRI-O.
+	crioSocket = "/var/run/crio/crio.sock"
+	// crioConfigFilePath is the path of the CRI-O config file.
+	crioConfigFilePath = "/etc/crio/crio.conf"
+)
+
+// CrioBuilder installs and configures CRI-O
+type CrioBuilder struct {
+	*NodeupModelContext
+}
+
+var _ fi.ModelBuilder = &CrioBuilder{}
+
+// Build is responsible for configuring the CRI-O daemon
+func (b *CrioBuilder) Build(c *fi.ModelBuilderContext) error {
+	if b.Cluster.Spec.ContainerRuntime != "crio" {
+		return nil
+	}
+	if b.Cluster.Spec.Crio != nil && b.Cluster.Spec.Crio.SkipInstall {
+		klog.Infof("SkipInstall is set to true; won't install CRI-O")
+		return nil
+	}
+
+	switch b.Distribution {
+	case distributions.DistributionFlatcar, distributions.DistributionContainerOS:
+		return fmt.Errorf("CRI-O is not supported on %v", b.Distribution)
+	}
+
+	config, err := b.buildCrioConfig()
+	if err != nil {
+		return err
+	}
+	c.AddTask(&nodetasks.File{
+		Path:     crioConfigFilePath,
+		Contents: fi.NewStringResource(config),
+		Type:     nodetasks.FileType_File,
+	})
+
+	// CRI-O refuses to pull images without a signature policy
+	c.AddTask(&nodetasks.File{
+		Path:     "/etc/containers/policy.json",
+		Contents: fi.NewStringResource(`{"default":[{"type":"insecureAcceptAnything"}]}` + "\n"),
+		Type:     nodetasks.FileType_File,
+	})
+
+	// Add binaries from the CRI-O bundle
+	f := b.Assets.FindMatches(regexp.MustCompile(`^(\./)?cri-o/bin/(conmon|crictl|crio|crio-status|crun|pinns|runc)$`))
+	if len(f) == 0 {
+		return fmt.Errorf("unable to find any CRI-O binaries in assets")
+	}
+	for k, v := range f {
+		c.AddTask(&nodetasks.File{
+			Path:     filepath.Join("/usr/bin", filepath.Base(k)),
+			Contents: v,
+			Type:     nodetasks.FileType_File,
+			Mode:     fi.String("0755"),
+		})
+	}
+
+	// Add configuration file for easier use of crictl
+	c.AddTask(&nodetasks.File{
+		Path:     "/etc/crictl.yaml",
+		Contents: fi.NewStringResource("runtime-endpoint: unix://" + crioSocket + "\n"),
+		Type:     nodetasks.FileType_File,
+	})
+
+	c.AddTask(b.buildSystemdService())
+
+	return nil
+}
+
+// buildCrioConfig builds the CRI-O config file, using the cgroup driver of the kubelet.
+func (b *CrioBuilder) buildCrioConfig() (string, error) {
+	crio := b.Cluster.Spec.Crio
+	if crio != nil && fi.StringValue(crio.ConfigOverride) != "" {
+		return *crio.ConfigOverride, nil
+	}
+
+	config, err := toml.Load("")
+	if err != nil {
+		return "", err
+	}
+
+	config.SetPath([]string{"crio", "api", "listen"}, crioSocket)
+
+	if crio != nil && crio.LogLevel != nil {
+		config.SetPath([]string{"crio", "runtime", "log_level"}, fi.StringValue(crio.LogLevel))
+	}
+
+	// CRI-O and the kubelet must use the same cgroup driver
+	if b.Cluster.Spec.Kubelet != nil && b.Cluster.Spec.Kubelet.CgroupDriver == "systemd" {
+		config.SetPath([]string{"crio", "runtime", "cgroup_manager"}, "systemd")
+		config.SetPath([]string{"crio", "runtime", "conmon_cgroup"}, "system.slice")
+	} else {
+		config.SetPath([]string{"crio", "runtime", "cgroup_manager"}, "cgroupfs")
+		config.SetPath([]string{"crio", "runtime", "conmon_cgroup"}, "pod")
+	}
+	config.SetPath([]string{"crio", "runtime", "conmon"}, "/usr/bin/conmon")
+	config.SetPath([]string{"crio", "runtime", "pinns_path"}, "/usr/bin/pinns")
+	config.SetPath([]string{"crio", "runtime", "default_runtime"}, "runc")
+	config.SetPath([]string{"crio", "runtime", "runtimes", "runc", "runtime_path"}, "/usr/bin/runc")
+	config.SetPath([]string{"crio", "runtime", "runtimes", "runc", "runtime_type"}, "oci")
+	config.SetPath([]string{"crio", "runtime", "runtimes", "runc", "runtime_root"}, "/run/runc")
+
+	if b.Cluster.Spec.Kubelet != nil && b.Cluster.Spec.Kubelet.PodInfraContainerImage != "" {
+		config.SetPath([]string{"crio", "image", "pause_image"}, b.Cluster.Spec.Kubelet.PodInfraContainerImage)
+	}
+
+	config.SetPath([]string{"crio", "network", "network_dir"}, b.CNIConfDir())
+	config.SetPath([]string{"crio", "network", "plugin_dirs"}, []string{b.CNIBinDir()})
+
+	return config.String(), nil
+}
+
+func (b *CrioBuilder) buildSystemdService() *nodetasks.Service {
+	// Based on https://github.com/cri-o/cri-o/blob/main/contrib/systemd/crio.service
+
+	manifest := &systemd.Manifest{}
+	manifest.Set("Unit", "Description", "Container Runtime Interface for OCI (CRI-O)")
+	manifest.Set("Unit", "Documentation", "https://github.com/cri-o/cri-o")
+	manifest.Set("Unit", "Wants", "network-online.target")
+	manifest.Set("Unit", "After", "network-online.target")
+
+	manifest.Set("Service", "Type", "notify")
+	manifest.Set("Service", "EnvironmentFile", "/etc/environment")
+	manifest.Set("Service", "Environment", "GOTRACEBACK=crash")
+	manifest.Set("Service", "ExecStartPre", "-/sbin/modprobe overlay")
+	manifest.Set("Service", "ExecStart", "/usr/bin/crio --config "+crioConfigFilePath)
+	manifest.Set("Service", "ExecReload", "/bin/kill -s HUP $MAINPID")
+
+	manifest.Set("Service", "Restart", "always")
+	manifest.Set("Service", "RestartSec", "5")
+
+	manifest.Set("Service", "LimitNOFILE", "1048576")
+	manifest.Set("Service", "LimitNPROC", "1048576")
+	manifest.Set("Service", "LimitCORE", "infinity")
+	manifest.Set("Service", "TasksMax", "infinity")
+
+	// make killing of processes of this unit under memory pressure very unlikely
+	manifest.Set("Service", "OOMScoreAdjust", "-999")
+
+	manifest.Set("Install", "WantedBy", "multi-user.target")
+
+	if b.Cluster.Spec.Kubelet.CgroupDriver == "systemd" {
+		cgroup := b.Cluster.Spec.Kubelet.RuntimeCgroups
+		if cgroup != "" {
+			manifest.Set("Service", "Slice", strings.Trim(cgroup, "/")+".slice")
+		}
+	}
+
+	manifestString := manifest.Render()
+	klog.V(8).Infof("Built service manifest %q\n%s", "crio", manifestString)
+
+	service := &nodetasks.Service{
+		Name:       "crio.service",
+		Definition: s(manifestString),
+	}
+
+	service.InitDefaults()
+
+	return service
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/testutils"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/util/pkg/distributions"
+)
+
+func TestCrioBuilder_Simple(t *testing.T) {
+	h := testutils.NewIntegrationTestHarness(t)
+	defer h.Close()
+
+	h.MockKopsVersion("1.18.0")
+	h.SetupMockAWS()
+
+	basedir := path.Join("tests/criobuilder/", "simple")
+
+	model, err := testutils.LoadModel(basedir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nodeUpModelContext, err := BuildNodeupModelContext(model)
+	if err != nil {
+		t.Fatalf("error parsing cluster yaml %q: %v", basedir, err)
+	}
+
+	nodeUpModelContext.Distribution = distributions.DistributionUbuntu2004
+
+	nodeUpModelContext.Assets = fi.NewAssetStore("")
+	for _, name := range []string{"conmon", "crictl", "crio", "crio-status", "crun", "pinns", "runc"} {
+		nodeUpModelContext.Assets.AddForTest(name, "cri-o/bin/"+name, "testing cri-o content")
+	}
+
+	if err := nodeUpModelContext.Init(); err != nil {
+		t.Fatalf("error from nodeupModelContext.Init(): %v", err)
+	}
+	context := &fi.ModelBuilderContext{
+		Tasks: make(map[string]fi.Task),
+	}
+
+	builder := CrioBuilder{NodeupModelContext: nodeUpModelContext}
+	if err := builder.Build(context); err != nil {
+		t.Fatalf("error from CrioBuilder Build: %v", err)
+	}
+
+	testutils.ValidateTasks(t, filepath.Join(basedir, "tasks.yaml"), context)
+}
+
+func TestCrioConfigCgroupDriver(t *testing.T) {
+	grid := map[string]string{
+		"systemd":  `cgroup_manager = "systemd"`,
+		"cgroupfs": `cgroup_manager = "cgroupfs"`,
+	}
+
+	for driver, expected := range grid {
+		t.Run(driver, func(t *testing.T) {
+			b := &CrioBuilder{
+				NodeupModelContext: &NodeupModelContext{
+					Cluster: &kops.Cluster{
+						Spec: kops.ClusterSpec{
+							ContainerRuntime: "crio",
+							Crio:             &kops.CrioConfig{},
+							Kubelet:          &kops.KubeletConfigSpec{CgroupDriver: driver},
+						},
+					},
+				},
+			}
+
+			config, err := b.buildCrioConfig()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strings.Contains(config, expected) {
+				t.Errorf("expected %q in config:\n%s", expected, config)
+			}
+		})
+	}
+}
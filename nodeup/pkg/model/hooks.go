@@ -133,6 +133,8 @@ func (h *HookBuilder) buildSystemdService(name string, hook *kops.HookSpec) (*no
 				if err := h.buildDockerService(unit, hook); err != nil {
 					return nil, err
 				}
+			case "crio":
+				return nil, fmt.Errorf("execContainer hooks are not supported with CRI-O")
 			default:
 				return nil, fmt.Errorf("unknown container runtime %q", h.Cluster.Spec.ContainerRuntime)
 			}
@@ -19,16 +19,17 @@ package model
 import (
 	"fmt"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/kops/model"
 	"k8s.io/kops/pkg/flagbuilder"
 	"k8s.io/kops/pkg/k8scodecs"
 	"k8s.io/kops/pkg/kubeconfig"
 	"k8s.io/kops/pkg/kubemanifest"
 	"k8s.io/kops/pkg/model/components"
 	"k8s.io/kops/pkg/tokens"
-	"k8s.io/kops/pkg/wellknownports"
 	"k8s.io/kops/pkg/wellknownusers"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
@@ -78,6 +79,10 @@ func (b *KubeAPIServerBuilder) Build(c *fi.ModelBuilderContext) error {
 		return err
 	}
 
+	if err := b.writeAdmissionPluginConfig(c, &kubeAPIServer); err != nil {
+		return err
+	}
+
 	if b.NodeupConfig.APIServerConfig.EncryptionConfigSecretHash != "" {
 		encryptionConfigPath := fi.String(filepath.Join(pathSrvKAPI, "encryptionconfig.yaml"))
 
@@ -354,6 +359,102 @@ func (b *KubeAPIServerBuilder) writeAuthenticationConfig(c *fi.ModelBuilderConte
 	return fmt.Errorf("unrecognized authentication config %v", b.Cluster.Spec.Authentication)
 }
 
+// admissionConfiguration is the AdmissionConfiguration read by kube-apiserver from --admission-control-config-file.
+type admissionConfiguration struct {
+	APIVersion string                         `json:"apiVersion"`
+	Kind       string                         `json:"kind"`
+	Plugins    []admissionPluginConfiguration `json:"plugins"`
+}
+
+type admissionPluginConfiguration struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// eventRateLimitConfiguration is the configuration file of the EventRateLimit admission plugin.
+type eventRateLimitConfiguration struct {
+	APIVersion string                `json:"apiVersion"`
+	Kind       string                `json:"kind"`
+	Limits     []kops.EventRateLimit `json:"limits"`
+}
+
+// imagePolicyWebhookConfiguration is the configuration file of the ImagePolicyWebhook admission plugin.
+type imagePolicyWebhookConfiguration struct {
+	ImagePolicy struct {
+		KubeConfigFile string `json:"kubeConfigFile"`
+		AllowTTL       int32  `json:"allowTTL,omitempty"`
+		DenyTTL        int32  `json:"denyTTL,omitempty"`
+		RetryBackoff   int32  `json:"retryBackoff,omitempty"`
+		DefaultAllow   bool   `json:"defaultAllow"`
+	} `json:"imagePolicy"`
+}
+
+// writeAdmissionPluginConfig writes the admission configuration and the configuration files of the admission plugins configured in the spec.
+func (b *KubeAPIServerBuilder) writeAdmissionPluginConfig(c *fi.ModelBuilderContext, kubeAPIServer *kops.KubeAPIServerConfig) error {
+	spec := kubeAPIServer.AdmissionPluginConfig
+	if spec == nil {
+		return nil
+	}
+
+	pathAdmission := filepath.Join(b.PathSrvKubernetes(), "kube-apiserver", "admission")
+	config := &admissionConfiguration{
+		APIVersion: "apiserver.config.k8s.io/v1",
+		Kind:       "AdmissionConfiguration",
+	}
+
+	addFile := func(name string, contents []byte) string {
+		path := filepath.Join(pathAdmission, name)
+		c.AddTask(&nodetasks.File{
+			Path:     path,
+			Contents: fi.NewBytesResource(contents),
+			Mode:     fi.String("600"),
+			Type:     nodetasks.FileType_File,
+		})
+		return path
+	}
+
+	if spec.EventRateLimit != nil {
+		manifest, err := kops.ToRawYaml(&eventRateLimitConfiguration{
+			APIVersion: "eventratelimit.admission.k8s.io/v1alpha1",
+			Kind:       "Configuration",
+			Limits:     spec.EventRateLimit.Limits,
+		})
+		if err != nil {
+			return fmt.Errorf("error marshaling EventRateLimit configuration to yaml: %v", err)
+		}
+		config.Plugins = append(config.Plugins, admissionPluginConfiguration{
+			Name: "EventRateLimit",
+			Path: addFile("eventratelimit.yaml", manifest),
+		})
+	}
+
+	if spec.ImagePolicyWebhook != nil {
+		imagePolicy := &imagePolicyWebhookConfiguration{}
+		imagePolicy.ImagePolicy.KubeConfigFile = addFile("imagepolicywebhook.kubeconfig", []byte(spec.ImagePolicyWebhook.KubeConfig))
+		imagePolicy.ImagePolicy.AllowTTL = fi.Int32Value(spec.ImagePolicyWebhook.AllowTTL)
+		imagePolicy.ImagePolicy.DenyTTL = fi.Int32Value(spec.ImagePolicyWebhook.DenyTTL)
+		imagePolicy.ImagePolicy.RetryBackoff = fi.Int32Value(spec.ImagePolicyWebhook.RetryBackoff)
+		imagePolicy.ImagePolicy.DefaultAllow = spec.ImagePolicyWebhook.DefaultAllow
+
+		manifest, err := kops.ToRawYaml(imagePolicy)
+		if err != nil {
+			return fmt.Errorf("error marshaling ImagePolicyWebhook configuration to yaml: %v", err)
+		}
+		config.Plugins = append(config.Plugins, admissionPluginConfiguration{
+			Name: "ImagePolicyWebhook",
+			Path: addFile("imagepolicywebhook.yaml", manifest),
+		})
+	}
+
+	manifest, err := kops.ToRawYaml(config)
+	if err != nil {
+		return fmt.Errorf("error marshaling admission configuration to yaml: %v", err)
+	}
+	kubeAPIServer.AdmissionControlConfigFile = addFile("admission-configuration.yaml", manifest)
+
+	return nil
+}
+
 func (b *KubeAPIServerBuilder) writeServerCertificate(c *fi.ModelBuilderContext, kubeAPIServer *kops.KubeAPIServerConfig) error {
 	pathSrvKAPI := filepath.Join(b.PathSrvKubernetes(), "kube-apiserver")
 
@@ -570,12 +671,12 @@ func (b *KubeAPIServerBuilder) buildPod(kubeAPIServer *kops.KubeAPIServerConfig)
 	probeAction := &v1.HTTPGetAction{
 		Host: "127.0.0.1",
 		Path: "/healthz",
-		Port: intstr.FromInt(wellknownports.KubeAPIServerHealthCheck),
+		Port: intstr.FromInt(model.KubeAPIServerHealthcheckPort(b.Cluster)),
 	}
 
 	insecurePort := fi.Int32Value(kubeAPIServer.InsecurePort)
 	if useHealthcheckProxy {
-		// kube-apiserver-healthcheck sidecar container runs on port 3990
+		// kube-apiserver-healthcheck sidecar container runs on port 3990, unless configured otherwise
 	} else if insecurePort != 0 {
 		probeAction.Port = intstr.FromInt(int(insecurePort))
 	} else if kubeAPIServer.SecurePort != 0 {
@@ -616,13 +717,13 @@ func (b *KubeAPIServerBuilder) buildPod(kubeAPIServer *kops.KubeAPIServerConfig)
 		Name:  "kube-apiserver",
 		Image: image,
 		Env:   proxy.GetProxyEnvVars(b.Cluster.Spec.EgressProxy),
-		LivenessProbe: &v1.Probe{
+		LivenessProbe: overrideProbe(&v1.Probe{
 			ProbeHandler: v1.ProbeHandler{
 				HTTPGet: probeAction,
 			},
 			InitialDelaySeconds: 45,
 			TimeoutSeconds:      15,
-		},
+		}, kubeAPIServer.LivenessProbe),
 		Ports: []v1.ContainerPort{
 			{
 				Name:          "https",
@@ -636,6 +737,23 @@ func (b *KubeAPIServerBuilder) buildPod(kubeAPIServer *kops.KubeAPIServerConfig)
 		},
 	}
 
+	if kubeAPIServer.ReadinessProbe != nil {
+		readinessAction := *probeAction
+		readinessAction.Path = "/readyz"
+		container.ReadinessProbe = overrideProbe(&v1.Probe{
+			ProbeHandler: v1.ProbeHandler{
+				HTTPGet: &readinessAction,
+			},
+		}, kubeAPIServer.ReadinessProbe)
+	}
+	if kubeAPIServer.StartupProbe != nil {
+		container.StartupProbe = overrideProbe(&v1.Probe{
+			ProbeHandler: v1.ProbeHandler{
+				HTTPGet: probeAction,
+			},
+		}, kubeAPIServer.StartupProbe)
+	}
+
 	if insecurePort != 0 {
 		container.Ports = append(container.Ports, v1.ContainerPort{
 			Name:          "local",
@@ -726,13 +844,25 @@ func (b *KubeAPIServerBuilder) buildAnnotations() map[string]string {
 	annotations := make(map[string]string)
 	annotations["kubectl.kubernetes.io/default-container"] = "kube-apiserver"
 
+	var apiRecord, apiInternalRecord *kops.DNSRecordSpec
+	if b.Cluster.Spec.DNSRecords != nil {
+		apiRecord = b.Cluster.Spec.DNSRecords.API
+		apiInternalRecord = b.Cluster.Spec.DNSRecords.APIInternal
+	}
+
 	if b.Cluster.Spec.API != nil {
 		if b.Cluster.Spec.API.LoadBalancer == nil || !b.Cluster.Spec.API.LoadBalancer.UseForInternalAPI {
 			annotations["dns.alpha.kubernetes.io/internal"] = b.Cluster.Spec.MasterInternalName
+			if apiInternalRecord != nil && apiInternalRecord.TTL != nil {
+				annotations["dns.alpha.kubernetes.io/internal-ttl"] = strconv.Itoa(int(*apiInternalRecord.TTL))
+			}
 		}
 
 		if b.Cluster.Spec.API.DNS != nil {
 			annotations["dns.alpha.kubernetes.io/external"] = b.Cluster.Spec.MasterPublicName
+			if apiRecord != nil && apiRecord.TTL != nil {
+				annotations["dns.alpha.kubernetes.io/external-ttl"] = strconv.Itoa(int(*apiRecord.TTL))
+			}
 		}
 	}
 
@@ -17,11 +17,14 @@ limitations under the License.
 package model
 
 import (
+	"io"
+	"strings"
 	"testing"
 
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/flagbuilder"
 	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
 	"k8s.io/kops/util/pkg/architectures"
 )
 
@@ -183,3 +186,81 @@ func TestKubeAPIServerBuilderARM64(t *testing.T) {
 		return builder.Build(target)
 	})
 }
+
+func TestKubeAPIServerAdmissionPluginConfig(t *testing.T) {
+	kubeAPIServer := &kops.KubeAPIServerConfig{
+		AdmissionPluginConfig: &kops.AdmissionPluginConfigSpec{
+			EventRateLimit: &kops.EventRateLimitAdmissionConfig{
+				Limits: []kops.EventRateLimit{
+					{Type: "Namespace", QPS: 50, Burst: 100, CacheSize: fi.Int32(2000)},
+				},
+			},
+			ImagePolicyWebhook: &kops.ImagePolicyWebhookAdmissionConfig{
+				KubeConfig: "apiVersion: v1\nkind: Config\n",
+				AllowTTL:   fi.Int32(50),
+			},
+		},
+	}
+
+	builder := KubeAPIServerBuilder{NodeupModelContext: &NodeupModelContext{}}
+	c := &fi.ModelBuilderContext{
+		Tasks: map[string]fi.Task{},
+	}
+	if err := builder.writeAdmissionPluginConfig(c, kubeAPIServer); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if kubeAPIServer.AdmissionControlConfigFile != "/srv/kubernetes/kube-apiserver/admission/admission-configuration.yaml" {
+		t.Errorf("unexpected admissionControlConfigFile %q", kubeAPIServer.AdmissionControlConfigFile)
+	}
+
+	files := map[string]string{}
+	for _, task := range c.Tasks {
+		file, ok := task.(*nodetasks.File)
+		if !ok {
+			continue
+		}
+		r, err := file.Contents.Open()
+		if err != nil {
+			t.Fatalf("error opening contents of %s: %v", file.Path, err)
+		}
+		b, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatalf("error reading contents of %s: %v", file.Path, err)
+		}
+		files[strings.TrimPrefix(file.Path, "/srv/kubernetes/kube-apiserver/admission/")] = string(b)
+	}
+
+	expected := map[string]string{
+		"admission-configuration.yaml": `apiVersion: apiserver.config.k8s.io/v1
+kind: AdmissionConfiguration
+plugins:
+- name: EventRateLimit
+  path: /srv/kubernetes/kube-apiserver/admission/eventratelimit.yaml
+- name: ImagePolicyWebhook
+  path: /srv/kubernetes/kube-apiserver/admission/imagepolicywebhook.yaml
+`,
+		"eventratelimit.yaml": `apiVersion: eventratelimit.admission.k8s.io/v1alpha1
+kind: Configuration
+limits:
+- burst: 100
+  cacheSize: 2000
+  qps: 50
+  type: Namespace
+`,
+		"imagepolicywebhook.yaml": `imagePolicy:
+  allowTTL: 50
+  defaultAllow: false
+  kubeConfigFile: /srv/kubernetes/kube-apiserver/admission/imagepolicywebhook.kubeconfig
+`,
+		"imagepolicywebhook.kubeconfig": "apiVersion: v1\nkind: Config\n",
+	}
+	if len(files) != len(expected) {
+		t.Errorf("unexpected files %v", files)
+	}
+	for name, contents := range expected {
+		if files[name] != contents {
+			t.Errorf("unexpected contents of %s, got:\n%s\nwant:\n%s", name, files[name], contents)
+		}
+	}
+}
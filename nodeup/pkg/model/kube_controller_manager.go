@@ -215,22 +215,22 @@ func (b *KubeControllerManagerBuilder) buildPod(kcm *kops.KubeControllerManagerC
 		image = strings.Replace(image, "-amd64", "-"+string(b.Architecture), 1)
 	}
 
+	healthAction := &v1.HTTPGetAction{
+		Host:   "127.0.0.1",
+		Path:   "/healthz",
+		Port:   intstr.FromInt(10257),
+		Scheme: "HTTPS",
+	}
+
 	container := &v1.Container{
 		Name:  "kube-controller-manager",
 		Image: image,
 		Env:   proxy.GetProxyEnvVars(b.Cluster.Spec.EgressProxy),
-		LivenessProbe: &v1.Probe{
-			ProbeHandler: v1.ProbeHandler{
-				HTTPGet: &v1.HTTPGetAction{
-					Host:   "127.0.0.1",
-					Path:   "/healthz",
-					Port:   intstr.FromInt(10257),
-					Scheme: "HTTPS",
-				},
-			},
+		LivenessProbe: overrideProbe(&v1.Probe{
+			ProbeHandler:        v1.ProbeHandler{HTTPGet: healthAction},
 			InitialDelaySeconds: 15,
 			TimeoutSeconds:      15,
-		},
+		}, kcm.LivenessProbe),
 		Resources: v1.ResourceRequirements{
 			Requests: v1.ResourceList{
 				v1.ResourceCPU: resource.MustParse("100m"),
@@ -238,6 +238,12 @@ func (b *KubeControllerManagerBuilder) buildPod(kcm *kops.KubeControllerManagerC
 		},
 	}
 
+	if kcm.StartupProbe != nil {
+		container.StartupProbe = overrideProbe(&v1.Probe{
+			ProbeHandler: v1.ProbeHandler{HTTPGet: healthAction},
+		}, kcm.StartupProbe)
+	}
+
 	// Log both to docker and to the logfile
 	kubemanifest.AddHostPathMapping(pod, container, "logfile", "/var/log/kube-controller-manager.log").WithReadWrite()
 	// We use lighter containers that don't include shells
@@ -236,17 +236,23 @@ func (b *KubeSchedulerBuilder) buildPod(kubeScheduler *kops.KubeSchedulerConfig)
 		Name:  "kube-scheduler",
 		Image: image,
 		Env:   proxy.GetProxyEnvVars(b.Cluster.Spec.EgressProxy),
-		LivenessProbe: &v1.Probe{
+		LivenessProbe: overrideProbe(&v1.Probe{
 			ProbeHandler:        v1.ProbeHandler{HTTPGet: healthAction},
 			InitialDelaySeconds: 15,
 			TimeoutSeconds:      15,
-		},
+		}, kubeScheduler.LivenessProbe),
 		Resources: v1.ResourceRequirements{
 			Requests: v1.ResourceList{
 				v1.ResourceCPU: resource.MustParse("100m"),
 			},
 		},
 	}
+	if kubeScheduler.StartupProbe != nil {
+		container.StartupProbe = overrideProbe(&v1.Probe{
+			ProbeHandler: v1.ProbeHandler{HTTPGet: healthAction},
+		}, kubeScheduler.StartupProbe)
+	}
+
 	kubemanifest.AddHostPathMapping(pod, container, "varlibkubescheduler", "/var/lib/kube-scheduler")
 	kubemanifest.AddHostPathMapping(pod, container, "srvscheduler", pathSrvScheduler)
 
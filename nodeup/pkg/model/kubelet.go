@@ -23,16 +23,20 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws/ec2metadata"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/bootstrap/metal"
 	"k8s.io/kops/pkg/flagbuilder"
 	"k8s.io/kops/pkg/model/components"
 	"k8s.io/kops/pkg/nodelabels"
@@ -53,6 +57,9 @@ const (
 	kubeletService = "kubelet.service"
 
 	kubeletConfigFilePath = "/var/lib/kubelet/kubelet.conf"
+
+	// credentialProviderConfigFilePath is the path of the config file for the kubelet credential providers
+	credentialProviderConfigFilePath = "/var/lib/kubelet/credential-provider.yaml"
 )
 
 // KubeletBuilder installs kubelet
@@ -163,7 +170,13 @@ func (b *KubeletBuilder) Build(c *fi.ModelBuilderContext) error {
 		return err
 	}
 
-	if kubeletConfig.CgroupDriver == "systemd" && b.Cluster.Spec.ContainerRuntime == "containerd" {
+	if fi.BoolValue(kubeletConfig.ImageCredentialProviders) {
+		if err := b.addImageCredentialProvider(c); err != nil {
+			return err
+		}
+	}
+
+	if kubeletConfig.CgroupDriver == "systemd" && b.Cluster.Spec.ContainerRuntime != "docker" {
 
 		{
 			cgroup := kubeletConfig.KubeletCgroups
@@ -259,6 +272,145 @@ func (b *KubeletBuilder) kubeletPath() string {
 	return kubeletCommand
 }
 
+// imageCredentialProvider describes the kubelet credential provider for the container registry of a cloud provider.
+type imageCredentialProvider struct {
+	// name is the name of the provider, and of its binary
+	name string
+	// asset matches the asset with the binary of the provider
+	asset *regexp.Regexp
+	// matchImages are the images the kubelet fetches credentials for with the provider
+	matchImages []string
+	// cacheDuration is how long the kubelet caches credentials if the provider doesn't say
+	cacheDuration time.Duration
+	// args are the arguments passed to the provider
+	args []string
+}
+
+// imageCredentialProvider returns the kubelet credential provider for the cloud provider
+func (b *KubeletBuilder) imageCredentialProvider() (*imageCredentialProvider, error) {
+	switch b.CloudProvider {
+	case kops.CloudProviderAWS:
+		return &imageCredentialProvider{
+			name:  "ecr-credential-provider",
+			asset: regexp.MustCompile(`/ecr-credential-provider-linux-(amd64|arm64)$`),
+			matchImages: []string{
+				"*.dkr.ecr.*.amazonaws.com",
+				"*.dkr.ecr.*.amazonaws.com.cn",
+				"*.dkr.ecr-fips.*.amazonaws.com",
+				"*.dkr.ecr.us-iso-east-1.c2s.ic.gov",
+				"*.dkr.ecr.us-isob-east-1.sc2s.sgov.gov",
+			},
+			cacheDuration: 12 * time.Hour,
+		}, nil
+	case kops.CloudProviderGCE:
+		return &imageCredentialProvider{
+			name:  "auth-provider-gcp",
+			asset: regexp.MustCompile(`/auth-provider-gcp$`),
+			matchImages: []string{
+				"container.cloud.google.com",
+				"gcr.io",
+				"*.gcr.io",
+				"*.pkg.dev",
+			},
+			cacheDuration: time.Minute,
+			args:          []string{"get-credentials", "--v=3"},
+		}, nil
+	case kops.CloudProviderAzure:
+		return &imageCredentialProvider{
+			name:  "acr-credential-provider",
+			asset: regexp.MustCompile(`/azure-acr-credential-provider-linux-(amd64|arm64)$`),
+			matchImages: []string{
+				"*.azurecr.io",
+				"*.azurecr.cn",
+				"*.azurecr.de",
+				"*.azurecr.us",
+			},
+			cacheDuration: 10 * time.Minute,
+			args:          []string{CloudConfigFilePath},
+		}, nil
+	default:
+		return nil, fmt.Errorf("image credential providers are not supported on %q", b.CloudProvider)
+	}
+}
+
+// credentialProviderBinDir returns the directory of the kubelet credential provider binaries, based on distro
+func (b *KubeletBuilder) credentialProviderBinDir() string {
+	if b.Distribution == distributions.DistributionContainerOS {
+		return "/home/kubernetes/bin/credential-provider"
+	}
+	return "/opt/kubernetes/credential-provider/bin"
+}
+
+// addImageCredentialProvider installs the kubelet credential provider and its config file
+func (b *KubeletBuilder) addImageCredentialProvider(c *fi.ModelBuilderContext) error {
+	provider, err := b.imageCredentialProvider()
+	if err != nil {
+		return err
+	}
+
+	_, asset, err := b.Assets.FindMatch(provider.asset)
+	if err != nil {
+		return fmt.Errorf("error finding credential provider asset: %w", err)
+	}
+
+	c.AddTask(&nodetasks.File{
+		Path:           filepath.Join(b.credentialProviderBinDir(), provider.name),
+		Contents:       asset,
+		Type:           nodetasks.FileType_File,
+		Mode:           s("0755"),
+		BeforeServices: []string{kubeletService},
+	})
+
+	t, err := buildImageCredentialProviderConfig(provider)
+	if err != nil {
+		return err
+	}
+	c.AddTask(t)
+
+	return nil
+}
+
+// buildImageCredentialProviderConfig renders the config file for the kubelet credential providers
+func buildImageCredentialProviderConfig(provider *imageCredentialProvider) (*nodetasks.File, error) {
+	config := kubelet.CredentialProviderConfig{
+		Providers: []kubelet.CredentialProvider{
+			{
+				Name:                 provider.name,
+				MatchImages:          provider.matchImages,
+				DefaultCacheDuration: &metav1.Duration{Duration: provider.cacheDuration},
+				APIVersion:           "credentialprovider.kubelet.k8s.io/v1alpha1",
+				Args:                 provider.args,
+			},
+		},
+	}
+
+	s := runtime.NewScheme()
+	if err := kubelet.AddToScheme(s); err != nil {
+		return nil, err
+	}
+
+	gv := kubelet.SchemeGroupVersion
+	codecFactory := serializer.NewCodecFactory(s)
+	info, ok := runtime.SerializerInfoForMediaType(codecFactory.SupportedMediaTypes(), "application/yaml")
+	if !ok {
+		return nil, fmt.Errorf("failed to find serializer")
+	}
+	encoder := codecFactory.EncoderForVersion(info.Serializer, gv)
+	var w bytes.Buffer
+	if err := encoder.Encode(&config, &w); err != nil {
+		return nil, err
+	}
+
+	t := &nodetasks.File{
+		Path:           credentialProviderConfigFilePath,
+		Contents:       fi.NewBytesResource(w.Bytes()),
+		Type:           nodetasks.FileType_File,
+		BeforeServices: []string{kubeletService},
+	}
+
+	return t, nil
+}
+
 // buildManifestDirectory creates the directory where kubelet expects static manifests to reside
 func (b *KubeletBuilder) buildManifestDirectory(kubeletConfig *kops.KubeletConfigSpec) (*nodetasks.File, error) {
 	directory := &nodetasks.File{
@@ -323,6 +475,12 @@ func (b *KubeletBuilder) buildSystemdEnvironmentFile(kubeletConfig *kops.Kubelet
 		} else {
 			flags += " --container-runtime-endpoint=unix://" + fi.StringValue(b.Cluster.Spec.Containerd.Address)
 		}
+	case "crio":
+		if b.IsKubernetesLT("1.24") {
+			flags += " --container-runtime=remote"
+		}
+		flags += " --runtime-request-timeout=15m"
+		flags += " --container-runtime-endpoint=unix://" + crioSocket
 	}
 
 	if b.UseKopsControllerForNodeBootstrap() {
@@ -334,6 +492,20 @@ func (b *KubeletBuilder) buildSystemdEnvironmentFile(kubeletConfig *kops.Kubelet
 		flags += " --node-ip=::"
 	}
 
+	if b.CloudProvider == kops.CloudProviderMetal {
+		// Metal machines are registered with their hostname, which is also the common name of their host certificate
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("error getting hostname: %w", err)
+		}
+		flags += " --provider-id=" + metal.ProviderID(b.BootConfig.InstanceGroupName, strings.ToLower(hostname))
+	}
+
+	if fi.BoolValue(kubeletConfig.ImageCredentialProviders) {
+		flags += " --image-credential-provider-config=" + credentialProviderConfigFilePath
+		flags += " --image-credential-provider-bin-dir=" + b.credentialProviderBinDir()
+	}
+
 	flags += " --config=" + kubeletConfigFilePath
 
 	sysconfig := "DAEMON_ARGS=\"" + flags + "\"\n"
@@ -361,6 +533,8 @@ func (b *KubeletBuilder) buildSystemdService() *nodetasks.Service {
 		manifest.Set("Unit", "After", "docker.service")
 	case "containerd":
 		manifest.Set("Unit", "After", "containerd.service")
+	case "crio":
+		manifest.Set("Unit", "After", "crio.service")
 	default:
 		klog.Warningf("unknown container runtime %q", b.Cluster.Spec.ContainerRuntime)
 	}
@@ -384,7 +558,7 @@ func (b *KubeletBuilder) buildSystemdService() *nodetasks.Service {
 
 	manifest.Set("Install", "WantedBy", "multi-user.target")
 
-	if b.Cluster.Spec.Kubelet.CgroupDriver == "systemd" && b.Cluster.Spec.ContainerRuntime == "containerd" {
+	if b.Cluster.Spec.Kubelet.CgroupDriver == "systemd" && b.Cluster.Spec.ContainerRuntime != "docker" {
 		cgroup := b.Cluster.Spec.Kubelet.KubeletCgroups
 		if cgroup != "" {
 			manifest.Set("Service", "Slice", strings.Trim(cgroup, "/")+".slice")
@@ -627,6 +801,19 @@ func (b *KubeletBuilder) buildKubeletConfigSpec() (*kops.KubeletConfigSpec, erro
 	// For bootstrapping reasons, protokube sets the critical labels for kops-controller to run.
 	c.NodeLabels = nil
 
+	if fi.BoolValue(c.ImageCredentialProviders) {
+		// Copy the map, so we don't modify the NodeupConfig
+		featureGates := make(map[string]string)
+		for k, v := range c.FeatureGates {
+			featureGates[k] = v
+		}
+		// The credential provider replaces the deprecated registry credentials built into the kubelet
+		if _, found := featureGates["DisableKubeletCloudCredentialProviders"]; !found {
+			featureGates["DisableKubeletCloudCredentialProviders"] = "true"
+		}
+		c.FeatureGates = featureGates
+	}
+
 	if c.AuthorizationMode == "" {
 		c.AuthorizationMode = "Webhook"
 	}
@@ -211,6 +211,34 @@ func Test_RunKubeletBuilderWarmPool(t *testing.T) {
 	testutils.ValidateTasks(t, filepath.Join(basedir, "tasks.yaml"), context)
 }
 
+func Test_RunKubeletBuilderImageCredentialProviders(t *testing.T) {
+	h := testutils.NewIntegrationTestHarness(t)
+	defer h.Close()
+
+	h.MockKopsVersion("1.18.0")
+	h.SetupMockAWS()
+
+	basedir := "tests/kubelet/credentialproviders"
+
+	context := &fi.ModelBuilderContext{
+		Tasks: make(map[string]fi.Task),
+	}
+
+	model, err := testutils.LoadModel(basedir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nodeUpModelContext, err := BuildNodeupModelContext(model)
+	if err != nil {
+		t.Fatalf("error loading model %q: %v", basedir, err)
+		return
+	}
+	runKubeletBuilder(t, context, nodeUpModelContext)
+
+	testutils.ValidateTasks(t, filepath.Join(basedir, "tasks.yaml"), context)
+}
+
 func runKubeletBuilder(t *testing.T, context *fi.ModelBuilderContext, nodeupModelContext *NodeupModelContext) {
 	if err := nodeupModelContext.Init(); err != nil {
 		t.Fatalf("error from nodeupModelContext.Init(): %v", err)
@@ -240,6 +268,19 @@ func runKubeletBuilder(t *testing.T, context *fi.ModelBuilderContext, nodeupMode
 		}
 		context.AddTask(fileTask)
 	}
+	if fi.BoolValue(kubeletConfig.ImageCredentialProviders) {
+		provider, err := builder.imageCredentialProvider()
+		if err != nil {
+			t.Fatalf("error from KubeletBuilder imageCredentialProvider: %v", err)
+			return
+		}
+		fileTask, err := buildImageCredentialProviderConfig(provider)
+		if err != nil {
+			t.Fatalf("error from KubeletBuilder buildImageCredentialProviderConfig: %v", err)
+			return
+		}
+		context.AddTask(fileTask)
+	}
 	{
 		task, err := builder.buildManifestDirectory(kubeletConfig)
 		if err != nil {
@@ -398,7 +439,7 @@ func RunGoldenTest(t *testing.T, basedir string, key string, builder func(*Nodeu
 
 	keystore := &fakeKeystore{}
 	keystore.T = t
-	saKeyset, _ := rotatingPrivateKeyset().ToAPIObject("service-account")
+	saKeyset, _ := rotatingPrivateKeyset().ToAPIObject("service-account", "")
 	keystore.privateKeysets = map[string]*kops.Keyset{
 		"kubernetes-ca":           simplePrivateKeyset(dummyCertificate, dummyKey),
 		"apiserver-aggregator-ca": simplePrivateKeyset(dummyCertificate, dummyKey),
@@ -17,6 +17,12 @@ limitations under the License.
 package networking
 
 import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"k8s.io/klog/v2"
 	"k8s.io/kops/nodeup/pkg/model"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
@@ -41,5 +47,55 @@ func (b *CalicoBuilder) Build(c *fi.ModelBuilderContext) error {
 		c.AddTask(&nodetasks.Package{Name: "wireguard"})
 	}
 
+	if networking.Calico.BPFEnabled {
+		if err := b.checkBPFKernel(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkBPFKernel fails early if the kernel is too old for the Calico eBPF dataplane,
+// which would otherwise leave calico-node crash-looping and the node without networking.
+// Calico requires kernel 5.3 or later, or 4.18 on the RHEL family, which backports the required features.
+func (b *CalicoBuilder) checkBPFKernel() error {
+	osRelease, err := os.ReadFile("/proc/sys/kernel/osrelease")
+	if err != nil {
+		return fmt.Errorf("error reading kernel version: %w", err)
+	}
+	release := strings.TrimSpace(string(osRelease))
+
+	major, minor, err := parseKernelVersion(release)
+	if err != nil {
+		return err
+	}
+
+	minMajor, minMinor := 5, 3
+	if b.Distribution.IsRHELFamily() {
+		minMajor, minMinor = 4, 18
+	}
+	if major < minMajor || (major == minMajor && minor < minMinor) {
+		return fmt.Errorf("the Calico eBPF dataplane requires kernel %d.%d or later, but the kernel is %s", minMajor, minMinor, release)
+	}
+	klog.V(2).Infof("kernel %s supports the Calico eBPF dataplane", release)
+
 	return nil
 }
+
+// parseKernelVersion returns the major and minor version of a kernel release such as "5.15.0-1019-aws"
+func parseKernelVersion(release string) (int, int, error) {
+	tokens := strings.SplitN(release, ".", 3)
+	if len(tokens) < 2 {
+		return 0, 0, fmt.Errorf("unable to parse kernel version %q", release)
+	}
+	major, err := strconv.Atoi(tokens[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("unable to parse kernel version %q: %w", release, err)
+	}
+	minor, err := strconv.Atoi(strings.TrimRightFunc(tokens[1], func(r rune) bool { return r < '0' || r > '9' }))
+	if err != nil {
+		return 0, 0, fmt.Errorf("unable to parse kernel version %q: %w", release, err)
+	}
+	return major, minor, nil
+}
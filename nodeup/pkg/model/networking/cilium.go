@@ -22,8 +22,6 @@ import (
 	"os"
 	"path/filepath"
 
-	"golang.org/x/sys/unix"
-
 	"k8s.io/kops/nodeup/pkg/model"
 	apiModel "k8s.io/kops/pkg/apis/kops/model"
 	"k8s.io/kops/upup/pkg/fi"
@@ -64,8 +62,7 @@ func (b *CiliumBuilder) Build(c *fi.ModelBuilderContext) error {
 }
 
 func (b *CiliumBuilder) buildBPFMount(c *fi.ModelBuilderContext) error {
-	var fsdata unix.Statfs_t
-	err := unix.Statfs("/sys/fs/bpf", &fsdata)
+	fsType, err := statfsType("/sys/fs/bpf")
 	if err != nil {
 		return fmt.Errorf("error checking for /sys/fs/bpf: %v", err)
 	}
@@ -74,7 +71,7 @@ func (b *CiliumBuilder) buildBPFMount(c *fi.ModelBuilderContext) error {
 	BPF_FS_MAGIC := uint32(0xcafe4a11)
 
 	// systemd v238 includes the bpffs mount by default; and gives an error "has a bad unit file setting" if we try to mount it again (see mount_point_is_api)
-	alreadyMounted := uint32(fsdata.Type) == BPF_FS_MAGIC
+	alreadyMounted := fsType == BPF_FS_MAGIC
 
 	if !alreadyMounted {
 		unit := `
@@ -107,8 +104,7 @@ WantedBy=multi-user.target
 func (b *CiliumBuilder) buildCgroup2Mount(c *fi.ModelBuilderContext) error {
 	cgroupPath := "/run/cilium/cgroupv2"
 
-	var fsdata unix.Statfs_t
-	err := unix.Statfs(cgroupPath, &fsdata)
+	fsType, err := statfsType(cgroupPath)
 
 	// If the path does not exist, systemd will create it
 	if err != nil && !errors.Is(err, os.ErrNotExist) {
@@ -117,7 +113,7 @@ func (b *CiliumBuilder) buildCgroup2Mount(c *fi.ModelBuilderContext) error {
 
 	CGROUP_FS_MAGIC := uint32(0x63677270)
 
-	alreadyMounted := uint32(fsdata.Type) == CGROUP_FS_MAGIC
+	alreadyMounted := fsType == CGROUP_FS_MAGIC
 
 	if !alreadyMounted {
 		unit := `
@@ -0,0 +1,32 @@
+//go:build !windows
+
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networking
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// statfsType returns the type of the filesystem mounted at the path.
+func statfsType(path string) (uint32, error) {
+	var fsdata unix.Statfs_t
+	if err := unix.Statfs(path, &fsdata); err != nil {
+		return 0, err
+	}
+	return uint32(fsdata.Type), nil
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
+)
+
+// PreloadImagesBuilder pulls the preload images of the instance group before the kubelet is started.
+type PreloadImagesBuilder struct {
+	*NodeupModelContext
+}
+
+var _ fi.ModelBuilder = &PreloadImagesBuilder{}
+
+func (b *PreloadImagesBuilder) Build(c *fi.ModelBuilderContext) error {
+	if b.NodeupConfig == nil {
+		return nil
+	}
+
+	for _, image := range b.NodeupConfig.PreloadImages {
+		c.AddTask(&nodetasks.PullImageTask{
+			Name:           image,
+			Runtime:        b.Cluster.Spec.ContainerRuntime,
+			BeforeServices: []string{kubeletService},
+		})
+	}
+
+	return nil
+}
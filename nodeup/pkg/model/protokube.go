@@ -34,11 +34,16 @@ import (
 	"k8s.io/kops/util/pkg/distributions"
 
 	"k8s.io/kops/util/pkg/proxy"
+	"k8s.io/kops/util/pkg/vfs"
 
 	"github.com/blang/semver/v4"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
 )
 
+// gossipEncryptionKeysPath is the file with the keys that protokube encrypts memberlist gossip with.
+const gossipEncryptionKeysPath = "/var/lib/kops/gossip-encryption-keys"
+
 // ProtokubeBuilder configures protokube
 type ProtokubeBuilder struct {
 	*NodeupModelContext
@@ -99,6 +104,15 @@ func (t *ProtokubeBuilder) Build(c *fi.ModelBuilderContext) error {
 		})
 	}
 
+	if len(t.NodeupConfig.GossipEncryptionKeys) != 0 {
+		c.AddTask(&nodetasks.File{
+			Path:     gossipEncryptionKeysPath,
+			Contents: fi.NewStringResource(strings.Join(t.NodeupConfig.GossipEncryptionKeys, "\n") + "\n"),
+			Type:     nodetasks.FileType_File,
+			Mode:     s("0400"),
+		})
+	}
+
 	envFile, err := t.buildEnvFile()
 	if err != nil {
 		return err
@@ -181,6 +195,12 @@ type ProtokubeFlags struct {
 	GossipProtocolSecondary *string `json:"gossip-protocol-secondary" flag:"gossip-protocol-secondary" flag-include-empty:"true"`
 	GossipListenSecondary   *string `json:"gossip-listen-secondary" flag:"gossip-listen-secondary"`
 	GossipSecretSecondary   *string `json:"gossip-secret-secondary" flag:"gossip-secret-secondary"`
+
+	GossipEncryptionKeysFile *string `json:"gossip-encryption-keys-file,omitempty" flag:"gossip-encryption-keys-file"`
+
+	GossipProbeInterval    *metav1.Duration `json:"gossip-probe-interval,omitempty" flag:"gossip-probe-interval"`
+	GossipInterval         *metav1.Duration `json:"gossip-interval,omitempty" flag:"gossip-interval"`
+	GossipPushPullInterval *metav1.Duration `json:"gossip-push-pull-interval,omitempty" flag:"gossip-push-pull-interval"`
 }
 
 // ProtokubeFlags is responsible for building the command line flags for protokube
@@ -216,6 +236,9 @@ func (t *ProtokubeBuilder) ProtokubeFlags(k8sVersion semver.Version) (*Protokube
 			f.GossipProtocol = t.Cluster.Spec.GossipConfig.Protocol
 			f.GossipListen = t.Cluster.Spec.GossipConfig.Listen
 			f.GossipSecret = t.Cluster.Spec.GossipConfig.Secret
+			f.GossipProbeInterval = t.Cluster.Spec.GossipConfig.ProbeInterval
+			f.GossipInterval = t.Cluster.Spec.GossipConfig.GossipInterval
+			f.GossipPushPullInterval = t.Cluster.Spec.GossipConfig.PushPullInterval
 
 			if t.Cluster.Spec.GossipConfig.Secondary != nil {
 				f.GossipProtocolSecondary = t.Cluster.Spec.GossipConfig.Secondary.Protocol
@@ -224,6 +247,10 @@ func (t *ProtokubeBuilder) ProtokubeFlags(k8sVersion semver.Version) (*Protokube
 			}
 		}
 
+		if len(t.NodeupConfig.GossipEncryptionKeys) != 0 {
+			f.GossipEncryptionKeysFile = fi.String(gossipEncryptionKeysPath)
+		}
+
 		// @TODO: This is hacky, but we want it so that we can have a different internal & external name
 		internalSuffix := t.Cluster.Spec.MasterInternalName
 		internalSuffix = strings.TrimPrefix(internalSuffix, "api.")
@@ -273,6 +300,13 @@ func (t *ProtokubeBuilder) buildEnvFile() (*nodetasks.File, error) {
 		envVars["S3_SECRET_ACCESS_KEY"] = os.Getenv("S3_SECRET_ACCESS_KEY")
 	}
 
+	// Pass in the credentials of the S3 compatible state stores
+	for _, envVar := range vfs.S3EndpointEnvVars {
+		if os.Getenv(envVar) != "" {
+			envVars[envVar] = os.Getenv(envVar)
+		}
+	}
+
 	if os.Getenv("OS_AUTH_URL") != "" {
 		for _, envVar := range []string{
 			"OS_TENANT_ID", "OS_TENANT_NAME", "OS_PROJECT_ID", "OS_PROJECT_NAME",
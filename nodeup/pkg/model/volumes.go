@@ -64,6 +64,13 @@ func (b *VolumesBuilder) Build(c *fi.ModelBuilderContext) error {
 
 		klog.Infof("Attempting to format and mount device: %s, path: %s", x.Device, x.Path)
 
+		// @step: mount-utils does not accept format options, so format the device ourselves when they are set
+		if len(x.FormatOptions) > 0 {
+			if err := formatDevice(m, x.Device, x.Filesystem, x.FormatOptions); err != nil {
+				return err
+			}
+		}
+
 		if err := m.FormatAndMount(x.Device, x.Path, x.Filesystem, x.MountOptions); err != nil {
 			klog.Errorf("failed to mount the device: %s on: %s, error: %s", x.Device, x.Path, err)
 
@@ -0,0 +1,47 @@
+//go:build !windows
+
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+
+	"k8s.io/klog/v2"
+	"k8s.io/mount-utils"
+)
+
+// formatDevice formats an unformatted device using the given mkfs options; devices which
+// already have a filesystem are left untouched.
+func formatDevice(m *mount.SafeFormatAndMount, device string, filesystem string, options []string) error {
+	existing, err := m.GetDiskFormat(device)
+	if err != nil {
+		return fmt.Errorf("failed to determine the format of device %q, error: %w", device, err)
+	}
+	if existing != "" {
+		klog.V(3).Infof("Skipping formatting device: %s, already formatted as %s", device, existing)
+		return nil
+	}
+
+	args := append(append([]string{}, options...), device)
+	klog.Infof("Formatting device: %s with mkfs.%s %v", device, filesystem, args)
+	if output, err := m.Exec.Command("mkfs."+filesystem, args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to format device %q, error: %w, output: %s", device, err, string(output))
+	}
+
+	return nil
+}
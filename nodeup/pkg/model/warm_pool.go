@@ -17,6 +17,7 @@ limitations under the License.
 package model
 
 import (
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
@@ -36,7 +37,12 @@ func (b *WarmPoolBuilder) Build(c *fi.ModelBuilderContext) error {
 
 	// Pre-pull container images during pre-initialization
 	if b.NodeupConfig != nil && b.ConfigurationMode == "Warming" {
+		preloadImages := sets.NewString(b.NodeupConfig.PreloadImages...)
 		for _, image := range b.NodeupConfig.WarmPoolImages {
+			// The PreloadImagesBuilder pulls these
+			if preloadImages.Has(image) {
+				continue
+			}
 			c.AddTask(&nodetasks.PullImageTask{
 				Name:    image,
 				Runtime: b.Cluster.Spec.ContainerRuntime,
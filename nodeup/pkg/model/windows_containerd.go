@@ -0,0 +1,153 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+
+	"github.com/pelletier/go-toml"
+
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
+)
+
+const (
+	// windowsContainerdDir is the directory of the containerd binaries on Windows
+	windowsContainerdDir = `C:\Program Files\containerd`
+	// windowsContainerdEndpoint is the CRI endpoint of containerd on Windows
+	windowsContainerdEndpoint = "npipe:////./pipe/containerd-containerd"
+	// windowsContainerdService is the name of the containerd Windows service
+	windowsContainerdService = "containerd"
+
+	// windowsLogDir is the directory of the logs of the Windows services
+	windowsLogDir = `C:\var\log`
+
+	// windowsCNIBinDir is the directory of the CNI plugins on Windows
+	windowsCNIBinDir = `C:\opt\cni\bin`
+	// windowsCNIConfDir is the directory of the CNI config on Windows
+	windowsCNIConfDir = `C:\etc\cni\net.d`
+)
+
+// WindowsContainerdBuilder installs containerd on Windows nodes
+type WindowsContainerdBuilder struct {
+	*NodeupModelContext
+}
+
+var _ fi.ModelBuilder = &WindowsContainerdBuilder{}
+
+// Build is responsible for installing and configuring containerd as a Windows service
+func (b *WindowsContainerdBuilder) Build(c *fi.ModelBuilderContext) error {
+	if !b.Distribution.IsWindows() {
+		return nil
+	}
+	if b.Cluster.Spec.ContainerRuntime != "containerd" {
+		return fmt.Errorf("container runtime %q is not supported on Windows", b.Cluster.Spec.ContainerRuntime)
+	}
+
+	// Add containerd binaries from the containerd release package
+	f := b.Assets.FindMatches(regexp.MustCompile(`^(\./)?bin/(containerd|containerd-shim-runhcs-v1|ctr)\.exe$`))
+	if len(f) == 0 {
+		return fmt.Errorf("unable to find any containerd binaries in assets")
+	}
+	for k, v := range f {
+		c.AddTask(&nodetasks.File{
+			Path:           windowsContainerdDir + `\` + path.Base(k),
+			Contents:       v,
+			Type:           nodetasks.FileType_File,
+			BeforeServices: []string{windowsContainerdService},
+		})
+	}
+
+	// Add the CNI plugins, that the network addons configure
+	for _, dir := range []string{windowsCNIBinDir, windowsCNIConfDir} {
+		c.EnsureTask(&nodetasks.File{
+			Path: dir,
+			Type: nodetasks.FileType_Directory,
+		})
+	}
+	f = b.Assets.FindMatches(regexp.MustCompile(`^(\./)?(flannel|host-local|win-bridge|win-overlay)\.exe$`))
+	for k, v := range f {
+		c.AddTask(&nodetasks.File{
+			Path:           windowsCNIBinDir + `\` + path.Base(k),
+			Contents:       v,
+			Type:           nodetasks.FileType_File,
+			BeforeServices: []string{windowsContainerdService},
+		})
+	}
+
+	config, err := b.buildContainerdConfig()
+	if err != nil {
+		return err
+	}
+	c.AddTask(&nodetasks.File{
+		Path:           windowsContainerdDir + `\config.toml`,
+		Contents:       fi.NewStringResource(config),
+		Type:           nodetasks.FileType_File,
+		BeforeServices: []string{windowsContainerdService},
+	})
+
+	c.EnsureTask(&nodetasks.File{
+		Path: windowsLogDir,
+		Type: nodetasks.FileType_Directory,
+	})
+
+	c.AddTask(&nodetasks.WindowsService{
+		Name:        windowsContainerdService,
+		DisplayName: "containerd container runtime",
+		BinaryPath:  fmt.Sprintf(`"%s\containerd.exe" --run-service --config "%s\config.toml" --log-file "%s\containerd.log"`, windowsContainerdDir, windowsContainerdDir, windowsLogDir),
+	})
+
+	return nil
+}
+
+// buildContainerdConfig builds the config file of containerd running in CRI mode on Windows
+func (b *WindowsContainerdBuilder) buildContainerdConfig() (string, error) {
+	containerd := b.NodeupConfig.ContainerdConfig
+	if containerd != nil && fi.StringValue(containerd.ConfigOverride) != "" {
+		return *containerd.ConfigOverride, nil
+	}
+
+	config, _ := toml.Load("")
+	config.SetPath([]string{"version"}, int64(2))
+	config.SetPath([]string{"root"}, `C:\ProgramData\containerd\root`)
+	config.SetPath([]string{"state"}, `C:\ProgramData\containerd\state`)
+	if b.Cluster.Spec.Kubelet != nil && b.Cluster.Spec.Kubelet.PodInfraContainerImage != "" {
+		config.SetPath([]string{"plugins", "io.containerd.grpc.v1.cri", "sandbox_image"}, b.Cluster.Spec.Kubelet.PodInfraContainerImage)
+	}
+	if containerd != nil {
+		for name, endpoints := range containerd.RegistryMirrors {
+			config.SetPath([]string{"plugins", "io.containerd.grpc.v1.cri", "registry", "mirrors", name, "endpoint"}, endpoints)
+		}
+	}
+	config.SetPath([]string{"plugins", "io.containerd.grpc.v1.cri", "containerd", "snapshotter"}, "windows")
+	config.SetPath([]string{"plugins", "io.containerd.grpc.v1.cri", "containerd", "default_runtime_name"}, "runhcs-wcow-process")
+	config.SetPath([]string{"plugins", "io.containerd.grpc.v1.cri", "containerd", "runtimes", "runhcs-wcow-process", "runtime_type"}, "io.containerd.runhcs.v1")
+	config.SetPath([]string{"plugins", "io.containerd.grpc.v1.cri", "cni", "bin_dir"}, windowsCNIBinDir)
+	config.SetPath([]string{"plugins", "io.containerd.grpc.v1.cri", "cni", "conf_dir"}, windowsCNIConfDir)
+
+	if containerd != nil && fi.StringValue(containerd.ConfigAdditions) != "" {
+		additions, err := toml.Load(*containerd.ConfigAdditions)
+		if err != nil {
+			return "", fmt.Errorf("error parsing containerd configAdditions: %v", err)
+		}
+		mergeTOML(config, additions)
+	}
+
+	return config.String(), nil
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"testing"
+
+	"github.com/pelletier/go-toml"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/nodeup"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func TestWindowsContainerdConfig(t *testing.T) {
+	cluster := &kops.Cluster{
+		Spec: kops.ClusterSpec{
+			ContainerRuntime:  "containerd",
+			Containerd:        &kops.ContainerdConfig{},
+			KubernetesVersion: "1.24.0",
+			Kubelet: &kops.KubeletConfigSpec{
+				PodInfraContainerImage: "registry.k8s.io/pause:3.6",
+			},
+		},
+	}
+
+	b := &WindowsContainerdBuilder{
+		NodeupModelContext: &NodeupModelContext{
+			Cluster: cluster,
+			NodeupConfig: &nodeup.Config{
+				ContainerdConfig: &kops.ContainerdConfig{
+					Version: fi.String("1.6.6"),
+					ConfigAdditions: fi.String(`[plugins."io.containerd.grpc.v1.cri"]
+  max_concurrent_downloads = 10
+`),
+				},
+			},
+		},
+	}
+
+	s, err := b.buildContainerdConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	config, err := toml.Load(s)
+	if err != nil {
+		t.Fatalf("unexpected error parsing config: %v", err)
+	}
+
+	for _, g := range []struct {
+		path     []string
+		expected interface{}
+	}{
+		{path: []string{"plugins", "io.containerd.grpc.v1.cri", "sandbox_image"}, expected: "registry.k8s.io/pause:3.6"},
+		{path: []string{"plugins", "io.containerd.grpc.v1.cri", "max_concurrent_downloads"}, expected: int64(10)},
+		{path: []string{"plugins", "io.containerd.grpc.v1.cri", "containerd", "snapshotter"}, expected: "windows"},
+		{path: []string{"plugins", "io.containerd.grpc.v1.cri", "containerd", "runtimes", "runhcs-wcow-process", "runtime_type"}, expected: "io.containerd.runhcs.v1"},
+		{path: []string{"plugins", "io.containerd.grpc.v1.cri", "cni", "bin_dir"}, expected: `C:\opt\cni\bin`},
+		{path: []string{"plugins", "io.containerd.grpc.v1.cri", "cni", "conf_dir"}, expected: `C:\etc\cni\net.d`},
+	} {
+		if actual := config.GetPath(g.path); actual != g.expected {
+			t.Errorf("unexpected value of %v: expected %v, got %v", g.path, g.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/flagbuilder"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
+)
+
+const (
+	// windowsKubernetesDir is the directory of the kubernetes binaries on Windows
+	windowsKubernetesDir = `C:\k`
+	// windowsKubeletService is the name of the kubelet Windows service
+	windowsKubeletService = "kubelet"
+)
+
+// WindowsKubeletBuilder installs the kubelet on Windows nodes
+type WindowsKubeletBuilder struct {
+	*NodeupModelContext
+}
+
+var _ fi.ModelBuilder = &WindowsKubeletBuilder{}
+
+// Build is responsible for installing and configuring the kubelet as a Windows service
+func (b *WindowsKubeletBuilder) Build(c *fi.ModelBuilderContext) error {
+	if !b.Distribution.IsWindows() {
+		return nil
+	}
+
+	kubeletConfig, err := b.buildKubeletConfig()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range []string{"kubelet.exe", "kubectl.exe"} {
+		asset, err := b.Assets.Find(name, "")
+		if err != nil {
+			return fmt.Errorf("error trying to locate asset %q: %v", name, err)
+		}
+		if asset == nil {
+			return fmt.Errorf("unable to locate asset %q", name)
+		}
+
+		c.AddTask(&nodetasks.File{
+			Path:           windowsKubernetesDir + `\` + name,
+			Contents:       asset,
+			Type:           nodetasks.FileType_File,
+			BeforeServices: []string{windowsKubeletService},
+		})
+	}
+
+	// The kops paths are relative to the system drive on Windows, e.g. C:\var\lib\kubelet
+	c.EnsureTask(&nodetasks.File{
+		Path: filepath.Dir(b.KubeletKubeConfig()),
+		Type: nodetasks.FileType_Directory,
+	})
+
+	if b.UseKopsControllerForNodeBootstrap() {
+		name := "kubelet-server"
+		cert, key, err := b.GetBootstrapCert(name, fi.CertificateIDCA)
+		if err != nil {
+			return err
+		}
+
+		c.AddTask(&nodetasks.File{
+			Path:           filepath.Join(b.PathSrvKubernetes(), name+".crt"),
+			Contents:       cert,
+			Type:           nodetasks.FileType_File,
+			BeforeServices: []string{windowsKubeletService},
+		})
+		c.AddTask(&nodetasks.File{
+			Path:           filepath.Join(b.PathSrvKubernetes(), name+".key"),
+			Contents:       key,
+			Type:           nodetasks.FileType_File,
+			BeforeServices: []string{windowsKubeletService},
+		})
+	}
+
+	if !b.UseBootstrapTokens() {
+		kubeconfig, err := b.BuildBootstrapKubeconfig("kubelet", c)
+		if err != nil {
+			return err
+		}
+
+		c.AddTask(&nodetasks.File{
+			Path:           b.KubeletKubeConfig(),
+			Contents:       kubeconfig,
+			Type:           nodetasks.FileType_File,
+			BeforeServices: []string{windowsKubeletService},
+		})
+	}
+
+	flags, err := flagbuilder.BuildFlags(kubeletConfig)
+	if err != nil {
+		return fmt.Errorf("error building kubelet flags: %v", err)
+	}
+	flags += " --windows-service"
+	flags += " --cgroups-per-qos=false"
+	flags += " --enforce-node-allocatable="
+	flags += " --container-runtime-endpoint=" + windowsContainerdEndpoint
+	flags += " --runtime-request-timeout=15m"
+	if b.IsKubernetesLT("1.24") {
+		flags += " --container-runtime=remote"
+	}
+	if b.Cluster.Spec.CloudConfig != nil {
+		flags += " --cloud-config=" + InTreeCloudConfigFilePath
+	}
+	if b.UseKopsControllerForNodeBootstrap() {
+		flags += " --tls-cert-file=" + filepath.Join(b.PathSrvKubernetes(), "kubelet-server.crt")
+		flags += " --tls-private-key-file=" + filepath.Join(b.PathSrvKubernetes(), "kubelet-server.key")
+	}
+	flags += " --logtostderr=false"
+	flags += " --log-file=" + windowsLogDir + `\kubelet.log`
+
+	c.AddTask(&nodetasks.WindowsService{
+		Name:         windowsKubeletService,
+		DisplayName:  "Kubernetes kubelet",
+		BinaryPath:   windowsKubernetesDir + `\kubelet.exe ` + flags,
+		Dependencies: []string{windowsContainerdService},
+	})
+
+	return nil
+}
+
+// buildKubeletConfig builds the kubelet config of the Linux nodes, without the settings that Windows doesn't support
+func (b *WindowsKubeletBuilder) buildKubeletConfig() (*kops.KubeletConfigSpec, error) {
+	kubeletConfig, err := (&KubeletBuilder{NodeupModelContext: b.NodeupModelContext}).buildKubeletConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	// Windows has no cgroups
+	kubeletConfig.CgroupDriver = ""
+	kubeletConfig.CgroupRoot = ""
+	kubeletConfig.KubeletCgroups = ""
+	kubeletConfig.RuntimeCgroups = ""
+	kubeletConfig.SystemCgroups = ""
+	kubeletConfig.KubeReservedCgroup = ""
+	kubeletConfig.SystemReservedCgroup = ""
+	kubeletConfig.EnforceNodeAllocatable = ""
+
+	// The Linux paths and eviction signals don't apply to Windows
+	kubeletConfig.PodManifestPath = ""
+	kubeletConfig.VolumePluginDirectory = ""
+	kubeletConfig.ResolverConfig = fi.String("")
+	kubeletConfig.EvictionHard = nil
+	kubeletConfig.ShutdownGracePeriod = nil
+	kubeletConfig.ShutdownGracePeriodCriticalPods = nil
+	kubeletConfig.ProtectKernelDefaults = nil
+
+	return kubeletConfig, nil
+}
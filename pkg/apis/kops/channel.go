@@ -292,6 +292,9 @@ const (
 	CloudProviderHetzner   CloudProviderID = "hetzner"
 	CloudProviderOpenstack CloudProviderID = "openstack"
 	CloudProviderAzure     CloudProviderID = "azure"
+	// CloudProviderMetal is used by nodeup on machines of metal instance groups, which run outside of the cloud.
+	// It is never the cloud provider of a cluster.
+	CloudProviderMetal CloudProviderID = "metal"
 )
 
 // FindImage returns the image for the cloudprovider, or nil if none found
@@ -200,3 +200,55 @@ func assertResolvesValue(t *testing.T, name string, expected interface{}, warmPo
 		return assert.Equal(t, expected, value.Interface(), msg)
 	}
 }
+
+func TestInstanceMetadataOptions_ResolveDefaults(t *testing.T) {
+	required := "required"
+	optional := "optional"
+	for _, tc := range []struct {
+		name     string
+		cluster  *InstanceMetadataOptions
+		ig       *InstanceMetadataOptions
+		expected *InstanceMetadataOptions
+	}{
+		{
+			name: "nil nil",
+		},
+		{
+			name:     "cluster only",
+			cluster:  &InstanceMetadataOptions{HTTPTokens: &required, HTTPPutResponseHopLimit: int64ptr(1)},
+			expected: &InstanceMetadataOptions{HTTPTokens: &required, HTTPPutResponseHopLimit: int64ptr(1)},
+		},
+		{
+			name:     "instancegroup only",
+			ig:       &InstanceMetadataOptions{HTTPTokens: &optional},
+			expected: &InstanceMetadataOptions{HTTPTokens: &optional},
+		},
+		{
+			name:     "instancegroup overrides tokens",
+			cluster:  &InstanceMetadataOptions{HTTPTokens: &required, HTTPPutResponseHopLimit: int64ptr(1)},
+			ig:       &InstanceMetadataOptions{HTTPTokens: &optional},
+			expected: &InstanceMetadataOptions{HTTPTokens: &optional, HTTPPutResponseHopLimit: int64ptr(1)},
+		},
+		{
+			name:     "instancegroup overrides hop limit",
+			cluster:  &InstanceMetadataOptions{HTTPTokens: &required, HTTPPutResponseHopLimit: int64ptr(1)},
+			ig:       &InstanceMetadataOptions{HTTPPutResponseHopLimit: int64ptr(3)},
+			expected: &InstanceMetadataOptions{HTTPTokens: &required, HTTPPutResponseHopLimit: int64ptr(3)},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ig := &InstanceGroup{
+				Spec: InstanceGroupSpec{
+					InstanceMetadata: tc.ig.DeepCopy(),
+				},
+			}
+			clusterCopy := tc.cluster.DeepCopy()
+
+			resolved := tc.cluster.ResolveDefaults(ig)
+
+			assert.Equal(t, tc.expected, resolved)
+			assert.Equal(t, clusterCopy, tc.cluster, "cluster not modified")
+			assert.Equal(t, tc.ig, ig.Spec.InstanceMetadata, "instancegroup not modified")
+		})
+	}
+}
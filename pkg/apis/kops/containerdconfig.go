@@ -31,6 +31,11 @@ type ContainerdConfig struct {
 	Packages *PackagesConfig `json:"packages,omitempty"`
 	// RegistryMirrors is list of image registries
 	RegistryMirrors map[string][]string `json:"registryMirrors,omitempty"`
+	// Registries configures the mirrors containerd pulls the images of a registry from, keyed by registry name.
+	// The "*" key configures the mirrors of all registries. Requires containerd 1.5 or later.
+	Registries map[string]ContainerdRegistryConfig `json:"registries,omitempty"`
+	// ConfigAdditions is a TOML fragment that is deep-merged into the containerd config file built by kOps.
+	ConfigAdditions *string `json:"configAdditions,omitempty"`
 	// Root directory for persistent data (default "/var/lib/containerd").
 	Root *string `json:"root,omitempty" flag:"root"`
 	// SkipInstall prevents kOps from installing and modifying containerd in any way (default "false").
@@ -41,6 +46,41 @@ type ContainerdConfig struct {
 	Version *string `json:"version,omitempty"`
 	// NvidiaGPU configures the Nvidia GPU runtime.
 	NvidiaGPU *NvidiaGPUConfig `json:"nvidiaGPU,omitempty"`
+	// Runc configures the runc runtime used by containerd 1.6 or later.
+	Runc *Runc `json:"runc,omitempty"`
+}
+
+// Runc configures the runc binary that is installed alongside containerd.
+type Runc struct {
+	// Version used to pick the runc package.
+	Version *string `json:"version,omitempty"`
+	// Packages overrides the URL and hash for the packages.
+	Packages *PackagesConfig `json:"packages,omitempty"`
+}
+
+// ContainerdRegistryConfig configures how containerd pulls the images of a registry.
+type ContainerdRegistryConfig struct {
+	// Mirrors are tried in order before the registry itself.
+	Mirrors []ContainerdRegistryMirror `json:"mirrors,omitempty"`
+}
+
+// ContainerdRegistryMirror is a registry mirror for containerd.
+type ContainerdRegistryMirror struct {
+	// Endpoint is the URL of the mirror, such as "https://mirror.example.com".
+	Endpoint string `json:"endpoint"`
+	// SkipVerify disables the verification of the TLS certificate of the mirror.
+	SkipVerify bool `json:"skipVerify,omitempty"`
+	// Auth is the credentials used to authenticate to the mirror.
+	Auth *ContainerdRegistryAuth `json:"auth,omitempty"`
+}
+
+// ContainerdRegistryAuth is the basic authentication credentials for a registry mirror.
+type ContainerdRegistryAuth struct {
+	// Username is the name of the user to authenticate as.
+	Username string `json:"username"`
+	// PasswordSecret is the name of the kOps secret holding the password of the user.
+	// The secret is created with "kops create secret registrypassword".
+	PasswordSecret string `json:"passwordSecret"`
 }
 
 type NvidiaGPUConfig struct {
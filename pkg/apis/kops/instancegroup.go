@@ -17,6 +17,8 @@ limitations under the License.
 package kops
 
 import (
+	"strings"
+
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -88,6 +90,12 @@ type InstanceManager string
 const (
 	InstanceManagerCloudGroup InstanceManager = "CloudGroup"
 	InstanceManagerKarpenter  InstanceManager = "Karpenter"
+	// InstanceManagerExternal is set when the instances are launched by a provisioner outside of kOps.
+	// kOps renders the launch template and nodeup configuration, but doesn't create an autoscaling group.
+	InstanceManagerExternal InstanceManager = "External"
+	// InstanceManagerMetal is set for instance groups of existing machines outside of the cloud, enrolled with "kops toolbox enroll".
+	// kOps renders the nodeup configuration and bootstrap script, but doesn't launch any instances.
+	InstanceManagerMetal InstanceManager = "Metal"
 )
 
 // InstanceGroupSpec is the specification for an InstanceGroup
@@ -147,8 +155,14 @@ type InstanceGroupSpec struct {
 	NodeLabels map[string]string `json:"nodeLabels,omitempty"`
 	// FileAssets is a collection of file assets for this instance group
 	FileAssets []FileAssetSpec `json:"fileAssets,omitempty"`
-	// Describes the tenancy of this instance group. Can be either default or dedicated. Currently only applies to AWS.
+	// Describes the tenancy of this instance group. Can be default, dedicated or host. Currently only applies to AWS.
 	Tenancy string `json:"tenancy,omitempty"`
+	// HostResourceGroupARN is the ARN of the host resource group in which the instances are launched.
+	// Requires the host tenancy. Currently only applies to AWS.
+	HostResourceGroupARN *string `json:"hostResourceGroupARN,omitempty"`
+	// HostAffinity is the affinity of the instances to a Dedicated Host: "host" restarts a stopped instance on the same host,
+	// "default" on any available host. Requires the host tenancy. Currently only applies to AWS.
+	HostAffinity *string `json:"hostAffinity,omitempty"`
 	// Kubelet overrides kubelet config from the ClusterSpec
 	Kubelet *KubeletConfigSpec `json:"kubelet,omitempty"`
 	// Taints indicates the kubernetes taints for nodes in this instance group
@@ -157,6 +171,12 @@ type InstanceGroupSpec struct {
 	MixedInstancesPolicy *MixedInstancesPolicySpec `json:"mixedInstancesPolicy,omitempty"`
 	// AdditionalUserData is any additional user-data to be passed to the host
 	AdditionalUserData []UserData `json:"additionalUserData,omitempty"`
+	// CloudInit configures the instances with cloud-init multipart user-data, merging the cloud-config of the instance group
+	// with the one built by kOps.
+	CloudInit *CloudInitSpec `json:"cloudInit,omitempty"`
+	// Ignition renders the user-data as an Ignition config, rather than a cloud-init script, for Flatcar Container Linux.
+	// Defaults to true for images whose name contains "flatcar".
+	Ignition *bool `json:"ignition,omitempty"`
 	// SuspendProcesses disables the listed Scaling Policies
 	SuspendProcesses []string `json:"suspendProcesses,omitempty"`
 	// ExternalLoadBalancers define loadbalancers that should be attached to this instance group
@@ -196,6 +216,22 @@ type InstanceGroupSpec struct {
 	Packages []string `json:"packages,omitempty"`
 	// GuestAccelerators configures additional accelerators
 	GuestAccelerators []AcceleratorConfig `json:"guestAccelerators,omitempty"`
+	// Monitoring overrides which of the cluster's monitoring agents run on this instance group.
+	Monitoring *InstanceGroupMonitoringSpec `json:"monitoring,omitempty"`
+	// EFA attaches Elastic Fabric Adapter network interfaces to the instances (AWS only).
+	EFA *EFASpec `json:"efa,omitempty"`
+	// NitroEnclave enables AWS Nitro Enclaves on the instances (AWS only).
+	// The machine type must support Nitro Enclaves.
+	NitroEnclave *bool `json:"nitroEnclave,omitempty"`
+	// ElasticInferenceAccelerators attaches Elastic Inference accelerators to the instances (AWS only).
+	ElasticInferenceAccelerators []AcceleratorConfig `json:"elasticInferenceAccelerators,omitempty"`
+	// AssetCache configures a cache of the files downloaded by nodeup, shared by the instances of the group.
+	AssetCache *AssetCacheSpec `json:"assetCache,omitempty"`
+	// PreloadImages are container images that nodeup pulls at boot, before the kubelet is started.
+	// This reduces the time until pods of critical DaemonSets or common base images can start on new instances.
+	PreloadImages []string `json:"preloadImages,omitempty"`
+	// NodeUp overrides the nodeup binaries used by the instance group, e.g. to test a new nodeup build on one group.
+	NodeUp *PackagesConfig `json:"nodeup,omitempty"`
 }
 
 const (
@@ -227,6 +263,27 @@ type InstanceMetadataOptions struct {
 	HTTPTokens *string `json:"httpTokens,omitempty"`
 }
 
+// ResolveDefaults returns the instance metadata options of the instance group,
+// taking any options it does not set from the cluster-wide defaults.
+func (in *InstanceMetadataOptions) ResolveDefaults(ig *InstanceGroup) *InstanceMetadataOptions {
+	igInstanceMetadata := ig.Spec.InstanceMetadata
+	if in == nil {
+		return igInstanceMetadata
+	}
+	if igInstanceMetadata == nil {
+		return in
+	}
+
+	spec := *igInstanceMetadata
+	if spec.HTTPPutResponseHopLimit == nil {
+		spec.HTTPPutResponseHopLimit = in.HTTPPutResponseHopLimit
+	}
+	if spec.HTTPTokens == nil {
+		spec.HTTPTokens = in.HTTPTokens
+	}
+	return &spec
+}
+
 // MixedInstancesPolicySpec defines the specification for an autoscaling group backed by a ec2 fleet
 type MixedInstancesPolicySpec struct {
 	// Instances is a list of instance types which we are willing to run in the EC2 fleet
@@ -250,6 +307,22 @@ type MixedInstancesPolicySpec struct {
 	// SpotInstancePools is the number of Spot pools to use to allocate your Spot capacity (defaults to 2)
 	// pools are determined from the different instance types in the Overrides array of LaunchTemplate
 	SpotInstancePools *int64 `json:"spotInstancePools,omitempty"`
+	// SpotFallback configures kops-controller to temporarily switch the instance group to On-Demand
+	// instances when Spot capacity is unavailable.
+	SpotFallback *SpotFallbackSpec `json:"spotFallback,omitempty"`
+}
+
+// SpotFallbackSpec configures the automated fallback from Spot to On-Demand capacity.
+type SpotFallbackSpec struct {
+	// Enabled turns on the fallback to On-Demand instances.
+	Enabled *bool `json:"enabled,omitempty"`
+	// FailureThreshold is the number of failed Spot launches within FailureWindow
+	// that triggers the fallback. (default 3)
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+	// FailureWindow is the period in which failed Spot launches are counted. (default 15m)
+	FailureWindow *metav1.Duration `json:"failureWindow,omitempty"`
+	// RevertAfter is how long the instance group runs On-Demand before Spot is tried again. (default 1h)
+	RevertAfter *metav1.Duration `json:"revertAfter,omitempty"`
 }
 
 // InstanceRequirementsSpec is a list of requirements for any instance type we are willing to run in the EC2 fleet.
@@ -273,6 +346,13 @@ type UserData struct {
 	Content string `json:"content,omitempty"`
 }
 
+// CloudInitSpec configures the cloud-init user-data of an instance group.
+type CloudInitSpec struct {
+	// CloudConfig is a cloud-config document that is merged into the cloud-config part of the user-data.
+	// Lists are appended to the ones set by kOps and maps are merged recursively.
+	CloudConfig string `json:"cloudConfig,omitempty"`
+}
+
 // VolumeSpec defined the spec for an additional volume attached to the instance group
 type VolumeSpec struct {
 	// DeleteOnTermination configures volume retention policy upon instance termination.
@@ -302,17 +382,18 @@ type VolumeMountSpec struct {
 	Filesystem string `json:"filesystem,omitempty"`
 	// FormatOptions is a collection of options passed when formatting the device
 	FormatOptions []string `json:"formatOptions,omitempty"`
-	// MountOptions is a collection of mount options - @TODO need to be added
+	// MountOptions is a collection of mount options
 	MountOptions []string `json:"mountOptions,omitempty"`
 	// Path is the location to mount the device
 	Path string `json:"path,omitempty"`
 }
 
-// IAMProfileSpec is the AWS IAM Profile to attach to instances in this instance
-// group. Specify the ARN for the IAM instance profile (AWS only).
+// IAMProfileSpec is the existing cloud identity to attach to instances in this instance
+// group, instead of the one kOps would otherwise create.
 type IAMProfileSpec struct {
-	// Profile is the AWS IAM Profile to attach to instances in this instance group.
-	// Specify the ARN for the IAM instance profile. (AWS only)
+	// Profile is the existing cloud identity to attach to instances in this instance group.
+	// On AWS this is the ARN of an IAM instance profile, on GCE the email of a service account
+	// and on Azure the resource ID of a user-assigned managed identity.
 	Profile *string `json:"profile,omitempty"`
 }
 
@@ -351,6 +432,19 @@ func (g *InstanceGroup) IsBastion() bool {
 	}
 }
 
+// IsWindows checks if instanceGroup runs Windows, based on the name of its image
+func (g *InstanceGroup) IsWindows() bool {
+	return strings.Contains(strings.ToLower(g.Spec.Image), "windows")
+}
+
+// UsesIgnition checks if instanceGroup is provisioned with an Ignition config, as set in its spec or based on the name of its image
+func (g *InstanceGroup) UsesIgnition() bool {
+	if g.Spec.Ignition != nil {
+		return *g.Spec.Ignition
+	}
+	return strings.Contains(strings.ToLower(g.Spec.Image), "flatcar")
+}
+
 func (g *InstanceGroup) AddInstanceGroupNodeLabel() {
 	if g.Spec.NodeLabels == nil {
 		g.Spec.NodeLabels = make(map[string]string)
@@ -371,3 +465,35 @@ type AcceleratorConfig struct {
 	AcceleratorCount int64  `json:"acceleratorCount,omitempty"`
 	AcceleratorType  string `json:"acceleratorType,omitempty"`
 }
+
+// InstanceGroupMonitoringSpec controls which monitoring agents run on the instances of an instance group.
+type InstanceGroupMonitoringSpec struct {
+	// NodeExporter runs node-exporter on this instance group.
+	// Defaults to the value of spec.monitoring.nodeExporter.enabled in the Cluster.
+	NodeExporter *bool `json:"nodeExporter,omitempty"`
+	// CloudWatchAgent runs the CloudWatch agent on this instance group (AWS only).
+	// Defaults to the value of spec.monitoring.cloudWatchAgent.enabled in the Cluster.
+	CloudWatchAgent *bool `json:"cloudWatchAgent,omitempty"`
+}
+
+// EFASpec configures Elastic Fabric Adapter network interfaces, for HPC and machine learning workloads.
+type EFASpec struct {
+	// Enabled makes the primary network interface an EFA, and attaches an EFA to each additional network card.
+	// The machine type must support EFA.
+	Enabled *bool `json:"enabled,omitempty"`
+	// NetworkCards is the number of network cards that get an EFA.
+	// Defaults to all network cards of the machine type, e.g. 4 for p4d.24xlarge.
+	NetworkCards *int32 `json:"networkCards,omitempty"`
+}
+
+// AssetCacheSpec configures where nodeup looks for files such as the kubelet and containerd before downloading them.
+// Files are looked up by their SHA-256 hash, and are only used when their contents match the hash.
+type AssetCacheSpec struct {
+	// Path is a directory on a volume shared by the instances of the group, e.g. an NFS or EFS mount.
+	// Nodeup copies files from it, and stores the files it downloads in it.
+	// The volume must be mounted before nodeup runs, e.g. by additionalUserData.
+	Path *string `json:"path,omitempty"`
+	// URL is the base URL of a read-only cache, e.g. an S3 transfer acceleration endpoint.
+	// Nodeup tries <URL>/<sha256>/<filename> before the usual locations of a file.
+	URL *string `json:"url,omitempty"`
+}
@@ -64,6 +64,10 @@ type KeysetItem struct {
 
 	// PrivateMaterial holds secret material (e.g. a private key, or symmetric token)
 	PrivateMaterial []byte `json:"privateMaterial,omitempty"`
+
+	// ExternalCertificateID identifies the certificate in the certificate authority service that issued it,
+	// e.g. the ARN of a certificate issued by ACM Private CA. It is not set for certificates issued by kOps.
+	ExternalCertificateID string `json:"externalCertificateID,omitempty"`
 }
 
 // KeysetSpec is the spec for a Keyset
@@ -76,4 +80,29 @@ type KeysetSpec struct {
 
 	// Keys is the set of keys that make up the keyset
 	Keys []KeysetItem `json:"keys,omitempty"`
+
+	// Rotation tracks the rotation of the keyset by "kops rotate ca", while it is in progress.
+	Rotation *KeysetRotation `json:"rotation,omitempty"`
+}
+
+// KeysetRotationPhase is the phase of the rotation of a keyset
+type KeysetRotationPhase string
+
+const (
+	// KeysetRotationPhaseStaged is the phase in which the new keypair is trusted, but not yet primary
+	KeysetRotationPhaseStaged KeysetRotationPhase = "Staged"
+	// KeysetRotationPhasePromoted is the phase in which the new keypair is primary, and the previous keypair still trusted
+	KeysetRotationPhasePromoted KeysetRotationPhase = "Promoted"
+	// KeysetRotationPhaseCompleted is the phase in which the previous keypair is distrusted
+	KeysetRotationPhaseCompleted KeysetRotationPhase = "Completed"
+)
+
+// KeysetRotation is the state of the rotation of a keyset
+type KeysetRotation struct {
+	// Phase is the phase of the rotation that was last completed
+	Phase KeysetRotationPhase `json:"phase,omitempty"`
+	// NewID is the id of the keypair that is replacing the previous primary keypair
+	NewID string `json:"newID,omitempty"`
+	// PreviousID is the id of the primary keypair when the rotation was started
+	PreviousID string `json:"previousID,omitempty"`
 }
@@ -23,6 +23,15 @@ const (
 	// AnnotationValueManagementImported is the annotation value that indicates a cluster was imported, typically as part of an upgrade
 	AnnotationValueManagementImported = "imported"
 
+	// AnnotationNameStopped is the annotation that records when a cluster was stopped by "kops stop cluster"
+	AnnotationNameStopped = "kops.kubernetes.io/stopped"
+
+	// AnnotationNameStoppedMinSize is the annotation that records the minSize of an instance group before its cluster was stopped
+	AnnotationNameStoppedMinSize = "kops.kubernetes.io/stopped-min-size"
+
+	// AnnotationNameStoppedMaxSize is the annotation that records the maxSize of an instance group before its cluster was stopped
+	AnnotationNameStoppedMaxSize = "kops.kubernetes.io/stopped-max-size"
+
 	// UpdatePolicyAutomatic is a value for ClusterSpec.UpdatePolicy and InstanceGroup.UpdatePolicy indicating that upgrades are performed automatically
 	UpdatePolicyAutomatic = "automatic"
 
@@ -18,6 +18,7 @@ package model
 
 import (
 	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/wellknownports"
 )
 
 // UseKopsControllerForNodeBootstrap is true if nodeup should use kops-controller for bootstrapping.
@@ -46,3 +47,51 @@ func UseCiliumEtcd(cluster *kops.Cluster) bool {
 
 	return false
 }
+
+// KubeAPIServerHealthcheckPort is the port on which the kube-apiserver-healthcheck sidecar listens.
+func KubeAPIServerHealthcheckPort(cluster *kops.Cluster) int {
+	if apiserver := cluster.Spec.KubeAPIServer; apiserver != nil && apiserver.HealthcheckSidecar != nil && apiserver.HealthcheckSidecar.Port != nil {
+		return int(*apiserver.HealthcheckSidecar.Port)
+	}
+	return wellknownports.KubeAPIServerHealthCheck
+}
+
+// KubeAPIServerHealthcheckPath is the path that load balancers should query through the kube-apiserver-healthcheck sidecar,
+// or "" if load balancer health checks should only check TCP connectivity to the apiserver.
+func KubeAPIServerHealthcheckPath(cluster *kops.Cluster) string {
+	if apiserver := cluster.Spec.KubeAPIServer; apiserver != nil && apiserver.HealthcheckSidecar != nil && apiserver.HealthcheckSidecar.Path != nil {
+		return *apiserver.HealthcheckSidecar.Path
+	}
+	return ""
+}
+
+// NodeExporterEnabled is true if node-exporter should run on the instances of the instance group.
+func NodeExporterEnabled(cluster *kops.Cluster, ig *kops.InstanceGroup) bool {
+	if ig.Spec.Monitoring != nil && ig.Spec.Monitoring.NodeExporter != nil {
+		return *ig.Spec.Monitoring.NodeExporter
+	}
+	monitoring := cluster.Spec.Monitoring
+	return monitoring != nil && monitoring.NodeExporter != nil && monitoring.NodeExporter.Enabled != nil && *monitoring.NodeExporter.Enabled
+}
+
+// CloudWatchAgentEnabled is true if the CloudWatch agent should run on the instances of the instance group.
+func CloudWatchAgentEnabled(cluster *kops.Cluster, ig *kops.InstanceGroup) bool {
+	if cluster.Spec.GetCloudProvider() != kops.CloudProviderAWS {
+		return false
+	}
+	if ig.Spec.Monitoring != nil && ig.Spec.Monitoring.CloudWatchAgent != nil {
+		return *ig.Spec.Monitoring.CloudWatchAgent
+	}
+	monitoring := cluster.Spec.Monitoring
+	return monitoring != nil && monitoring.CloudWatchAgent != nil && monitoring.CloudWatchAgent.Enabled != nil && *monitoring.CloudWatchAgent.Enabled
+}
+
+// SpotFallbackEnabled is true if kops-controller should switch the instance group to On-Demand instances
+// when Spot capacity is unavailable.
+func SpotFallbackEnabled(cluster *kops.Cluster, ig *kops.InstanceGroup) bool {
+	if cluster.Spec.GetCloudProvider() != kops.CloudProviderAWS || ig.Spec.Manager == kops.InstanceManagerKarpenter {
+		return false
+	}
+	policy := ig.Spec.MixedInstancesPolicy
+	return policy != nil && policy.SpotFallback != nil && policy.SpotFallback.Enabled != nil && *policy.SpotFallback.Enabled
+}
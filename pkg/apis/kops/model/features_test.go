@@ -81,3 +81,77 @@ func TestUseCiliumEtcd(t *testing.T) {
 		}
 	}
 }
+
+func TestMonitoringAgentsEnabled(t *testing.T) {
+	enabled := true
+	disabled := false
+	for _, tc := range []struct {
+		name                    string
+		cloudProvider           kops.CloudProviderSpec
+		monitoring              *kops.MonitoringSpec
+		igMonitoring            *kops.InstanceGroupMonitoringSpec
+		expectedNodeExporter    bool
+		expectedCloudWatchAgent bool
+	}{
+		{
+			name:          "not configured",
+			cloudProvider: kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+		},
+		{
+			name:          "enabled in cluster",
+			cloudProvider: kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			monitoring: &kops.MonitoringSpec{
+				NodeExporter:    &kops.MonitoringAgentConfig{Enabled: &enabled},
+				CloudWatchAgent: &kops.MonitoringAgentConfig{Enabled: &enabled},
+			},
+			expectedNodeExporter:    true,
+			expectedCloudWatchAgent: true,
+		},
+		{
+			name:          "disabled for instance group",
+			cloudProvider: kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			monitoring: &kops.MonitoringSpec{
+				NodeExporter:    &kops.MonitoringAgentConfig{Enabled: &enabled},
+				CloudWatchAgent: &kops.MonitoringAgentConfig{Enabled: &enabled},
+			},
+			igMonitoring: &kops.InstanceGroupMonitoringSpec{
+				NodeExporter:    &disabled,
+				CloudWatchAgent: &disabled,
+			},
+		},
+		{
+			name:          "enabled for instance group only",
+			cloudProvider: kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			igMonitoring: &kops.InstanceGroupMonitoringSpec{
+				NodeExporter:    &enabled,
+				CloudWatchAgent: &enabled,
+			},
+			expectedNodeExporter:    true,
+			expectedCloudWatchAgent: true,
+		},
+		{
+			name:          "cloudwatch agent requires aws",
+			cloudProvider: kops.CloudProviderSpec{GCE: &kops.GCESpec{}},
+			monitoring: &kops.MonitoringSpec{
+				NodeExporter:    &kops.MonitoringAgentConfig{Enabled: &enabled},
+				CloudWatchAgent: &kops.MonitoringAgentConfig{Enabled: &enabled},
+			},
+			expectedNodeExporter: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := &kops.Cluster{}
+			cluster.Spec.CloudProvider = tc.cloudProvider
+			cluster.Spec.Monitoring = tc.monitoring
+			ig := &kops.InstanceGroup{}
+			ig.Spec.Monitoring = tc.igMonitoring
+
+			if actual := NodeExporterEnabled(cluster, ig); actual != tc.expectedNodeExporter {
+				t.Errorf("expected NodeExporterEnabled %v, got %v", tc.expectedNodeExporter, actual)
+			}
+			if actual := CloudWatchAgentEnabled(cluster, ig); actual != tc.expectedCloudWatchAgent {
+				t.Errorf("expected CloudWatchAgentEnabled %v, got %v", tc.expectedCloudWatchAgent, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+// specHashLength is the number of hex characters kept of the spec hashes; enough to tell revisions apart.
+const specHashLength = 16
+
+// ClusterSpecHash returns a hash of the cluster spec, as stored in the state store.
+func ClusterSpecHash(cluster *kops.Cluster) (string, error) {
+	return specHash(cluster.Spec)
+}
+
+// InstanceGroupSpecHash returns a hash of the cluster spec and the spec of the instance group, as stored in the state store.
+// Changes to either can change the resources of the instance group.
+func InstanceGroupSpecHash(cluster *kops.Cluster, ig *kops.InstanceGroup) (string, error) {
+	return specHash([]interface{}{cluster.Spec, ig.Spec})
+}
+
+func specHash(spec interface{}) (string, error) {
+	b, err := json.Marshal(spec)
+	if err != nil {
+		return "", fmt.Errorf("error serializing spec: %w", err)
+	}
+	hash := sha256.Sum256(b)
+	return hex.EncodeToString(hash[:])[:specHashLength], nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+func TestSpecHash(t *testing.T) {
+	cluster := &kops.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "minimal.example.com"},
+		Spec:       kops.ClusterSpec{KubernetesVersion: "1.24.0"},
+	}
+	ig := &kops.InstanceGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "nodes"},
+		Spec:       kops.InstanceGroupSpec{MachineType: "t3.medium"},
+	}
+
+	clusterHash, err := ClusterSpecHash(cluster)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	igHash, err := InstanceGroupSpecHash(cluster, ig)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(clusterHash) != specHashLength || len(igHash) != specHashLength {
+		t.Fatalf("unexpected hash lengths: %q, %q", clusterHash, igHash)
+	}
+
+	// Metadata doesn't change the hashes
+	cluster.ObjectMeta.Generation++
+	ig.ObjectMeta.Labels = map[string]string{"foo": "bar"}
+	if h, _ := ClusterSpecHash(cluster); h != clusterHash {
+		t.Errorf("cluster hash changed with metadata: %q != %q", h, clusterHash)
+	}
+	if h, _ := InstanceGroupSpecHash(cluster, ig); h != igHash {
+		t.Errorf("instance group hash changed with metadata: %q != %q", h, igHash)
+	}
+
+	// The instance group hash changes with the instance group spec, but the cluster hash doesn't
+	ig.Spec.MachineType = "t3.large"
+	if h, _ := ClusterSpecHash(cluster); h != clusterHash {
+		t.Errorf("cluster hash changed with instance group spec: %q != %q", h, clusterHash)
+	}
+	if h, _ := InstanceGroupSpecHash(cluster, ig); h == igHash {
+		t.Errorf("instance group hash didn't change with instance group spec")
+	}
+
+	// Both change with the cluster spec
+	ig.Spec.MachineType = "t3.medium"
+	cluster.Spec.KubernetesVersion = "1.24.1"
+	if h, _ := ClusterSpecHash(cluster); h == clusterHash {
+		t.Errorf("cluster hash didn't change with cluster spec")
+	}
+	if h, _ := InstanceGroupSpecHash(cluster, ig); h == igHash {
+		t.Errorf("instance group hash didn't change with cluster spec")
+	}
+}
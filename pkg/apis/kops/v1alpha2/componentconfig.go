@@ -232,6 +232,10 @@ type KubeletConfigSpec struct {
 	// ShutdownGracePeriodCriticalPods specifies the duration used to terminate critical pods during a node shutdown.
 	// Default: 10s
 	ShutdownGracePeriodCriticalPods *metav1.Duration `json:"shutdownGracePeriodCriticalPods,omitempty"`
+	// ImageCredentialProviders installs the kubelet credential provider for the container registry of the cloud provider:
+	// ecr-credential-provider on AWS, auth-provider-gcp on GCE and acr-credential-provider on Azure.
+	// The kubelet then uses it instead of its deprecated built-in registry credentials.
+	ImageCredentialProviders *bool `json:"imageCredentialProviders,omitempty"`
 }
 
 // KubeProxyConfig defines the configuration for a proxy
@@ -317,6 +321,9 @@ type KubeAPIServerConfig struct {
 	DisableAdmissionPlugins []string `json:"disableAdmissionPlugins,omitempty" flag:"disable-admission-plugins"`
 	// AdmissionControlConfigFile is the location of the admission-control-config-file
 	AdmissionControlConfigFile string `json:"admissionControlConfigFile,omitempty" flag:"admission-control-config-file"`
+	// AdmissionPluginConfig configures the admission plugins that read a configuration file.
+	// kOps writes the admission configuration and sets admissionControlConfigFile.
+	AdmissionPluginConfig *AdmissionPluginConfigSpec `json:"admissionPluginConfig,omitempty"`
 	// ServiceClusterIPRange is the service address range
 	ServiceClusterIPRange string `json:"serviceClusterIPRange,omitempty" flag:"service-cluster-ip-range"`
 	// Passed as --service-node-port-range to kube-apiserver. Expects 'startPort-endPort' format e.g. 30000-33000
@@ -460,6 +467,11 @@ type KubeAPIServerConfig struct {
 	MaxRequestsInflight int32 `json:"maxRequestsInflight,omitempty" flag:"max-requests-inflight" flag-empty:"0"`
 	// MaxMutatingRequestsInflight The maximum number of mutating requests in flight at a given time. Defaults to 200
 	MaxMutatingRequestsInflight int32 `json:"maxMutatingRequestsInflight,omitempty" flag:"max-mutating-requests-inflight" flag-empty:"0"`
+	// EnablePriorityAndFairness enables API Priority and Fairness, which divides the inflight limits between priority levels.
+	EnablePriorityAndFairness *bool `json:"enablePriorityAndFairness,omitempty" flag:"enable-priority-and-fairness"`
+	// FlowSchemaPresets is a list of API Priority and Fairness presets to install: protect-system-nodes, protect-kube-system
+	// and/or limit-service-accounts. kOps creates the FlowSchemas and PriorityLevelConfigurations for each preset.
+	FlowSchemaPresets []string `json:"flowSchemaPresets,omitempty"`
 
 	// HTTP2MaxStreamsPerConnection sets the limit that the server gives to clients for the maximum number of streams in an HTTP/2 connection. Zero means to use golang's default.
 	HTTP2MaxStreamsPerConnection *int32 `json:"http2MaxStreamsPerConnection,omitempty" flag:"http2-max-streams-per-connection"`
@@ -525,6 +537,82 @@ type KubeAPIServerConfig struct {
 	DefaultNotReadyTolerationSeconds *int64 `json:"defaultNotReadyTolerationSeconds,omitempty" flag:"default-not-ready-toleration-seconds"`
 	// DefaultUnreachableTolerationSeconds
 	DefaultUnreachableTolerationSeconds *int64 `json:"defaultUnreachableTolerationSeconds,omitempty" flag:"default-unreachable-toleration-seconds"`
+
+	// HealthcheckSidecar configures the kube-apiserver-healthcheck sidecar, which load balancers use to check apiserver health.
+	HealthcheckSidecar *KubeAPIServerHealthcheckSidecarConfig `json:"healthcheckSidecar,omitempty"`
+	// LivenessProbe overrides the settings of the liveness probe of the kube-apiserver container.
+	LivenessProbe *ProbeSpec `json:"livenessProbe,omitempty"`
+	// ReadinessProbe adds a readiness probe to the kube-apiserver container, checking /readyz.
+	ReadinessProbe *ProbeSpec `json:"readinessProbe,omitempty"`
+	// StartupProbe adds a startup probe to the kube-apiserver container, which holds off the liveness probe until it succeeds.
+	// This gives a slow starting kube-apiserver, such as one waiting for etcd to restore, time to start without being restarted.
+	StartupProbe *ProbeSpec `json:"startupProbe,omitempty"`
+}
+
+// KubeAPIServerHealthcheckSidecarConfig configures the kube-apiserver-healthcheck sidecar
+type KubeAPIServerHealthcheckSidecarConfig struct {
+	// Port is the port on which the sidecar listens. Defaults to 3990.
+	Port *int32 `json:"port,omitempty"`
+	// Path is the apiserver health endpoint (/healthz, /livez or /readyz) that load balancers query through the sidecar.
+	// When set, load balancer health checks use HTTP against the sidecar instead of TCP connectivity to the apiserver.
+	Path *string `json:"path,omitempty"`
+	// AdditionalChecks are individual apiserver health checks, such as "etcd", that must also pass
+	// before the sidecar reports Path as healthy.
+	AdditionalChecks []string `json:"additionalChecks,omitempty"`
+}
+
+// ProbeSpec configures a health probe of a control plane static pod.
+// Unset fields keep the kOps default for the probe, or the Kubernetes default for probes kOps doesn't set up.
+type ProbeSpec struct {
+	// InitialDelaySeconds is the number of seconds after the container has started before the probe is initiated.
+	InitialDelaySeconds *int32 `json:"initialDelaySeconds,omitempty"`
+	// TimeoutSeconds is the number of seconds after which the probe times out.
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+	// PeriodSeconds is how often, in seconds, the probe is performed.
+	PeriodSeconds *int32 `json:"periodSeconds,omitempty"`
+	// FailureThreshold is the number of consecutive failures after which the probe is considered failed.
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+}
+
+// AdmissionPluginConfigSpec configures the admission plugins that read a configuration file.
+// Configured plugins are added to the enabled admission plugins.
+type AdmissionPluginConfigSpec struct {
+	// EventRateLimit configures the EventRateLimit admission plugin.
+	EventRateLimit *EventRateLimitAdmissionConfig `json:"eventRateLimit,omitempty"`
+	// ImagePolicyWebhook configures the ImagePolicyWebhook admission plugin.
+	ImagePolicyWebhook *ImagePolicyWebhookAdmissionConfig `json:"imagePolicyWebhook,omitempty"`
+}
+
+// EventRateLimitAdmissionConfig configures the EventRateLimit admission plugin.
+type EventRateLimitAdmissionConfig struct {
+	// Limits are the limits on the rate of events accepted by the apiserver.
+	Limits []EventRateLimit `json:"limits,omitempty"`
+}
+
+// EventRateLimit is a limit on the rate of events accepted by the apiserver.
+type EventRateLimit struct {
+	// Type is the scope of the limit: Server, Namespace, User or SourceAndObject.
+	Type string `json:"type"`
+	// QPS is the number of events per second allowed by the limit.
+	QPS int32 `json:"qps"`
+	// Burst is the number of events allowed in a burst above QPS.
+	Burst int32 `json:"burst"`
+	// CacheSize is the number of namespaces, users or sources tracked by the limit; it does not apply to the Server type.
+	CacheSize *int32 `json:"cacheSize,omitempty"`
+}
+
+// ImagePolicyWebhookAdmissionConfig configures the ImagePolicyWebhook admission plugin.
+type ImagePolicyWebhookAdmissionConfig struct {
+	// KubeConfig is the content of the kubeconfig file used to connect to the image policy backend.
+	KubeConfig string `json:"kubeConfig,omitempty"`
+	// AllowTTL is the number of seconds to cache approvals.
+	AllowTTL *int32 `json:"allowTTL,omitempty"`
+	// DenyTTL is the number of seconds to cache denials.
+	DenyTTL *int32 `json:"denyTTL,omitempty"`
+	// RetryBackoff is the number of milliseconds to wait between retries to the backend.
+	RetryBackoff *int32 `json:"retryBackoff,omitempty"`
+	// DefaultAllow allows images to be admitted when the backend cannot be reached.
+	DefaultAllow bool `json:"defaultAllow,omitempty"`
 }
 
 // KubeControllerManagerConfig is the configuration for the controller
@@ -659,6 +747,10 @@ type KubeControllerManagerConfig struct {
 	EnableProfiling *bool `json:"enableProfiling,omitempty" flag:"profiling"`
 	// EnableLeaderMigration enables controller leader migration.
 	EnableLeaderMigration *bool `json:"enableLeaderMigration,omitempty" flag:"enable-leader-migration"`
+	// LivenessProbe overrides the settings of the liveness probe of the kube-controller-manager container.
+	LivenessProbe *ProbeSpec `json:"livenessProbe,omitempty"`
+	// StartupProbe adds a startup probe to the kube-controller-manager container, which holds off the liveness probe until it succeeds.
+	StartupProbe *ProbeSpec `json:"startupProbe,omitempty"`
 }
 
 // CloudControllerManagerConfig is the configuration of the cloud controller
@@ -735,6 +827,10 @@ type KubeSchedulerConfig struct {
 	TLSCertFile *string `json:"tlsCertFile,omitempty" flag:"tls-cert-file"`
 	// TLSPrivateKeyFile is the file containing the private key for the TLS server certificate.
 	TLSPrivateKeyFile string `json:"tlsPrivateKeyFile,omitempty" flag:"tls-private-key-file"`
+	// LivenessProbe overrides the settings of the liveness probe of the kube-scheduler container.
+	LivenessProbe *ProbeSpec `json:"livenessProbe,omitempty"`
+	// StartupProbe adds a startup probe to the kube-scheduler container, which holds off the liveness probe until it succeeds.
+	StartupProbe *ProbeSpec `json:"startupProbe,omitempty"`
 }
 
 // LeaderElectionConfiguration defines the configuration of leader election
@@ -998,6 +1094,35 @@ type NodeProblemDetectorConfig struct {
 	CPULimit *resource.Quantity `json:"cpuLimit,omitempty"`
 }
 
+// MonitoringSpec configures the node-level metrics agents that kops deploys as managed addons.
+type MonitoringSpec struct {
+	// NodeExporter configures the Prometheus node-exporter.
+	NodeExporter *MonitoringAgentConfig `json:"nodeExporter,omitempty"`
+	// CloudWatchAgent configures the Amazon CloudWatch agent (AWS only).
+	CloudWatchAgent *MonitoringAgentConfig `json:"cloudWatchAgent,omitempty"`
+}
+
+// MonitoringAgentConfig determines the configuration of a monitoring agent.
+type MonitoringAgentConfig struct {
+	// Enabled runs the agent on every instance group that does not override it.
+	// Default: false
+	Enabled *bool `json:"enabled,omitempty"`
+	// Image is the container image used.
+	Image *string `json:"image,omitempty"`
+	// ResourcePreset selects the default resource requests and limits: small, medium or large.
+	// Default: small
+	ResourcePreset *string `json:"resourcePreset,omitempty"`
+
+	// MemoryRequest of the agent container, overriding the preset.
+	MemoryRequest *resource.Quantity `json:"memoryRequest,omitempty"`
+	// CPURequest of the agent container, overriding the preset.
+	CPURequest *resource.Quantity `json:"cpuRequest,omitempty"`
+	// MemoryLimit of the agent container, overriding the preset.
+	MemoryLimit *resource.Quantity `json:"memoryLimit,omitempty"`
+	// CPULimit of the agent container, overriding the preset.
+	CPULimit *resource.Quantity `json:"cpuLimit,omitempty"`
+}
+
 // ClusterAutoscalerConfig determines the cluster autoscaler configuration.
 type ClusterAutoscalerConfig struct {
 	// Enabled enables the cluster autoscaler.
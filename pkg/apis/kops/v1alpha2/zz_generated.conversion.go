@@ -84,6 +84,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*AWSPrivateCAIssuerSpec)(nil), (*kops.AWSPrivateCAIssuerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_AWSPrivateCAIssuerSpec_To_kops_AWSPrivateCAIssuerSpec(a.(*AWSPrivateCAIssuerSpec), b.(*kops.AWSPrivateCAIssuerSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.AWSPrivateCAIssuerSpec)(nil), (*AWSPrivateCAIssuerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_AWSPrivateCAIssuerSpec_To_v1alpha2_AWSPrivateCAIssuerSpec(a.(*kops.AWSPrivateCAIssuerSpec), b.(*AWSPrivateCAIssuerSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*AcceleratorConfig)(nil), (*kops.AcceleratorConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_AcceleratorConfig_To_kops_AcceleratorConfig(a.(*AcceleratorConfig), b.(*kops.AcceleratorConfig), scope)
 	}); err != nil {
@@ -124,6 +134,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*AdmissionPluginConfigSpec)(nil), (*kops.AdmissionPluginConfigSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_AdmissionPluginConfigSpec_To_kops_AdmissionPluginConfigSpec(a.(*AdmissionPluginConfigSpec), b.(*kops.AdmissionPluginConfigSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.AdmissionPluginConfigSpec)(nil), (*AdmissionPluginConfigSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_AdmissionPluginConfigSpec_To_v1alpha2_AdmissionPluginConfigSpec(a.(*kops.AdmissionPluginConfigSpec), b.(*AdmissionPluginConfigSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*AlwaysAllowAuthorizationSpec)(nil), (*kops.AlwaysAllowAuthorizationSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_AlwaysAllowAuthorizationSpec_To_kops_AlwaysAllowAuthorizationSpec(a.(*AlwaysAllowAuthorizationSpec), b.(*kops.AlwaysAllowAuthorizationSpec), scope)
 	}); err != nil {
@@ -144,6 +164,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*AssetCacheSpec)(nil), (*kops.AssetCacheSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_AssetCacheSpec_To_kops_AssetCacheSpec(a.(*AssetCacheSpec), b.(*kops.AssetCacheSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.AssetCacheSpec)(nil), (*AssetCacheSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_AssetCacheSpec_To_v1alpha2_AssetCacheSpec(a.(*kops.AssetCacheSpec), b.(*AssetCacheSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*AssetTrustPolicy)(nil), (*kops.AssetTrustPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_AssetTrustPolicy_To_kops_AssetTrustPolicy(a.(*AssetTrustPolicy), b.(*kops.AssetTrustPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.AssetTrustPolicy)(nil), (*AssetTrustPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_AssetTrustPolicy_To_v1alpha2_AssetTrustPolicy(a.(*kops.AssetTrustPolicy), b.(*AssetTrustPolicy), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Assets)(nil), (*kops.Assets)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_Assets_To_kops_Assets(a.(*Assets), b.(*kops.Assets), scope)
 	}); err != nil {
@@ -234,6 +274,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CertificateIssuerSpec)(nil), (*kops.CertificateIssuerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CertificateIssuerSpec_To_kops_CertificateIssuerSpec(a.(*CertificateIssuerSpec), b.(*kops.CertificateIssuerSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.CertificateIssuerSpec)(nil), (*CertificateIssuerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_CertificateIssuerSpec_To_v1alpha2_CertificateIssuerSpec(a.(*kops.CertificateIssuerSpec), b.(*CertificateIssuerSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CiliumENISpec)(nil), (*kops.CiliumENISpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CiliumENISpec_To_kops_CiliumENISpec(a.(*CiliumENISpec), b.(*kops.CiliumENISpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.CiliumENISpec)(nil), (*CiliumENISpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_CiliumENISpec_To_v1alpha2_CiliumENISpec(a.(*kops.CiliumENISpec), b.(*CiliumENISpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ClassicNetworkingSpec)(nil), (*kops.ClassicNetworkingSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_ClassicNetworkingSpec_To_kops_ClassicNetworkingSpec(a.(*ClassicNetworkingSpec), b.(*kops.ClassicNetworkingSpec), scope)
 	}); err != nil {
@@ -264,6 +324,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CloudInitSpec)(nil), (*kops.CloudInitSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CloudInitSpec_To_kops_CloudInitSpec(a.(*CloudInitSpec), b.(*kops.CloudInitSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.CloudInitSpec)(nil), (*CloudInitSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_CloudInitSpec_To_v1alpha2_CloudInitSpec(a.(*kops.CloudInitSpec), b.(*CloudInitSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Cluster)(nil), (*kops.Cluster)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_Cluster_To_kops_Cluster(a.(*Cluster), b.(*kops.Cluster), scope)
 	}); err != nil {
@@ -314,6 +384,46 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ContainerdRegistryAuth)(nil), (*kops.ContainerdRegistryAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ContainerdRegistryAuth_To_kops_ContainerdRegistryAuth(a.(*ContainerdRegistryAuth), b.(*kops.ContainerdRegistryAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ContainerdRegistryAuth)(nil), (*ContainerdRegistryAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ContainerdRegistryAuth_To_v1alpha2_ContainerdRegistryAuth(a.(*kops.ContainerdRegistryAuth), b.(*ContainerdRegistryAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ContainerdRegistryConfig)(nil), (*kops.ContainerdRegistryConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ContainerdRegistryConfig_To_kops_ContainerdRegistryConfig(a.(*ContainerdRegistryConfig), b.(*kops.ContainerdRegistryConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ContainerdRegistryConfig)(nil), (*ContainerdRegistryConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ContainerdRegistryConfig_To_v1alpha2_ContainerdRegistryConfig(a.(*kops.ContainerdRegistryConfig), b.(*ContainerdRegistryConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ContainerdRegistryMirror)(nil), (*kops.ContainerdRegistryMirror)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ContainerdRegistryMirror_To_kops_ContainerdRegistryMirror(a.(*ContainerdRegistryMirror), b.(*kops.ContainerdRegistryMirror), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ContainerdRegistryMirror)(nil), (*ContainerdRegistryMirror)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ContainerdRegistryMirror_To_v1alpha2_ContainerdRegistryMirror(a.(*kops.ContainerdRegistryMirror), b.(*ContainerdRegistryMirror), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CrioConfig)(nil), (*kops.CrioConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CrioConfig_To_kops_CrioConfig(a.(*CrioConfig), b.(*kops.CrioConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.CrioConfig)(nil), (*CrioConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_CrioConfig_To_v1alpha2_CrioConfig(a.(*kops.CrioConfig), b.(*CrioConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*DNSAccessSpec)(nil), (*kops.DNSAccessSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_DNSAccessSpec_To_kops_DNSAccessSpec(a.(*DNSAccessSpec), b.(*kops.DNSAccessSpec), scope)
 	}); err != nil {
@@ -344,6 +454,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*DNSRecordSpec)(nil), (*kops.DNSRecordSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_DNSRecordSpec_To_kops_DNSRecordSpec(a.(*DNSRecordSpec), b.(*kops.DNSRecordSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.DNSRecordSpec)(nil), (*DNSRecordSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_DNSRecordSpec_To_v1alpha2_DNSRecordSpec(a.(*kops.DNSRecordSpec), b.(*DNSRecordSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DNSRecordsSpec)(nil), (*kops.DNSRecordsSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_DNSRecordsSpec_To_kops_DNSRecordsSpec(a.(*DNSRecordsSpec), b.(*kops.DNSRecordsSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.DNSRecordsSpec)(nil), (*DNSRecordsSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_DNSRecordsSpec_To_v1alpha2_DNSRecordsSpec(a.(*kops.DNSRecordsSpec), b.(*DNSRecordsSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*DNSSpec)(nil), (*kops.DNSSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_DNSSpec_To_kops_DNSSpec(a.(*DNSSpec), b.(*kops.DNSSpec), scope)
 	}); err != nil {
@@ -364,6 +494,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*EFASpec)(nil), (*kops.EFASpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_EFASpec_To_kops_EFASpec(a.(*EFASpec), b.(*kops.EFASpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.EFASpec)(nil), (*EFASpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_EFASpec_To_v1alpha2_EFASpec(a.(*kops.EFASpec), b.(*EFASpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*EgressProxySpec)(nil), (*kops.EgressProxySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_EgressProxySpec_To_kops_EgressProxySpec(a.(*EgressProxySpec), b.(*kops.EgressProxySpec), scope)
 	}); err != nil {
@@ -404,6 +544,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*EtcdCrossAccountStoreSpec)(nil), (*kops.EtcdCrossAccountStoreSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_EtcdCrossAccountStoreSpec_To_kops_EtcdCrossAccountStoreSpec(a.(*EtcdCrossAccountStoreSpec), b.(*kops.EtcdCrossAccountStoreSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.EtcdCrossAccountStoreSpec)(nil), (*EtcdCrossAccountStoreSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_EtcdCrossAccountStoreSpec_To_v1alpha2_EtcdCrossAccountStoreSpec(a.(*kops.EtcdCrossAccountStoreSpec), b.(*EtcdCrossAccountStoreSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*EtcdManagerSpec)(nil), (*kops.EtcdManagerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_EtcdManagerSpec_To_kops_EtcdManagerSpec(a.(*EtcdManagerSpec), b.(*kops.EtcdManagerSpec), scope)
 	}); err != nil {
@@ -424,6 +574,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*EventRateLimit)(nil), (*kops.EventRateLimit)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_EventRateLimit_To_kops_EventRateLimit(a.(*EventRateLimit), b.(*kops.EventRateLimit), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.EventRateLimit)(nil), (*EventRateLimit)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_EventRateLimit_To_v1alpha2_EventRateLimit(a.(*kops.EventRateLimit), b.(*EventRateLimit), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*EventRateLimitAdmissionConfig)(nil), (*kops.EventRateLimitAdmissionConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_EventRateLimitAdmissionConfig_To_kops_EventRateLimitAdmissionConfig(a.(*EventRateLimitAdmissionConfig), b.(*kops.EventRateLimitAdmissionConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.EventRateLimitAdmissionConfig)(nil), (*EventRateLimitAdmissionConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_EventRateLimitAdmissionConfig_To_v1alpha2_EventRateLimitAdmissionConfig(a.(*kops.EventRateLimitAdmissionConfig), b.(*EventRateLimitAdmissionConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ExecContainerAction)(nil), (*kops.ExecContainerAction)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_ExecContainerAction_To_kops_ExecContainerAction(a.(*ExecContainerAction), b.(*kops.ExecContainerAction), scope)
 	}); err != nil {
@@ -454,6 +624,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*FileTrustPolicy)(nil), (*kops.FileTrustPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_FileTrustPolicy_To_kops_FileTrustPolicy(a.(*FileTrustPolicy), b.(*kops.FileTrustPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.FileTrustPolicy)(nil), (*FileTrustPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_FileTrustPolicy_To_v1alpha2_FileTrustPolicy(a.(*kops.FileTrustPolicy), b.(*FileTrustPolicy), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*FlannelNetworkingSpec)(nil), (*kops.FlannelNetworkingSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_FlannelNetworkingSpec_To_kops_FlannelNetworkingSpec(a.(*FlannelNetworkingSpec), b.(*kops.FlannelNetworkingSpec), scope)
 	}); err != nil {
@@ -474,6 +654,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*GCPCASIssuerSpec)(nil), (*kops.GCPCASIssuerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_GCPCASIssuerSpec_To_kops_GCPCASIssuerSpec(a.(*GCPCASIssuerSpec), b.(*kops.GCPCASIssuerSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.GCPCASIssuerSpec)(nil), (*GCPCASIssuerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_GCPCASIssuerSpec_To_v1alpha2_GCPCASIssuerSpec(a.(*kops.GCPCASIssuerSpec), b.(*GCPCASIssuerSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*GCPPDCSIDriver)(nil), (*kops.GCPPDCSIDriver)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_GCPPDCSIDriver_To_kops_GCPPDCSIDriver(a.(*GCPPDCSIDriver), b.(*kops.GCPPDCSIDriver), scope)
 	}); err != nil {
@@ -484,6 +674,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*GCPPermission)(nil), (*kops.GCPPermission)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_GCPPermission_To_kops_GCPPermission(a.(*GCPPermission), b.(*kops.GCPPermission), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.GCPPermission)(nil), (*GCPPermission)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_GCPPermission_To_v1alpha2_GCPPermission(a.(*kops.GCPPermission), b.(*GCPPermission), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*GossipConfig)(nil), (*kops.GossipConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_GossipConfig_To_kops_GossipConfig(a.(*GossipConfig), b.(*kops.GossipConfig), scope)
 	}); err != nil {
@@ -554,6 +754,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ImagePolicyWebhookAdmissionConfig)(nil), (*kops.ImagePolicyWebhookAdmissionConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ImagePolicyWebhookAdmissionConfig_To_kops_ImagePolicyWebhookAdmissionConfig(a.(*ImagePolicyWebhookAdmissionConfig), b.(*kops.ImagePolicyWebhookAdmissionConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ImagePolicyWebhookAdmissionConfig)(nil), (*ImagePolicyWebhookAdmissionConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ImagePolicyWebhookAdmissionConfig_To_v1alpha2_ImagePolicyWebhookAdmissionConfig(a.(*kops.ImagePolicyWebhookAdmissionConfig), b.(*ImagePolicyWebhookAdmissionConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ImageTrustPolicy)(nil), (*kops.ImageTrustPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ImageTrustPolicy_To_kops_ImageTrustPolicy(a.(*ImageTrustPolicy), b.(*kops.ImageTrustPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ImageTrustPolicy)(nil), (*ImageTrustPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ImageTrustPolicy_To_v1alpha2_ImageTrustPolicy(a.(*kops.ImageTrustPolicy), b.(*ImageTrustPolicy), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*InstanceGroup)(nil), (*kops.InstanceGroup)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_InstanceGroup_To_kops_InstanceGroup(a.(*InstanceGroup), b.(*kops.InstanceGroup), scope)
 	}); err != nil {
@@ -574,6 +794,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*InstanceGroupMonitoringSpec)(nil), (*kops.InstanceGroupMonitoringSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_InstanceGroupMonitoringSpec_To_kops_InstanceGroupMonitoringSpec(a.(*InstanceGroupMonitoringSpec), b.(*kops.InstanceGroupMonitoringSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.InstanceGroupMonitoringSpec)(nil), (*InstanceGroupMonitoringSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_InstanceGroupMonitoringSpec_To_v1alpha2_InstanceGroupMonitoringSpec(a.(*kops.InstanceGroupMonitoringSpec), b.(*InstanceGroupMonitoringSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*InstanceGroupSpec)(nil), (*kops.InstanceGroupSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_InstanceGroupSpec_To_kops_InstanceGroupSpec(a.(*InstanceGroupSpec), b.(*kops.InstanceGroupSpec), scope)
 	}); err != nil {
@@ -644,6 +874,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*KeysetRotation)(nil), (*kops.KeysetRotation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_KeysetRotation_To_kops_KeysetRotation(a.(*KeysetRotation), b.(*kops.KeysetRotation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.KeysetRotation)(nil), (*KeysetRotation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_KeysetRotation_To_v1alpha2_KeysetRotation(a.(*kops.KeysetRotation), b.(*KeysetRotation), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*KeysetSpec)(nil), (*kops.KeysetSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_KeysetSpec_To_kops_KeysetSpec(a.(*KeysetSpec), b.(*kops.KeysetSpec), scope)
 	}); err != nil {
@@ -684,6 +924,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*KubeAPIServerHealthcheckSidecarConfig)(nil), (*kops.KubeAPIServerHealthcheckSidecarConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_KubeAPIServerHealthcheckSidecarConfig_To_kops_KubeAPIServerHealthcheckSidecarConfig(a.(*KubeAPIServerHealthcheckSidecarConfig), b.(*kops.KubeAPIServerHealthcheckSidecarConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.KubeAPIServerHealthcheckSidecarConfig)(nil), (*KubeAPIServerHealthcheckSidecarConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_KubeAPIServerHealthcheckSidecarConfig_To_v1alpha2_KubeAPIServerHealthcheckSidecarConfig(a.(*kops.KubeAPIServerHealthcheckSidecarConfig), b.(*KubeAPIServerHealthcheckSidecarConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*KubeControllerManagerConfig)(nil), (*kops.KubeControllerManagerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_KubeControllerManagerConfig_To_kops_KubeControllerManagerConfig(a.(*KubeControllerManagerConfig), b.(*kops.KubeControllerManagerConfig), scope)
 	}); err != nil {
@@ -784,6 +1034,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*LoadBalancerHealthCheckSpec)(nil), (*kops.LoadBalancerHealthCheckSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_LoadBalancerHealthCheckSpec_To_kops_LoadBalancerHealthCheckSpec(a.(*LoadBalancerHealthCheckSpec), b.(*kops.LoadBalancerHealthCheckSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.LoadBalancerHealthCheckSpec)(nil), (*LoadBalancerHealthCheckSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_LoadBalancerHealthCheckSpec_To_v1alpha2_LoadBalancerHealthCheckSpec(a.(*kops.LoadBalancerHealthCheckSpec), b.(*LoadBalancerHealthCheckSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*LoadBalancerSubnetSpec)(nil), (*kops.LoadBalancerSubnetSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_LoadBalancerSubnetSpec_To_kops_LoadBalancerSubnetSpec(a.(*LoadBalancerSubnetSpec), b.(*kops.LoadBalancerSubnetSpec), scope)
 	}); err != nil {
@@ -834,6 +1094,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*MonitoringAgentConfig)(nil), (*kops.MonitoringAgentConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_MonitoringAgentConfig_To_kops_MonitoringAgentConfig(a.(*MonitoringAgentConfig), b.(*kops.MonitoringAgentConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.MonitoringAgentConfig)(nil), (*MonitoringAgentConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_MonitoringAgentConfig_To_v1alpha2_MonitoringAgentConfig(a.(*kops.MonitoringAgentConfig), b.(*MonitoringAgentConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*MonitoringSpec)(nil), (*kops.MonitoringSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_MonitoringSpec_To_kops_MonitoringSpec(a.(*MonitoringSpec), b.(*kops.MonitoringSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.MonitoringSpec)(nil), (*MonitoringSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_MonitoringSpec_To_v1alpha2_MonitoringSpec(a.(*kops.MonitoringSpec), b.(*MonitoringSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*NTPConfig)(nil), (*kops.NTPConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_NTPConfig_To_kops_NTPConfig(a.(*NTPConfig), b.(*kops.NTPConfig), scope)
 	}); err != nil {
@@ -874,6 +1154,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*NodeCertificatesSpec)(nil), (*kops.NodeCertificatesSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_NodeCertificatesSpec_To_kops_NodeCertificatesSpec(a.(*NodeCertificatesSpec), b.(*kops.NodeCertificatesSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.NodeCertificatesSpec)(nil), (*NodeCertificatesSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_NodeCertificatesSpec_To_v1alpha2_NodeCertificatesSpec(a.(*kops.NodeCertificatesSpec), b.(*NodeCertificatesSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NodeLabelsFromCloudTagsSpec)(nil), (*kops.NodeLabelsFromCloudTagsSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_NodeLabelsFromCloudTagsSpec_To_kops_NodeLabelsFromCloudTagsSpec(a.(*NodeLabelsFromCloudTagsSpec), b.(*kops.NodeLabelsFromCloudTagsSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.NodeLabelsFromCloudTagsSpec)(nil), (*NodeLabelsFromCloudTagsSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_NodeLabelsFromCloudTagsSpec_To_v1alpha2_NodeLabelsFromCloudTagsSpec(a.(*kops.NodeLabelsFromCloudTagsSpec), b.(*NodeLabelsFromCloudTagsSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*NodeLocalDNSConfig)(nil), (*kops.NodeLocalDNSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_NodeLocalDNSConfig_To_kops_NodeLocalDNSConfig(a.(*NodeLocalDNSConfig), b.(*kops.NodeLocalDNSConfig), scope)
 	}); err != nil {
@@ -1004,6 +1304,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ProbeSpec)(nil), (*kops.ProbeSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ProbeSpec_To_kops_ProbeSpec(a.(*ProbeSpec), b.(*kops.ProbeSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ProbeSpec)(nil), (*ProbeSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ProbeSpec_To_v1alpha2_ProbeSpec(a.(*kops.ProbeSpec), b.(*ProbeSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*RBACAuthorizationSpec)(nil), (*kops.RBACAuthorizationSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_RBACAuthorizationSpec_To_kops_RBACAuthorizationSpec(a.(*RBACAuthorizationSpec), b.(*kops.RBACAuthorizationSpec), scope)
 	}); err != nil {
@@ -1044,6 +1354,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*Runc)(nil), (*kops.Runc)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_Runc_To_kops_Runc(a.(*Runc), b.(*kops.Runc), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.Runc)(nil), (*Runc)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_Runc_To_v1alpha2_Runc(a.(*kops.Runc), b.(*Runc), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*SSHCredential)(nil), (*kops.SSHCredential)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_SSHCredential_To_kops_SSHCredential(a.(*SSHCredential), b.(*kops.SSHCredential), scope)
 	}); err != nil {
@@ -1104,6 +1424,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*SpotFallbackSpec)(nil), (*kops.SpotFallbackSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_SpotFallbackSpec_To_kops_SpotFallbackSpec(a.(*SpotFallbackSpec), b.(*kops.SpotFallbackSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.SpotFallbackSpec)(nil), (*SpotFallbackSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_SpotFallbackSpec_To_v1alpha2_SpotFallbackSpec(a.(*kops.SpotFallbackSpec), b.(*SpotFallbackSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*TargetSpec)(nil), (*kops.TargetSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_TargetSpec_To_kops_TargetSpec(a.(*TargetSpec), b.(*kops.TargetSpec), scope)
 	}); err != nil {
@@ -1144,6 +1474,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ValidationCheck)(nil), (*kops.ValidationCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ValidationCheck_To_kops_ValidationCheck(a.(*ValidationCheck), b.(*kops.ValidationCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ValidationCheck)(nil), (*ValidationCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ValidationCheck_To_v1alpha2_ValidationCheck(a.(*kops.ValidationCheck), b.(*ValidationCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ValidationCheckObject)(nil), (*kops.ValidationCheckObject)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ValidationCheckObject_To_kops_ValidationCheckObject(a.(*ValidationCheckObject), b.(*kops.ValidationCheckObject), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ValidationCheckObject)(nil), (*ValidationCheckObject)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ValidationCheckObject_To_v1alpha2_ValidationCheckObject(a.(*kops.ValidationCheckObject), b.(*ValidationCheckObject), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*VolumeMountSpec)(nil), (*kops.VolumeMountSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_VolumeMountSpec_To_kops_VolumeMountSpec(a.(*VolumeMountSpec), b.(*kops.VolumeMountSpec), scope)
 	}); err != nil {
@@ -1379,6 +1729,26 @@ func Convert_kops_AWSPermission_To_v1alpha2_AWSPermission(in *kops.AWSPermission
 	return autoConvert_kops_AWSPermission_To_v1alpha2_AWSPermission(in, out, s)
 }
 
+func autoConvert_v1alpha2_AWSPrivateCAIssuerSpec_To_kops_AWSPrivateCAIssuerSpec(in *AWSPrivateCAIssuerSpec, out *kops.AWSPrivateCAIssuerSpec, s conversion.Scope) error {
+	out.ARN = in.ARN
+	return nil
+}
+
+// Convert_v1alpha2_AWSPrivateCAIssuerSpec_To_kops_AWSPrivateCAIssuerSpec is an autogenerated conversion function.
+func Convert_v1alpha2_AWSPrivateCAIssuerSpec_To_kops_AWSPrivateCAIssuerSpec(in *AWSPrivateCAIssuerSpec, out *kops.AWSPrivateCAIssuerSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_AWSPrivateCAIssuerSpec_To_kops_AWSPrivateCAIssuerSpec(in, out, s)
+}
+
+func autoConvert_kops_AWSPrivateCAIssuerSpec_To_v1alpha2_AWSPrivateCAIssuerSpec(in *kops.AWSPrivateCAIssuerSpec, out *AWSPrivateCAIssuerSpec, s conversion.Scope) error {
+	out.ARN = in.ARN
+	return nil
+}
+
+// Convert_kops_AWSPrivateCAIssuerSpec_To_v1alpha2_AWSPrivateCAIssuerSpec is an autogenerated conversion function.
+func Convert_kops_AWSPrivateCAIssuerSpec_To_v1alpha2_AWSPrivateCAIssuerSpec(in *kops.AWSPrivateCAIssuerSpec, out *AWSPrivateCAIssuerSpec, s conversion.Scope) error {
+	return autoConvert_kops_AWSPrivateCAIssuerSpec_To_v1alpha2_AWSPrivateCAIssuerSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_AcceleratorConfig_To_kops_AcceleratorConfig(in *AcceleratorConfig, out *kops.AcceleratorConfig, s conversion.Scope) error {
 	out.AcceleratorCount = in.AcceleratorCount
 	out.AcceleratorType = in.AcceleratorType
@@ -1499,6 +1869,60 @@ func Convert_kops_AddonSpec_To_v1alpha2_AddonSpec(in *kops.AddonSpec, out *Addon
 	return autoConvert_kops_AddonSpec_To_v1alpha2_AddonSpec(in, out, s)
 }
 
+func autoConvert_v1alpha2_AdmissionPluginConfigSpec_To_kops_AdmissionPluginConfigSpec(in *AdmissionPluginConfigSpec, out *kops.AdmissionPluginConfigSpec, s conversion.Scope) error {
+	if in.EventRateLimit != nil {
+		in, out := &in.EventRateLimit, &out.EventRateLimit
+		*out = new(kops.EventRateLimitAdmissionConfig)
+		if err := Convert_v1alpha2_EventRateLimitAdmissionConfig_To_kops_EventRateLimitAdmissionConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.EventRateLimit = nil
+	}
+	if in.ImagePolicyWebhook != nil {
+		in, out := &in.ImagePolicyWebhook, &out.ImagePolicyWebhook
+		*out = new(kops.ImagePolicyWebhookAdmissionConfig)
+		if err := Convert_v1alpha2_ImagePolicyWebhookAdmissionConfig_To_kops_ImagePolicyWebhookAdmissionConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ImagePolicyWebhook = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha2_AdmissionPluginConfigSpec_To_kops_AdmissionPluginConfigSpec is an autogenerated conversion function.
+func Convert_v1alpha2_AdmissionPluginConfigSpec_To_kops_AdmissionPluginConfigSpec(in *AdmissionPluginConfigSpec, out *kops.AdmissionPluginConfigSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_AdmissionPluginConfigSpec_To_kops_AdmissionPluginConfigSpec(in, out, s)
+}
+
+func autoConvert_kops_AdmissionPluginConfigSpec_To_v1alpha2_AdmissionPluginConfigSpec(in *kops.AdmissionPluginConfigSpec, out *AdmissionPluginConfigSpec, s conversion.Scope) error {
+	if in.EventRateLimit != nil {
+		in, out := &in.EventRateLimit, &out.EventRateLimit
+		*out = new(EventRateLimitAdmissionConfig)
+		if err := Convert_kops_EventRateLimitAdmissionConfig_To_v1alpha2_EventRateLimitAdmissionConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.EventRateLimit = nil
+	}
+	if in.ImagePolicyWebhook != nil {
+		in, out := &in.ImagePolicyWebhook, &out.ImagePolicyWebhook
+		*out = new(ImagePolicyWebhookAdmissionConfig)
+		if err := Convert_kops_ImagePolicyWebhookAdmissionConfig_To_v1alpha2_ImagePolicyWebhookAdmissionConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ImagePolicyWebhook = nil
+	}
+	return nil
+}
+
+// Convert_kops_AdmissionPluginConfigSpec_To_v1alpha2_AdmissionPluginConfigSpec is an autogenerated conversion function.
+func Convert_kops_AdmissionPluginConfigSpec_To_v1alpha2_AdmissionPluginConfigSpec(in *kops.AdmissionPluginConfigSpec, out *AdmissionPluginConfigSpec, s conversion.Scope) error {
+	return autoConvert_kops_AdmissionPluginConfigSpec_To_v1alpha2_AdmissionPluginConfigSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_AlwaysAllowAuthorizationSpec_To_kops_AlwaysAllowAuthorizationSpec(in *AlwaysAllowAuthorizationSpec, out *kops.AlwaysAllowAuthorizationSpec, s conversion.Scope) error {
 	return nil
 }
@@ -1561,10 +1985,103 @@ func Convert_kops_AmazonVPCNetworkingSpec_To_v1alpha2_AmazonVPCNetworkingSpec(in
 	return autoConvert_kops_AmazonVPCNetworkingSpec_To_v1alpha2_AmazonVPCNetworkingSpec(in, out, s)
 }
 
+func autoConvert_v1alpha2_AssetCacheSpec_To_kops_AssetCacheSpec(in *AssetCacheSpec, out *kops.AssetCacheSpec, s conversion.Scope) error {
+	out.Path = in.Path
+	out.URL = in.URL
+	return nil
+}
+
+// Convert_v1alpha2_AssetCacheSpec_To_kops_AssetCacheSpec is an autogenerated conversion function.
+func Convert_v1alpha2_AssetCacheSpec_To_kops_AssetCacheSpec(in *AssetCacheSpec, out *kops.AssetCacheSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_AssetCacheSpec_To_kops_AssetCacheSpec(in, out, s)
+}
+
+func autoConvert_kops_AssetCacheSpec_To_v1alpha2_AssetCacheSpec(in *kops.AssetCacheSpec, out *AssetCacheSpec, s conversion.Scope) error {
+	out.Path = in.Path
+	out.URL = in.URL
+	return nil
+}
+
+// Convert_kops_AssetCacheSpec_To_v1alpha2_AssetCacheSpec is an autogenerated conversion function.
+func Convert_kops_AssetCacheSpec_To_v1alpha2_AssetCacheSpec(in *kops.AssetCacheSpec, out *AssetCacheSpec, s conversion.Scope) error {
+	return autoConvert_kops_AssetCacheSpec_To_v1alpha2_AssetCacheSpec(in, out, s)
+}
+
+func autoConvert_v1alpha2_AssetTrustPolicy_To_kops_AssetTrustPolicy(in *AssetTrustPolicy, out *kops.AssetTrustPolicy, s conversion.Scope) error {
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]kops.ImageTrustPolicy, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_ImageTrustPolicy_To_kops_ImageTrustPolicy(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Images = nil
+	}
+	if in.Files != nil {
+		in, out := &in.Files, &out.Files
+		*out = make([]kops.FileTrustPolicy, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_FileTrustPolicy_To_kops_FileTrustPolicy(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Files = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha2_AssetTrustPolicy_To_kops_AssetTrustPolicy is an autogenerated conversion function.
+func Convert_v1alpha2_AssetTrustPolicy_To_kops_AssetTrustPolicy(in *AssetTrustPolicy, out *kops.AssetTrustPolicy, s conversion.Scope) error {
+	return autoConvert_v1alpha2_AssetTrustPolicy_To_kops_AssetTrustPolicy(in, out, s)
+}
+
+func autoConvert_kops_AssetTrustPolicy_To_v1alpha2_AssetTrustPolicy(in *kops.AssetTrustPolicy, out *AssetTrustPolicy, s conversion.Scope) error {
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]ImageTrustPolicy, len(*in))
+		for i := range *in {
+			if err := Convert_kops_ImageTrustPolicy_To_v1alpha2_ImageTrustPolicy(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Images = nil
+	}
+	if in.Files != nil {
+		in, out := &in.Files, &out.Files
+		*out = make([]FileTrustPolicy, len(*in))
+		for i := range *in {
+			if err := Convert_kops_FileTrustPolicy_To_v1alpha2_FileTrustPolicy(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Files = nil
+	}
+	return nil
+}
+
+// Convert_kops_AssetTrustPolicy_To_v1alpha2_AssetTrustPolicy is an autogenerated conversion function.
+func Convert_kops_AssetTrustPolicy_To_v1alpha2_AssetTrustPolicy(in *kops.AssetTrustPolicy, out *AssetTrustPolicy, s conversion.Scope) error {
+	return autoConvert_kops_AssetTrustPolicy_To_v1alpha2_AssetTrustPolicy(in, out, s)
+}
+
 func autoConvert_v1alpha2_Assets_To_kops_Assets(in *Assets, out *kops.Assets, s conversion.Scope) error {
 	out.ContainerRegistry = in.ContainerRegistry
 	out.FileRepository = in.FileRepository
 	out.ContainerProxy = in.ContainerProxy
+	if in.TrustPolicy != nil {
+		in, out := &in.TrustPolicy, &out.TrustPolicy
+		*out = new(kops.AssetTrustPolicy)
+		if err := Convert_v1alpha2_AssetTrustPolicy_To_kops_AssetTrustPolicy(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TrustPolicy = nil
+	}
 	return nil
 }
 
@@ -1577,6 +2094,15 @@ func autoConvert_kops_Assets_To_v1alpha2_Assets(in *kops.Assets, out *Assets, s
 	out.ContainerRegistry = in.ContainerRegistry
 	out.FileRepository = in.FileRepository
 	out.ContainerProxy = in.ContainerProxy
+	if in.TrustPolicy != nil {
+		in, out := &in.TrustPolicy, &out.TrustPolicy
+		*out = new(AssetTrustPolicy)
+		if err := Convert_kops_AssetTrustPolicy_To_v1alpha2_AssetTrustPolicy(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TrustPolicy = nil
+	}
 	return nil
 }
 
@@ -1785,6 +2311,8 @@ func Convert_kops_BastionSpec_To_v1alpha2_BastionSpec(in *kops.BastionSpec, out
 
 func autoConvert_v1alpha2_CNINetworkingSpec_To_kops_CNINetworkingSpec(in *CNINetworkingSpec, out *kops.CNINetworkingSpec, s conversion.Scope) error {
 	out.UsesSecondaryIP = in.UsesSecondaryIP
+	out.ManifestURL = in.ManifestURL
+	out.ManifestSHA256 = in.ManifestSHA256
 	return nil
 }
 
@@ -1795,6 +2323,8 @@ func Convert_v1alpha2_CNINetworkingSpec_To_kops_CNINetworkingSpec(in *CNINetwork
 
 func autoConvert_kops_CNINetworkingSpec_To_v1alpha2_CNINetworkingSpec(in *kops.CNINetworkingSpec, out *CNINetworkingSpec, s conversion.Scope) error {
 	out.UsesSecondaryIP = in.UsesSecondaryIP
+	out.ManifestURL = in.ManifestURL
+	out.ManifestSHA256 = in.ManifestSHA256
 	return nil
 }
 
@@ -1941,6 +2471,86 @@ func Convert_kops_CertManagerConfig_To_v1alpha2_CertManagerConfig(in *kops.CertM
 	return autoConvert_kops_CertManagerConfig_To_v1alpha2_CertManagerConfig(in, out, s)
 }
 
+func autoConvert_v1alpha2_CertificateIssuerSpec_To_kops_CertificateIssuerSpec(in *CertificateIssuerSpec, out *kops.CertificateIssuerSpec, s conversion.Scope) error {
+	if in.AWSPrivateCA != nil {
+		in, out := &in.AWSPrivateCA, &out.AWSPrivateCA
+		*out = new(kops.AWSPrivateCAIssuerSpec)
+		if err := Convert_v1alpha2_AWSPrivateCAIssuerSpec_To_kops_AWSPrivateCAIssuerSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AWSPrivateCA = nil
+	}
+	if in.GCPCAS != nil {
+		in, out := &in.GCPCAS, &out.GCPCAS
+		*out = new(kops.GCPCASIssuerSpec)
+		if err := Convert_v1alpha2_GCPCASIssuerSpec_To_kops_GCPCASIssuerSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.GCPCAS = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha2_CertificateIssuerSpec_To_kops_CertificateIssuerSpec is an autogenerated conversion function.
+func Convert_v1alpha2_CertificateIssuerSpec_To_kops_CertificateIssuerSpec(in *CertificateIssuerSpec, out *kops.CertificateIssuerSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CertificateIssuerSpec_To_kops_CertificateIssuerSpec(in, out, s)
+}
+
+func autoConvert_kops_CertificateIssuerSpec_To_v1alpha2_CertificateIssuerSpec(in *kops.CertificateIssuerSpec, out *CertificateIssuerSpec, s conversion.Scope) error {
+	if in.AWSPrivateCA != nil {
+		in, out := &in.AWSPrivateCA, &out.AWSPrivateCA
+		*out = new(AWSPrivateCAIssuerSpec)
+		if err := Convert_kops_AWSPrivateCAIssuerSpec_To_v1alpha2_AWSPrivateCAIssuerSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AWSPrivateCA = nil
+	}
+	if in.GCPCAS != nil {
+		in, out := &in.GCPCAS, &out.GCPCAS
+		*out = new(GCPCASIssuerSpec)
+		if err := Convert_kops_GCPCASIssuerSpec_To_v1alpha2_GCPCASIssuerSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.GCPCAS = nil
+	}
+	return nil
+}
+
+// Convert_kops_CertificateIssuerSpec_To_v1alpha2_CertificateIssuerSpec is an autogenerated conversion function.
+func Convert_kops_CertificateIssuerSpec_To_v1alpha2_CertificateIssuerSpec(in *kops.CertificateIssuerSpec, out *CertificateIssuerSpec, s conversion.Scope) error {
+	return autoConvert_kops_CertificateIssuerSpec_To_v1alpha2_CertificateIssuerSpec(in, out, s)
+}
+
+func autoConvert_v1alpha2_CiliumENISpec_To_kops_CiliumENISpec(in *CiliumENISpec, out *kops.CiliumENISpec, s conversion.Scope) error {
+	out.SubnetIDsFilter = in.SubnetIDsFilter
+	out.SubnetTagsFilter = in.SubnetTagsFilter
+	out.AWSReleaseExcessIPs = in.AWSReleaseExcessIPs
+	out.UpdateEC2AdapterLimitViaAPI = in.UpdateEC2AdapterLimitViaAPI
+	return nil
+}
+
+// Convert_v1alpha2_CiliumENISpec_To_kops_CiliumENISpec is an autogenerated conversion function.
+func Convert_v1alpha2_CiliumENISpec_To_kops_CiliumENISpec(in *CiliumENISpec, out *kops.CiliumENISpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CiliumENISpec_To_kops_CiliumENISpec(in, out, s)
+}
+
+func autoConvert_kops_CiliumENISpec_To_v1alpha2_CiliumENISpec(in *kops.CiliumENISpec, out *CiliumENISpec, s conversion.Scope) error {
+	out.SubnetIDsFilter = in.SubnetIDsFilter
+	out.SubnetTagsFilter = in.SubnetTagsFilter
+	out.AWSReleaseExcessIPs = in.AWSReleaseExcessIPs
+	out.UpdateEC2AdapterLimitViaAPI = in.UpdateEC2AdapterLimitViaAPI
+	return nil
+}
+
+// Convert_kops_CiliumENISpec_To_v1alpha2_CiliumENISpec is an autogenerated conversion function.
+func Convert_kops_CiliumENISpec_To_v1alpha2_CiliumENISpec(in *kops.CiliumENISpec, out *CiliumENISpec, s conversion.Scope) error {
+	return autoConvert_kops_CiliumENISpec_To_v1alpha2_CiliumENISpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_CiliumNetworkingSpec_To_kops_CiliumNetworkingSpec(in *CiliumNetworkingSpec, out *kops.CiliumNetworkingSpec, s conversion.Scope) error {
 	out.Version = in.Version
 	out.MemoryRequest = in.MemoryRequest
@@ -1964,6 +2574,8 @@ func autoConvert_v1alpha2_CiliumNetworkingSpec_To_kops_CiliumNetworkingSpec(in *
 	// INFO: in.DisableK8sServices opted out of conversion generation
 	out.EnablePolicy = in.EnablePolicy
 	out.EnableL7Proxy = in.EnableL7Proxy
+	out.EnableEnvoyConfig = in.EnableEnvoyConfig
+	out.EnableGatewayAPI = in.EnableGatewayAPI
 	out.EnableBPFMasquerade = in.EnableBPFMasquerade
 	out.EnableEndpointHealthChecking = in.EnableEndpointHealthChecking
 	// INFO: in.EnableTracing opted out of conversion generation
@@ -2047,6 +2659,15 @@ func autoConvert_v1alpha2_CiliumNetworkingSpec_To_kops_CiliumNetworkingSpec(in *
 	// INFO: in.CniBinPath opted out of conversion generation
 	out.DisableCNPStatusUpdates = in.DisableCNPStatusUpdates
 	out.EnableServiceTopology = in.EnableServiceTopology
+	if in.ENI != nil {
+		in, out := &in.ENI, &out.ENI
+		*out = new(kops.CiliumENISpec)
+		if err := Convert_v1alpha2_CiliumENISpec_To_kops_CiliumENISpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ENI = nil
+	}
 	return nil
 }
 
@@ -2061,6 +2682,8 @@ func autoConvert_kops_CiliumNetworkingSpec_To_v1alpha2_CiliumNetworkingSpec(in *
 	out.DisableEndpointCRD = in.DisableEndpointCRD
 	out.EnablePolicy = in.EnablePolicy
 	out.EnableL7Proxy = in.EnableL7Proxy
+	out.EnableEnvoyConfig = in.EnableEnvoyConfig
+	out.EnableGatewayAPI = in.EnableGatewayAPI
 	out.EnableBPFMasquerade = in.EnableBPFMasquerade
 	out.EnableEndpointHealthChecking = in.EnableEndpointHealthChecking
 	out.EnablePrometheusMetrics = in.EnablePrometheusMetrics
@@ -2104,6 +2727,15 @@ func autoConvert_kops_CiliumNetworkingSpec_To_v1alpha2_CiliumNetworkingSpec(in *
 	}
 	out.DisableCNPStatusUpdates = in.DisableCNPStatusUpdates
 	out.EnableServiceTopology = in.EnableServiceTopology
+	if in.ENI != nil {
+		in, out := &in.ENI, &out.ENI
+		*out = new(CiliumENISpec)
+		if err := Convert_kops_CiliumENISpec_To_v1alpha2_CiliumENISpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ENI = nil
+	}
 	return nil
 }
 
@@ -2270,6 +2902,26 @@ func Convert_kops_CloudControllerManagerConfig_To_v1alpha2_CloudControllerManage
 	return autoConvert_kops_CloudControllerManagerConfig_To_v1alpha2_CloudControllerManagerConfig(in, out, s)
 }
 
+func autoConvert_v1alpha2_CloudInitSpec_To_kops_CloudInitSpec(in *CloudInitSpec, out *kops.CloudInitSpec, s conversion.Scope) error {
+	out.CloudConfig = in.CloudConfig
+	return nil
+}
+
+// Convert_v1alpha2_CloudInitSpec_To_kops_CloudInitSpec is an autogenerated conversion function.
+func Convert_v1alpha2_CloudInitSpec_To_kops_CloudInitSpec(in *CloudInitSpec, out *kops.CloudInitSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CloudInitSpec_To_kops_CloudInitSpec(in, out, s)
+}
+
+func autoConvert_kops_CloudInitSpec_To_v1alpha2_CloudInitSpec(in *kops.CloudInitSpec, out *CloudInitSpec, s conversion.Scope) error {
+	out.CloudConfig = in.CloudConfig
+	return nil
+}
+
+// Convert_kops_CloudInitSpec_To_v1alpha2_CloudInitSpec is an autogenerated conversion function.
+func Convert_kops_CloudInitSpec_To_v1alpha2_CloudInitSpec(in *kops.CloudInitSpec, out *CloudInitSpec, s conversion.Scope) error {
+	return autoConvert_kops_CloudInitSpec_To_v1alpha2_CloudInitSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_Cluster_To_kops_Cluster(in *Cluster, out *kops.Cluster, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha2_ClusterSpec_To_kops_ClusterSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -2439,6 +3091,7 @@ func autoConvert_v1alpha2_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	}
 	out.SecretStore = in.SecretStore
 	out.KeyStore = in.KeyStore
+	out.KeyStoreKMSKey = in.KeyStoreKMSKey
 	out.ConfigStore = in.ConfigStore
 	out.DNSZone = in.DNSZone
 	if in.DNSControllerGossipConfig != nil {
@@ -2450,6 +3103,15 @@ func autoConvert_v1alpha2_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	} else {
 		out.DNSControllerGossipConfig = nil
 	}
+	if in.DNSRecords != nil {
+		in, out := &in.DNSRecords, &out.DNSRecords
+		*out = new(kops.DNSRecordsSpec)
+		if err := Convert_v1alpha2_DNSRecordsSpec_To_kops_DNSRecordsSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.DNSRecords = nil
+	}
 	out.AdditionalSANs = in.AdditionalSANs
 	out.ClusterDNSDomain = in.ClusterDNSDomain
 	out.ServiceClusterIPRange = in.ServiceClusterIPRange
@@ -2466,6 +3128,7 @@ func autoConvert_v1alpha2_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	} else {
 		out.EgressProxy = nil
 	}
+	out.DisableConnectivityChecks = in.DisableConnectivityChecks
 	out.SSHKeyName = in.SSHKeyName
 	out.KubernetesAPIAccess = in.KubernetesAPIAccess
 	out.IsolateMasters = in.IsolateMasters
@@ -2503,6 +3166,15 @@ func autoConvert_v1alpha2_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	} else {
 		out.Containerd = nil
 	}
+	if in.Crio != nil {
+		in, out := &in.Crio, &out.Crio
+		*out = new(kops.CrioConfig)
+		if err := Convert_v1alpha2_CrioConfig_To_kops_CrioConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Crio = nil
+	}
 	if in.Docker != nil {
 		in, out := &in.Docker, &out.Docker
 		*out = new(kops.DockerConfig)
@@ -2629,6 +3301,15 @@ func autoConvert_v1alpha2_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	} else {
 		out.NodeProblemDetector = nil
 	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(kops.MonitoringSpec)
+		if err := Convert_v1alpha2_MonitoringSpec_To_kops_MonitoringSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Monitoring = nil
+	}
 	if in.MetricsServer != nil {
 		in, out := &in.MetricsServer, &out.MetricsServer
 		*out = new(kops.MetricsServerConfig)
@@ -2701,7 +3382,25 @@ func autoConvert_v1alpha2_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	} else {
 		out.NodeAuthorization = nil
 	}
+	if in.NodeCertificates != nil {
+		in, out := &in.NodeCertificates, &out.NodeCertificates
+		*out = new(kops.NodeCertificatesSpec)
+		if err := Convert_v1alpha2_NodeCertificatesSpec_To_kops_NodeCertificatesSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.NodeCertificates = nil
+	}
 	out.CloudLabels = in.CloudLabels
+	if in.NodeLabelsFromCloudTags != nil {
+		in, out := &in.NodeLabelsFromCloudTags, &out.NodeLabelsFromCloudTags
+		*out = new(kops.NodeLabelsFromCloudTagsSpec)
+		if err := Convert_v1alpha2_NodeLabelsFromCloudTagsSpec_To_kops_NodeLabelsFromCloudTagsSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.NodeLabelsFromCloudTags = nil
+	}
 	if in.Hooks != nil {
 		in, out := &in.Hooks, &out.Hooks
 		*out = make([]kops.HookSpec, len(*in))
@@ -2753,6 +3452,17 @@ func autoConvert_v1alpha2_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	} else {
 		out.RollingUpdate = nil
 	}
+	if in.ValidationChecks != nil {
+		in, out := &in.ValidationChecks, &out.ValidationChecks
+		*out = make([]kops.ValidationCheck, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_ValidationCheck_To_kops_ValidationCheck(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.ValidationChecks = nil
+	}
 	if in.ClusterAutoscaler != nil {
 		in, out := &in.ClusterAutoscaler, &out.ClusterAutoscaler
 		*out = new(kops.ClusterAutoscalerConfig)
@@ -2771,6 +3481,15 @@ func autoConvert_v1alpha2_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	} else {
 		out.WarmPool = nil
 	}
+	if in.InstanceMetadata != nil {
+		in, out := &in.InstanceMetadata, &out.InstanceMetadata
+		*out = new(kops.InstanceMetadataOptions)
+		if err := Convert_v1alpha2_InstanceMetadataOptions_To_kops_InstanceMetadataOptions(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.InstanceMetadata = nil
+	}
 	if in.ServiceAccountIssuerDiscovery != nil {
 		in, out := &in.ServiceAccountIssuerDiscovery, &out.ServiceAccountIssuerDiscovery
 		*out = new(kops.ServiceAccountIssuerDiscoveryConfig)
@@ -2807,6 +3526,15 @@ func autoConvert_v1alpha2_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	} else {
 		out.PodIdentityWebhook = nil
 	}
+	if in.CertificateIssuer != nil {
+		in, out := &in.CertificateIssuer, &out.CertificateIssuer
+		*out = new(kops.CertificateIssuerSpec)
+		if err := Convert_v1alpha2_CertificateIssuerSpec_To_kops_CertificateIssuerSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CertificateIssuer = nil
+	}
 	return nil
 }
 
@@ -2864,6 +3592,7 @@ func autoConvert_kops_ClusterSpec_To_v1alpha2_ClusterSpec(in *kops.ClusterSpec,
 	}
 	out.SecretStore = in.SecretStore
 	out.KeyStore = in.KeyStore
+	out.KeyStoreKMSKey = in.KeyStoreKMSKey
 	out.ConfigStore = in.ConfigStore
 	out.DNSZone = in.DNSZone
 	if in.DNSControllerGossipConfig != nil {
@@ -2875,6 +3604,15 @@ func autoConvert_kops_ClusterSpec_To_v1alpha2_ClusterSpec(in *kops.ClusterSpec,
 	} else {
 		out.DNSControllerGossipConfig = nil
 	}
+	if in.DNSRecords != nil {
+		in, out := &in.DNSRecords, &out.DNSRecords
+		*out = new(DNSRecordsSpec)
+		if err := Convert_kops_DNSRecordsSpec_To_v1alpha2_DNSRecordsSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.DNSRecords = nil
+	}
 	out.AdditionalSANs = in.AdditionalSANs
 	out.ClusterDNSDomain = in.ClusterDNSDomain
 	out.ServiceClusterIPRange = in.ServiceClusterIPRange
@@ -2891,6 +3629,7 @@ func autoConvert_kops_ClusterSpec_To_v1alpha2_ClusterSpec(in *kops.ClusterSpec,
 	} else {
 		out.EgressProxy = nil
 	}
+	out.DisableConnectivityChecks = in.DisableConnectivityChecks
 	out.SSHKeyName = in.SSHKeyName
 	out.KubernetesAPIAccess = in.KubernetesAPIAccess
 	out.IsolateMasters = in.IsolateMasters
@@ -2928,6 +3667,15 @@ func autoConvert_kops_ClusterSpec_To_v1alpha2_ClusterSpec(in *kops.ClusterSpec,
 	} else {
 		out.Containerd = nil
 	}
+	if in.Crio != nil {
+		in, out := &in.Crio, &out.Crio
+		*out = new(CrioConfig)
+		if err := Convert_kops_CrioConfig_To_v1alpha2_CrioConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Crio = nil
+	}
 	if in.Docker != nil {
 		in, out := &in.Docker, &out.Docker
 		*out = new(DockerConfig)
@@ -3054,6 +3802,15 @@ func autoConvert_kops_ClusterSpec_To_v1alpha2_ClusterSpec(in *kops.ClusterSpec,
 	} else {
 		out.NodeProblemDetector = nil
 	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(MonitoringSpec)
+		if err := Convert_kops_MonitoringSpec_To_v1alpha2_MonitoringSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Monitoring = nil
+	}
 	if in.MetricsServer != nil {
 		in, out := &in.MetricsServer, &out.MetricsServer
 		*out = new(MetricsServerConfig)
@@ -3126,7 +3883,25 @@ func autoConvert_kops_ClusterSpec_To_v1alpha2_ClusterSpec(in *kops.ClusterSpec,
 	} else {
 		out.NodeAuthorization = nil
 	}
+	if in.NodeCertificates != nil {
+		in, out := &in.NodeCertificates, &out.NodeCertificates
+		*out = new(NodeCertificatesSpec)
+		if err := Convert_kops_NodeCertificatesSpec_To_v1alpha2_NodeCertificatesSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.NodeCertificates = nil
+	}
 	out.CloudLabels = in.CloudLabels
+	if in.NodeLabelsFromCloudTags != nil {
+		in, out := &in.NodeLabelsFromCloudTags, &out.NodeLabelsFromCloudTags
+		*out = new(NodeLabelsFromCloudTagsSpec)
+		if err := Convert_kops_NodeLabelsFromCloudTagsSpec_To_v1alpha2_NodeLabelsFromCloudTagsSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.NodeLabelsFromCloudTags = nil
+	}
 	if in.Hooks != nil {
 		in, out := &in.Hooks, &out.Hooks
 		*out = make([]HookSpec, len(*in))
@@ -3178,6 +3953,17 @@ func autoConvert_kops_ClusterSpec_To_v1alpha2_ClusterSpec(in *kops.ClusterSpec,
 	} else {
 		out.RollingUpdate = nil
 	}
+	if in.ValidationChecks != nil {
+		in, out := &in.ValidationChecks, &out.ValidationChecks
+		*out = make([]ValidationCheck, len(*in))
+		for i := range *in {
+			if err := Convert_kops_ValidationCheck_To_v1alpha2_ValidationCheck(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.ValidationChecks = nil
+	}
 	if in.ClusterAutoscaler != nil {
 		in, out := &in.ClusterAutoscaler, &out.ClusterAutoscaler
 		*out = new(ClusterAutoscalerConfig)
@@ -3196,6 +3982,15 @@ func autoConvert_kops_ClusterSpec_To_v1alpha2_ClusterSpec(in *kops.ClusterSpec,
 	} else {
 		out.WarmPool = nil
 	}
+	if in.InstanceMetadata != nil {
+		in, out := &in.InstanceMetadata, &out.InstanceMetadata
+		*out = new(InstanceMetadataOptions)
+		if err := Convert_kops_InstanceMetadataOptions_To_v1alpha2_InstanceMetadataOptions(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.InstanceMetadata = nil
+	}
 	if in.ServiceAccountIssuerDiscovery != nil {
 		in, out := &in.ServiceAccountIssuerDiscovery, &out.ServiceAccountIssuerDiscovery
 		*out = new(ServiceAccountIssuerDiscoveryConfig)
@@ -3232,6 +4027,15 @@ func autoConvert_kops_ClusterSpec_To_v1alpha2_ClusterSpec(in *kops.ClusterSpec,
 	} else {
 		out.PodIdentityWebhook = nil
 	}
+	if in.CertificateIssuer != nil {
+		in, out := &in.CertificateIssuer, &out.CertificateIssuer
+		*out = new(CertificateIssuerSpec)
+		if err := Convert_kops_CertificateIssuerSpec_To_v1alpha2_CertificateIssuerSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CertificateIssuer = nil
+	}
 	return nil
 }
 
@@ -3307,6 +4111,20 @@ func autoConvert_v1alpha2_ContainerdConfig_To_kops_ContainerdConfig(in *Containe
 		out.Packages = nil
 	}
 	out.RegistryMirrors = in.RegistryMirrors
+	if in.Registries != nil {
+		in, out := &in.Registries, &out.Registries
+		*out = make(map[string]kops.ContainerdRegistryConfig, len(*in))
+		for key, val := range *in {
+			newVal := new(kops.ContainerdRegistryConfig)
+			if err := Convert_v1alpha2_ContainerdRegistryConfig_To_kops_ContainerdRegistryConfig(&val, newVal, s); err != nil {
+				return err
+			}
+			(*out)[key] = *newVal
+		}
+	} else {
+		out.Registries = nil
+	}
+	out.ConfigAdditions = in.ConfigAdditions
 	out.Root = in.Root
 	out.SkipInstall = in.SkipInstall
 	out.State = in.State
@@ -3320,6 +4138,15 @@ func autoConvert_v1alpha2_ContainerdConfig_To_kops_ContainerdConfig(in *Containe
 	} else {
 		out.NvidiaGPU = nil
 	}
+	if in.Runc != nil {
+		in, out := &in.Runc, &out.Runc
+		*out = new(kops.Runc)
+		if err := Convert_v1alpha2_Runc_To_kops_Runc(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Runc = nil
+	}
 	return nil
 }
 
@@ -3342,6 +4169,20 @@ func autoConvert_kops_ContainerdConfig_To_v1alpha2_ContainerdConfig(in *kops.Con
 		out.Packages = nil
 	}
 	out.RegistryMirrors = in.RegistryMirrors
+	if in.Registries != nil {
+		in, out := &in.Registries, &out.Registries
+		*out = make(map[string]ContainerdRegistryConfig, len(*in))
+		for key, val := range *in {
+			newVal := new(ContainerdRegistryConfig)
+			if err := Convert_kops_ContainerdRegistryConfig_To_v1alpha2_ContainerdRegistryConfig(&val, newVal, s); err != nil {
+				return err
+			}
+			(*out)[key] = *newVal
+		}
+	} else {
+		out.Registries = nil
+	}
+	out.ConfigAdditions = in.ConfigAdditions
 	out.Root = in.Root
 	out.SkipInstall = in.SkipInstall
 	out.State = in.State
@@ -3355,6 +4196,15 @@ func autoConvert_kops_ContainerdConfig_To_v1alpha2_ContainerdConfig(in *kops.Con
 	} else {
 		out.NvidiaGPU = nil
 	}
+	if in.Runc != nil {
+		in, out := &in.Runc, &out.Runc
+		*out = new(Runc)
+		if err := Convert_kops_Runc_To_v1alpha2_Runc(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Runc = nil
+	}
 	return nil
 }
 
@@ -3363,6 +4213,152 @@ func Convert_kops_ContainerdConfig_To_v1alpha2_ContainerdConfig(in *kops.Contain
 	return autoConvert_kops_ContainerdConfig_To_v1alpha2_ContainerdConfig(in, out, s)
 }
 
+func autoConvert_v1alpha2_ContainerdRegistryAuth_To_kops_ContainerdRegistryAuth(in *ContainerdRegistryAuth, out *kops.ContainerdRegistryAuth, s conversion.Scope) error {
+	out.Username = in.Username
+	out.PasswordSecret = in.PasswordSecret
+	return nil
+}
+
+// Convert_v1alpha2_ContainerdRegistryAuth_To_kops_ContainerdRegistryAuth is an autogenerated conversion function.
+func Convert_v1alpha2_ContainerdRegistryAuth_To_kops_ContainerdRegistryAuth(in *ContainerdRegistryAuth, out *kops.ContainerdRegistryAuth, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ContainerdRegistryAuth_To_kops_ContainerdRegistryAuth(in, out, s)
+}
+
+func autoConvert_kops_ContainerdRegistryAuth_To_v1alpha2_ContainerdRegistryAuth(in *kops.ContainerdRegistryAuth, out *ContainerdRegistryAuth, s conversion.Scope) error {
+	out.Username = in.Username
+	out.PasswordSecret = in.PasswordSecret
+	return nil
+}
+
+// Convert_kops_ContainerdRegistryAuth_To_v1alpha2_ContainerdRegistryAuth is an autogenerated conversion function.
+func Convert_kops_ContainerdRegistryAuth_To_v1alpha2_ContainerdRegistryAuth(in *kops.ContainerdRegistryAuth, out *ContainerdRegistryAuth, s conversion.Scope) error {
+	return autoConvert_kops_ContainerdRegistryAuth_To_v1alpha2_ContainerdRegistryAuth(in, out, s)
+}
+
+func autoConvert_v1alpha2_ContainerdRegistryConfig_To_kops_ContainerdRegistryConfig(in *ContainerdRegistryConfig, out *kops.ContainerdRegistryConfig, s conversion.Scope) error {
+	if in.Mirrors != nil {
+		in, out := &in.Mirrors, &out.Mirrors
+		*out = make([]kops.ContainerdRegistryMirror, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_ContainerdRegistryMirror_To_kops_ContainerdRegistryMirror(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Mirrors = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha2_ContainerdRegistryConfig_To_kops_ContainerdRegistryConfig is an autogenerated conversion function.
+func Convert_v1alpha2_ContainerdRegistryConfig_To_kops_ContainerdRegistryConfig(in *ContainerdRegistryConfig, out *kops.ContainerdRegistryConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ContainerdRegistryConfig_To_kops_ContainerdRegistryConfig(in, out, s)
+}
+
+func autoConvert_kops_ContainerdRegistryConfig_To_v1alpha2_ContainerdRegistryConfig(in *kops.ContainerdRegistryConfig, out *ContainerdRegistryConfig, s conversion.Scope) error {
+	if in.Mirrors != nil {
+		in, out := &in.Mirrors, &out.Mirrors
+		*out = make([]ContainerdRegistryMirror, len(*in))
+		for i := range *in {
+			if err := Convert_kops_ContainerdRegistryMirror_To_v1alpha2_ContainerdRegistryMirror(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Mirrors = nil
+	}
+	return nil
+}
+
+// Convert_kops_ContainerdRegistryConfig_To_v1alpha2_ContainerdRegistryConfig is an autogenerated conversion function.
+func Convert_kops_ContainerdRegistryConfig_To_v1alpha2_ContainerdRegistryConfig(in *kops.ContainerdRegistryConfig, out *ContainerdRegistryConfig, s conversion.Scope) error {
+	return autoConvert_kops_ContainerdRegistryConfig_To_v1alpha2_ContainerdRegistryConfig(in, out, s)
+}
+
+func autoConvert_v1alpha2_ContainerdRegistryMirror_To_kops_ContainerdRegistryMirror(in *ContainerdRegistryMirror, out *kops.ContainerdRegistryMirror, s conversion.Scope) error {
+	out.Endpoint = in.Endpoint
+	out.SkipVerify = in.SkipVerify
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(kops.ContainerdRegistryAuth)
+		if err := Convert_v1alpha2_ContainerdRegistryAuth_To_kops_ContainerdRegistryAuth(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Auth = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha2_ContainerdRegistryMirror_To_kops_ContainerdRegistryMirror is an autogenerated conversion function.
+func Convert_v1alpha2_ContainerdRegistryMirror_To_kops_ContainerdRegistryMirror(in *ContainerdRegistryMirror, out *kops.ContainerdRegistryMirror, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ContainerdRegistryMirror_To_kops_ContainerdRegistryMirror(in, out, s)
+}
+
+func autoConvert_kops_ContainerdRegistryMirror_To_v1alpha2_ContainerdRegistryMirror(in *kops.ContainerdRegistryMirror, out *ContainerdRegistryMirror, s conversion.Scope) error {
+	out.Endpoint = in.Endpoint
+	out.SkipVerify = in.SkipVerify
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(ContainerdRegistryAuth)
+		if err := Convert_kops_ContainerdRegistryAuth_To_v1alpha2_ContainerdRegistryAuth(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Auth = nil
+	}
+	return nil
+}
+
+// Convert_kops_ContainerdRegistryMirror_To_v1alpha2_ContainerdRegistryMirror is an autogenerated conversion function.
+func Convert_kops_ContainerdRegistryMirror_To_v1alpha2_ContainerdRegistryMirror(in *kops.ContainerdRegistryMirror, out *ContainerdRegistryMirror, s conversion.Scope) error {
+	return autoConvert_kops_ContainerdRegistryMirror_To_v1alpha2_ContainerdRegistryMirror(in, out, s)
+}
+
+func autoConvert_v1alpha2_CrioConfig_To_kops_CrioConfig(in *CrioConfig, out *kops.CrioConfig, s conversion.Scope) error {
+	out.ConfigOverride = in.ConfigOverride
+	out.LogLevel = in.LogLevel
+	if in.Packages != nil {
+		in, out := &in.Packages, &out.Packages
+		*out = new(kops.PackagesConfig)
+		if err := Convert_v1alpha2_PackagesConfig_To_kops_PackagesConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Packages = nil
+	}
+	out.SkipInstall = in.SkipInstall
+	out.Version = in.Version
+	return nil
+}
+
+// Convert_v1alpha2_CrioConfig_To_kops_CrioConfig is an autogenerated conversion function.
+func Convert_v1alpha2_CrioConfig_To_kops_CrioConfig(in *CrioConfig, out *kops.CrioConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CrioConfig_To_kops_CrioConfig(in, out, s)
+}
+
+func autoConvert_kops_CrioConfig_To_v1alpha2_CrioConfig(in *kops.CrioConfig, out *CrioConfig, s conversion.Scope) error {
+	out.ConfigOverride = in.ConfigOverride
+	out.LogLevel = in.LogLevel
+	if in.Packages != nil {
+		in, out := &in.Packages, &out.Packages
+		*out = new(PackagesConfig)
+		if err := Convert_kops_PackagesConfig_To_v1alpha2_PackagesConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Packages = nil
+	}
+	out.SkipInstall = in.SkipInstall
+	out.Version = in.Version
+	return nil
+}
+
+// Convert_kops_CrioConfig_To_v1alpha2_CrioConfig is an autogenerated conversion function.
+func Convert_kops_CrioConfig_To_v1alpha2_CrioConfig(in *kops.CrioConfig, out *CrioConfig, s conversion.Scope) error {
+	return autoConvert_kops_CrioConfig_To_v1alpha2_CrioConfig(in, out, s)
+}
+
 func autoConvert_v1alpha2_DNSAccessSpec_To_kops_DNSAccessSpec(in *DNSAccessSpec, out *kops.DNSAccessSpec, s conversion.Scope) error {
 	return nil
 }
@@ -3395,6 +4391,9 @@ func autoConvert_v1alpha2_DNSControllerGossipConfig_To_kops_DNSControllerGossipC
 		out.Secondary = nil
 	}
 	out.Seed = in.Seed
+	out.ProbeInterval = in.ProbeInterval
+	out.GossipInterval = in.GossipInterval
+	out.PushPullInterval = in.PushPullInterval
 	return nil
 }
 
@@ -3417,6 +4416,9 @@ func autoConvert_kops_DNSControllerGossipConfig_To_v1alpha2_DNSControllerGossipC
 		out.Secondary = nil
 	}
 	out.Seed = in.Seed
+	out.ProbeInterval = in.ProbeInterval
+	out.GossipInterval = in.GossipInterval
+	out.PushPullInterval = in.PushPullInterval
 	return nil
 }
 
@@ -3446,9 +4448,121 @@ func autoConvert_kops_DNSControllerGossipConfigSecondary_To_v1alpha2_DNSControll
 	return nil
 }
 
-// Convert_kops_DNSControllerGossipConfigSecondary_To_v1alpha2_DNSControllerGossipConfigSecondary is an autogenerated conversion function.
-func Convert_kops_DNSControllerGossipConfigSecondary_To_v1alpha2_DNSControllerGossipConfigSecondary(in *kops.DNSControllerGossipConfigSecondary, out *DNSControllerGossipConfigSecondary, s conversion.Scope) error {
-	return autoConvert_kops_DNSControllerGossipConfigSecondary_To_v1alpha2_DNSControllerGossipConfigSecondary(in, out, s)
+// Convert_kops_DNSControllerGossipConfigSecondary_To_v1alpha2_DNSControllerGossipConfigSecondary is an autogenerated conversion function.
+func Convert_kops_DNSControllerGossipConfigSecondary_To_v1alpha2_DNSControllerGossipConfigSecondary(in *kops.DNSControllerGossipConfigSecondary, out *DNSControllerGossipConfigSecondary, s conversion.Scope) error {
+	return autoConvert_kops_DNSControllerGossipConfigSecondary_To_v1alpha2_DNSControllerGossipConfigSecondary(in, out, s)
+}
+
+func autoConvert_v1alpha2_DNSRecordSpec_To_kops_DNSRecordSpec(in *DNSRecordSpec, out *kops.DNSRecordSpec, s conversion.Scope) error {
+	out.Type = kops.DNSRecordType(in.Type)
+	out.TTL = in.TTL
+	return nil
+}
+
+// Convert_v1alpha2_DNSRecordSpec_To_kops_DNSRecordSpec is an autogenerated conversion function.
+func Convert_v1alpha2_DNSRecordSpec_To_kops_DNSRecordSpec(in *DNSRecordSpec, out *kops.DNSRecordSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_DNSRecordSpec_To_kops_DNSRecordSpec(in, out, s)
+}
+
+func autoConvert_kops_DNSRecordSpec_To_v1alpha2_DNSRecordSpec(in *kops.DNSRecordSpec, out *DNSRecordSpec, s conversion.Scope) error {
+	out.Type = DNSRecordType(in.Type)
+	out.TTL = in.TTL
+	return nil
+}
+
+// Convert_kops_DNSRecordSpec_To_v1alpha2_DNSRecordSpec is an autogenerated conversion function.
+func Convert_kops_DNSRecordSpec_To_v1alpha2_DNSRecordSpec(in *kops.DNSRecordSpec, out *DNSRecordSpec, s conversion.Scope) error {
+	return autoConvert_kops_DNSRecordSpec_To_v1alpha2_DNSRecordSpec(in, out, s)
+}
+
+func autoConvert_v1alpha2_DNSRecordsSpec_To_kops_DNSRecordsSpec(in *DNSRecordsSpec, out *kops.DNSRecordsSpec, s conversion.Scope) error {
+	if in.API != nil {
+		in, out := &in.API, &out.API
+		*out = new(kops.DNSRecordSpec)
+		if err := Convert_v1alpha2_DNSRecordSpec_To_kops_DNSRecordSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.API = nil
+	}
+	if in.APIInternal != nil {
+		in, out := &in.APIInternal, &out.APIInternal
+		*out = new(kops.DNSRecordSpec)
+		if err := Convert_v1alpha2_DNSRecordSpec_To_kops_DNSRecordSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.APIInternal = nil
+	}
+	if in.Bastion != nil {
+		in, out := &in.Bastion, &out.Bastion
+		*out = new(kops.DNSRecordSpec)
+		if err := Convert_v1alpha2_DNSRecordSpec_To_kops_DNSRecordSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Bastion = nil
+	}
+	if in.KopsController != nil {
+		in, out := &in.KopsController, &out.KopsController
+		*out = new(kops.DNSRecordSpec)
+		if err := Convert_v1alpha2_DNSRecordSpec_To_kops_DNSRecordSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.KopsController = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha2_DNSRecordsSpec_To_kops_DNSRecordsSpec is an autogenerated conversion function.
+func Convert_v1alpha2_DNSRecordsSpec_To_kops_DNSRecordsSpec(in *DNSRecordsSpec, out *kops.DNSRecordsSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_DNSRecordsSpec_To_kops_DNSRecordsSpec(in, out, s)
+}
+
+func autoConvert_kops_DNSRecordsSpec_To_v1alpha2_DNSRecordsSpec(in *kops.DNSRecordsSpec, out *DNSRecordsSpec, s conversion.Scope) error {
+	if in.API != nil {
+		in, out := &in.API, &out.API
+		*out = new(DNSRecordSpec)
+		if err := Convert_kops_DNSRecordSpec_To_v1alpha2_DNSRecordSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.API = nil
+	}
+	if in.APIInternal != nil {
+		in, out := &in.APIInternal, &out.APIInternal
+		*out = new(DNSRecordSpec)
+		if err := Convert_kops_DNSRecordSpec_To_v1alpha2_DNSRecordSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.APIInternal = nil
+	}
+	if in.Bastion != nil {
+		in, out := &in.Bastion, &out.Bastion
+		*out = new(DNSRecordSpec)
+		if err := Convert_kops_DNSRecordSpec_To_v1alpha2_DNSRecordSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Bastion = nil
+	}
+	if in.KopsController != nil {
+		in, out := &in.KopsController, &out.KopsController
+		*out = new(DNSRecordSpec)
+		if err := Convert_kops_DNSRecordSpec_To_v1alpha2_DNSRecordSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.KopsController = nil
+	}
+	return nil
+}
+
+// Convert_kops_DNSRecordsSpec_To_v1alpha2_DNSRecordsSpec is an autogenerated conversion function.
+func Convert_kops_DNSRecordsSpec_To_v1alpha2_DNSRecordsSpec(in *kops.DNSRecordsSpec, out *DNSRecordsSpec, s conversion.Scope) error {
+	return autoConvert_kops_DNSRecordsSpec_To_v1alpha2_DNSRecordsSpec(in, out, s)
 }
 
 func autoConvert_v1alpha2_DNSSpec_To_kops_DNSSpec(in *DNSSpec, out *kops.DNSSpec, s conversion.Scope) error {
@@ -3573,6 +4687,28 @@ func Convert_kops_DockerConfig_To_v1alpha2_DockerConfig(in *kops.DockerConfig, o
 	return autoConvert_kops_DockerConfig_To_v1alpha2_DockerConfig(in, out, s)
 }
 
+func autoConvert_v1alpha2_EFASpec_To_kops_EFASpec(in *EFASpec, out *kops.EFASpec, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.NetworkCards = in.NetworkCards
+	return nil
+}
+
+// Convert_v1alpha2_EFASpec_To_kops_EFASpec is an autogenerated conversion function.
+func Convert_v1alpha2_EFASpec_To_kops_EFASpec(in *EFASpec, out *kops.EFASpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_EFASpec_To_kops_EFASpec(in, out, s)
+}
+
+func autoConvert_kops_EFASpec_To_v1alpha2_EFASpec(in *kops.EFASpec, out *EFASpec, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.NetworkCards = in.NetworkCards
+	return nil
+}
+
+// Convert_kops_EFASpec_To_v1alpha2_EFASpec is an autogenerated conversion function.
+func Convert_kops_EFASpec_To_v1alpha2_EFASpec(in *kops.EFASpec, out *EFASpec, s conversion.Scope) error {
+	return autoConvert_kops_EFASpec_To_v1alpha2_EFASpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_EgressProxySpec_To_kops_EgressProxySpec(in *EgressProxySpec, out *kops.EgressProxySpec, s conversion.Scope) error {
 	if err := Convert_v1alpha2_HTTPProxy_To_kops_HTTPProxy(&in.HTTPProxy, &out.HTTPProxy, s); err != nil {
 		return err
@@ -3624,6 +4760,15 @@ func Convert_kops_EnvVar_To_v1alpha2_EnvVar(in *kops.EnvVar, out *EnvVar, s conv
 func autoConvert_v1alpha2_EtcdBackupSpec_To_kops_EtcdBackupSpec(in *EtcdBackupSpec, out *kops.EtcdBackupSpec, s conversion.Scope) error {
 	out.BackupStore = in.BackupStore
 	out.Image = in.Image
+	if in.CrossAccountStore != nil {
+		in, out := &in.CrossAccountStore, &out.CrossAccountStore
+		*out = new(kops.EtcdCrossAccountStoreSpec)
+		if err := Convert_v1alpha2_EtcdCrossAccountStoreSpec_To_kops_EtcdCrossAccountStoreSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CrossAccountStore = nil
+	}
 	return nil
 }
 
@@ -3635,6 +4780,15 @@ func Convert_v1alpha2_EtcdBackupSpec_To_kops_EtcdBackupSpec(in *EtcdBackupSpec,
 func autoConvert_kops_EtcdBackupSpec_To_v1alpha2_EtcdBackupSpec(in *kops.EtcdBackupSpec, out *EtcdBackupSpec, s conversion.Scope) error {
 	out.BackupStore = in.BackupStore
 	out.Image = in.Image
+	if in.CrossAccountStore != nil {
+		in, out := &in.CrossAccountStore, &out.CrossAccountStore
+		*out = new(EtcdCrossAccountStoreSpec)
+		if err := Convert_kops_EtcdCrossAccountStoreSpec_To_v1alpha2_EtcdCrossAccountStoreSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CrossAccountStore = nil
+	}
 	return nil
 }
 
@@ -3662,6 +4816,10 @@ func autoConvert_v1alpha2_EtcdClusterSpec_To_kops_EtcdClusterSpec(in *EtcdCluste
 	out.Version = in.Version
 	out.LeaderElectionTimeout = in.LeaderElectionTimeout
 	out.HeartbeatInterval = in.HeartbeatInterval
+	out.QuotaBackendBytes = in.QuotaBackendBytes
+	out.AutoCompactionMode = in.AutoCompactionMode
+	out.AutoCompactionRetention = in.AutoCompactionRetention
+	out.SnapshotCount = in.SnapshotCount
 	out.Image = in.Image
 	if in.Backups != nil {
 		in, out := &in.Backups, &out.Backups
@@ -3691,6 +4849,18 @@ func Convert_v1alpha2_EtcdClusterSpec_To_kops_EtcdClusterSpec(in *EtcdClusterSpe
 	return autoConvert_v1alpha2_EtcdClusterSpec_To_kops_EtcdClusterSpec(in, out, s)
 }
 
+func autoConvert_v1alpha2_EtcdCrossAccountStoreSpec_To_kops_EtcdCrossAccountStoreSpec(in *EtcdCrossAccountStoreSpec, out *kops.EtcdCrossAccountStoreSpec, s conversion.Scope) error {
+	out.BackupStore = in.BackupStore
+	out.RoleARN = in.RoleARN
+	out.ExternalID = in.ExternalID
+	return nil
+}
+
+// Convert_v1alpha2_EtcdCrossAccountStoreSpec_To_kops_EtcdCrossAccountStoreSpec is an autogenerated conversion function.
+func Convert_v1alpha2_EtcdCrossAccountStoreSpec_To_kops_EtcdCrossAccountStoreSpec(in *EtcdCrossAccountStoreSpec, out *kops.EtcdCrossAccountStoreSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_EtcdCrossAccountStoreSpec_To_kops_EtcdCrossAccountStoreSpec(in, out, s)
+}
+
 func autoConvert_kops_EtcdClusterSpec_To_v1alpha2_EtcdClusterSpec(in *kops.EtcdClusterSpec, out *EtcdClusterSpec, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Provider = EtcdProviderType(in.Provider)
@@ -3708,6 +4878,10 @@ func autoConvert_kops_EtcdClusterSpec_To_v1alpha2_EtcdClusterSpec(in *kops.EtcdC
 	out.Version = in.Version
 	out.LeaderElectionTimeout = in.LeaderElectionTimeout
 	out.HeartbeatInterval = in.HeartbeatInterval
+	out.QuotaBackendBytes = in.QuotaBackendBytes
+	out.AutoCompactionMode = in.AutoCompactionMode
+	out.AutoCompactionRetention = in.AutoCompactionRetention
+	out.SnapshotCount = in.SnapshotCount
 	out.Image = in.Image
 	if in.Backups != nil {
 		in, out := &in.Backups, &out.Backups
@@ -3737,6 +4911,18 @@ func Convert_kops_EtcdClusterSpec_To_v1alpha2_EtcdClusterSpec(in *kops.EtcdClust
 	return autoConvert_kops_EtcdClusterSpec_To_v1alpha2_EtcdClusterSpec(in, out, s)
 }
 
+func autoConvert_kops_EtcdCrossAccountStoreSpec_To_v1alpha2_EtcdCrossAccountStoreSpec(in *kops.EtcdCrossAccountStoreSpec, out *EtcdCrossAccountStoreSpec, s conversion.Scope) error {
+	out.BackupStore = in.BackupStore
+	out.RoleARN = in.RoleARN
+	out.ExternalID = in.ExternalID
+	return nil
+}
+
+// Convert_kops_EtcdCrossAccountStoreSpec_To_v1alpha2_EtcdCrossAccountStoreSpec is an autogenerated conversion function.
+func Convert_kops_EtcdCrossAccountStoreSpec_To_v1alpha2_EtcdCrossAccountStoreSpec(in *kops.EtcdCrossAccountStoreSpec, out *EtcdCrossAccountStoreSpec, s conversion.Scope) error {
+	return autoConvert_kops_EtcdCrossAccountStoreSpec_To_v1alpha2_EtcdCrossAccountStoreSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_EtcdManagerSpec_To_kops_EtcdManagerSpec(in *EtcdManagerSpec, out *kops.EtcdManagerSpec, s conversion.Scope) error {
 	out.Image = in.Image
 	if in.Env != nil {
@@ -3817,6 +5003,72 @@ func Convert_kops_EtcdMemberSpec_To_v1alpha2_EtcdMemberSpec(in *kops.EtcdMemberS
 	return autoConvert_kops_EtcdMemberSpec_To_v1alpha2_EtcdMemberSpec(in, out, s)
 }
 
+func autoConvert_v1alpha2_EventRateLimit_To_kops_EventRateLimit(in *EventRateLimit, out *kops.EventRateLimit, s conversion.Scope) error {
+	out.Type = in.Type
+	out.QPS = in.QPS
+	out.Burst = in.Burst
+	out.CacheSize = in.CacheSize
+	return nil
+}
+
+// Convert_v1alpha2_EventRateLimit_To_kops_EventRateLimit is an autogenerated conversion function.
+func Convert_v1alpha2_EventRateLimit_To_kops_EventRateLimit(in *EventRateLimit, out *kops.EventRateLimit, s conversion.Scope) error {
+	return autoConvert_v1alpha2_EventRateLimit_To_kops_EventRateLimit(in, out, s)
+}
+
+func autoConvert_kops_EventRateLimit_To_v1alpha2_EventRateLimit(in *kops.EventRateLimit, out *EventRateLimit, s conversion.Scope) error {
+	out.Type = in.Type
+	out.QPS = in.QPS
+	out.Burst = in.Burst
+	out.CacheSize = in.CacheSize
+	return nil
+}
+
+// Convert_kops_EventRateLimit_To_v1alpha2_EventRateLimit is an autogenerated conversion function.
+func Convert_kops_EventRateLimit_To_v1alpha2_EventRateLimit(in *kops.EventRateLimit, out *EventRateLimit, s conversion.Scope) error {
+	return autoConvert_kops_EventRateLimit_To_v1alpha2_EventRateLimit(in, out, s)
+}
+
+func autoConvert_v1alpha2_EventRateLimitAdmissionConfig_To_kops_EventRateLimitAdmissionConfig(in *EventRateLimitAdmissionConfig, out *kops.EventRateLimitAdmissionConfig, s conversion.Scope) error {
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = make([]kops.EventRateLimit, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_EventRateLimit_To_kops_EventRateLimit(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Limits = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha2_EventRateLimitAdmissionConfig_To_kops_EventRateLimitAdmissionConfig is an autogenerated conversion function.
+func Convert_v1alpha2_EventRateLimitAdmissionConfig_To_kops_EventRateLimitAdmissionConfig(in *EventRateLimitAdmissionConfig, out *kops.EventRateLimitAdmissionConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha2_EventRateLimitAdmissionConfig_To_kops_EventRateLimitAdmissionConfig(in, out, s)
+}
+
+func autoConvert_kops_EventRateLimitAdmissionConfig_To_v1alpha2_EventRateLimitAdmissionConfig(in *kops.EventRateLimitAdmissionConfig, out *EventRateLimitAdmissionConfig, s conversion.Scope) error {
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = make([]EventRateLimit, len(*in))
+		for i := range *in {
+			if err := Convert_kops_EventRateLimit_To_v1alpha2_EventRateLimit(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Limits = nil
+	}
+	return nil
+}
+
+// Convert_kops_EventRateLimitAdmissionConfig_To_v1alpha2_EventRateLimitAdmissionConfig is an autogenerated conversion function.
+func Convert_kops_EventRateLimitAdmissionConfig_To_v1alpha2_EventRateLimitAdmissionConfig(in *kops.EventRateLimitAdmissionConfig, out *EventRateLimitAdmissionConfig, s conversion.Scope) error {
+	return autoConvert_kops_EventRateLimitAdmissionConfig_To_v1alpha2_EventRateLimitAdmissionConfig(in, out, s)
+}
+
 func autoConvert_v1alpha2_ExecContainerAction_To_kops_ExecContainerAction(in *ExecContainerAction, out *kops.ExecContainerAction, s conversion.Scope) error {
 	out.Image = in.Image
 	out.Command = in.Command
@@ -3920,6 +5172,28 @@ func Convert_kops_FileAssetSpec_To_v1alpha2_FileAssetSpec(in *kops.FileAssetSpec
 	return autoConvert_kops_FileAssetSpec_To_v1alpha2_FileAssetSpec(in, out, s)
 }
 
+func autoConvert_v1alpha2_FileTrustPolicy_To_kops_FileTrustPolicy(in *FileTrustPolicy, out *kops.FileTrustPolicy, s conversion.Scope) error {
+	out.URL = in.URL
+	out.Hash = in.Hash
+	return nil
+}
+
+// Convert_v1alpha2_FileTrustPolicy_To_kops_FileTrustPolicy is an autogenerated conversion function.
+func Convert_v1alpha2_FileTrustPolicy_To_kops_FileTrustPolicy(in *FileTrustPolicy, out *kops.FileTrustPolicy, s conversion.Scope) error {
+	return autoConvert_v1alpha2_FileTrustPolicy_To_kops_FileTrustPolicy(in, out, s)
+}
+
+func autoConvert_kops_FileTrustPolicy_To_v1alpha2_FileTrustPolicy(in *kops.FileTrustPolicy, out *FileTrustPolicy, s conversion.Scope) error {
+	out.URL = in.URL
+	out.Hash = in.Hash
+	return nil
+}
+
+// Convert_kops_FileTrustPolicy_To_v1alpha2_FileTrustPolicy is an autogenerated conversion function.
+func Convert_kops_FileTrustPolicy_To_v1alpha2_FileTrustPolicy(in *kops.FileTrustPolicy, out *FileTrustPolicy, s conversion.Scope) error {
+	return autoConvert_kops_FileTrustPolicy_To_v1alpha2_FileTrustPolicy(in, out, s)
+}
+
 func autoConvert_v1alpha2_FlannelNetworkingSpec_To_kops_FlannelNetworkingSpec(in *FlannelNetworkingSpec, out *kops.FlannelNetworkingSpec, s conversion.Scope) error {
 	out.Backend = in.Backend
 	// INFO: in.DisableTxChecksumOffloading opted out of conversion generation
@@ -3961,6 +5235,26 @@ func Convert_kops_GCENetworkingSpec_To_v1alpha2_GCENetworkingSpec(in *kops.GCENe
 	return autoConvert_kops_GCENetworkingSpec_To_v1alpha2_GCENetworkingSpec(in, out, s)
 }
 
+func autoConvert_v1alpha2_GCPCASIssuerSpec_To_kops_GCPCASIssuerSpec(in *GCPCASIssuerSpec, out *kops.GCPCASIssuerSpec, s conversion.Scope) error {
+	out.CAPool = in.CAPool
+	return nil
+}
+
+// Convert_v1alpha2_GCPCASIssuerSpec_To_kops_GCPCASIssuerSpec is an autogenerated conversion function.
+func Convert_v1alpha2_GCPCASIssuerSpec_To_kops_GCPCASIssuerSpec(in *GCPCASIssuerSpec, out *kops.GCPCASIssuerSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_GCPCASIssuerSpec_To_kops_GCPCASIssuerSpec(in, out, s)
+}
+
+func autoConvert_kops_GCPCASIssuerSpec_To_v1alpha2_GCPCASIssuerSpec(in *kops.GCPCASIssuerSpec, out *GCPCASIssuerSpec, s conversion.Scope) error {
+	out.CAPool = in.CAPool
+	return nil
+}
+
+// Convert_kops_GCPCASIssuerSpec_To_v1alpha2_GCPCASIssuerSpec is an autogenerated conversion function.
+func Convert_kops_GCPCASIssuerSpec_To_v1alpha2_GCPCASIssuerSpec(in *kops.GCPCASIssuerSpec, out *GCPCASIssuerSpec, s conversion.Scope) error {
+	return autoConvert_kops_GCPCASIssuerSpec_To_v1alpha2_GCPCASIssuerSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_GCPPDCSIDriver_To_kops_GCPPDCSIDriver(in *GCPPDCSIDriver, out *kops.GCPPDCSIDriver, s conversion.Scope) error {
 	out.Enabled = in.Enabled
 	return nil
@@ -3981,6 +5275,26 @@ func Convert_kops_GCPPDCSIDriver_To_v1alpha2_GCPPDCSIDriver(in *kops.GCPPDCSIDri
 	return autoConvert_kops_GCPPDCSIDriver_To_v1alpha2_GCPPDCSIDriver(in, out, s)
 }
 
+func autoConvert_v1alpha2_GCPPermission_To_kops_GCPPermission(in *GCPPermission, out *kops.GCPPermission, s conversion.Scope) error {
+	out.Roles = in.Roles
+	return nil
+}
+
+// Convert_v1alpha2_GCPPermission_To_kops_GCPPermission is an autogenerated conversion function.
+func Convert_v1alpha2_GCPPermission_To_kops_GCPPermission(in *GCPPermission, out *kops.GCPPermission, s conversion.Scope) error {
+	return autoConvert_v1alpha2_GCPPermission_To_kops_GCPPermission(in, out, s)
+}
+
+func autoConvert_kops_GCPPermission_To_v1alpha2_GCPPermission(in *kops.GCPPermission, out *GCPPermission, s conversion.Scope) error {
+	out.Roles = in.Roles
+	return nil
+}
+
+// Convert_kops_GCPPermission_To_v1alpha2_GCPPermission is an autogenerated conversion function.
+func Convert_kops_GCPPermission_To_v1alpha2_GCPPermission(in *kops.GCPPermission, out *GCPPermission, s conversion.Scope) error {
+	return autoConvert_kops_GCPPermission_To_v1alpha2_GCPPermission(in, out, s)
+}
+
 func autoConvert_v1alpha2_GossipConfig_To_kops_GossipConfig(in *GossipConfig, out *kops.GossipConfig, s conversion.Scope) error {
 	out.Protocol = in.Protocol
 	out.Listen = in.Listen
@@ -3994,6 +5308,10 @@ func autoConvert_v1alpha2_GossipConfig_To_kops_GossipConfig(in *GossipConfig, ou
 	} else {
 		out.Secondary = nil
 	}
+	out.Encryption = in.Encryption
+	out.ProbeInterval = in.ProbeInterval
+	out.GossipInterval = in.GossipInterval
+	out.PushPullInterval = in.PushPullInterval
 	return nil
 }
 
@@ -4015,6 +5333,10 @@ func autoConvert_kops_GossipConfig_To_v1alpha2_GossipConfig(in *kops.GossipConfi
 	} else {
 		out.Secondary = nil
 	}
+	out.Encryption = in.Encryption
+	out.ProbeInterval = in.ProbeInterval
+	out.GossipInterval = in.GossipInterval
+	out.PushPullInterval = in.PushPullInterval
 	return nil
 }
 
@@ -4181,6 +5503,7 @@ func autoConvert_v1alpha2_IAMSpec_To_kops_IAMSpec(in *IAMSpec, out *kops.IAMSpec
 	out.Legacy = in.Legacy
 	out.AllowContainerRegistry = in.AllowContainerRegistry
 	out.PermissionsBoundary = in.PermissionsBoundary
+	out.Path = in.Path
 	out.UseServiceAccountExternalPermissions = in.UseServiceAccountExternalPermissions
 	if in.ServiceAccountExternalPermissions != nil {
 		in, out := &in.ServiceAccountExternalPermissions, &out.ServiceAccountExternalPermissions
@@ -4193,6 +5516,7 @@ func autoConvert_v1alpha2_IAMSpec_To_kops_IAMSpec(in *IAMSpec, out *kops.IAMSpec
 	} else {
 		out.ServiceAccountExternalPermissions = nil
 	}
+	out.ScopeResourceARNs = in.ScopeResourceARNs
 	return nil
 }
 
@@ -4205,6 +5529,7 @@ func autoConvert_kops_IAMSpec_To_v1alpha2_IAMSpec(in *kops.IAMSpec, out *IAMSpec
 	out.Legacy = in.Legacy
 	out.AllowContainerRegistry = in.AllowContainerRegistry
 	out.PermissionsBoundary = in.PermissionsBoundary
+	out.Path = in.Path
 	out.UseServiceAccountExternalPermissions = in.UseServiceAccountExternalPermissions
 	if in.ServiceAccountExternalPermissions != nil {
 		in, out := &in.ServiceAccountExternalPermissions, &out.ServiceAccountExternalPermissions
@@ -4217,6 +5542,7 @@ func autoConvert_kops_IAMSpec_To_v1alpha2_IAMSpec(in *kops.IAMSpec, out *IAMSpec
 	} else {
 		out.ServiceAccountExternalPermissions = nil
 	}
+	out.ScopeResourceARNs = in.ScopeResourceARNs
 	return nil
 }
 
@@ -4225,6 +5551,56 @@ func Convert_kops_IAMSpec_To_v1alpha2_IAMSpec(in *kops.IAMSpec, out *IAMSpec, s
 	return autoConvert_kops_IAMSpec_To_v1alpha2_IAMSpec(in, out, s)
 }
 
+func autoConvert_v1alpha2_ImagePolicyWebhookAdmissionConfig_To_kops_ImagePolicyWebhookAdmissionConfig(in *ImagePolicyWebhookAdmissionConfig, out *kops.ImagePolicyWebhookAdmissionConfig, s conversion.Scope) error {
+	out.KubeConfig = in.KubeConfig
+	out.AllowTTL = in.AllowTTL
+	out.DenyTTL = in.DenyTTL
+	out.RetryBackoff = in.RetryBackoff
+	out.DefaultAllow = in.DefaultAllow
+	return nil
+}
+
+// Convert_v1alpha2_ImagePolicyWebhookAdmissionConfig_To_kops_ImagePolicyWebhookAdmissionConfig is an autogenerated conversion function.
+func Convert_v1alpha2_ImagePolicyWebhookAdmissionConfig_To_kops_ImagePolicyWebhookAdmissionConfig(in *ImagePolicyWebhookAdmissionConfig, out *kops.ImagePolicyWebhookAdmissionConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ImagePolicyWebhookAdmissionConfig_To_kops_ImagePolicyWebhookAdmissionConfig(in, out, s)
+}
+
+func autoConvert_kops_ImagePolicyWebhookAdmissionConfig_To_v1alpha2_ImagePolicyWebhookAdmissionConfig(in *kops.ImagePolicyWebhookAdmissionConfig, out *ImagePolicyWebhookAdmissionConfig, s conversion.Scope) error {
+	out.KubeConfig = in.KubeConfig
+	out.AllowTTL = in.AllowTTL
+	out.DenyTTL = in.DenyTTL
+	out.RetryBackoff = in.RetryBackoff
+	out.DefaultAllow = in.DefaultAllow
+	return nil
+}
+
+// Convert_kops_ImagePolicyWebhookAdmissionConfig_To_v1alpha2_ImagePolicyWebhookAdmissionConfig is an autogenerated conversion function.
+func Convert_kops_ImagePolicyWebhookAdmissionConfig_To_v1alpha2_ImagePolicyWebhookAdmissionConfig(in *kops.ImagePolicyWebhookAdmissionConfig, out *ImagePolicyWebhookAdmissionConfig, s conversion.Scope) error {
+	return autoConvert_kops_ImagePolicyWebhookAdmissionConfig_To_v1alpha2_ImagePolicyWebhookAdmissionConfig(in, out, s)
+}
+
+func autoConvert_v1alpha2_ImageTrustPolicy_To_kops_ImageTrustPolicy(in *ImageTrustPolicy, out *kops.ImageTrustPolicy, s conversion.Scope) error {
+	out.Prefix = in.Prefix
+	out.PublicKeys = in.PublicKeys
+	return nil
+}
+
+// Convert_v1alpha2_ImageTrustPolicy_To_kops_ImageTrustPolicy is an autogenerated conversion function.
+func Convert_v1alpha2_ImageTrustPolicy_To_kops_ImageTrustPolicy(in *ImageTrustPolicy, out *kops.ImageTrustPolicy, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ImageTrustPolicy_To_kops_ImageTrustPolicy(in, out, s)
+}
+
+func autoConvert_kops_ImageTrustPolicy_To_v1alpha2_ImageTrustPolicy(in *kops.ImageTrustPolicy, out *ImageTrustPolicy, s conversion.Scope) error {
+	out.Prefix = in.Prefix
+	out.PublicKeys = in.PublicKeys
+	return nil
+}
+
+// Convert_kops_ImageTrustPolicy_To_v1alpha2_ImageTrustPolicy is an autogenerated conversion function.
+func Convert_kops_ImageTrustPolicy_To_v1alpha2_ImageTrustPolicy(in *kops.ImageTrustPolicy, out *ImageTrustPolicy, s conversion.Scope) error {
+	return autoConvert_kops_ImageTrustPolicy_To_v1alpha2_ImageTrustPolicy(in, out, s)
+}
+
 func autoConvert_v1alpha2_InstanceGroup_To_kops_InstanceGroup(in *InstanceGroup, out *kops.InstanceGroup, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha2_InstanceGroupSpec_To_kops_InstanceGroupSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -4293,6 +5669,28 @@ func Convert_kops_InstanceGroupList_To_v1alpha2_InstanceGroupList(in *kops.Insta
 	return autoConvert_kops_InstanceGroupList_To_v1alpha2_InstanceGroupList(in, out, s)
 }
 
+func autoConvert_v1alpha2_InstanceGroupMonitoringSpec_To_kops_InstanceGroupMonitoringSpec(in *InstanceGroupMonitoringSpec, out *kops.InstanceGroupMonitoringSpec, s conversion.Scope) error {
+	out.NodeExporter = in.NodeExporter
+	out.CloudWatchAgent = in.CloudWatchAgent
+	return nil
+}
+
+// Convert_v1alpha2_InstanceGroupMonitoringSpec_To_kops_InstanceGroupMonitoringSpec is an autogenerated conversion function.
+func Convert_v1alpha2_InstanceGroupMonitoringSpec_To_kops_InstanceGroupMonitoringSpec(in *InstanceGroupMonitoringSpec, out *kops.InstanceGroupMonitoringSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_InstanceGroupMonitoringSpec_To_kops_InstanceGroupMonitoringSpec(in, out, s)
+}
+
+func autoConvert_kops_InstanceGroupMonitoringSpec_To_v1alpha2_InstanceGroupMonitoringSpec(in *kops.InstanceGroupMonitoringSpec, out *InstanceGroupMonitoringSpec, s conversion.Scope) error {
+	out.NodeExporter = in.NodeExporter
+	out.CloudWatchAgent = in.CloudWatchAgent
+	return nil
+}
+
+// Convert_kops_InstanceGroupMonitoringSpec_To_v1alpha2_InstanceGroupMonitoringSpec is an autogenerated conversion function.
+func Convert_kops_InstanceGroupMonitoringSpec_To_v1alpha2_InstanceGroupMonitoringSpec(in *kops.InstanceGroupMonitoringSpec, out *InstanceGroupMonitoringSpec, s conversion.Scope) error {
+	return autoConvert_kops_InstanceGroupMonitoringSpec_To_v1alpha2_InstanceGroupMonitoringSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_InstanceGroupSpec_To_kops_InstanceGroupSpec(in *InstanceGroupSpec, out *kops.InstanceGroupSpec, s conversion.Scope) error {
 	out.Manager = kops.InstanceManager(in.Manager)
 	out.Role = kops.InstanceGroupRole(in.Role)
@@ -4363,6 +5761,8 @@ func autoConvert_v1alpha2_InstanceGroupSpec_To_kops_InstanceGroupSpec(in *Instan
 		out.FileAssets = nil
 	}
 	out.Tenancy = in.Tenancy
+	out.HostResourceGroupARN = in.HostResourceGroupARN
+	out.HostAffinity = in.HostAffinity
 	if in.Kubelet != nil {
 		in, out := &in.Kubelet, &out.Kubelet
 		*out = new(kops.KubeletConfigSpec)
@@ -4393,6 +5793,16 @@ func autoConvert_v1alpha2_InstanceGroupSpec_To_kops_InstanceGroupSpec(in *Instan
 	} else {
 		out.AdditionalUserData = nil
 	}
+	if in.CloudInit != nil {
+		in, out := &in.CloudInit, &out.CloudInit
+		*out = new(kops.CloudInitSpec)
+		if err := Convert_v1alpha2_CloudInitSpec_To_kops_CloudInitSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CloudInit = nil
+	}
+	out.Ignition = in.Ignition
 	out.SuspendProcesses = in.SuspendProcesses
 	if in.ExternalLoadBalancers != nil {
 		in, out := &in.ExternalLoadBalancers, &out.ExternalLoadBalancers
@@ -4469,6 +5879,55 @@ func autoConvert_v1alpha2_InstanceGroupSpec_To_kops_InstanceGroupSpec(in *Instan
 	} else {
 		out.GuestAccelerators = nil
 	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(kops.InstanceGroupMonitoringSpec)
+		if err := Convert_v1alpha2_InstanceGroupMonitoringSpec_To_kops_InstanceGroupMonitoringSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Monitoring = nil
+	}
+	if in.EFA != nil {
+		in, out := &in.EFA, &out.EFA
+		*out = new(kops.EFASpec)
+		if err := Convert_v1alpha2_EFASpec_To_kops_EFASpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.EFA = nil
+	}
+	out.NitroEnclave = in.NitroEnclave
+	if in.ElasticInferenceAccelerators != nil {
+		in, out := &in.ElasticInferenceAccelerators, &out.ElasticInferenceAccelerators
+		*out = make([]kops.AcceleratorConfig, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_AcceleratorConfig_To_kops_AcceleratorConfig(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.ElasticInferenceAccelerators = nil
+	}
+	if in.AssetCache != nil {
+		in, out := &in.AssetCache, &out.AssetCache
+		*out = new(kops.AssetCacheSpec)
+		if err := Convert_v1alpha2_AssetCacheSpec_To_kops_AssetCacheSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AssetCache = nil
+	}
+	out.PreloadImages = in.PreloadImages
+	if in.NodeUp != nil {
+		in, out := &in.NodeUp, &out.NodeUp
+		*out = new(kops.PackagesConfig)
+		if err := Convert_v1alpha2_PackagesConfig_To_kops_PackagesConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.NodeUp = nil
+	}
 	return nil
 }
 
@@ -4546,6 +6005,8 @@ func autoConvert_kops_InstanceGroupSpec_To_v1alpha2_InstanceGroupSpec(in *kops.I
 		out.FileAssets = nil
 	}
 	out.Tenancy = in.Tenancy
+	out.HostResourceGroupARN = in.HostResourceGroupARN
+	out.HostAffinity = in.HostAffinity
 	if in.Kubelet != nil {
 		in, out := &in.Kubelet, &out.Kubelet
 		*out = new(KubeletConfigSpec)
@@ -4576,6 +6037,16 @@ func autoConvert_kops_InstanceGroupSpec_To_v1alpha2_InstanceGroupSpec(in *kops.I
 	} else {
 		out.AdditionalUserData = nil
 	}
+	if in.CloudInit != nil {
+		in, out := &in.CloudInit, &out.CloudInit
+		*out = new(CloudInitSpec)
+		if err := Convert_kops_CloudInitSpec_To_v1alpha2_CloudInitSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CloudInit = nil
+	}
+	out.Ignition = in.Ignition
 	out.SuspendProcesses = in.SuspendProcesses
 	if in.ExternalLoadBalancers != nil {
 		in, out := &in.ExternalLoadBalancers, &out.ExternalLoadBalancers
@@ -4652,6 +6123,55 @@ func autoConvert_kops_InstanceGroupSpec_To_v1alpha2_InstanceGroupSpec(in *kops.I
 	} else {
 		out.GuestAccelerators = nil
 	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(InstanceGroupMonitoringSpec)
+		if err := Convert_kops_InstanceGroupMonitoringSpec_To_v1alpha2_InstanceGroupMonitoringSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Monitoring = nil
+	}
+	if in.EFA != nil {
+		in, out := &in.EFA, &out.EFA
+		*out = new(EFASpec)
+		if err := Convert_kops_EFASpec_To_v1alpha2_EFASpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.EFA = nil
+	}
+	out.NitroEnclave = in.NitroEnclave
+	if in.ElasticInferenceAccelerators != nil {
+		in, out := &in.ElasticInferenceAccelerators, &out.ElasticInferenceAccelerators
+		*out = make([]AcceleratorConfig, len(*in))
+		for i := range *in {
+			if err := Convert_kops_AcceleratorConfig_To_v1alpha2_AcceleratorConfig(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.ElasticInferenceAccelerators = nil
+	}
+	if in.AssetCache != nil {
+		in, out := &in.AssetCache, &out.AssetCache
+		*out = new(AssetCacheSpec)
+		if err := Convert_kops_AssetCacheSpec_To_v1alpha2_AssetCacheSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AssetCache = nil
+	}
+	out.PreloadImages = in.PreloadImages
+	if in.NodeUp != nil {
+		in, out := &in.NodeUp, &out.NodeUp
+		*out = new(PackagesConfig)
+		if err := Convert_kops_PackagesConfig_To_v1alpha2_PackagesConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.NodeUp = nil
+	}
 	return nil
 }
 
@@ -4791,6 +6311,7 @@ func autoConvert_v1alpha2_KeysetItem_To_kops_KeysetItem(in *KeysetItem, out *kop
 	if err := conversion.Convert_Slice_byte_To_Slice_byte(&in.PrivateMaterial, &out.PrivateMaterial, s); err != nil {
 		return err
 	}
+	out.ExternalCertificateID = in.ExternalCertificateID
 	return nil
 }
 
@@ -4808,6 +6329,7 @@ func autoConvert_kops_KeysetItem_To_v1alpha2_KeysetItem(in *kops.KeysetItem, out
 	if err := conversion.Convert_Slice_byte_To_Slice_byte(&in.PrivateMaterial, &out.PrivateMaterial, s); err != nil {
 		return err
 	}
+	out.ExternalCertificateID = in.ExternalCertificateID
 	return nil
 }
 
@@ -4858,6 +6380,30 @@ func Convert_kops_KeysetList_To_v1alpha2_KeysetList(in *kops.KeysetList, out *Ke
 	return autoConvert_kops_KeysetList_To_v1alpha2_KeysetList(in, out, s)
 }
 
+func autoConvert_v1alpha2_KeysetRotation_To_kops_KeysetRotation(in *KeysetRotation, out *kops.KeysetRotation, s conversion.Scope) error {
+	out.Phase = kops.KeysetRotationPhase(in.Phase)
+	out.NewID = in.NewID
+	out.PreviousID = in.PreviousID
+	return nil
+}
+
+// Convert_v1alpha2_KeysetRotation_To_kops_KeysetRotation is an autogenerated conversion function.
+func Convert_v1alpha2_KeysetRotation_To_kops_KeysetRotation(in *KeysetRotation, out *kops.KeysetRotation, s conversion.Scope) error {
+	return autoConvert_v1alpha2_KeysetRotation_To_kops_KeysetRotation(in, out, s)
+}
+
+func autoConvert_kops_KeysetRotation_To_v1alpha2_KeysetRotation(in *kops.KeysetRotation, out *KeysetRotation, s conversion.Scope) error {
+	out.Phase = KeysetRotationPhase(in.Phase)
+	out.NewID = in.NewID
+	out.PreviousID = in.PreviousID
+	return nil
+}
+
+// Convert_kops_KeysetRotation_To_v1alpha2_KeysetRotation is an autogenerated conversion function.
+func Convert_kops_KeysetRotation_To_v1alpha2_KeysetRotation(in *kops.KeysetRotation, out *KeysetRotation, s conversion.Scope) error {
+	return autoConvert_kops_KeysetRotation_To_v1alpha2_KeysetRotation(in, out, s)
+}
+
 func autoConvert_v1alpha2_KeysetSpec_To_kops_KeysetSpec(in *KeysetSpec, out *kops.KeysetSpec, s conversion.Scope) error {
 	out.Type = kops.KeysetType(in.Type)
 	out.PrimaryID = in.PrimaryID
@@ -4872,6 +6418,15 @@ func autoConvert_v1alpha2_KeysetSpec_To_kops_KeysetSpec(in *KeysetSpec, out *kop
 	} else {
 		out.Keys = nil
 	}
+	if in.Rotation != nil {
+		in, out := &in.Rotation, &out.Rotation
+		*out = new(kops.KeysetRotation)
+		if err := Convert_v1alpha2_KeysetRotation_To_kops_KeysetRotation(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Rotation = nil
+	}
 	return nil
 }
 
@@ -4894,6 +6449,15 @@ func autoConvert_kops_KeysetSpec_To_v1alpha2_KeysetSpec(in *kops.KeysetSpec, out
 	} else {
 		out.Keys = nil
 	}
+	if in.Rotation != nil {
+		in, out := &in.Rotation, &out.Rotation
+		*out = new(KeysetRotation)
+		if err := Convert_kops_KeysetRotation_To_v1alpha2_KeysetRotation(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Rotation = nil
+	}
 	return nil
 }
 
@@ -4957,6 +6521,15 @@ func autoConvert_v1alpha2_KubeAPIServerConfig_To_kops_KubeAPIServerConfig(in *Ku
 	out.EnableAdmissionPlugins = in.EnableAdmissionPlugins
 	out.DisableAdmissionPlugins = in.DisableAdmissionPlugins
 	out.AdmissionControlConfigFile = in.AdmissionControlConfigFile
+	if in.AdmissionPluginConfig != nil {
+		in, out := &in.AdmissionPluginConfig, &out.AdmissionPluginConfig
+		*out = new(kops.AdmissionPluginConfigSpec)
+		if err := Convert_v1alpha2_AdmissionPluginConfigSpec_To_kops_AdmissionPluginConfigSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AdmissionPluginConfig = nil
+	}
 	out.ServiceClusterIPRange = in.ServiceClusterIPRange
 	out.ServiceNodePortRange = in.ServiceNodePortRange
 	out.EtcdServers = in.EtcdServers
@@ -5022,6 +6595,8 @@ func autoConvert_v1alpha2_KubeAPIServerConfig_To_kops_KubeAPIServerConfig(in *Ku
 	out.FeatureGates = in.FeatureGates
 	out.MaxRequestsInflight = in.MaxRequestsInflight
 	out.MaxMutatingRequestsInflight = in.MaxMutatingRequestsInflight
+	out.EnablePriorityAndFairness = in.EnablePriorityAndFairness
+	out.FlowSchemaPresets = in.FlowSchemaPresets
 	out.HTTP2MaxStreamsPerConnection = in.HTTP2MaxStreamsPerConnection
 	out.EtcdQuorumRead = in.EtcdQuorumRead
 	out.RequestTimeout = in.RequestTimeout
@@ -5042,6 +6617,42 @@ func autoConvert_v1alpha2_KubeAPIServerConfig_To_kops_KubeAPIServerConfig(in *Ku
 	out.CorsAllowedOrigins = in.CorsAllowedOrigins
 	out.DefaultNotReadyTolerationSeconds = in.DefaultNotReadyTolerationSeconds
 	out.DefaultUnreachableTolerationSeconds = in.DefaultUnreachableTolerationSeconds
+	if in.HealthcheckSidecar != nil {
+		in, out := &in.HealthcheckSidecar, &out.HealthcheckSidecar
+		*out = new(kops.KubeAPIServerHealthcheckSidecarConfig)
+		if err := Convert_v1alpha2_KubeAPIServerHealthcheckSidecarConfig_To_kops_KubeAPIServerHealthcheckSidecarConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.HealthcheckSidecar = nil
+	}
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(kops.ProbeSpec)
+		if err := Convert_v1alpha2_ProbeSpec_To_kops_ProbeSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.LivenessProbe = nil
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(kops.ProbeSpec)
+		if err := Convert_v1alpha2_ProbeSpec_To_kops_ProbeSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ReadinessProbe = nil
+	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(kops.ProbeSpec)
+		if err := Convert_v1alpha2_ProbeSpec_To_kops_ProbeSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.StartupProbe = nil
+	}
 	return nil
 }
 
@@ -5069,6 +6680,15 @@ func autoConvert_kops_KubeAPIServerConfig_To_v1alpha2_KubeAPIServerConfig(in *ko
 	out.EnableAdmissionPlugins = in.EnableAdmissionPlugins
 	out.DisableAdmissionPlugins = in.DisableAdmissionPlugins
 	out.AdmissionControlConfigFile = in.AdmissionControlConfigFile
+	if in.AdmissionPluginConfig != nil {
+		in, out := &in.AdmissionPluginConfig, &out.AdmissionPluginConfig
+		*out = new(AdmissionPluginConfigSpec)
+		if err := Convert_kops_AdmissionPluginConfigSpec_To_v1alpha2_AdmissionPluginConfigSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AdmissionPluginConfig = nil
+	}
 	out.ServiceClusterIPRange = in.ServiceClusterIPRange
 	out.ServiceNodePortRange = in.ServiceNodePortRange
 	out.EtcdServers = in.EtcdServers
@@ -5134,6 +6754,8 @@ func autoConvert_kops_KubeAPIServerConfig_To_v1alpha2_KubeAPIServerConfig(in *ko
 	out.FeatureGates = in.FeatureGates
 	out.MaxRequestsInflight = in.MaxRequestsInflight
 	out.MaxMutatingRequestsInflight = in.MaxMutatingRequestsInflight
+	out.EnablePriorityAndFairness = in.EnablePriorityAndFairness
+	out.FlowSchemaPresets = in.FlowSchemaPresets
 	out.HTTP2MaxStreamsPerConnection = in.HTTP2MaxStreamsPerConnection
 	out.EtcdQuorumRead = in.EtcdQuorumRead
 	out.RequestTimeout = in.RequestTimeout
@@ -5154,6 +6776,42 @@ func autoConvert_kops_KubeAPIServerConfig_To_v1alpha2_KubeAPIServerConfig(in *ko
 	out.CorsAllowedOrigins = in.CorsAllowedOrigins
 	out.DefaultNotReadyTolerationSeconds = in.DefaultNotReadyTolerationSeconds
 	out.DefaultUnreachableTolerationSeconds = in.DefaultUnreachableTolerationSeconds
+	if in.HealthcheckSidecar != nil {
+		in, out := &in.HealthcheckSidecar, &out.HealthcheckSidecar
+		*out = new(KubeAPIServerHealthcheckSidecarConfig)
+		if err := Convert_kops_KubeAPIServerHealthcheckSidecarConfig_To_v1alpha2_KubeAPIServerHealthcheckSidecarConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.HealthcheckSidecar = nil
+	}
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(ProbeSpec)
+		if err := Convert_kops_ProbeSpec_To_v1alpha2_ProbeSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.LivenessProbe = nil
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(ProbeSpec)
+		if err := Convert_kops_ProbeSpec_To_v1alpha2_ProbeSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ReadinessProbe = nil
+	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(ProbeSpec)
+		if err := Convert_kops_ProbeSpec_To_v1alpha2_ProbeSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.StartupProbe = nil
+	}
 	return nil
 }
 
@@ -5162,6 +6820,30 @@ func Convert_kops_KubeAPIServerConfig_To_v1alpha2_KubeAPIServerConfig(in *kops.K
 	return autoConvert_kops_KubeAPIServerConfig_To_v1alpha2_KubeAPIServerConfig(in, out, s)
 }
 
+func autoConvert_v1alpha2_KubeAPIServerHealthcheckSidecarConfig_To_kops_KubeAPIServerHealthcheckSidecarConfig(in *KubeAPIServerHealthcheckSidecarConfig, out *kops.KubeAPIServerHealthcheckSidecarConfig, s conversion.Scope) error {
+	out.Port = in.Port
+	out.Path = in.Path
+	out.AdditionalChecks = in.AdditionalChecks
+	return nil
+}
+
+// Convert_v1alpha2_KubeAPIServerHealthcheckSidecarConfig_To_kops_KubeAPIServerHealthcheckSidecarConfig is an autogenerated conversion function.
+func Convert_v1alpha2_KubeAPIServerHealthcheckSidecarConfig_To_kops_KubeAPIServerHealthcheckSidecarConfig(in *KubeAPIServerHealthcheckSidecarConfig, out *kops.KubeAPIServerHealthcheckSidecarConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha2_KubeAPIServerHealthcheckSidecarConfig_To_kops_KubeAPIServerHealthcheckSidecarConfig(in, out, s)
+}
+
+func autoConvert_kops_KubeAPIServerHealthcheckSidecarConfig_To_v1alpha2_KubeAPIServerHealthcheckSidecarConfig(in *kops.KubeAPIServerHealthcheckSidecarConfig, out *KubeAPIServerHealthcheckSidecarConfig, s conversion.Scope) error {
+	out.Port = in.Port
+	out.Path = in.Path
+	out.AdditionalChecks = in.AdditionalChecks
+	return nil
+}
+
+// Convert_kops_KubeAPIServerHealthcheckSidecarConfig_To_v1alpha2_KubeAPIServerHealthcheckSidecarConfig is an autogenerated conversion function.
+func Convert_kops_KubeAPIServerHealthcheckSidecarConfig_To_v1alpha2_KubeAPIServerHealthcheckSidecarConfig(in *kops.KubeAPIServerHealthcheckSidecarConfig, out *KubeAPIServerHealthcheckSidecarConfig, s conversion.Scope) error {
+	return autoConvert_kops_KubeAPIServerHealthcheckSidecarConfig_To_v1alpha2_KubeAPIServerHealthcheckSidecarConfig(in, out, s)
+}
+
 func autoConvert_v1alpha2_KubeControllerManagerConfig_To_kops_KubeControllerManagerConfig(in *KubeControllerManagerConfig, out *kops.KubeControllerManagerConfig, s conversion.Scope) error {
 	out.Master = in.Master
 	out.LogFormat = in.LogFormat
@@ -5224,6 +6906,24 @@ func autoConvert_v1alpha2_KubeControllerManagerConfig_To_kops_KubeControllerMana
 	out.ExternalCloudVolumePlugin = in.ExternalCloudVolumePlugin
 	out.EnableProfiling = in.EnableProfiling
 	out.EnableLeaderMigration = in.EnableLeaderMigration
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(kops.ProbeSpec)
+		if err := Convert_v1alpha2_ProbeSpec_To_kops_ProbeSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.LivenessProbe = nil
+	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(kops.ProbeSpec)
+		if err := Convert_v1alpha2_ProbeSpec_To_kops_ProbeSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.StartupProbe = nil
+	}
 	return nil
 }
 
@@ -5294,6 +6994,24 @@ func autoConvert_kops_KubeControllerManagerConfig_To_v1alpha2_KubeControllerMana
 	out.ExternalCloudVolumePlugin = in.ExternalCloudVolumePlugin
 	out.EnableProfiling = in.EnableProfiling
 	out.EnableLeaderMigration = in.EnableLeaderMigration
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(ProbeSpec)
+		if err := Convert_kops_ProbeSpec_To_v1alpha2_ProbeSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.LivenessProbe = nil
+	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(ProbeSpec)
+		if err := Convert_kops_ProbeSpec_To_v1alpha2_ProbeSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.StartupProbe = nil
+	}
 	return nil
 }
 
@@ -5453,6 +7171,24 @@ func autoConvert_v1alpha2_KubeSchedulerConfig_To_kops_KubeSchedulerConfig(in *Ku
 	out.EnableProfiling = in.EnableProfiling
 	out.TLSCertFile = in.TLSCertFile
 	out.TLSPrivateKeyFile = in.TLSPrivateKeyFile
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(kops.ProbeSpec)
+		if err := Convert_v1alpha2_ProbeSpec_To_kops_ProbeSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.LivenessProbe = nil
+	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(kops.ProbeSpec)
+		if err := Convert_v1alpha2_ProbeSpec_To_kops_ProbeSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.StartupProbe = nil
+	}
 	return nil
 }
 
@@ -5486,6 +7222,24 @@ func autoConvert_kops_KubeSchedulerConfig_To_v1alpha2_KubeSchedulerConfig(in *ko
 	out.EnableProfiling = in.EnableProfiling
 	out.TLSCertFile = in.TLSCertFile
 	out.TLSPrivateKeyFile = in.TLSPrivateKeyFile
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(ProbeSpec)
+		if err := Convert_kops_ProbeSpec_To_v1alpha2_ProbeSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.LivenessProbe = nil
+	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(ProbeSpec)
+		if err := Convert_kops_ProbeSpec_To_v1alpha2_ProbeSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.StartupProbe = nil
+	}
 	return nil
 }
 
@@ -5585,6 +7339,7 @@ func autoConvert_v1alpha2_KubeletConfigSpec_To_kops_KubeletConfigSpec(in *Kubele
 	out.PodPidsLimit = in.PodPidsLimit
 	out.ShutdownGracePeriod = in.ShutdownGracePeriod
 	out.ShutdownGracePeriodCriticalPods = in.ShutdownGracePeriodCriticalPods
+	out.ImageCredentialProviders = in.ImageCredentialProviders
 	return nil
 }
 
@@ -5684,6 +7439,7 @@ func autoConvert_kops_KubeletConfigSpec_To_v1alpha2_KubeletConfigSpec(in *kops.K
 	out.PodPidsLimit = in.PodPidsLimit
 	out.ShutdownGracePeriod = in.ShutdownGracePeriod
 	out.ShutdownGracePeriodCriticalPods = in.ShutdownGracePeriodCriticalPods
+	out.ImageCredentialProviders = in.ImageCredentialProviders
 	return nil
 }
 
@@ -5812,6 +7568,17 @@ func autoConvert_v1alpha2_LoadBalancerAccessSpec_To_kops_LoadBalancerAccessSpec(
 	} else {
 		out.AccessLog = nil
 	}
+	out.ConnectionDrainingTimeoutSeconds = in.ConnectionDrainingTimeoutSeconds
+	out.DeregistrationDelaySeconds = in.DeregistrationDelaySeconds
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(kops.LoadBalancerHealthCheckSpec)
+		if err := Convert_v1alpha2_LoadBalancerHealthCheckSpec_To_kops_LoadBalancerHealthCheckSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.HealthCheck = nil
+	}
 	return nil
 }
 
@@ -5850,6 +7617,17 @@ func autoConvert_kops_LoadBalancerAccessSpec_To_v1alpha2_LoadBalancerAccessSpec(
 	} else {
 		out.AccessLog = nil
 	}
+	out.ConnectionDrainingTimeoutSeconds = in.ConnectionDrainingTimeoutSeconds
+	out.DeregistrationDelaySeconds = in.DeregistrationDelaySeconds
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(LoadBalancerHealthCheckSpec)
+		if err := Convert_kops_LoadBalancerHealthCheckSpec_To_v1alpha2_LoadBalancerHealthCheckSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.HealthCheck = nil
+	}
 	return nil
 }
 
@@ -5858,6 +7636,30 @@ func Convert_kops_LoadBalancerAccessSpec_To_v1alpha2_LoadBalancerAccessSpec(in *
 	return autoConvert_kops_LoadBalancerAccessSpec_To_v1alpha2_LoadBalancerAccessSpec(in, out, s)
 }
 
+func autoConvert_v1alpha2_LoadBalancerHealthCheckSpec_To_kops_LoadBalancerHealthCheckSpec(in *LoadBalancerHealthCheckSpec, out *kops.LoadBalancerHealthCheckSpec, s conversion.Scope) error {
+	out.Protocol = in.Protocol
+	out.Port = in.Port
+	out.Path = in.Path
+	return nil
+}
+
+// Convert_v1alpha2_LoadBalancerHealthCheckSpec_To_kops_LoadBalancerHealthCheckSpec is an autogenerated conversion function.
+func Convert_v1alpha2_LoadBalancerHealthCheckSpec_To_kops_LoadBalancerHealthCheckSpec(in *LoadBalancerHealthCheckSpec, out *kops.LoadBalancerHealthCheckSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_LoadBalancerHealthCheckSpec_To_kops_LoadBalancerHealthCheckSpec(in, out, s)
+}
+
+func autoConvert_kops_LoadBalancerHealthCheckSpec_To_v1alpha2_LoadBalancerHealthCheckSpec(in *kops.LoadBalancerHealthCheckSpec, out *LoadBalancerHealthCheckSpec, s conversion.Scope) error {
+	out.Protocol = in.Protocol
+	out.Port = in.Port
+	out.Path = in.Path
+	return nil
+}
+
+// Convert_kops_LoadBalancerHealthCheckSpec_To_v1alpha2_LoadBalancerHealthCheckSpec is an autogenerated conversion function.
+func Convert_kops_LoadBalancerHealthCheckSpec_To_v1alpha2_LoadBalancerHealthCheckSpec(in *kops.LoadBalancerHealthCheckSpec, out *LoadBalancerHealthCheckSpec, s conversion.Scope) error {
+	return autoConvert_kops_LoadBalancerHealthCheckSpec_To_v1alpha2_LoadBalancerHealthCheckSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_LoadBalancerSubnetSpec_To_kops_LoadBalancerSubnetSpec(in *LoadBalancerSubnetSpec, out *kops.LoadBalancerSubnetSpec, s conversion.Scope) error {
 	out.Name = in.Name
 	out.PrivateIPv4Address = in.PrivateIPv4Address
@@ -5964,6 +7766,15 @@ func autoConvert_v1alpha2_MixedInstancesPolicySpec_To_kops_MixedInstancesPolicyS
 	out.OnDemandAboveBase = in.OnDemandAboveBase
 	out.SpotAllocationStrategy = in.SpotAllocationStrategy
 	out.SpotInstancePools = in.SpotInstancePools
+	if in.SpotFallback != nil {
+		in, out := &in.SpotFallback, &out.SpotFallback
+		*out = new(kops.SpotFallbackSpec)
+		if err := Convert_v1alpha2_SpotFallbackSpec_To_kops_SpotFallbackSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SpotFallback = nil
+	}
 	return nil
 }
 
@@ -5988,6 +7799,15 @@ func autoConvert_kops_MixedInstancesPolicySpec_To_v1alpha2_MixedInstancesPolicyS
 	out.OnDemandAboveBase = in.OnDemandAboveBase
 	out.SpotAllocationStrategy = in.SpotAllocationStrategy
 	out.SpotInstancePools = in.SpotInstancePools
+	if in.SpotFallback != nil {
+		in, out := &in.SpotFallback, &out.SpotFallback
+		*out = new(SpotFallbackSpec)
+		if err := Convert_kops_SpotFallbackSpec_To_v1alpha2_SpotFallbackSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SpotFallback = nil
+	}
 	return nil
 }
 
@@ -5996,6 +7816,92 @@ func Convert_kops_MixedInstancesPolicySpec_To_v1alpha2_MixedInstancesPolicySpec(
 	return autoConvert_kops_MixedInstancesPolicySpec_To_v1alpha2_MixedInstancesPolicySpec(in, out, s)
 }
 
+func autoConvert_v1alpha2_MonitoringAgentConfig_To_kops_MonitoringAgentConfig(in *MonitoringAgentConfig, out *kops.MonitoringAgentConfig, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.Image = in.Image
+	out.ResourcePreset = in.ResourcePreset
+	out.MemoryRequest = in.MemoryRequest
+	out.CPURequest = in.CPURequest
+	out.MemoryLimit = in.MemoryLimit
+	out.CPULimit = in.CPULimit
+	return nil
+}
+
+// Convert_v1alpha2_MonitoringAgentConfig_To_kops_MonitoringAgentConfig is an autogenerated conversion function.
+func Convert_v1alpha2_MonitoringAgentConfig_To_kops_MonitoringAgentConfig(in *MonitoringAgentConfig, out *kops.MonitoringAgentConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha2_MonitoringAgentConfig_To_kops_MonitoringAgentConfig(in, out, s)
+}
+
+func autoConvert_kops_MonitoringAgentConfig_To_v1alpha2_MonitoringAgentConfig(in *kops.MonitoringAgentConfig, out *MonitoringAgentConfig, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.Image = in.Image
+	out.ResourcePreset = in.ResourcePreset
+	out.MemoryRequest = in.MemoryRequest
+	out.CPURequest = in.CPURequest
+	out.MemoryLimit = in.MemoryLimit
+	out.CPULimit = in.CPULimit
+	return nil
+}
+
+// Convert_kops_MonitoringAgentConfig_To_v1alpha2_MonitoringAgentConfig is an autogenerated conversion function.
+func Convert_kops_MonitoringAgentConfig_To_v1alpha2_MonitoringAgentConfig(in *kops.MonitoringAgentConfig, out *MonitoringAgentConfig, s conversion.Scope) error {
+	return autoConvert_kops_MonitoringAgentConfig_To_v1alpha2_MonitoringAgentConfig(in, out, s)
+}
+
+func autoConvert_v1alpha2_MonitoringSpec_To_kops_MonitoringSpec(in *MonitoringSpec, out *kops.MonitoringSpec, s conversion.Scope) error {
+	if in.NodeExporter != nil {
+		in, out := &in.NodeExporter, &out.NodeExporter
+		*out = new(kops.MonitoringAgentConfig)
+		if err := Convert_v1alpha2_MonitoringAgentConfig_To_kops_MonitoringAgentConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.NodeExporter = nil
+	}
+	if in.CloudWatchAgent != nil {
+		in, out := &in.CloudWatchAgent, &out.CloudWatchAgent
+		*out = new(kops.MonitoringAgentConfig)
+		if err := Convert_v1alpha2_MonitoringAgentConfig_To_kops_MonitoringAgentConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CloudWatchAgent = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha2_MonitoringSpec_To_kops_MonitoringSpec is an autogenerated conversion function.
+func Convert_v1alpha2_MonitoringSpec_To_kops_MonitoringSpec(in *MonitoringSpec, out *kops.MonitoringSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_MonitoringSpec_To_kops_MonitoringSpec(in, out, s)
+}
+
+func autoConvert_kops_MonitoringSpec_To_v1alpha2_MonitoringSpec(in *kops.MonitoringSpec, out *MonitoringSpec, s conversion.Scope) error {
+	if in.NodeExporter != nil {
+		in, out := &in.NodeExporter, &out.NodeExporter
+		*out = new(MonitoringAgentConfig)
+		if err := Convert_kops_MonitoringAgentConfig_To_v1alpha2_MonitoringAgentConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.NodeExporter = nil
+	}
+	if in.CloudWatchAgent != nil {
+		in, out := &in.CloudWatchAgent, &out.CloudWatchAgent
+		*out = new(MonitoringAgentConfig)
+		if err := Convert_kops_MonitoringAgentConfig_To_v1alpha2_MonitoringAgentConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CloudWatchAgent = nil
+	}
+	return nil
+}
+
+// Convert_kops_MonitoringSpec_To_v1alpha2_MonitoringSpec is an autogenerated conversion function.
+func Convert_kops_MonitoringSpec_To_v1alpha2_MonitoringSpec(in *kops.MonitoringSpec, out *MonitoringSpec, s conversion.Scope) error {
+	return autoConvert_kops_MonitoringSpec_To_v1alpha2_MonitoringSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_NTPConfig_To_kops_NTPConfig(in *NTPConfig, out *kops.NTPConfig, s conversion.Scope) error {
 	out.Managed = in.Managed
 	return nil
@@ -6374,6 +8280,50 @@ func Convert_kops_NodeAuthorizerSpec_To_v1alpha2_NodeAuthorizerSpec(in *kops.Nod
 	return autoConvert_kops_NodeAuthorizerSpec_To_v1alpha2_NodeAuthorizerSpec(in, out, s)
 }
 
+func autoConvert_v1alpha2_NodeCertificatesSpec_To_kops_NodeCertificatesSpec(in *NodeCertificatesSpec, out *kops.NodeCertificatesSpec, s conversion.Scope) error {
+	out.Validity = in.Validity
+	out.RenewBefore = in.RenewBefore
+	return nil
+}
+
+// Convert_v1alpha2_NodeCertificatesSpec_To_kops_NodeCertificatesSpec is an autogenerated conversion function.
+func Convert_v1alpha2_NodeCertificatesSpec_To_kops_NodeCertificatesSpec(in *NodeCertificatesSpec, out *kops.NodeCertificatesSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_NodeCertificatesSpec_To_kops_NodeCertificatesSpec(in, out, s)
+}
+
+func autoConvert_kops_NodeCertificatesSpec_To_v1alpha2_NodeCertificatesSpec(in *kops.NodeCertificatesSpec, out *NodeCertificatesSpec, s conversion.Scope) error {
+	out.Validity = in.Validity
+	out.RenewBefore = in.RenewBefore
+	return nil
+}
+
+// Convert_kops_NodeCertificatesSpec_To_v1alpha2_NodeCertificatesSpec is an autogenerated conversion function.
+func Convert_kops_NodeCertificatesSpec_To_v1alpha2_NodeCertificatesSpec(in *kops.NodeCertificatesSpec, out *NodeCertificatesSpec, s conversion.Scope) error {
+	return autoConvert_kops_NodeCertificatesSpec_To_v1alpha2_NodeCertificatesSpec(in, out, s)
+}
+
+func autoConvert_v1alpha2_NodeLabelsFromCloudTagsSpec_To_kops_NodeLabelsFromCloudTagsSpec(in *NodeLabelsFromCloudTagsSpec, out *kops.NodeLabelsFromCloudTagsSpec, s conversion.Scope) error {
+	out.Tags = in.Tags
+	out.Prefix = in.Prefix
+	return nil
+}
+
+// Convert_v1alpha2_NodeLabelsFromCloudTagsSpec_To_kops_NodeLabelsFromCloudTagsSpec is an autogenerated conversion function.
+func Convert_v1alpha2_NodeLabelsFromCloudTagsSpec_To_kops_NodeLabelsFromCloudTagsSpec(in *NodeLabelsFromCloudTagsSpec, out *kops.NodeLabelsFromCloudTagsSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_NodeLabelsFromCloudTagsSpec_To_kops_NodeLabelsFromCloudTagsSpec(in, out, s)
+}
+
+func autoConvert_kops_NodeLabelsFromCloudTagsSpec_To_v1alpha2_NodeLabelsFromCloudTagsSpec(in *kops.NodeLabelsFromCloudTagsSpec, out *NodeLabelsFromCloudTagsSpec, s conversion.Scope) error {
+	out.Tags = in.Tags
+	out.Prefix = in.Prefix
+	return nil
+}
+
+// Convert_kops_NodeLabelsFromCloudTagsSpec_To_v1alpha2_NodeLabelsFromCloudTagsSpec is an autogenerated conversion function.
+func Convert_kops_NodeLabelsFromCloudTagsSpec_To_v1alpha2_NodeLabelsFromCloudTagsSpec(in *kops.NodeLabelsFromCloudTagsSpec, out *NodeLabelsFromCloudTagsSpec, s conversion.Scope) error {
+	return autoConvert_kops_NodeLabelsFromCloudTagsSpec_To_v1alpha2_NodeLabelsFromCloudTagsSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_NodeLocalDNSConfig_To_kops_NodeLocalDNSConfig(in *NodeLocalDNSConfig, out *kops.NodeLocalDNSConfig, s conversion.Scope) error {
 	out.Enabled = in.Enabled
 	out.Image = in.Image
@@ -6832,6 +8782,32 @@ func Convert_kops_PodIdentityWebhookConfig_To_v1alpha2_PodIdentityWebhookConfig(
 	return autoConvert_kops_PodIdentityWebhookConfig_To_v1alpha2_PodIdentityWebhookConfig(in, out, s)
 }
 
+func autoConvert_v1alpha2_ProbeSpec_To_kops_ProbeSpec(in *ProbeSpec, out *kops.ProbeSpec, s conversion.Scope) error {
+	out.InitialDelaySeconds = in.InitialDelaySeconds
+	out.TimeoutSeconds = in.TimeoutSeconds
+	out.PeriodSeconds = in.PeriodSeconds
+	out.FailureThreshold = in.FailureThreshold
+	return nil
+}
+
+// Convert_v1alpha2_ProbeSpec_To_kops_ProbeSpec is an autogenerated conversion function.
+func Convert_v1alpha2_ProbeSpec_To_kops_ProbeSpec(in *ProbeSpec, out *kops.ProbeSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ProbeSpec_To_kops_ProbeSpec(in, out, s)
+}
+
+func autoConvert_kops_ProbeSpec_To_v1alpha2_ProbeSpec(in *kops.ProbeSpec, out *ProbeSpec, s conversion.Scope) error {
+	out.InitialDelaySeconds = in.InitialDelaySeconds
+	out.TimeoutSeconds = in.TimeoutSeconds
+	out.PeriodSeconds = in.PeriodSeconds
+	out.FailureThreshold = in.FailureThreshold
+	return nil
+}
+
+// Convert_kops_ProbeSpec_To_v1alpha2_ProbeSpec is an autogenerated conversion function.
+func Convert_kops_ProbeSpec_To_v1alpha2_ProbeSpec(in *kops.ProbeSpec, out *ProbeSpec, s conversion.Scope) error {
+	return autoConvert_kops_ProbeSpec_To_v1alpha2_ProbeSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_RBACAuthorizationSpec_To_kops_RBACAuthorizationSpec(in *RBACAuthorizationSpec, out *kops.RBACAuthorizationSpec, s conversion.Scope) error {
 	return nil
 }
@@ -6854,6 +8830,8 @@ func autoConvert_v1alpha2_RollingUpdate_To_kops_RollingUpdate(in *RollingUpdate,
 	out.DrainAndTerminate = in.DrainAndTerminate
 	out.MaxUnavailable = in.MaxUnavailable
 	out.MaxSurge = in.MaxSurge
+	out.DrainTimeout = in.DrainTimeout
+	out.PDBViolationPolicy = kops.PDBViolationPolicy(in.PDBViolationPolicy)
 	return nil
 }
 
@@ -6866,6 +8844,8 @@ func autoConvert_kops_RollingUpdate_To_v1alpha2_RollingUpdate(in *kops.RollingUp
 	out.DrainAndTerminate = in.DrainAndTerminate
 	out.MaxUnavailable = in.MaxUnavailable
 	out.MaxSurge = in.MaxSurge
+	out.DrainTimeout = in.DrainTimeout
+	out.PDBViolationPolicy = PDBViolationPolicy(in.PDBViolationPolicy)
 	return nil
 }
 
@@ -6918,6 +8898,44 @@ func Convert_kops_RouteSpec_To_v1alpha2_RouteSpec(in *kops.RouteSpec, out *Route
 	return autoConvert_kops_RouteSpec_To_v1alpha2_RouteSpec(in, out, s)
 }
 
+func autoConvert_v1alpha2_Runc_To_kops_Runc(in *Runc, out *kops.Runc, s conversion.Scope) error {
+	out.Version = in.Version
+	if in.Packages != nil {
+		in, out := &in.Packages, &out.Packages
+		*out = new(kops.PackagesConfig)
+		if err := Convert_v1alpha2_PackagesConfig_To_kops_PackagesConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Packages = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha2_Runc_To_kops_Runc is an autogenerated conversion function.
+func Convert_v1alpha2_Runc_To_kops_Runc(in *Runc, out *kops.Runc, s conversion.Scope) error {
+	return autoConvert_v1alpha2_Runc_To_kops_Runc(in, out, s)
+}
+
+func autoConvert_kops_Runc_To_v1alpha2_Runc(in *kops.Runc, out *Runc, s conversion.Scope) error {
+	out.Version = in.Version
+	if in.Packages != nil {
+		in, out := &in.Packages, &out.Packages
+		*out = new(PackagesConfig)
+		if err := Convert_kops_PackagesConfig_To_v1alpha2_PackagesConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Packages = nil
+	}
+	return nil
+}
+
+// Convert_kops_Runc_To_v1alpha2_Runc is an autogenerated conversion function.
+func Convert_kops_Runc_To_v1alpha2_Runc(in *kops.Runc, out *Runc, s conversion.Scope) error {
+	return autoConvert_kops_Runc_To_v1alpha2_Runc(in, out, s)
+}
+
 func autoConvert_v1alpha2_SSHCredential_To_kops_SSHCredential(in *SSHCredential, out *kops.SSHCredential, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha2_SSHCredentialSpec_To_kops_SSHCredentialSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -7018,6 +9036,15 @@ func autoConvert_v1alpha2_ServiceAccountExternalPermission_To_kops_ServiceAccoun
 	} else {
 		out.AWS = nil
 	}
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(kops.GCPPermission)
+		if err := Convert_v1alpha2_GCPPermission_To_kops_GCPPermission(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.GCP = nil
+	}
 	return nil
 }
 
@@ -7038,6 +9065,15 @@ func autoConvert_kops_ServiceAccountExternalPermission_To_v1alpha2_ServiceAccoun
 	} else {
 		out.AWS = nil
 	}
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(GCPPermission)
+		if err := Convert_kops_GCPPermission_To_v1alpha2_GCPPermission(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.GCP = nil
+	}
 	return nil
 }
 
@@ -7050,6 +9086,7 @@ func autoConvert_v1alpha2_ServiceAccountIssuerDiscoveryConfig_To_kops_ServiceAcc
 	out.DiscoveryStore = in.DiscoveryStore
 	out.EnableAWSOIDCProvider = in.EnableAWSOIDCProvider
 	out.AdditionalAudiences = in.AdditionalAudiences
+	out.GCPWorkloadIdentityProvider = in.GCPWorkloadIdentityProvider
 	return nil
 }
 
@@ -7062,6 +9099,7 @@ func autoConvert_kops_ServiceAccountIssuerDiscoveryConfig_To_v1alpha2_ServiceAcc
 	out.DiscoveryStore = in.DiscoveryStore
 	out.EnableAWSOIDCProvider = in.EnableAWSOIDCProvider
 	out.AdditionalAudiences = in.AdditionalAudiences
+	out.GCPWorkloadIdentityProvider = in.GCPWorkloadIdentityProvider
 	return nil
 }
 
@@ -7092,6 +9130,32 @@ func Convert_kops_SnapshotControllerConfig_To_v1alpha2_SnapshotControllerConfig(
 	return autoConvert_kops_SnapshotControllerConfig_To_v1alpha2_SnapshotControllerConfig(in, out, s)
 }
 
+func autoConvert_v1alpha2_SpotFallbackSpec_To_kops_SpotFallbackSpec(in *SpotFallbackSpec, out *kops.SpotFallbackSpec, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.FailureThreshold = in.FailureThreshold
+	out.FailureWindow = in.FailureWindow
+	out.RevertAfter = in.RevertAfter
+	return nil
+}
+
+// Convert_v1alpha2_SpotFallbackSpec_To_kops_SpotFallbackSpec is an autogenerated conversion function.
+func Convert_v1alpha2_SpotFallbackSpec_To_kops_SpotFallbackSpec(in *SpotFallbackSpec, out *kops.SpotFallbackSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_SpotFallbackSpec_To_kops_SpotFallbackSpec(in, out, s)
+}
+
+func autoConvert_kops_SpotFallbackSpec_To_v1alpha2_SpotFallbackSpec(in *kops.SpotFallbackSpec, out *SpotFallbackSpec, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.FailureThreshold = in.FailureThreshold
+	out.FailureWindow = in.FailureWindow
+	out.RevertAfter = in.RevertAfter
+	return nil
+}
+
+// Convert_kops_SpotFallbackSpec_To_v1alpha2_SpotFallbackSpec is an autogenerated conversion function.
+func Convert_kops_SpotFallbackSpec_To_v1alpha2_SpotFallbackSpec(in *kops.SpotFallbackSpec, out *SpotFallbackSpec, s conversion.Scope) error {
+	return autoConvert_kops_SpotFallbackSpec_To_v1alpha2_SpotFallbackSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_TargetSpec_To_kops_TargetSpec(in *TargetSpec, out *kops.TargetSpec, s conversion.Scope) error {
 	if in.Terraform != nil {
 		in, out := &in.Terraform, &out.Terraform
@@ -7131,6 +9195,7 @@ func Convert_kops_TargetSpec_To_v1alpha2_TargetSpec(in *kops.TargetSpec, out *Ta
 func autoConvert_v1alpha2_TerraformSpec_To_kops_TerraformSpec(in *TerraformSpec, out *kops.TerraformSpec, s conversion.Scope) error {
 	out.ProviderExtraConfig = in.ProviderExtraConfig
 	out.FilesProviderExtraConfig = in.FilesProviderExtraConfig
+	out.SharedResourcesAsDataSources = in.SharedResourcesAsDataSources
 	return nil
 }
 
@@ -7142,6 +9207,7 @@ func Convert_v1alpha2_TerraformSpec_To_kops_TerraformSpec(in *TerraformSpec, out
 func autoConvert_kops_TerraformSpec_To_v1alpha2_TerraformSpec(in *kops.TerraformSpec, out *TerraformSpec, s conversion.Scope) error {
 	out.ProviderExtraConfig = in.ProviderExtraConfig
 	out.FilesProviderExtraConfig = in.FilesProviderExtraConfig
+	out.SharedResourcesAsDataSources = in.SharedResourcesAsDataSources
 	return nil
 }
 
@@ -7232,6 +9298,86 @@ func Convert_kops_UserData_To_v1alpha2_UserData(in *kops.UserData, out *UserData
 	return autoConvert_kops_UserData_To_v1alpha2_UserData(in, out, s)
 }
 
+func autoConvert_v1alpha2_ValidationCheck_To_kops_ValidationCheck(in *ValidationCheck, out *kops.ValidationCheck, s conversion.Scope) error {
+	out.Name = in.Name
+	if in.DaemonSet != nil {
+		in, out := &in.DaemonSet, &out.DaemonSet
+		*out = new(kops.ValidationCheckObject)
+		if err := Convert_v1alpha2_ValidationCheckObject_To_kops_ValidationCheckObject(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.DaemonSet = nil
+	}
+	if in.Deployment != nil {
+		in, out := &in.Deployment, &out.Deployment
+		*out = new(kops.ValidationCheckObject)
+		if err := Convert_v1alpha2_ValidationCheckObject_To_kops_ValidationCheckObject(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Deployment = nil
+	}
+	out.MinNodesPerZone = in.MinNodesPerZone
+	return nil
+}
+
+// Convert_v1alpha2_ValidationCheck_To_kops_ValidationCheck is an autogenerated conversion function.
+func Convert_v1alpha2_ValidationCheck_To_kops_ValidationCheck(in *ValidationCheck, out *kops.ValidationCheck, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ValidationCheck_To_kops_ValidationCheck(in, out, s)
+}
+
+func autoConvert_kops_ValidationCheck_To_v1alpha2_ValidationCheck(in *kops.ValidationCheck, out *ValidationCheck, s conversion.Scope) error {
+	out.Name = in.Name
+	if in.DaemonSet != nil {
+		in, out := &in.DaemonSet, &out.DaemonSet
+		*out = new(ValidationCheckObject)
+		if err := Convert_kops_ValidationCheckObject_To_v1alpha2_ValidationCheckObject(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.DaemonSet = nil
+	}
+	if in.Deployment != nil {
+		in, out := &in.Deployment, &out.Deployment
+		*out = new(ValidationCheckObject)
+		if err := Convert_kops_ValidationCheckObject_To_v1alpha2_ValidationCheckObject(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Deployment = nil
+	}
+	out.MinNodesPerZone = in.MinNodesPerZone
+	return nil
+}
+
+// Convert_kops_ValidationCheck_To_v1alpha2_ValidationCheck is an autogenerated conversion function.
+func Convert_kops_ValidationCheck_To_v1alpha2_ValidationCheck(in *kops.ValidationCheck, out *ValidationCheck, s conversion.Scope) error {
+	return autoConvert_kops_ValidationCheck_To_v1alpha2_ValidationCheck(in, out, s)
+}
+
+func autoConvert_v1alpha2_ValidationCheckObject_To_kops_ValidationCheckObject(in *ValidationCheckObject, out *kops.ValidationCheckObject, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1alpha2_ValidationCheckObject_To_kops_ValidationCheckObject is an autogenerated conversion function.
+func Convert_v1alpha2_ValidationCheckObject_To_kops_ValidationCheckObject(in *ValidationCheckObject, out *kops.ValidationCheckObject, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ValidationCheckObject_To_kops_ValidationCheckObject(in, out, s)
+}
+
+func autoConvert_kops_ValidationCheckObject_To_v1alpha2_ValidationCheckObject(in *kops.ValidationCheckObject, out *ValidationCheckObject, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_kops_ValidationCheckObject_To_v1alpha2_ValidationCheckObject is an autogenerated conversion function.
+func Convert_kops_ValidationCheckObject_To_v1alpha2_ValidationCheckObject(in *kops.ValidationCheckObject, out *ValidationCheckObject, s conversion.Scope) error {
+	return autoConvert_kops_ValidationCheckObject_To_v1alpha2_ValidationCheckObject(in, out, s)
+}
+
 func autoConvert_v1alpha2_VolumeMountSpec_To_kops_VolumeMountSpec(in *VolumeMountSpec, out *kops.VolumeMountSpec, s conversion.Scope) error {
 	out.Device = in.Device
 	out.Filesystem = in.Filesystem
@@ -177,6 +177,22 @@ func (in *AWSPermission) DeepCopy() *AWSPermission {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSPrivateCAIssuerSpec) DeepCopyInto(out *AWSPrivateCAIssuerSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSPrivateCAIssuerSpec.
+func (in *AWSPrivateCAIssuerSpec) DeepCopy() *AWSPrivateCAIssuerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSPrivateCAIssuerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AcceleratorConfig) DeepCopyInto(out *AcceleratorConfig) {
 	*out = *in
@@ -251,6 +267,32 @@ func (in *AddonSpec) DeepCopy() *AddonSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdmissionPluginConfigSpec) DeepCopyInto(out *AdmissionPluginConfigSpec) {
+	*out = *in
+	if in.EventRateLimit != nil {
+		in, out := &in.EventRateLimit, &out.EventRateLimit
+		*out = new(EventRateLimitAdmissionConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImagePolicyWebhook != nil {
+		in, out := &in.ImagePolicyWebhook, &out.ImagePolicyWebhook
+		*out = new(ImagePolicyWebhookAdmissionConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionPluginConfigSpec.
+func (in *AdmissionPluginConfigSpec) DeepCopy() *AdmissionPluginConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AdmissionPluginConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AlwaysAllowAuthorizationSpec) DeepCopyInto(out *AlwaysAllowAuthorizationSpec) {
 	*out = *in
@@ -288,6 +330,60 @@ func (in *AmazonVPCNetworkingSpec) DeepCopy() *AmazonVPCNetworkingSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AssetCacheSpec) DeepCopyInto(out *AssetCacheSpec) {
+	*out = *in
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = new(string)
+		**out = **in
+	}
+	if in.URL != nil {
+		in, out := &in.URL, &out.URL
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AssetCacheSpec.
+func (in *AssetCacheSpec) DeepCopy() *AssetCacheSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AssetCacheSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AssetTrustPolicy) DeepCopyInto(out *AssetTrustPolicy) {
+	*out = *in
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]ImageTrustPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Files != nil {
+		in, out := &in.Files, &out.Files
+		*out = make([]FileTrustPolicy, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AssetTrustPolicy.
+func (in *AssetTrustPolicy) DeepCopy() *AssetTrustPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AssetTrustPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Assets) DeepCopyInto(out *Assets) {
 	*out = *in
@@ -306,6 +402,11 @@ func (in *Assets) DeepCopyInto(out *Assets) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.TrustPolicy != nil {
+		in, out := &in.TrustPolicy, &out.TrustPolicy
+		*out = new(AssetTrustPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -553,6 +654,60 @@ func (in *CertManagerConfig) DeepCopy() *CertManagerConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateIssuerSpec) DeepCopyInto(out *CertificateIssuerSpec) {
+	*out = *in
+	if in.AWSPrivateCA != nil {
+		in, out := &in.AWSPrivateCA, &out.AWSPrivateCA
+		*out = new(AWSPrivateCAIssuerSpec)
+		**out = **in
+	}
+	if in.GCPCAS != nil {
+		in, out := &in.GCPCAS, &out.GCPCAS
+		*out = new(GCPCASIssuerSpec)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateIssuerSpec.
+func (in *CertificateIssuerSpec) DeepCopy() *CertificateIssuerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateIssuerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CiliumENISpec) DeepCopyInto(out *CiliumENISpec) {
+	*out = *in
+	if in.SubnetIDsFilter != nil {
+		in, out := &in.SubnetIDsFilter, &out.SubnetIDsFilter
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SubnetTagsFilter != nil {
+		in, out := &in.SubnetTagsFilter, &out.SubnetTagsFilter
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CiliumENISpec.
+func (in *CiliumENISpec) DeepCopy() *CiliumENISpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CiliumENISpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CiliumNetworkingSpec) DeepCopyInto(out *CiliumNetworkingSpec) {
 	*out = *in
@@ -657,6 +812,11 @@ func (in *CiliumNetworkingSpec) DeepCopyInto(out *CiliumNetworkingSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.ENI != nil {
+		in, out := &in.ENI, &out.ENI
+		*out = new(CiliumENISpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -858,6 +1018,22 @@ func (in *CloudControllerManagerConfig) DeepCopy() *CloudControllerManagerConfig
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudInitSpec) DeepCopyInto(out *CloudInitSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudInitSpec.
+func (in *CloudInitSpec) DeepCopy() *CloudInitSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudInitSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Cluster) DeepCopyInto(out *Cluster) {
 	*out = *in
@@ -1037,6 +1213,11 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(DNSControllerGossipConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DNSRecords != nil {
+		in, out := &in.DNSRecords, &out.DNSRecords
+		*out = new(DNSRecordsSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.AdditionalSANs != nil {
 		in, out := &in.AdditionalSANs, &out.AdditionalSANs
 		*out = make([]string, len(*in))
@@ -1126,6 +1307,11 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(ContainerdConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Crio != nil {
+		in, out := &in.Crio, &out.Crio
+		*out = new(CrioConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Docker != nil {
 		in, out := &in.Docker, &out.Docker
 		*out = new(DockerConfig)
@@ -1196,6 +1382,11 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(NodeProblemDetectorConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(MonitoringSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.MetricsServer != nil {
 		in, out := &in.MetricsServer, &out.MetricsServer
 		*out = new(MetricsServerConfig)
@@ -1236,6 +1427,11 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(NodeAuthorizationSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NodeCertificates != nil {
+		in, out := &in.NodeCertificates, &out.NodeCertificates
+		*out = new(NodeCertificatesSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.CloudLabels != nil {
 		in, out := &in.CloudLabels, &out.CloudLabels
 		*out = make(map[string]string, len(*in))
@@ -1243,6 +1439,11 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.NodeLabelsFromCloudTags != nil {
+		in, out := &in.NodeLabelsFromCloudTags, &out.NodeLabelsFromCloudTags
+		*out = new(NodeLabelsFromCloudTagsSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Hooks != nil {
 		in, out := &in.Hooks, &out.Hooks
 		*out = make([]HookSpec, len(*in))
@@ -1290,6 +1491,13 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(RollingUpdate)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ValidationChecks != nil {
+		in, out := &in.ValidationChecks, &out.ValidationChecks
+		*out = make([]ValidationCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.ClusterAutoscaler != nil {
 		in, out := &in.ClusterAutoscaler, &out.ClusterAutoscaler
 		*out = new(ClusterAutoscalerConfig)
@@ -1300,6 +1508,11 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(WarmPoolSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.InstanceMetadata != nil {
+		in, out := &in.InstanceMetadata, &out.InstanceMetadata
+		*out = new(InstanceMetadataOptions)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ServiceAccountIssuerDiscovery != nil {
 		in, out := &in.ServiceAccountIssuerDiscovery, &out.ServiceAccountIssuerDiscovery
 		*out = new(ServiceAccountIssuerDiscoveryConfig)
@@ -1320,6 +1533,11 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(PodIdentityWebhookConfig)
 		**out = **in
 	}
+	if in.CertificateIssuer != nil {
+		in, out := &in.CertificateIssuer, &out.CertificateIssuer
+		*out = new(CertificateIssuerSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1392,6 +1610,18 @@ func (in *ContainerdConfig) DeepCopyInto(out *ContainerdConfig) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.Registries != nil {
+		in, out := &in.Registries, &out.Registries
+		*out = make(map[string]ContainerdRegistryConfig, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.ConfigAdditions != nil {
+		in, out := &in.ConfigAdditions, &out.ConfigAdditions
+		*out = new(string)
+		**out = **in
+	}
 	if in.Root != nil {
 		in, out := &in.Root, &out.Root
 		*out = new(string)
@@ -1412,6 +1642,11 @@ func (in *ContainerdConfig) DeepCopyInto(out *ContainerdConfig) {
 		*out = new(NvidiaGPUConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Runc != nil {
+		in, out := &in.Runc, &out.Runc
+		*out = new(Runc)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1425,6 +1660,102 @@ func (in *ContainerdConfig) DeepCopy() *ContainerdConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerdRegistryAuth) DeepCopyInto(out *ContainerdRegistryAuth) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerdRegistryAuth.
+func (in *ContainerdRegistryAuth) DeepCopy() *ContainerdRegistryAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerdRegistryAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerdRegistryConfig) DeepCopyInto(out *ContainerdRegistryConfig) {
+	*out = *in
+	if in.Mirrors != nil {
+		in, out := &in.Mirrors, &out.Mirrors
+		*out = make([]ContainerdRegistryMirror, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerdRegistryConfig.
+func (in *ContainerdRegistryConfig) DeepCopy() *ContainerdRegistryConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerdRegistryConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerdRegistryMirror) DeepCopyInto(out *ContainerdRegistryMirror) {
+	*out = *in
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(ContainerdRegistryAuth)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerdRegistryMirror.
+func (in *ContainerdRegistryMirror) DeepCopy() *ContainerdRegistryMirror {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerdRegistryMirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CrioConfig) DeepCopyInto(out *CrioConfig) {
+	*out = *in
+	if in.ConfigOverride != nil {
+		in, out := &in.ConfigOverride, &out.ConfigOverride
+		*out = new(string)
+		**out = **in
+	}
+	if in.LogLevel != nil {
+		in, out := &in.LogLevel, &out.LogLevel
+		*out = new(string)
+		**out = **in
+	}
+	if in.Packages != nil {
+		in, out := &in.Packages, &out.Packages
+		*out = new(PackagesConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrioConfig.
+func (in *CrioConfig) DeepCopy() *CrioConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CrioConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNSAccessSpec) DeepCopyInto(out *DNSAccessSpec) {
 	*out = *in
@@ -1469,6 +1800,21 @@ func (in *DNSControllerGossipConfig) DeepCopyInto(out *DNSControllerGossipConfig
 		*out = new(string)
 		**out = **in
 	}
+	if in.ProbeInterval != nil {
+		in, out := &in.ProbeInterval, &out.ProbeInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.GossipInterval != nil {
+		in, out := &in.GossipInterval, &out.GossipInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.PushPullInterval != nil {
+		in, out := &in.PushPullInterval, &out.PushPullInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -1518,6 +1864,63 @@ func (in *DNSControllerGossipConfigSecondary) DeepCopy() *DNSControllerGossipCon
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSRecordSpec) DeepCopyInto(out *DNSRecordSpec) {
+	*out = *in
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSRecordSpec.
+func (in *DNSRecordSpec) DeepCopy() *DNSRecordSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSRecordSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSRecordsSpec) DeepCopyInto(out *DNSRecordsSpec) {
+	*out = *in
+	if in.API != nil {
+		in, out := &in.API, &out.API
+		*out = new(DNSRecordSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.APIInternal != nil {
+		in, out := &in.APIInternal, &out.APIInternal
+		*out = new(DNSRecordSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Bastion != nil {
+		in, out := &in.Bastion, &out.Bastion
+		*out = new(DNSRecordSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KopsController != nil {
+		in, out := &in.KopsController, &out.KopsController
+		*out = new(DNSRecordSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSRecordsSpec.
+func (in *DNSRecordsSpec) DeepCopy() *DNSRecordsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSRecordsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNSSpec) DeepCopyInto(out *DNSSpec) {
 	*out = *in
@@ -1677,30 +2080,56 @@ func (in *DockerConfig) DeepCopyInto(out *DockerConfig) {
 		*out = new(bool)
 		**out = **in
 	}
-	if in.Storage != nil {
-		in, out := &in.Storage, &out.Storage
-		*out = new(string)
-		**out = **in
-	}
-	if in.StorageOpts != nil {
-		in, out := &in.StorageOpts, &out.StorageOpts
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Version != nil {
-		in, out := &in.Version, &out.Version
-		*out = new(string)
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(string)
+		**out = **in
+	}
+	if in.StorageOpts != nil {
+		in, out := &in.StorageOpts, &out.StorageOpts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DockerConfig.
+func (in *DockerConfig) DeepCopy() *DockerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DockerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EFASpec) DeepCopyInto(out *EFASpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NetworkCards != nil {
+		in, out := &in.NetworkCards, &out.NetworkCards
+		*out = new(int32)
 		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DockerConfig.
-func (in *DockerConfig) DeepCopy() *DockerConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EFASpec.
+func (in *EFASpec) DeepCopy() *EFASpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DockerConfig)
+	out := new(EFASpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1741,6 +2170,11 @@ func (in *EnvVar) DeepCopy() *EnvVar {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EtcdBackupSpec) DeepCopyInto(out *EtcdBackupSpec) {
 	*out = *in
+	if in.CrossAccountStore != nil {
+		in, out := &in.CrossAccountStore, &out.CrossAccountStore
+		*out = new(EtcdCrossAccountStoreSpec)
+		**out = **in
+	}
 	return
 }
 
@@ -1774,10 +2208,20 @@ func (in *EtcdClusterSpec) DeepCopyInto(out *EtcdClusterSpec) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.QuotaBackendBytes != nil {
+		in, out := &in.QuotaBackendBytes, &out.QuotaBackendBytes
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.SnapshotCount != nil {
+		in, out := &in.SnapshotCount, &out.SnapshotCount
+		*out = new(int64)
+		**out = **in
+	}
 	if in.Backups != nil {
 		in, out := &in.Backups, &out.Backups
 		*out = new(EtcdBackupSpec)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Manager != nil {
 		in, out := &in.Manager, &out.Manager
@@ -1807,6 +2251,22 @@ func (in *EtcdClusterSpec) DeepCopy() *EtcdClusterSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdCrossAccountStoreSpec) DeepCopyInto(out *EtcdCrossAccountStoreSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdCrossAccountStoreSpec.
+func (in *EtcdCrossAccountStoreSpec) DeepCopy() *EtcdCrossAccountStoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdCrossAccountStoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EtcdManagerSpec) DeepCopyInto(out *EtcdManagerSpec) {
 	*out = *in
@@ -1889,6 +2349,50 @@ func (in *EtcdMemberSpec) DeepCopy() *EtcdMemberSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventRateLimit) DeepCopyInto(out *EventRateLimit) {
+	*out = *in
+	if in.CacheSize != nil {
+		in, out := &in.CacheSize, &out.CacheSize
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventRateLimit.
+func (in *EventRateLimit) DeepCopy() *EventRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(EventRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventRateLimitAdmissionConfig) DeepCopyInto(out *EventRateLimitAdmissionConfig) {
+	*out = *in
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = make([]EventRateLimit, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventRateLimitAdmissionConfig.
+func (in *EventRateLimitAdmissionConfig) DeepCopy() *EventRateLimitAdmissionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EventRateLimitAdmissionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExecContainerAction) DeepCopyInto(out *ExecContainerAction) {
 	*out = *in
@@ -1975,6 +2479,22 @@ func (in *FileAssetSpec) DeepCopy() *FileAssetSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileTrustPolicy) DeepCopyInto(out *FileTrustPolicy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileTrustPolicy.
+func (in *FileTrustPolicy) DeepCopy() *FileTrustPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(FileTrustPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FlannelNetworkingSpec) DeepCopyInto(out *FlannelNetworkingSpec) {
 	*out = *in
@@ -2012,6 +2532,22 @@ func (in *GCENetworkingSpec) DeepCopy() *GCENetworkingSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPCASIssuerSpec) DeepCopyInto(out *GCPCASIssuerSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPCASIssuerSpec.
+func (in *GCPCASIssuerSpec) DeepCopy() *GCPCASIssuerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPCASIssuerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GCPPDCSIDriver) DeepCopyInto(out *GCPPDCSIDriver) {
 	*out = *in
@@ -2033,6 +2569,27 @@ func (in *GCPPDCSIDriver) DeepCopy() *GCPPDCSIDriver {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPPermission) DeepCopyInto(out *GCPPermission) {
+	*out = *in
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPPermission.
+func (in *GCPPermission) DeepCopy() *GCPPermission {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPPermission)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GossipConfig) DeepCopyInto(out *GossipConfig) {
 	*out = *in
@@ -2056,6 +2613,26 @@ func (in *GossipConfig) DeepCopyInto(out *GossipConfig) {
 		*out = new(GossipConfigSecondary)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ProbeInterval != nil {
+		in, out := &in.ProbeInterval, &out.ProbeInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.GossipInterval != nil {
+		in, out := &in.GossipInterval, &out.GossipInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.PushPullInterval != nil {
+		in, out := &in.PushPullInterval, &out.PushPullInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -2237,6 +2814,58 @@ func (in *IAMSpec) DeepCopy() *IAMSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePolicyWebhookAdmissionConfig) DeepCopyInto(out *ImagePolicyWebhookAdmissionConfig) {
+	*out = *in
+	if in.AllowTTL != nil {
+		in, out := &in.AllowTTL, &out.AllowTTL
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DenyTTL != nil {
+		in, out := &in.DenyTTL, &out.DenyTTL
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RetryBackoff != nil {
+		in, out := &in.RetryBackoff, &out.RetryBackoff
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePolicyWebhookAdmissionConfig.
+func (in *ImagePolicyWebhookAdmissionConfig) DeepCopy() *ImagePolicyWebhookAdmissionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePolicyWebhookAdmissionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageTrustPolicy) DeepCopyInto(out *ImageTrustPolicy) {
+	*out = *in
+	if in.PublicKeys != nil {
+		in, out := &in.PublicKeys, &out.PublicKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageTrustPolicy.
+func (in *ImageTrustPolicy) DeepCopy() *ImageTrustPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageTrustPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstanceGroup) DeepCopyInto(out *InstanceGroup) {
 	*out = *in
@@ -2297,6 +2926,32 @@ func (in *InstanceGroupList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceGroupMonitoringSpec) DeepCopyInto(out *InstanceGroupMonitoringSpec) {
+	*out = *in
+	if in.NodeExporter != nil {
+		in, out := &in.NodeExporter, &out.NodeExporter
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CloudWatchAgent != nil {
+		in, out := &in.CloudWatchAgent, &out.CloudWatchAgent
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceGroupMonitoringSpec.
+func (in *InstanceGroupMonitoringSpec) DeepCopy() *InstanceGroupMonitoringSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceGroupMonitoringSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstanceGroupSpec) DeepCopyInto(out *InstanceGroupSpec) {
 	*out = *in
@@ -2432,6 +3087,16 @@ func (in *InstanceGroupSpec) DeepCopyInto(out *InstanceGroupSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.HostResourceGroupARN != nil {
+		in, out := &in.HostResourceGroupARN, &out.HostResourceGroupARN
+		*out = new(string)
+		**out = **in
+	}
+	if in.HostAffinity != nil {
+		in, out := &in.HostAffinity, &out.HostAffinity
+		*out = new(string)
+		**out = **in
+	}
 	if in.Kubelet != nil {
 		in, out := &in.Kubelet, &out.Kubelet
 		*out = new(KubeletConfigSpec)
@@ -2452,6 +3117,16 @@ func (in *InstanceGroupSpec) DeepCopyInto(out *InstanceGroupSpec) {
 		*out = make([]UserData, len(*in))
 		copy(*out, *in)
 	}
+	if in.CloudInit != nil {
+		in, out := &in.CloudInit, &out.CloudInit
+		*out = new(CloudInitSpec)
+		**out = **in
+	}
+	if in.Ignition != nil {
+		in, out := &in.Ignition, &out.Ignition
+		*out = new(bool)
+		**out = **in
+	}
 	if in.SuspendProcesses != nil {
 		in, out := &in.SuspendProcesses, &out.SuspendProcesses
 		*out = make([]string, len(*in))
@@ -2534,6 +3209,41 @@ func (in *InstanceGroupSpec) DeepCopyInto(out *InstanceGroupSpec) {
 		*out = make([]AcceleratorConfig, len(*in))
 		copy(*out, *in)
 	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(InstanceGroupMonitoringSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EFA != nil {
+		in, out := &in.EFA, &out.EFA
+		*out = new(EFASpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NitroEnclave != nil {
+		in, out := &in.NitroEnclave, &out.NitroEnclave
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ElasticInferenceAccelerators != nil {
+		in, out := &in.ElasticInferenceAccelerators, &out.ElasticInferenceAccelerators
+		*out = make([]AcceleratorConfig, len(*in))
+		copy(*out, *in)
+	}
+	if in.AssetCache != nil {
+		in, out := &in.AssetCache, &out.AssetCache
+		*out = new(AssetCacheSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PreloadImages != nil {
+		in, out := &in.PreloadImages, &out.PreloadImages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeUp != nil {
+		in, out := &in.NodeUp, &out.NodeUp
+		*out = new(PackagesConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2705,6 +3415,22 @@ func (in *KeysetList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeysetRotation) DeepCopyInto(out *KeysetRotation) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeysetRotation.
+func (in *KeysetRotation) DeepCopy() *KeysetRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(KeysetRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeysetSpec) DeepCopyInto(out *KeysetSpec) {
 	*out = *in
@@ -2715,6 +3441,11 @@ func (in *KeysetSpec) DeepCopyInto(out *KeysetSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Rotation != nil {
+		in, out := &in.Rotation, &out.Rotation
+		*out = new(KeysetRotation)
+		**out = **in
+	}
 	return
 }
 
@@ -2803,6 +3534,11 @@ func (in *KubeAPIServerConfig) DeepCopyInto(out *KubeAPIServerConfig) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.AdmissionPluginConfig != nil {
+		in, out := &in.AdmissionPluginConfig, &out.AdmissionPluginConfig
+		*out = new(AdmissionPluginConfigSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.EtcdServers != nil {
 		in, out := &in.EtcdServers, &out.EtcdServers
 		*out = make([]string, len(*in))
@@ -3032,6 +3768,16 @@ func (in *KubeAPIServerConfig) DeepCopyInto(out *KubeAPIServerConfig) {
 			(*out)[key] = val
 		}
 	}
+	if in.EnablePriorityAndFairness != nil {
+		in, out := &in.EnablePriorityAndFairness, &out.EnablePriorityAndFairness
+		*out = new(bool)
+		**out = **in
+	}
+	if in.FlowSchemaPresets != nil {
+		in, out := &in.FlowSchemaPresets, &out.FlowSchemaPresets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.HTTP2MaxStreamsPerConnection != nil {
 		in, out := &in.HTTP2MaxStreamsPerConnection, &out.HTTP2MaxStreamsPerConnection
 		*out = new(int32)
@@ -3127,15 +3873,66 @@ func (in *KubeAPIServerConfig) DeepCopyInto(out *KubeAPIServerConfig) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.HealthcheckSidecar != nil {
+		in, out := &in.HealthcheckSidecar, &out.HealthcheckSidecar
+		*out = new(KubeAPIServerHealthcheckSidecarConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(ProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(ProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(ProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeAPIServerConfig.
+func (in *KubeAPIServerConfig) DeepCopy() *KubeAPIServerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeAPIServerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeAPIServerHealthcheckSidecarConfig) DeepCopyInto(out *KubeAPIServerHealthcheckSidecarConfig) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = new(string)
+		**out = **in
+	}
+	if in.AdditionalChecks != nil {
+		in, out := &in.AdditionalChecks, &out.AdditionalChecks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeAPIServerConfig.
-func (in *KubeAPIServerConfig) DeepCopy() *KubeAPIServerConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeAPIServerHealthcheckSidecarConfig.
+func (in *KubeAPIServerHealthcheckSidecarConfig) DeepCopy() *KubeAPIServerHealthcheckSidecarConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeAPIServerConfig)
+	out := new(KubeAPIServerHealthcheckSidecarConfig)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -3335,6 +4132,16 @@ func (in *KubeControllerManagerConfig) DeepCopyInto(out *KubeControllerManagerCo
 		*out = new(bool)
 		**out = **in
 	}
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(ProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(ProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3549,6 +4356,16 @@ func (in *KubeSchedulerConfig) DeepCopyInto(out *KubeSchedulerConfig) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(ProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(ProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3848,6 +4665,11 @@ func (in *KubeletConfigSpec) DeepCopyInto(out *KubeletConfigSpec) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.ImageCredentialProviders != nil {
+		in, out := &in.ImageCredentialProviders, &out.ImageCredentialProviders
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -4010,6 +4832,21 @@ func (in *LoadBalancerAccessSpec) DeepCopyInto(out *LoadBalancerAccessSpec) {
 		*out = new(AccessLogSpec)
 		**out = **in
 	}
+	if in.ConnectionDrainingTimeoutSeconds != nil {
+		in, out := &in.ConnectionDrainingTimeoutSeconds, &out.ConnectionDrainingTimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DeregistrationDelaySeconds != nil {
+		in, out := &in.DeregistrationDelaySeconds, &out.DeregistrationDelaySeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(LoadBalancerHealthCheckSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -4023,6 +4860,37 @@ func (in *LoadBalancerAccessSpec) DeepCopy() *LoadBalancerAccessSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerHealthCheckSpec) DeepCopyInto(out *LoadBalancerHealthCheckSpec) {
+	*out = *in
+	if in.Protocol != nil {
+		in, out := &in.Protocol, &out.Protocol
+		*out = new(string)
+		**out = **in
+	}
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerHealthCheckSpec.
+func (in *LoadBalancerHealthCheckSpec) DeepCopy() *LoadBalancerHealthCheckSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerHealthCheckSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LoadBalancerSubnetSpec) DeepCopyInto(out *LoadBalancerSubnetSpec) {
 	*out = *in
@@ -4167,6 +5035,11 @@ func (in *MixedInstancesPolicySpec) DeepCopyInto(out *MixedInstancesPolicySpec)
 		*out = new(int64)
 		**out = **in
 	}
+	if in.SpotFallback != nil {
+		in, out := &in.SpotFallback, &out.SpotFallback
+		*out = new(SpotFallbackSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -4180,6 +5053,83 @@ func (in *MixedInstancesPolicySpec) DeepCopy() *MixedInstancesPolicySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringAgentConfig) DeepCopyInto(out *MonitoringAgentConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(string)
+		**out = **in
+	}
+	if in.ResourcePreset != nil {
+		in, out := &in.ResourcePreset, &out.ResourcePreset
+		*out = new(string)
+		**out = **in
+	}
+	if in.MemoryRequest != nil {
+		in, out := &in.MemoryRequest, &out.MemoryRequest
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.CPURequest != nil {
+		in, out := &in.CPURequest, &out.CPURequest
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MemoryLimit != nil {
+		in, out := &in.MemoryLimit, &out.MemoryLimit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.CPULimit != nil {
+		in, out := &in.CPULimit, &out.CPULimit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringAgentConfig.
+func (in *MonitoringAgentConfig) DeepCopy() *MonitoringAgentConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringAgentConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringSpec) DeepCopyInto(out *MonitoringSpec) {
+	*out = *in
+	if in.NodeExporter != nil {
+		in, out := &in.NodeExporter, &out.NodeExporter
+		*out = new(MonitoringAgentConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CloudWatchAgent != nil {
+		in, out := &in.CloudWatchAgent, &out.CloudWatchAgent
+		*out = new(MonitoringAgentConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringSpec.
+func (in *MonitoringSpec) DeepCopy() *MonitoringSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NTPConfig) DeepCopyInto(out *NTPConfig) {
 	*out = *in
@@ -4349,6 +5299,53 @@ func (in *NodeAuthorizerSpec) DeepCopy() *NodeAuthorizerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeCertificatesSpec) DeepCopyInto(out *NodeCertificatesSpec) {
+	*out = *in
+	if in.Validity != nil {
+		in, out := &in.Validity, &out.Validity
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeCertificatesSpec.
+func (in *NodeCertificatesSpec) DeepCopy() *NodeCertificatesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeCertificatesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeLabelsFromCloudTagsSpec) DeepCopyInto(out *NodeLabelsFromCloudTagsSpec) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeLabelsFromCloudTagsSpec.
+func (in *NodeLabelsFromCloudTagsSpec) DeepCopy() *NodeLabelsFromCloudTagsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeLabelsFromCloudTagsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeLocalDNSConfig) DeepCopyInto(out *NodeLocalDNSConfig) {
 	*out = *in
@@ -4871,6 +5868,42 @@ func (in *PodIdentityWebhookConfig) DeepCopy() *PodIdentityWebhookConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeSpec) DeepCopyInto(out *ProbeSpec) {
+	*out = *in
+	if in.InitialDelaySeconds != nil {
+		in, out := &in.InitialDelaySeconds, &out.InitialDelaySeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PeriodSeconds != nil {
+		in, out := &in.PeriodSeconds, &out.PeriodSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeSpec.
+func (in *ProbeSpec) DeepCopy() *ProbeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RBACAuthorizationSpec) DeepCopyInto(out *RBACAuthorizationSpec) {
 	*out = *in
@@ -4905,6 +5938,11 @@ func (in *RollingUpdate) DeepCopyInto(out *RollingUpdate) {
 		*out = new(intstr.IntOrString)
 		**out = **in
 	}
+	if in.DrainTimeout != nil {
+		in, out := &in.DrainTimeout, &out.DrainTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -4950,6 +5988,32 @@ func (in *RouteSpec) DeepCopy() *RouteSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Runc) DeepCopyInto(out *Runc) {
+	*out = *in
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
+		*out = new(string)
+		**out = **in
+	}
+	if in.Packages != nil {
+		in, out := &in.Packages, &out.Packages
+		*out = new(PackagesConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Runc.
+func (in *Runc) DeepCopy() *Runc {
+	if in == nil {
+		return nil
+	}
+	out := new(Runc)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SSHCredential) DeepCopyInto(out *SSHCredential) {
 	*out = *in
@@ -5034,6 +6098,11 @@ func (in *ServiceAccountExternalPermission) DeepCopyInto(out *ServiceAccountExte
 		*out = new(AWSPermission)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(GCPPermission)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -5089,6 +6158,42 @@ func (in *SnapshotControllerConfig) DeepCopy() *SnapshotControllerConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpotFallbackSpec) DeepCopyInto(out *SpotFallbackSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailureWindow != nil {
+		in, out := &in.FailureWindow, &out.FailureWindow
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RevertAfter != nil {
+		in, out := &in.RevertAfter, &out.RevertAfter
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpotFallbackSpec.
+func (in *SpotFallbackSpec) DeepCopy() *SpotFallbackSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SpotFallbackSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TargetSpec) DeepCopyInto(out *TargetSpec) {
 	*out = *in
@@ -5135,6 +6240,11 @@ func (in *TerraformSpec) DeepCopyInto(out *TerraformSpec) {
 			}
 		}
 	}
+	if in.SharedResourcesAsDataSources != nil {
+		in, out := &in.SharedResourcesAsDataSources, &out.SharedResourcesAsDataSources
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -5190,6 +6300,53 @@ func (in *UserData) DeepCopy() *UserData {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValidationCheck) DeepCopyInto(out *ValidationCheck) {
+	*out = *in
+	if in.DaemonSet != nil {
+		in, out := &in.DaemonSet, &out.DaemonSet
+		*out = new(ValidationCheckObject)
+		**out = **in
+	}
+	if in.Deployment != nil {
+		in, out := &in.Deployment, &out.Deployment
+		*out = new(ValidationCheckObject)
+		**out = **in
+	}
+	if in.MinNodesPerZone != nil {
+		in, out := &in.MinNodesPerZone, &out.MinNodesPerZone
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidationCheck.
+func (in *ValidationCheck) DeepCopy() *ValidationCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(ValidationCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValidationCheckObject) DeepCopyInto(out *ValidationCheckObject) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidationCheckObject.
+func (in *ValidationCheckObject) DeepCopy() *ValidationCheckObject {
+	if in == nil {
+		return nil
+	}
+	out := new(ValidationCheckObject)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeMountSpec) DeepCopyInto(out *VolumeMountSpec) {
 	*out = *in
@@ -90,6 +90,9 @@ type ClusterSpec struct {
 	SecretStore string `json:"secretStore,omitempty"`
 	// KeyStore is the VFS path to where SSL keys and certificates are stored
 	KeyStore string `json:"keyStore,omitempty"`
+	// KeyStoreKMSKey is the cloud KMS key with which the private keys in the KeyStore are wrapped, either the ARN of an AWS KMS key
+	// or the resource name of a GCP KMS key (projects/*/locations/*/keyRings/*/cryptoKeys/*)
+	KeyStoreKMSKey string `json:"keyStoreKMSKey,omitempty"`
 	// ConfigStore is the VFS path to where the configuration (Cluster, InstanceGroups etc) is stored
 	ConfigStore string `json:"configStore,omitempty"`
 	// DNSZone is the DNS zone we should use when configuring DNS
@@ -101,6 +104,8 @@ type ClusterSpec struct {
 	DNSZone string `json:"dnsZone,omitempty"`
 	// DNSControllerGossipConfig for the cluster assuming the use of gossip DNS
 	DNSControllerGossipConfig *DNSControllerGossipConfig `json:"dnsControllerGossipConfig,omitempty"`
+	// DNSRecords configures the DNS records that kOps manages for the cluster.
+	DNSRecords *DNSRecordsSpec `json:"dnsRecords,omitempty"`
 	// AdditionalSANs adds additional Subject Alternate Names to apiserver cert that kops generates
 	AdditionalSANs []string `json:"additionalSANs,omitempty"`
 	// ClusterDNSDomain is the suffix we use for internal DNS names (normally cluster.local)
@@ -120,6 +125,9 @@ type ClusterSpec struct {
 	NodePortAccess []string `json:"nodePortAccess,omitempty"`
 	// HTTPProxy defines connection information to support use of a private cluster behind an forward HTTP Proxy
 	EgressProxy *EgressProxySpec `json:"egressProxy,omitempty"`
+	// DisableConnectivityChecks stops nodeup from checking, when it first configures an instance, that the instance can reach
+	// the endpoints it depends on
+	DisableConnectivityChecks bool `json:"disableConnectivityChecks,omitempty"`
 	// SSHKeyName specifies a preexisting SSH key to use
 	SSHKeyName *string `json:"sshKeyName,omitempty"`
 	// KubernetesAPIAccess determines the permitted access to the API endpoints (master HTTPS)
@@ -148,6 +156,7 @@ type ClusterSpec struct {
 	EtcdClusters []EtcdClusterSpec `json:"etcdClusters,omitempty"`
 	// Component configurations
 	Containerd                     *ContainerdConfig             `json:"containerd,omitempty"`
+	Crio                           *CrioConfig                   `json:"crio,omitempty"`
 	Docker                         *DockerConfig                 `json:"docker,omitempty"`
 	KubeDNS                        *KubeDNSConfig                `json:"kubeDNS,omitempty"`
 	KubeAPIServer                  *KubeAPIServerConfig          `json:"kubeAPIServer,omitempty"`
@@ -165,6 +174,8 @@ type ClusterSpec struct {
 	NodeTerminationHandler *NodeTerminationHandlerConfig `json:"nodeTerminationHandler,omitempty"`
 	// NodeProblemDetector determines the node problem detector configuration.
 	NodeProblemDetector *NodeProblemDetectorConfig `json:"nodeProblemDetector,omitempty"`
+	// Monitoring configures the node-level metrics agents deployed as managed addons.
+	Monitoring *MonitoringSpec `json:"monitoring,omitempty"`
 	// MetricsServer determines the metrics server configuration.
 	MetricsServer *MetricsServerConfig `json:"metricsServer,omitempty"`
 	// CertManager determines the metrics server configuration.
@@ -181,8 +192,12 @@ type ClusterSpec struct {
 	// Authorization field controls how the cluster is configured for authorization
 	Authorization     *AuthorizationSpec          `json:"authorization,omitempty"`
 	NodeAuthorization *kops.NodeAuthorizationSpec `json:"-"`
+	// NodeCertificates configures the lifetime and renewal of the kubelet certificates that kops-controller issues to nodes.
+	NodeCertificates *NodeCertificatesSpec `json:"nodeCertificates,omitempty"`
 	// CloudLabels defines additional tags or labels on cloud provider resources
 	CloudLabels map[string]string `json:"cloudLabels,omitempty"`
+	// NodeLabelsFromCloudTags configures kops-controller to copy cloud tags of the instances onto their Node objects as labels.
+	NodeLabelsFromCloudTags *NodeLabelsFromCloudTagsSpec `json:"nodeLabelsFromCloudTags,omitempty"`
 	// Hooks for custom actions e.g. on first installation
 	Hooks []HookSpec `json:"hooks,omitempty"`
 	// Alternative locations for files and containers
@@ -204,10 +219,14 @@ type ClusterSpec struct {
 	SysctlParameters []string `json:"sysctlParameters,omitempty"`
 	// RollingUpdate defines the default rolling-update settings for instance groups
 	RollingUpdate *RollingUpdate `json:"rollingUpdate,omitempty"`
+	// ValidationChecks are additional checks of cluster validation, used by kops validate cluster and rolling updates.
+	ValidationChecks []ValidationCheck `json:"validationChecks,omitempty"`
 	// ClusterAutoscaler defines the cluaster autoscaler configuration.
 	ClusterAutoscaler *ClusterAutoscalerConfig `json:"clusterAutoscaler,omitempty"`
 	// WarmPool defines the default warm pool settings for instance groups (AWS only).
 	WarmPool *WarmPoolSpec `json:"warmPool,omitempty"`
+	// InstanceMetadata defines the default EC2 instance metadata service options for instance groups (AWS only).
+	InstanceMetadata *InstanceMetadataOptions `json:"instanceMetadata,omitempty"`
 	// ServiceAccountIssuerDiscovery configures the OIDC Issuer for ServiceAccounts.
 	ServiceAccountIssuerDiscovery *ServiceAccountIssuerDiscoveryConfig `json:"serviceAccountIssuerDiscovery,omitempty"`
 	// SnapshotController defines the CSI Snapshot Controller configuration.
@@ -216,6 +235,25 @@ type ClusterSpec struct {
 	Karpenter *KarpenterConfig `json:"karpenter,omitempty"`
 	// PodIdentityWebhook determines the EKS Pod Identity Webhook configuration.
 	PodIdentityWebhook *PodIdentityWebhookConfig `json:"podIdentityWebhook,omitempty"`
+	// CertificateIssuer configures a cloud certificate authority service to issue the certificates of the cluster CAs.
+	CertificateIssuer *CertificateIssuerSpec `json:"certificateIssuer,omitempty"`
+}
+
+// NodeCertificatesSpec configures the certificates that kops-controller issues to the kubelets of nodes.
+type NodeCertificatesSpec struct {
+	// Validity is how long the kubelet client and serving certificates of nodes are valid.
+	// Nodes renew them periodically, before they expire.
+	Validity *metav1.Duration `json:"validity,omitempty"`
+	// RenewBefore is how long before the certificates expire that nodes renew them. Defaults to a third of the validity.
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+}
+
+// NodeLabelsFromCloudTagsSpec configures the cloud tags that are copied onto Node objects as labels.
+type NodeLabelsFromCloudTagsSpec struct {
+	// Tags are the keys of the cloud tags to copy. Tags that are not set on an instance are ignored.
+	Tags []string `json:"tags,omitempty"`
+	// Prefix is prepended to the tag keys to build the label keys. Defaults to "tag.kops.k8s.io/".
+	Prefix string `json:"prefix,omitempty"`
 }
 
 // PodIdentityWebhookConfig configures an EKS Pod Identity Webhook.
@@ -223,6 +261,27 @@ type PodIdentityWebhookConfig struct {
 	Enabled bool `json:"enabled,omitempty"`
 }
 
+// CertificateIssuerSpec configures a cloud certificate authority service that issues the certificates
+// of the cluster CAs, instead of kOps self-signing them. Exactly one service must be set.
+type CertificateIssuerSpec struct {
+	// AWSPrivateCA configures AWS Certificate Manager Private Certificate Authority.
+	AWSPrivateCA *AWSPrivateCAIssuerSpec `json:"awsPrivateCA,omitempty"`
+	// GCPCAS configures Google Cloud Certificate Authority Service.
+	GCPCAS *GCPCASIssuerSpec `json:"gcpCAS,omitempty"`
+}
+
+// AWSPrivateCAIssuerSpec configures the issuance of certificates by ACM Private CA.
+type AWSPrivateCAIssuerSpec struct {
+	// ARN is the ARN of the private certificate authority.
+	ARN string `json:"arn,omitempty"`
+}
+
+// GCPCASIssuerSpec configures the issuance of certificates by Certificate Authority Service.
+type GCPCASIssuerSpec struct {
+	// CAPool is the resource name of the CA pool, in the form projects/<project>/locations/<location>/caPools/<pool>.
+	CAPool string `json:"caPool,omitempty"`
+}
+
 // CloudProviderSpec configures the cloud provider to use.
 type CloudProviderSpec struct {
 	// AWS configures the AWS cloud provider.
@@ -267,6 +326,9 @@ type ServiceAccountIssuerDiscoveryConfig struct {
 	EnableAWSOIDCProvider bool `json:"enableAWSOIDCProvider,omitempty"`
 	// AdditionalAudiences adds user defined audiences to the provisioned AWS OIDC provider
 	AdditionalAudiences []string `json:"additionalAudiences,omitempty"`
+	// GCPWorkloadIdentityProvider is the resource name of an existing GCP workload identity pool provider that trusts
+	// the ServiceAccount Issuer, in the form projects/<number>/locations/global/workloadIdentityPools/<pool>/providers/<provider>.
+	GCPWorkloadIdentityProvider string `json:"gcpWorkloadIdentityProvider,omitempty"`
 }
 
 // ServiceAccountExternalPermissions grants a ServiceAccount permissions to external resources.
@@ -277,6 +339,8 @@ type ServiceAccountExternalPermission struct {
 	Namespace string `json:"namespace"`
 	// AWS grants permissions to AWS resources.
 	AWS *AWSPermission `json:"aws,omitempty"`
+	// GCP grants permissions to GCP resources.
+	GCP *GCPPermission `json:"gcp,omitempty"`
 }
 
 // AWSPermission grants permissions to AWS resources.
@@ -287,6 +351,12 @@ type AWSPermission struct {
 	InlinePolicy string `json:"inlinePolicy,omitempty"`
 }
 
+// GCPPermission grants permissions to GCP resources.
+type GCPPermission struct {
+	// Roles is a list of IAM roles granted on the project to the GCP service account of the ServiceAccount.
+	Roles []string `json:"roles,omitempty"`
+}
+
 // AddonSpec defines an addon that we want to install in the cluster
 type AddonSpec struct {
 	// Manifest is a path to the manifest that defines the addon
@@ -317,6 +387,33 @@ type Assets struct {
 	FileRepository *string `json:"fileRepository,omitempty"`
 	// ContainerProxy is a url for a pull-through proxy of a docker registry
 	ContainerProxy *string `json:"containerProxy,omitempty"`
+	// TrustPolicy sets how the images and files used by the cluster are verified.
+	TrustPolicy *AssetTrustPolicy `json:"trustPolicy,omitempty"`
+}
+
+// AssetTrustPolicy sets how the images and files used by the cluster are verified.
+// kOps fails to update the cluster when an asset covered by the policy can't be verified.
+type AssetTrustPolicy struct {
+	// Images requires cosign signatures on the images matching a name prefix.
+	Images []ImageTrustPolicy `json:"images,omitempty"`
+	// Files pins the checksums of files.
+	Files []FileTrustPolicy `json:"files,omitempty"`
+}
+
+// ImageTrustPolicy requires cosign signatures on the images matching a name prefix.
+type ImageTrustPolicy struct {
+	// Prefix is the prefix of the canonical names of the images the policy applies to, such as "registry.k8s.io/".
+	Prefix string `json:"prefix"`
+	// PublicKeys are PEM-encoded cosign public keys. An image must have a signature made with one of them.
+	PublicKeys []string `json:"publicKeys"`
+}
+
+// FileTrustPolicy pins the checksum of a file.
+type FileTrustPolicy struct {
+	// URL is the canonical URL of the file.
+	URL string `json:"url"`
+	// Hash is the SHA256 hash of the file.
+	Hash string `json:"hash"`
 }
 
 // IAMSpec adds control over the IAM security policies applied to resources
@@ -324,11 +421,17 @@ type IAMSpec struct {
 	Legacy                 bool    `json:"-"`
 	AllowContainerRegistry bool    `json:"allowContainerRegistry,omitempty"`
 	PermissionsBoundary    *string `json:"permissionsBoundary,omitempty"`
+	// Path is the path of the IAM roles created by kOps, for example "/kops/". It must begin and end with a slash.
+	// The path of an existing role cannot be changed.
+	Path string `json:"path,omitempty"`
 	// UseServiceAccountExternalPermissions determines if managed ServiceAccounts will use external permissions directly.
 	// If this is set to false, ServiceAccounts will assume external permissions from the instances they run on.
 	UseServiceAccountExternalPermissions *bool `json:"useServiceAccountExternalPermissions,omitempty"`
 	// ServiceAccountExternalPermissions defines the relationship between Kubernetes ServiceAccounts and permissions with external resources.
 	ServiceAccountExternalPermissions []ServiceAccountExternalPermission `json:"serviceAccountExternalPermissions,omitempty"`
+	// ScopeResourceARNs restricts the resources of statements that are conditioned on cluster tags
+	// to ARNs in the cluster's region and account, rather than granting them on all resources.
+	ScopeResourceARNs bool `json:"scopeResourceARNs,omitempty"`
 }
 
 // HookSpec is a definition hook
@@ -493,6 +596,22 @@ type LoadBalancerAccessSpec struct {
 	Subnets []LoadBalancerSubnetSpec `json:"subnets,omitempty"`
 	// AccessLog is the configuration of access logs
 	AccessLog *AccessLogSpec `json:"accessLog,omitempty"`
+	// ConnectionDrainingTimeoutSeconds is how long a Classic load balancer keeps connections to deregistered instances open. Defaults to 300.
+	ConnectionDrainingTimeoutSeconds *int64 `json:"connectionDrainingTimeoutSeconds,omitempty"`
+	// DeregistrationDelaySeconds is how long a Network load balancer waits before deregistering draining targets.
+	DeregistrationDelaySeconds *int64 `json:"deregistrationDelaySeconds,omitempty"`
+	// HealthCheck overrides the health check of the load balancer's targets.
+	HealthCheck *LoadBalancerHealthCheckSpec `json:"healthCheck,omitempty"`
+}
+
+// LoadBalancerHealthCheckSpec overrides the health check of the API load balancer.
+type LoadBalancerHealthCheckSpec struct {
+	// Protocol is the protocol of the health check: TCP, HTTP or HTTPS, or SSL for a Classic load balancer.
+	Protocol *string `json:"protocol,omitempty"`
+	// Port is the port the health check connects to.
+	Port *int64 `json:"port,omitempty"`
+	// Path is the path requested by HTTP and HTTPS health checks.
+	Path *string `json:"path,omitempty"`
 }
 
 // KubeDNSConfig defines the kube dns configuration
@@ -577,9 +696,21 @@ type EtcdClusterSpec struct {
 	// Members stores the configurations for each member of the cluster (including the data volume)
 	Members []EtcdMemberSpec `json:"etcdMembers,omitempty"`
 	// Version is the version of etcd to run.
-	Version               string           `json:"version,omitempty"`
-	LeaderElectionTimeout *metav1.Duration `json:"-"`
-	HeartbeatInterval     *metav1.Duration `json:"-"`
+	Version string `json:"version,omitempty"`
+	// LeaderElectionTimeout is the time (in milliseconds) for an etcd leader election timeout
+	LeaderElectionTimeout *metav1.Duration `json:"leaderElectionTimeout,omitempty"`
+	// HeartbeatInterval is the time (in milliseconds) for an etcd heartbeat interval
+	HeartbeatInterval *metav1.Duration `json:"heartbeatInterval,omitempty"`
+	// QuotaBackendBytes is the maximum size of the etcd database. etcd stops accepting writes once it is exceeded.
+	// Defaults to the etcd default of 2Gi.
+	QuotaBackendBytes *resource.Quantity `json:"quotaBackendBytes,omitempty"`
+	// AutoCompactionMode is the mode of the automatic compaction of the key history: periodic or revision.
+	AutoCompactionMode string `json:"autoCompactionMode,omitempty"`
+	// AutoCompactionRetention is how much of the key history automatic compaction retains:
+	// a duration such as 1h in periodic mode, or a number of revisions in revision mode.
+	AutoCompactionRetention string `json:"autoCompactionRetention,omitempty"`
+	// SnapshotCount is the number of committed transactions that trigger a snapshot to disk.
+	SnapshotCount *int64 `json:"snapshotCount,omitempty"`
 	// Image is the etcd docker image to use. Setting this will ignore the Version specified.
 	Image string `json:"image,omitempty"`
 	// Backups describes how we do backups of etcd
@@ -598,6 +729,22 @@ type EtcdBackupSpec struct {
 	BackupStore string `json:"backupStore,omitempty"`
 	// Image is the etcd backup manager image to use.  Setting this will create a sidecar container in the etcd pod with the specified image.
 	Image string `json:"image,omitempty"`
+	// CrossAccountStore is a backup store outside of the state store, such as a bucket in another account.
+	// When set, etcd-manager reads and writes backups there instead of in backupStore, so that they survive
+	// the compromise or deletion of the state store.
+	CrossAccountStore *EtcdCrossAccountStoreSpec `json:"crossAccountStore,omitempty"`
+}
+
+// EtcdCrossAccountStoreSpec describes a backup store outside of the state store
+type EtcdCrossAccountStoreSpec struct {
+	// BackupStore is the VFS path where etcd-manager will read/write backup data
+	BackupStore string `json:"backupStore,omitempty"`
+	// RoleARN is the ARN of an IAM role that etcd-manager assumes to access the backup store.
+	// If not set, the control plane instances access the backup store with their own credentials.
+	// Only supported on AWS.
+	RoleARN string `json:"roleARN,omitempty"`
+	// ExternalID is the external ID to pass when assuming RoleARN.
+	ExternalID string `json:"externalID,omitempty"`
 }
 
 // EtcdManagerSpec describes how we configure the etcd manager
@@ -708,6 +855,9 @@ type TerraformSpec struct {
 	ProviderExtraConfig *map[string]string `json:"providerExtraConfig,omitempty"`
 	// FilesProviderExtraConfig contains key/value pairs to add to the terraform provider block used for managed files
 	FilesProviderExtraConfig *map[string]string `json:"filesProviderExtraConfig,omitempty"`
+	// SharedResourcesAsDataSources references shared resources, such as a shared VPC, subnets and security groups,
+	// through terraform data sources instead of through their IDs.
+	SharedResourcesAsDataSources *bool `json:"sharedResourcesAsDataSources,omitempty"`
 }
 
 func (t *TerraformSpec) IsEmpty() bool {
@@ -736,6 +886,18 @@ type GossipConfig struct {
 	Listen    *string                `json:"listen,omitempty"`
 	Secret    *string                `json:"secret,omitempty"`
 	Secondary *GossipConfigSecondary `json:"secondary,omitempty"`
+	// Encryption enables the encryption of memberlist gossip with the keys in the gossipencryption secret.
+	// The first key in the secret is used to encrypt messages; all of the keys are used to decrypt them.
+	Encryption *bool `json:"encryption,omitempty"`
+	// ProbeInterval is the interval between memberlist failure detection probes.
+	// Default: 1s. Larger clusters benefit from a longer interval.
+	ProbeInterval *metav1.Duration `json:"probeInterval,omitempty"`
+	// GossipInterval is the interval between memberlist gossip messages.
+	// Default: 200ms
+	GossipInterval *metav1.Duration `json:"gossipInterval,omitempty"`
+	// PushPullInterval is the interval between memberlist full state syncs with a random member.
+	// Default: 1m
+	PushPullInterval *metav1.Duration `json:"pushPullInterval,omitempty"`
 }
 
 type GossipConfigSecondary struct {
@@ -750,6 +912,15 @@ type DNSControllerGossipConfig struct {
 	Secret    *string                             `json:"secret,omitempty"`
 	Secondary *DNSControllerGossipConfigSecondary `json:"secondary,omitempty"`
 	Seed      *string                             `json:"seed,omitempty"`
+	// ProbeInterval is the interval between memberlist failure detection probes.
+	// Default: 1s. Larger clusters benefit from a longer interval.
+	ProbeInterval *metav1.Duration `json:"probeInterval,omitempty"`
+	// GossipInterval is the interval between memberlist gossip messages.
+	// Default: 200ms
+	GossipInterval *metav1.Duration `json:"gossipInterval,omitempty"`
+	// PushPullInterval is the interval between memberlist full state syncs with a random member.
+	// Default: 1m
+	PushPullInterval *metav1.Duration `json:"pushPullInterval,omitempty"`
 }
 
 type DNSControllerGossipConfigSecondary struct {
@@ -759,6 +930,37 @@ type DNSControllerGossipConfigSecondary struct {
 	Seed     *string `json:"seed,omitempty"`
 }
 
+// DNSRecordsSpec configures the DNS records that kOps manages for the cluster.
+type DNSRecordsSpec struct {
+	// API configures the record for the public name of the API server.
+	API *DNSRecordSpec `json:"api,omitempty"`
+	// APIInternal configures the record for the internal name of the API server.
+	APIInternal *DNSRecordSpec `json:"apiInternal,omitempty"`
+	// Bastion configures the record for the public name of the bastion.
+	Bastion *DNSRecordSpec `json:"bastion,omitempty"`
+	// KopsController configures the record for the internal name of kops-controller.
+	KopsController *DNSRecordSpec `json:"kopsController,omitempty"`
+}
+
+// DNSRecordSpec configures a DNS record.
+type DNSRecordSpec struct {
+	// Type is the type of record for names that point to a load balancer: Alias (the default) or CNAME.
+	// Names that point to the control plane nodes always use A and AAAA records.
+	Type DNSRecordType `json:"type,omitempty"`
+	// TTL is the time to live of the record, in seconds. Alias records do not have a TTL.
+	TTL *int32 `json:"ttl,omitempty"`
+}
+
+// DNSRecordType is the type of a DNS record pointing to a load balancer.
+type DNSRecordType string
+
+const (
+	// DNSRecordTypeAlias points the name to the load balancer with A and AAAA alias records.
+	DNSRecordTypeAlias DNSRecordType = "Alias"
+	// DNSRecordTypeCNAME points the name to the load balancer with a CNAME record.
+	DNSRecordTypeCNAME DNSRecordType = "CNAME"
+)
+
 type RollingUpdate struct {
 	// DrainAndTerminate enables draining and terminating nodes during rolling updates.
 	// Defaults to true.
@@ -788,6 +990,47 @@ type RollingUpdate struct {
 	// nodes.
 	// +optional
 	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+	// DrainTimeout is the maximum time to wait while draining a node.
+	// Defaults to the --drain-timeout flag of kops rolling-update cluster.
+	// +optional
+	DrainTimeout *metav1.Duration `json:"drainTimeout,omitempty"`
+	// PDBViolationPolicy is what to do with the pods whose eviction PodDisruptionBudgets still block when the drain times out.
+	// "Fail" fails the drain, as the --fail-on-drain-error flag of kops rolling-update cluster allows.
+	// "Delete" deletes the pods, bypassing their PodDisruptionBudgets, and logs which pods were deleted.
+	// Defaults to "Fail".
+	// +optional
+	PDBViolationPolicy PDBViolationPolicy `json:"pdbViolationPolicy,omitempty"`
+}
+
+// PDBViolationPolicy is what a rolling update does with pods that PodDisruptionBudgets prevent from being evicted.
+type PDBViolationPolicy string
+
+const (
+	// PDBViolationPolicyFail fails the drain of the node
+	PDBViolationPolicyFail PDBViolationPolicy = "Fail"
+	// PDBViolationPolicyDelete deletes the pods, bypassing their PodDisruptionBudgets
+	PDBViolationPolicyDelete PDBViolationPolicy = "Delete"
+)
+
+// ValidationCheck is an additional check that cluster validation performs.
+// Exactly one of DaemonSet, Deployment and MinNodesPerZone must be set.
+type ValidationCheck struct {
+	// Name identifies the check in the validation failures.
+	Name string `json:"name"`
+	// DaemonSet requires the desired pods of a DaemonSet to be ready on all nodes.
+	DaemonSet *ValidationCheckObject `json:"daemonSet,omitempty"`
+	// Deployment requires the desired replicas of a Deployment, such as an addon, to be available.
+	Deployment *ValidationCheckObject `json:"deployment,omitempty"`
+	// MinNodesPerZone is the minimum number of ready nodes of role Node in each zone of the cluster.
+	MinNodesPerZone *int32 `json:"minNodesPerZone,omitempty"`
+}
+
+// ValidationCheckObject is the Kubernetes object that a ValidationCheck checks.
+type ValidationCheckObject struct {
+	// Namespace is the namespace of the object. Defaults to kube-system.
+	Namespace string `json:"namespace,omitempty"`
+	// Name is the name of the object.
+	Name string `json:"name"`
 }
 
 type PackagesConfig struct {
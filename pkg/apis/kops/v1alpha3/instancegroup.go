@@ -109,9 +109,15 @@ type InstanceGroupSpec struct {
 	NodeLabels map[string]string `json:"nodeLabels,omitempty"`
 	// FileAssets is a collection of file assets for this instance group
 	FileAssets []FileAssetSpec `json:"fileAssets,omitempty"`
-	// Describes the tenancy of this instance group. Can be either default or dedicated.
+	// Describes the tenancy of this instance group. Can be default, dedicated or host.
 	// Currently only applies to AWS.
 	Tenancy string `json:"tenancy,omitempty"`
+	// HostResourceGroupARN is the ARN of the host resource group in which the instances are launched.
+	// Requires the host tenancy. Currently only applies to AWS.
+	HostResourceGroupARN *string `json:"hostResourceGroupARN,omitempty"`
+	// HostAffinity is the affinity of the instances to a Dedicated Host: "host" restarts a stopped instance on the same host,
+	// "default" on any available host. Requires the host tenancy. Currently only applies to AWS.
+	HostAffinity *string `json:"hostAffinity,omitempty"`
 	// Kubelet overrides kubelet config from the ClusterSpec
 	Kubelet *KubeletConfigSpec `json:"kubelet,omitempty"`
 	// Taints indicates the kubernetes taints for nodes in this instance group
@@ -120,6 +126,12 @@ type InstanceGroupSpec struct {
 	MixedInstancesPolicy *MixedInstancesPolicySpec `json:"mixedInstancesPolicy,omitempty"`
 	// AdditionalUserData is any additional user-data to be passed to the host
 	AdditionalUserData []UserData `json:"additionalUserData,omitempty"`
+	// CloudInit configures the instances with cloud-init multipart user-data, merging the cloud-config of the instance group
+	// with the one built by kOps.
+	CloudInit *CloudInitSpec `json:"cloudInit,omitempty"`
+	// Ignition renders the user-data as an Ignition config, rather than a cloud-init script, for Flatcar Container Linux.
+	// Defaults to true for images whose name contains "flatcar".
+	Ignition *bool `json:"ignition,omitempty"`
 	// SuspendProcesses disables the listed Scaling Policies
 	SuspendProcesses []string `json:"suspendProcesses,omitempty"`
 	// ExternalLoadBalancers define loadbalancers that should be attached to this instance group
@@ -159,6 +171,22 @@ type InstanceGroupSpec struct {
 	Packages []string `json:"packages,omitempty"`
 	// GuestAccelerators configures additional accelerators
 	GuestAccelerators []AcceleratorConfig `json:"guestAccelerators,omitempty"`
+	// Monitoring overrides which of the cluster's monitoring agents run on this instance group.
+	Monitoring *InstanceGroupMonitoringSpec `json:"monitoring,omitempty"`
+	// EFA attaches Elastic Fabric Adapter network interfaces to the instances (AWS only).
+	EFA *EFASpec `json:"efa,omitempty"`
+	// NitroEnclave enables AWS Nitro Enclaves on the instances (AWS only).
+	// The machine type must support Nitro Enclaves.
+	NitroEnclave *bool `json:"nitroEnclave,omitempty"`
+	// ElasticInferenceAccelerators attaches Elastic Inference accelerators to the instances (AWS only).
+	ElasticInferenceAccelerators []AcceleratorConfig `json:"elasticInferenceAccelerators,omitempty"`
+	// AssetCache configures a cache of the files downloaded by nodeup, shared by the instances of the group.
+	AssetCache *AssetCacheSpec `json:"assetCache,omitempty"`
+	// PreloadImages are container images that nodeup pulls at boot, before the kubelet is started.
+	// This reduces the time until pods of critical DaemonSets or common base images can start on new instances.
+	PreloadImages []string `json:"preloadImages,omitempty"`
+	// NodeUp overrides the nodeup binaries used by the instance group, e.g. to test a new nodeup build on one group.
+	NodeUp *PackagesConfig `json:"nodeup,omitempty"`
 }
 
 // InstanceMetadataOptions defines the EC2 instance metadata service options (AWS Only)
@@ -194,6 +222,22 @@ type MixedInstancesPolicySpec struct {
 	// SpotInstancePools is the number of Spot pools to use to allocate your Spot capacity (defaults to 2)
 	// pools are determined from the different instance types in the Overrides array of LaunchTemplate
 	SpotInstancePools *int64 `json:"spotInstancePools,omitempty"`
+	// SpotFallback configures kops-controller to temporarily switch the instance group to On-Demand
+	// instances when Spot capacity is unavailable.
+	SpotFallback *SpotFallbackSpec `json:"spotFallback,omitempty"`
+}
+
+// SpotFallbackSpec configures the automated fallback from Spot to On-Demand capacity.
+type SpotFallbackSpec struct {
+	// Enabled turns on the fallback to On-Demand instances.
+	Enabled *bool `json:"enabled,omitempty"`
+	// FailureThreshold is the number of failed Spot launches within FailureWindow
+	// that triggers the fallback. (default 3)
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+	// FailureWindow is the period in which failed Spot launches are counted. (default 15m)
+	FailureWindow *metav1.Duration `json:"failureWindow,omitempty"`
+	// RevertAfter is how long the instance group runs On-Demand before Spot is tried again. (default 1h)
+	RevertAfter *metav1.Duration `json:"revertAfter,omitempty"`
 }
 
 // InstanceRequirementsSpec is a list of requirements for any instance type we are willing to run in the EC2 fleet.
@@ -217,6 +261,13 @@ type UserData struct {
 	Content string `json:"content,omitempty"`
 }
 
+// CloudInitSpec configures the cloud-init user-data of an instance group.
+type CloudInitSpec struct {
+	// CloudConfig is a cloud-config document that is merged into the cloud-config part of the user-data.
+	// Lists are appended to the ones set by kOps and maps are merged recursively.
+	CloudConfig string `json:"cloudConfig,omitempty"`
+}
+
 // VolumeSpec defined the spec for an additional volume attached to the instance group
 type VolumeSpec struct {
 	// DeleteOnTermination configures volume retention policy upon instance termination.
@@ -252,11 +303,12 @@ type VolumeMountSpec struct {
 	Path string `json:"path,omitempty"`
 }
 
-// IAMProfileSpec is the AWS IAM Profile to attach to instances in this instance
-// group. Specify the ARN for the IAM instance profile (AWS only).
+// IAMProfileSpec is the existing cloud identity to attach to instances in this instance
+// group, instead of the one kOps would otherwise create.
 type IAMProfileSpec struct {
 	// Profile of the cloud group IAM profile. In aws this is the arn
-	// for the iam instance profile
+	// for the iam instance profile, in gce the email of the service account
+	// and in azure the resource id of the user-assigned managed identity
 	Profile *string `json:"profile,omitempty"`
 }
 
@@ -273,3 +325,35 @@ type AcceleratorConfig struct {
 	AcceleratorCount int64  `json:"acceleratorCount,omitempty"`
 	AcceleratorType  string `json:"acceleratorType,omitempty"`
 }
+
+// InstanceGroupMonitoringSpec controls which monitoring agents run on the instances of an instance group.
+type InstanceGroupMonitoringSpec struct {
+	// NodeExporter runs node-exporter on this instance group.
+	// Defaults to the value of spec.monitoring.nodeExporter.enabled in the Cluster.
+	NodeExporter *bool `json:"nodeExporter,omitempty"`
+	// CloudWatchAgent runs the CloudWatch agent on this instance group (AWS only).
+	// Defaults to the value of spec.monitoring.cloudWatchAgent.enabled in the Cluster.
+	CloudWatchAgent *bool `json:"cloudWatchAgent,omitempty"`
+}
+
+// EFASpec configures Elastic Fabric Adapter network interfaces, for HPC and machine learning workloads.
+type EFASpec struct {
+	// Enabled makes the primary network interface an EFA, and attaches an EFA to each additional network card.
+	// The machine type must support EFA.
+	Enabled *bool `json:"enabled,omitempty"`
+	// NetworkCards is the number of network cards that get an EFA.
+	// Defaults to all network cards of the machine type, e.g. 4 for p4d.24xlarge.
+	NetworkCards *int32 `json:"networkCards,omitempty"`
+}
+
+// AssetCacheSpec configures where nodeup looks for files such as the kubelet and containerd before downloading them.
+// Files are looked up by their SHA-256 hash, and are only used when their contents match the hash.
+type AssetCacheSpec struct {
+	// Path is a directory on a volume shared by the instances of the group, e.g. an NFS or EFS mount.
+	// Nodeup copies files from it, and stores the files it downloads in it.
+	// The volume must be mounted before nodeup runs, e.g. by additionalUserData.
+	Path *string `json:"path,omitempty"`
+	// URL is the base URL of a read-only cache, e.g. an S3 transfer acceleration endpoint.
+	// Nodeup tries <URL>/<sha256>/<filename> before the usual locations of a file.
+	URL *string `json:"url,omitempty"`
+}
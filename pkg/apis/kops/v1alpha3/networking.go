@@ -50,6 +50,11 @@ type ExternalNetworkingSpec struct{}
 // CNINetworkingSpec is the specification for networking that is implemented by a user-provided Daemonset, which uses the CNI kubelet networking plugin.
 type CNINetworkingSpec struct {
 	UsesSecondaryIP bool `json:"usesSecondaryIP,omitempty"`
+	// ManifestURL is the location of a manifest that installs the CNI plugin.
+	// If set, kOps installs the manifest as a managed addon instead of requiring it to be applied separately.
+	ManifestURL string `json:"manifestURL,omitempty"`
+	// ManifestSHA256 is the SHA256 hash of the manifest at ManifestURL. Required if ManifestURL is set.
+	ManifestSHA256 string `json:"sha256,omitempty"`
 }
 
 // KopeioNetworkingSpec declares that we want Kopeio networking
@@ -285,6 +290,14 @@ type CiliumNetworkingSpec struct {
 	// EnableL7Proxy enables L7 proxy for L7 policy enforcement.
 	// Default: true
 	EnableL7Proxy *bool `json:"enableL7Proxy,omitempty"`
+	// EnableEnvoyConfig enables the CiliumEnvoyConfig and CiliumClusterwideEnvoyConfig CRDs,
+	// which configure the L7 proxy directly. Requires Cilium 1.12 or later.
+	// Default: false
+	EnableEnvoyConfig bool `json:"enableEnvoyConfig,omitempty"`
+	// EnableGatewayAPI enables Cilium's implementation of the Kubernetes Gateway API.
+	// Requires Cilium 1.13 or later, the L7 proxy and enableNodePort. The Gateway API CRDs must be installed separately.
+	// Default: false
+	EnableGatewayAPI bool `json:"enableGatewayAPI,omitempty"`
 	// EnableBPFMasquerade enables masquerading packets from endpoints leaving the host with BPF instead of iptables.
 	// Default: false
 	EnableBPFMasquerade *bool `json:"enableBPFMasquerade,omitempty"`
@@ -401,6 +414,25 @@ type CiliumNetworkingSpec struct {
 
 	// EnableServiceTopology determine if cilium should use topology aware hints.
 	EnableServiceTopology bool `json:"enableServiceTopology,omitempty"`
+
+	// ENI configures the AWS ENI IPAM mode. Only used when IPAM is "eni".
+	ENI *CiliumENISpec `json:"eni,omitempty"`
+}
+
+// CiliumENISpec configures Cilium's AWS ENI IPAM mode.
+type CiliumENISpec struct {
+	// SubnetIDsFilter restricts the subnets in which ENIs are created to the given subnet IDs.
+	// Default: the subnets of the instance's primary ENI
+	SubnetIDsFilter []string `json:"subnetIDsFilter,omitempty"`
+	// SubnetTagsFilter restricts the subnets in which ENIs are created to those with all the given tags.
+	SubnetTagsFilter map[string]string `json:"subnetTagsFilter,omitempty"`
+	// AWSReleaseExcessIPs releases IP addresses that are no longer needed back to the ENI.
+	// Default: false
+	AWSReleaseExcessIPs bool `json:"awsReleaseExcessIPs,omitempty"`
+	// UpdateEC2AdapterLimitViaAPI looks up the ENI and IP address limits of instance types through the EC2 API,
+	// instead of relying on the limits built into Cilium. Useful for instance types newer than the Cilium release.
+	// Default: false
+	UpdateEC2AdapterLimitViaAPI bool `json:"updateEC2AdapterLimitViaAPI,omitempty"`
 }
 
 // HubbleSpec configures the Hubble service on the Cilium agent.
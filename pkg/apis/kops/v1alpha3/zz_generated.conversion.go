@@ -84,6 +84,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*AWSPrivateCAIssuerSpec)(nil), (*kops.AWSPrivateCAIssuerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_AWSPrivateCAIssuerSpec_To_kops_AWSPrivateCAIssuerSpec(a.(*AWSPrivateCAIssuerSpec), b.(*kops.AWSPrivateCAIssuerSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.AWSPrivateCAIssuerSpec)(nil), (*AWSPrivateCAIssuerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_AWSPrivateCAIssuerSpec_To_v1alpha3_AWSPrivateCAIssuerSpec(a.(*kops.AWSPrivateCAIssuerSpec), b.(*AWSPrivateCAIssuerSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*AWSSpec)(nil), (*kops.AWSSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_AWSSpec_To_kops_AWSSpec(a.(*AWSSpec), b.(*kops.AWSSpec), scope)
 	}); err != nil {
@@ -134,6 +144,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*AdmissionPluginConfigSpec)(nil), (*kops.AdmissionPluginConfigSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_AdmissionPluginConfigSpec_To_kops_AdmissionPluginConfigSpec(a.(*AdmissionPluginConfigSpec), b.(*kops.AdmissionPluginConfigSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.AdmissionPluginConfigSpec)(nil), (*AdmissionPluginConfigSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_AdmissionPluginConfigSpec_To_v1alpha3_AdmissionPluginConfigSpec(a.(*kops.AdmissionPluginConfigSpec), b.(*AdmissionPluginConfigSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*AlwaysAllowAuthorizationSpec)(nil), (*kops.AlwaysAllowAuthorizationSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_AlwaysAllowAuthorizationSpec_To_kops_AlwaysAllowAuthorizationSpec(a.(*AlwaysAllowAuthorizationSpec), b.(*kops.AlwaysAllowAuthorizationSpec), scope)
 	}); err != nil {
@@ -154,6 +174,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*AssetCacheSpec)(nil), (*kops.AssetCacheSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_AssetCacheSpec_To_kops_AssetCacheSpec(a.(*AssetCacheSpec), b.(*kops.AssetCacheSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.AssetCacheSpec)(nil), (*AssetCacheSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_AssetCacheSpec_To_v1alpha3_AssetCacheSpec(a.(*kops.AssetCacheSpec), b.(*AssetCacheSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*AssetTrustPolicy)(nil), (*kops.AssetTrustPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_AssetTrustPolicy_To_kops_AssetTrustPolicy(a.(*AssetTrustPolicy), b.(*kops.AssetTrustPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.AssetTrustPolicy)(nil), (*AssetTrustPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_AssetTrustPolicy_To_v1alpha3_AssetTrustPolicy(a.(*kops.AssetTrustPolicy), b.(*AssetTrustPolicy), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Assets)(nil), (*kops.Assets)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_Assets_To_kops_Assets(a.(*Assets), b.(*kops.Assets), scope)
 	}); err != nil {
@@ -254,6 +294,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CertificateIssuerSpec)(nil), (*kops.CertificateIssuerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CertificateIssuerSpec_To_kops_CertificateIssuerSpec(a.(*CertificateIssuerSpec), b.(*kops.CertificateIssuerSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.CertificateIssuerSpec)(nil), (*CertificateIssuerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_CertificateIssuerSpec_To_v1alpha3_CertificateIssuerSpec(a.(*kops.CertificateIssuerSpec), b.(*CertificateIssuerSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CiliumENISpec)(nil), (*kops.CiliumENISpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CiliumENISpec_To_kops_CiliumENISpec(a.(*CiliumENISpec), b.(*kops.CiliumENISpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.CiliumENISpec)(nil), (*CiliumENISpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_CiliumENISpec_To_v1alpha3_CiliumENISpec(a.(*kops.CiliumENISpec), b.(*CiliumENISpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CiliumNetworkingSpec)(nil), (*kops.CiliumNetworkingSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_CiliumNetworkingSpec_To_kops_CiliumNetworkingSpec(a.(*CiliumNetworkingSpec), b.(*kops.CiliumNetworkingSpec), scope)
 	}); err != nil {
@@ -284,6 +344,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CloudInitSpec)(nil), (*kops.CloudInitSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CloudInitSpec_To_kops_CloudInitSpec(a.(*CloudInitSpec), b.(*kops.CloudInitSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.CloudInitSpec)(nil), (*CloudInitSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_CloudInitSpec_To_v1alpha3_CloudInitSpec(a.(*kops.CloudInitSpec), b.(*CloudInitSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CloudProviderSpec)(nil), (*kops.CloudProviderSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_CloudProviderSpec_To_kops_CloudProviderSpec(a.(*CloudProviderSpec), b.(*kops.CloudProviderSpec), scope)
 	}); err != nil {
@@ -354,6 +424,46 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ContainerdRegistryAuth)(nil), (*kops.ContainerdRegistryAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ContainerdRegistryAuth_To_kops_ContainerdRegistryAuth(a.(*ContainerdRegistryAuth), b.(*kops.ContainerdRegistryAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ContainerdRegistryAuth)(nil), (*ContainerdRegistryAuth)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ContainerdRegistryAuth_To_v1alpha3_ContainerdRegistryAuth(a.(*kops.ContainerdRegistryAuth), b.(*ContainerdRegistryAuth), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ContainerdRegistryConfig)(nil), (*kops.ContainerdRegistryConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ContainerdRegistryConfig_To_kops_ContainerdRegistryConfig(a.(*ContainerdRegistryConfig), b.(*kops.ContainerdRegistryConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ContainerdRegistryConfig)(nil), (*ContainerdRegistryConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ContainerdRegistryConfig_To_v1alpha3_ContainerdRegistryConfig(a.(*kops.ContainerdRegistryConfig), b.(*ContainerdRegistryConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ContainerdRegistryMirror)(nil), (*kops.ContainerdRegistryMirror)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ContainerdRegistryMirror_To_kops_ContainerdRegistryMirror(a.(*ContainerdRegistryMirror), b.(*kops.ContainerdRegistryMirror), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ContainerdRegistryMirror)(nil), (*ContainerdRegistryMirror)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ContainerdRegistryMirror_To_v1alpha3_ContainerdRegistryMirror(a.(*kops.ContainerdRegistryMirror), b.(*ContainerdRegistryMirror), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CrioConfig)(nil), (*kops.CrioConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CrioConfig_To_kops_CrioConfig(a.(*CrioConfig), b.(*kops.CrioConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.CrioConfig)(nil), (*CrioConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_CrioConfig_To_v1alpha3_CrioConfig(a.(*kops.CrioConfig), b.(*CrioConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*DNSAccessSpec)(nil), (*kops.DNSAccessSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_DNSAccessSpec_To_kops_DNSAccessSpec(a.(*DNSAccessSpec), b.(*kops.DNSAccessSpec), scope)
 	}); err != nil {
@@ -384,6 +494,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*DNSRecordSpec)(nil), (*kops.DNSRecordSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_DNSRecordSpec_To_kops_DNSRecordSpec(a.(*DNSRecordSpec), b.(*kops.DNSRecordSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.DNSRecordSpec)(nil), (*DNSRecordSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_DNSRecordSpec_To_v1alpha3_DNSRecordSpec(a.(*kops.DNSRecordSpec), b.(*DNSRecordSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DNSRecordsSpec)(nil), (*kops.DNSRecordsSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_DNSRecordsSpec_To_kops_DNSRecordsSpec(a.(*DNSRecordsSpec), b.(*kops.DNSRecordsSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.DNSRecordsSpec)(nil), (*DNSRecordsSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_DNSRecordsSpec_To_v1alpha3_DNSRecordsSpec(a.(*kops.DNSRecordsSpec), b.(*DNSRecordsSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*DNSSpec)(nil), (*kops.DNSSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_DNSSpec_To_kops_DNSSpec(a.(*DNSSpec), b.(*kops.DNSSpec), scope)
 	}); err != nil {
@@ -414,6 +544,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*EFASpec)(nil), (*kops.EFASpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_EFASpec_To_kops_EFASpec(a.(*EFASpec), b.(*kops.EFASpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.EFASpec)(nil), (*EFASpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_EFASpec_To_v1alpha3_EFASpec(a.(*kops.EFASpec), b.(*EFASpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*EgressProxySpec)(nil), (*kops.EgressProxySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_EgressProxySpec_To_kops_EgressProxySpec(a.(*EgressProxySpec), b.(*kops.EgressProxySpec), scope)
 	}); err != nil {
@@ -454,6 +594,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*EtcdCrossAccountStoreSpec)(nil), (*kops.EtcdCrossAccountStoreSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_EtcdCrossAccountStoreSpec_To_kops_EtcdCrossAccountStoreSpec(a.(*EtcdCrossAccountStoreSpec), b.(*kops.EtcdCrossAccountStoreSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.EtcdCrossAccountStoreSpec)(nil), (*EtcdCrossAccountStoreSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_EtcdCrossAccountStoreSpec_To_v1alpha3_EtcdCrossAccountStoreSpec(a.(*kops.EtcdCrossAccountStoreSpec), b.(*EtcdCrossAccountStoreSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*EtcdManagerSpec)(nil), (*kops.EtcdManagerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_EtcdManagerSpec_To_kops_EtcdManagerSpec(a.(*EtcdManagerSpec), b.(*kops.EtcdManagerSpec), scope)
 	}); err != nil {
@@ -474,6 +624,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*EventRateLimit)(nil), (*kops.EventRateLimit)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_EventRateLimit_To_kops_EventRateLimit(a.(*EventRateLimit), b.(*kops.EventRateLimit), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.EventRateLimit)(nil), (*EventRateLimit)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_EventRateLimit_To_v1alpha3_EventRateLimit(a.(*kops.EventRateLimit), b.(*EventRateLimit), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*EventRateLimitAdmissionConfig)(nil), (*kops.EventRateLimitAdmissionConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_EventRateLimitAdmissionConfig_To_kops_EventRateLimitAdmissionConfig(a.(*EventRateLimitAdmissionConfig), b.(*kops.EventRateLimitAdmissionConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.EventRateLimitAdmissionConfig)(nil), (*EventRateLimitAdmissionConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_EventRateLimitAdmissionConfig_To_v1alpha3_EventRateLimitAdmissionConfig(a.(*kops.EventRateLimitAdmissionConfig), b.(*EventRateLimitAdmissionConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ExecContainerAction)(nil), (*kops.ExecContainerAction)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_ExecContainerAction_To_kops_ExecContainerAction(a.(*ExecContainerAction), b.(*kops.ExecContainerAction), scope)
 	}); err != nil {
@@ -514,6 +684,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*FileTrustPolicy)(nil), (*kops.FileTrustPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_FileTrustPolicy_To_kops_FileTrustPolicy(a.(*FileTrustPolicy), b.(*kops.FileTrustPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.FileTrustPolicy)(nil), (*FileTrustPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_FileTrustPolicy_To_v1alpha3_FileTrustPolicy(a.(*kops.FileTrustPolicy), b.(*FileTrustPolicy), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*FlannelNetworkingSpec)(nil), (*kops.FlannelNetworkingSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_FlannelNetworkingSpec_To_kops_FlannelNetworkingSpec(a.(*FlannelNetworkingSpec), b.(*kops.FlannelNetworkingSpec), scope)
 	}); err != nil {
@@ -544,6 +724,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*GCPCASIssuerSpec)(nil), (*kops.GCPCASIssuerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_GCPCASIssuerSpec_To_kops_GCPCASIssuerSpec(a.(*GCPCASIssuerSpec), b.(*kops.GCPCASIssuerSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.GCPCASIssuerSpec)(nil), (*GCPCASIssuerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_GCPCASIssuerSpec_To_v1alpha3_GCPCASIssuerSpec(a.(*kops.GCPCASIssuerSpec), b.(*GCPCASIssuerSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*GCPPDCSIDriver)(nil), (*kops.GCPPDCSIDriver)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_GCPPDCSIDriver_To_kops_GCPPDCSIDriver(a.(*GCPPDCSIDriver), b.(*kops.GCPPDCSIDriver), scope)
 	}); err != nil {
@@ -554,6 +744,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*GCPPermission)(nil), (*kops.GCPPermission)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_GCPPermission_To_kops_GCPPermission(a.(*GCPPermission), b.(*kops.GCPPermission), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.GCPPermission)(nil), (*GCPPermission)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_GCPPermission_To_v1alpha3_GCPPermission(a.(*kops.GCPPermission), b.(*GCPPermission), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*GossipConfig)(nil), (*kops.GossipConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_GossipConfig_To_kops_GossipConfig(a.(*GossipConfig), b.(*kops.GossipConfig), scope)
 	}); err != nil {
@@ -634,6 +834,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ImagePolicyWebhookAdmissionConfig)(nil), (*kops.ImagePolicyWebhookAdmissionConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ImagePolicyWebhookAdmissionConfig_To_kops_ImagePolicyWebhookAdmissionConfig(a.(*ImagePolicyWebhookAdmissionConfig), b.(*kops.ImagePolicyWebhookAdmissionConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ImagePolicyWebhookAdmissionConfig)(nil), (*ImagePolicyWebhookAdmissionConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ImagePolicyWebhookAdmissionConfig_To_v1alpha3_ImagePolicyWebhookAdmissionConfig(a.(*kops.ImagePolicyWebhookAdmissionConfig), b.(*ImagePolicyWebhookAdmissionConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ImageTrustPolicy)(nil), (*kops.ImageTrustPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ImageTrustPolicy_To_kops_ImageTrustPolicy(a.(*ImageTrustPolicy), b.(*kops.ImageTrustPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ImageTrustPolicy)(nil), (*ImageTrustPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ImageTrustPolicy_To_v1alpha3_ImageTrustPolicy(a.(*kops.ImageTrustPolicy), b.(*ImageTrustPolicy), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*InstanceGroup)(nil), (*kops.InstanceGroup)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_InstanceGroup_To_kops_InstanceGroup(a.(*InstanceGroup), b.(*kops.InstanceGroup), scope)
 	}); err != nil {
@@ -654,6 +874,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*InstanceGroupMonitoringSpec)(nil), (*kops.InstanceGroupMonitoringSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_InstanceGroupMonitoringSpec_To_kops_InstanceGroupMonitoringSpec(a.(*InstanceGroupMonitoringSpec), b.(*kops.InstanceGroupMonitoringSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.InstanceGroupMonitoringSpec)(nil), (*InstanceGroupMonitoringSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_InstanceGroupMonitoringSpec_To_v1alpha3_InstanceGroupMonitoringSpec(a.(*kops.InstanceGroupMonitoringSpec), b.(*InstanceGroupMonitoringSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*InstanceGroupSpec)(nil), (*kops.InstanceGroupSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_InstanceGroupSpec_To_kops_InstanceGroupSpec(a.(*InstanceGroupSpec), b.(*kops.InstanceGroupSpec), scope)
 	}); err != nil {
@@ -724,6 +954,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*KeysetRotation)(nil), (*kops.KeysetRotation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_KeysetRotation_To_kops_KeysetRotation(a.(*KeysetRotation), b.(*kops.KeysetRotation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.KeysetRotation)(nil), (*KeysetRotation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_KeysetRotation_To_v1alpha3_KeysetRotation(a.(*kops.KeysetRotation), b.(*KeysetRotation), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*KeysetSpec)(nil), (*kops.KeysetSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_KeysetSpec_To_kops_KeysetSpec(a.(*KeysetSpec), b.(*kops.KeysetSpec), scope)
 	}); err != nil {
@@ -764,6 +1004,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*KubeAPIServerHealthcheckSidecarConfig)(nil), (*kops.KubeAPIServerHealthcheckSidecarConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_KubeAPIServerHealthcheckSidecarConfig_To_kops_KubeAPIServerHealthcheckSidecarConfig(a.(*KubeAPIServerHealthcheckSidecarConfig), b.(*kops.KubeAPIServerHealthcheckSidecarConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.KubeAPIServerHealthcheckSidecarConfig)(nil), (*KubeAPIServerHealthcheckSidecarConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_KubeAPIServerHealthcheckSidecarConfig_To_v1alpha3_KubeAPIServerHealthcheckSidecarConfig(a.(*kops.KubeAPIServerHealthcheckSidecarConfig), b.(*KubeAPIServerHealthcheckSidecarConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*KubeControllerManagerConfig)(nil), (*kops.KubeControllerManagerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_KubeControllerManagerConfig_To_kops_KubeControllerManagerConfig(a.(*KubeControllerManagerConfig), b.(*kops.KubeControllerManagerConfig), scope)
 	}); err != nil {
@@ -864,6 +1114,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*LoadBalancerHealthCheckSpec)(nil), (*kops.LoadBalancerHealthCheckSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_LoadBalancerHealthCheckSpec_To_kops_LoadBalancerHealthCheckSpec(a.(*LoadBalancerHealthCheckSpec), b.(*kops.LoadBalancerHealthCheckSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.LoadBalancerHealthCheckSpec)(nil), (*LoadBalancerHealthCheckSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_LoadBalancerHealthCheckSpec_To_v1alpha3_LoadBalancerHealthCheckSpec(a.(*kops.LoadBalancerHealthCheckSpec), b.(*LoadBalancerHealthCheckSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*LoadBalancerSubnetSpec)(nil), (*kops.LoadBalancerSubnetSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_LoadBalancerSubnetSpec_To_kops_LoadBalancerSubnetSpec(a.(*LoadBalancerSubnetSpec), b.(*kops.LoadBalancerSubnetSpec), scope)
 	}); err != nil {
@@ -904,6 +1164,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*MonitoringAgentConfig)(nil), (*kops.MonitoringAgentConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_MonitoringAgentConfig_To_kops_MonitoringAgentConfig(a.(*MonitoringAgentConfig), b.(*kops.MonitoringAgentConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.MonitoringAgentConfig)(nil), (*MonitoringAgentConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_MonitoringAgentConfig_To_v1alpha3_MonitoringAgentConfig(a.(*kops.MonitoringAgentConfig), b.(*MonitoringAgentConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*MonitoringSpec)(nil), (*kops.MonitoringSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_MonitoringSpec_To_kops_MonitoringSpec(a.(*MonitoringSpec), b.(*kops.MonitoringSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.MonitoringSpec)(nil), (*MonitoringSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_MonitoringSpec_To_v1alpha3_MonitoringSpec(a.(*kops.MonitoringSpec), b.(*MonitoringSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*NTPConfig)(nil), (*kops.NTPConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_NTPConfig_To_kops_NTPConfig(a.(*NTPConfig), b.(*kops.NTPConfig), scope)
 	}); err != nil {
@@ -924,6 +1204,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*NodeCertificatesSpec)(nil), (*kops.NodeCertificatesSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_NodeCertificatesSpec_To_kops_NodeCertificatesSpec(a.(*NodeCertificatesSpec), b.(*kops.NodeCertificatesSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.NodeCertificatesSpec)(nil), (*NodeCertificatesSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_NodeCertificatesSpec_To_v1alpha3_NodeCertificatesSpec(a.(*kops.NodeCertificatesSpec), b.(*NodeCertificatesSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NodeLabelsFromCloudTagsSpec)(nil), (*kops.NodeLabelsFromCloudTagsSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_NodeLabelsFromCloudTagsSpec_To_kops_NodeLabelsFromCloudTagsSpec(a.(*NodeLabelsFromCloudTagsSpec), b.(*kops.NodeLabelsFromCloudTagsSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.NodeLabelsFromCloudTagsSpec)(nil), (*NodeLabelsFromCloudTagsSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_NodeLabelsFromCloudTagsSpec_To_v1alpha3_NodeLabelsFromCloudTagsSpec(a.(*kops.NodeLabelsFromCloudTagsSpec), b.(*NodeLabelsFromCloudTagsSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*NodeLocalDNSConfig)(nil), (*kops.NodeLocalDNSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_NodeLocalDNSConfig_To_kops_NodeLocalDNSConfig(a.(*NodeLocalDNSConfig), b.(*kops.NodeLocalDNSConfig), scope)
 	}); err != nil {
@@ -1054,6 +1354,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ProbeSpec)(nil), (*kops.ProbeSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ProbeSpec_To_kops_ProbeSpec(a.(*ProbeSpec), b.(*kops.ProbeSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ProbeSpec)(nil), (*ProbeSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ProbeSpec_To_v1alpha3_ProbeSpec(a.(*kops.ProbeSpec), b.(*ProbeSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*RBACAuthorizationSpec)(nil), (*kops.RBACAuthorizationSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_RBACAuthorizationSpec_To_kops_RBACAuthorizationSpec(a.(*RBACAuthorizationSpec), b.(*kops.RBACAuthorizationSpec), scope)
 	}); err != nil {
@@ -1084,6 +1394,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*Runc)(nil), (*kops.Runc)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_Runc_To_kops_Runc(a.(*Runc), b.(*kops.Runc), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.Runc)(nil), (*Runc)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_Runc_To_v1alpha3_Runc(a.(*kops.Runc), b.(*Runc), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*SSHCredential)(nil), (*kops.SSHCredential)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_SSHCredential_To_kops_SSHCredential(a.(*SSHCredential), b.(*kops.SSHCredential), scope)
 	}); err != nil {
@@ -1144,6 +1464,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*SpotFallbackSpec)(nil), (*kops.SpotFallbackSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_SpotFallbackSpec_To_kops_SpotFallbackSpec(a.(*SpotFallbackSpec), b.(*kops.SpotFallbackSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.SpotFallbackSpec)(nil), (*SpotFallbackSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_SpotFallbackSpec_To_v1alpha3_SpotFallbackSpec(a.(*kops.SpotFallbackSpec), b.(*SpotFallbackSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*TargetSpec)(nil), (*kops.TargetSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_TargetSpec_To_kops_TargetSpec(a.(*TargetSpec), b.(*kops.TargetSpec), scope)
 	}); err != nil {
@@ -1184,6 +1514,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ValidationCheck)(nil), (*kops.ValidationCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ValidationCheck_To_kops_ValidationCheck(a.(*ValidationCheck), b.(*kops.ValidationCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ValidationCheck)(nil), (*ValidationCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ValidationCheck_To_v1alpha3_ValidationCheck(a.(*kops.ValidationCheck), b.(*ValidationCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ValidationCheckObject)(nil), (*kops.ValidationCheckObject)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ValidationCheckObject_To_kops_ValidationCheckObject(a.(*ValidationCheckObject), b.(*kops.ValidationCheckObject), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ValidationCheckObject)(nil), (*ValidationCheckObject)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ValidationCheckObject_To_v1alpha3_ValidationCheckObject(a.(*kops.ValidationCheckObject), b.(*ValidationCheckObject), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*VolumeMountSpec)(nil), (*kops.VolumeMountSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_VolumeMountSpec_To_kops_VolumeMountSpec(a.(*VolumeMountSpec), b.(*kops.VolumeMountSpec), scope)
 	}); err != nil {
@@ -1379,6 +1729,26 @@ func Convert_kops_AWSPermission_To_v1alpha3_AWSPermission(in *kops.AWSPermission
 	return autoConvert_kops_AWSPermission_To_v1alpha3_AWSPermission(in, out, s)
 }
 
+func autoConvert_v1alpha3_AWSPrivateCAIssuerSpec_To_kops_AWSPrivateCAIssuerSpec(in *AWSPrivateCAIssuerSpec, out *kops.AWSPrivateCAIssuerSpec, s conversion.Scope) error {
+	out.ARN = in.ARN
+	return nil
+}
+
+// Convert_v1alpha3_AWSPrivateCAIssuerSpec_To_kops_AWSPrivateCAIssuerSpec is an autogenerated conversion function.
+func Convert_v1alpha3_AWSPrivateCAIssuerSpec_To_kops_AWSPrivateCAIssuerSpec(in *AWSPrivateCAIssuerSpec, out *kops.AWSPrivateCAIssuerSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_AWSPrivateCAIssuerSpec_To_kops_AWSPrivateCAIssuerSpec(in, out, s)
+}
+
+func autoConvert_kops_AWSPrivateCAIssuerSpec_To_v1alpha3_AWSPrivateCAIssuerSpec(in *kops.AWSPrivateCAIssuerSpec, out *AWSPrivateCAIssuerSpec, s conversion.Scope) error {
+	out.ARN = in.ARN
+	return nil
+}
+
+// Convert_kops_AWSPrivateCAIssuerSpec_To_v1alpha3_AWSPrivateCAIssuerSpec is an autogenerated conversion function.
+func Convert_kops_AWSPrivateCAIssuerSpec_To_v1alpha3_AWSPrivateCAIssuerSpec(in *kops.AWSPrivateCAIssuerSpec, out *AWSPrivateCAIssuerSpec, s conversion.Scope) error {
+	return autoConvert_kops_AWSPrivateCAIssuerSpec_To_v1alpha3_AWSPrivateCAIssuerSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_AWSSpec_To_kops_AWSSpec(in *AWSSpec, out *kops.AWSSpec, s conversion.Scope) error {
 	return nil
 }
@@ -1517,6 +1887,60 @@ func Convert_kops_AddonSpec_To_v1alpha3_AddonSpec(in *kops.AddonSpec, out *Addon
 	return autoConvert_kops_AddonSpec_To_v1alpha3_AddonSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_AdmissionPluginConfigSpec_To_kops_AdmissionPluginConfigSpec(in *AdmissionPluginConfigSpec, out *kops.AdmissionPluginConfigSpec, s conversion.Scope) error {
+	if in.EventRateLimit != nil {
+		in, out := &in.EventRateLimit, &out.EventRateLimit
+		*out = new(kops.EventRateLimitAdmissionConfig)
+		if err := Convert_v1alpha3_EventRateLimitAdmissionConfig_To_kops_EventRateLimitAdmissionConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.EventRateLimit = nil
+	}
+	if in.ImagePolicyWebhook != nil {
+		in, out := &in.ImagePolicyWebhook, &out.ImagePolicyWebhook
+		*out = new(kops.ImagePolicyWebhookAdmissionConfig)
+		if err := Convert_v1alpha3_ImagePolicyWebhookAdmissionConfig_To_kops_ImagePolicyWebhookAdmissionConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ImagePolicyWebhook = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha3_AdmissionPluginConfigSpec_To_kops_AdmissionPluginConfigSpec is an autogenerated conversion function.
+func Convert_v1alpha3_AdmissionPluginConfigSpec_To_kops_AdmissionPluginConfigSpec(in *AdmissionPluginConfigSpec, out *kops.AdmissionPluginConfigSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_AdmissionPluginConfigSpec_To_kops_AdmissionPluginConfigSpec(in, out, s)
+}
+
+func autoConvert_kops_AdmissionPluginConfigSpec_To_v1alpha3_AdmissionPluginConfigSpec(in *kops.AdmissionPluginConfigSpec, out *AdmissionPluginConfigSpec, s conversion.Scope) error {
+	if in.EventRateLimit != nil {
+		in, out := &in.EventRateLimit, &out.EventRateLimit
+		*out = new(EventRateLimitAdmissionConfig)
+		if err := Convert_kops_EventRateLimitAdmissionConfig_To_v1alpha3_EventRateLimitAdmissionConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.EventRateLimit = nil
+	}
+	if in.ImagePolicyWebhook != nil {
+		in, out := &in.ImagePolicyWebhook, &out.ImagePolicyWebhook
+		*out = new(ImagePolicyWebhookAdmissionConfig)
+		if err := Convert_kops_ImagePolicyWebhookAdmissionConfig_To_v1alpha3_ImagePolicyWebhookAdmissionConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ImagePolicyWebhook = nil
+	}
+	return nil
+}
+
+// Convert_kops_AdmissionPluginConfigSpec_To_v1alpha3_AdmissionPluginConfigSpec is an autogenerated conversion function.
+func Convert_kops_AdmissionPluginConfigSpec_To_v1alpha3_AdmissionPluginConfigSpec(in *kops.AdmissionPluginConfigSpec, out *AdmissionPluginConfigSpec, s conversion.Scope) error {
+	return autoConvert_kops_AdmissionPluginConfigSpec_To_v1alpha3_AdmissionPluginConfigSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_AlwaysAllowAuthorizationSpec_To_kops_AlwaysAllowAuthorizationSpec(in *AlwaysAllowAuthorizationSpec, out *kops.AlwaysAllowAuthorizationSpec, s conversion.Scope) error {
 	return nil
 }
@@ -1579,10 +2003,103 @@ func Convert_kops_AmazonVPCNetworkingSpec_To_v1alpha3_AmazonVPCNetworkingSpec(in
 	return autoConvert_kops_AmazonVPCNetworkingSpec_To_v1alpha3_AmazonVPCNetworkingSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_AssetCacheSpec_To_kops_AssetCacheSpec(in *AssetCacheSpec, out *kops.AssetCacheSpec, s conversion.Scope) error {
+	out.Path = in.Path
+	out.URL = in.URL
+	return nil
+}
+
+// Convert_v1alpha3_AssetCacheSpec_To_kops_AssetCacheSpec is an autogenerated conversion function.
+func Convert_v1alpha3_AssetCacheSpec_To_kops_AssetCacheSpec(in *AssetCacheSpec, out *kops.AssetCacheSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_AssetCacheSpec_To_kops_AssetCacheSpec(in, out, s)
+}
+
+func autoConvert_kops_AssetCacheSpec_To_v1alpha3_AssetCacheSpec(in *kops.AssetCacheSpec, out *AssetCacheSpec, s conversion.Scope) error {
+	out.Path = in.Path
+	out.URL = in.URL
+	return nil
+}
+
+// Convert_kops_AssetCacheSpec_To_v1alpha3_AssetCacheSpec is an autogenerated conversion function.
+func Convert_kops_AssetCacheSpec_To_v1alpha3_AssetCacheSpec(in *kops.AssetCacheSpec, out *AssetCacheSpec, s conversion.Scope) error {
+	return autoConvert_kops_AssetCacheSpec_To_v1alpha3_AssetCacheSpec(in, out, s)
+}
+
+func autoConvert_v1alpha3_AssetTrustPolicy_To_kops_AssetTrustPolicy(in *AssetTrustPolicy, out *kops.AssetTrustPolicy, s conversion.Scope) error {
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]kops.ImageTrustPolicy, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_ImageTrustPolicy_To_kops_ImageTrustPolicy(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Images = nil
+	}
+	if in.Files != nil {
+		in, out := &in.Files, &out.Files
+		*out = make([]kops.FileTrustPolicy, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_FileTrustPolicy_To_kops_FileTrustPolicy(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Files = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha3_AssetTrustPolicy_To_kops_AssetTrustPolicy is an autogenerated conversion function.
+func Convert_v1alpha3_AssetTrustPolicy_To_kops_AssetTrustPolicy(in *AssetTrustPolicy, out *kops.AssetTrustPolicy, s conversion.Scope) error {
+	return autoConvert_v1alpha3_AssetTrustPolicy_To_kops_AssetTrustPolicy(in, out, s)
+}
+
+func autoConvert_kops_AssetTrustPolicy_To_v1alpha3_AssetTrustPolicy(in *kops.AssetTrustPolicy, out *AssetTrustPolicy, s conversion.Scope) error {
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]ImageTrustPolicy, len(*in))
+		for i := range *in {
+			if err := Convert_kops_ImageTrustPolicy_To_v1alpha3_ImageTrustPolicy(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Images = nil
+	}
+	if in.Files != nil {
+		in, out := &in.Files, &out.Files
+		*out = make([]FileTrustPolicy, len(*in))
+		for i := range *in {
+			if err := Convert_kops_FileTrustPolicy_To_v1alpha3_FileTrustPolicy(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Files = nil
+	}
+	return nil
+}
+
+// Convert_kops_AssetTrustPolicy_To_v1alpha3_AssetTrustPolicy is an autogenerated conversion function.
+func Convert_kops_AssetTrustPolicy_To_v1alpha3_AssetTrustPolicy(in *kops.AssetTrustPolicy, out *AssetTrustPolicy, s conversion.Scope) error {
+	return autoConvert_kops_AssetTrustPolicy_To_v1alpha3_AssetTrustPolicy(in, out, s)
+}
+
 func autoConvert_v1alpha3_Assets_To_kops_Assets(in *Assets, out *kops.Assets, s conversion.Scope) error {
 	out.ContainerRegistry = in.ContainerRegistry
 	out.FileRepository = in.FileRepository
 	out.ContainerProxy = in.ContainerProxy
+	if in.TrustPolicy != nil {
+		in, out := &in.TrustPolicy, &out.TrustPolicy
+		*out = new(kops.AssetTrustPolicy)
+		if err := Convert_v1alpha3_AssetTrustPolicy_To_kops_AssetTrustPolicy(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TrustPolicy = nil
+	}
 	return nil
 }
 
@@ -1595,6 +2112,15 @@ func autoConvert_kops_Assets_To_v1alpha3_Assets(in *kops.Assets, out *Assets, s
 	out.ContainerRegistry = in.ContainerRegistry
 	out.FileRepository = in.FileRepository
 	out.ContainerProxy = in.ContainerProxy
+	if in.TrustPolicy != nil {
+		in, out := &in.TrustPolicy, &out.TrustPolicy
+		*out = new(AssetTrustPolicy)
+		if err := Convert_kops_AssetTrustPolicy_To_v1alpha3_AssetTrustPolicy(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TrustPolicy = nil
+	}
 	return nil
 }
 
@@ -1803,6 +2329,8 @@ func Convert_kops_BastionSpec_To_v1alpha3_BastionSpec(in *kops.BastionSpec, out
 
 func autoConvert_v1alpha3_CNINetworkingSpec_To_kops_CNINetworkingSpec(in *CNINetworkingSpec, out *kops.CNINetworkingSpec, s conversion.Scope) error {
 	out.UsesSecondaryIP = in.UsesSecondaryIP
+	out.ManifestURL = in.ManifestURL
+	out.ManifestSHA256 = in.ManifestSHA256
 	return nil
 }
 
@@ -1813,6 +2341,8 @@ func Convert_v1alpha3_CNINetworkingSpec_To_kops_CNINetworkingSpec(in *CNINetwork
 
 func autoConvert_kops_CNINetworkingSpec_To_v1alpha3_CNINetworkingSpec(in *kops.CNINetworkingSpec, out *CNINetworkingSpec, s conversion.Scope) error {
 	out.UsesSecondaryIP = in.UsesSecondaryIP
+	out.ManifestURL = in.ManifestURL
+	out.ManifestSHA256 = in.ManifestSHA256
 	return nil
 }
 
@@ -1967,6 +2497,86 @@ func Convert_kops_CertManagerConfig_To_v1alpha3_CertManagerConfig(in *kops.CertM
 	return autoConvert_kops_CertManagerConfig_To_v1alpha3_CertManagerConfig(in, out, s)
 }
 
+func autoConvert_v1alpha3_CertificateIssuerSpec_To_kops_CertificateIssuerSpec(in *CertificateIssuerSpec, out *kops.CertificateIssuerSpec, s conversion.Scope) error {
+	if in.AWSPrivateCA != nil {
+		in, out := &in.AWSPrivateCA, &out.AWSPrivateCA
+		*out = new(kops.AWSPrivateCAIssuerSpec)
+		if err := Convert_v1alpha3_AWSPrivateCAIssuerSpec_To_kops_AWSPrivateCAIssuerSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AWSPrivateCA = nil
+	}
+	if in.GCPCAS != nil {
+		in, out := &in.GCPCAS, &out.GCPCAS
+		*out = new(kops.GCPCASIssuerSpec)
+		if err := Convert_v1alpha3_GCPCASIssuerSpec_To_kops_GCPCASIssuerSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.GCPCAS = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha3_CertificateIssuerSpec_To_kops_CertificateIssuerSpec is an autogenerated conversion function.
+func Convert_v1alpha3_CertificateIssuerSpec_To_kops_CertificateIssuerSpec(in *CertificateIssuerSpec, out *kops.CertificateIssuerSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CertificateIssuerSpec_To_kops_CertificateIssuerSpec(in, out, s)
+}
+
+func autoConvert_kops_CertificateIssuerSpec_To_v1alpha3_CertificateIssuerSpec(in *kops.CertificateIssuerSpec, out *CertificateIssuerSpec, s conversion.Scope) error {
+	if in.AWSPrivateCA != nil {
+		in, out := &in.AWSPrivateCA, &out.AWSPrivateCA
+		*out = new(AWSPrivateCAIssuerSpec)
+		if err := Convert_kops_AWSPrivateCAIssuerSpec_To_v1alpha3_AWSPrivateCAIssuerSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AWSPrivateCA = nil
+	}
+	if in.GCPCAS != nil {
+		in, out := &in.GCPCAS, &out.GCPCAS
+		*out = new(GCPCASIssuerSpec)
+		if err := Convert_kops_GCPCASIssuerSpec_To_v1alpha3_GCPCASIssuerSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.GCPCAS = nil
+	}
+	return nil
+}
+
+// Convert_kops_CertificateIssuerSpec_To_v1alpha3_CertificateIssuerSpec is an autogenerated conversion function.
+func Convert_kops_CertificateIssuerSpec_To_v1alpha3_CertificateIssuerSpec(in *kops.CertificateIssuerSpec, out *CertificateIssuerSpec, s conversion.Scope) error {
+	return autoConvert_kops_CertificateIssuerSpec_To_v1alpha3_CertificateIssuerSpec(in, out, s)
+}
+
+func autoConvert_v1alpha3_CiliumENISpec_To_kops_CiliumENISpec(in *CiliumENISpec, out *kops.CiliumENISpec, s conversion.Scope) error {
+	out.SubnetIDsFilter = in.SubnetIDsFilter
+	out.SubnetTagsFilter = in.SubnetTagsFilter
+	out.AWSReleaseExcessIPs = in.AWSReleaseExcessIPs
+	out.UpdateEC2AdapterLimitViaAPI = in.UpdateEC2AdapterLimitViaAPI
+	return nil
+}
+
+// Convert_v1alpha3_CiliumENISpec_To_kops_CiliumENISpec is an autogenerated conversion function.
+func Convert_v1alpha3_CiliumENISpec_To_kops_CiliumENISpec(in *CiliumENISpec, out *kops.CiliumENISpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CiliumENISpec_To_kops_CiliumENISpec(in, out, s)
+}
+
+func autoConvert_kops_CiliumENISpec_To_v1alpha3_CiliumENISpec(in *kops.CiliumENISpec, out *CiliumENISpec, s conversion.Scope) error {
+	out.SubnetIDsFilter = in.SubnetIDsFilter
+	out.SubnetTagsFilter = in.SubnetTagsFilter
+	out.AWSReleaseExcessIPs = in.AWSReleaseExcessIPs
+	out.UpdateEC2AdapterLimitViaAPI = in.UpdateEC2AdapterLimitViaAPI
+	return nil
+}
+
+// Convert_kops_CiliumENISpec_To_v1alpha3_CiliumENISpec is an autogenerated conversion function.
+func Convert_kops_CiliumENISpec_To_v1alpha3_CiliumENISpec(in *kops.CiliumENISpec, out *CiliumENISpec, s conversion.Scope) error {
+	return autoConvert_kops_CiliumENISpec_To_v1alpha3_CiliumENISpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_CiliumNetworkingSpec_To_kops_CiliumNetworkingSpec(in *CiliumNetworkingSpec, out *kops.CiliumNetworkingSpec, s conversion.Scope) error {
 	out.Version = in.Version
 	out.MemoryRequest = in.MemoryRequest
@@ -1978,6 +2588,8 @@ func autoConvert_v1alpha3_CiliumNetworkingSpec_To_kops_CiliumNetworkingSpec(in *
 	out.DisableEndpointCRD = in.DisableEndpointCRD
 	out.EnablePolicy = in.EnablePolicy
 	out.EnableL7Proxy = in.EnableL7Proxy
+	out.EnableEnvoyConfig = in.EnableEnvoyConfig
+	out.EnableGatewayAPI = in.EnableGatewayAPI
 	out.EnableBPFMasquerade = in.EnableBPFMasquerade
 	out.EnableEndpointHealthChecking = in.EnableEndpointHealthChecking
 	out.EnablePrometheusMetrics = in.EnablePrometheusMetrics
@@ -2021,6 +2633,15 @@ func autoConvert_v1alpha3_CiliumNetworkingSpec_To_kops_CiliumNetworkingSpec(in *
 	}
 	out.DisableCNPStatusUpdates = in.DisableCNPStatusUpdates
 	out.EnableServiceTopology = in.EnableServiceTopology
+	if in.ENI != nil {
+		in, out := &in.ENI, &out.ENI
+		*out = new(kops.CiliumENISpec)
+		if err := Convert_v1alpha3_CiliumENISpec_To_kops_CiliumENISpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ENI = nil
+	}
 	return nil
 }
 
@@ -2040,6 +2661,8 @@ func autoConvert_kops_CiliumNetworkingSpec_To_v1alpha3_CiliumNetworkingSpec(in *
 	out.DisableEndpointCRD = in.DisableEndpointCRD
 	out.EnablePolicy = in.EnablePolicy
 	out.EnableL7Proxy = in.EnableL7Proxy
+	out.EnableEnvoyConfig = in.EnableEnvoyConfig
+	out.EnableGatewayAPI = in.EnableGatewayAPI
 	out.EnableBPFMasquerade = in.EnableBPFMasquerade
 	out.EnableEndpointHealthChecking = in.EnableEndpointHealthChecking
 	out.EnablePrometheusMetrics = in.EnablePrometheusMetrics
@@ -2083,6 +2706,15 @@ func autoConvert_kops_CiliumNetworkingSpec_To_v1alpha3_CiliumNetworkingSpec(in *
 	}
 	out.DisableCNPStatusUpdates = in.DisableCNPStatusUpdates
 	out.EnableServiceTopology = in.EnableServiceTopology
+	if in.ENI != nil {
+		in, out := &in.ENI, &out.ENI
+		*out = new(CiliumENISpec)
+		if err := Convert_kops_CiliumENISpec_To_v1alpha3_CiliumENISpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ENI = nil
+	}
 	return nil
 }
 
@@ -2227,6 +2859,26 @@ func Convert_kops_CloudControllerManagerConfig_To_v1alpha3_CloudControllerManage
 	return autoConvert_kops_CloudControllerManagerConfig_To_v1alpha3_CloudControllerManagerConfig(in, out, s)
 }
 
+func autoConvert_v1alpha3_CloudInitSpec_To_kops_CloudInitSpec(in *CloudInitSpec, out *kops.CloudInitSpec, s conversion.Scope) error {
+	out.CloudConfig = in.CloudConfig
+	return nil
+}
+
+// Convert_v1alpha3_CloudInitSpec_To_kops_CloudInitSpec is an autogenerated conversion function.
+func Convert_v1alpha3_CloudInitSpec_To_kops_CloudInitSpec(in *CloudInitSpec, out *kops.CloudInitSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CloudInitSpec_To_kops_CloudInitSpec(in, out, s)
+}
+
+func autoConvert_kops_CloudInitSpec_To_v1alpha3_CloudInitSpec(in *kops.CloudInitSpec, out *CloudInitSpec, s conversion.Scope) error {
+	out.CloudConfig = in.CloudConfig
+	return nil
+}
+
+// Convert_kops_CloudInitSpec_To_v1alpha3_CloudInitSpec is an autogenerated conversion function.
+func Convert_kops_CloudInitSpec_To_v1alpha3_CloudInitSpec(in *kops.CloudInitSpec, out *CloudInitSpec, s conversion.Scope) error {
+	return autoConvert_kops_CloudInitSpec_To_v1alpha3_CloudInitSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_CloudProviderSpec_To_kops_CloudProviderSpec(in *CloudProviderSpec, out *kops.CloudProviderSpec, s conversion.Scope) error {
 	if in.AWS != nil {
 		in, out := &in.AWS, &out.AWS
@@ -2523,6 +3175,7 @@ func autoConvert_v1alpha3_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	}
 	out.SecretStore = in.SecretStore
 	out.KeyStore = in.KeyStore
+	out.KeyStoreKMSKey = in.KeyStoreKMSKey
 	out.ConfigStore = in.ConfigStore
 	out.DNSZone = in.DNSZone
 	if in.DNSControllerGossipConfig != nil {
@@ -2534,6 +3187,15 @@ func autoConvert_v1alpha3_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	} else {
 		out.DNSControllerGossipConfig = nil
 	}
+	if in.DNSRecords != nil {
+		in, out := &in.DNSRecords, &out.DNSRecords
+		*out = new(kops.DNSRecordsSpec)
+		if err := Convert_v1alpha3_DNSRecordsSpec_To_kops_DNSRecordsSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.DNSRecords = nil
+	}
 	out.AdditionalSANs = in.AdditionalSANs
 	out.ClusterDNSDomain = in.ClusterDNSDomain
 	out.ServiceClusterIPRange = in.ServiceClusterIPRange
@@ -2550,6 +3212,7 @@ func autoConvert_v1alpha3_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	} else {
 		out.EgressProxy = nil
 	}
+	out.DisableConnectivityChecks = in.DisableConnectivityChecks
 	out.SSHKeyName = in.SSHKeyName
 	out.KubernetesAPIAccess = in.KubernetesAPIAccess
 	out.IsolateMasters = in.IsolateMasters
@@ -2587,6 +3250,15 @@ func autoConvert_v1alpha3_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	} else {
 		out.Containerd = nil
 	}
+	if in.Crio != nil {
+		in, out := &in.Crio, &out.Crio
+		*out = new(kops.CrioConfig)
+		if err := Convert_v1alpha3_CrioConfig_To_kops_CrioConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Crio = nil
+	}
 	if in.Docker != nil {
 		in, out := &in.Docker, &out.Docker
 		*out = new(kops.DockerConfig)
@@ -2713,6 +3385,15 @@ func autoConvert_v1alpha3_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	} else {
 		out.NodeProblemDetector = nil
 	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(kops.MonitoringSpec)
+		if err := Convert_v1alpha3_MonitoringSpec_To_kops_MonitoringSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Monitoring = nil
+	}
 	if in.MetricsServer != nil {
 		in, out := &in.MetricsServer, &out.MetricsServer
 		*out = new(kops.MetricsServerConfig)
@@ -2777,7 +3458,25 @@ func autoConvert_v1alpha3_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 		out.Authorization = nil
 	}
 	out.NodeAuthorization = in.NodeAuthorization
+	if in.NodeCertificates != nil {
+		in, out := &in.NodeCertificates, &out.NodeCertificates
+		*out = new(kops.NodeCertificatesSpec)
+		if err := Convert_v1alpha3_NodeCertificatesSpec_To_kops_NodeCertificatesSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.NodeCertificates = nil
+	}
 	out.CloudLabels = in.CloudLabels
+	if in.NodeLabelsFromCloudTags != nil {
+		in, out := &in.NodeLabelsFromCloudTags, &out.NodeLabelsFromCloudTags
+		*out = new(kops.NodeLabelsFromCloudTagsSpec)
+		if err := Convert_v1alpha3_NodeLabelsFromCloudTagsSpec_To_kops_NodeLabelsFromCloudTagsSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.NodeLabelsFromCloudTags = nil
+	}
 	if in.Hooks != nil {
 		in, out := &in.Hooks, &out.Hooks
 		*out = make([]kops.HookSpec, len(*in))
@@ -2829,6 +3528,17 @@ func autoConvert_v1alpha3_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	} else {
 		out.RollingUpdate = nil
 	}
+	if in.ValidationChecks != nil {
+		in, out := &in.ValidationChecks, &out.ValidationChecks
+		*out = make([]kops.ValidationCheck, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_ValidationCheck_To_kops_ValidationCheck(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.ValidationChecks = nil
+	}
 	if in.ClusterAutoscaler != nil {
 		in, out := &in.ClusterAutoscaler, &out.ClusterAutoscaler
 		*out = new(kops.ClusterAutoscalerConfig)
@@ -2847,6 +3557,15 @@ func autoConvert_v1alpha3_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	} else {
 		out.WarmPool = nil
 	}
+	if in.InstanceMetadata != nil {
+		in, out := &in.InstanceMetadata, &out.InstanceMetadata
+		*out = new(kops.InstanceMetadataOptions)
+		if err := Convert_v1alpha3_InstanceMetadataOptions_To_kops_InstanceMetadataOptions(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.InstanceMetadata = nil
+	}
 	if in.ServiceAccountIssuerDiscovery != nil {
 		in, out := &in.ServiceAccountIssuerDiscovery, &out.ServiceAccountIssuerDiscovery
 		*out = new(kops.ServiceAccountIssuerDiscoveryConfig)
@@ -2883,6 +3602,15 @@ func autoConvert_v1alpha3_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	} else {
 		out.PodIdentityWebhook = nil
 	}
+	if in.CertificateIssuer != nil {
+		in, out := &in.CertificateIssuer, &out.CertificateIssuer
+		*out = new(kops.CertificateIssuerSpec)
+		if err := Convert_v1alpha3_CertificateIssuerSpec_To_kops_CertificateIssuerSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CertificateIssuer = nil
+	}
 	return nil
 }
 
@@ -2947,6 +3675,7 @@ func autoConvert_kops_ClusterSpec_To_v1alpha3_ClusterSpec(in *kops.ClusterSpec,
 	}
 	out.SecretStore = in.SecretStore
 	out.KeyStore = in.KeyStore
+	out.KeyStoreKMSKey = in.KeyStoreKMSKey
 	out.ConfigStore = in.ConfigStore
 	out.DNSZone = in.DNSZone
 	if in.DNSControllerGossipConfig != nil {
@@ -2958,6 +3687,15 @@ func autoConvert_kops_ClusterSpec_To_v1alpha3_ClusterSpec(in *kops.ClusterSpec,
 	} else {
 		out.DNSControllerGossipConfig = nil
 	}
+	if in.DNSRecords != nil {
+		in, out := &in.DNSRecords, &out.DNSRecords
+		*out = new(DNSRecordsSpec)
+		if err := Convert_kops_DNSRecordsSpec_To_v1alpha3_DNSRecordsSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.DNSRecords = nil
+	}
 	out.AdditionalSANs = in.AdditionalSANs
 	out.ClusterDNSDomain = in.ClusterDNSDomain
 	out.ServiceClusterIPRange = in.ServiceClusterIPRange
@@ -2974,6 +3712,7 @@ func autoConvert_kops_ClusterSpec_To_v1alpha3_ClusterSpec(in *kops.ClusterSpec,
 	} else {
 		out.EgressProxy = nil
 	}
+	out.DisableConnectivityChecks = in.DisableConnectivityChecks
 	out.SSHKeyName = in.SSHKeyName
 	out.KubernetesAPIAccess = in.KubernetesAPIAccess
 	out.IsolateMasters = in.IsolateMasters
@@ -3011,6 +3750,15 @@ func autoConvert_kops_ClusterSpec_To_v1alpha3_ClusterSpec(in *kops.ClusterSpec,
 	} else {
 		out.Containerd = nil
 	}
+	if in.Crio != nil {
+		in, out := &in.Crio, &out.Crio
+		*out = new(CrioConfig)
+		if err := Convert_kops_CrioConfig_To_v1alpha3_CrioConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Crio = nil
+	}
 	if in.Docker != nil {
 		in, out := &in.Docker, &out.Docker
 		*out = new(DockerConfig)
@@ -3137,6 +3885,15 @@ func autoConvert_kops_ClusterSpec_To_v1alpha3_ClusterSpec(in *kops.ClusterSpec,
 	} else {
 		out.NodeProblemDetector = nil
 	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(MonitoringSpec)
+		if err := Convert_kops_MonitoringSpec_To_v1alpha3_MonitoringSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Monitoring = nil
+	}
 	if in.MetricsServer != nil {
 		in, out := &in.MetricsServer, &out.MetricsServer
 		*out = new(MetricsServerConfig)
@@ -3201,7 +3958,25 @@ func autoConvert_kops_ClusterSpec_To_v1alpha3_ClusterSpec(in *kops.ClusterSpec,
 		out.Authorization = nil
 	}
 	out.NodeAuthorization = in.NodeAuthorization
+	if in.NodeCertificates != nil {
+		in, out := &in.NodeCertificates, &out.NodeCertificates
+		*out = new(NodeCertificatesSpec)
+		if err := Convert_kops_NodeCertificatesSpec_To_v1alpha3_NodeCertificatesSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.NodeCertificates = nil
+	}
 	out.CloudLabels = in.CloudLabels
+	if in.NodeLabelsFromCloudTags != nil {
+		in, out := &in.NodeLabelsFromCloudTags, &out.NodeLabelsFromCloudTags
+		*out = new(NodeLabelsFromCloudTagsSpec)
+		if err := Convert_kops_NodeLabelsFromCloudTagsSpec_To_v1alpha3_NodeLabelsFromCloudTagsSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.NodeLabelsFromCloudTags = nil
+	}
 	if in.Hooks != nil {
 		in, out := &in.Hooks, &out.Hooks
 		*out = make([]HookSpec, len(*in))
@@ -3253,6 +4028,17 @@ func autoConvert_kops_ClusterSpec_To_v1alpha3_ClusterSpec(in *kops.ClusterSpec,
 	} else {
 		out.RollingUpdate = nil
 	}
+	if in.ValidationChecks != nil {
+		in, out := &in.ValidationChecks, &out.ValidationChecks
+		*out = make([]ValidationCheck, len(*in))
+		for i := range *in {
+			if err := Convert_kops_ValidationCheck_To_v1alpha3_ValidationCheck(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.ValidationChecks = nil
+	}
 	if in.ClusterAutoscaler != nil {
 		in, out := &in.ClusterAutoscaler, &out.ClusterAutoscaler
 		*out = new(ClusterAutoscalerConfig)
@@ -3271,6 +4057,15 @@ func autoConvert_kops_ClusterSpec_To_v1alpha3_ClusterSpec(in *kops.ClusterSpec,
 	} else {
 		out.WarmPool = nil
 	}
+	if in.InstanceMetadata != nil {
+		in, out := &in.InstanceMetadata, &out.InstanceMetadata
+		*out = new(InstanceMetadataOptions)
+		if err := Convert_kops_InstanceMetadataOptions_To_v1alpha3_InstanceMetadataOptions(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.InstanceMetadata = nil
+	}
 	if in.ServiceAccountIssuerDiscovery != nil {
 		in, out := &in.ServiceAccountIssuerDiscovery, &out.ServiceAccountIssuerDiscovery
 		*out = new(ServiceAccountIssuerDiscoveryConfig)
@@ -3307,6 +4102,15 @@ func autoConvert_kops_ClusterSpec_To_v1alpha3_ClusterSpec(in *kops.ClusterSpec,
 	} else {
 		out.PodIdentityWebhook = nil
 	}
+	if in.CertificateIssuer != nil {
+		in, out := &in.CertificateIssuer, &out.CertificateIssuer
+		*out = new(CertificateIssuerSpec)
+		if err := Convert_kops_CertificateIssuerSpec_To_v1alpha3_CertificateIssuerSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CertificateIssuer = nil
+	}
 	return nil
 }
 
@@ -3387,6 +4191,20 @@ func autoConvert_v1alpha3_ContainerdConfig_To_kops_ContainerdConfig(in *Containe
 		out.Packages = nil
 	}
 	out.RegistryMirrors = in.RegistryMirrors
+	if in.Registries != nil {
+		in, out := &in.Registries, &out.Registries
+		*out = make(map[string]kops.ContainerdRegistryConfig, len(*in))
+		for key, val := range *in {
+			newVal := new(kops.ContainerdRegistryConfig)
+			if err := Convert_v1alpha3_ContainerdRegistryConfig_To_kops_ContainerdRegistryConfig(&val, newVal, s); err != nil {
+				return err
+			}
+			(*out)[key] = *newVal
+		}
+	} else {
+		out.Registries = nil
+	}
+	out.ConfigAdditions = in.ConfigAdditions
 	out.Root = in.Root
 	out.SkipInstall = in.SkipInstall
 	out.State = in.State
@@ -3400,6 +4218,15 @@ func autoConvert_v1alpha3_ContainerdConfig_To_kops_ContainerdConfig(in *Containe
 	} else {
 		out.NvidiaGPU = nil
 	}
+	if in.Runc != nil {
+		in, out := &in.Runc, &out.Runc
+		*out = new(kops.Runc)
+		if err := Convert_v1alpha3_Runc_To_kops_Runc(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Runc = nil
+	}
 	return nil
 }
 
@@ -3422,6 +4249,20 @@ func autoConvert_kops_ContainerdConfig_To_v1alpha3_ContainerdConfig(in *kops.Con
 		out.Packages = nil
 	}
 	out.RegistryMirrors = in.RegistryMirrors
+	if in.Registries != nil {
+		in, out := &in.Registries, &out.Registries
+		*out = make(map[string]ContainerdRegistryConfig, len(*in))
+		for key, val := range *in {
+			newVal := new(ContainerdRegistryConfig)
+			if err := Convert_kops_ContainerdRegistryConfig_To_v1alpha3_ContainerdRegistryConfig(&val, newVal, s); err != nil {
+				return err
+			}
+			(*out)[key] = *newVal
+		}
+	} else {
+		out.Registries = nil
+	}
+	out.ConfigAdditions = in.ConfigAdditions
 	out.Root = in.Root
 	out.SkipInstall = in.SkipInstall
 	out.State = in.State
@@ -3435,6 +4276,15 @@ func autoConvert_kops_ContainerdConfig_To_v1alpha3_ContainerdConfig(in *kops.Con
 	} else {
 		out.NvidiaGPU = nil
 	}
+	if in.Runc != nil {
+		in, out := &in.Runc, &out.Runc
+		*out = new(Runc)
+		if err := Convert_kops_Runc_To_v1alpha3_Runc(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Runc = nil
+	}
 	return nil
 }
 
@@ -3443,6 +4293,152 @@ func Convert_kops_ContainerdConfig_To_v1alpha3_ContainerdConfig(in *kops.Contain
 	return autoConvert_kops_ContainerdConfig_To_v1alpha3_ContainerdConfig(in, out, s)
 }
 
+func autoConvert_v1alpha3_ContainerdRegistryAuth_To_kops_ContainerdRegistryAuth(in *ContainerdRegistryAuth, out *kops.ContainerdRegistryAuth, s conversion.Scope) error {
+	out.Username = in.Username
+	out.PasswordSecret = in.PasswordSecret
+	return nil
+}
+
+// Convert_v1alpha3_ContainerdRegistryAuth_To_kops_ContainerdRegistryAuth is an autogenerated conversion function.
+func Convert_v1alpha3_ContainerdRegistryAuth_To_kops_ContainerdRegistryAuth(in *ContainerdRegistryAuth, out *kops.ContainerdRegistryAuth, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ContainerdRegistryAuth_To_kops_ContainerdRegistryAuth(in, out, s)
+}
+
+func autoConvert_kops_ContainerdRegistryAuth_To_v1alpha3_ContainerdRegistryAuth(in *kops.ContainerdRegistryAuth, out *ContainerdRegistryAuth, s conversion.Scope) error {
+	out.Username = in.Username
+	out.PasswordSecret = in.PasswordSecret
+	return nil
+}
+
+// Convert_kops_ContainerdRegistryAuth_To_v1alpha3_ContainerdRegistryAuth is an autogenerated conversion function.
+func Convert_kops_ContainerdRegistryAuth_To_v1alpha3_ContainerdRegistryAuth(in *kops.ContainerdRegistryAuth, out *ContainerdRegistryAuth, s conversion.Scope) error {
+	return autoConvert_kops_ContainerdRegistryAuth_To_v1alpha3_ContainerdRegistryAuth(in, out, s)
+}
+
+func autoConvert_v1alpha3_ContainerdRegistryConfig_To_kops_ContainerdRegistryConfig(in *ContainerdRegistryConfig, out *kops.ContainerdRegistryConfig, s conversion.Scope) error {
+	if in.Mirrors != nil {
+		in, out := &in.Mirrors, &out.Mirrors
+		*out = make([]kops.ContainerdRegistryMirror, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_ContainerdRegistryMirror_To_kops_ContainerdRegistryMirror(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Mirrors = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha3_ContainerdRegistryConfig_To_kops_ContainerdRegistryConfig is an autogenerated conversion function.
+func Convert_v1alpha3_ContainerdRegistryConfig_To_kops_ContainerdRegistryConfig(in *ContainerdRegistryConfig, out *kops.ContainerdRegistryConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ContainerdRegistryConfig_To_kops_ContainerdRegistryConfig(in, out, s)
+}
+
+func autoConvert_kops_ContainerdRegistryConfig_To_v1alpha3_ContainerdRegistryConfig(in *kops.ContainerdRegistryConfig, out *ContainerdRegistryConfig, s conversion.Scope) error {
+	if in.Mirrors != nil {
+		in, out := &in.Mirrors, &out.Mirrors
+		*out = make([]ContainerdRegistryMirror, len(*in))
+		for i := range *in {
+			if err := Convert_kops_ContainerdRegistryMirror_To_v1alpha3_ContainerdRegistryMirror(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Mirrors = nil
+	}
+	return nil
+}
+
+// Convert_kops_ContainerdRegistryConfig_To_v1alpha3_ContainerdRegistryConfig is an autogenerated conversion function.
+func Convert_kops_ContainerdRegistryConfig_To_v1alpha3_ContainerdRegistryConfig(in *kops.ContainerdRegistryConfig, out *ContainerdRegistryConfig, s conversion.Scope) error {
+	return autoConvert_kops_ContainerdRegistryConfig_To_v1alpha3_ContainerdRegistryConfig(in, out, s)
+}
+
+func autoConvert_v1alpha3_ContainerdRegistryMirror_To_kops_ContainerdRegistryMirror(in *ContainerdRegistryMirror, out *kops.ContainerdRegistryMirror, s conversion.Scope) error {
+	out.Endpoint = in.Endpoint
+	out.SkipVerify = in.SkipVerify
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(kops.ContainerdRegistryAuth)
+		if err := Convert_v1alpha3_ContainerdRegistryAuth_To_kops_ContainerdRegistryAuth(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Auth = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha3_ContainerdRegistryMirror_To_kops_ContainerdRegistryMirror is an autogenerated conversion function.
+func Convert_v1alpha3_ContainerdRegistryMirror_To_kops_ContainerdRegistryMirror(in *ContainerdRegistryMirror, out *kops.ContainerdRegistryMirror, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ContainerdRegistryMirror_To_kops_ContainerdRegistryMirror(in, out, s)
+}
+
+func autoConvert_kops_ContainerdRegistryMirror_To_v1alpha3_ContainerdRegistryMirror(in *kops.ContainerdRegistryMirror, out *ContainerdRegistryMirror, s conversion.Scope) error {
+	out.Endpoint = in.Endpoint
+	out.SkipVerify = in.SkipVerify
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(ContainerdRegistryAuth)
+		if err := Convert_kops_ContainerdRegistryAuth_To_v1alpha3_ContainerdRegistryAuth(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Auth = nil
+	}
+	return nil
+}
+
+// Convert_kops_ContainerdRegistryMirror_To_v1alpha3_ContainerdRegistryMirror is an autogenerated conversion function.
+func Convert_kops_ContainerdRegistryMirror_To_v1alpha3_ContainerdRegistryMirror(in *kops.ContainerdRegistryMirror, out *ContainerdRegistryMirror, s conversion.Scope) error {
+	return autoConvert_kops_ContainerdRegistryMirror_To_v1alpha3_ContainerdRegistryMirror(in, out, s)
+}
+
+func autoConvert_v1alpha3_CrioConfig_To_kops_CrioConfig(in *CrioConfig, out *kops.CrioConfig, s conversion.Scope) error {
+	out.ConfigOverride = in.ConfigOverride
+	out.LogLevel = in.LogLevel
+	if in.Packages != nil {
+		in, out := &in.Packages, &out.Packages
+		*out = new(kops.PackagesConfig)
+		if err := Convert_v1alpha3_PackagesConfig_To_kops_PackagesConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Packages = nil
+	}
+	out.SkipInstall = in.SkipInstall
+	out.Version = in.Version
+	return nil
+}
+
+// Convert_v1alpha3_CrioConfig_To_kops_CrioConfig is an autogenerated conversion function.
+func Convert_v1alpha3_CrioConfig_To_kops_CrioConfig(in *CrioConfig, out *kops.CrioConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CrioConfig_To_kops_CrioConfig(in, out, s)
+}
+
+func autoConvert_kops_CrioConfig_To_v1alpha3_CrioConfig(in *kops.CrioConfig, out *CrioConfig, s conversion.Scope) error {
+	out.ConfigOverride = in.ConfigOverride
+	out.LogLevel = in.LogLevel
+	if in.Packages != nil {
+		in, out := &in.Packages, &out.Packages
+		*out = new(PackagesConfig)
+		if err := Convert_kops_PackagesConfig_To_v1alpha3_PackagesConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Packages = nil
+	}
+	out.SkipInstall = in.SkipInstall
+	out.Version = in.Version
+	return nil
+}
+
+// Convert_kops_CrioConfig_To_v1alpha3_CrioConfig is an autogenerated conversion function.
+func Convert_kops_CrioConfig_To_v1alpha3_CrioConfig(in *kops.CrioConfig, out *CrioConfig, s conversion.Scope) error {
+	return autoConvert_kops_CrioConfig_To_v1alpha3_CrioConfig(in, out, s)
+}
+
 func autoConvert_v1alpha3_DNSAccessSpec_To_kops_DNSAccessSpec(in *DNSAccessSpec, out *kops.DNSAccessSpec, s conversion.Scope) error {
 	return nil
 }
@@ -3475,6 +4471,9 @@ func autoConvert_v1alpha3_DNSControllerGossipConfig_To_kops_DNSControllerGossipC
 		out.Secondary = nil
 	}
 	out.Seed = in.Seed
+	out.ProbeInterval = in.ProbeInterval
+	out.GossipInterval = in.GossipInterval
+	out.PushPullInterval = in.PushPullInterval
 	return nil
 }
 
@@ -3497,6 +4496,9 @@ func autoConvert_kops_DNSControllerGossipConfig_To_v1alpha3_DNSControllerGossipC
 		out.Secondary = nil
 	}
 	out.Seed = in.Seed
+	out.ProbeInterval = in.ProbeInterval
+	out.GossipInterval = in.GossipInterval
+	out.PushPullInterval = in.PushPullInterval
 	return nil
 }
 
@@ -3526,9 +4528,121 @@ func autoConvert_kops_DNSControllerGossipConfigSecondary_To_v1alpha3_DNSControll
 	return nil
 }
 
-// Convert_kops_DNSControllerGossipConfigSecondary_To_v1alpha3_DNSControllerGossipConfigSecondary is an autogenerated conversion function.
-func Convert_kops_DNSControllerGossipConfigSecondary_To_v1alpha3_DNSControllerGossipConfigSecondary(in *kops.DNSControllerGossipConfigSecondary, out *DNSControllerGossipConfigSecondary, s conversion.Scope) error {
-	return autoConvert_kops_DNSControllerGossipConfigSecondary_To_v1alpha3_DNSControllerGossipConfigSecondary(in, out, s)
+// Convert_kops_DNSControllerGossipConfigSecondary_To_v1alpha3_DNSControllerGossipConfigSecondary is an autogenerated conversion function.
+func Convert_kops_DNSControllerGossipConfigSecondary_To_v1alpha3_DNSControllerGossipConfigSecondary(in *kops.DNSControllerGossipConfigSecondary, out *DNSControllerGossipConfigSecondary, s conversion.Scope) error {
+	return autoConvert_kops_DNSControllerGossipConfigSecondary_To_v1alpha3_DNSControllerGossipConfigSecondary(in, out, s)
+}
+
+func autoConvert_v1alpha3_DNSRecordSpec_To_kops_DNSRecordSpec(in *DNSRecordSpec, out *kops.DNSRecordSpec, s conversion.Scope) error {
+	out.Type = kops.DNSRecordType(in.Type)
+	out.TTL = in.TTL
+	return nil
+}
+
+// Convert_v1alpha3_DNSRecordSpec_To_kops_DNSRecordSpec is an autogenerated conversion function.
+func Convert_v1alpha3_DNSRecordSpec_To_kops_DNSRecordSpec(in *DNSRecordSpec, out *kops.DNSRecordSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_DNSRecordSpec_To_kops_DNSRecordSpec(in, out, s)
+}
+
+func autoConvert_kops_DNSRecordSpec_To_v1alpha3_DNSRecordSpec(in *kops.DNSRecordSpec, out *DNSRecordSpec, s conversion.Scope) error {
+	out.Type = DNSRecordType(in.Type)
+	out.TTL = in.TTL
+	return nil
+}
+
+// Convert_kops_DNSRecordSpec_To_v1alpha3_DNSRecordSpec is an autogenerated conversion function.
+func Convert_kops_DNSRecordSpec_To_v1alpha3_DNSRecordSpec(in *kops.DNSRecordSpec, out *DNSRecordSpec, s conversion.Scope) error {
+	return autoConvert_kops_DNSRecordSpec_To_v1alpha3_DNSRecordSpec(in, out, s)
+}
+
+func autoConvert_v1alpha3_DNSRecordsSpec_To_kops_DNSRecordsSpec(in *DNSRecordsSpec, out *kops.DNSRecordsSpec, s conversion.Scope) error {
+	if in.API != nil {
+		in, out := &in.API, &out.API
+		*out = new(kops.DNSRecordSpec)
+		if err := Convert_v1alpha3_DNSRecordSpec_To_kops_DNSRecordSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.API = nil
+	}
+	if in.APIInternal != nil {
+		in, out := &in.APIInternal, &out.APIInternal
+		*out = new(kops.DNSRecordSpec)
+		if err := Convert_v1alpha3_DNSRecordSpec_To_kops_DNSRecordSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.APIInternal = nil
+	}
+	if in.Bastion != nil {
+		in, out := &in.Bastion, &out.Bastion
+		*out = new(kops.DNSRecordSpec)
+		if err := Convert_v1alpha3_DNSRecordSpec_To_kops_DNSRecordSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Bastion = nil
+	}
+	if in.KopsController != nil {
+		in, out := &in.KopsController, &out.KopsController
+		*out = new(kops.DNSRecordSpec)
+		if err := Convert_v1alpha3_DNSRecordSpec_To_kops_DNSRecordSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.KopsController = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha3_DNSRecordsSpec_To_kops_DNSRecordsSpec is an autogenerated conversion function.
+func Convert_v1alpha3_DNSRecordsSpec_To_kops_DNSRecordsSpec(in *DNSRecordsSpec, out *kops.DNSRecordsSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_DNSRecordsSpec_To_kops_DNSRecordsSpec(in, out, s)
+}
+
+func autoConvert_kops_DNSRecordsSpec_To_v1alpha3_DNSRecordsSpec(in *kops.DNSRecordsSpec, out *DNSRecordsSpec, s conversion.Scope) error {
+	if in.API != nil {
+		in, out := &in.API, &out.API
+		*out = new(DNSRecordSpec)
+		if err := Convert_kops_DNSRecordSpec_To_v1alpha3_DNSRecordSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.API = nil
+	}
+	if in.APIInternal != nil {
+		in, out := &in.APIInternal, &out.APIInternal
+		*out = new(DNSRecordSpec)
+		if err := Convert_kops_DNSRecordSpec_To_v1alpha3_DNSRecordSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.APIInternal = nil
+	}
+	if in.Bastion != nil {
+		in, out := &in.Bastion, &out.Bastion
+		*out = new(DNSRecordSpec)
+		if err := Convert_kops_DNSRecordSpec_To_v1alpha3_DNSRecordSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Bastion = nil
+	}
+	if in.KopsController != nil {
+		in, out := &in.KopsController, &out.KopsController
+		*out = new(DNSRecordSpec)
+		if err := Convert_kops_DNSRecordSpec_To_v1alpha3_DNSRecordSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.KopsController = nil
+	}
+	return nil
+}
+
+// Convert_kops_DNSRecordsSpec_To_v1alpha3_DNSRecordsSpec is an autogenerated conversion function.
+func Convert_kops_DNSRecordsSpec_To_v1alpha3_DNSRecordsSpec(in *kops.DNSRecordsSpec, out *DNSRecordsSpec, s conversion.Scope) error {
+	return autoConvert_kops_DNSRecordsSpec_To_v1alpha3_DNSRecordsSpec(in, out, s)
 }
 
 func autoConvert_v1alpha3_DNSSpec_To_kops_DNSSpec(in *DNSSpec, out *kops.DNSSpec, s conversion.Scope) error {
@@ -3671,6 +4785,28 @@ func Convert_kops_DockerConfig_To_v1alpha3_DockerConfig(in *kops.DockerConfig, o
 	return autoConvert_kops_DockerConfig_To_v1alpha3_DockerConfig(in, out, s)
 }
 
+func autoConvert_v1alpha3_EFASpec_To_kops_EFASpec(in *EFASpec, out *kops.EFASpec, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.NetworkCards = in.NetworkCards
+	return nil
+}
+
+// Convert_v1alpha3_EFASpec_To_kops_EFASpec is an autogenerated conversion function.
+func Convert_v1alpha3_EFASpec_To_kops_EFASpec(in *EFASpec, out *kops.EFASpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_EFASpec_To_kops_EFASpec(in, out, s)
+}
+
+func autoConvert_kops_EFASpec_To_v1alpha3_EFASpec(in *kops.EFASpec, out *EFASpec, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.NetworkCards = in.NetworkCards
+	return nil
+}
+
+// Convert_kops_EFASpec_To_v1alpha3_EFASpec is an autogenerated conversion function.
+func Convert_kops_EFASpec_To_v1alpha3_EFASpec(in *kops.EFASpec, out *EFASpec, s conversion.Scope) error {
+	return autoConvert_kops_EFASpec_To_v1alpha3_EFASpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_EgressProxySpec_To_kops_EgressProxySpec(in *EgressProxySpec, out *kops.EgressProxySpec, s conversion.Scope) error {
 	if err := Convert_v1alpha3_HTTPProxy_To_kops_HTTPProxy(&in.HTTPProxy, &out.HTTPProxy, s); err != nil {
 		return err
@@ -3722,6 +4858,15 @@ func Convert_kops_EnvVar_To_v1alpha3_EnvVar(in *kops.EnvVar, out *EnvVar, s conv
 func autoConvert_v1alpha3_EtcdBackupSpec_To_kops_EtcdBackupSpec(in *EtcdBackupSpec, out *kops.EtcdBackupSpec, s conversion.Scope) error {
 	out.BackupStore = in.BackupStore
 	out.Image = in.Image
+	if in.CrossAccountStore != nil {
+		in, out := &in.CrossAccountStore, &out.CrossAccountStore
+		*out = new(kops.EtcdCrossAccountStoreSpec)
+		if err := Convert_v1alpha3_EtcdCrossAccountStoreSpec_To_kops_EtcdCrossAccountStoreSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CrossAccountStore = nil
+	}
 	return nil
 }
 
@@ -3733,6 +4878,15 @@ func Convert_v1alpha3_EtcdBackupSpec_To_kops_EtcdBackupSpec(in *EtcdBackupSpec,
 func autoConvert_kops_EtcdBackupSpec_To_v1alpha3_EtcdBackupSpec(in *kops.EtcdBackupSpec, out *EtcdBackupSpec, s conversion.Scope) error {
 	out.BackupStore = in.BackupStore
 	out.Image = in.Image
+	if in.CrossAccountStore != nil {
+		in, out := &in.CrossAccountStore, &out.CrossAccountStore
+		*out = new(EtcdCrossAccountStoreSpec)
+		if err := Convert_kops_EtcdCrossAccountStoreSpec_To_v1alpha3_EtcdCrossAccountStoreSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CrossAccountStore = nil
+	}
 	return nil
 }
 
@@ -3758,6 +4912,10 @@ func autoConvert_v1alpha3_EtcdClusterSpec_To_kops_EtcdClusterSpec(in *EtcdCluste
 	out.Version = in.Version
 	out.LeaderElectionTimeout = in.LeaderElectionTimeout
 	out.HeartbeatInterval = in.HeartbeatInterval
+	out.QuotaBackendBytes = in.QuotaBackendBytes
+	out.AutoCompactionMode = in.AutoCompactionMode
+	out.AutoCompactionRetention = in.AutoCompactionRetention
+	out.SnapshotCount = in.SnapshotCount
 	out.Image = in.Image
 	if in.Backups != nil {
 		in, out := &in.Backups, &out.Backups
@@ -3787,6 +4945,18 @@ func Convert_v1alpha3_EtcdClusterSpec_To_kops_EtcdClusterSpec(in *EtcdClusterSpe
 	return autoConvert_v1alpha3_EtcdClusterSpec_To_kops_EtcdClusterSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_EtcdCrossAccountStoreSpec_To_kops_EtcdCrossAccountStoreSpec(in *EtcdCrossAccountStoreSpec, out *kops.EtcdCrossAccountStoreSpec, s conversion.Scope) error {
+	out.BackupStore = in.BackupStore
+	out.RoleARN = in.RoleARN
+	out.ExternalID = in.ExternalID
+	return nil
+}
+
+// Convert_v1alpha3_EtcdCrossAccountStoreSpec_To_kops_EtcdCrossAccountStoreSpec is an autogenerated conversion function.
+func Convert_v1alpha3_EtcdCrossAccountStoreSpec_To_kops_EtcdCrossAccountStoreSpec(in *EtcdCrossAccountStoreSpec, out *kops.EtcdCrossAccountStoreSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_EtcdCrossAccountStoreSpec_To_kops_EtcdCrossAccountStoreSpec(in, out, s)
+}
+
 func autoConvert_kops_EtcdClusterSpec_To_v1alpha3_EtcdClusterSpec(in *kops.EtcdClusterSpec, out *EtcdClusterSpec, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Provider = string(in.Provider)
@@ -3804,6 +4974,10 @@ func autoConvert_kops_EtcdClusterSpec_To_v1alpha3_EtcdClusterSpec(in *kops.EtcdC
 	out.Version = in.Version
 	out.LeaderElectionTimeout = in.LeaderElectionTimeout
 	out.HeartbeatInterval = in.HeartbeatInterval
+	out.QuotaBackendBytes = in.QuotaBackendBytes
+	out.AutoCompactionMode = in.AutoCompactionMode
+	out.AutoCompactionRetention = in.AutoCompactionRetention
+	out.SnapshotCount = in.SnapshotCount
 	out.Image = in.Image
 	if in.Backups != nil {
 		in, out := &in.Backups, &out.Backups
@@ -3833,6 +5007,18 @@ func Convert_kops_EtcdClusterSpec_To_v1alpha3_EtcdClusterSpec(in *kops.EtcdClust
 	return autoConvert_kops_EtcdClusterSpec_To_v1alpha3_EtcdClusterSpec(in, out, s)
 }
 
+func autoConvert_kops_EtcdCrossAccountStoreSpec_To_v1alpha3_EtcdCrossAccountStoreSpec(in *kops.EtcdCrossAccountStoreSpec, out *EtcdCrossAccountStoreSpec, s conversion.Scope) error {
+	out.BackupStore = in.BackupStore
+	out.RoleARN = in.RoleARN
+	out.ExternalID = in.ExternalID
+	return nil
+}
+
+// Convert_kops_EtcdCrossAccountStoreSpec_To_v1alpha3_EtcdCrossAccountStoreSpec is an autogenerated conversion function.
+func Convert_kops_EtcdCrossAccountStoreSpec_To_v1alpha3_EtcdCrossAccountStoreSpec(in *kops.EtcdCrossAccountStoreSpec, out *EtcdCrossAccountStoreSpec, s conversion.Scope) error {
+	return autoConvert_kops_EtcdCrossAccountStoreSpec_To_v1alpha3_EtcdCrossAccountStoreSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_EtcdManagerSpec_To_kops_EtcdManagerSpec(in *EtcdManagerSpec, out *kops.EtcdManagerSpec, s conversion.Scope) error {
 	out.Image = in.Image
 	if in.Env != nil {
@@ -3913,6 +5099,72 @@ func Convert_kops_EtcdMemberSpec_To_v1alpha3_EtcdMemberSpec(in *kops.EtcdMemberS
 	return autoConvert_kops_EtcdMemberSpec_To_v1alpha3_EtcdMemberSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_EventRateLimit_To_kops_EventRateLimit(in *EventRateLimit, out *kops.EventRateLimit, s conversion.Scope) error {
+	out.Type = in.Type
+	out.QPS = in.QPS
+	out.Burst = in.Burst
+	out.CacheSize = in.CacheSize
+	return nil
+}
+
+// Convert_v1alpha3_EventRateLimit_To_kops_EventRateLimit is an autogenerated conversion function.
+func Convert_v1alpha3_EventRateLimit_To_kops_EventRateLimit(in *EventRateLimit, out *kops.EventRateLimit, s conversion.Scope) error {
+	return autoConvert_v1alpha3_EventRateLimit_To_kops_EventRateLimit(in, out, s)
+}
+
+func autoConvert_kops_EventRateLimit_To_v1alpha3_EventRateLimit(in *kops.EventRateLimit, out *EventRateLimit, s conversion.Scope) error {
+	out.Type = in.Type
+	out.QPS = in.QPS
+	out.Burst = in.Burst
+	out.CacheSize = in.CacheSize
+	return nil
+}
+
+// Convert_kops_EventRateLimit_To_v1alpha3_EventRateLimit is an autogenerated conversion function.
+func Convert_kops_EventRateLimit_To_v1alpha3_EventRateLimit(in *kops.EventRateLimit, out *EventRateLimit, s conversion.Scope) error {
+	return autoConvert_kops_EventRateLimit_To_v1alpha3_EventRateLimit(in, out, s)
+}
+
+func autoConvert_v1alpha3_EventRateLimitAdmissionConfig_To_kops_EventRateLimitAdmissionConfig(in *EventRateLimitAdmissionConfig, out *kops.EventRateLimitAdmissionConfig, s conversion.Scope) error {
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = make([]kops.EventRateLimit, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_EventRateLimit_To_kops_EventRateLimit(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Limits = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha3_EventRateLimitAdmissionConfig_To_kops_EventRateLimitAdmissionConfig is an autogenerated conversion function.
+func Convert_v1alpha3_EventRateLimitAdmissionConfig_To_kops_EventRateLimitAdmissionConfig(in *EventRateLimitAdmissionConfig, out *kops.EventRateLimitAdmissionConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha3_EventRateLimitAdmissionConfig_To_kops_EventRateLimitAdmissionConfig(in, out, s)
+}
+
+func autoConvert_kops_EventRateLimitAdmissionConfig_To_v1alpha3_EventRateLimitAdmissionConfig(in *kops.EventRateLimitAdmissionConfig, out *EventRateLimitAdmissionConfig, s conversion.Scope) error {
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = make([]EventRateLimit, len(*in))
+		for i := range *in {
+			if err := Convert_kops_EventRateLimit_To_v1alpha3_EventRateLimit(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Limits = nil
+	}
+	return nil
+}
+
+// Convert_kops_EventRateLimitAdmissionConfig_To_v1alpha3_EventRateLimitAdmissionConfig is an autogenerated conversion function.
+func Convert_kops_EventRateLimitAdmissionConfig_To_v1alpha3_EventRateLimitAdmissionConfig(in *kops.EventRateLimitAdmissionConfig, out *EventRateLimitAdmissionConfig, s conversion.Scope) error {
+	return autoConvert_kops_EventRateLimitAdmissionConfig_To_v1alpha3_EventRateLimitAdmissionConfig(in, out, s)
+}
+
 func autoConvert_v1alpha3_ExecContainerAction_To_kops_ExecContainerAction(in *ExecContainerAction, out *kops.ExecContainerAction, s conversion.Scope) error {
 	out.Image = in.Image
 	out.Command = in.Command
@@ -4025,6 +5277,28 @@ func Convert_kops_FileAssetSpec_To_v1alpha3_FileAssetSpec(in *kops.FileAssetSpec
 	return autoConvert_kops_FileAssetSpec_To_v1alpha3_FileAssetSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_FileTrustPolicy_To_kops_FileTrustPolicy(in *FileTrustPolicy, out *kops.FileTrustPolicy, s conversion.Scope) error {
+	out.URL = in.URL
+	out.Hash = in.Hash
+	return nil
+}
+
+// Convert_v1alpha3_FileTrustPolicy_To_kops_FileTrustPolicy is an autogenerated conversion function.
+func Convert_v1alpha3_FileTrustPolicy_To_kops_FileTrustPolicy(in *FileTrustPolicy, out *kops.FileTrustPolicy, s conversion.Scope) error {
+	return autoConvert_v1alpha3_FileTrustPolicy_To_kops_FileTrustPolicy(in, out, s)
+}
+
+func autoConvert_kops_FileTrustPolicy_To_v1alpha3_FileTrustPolicy(in *kops.FileTrustPolicy, out *FileTrustPolicy, s conversion.Scope) error {
+	out.URL = in.URL
+	out.Hash = in.Hash
+	return nil
+}
+
+// Convert_kops_FileTrustPolicy_To_v1alpha3_FileTrustPolicy is an autogenerated conversion function.
+func Convert_kops_FileTrustPolicy_To_v1alpha3_FileTrustPolicy(in *kops.FileTrustPolicy, out *FileTrustPolicy, s conversion.Scope) error {
+	return autoConvert_kops_FileTrustPolicy_To_v1alpha3_FileTrustPolicy(in, out, s)
+}
+
 func autoConvert_v1alpha3_FlannelNetworkingSpec_To_kops_FlannelNetworkingSpec(in *FlannelNetworkingSpec, out *kops.FlannelNetworkingSpec, s conversion.Scope) error {
 	out.Backend = in.Backend
 	out.IptablesResyncSeconds = in.IptablesResyncSeconds
@@ -4083,6 +5357,26 @@ func Convert_kops_GCESpec_To_v1alpha3_GCESpec(in *kops.GCESpec, out *GCESpec, s
 	return autoConvert_kops_GCESpec_To_v1alpha3_GCESpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_GCPCASIssuerSpec_To_kops_GCPCASIssuerSpec(in *GCPCASIssuerSpec, out *kops.GCPCASIssuerSpec, s conversion.Scope) error {
+	out.CAPool = in.CAPool
+	return nil
+}
+
+// Convert_v1alpha3_GCPCASIssuerSpec_To_kops_GCPCASIssuerSpec is an autogenerated conversion function.
+func Convert_v1alpha3_GCPCASIssuerSpec_To_kops_GCPCASIssuerSpec(in *GCPCASIssuerSpec, out *kops.GCPCASIssuerSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_GCPCASIssuerSpec_To_kops_GCPCASIssuerSpec(in, out, s)
+}
+
+func autoConvert_kops_GCPCASIssuerSpec_To_v1alpha3_GCPCASIssuerSpec(in *kops.GCPCASIssuerSpec, out *GCPCASIssuerSpec, s conversion.Scope) error {
+	out.CAPool = in.CAPool
+	return nil
+}
+
+// Convert_kops_GCPCASIssuerSpec_To_v1alpha3_GCPCASIssuerSpec is an autogenerated conversion function.
+func Convert_kops_GCPCASIssuerSpec_To_v1alpha3_GCPCASIssuerSpec(in *kops.GCPCASIssuerSpec, out *GCPCASIssuerSpec, s conversion.Scope) error {
+	return autoConvert_kops_GCPCASIssuerSpec_To_v1alpha3_GCPCASIssuerSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_GCPPDCSIDriver_To_kops_GCPPDCSIDriver(in *GCPPDCSIDriver, out *kops.GCPPDCSIDriver, s conversion.Scope) error {
 	out.Enabled = in.Enabled
 	return nil
@@ -4103,6 +5397,26 @@ func Convert_kops_GCPPDCSIDriver_To_v1alpha3_GCPPDCSIDriver(in *kops.GCPPDCSIDri
 	return autoConvert_kops_GCPPDCSIDriver_To_v1alpha3_GCPPDCSIDriver(in, out, s)
 }
 
+func autoConvert_v1alpha3_GCPPermission_To_kops_GCPPermission(in *GCPPermission, out *kops.GCPPermission, s conversion.Scope) error {
+	out.Roles = in.Roles
+	return nil
+}
+
+// Convert_v1alpha3_GCPPermission_To_kops_GCPPermission is an autogenerated conversion function.
+func Convert_v1alpha3_GCPPermission_To_kops_GCPPermission(in *GCPPermission, out *kops.GCPPermission, s conversion.Scope) error {
+	return autoConvert_v1alpha3_GCPPermission_To_kops_GCPPermission(in, out, s)
+}
+
+func autoConvert_kops_GCPPermission_To_v1alpha3_GCPPermission(in *kops.GCPPermission, out *GCPPermission, s conversion.Scope) error {
+	out.Roles = in.Roles
+	return nil
+}
+
+// Convert_kops_GCPPermission_To_v1alpha3_GCPPermission is an autogenerated conversion function.
+func Convert_kops_GCPPermission_To_v1alpha3_GCPPermission(in *kops.GCPPermission, out *GCPPermission, s conversion.Scope) error {
+	return autoConvert_kops_GCPPermission_To_v1alpha3_GCPPermission(in, out, s)
+}
+
 func autoConvert_v1alpha3_GossipConfig_To_kops_GossipConfig(in *GossipConfig, out *kops.GossipConfig, s conversion.Scope) error {
 	out.Protocol = in.Protocol
 	out.Listen = in.Listen
@@ -4116,6 +5430,10 @@ func autoConvert_v1alpha3_GossipConfig_To_kops_GossipConfig(in *GossipConfig, ou
 	} else {
 		out.Secondary = nil
 	}
+	out.Encryption = in.Encryption
+	out.ProbeInterval = in.ProbeInterval
+	out.GossipInterval = in.GossipInterval
+	out.PushPullInterval = in.PushPullInterval
 	return nil
 }
 
@@ -4137,6 +5455,10 @@ func autoConvert_kops_GossipConfig_To_v1alpha3_GossipConfig(in *kops.GossipConfi
 	} else {
 		out.Secondary = nil
 	}
+	out.Encryption = in.Encryption
+	out.ProbeInterval = in.ProbeInterval
+	out.GossipInterval = in.GossipInterval
+	out.PushPullInterval = in.PushPullInterval
 	return nil
 }
 
@@ -4321,6 +5643,7 @@ func autoConvert_v1alpha3_IAMSpec_To_kops_IAMSpec(in *IAMSpec, out *kops.IAMSpec
 	out.Legacy = in.Legacy
 	out.AllowContainerRegistry = in.AllowContainerRegistry
 	out.PermissionsBoundary = in.PermissionsBoundary
+	out.Path = in.Path
 	out.UseServiceAccountExternalPermissions = in.UseServiceAccountExternalPermissions
 	if in.ServiceAccountExternalPermissions != nil {
 		in, out := &in.ServiceAccountExternalPermissions, &out.ServiceAccountExternalPermissions
@@ -4333,6 +5656,7 @@ func autoConvert_v1alpha3_IAMSpec_To_kops_IAMSpec(in *IAMSpec, out *kops.IAMSpec
 	} else {
 		out.ServiceAccountExternalPermissions = nil
 	}
+	out.ScopeResourceARNs = in.ScopeResourceARNs
 	return nil
 }
 
@@ -4345,6 +5669,7 @@ func autoConvert_kops_IAMSpec_To_v1alpha3_IAMSpec(in *kops.IAMSpec, out *IAMSpec
 	out.Legacy = in.Legacy
 	out.AllowContainerRegistry = in.AllowContainerRegistry
 	out.PermissionsBoundary = in.PermissionsBoundary
+	out.Path = in.Path
 	out.UseServiceAccountExternalPermissions = in.UseServiceAccountExternalPermissions
 	if in.ServiceAccountExternalPermissions != nil {
 		in, out := &in.ServiceAccountExternalPermissions, &out.ServiceAccountExternalPermissions
@@ -4357,6 +5682,7 @@ func autoConvert_kops_IAMSpec_To_v1alpha3_IAMSpec(in *kops.IAMSpec, out *IAMSpec
 	} else {
 		out.ServiceAccountExternalPermissions = nil
 	}
+	out.ScopeResourceARNs = in.ScopeResourceARNs
 	return nil
 }
 
@@ -4365,6 +5691,56 @@ func Convert_kops_IAMSpec_To_v1alpha3_IAMSpec(in *kops.IAMSpec, out *IAMSpec, s
 	return autoConvert_kops_IAMSpec_To_v1alpha3_IAMSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_ImagePolicyWebhookAdmissionConfig_To_kops_ImagePolicyWebhookAdmissionConfig(in *ImagePolicyWebhookAdmissionConfig, out *kops.ImagePolicyWebhookAdmissionConfig, s conversion.Scope) error {
+	out.KubeConfig = in.KubeConfig
+	out.AllowTTL = in.AllowTTL
+	out.DenyTTL = in.DenyTTL
+	out.RetryBackoff = in.RetryBackoff
+	out.DefaultAllow = in.DefaultAllow
+	return nil
+}
+
+// Convert_v1alpha3_ImagePolicyWebhookAdmissionConfig_To_kops_ImagePolicyWebhookAdmissionConfig is an autogenerated conversion function.
+func Convert_v1alpha3_ImagePolicyWebhookAdmissionConfig_To_kops_ImagePolicyWebhookAdmissionConfig(in *ImagePolicyWebhookAdmissionConfig, out *kops.ImagePolicyWebhookAdmissionConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ImagePolicyWebhookAdmissionConfig_To_kops_ImagePolicyWebhookAdmissionConfig(in, out, s)
+}
+
+func autoConvert_kops_ImagePolicyWebhookAdmissionConfig_To_v1alpha3_ImagePolicyWebhookAdmissionConfig(in *kops.ImagePolicyWebhookAdmissionConfig, out *ImagePolicyWebhookAdmissionConfig, s conversion.Scope) error {
+	out.KubeConfig = in.KubeConfig
+	out.AllowTTL = in.AllowTTL
+	out.DenyTTL = in.DenyTTL
+	out.RetryBackoff = in.RetryBackoff
+	out.DefaultAllow = in.DefaultAllow
+	return nil
+}
+
+// Convert_kops_ImagePolicyWebhookAdmissionConfig_To_v1alpha3_ImagePolicyWebhookAdmissionConfig is an autogenerated conversion function.
+func Convert_kops_ImagePolicyWebhookAdmissionConfig_To_v1alpha3_ImagePolicyWebhookAdmissionConfig(in *kops.ImagePolicyWebhookAdmissionConfig, out *ImagePolicyWebhookAdmissionConfig, s conversion.Scope) error {
+	return autoConvert_kops_ImagePolicyWebhookAdmissionConfig_To_v1alpha3_ImagePolicyWebhookAdmissionConfig(in, out, s)
+}
+
+func autoConvert_v1alpha3_ImageTrustPolicy_To_kops_ImageTrustPolicy(in *ImageTrustPolicy, out *kops.ImageTrustPolicy, s conversion.Scope) error {
+	out.Prefix = in.Prefix
+	out.PublicKeys = in.PublicKeys
+	return nil
+}
+
+// Convert_v1alpha3_ImageTrustPolicy_To_kops_ImageTrustPolicy is an autogenerated conversion function.
+func Convert_v1alpha3_ImageTrustPolicy_To_kops_ImageTrustPolicy(in *ImageTrustPolicy, out *kops.ImageTrustPolicy, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ImageTrustPolicy_To_kops_ImageTrustPolicy(in, out, s)
+}
+
+func autoConvert_kops_ImageTrustPolicy_To_v1alpha3_ImageTrustPolicy(in *kops.ImageTrustPolicy, out *ImageTrustPolicy, s conversion.Scope) error {
+	out.Prefix = in.Prefix
+	out.PublicKeys = in.PublicKeys
+	return nil
+}
+
+// Convert_kops_ImageTrustPolicy_To_v1alpha3_ImageTrustPolicy is an autogenerated conversion function.
+func Convert_kops_ImageTrustPolicy_To_v1alpha3_ImageTrustPolicy(in *kops.ImageTrustPolicy, out *ImageTrustPolicy, s conversion.Scope) error {
+	return autoConvert_kops_ImageTrustPolicy_To_v1alpha3_ImageTrustPolicy(in, out, s)
+}
+
 func autoConvert_v1alpha3_InstanceGroup_To_kops_InstanceGroup(in *InstanceGroup, out *kops.InstanceGroup, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha3_InstanceGroupSpec_To_kops_InstanceGroupSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -4433,6 +5809,28 @@ func Convert_kops_InstanceGroupList_To_v1alpha3_InstanceGroupList(in *kops.Insta
 	return autoConvert_kops_InstanceGroupList_To_v1alpha3_InstanceGroupList(in, out, s)
 }
 
+func autoConvert_v1alpha3_InstanceGroupMonitoringSpec_To_kops_InstanceGroupMonitoringSpec(in *InstanceGroupMonitoringSpec, out *kops.InstanceGroupMonitoringSpec, s conversion.Scope) error {
+	out.NodeExporter = in.NodeExporter
+	out.CloudWatchAgent = in.CloudWatchAgent
+	return nil
+}
+
+// Convert_v1alpha3_InstanceGroupMonitoringSpec_To_kops_InstanceGroupMonitoringSpec is an autogenerated conversion function.
+func Convert_v1alpha3_InstanceGroupMonitoringSpec_To_kops_InstanceGroupMonitoringSpec(in *InstanceGroupMonitoringSpec, out *kops.InstanceGroupMonitoringSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_InstanceGroupMonitoringSpec_To_kops_InstanceGroupMonitoringSpec(in, out, s)
+}
+
+func autoConvert_kops_InstanceGroupMonitoringSpec_To_v1alpha3_InstanceGroupMonitoringSpec(in *kops.InstanceGroupMonitoringSpec, out *InstanceGroupMonitoringSpec, s conversion.Scope) error {
+	out.NodeExporter = in.NodeExporter
+	out.CloudWatchAgent = in.CloudWatchAgent
+	return nil
+}
+
+// Convert_kops_InstanceGroupMonitoringSpec_To_v1alpha3_InstanceGroupMonitoringSpec is an autogenerated conversion function.
+func Convert_kops_InstanceGroupMonitoringSpec_To_v1alpha3_InstanceGroupMonitoringSpec(in *kops.InstanceGroupMonitoringSpec, out *InstanceGroupMonitoringSpec, s conversion.Scope) error {
+	return autoConvert_kops_InstanceGroupMonitoringSpec_To_v1alpha3_InstanceGroupMonitoringSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_InstanceGroupSpec_To_kops_InstanceGroupSpec(in *InstanceGroupSpec, out *kops.InstanceGroupSpec, s conversion.Scope) error {
 	out.Manager = kops.InstanceManager(in.Manager)
 	out.Role = kops.InstanceGroupRole(in.Role)
@@ -4502,6 +5900,8 @@ func autoConvert_v1alpha3_InstanceGroupSpec_To_kops_InstanceGroupSpec(in *Instan
 		out.FileAssets = nil
 	}
 	out.Tenancy = in.Tenancy
+	out.HostResourceGroupARN = in.HostResourceGroupARN
+	out.HostAffinity = in.HostAffinity
 	if in.Kubelet != nil {
 		in, out := &in.Kubelet, &out.Kubelet
 		*out = new(kops.KubeletConfigSpec)
@@ -4532,6 +5932,16 @@ func autoConvert_v1alpha3_InstanceGroupSpec_To_kops_InstanceGroupSpec(in *Instan
 	} else {
 		out.AdditionalUserData = nil
 	}
+	if in.CloudInit != nil {
+		in, out := &in.CloudInit, &out.CloudInit
+		*out = new(kops.CloudInitSpec)
+		if err := Convert_v1alpha3_CloudInitSpec_To_kops_CloudInitSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CloudInit = nil
+	}
+	out.Ignition = in.Ignition
 	out.SuspendProcesses = in.SuspendProcesses
 	if in.ExternalLoadBalancers != nil {
 		in, out := &in.ExternalLoadBalancers, &out.ExternalLoadBalancers
@@ -4608,6 +6018,55 @@ func autoConvert_v1alpha3_InstanceGroupSpec_To_kops_InstanceGroupSpec(in *Instan
 	} else {
 		out.GuestAccelerators = nil
 	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(kops.InstanceGroupMonitoringSpec)
+		if err := Convert_v1alpha3_InstanceGroupMonitoringSpec_To_kops_InstanceGroupMonitoringSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Monitoring = nil
+	}
+	if in.EFA != nil {
+		in, out := &in.EFA, &out.EFA
+		*out = new(kops.EFASpec)
+		if err := Convert_v1alpha3_EFASpec_To_kops_EFASpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.EFA = nil
+	}
+	out.NitroEnclave = in.NitroEnclave
+	if in.ElasticInferenceAccelerators != nil {
+		in, out := &in.ElasticInferenceAccelerators, &out.ElasticInferenceAccelerators
+		*out = make([]kops.AcceleratorConfig, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_AcceleratorConfig_To_kops_AcceleratorConfig(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.ElasticInferenceAccelerators = nil
+	}
+	if in.AssetCache != nil {
+		in, out := &in.AssetCache, &out.AssetCache
+		*out = new(kops.AssetCacheSpec)
+		if err := Convert_v1alpha3_AssetCacheSpec_To_kops_AssetCacheSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AssetCache = nil
+	}
+	out.PreloadImages = in.PreloadImages
+	if in.NodeUp != nil {
+		in, out := &in.NodeUp, &out.NodeUp
+		*out = new(kops.PackagesConfig)
+		if err := Convert_v1alpha3_PackagesConfig_To_kops_PackagesConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.NodeUp = nil
+	}
 	return nil
 }
 
@@ -4685,6 +6144,8 @@ func autoConvert_kops_InstanceGroupSpec_To_v1alpha3_InstanceGroupSpec(in *kops.I
 		out.FileAssets = nil
 	}
 	out.Tenancy = in.Tenancy
+	out.HostResourceGroupARN = in.HostResourceGroupARN
+	out.HostAffinity = in.HostAffinity
 	if in.Kubelet != nil {
 		in, out := &in.Kubelet, &out.Kubelet
 		*out = new(KubeletConfigSpec)
@@ -4715,6 +6176,16 @@ func autoConvert_kops_InstanceGroupSpec_To_v1alpha3_InstanceGroupSpec(in *kops.I
 	} else {
 		out.AdditionalUserData = nil
 	}
+	if in.CloudInit != nil {
+		in, out := &in.CloudInit, &out.CloudInit
+		*out = new(CloudInitSpec)
+		if err := Convert_kops_CloudInitSpec_To_v1alpha3_CloudInitSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CloudInit = nil
+	}
+	out.Ignition = in.Ignition
 	out.SuspendProcesses = in.SuspendProcesses
 	if in.ExternalLoadBalancers != nil {
 		in, out := &in.ExternalLoadBalancers, &out.ExternalLoadBalancers
@@ -4791,6 +6262,55 @@ func autoConvert_kops_InstanceGroupSpec_To_v1alpha3_InstanceGroupSpec(in *kops.I
 	} else {
 		out.GuestAccelerators = nil
 	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(InstanceGroupMonitoringSpec)
+		if err := Convert_kops_InstanceGroupMonitoringSpec_To_v1alpha3_InstanceGroupMonitoringSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Monitoring = nil
+	}
+	if in.EFA != nil {
+		in, out := &in.EFA, &out.EFA
+		*out = new(EFASpec)
+		if err := Convert_kops_EFASpec_To_v1alpha3_EFASpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.EFA = nil
+	}
+	out.NitroEnclave = in.NitroEnclave
+	if in.ElasticInferenceAccelerators != nil {
+		in, out := &in.ElasticInferenceAccelerators, &out.ElasticInferenceAccelerators
+		*out = make([]AcceleratorConfig, len(*in))
+		for i := range *in {
+			if err := Convert_kops_AcceleratorConfig_To_v1alpha3_AcceleratorConfig(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.ElasticInferenceAccelerators = nil
+	}
+	if in.AssetCache != nil {
+		in, out := &in.AssetCache, &out.AssetCache
+		*out = new(AssetCacheSpec)
+		if err := Convert_kops_AssetCacheSpec_To_v1alpha3_AssetCacheSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AssetCache = nil
+	}
+	out.PreloadImages = in.PreloadImages
+	if in.NodeUp != nil {
+		in, out := &in.NodeUp, &out.NodeUp
+		*out = new(PackagesConfig)
+		if err := Convert_kops_PackagesConfig_To_v1alpha3_PackagesConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.NodeUp = nil
+	}
 	return nil
 }
 
@@ -4930,6 +6450,7 @@ func autoConvert_v1alpha3_KeysetItem_To_kops_KeysetItem(in *KeysetItem, out *kop
 	if err := conversion.Convert_Slice_byte_To_Slice_byte(&in.PrivateMaterial, &out.PrivateMaterial, s); err != nil {
 		return err
 	}
+	out.ExternalCertificateID = in.ExternalCertificateID
 	return nil
 }
 
@@ -4947,6 +6468,7 @@ func autoConvert_kops_KeysetItem_To_v1alpha3_KeysetItem(in *kops.KeysetItem, out
 	if err := conversion.Convert_Slice_byte_To_Slice_byte(&in.PrivateMaterial, &out.PrivateMaterial, s); err != nil {
 		return err
 	}
+	out.ExternalCertificateID = in.ExternalCertificateID
 	return nil
 }
 
@@ -4997,6 +6519,30 @@ func Convert_kops_KeysetList_To_v1alpha3_KeysetList(in *kops.KeysetList, out *Ke
 	return autoConvert_kops_KeysetList_To_v1alpha3_KeysetList(in, out, s)
 }
 
+func autoConvert_v1alpha3_KeysetRotation_To_kops_KeysetRotation(in *KeysetRotation, out *kops.KeysetRotation, s conversion.Scope) error {
+	out.Phase = kops.KeysetRotationPhase(in.Phase)
+	out.NewID = in.NewID
+	out.PreviousID = in.PreviousID
+	return nil
+}
+
+// Convert_v1alpha3_KeysetRotation_To_kops_KeysetRotation is an autogenerated conversion function.
+func Convert_v1alpha3_KeysetRotation_To_kops_KeysetRotation(in *KeysetRotation, out *kops.KeysetRotation, s conversion.Scope) error {
+	return autoConvert_v1alpha3_KeysetRotation_To_kops_KeysetRotation(in, out, s)
+}
+
+func autoConvert_kops_KeysetRotation_To_v1alpha3_KeysetRotation(in *kops.KeysetRotation, out *KeysetRotation, s conversion.Scope) error {
+	out.Phase = KeysetRotationPhase(in.Phase)
+	out.NewID = in.NewID
+	out.PreviousID = in.PreviousID
+	return nil
+}
+
+// Convert_kops_KeysetRotation_To_v1alpha3_KeysetRotation is an autogenerated conversion function.
+func Convert_kops_KeysetRotation_To_v1alpha3_KeysetRotation(in *kops.KeysetRotation, out *KeysetRotation, s conversion.Scope) error {
+	return autoConvert_kops_KeysetRotation_To_v1alpha3_KeysetRotation(in, out, s)
+}
+
 func autoConvert_v1alpha3_KeysetSpec_To_kops_KeysetSpec(in *KeysetSpec, out *kops.KeysetSpec, s conversion.Scope) error {
 	out.Type = kops.KeysetType(in.Type)
 	out.PrimaryID = in.PrimaryID
@@ -5011,6 +6557,15 @@ func autoConvert_v1alpha3_KeysetSpec_To_kops_KeysetSpec(in *KeysetSpec, out *kop
 	} else {
 		out.Keys = nil
 	}
+	if in.Rotation != nil {
+		in, out := &in.Rotation, &out.Rotation
+		*out = new(kops.KeysetRotation)
+		if err := Convert_v1alpha3_KeysetRotation_To_kops_KeysetRotation(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Rotation = nil
+	}
 	return nil
 }
 
@@ -5033,6 +6588,15 @@ func autoConvert_kops_KeysetSpec_To_v1alpha3_KeysetSpec(in *kops.KeysetSpec, out
 	} else {
 		out.Keys = nil
 	}
+	if in.Rotation != nil {
+		in, out := &in.Rotation, &out.Rotation
+		*out = new(KeysetRotation)
+		if err := Convert_kops_KeysetRotation_To_v1alpha3_KeysetRotation(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Rotation = nil
+	}
 	return nil
 }
 
@@ -5096,6 +6660,15 @@ func autoConvert_v1alpha3_KubeAPIServerConfig_To_kops_KubeAPIServerConfig(in *Ku
 	out.EnableAdmissionPlugins = in.EnableAdmissionPlugins
 	out.DisableAdmissionPlugins = in.DisableAdmissionPlugins
 	out.AdmissionControlConfigFile = in.AdmissionControlConfigFile
+	if in.AdmissionPluginConfig != nil {
+		in, out := &in.AdmissionPluginConfig, &out.AdmissionPluginConfig
+		*out = new(kops.AdmissionPluginConfigSpec)
+		if err := Convert_v1alpha3_AdmissionPluginConfigSpec_To_kops_AdmissionPluginConfigSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AdmissionPluginConfig = nil
+	}
 	out.ServiceClusterIPRange = in.ServiceClusterIPRange
 	out.ServiceNodePortRange = in.ServiceNodePortRange
 	out.EtcdServers = in.EtcdServers
@@ -5161,6 +6734,8 @@ func autoConvert_v1alpha3_KubeAPIServerConfig_To_kops_KubeAPIServerConfig(in *Ku
 	out.FeatureGates = in.FeatureGates
 	out.MaxRequestsInflight = in.MaxRequestsInflight
 	out.MaxMutatingRequestsInflight = in.MaxMutatingRequestsInflight
+	out.EnablePriorityAndFairness = in.EnablePriorityAndFairness
+	out.FlowSchemaPresets = in.FlowSchemaPresets
 	out.HTTP2MaxStreamsPerConnection = in.HTTP2MaxStreamsPerConnection
 	out.EtcdQuorumRead = in.EtcdQuorumRead
 	out.RequestTimeout = in.RequestTimeout
@@ -5181,6 +6756,42 @@ func autoConvert_v1alpha3_KubeAPIServerConfig_To_kops_KubeAPIServerConfig(in *Ku
 	out.CorsAllowedOrigins = in.CorsAllowedOrigins
 	out.DefaultNotReadyTolerationSeconds = in.DefaultNotReadyTolerationSeconds
 	out.DefaultUnreachableTolerationSeconds = in.DefaultUnreachableTolerationSeconds
+	if in.HealthcheckSidecar != nil {
+		in, out := &in.HealthcheckSidecar, &out.HealthcheckSidecar
+		*out = new(kops.KubeAPIServerHealthcheckSidecarConfig)
+		if err := Convert_v1alpha3_KubeAPIServerHealthcheckSidecarConfig_To_kops_KubeAPIServerHealthcheckSidecarConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.HealthcheckSidecar = nil
+	}
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(kops.ProbeSpec)
+		if err := Convert_v1alpha3_ProbeSpec_To_kops_ProbeSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.LivenessProbe = nil
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(kops.ProbeSpec)
+		if err := Convert_v1alpha3_ProbeSpec_To_kops_ProbeSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ReadinessProbe = nil
+	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(kops.ProbeSpec)
+		if err := Convert_v1alpha3_ProbeSpec_To_kops_ProbeSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.StartupProbe = nil
+	}
 	return nil
 }
 
@@ -5208,6 +6819,15 @@ func autoConvert_kops_KubeAPIServerConfig_To_v1alpha3_KubeAPIServerConfig(in *ko
 	out.EnableAdmissionPlugins = in.EnableAdmissionPlugins
 	out.DisableAdmissionPlugins = in.DisableAdmissionPlugins
 	out.AdmissionControlConfigFile = in.AdmissionControlConfigFile
+	if in.AdmissionPluginConfig != nil {
+		in, out := &in.AdmissionPluginConfig, &out.AdmissionPluginConfig
+		*out = new(AdmissionPluginConfigSpec)
+		if err := Convert_kops_AdmissionPluginConfigSpec_To_v1alpha3_AdmissionPluginConfigSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AdmissionPluginConfig = nil
+	}
 	out.ServiceClusterIPRange = in.ServiceClusterIPRange
 	out.ServiceNodePortRange = in.ServiceNodePortRange
 	out.EtcdServers = in.EtcdServers
@@ -5273,6 +6893,8 @@ func autoConvert_kops_KubeAPIServerConfig_To_v1alpha3_KubeAPIServerConfig(in *ko
 	out.FeatureGates = in.FeatureGates
 	out.MaxRequestsInflight = in.MaxRequestsInflight
 	out.MaxMutatingRequestsInflight = in.MaxMutatingRequestsInflight
+	out.EnablePriorityAndFairness = in.EnablePriorityAndFairness
+	out.FlowSchemaPresets = in.FlowSchemaPresets
 	out.HTTP2MaxStreamsPerConnection = in.HTTP2MaxStreamsPerConnection
 	out.EtcdQuorumRead = in.EtcdQuorumRead
 	out.RequestTimeout = in.RequestTimeout
@@ -5293,6 +6915,42 @@ func autoConvert_kops_KubeAPIServerConfig_To_v1alpha3_KubeAPIServerConfig(in *ko
 	out.CorsAllowedOrigins = in.CorsAllowedOrigins
 	out.DefaultNotReadyTolerationSeconds = in.DefaultNotReadyTolerationSeconds
 	out.DefaultUnreachableTolerationSeconds = in.DefaultUnreachableTolerationSeconds
+	if in.HealthcheckSidecar != nil {
+		in, out := &in.HealthcheckSidecar, &out.HealthcheckSidecar
+		*out = new(KubeAPIServerHealthcheckSidecarConfig)
+		if err := Convert_kops_KubeAPIServerHealthcheckSidecarConfig_To_v1alpha3_KubeAPIServerHealthcheckSidecarConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.HealthcheckSidecar = nil
+	}
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(ProbeSpec)
+		if err := Convert_kops_ProbeSpec_To_v1alpha3_ProbeSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.LivenessProbe = nil
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(ProbeSpec)
+		if err := Convert_kops_ProbeSpec_To_v1alpha3_ProbeSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ReadinessProbe = nil
+	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(ProbeSpec)
+		if err := Convert_kops_ProbeSpec_To_v1alpha3_ProbeSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.StartupProbe = nil
+	}
 	return nil
 }
 
@@ -5301,6 +6959,30 @@ func Convert_kops_KubeAPIServerConfig_To_v1alpha3_KubeAPIServerConfig(in *kops.K
 	return autoConvert_kops_KubeAPIServerConfig_To_v1alpha3_KubeAPIServerConfig(in, out, s)
 }
 
+func autoConvert_v1alpha3_KubeAPIServerHealthcheckSidecarConfig_To_kops_KubeAPIServerHealthcheckSidecarConfig(in *KubeAPIServerHealthcheckSidecarConfig, out *kops.KubeAPIServerHealthcheckSidecarConfig, s conversion.Scope) error {
+	out.Port = in.Port
+	out.Path = in.Path
+	out.AdditionalChecks = in.AdditionalChecks
+	return nil
+}
+
+// Convert_v1alpha3_KubeAPIServerHealthcheckSidecarConfig_To_kops_KubeAPIServerHealthcheckSidecarConfig is an autogenerated conversion function.
+func Convert_v1alpha3_KubeAPIServerHealthcheckSidecarConfig_To_kops_KubeAPIServerHealthcheckSidecarConfig(in *KubeAPIServerHealthcheckSidecarConfig, out *kops.KubeAPIServerHealthcheckSidecarConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha3_KubeAPIServerHealthcheckSidecarConfig_To_kops_KubeAPIServerHealthcheckSidecarConfig(in, out, s)
+}
+
+func autoConvert_kops_KubeAPIServerHealthcheckSidecarConfig_To_v1alpha3_KubeAPIServerHealthcheckSidecarConfig(in *kops.KubeAPIServerHealthcheckSidecarConfig, out *KubeAPIServerHealthcheckSidecarConfig, s conversion.Scope) error {
+	out.Port = in.Port
+	out.Path = in.Path
+	out.AdditionalChecks = in.AdditionalChecks
+	return nil
+}
+
+// Convert_kops_KubeAPIServerHealthcheckSidecarConfig_To_v1alpha3_KubeAPIServerHealthcheckSidecarConfig is an autogenerated conversion function.
+func Convert_kops_KubeAPIServerHealthcheckSidecarConfig_To_v1alpha3_KubeAPIServerHealthcheckSidecarConfig(in *kops.KubeAPIServerHealthcheckSidecarConfig, out *KubeAPIServerHealthcheckSidecarConfig, s conversion.Scope) error {
+	return autoConvert_kops_KubeAPIServerHealthcheckSidecarConfig_To_v1alpha3_KubeAPIServerHealthcheckSidecarConfig(in, out, s)
+}
+
 func autoConvert_v1alpha3_KubeControllerManagerConfig_To_kops_KubeControllerManagerConfig(in *KubeControllerManagerConfig, out *kops.KubeControllerManagerConfig, s conversion.Scope) error {
 	out.Master = in.Master
 	out.LogFormat = in.LogFormat
@@ -5363,6 +7045,24 @@ func autoConvert_v1alpha3_KubeControllerManagerConfig_To_kops_KubeControllerMana
 	out.ExternalCloudVolumePlugin = in.ExternalCloudVolumePlugin
 	out.EnableProfiling = in.EnableProfiling
 	out.EnableLeaderMigration = in.EnableLeaderMigration
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(kops.ProbeSpec)
+		if err := Convert_v1alpha3_ProbeSpec_To_kops_ProbeSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.LivenessProbe = nil
+	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(kops.ProbeSpec)
+		if err := Convert_v1alpha3_ProbeSpec_To_kops_ProbeSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.StartupProbe = nil
+	}
 	return nil
 }
 
@@ -5433,6 +7133,24 @@ func autoConvert_kops_KubeControllerManagerConfig_To_v1alpha3_KubeControllerMana
 	out.ExternalCloudVolumePlugin = in.ExternalCloudVolumePlugin
 	out.EnableProfiling = in.EnableProfiling
 	out.EnableLeaderMigration = in.EnableLeaderMigration
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(ProbeSpec)
+		if err := Convert_kops_ProbeSpec_To_v1alpha3_ProbeSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.LivenessProbe = nil
+	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(ProbeSpec)
+		if err := Convert_kops_ProbeSpec_To_v1alpha3_ProbeSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.StartupProbe = nil
+	}
 	return nil
 }
 
@@ -5590,6 +7308,24 @@ func autoConvert_v1alpha3_KubeSchedulerConfig_To_kops_KubeSchedulerConfig(in *Ku
 	out.EnableProfiling = in.EnableProfiling
 	out.TLSCertFile = in.TLSCertFile
 	out.TLSPrivateKeyFile = in.TLSPrivateKeyFile
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(kops.ProbeSpec)
+		if err := Convert_v1alpha3_ProbeSpec_To_kops_ProbeSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.LivenessProbe = nil
+	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(kops.ProbeSpec)
+		if err := Convert_v1alpha3_ProbeSpec_To_kops_ProbeSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.StartupProbe = nil
+	}
 	return nil
 }
 
@@ -5623,6 +7359,24 @@ func autoConvert_kops_KubeSchedulerConfig_To_v1alpha3_KubeSchedulerConfig(in *ko
 	out.EnableProfiling = in.EnableProfiling
 	out.TLSCertFile = in.TLSCertFile
 	out.TLSPrivateKeyFile = in.TLSPrivateKeyFile
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(ProbeSpec)
+		if err := Convert_kops_ProbeSpec_To_v1alpha3_ProbeSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.LivenessProbe = nil
+	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(ProbeSpec)
+		if err := Convert_kops_ProbeSpec_To_v1alpha3_ProbeSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.StartupProbe = nil
+	}
 	return nil
 }
 
@@ -5722,6 +7476,7 @@ func autoConvert_v1alpha3_KubeletConfigSpec_To_kops_KubeletConfigSpec(in *Kubele
 	out.PodPidsLimit = in.PodPidsLimit
 	out.ShutdownGracePeriod = in.ShutdownGracePeriod
 	out.ShutdownGracePeriodCriticalPods = in.ShutdownGracePeriodCriticalPods
+	out.ImageCredentialProviders = in.ImageCredentialProviders
 	return nil
 }
 
@@ -5821,6 +7576,7 @@ func autoConvert_kops_KubeletConfigSpec_To_v1alpha3_KubeletConfigSpec(in *kops.K
 	out.PodPidsLimit = in.PodPidsLimit
 	out.ShutdownGracePeriod = in.ShutdownGracePeriod
 	out.ShutdownGracePeriodCriticalPods = in.ShutdownGracePeriodCriticalPods
+	out.ImageCredentialProviders = in.ImageCredentialProviders
 	return nil
 }
 
@@ -5949,6 +7705,17 @@ func autoConvert_v1alpha3_LoadBalancerAccessSpec_To_kops_LoadBalancerAccessSpec(
 	} else {
 		out.AccessLog = nil
 	}
+	out.ConnectionDrainingTimeoutSeconds = in.ConnectionDrainingTimeoutSeconds
+	out.DeregistrationDelaySeconds = in.DeregistrationDelaySeconds
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(kops.LoadBalancerHealthCheckSpec)
+		if err := Convert_v1alpha3_LoadBalancerHealthCheckSpec_To_kops_LoadBalancerHealthCheckSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.HealthCheck = nil
+	}
 	return nil
 }
 
@@ -5987,6 +7754,17 @@ func autoConvert_kops_LoadBalancerAccessSpec_To_v1alpha3_LoadBalancerAccessSpec(
 	} else {
 		out.AccessLog = nil
 	}
+	out.ConnectionDrainingTimeoutSeconds = in.ConnectionDrainingTimeoutSeconds
+	out.DeregistrationDelaySeconds = in.DeregistrationDelaySeconds
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(LoadBalancerHealthCheckSpec)
+		if err := Convert_kops_LoadBalancerHealthCheckSpec_To_v1alpha3_LoadBalancerHealthCheckSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.HealthCheck = nil
+	}
 	return nil
 }
 
@@ -5995,6 +7773,30 @@ func Convert_kops_LoadBalancerAccessSpec_To_v1alpha3_LoadBalancerAccessSpec(in *
 	return autoConvert_kops_LoadBalancerAccessSpec_To_v1alpha3_LoadBalancerAccessSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_LoadBalancerHealthCheckSpec_To_kops_LoadBalancerHealthCheckSpec(in *LoadBalancerHealthCheckSpec, out *kops.LoadBalancerHealthCheckSpec, s conversion.Scope) error {
+	out.Protocol = in.Protocol
+	out.Port = in.Port
+	out.Path = in.Path
+	return nil
+}
+
+// Convert_v1alpha3_LoadBalancerHealthCheckSpec_To_kops_LoadBalancerHealthCheckSpec is an autogenerated conversion function.
+func Convert_v1alpha3_LoadBalancerHealthCheckSpec_To_kops_LoadBalancerHealthCheckSpec(in *LoadBalancerHealthCheckSpec, out *kops.LoadBalancerHealthCheckSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_LoadBalancerHealthCheckSpec_To_kops_LoadBalancerHealthCheckSpec(in, out, s)
+}
+
+func autoConvert_kops_LoadBalancerHealthCheckSpec_To_v1alpha3_LoadBalancerHealthCheckSpec(in *kops.LoadBalancerHealthCheckSpec, out *LoadBalancerHealthCheckSpec, s conversion.Scope) error {
+	out.Protocol = in.Protocol
+	out.Port = in.Port
+	out.Path = in.Path
+	return nil
+}
+
+// Convert_kops_LoadBalancerHealthCheckSpec_To_v1alpha3_LoadBalancerHealthCheckSpec is an autogenerated conversion function.
+func Convert_kops_LoadBalancerHealthCheckSpec_To_v1alpha3_LoadBalancerHealthCheckSpec(in *kops.LoadBalancerHealthCheckSpec, out *LoadBalancerHealthCheckSpec, s conversion.Scope) error {
+	return autoConvert_kops_LoadBalancerHealthCheckSpec_To_v1alpha3_LoadBalancerHealthCheckSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_LoadBalancerSubnetSpec_To_kops_LoadBalancerSubnetSpec(in *LoadBalancerSubnetSpec, out *kops.LoadBalancerSubnetSpec, s conversion.Scope) error {
 	out.Name = in.Name
 	out.PrivateIPv4Address = in.PrivateIPv4Address
@@ -6081,6 +7883,15 @@ func autoConvert_v1alpha3_MixedInstancesPolicySpec_To_kops_MixedInstancesPolicyS
 	out.OnDemandAboveBase = in.OnDemandAboveBase
 	out.SpotAllocationStrategy = in.SpotAllocationStrategy
 	out.SpotInstancePools = in.SpotInstancePools
+	if in.SpotFallback != nil {
+		in, out := &in.SpotFallback, &out.SpotFallback
+		*out = new(kops.SpotFallbackSpec)
+		if err := Convert_v1alpha3_SpotFallbackSpec_To_kops_SpotFallbackSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SpotFallback = nil
+	}
 	return nil
 }
 
@@ -6105,6 +7916,15 @@ func autoConvert_kops_MixedInstancesPolicySpec_To_v1alpha3_MixedInstancesPolicyS
 	out.OnDemandAboveBase = in.OnDemandAboveBase
 	out.SpotAllocationStrategy = in.SpotAllocationStrategy
 	out.SpotInstancePools = in.SpotInstancePools
+	if in.SpotFallback != nil {
+		in, out := &in.SpotFallback, &out.SpotFallback
+		*out = new(SpotFallbackSpec)
+		if err := Convert_kops_SpotFallbackSpec_To_v1alpha3_SpotFallbackSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.SpotFallback = nil
+	}
 	return nil
 }
 
@@ -6113,6 +7933,92 @@ func Convert_kops_MixedInstancesPolicySpec_To_v1alpha3_MixedInstancesPolicySpec(
 	return autoConvert_kops_MixedInstancesPolicySpec_To_v1alpha3_MixedInstancesPolicySpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_MonitoringAgentConfig_To_kops_MonitoringAgentConfig(in *MonitoringAgentConfig, out *kops.MonitoringAgentConfig, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.Image = in.Image
+	out.ResourcePreset = in.ResourcePreset
+	out.MemoryRequest = in.MemoryRequest
+	out.CPURequest = in.CPURequest
+	out.MemoryLimit = in.MemoryLimit
+	out.CPULimit = in.CPULimit
+	return nil
+}
+
+// Convert_v1alpha3_MonitoringAgentConfig_To_kops_MonitoringAgentConfig is an autogenerated conversion function.
+func Convert_v1alpha3_MonitoringAgentConfig_To_kops_MonitoringAgentConfig(in *MonitoringAgentConfig, out *kops.MonitoringAgentConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha3_MonitoringAgentConfig_To_kops_MonitoringAgentConfig(in, out, s)
+}
+
+func autoConvert_kops_MonitoringAgentConfig_To_v1alpha3_MonitoringAgentConfig(in *kops.MonitoringAgentConfig, out *MonitoringAgentConfig, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.Image = in.Image
+	out.ResourcePreset = in.ResourcePreset
+	out.MemoryRequest = in.MemoryRequest
+	out.CPURequest = in.CPURequest
+	out.MemoryLimit = in.MemoryLimit
+	out.CPULimit = in.CPULimit
+	return nil
+}
+
+// Convert_kops_MonitoringAgentConfig_To_v1alpha3_MonitoringAgentConfig is an autogenerated conversion function.
+func Convert_kops_MonitoringAgentConfig_To_v1alpha3_MonitoringAgentConfig(in *kops.MonitoringAgentConfig, out *MonitoringAgentConfig, s conversion.Scope) error {
+	return autoConvert_kops_MonitoringAgentConfig_To_v1alpha3_MonitoringAgentConfig(in, out, s)
+}
+
+func autoConvert_v1alpha3_MonitoringSpec_To_kops_MonitoringSpec(in *MonitoringSpec, out *kops.MonitoringSpec, s conversion.Scope) error {
+	if in.NodeExporter != nil {
+		in, out := &in.NodeExporter, &out.NodeExporter
+		*out = new(kops.MonitoringAgentConfig)
+		if err := Convert_v1alpha3_MonitoringAgentConfig_To_kops_MonitoringAgentConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.NodeExporter = nil
+	}
+	if in.CloudWatchAgent != nil {
+		in, out := &in.CloudWatchAgent, &out.CloudWatchAgent
+		*out = new(kops.MonitoringAgentConfig)
+		if err := Convert_v1alpha3_MonitoringAgentConfig_To_kops_MonitoringAgentConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CloudWatchAgent = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha3_MonitoringSpec_To_kops_MonitoringSpec is an autogenerated conversion function.
+func Convert_v1alpha3_MonitoringSpec_To_kops_MonitoringSpec(in *MonitoringSpec, out *kops.MonitoringSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_MonitoringSpec_To_kops_MonitoringSpec(in, out, s)
+}
+
+func autoConvert_kops_MonitoringSpec_To_v1alpha3_MonitoringSpec(in *kops.MonitoringSpec, out *MonitoringSpec, s conversion.Scope) error {
+	if in.NodeExporter != nil {
+		in, out := &in.NodeExporter, &out.NodeExporter
+		*out = new(MonitoringAgentConfig)
+		if err := Convert_kops_MonitoringAgentConfig_To_v1alpha3_MonitoringAgentConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.NodeExporter = nil
+	}
+	if in.CloudWatchAgent != nil {
+		in, out := &in.CloudWatchAgent, &out.CloudWatchAgent
+		*out = new(MonitoringAgentConfig)
+		if err := Convert_kops_MonitoringAgentConfig_To_v1alpha3_MonitoringAgentConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CloudWatchAgent = nil
+	}
+	return nil
+}
+
+// Convert_kops_MonitoringSpec_To_v1alpha3_MonitoringSpec is an autogenerated conversion function.
+func Convert_kops_MonitoringSpec_To_v1alpha3_MonitoringSpec(in *kops.MonitoringSpec, out *MonitoringSpec, s conversion.Scope) error {
+	return autoConvert_kops_MonitoringSpec_To_v1alpha3_MonitoringSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_NTPConfig_To_kops_NTPConfig(in *NTPConfig, out *kops.NTPConfig, s conversion.Scope) error {
 	out.Managed = in.Managed
 	return nil
@@ -6373,6 +8279,50 @@ func Convert_kops_NetworkingSpec_To_v1alpha3_NetworkingSpec(in *kops.NetworkingS
 	return autoConvert_kops_NetworkingSpec_To_v1alpha3_NetworkingSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_NodeCertificatesSpec_To_kops_NodeCertificatesSpec(in *NodeCertificatesSpec, out *kops.NodeCertificatesSpec, s conversion.Scope) error {
+	out.Validity = in.Validity
+	out.RenewBefore = in.RenewBefore
+	return nil
+}
+
+// Convert_v1alpha3_NodeCertificatesSpec_To_kops_NodeCertificatesSpec is an autogenerated conversion function.
+func Convert_v1alpha3_NodeCertificatesSpec_To_kops_NodeCertificatesSpec(in *NodeCertificatesSpec, out *kops.NodeCertificatesSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_NodeCertificatesSpec_To_kops_NodeCertificatesSpec(in, out, s)
+}
+
+func autoConvert_kops_NodeCertificatesSpec_To_v1alpha3_NodeCertificatesSpec(in *kops.NodeCertificatesSpec, out *NodeCertificatesSpec, s conversion.Scope) error {
+	out.Validity = in.Validity
+	out.RenewBefore = in.RenewBefore
+	return nil
+}
+
+// Convert_kops_NodeCertificatesSpec_To_v1alpha3_NodeCertificatesSpec is an autogenerated conversion function.
+func Convert_kops_NodeCertificatesSpec_To_v1alpha3_NodeCertificatesSpec(in *kops.NodeCertificatesSpec, out *NodeCertificatesSpec, s conversion.Scope) error {
+	return autoConvert_kops_NodeCertificatesSpec_To_v1alpha3_NodeCertificatesSpec(in, out, s)
+}
+
+func autoConvert_v1alpha3_NodeLabelsFromCloudTagsSpec_To_kops_NodeLabelsFromCloudTagsSpec(in *NodeLabelsFromCloudTagsSpec, out *kops.NodeLabelsFromCloudTagsSpec, s conversion.Scope) error {
+	out.Tags = in.Tags
+	out.Prefix = in.Prefix
+	return nil
+}
+
+// Convert_v1alpha3_NodeLabelsFromCloudTagsSpec_To_kops_NodeLabelsFromCloudTagsSpec is an autogenerated conversion function.
+func Convert_v1alpha3_NodeLabelsFromCloudTagsSpec_To_kops_NodeLabelsFromCloudTagsSpec(in *NodeLabelsFromCloudTagsSpec, out *kops.NodeLabelsFromCloudTagsSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_NodeLabelsFromCloudTagsSpec_To_kops_NodeLabelsFromCloudTagsSpec(in, out, s)
+}
+
+func autoConvert_kops_NodeLabelsFromCloudTagsSpec_To_v1alpha3_NodeLabelsFromCloudTagsSpec(in *kops.NodeLabelsFromCloudTagsSpec, out *NodeLabelsFromCloudTagsSpec, s conversion.Scope) error {
+	out.Tags = in.Tags
+	out.Prefix = in.Prefix
+	return nil
+}
+
+// Convert_kops_NodeLabelsFromCloudTagsSpec_To_v1alpha3_NodeLabelsFromCloudTagsSpec is an autogenerated conversion function.
+func Convert_kops_NodeLabelsFromCloudTagsSpec_To_v1alpha3_NodeLabelsFromCloudTagsSpec(in *kops.NodeLabelsFromCloudTagsSpec, out *NodeLabelsFromCloudTagsSpec, s conversion.Scope) error {
+	return autoConvert_kops_NodeLabelsFromCloudTagsSpec_To_v1alpha3_NodeLabelsFromCloudTagsSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_NodeLocalDNSConfig_To_kops_NodeLocalDNSConfig(in *NodeLocalDNSConfig, out *kops.NodeLocalDNSConfig, s conversion.Scope) error {
 	out.Enabled = in.Enabled
 	out.Image = in.Image
@@ -6831,6 +8781,32 @@ func Convert_kops_PodIdentityWebhookConfig_To_v1alpha3_PodIdentityWebhookConfig(
 	return autoConvert_kops_PodIdentityWebhookConfig_To_v1alpha3_PodIdentityWebhookConfig(in, out, s)
 }
 
+func autoConvert_v1alpha3_ProbeSpec_To_kops_ProbeSpec(in *ProbeSpec, out *kops.ProbeSpec, s conversion.Scope) error {
+	out.InitialDelaySeconds = in.InitialDelaySeconds
+	out.TimeoutSeconds = in.TimeoutSeconds
+	out.PeriodSeconds = in.PeriodSeconds
+	out.FailureThreshold = in.FailureThreshold
+	return nil
+}
+
+// Convert_v1alpha3_ProbeSpec_To_kops_ProbeSpec is an autogenerated conversion function.
+func Convert_v1alpha3_ProbeSpec_To_kops_ProbeSpec(in *ProbeSpec, out *kops.ProbeSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ProbeSpec_To_kops_ProbeSpec(in, out, s)
+}
+
+func autoConvert_kops_ProbeSpec_To_v1alpha3_ProbeSpec(in *kops.ProbeSpec, out *ProbeSpec, s conversion.Scope) error {
+	out.InitialDelaySeconds = in.InitialDelaySeconds
+	out.TimeoutSeconds = in.TimeoutSeconds
+	out.PeriodSeconds = in.PeriodSeconds
+	out.FailureThreshold = in.FailureThreshold
+	return nil
+}
+
+// Convert_kops_ProbeSpec_To_v1alpha3_ProbeSpec is an autogenerated conversion function.
+func Convert_kops_ProbeSpec_To_v1alpha3_ProbeSpec(in *kops.ProbeSpec, out *ProbeSpec, s conversion.Scope) error {
+	return autoConvert_kops_ProbeSpec_To_v1alpha3_ProbeSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_RBACAuthorizationSpec_To_kops_RBACAuthorizationSpec(in *RBACAuthorizationSpec, out *kops.RBACAuthorizationSpec, s conversion.Scope) error {
 	return nil
 }
@@ -6853,6 +8829,8 @@ func autoConvert_v1alpha3_RollingUpdate_To_kops_RollingUpdate(in *RollingUpdate,
 	out.DrainAndTerminate = in.DrainAndTerminate
 	out.MaxUnavailable = in.MaxUnavailable
 	out.MaxSurge = in.MaxSurge
+	out.DrainTimeout = in.DrainTimeout
+	out.PDBViolationPolicy = kops.PDBViolationPolicy(in.PDBViolationPolicy)
 	return nil
 }
 
@@ -6865,6 +8843,8 @@ func autoConvert_kops_RollingUpdate_To_v1alpha3_RollingUpdate(in *kops.RollingUp
 	out.DrainAndTerminate = in.DrainAndTerminate
 	out.MaxUnavailable = in.MaxUnavailable
 	out.MaxSurge = in.MaxSurge
+	out.DrainTimeout = in.DrainTimeout
+	out.PDBViolationPolicy = PDBViolationPolicy(in.PDBViolationPolicy)
 	return nil
 }
 
@@ -6895,6 +8875,44 @@ func Convert_kops_RouteSpec_To_v1alpha3_RouteSpec(in *kops.RouteSpec, out *Route
 	return autoConvert_kops_RouteSpec_To_v1alpha3_RouteSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_Runc_To_kops_Runc(in *Runc, out *kops.Runc, s conversion.Scope) error {
+	out.Version = in.Version
+	if in.Packages != nil {
+		in, out := &in.Packages, &out.Packages
+		*out = new(kops.PackagesConfig)
+		if err := Convert_v1alpha3_PackagesConfig_To_kops_PackagesConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Packages = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha3_Runc_To_kops_Runc is an autogenerated conversion function.
+func Convert_v1alpha3_Runc_To_kops_Runc(in *Runc, out *kops.Runc, s conversion.Scope) error {
+	return autoConvert_v1alpha3_Runc_To_kops_Runc(in, out, s)
+}
+
+func autoConvert_kops_Runc_To_v1alpha3_Runc(in *kops.Runc, out *Runc, s conversion.Scope) error {
+	out.Version = in.Version
+	if in.Packages != nil {
+		in, out := &in.Packages, &out.Packages
+		*out = new(PackagesConfig)
+		if err := Convert_kops_PackagesConfig_To_v1alpha3_PackagesConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Packages = nil
+	}
+	return nil
+}
+
+// Convert_kops_Runc_To_v1alpha3_Runc is an autogenerated conversion function.
+func Convert_kops_Runc_To_v1alpha3_Runc(in *kops.Runc, out *Runc, s conversion.Scope) error {
+	return autoConvert_kops_Runc_To_v1alpha3_Runc(in, out, s)
+}
+
 func autoConvert_v1alpha3_SSHCredential_To_kops_SSHCredential(in *SSHCredential, out *kops.SSHCredential, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha3_SSHCredentialSpec_To_kops_SSHCredentialSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -6995,6 +9013,15 @@ func autoConvert_v1alpha3_ServiceAccountExternalPermission_To_kops_ServiceAccoun
 	} else {
 		out.AWS = nil
 	}
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(kops.GCPPermission)
+		if err := Convert_v1alpha3_GCPPermission_To_kops_GCPPermission(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.GCP = nil
+	}
 	return nil
 }
 
@@ -7015,6 +9042,15 @@ func autoConvert_kops_ServiceAccountExternalPermission_To_v1alpha3_ServiceAccoun
 	} else {
 		out.AWS = nil
 	}
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(GCPPermission)
+		if err := Convert_kops_GCPPermission_To_v1alpha3_GCPPermission(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.GCP = nil
+	}
 	return nil
 }
 
@@ -7027,6 +9063,7 @@ func autoConvert_v1alpha3_ServiceAccountIssuerDiscoveryConfig_To_kops_ServiceAcc
 	out.DiscoveryStore = in.DiscoveryStore
 	out.EnableAWSOIDCProvider = in.EnableAWSOIDCProvider
 	out.AdditionalAudiences = in.AdditionalAudiences
+	out.GCPWorkloadIdentityProvider = in.GCPWorkloadIdentityProvider
 	return nil
 }
 
@@ -7039,6 +9076,7 @@ func autoConvert_kops_ServiceAccountIssuerDiscoveryConfig_To_v1alpha3_ServiceAcc
 	out.DiscoveryStore = in.DiscoveryStore
 	out.EnableAWSOIDCProvider = in.EnableAWSOIDCProvider
 	out.AdditionalAudiences = in.AdditionalAudiences
+	out.GCPWorkloadIdentityProvider = in.GCPWorkloadIdentityProvider
 	return nil
 }
 
@@ -7069,6 +9107,32 @@ func Convert_kops_SnapshotControllerConfig_To_v1alpha3_SnapshotControllerConfig(
 	return autoConvert_kops_SnapshotControllerConfig_To_v1alpha3_SnapshotControllerConfig(in, out, s)
 }
 
+func autoConvert_v1alpha3_SpotFallbackSpec_To_kops_SpotFallbackSpec(in *SpotFallbackSpec, out *kops.SpotFallbackSpec, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.FailureThreshold = in.FailureThreshold
+	out.FailureWindow = in.FailureWindow
+	out.RevertAfter = in.RevertAfter
+	return nil
+}
+
+// Convert_v1alpha3_SpotFallbackSpec_To_kops_SpotFallbackSpec is an autogenerated conversion function.
+func Convert_v1alpha3_SpotFallbackSpec_To_kops_SpotFallbackSpec(in *SpotFallbackSpec, out *kops.SpotFallbackSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_SpotFallbackSpec_To_kops_SpotFallbackSpec(in, out, s)
+}
+
+func autoConvert_kops_SpotFallbackSpec_To_v1alpha3_SpotFallbackSpec(in *kops.SpotFallbackSpec, out *SpotFallbackSpec, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.FailureThreshold = in.FailureThreshold
+	out.FailureWindow = in.FailureWindow
+	out.RevertAfter = in.RevertAfter
+	return nil
+}
+
+// Convert_kops_SpotFallbackSpec_To_v1alpha3_SpotFallbackSpec is an autogenerated conversion function.
+func Convert_kops_SpotFallbackSpec_To_v1alpha3_SpotFallbackSpec(in *kops.SpotFallbackSpec, out *SpotFallbackSpec, s conversion.Scope) error {
+	return autoConvert_kops_SpotFallbackSpec_To_v1alpha3_SpotFallbackSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_TargetSpec_To_kops_TargetSpec(in *TargetSpec, out *kops.TargetSpec, s conversion.Scope) error {
 	if in.Terraform != nil {
 		in, out := &in.Terraform, &out.Terraform
@@ -7108,6 +9172,7 @@ func Convert_kops_TargetSpec_To_v1alpha3_TargetSpec(in *kops.TargetSpec, out *Ta
 func autoConvert_v1alpha3_TerraformSpec_To_kops_TerraformSpec(in *TerraformSpec, out *kops.TerraformSpec, s conversion.Scope) error {
 	out.ProviderExtraConfig = in.ProviderExtraConfig
 	out.FilesProviderExtraConfig = in.FilesProviderExtraConfig
+	out.SharedResourcesAsDataSources = in.SharedResourcesAsDataSources
 	return nil
 }
 
@@ -7119,6 +9184,7 @@ func Convert_v1alpha3_TerraformSpec_To_kops_TerraformSpec(in *TerraformSpec, out
 func autoConvert_kops_TerraformSpec_To_v1alpha3_TerraformSpec(in *kops.TerraformSpec, out *TerraformSpec, s conversion.Scope) error {
 	out.ProviderExtraConfig = in.ProviderExtraConfig
 	out.FilesProviderExtraConfig = in.FilesProviderExtraConfig
+	out.SharedResourcesAsDataSources = in.SharedResourcesAsDataSources
 	return nil
 }
 
@@ -7209,6 +9275,86 @@ func Convert_kops_UserData_To_v1alpha3_UserData(in *kops.UserData, out *UserData
 	return autoConvert_kops_UserData_To_v1alpha3_UserData(in, out, s)
 }
 
+func autoConvert_v1alpha3_ValidationCheck_To_kops_ValidationCheck(in *ValidationCheck, out *kops.ValidationCheck, s conversion.Scope) error {
+	out.Name = in.Name
+	if in.DaemonSet != nil {
+		in, out := &in.DaemonSet, &out.DaemonSet
+		*out = new(kops.ValidationCheckObject)
+		if err := Convert_v1alpha3_ValidationCheckObject_To_kops_ValidationCheckObject(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.DaemonSet = nil
+	}
+	if in.Deployment != nil {
+		in, out := &in.Deployment, &out.Deployment
+		*out = new(kops.ValidationCheckObject)
+		if err := Convert_v1alpha3_ValidationCheckObject_To_kops_ValidationCheckObject(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Deployment = nil
+	}
+	out.MinNodesPerZone = in.MinNodesPerZone
+	return nil
+}
+
+// Convert_v1alpha3_ValidationCheck_To_kops_ValidationCheck is an autogenerated conversion function.
+func Convert_v1alpha3_ValidationCheck_To_kops_ValidationCheck(in *ValidationCheck, out *kops.ValidationCheck, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ValidationCheck_To_kops_ValidationCheck(in, out, s)
+}
+
+func autoConvert_kops_ValidationCheck_To_v1alpha3_ValidationCheck(in *kops.ValidationCheck, out *ValidationCheck, s conversion.Scope) error {
+	out.Name = in.Name
+	if in.DaemonSet != nil {
+		in, out := &in.DaemonSet, &out.DaemonSet
+		*out = new(ValidationCheckObject)
+		if err := Convert_kops_ValidationCheckObject_To_v1alpha3_ValidationCheckObject(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.DaemonSet = nil
+	}
+	if in.Deployment != nil {
+		in, out := &in.Deployment, &out.Deployment
+		*out = new(ValidationCheckObject)
+		if err := Convert_kops_ValidationCheckObject_To_v1alpha3_ValidationCheckObject(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Deployment = nil
+	}
+	out.MinNodesPerZone = in.MinNodesPerZone
+	return nil
+}
+
+// Convert_kops_ValidationCheck_To_v1alpha3_ValidationCheck is an autogenerated conversion function.
+func Convert_kops_ValidationCheck_To_v1alpha3_ValidationCheck(in *kops.ValidationCheck, out *ValidationCheck, s conversion.Scope) error {
+	return autoConvert_kops_ValidationCheck_To_v1alpha3_ValidationCheck(in, out, s)
+}
+
+func autoConvert_v1alpha3_ValidationCheckObject_To_kops_ValidationCheckObject(in *ValidationCheckObject, out *kops.ValidationCheckObject, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1alpha3_ValidationCheckObject_To_kops_ValidationCheckObject is an autogenerated conversion function.
+func Convert_v1alpha3_ValidationCheckObject_To_kops_ValidationCheckObject(in *ValidationCheckObject, out *kops.ValidationCheckObject, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ValidationCheckObject_To_kops_ValidationCheckObject(in, out, s)
+}
+
+func autoConvert_kops_ValidationCheckObject_To_v1alpha3_ValidationCheckObject(in *kops.ValidationCheckObject, out *ValidationCheckObject, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_kops_ValidationCheckObject_To_v1alpha3_ValidationCheckObject is an autogenerated conversion function.
+func Convert_kops_ValidationCheckObject_To_v1alpha3_ValidationCheckObject(in *kops.ValidationCheckObject, out *ValidationCheckObject, s conversion.Scope) error {
+	return autoConvert_kops_ValidationCheckObject_To_v1alpha3_ValidationCheckObject(in, out, s)
+}
+
 func autoConvert_v1alpha3_VolumeMountSpec_To_kops_VolumeMountSpec(in *VolumeMountSpec, out *kops.VolumeMountSpec, s conversion.Scope) error {
 	out.Device = in.Device
 	out.Filesystem = in.Filesystem
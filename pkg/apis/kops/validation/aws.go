@@ -39,6 +39,10 @@ func awsValidateCluster(c *kops.Cluster) field.ErrorList {
 			allErrs = append(allErrs, awsValidateAdditionalSecurityGroups(field.NewPath("spec", "api", "loadBalancer", "additionalSecurityGroups"), c.Spec.API.LoadBalancer.AdditionalSecurityGroups)...)
 			allErrs = append(allErrs, awsValidateSSLPolicy(field.NewPath("spec", "api", "loadBalancer", "sslPolicy"), c.Spec.API.LoadBalancer)...)
 			allErrs = append(allErrs, awsValidateLoadBalancerSubnets(field.NewPath("spec", "api", "loadBalancer", "subnets"), c.Spec)...)
+			allErrs = append(allErrs, awsValidateLoadBalancerDraining(field.NewPath("spec", "api", "loadBalancer"), c.Spec.API.LoadBalancer)...)
+			if c.Spec.API.LoadBalancer.HealthCheck != nil {
+				allErrs = append(allErrs, awsValidateLoadBalancerHealthCheck(field.NewPath("spec", "api", "loadBalancer", "healthCheck"), c.Spec.API.LoadBalancer)...)
+			}
 		}
 	}
 
@@ -48,6 +52,10 @@ func awsValidateCluster(c *kops.Cluster) field.ErrorList {
 		allErrs = append(allErrs, awsValidateIAMAuthenticator(field.NewPath("spec", "authentication", "aws"), c.Spec.Authentication.AWS)...)
 	}
 
+	if c.Spec.IAM != nil && c.Spec.IAM.Path != "" {
+		allErrs = append(allErrs, awsValidateIAMPath(field.NewPath("spec", "iam", "path"), c.Spec.IAM.Path)...)
+	}
+
 	for i, subnet := range c.Spec.Subnets {
 		f := field.NewPath("spec", "subnets").Index(i)
 		if subnet.AdditionalRoutes != nil {
@@ -100,6 +108,110 @@ func awsValidateInstanceGroup(ig *kops.InstanceGroup, cloud awsup.AWSCloud) fiel
 		allErrs = append(allErrs, awsValidateCPUCredits(field.NewPath("spec"), &ig.Spec, cloud)...)
 	}
 
+	if ig.Spec.EFA != nil {
+		allErrs = append(allErrs, awsValidateEFA(field.NewPath("spec", "efa"), ig, cloud)...)
+	}
+
+	if len(ig.Spec.ElasticInferenceAccelerators) > 0 {
+		allErrs = append(allErrs, awsValidateElasticInferenceAccelerators(field.NewPath("spec", "elasticInferenceAccelerators"), ig.Spec.ElasticInferenceAccelerators)...)
+	}
+
+	for i := range ig.Spec.Volumes {
+		allErrs = append(allErrs, awsValidateVolume(field.NewPath("spec", "volumes").Index(i), &ig.Spec.Volumes[i])...)
+	}
+
+	if fi.BoolValue(ig.Spec.NitroEnclave) && fi.StringValue(ig.Spec.InstanceInterruptionBehavior) == ec2.InstanceInterruptionBehaviorHibernate {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "nitroEnclave"), "Nitro Enclaves are not supported with hibernation"))
+	}
+
+	return allErrs
+}
+
+func awsValidateEFA(fieldPath *field.Path, ig *kops.InstanceGroup, cloud awsup.AWSCloud) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if !fi.BoolValue(ig.Spec.EFA.Enabled) {
+		return allErrs
+	}
+
+	networkCards := fi.Int32Value(ig.Spec.EFA.NetworkCards)
+	if ig.Spec.EFA.NetworkCards != nil && networkCards < 1 {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("networkCards"), networkCards, "must be at least 1"))
+	}
+
+	if ig.Spec.MixedInstancesPolicy != nil && len(ig.Spec.MixedInstancesPolicy.Instances) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("enabled"), "Elastic Fabric Adapters are not supported with a mixed instances policy"))
+	}
+
+	for _, machineType := range strings.Split(ig.Spec.MachineType, ",") {
+		info, err := awsup.GetMachineTypeInfo(cloud, machineType)
+		if err != nil {
+			// awsValidateInstanceTypeAndImage reports invalid machine types
+			continue
+		}
+		if !info.EFASupported {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("enabled"), fmt.Sprintf("machine type %q does not support Elastic Fabric Adapters", machineType)))
+		} else if int(networkCards) > info.NetworkCards {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("networkCards"), networkCards, fmt.Sprintf("machine type %q has %d network cards", machineType, info.NetworkCards)))
+		}
+	}
+
+	return allErrs
+}
+
+func awsValidateElasticInferenceAccelerators(fieldPath *field.Path, accelerators []kops.AcceleratorConfig) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	validTypes := []string{"eia1.medium", "eia1.large", "eia1.xlarge", "eia2.medium", "eia2.large", "eia2.xlarge"}
+	for i, accelerator := range accelerators {
+		allErrs = append(allErrs, IsValidValue(fieldPath.Index(i).Child("acceleratorType"), &accelerator.AcceleratorType, validTypes)...)
+		if accelerator.AcceleratorCount < 0 {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Index(i).Child("acceleratorCount"), accelerator.AcceleratorCount, "must not be negative"))
+		}
+	}
+
+	return allErrs
+}
+
+// awsValidateVolume checks the IOPS and throughput of an additional EBS volume against the limits of its volume type.
+func awsValidateVolume(fieldPath *field.Path, volume *kops.VolumeSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	// volumes without a type are created as gp3 volumes
+	volumeType := volume.Type
+	if volumeType == "" {
+		volumeType = ec2.VolumeTypeGp3
+	}
+	allErrs = append(allErrs, IsValidValue(fieldPath.Child("type"), &volumeType, ec2.VolumeType_Values())...)
+
+	if volume.Throughput != nil {
+		if volumeType != ec2.VolumeTypeGp3 {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("throughput"), "throughput is only supported for gp3 volumes"))
+		} else if throughput := fi.Int64Value(volume.Throughput); throughput < 125 || throughput > 1000 {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("throughput"), throughput, "gp3 throughput must be between 125 and 1000 MBps"))
+		}
+	}
+
+	if volume.IOPS != nil {
+		iops := fi.Int64Value(volume.IOPS)
+		switch volumeType {
+		case ec2.VolumeTypeGp3:
+			if iops < 3000 || iops > 16000 {
+				allErrs = append(allErrs, field.Invalid(fieldPath.Child("iops"), iops, "gp3 IOPS must be between 3000 and 16000"))
+			}
+		case ec2.VolumeTypeIo1, ec2.VolumeTypeIo2:
+			if iops < 100 || iops > 64000 {
+				allErrs = append(allErrs, field.Invalid(fieldPath.Child("iops"), iops, "io1 and io2 IOPS must be between 100 and 64000"))
+			}
+		default:
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("iops"), "IOPS are only supported for gp3, io1 and io2 volumes"))
+		}
+	}
+
+	if volume.Key != nil && volume.Encrypted != nil && !fi.BoolValue(volume.Encrypted) {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("key"), "an encryption key cannot be set on an unencrypted volume"))
+	}
+
 	return allErrs
 }
 
@@ -254,9 +366,39 @@ func awsValidateMixedInstancesPolicy(path *field.Path, spec *kops.MixedInstances
 
 	errs = append(errs, IsValidValue(path.Child("spotAllocationStrategy"), spec.SpotAllocationStrategy, kops.SpotAllocationStrategies)...)
 
+	if spec.SpotFallback != nil {
+		errs = append(errs, awsValidateSpotFallback(path.Child("spotFallback"), spec, ig)...)
+	}
+
 	return errs
 }
 
+func awsValidateSpotFallback(fieldPath *field.Path, spec *kops.MixedInstancesPolicySpec, ig *kops.InstanceGroup) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	fallback := spec.SpotFallback
+	if fi.BoolValue(fallback.Enabled) {
+		if ig.Spec.Manager == kops.InstanceManagerKarpenter {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("enabled"), "spot fallback is not supported for instance groups managed by Karpenter"))
+		}
+		if spec.OnDemandAboveBase == nil || *spec.OnDemandAboveBase >= 100 {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("enabled"), "spot fallback requires onDemandAboveBase to be less than 100"))
+		}
+	}
+
+	if fallback.FailureThreshold != nil && *fallback.FailureThreshold < 1 {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("failureThreshold"), *fallback.FailureThreshold, "must be at least 1"))
+	}
+	if fallback.FailureWindow != nil && fallback.FailureWindow.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("failureWindow"), fallback.FailureWindow.Duration.String(), "must be greater than zero"))
+	}
+	if fallback.RevertAfter != nil && fallback.RevertAfter.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("revertAfter"), fallback.RevertAfter.Duration.String(), "must be greater than zero"))
+	}
+
+	return allErrs
+}
+
 func awsValidateSSLPolicy(fieldPath *field.Path, spec *kops.LoadBalancerAccessSpec) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -272,6 +414,60 @@ func awsValidateSSLPolicy(fieldPath *field.Path, spec *kops.LoadBalancerAccessSp
 	return allErrs
 }
 
+func awsValidateLoadBalancerDraining(fieldPath *field.Path, spec *kops.LoadBalancerAccessSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spec.ConnectionDrainingTimeoutSeconds != nil {
+		fp := fieldPath.Child("connectionDrainingTimeoutSeconds")
+		if spec.Class == kops.LoadBalancerClassNetwork {
+			allErrs = append(allErrs, field.Forbidden(fp, "connectionDrainingTimeoutSeconds should be specified with Classic Load Balancer"))
+		} else if *spec.ConnectionDrainingTimeoutSeconds < 1 || *spec.ConnectionDrainingTimeoutSeconds > 3600 {
+			allErrs = append(allErrs, field.Invalid(fp, *spec.ConnectionDrainingTimeoutSeconds, "must be between 1 and 3600 seconds"))
+		}
+	}
+
+	if spec.DeregistrationDelaySeconds != nil {
+		fp := fieldPath.Child("deregistrationDelaySeconds")
+		if spec.Class != kops.LoadBalancerClassNetwork {
+			allErrs = append(allErrs, field.Forbidden(fp, "deregistrationDelaySeconds should be specified with Network Load Balancer"))
+		} else if *spec.DeregistrationDelaySeconds < 0 || *spec.DeregistrationDelaySeconds > 3600 {
+			allErrs = append(allErrs, field.Invalid(fp, *spec.DeregistrationDelaySeconds, "must be between 0 and 3600 seconds"))
+		}
+	}
+
+	return allErrs
+}
+
+func awsValidateLoadBalancerHealthCheck(fieldPath *field.Path, spec *kops.LoadBalancerAccessSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	healthCheck := spec.HealthCheck
+
+	protocol := ""
+	if healthCheck.Protocol != nil {
+		protocol = *healthCheck.Protocol
+		supported := []string{"TCP", "HTTP", "HTTPS"}
+		if spec.Class != kops.LoadBalancerClassNetwork {
+			supported = append(supported, "SSL")
+		}
+		allErrs = append(allErrs, IsValidValue(fieldPath.Child("protocol"), healthCheck.Protocol, supported)...)
+	}
+
+	if healthCheck.Port != nil && (*healthCheck.Port < 1 || *healthCheck.Port > 65535) {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("port"), *healthCheck.Port, "must be between 1 and 65535"))
+	}
+
+	if healthCheck.Path != nil {
+		if protocol != "HTTP" && protocol != "HTTPS" {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("path"), "path can only be specified with the HTTP or HTTPS protocol"))
+		} else if !strings.HasPrefix(*healthCheck.Path, "/") {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("path"), *healthCheck.Path, "must start with \"/\""))
+		}
+	}
+
+	return allErrs
+}
+
 func awsValidateLoadBalancerSubnets(fieldPath *field.Path, spec kops.ClusterSpec) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -351,6 +547,27 @@ func awsValidateIAMAuthenticator(fieldPath *field.Path, spec *kops.AWSAuthentica
 	return allErrs
 }
 
+// awsValidateIAMPath checks the path follows the IAM rules: a slash, or printable ASCII characters between slashes, up to 512 characters
+func awsValidateIAMPath(fieldPath *field.Path, path string) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(path) > 512 || !strings.HasPrefix(path, "/") || !strings.HasSuffix(path, "/") {
+		allErrs = append(allErrs, field.Invalid(fieldPath, path, "path must begin and end with a slash, and be at most 512 characters"))
+		return allErrs
+	}
+	for _, c := range path {
+		if c < 0x21 || c > 0x7e {
+			allErrs = append(allErrs, field.Invalid(fieldPath, path, "path must contain only printable ASCII characters"))
+			break
+		}
+	}
+	if strings.Contains(path, "//") {
+		allErrs = append(allErrs, field.Invalid(fieldPath, path, "path must not contain empty segments"))
+	}
+
+	return allErrs
+}
+
 func hasAWSEBSCSIDriver(c kops.ClusterSpec) bool {
 	// AWSEBSCSIDriver will have a default value, so if this is all false, it will be populated on next pass
 	if c.CloudConfig == nil || c.CloudConfig.AWSEBSCSIDriver == nil || c.CloudConfig.AWSEBSCSIDriver.Enabled == nil {
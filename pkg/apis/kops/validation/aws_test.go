@@ -18,6 +18,7 @@ package validation
 
 import (
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
@@ -27,6 +28,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/kops/pkg/apis/kops"
 )
 
@@ -305,6 +307,63 @@ func TestMixedInstancePolicies(t *testing.T) {
 			},
 			ExpectedErrors: []string{"Invalid value::spec.mixedInstancesPolicy.onDemandAboveBase"},
 		},
+		{
+			Input: kops.InstanceGroupSpec{
+				MachineType: "m4.large",
+				Image:       "ami-073c8c0760395aab8",
+				MixedInstancesPolicy: &kops.MixedInstancesPolicySpec{
+					Instances: []string{
+						"m4.large",
+						"c5.large",
+					},
+					OnDemandAboveBase: fi.Int64(0),
+					SpotFallback: &kops.SpotFallbackSpec{
+						Enabled:          fi.Bool(true),
+						FailureThreshold: fi.Int32(5),
+						RevertAfter:      &v1.Duration{Duration: 30 * time.Minute},
+					},
+				},
+			},
+			ExpectedErrors: nil,
+		},
+		{
+			Input: kops.InstanceGroupSpec{
+				MachineType: "m4.large",
+				Image:       "ami-073c8c0760395aab8",
+				MixedInstancesPolicy: &kops.MixedInstancesPolicySpec{
+					Instances: []string{
+						"m4.large",
+						"c5.large",
+					},
+					SpotFallback: &kops.SpotFallbackSpec{
+						Enabled: fi.Bool(true),
+					},
+				},
+			},
+			ExpectedErrors: []string{"Forbidden::spec.mixedInstancesPolicy.spotFallback.enabled"},
+		},
+		{
+			Input: kops.InstanceGroupSpec{
+				MachineType: "m4.large",
+				Image:       "ami-073c8c0760395aab8",
+				MixedInstancesPolicy: &kops.MixedInstancesPolicySpec{
+					Instances: []string{
+						"m4.large",
+						"c5.large",
+					},
+					OnDemandAboveBase: fi.Int64(20),
+					SpotFallback: &kops.SpotFallbackSpec{
+						Enabled:          fi.Bool(true),
+						FailureThreshold: fi.Int32(0),
+						FailureWindow:    &v1.Duration{},
+					},
+				},
+			},
+			ExpectedErrors: []string{
+				"Invalid value::spec.mixedInstancesPolicy.spotFallback.failureThreshold",
+				"Invalid value::spec.mixedInstancesPolicy.spotFallback.failureWindow",
+			},
+		},
 	}
 	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
 	mockEC2 := &mockec2.MockEC2{}
@@ -391,6 +450,193 @@ func TestInstanceMetadataOptions(t *testing.T) {
 	}
 }
 
+func TestEFA(t *testing.T) {
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+
+	mockEC2 := &mockec2.MockEC2{}
+	cloud.MockEC2 = mockEC2
+
+	mockEC2.Images = append(mockEC2.Images, &ec2.Image{
+		CreationDate:   aws.String("2016-10-21T20:07:19.000Z"),
+		ImageId:        aws.String("ami-073c8c0760395aab8"),
+		Name:           aws.String("focal"),
+		OwnerId:        aws.String(awsup.WellKnownAccountUbuntu),
+		RootDeviceName: aws.String("/dev/xvda"),
+		Architecture:   aws.String("x86_64"),
+	})
+
+	tests := []struct {
+		name        string
+		machineType string
+		efa         *kops.EFASpec
+		expected    []string
+	}{
+		{
+			name:        "all network cards",
+			machineType: "p4d.24xlarge",
+			efa:         &kops.EFASpec{Enabled: fi.Bool(true)},
+		},
+		{
+			name:        "some network cards",
+			machineType: "p4d.24xlarge",
+			efa:         &kops.EFASpec{Enabled: fi.Bool(true), NetworkCards: fi.Int32(2)},
+		},
+		{
+			name:        "too many network cards",
+			machineType: "p4d.24xlarge",
+			efa:         &kops.EFASpec{Enabled: fi.Bool(true), NetworkCards: fi.Int32(8)},
+			expected:    []string{"Invalid value::spec.efa.networkCards"},
+		},
+		{
+			name:        "no network cards",
+			machineType: "p4d.24xlarge",
+			efa:         &kops.EFASpec{Enabled: fi.Bool(true), NetworkCards: fi.Int32(0)},
+			expected:    []string{"Invalid value::spec.efa.networkCards"},
+		},
+		{
+			name:        "unsupported machine type",
+			machineType: "t3.medium",
+			efa:         &kops.EFASpec{Enabled: fi.Bool(true)},
+			expected:    []string{"Forbidden::spec.efa.enabled"},
+		},
+		{
+			name:        "disabled",
+			machineType: "t3.medium",
+			efa:         &kops.EFASpec{Enabled: fi.Bool(false)},
+		},
+	}
+
+	for _, test := range tests {
+		ig := &kops.InstanceGroup{
+			ObjectMeta: v1.ObjectMeta{
+				Name: "some-ig",
+			},
+			Spec: kops.InstanceGroupSpec{
+				Role:        "Node",
+				Image:       "ami-073c8c0760395aab8",
+				MachineType: test.machineType,
+				EFA:         test.efa,
+			},
+		}
+		errs := ValidateInstanceGroup(ig, cloud, true)
+		testErrors(t, test.name, errs, test.expected)
+	}
+}
+
+func TestElasticInferenceAndNitroEnclaves(t *testing.T) {
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+
+	mockEC2 := &mockec2.MockEC2{}
+	cloud.MockEC2 = mockEC2
+
+	mockEC2.Images = append(mockEC2.Images, &ec2.Image{
+		CreationDate:   aws.String("2016-10-21T20:07:19.000Z"),
+		ImageId:        aws.String("ami-073c8c0760395aab8"),
+		Name:           aws.String("focal"),
+		OwnerId:        aws.String(awsup.WellKnownAccountUbuntu),
+		RootDeviceName: aws.String("/dev/xvda"),
+		Architecture:   aws.String("x86_64"),
+	})
+
+	tests := []struct {
+		name                         string
+		elasticInferenceAccelerators []kops.AcceleratorConfig
+		nitroEnclave                 *bool
+		instanceInterruptionBehavior *string
+		expected                     []string
+	}{
+		{
+			name: "elastic inference accelerator",
+			elasticInferenceAccelerators: []kops.AcceleratorConfig{
+				{AcceleratorType: "eia2.medium", AcceleratorCount: 1},
+			},
+		},
+		{
+			name: "invalid elastic inference accelerator",
+			elasticInferenceAccelerators: []kops.AcceleratorConfig{
+				{AcceleratorType: "eia2.medium"},
+				{AcceleratorType: "nvidia-tesla-t4", AcceleratorCount: -1},
+			},
+			expected: []string{
+				"Unsupported value::spec.elasticInferenceAccelerators[1].acceleratorType",
+				"Invalid value::spec.elasticInferenceAccelerators[1].acceleratorCount",
+			},
+		},
+		{
+			name:         "nitro enclave",
+			nitroEnclave: fi.Bool(true),
+		},
+		{
+			name:                         "nitro enclave with hibernation",
+			nitroEnclave:                 fi.Bool(true),
+			instanceInterruptionBehavior: fi.String("hibernate"),
+			expected:                     []string{"Forbidden::spec.nitroEnclave"},
+		},
+	}
+
+	for _, test := range tests {
+		ig := &kops.InstanceGroup{
+			ObjectMeta: v1.ObjectMeta{
+				Name: "some-ig",
+			},
+			Spec: kops.InstanceGroupSpec{
+				Role:                         "Node",
+				Image:                        "ami-073c8c0760395aab8",
+				MachineType:                  "m5.xlarge",
+				ElasticInferenceAccelerators: test.elasticInferenceAccelerators,
+				NitroEnclave:                 test.nitroEnclave,
+				InstanceInterruptionBehavior: test.instanceInterruptionBehavior,
+			},
+		}
+		errs := ValidateInstanceGroup(ig, cloud, true)
+		testErrors(t, test.name, errs, test.expected)
+	}
+}
+
+func TestAWSValidateVolume(t *testing.T) {
+	grid := []struct {
+		volume   kops.VolumeSpec
+		expected []string
+	}{
+		{
+			volume: kops.VolumeSpec{Device: "/dev/sdf", Size: 20},
+		},
+		{
+			volume: kops.VolumeSpec{Device: "/dev/sdf", Size: 20, Type: "gp3", IOPS: fi.Int64(6000), Throughput: fi.Int64(500)},
+		},
+		{
+			volume: kops.VolumeSpec{Device: "/dev/sdf", Size: 20, Throughput: fi.Int64(250)},
+		},
+		{
+			volume:   kops.VolumeSpec{Device: "/dev/sdf", Size: 20, Type: "gp3", IOPS: fi.Int64(2000), Throughput: fi.Int64(1500)},
+			expected: []string{"Invalid value::spec.volumes[0].throughput", "Invalid value::spec.volumes[0].iops"},
+		},
+		{
+			volume:   kops.VolumeSpec{Device: "/dev/sdf", Size: 20, Type: "gp2", IOPS: fi.Int64(3000), Throughput: fi.Int64(250)},
+			expected: []string{"Forbidden::spec.volumes[0].throughput", "Forbidden::spec.volumes[0].iops"},
+		},
+		{
+			volume: kops.VolumeSpec{Device: "/dev/sdf", Size: 20, Type: "io2", IOPS: fi.Int64(10000)},
+		},
+		{
+			volume:   kops.VolumeSpec{Device: "/dev/sdf", Size: 20, Type: "io1", IOPS: fi.Int64(50)},
+			expected: []string{"Invalid value::spec.volumes[0].iops"},
+		},
+		{
+			volume:   kops.VolumeSpec{Device: "/dev/sdf", Size: 20, Type: "gp4"},
+			expected: []string{"Unsupported value::spec.volumes[0].type"},
+		},
+		{
+			volume:   kops.VolumeSpec{Device: "/dev/sdf", Size: 20, Encrypted: fi.Bool(false), Key: fi.String("arn:aws:kms:us-east-1:123456789012:key/abc")},
+			expected: []string{"Forbidden::spec.volumes[0].key"},
+		},
+	}
+	for _, g := range grid {
+		errs := awsValidateVolume(field.NewPath("spec", "volumes").Index(0), &g.volume)
+		testErrors(t, g.volume, errs, g.expected)
+	}
+}
+
 func TestLoadBalancerSubnets(t *testing.T) {
 	cidr := "10.0.0.0/24"
 	tests := []struct {
@@ -645,6 +891,48 @@ func TestAWSAuthentication(t *testing.T) {
 	}
 }
 
+func TestAWSIAMPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected []string
+	}{
+		{
+			path: "/",
+		},
+		{
+			path: "/kops/clusters/",
+		},
+		{
+			path:     "kops/",
+			expected: []string{"Invalid value::spec.iam.path"},
+		},
+		{
+			path:     "/kops",
+			expected: []string{"Invalid value::spec.iam.path"},
+		},
+		{
+			path:     "/kops clusters/",
+			expected: []string{"Invalid value::spec.iam.path"},
+		},
+		{
+			path:     "/kops//clusters/",
+			expected: []string{"Invalid value::spec.iam.path"},
+		},
+	}
+
+	for _, test := range tests {
+		cluster := kops.Cluster{
+			Spec: kops.ClusterSpec{
+				IAM: &kops.IAMSpec{
+					Path: test.path,
+				},
+			},
+		}
+		errs := awsValidateCluster(&cluster)
+		testErrors(t, test, errs, test.expected)
+	}
+}
+
 func TestAWSAdditionalRoutes(t *testing.T) {
 	tests := []struct {
 		clusterCidr string
@@ -814,3 +1102,99 @@ func TestAWSAdditionalRoutes(t *testing.T) {
 		testErrors(t, test, errs, test.expected)
 	}
 }
+
+func TestAWSLoadBalancerFailover(t *testing.T) {
+	tests := []struct {
+		class    kops.LoadBalancerClass
+		draining *int64
+		delay    *int64
+		check    *kops.LoadBalancerHealthCheckSpec
+		expected []string
+	}{
+		{ // valid classic
+			class:    kops.LoadBalancerClassClassic,
+			draining: fi.Int64(60),
+			check: &kops.LoadBalancerHealthCheckSpec{
+				Protocol: fi.String("SSL"),
+				Port:     fi.Int64(443),
+			},
+		},
+		{ // valid network
+			class: kops.LoadBalancerClassNetwork,
+			delay: fi.Int64(0),
+			check: &kops.LoadBalancerHealthCheckSpec{
+				Protocol: fi.String("HTTPS"),
+				Port:     fi.Int64(443),
+				Path:     fi.String("/readyz"),
+			},
+		},
+		{ // draining on network
+			class:    kops.LoadBalancerClassNetwork,
+			draining: fi.Int64(60),
+			expected: []string{"Forbidden::spec.api.loadBalancer.connectionDrainingTimeoutSeconds"},
+		},
+		{ // draining out of range
+			class:    kops.LoadBalancerClassClassic,
+			draining: fi.Int64(0),
+			expected: []string{"Invalid value::spec.api.loadBalancer.connectionDrainingTimeoutSeconds"},
+		},
+		{ // deregistration delay on classic
+			class:    kops.LoadBalancerClassClassic,
+			delay:    fi.Int64(60),
+			expected: []string{"Forbidden::spec.api.loadBalancer.deregistrationDelaySeconds"},
+		},
+		{ // deregistration delay out of range
+			class:    kops.LoadBalancerClassNetwork,
+			delay:    fi.Int64(3601),
+			expected: []string{"Invalid value::spec.api.loadBalancer.deregistrationDelaySeconds"},
+		},
+		{ // SSL health check on network
+			class: kops.LoadBalancerClassNetwork,
+			check: &kops.LoadBalancerHealthCheckSpec{
+				Protocol: fi.String("SSL"),
+			},
+			expected: []string{"Unsupported value::spec.api.loadBalancer.healthCheck.protocol"},
+		},
+		{ // invalid port
+			class: kops.LoadBalancerClassNetwork,
+			check: &kops.LoadBalancerHealthCheckSpec{
+				Port: fi.Int64(0),
+			},
+			expected: []string{"Invalid value::spec.api.loadBalancer.healthCheck.port"},
+		},
+		{ // path with TCP
+			class: kops.LoadBalancerClassNetwork,
+			check: &kops.LoadBalancerHealthCheckSpec{
+				Protocol: fi.String("TCP"),
+				Path:     fi.String("/healthz"),
+			},
+			expected: []string{"Forbidden::spec.api.loadBalancer.healthCheck.path"},
+		},
+		{ // relative path
+			class: kops.LoadBalancerClassClassic,
+			check: &kops.LoadBalancerHealthCheckSpec{
+				Protocol: fi.String("HTTP"),
+				Path:     fi.String("healthz"),
+			},
+			expected: []string{"Invalid value::spec.api.loadBalancer.healthCheck.path"},
+		},
+	}
+
+	for _, test := range tests {
+		cluster := kops.Cluster{
+			Spec: kops.ClusterSpec{
+				API: &kops.AccessSpec{
+					LoadBalancer: &kops.LoadBalancerAccessSpec{
+						Class:                            test.class,
+						Type:                             kops.LoadBalancerTypePublic,
+						ConnectionDrainingTimeoutSeconds: test.draining,
+						DeregistrationDelaySeconds:       test.delay,
+						HealthCheck:                      test.check,
+					},
+				},
+			},
+		}
+		errs := awsValidateCluster(&cluster)
+		testErrors(t, test, errs, test.expected)
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi/cloudup/azure"
+)
+
+// azureValidateInstanceGroupIdentity checks that the user-assigned identity set as the instance profile exists
+// and is assigned the roles in the cluster's resource group that kOps would assign to the VM Scale Set identities it creates.
+func azureValidateInstanceGroupIdentity(ig *kops.InstanceGroup, cluster *kops.Cluster, cloud azure.AzureCloud, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	ctx := context.TODO()
+
+	id := *ig.Spec.IAM.Profile
+	identity, err := cloud.UserAssignedIdentity().Get(ctx, id)
+	if err != nil {
+		return append(allErrs, field.InternalError(fldPath, fmt.Errorf("error getting user-assigned identity %q: %w", id, err)))
+	}
+
+	resourceGroup := cluster.AzureResourceGroupName()
+	ras, err := cloud.RoleAssignment().List(ctx, resourceGroup)
+	if err != nil {
+		return append(allErrs, field.InternalError(fldPath, fmt.Errorf("error listing role assignments in resource group %q: %w", resourceGroup, err)))
+	}
+
+	assignedRoleDefIDs := sets.NewString()
+	for _, ra := range ras {
+		if ra.RoleAssignmentPropertiesWithScope == nil || ra.PrincipalID == nil || ra.RoleDefinitionID == nil {
+			continue
+		}
+		if *ra.PrincipalID != identity.PrincipalID {
+			continue
+		}
+		l := strings.Split(*ra.RoleDefinitionID, "/")
+		assignedRoleDefIDs.Insert(l[len(l)-1])
+	}
+
+	var requiredRoleDefIDs []string
+	for _, roleDefID := range azure.InstanceRoleDefIDs() {
+		requiredRoleDefIDs = append(requiredRoleDefIDs, roleDefID)
+	}
+	sort.Strings(requiredRoleDefIDs)
+	for _, roleDefID := range requiredRoleDefIDs {
+		if !assignedRoleDefIDs.Has(roleDefID) {
+			allErrs = append(allErrs, field.Forbidden(fldPath,
+				fmt.Sprintf("user-assigned identity %q must be assigned the role %q in resource group %q", id, roleDefID, resourceGroup)))
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"strconv"
+	"testing"
+
+	authz "github.com/Azure/azure-sdk-for-go/services/preview/authorization/mgmt/2018-01-01-preview/authorization"
+	"github.com/Azure/go-autorest/autorest/to"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/azure"
+	"k8s.io/kops/upup/pkg/fi/cloudup/azuretasks"
+)
+
+func TestAzureValidateInstanceGroupIdentity(t *testing.T) {
+	const identityID = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/kops-nodes"
+
+	roleAssignment := func(principalID, roleDefID string) authz.RoleAssignment {
+		return authz.RoleAssignment{
+			RoleAssignmentPropertiesWithScope: &authz.RoleAssignmentPropertiesWithScope{
+				RoleDefinitionID: to.StringPtr("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Authorization/roleDefinitions/" + roleDefID),
+				PrincipalID:      to.StringPtr(principalID),
+			},
+		}
+	}
+
+	for _, test := range []struct {
+		label           string
+		identityID      string
+		roleAssignments []authz.RoleAssignment
+		expected        []string
+	}{
+		{
+			label:      "all roles",
+			identityID: identityID,
+			roleAssignments: []authz.RoleAssignment{
+				roleAssignment("pid", azure.RoleDefIDOwner),
+				roleAssignment("pid", azure.RoleDefIDStorageBlobDataContributor),
+			},
+		},
+		{
+			label:      "missing blob role",
+			identityID: identityID,
+			roleAssignments: []authz.RoleAssignment{
+				roleAssignment("pid", azure.RoleDefIDOwner),
+				roleAssignment("other", azure.RoleDefIDStorageBlobDataContributor),
+			},
+			expected: []string{"Forbidden::spec.iam.profile"},
+		},
+		{
+			label:      "unknown identity",
+			identityID: "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/unknown",
+			expected:   []string{"Internal error::spec.iam.profile"},
+		},
+	} {
+		t.Run(test.label, func(t *testing.T) {
+			cloud := azuretasks.NewMockAzureCloud("eastus")
+			cloud.IdentitiesClient.Identities[identityID] = azure.UserAssignedIdentity{
+				ID:          identityID,
+				PrincipalID: "pid",
+			}
+			for i, ra := range test.roleAssignments {
+				cloud.RoleAssignmentsClient.RAs[strconv.Itoa(i)] = ra
+			}
+
+			cluster := &kops.Cluster{
+				Spec: kops.ClusterSpec{
+					CloudProvider: kops.CloudProviderSpec{Azure: &kops.AzureSpec{ResourceGroupName: "rg"}},
+				},
+			}
+			ig := createMinimalInstanceGroup()
+			ig.Spec.IAM = &kops.IAMProfileSpec{Profile: fi.String(test.identityID)}
+
+			errs := azureValidateInstanceGroupIdentity(ig, cluster, cloud, field.NewPath("spec", "iam", "profile"))
+			testErrors(t, test.label, errs, test.expected)
+		})
+	}
+}
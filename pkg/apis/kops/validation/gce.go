@@ -17,8 +17,14 @@ limitations under the License.
 package validation
 
 import (
+	"context"
+	"fmt"
+
+	"google.golang.org/api/cloudresourcemanager/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi/cloudup/gce"
 )
 
 func gceValidateCluster(c *kops.Cluster) field.ErrorList {
@@ -45,3 +51,56 @@ func gceValidateCluster(c *kops.Cluster) field.ErrorList {
 
 	return allErrs
 }
+
+// gceValidateInstanceGroupServiceAccount checks that the service account set as the instance profile exists
+// and is granted the project roles that kOps would grant to the service accounts it creates.
+func gceValidateInstanceGroupServiceAccount(ig *kops.InstanceGroup, cloud gce.GCECloud, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	ctx := context.TODO()
+
+	email := *ig.Spec.IAM.Profile
+	_, projectID, err := gce.SplitServiceAccountEmail(email)
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, email, err.Error()))
+	}
+	fqn := "projects/" + projectID + "/serviceAccounts/" + email
+	if _, err := cloud.IAM().ServiceAccounts().Get(ctx, fqn); err != nil {
+		if gce.IsNotFound(err) {
+			return append(allErrs, field.NotFound(fldPath, email))
+		}
+		return append(allErrs, field.InternalError(fldPath, fmt.Errorf("error getting ServiceAccount %q: %w", fqn, err)))
+	}
+
+	requiredRoles := gce.InstanceGroupServiceAccountRoles(ig.Spec.Role)
+	if len(requiredRoles) == 0 {
+		return allErrs
+	}
+
+	policy, err := cloud.CloudResourceManager().Projects.GetIamPolicy(cloud.Project(), &cloudresourcemanager.GetIamPolicyRequest{}).Context(ctx).Do()
+	if err != nil {
+		return append(allErrs, field.InternalError(fldPath, fmt.Errorf("error getting IAM policy of project %q: %w", cloud.Project(), err)))
+	}
+
+	member := "serviceAccount:" + email
+	grantedRoles := sets.NewString()
+	for _, binding := range policy.Bindings {
+		for _, m := range binding.Members {
+			if m == member {
+				grantedRoles.Insert(binding.Role)
+			}
+		}
+	}
+	// The basic roles include the permissions of every predefined role
+	if grantedRoles.HasAny("roles/owner", "roles/editor") {
+		return allErrs
+	}
+	for _, requiredRole := range requiredRoles {
+		if !grantedRoles.Has(requiredRole) {
+			allErrs = append(allErrs, field.Forbidden(fldPath,
+				fmt.Sprintf("ServiceAccount %q must be granted the role %q in project %q", email, requiredRole, cloud.Project())))
+		}
+	}
+
+	return allErrs
+}
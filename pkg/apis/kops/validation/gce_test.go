@@ -0,0 +1,122 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/api/cloudresourcemanager/v1"
+	"google.golang.org/api/iam/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	gcemock "k8s.io/kops/cloudmock/gce"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func TestGCEValidateInstanceGroupServiceAccount(t *testing.T) {
+	const email = "kops-nodes@testproject.iam.gserviceaccount.com"
+
+	for _, test := range []struct {
+		label    string
+		email    string
+		role     kops.InstanceGroupRole
+		bindings []*cloudresourcemanager.Binding
+		expected []string
+	}{
+		{
+			label: "viewer role",
+			email: email,
+			role:  kops.InstanceGroupRoleNode,
+			bindings: []*cloudresourcemanager.Binding{
+				{Role: "roles/compute.viewer", Members: []string{"serviceAccount:" + email}},
+			},
+		},
+		{
+			label: "editor role",
+			email: email,
+			role:  kops.InstanceGroupRoleNode,
+			bindings: []*cloudresourcemanager.Binding{
+				{Role: "roles/editor", Members: []string{"serviceAccount:" + email}},
+			},
+		},
+		{
+			label:    "missing role",
+			email:    email,
+			role:     kops.InstanceGroupRoleNode,
+			expected: []string{"Forbidden::spec.iam.profile"},
+		},
+		{
+			label: "missing control-plane role",
+			email: email,
+			role:  kops.InstanceGroupRoleMaster,
+			bindings: []*cloudresourcemanager.Binding{
+				{Role: "roles/compute.viewer", Members: []string{"serviceAccount:" + email}},
+			},
+			expected: []string{"Forbidden::spec.iam.profile"},
+		},
+		{
+			label: "role granted to another member",
+			email: email,
+			role:  kops.InstanceGroupRoleNode,
+			bindings: []*cloudresourcemanager.Binding{
+				{Role: "roles/compute.viewer", Members: []string{"serviceAccount:other@testproject.iam.gserviceaccount.com"}},
+			},
+			expected: []string{"Forbidden::spec.iam.profile"},
+		},
+		{
+			label: "bastion",
+			email: email,
+			role:  kops.InstanceGroupRoleBastion,
+		},
+		{
+			label:    "unknown service account",
+			email:    "unknown@testproject.iam.gserviceaccount.com",
+			role:     kops.InstanceGroupRoleNode,
+			expected: []string{"Not found::spec.iam.profile"},
+		},
+	} {
+		t.Run(test.label, func(t *testing.T) {
+			ctx := context.TODO()
+
+			cloud := gcemock.InstallMockGCECloud("us-test1", "testproject")
+			if _, err := cloud.IAM().ServiceAccounts().Create(ctx, "projects/testproject", &iam.CreateServiceAccountRequest{
+				AccountId:      "kops-nodes",
+				ServiceAccount: &iam.ServiceAccount{},
+			}); err != nil {
+				t.Fatalf("error creating service account: %v", err)
+			}
+			policy, err := cloud.CloudResourceManager().Projects.GetIamPolicy("testproject", &cloudresourcemanager.GetIamPolicyRequest{}).Context(ctx).Do()
+			if err != nil {
+				t.Fatalf("error getting project policy: %v", err)
+			}
+			policy.Bindings = test.bindings
+			if _, err := cloud.CloudResourceManager().Projects.SetIamPolicy("testproject", &cloudresourcemanager.SetIamPolicyRequest{
+				Policy: policy,
+			}).Context(ctx).Do(); err != nil {
+				t.Fatalf("error setting project policy: %v", err)
+			}
+
+			ig := createMinimalInstanceGroup()
+			ig.Spec.Role = test.role
+			ig.Spec.IAM = &kops.IAMProfileSpec{Profile: fi.String(test.email)}
+
+			errs := gceValidateInstanceGroupServiceAccount(ig, cloud, field.NewPath("spec", "iam", "profile"))
+			testErrors(t, test.label, errs, test.expected)
+		})
+	}
+}
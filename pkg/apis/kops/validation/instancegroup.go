@@ -18,19 +18,26 @@ package validation
 
 import (
 	"fmt"
+	"net/url"
+	"path"
 	"strings"
 
 	"k8s.io/kops/pkg/nodeidentity/aws"
 
 	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/blang/semver/v4"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/apis/kops/util"
+	"k8s.io/kops/pkg/dns"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	"k8s.io/kops/upup/pkg/fi/cloudup/azure"
+	"k8s.io/kops/upup/pkg/fi/cloudup/gce"
+	"sigs.k8s.io/yaml"
 )
 
 // ValidateInstanceGroup is responsible for validating the configuration of a instancegroup
@@ -63,6 +70,21 @@ func ValidateInstanceGroup(g *kops.InstanceGroup, cloud fi.Cloud, strict bool) f
 		allErrs = append(allErrs, IsValidValue(field.NewPath("spec", "tenancy"), &g.Spec.Tenancy, ec2.Tenancy_Values())...)
 	}
 
+	if g.Spec.Tenancy != ec2.TenancyHost {
+		if g.Spec.HostResourceGroupARN != nil {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "hostResourceGroupARN"), "hostResourceGroupARN requires the host tenancy"))
+		}
+		if g.Spec.HostAffinity != nil {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "hostAffinity"), "hostAffinity requires the host tenancy"))
+		}
+	}
+	if g.Spec.HostAffinity != nil {
+		allErrs = append(allErrs, IsValidValue(field.NewPath("spec", "hostAffinity"), g.Spec.HostAffinity, []string{"default", "host"})...)
+	}
+	if g.Spec.HostResourceGroupARN != nil && !strings.HasPrefix(*g.Spec.HostResourceGroupARN, "arn:") {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "hostResourceGroupARN"), *g.Spec.HostResourceGroupARN, "must be an ARN"))
+	}
+
 	if strict && g.Spec.Manager == kops.InstanceManagerCloudGroup {
 		if g.Spec.MaxSize == nil {
 			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "maxSize"), "maxSize must be set"))
@@ -104,9 +126,27 @@ func ValidateInstanceGroup(g *kops.InstanceGroup, cloud fi.Cloud, strict bool) f
 		allErrs = append(allErrs, validateExtraUserData(&UserDataInfo)...)
 	}
 
+	if g.Spec.CloudInit != nil {
+		allErrs = append(allErrs, validateCloudInit(g.Spec.CloudInit, field.NewPath("spec", "cloudInit"))...)
+	}
+
+	// The Ignition config replaces the cloud-init user-data
+	if g.UsesIgnition() {
+		if len(g.Spec.AdditionalUserData) > 0 {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "additionalUserData"), "additional user-data is not supported with Ignition"))
+		}
+		if g.Spec.CloudInit != nil {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "cloudInit"), "cloud-init is not supported with Ignition"))
+		}
+	}
+
+	if g.Spec.NodeUp != nil {
+		allErrs = append(allErrs, validatePackagesConfig(g.Spec.NodeUp, field.NewPath("spec", "nodeup"))...)
+	}
+
 	// @step: iterate and check the volume specs
+	devices := make(map[string]bool)
 	for i, x := range g.Spec.Volumes {
-		devices := make(map[string]bool)
 		path := field.NewPath("spec", "volumes").Index(i)
 
 		allErrs = append(allErrs, validateVolumeSpec(path, x)...)
@@ -120,17 +160,21 @@ func ValidateInstanceGroup(g *kops.InstanceGroup, cloud fi.Cloud, strict bool) f
 	}
 
 	// @step: iterate and check the volume mount specs
+	mountedDevices := make(map[string]bool)
+	mountedPaths := make(map[string]bool)
 	for i, x := range g.Spec.VolumeMounts {
-		used := make(map[string]bool)
 		path := field.NewPath("spec", "volumeMounts").Index(i)
 
 		allErrs = append(allErrs, validateVolumeMountSpec(path, x)...)
-		if _, found := used[x.Device]; found {
+		if _, found := mountedDevices[x.Device]; found {
 			allErrs = append(allErrs, field.Duplicate(path.Child("device"), x.Device))
 		}
-		if _, found := used[x.Path]; found {
+		if _, found := mountedPaths[x.Path]; found {
 			allErrs = append(allErrs, field.Duplicate(path.Child("path"), x.Path))
 		}
+
+		mountedDevices[x.Device] = true
+		mountedPaths[x.Path] = true
 	}
 
 	allErrs = append(allErrs, validateInstanceProfile(g.Spec.IAM, field.NewPath("spec", "iam"))...)
@@ -159,6 +203,23 @@ func ValidateInstanceGroup(g *kops.InstanceGroup, cloud fi.Cloud, strict bool) f
 
 	allErrs = append(allErrs, IsValidValue(field.NewPath("spec", "updatePolicy"), g.Spec.UpdatePolicy, []string{kops.UpdatePolicyAutomatic, kops.UpdatePolicyExternal})...)
 
+	if g.Spec.AssetCache != nil {
+		allErrs = append(allErrs, validateAssetCache(g.Spec.AssetCache, field.NewPath("spec", "assetCache"))...)
+	}
+
+	preloadImages := sets.NewString()
+	for i, image := range g.Spec.PreloadImages {
+		path := field.NewPath("spec", "preloadImages").Index(i)
+		if image == "" || strings.ContainsAny(image, " \t\n") {
+			allErrs = append(allErrs, field.Invalid(path, image, "must be a container image reference"))
+		}
+		if preloadImages.Has(image) {
+			allErrs = append(allErrs, field.Duplicate(path, image))
+		} else {
+			preloadImages.Insert(image)
+		}
+	}
+
 	taintKeys := sets.NewString()
 	for i, taint := range g.Spec.Taints {
 		path := field.NewPath("spec", "taints").Index(i)
@@ -176,6 +237,23 @@ func ValidateInstanceGroup(g *kops.InstanceGroup, cloud fi.Cloud, strict bool) f
 	return allErrs
 }
 
+func validateAssetCache(spec *kops.AssetCacheSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spec.Path != nil && !path.IsAbs(*spec.Path) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("path"), *spec.Path, "must be an absolute path"))
+	}
+
+	if spec.URL != nil {
+		u, err := url.Parse(*spec.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("url"), *spec.URL, "must be an http or https URL"))
+		}
+	}
+
+	return allErrs
+}
+
 // validateVolumeSpec is responsible for checking a volume spec is ok
 func validateVolumeSpec(path *field.Path, v kops.VolumeSpec) field.ErrorList {
 	allErrs := field.ErrorList{}
@@ -217,10 +295,60 @@ func CrossValidateInstanceGroup(g *kops.InstanceGroup, cluster *kops.Cluster, cl
 		allErrs = append(allErrs, ValidateMasterInstanceGroup(g, cluster)...)
 	}
 
+	if g.Spec.IAM != nil && g.Spec.IAM.Profile != nil {
+		allErrs = append(allErrs, crossValidateInstanceProfile(g, cluster, cloud, field.NewPath("spec", "iam", "profile"))...)
+	}
+
 	if g.Spec.Role == kops.InstanceGroupRoleAPIServer && cluster.Spec.GetCloudProvider() != kops.CloudProviderAWS {
 		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "role"), "Apiserver role only supported on AWS"))
 	}
 
+	if g.Spec.Manager == kops.InstanceManagerExternal {
+		if cluster.Spec.GetCloudProvider() != kops.CloudProviderAWS {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "manager"), "externally managed instance groups are only supported on AWS"))
+		}
+		if g.Spec.Role != kops.InstanceGroupRoleNode {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "manager"), "only instance groups with the Node role can be externally managed"))
+		}
+		if g.Spec.WarmPool != nil {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "warmPool"), "warm pools are not supported for externally managed instance groups"))
+		}
+	}
+
+	if g.Spec.Manager == kops.InstanceManagerMetal {
+		fieldPath := field.NewPath("spec", "manager")
+		if cluster.Spec.GetCloudProvider() != kops.CloudProviderAWS {
+			allErrs = append(allErrs, field.Forbidden(fieldPath, "metal instance groups are only supported on AWS"))
+		}
+		if g.Spec.Role != kops.InstanceGroupRoleNode {
+			allErrs = append(allErrs, field.Forbidden(fieldPath, "only instance groups with the Node role can be metal"))
+		}
+		if g.Spec.WarmPool != nil {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "warmPool"), "warm pools are not supported for metal instance groups"))
+		}
+		if g.Spec.CloudInit != nil {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "cloudInit"), "cloud-init is not supported for metal instance groups"))
+		}
+		if fi.BoolValue(g.Spec.Ignition) {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "ignition"), "Ignition is not supported for metal instance groups"))
+		}
+		if dns.IsGossipHostname(cluster.ObjectMeta.Name) {
+			allErrs = append(allErrs, field.Forbidden(fieldPath, "metal instance groups are not supported with gossip DNS"))
+		}
+		if cluster.Spec.IsKopsControllerIPAM() {
+			allErrs = append(allErrs, field.Forbidden(fieldPath, "metal instance groups are not supported when kops-controller allocates the pod CIDRs"))
+		}
+		if networking := cluster.Spec.Networking; networking != nil {
+			if networking.AmazonVPC != nil || (networking.CNI != nil && networking.CNI.UsesSecondaryIP) || (networking.Cilium != nil && networking.Cilium.IPAM == kops.CiliumIpamEni) {
+				allErrs = append(allErrs, field.Forbidden(fieldPath, "metal instance groups are not supported with networking that assigns pod IPs from the cloud"))
+			}
+		}
+	}
+
+	if g.IsWindows() {
+		allErrs = append(allErrs, validateWindowsInstanceGroup(g, cluster)...)
+	}
+
 	// Check that instance groups are defined in subnets that are defined in the cluster
 	{
 		clusterSubnets := make(map[string]*kops.ClusterSubnetSpec)
@@ -248,6 +376,32 @@ func CrossValidateInstanceGroup(g *kops.InstanceGroup, cluster *kops.Cluster, cl
 
 	if g.Spec.Containerd != nil {
 		allErrs = append(allErrs, validateContainerdConfig(&cluster.Spec, g.Spec.Containerd, field.NewPath("spec", "containerd"), false)...)
+
+		// The instance group config is merged into the cluster config, which would ignore the registries.
+		if clusterContainerd := cluster.Spec.Containerd; clusterContainerd != nil {
+			if clusterContainerd.ConfigOverride != nil && len(g.Spec.Containerd.Registries) != 0 {
+				allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "containerd", "registries"), "registries cannot be used in conjunction with the configOverride of the cluster"))
+			}
+			if g.Spec.Containerd.ConfigOverride != nil && len(clusterContainerd.Registries) != 0 {
+				allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "containerd", "configOverride"), "configOverride cannot be used in conjunction with the registries of the cluster"))
+			}
+		}
+	}
+
+	if g.Spec.Monitoring != nil {
+		monitoring := cluster.Spec.Monitoring
+		if fi.BoolValue(g.Spec.Monitoring.NodeExporter) && (monitoring == nil || monitoring.NodeExporter == nil) {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "monitoring", "nodeExporter"), "node-exporter must be configured in the cluster spec.monitoring.nodeExporter"))
+		}
+		if g.Spec.Monitoring.CloudWatchAgent != nil && cluster.Spec.GetCloudProvider() != kops.CloudProviderAWS {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "monitoring", "cloudWatchAgent"), "the CloudWatch agent is only supported on AWS"))
+		} else if fi.BoolValue(g.Spec.Monitoring.CloudWatchAgent) && (monitoring == nil || monitoring.CloudWatchAgent == nil) {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "monitoring", "cloudWatchAgent"), "the CloudWatch agent must be configured in the cluster spec.monitoring.cloudWatchAgent"))
+		}
+	}
+
+	if g.Spec.EFA != nil && cluster.Spec.GetCloudProvider() != kops.CloudProviderAWS {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "efa"), "Elastic Fabric Adapters are only supported on AWS"))
 	}
 
 	{
@@ -278,6 +432,77 @@ func CrossValidateInstanceGroup(g *kops.InstanceGroup, cluster *kops.Cluster, cl
 	return allErrs
 }
 
+// validateWindowsInstanceGroup restricts Windows instance groups to the features that nodeup supports on Windows
+func validateWindowsInstanceGroup(g *kops.InstanceGroup, cluster *kops.Cluster) field.ErrorList {
+	allErrs := field.ErrorList{}
+	fieldPath := field.NewPath("spec", "image")
+
+	if cluster.Spec.GetCloudProvider() != kops.CloudProviderAWS {
+		allErrs = append(allErrs, field.Forbidden(fieldPath, "Windows instance groups are only supported on AWS"))
+	}
+	if g.Spec.Role != kops.InstanceGroupRoleNode {
+		allErrs = append(allErrs, field.Forbidden(fieldPath, "only instance groups with the Node role can run Windows"))
+	}
+	if g.Spec.Manager == kops.InstanceManagerMetal {
+		allErrs = append(allErrs, field.Forbidden(fieldPath, "metal instance groups cannot run Windows"))
+	}
+	if cluster.IsKubernetesLT("1.23") {
+		allErrs = append(allErrs, field.Forbidden(fieldPath, "Windows instance groups require Kubernetes 1.23 or later"))
+	}
+	if cluster.Spec.ContainerRuntime != "containerd" {
+		allErrs = append(allErrs, field.Forbidden(fieldPath, "Windows instance groups require the containerd container runtime"))
+	} else if cluster.Spec.Containerd != nil && cluster.Spec.Containerd.Version != nil {
+		sv, err := semver.ParseTolerant(*cluster.Spec.Containerd.Version)
+		if err == nil && sv.LT(semver.MustParse("1.6.0")) {
+			allErrs = append(allErrs, field.Forbidden(fieldPath, "Windows instance groups require containerd 1.6 or later"))
+		}
+	}
+	if dns.IsGossipHostname(cluster.ObjectMeta.Name) {
+		allErrs = append(allErrs, field.Forbidden(fieldPath, "Windows instance groups are not supported with gossip DNS"))
+	}
+	if cluster.Spec.EgressProxy != nil {
+		allErrs = append(allErrs, field.Forbidden(fieldPath, "Windows instance groups are not supported with an egress proxy"))
+	}
+	if networking := cluster.Spec.Networking; networking != nil {
+		if networking.Calico == nil && networking.Flannel == nil && networking.CNI == nil {
+			allErrs = append(allErrs, field.Forbidden(fieldPath, "Windows instance groups are only supported with calico, flannel or cni networking"))
+		}
+	}
+	if kubeDNS := cluster.Spec.KubeDNS; kubeDNS != nil && kubeDNS.NodeLocalDNS != nil && fi.BoolValue(kubeDNS.NodeLocalDNS.Enabled) {
+		allErrs = append(allErrs, field.Forbidden(fieldPath, "Windows instance groups are not supported with node-local-dns"))
+	}
+
+	if len(g.Spec.Hooks) > 0 {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "hooks"), "hooks are not supported on Windows"))
+	}
+	if len(g.Spec.FileAssets) > 0 {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "fileAssets"), "file assets are not supported on Windows"))
+	}
+	if len(g.Spec.SysctlParameters) > 0 {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "sysctlParameters"), "sysctls are not supported on Windows"))
+	}
+	if len(g.Spec.Packages) > 0 {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "packages"), "packages are not supported on Windows"))
+	}
+	if len(g.Spec.AdditionalUserData) > 0 {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "additionalUserData"), "additional user-data is not supported on Windows"))
+	}
+	if g.Spec.CloudInit != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "cloudInit"), "cloud-init is not supported on Windows"))
+	}
+	if fi.BoolValue(g.Spec.Ignition) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "ignition"), "Ignition is not supported on Windows"))
+	}
+	if len(g.Spec.Volumes) > 0 {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "volumes"), "volumes are not supported on Windows"))
+	}
+	if len(g.Spec.VolumeMounts) > 0 {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "volumeMounts"), "volume mounts are not supported on Windows"))
+	}
+
+	return allErrs
+}
+
 func ValidateMasterInstanceGroup(g *kops.InstanceGroup, cluster *kops.Cluster) field.ErrorList {
 	allErrs := field.ErrorList{}
 	for _, etcd := range cluster.Spec.EtcdClusters {
@@ -323,18 +548,83 @@ func validateExtraUserData(userData *kops.UserData) field.ErrorList {
 	return allErrs
 }
 
+func validateCloudInit(cloudInit *kops.CloudInitSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if cloudInit.CloudConfig != "" {
+		cloudConfig := make(map[string]interface{})
+		if err := yaml.Unmarshal([]byte(cloudInit.CloudConfig), &cloudConfig); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("cloudConfig"), cloudInit.CloudConfig, fmt.Sprintf("must be a cloud-config map: %v", err)))
+		}
+	}
+
+	return allErrs
+}
+
 // validateInstanceProfile checks the String values for the AuthProfile
 func validateInstanceProfile(v *kops.IAMProfileSpec, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
 	if v != nil && v.Profile != nil {
-		instanceProfileARN := *v.Profile
-		parsedARN, err := arn.Parse(instanceProfileARN)
-		if err != nil || !strings.HasPrefix(parsedARN.Resource, "instance-profile/") {
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("profile"), instanceProfileARN,
-				"Instance Group IAM Instance Profile must be a valid aws arn such as arn:aws:iam::123456789012:instance-profile/KopsExampleRole"))
+		instanceProfile := *v.Profile
+		if instanceProfileCloudProvider(instanceProfile) == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("profile"), instanceProfile,
+				"Instance Group IAM Instance Profile must be a valid aws arn such as arn:aws:iam::123456789012:instance-profile/KopsExampleRole, "+
+					"a gce service account email such as kops-nodes@my-project.iam.gserviceaccount.com "+
+					"or an azure user-assigned identity id such as /subscriptions/<id>/resourceGroups/<group>/providers/Microsoft.ManagedIdentity/userAssignedIdentities/kops-nodes"))
+		}
+	}
+	return allErrs
+}
+
+// instanceProfileCloudProvider returns the cloud provider whose identity format the instance profile matches,
+// or an empty string if it matches none of them.
+func instanceProfileCloudProvider(instanceProfile string) kops.CloudProviderID {
+	if parsedARN, err := arn.Parse(instanceProfile); err == nil && strings.HasPrefix(parsedARN.Resource, "instance-profile/") {
+		return kops.CloudProviderAWS
+	}
+	if _, _, err := gce.SplitServiceAccountEmail(instanceProfile); err == nil {
+		return kops.CloudProviderGCE
+	}
+	if _, err := azure.ParseUserAssignedIdentityID(instanceProfile); err == nil {
+		return kops.CloudProviderAzure
+	}
+	return ""
+}
+
+// crossValidateInstanceProfile checks that the instance profile is an identity of the cluster's cloud provider
+// and, if a cloud is available, that the identity exists and has the permissions the instances require.
+func crossValidateInstanceProfile(g *kops.InstanceGroup, cluster *kops.Cluster, cloud fi.Cloud, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	instanceProfile := *g.Spec.IAM.Profile
+	cloudProvider := cluster.Spec.GetCloudProvider()
+	switch cloudProvider {
+	case kops.CloudProviderAWS, kops.CloudProviderGCE, kops.CloudProviderAzure:
+	default:
+		return append(allErrs, field.Forbidden(fldPath, "Instance Group IAM Instance Profile is only supported on AWS, GCE and Azure"))
+	}
+
+	if found := instanceProfileCloudProvider(instanceProfile); found != cloudProvider {
+		// Values that match no cloud provider are already reported by validateInstanceProfile
+		if found != "" {
+			allErrs = append(allErrs, field.Invalid(fldPath, instanceProfile,
+				fmt.Sprintf("Instance Group IAM Instance Profile is a %s identity, but the cluster runs on %s", found, cloudProvider)))
 		}
+		return allErrs
+	}
+
+	if cloud == nil {
+		return allErrs
 	}
+
+	switch cloudProvider {
+	case kops.CloudProviderGCE:
+		allErrs = append(allErrs, gceValidateInstanceGroupServiceAccount(g, cloud.(gce.GCECloud), fldPath)...)
+	case kops.CloudProviderAzure:
+		allErrs = append(allErrs, azureValidateInstanceGroupIdentity(g, cluster, cloud.(azure.AzureCloud), fldPath)...)
+	}
+
 	return allErrs
 }
 
@@ -57,19 +57,45 @@ func TestValidateInstanceProfile(t *testing.T) {
 				Profile: s("arn:aws-us-gov:iam::123456789012:instance-profile/has/path/S3Access"),
 			},
 		},
+		{
+			Input: &kops.IAMProfileSpec{
+				Profile: s("kops-nodes@my-project.iam.gserviceaccount.com"),
+			},
+		},
+		{
+			Input: &kops.IAMProfileSpec{
+				Profile: s("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/kops-nodes"),
+			},
+		},
+		{
+			Input: &kops.IAMProfileSpec{
+				Profile: s("kops-nodes@example.com"),
+			},
+			ExpectedErrors: []string{"Invalid value::iam.profile"},
+		},
+		{
+			Input: &kops.IAMProfileSpec{
+				Profile: s("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/virtualMachineScaleSets/kops-nodes"),
+			},
+			ExpectedErrors: []string{"Invalid value::iam.profile"},
+		},
 		{
 			Input: &kops.IAMProfileSpec{
 				Profile: s("42"),
 			},
 			ExpectedErrors: []string{"Invalid value::iam.profile"},
-			ExpectedDetail: "Instance Group IAM Instance Profile must be a valid aws arn such as arn:aws:iam::123456789012:instance-profile/KopsExampleRole",
+			ExpectedDetail: "Instance Group IAM Instance Profile must be a valid aws arn such as arn:aws:iam::123456789012:instance-profile/KopsExampleRole, " +
+				"a gce service account email such as kops-nodes@my-project.iam.gserviceaccount.com " +
+				"or an azure user-assigned identity id such as /subscriptions/<id>/resourceGroups/<group>/providers/Microsoft.ManagedIdentity/userAssignedIdentities/kops-nodes",
 		},
 		{
 			Input: &kops.IAMProfileSpec{
 				Profile: s("arn:aws:iam::123456789012:group/division_abc/subdivision_xyz/product_A/Developers"),
 			},
 			ExpectedErrors: []string{"Invalid value::iam.profile"},
-			ExpectedDetail: "Instance Group IAM Instance Profile must be a valid aws arn such as arn:aws:iam::123456789012:instance-profile/KopsExampleRole",
+			ExpectedDetail: "Instance Group IAM Instance Profile must be a valid aws arn such as arn:aws:iam::123456789012:instance-profile/KopsExampleRole, " +
+				"a gce service account email such as kops-nodes@my-project.iam.gserviceaccount.com " +
+				"or an azure user-assigned identity id such as /subscriptions/<id>/resourceGroups/<group>/providers/Microsoft.ManagedIdentity/userAssignedIdentities/kops-nodes",
 		},
 	}
 
@@ -227,6 +253,51 @@ func TestValidBootDevice(t *testing.T) {
 	}
 }
 
+func TestValidVolumes(t *testing.T) {
+	grid := []struct {
+		name         string
+		volumes      []kops.VolumeSpec
+		volumeMounts []kops.VolumeMountSpec
+		expected     []string
+	}{
+		{
+			name: "volumes and mounts",
+			volumes: []kops.VolumeSpec{
+				{Device: "/dev/sdf", Size: 20},
+				{Device: "/dev/sdg", Size: 20},
+			},
+			volumeMounts: []kops.VolumeMountSpec{
+				{Device: "/dev/sdf", Filesystem: "ext4", Path: "/data"},
+				{Device: "/dev/sdg", Filesystem: "xfs", Path: "/logs", FormatOptions: []string{"-L", "logs"}},
+			},
+		},
+		{
+			name: "duplicate volume device",
+			volumes: []kops.VolumeSpec{
+				{Device: "/dev/sdf", Size: 20},
+				{Device: "/dev/sdf", Size: 20},
+			},
+			expected: []string{"Duplicate value::spec.volumes[1].device"},
+		},
+		{
+			name: "duplicate mount device and path",
+			volumeMounts: []kops.VolumeMountSpec{
+				{Device: "/dev/sdf", Filesystem: "ext4", Path: "/data"},
+				{Device: "/dev/sdf", Filesystem: "ext4", Path: "/data"},
+			},
+			expected: []string{"Duplicate value::spec.volumeMounts[1].device", "Duplicate value::spec.volumeMounts[1].path"},
+		},
+	}
+
+	for _, g := range grid {
+		ig := createMinimalInstanceGroup()
+		ig.Spec.Volumes = g.volumes
+		ig.Spec.VolumeMounts = g.volumeMounts
+		errs := ValidateInstanceGroup(ig, nil, true)
+		testErrors(t, g.name, errs, g.expected)
+	}
+}
+
 func TestValidNodeLabels(t *testing.T) {
 	grid := []struct {
 		label    string
@@ -382,6 +453,58 @@ func TestIGUpdatePolicy(t *testing.T) {
 	}
 }
 
+func TestIGDedicatedHosts(t *testing.T) {
+	const hostResourceGroupARN = "arn:aws:resource-groups:us-east-1:123456789012:group/byol-hosts"
+	for _, test := range []struct {
+		label                string
+		tenancy              string
+		hostResourceGroupARN *string
+		hostAffinity         *string
+		expected             []string
+	}{
+		{
+			label:   "host tenancy",
+			tenancy: "host",
+		},
+		{
+			label:                "host resource group",
+			tenancy:              "host",
+			hostResourceGroupARN: fi.String(hostResourceGroupARN),
+			hostAffinity:         fi.String("host"),
+		},
+		{
+			label:                "host resource group without host tenancy",
+			tenancy:              "dedicated",
+			hostResourceGroupARN: fi.String(hostResourceGroupARN),
+			hostAffinity:         fi.String("default"),
+			expected: []string{
+				"Forbidden::spec.hostResourceGroupARN",
+				"Forbidden::spec.hostAffinity",
+			},
+		},
+		{
+			label:                "invalid values",
+			tenancy:              "host",
+			hostResourceGroupARN: fi.String("byol-hosts"),
+			hostAffinity:         fi.String("any"),
+			expected: []string{
+				"Invalid value::spec.hostResourceGroupARN",
+				"Unsupported value::spec.hostAffinity",
+			},
+		},
+	} {
+		ig := createMinimalInstanceGroup()
+
+		t.Run(test.label, func(t *testing.T) {
+			ig.Spec.Tenancy = test.tenancy
+			ig.Spec.HostResourceGroupARN = test.hostResourceGroupARN
+			ig.Spec.HostAffinity = test.hostAffinity
+			errs := ValidateInstanceGroup(ig, nil, true)
+			testErrors(t, test.label, errs, test.expected)
+		})
+	}
+}
+
 func TestValidInstanceGroup(t *testing.T) {
 	grid := []struct {
 		IG             *kops.InstanceGroup
@@ -474,3 +597,536 @@ func createMinimalInstanceGroup() *kops.InstanceGroup {
 	}
 	return ig
 }
+
+func TestIGMonitoring(t *testing.T) {
+	for _, test := range []struct {
+		label         string
+		cloudProvider kops.CloudProviderSpec
+		monitoring    *kops.MonitoringSpec
+		igMonitoring  *kops.InstanceGroupMonitoringSpec
+		expected      []string
+	}{
+		{
+			label:         "enabled",
+			cloudProvider: kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			monitoring: &kops.MonitoringSpec{
+				NodeExporter:    &kops.MonitoringAgentConfig{},
+				CloudWatchAgent: &kops.MonitoringAgentConfig{},
+			},
+			igMonitoring: &kops.InstanceGroupMonitoringSpec{
+				NodeExporter:    fi.Bool(true),
+				CloudWatchAgent: fi.Bool(true),
+			},
+		},
+		{
+			label:         "disabled without cluster config",
+			cloudProvider: kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			igMonitoring: &kops.InstanceGroupMonitoringSpec{
+				NodeExporter:    fi.Bool(false),
+				CloudWatchAgent: fi.Bool(false),
+			},
+		},
+		{
+			label:         "enabled without cluster config",
+			cloudProvider: kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			igMonitoring: &kops.InstanceGroupMonitoringSpec{
+				NodeExporter:    fi.Bool(true),
+				CloudWatchAgent: fi.Bool(true),
+			},
+			expected: []string{
+				"Forbidden::spec.monitoring.nodeExporter",
+				"Forbidden::spec.monitoring.cloudWatchAgent",
+			},
+		},
+		{
+			label:         "cloudwatch agent on gce",
+			cloudProvider: kops.CloudProviderSpec{GCE: &kops.GCESpec{}},
+			monitoring: &kops.MonitoringSpec{
+				NodeExporter: &kops.MonitoringAgentConfig{},
+			},
+			igMonitoring: &kops.InstanceGroupMonitoringSpec{
+				NodeExporter:    fi.Bool(true),
+				CloudWatchAgent: fi.Bool(false),
+			},
+			expected: []string{"Forbidden::spec.monitoring.cloudWatchAgent"},
+		},
+	} {
+		ig := createMinimalInstanceGroup()
+
+		t.Run(test.label, func(t *testing.T) {
+			cluster := &kops.Cluster{
+				Spec: kops.ClusterSpec{
+					CloudProvider: test.cloudProvider,
+					Monitoring:    test.monitoring,
+				},
+			}
+			ig.Spec.Monitoring = test.igMonitoring
+			errs := CrossValidateInstanceGroup(ig, cluster, nil, true)
+			testErrors(t, test.label, errs, test.expected)
+		})
+	}
+}
+
+func TestIGExternalManager(t *testing.T) {
+	for _, test := range []struct {
+		label         string
+		cloudProvider kops.CloudProviderSpec
+		role          kops.InstanceGroupRole
+		warmPool      *kops.WarmPoolSpec
+		expected      []string
+	}{
+		{
+			label:         "nodes on aws",
+			cloudProvider: kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			role:          kops.InstanceGroupRoleNode,
+		},
+		{
+			label:         "nodes on gce",
+			cloudProvider: kops.CloudProviderSpec{GCE: &kops.GCESpec{}},
+			role:          kops.InstanceGroupRoleNode,
+			expected:      []string{"Forbidden::spec.manager"},
+		},
+		{
+			label:         "bastion",
+			cloudProvider: kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			role:          kops.InstanceGroupRoleBastion,
+			expected:      []string{"Forbidden::spec.manager"},
+		},
+		{
+			label:         "warm pool",
+			cloudProvider: kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			role:          kops.InstanceGroupRoleNode,
+			warmPool:      &kops.WarmPoolSpec{},
+			expected:      []string{"Forbidden::spec.warmPool"},
+		},
+	} {
+		ig := createMinimalInstanceGroup()
+
+		t.Run(test.label, func(t *testing.T) {
+			cluster := &kops.Cluster{
+				Spec: kops.ClusterSpec{
+					CloudProvider: test.cloudProvider,
+				},
+			}
+			ig.Spec.Manager = kops.InstanceManagerExternal
+			ig.Spec.Role = test.role
+			ig.Spec.WarmPool = test.warmPool
+			errs := CrossValidateInstanceGroup(ig, cluster, nil, true)
+			testErrors(t, test.label, errs, test.expected)
+		})
+	}
+}
+
+func TestIGMetalManager(t *testing.T) {
+	for _, test := range []struct {
+		label         string
+		clusterName   string
+		cloudProvider kops.CloudProviderSpec
+		networking    *kops.NetworkingSpec
+		role          kops.InstanceGroupRole
+		expected      []string
+	}{
+		{
+			label:         "nodes on aws",
+			cloudProvider: kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			role:          kops.InstanceGroupRoleNode,
+		},
+		{
+			label:         "nodes on gce",
+			cloudProvider: kops.CloudProviderSpec{GCE: &kops.GCESpec{}},
+			role:          kops.InstanceGroupRoleNode,
+			expected:      []string{"Forbidden::spec.manager"},
+		},
+		{
+			label:         "control plane",
+			cloudProvider: kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			role:          kops.InstanceGroupRoleAPIServer,
+			expected:      []string{"Forbidden::spec.manager"},
+		},
+		{
+			label:         "gossip",
+			clusterName:   "cluster.k8s.local",
+			cloudProvider: kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			role:          kops.InstanceGroupRoleNode,
+			expected:      []string{"Forbidden::spec.manager"},
+		},
+		{
+			label:         "amazon vpc",
+			cloudProvider: kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			networking:    &kops.NetworkingSpec{AmazonVPC: &kops.AmazonVPCNetworkingSpec{}},
+			role:          kops.InstanceGroupRoleNode,
+			expected:      []string{"Forbidden::spec.manager"},
+		},
+	} {
+		ig := createMinimalInstanceGroup()
+
+		t.Run(test.label, func(t *testing.T) {
+			clusterName := test.clusterName
+			if clusterName == "" {
+				clusterName = "cluster.example.com"
+			}
+			cluster := &kops.Cluster{
+				ObjectMeta: v1.ObjectMeta{Name: clusterName},
+				Spec: kops.ClusterSpec{
+					CloudProvider: test.cloudProvider,
+					Networking:    test.networking,
+				},
+			}
+			ig.Spec.Manager = kops.InstanceManagerMetal
+			ig.Spec.Role = test.role
+			errs := CrossValidateInstanceGroup(ig, cluster, nil, true)
+			testErrors(t, test.label, errs, test.expected)
+		})
+	}
+}
+
+func TestIGInstanceProfile(t *testing.T) {
+	for _, test := range []struct {
+		label         string
+		cloudProvider kops.CloudProviderSpec
+		profile       string
+		expected      []string
+	}{
+		{
+			label:         "instance profile on aws",
+			cloudProvider: kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			profile:       "arn:aws:iam::123456789012:instance-profile/kops-nodes",
+		},
+		{
+			label:         "service account on gce",
+			cloudProvider: kops.CloudProviderSpec{GCE: &kops.GCESpec{}},
+			profile:       "kops-nodes@my-project.iam.gserviceaccount.com",
+		},
+		{
+			label:         "user-assigned identity on azure",
+			cloudProvider: kops.CloudProviderSpec{Azure: &kops.AzureSpec{}},
+			profile:       "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/kops-nodes",
+		},
+		{
+			label:         "instance profile on gce",
+			cloudProvider: kops.CloudProviderSpec{GCE: &kops.GCESpec{}},
+			profile:       "arn:aws:iam::123456789012:instance-profile/kops-nodes",
+			expected:      []string{"Invalid value::spec.iam.profile"},
+		},
+		{
+			label:         "service account on azure",
+			cloudProvider: kops.CloudProviderSpec{Azure: &kops.AzureSpec{}},
+			profile:       "kops-nodes@my-project.iam.gserviceaccount.com",
+			expected:      []string{"Invalid value::spec.iam.profile"},
+		},
+		{
+			label:         "openstack",
+			cloudProvider: kops.CloudProviderSpec{Openstack: &kops.OpenstackSpec{}},
+			profile:       "arn:aws:iam::123456789012:instance-profile/kops-nodes",
+			expected:      []string{"Forbidden::spec.iam.profile"},
+		},
+	} {
+		ig := createMinimalInstanceGroup()
+
+		t.Run(test.label, func(t *testing.T) {
+			cluster := &kops.Cluster{
+				Spec: kops.ClusterSpec{
+					CloudProvider: test.cloudProvider,
+				},
+			}
+			ig.Spec.IAM = &kops.IAMProfileSpec{Profile: fi.String(test.profile)}
+			errs := CrossValidateInstanceGroup(ig, cluster, nil, true)
+			testErrors(t, test.label, errs, test.expected)
+		})
+	}
+}
+
+func TestIGAssetCache(t *testing.T) {
+	for _, test := range []struct {
+		label      string
+		assetCache *kops.AssetCacheSpec
+		expected   []string
+	}{
+		{
+			label: "path and url",
+			assetCache: &kops.AssetCacheSpec{
+				Path: fi.String("/mnt/assets"),
+				URL:  fi.String("https://assets.s3-accelerate.amazonaws.com/cache"),
+			},
+		},
+		{
+			label:      "relative path",
+			assetCache: &kops.AssetCacheSpec{Path: fi.String("mnt/assets")},
+			expected:   []string{"Invalid value::spec.assetCache.path"},
+		},
+		{
+			label:      "s3 url",
+			assetCache: &kops.AssetCacheSpec{URL: fi.String("s3://assets/cache")},
+			expected:   []string{"Invalid value::spec.assetCache.url"},
+		},
+	} {
+		ig := createMinimalInstanceGroup()
+
+		t.Run(test.label, func(t *testing.T) {
+			ig.Spec.AssetCache = test.assetCache
+			errs := ValidateInstanceGroup(ig, nil, true)
+			testErrors(t, test.label, errs, test.expected)
+		})
+	}
+}
+
+func TestIGContainerdRegistries(t *testing.T) {
+	registries := map[string]kops.ContainerdRegistryConfig{
+		"docker.io": {Mirrors: []kops.ContainerdRegistryMirror{{Endpoint: "https://mirror.example.com"}}},
+	}
+
+	for _, test := range []struct {
+		label         string
+		cluster       *kops.ContainerdConfig
+		instanceGroup *kops.ContainerdConfig
+		expected      []string
+	}{
+		{
+			label:         "registries in both",
+			cluster:       &kops.ContainerdConfig{Registries: registries},
+			instanceGroup: &kops.ContainerdConfig{Registries: registries},
+		},
+		{
+			label:         "registries with cluster configOverride",
+			cluster:       &kops.ContainerdConfig{ConfigOverride: fi.String("version = 2")},
+			instanceGroup: &kops.ContainerdConfig{Registries: registries},
+			expected:      []string{"Forbidden::spec.containerd.registries"},
+		},
+		{
+			label:         "configOverride with cluster registries",
+			cluster:       &kops.ContainerdConfig{Registries: registries},
+			instanceGroup: &kops.ContainerdConfig{ConfigOverride: fi.String("version = 2")},
+			expected:      []string{"Forbidden::spec.containerd.configOverride"},
+		},
+	} {
+		ig := createMinimalInstanceGroup()
+
+		t.Run(test.label, func(t *testing.T) {
+			cluster := &kops.Cluster{
+				Spec: kops.ClusterSpec{
+					CloudProvider:    kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+					ContainerRuntime: "containerd",
+					Containerd:       test.cluster,
+				},
+			}
+			ig.Spec.Containerd = test.instanceGroup
+			errs := CrossValidateInstanceGroup(ig, cluster, nil, true)
+			testErrors(t, test.label, errs, test.expected)
+		})
+	}
+}
+
+func TestIGPreloadImages(t *testing.T) {
+	for _, test := range []struct {
+		label    string
+		images   []string
+		expected []string
+	}{
+		{
+			label:  "images",
+			images: []string{"registry.k8s.io/pause:3.6", "quay.io/cilium/cilium:v1.11.6"},
+		},
+		{
+			label:    "empty image",
+			images:   []string{""},
+			expected: []string{"Invalid value::spec.preloadImages[0]"},
+		},
+		{
+			label:    "duplicate image",
+			images:   []string{"registry.k8s.io/pause:3.6", "registry.k8s.io/pause:3.6"},
+			expected: []string{"Duplicate value::spec.preloadImages[1]"},
+		},
+	} {
+		ig := createMinimalInstanceGroup()
+
+		t.Run(test.label, func(t *testing.T) {
+			ig.Spec.PreloadImages = test.images
+			errs := ValidateInstanceGroup(ig, nil, true)
+			testErrors(t, test.label, errs, test.expected)
+		})
+	}
+}
+
+func TestIGCloudInit(t *testing.T) {
+	for _, test := range []struct {
+		label       string
+		cloudConfig string
+		expected    []string
+	}{
+		{
+			label:       "cloud-config",
+			cloudConfig: "packages:\n- nfs-common\n",
+		},
+		{
+			label: "empty",
+		},
+		{
+			label:       "list",
+			cloudConfig: "- nfs-common\n",
+			expected:    []string{"Invalid value::spec.cloudInit.cloudConfig"},
+		},
+	} {
+		ig := createMinimalInstanceGroup()
+
+		t.Run(test.label, func(t *testing.T) {
+			ig.Spec.CloudInit = &kops.CloudInitSpec{CloudConfig: test.cloudConfig}
+			errs := ValidateInstanceGroup(ig, nil, true)
+			testErrors(t, test.label, errs, test.expected)
+		})
+	}
+}
+
+func TestIGNodeUp(t *testing.T) {
+	for _, test := range []struct {
+		label    string
+		nodeUp   *kops.PackagesConfig
+		expected []string
+	}{
+		{
+			label: "amd64",
+			nodeUp: &kops.PackagesConfig{
+				UrlAmd64:  fi.String("https://example.com/nodeup-amd64"),
+				HashAmd64: fi.String("833723369ad345a88dd85d61b1e77336d56e61b864557ded71b92b6e34158e6a"),
+			},
+		},
+		{
+			label: "missing hash",
+			nodeUp: &kops.PackagesConfig{
+				UrlArm64: fi.String("https://example.com/nodeup-arm64"),
+			},
+			expected: []string{"Invalid value::spec.nodeup.packageUrlArm64"},
+		},
+	} {
+		ig := createMinimalInstanceGroup()
+
+		t.Run(test.label, func(t *testing.T) {
+			ig.Spec.NodeUp = test.nodeUp
+			errs := ValidateInstanceGroup(ig, nil, true)
+			testErrors(t, test.label, errs, test.expected)
+		})
+	}
+}
+
+func TestIGIgnition(t *testing.T) {
+	for _, test := range []struct {
+		label     string
+		image     string
+		ignition  *bool
+		cloudInit *kops.CloudInitSpec
+		expected  []string
+	}{
+		{
+			label: "flatcar",
+			image: "075585003325/Flatcar-stable-3139.2.3-hvm",
+		},
+		{
+			label:     "flatcar with cloud-init",
+			image:     "075585003325/Flatcar-stable-3139.2.3-hvm",
+			cloudInit: &kops.CloudInitSpec{},
+			expected:  []string{"Forbidden::spec.cloudInit"},
+		},
+		{
+			label:     "flatcar without ignition",
+			image:     "075585003325/Flatcar-stable-3139.2.3-hvm",
+			ignition:  fi.Bool(false),
+			cloudInit: &kops.CloudInitSpec{},
+		},
+		{
+			label:     "ignition with cloud-init",
+			image:     "ami-0123456789abcdef0",
+			ignition:  fi.Bool(true),
+			cloudInit: &kops.CloudInitSpec{},
+			expected:  []string{"Forbidden::spec.cloudInit"},
+		},
+	} {
+		ig := createMinimalInstanceGroup()
+
+		t.Run(test.label, func(t *testing.T) {
+			ig.Spec.Image = test.image
+			ig.Spec.Ignition = test.ignition
+			ig.Spec.CloudInit = test.cloudInit
+			errs := ValidateInstanceGroup(ig, nil, true)
+			testErrors(t, test.label, errs, test.expected)
+		})
+	}
+}
+
+func TestIGWindows(t *testing.T) {
+	for _, test := range []struct {
+		label            string
+		cloudProvider    kops.CloudProviderSpec
+		containerRuntime string
+		networking       *kops.NetworkingSpec
+		role             kops.InstanceGroupRole
+		hooks            []kops.HookSpec
+		expected         []string
+	}{
+		{
+			label:            "nodes on aws",
+			cloudProvider:    kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			containerRuntime: "containerd",
+			networking:       &kops.NetworkingSpec{Calico: &kops.CalicoNetworkingSpec{}},
+			role:             kops.InstanceGroupRoleNode,
+		},
+		{
+			label:            "nodes on gce",
+			cloudProvider:    kops.CloudProviderSpec{GCE: &kops.GCESpec{}},
+			containerRuntime: "containerd",
+			networking:       &kops.NetworkingSpec{Calico: &kops.CalicoNetworkingSpec{}},
+			role:             kops.InstanceGroupRoleNode,
+			expected:         []string{"Forbidden::spec.image"},
+		},
+		{
+			label:            "control plane",
+			cloudProvider:    kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			containerRuntime: "containerd",
+			networking:       &kops.NetworkingSpec{Calico: &kops.CalicoNetworkingSpec{}},
+			role:             kops.InstanceGroupRoleAPIServer,
+			expected:         []string{"Forbidden::spec.image"},
+		},
+		{
+			label:            "docker",
+			cloudProvider:    kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			containerRuntime: "docker",
+			networking:       &kops.NetworkingSpec{Calico: &kops.CalicoNetworkingSpec{}},
+			role:             kops.InstanceGroupRoleNode,
+			expected:         []string{"Forbidden::spec.image"},
+		},
+		{
+			label:            "cilium",
+			cloudProvider:    kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			containerRuntime: "containerd",
+			networking:       &kops.NetworkingSpec{Cilium: &kops.CiliumNetworkingSpec{}},
+			role:             kops.InstanceGroupRoleNode,
+			expected:         []string{"Forbidden::spec.image"},
+		},
+		{
+			label:            "hooks",
+			cloudProvider:    kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			containerRuntime: "containerd",
+			networking:       &kops.NetworkingSpec{Calico: &kops.CalicoNetworkingSpec{}},
+			role:             kops.InstanceGroupRoleNode,
+			hooks:            []kops.HookSpec{{Name: "hook", Manifest: "[Service]"}},
+			expected:         []string{"Forbidden::spec.hooks"},
+		},
+	} {
+		ig := createMinimalInstanceGroup()
+
+		t.Run(test.label, func(t *testing.T) {
+			cluster := &kops.Cluster{
+				ObjectMeta: v1.ObjectMeta{Name: "cluster.example.com"},
+				Spec: kops.ClusterSpec{
+					CloudProvider:     test.cloudProvider,
+					ContainerRuntime:  test.containerRuntime,
+					KubernetesVersion: "1.24.0",
+					Networking:        test.networking,
+				},
+			}
+			ig.Spec.Image = "Windows_Server-2019-English-Full-ContainersLatest-2022.05.11"
+			ig.Spec.Role = test.role
+			ig.Spec.Hooks = test.hooks
+			errs := CrossValidateInstanceGroup(ig, cluster, nil, true)
+			testErrors(t, test.label, errs, test.expected)
+		})
+	}
+}
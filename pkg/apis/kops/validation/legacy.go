@@ -22,11 +22,13 @@ import (
 	"strings"
 
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/apis/kops/util"
 	"k8s.io/kops/pkg/featureflag"
 	"k8s.io/kops/pkg/util/subnet"
 	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/gce"
 	"k8s.io/kops/util/pkg/vfs"
 )
 
@@ -423,16 +425,16 @@ func validateServiceAccountIssuerDiscovery(c *kops.Cluster, said *kops.ServiceAc
 			allErrs = append(allErrs, field.Invalid(saidStoreField, saidStore, "not a valid VFS path"))
 		} else {
 			switch base := base.(type) {
-			case *vfs.S3Path:
+			case *vfs.S3Path, *vfs.GSPath, *vfs.AzureBlobPath:
 				// OK
 			case *vfs.MemFSPath:
 				// memfs is ok for tests; not OK otherwise
 				if !base.IsClusterReadable() {
 					// (If this _is_ a test, we should call MarkClusterReadable)
-					allErrs = append(allErrs, field.Invalid(saidStoreField, saidStore, "S3 is the only supported VFS for discoveryStore"))
+					allErrs = append(allErrs, field.Invalid(saidStoreField, saidStore, "only S3, GCS and Azure Blob are supported for discoveryStore"))
 				}
 			default:
-				allErrs = append(allErrs, field.Invalid(saidStoreField, saidStore, "S3 is the only supported VFS for discoveryStore"))
+				allErrs = append(allErrs, field.Invalid(saidStoreField, saidStore, "only S3, GCS and Azure Blob are supported for discoveryStore"))
 			}
 		}
 	}
@@ -445,6 +447,18 @@ func validateServiceAccountIssuerDiscovery(c *kops.Cluster, said *kops.ServiceAc
 			allErrs = append(allErrs, field.Forbidden(enableOIDCField, "AWS OIDC Provider requires a discovery store"))
 		}
 	}
+	if said.GCPWorkloadIdentityProvider != "" {
+		providerField := fieldSpec.Child("serviceAccountIssuerDiscovery", "gcpWorkloadIdentityProvider")
+		if c.Spec.GetCloudProvider() != kops.CloudProviderGCE {
+			allErrs = append(allErrs, field.Forbidden(providerField, "GCP workload identity provider is only supported on GCE"))
+		}
+		if saidStore == "" {
+			allErrs = append(allErrs, field.Forbidden(providerField, "GCP workload identity provider requires a discovery store"))
+		}
+		if !gce.IsWorkloadIdentityProviderName(said.GCPWorkloadIdentityProvider) {
+			allErrs = append(allErrs, field.Invalid(providerField, said.GCPWorkloadIdentityProvider, "must be of the form projects/<number>/locations/global/workloadIdentityPools/<pool>/providers/<provider>"))
+		}
+	}
 
 	return allErrs
 }
@@ -503,11 +517,21 @@ func DeepValidate(c *kops.Cluster, groups []*kops.InstanceGroup, strict bool, cl
 		if len(errs) != 0 {
 			return errs.ToAggregate()
 		}
+
+		if c.Spec.GetCloudProvider() == kops.CloudProviderAWS && !requiresIMDSv2(c, g) {
+			klog.Warningf("InstanceGroup %q allows IMDSv1; set spec.instanceMetadata.httpTokens to \"required\" in the Cluster or InstanceGroup to require IMDSv2", g.ObjectMeta.Name)
+		}
 	}
 
 	return nil
 }
 
+// requiresIMDSv2 is true if the instances of the instance group only accept session-token (IMDSv2) instance metadata requests.
+func requiresIMDSv2(c *kops.Cluster, g *kops.InstanceGroup) bool {
+	instanceMetadata := c.Spec.InstanceMetadata.ResolveDefaults(g)
+	return instanceMetadata != nil && fi.StringValue(instanceMetadata.HTTPTokens) == "required"
+}
+
 func isExperimentalClusterDNS(k *kops.KubeletConfigSpec, dns *kops.KubeDNSConfig) bool {
 	return k != nil && k.ClusterDNS != dns.ServerIP && dns.NodeLocalDNS != nil && k.ClusterDNS != dns.NodeLocalDNS.LocalIP
 }
@@ -22,13 +22,17 @@ import (
 	"net"
 	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/blang/semver/v4"
+	"github.com/pelletier/go-toml"
 	"golang.org/x/net/ipv4"
 	"golang.org/x/net/ipv6"
 	"k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	utilnet "k8s.io/apimachinery/pkg/util/net"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -36,10 +40,12 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/kops/model"
 	"k8s.io/kops/pkg/dns"
 	"k8s.io/kops/pkg/featureflag"
 	"k8s.io/kops/pkg/model/components"
 	"k8s.io/kops/pkg/model/iam"
+	"k8s.io/kops/pkg/pki"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/utils"
 )
@@ -143,10 +149,24 @@ func validateClusterSpec(spec *kops.ClusterSpec, c *kops.Cluster, fieldPath *fie
 		allErrs = append(allErrs, validateKubeAPIServer(spec.KubeAPIServer, c, fieldPath.Child("kubeAPIServer"))...)
 	}
 
+	if spec.KubeControllerManager != nil {
+		allErrs = append(allErrs, validateProbeSpec(spec.KubeControllerManager.LivenessProbe, fieldPath.Child("kubeControllerManager", "livenessProbe"))...)
+		allErrs = append(allErrs, validateProbeSpec(spec.KubeControllerManager.StartupProbe, fieldPath.Child("kubeControllerManager", "startupProbe"))...)
+	}
+
+	if spec.KubeScheduler != nil {
+		allErrs = append(allErrs, validateProbeSpec(spec.KubeScheduler.LivenessProbe, fieldPath.Child("kubeScheduler", "livenessProbe"))...)
+		allErrs = append(allErrs, validateProbeSpec(spec.KubeScheduler.StartupProbe, fieldPath.Child("kubeScheduler", "startupProbe"))...)
+	}
+
 	if spec.ExternalCloudControllerManager == nil && spec.IsIPv6Only() {
 		allErrs = append(allErrs, field.Required(fieldPath.Child("cloudControllerManager"), "IPv6 requires external Cloud Controller Manager"))
 	}
 
+	if spec.IsIPv6Only() {
+		allErrs = append(allErrs, validateIPv6Only(c, fieldPath)...)
+	}
+
 	if spec.KubeProxy != nil {
 		allErrs = append(allErrs, validateKubeProxy(spec.KubeProxy, fieldPath.Child("kubeProxy"))...)
 	}
@@ -170,6 +190,26 @@ func validateClusterSpec(spec *kops.ClusterSpec, c *kops.Cluster, fieldPath *fie
 		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("nodeAuthorization"), "NodeAuthorization must be empty. The functionality has been reimplemented and is enabled on kubernetes >= 1.19.0."))
 	}
 
+	if spec.NodeCertificates != nil {
+		allErrs = append(allErrs, validateNodeCertificates(c, spec.NodeCertificates, fieldPath.Child("nodeCertificates"))...)
+	}
+
+	if spec.NodeLabelsFromCloudTags != nil {
+		allErrs = append(allErrs, validateNodeLabelsFromCloudTags(c, spec.NodeLabelsFromCloudTags, fieldPath.Child("nodeLabelsFromCloudTags"))...)
+	}
+
+	if spec.DNSRecords != nil {
+		allErrs = append(allErrs, validateDNSRecords(c, spec.DNSRecords, fieldPath.Child("dnsRecords"))...)
+	}
+
+	if spec.GossipConfig != nil {
+		allErrs = append(allErrs, validateGossipConfig(c, spec.GossipConfig, fieldPath.Child("gossipConfig"))...)
+	}
+
+	if spec.DNSControllerGossipConfig != nil {
+		allErrs = append(allErrs, validateDNSControllerGossipConfig(spec.DNSControllerGossipConfig, fieldPath.Child("dnsControllerGossipConfig"))...)
+	}
+
 	if spec.ClusterAutoscaler != nil {
 		allErrs = append(allErrs, validateClusterAutoscaler(c, spec.ClusterAutoscaler, fieldPath.Child("clusterAutoscaler"))...)
 	}
@@ -194,6 +234,10 @@ func validateClusterSpec(spec *kops.ClusterSpec, c *kops.Cluster, fieldPath *fie
 		allErrs = append(allErrs, validateSnapshotController(c, spec.SnapshotController, fieldPath.Child("snapshotController"))...)
 	}
 
+	if spec.KeyStoreKMSKey != "" {
+		allErrs = append(allErrs, validateKeyStoreKMSKey(spec, fieldPath.Child("keyStoreKMSKey"))...)
+	}
+
 	// IAM additional policies
 	if spec.AdditionalPolicies != nil {
 		for k, v := range *spec.AdditionalPolicies {
@@ -230,6 +274,10 @@ func validateClusterSpec(spec *kops.ClusterSpec, c *kops.Cluster, fieldPath *fie
 		allErrs = append(allErrs, validateContainerdConfig(spec, spec.Containerd, fieldPath.Child("containerd"), true)...)
 	}
 
+	if spec.Crio != nil {
+		allErrs = append(allErrs, validateCrioConfig(c, spec.Crio, fieldPath.Child("crio"))...)
+	}
+
 	if spec.Docker != nil {
 		allErrs = append(allErrs, validateDockerConfig(spec.Docker, fieldPath.Child("docker"))...)
 	}
@@ -238,12 +286,19 @@ func validateClusterSpec(spec *kops.ClusterSpec, c *kops.Cluster, fieldPath *fie
 		if spec.Assets.ContainerProxy != nil && spec.Assets.ContainerRegistry != nil {
 			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("assets", "containerProxy"), "containerProxy cannot be used in conjunction with containerRegistry"))
 		}
+		if spec.Assets.TrustPolicy != nil {
+			allErrs = append(allErrs, validateAssetTrustPolicy(spec.Assets.TrustPolicy, fieldPath.Child("assets", "trustPolicy"))...)
+		}
 	}
 
 	if spec.RollingUpdate != nil {
 		allErrs = append(allErrs, validateRollingUpdate(spec.RollingUpdate, fieldPath.Child("rollingUpdate"), false)...)
 	}
 
+	if len(spec.ValidationChecks) > 0 {
+		allErrs = append(allErrs, validateValidationChecks(spec.ValidationChecks, fieldPath.Child("validationChecks"))...)
+	}
+
 	if spec.API != nil && spec.API.LoadBalancer != nil {
 		lbSpec := spec.API.LoadBalancer
 		lbPath := fieldPath.Child("api", "loadBalancer")
@@ -284,16 +339,36 @@ func validateClusterSpec(spec *kops.ClusterSpec, c *kops.Cluster, fieldPath *fie
 		}
 	}
 
+	if spec.InstanceMetadata != nil {
+		if spec.GetCloudProvider() != kops.CloudProviderAWS {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("instanceMetadata"), "instance metadata options only supported on AWS"))
+		} else {
+			allErrs = append(allErrs, awsValidateInstanceMetadata(fieldPath.Child("instanceMetadata"), spec.InstanceMetadata)...)
+		}
+	}
+
 	if spec.IAM != nil {
 		if spec.IAM.Legacy {
 			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("iam", "legacy"), "legacy IAM permissions are no longer supported"))
 		}
 
+		if spec.IAM.ScopeResourceARNs && spec.GetCloudProvider() != kops.CloudProviderAWS {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("iam", "scopeResourceARNs"), "scopeResourceARNs is only supported on AWS"))
+		}
+
+		if spec.IAM.Path != "" && spec.GetCloudProvider() != kops.CloudProviderAWS {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("iam", "path"), "path is only supported on AWS"))
+		}
+
 		if len(spec.IAM.ServiceAccountExternalPermissions) > 0 {
-			if spec.ServiceAccountIssuerDiscovery == nil || !spec.ServiceAccountIssuerDiscovery.EnableAWSOIDCProvider {
+			if spec.GetCloudProvider() == kops.CloudProviderGCE {
+				if spec.ServiceAccountIssuerDiscovery == nil || spec.ServiceAccountIssuerDiscovery.GCPWorkloadIdentityProvider == "" {
+					allErrs = append(allErrs, field.Forbidden(fieldPath.Child("iam", "serviceAccountExternalPermissions"), "serviceAccountExternalPermissions requires a GCP workload identity provider"))
+				}
+			} else if spec.ServiceAccountIssuerDiscovery == nil || !spec.ServiceAccountIssuerDiscovery.EnableAWSOIDCProvider {
 				allErrs = append(allErrs, field.Forbidden(fieldPath.Child("iam", "serviceAccountExternalPermissions"), "serviceAccountExternalPermissions requires AWS OIDC Provider to be enabled"))
 			}
-			allErrs = append(allErrs, validateSAExternalPermissions(spec.IAM.ServiceAccountExternalPermissions, fieldPath.Child("iam", "serviceAccountExternalPermissions"))...)
+			allErrs = append(allErrs, validateSAExternalPermissions(spec.GetCloudProvider(), spec.IAM.ServiceAccountExternalPermissions, fieldPath.Child("iam", "serviceAccountExternalPermissions"))...)
 		}
 	}
 
@@ -307,10 +382,42 @@ func validateClusterSpec(spec *kops.ClusterSpec, c *kops.Cluster, fieldPath *fie
 		allErrs = append(allErrs, validatePodIdentityWebhook(c, spec.PodIdentityWebhook, fieldPath.Child("podIdentityWebhook"))...)
 	}
 
+	if spec.Monitoring != nil {
+		allErrs = append(allErrs, validateMonitoring(spec, fieldPath.Child("monitoring"))...)
+	}
+
+	if spec.CertificateIssuer != nil {
+		allErrs = append(allErrs, validateCertificateIssuer(spec, spec.CertificateIssuer, fieldPath.Child("certificateIssuer"))...)
+	}
+
+	return allErrs
+}
+
+func validateMonitoring(spec *kops.ClusterSpec, fieldPath *field.Path) (allErrs field.ErrorList) {
+	if spec.Monitoring.NodeExporter != nil {
+		allErrs = append(allErrs, validateMonitoringAgent(spec.Monitoring.NodeExporter, fieldPath.Child("nodeExporter"))...)
+	}
+
+	if spec.Monitoring.CloudWatchAgent != nil {
+		if spec.GetCloudProvider() != kops.CloudProviderAWS {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("cloudWatchAgent"), "the CloudWatch agent is only supported on AWS"))
+		} else {
+			allErrs = append(allErrs, validateMonitoringAgent(spec.Monitoring.CloudWatchAgent, fieldPath.Child("cloudWatchAgent"))...)
+		}
+	}
+
 	return allErrs
 }
 
-func validateSAExternalPermissions(externalPermissions []kops.ServiceAccountExternalPermission, path *field.Path) (allErrs field.ErrorList) {
+func validateMonitoringAgent(agent *kops.MonitoringAgentConfig, fieldPath *field.Path) (allErrs field.ErrorList) {
+	if agent.ResourcePreset != nil {
+		allErrs = append(allErrs, IsValidValue(fieldPath.Child("resourcePreset"), agent.ResourcePreset, kops.MonitoringResourcePresets)...)
+	}
+
+	return allErrs
+}
+
+func validateSAExternalPermissions(cloudProvider kops.CloudProviderID, externalPermissions []kops.ServiceAccountExternalPermission, path *field.Path) (allErrs field.ErrorList) {
 	if len(externalPermissions) == 0 {
 		return allErrs
 	}
@@ -330,6 +437,31 @@ func validateSAExternalPermissions(externalPermissions []kops.ServiceAccountExte
 			allErrs = append(allErrs, field.Duplicate(p, key))
 		}
 		sas[key] = ""
+
+		if cloudProvider == kops.CloudProviderGCE {
+			gcp := sa.GCP
+			gp := p.Child("gcp")
+			if sa.AWS != nil {
+				allErrs = append(allErrs, field.Forbidden(p.Child("aws"), "AWS permissions are only supported on AWS"))
+			}
+			if gcp == nil {
+				allErrs = append(allErrs, field.Required(gp, "GCP permissions must be set"))
+				continue
+			}
+			if len(gcp.Roles) == 0 {
+				allErrs = append(allErrs, field.Required(gp.Child("roles"), "at least one role must be set"))
+			}
+			for i, role := range gcp.Roles {
+				if !strings.HasPrefix(role, "roles/") && !strings.HasPrefix(role, "projects/") && !strings.HasPrefix(role, "organizations/") {
+					allErrs = append(allErrs, field.Invalid(gp.Child("roles").Index(i), role, "must be the name of an IAM role"))
+				}
+			}
+			continue
+		}
+
+		if sa.GCP != nil {
+			allErrs = append(allErrs, field.Forbidden(p.Child("gcp"), "GCP permissions are only supported on GCE"))
+		}
 		aws := sa.AWS
 		ap := p.Child("aws")
 		if aws == nil {
@@ -395,6 +527,43 @@ func validateIPv6CIDR(cidr string, fieldPath *field.Path) field.ErrorList {
 	return allErrs
 }
 
+// validateIPv6Only checks that the settings of an IPv6-only cluster, and the addons it enables, support IPv6
+func validateIPv6Only(c *kops.Cluster, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	spec := &c.Spec
+
+	if spec.GetCloudProvider() != kops.CloudProviderAWS {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("nonMasqueradeCIDR"), "IPv6 is only supported on AWS"))
+	}
+
+	if spec.PodCIDR != "" && !utils.IsIPv6CIDR(spec.PodCIDR) {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("podCIDR"), spec.PodCIDR, "IPv6 clusters require an IPv6 podCIDR"))
+	}
+
+	if spec.GetCloudProvider() == kops.CloudProviderAWS {
+		// Nodes draw the IPv6 prefixes of their pods from their subnet
+		for i, subnet := range spec.Subnets {
+			if subnet.Type != kops.SubnetTypeUtility && subnet.ProviderID == "" && subnet.IPv6CIDR == "" {
+				allErrs = append(allErrs, field.Required(fieldPath.Child("subnets").Index(i).Child("ipv6CIDR"), "subnets of IPv6 clusters must have an ipv6CIDR"))
+			}
+		}
+	}
+
+	if spec.KubeDNS != nil && spec.KubeDNS.Provider == "KubeDNS" {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("kubeDNS", "provider"), "KubeDNS does not support IPv6; use CoreDNS"))
+	}
+
+	if spec.ExternalDNS != nil && spec.ExternalDNS.Provider == kops.ExternalDNSProviderExternalDNS {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("externalDNS", "provider"), "external-dns does not support registering AAAA records"))
+	}
+
+	if spec.Karpenter != nil && spec.Karpenter.Enabled {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("karpenter", "enabled"), "Karpenter does not support IPv6"))
+	}
+
+	return allErrs
+}
+
 func validateTopology(c *kops.Cluster, topology *kops.TopologySpec, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -584,6 +753,268 @@ func validateExecContainerAction(v *kops.ExecContainerAction, fldPath *field.Pat
 	return allErrs
 }
 
+func validateNodeCertificates(c *kops.Cluster, spec *kops.NodeCertificatesSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if !model.UseKopsControllerForNodeBootstrap(c) {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "nodeCertificates requires nodes to bootstrap with kops-controller"))
+	}
+
+	if spec.Validity == nil {
+		allErrs = append(allErrs, field.Required(fldPath.Child("validity"), "validity must be specified"))
+		return allErrs
+	}
+	if spec.Validity.Duration < time.Hour {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("validity"), spec.Validity.Duration.String(), "validity must be at least 1h"))
+	}
+
+	renewBefore := spec.RenewBeforeDuration()
+	if renewBefore <= 0 || renewBefore >= spec.Validity.Duration {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("renewBefore"), renewBefore.String(), "renewBefore must be positive and less than validity"))
+	}
+
+	return allErrs
+}
+
+func validateNodeLabelsFromCloudTags(c *kops.Cluster, spec *kops.NodeLabelsFromCloudTagsSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	switch c.Spec.GetCloudProvider() {
+	case kops.CloudProviderAWS, kops.CloudProviderAzure:
+	default:
+		allErrs = append(allErrs, field.Forbidden(fldPath, "nodeLabelsFromCloudTags is only supported on AWS and Azure"))
+	}
+
+	if len(spec.Tags) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("tags"), "at least one tag must be specified"))
+	}
+
+	if spec.Prefix != "" && !strings.HasSuffix(spec.Prefix, "/") {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("prefix"), spec.Prefix, "prefix must end with \"/\""))
+	} else {
+		for i, tag := range spec.Tags {
+			for _, msg := range utilvalidation.IsQualifiedName(spec.LabelPrefix() + tag) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("tags").Index(i), tag, fmt.Sprintf("label %q is not valid: %s", spec.LabelPrefix()+tag, msg)))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func validateGossipConfig(c *kops.Cluster, spec *kops.GossipConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	// protokube gossips with mesh and memberlist unless configured otherwise
+	protocols := []string{"mesh", "memberlist"}
+	listens := []*string{spec.Listen, nil}
+	if spec.Protocol != nil {
+		protocols[0] = *spec.Protocol
+	}
+	if spec.Secondary != nil {
+		protocols[1] = fi.StringValue(spec.Secondary.Protocol)
+		listens[1] = spec.Secondary.Listen
+		allErrs = append(allErrs, validateGossipProtocol(spec.Secondary.Protocol, spec.Secondary.Listen, fldPath.Child("secondary"))...)
+	}
+	allErrs = append(allErrs, validateGossipProtocol(spec.Protocol, spec.Listen, fldPath)...)
+	if listens[0] != nil && listens[1] != nil && protocols[1] != "" && *listens[0] == *listens[1] {
+		allErrs = append(allErrs, field.Duplicate(fldPath.Child("secondary", "listen"), *listens[1]))
+	}
+
+	if fi.BoolValue(spec.Encryption) {
+		if !dns.IsGossipHostname(c.ObjectMeta.Name) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("encryption"), "gossip encryption requires gossip DNS"))
+		}
+		if protocols[0] != "memberlist" && protocols[1] != "memberlist" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("encryption"), "gossip encryption requires the memberlist protocol"))
+		}
+	}
+
+	allErrs = append(allErrs, validateGossipIntervals(protocols, spec.ProbeInterval, spec.GossipInterval, spec.PushPullInterval, fldPath)...)
+
+	return allErrs
+}
+
+func validateDNSControllerGossipConfig(spec *kops.DNSControllerGossipConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	// dns-controller only gossips with a secondary protocol if one is configured
+	protocols := []string{"mesh", ""}
+	if spec.Protocol != nil {
+		protocols[0] = *spec.Protocol
+	}
+	allErrs = append(allErrs, validateGossipProtocol(spec.Protocol, spec.Listen, fldPath)...)
+	if spec.Secondary != nil {
+		protocols[1] = fi.StringValue(spec.Secondary.Protocol)
+		allErrs = append(allErrs, validateGossipProtocol(spec.Secondary.Protocol, spec.Secondary.Listen, fldPath.Child("secondary"))...)
+		if spec.Listen != nil && spec.Secondary.Listen != nil && protocols[1] != "" && *spec.Listen == *spec.Secondary.Listen {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("secondary", "listen"), *spec.Secondary.Listen))
+		}
+	}
+
+	allErrs = append(allErrs, validateGossipIntervals(protocols, spec.ProbeInterval, spec.GossipInterval, spec.PushPullInterval, fldPath)...)
+
+	return allErrs
+}
+
+// validateGossipProtocol validates the protocol and listen address of a primary or secondary gossip configuration.
+func validateGossipProtocol(protocol *string, listen *string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if protocol != nil && *protocol != "" {
+		allErrs = append(allErrs, IsValidValue(fldPath.Child("protocol"), protocol, []string{"mesh", "memberlist"})...)
+	}
+
+	if listen != nil {
+		_, port, err := net.SplitHostPort(*listen)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("listen"), *listen, "must be an address:port"))
+		} else if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("listen"), *listen, "must have a port between 1 and 65535"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateGossipIntervals validates the memberlist intervals, which only apply if one of the protocols is memberlist.
+func validateGossipIntervals(protocols []string, probeInterval, gossipInterval, pushPullInterval *metav1.Duration, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for _, interval := range []struct {
+		name  string
+		value *metav1.Duration
+	}{
+		{"probeInterval", probeInterval},
+		{"gossipInterval", gossipInterval},
+		{"pushPullInterval", pushPullInterval},
+	} {
+		if interval.value == nil {
+			continue
+		}
+		if interval.value.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(interval.name), interval.value.Duration.String(), "must be greater than zero"))
+		}
+		if protocols[0] != "memberlist" && protocols[1] != "memberlist" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child(interval.name), "gossip intervals require the memberlist protocol"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateDNSRecords(c *kops.Cluster, spec *kops.DNSRecordsSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if dns.IsGossipHostname(c.ObjectMeta.Name) {
+		return append(allErrs, field.Forbidden(fldPath, "dnsRecords cannot be used with gossip DNS"))
+	}
+
+	apiLoadBalancer := c.Spec.API != nil && c.Spec.API.LoadBalancer != nil
+	if spec.API != nil {
+		allErrs = append(allErrs, validateDNSRecord(c, spec.API, apiLoadBalancer, fldPath.Child("api"))...)
+	}
+	if spec.APIInternal != nil {
+		allErrs = append(allErrs, validateDNSRecord(c, spec.APIInternal, apiLoadBalancer && c.Spec.API.LoadBalancer.UseForInternalAPI, fldPath.Child("apiInternal"))...)
+	}
+	if spec.Bastion != nil {
+		allErrs = append(allErrs, validateDNSRecord(c, spec.Bastion, true, fldPath.Child("bastion"))...)
+	}
+	if spec.KopsController != nil {
+		allErrs = append(allErrs, validateDNSRecord(c, spec.KopsController, false, fldPath.Child("kopsController"))...)
+	}
+
+	return allErrs
+}
+
+// validateDNSRecord validates the configuration of a DNS record.
+// Records that point to a load balancer are aliases unless their type is CNAME; records that don't are A and AAAA records.
+func validateDNSRecord(c *kops.Cluster, spec *kops.DNSRecordSpec, loadBalancer bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spec.Type != "" {
+		if errs := IsValidValue(fldPath.Child("type"), fi.String(string(spec.Type)), kops.SupportedDNSRecordTypes); len(errs) > 0 {
+			allErrs = append(allErrs, errs...)
+		} else if c.Spec.GetCloudProvider() != kops.CloudProviderAWS {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("type"), "type is only supported on AWS"))
+		} else if !loadBalancer {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("type"), "type can only be set for names that point to a load balancer"))
+		}
+	}
+
+	if spec.TTL != nil {
+		if *spec.TTL <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("ttl"), *spec.TTL, "ttl must be greater than zero"))
+		} else if loadBalancer && spec.Type != kops.DNSRecordTypeCNAME {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("ttl"), "alias records do not have a TTL; set type to CNAME to use a TTL"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateAdmissionPluginConfig(v *kops.AdmissionPluginConfigSpec, apiServer *kops.KubeAPIServerConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if apiServer.AdmissionControlConfigFile != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "admissionPluginConfig cannot be used together with admissionControlConfigFile"))
+	}
+	if len(apiServer.AdmissionControl) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "admissionPluginConfig cannot be used together with the deprecated admissionControl"))
+	}
+
+	if v.EventRateLimit != nil {
+		fldPath := fldPath.Child("eventRateLimit")
+		if sets.NewString(apiServer.DisableAdmissionPlugins...).Has("EventRateLimit") {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "EventRateLimit admission plugin is disabled"))
+		}
+		if len(v.EventRateLimit.Limits) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("limits"), "at least one limit must be specified"))
+		}
+		for i, limit := range v.EventRateLimit.Limits {
+			fldPath := fldPath.Child("limits").Index(i)
+			allErrs = append(allErrs, IsValidValue(fldPath.Child("type"), &limit.Type, []string{"Server", "Namespace", "User", "SourceAndObject"})...)
+			if limit.QPS <= 0 {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("qps"), limit.QPS, "must be greater than 0"))
+			}
+			if limit.Burst <= 0 {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("burst"), limit.Burst, "must be greater than 0"))
+			}
+			if limit.CacheSize != nil {
+				if limit.Type == "Server" {
+					allErrs = append(allErrs, field.Forbidden(fldPath.Child("cacheSize"), "cacheSize does not apply to limits of type Server"))
+				} else if *limit.CacheSize <= 0 {
+					allErrs = append(allErrs, field.Invalid(fldPath.Child("cacheSize"), *limit.CacheSize, "must be greater than 0"))
+				}
+			}
+		}
+	}
+
+	if v.ImagePolicyWebhook != nil {
+		fldPath := fldPath.Child("imagePolicyWebhook")
+		if sets.NewString(apiServer.DisableAdmissionPlugins...).Has("ImagePolicyWebhook") {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "ImagePolicyWebhook admission plugin is disabled"))
+		}
+		if v.ImagePolicyWebhook.KubeConfig == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("kubeConfig"), "kubeConfig for the image policy backend must be specified"))
+		}
+		for _, ttl := range []struct {
+			name  string
+			value *int32
+		}{
+			{"allowTTL", v.ImagePolicyWebhook.AllowTTL},
+			{"denyTTL", v.ImagePolicyWebhook.DenyTTL},
+			{"retryBackoff", v.ImagePolicyWebhook.RetryBackoff},
+		} {
+			if ttl.value != nil && *ttl.value <= 0 {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child(ttl.name), *ttl.value, "must be greater than 0"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
 func validateKubeAPIServer(v *kops.KubeAPIServerConfig, c *kops.Cluster, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -594,6 +1025,10 @@ func validateKubeAPIServer(v *kops.KubeAPIServerConfig, c *kops.Cluster, fldPath
 		}
 	}
 
+	if v.AdmissionPluginConfig != nil {
+		allErrs = append(allErrs, validateAdmissionPluginConfig(v.AdmissionPluginConfig, v, fldPath.Child("admissionPluginConfig"))...)
+	}
+
 	proxyClientCertIsNil := v.ProxyClientCertFile == nil
 	proxyClientKeyIsNil := v.ProxyClientKeyFile == nil
 
@@ -651,6 +1086,86 @@ func validateKubeAPIServer(v *kops.KubeAPIServerConfig, c *kops.Cluster, fldPath
 		}
 	}
 
+	if v.HealthcheckSidecar != nil {
+		allErrs = append(allErrs, validateKubeAPIServerHealthcheckSidecar(v.HealthcheckSidecar, v, fldPath.Child("healthcheckSidecar"))...)
+	}
+
+	if v.MaxRequestsInflight < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxRequestsInflight"), v.MaxRequestsInflight, "must not be negative"))
+	}
+	if v.MaxMutatingRequestsInflight < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxMutatingRequestsInflight"), v.MaxMutatingRequestsInflight, "must not be negative"))
+	}
+
+	if len(v.FlowSchemaPresets) > 0 {
+		if v.EnablePriorityAndFairness != nil && !*v.EnablePriorityAndFairness {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("flowSchemaPresets"), "flowSchemaPresets require API Priority and Fairness to be enabled"))
+		}
+		presets := sets.NewString()
+		for i, preset := range v.FlowSchemaPresets {
+			presetPath := fldPath.Child("flowSchemaPresets").Index(i)
+			if presets.Has(preset) {
+				allErrs = append(allErrs, field.Duplicate(presetPath, preset))
+			}
+			presets.Insert(preset)
+			allErrs = append(allErrs, IsValidValue(presetPath, &preset, kops.FlowSchemaPresets)...)
+		}
+	}
+
+	allErrs = append(allErrs, validateProbeSpec(v.LivenessProbe, fldPath.Child("livenessProbe"))...)
+	allErrs = append(allErrs, validateProbeSpec(v.ReadinessProbe, fldPath.Child("readinessProbe"))...)
+	allErrs = append(allErrs, validateProbeSpec(v.StartupProbe, fldPath.Child("startupProbe"))...)
+
+	return allErrs
+}
+
+func validateKubeAPIServerHealthcheckSidecar(h *kops.KubeAPIServerHealthcheckSidecarConfig, v *kops.KubeAPIServerConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if h.Port != nil {
+		port := *h.Port
+		if port <= 0 || port > 65535 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("port"), port, "must be between 1 and 65535"))
+		} else if port == 443 || port == v.SecurePort {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("port"), port, "must not conflict with the kube-apiserver secure port"))
+		}
+	}
+
+	if h.Path != nil {
+		allErrs = append(allErrs, IsValidValue(fldPath.Child("path"), h.Path, []string{"/healthz", "/livez", "/readyz"})...)
+	}
+
+	for i, check := range h.AdditionalChecks {
+		if check == "" || strings.HasPrefix(check, "/") || strings.ContainsAny(check, "?#") || strings.Contains(check, "..") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("additionalChecks").Index(i), check, "must be the name of an individual apiserver health check, such as \"etcd\""))
+		}
+	}
+	if len(h.AdditionalChecks) != 0 && h.Path == nil {
+		allErrs = append(allErrs, field.Required(fldPath.Child("path"), "path must be set when additionalChecks are specified"))
+	}
+
+	return allErrs
+}
+
+func validateProbeSpec(p *kops.ProbeSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if p == nil {
+		return allErrs
+	}
+
+	if p.InitialDelaySeconds != nil && *p.InitialDelaySeconds < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("initialDelaySeconds"), *p.InitialDelaySeconds, "must not be negative"))
+	}
+	if p.TimeoutSeconds != nil && *p.TimeoutSeconds < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("timeoutSeconds"), *p.TimeoutSeconds, "must be at least 1"))
+	}
+	if p.PeriodSeconds != nil && *p.PeriodSeconds < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("periodSeconds"), *p.PeriodSeconds, "must be at least 1"))
+	}
+	if p.FailureThreshold != nil && *p.FailureThreshold < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("failureThreshold"), *p.FailureThreshold, "must be at least 1"))
+	}
+
 	return allErrs
 }
 
@@ -748,6 +1263,17 @@ func validateKubelet(k *kops.KubeletConfigSpec, c *kops.Cluster, kubeletPath *fi
 			}
 		}
 
+		if fi.BoolValue(k.ImageCredentialProviders) {
+			if c.IsKubernetesLT("1.24") {
+				allErrs = append(allErrs, field.Forbidden(kubeletPath.Child("imageCredentialProviders"), "imageCredentialProviders requires Kubernetes 1.24+"))
+			}
+			switch c.Spec.GetCloudProvider() {
+			case kops.CloudProviderAWS, kops.CloudProviderGCE, kops.CloudProviderAzure:
+			default:
+				allErrs = append(allErrs, field.Forbidden(kubeletPath.Child("imageCredentialProviders"), "imageCredentialProviders is only supported on AWS, GCE and Azure"))
+			}
+		}
+
 		if k.ShutdownGracePeriodCriticalPods != nil {
 			if k.ShutdownGracePeriod == nil {
 				allErrs = append(allErrs, field.Forbidden(kubeletPath.Child("shutdownGracePeriodCriticalPods"), "shutdownGracePeriodCriticalPods require shutdownGracePeriod"))
@@ -795,8 +1321,12 @@ func validateNetworking(cluster *kops.Cluster, v *kops.NetworkingSpec, fldPath *
 		}
 	}
 
-	if v.CNI != nil && optionTaken {
-		allErrs = append(allErrs, field.Forbidden(fldPath.Child("cni"), "only one networking option permitted"))
+	if v.CNI != nil {
+		if optionTaken {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("cni"), "only one networking option permitted"))
+		}
+
+		allErrs = append(allErrs, validateNetworkingCNI(v.CNI, fldPath.Child("cni"))...)
 	}
 
 	if v.Weave != nil {
@@ -895,6 +1425,31 @@ func validateNetworking(cluster *kops.Cluster, v *kops.NetworkingSpec, fldPath *
 	return allErrs
 }
 
+var sha256Regex = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+func validateNetworkingCNI(v *kops.CNINetworkingSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if v.ManifestURL == "" {
+		if v.ManifestSHA256 != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("sha256"), "sha256 requires manifestURL to be set"))
+		}
+		return allErrs
+	}
+
+	if u, err := url.Parse(v.ManifestURL); err != nil || u.Scheme == "" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("manifestURL"), v.ManifestURL, "must be a URL"))
+	}
+
+	if v.ManifestSHA256 == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("sha256"), "the hash of the manifest is required when manifestURL is set"))
+	} else if !sha256Regex.MatchString(v.ManifestSHA256) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("sha256"), v.ManifestSHA256, "must be a lowercase hex-encoded SHA256 hash"))
+	}
+
+	return allErrs
+}
+
 func validateNetworkingFlannel(c *kops.Cluster, v *kops.FlannelNetworkingSpec, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -963,6 +1518,13 @@ func validateNetworkingCilium(cluster *kops.Cluster, v *kops.CiliumNetworkingSpe
 			allErrs = append(allErrs, field.Invalid(versionFld, v.Version, "Only version 1.11 with patch version 5 or higher is supported"))
 		}
 
+		if v.EnableEnvoyConfig && version.LT(semver.MustParse("1.12.0")) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("enableEnvoyConfig"), "CiliumEnvoyConfig requires Cilium 1.12 or later"))
+		}
+		if v.EnableGatewayAPI && version.LT(semver.MustParse("1.13.0")) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("enableGatewayAPI"), "Gateway API requires Cilium 1.13 or later"))
+		}
+
 		if v.Hubble != nil && fi.BoolValue(v.Hubble.Enabled) {
 			if !components.IsCertManagerEnabled(cluster) {
 				allErrs = append(allErrs, field.Forbidden(fldPath.Child("hubble", "enabled"), "Hubble requires that cert manager is enabled"))
@@ -1012,6 +1574,19 @@ func validateNetworkingCilium(cluster *kops.Cluster, v *kops.CiliumNetworkingSpe
 		}
 	}
 
+	if v.EnableEnvoyConfig && v.EnableL7Proxy != nil && !*v.EnableL7Proxy {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("enableEnvoyConfig"), "CiliumEnvoyConfig requires the L7 proxy"))
+	}
+
+	if v.EnableGatewayAPI {
+		if v.EnableL7Proxy != nil && !*v.EnableL7Proxy {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("enableGatewayAPI"), "Gateway API requires the L7 proxy"))
+		}
+		if !v.EnableNodePort {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("enableGatewayAPI"), "Gateway API requires enableNodePort"))
+		}
+	}
+
 	if fi.BoolValue(v.EnableL7Proxy) && v.InstallIptablesRules != nil && !*v.InstallIptablesRules {
 		allErrs = append(allErrs, field.Forbidden(fldPath.Child("enableL7Proxy"), "Cilium L7 Proxy requires installIptablesRules."))
 	}
@@ -1030,6 +1605,24 @@ func validateNetworkingCilium(cluster *kops.Cluster, v *kops.CiliumNetworkingSpe
 			if c.IsIPv6Only() {
 				allErrs = append(allErrs, field.Forbidden(fldPath.Child("ipam"), "Cilium ENI IPAM does not support IPv6"))
 			}
+			if c.Kubelet != nil && fi.StringValue(c.Kubelet.NetworkPluginName) == "kubenet" {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Root().Child("spec", "kubelet", "networkPluginName"), "Cilium ENI IPAM cannot be used with kubenet"))
+			}
+			if c.MasterKubelet != nil && fi.StringValue(c.MasterKubelet.NetworkPluginName) == "kubenet" {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Root().Child("spec", "masterKubelet", "networkPluginName"), "Cilium ENI IPAM cannot be used with kubenet"))
+			}
+		}
+	}
+
+	if v.ENI != nil {
+		eniPath := fldPath.Child("eni")
+		if v.IPAM != kops.CiliumIpamEni {
+			allErrs = append(allErrs, field.Forbidden(eniPath, "ENI settings can only be used when IPAM is \"eni\""))
+		}
+		for i, subnetID := range v.ENI.SubnetIDsFilter {
+			if !strings.HasPrefix(subnetID, "subnet-") {
+				allErrs = append(allErrs, field.Invalid(eniPath.Child("subnetIDsFilter").Index(i), subnetID, "must be a subnet ID"))
+			}
 		}
 	}
 
@@ -1130,6 +1723,104 @@ func validateEtcdClusterSpec(spec kops.EtcdClusterSpec, c *kops.Cluster, fieldPa
 	for i, m := range spec.Members {
 		allErrs = append(allErrs, validateEtcdMemberSpec(m, fieldPath.Child("etcdMembers").Index(i))...)
 	}
+	allErrs = append(allErrs, validateEtcdTuning(spec, fieldPath)...)
+	if spec.Backups != nil && spec.Backups.CrossAccountStore != nil {
+		allErrs = append(allErrs, validateEtcdCrossAccountStore(spec.Backups, c, fieldPath.Child("backups", "crossAccountStore"))...)
+	}
+
+	return allErrs
+}
+
+// validateEtcdCrossAccountStore checks the cross-account backup store is outside of the state store
+func validateEtcdCrossAccountStore(spec *kops.EtcdBackupSpec, c *kops.Cluster, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	store := spec.CrossAccountStore
+	if store.BackupStore == "" {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("backupStore"), "backupStore must be set"))
+	} else {
+		for _, p := range []string{c.Spec.ConfigBase, spec.BackupStore} {
+			if p == "" {
+				continue
+			}
+			base := strings.TrimSuffix(p, "/") + "/"
+			if strings.HasPrefix(strings.TrimSuffix(store.BackupStore, "/")+"/", base) {
+				allErrs = append(allErrs, field.Invalid(fieldPath.Child("backupStore"), store.BackupStore, fmt.Sprintf("must be outside of %q", p)))
+				break
+			}
+		}
+	}
+
+	if store.RoleARN != "" {
+		if c.Spec.GetCloudProvider() != kops.CloudProviderAWS {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("roleARN"), "roleARN is only supported on AWS"))
+		} else if parsedARN, err := arn.Parse(store.RoleARN); err != nil || !strings.HasPrefix(parsedARN.Resource, "role/") {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("roleARN"), store.RoleARN,
+				"roleARN must be a valid IAM Role ARN such as arn:aws:iam::123456789012:role/KopsExampleRole"))
+		}
+		if store.BackupStore != "" && !strings.HasPrefix(store.BackupStore, "s3://") {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("roleARN"), "roleARN is only supported with S3 backup stores"))
+		}
+	} else if store.ExternalID != "" {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("externalID"), "externalID requires roleARN"))
+	}
+
+	return allErrs
+}
+
+// validateEtcdTuning checks the etcd tuning settings against the constraints etcd enforces on startup
+func validateEtcdTuning(spec kops.EtcdClusterSpec, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	// The etcd defaults
+	heartbeatInterval := 100 * time.Millisecond
+	electionTimeout := 1000 * time.Millisecond
+	if spec.HeartbeatInterval != nil {
+		heartbeatInterval = spec.HeartbeatInterval.Duration
+		if heartbeatInterval < time.Millisecond {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("heartbeatInterval"), spec.HeartbeatInterval.Duration.String(), "must be at least 1ms"))
+		}
+	}
+	if spec.LeaderElectionTimeout != nil {
+		electionTimeout = spec.LeaderElectionTimeout.Duration
+		if electionTimeout > 50*time.Second {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("leaderElectionTimeout"), spec.LeaderElectionTimeout.Duration.String(), "must be at most 50s"))
+		}
+	}
+	if electionTimeout < 5*heartbeatInterval {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("leaderElectionTimeout"), electionTimeout.String(),
+			fmt.Sprintf("must be at least five times the heartbeat interval (%v)", heartbeatInterval)))
+	}
+
+	if spec.QuotaBackendBytes != nil && spec.QuotaBackendBytes.Sign() <= 0 {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("quotaBackendBytes"), spec.QuotaBackendBytes.String(), "must be greater than 0"))
+	}
+
+	compactionMode := "periodic"
+	if spec.AutoCompactionMode != "" {
+		compactionMode = spec.AutoCompactionMode
+		allErrs = append(allErrs, IsValidValue(fieldPath.Child("autoCompactionMode"), &spec.AutoCompactionMode, []string{"periodic", "revision"})...)
+	}
+	if spec.AutoCompactionRetention != "" {
+		retention := spec.AutoCompactionRetention
+		switch compactionMode {
+		case "periodic":
+			// A bare number is a number of hours
+			if _, err := strconv.ParseUint(retention, 10, 64); err != nil {
+				if d, err := time.ParseDuration(retention); err != nil || d < 0 {
+					allErrs = append(allErrs, field.Invalid(fieldPath.Child("autoCompactionRetention"), retention, "must be a duration, such as 1h, in periodic mode"))
+				}
+			}
+		case "revision":
+			if _, err := strconv.ParseUint(retention, 10, 64); err != nil {
+				allErrs = append(allErrs, field.Invalid(fieldPath.Child("autoCompactionRetention"), retention, "must be a number of revisions in revision mode"))
+			}
+		}
+	}
+
+	if spec.SnapshotCount != nil && *spec.SnapshotCount < 1 {
+		allErrs = append(allErrs, field.Invalid(fieldPath.Child("snapshotCount"), *spec.SnapshotCount, "must be greater than 0"))
+	}
 
 	return allErrs
 }
@@ -1220,6 +1911,16 @@ func validateNetworkingCalico(c *kops.ClusterSpec, v *kops.CalicoNetworkingSpec,
 		}
 	}
 
+	if v.BPFEnabled {
+		// The eBPF dataplane takes over the role of kube-proxy, connecting to the API server directly.
+		if c.KubeProxy != nil && fi.BoolValue(c.KubeProxy.Enabled) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Root().Child("spec", "kubeProxy", "enabled"), "Calico eBPF dataplane requires kubeProxy to be disabled"))
+		}
+		if c.IsIPv6Only() {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("bpfEnabled"), "Calico eBPF dataplane does not support IPv6"))
+		}
+	}
+
 	if v.BPFExternalServiceMode != "" {
 		valid := []string{"Tunnel", "DSR"}
 		allErrs = append(allErrs, IsValidValue(fldPath.Child("bpfExternalServiceMode"), &v.BPFExternalServiceMode, valid)...)
@@ -1362,7 +2063,7 @@ func validateCalicoEncapsulationMode(mode string, fldPath *field.Path) field.Err
 }
 
 func validateContainerRuntime(c *kops.Cluster, runtime string, fldPath *field.Path) field.ErrorList {
-	valid := []string{"containerd", "docker"}
+	valid := []string{"containerd", "crio", "docker"}
 
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, IsValidValue(fldPath, &runtime, valid)...)
@@ -1371,6 +2072,49 @@ func validateContainerRuntime(c *kops.Cluster, runtime string, fldPath *field.Pa
 		allErrs = append(allErrs, field.Forbidden(fldPath, "Docker CRI support was removed in Kubernetes 1.24: https://kubernetes.io/blog/2020/12/02/dockershim-faq"))
 	}
 
+	if runtime == "crio" {
+		if c.IsKubernetesLT("1.22") {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "CRI-O is only supported for Kubernetes 1.22 or later"))
+		}
+		if components.UsesKubenet(c.Spec.Networking) {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "CRI-O is not supported with kubenet networking"))
+		}
+		if components.IsBaseURL(c.Spec.KubernetesVersion) {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "CRI-O cannot load the images of custom Kubernetes builds"))
+		}
+		for i, hook := range c.Spec.Hooks {
+			if hook.ExecContainer != nil {
+				allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "hooks").Index(i).Child("execContainer"), "execContainer hooks are not supported with CRI-O"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func validateCrioConfig(c *kops.Cluster, config *kops.CrioConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if config.Version != nil {
+		sv, err := semver.ParseTolerant(*config.Version)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("version"), config.Version,
+				fmt.Sprintf("unable to parse version string: %s", err.Error())))
+		} else if c.Spec.ContainerRuntime == "crio" {
+			// CRI-O releases follow the Kubernetes minor versions
+			minor := fmt.Sprintf("%d.%d", sv.Major, sv.Minor)
+			next := fmt.Sprintf("%d.%d", sv.Major, sv.Minor+1)
+			if c.IsKubernetesLT(minor) || c.IsKubernetesGTE(next) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("version"), config.Version,
+					"the minor version of CRI-O must match the minor version of Kubernetes"))
+			}
+		}
+	}
+
+	if config.Packages != nil {
+		allErrs = append(allErrs, validatePackagesConfig(config.Packages, fldPath)...)
+	}
+
 	return allErrs
 }
 
@@ -1390,51 +2134,158 @@ func validateContainerdConfig(spec *kops.ClusterSpec, config *kops.ContainerdCon
 	}
 
 	if config.Packages != nil {
-		if config.Packages.UrlAmd64 != nil && config.Packages.HashAmd64 != nil {
-			u := fi.StringValue(config.Packages.UrlAmd64)
-			_, err := url.Parse(u)
-			if err != nil {
-				allErrs = append(allErrs, field.Invalid(fldPath.Child("packageUrl"), config.Packages.UrlAmd64,
-					fmt.Sprintf("cannot parse package URL: %v", err)))
-			}
-			h := fi.StringValue(config.Packages.HashAmd64)
-			if len(h) > 64 {
-				allErrs = append(allErrs, field.Invalid(fldPath.Child("packageHash"), config.Packages.HashAmd64,
-					"Package hash must be 64 characters long"))
-			}
-		} else if config.Packages.UrlAmd64 != nil {
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("packageUrl"), config.Packages.HashAmd64,
-				"Package hash must also be set"))
-		} else if config.Packages.HashAmd64 != nil {
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("packageHash"), config.Packages.HashAmd64,
-				"Package URL must also be set"))
-		}
+		allErrs = append(allErrs, validatePackagesConfig(config.Packages, fldPath)...)
+	}
 
-		if config.Packages.UrlArm64 != nil && config.Packages.HashArm64 != nil {
-			u := fi.StringValue(config.Packages.UrlArm64)
-			_, err := url.Parse(u)
-			if err != nil {
-				allErrs = append(allErrs, field.Invalid(fldPath.Child("packageUrlArm64"), config.Packages.UrlArm64,
-					fmt.Sprintf("cannot parse package URL: %v", err)))
-			}
-			h := fi.StringValue(config.Packages.HashArm64)
-			if len(h) > 64 {
-				allErrs = append(allErrs, field.Invalid(fldPath.Child("packageHashArm64"), config.Packages.HashArm64,
-					"Package hash must be 64 characters long"))
-			}
-		} else if config.Packages.UrlArm64 != nil {
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("packageUrlArm64"), config.Packages.HashArm64,
-				"Package hash must also be set"))
-		} else if config.Packages.HashArm64 != nil {
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("packageHashArm64"), config.Packages.HashArm64,
-				"Package URL must also be set"))
-		}
+	if config.Runc != nil {
+		allErrs = append(allErrs, validateRuncConfig(config, fldPath.Child("runc"), inClusterConfig)...)
 	}
 
 	if config.NvidiaGPU != nil {
 		allErrs = append(allErrs, validateNvidiaConfig(spec, config.NvidiaGPU, fldPath.Child("nvidia"), inClusterConfig)...)
 	}
 
+	if config.ConfigAdditions != nil {
+		if config.ConfigOverride != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("configAdditions"), "configAdditions cannot be used in conjunction with configOverride"))
+		} else if _, err := toml.Load(*config.ConfigAdditions); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("configAdditions"), "...", fmt.Sprintf("could not parse TOML: %v", err)))
+		}
+	}
+
+	if len(config.Registries) != 0 {
+		allErrs = append(allErrs, validateContainerdRegistries(config, fldPath.Child("registries"))...)
+	}
+
+	return allErrs
+}
+
+func validatePackagesConfig(packages *kops.PackagesConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if packages.UrlAmd64 != nil && packages.HashAmd64 != nil {
+		u := fi.StringValue(packages.UrlAmd64)
+		_, err := url.Parse(u)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("packageUrl"), packages.UrlAmd64,
+				fmt.Sprintf("cannot parse package URL: %v", err)))
+		}
+		h := fi.StringValue(packages.HashAmd64)
+		if len(h) > 64 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("packageHash"), packages.HashAmd64,
+				"Package hash must be 64 characters long"))
+		}
+	} else if packages.UrlAmd64 != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("packageUrl"), packages.HashAmd64,
+			"Package hash must also be set"))
+	} else if packages.HashAmd64 != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("packageHash"), packages.HashAmd64,
+			"Package URL must also be set"))
+	}
+
+	if packages.UrlArm64 != nil && packages.HashArm64 != nil {
+		u := fi.StringValue(packages.UrlArm64)
+		_, err := url.Parse(u)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("packageUrlArm64"), packages.UrlArm64,
+				fmt.Sprintf("cannot parse package URL: %v", err)))
+		}
+		h := fi.StringValue(packages.HashArm64)
+		if len(h) > 64 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("packageHashArm64"), packages.HashArm64,
+				"Package hash must be 64 characters long"))
+		}
+	} else if packages.UrlArm64 != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("packageUrlArm64"), packages.HashArm64,
+			"Package hash must also be set"))
+	} else if packages.HashArm64 != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("packageHashArm64"), packages.HashArm64,
+			"Package URL must also be set"))
+	}
+
+	return allErrs
+}
+
+func validateRuncConfig(config *kops.ContainerdConfig, fldPath *field.Path, inClusterConfig bool) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	// The runc binary is part of the cluster assets, so it can't be picked per instance group
+	if !inClusterConfig {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "runc can only be configured in the cluster spec"))
+		return allErrs
+	}
+
+	// Containerd builds older than v1.6.0 come with their own runc binary
+	if config.Version != nil {
+		sv, err := semver.ParseTolerant(*config.Version)
+		if err == nil && sv.LT(semver.MustParse("1.6.0")) {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "runc can only be configured for containerd 1.6 or later"))
+		}
+	}
+
+	if config.Runc.Version != nil {
+		sv, err := semver.ParseTolerant(*config.Runc.Version)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("version"), config.Runc.Version,
+				fmt.Sprintf("unable to parse version string: %s", err.Error())))
+		} else if sv.LT(semver.MustParse("1.1.0")) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("version"), config.Runc.Version,
+				"unsupported runc version, containerd 1.6 requires runc 1.1.0 or later"))
+		}
+	}
+
+	if config.Runc.Packages != nil {
+		allErrs = append(allErrs, validatePackagesConfig(config.Runc.Packages, fldPath)...)
+	}
+
+	return allErrs
+}
+
+func validateContainerdRegistries(config *kops.ContainerdConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if config.ConfigOverride != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "registries cannot be used in conjunction with configOverride"))
+	}
+	if config.Version != nil {
+		if sv, err := semver.ParseTolerant(*config.Version); err == nil && sv.LT(semver.MustParse("1.5.0")) {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "registries requires containerd 1.5 or later"))
+		}
+	}
+
+	for name, registry := range config.Registries {
+		registryPath := fldPath.Key(name)
+		if name == "" {
+			allErrs = append(allErrs, field.Invalid(registryPath, name, "registry name must not be empty"))
+		} else if name != "*" && strings.ContainsAny(name, "/ ") {
+			allErrs = append(allErrs, field.Invalid(registryPath, name, "registry name must be a host name, optionally with a port"))
+		}
+		if _, found := config.RegistryMirrors[name]; found {
+			allErrs = append(allErrs, field.Forbidden(registryPath, "registry cannot also be configured in registryMirrors"))
+		}
+
+		for i, mirror := range registry.Mirrors {
+			mirrorPath := registryPath.Child("mirrors").Index(i)
+			if mirror.Endpoint == "" {
+				allErrs = append(allErrs, field.Required(mirrorPath.Child("endpoint"), ""))
+			} else if u, err := url.Parse(mirror.Endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				allErrs = append(allErrs, field.Invalid(mirrorPath.Child("endpoint"), mirror.Endpoint, "endpoint must be an http or https URL"))
+			}
+			if mirror.Auth != nil {
+				if mirror.Auth.Username == "" {
+					allErrs = append(allErrs, field.Required(mirrorPath.Child("auth", "username"), ""))
+				}
+				if mirror.Auth.PasswordSecret == "" {
+					allErrs = append(allErrs, field.Required(mirrorPath.Child("auth", "passwordSecret"), ""))
+				} else {
+					for _, msg := range utilvalidation.IsDNS1123Subdomain(mirror.Auth.PasswordSecret) {
+						allErrs = append(allErrs, field.Invalid(mirrorPath.Child("auth", "passwordSecret"), mirror.Auth.PasswordSecret, msg))
+					}
+				}
+			}
+		}
+	}
+
 	return allErrs
 }
 
@@ -1513,8 +2364,10 @@ func validateNvidiaConfig(spec *kops.ClusterSpec, nvidia *kops.NvidiaGPUConfig,
 	if !fi.BoolValue(nvidia.Enabled) {
 		return allErrs
 	}
-	if spec.GetCloudProvider() != kops.CloudProviderAWS && spec.GetCloudProvider() != kops.CloudProviderOpenstack {
-		allErrs = append(allErrs, field.Forbidden(fldPath, "Nvidia is only supported on AWS and OpenStack"))
+	switch spec.GetCloudProvider() {
+	case kops.CloudProviderAWS, kops.CloudProviderGCE, kops.CloudProviderAzure, kops.CloudProviderOpenstack:
+	default:
+		allErrs = append(allErrs, field.Forbidden(fldPath, "Nvidia is only supported on AWS, GCE, Azure and OpenStack"))
 	}
 	if spec.ContainerRuntime != "" && spec.ContainerRuntime != "containerd" {
 		allErrs = append(allErrs, field.Forbidden(fldPath, "Nvidia is only supported using containerd"))
@@ -1525,6 +2378,91 @@ func validateNvidiaConfig(spec *kops.ClusterSpec, nvidia *kops.NvidiaGPUConfig,
 	return allErrs
 }
 
+func validateAssetTrustPolicy(policy *kops.AssetTrustPolicy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	prefixes := sets.NewString()
+	for i, image := range policy.Images {
+		imagePath := fldPath.Child("images").Index(i)
+		if image.Prefix == "" {
+			allErrs = append(allErrs, field.Required(imagePath.Child("prefix"), ""))
+		} else if prefixes.Has(image.Prefix) {
+			allErrs = append(allErrs, field.Duplicate(imagePath.Child("prefix"), image.Prefix))
+		} else {
+			prefixes.Insert(image.Prefix)
+		}
+		if len(image.PublicKeys) == 0 {
+			allErrs = append(allErrs, field.Required(imagePath.Child("publicKeys"), "at least one public key must be specified"))
+		}
+		for j, publicKey := range image.PublicKeys {
+			if _, err := pki.ParsePEMPublicKey([]byte(publicKey)); err != nil {
+				allErrs = append(allErrs, field.Invalid(imagePath.Child("publicKeys").Index(j), "...", fmt.Sprintf("could not parse public key: %v", err)))
+			}
+		}
+	}
+
+	urls := sets.NewString()
+	for i, file := range policy.Files {
+		filePath := fldPath.Child("files").Index(i)
+		if file.URL == "" {
+			allErrs = append(allErrs, field.Required(filePath.Child("url"), ""))
+		} else if _, err := url.Parse(file.URL); err != nil {
+			allErrs = append(allErrs, field.Invalid(filePath.Child("url"), file.URL, fmt.Sprintf("could not parse URL: %v", err)))
+		} else if urls.Has(file.URL) {
+			allErrs = append(allErrs, field.Duplicate(filePath.Child("url"), file.URL))
+		} else {
+			urls.Insert(file.URL)
+		}
+		if !sha256Regex.MatchString(file.Hash) {
+			allErrs = append(allErrs, field.Invalid(filePath.Child("hash"), file.Hash, "must be a lowercase hex-encoded SHA256 hash"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateValidationChecks(checks []kops.ValidationCheck, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	names := sets.NewString()
+	for i, check := range checks {
+		checkPath := fldPath.Index(i)
+		if check.Name == "" {
+			allErrs = append(allErrs, field.Required(checkPath.Child("name"), ""))
+		} else if names.Has(check.Name) {
+			allErrs = append(allErrs, field.Duplicate(checkPath.Child("name"), check.Name))
+		}
+		names.Insert(check.Name)
+
+		kinds := 0
+		if check.DaemonSet != nil {
+			kinds++
+			allErrs = append(allErrs, validateValidationCheckObject(check.DaemonSet, checkPath.Child("daemonSet"))...)
+		}
+		if check.Deployment != nil {
+			kinds++
+			allErrs = append(allErrs, validateValidationCheckObject(check.Deployment, checkPath.Child("deployment"))...)
+		}
+		if check.MinNodesPerZone != nil {
+			kinds++
+			if *check.MinNodesPerZone < 0 {
+				allErrs = append(allErrs, field.Invalid(checkPath.Child("minNodesPerZone"), *check.MinNodesPerZone, "Cannot be negative"))
+			}
+		}
+		if kinds != 1 {
+			allErrs = append(allErrs, field.Invalid(checkPath, check.Name, "Exactly one of daemonSet, deployment and minNodesPerZone must be set"))
+		}
+	}
+	return allErrs
+}
+
+func validateValidationCheckObject(object *kops.ValidationCheckObject, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if object.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), ""))
+	}
+	return allErrs
+}
+
 func validateRollingUpdate(rollingUpdate *kops.RollingUpdate, fldpath *field.Path, onMasterInstanceGroup bool) field.ErrorList {
 	allErrs := field.ErrorList{}
 	var err error
@@ -1554,6 +2492,12 @@ func validateRollingUpdate(rollingUpdate *kops.RollingUpdate, fldpath *field.Pat
 			allErrs = append(allErrs, field.Forbidden(fldpath.Child("maxSurge"), "Cannot be zero if maxUnavailable is zero"))
 		}
 	}
+	if rollingUpdate.DrainTimeout != nil && rollingUpdate.DrainTimeout.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldpath.Child("drainTimeout"), rollingUpdate.DrainTimeout.Duration.String(), "Must be positive"))
+	}
+	if rollingUpdate.PDBViolationPolicy != "" {
+		allErrs = append(allErrs, IsValidValue(fldpath.Child("pdbViolationPolicy"), (*string)(&rollingUpdate.PDBViolationPolicy), []string{string(kops.PDBViolationPolicyFail), string(kops.PDBViolationPolicyDelete)})...)
+	}
 	return allErrs
 }
 
@@ -1676,6 +2620,61 @@ func validateSnapshotController(cluster *kops.Cluster, spec *kops.SnapshotContro
 	return allErrs
 }
 
+var gcpCAPoolRegex = regexp.MustCompile(`^projects/[^/]+/locations/[^/]+/caPools/[^/]+$`)
+
+var gcpCryptoKeyRegex = regexp.MustCompile(`^projects/[^/]+/locations/[^/]+/keyRings/[^/]+/cryptoKeys/[^/]+$`)
+
+func validateKeyStoreKMSKey(spec *kops.ClusterSpec, fldPath *field.Path) (allErrs field.ErrorList) {
+	switch spec.GetCloudProvider() {
+	case kops.CloudProviderAWS:
+		parsedARN, err := arn.Parse(spec.KeyStoreKMSKey)
+		if err != nil || parsedARN.Service != "kms" || !strings.HasPrefix(parsedARN.Resource, "key/") {
+			allErrs = append(allErrs, field.Invalid(fldPath, spec.KeyStoreKMSKey,
+				"must be a valid KMS key ARN such as arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"))
+		}
+	case kops.CloudProviderGCE:
+		if !gcpCryptoKeyRegex.MatchString(spec.KeyStoreKMSKey) {
+			allErrs = append(allErrs, field.Invalid(fldPath, spec.KeyStoreKMSKey,
+				"must be a Cloud KMS key name such as projects/my-project/locations/global/keyRings/my-ring/cryptoKeys/my-key"))
+		}
+	default:
+		allErrs = append(allErrs, field.Forbidden(fldPath, "wrapping keys with a KMS key is only supported on AWS and GCE"))
+	}
+
+	return allErrs
+}
+
+func validateCertificateIssuer(c *kops.ClusterSpec, spec *kops.CertificateIssuerSpec, fldPath *field.Path) (allErrs field.ErrorList) {
+	if spec.AWSPrivateCA != nil && spec.GCPCAS != nil {
+		return append(allErrs, field.Forbidden(fldPath, "only one certificate issuer may be specified"))
+	}
+
+	if spec.AWSPrivateCA != nil {
+		fieldAWS := fldPath.Child("awsPrivateCA")
+		if c.GetCloudProvider() != kops.CloudProviderAWS {
+			allErrs = append(allErrs, field.Forbidden(fieldAWS, "ACM Private CA is only supported on AWS"))
+		}
+		parsedARN, err := arn.Parse(spec.AWSPrivateCA.ARN)
+		if err != nil || parsedARN.Service != "acm-pca" || !strings.HasPrefix(parsedARN.Resource, "certificate-authority/") {
+			allErrs = append(allErrs, field.Invalid(fieldAWS.Child("arn"), spec.AWSPrivateCA.ARN,
+				"must be a valid ACM Private CA ARN such as arn:aws:acm-pca:us-east-1:123456789012:certificate-authority/12345678-1234-1234-1234-123456789012"))
+		}
+	} else if spec.GCPCAS != nil {
+		fieldGCP := fldPath.Child("gcpCAS")
+		if c.GetCloudProvider() != kops.CloudProviderGCE {
+			allErrs = append(allErrs, field.Forbidden(fieldGCP, "Certificate Authority Service is only supported on GCE"))
+		}
+		if !gcpCAPoolRegex.MatchString(spec.GCPCAS.CAPool) {
+			allErrs = append(allErrs, field.Invalid(fieldGCP.Child("caPool"), spec.GCPCAS.CAPool,
+				"must be a CA pool name such as projects/my-project/locations/us-central1/caPools/my-pool"))
+		}
+	} else {
+		allErrs = append(allErrs, field.Required(fldPath, "a certificate issuer must be specified"))
+	}
+
+	return allErrs
+}
+
 func validatePodIdentityWebhook(cluster *kops.Cluster, spec *kops.PodIdentityWebhookConfig, fldPath *field.Path) (allErrs field.ErrorList) {
 	if spec != nil && spec.Enabled {
 		if !components.IsCertManagerEnabled(cluster) {
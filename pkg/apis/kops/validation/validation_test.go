@@ -18,7 +18,10 @@ package validation
 
 import (
 	"testing"
+	"time"
 
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
@@ -303,6 +306,149 @@ func TestValidateKubeAPIServer(t *testing.T) {
 			},
 			ExpectedErrors: []string{"Unsupported value::KubeAPIServer.logFormat"},
 		},
+		{
+			Input: kops.KubeAPIServerConfig{
+				HealthcheckSidecar: &kops.KubeAPIServerHealthcheckSidecarConfig{
+					Port:             fi.Int32(3991),
+					Path:             fi.String("/readyz"),
+					AdditionalChecks: []string{"etcd", "poststarthook/start-apiextensions-controllers"},
+				},
+			},
+		},
+		{
+			Input: kops.KubeAPIServerConfig{
+				HealthcheckSidecar: &kops.KubeAPIServerHealthcheckSidecarConfig{
+					Port: fi.Int32(443),
+				},
+			},
+			ExpectedErrors: []string{"Invalid value::KubeAPIServer.healthcheckSidecar.port"},
+		},
+		{
+			Input: kops.KubeAPIServerConfig{
+				HealthcheckSidecar: &kops.KubeAPIServerHealthcheckSidecarConfig{
+					Path: fi.String("/metrics"),
+				},
+			},
+			ExpectedErrors: []string{"Unsupported value::KubeAPIServer.healthcheckSidecar.path"},
+		},
+		{
+			Input: kops.KubeAPIServerConfig{
+				AdmissionPluginConfig: &kops.AdmissionPluginConfigSpec{
+					EventRateLimit: &kops.EventRateLimitAdmissionConfig{
+						Limits: []kops.EventRateLimit{
+							{Type: "Server", QPS: 5000, Burst: 20000},
+							{Type: "Namespace", QPS: 50, Burst: 100, CacheSize: fi.Int32(2000)},
+						},
+					},
+					ImagePolicyWebhook: &kops.ImagePolicyWebhookAdmissionConfig{
+						KubeConfig: "apiVersion: v1",
+					},
+				},
+			},
+		},
+		{
+			Input: kops.KubeAPIServerConfig{
+				AdmissionControlConfigFile: "/srv/kubernetes/admission.yaml",
+				AdmissionPluginConfig: &kops.AdmissionPluginConfigSpec{
+					ImagePolicyWebhook: &kops.ImagePolicyWebhookAdmissionConfig{
+						KubeConfig: "apiVersion: v1",
+					},
+				},
+			},
+			ExpectedErrors: []string{"Forbidden::KubeAPIServer.admissionPluginConfig"},
+		},
+		{
+			Input: kops.KubeAPIServerConfig{
+				AdmissionPluginConfig: &kops.AdmissionPluginConfigSpec{
+					EventRateLimit: &kops.EventRateLimitAdmissionConfig{
+						Limits: []kops.EventRateLimit{
+							{Type: "Server", QPS: 0, Burst: 100, CacheSize: fi.Int32(10)},
+						},
+					},
+				},
+			},
+			ExpectedErrors: []string{
+				"Invalid value::KubeAPIServer.admissionPluginConfig.eventRateLimit.limits[0].qps",
+				"Forbidden::KubeAPIServer.admissionPluginConfig.eventRateLimit.limits[0].cacheSize",
+			},
+		},
+		{
+			Input: kops.KubeAPIServerConfig{
+				DisableAdmissionPlugins: []string{"ImagePolicyWebhook"},
+				AdmissionPluginConfig: &kops.AdmissionPluginConfigSpec{
+					ImagePolicyWebhook: &kops.ImagePolicyWebhookAdmissionConfig{},
+				},
+			},
+			ExpectedErrors: []string{
+				"Forbidden::KubeAPIServer.admissionPluginConfig.imagePolicyWebhook",
+				"Required value::KubeAPIServer.admissionPluginConfig.imagePolicyWebhook.kubeConfig",
+			},
+		},
+		{
+			Input: kops.KubeAPIServerConfig{
+				HealthcheckSidecar: &kops.KubeAPIServerHealthcheckSidecarConfig{
+					Path:             fi.String("/readyz"),
+					AdditionalChecks: []string{"/etcd"},
+				},
+			},
+			ExpectedErrors: []string{"Invalid value::KubeAPIServer.healthcheckSidecar.additionalChecks[0]"},
+		},
+		{
+			Input: kops.KubeAPIServerConfig{
+				HealthcheckSidecar: &kops.KubeAPIServerHealthcheckSidecarConfig{
+					AdditionalChecks: []string{"etcd"},
+				},
+			},
+			ExpectedErrors: []string{"Required value::KubeAPIServer.healthcheckSidecar.path"},
+		},
+		{
+			Input: kops.KubeAPIServerConfig{
+				MaxRequestsInflight:         800,
+				MaxMutatingRequestsInflight: 400,
+				FlowSchemaPresets:           []string{"protect-system-nodes", "limit-service-accounts"},
+			},
+		},
+		{
+			Input: kops.KubeAPIServerConfig{
+				MaxMutatingRequestsInflight: -1,
+			},
+			ExpectedErrors: []string{"Invalid value::KubeAPIServer.maxMutatingRequestsInflight"},
+		},
+		{
+			Input: kops.KubeAPIServerConfig{
+				FlowSchemaPresets: []string{"protect-kube-system", "protect-everything", "protect-kube-system"},
+			},
+			ExpectedErrors: []string{
+				"Unsupported value::KubeAPIServer.flowSchemaPresets[1]",
+				"Duplicate value::KubeAPIServer.flowSchemaPresets[2]",
+			},
+		},
+		{
+			Input: kops.KubeAPIServerConfig{
+				EnablePriorityAndFairness: fi.Bool(false),
+				FlowSchemaPresets:         []string{"protect-system-nodes"},
+			},
+			ExpectedErrors: []string{"Forbidden::KubeAPIServer.flowSchemaPresets"},
+		},
+		{
+			Input: kops.KubeAPIServerConfig{
+				LivenessProbe:  &kops.ProbeSpec{InitialDelaySeconds: fi.Int32(120), FailureThreshold: fi.Int32(8)},
+				ReadinessProbe: &kops.ProbeSpec{PeriodSeconds: fi.Int32(5)},
+				StartupProbe:   &kops.ProbeSpec{PeriodSeconds: fi.Int32(10), FailureThreshold: fi.Int32(60)},
+			},
+		},
+		{
+			Input: kops.KubeAPIServerConfig{
+				LivenessProbe: &kops.ProbeSpec{InitialDelaySeconds: fi.Int32(-1), TimeoutSeconds: fi.Int32(0)},
+				StartupProbe:  &kops.ProbeSpec{PeriodSeconds: fi.Int32(0), FailureThreshold: fi.Int32(0)},
+			},
+			ExpectedErrors: []string{
+				"Invalid value::KubeAPIServer.livenessProbe.initialDelaySeconds",
+				"Invalid value::KubeAPIServer.livenessProbe.timeoutSeconds",
+				"Invalid value::KubeAPIServer.startupProbe.periodSeconds",
+				"Invalid value::KubeAPIServer.startupProbe.failureThreshold",
+			},
+		},
 	}
 	for _, g := range grid {
 		if g.Cluster == nil {
@@ -395,6 +541,130 @@ func Test_Validate_Networking_Flannel(t *testing.T) {
 	}
 }
 
+func Test_Validate_Networking_CNI(t *testing.T) {
+	grid := []struct {
+		Input          kops.CNINetworkingSpec
+		ExpectedErrors []string
+	}{
+		{
+			Input: kops.CNINetworkingSpec{},
+		},
+		{
+			Input: kops.CNINetworkingSpec{
+				ManifestURL:    "https://example.com/cni.yaml",
+				ManifestSHA256: "aad3fd84879f4464bd205d0a3a9ec2e980e8f1ed33eb8b06a946bd7e93b766d6",
+			},
+		},
+		{
+			Input: kops.CNINetworkingSpec{
+				ManifestURL: "https://example.com/cni.yaml",
+			},
+			ExpectedErrors: []string{"Required value::networking.cni.sha256"},
+		},
+		{
+			Input: kops.CNINetworkingSpec{
+				ManifestURL:    "https://example.com/cni.yaml",
+				ManifestSHA256: "AAD3FD84",
+			},
+			ExpectedErrors: []string{"Invalid value::networking.cni.sha256"},
+		},
+		{
+			Input: kops.CNINetworkingSpec{
+				ManifestURL:    "cni.yaml",
+				ManifestSHA256: "aad3fd84879f4464bd205d0a3a9ec2e980e8f1ed33eb8b06a946bd7e93b766d6",
+			},
+			ExpectedErrors: []string{"Invalid value::networking.cni.manifestURL"},
+		},
+		{
+			Input: kops.CNINetworkingSpec{
+				ManifestSHA256: "aad3fd84879f4464bd205d0a3a9ec2e980e8f1ed33eb8b06a946bd7e93b766d6",
+			},
+			ExpectedErrors: []string{"Forbidden::networking.cni.sha256"},
+		},
+	}
+	for _, g := range grid {
+		networking := &kops.NetworkingSpec{}
+		networking.CNI = &g.Input
+
+		cluster := &kops.Cluster{}
+		cluster.Spec.Networking = networking
+
+		errs := validateNetworking(cluster, networking, field.NewPath("networking"))
+		testErrors(t, g.Input, errs, g.ExpectedErrors)
+	}
+}
+
+func Test_Validate_IPv6Only(t *testing.T) {
+	grid := []struct {
+		Description    string
+		Input          kops.ClusterSpec
+		ExpectedErrors []string
+	}{
+		{
+			Description: "valid",
+			Input: kops.ClusterSpec{
+				CloudProvider: kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+				PodCIDR:       "2001:db8::/64",
+				Subnets: []kops.ClusterSubnetSpec{
+					{Name: "us-test-1a", Type: kops.SubnetTypePrivate, IPv6CIDR: "/64#1"},
+					{Name: "dualstack-us-test-1a", Type: kops.SubnetTypeDualStack, CIDR: "10.0.0.0/24", IPv6CIDR: "/64#2"},
+					{Name: "utility-us-test-1a", Type: kops.SubnetTypeUtility, CIDR: "10.0.1.0/24"},
+					{Name: "shared", Type: kops.SubnetTypePrivate, ProviderID: "subnet-12345678"},
+				},
+				KubeDNS:     &kops.KubeDNSConfig{Provider: "CoreDNS"},
+				ExternalDNS: &kops.ExternalDNSConfig{Provider: kops.ExternalDNSProviderDNSController},
+			},
+		},
+		{
+			Description: "not AWS",
+			Input: kops.ClusterSpec{
+				CloudProvider: kops.CloudProviderSpec{GCE: &kops.GCESpec{}},
+			},
+			ExpectedErrors: []string{"Forbidden::spec.nonMasqueradeCIDR"},
+		},
+		{
+			Description: "IPv4 podCIDR",
+			Input: kops.ClusterSpec{
+				CloudProvider: kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+				PodCIDR:       "100.96.0.0/11",
+			},
+			ExpectedErrors: []string{"Invalid value::spec.podCIDR"},
+		},
+		{
+			Description: "subnet without ipv6CIDR",
+			Input: kops.ClusterSpec{
+				CloudProvider: kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+				Subnets: []kops.ClusterSubnetSpec{
+					{Name: "us-test-1a", Type: kops.SubnetTypePublic, CIDR: "10.0.0.0/24"},
+				},
+			},
+			ExpectedErrors: []string{"Required value::spec.subnets[0].ipv6CIDR"},
+		},
+		{
+			Description: "incompatible addons",
+			Input: kops.ClusterSpec{
+				CloudProvider: kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+				KubeDNS:       &kops.KubeDNSConfig{Provider: "KubeDNS"},
+				ExternalDNS:   &kops.ExternalDNSConfig{Provider: kops.ExternalDNSProviderExternalDNS},
+				Karpenter:     &kops.KarpenterConfig{Enabled: true},
+			},
+			ExpectedErrors: []string{
+				"Forbidden::spec.kubeDNS.provider",
+				"Forbidden::spec.externalDNS.provider",
+				"Forbidden::spec.karpenter.enabled",
+			},
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.Description, func(t *testing.T) {
+			cluster := &kops.Cluster{Spec: g.Input}
+			cluster.Spec.NonMasqueradeCIDR = "::/0"
+			errs := validateIPv6Only(cluster, field.NewPath("spec"))
+			testErrors(t, g.Input, errs, g.ExpectedErrors)
+		})
+	}
+}
+
 func Test_Validate_AdditionalPolicies(t *testing.T) {
 	grid := []struct {
 		Input          map[string]string
@@ -457,6 +727,66 @@ func Test_Validate_AdditionalPolicies(t *testing.T) {
 	}
 }
 
+func Test_Validate_InstanceMetadata(t *testing.T) {
+	grid := []struct {
+		CloudProvider  kops.CloudProviderSpec
+		Input          *kops.InstanceMetadataOptions
+		ExpectedErrors []string
+	}{
+		{
+			CloudProvider: kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			Input: &kops.InstanceMetadataOptions{
+				HTTPTokens:              fi.String("required"),
+				HTTPPutResponseHopLimit: fi.Int64(1),
+			},
+		},
+		{
+			CloudProvider: kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			Input: &kops.InstanceMetadataOptions{
+				HTTPTokens: fi.String("always"),
+			},
+			ExpectedErrors: []string{"Unsupported value::spec.instanceMetadata.httpTokens"},
+		},
+		{
+			CloudProvider: kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			Input: &kops.InstanceMetadataOptions{
+				HTTPPutResponseHopLimit: fi.Int64(65),
+			},
+			ExpectedErrors: []string{"Invalid value::spec.instanceMetadata.httpPutResponseHopLimit"},
+		},
+		{
+			CloudProvider: kops.CloudProviderSpec{GCE: &kops.GCESpec{}},
+			Input: &kops.InstanceMetadataOptions{
+				HTTPTokens: fi.String("required"),
+			},
+			ExpectedErrors: []string{"Forbidden::spec.instanceMetadata"},
+		},
+	}
+	for _, g := range grid {
+		clusterSpec := &kops.ClusterSpec{
+			KubernetesVersion: "1.22.0",
+			CloudProvider:     g.CloudProvider,
+			InstanceMetadata:  g.Input,
+			Subnets: []kops.ClusterSubnetSpec{
+				{Name: "subnet1", Type: kops.SubnetTypePublic},
+			},
+			EtcdClusters: []kops.EtcdClusterSpec{
+				{
+					Name: "main",
+					Members: []kops.EtcdMemberSpec{
+						{
+							Name:          "us-test-1a",
+							InstanceGroup: fi.String("master-us-test-1a"),
+						},
+					},
+				},
+			},
+		}
+		errs := validateClusterSpec(clusterSpec, &kops.Cluster{Spec: *clusterSpec}, field.NewPath("spec"))
+		testErrors(t, g.Input, errs, g.ExpectedErrors)
+	}
+}
+
 type caliInput struct {
 	Cluster *kops.ClusterSpec
 	Calico  *kops.CalicoNetworkingSpec
@@ -803,6 +1133,47 @@ func Test_Validate_Calico(t *testing.T) {
 				},
 			},
 		},
+		{
+			Description: "Calico eBPF dataplane",
+			Input: caliInput{
+				Cluster: &kops.ClusterSpec{
+					NonMasqueradeCIDR: "100.64.0.0/10",
+					KubeProxy: &kops.KubeProxyConfig{
+						Enabled: fi.Bool(false),
+					},
+				},
+				Calico: &kops.CalicoNetworkingSpec{
+					BPFEnabled: true,
+				},
+			},
+		},
+		{
+			Description: "Calico eBPF dataplane with kube-proxy",
+			Input: caliInput{
+				Cluster: &kops.ClusterSpec{
+					NonMasqueradeCIDR: "100.64.0.0/10",
+					KubeProxy: &kops.KubeProxyConfig{
+						Enabled: fi.Bool(true),
+					},
+				},
+				Calico: &kops.CalicoNetworkingSpec{
+					BPFEnabled: true,
+				},
+			},
+			ExpectedErrors: []string{"Forbidden::calico.spec.kubeProxy.enabled"},
+		},
+		{
+			Description: "Calico eBPF dataplane with IPv6",
+			Input: caliInput{
+				Cluster: &kops.ClusterSpec{
+					NonMasqueradeCIDR: "::/0",
+				},
+				Calico: &kops.CalicoNetworkingSpec{
+					BPFEnabled: true,
+				},
+			},
+			ExpectedErrors: []string{"Forbidden::calico.bpfEnabled"},
+		},
 	}
 	rootFieldPath := field.NewPath("calico")
 	for _, g := range grid {
@@ -891,40 +1262,92 @@ func Test_Validate_Cilium(t *testing.T) {
 		},
 		{
 			Cilium: kops.CiliumNetworkingSpec{
-				IdentityAllocationMode: "kvstore",
+				IPAM: "eni",
 			},
-			ExpectedErrors: []string{"Forbidden::cilium.identityAllocationMode"},
+			Spec: kops.ClusterSpec{
+				CloudProvider: kops.CloudProviderSpec{
+					AWS: &kops.AWSSpec{},
+				},
+				Kubelet: &kops.KubeletConfigSpec{
+					NetworkPluginName: fi.String("kubenet"),
+				},
+			},
+			ExpectedErrors: []string{"Forbidden::cilium.spec.kubelet.networkPluginName"},
 		},
 		{
 			Cilium: kops.CiliumNetworkingSpec{
-				Version: "v1.0.0",
+				IPAM: "eni",
+				ENI: &kops.CiliumENISpec{
+					SubnetIDsFilter:             []string{"subnet-0123456789abcdef0"},
+					SubnetTagsFilter:            map[string]string{"tier": "pods"},
+					AWSReleaseExcessIPs:         true,
+					UpdateEC2AdapterLimitViaAPI: true,
+				},
 			},
 			Spec: kops.ClusterSpec{
-				KubernetesVersion: "1.18.0",
+				CloudProvider: kops.CloudProviderSpec{
+					AWS: &kops.AWSSpec{},
+				},
 			},
-			ExpectedErrors: []string{"Invalid value::cilium.version"},
 		},
 		{
 			Cilium: kops.CiliumNetworkingSpec{
-				Version: "1.7.0",
+				IPAM: "eni",
+				ENI: &kops.CiliumENISpec{
+					SubnetIDsFilter: []string{"us-test-1a"},
+				},
 			},
-			ExpectedErrors: []string{"Invalid value::cilium.version"},
+			Spec: kops.ClusterSpec{
+				CloudProvider: kops.CloudProviderSpec{
+					AWS: &kops.AWSSpec{},
+				},
+			},
+			ExpectedErrors: []string{"Invalid value::cilium.eni.subnetIDsFilter[0]"},
 		},
 		{
 			Cilium: kops.CiliumNetworkingSpec{
-				Version: "v1.8.0",
-				Hubble: &kops.HubbleSpec{
-					Enabled: fi.Bool(true),
+				ENI: &kops.CiliumENISpec{
+					AWSReleaseExcessIPs: true,
 				},
 			},
-			ExpectedErrors: []string{"Forbidden::cilium.hubble.enabled"},
+			ExpectedErrors: []string{"Forbidden::cilium.eni"},
 		},
 		{
 			Cilium: kops.CiliumNetworkingSpec{
-				Version: "v1.11.5",
-				Hubble: &kops.HubbleSpec{
-					Enabled: fi.Bool(true),
-				},
+				IdentityAllocationMode: "kvstore",
+			},
+			ExpectedErrors: []string{"Forbidden::cilium.identityAllocationMode"},
+		},
+		{
+			Cilium: kops.CiliumNetworkingSpec{
+				Version: "v1.0.0",
+			},
+			Spec: kops.ClusterSpec{
+				KubernetesVersion: "1.18.0",
+			},
+			ExpectedErrors: []string{"Invalid value::cilium.version"},
+		},
+		{
+			Cilium: kops.CiliumNetworkingSpec{
+				Version: "1.7.0",
+			},
+			ExpectedErrors: []string{"Invalid value::cilium.version"},
+		},
+		{
+			Cilium: kops.CiliumNetworkingSpec{
+				Version: "v1.8.0",
+				Hubble: &kops.HubbleSpec{
+					Enabled: fi.Bool(true),
+				},
+			},
+			ExpectedErrors: []string{"Forbidden::cilium.hubble.enabled"},
+		},
+		{
+			Cilium: kops.CiliumNetworkingSpec{
+				Version: "v1.11.5",
+				Hubble: &kops.HubbleSpec{
+					Enabled: fi.Bool(true),
+				},
 			},
 			Spec: kops.ClusterSpec{
 				CertManager: &kops.CertManagerConfig{
@@ -932,6 +1355,41 @@ func Test_Validate_Cilium(t *testing.T) {
 				},
 			},
 		},
+		{
+			Cilium: kops.CiliumNetworkingSpec{
+				Version:           "v1.13.5",
+				EnableEnvoyConfig: true,
+				EnableGatewayAPI:  true,
+				EnableNodePort:    true,
+			},
+		},
+		{
+			Cilium: kops.CiliumNetworkingSpec{
+				Version:           "v1.11.5",
+				EnableEnvoyConfig: true,
+				EnableGatewayAPI:  true,
+				EnableNodePort:    true,
+			},
+			ExpectedErrors: []string{
+				"Forbidden::cilium.enableEnvoyConfig",
+				"Forbidden::cilium.enableGatewayAPI",
+			},
+		},
+		{
+			Cilium: kops.CiliumNetworkingSpec{
+				Version:           "v1.13.5",
+				EnableEnvoyConfig: true,
+				EnableL7Proxy:     fi.Bool(false),
+			},
+			ExpectedErrors: []string{"Forbidden::cilium.enableEnvoyConfig"},
+		},
+		{
+			Cilium: kops.CiliumNetworkingSpec{
+				Version:          "v1.13.5",
+				EnableGatewayAPI: true,
+			},
+			ExpectedErrors: []string{"Forbidden::cilium.enableGatewayAPI"},
+		},
 	}
 	for _, g := range grid {
 		g.Spec.Networking = &kops.NetworkingSpec{
@@ -1077,6 +1535,24 @@ func Test_Validate_RollingUpdate(t *testing.T) {
 			},
 			ExpectedErrors: []string{"Forbidden::testField.maxSurge"},
 		},
+		{
+			Input: kops.RollingUpdate{
+				DrainTimeout:       &metav1.Duration{Duration: 5 * time.Minute},
+				PDBViolationPolicy: kops.PDBViolationPolicyDelete,
+			},
+		},
+		{
+			Input: kops.RollingUpdate{
+				DrainTimeout: &metav1.Duration{},
+			},
+			ExpectedErrors: []string{"Invalid value::testField.drainTimeout"},
+		},
+		{
+			Input: kops.RollingUpdate{
+				PDBViolationPolicy: "Ignore",
+			},
+			ExpectedErrors: []string{"Unsupported value::testField.pdbViolationPolicy"},
+		},
 	}
 	for _, g := range grid {
 		errs := validateRollingUpdate(&g.Input, field.NewPath("testField"), g.OnMasterIG)
@@ -1084,6 +1560,62 @@ func Test_Validate_RollingUpdate(t *testing.T) {
 	}
 }
 
+func Test_Validate_ValidationChecks(t *testing.T) {
+	grid := []struct {
+		Input          []kops.ValidationCheck
+		ExpectedErrors []string
+	}{
+		{
+			Input: []kops.ValidationCheck{
+				{Name: "node-exporter", DaemonSet: &kops.ValidationCheckObject{Namespace: "monitoring", Name: "node-exporter"}},
+				{Name: "metrics-server", Deployment: &kops.ValidationCheckObject{Name: "metrics-server"}},
+				{Name: "zones", MinNodesPerZone: fi.Int32(2)},
+			},
+		},
+		{
+			Input: []kops.ValidationCheck{
+				{DaemonSet: &kops.ValidationCheckObject{Name: "node-exporter"}},
+			},
+			ExpectedErrors: []string{"Required value::testField[0].name"},
+		},
+		{
+			Input: []kops.ValidationCheck{
+				{Name: "zones", MinNodesPerZone: fi.Int32(1)},
+				{Name: "zones", MinNodesPerZone: fi.Int32(2)},
+			},
+			ExpectedErrors: []string{"Duplicate value::testField[1].name"},
+		},
+		{
+			Input: []kops.ValidationCheck{
+				{Name: "none"},
+			},
+			ExpectedErrors: []string{"Invalid value::testField[0]"},
+		},
+		{
+			Input: []kops.ValidationCheck{
+				{Name: "both", DaemonSet: &kops.ValidationCheckObject{Name: "a"}, Deployment: &kops.ValidationCheckObject{Name: "b"}},
+			},
+			ExpectedErrors: []string{"Invalid value::testField[0]"},
+		},
+		{
+			Input: []kops.ValidationCheck{
+				{Name: "unnamed", Deployment: &kops.ValidationCheckObject{Namespace: "kube-system"}},
+			},
+			ExpectedErrors: []string{"Required value::testField[0].deployment.name"},
+		},
+		{
+			Input: []kops.ValidationCheck{
+				{Name: "zones", MinNodesPerZone: fi.Int32(-1)},
+			},
+			ExpectedErrors: []string{"Invalid value::testField[0].minNodesPerZone"},
+		},
+	}
+	for _, g := range grid {
+		errs := validateValidationChecks(g.Input, field.NewPath("testField"))
+		testErrors(t, g.Input, errs, g.ExpectedErrors)
+	}
+}
+
 func intStr(i intstr.IntOrString) *intstr.IntOrString {
 	return &i
 }
@@ -1289,6 +1821,7 @@ func Test_Validate_CloudConfiguration(t *testing.T) {
 func TestValidateSAExternalPermissions(t *testing.T) {
 	grid := []struct {
 		Description    string
+		CloudProvider  kops.CloudProviderID
 		Input          []kops.ServiceAccountExternalPermission
 		ExpectedErrors []string
 	}{
@@ -1362,12 +1895,67 @@ func TestValidateSAExternalPermissions(t *testing.T) {
 			},
 			ExpectedErrors: []string{"Required value::iam.serviceAccountExternalPermissions[/MySA].namespace"},
 		},
+		{
+			Description:   "GCP roles",
+			CloudProvider: kops.CloudProviderGCE,
+			Input: []kops.ServiceAccountExternalPermission{
+				{
+					Name:      "MySA",
+					Namespace: "MyNS",
+					GCP: &kops.GCPPermission{
+						Roles: []string{"roles/storage.objectViewer", "projects/my-project/roles/custom"},
+					},
+				},
+			},
+		},
+		{
+			Description:   "Missing GCP permissions",
+			CloudProvider: kops.CloudProviderGCE,
+			Input: []kops.ServiceAccountExternalPermission{
+				{
+					Name:      "MySA",
+					Namespace: "MyNS",
+				},
+			},
+			ExpectedErrors: []string{"Required value::iam.serviceAccountExternalPermissions[MyNS/MySA].gcp"},
+		},
+		{
+			Description:   "Invalid GCP role",
+			CloudProvider: kops.CloudProviderGCE,
+			Input: []kops.ServiceAccountExternalPermission{
+				{
+					Name:      "MySA",
+					Namespace: "MyNS",
+					GCP: &kops.GCPPermission{
+						Roles: []string{"storage.objectViewer"},
+					},
+				},
+			},
+			ExpectedErrors: []string{"Invalid value::iam.serviceAccountExternalPermissions[MyNS/MySA].gcp.roles[0]"},
+		},
+		{
+			Description:   "GCP permissions on AWS",
+			CloudProvider: kops.CloudProviderAWS,
+			Input: []kops.ServiceAccountExternalPermission{
+				{
+					Name:      "MySA",
+					Namespace: "MyNS",
+					AWS: &kops.AWSPermission{
+						PolicyARNs: []string{"-"},
+					},
+					GCP: &kops.GCPPermission{
+						Roles: []string{"roles/storage.objectViewer"},
+					},
+				},
+			},
+			ExpectedErrors: []string{"Forbidden::iam.serviceAccountExternalPermissions[MyNS/MySA].gcp"},
+		},
 	}
 
 	for _, g := range grid {
 		fldPath := field.NewPath("iam.serviceAccountExternalPermissions")
 		t.Run(g.Description, func(t *testing.T) {
-			errs := validateSAExternalPermissions(g.Input, fldPath)
+			errs := validateSAExternalPermissions(g.CloudProvider, g.Input, fldPath)
 			testErrors(t, g.Input, errs, g.ExpectedErrors)
 		})
 	}
@@ -1417,6 +2005,32 @@ func Test_Validate_Nvidia_Cluster(t *testing.T) {
 				},
 				ContainerRuntime: "containerd",
 			},
+		},
+		{
+			Input: kops.ClusterSpec{
+				Containerd: &kops.ContainerdConfig{
+					NvidiaGPU: &kops.NvidiaGPUConfig{
+						Enabled: fi.Bool(true),
+					},
+				},
+				CloudProvider: kops.CloudProviderSpec{
+					Azure: &kops.AzureSpec{},
+				},
+				ContainerRuntime: "containerd",
+			},
+		},
+		{
+			Input: kops.ClusterSpec{
+				Containerd: &kops.ContainerdConfig{
+					NvidiaGPU: &kops.NvidiaGPUConfig{
+						Enabled: fi.Bool(true),
+					},
+				},
+				CloudProvider: kops.CloudProviderSpec{
+					DO: &kops.DOSpec{},
+				},
+				ContainerRuntime: "containerd",
+			},
 			ExpectedErrors: []string{"Forbidden::containerd.nvidiaGPU"},
 		},
 		{
@@ -1483,6 +2097,32 @@ func Test_Validate_Nvidia_Ig(t *testing.T) {
 				},
 				ContainerRuntime: "containerd",
 			},
+		},
+		{
+			Input: kops.ClusterSpec{
+				Containerd: &kops.ContainerdConfig{
+					NvidiaGPU: &kops.NvidiaGPUConfig{
+						Enabled: fi.Bool(true),
+					},
+				},
+				CloudProvider: kops.CloudProviderSpec{
+					Azure: &kops.AzureSpec{},
+				},
+				ContainerRuntime: "containerd",
+			},
+		},
+		{
+			Input: kops.ClusterSpec{
+				Containerd: &kops.ContainerdConfig{
+					NvidiaGPU: &kops.NvidiaGPUConfig{
+						Enabled: fi.Bool(true),
+					},
+				},
+				CloudProvider: kops.CloudProviderSpec{
+					DO: &kops.DOSpec{},
+				},
+				ContainerRuntime: "containerd",
+			},
 			ExpectedErrors: []string{"Forbidden::containerd.nvidiaGPU"},
 		},
 		{
@@ -1505,3 +2145,1035 @@ func Test_Validate_Nvidia_Ig(t *testing.T) {
 		testErrors(t, g.Input, errs, g.ExpectedErrors)
 	}
 }
+
+func Test_Validate_Monitoring(t *testing.T) {
+	grid := []struct {
+		Input          kops.ClusterSpec
+		ExpectedErrors []string
+	}{
+		{
+			Input: kops.ClusterSpec{
+				Monitoring: &kops.MonitoringSpec{
+					NodeExporter: &kops.MonitoringAgentConfig{
+						Enabled:        fi.Bool(true),
+						ResourcePreset: fi.String("medium"),
+					},
+					CloudWatchAgent: &kops.MonitoringAgentConfig{
+						Enabled:        fi.Bool(true),
+						ResourcePreset: fi.String("large"),
+					},
+				},
+				CloudProvider: kops.CloudProviderSpec{
+					AWS: &kops.AWSSpec{},
+				},
+			},
+		},
+		{
+			Input: kops.ClusterSpec{
+				Monitoring: &kops.MonitoringSpec{
+					NodeExporter: &kops.MonitoringAgentConfig{
+						ResourcePreset: fi.String("huge"),
+					},
+				},
+				CloudProvider: kops.CloudProviderSpec{
+					AWS: &kops.AWSSpec{},
+				},
+			},
+			ExpectedErrors: []string{"Unsupported value::monitoring.nodeExporter.resourcePreset"},
+		},
+		{
+			Input: kops.ClusterSpec{
+				Monitoring: &kops.MonitoringSpec{
+					NodeExporter: &kops.MonitoringAgentConfig{
+						Enabled: fi.Bool(true),
+					},
+					CloudWatchAgent: &kops.MonitoringAgentConfig{
+						Enabled: fi.Bool(true),
+					},
+				},
+				CloudProvider: kops.CloudProviderSpec{
+					GCE: &kops.GCESpec{},
+				},
+			},
+			ExpectedErrors: []string{"Forbidden::monitoring.cloudWatchAgent"},
+		},
+	}
+	for _, g := range grid {
+		errs := validateMonitoring(&g.Input, field.NewPath("monitoring"))
+		testErrors(t, g.Input, errs, g.ExpectedErrors)
+	}
+}
+
+func Test_Validate_NodeCertificates(t *testing.T) {
+	grid := []struct {
+		Description    string
+		Cloud          kops.CloudProviderSpec
+		Input          kops.NodeCertificatesSpec
+		ExpectedErrors []string
+	}{
+		{
+			Description: "default renewBefore",
+			Cloud:       kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			Input:       kops.NodeCertificatesSpec{Validity: &metav1.Duration{Duration: 24 * time.Hour}},
+		},
+		{
+			Description: "renewBefore",
+			Cloud:       kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			Input: kops.NodeCertificatesSpec{
+				Validity:    &metav1.Duration{Duration: 24 * time.Hour},
+				RenewBefore: &metav1.Duration{Duration: 12 * time.Hour},
+			},
+		},
+		{
+			Description:    "unsupported cloud",
+			Cloud:          kops.CloudProviderSpec{Openstack: &kops.OpenstackSpec{}},
+			Input:          kops.NodeCertificatesSpec{Validity: &metav1.Duration{Duration: 24 * time.Hour}},
+			ExpectedErrors: []string{"Forbidden::nodeCertificates"},
+		},
+		{
+			Description:    "no validity",
+			Cloud:          kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			Input:          kops.NodeCertificatesSpec{},
+			ExpectedErrors: []string{"Required value::nodeCertificates.validity"},
+		},
+		{
+			Description:    "short validity",
+			Cloud:          kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			Input:          kops.NodeCertificatesSpec{Validity: &metav1.Duration{Duration: 30 * time.Minute}},
+			ExpectedErrors: []string{"Invalid value::nodeCertificates.validity"},
+		},
+		{
+			Description: "renewBefore not less than validity",
+			Cloud:       kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			Input: kops.NodeCertificatesSpec{
+				Validity:    &metav1.Duration{Duration: 24 * time.Hour},
+				RenewBefore: &metav1.Duration{Duration: 24 * time.Hour},
+			},
+			ExpectedErrors: []string{"Invalid value::nodeCertificates.renewBefore"},
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.Description, func(t *testing.T) {
+			cluster := &kops.Cluster{
+				Spec: kops.ClusterSpec{
+					CloudProvider: g.Cloud,
+				},
+			}
+			errs := validateNodeCertificates(cluster, &g.Input, field.NewPath("nodeCertificates"))
+			testErrors(t, g.Input, errs, g.ExpectedErrors)
+		})
+	}
+}
+
+func Test_Validate_NodeLabelsFromCloudTags(t *testing.T) {
+	grid := []struct {
+		Description    string
+		Cloud          kops.CloudProviderSpec
+		Input          kops.NodeLabelsFromCloudTagsSpec
+		ExpectedErrors []string
+	}{
+		{
+			Description: "default prefix",
+			Cloud:       kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			Input:       kops.NodeLabelsFromCloudTagsSpec{Tags: []string{"team", "cost-center"}},
+		},
+		{
+			Description: "custom prefix",
+			Cloud:       kops.CloudProviderSpec{Azure: &kops.AzureSpec{}},
+			Input:       kops.NodeLabelsFromCloudTagsSpec{Tags: []string{"team"}, Prefix: "example.com/"},
+		},
+		{
+			Description:    "unsupported cloud",
+			Cloud:          kops.CloudProviderSpec{GCE: &kops.GCESpec{}},
+			Input:          kops.NodeLabelsFromCloudTagsSpec{Tags: []string{"team"}},
+			ExpectedErrors: []string{"Forbidden::nodeLabelsFromCloudTags"},
+		},
+		{
+			Description:    "no tags",
+			Cloud:          kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			Input:          kops.NodeLabelsFromCloudTagsSpec{},
+			ExpectedErrors: []string{"Required value::nodeLabelsFromCloudTags.tags"},
+		},
+		{
+			Description:    "prefix without slash",
+			Cloud:          kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			Input:          kops.NodeLabelsFromCloudTagsSpec{Tags: []string{"team"}, Prefix: "example.com"},
+			ExpectedErrors: []string{"Invalid value::nodeLabelsFromCloudTags.prefix"},
+		},
+		{
+			Description:    "invalid tag",
+			Cloud:          kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			Input:          kops.NodeLabelsFromCloudTagsSpec{Tags: []string{"cost center"}},
+			ExpectedErrors: []string{"Invalid value::nodeLabelsFromCloudTags.tags[0]"},
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.Description, func(t *testing.T) {
+			cluster := &kops.Cluster{
+				Spec: kops.ClusterSpec{
+					CloudProvider: g.Cloud,
+				},
+			}
+			errs := validateNodeLabelsFromCloudTags(cluster, &g.Input, field.NewPath("nodeLabelsFromCloudTags"))
+			testErrors(t, g.Input, errs, g.ExpectedErrors)
+		})
+	}
+}
+
+func Test_Validate_DNSRecords(t *testing.T) {
+	ttl := int32(300)
+	zero := int32(0)
+	grid := []struct {
+		Description    string
+		Cloud          kops.CloudProviderSpec
+		API            kops.AccessSpec
+		Input          kops.DNSRecordsSpec
+		ExpectedErrors []string
+	}{
+		{
+			Description: "ttl for records without load balancer",
+			Cloud:       kops.CloudProviderSpec{GCE: &kops.GCESpec{}},
+			API:         kops.AccessSpec{DNS: &kops.DNSAccessSpec{}},
+			Input: kops.DNSRecordsSpec{
+				API:            &kops.DNSRecordSpec{TTL: &ttl},
+				APIInternal:    &kops.DNSRecordSpec{TTL: &ttl},
+				KopsController: &kops.DNSRecordSpec{TTL: &ttl},
+			},
+		},
+		{
+			Description: "cname to load balancer",
+			Cloud:       kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			API:         kops.AccessSpec{LoadBalancer: &kops.LoadBalancerAccessSpec{UseForInternalAPI: true}},
+			Input: kops.DNSRecordsSpec{
+				API:         &kops.DNSRecordSpec{Type: kops.DNSRecordTypeCNAME, TTL: &ttl},
+				APIInternal: &kops.DNSRecordSpec{Type: kops.DNSRecordTypeCNAME},
+				Bastion:     &kops.DNSRecordSpec{Type: kops.DNSRecordTypeAlias},
+			},
+		},
+		{
+			Description:    "unsupported type",
+			Cloud:          kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			API:            kops.AccessSpec{LoadBalancer: &kops.LoadBalancerAccessSpec{}},
+			Input:          kops.DNSRecordsSpec{API: &kops.DNSRecordSpec{Type: "AAAA"}},
+			ExpectedErrors: []string{"Unsupported value::dnsRecords.api.type"},
+		},
+		{
+			Description:    "type on unsupported cloud",
+			Cloud:          kops.CloudProviderSpec{GCE: &kops.GCESpec{}},
+			API:            kops.AccessSpec{LoadBalancer: &kops.LoadBalancerAccessSpec{}},
+			Input:          kops.DNSRecordsSpec{API: &kops.DNSRecordSpec{Type: kops.DNSRecordTypeCNAME}},
+			ExpectedErrors: []string{"Forbidden::dnsRecords.api.type"},
+		},
+		{
+			Description: "type without load balancer",
+			Cloud:       kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			API:         kops.AccessSpec{LoadBalancer: &kops.LoadBalancerAccessSpec{}},
+			Input: kops.DNSRecordsSpec{
+				APIInternal:    &kops.DNSRecordSpec{Type: kops.DNSRecordTypeCNAME},
+				KopsController: &kops.DNSRecordSpec{Type: kops.DNSRecordTypeCNAME},
+			},
+			ExpectedErrors: []string{"Forbidden::dnsRecords.apiInternal.type", "Forbidden::dnsRecords.kopsController.type"},
+		},
+		{
+			Description:    "ttl on alias",
+			Cloud:          kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			API:            kops.AccessSpec{LoadBalancer: &kops.LoadBalancerAccessSpec{}},
+			Input:          kops.DNSRecordsSpec{API: &kops.DNSRecordSpec{TTL: &ttl}, Bastion: &kops.DNSRecordSpec{Type: kops.DNSRecordTypeAlias, TTL: &ttl}},
+			ExpectedErrors: []string{"Forbidden::dnsRecords.api.ttl", "Forbidden::dnsRecords.bastion.ttl"},
+		},
+		{
+			Description:    "zero ttl",
+			Cloud:          kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			API:            kops.AccessSpec{DNS: &kops.DNSAccessSpec{}},
+			Input:          kops.DNSRecordsSpec{API: &kops.DNSRecordSpec{TTL: &zero}},
+			ExpectedErrors: []string{"Invalid value::dnsRecords.api.ttl"},
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.Description, func(t *testing.T) {
+			cluster := &kops.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster.example.com"},
+				Spec: kops.ClusterSpec{
+					CloudProvider: g.Cloud,
+					API:           &g.API,
+				},
+			}
+			errs := validateDNSRecords(cluster, &g.Input, field.NewPath("dnsRecords"))
+			testErrors(t, g.Input, errs, g.ExpectedErrors)
+		})
+	}
+
+	t.Run("gossip", func(t *testing.T) {
+		cluster := &kops.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster.k8s.local"},
+		}
+		input := kops.DNSRecordsSpec{API: &kops.DNSRecordSpec{TTL: &ttl}}
+		errs := validateDNSRecords(cluster, &input, field.NewPath("dnsRecords"))
+		testErrors(t, input, errs, []string{"Forbidden::dnsRecords"})
+	})
+}
+
+func Test_Validate_GossipConfig(t *testing.T) {
+	grid := []struct {
+		Description    string
+		ClusterName    string
+		Input          kops.GossipConfig
+		ExpectedErrors []string
+	}{
+		{
+			Description: "encryption with the default protocols",
+			ClusterName: "cluster.k8s.local",
+			Input:       kops.GossipConfig{Encryption: fi.Bool(true)},
+		},
+		{
+			Description: "encryption with memberlist",
+			ClusterName: "cluster.k8s.local",
+			Input: kops.GossipConfig{
+				Protocol:   fi.String("memberlist"),
+				Secondary:  &kops.GossipConfigSecondary{Protocol: fi.String("")},
+				Encryption: fi.Bool(true),
+			},
+		},
+		{
+			Description: "encryption with mesh only",
+			ClusterName: "cluster.k8s.local",
+			Input: kops.GossipConfig{
+				Protocol:   fi.String("mesh"),
+				Secondary:  &kops.GossipConfigSecondary{Protocol: fi.String("")},
+				Encryption: fi.Bool(true),
+			},
+			ExpectedErrors: []string{"Forbidden::gossipConfig.encryption"},
+		},
+		{
+			Description:    "encryption without gossip DNS",
+			ClusterName:    "cluster.example.com",
+			Input:          kops.GossipConfig{Encryption: fi.Bool(true)},
+			ExpectedErrors: []string{"Forbidden::gossipConfig.encryption"},
+		},
+		{
+			Description:    "unknown protocol",
+			ClusterName:    "cluster.k8s.local",
+			Input:          kops.GossipConfig{Protocol: fi.String("serf")},
+			ExpectedErrors: []string{"Unsupported value::gossipConfig.protocol"},
+		},
+		{
+			Description: "listen address without a port",
+			ClusterName: "cluster.k8s.local",
+			Input: kops.GossipConfig{
+				Secondary: &kops.GossipConfigSecondary{Protocol: fi.String("memberlist"), Listen: fi.String("0.0.0.0")},
+			},
+			ExpectedErrors: []string{"Invalid value::gossipConfig.secondary.listen"},
+		},
+		{
+			Description: "primary and secondary on the same address",
+			ClusterName: "cluster.k8s.local",
+			Input: kops.GossipConfig{
+				Listen:    fi.String("0.0.0.0:3999"),
+				Secondary: &kops.GossipConfigSecondary{Protocol: fi.String("memberlist"), Listen: fi.String("0.0.0.0:3999")},
+			},
+			ExpectedErrors: []string{"Duplicate value::gossipConfig.secondary.listen"},
+		},
+		{
+			Description: "intervals with the default protocols",
+			ClusterName: "cluster.k8s.local",
+			Input: kops.GossipConfig{
+				ProbeInterval:    &metav1.Duration{Duration: 5 * time.Second},
+				GossipInterval:   &metav1.Duration{Duration: time.Second},
+				PushPullInterval: &metav1.Duration{Duration: 5 * time.Minute},
+			},
+		},
+		{
+			Description: "intervals with mesh only",
+			ClusterName: "cluster.k8s.local",
+			Input: kops.GossipConfig{
+				Protocol:      fi.String("mesh"),
+				Secondary:     &kops.GossipConfigSecondary{Protocol: fi.String("")},
+				ProbeInterval: &metav1.Duration{Duration: 5 * time.Second},
+			},
+			ExpectedErrors: []string{"Forbidden::gossipConfig.probeInterval"},
+		},
+		{
+			Description:    "zero interval",
+			ClusterName:    "cluster.k8s.local",
+			Input:          kops.GossipConfig{GossipInterval: &metav1.Duration{}},
+			ExpectedErrors: []string{"Invalid value::gossipConfig.gossipInterval"},
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.Description, func(t *testing.T) {
+			cluster := &kops.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: g.ClusterName},
+			}
+			errs := validateGossipConfig(cluster, &g.Input, field.NewPath("gossipConfig"))
+			testErrors(t, g.Input, errs, g.ExpectedErrors)
+		})
+	}
+}
+
+func Test_Validate_DNSControllerGossipConfig(t *testing.T) {
+	grid := []struct {
+		Description    string
+		Input          kops.DNSControllerGossipConfig
+		ExpectedErrors []string
+	}{
+		{
+			Description: "memberlist with intervals",
+			Input: kops.DNSControllerGossipConfig{
+				Protocol:      fi.String("memberlist"),
+				Listen:        fi.String("0.0.0.0:3993"),
+				ProbeInterval: &metav1.Duration{Duration: 5 * time.Second},
+			},
+		},
+		{
+			Description: "secondary memberlist with intervals",
+			Input: kops.DNSControllerGossipConfig{
+				Secondary:        &kops.DNSControllerGossipConfigSecondary{Protocol: fi.String("memberlist")},
+				PushPullInterval: &metav1.Duration{Duration: 5 * time.Minute},
+			},
+		},
+		{
+			Description: "intervals with mesh only",
+			Input: kops.DNSControllerGossipConfig{
+				ProbeInterval: &metav1.Duration{Duration: 5 * time.Second},
+			},
+			ExpectedErrors: []string{"Forbidden::dnsControllerGossipConfig.probeInterval"},
+		},
+		{
+			Description: "invalid port",
+			Input: kops.DNSControllerGossipConfig{
+				Listen: fi.String("0.0.0.0:99999"),
+			},
+			ExpectedErrors: []string{"Invalid value::dnsControllerGossipConfig.listen"},
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.Description, func(t *testing.T) {
+			errs := validateDNSControllerGossipConfig(&g.Input, field.NewPath("dnsControllerGossipConfig"))
+			testErrors(t, g.Input, errs, g.ExpectedErrors)
+		})
+	}
+}
+
+func Test_Validate_CertificateIssuer(t *testing.T) {
+	grid := []struct {
+		Description    string
+		Cloud          kops.CloudProviderSpec
+		Input          kops.CertificateIssuerSpec
+		ExpectedErrors []string
+	}{
+		{
+			Description: "AWS Private CA",
+			Cloud:       kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			Input: kops.CertificateIssuerSpec{
+				AWSPrivateCA: &kops.AWSPrivateCAIssuerSpec{ARN: "arn:aws:acm-pca:us-east-1:123456789012:certificate-authority/12345678-1234-1234-1234-123456789012"},
+			},
+		},
+		{
+			Description: "AWS Private CA with invalid ARN",
+			Cloud:       kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			Input: kops.CertificateIssuerSpec{
+				AWSPrivateCA: &kops.AWSPrivateCAIssuerSpec{ARN: "arn:aws:iam::123456789012:policy/KopsExamplePolicy"},
+			},
+			ExpectedErrors: []string{"Invalid value::spec.certificateIssuer.awsPrivateCA.arn"},
+		},
+		{
+			Description: "AWS Private CA on GCE",
+			Cloud:       kops.CloudProviderSpec{GCE: &kops.GCESpec{}},
+			Input: kops.CertificateIssuerSpec{
+				AWSPrivateCA: &kops.AWSPrivateCAIssuerSpec{ARN: "arn:aws:acm-pca:us-east-1:123456789012:certificate-authority/12345678-1234-1234-1234-123456789012"},
+			},
+			ExpectedErrors: []string{"Forbidden::spec.certificateIssuer.awsPrivateCA"},
+		},
+		{
+			Description: "GCP CAS",
+			Cloud:       kops.CloudProviderSpec{GCE: &kops.GCESpec{}},
+			Input: kops.CertificateIssuerSpec{
+				GCPCAS: &kops.GCPCASIssuerSpec{CAPool: "projects/my-project/locations/us-central1/caPools/my-pool"},
+			},
+		},
+		{
+			Description: "GCP CAS with invalid pool",
+			Cloud:       kops.CloudProviderSpec{GCE: &kops.GCESpec{}},
+			Input: kops.CertificateIssuerSpec{
+				GCPCAS: &kops.GCPCASIssuerSpec{CAPool: "my-pool"},
+			},
+			ExpectedErrors: []string{"Invalid value::spec.certificateIssuer.gcpCAS.caPool"},
+		},
+		{
+			Description: "GCP CAS on AWS",
+			Cloud:       kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			Input: kops.CertificateIssuerSpec{
+				GCPCAS: &kops.GCPCASIssuerSpec{CAPool: "projects/my-project/locations/us-central1/caPools/my-pool"},
+			},
+			ExpectedErrors: []string{"Forbidden::spec.certificateIssuer.gcpCAS"},
+		},
+		{
+			Description: "Both issuers",
+			Cloud:       kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			Input: kops.CertificateIssuerSpec{
+				AWSPrivateCA: &kops.AWSPrivateCAIssuerSpec{ARN: "arn:aws:acm-pca:us-east-1:123456789012:certificate-authority/12345678-1234-1234-1234-123456789012"},
+				GCPCAS:       &kops.GCPCASIssuerSpec{CAPool: "projects/my-project/locations/us-central1/caPools/my-pool"},
+			},
+			ExpectedErrors: []string{"Forbidden::spec.certificateIssuer"},
+		},
+		{
+			Description:    "No issuer",
+			Cloud:          kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			ExpectedErrors: []string{"Required value::spec.certificateIssuer"},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.Description, func(t *testing.T) {
+			spec := &kops.ClusterSpec{CloudProvider: g.Cloud}
+			errs := validateCertificateIssuer(spec, &g.Input, field.NewPath("spec", "certificateIssuer"))
+			testErrors(t, g.Input, errs, g.ExpectedErrors)
+		})
+	}
+}
+
+func Test_Validate_KeyStoreKMSKey(t *testing.T) {
+	grid := []struct {
+		Description    string
+		Cloud          kops.CloudProviderSpec
+		Input          string
+		ExpectedErrors []string
+	}{
+		{
+			Description: "AWS KMS key",
+			Cloud:       kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			Input:       "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+		},
+		{
+			Description:    "AWS with invalid ARN",
+			Cloud:          kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			Input:          "arn:aws:iam::123456789012:policy/KopsExamplePolicy",
+			ExpectedErrors: []string{"Invalid value::spec.keyStoreKMSKey"},
+		},
+		{
+			Description: "GCP KMS key",
+			Cloud:       kops.CloudProviderSpec{GCE: &kops.GCESpec{}},
+			Input:       "projects/my-project/locations/global/keyRings/my-ring/cryptoKeys/my-key",
+		},
+		{
+			Description:    "GCE with AWS ARN",
+			Cloud:          kops.CloudProviderSpec{GCE: &kops.GCESpec{}},
+			Input:          "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+			ExpectedErrors: []string{"Invalid value::spec.keyStoreKMSKey"},
+		},
+		{
+			Description:    "Unsupported cloud",
+			Cloud:          kops.CloudProviderSpec{Openstack: &kops.OpenstackSpec{}},
+			Input:          "projects/my-project/locations/global/keyRings/my-ring/cryptoKeys/my-key",
+			ExpectedErrors: []string{"Forbidden::spec.keyStoreKMSKey"},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.Description, func(t *testing.T) {
+			spec := &kops.ClusterSpec{CloudProvider: g.Cloud, KeyStoreKMSKey: g.Input}
+			errs := validateKeyStoreKMSKey(spec, field.NewPath("spec", "keyStoreKMSKey"))
+			testErrors(t, g.Input, errs, g.ExpectedErrors)
+		})
+	}
+}
+
+func Test_Validate_AssetTrustPolicy(t *testing.T) {
+	publicKey := `-----BEGIN PUBLIC KEY-----
+MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEuhCC5FlOAyhA5MiHaeB8FS/FY/TB
+HtDVTfrbejHlcgWJNIKNKaFpPbTAxIt2nqBv1j5UtFaLfCumFj3vxMHPJw==
+-----END PUBLIC KEY-----`
+
+	grid := []struct {
+		Description    string
+		Input          kops.AssetTrustPolicy
+		ExpectedErrors []string
+	}{
+		{
+			Description: "valid",
+			Input: kops.AssetTrustPolicy{
+				Images: []kops.ImageTrustPolicy{{Prefix: "registry.k8s.io/", PublicKeys: []string{publicKey}}},
+				Files:  []kops.FileTrustPolicy{{URL: "https://example.com/nodeup", Hash: "aad3fd84879f4464bd205d0a3a9ec2e980e8f1ed33eb8b06a946bd7e93b766d6"}},
+			},
+		},
+		{
+			Description: "image without prefix or keys",
+			Input: kops.AssetTrustPolicy{
+				Images: []kops.ImageTrustPolicy{{}},
+			},
+			ExpectedErrors: []string{
+				"Required value::spec.assets.trustPolicy.images[0].prefix",
+				"Required value::spec.assets.trustPolicy.images[0].publicKeys",
+			},
+		},
+		{
+			Description: "invalid public key",
+			Input: kops.AssetTrustPolicy{
+				Images: []kops.ImageTrustPolicy{{Prefix: "registry.k8s.io/", PublicKeys: []string{"not a key"}}},
+			},
+			ExpectedErrors: []string{"Invalid value::spec.assets.trustPolicy.images[0].publicKeys[0]"},
+		},
+		{
+			Description: "duplicate prefix",
+			Input: kops.AssetTrustPolicy{
+				Images: []kops.ImageTrustPolicy{
+					{Prefix: "registry.k8s.io/", PublicKeys: []string{publicKey}},
+					{Prefix: "registry.k8s.io/", PublicKeys: []string{publicKey}},
+				},
+			},
+			ExpectedErrors: []string{"Duplicate value::spec.assets.trustPolicy.images[1].prefix"},
+		},
+		{
+			Description: "invalid file hash",
+			Input: kops.AssetTrustPolicy{
+				Files: []kops.FileTrustPolicy{{URL: "https://example.com/nodeup", Hash: "AAD3FD84"}},
+			},
+			ExpectedErrors: []string{"Invalid value::spec.assets.trustPolicy.files[0].hash"},
+		},
+		{
+			Description: "file without URL",
+			Input: kops.AssetTrustPolicy{
+				Files: []kops.FileTrustPolicy{{Hash: "aad3fd84879f4464bd205d0a3a9ec2e980e8f1ed33eb8b06a946bd7e93b766d6"}},
+			},
+			ExpectedErrors: []string{"Required value::spec.assets.trustPolicy.files[0].url"},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.Description, func(t *testing.T) {
+			errs := validateAssetTrustPolicy(&g.Input, field.NewPath("spec", "assets", "trustPolicy"))
+			testErrors(t, g.Input, errs, g.ExpectedErrors)
+		})
+	}
+}
+
+func Test_Validate_ContainerdRegistries(t *testing.T) {
+	grid := []struct {
+		Description    string
+		Input          kops.ContainerdConfig
+		ExpectedErrors []string
+	}{
+		{
+			Description: "valid",
+			Input: kops.ContainerdConfig{
+				Version: fi.String("1.6.6"),
+				Registries: map[string]kops.ContainerdRegistryConfig{
+					"docker.io": {Mirrors: []kops.ContainerdRegistryMirror{
+						{Endpoint: "https://mirror.example.com", SkipVerify: true, Auth: &kops.ContainerdRegistryAuth{Username: "user", PasswordSecret: "mirror-password"}},
+					}},
+					"*": {Mirrors: []kops.ContainerdRegistryMirror{{Endpoint: "http://10.0.0.1:5000"}}},
+				},
+				ConfigAdditions: fi.String(`[plugins."io.containerd.grpc.v1.cri"]
+  max_concurrent_downloads = 10`),
+			},
+		},
+		{
+			Description: "old containerd",
+			Input: kops.ContainerdConfig{
+				Version: fi.String("1.4.13"),
+				Registries: map[string]kops.ContainerdRegistryConfig{
+					"docker.io": {Mirrors: []kops.ContainerdRegistryMirror{{Endpoint: "https://mirror.example.com"}}},
+				},
+			},
+			ExpectedErrors: []string{"Forbidden::containerd.registries"},
+		},
+		{
+			Description: "invalid endpoint",
+			Input: kops.ContainerdConfig{
+				Registries: map[string]kops.ContainerdRegistryConfig{
+					"docker.io": {Mirrors: []kops.ContainerdRegistryMirror{{Endpoint: "mirror.example.com"}}},
+				},
+			},
+			ExpectedErrors: []string{"Invalid value::containerd.registries[docker.io].mirrors[0].endpoint"},
+		},
+		{
+			Description: "also in registryMirrors",
+			Input: kops.ContainerdConfig{
+				RegistryMirrors: map[string][]string{"docker.io": {"https://mirror.example.com"}},
+				Registries: map[string]kops.ContainerdRegistryConfig{
+					"docker.io": {Mirrors: []kops.ContainerdRegistryMirror{{Endpoint: "https://mirror.example.com"}}},
+				},
+			},
+			ExpectedErrors: []string{"Forbidden::containerd.registries[docker.io]"},
+		},
+		{
+			Description: "auth without username",
+			Input: kops.ContainerdConfig{
+				Registries: map[string]kops.ContainerdRegistryConfig{
+					"docker.io": {Mirrors: []kops.ContainerdRegistryMirror{{Endpoint: "https://mirror.example.com", Auth: &kops.ContainerdRegistryAuth{PasswordSecret: "mirror-password"}}}},
+				},
+			},
+			ExpectedErrors: []string{"Required value::containerd.registries[docker.io].mirrors[0].auth.username"},
+		},
+		{
+			Description: "auth without password secret",
+			Input: kops.ContainerdConfig{
+				Registries: map[string]kops.ContainerdRegistryConfig{
+					"docker.io": {Mirrors: []kops.ContainerdRegistryMirror{{Endpoint: "https://mirror.example.com", Auth: &kops.ContainerdRegistryAuth{Username: "user"}}}},
+				},
+			},
+			ExpectedErrors: []string{"Required value::containerd.registries[docker.io].mirrors[0].auth.passwordSecret"},
+		},
+		{
+			Description: "invalid password secret name",
+			Input: kops.ContainerdConfig{
+				Registries: map[string]kops.ContainerdRegistryConfig{
+					"docker.io": {Mirrors: []kops.ContainerdRegistryMirror{{Endpoint: "https://mirror.example.com", Auth: &kops.ContainerdRegistryAuth{Username: "user", PasswordSecret: "../password"}}}},
+				},
+			},
+			ExpectedErrors: []string{"Invalid value::containerd.registries[docker.io].mirrors[0].auth.passwordSecret"},
+		},
+		{
+			Description: "with configOverride",
+			Input: kops.ContainerdConfig{
+				ConfigOverride:  fi.String("version = 2"),
+				ConfigAdditions: fi.String("version = 2"),
+				Registries: map[string]kops.ContainerdRegistryConfig{
+					"docker.io": {Mirrors: []kops.ContainerdRegistryMirror{{Endpoint: "https://mirror.example.com"}}},
+				},
+			},
+			ExpectedErrors: []string{
+				"Forbidden::containerd.configAdditions",
+				"Forbidden::containerd.registries",
+			},
+		},
+		{
+			Description: "invalid configAdditions",
+			Input: kops.ContainerdConfig{
+				ConfigAdditions: fi.String("[plugins"),
+			},
+			ExpectedErrors: []string{"Invalid value::containerd.configAdditions"},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.Description, func(t *testing.T) {
+			spec := &kops.ClusterSpec{ContainerRuntime: "containerd"}
+			errs := validateContainerdConfig(spec, &g.Input, field.NewPath("containerd"), true)
+			testErrors(t, g.Input, errs, g.ExpectedErrors)
+		})
+	}
+}
+
+func Test_Validate_ContainerdRunc(t *testing.T) {
+	grid := []struct {
+		Input           kops.ContainerdConfig
+		InClusterConfig bool
+		ExpectedErrors  []string
+	}{
+		{
+			Input: kops.ContainerdConfig{
+				Version: fi.String("1.6.6"),
+				Runc: &kops.Runc{
+					Version: fi.String("1.1.4"),
+				},
+			},
+			InClusterConfig: true,
+		},
+		{
+			Input: kops.ContainerdConfig{
+				Version: fi.String("1.6.6"),
+				Runc: &kops.Runc{
+					Version: fi.String("1.1.4"),
+					Packages: &kops.PackagesConfig{
+						UrlAmd64:  fi.String("https://example.com/runc.amd64"),
+						HashAmd64: fi.String("db772be63147a4e747b4fe286c7c16a2edc4a8458bd3092ea46aaee77750e8ce"),
+					},
+				},
+			},
+			InClusterConfig: true,
+		},
+		{
+			Input: kops.ContainerdConfig{
+				Version: fi.String("1.6.6"),
+				Runc: &kops.Runc{
+					Version: fi.String("1.0.3"),
+				},
+			},
+			InClusterConfig: true,
+			ExpectedErrors:  []string{"Invalid value::containerd.runc.version"},
+		},
+		{
+			Input: kops.ContainerdConfig{
+				Version: fi.String("1.6.6"),
+				Runc: &kops.Runc{
+					Version: fi.String("latest"),
+				},
+			},
+			InClusterConfig: true,
+			ExpectedErrors:  []string{"Invalid value::containerd.runc.version"},
+		},
+		{
+			Input: kops.ContainerdConfig{
+				Version: fi.String("1.4.13"),
+				Runc: &kops.Runc{
+					Version: fi.String("1.1.4"),
+				},
+			},
+			InClusterConfig: true,
+			ExpectedErrors:  []string{"Forbidden::containerd.runc"},
+		},
+		{
+			Input: kops.ContainerdConfig{
+				Version: fi.String("1.6.6"),
+				Runc: &kops.Runc{
+					Packages: &kops.PackagesConfig{
+						UrlAmd64: fi.String("https://example.com/runc.amd64"),
+					},
+				},
+			},
+			InClusterConfig: true,
+			ExpectedErrors:  []string{"Invalid value::containerd.runc.packageUrl"},
+		},
+		{
+			Input: kops.ContainerdConfig{
+				Runc: &kops.Runc{
+					Version: fi.String("1.1.4"),
+				},
+			},
+			InClusterConfig: false,
+			ExpectedErrors:  []string{"Forbidden::containerd.runc"},
+		},
+	}
+
+	for _, g := range grid {
+		spec := &kops.ClusterSpec{ContainerRuntime: "containerd"}
+		errs := validateContainerdConfig(spec, &g.Input, field.NewPath("containerd"), g.InClusterConfig)
+		testErrors(t, g.Input, errs, g.ExpectedErrors)
+	}
+}
+
+func Test_Validate_Crio(t *testing.T) {
+	grid := []struct {
+		Description       string
+		KubernetesVersion string
+		Networking        *kops.NetworkingSpec
+		Hooks             []kops.HookSpec
+		Crio              *kops.CrioConfig
+		ExpectedErrors    []string
+	}{
+		{
+			Description:       "default",
+			KubernetesVersion: "1.24.2",
+		},
+		{
+			Description:       "matching version",
+			KubernetesVersion: "1.24.2",
+			Crio:              &kops.CrioConfig{Version: fi.String("1.24.1")},
+		},
+		{
+			Description:       "mismatched version",
+			KubernetesVersion: "1.24.2",
+			Crio:              &kops.CrioConfig{Version: fi.String("1.23.3")},
+			ExpectedErrors:    []string{"Invalid value::spec.crio.version"},
+		},
+		{
+			Description:       "invalid version",
+			KubernetesVersion: "1.24.2",
+			Crio:              &kops.CrioConfig{Version: fi.String("latest")},
+			ExpectedErrors:    []string{"Invalid value::spec.crio.version"},
+		},
+		{
+			Description:       "old kubernetes",
+			KubernetesVersion: "1.21.0",
+			ExpectedErrors:    []string{"Forbidden::spec.containerRuntime"},
+		},
+		{
+			Description:       "kubenet",
+			KubernetesVersion: "1.24.2",
+			Networking:        &kops.NetworkingSpec{Kubenet: &kops.KubenetNetworkingSpec{}},
+			ExpectedErrors:    []string{"Forbidden::spec.containerRuntime"},
+		},
+		{
+			Description:       "execContainer hook",
+			KubernetesVersion: "1.24.2",
+			Hooks:             []kops.HookSpec{{ExecContainer: &kops.ExecContainerAction{Image: "busybox"}}},
+			ExpectedErrors:    []string{"Forbidden::spec.hooks[0].execContainer"},
+		},
+		{
+			Description:       "package hash without URL",
+			KubernetesVersion: "1.24.2",
+			Crio: &kops.CrioConfig{
+				Packages: &kops.PackagesConfig{HashAmd64: fi.String("7bde2ca3fa7d2bdd1a3d0cb6b0e1fb3bd0a7cbab7d8a6c2dbbac0ee59dc8d2b3")},
+			},
+			ExpectedErrors: []string{"Invalid value::spec.crio.packageHash"},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.Description, func(t *testing.T) {
+			networking := g.Networking
+			if networking == nil {
+				networking = &kops.NetworkingSpec{Calico: &kops.CalicoNetworkingSpec{}}
+			}
+			c := &kops.Cluster{
+				Spec: kops.ClusterSpec{
+					ContainerRuntime:  "crio",
+					KubernetesVersion: g.KubernetesVersion,
+					Networking:        networking,
+					Hooks:             g.Hooks,
+					Crio:              g.Crio,
+				},
+			}
+			errs := validateContainerRuntime(c, c.Spec.ContainerRuntime, field.NewPath("spec", "containerRuntime"))
+			if c.Spec.Crio != nil {
+				errs = append(errs, validateCrioConfig(c, c.Spec.Crio, field.NewPath("spec", "crio"))...)
+			}
+			testErrors(t, g.Description, errs, g.ExpectedErrors)
+		})
+	}
+}
+
+func Test_Validate_EtcdTuning(t *testing.T) {
+	quantity := func(s string) *resource.Quantity {
+		q := resource.MustParse(s)
+		return &q
+	}
+	grid := []struct {
+		Description    string
+		Input          kops.EtcdClusterSpec
+		ExpectedErrors []string
+	}{
+		{
+			Description: "defaults",
+		},
+		{
+			Description: "all settings",
+			Input: kops.EtcdClusterSpec{
+				HeartbeatInterval:       &metav1.Duration{Duration: 250 * time.Millisecond},
+				LeaderElectionTimeout:   &metav1.Duration{Duration: 2500 * time.Millisecond},
+				QuotaBackendBytes:       quantity("8Gi"),
+				AutoCompactionMode:      "periodic",
+				AutoCompactionRetention: "30m",
+				SnapshotCount:           fi.Int64(10000),
+			},
+		},
+		{
+			Description: "periodic retention in hours",
+			Input:       kops.EtcdClusterSpec{AutoCompactionRetention: "8"},
+		},
+		{
+			Description: "revision retention",
+			Input:       kops.EtcdClusterSpec{AutoCompactionMode: "revision", AutoCompactionRetention: "1000"},
+		},
+		{
+			Description:    "heartbeat interval too long for default election timeout",
+			Input:          kops.EtcdClusterSpec{HeartbeatInterval: &metav1.Duration{Duration: 500 * time.Millisecond}},
+			ExpectedErrors: []string{"Invalid value::etcdClusters[0].leaderElectionTimeout"},
+		},
+		{
+			Description:    "election timeout too long",
+			Input:          kops.EtcdClusterSpec{LeaderElectionTimeout: &metav1.Duration{Duration: time.Minute}},
+			ExpectedErrors: []string{"Invalid value::etcdClusters[0].leaderElectionTimeout"},
+		},
+		{
+			Description:    "negative quota",
+			Input:          kops.EtcdClusterSpec{QuotaBackendBytes: quantity("-1Gi")},
+			ExpectedErrors: []string{"Invalid value::etcdClusters[0].quotaBackendBytes"},
+		},
+		{
+			Description:    "unknown compaction mode",
+			Input:          kops.EtcdClusterSpec{AutoCompactionMode: "hourly"},
+			ExpectedErrors: []string{"Unsupported value::etcdClusters[0].autoCompactionMode"},
+		},
+		{
+			Description:    "duration retention in revision mode",
+			Input:          kops.EtcdClusterSpec{AutoCompactionMode: "revision", AutoCompactionRetention: "1h"},
+			ExpectedErrors: []string{"Invalid value::etcdClusters[0].autoCompactionRetention"},
+		},
+		{
+			Description:    "invalid periodic retention",
+			Input:          kops.EtcdClusterSpec{AutoCompactionRetention: "forever"},
+			ExpectedErrors: []string{"Invalid value::etcdClusters[0].autoCompactionRetention"},
+		},
+		{
+			Description:    "zero snapshot count",
+			Input:          kops.EtcdClusterSpec{SnapshotCount: fi.Int64(0)},
+			ExpectedErrors: []string{"Invalid value::etcdClusters[0].snapshotCount"},
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.Description, func(t *testing.T) {
+			errs := validateEtcdTuning(g.Input, field.NewPath("etcdClusters").Index(0))
+			testErrors(t, g.Description, errs, g.ExpectedErrors)
+		})
+	}
+}
+
+func Test_Validate_EtcdCrossAccountStore(t *testing.T) {
+	grid := []struct {
+		Description    string
+		CloudProvider  kops.CloudProviderSpec
+		Input          kops.EtcdCrossAccountStoreSpec
+		ExpectedErrors []string
+	}{
+		{
+			Description: "bucket with instance credentials",
+			Input:       kops.EtcdCrossAccountStoreSpec{BackupStore: "s3://backups/example.com/main"},
+		},
+		{
+			Description: "bucket with assumed role",
+			Input: kops.EtcdCrossAccountStoreSpec{
+				BackupStore: "s3://backups/example.com/main",
+				RoleARN:     "arn:aws:iam::123456789012:role/etcd-backups",
+				ExternalID:  "example",
+			},
+		},
+		{
+			Description:    "missing backup store",
+			Input:          kops.EtcdCrossAccountStoreSpec{},
+			ExpectedErrors: []string{"Required value::etcdClusters[0].backups.crossAccountStore.backupStore"},
+		},
+		{
+			Description:    "inside the state store",
+			Input:          kops.EtcdCrossAccountStoreSpec{BackupStore: "s3://state/example.com/other-backups"},
+			ExpectedErrors: []string{"Invalid value::etcdClusters[0].backups.crossAccountStore.backupStore"},
+		},
+		{
+			Description:    "same as backup store",
+			Input:          kops.EtcdCrossAccountStoreSpec{BackupStore: "s3://main-backups/example.com/"},
+			ExpectedErrors: []string{"Invalid value::etcdClusters[0].backups.crossAccountStore.backupStore"},
+		},
+		{
+			Description:    "invalid role",
+			Input:          kops.EtcdCrossAccountStoreSpec{BackupStore: "s3://backups/example.com/main", RoleARN: "arn:aws:iam::123456789012:user/etcd-backups"},
+			ExpectedErrors: []string{"Invalid value::etcdClusters[0].backups.crossAccountStore.roleARN"},
+		},
+		{
+			Description:    "role with non-S3 store",
+			Input:          kops.EtcdCrossAccountStoreSpec{BackupStore: "gs://backups/example.com/main", RoleARN: "arn:aws:iam::123456789012:role/etcd-backups"},
+			ExpectedErrors: []string{"Forbidden::etcdClusters[0].backups.crossAccountStore.roleARN"},
+		},
+		{
+			Description:    "role on GCE",
+			CloudProvider:  kops.CloudProviderSpec{GCE: &kops.GCESpec{}},
+			Input:          kops.EtcdCrossAccountStoreSpec{BackupStore: "gs://backups/example.com/main", RoleARN: "arn:aws:iam::123456789012:role/etcd-backups"},
+			ExpectedErrors: []string{"Forbidden::etcdClusters[0].backups.crossAccountStore.roleARN"},
+		},
+		{
+			Description:    "external ID without role",
+			Input:          kops.EtcdCrossAccountStoreSpec{BackupStore: "s3://backups/example.com/main", ExternalID: "example"},
+			ExpectedErrors: []string{"Forbidden::etcdClusters[0].backups.crossAccountStore.externalID"},
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.Description, func(t *testing.T) {
+			cloudProvider := g.CloudProvider
+			if cloudProvider.GCE == nil {
+				cloudProvider.AWS = &kops.AWSSpec{}
+			}
+			cluster := &kops.Cluster{
+				Spec: kops.ClusterSpec{
+					ConfigBase:    "s3://state/example.com",
+					CloudProvider: cloudProvider,
+				},
+			}
+			backups := &kops.EtcdBackupSpec{
+				BackupStore:       "s3://main-backups/example.com",
+				CrossAccountStore: &g.Input,
+			}
+			errs := validateEtcdCrossAccountStore(backups, cluster, field.NewPath("etcdClusters").Index(0).Child("backups", "crossAccountStore"))
+			testErrors(t, g.Description, errs, g.ExpectedErrors)
+		})
+	}
+}
@@ -31,6 +31,8 @@ type Config struct {
 	// Assets are locations where we can find files to be installed
 	// TODO: Remove once everything is in containers?
 	Assets map[architectures.Architecture][]string `json:",omitempty"`
+	// AssetCache configures caches that nodeup tries before downloading assets.
+	AssetCache *kops.AssetCacheSpec `json:"assetCache,omitempty"`
 	// Images are a list of images we should preload
 	Images map[architectures.Architecture][]*Image `json:"images,omitempty"`
 	// ClusterName is the name of the cluster
@@ -41,6 +43,8 @@ type Config struct {
 	ApiserverAdditionalIPs []string `json:",omitempty"`
 	// WarmPoolImages are the container images to pre-pull during instance pre-initialization
 	WarmPoolImages []string `json:"warmPoolImages,omitempty"`
+	// PreloadImages are the container images to pull before the kubelet is started.
+	PreloadImages []string `json:"preloadImages,omitempty"`
 	// Packages specifies additional packages to be installed.
 	Packages []string `json:"packages,omitempty"`
 
@@ -82,6 +86,8 @@ type Config struct {
 	NvidiaGPU *kops.NvidiaGPUConfig `json:",omitempty"`
 	// UseInstanceIDForNodeName uses the instance ID instead of the hostname for the node name.
 	UseInstanceIDForNodeName bool `json:"useInstanceIDForNodeName,omitempty"`
+	// GossipEncryptionKeys are the base64 encoded keys used to encrypt memberlist gossip, with the primary key first.
+	GossipEncryptionKeys []string `json:"gossipEncryptionKeys,omitempty"`
 }
 
 // BootConfig is the configuration for the nodeup binary that might be too big to fit in userdata.
@@ -152,6 +158,7 @@ func NewConfig(cluster *kops.Cluster, instanceGroup *kops.InstanceGroup) (*Confi
 		VolumeMounts:     instanceGroup.Spec.VolumeMounts,
 		FileAssets:       append(filterFileAssets(instanceGroup.Spec.FileAssets, role), filterFileAssets(cluster.Spec.FileAssets, role)...),
 		Hooks:            [][]kops.HookSpec{igHooks, clusterHooks},
+		AssetCache:       instanceGroup.Spec.AssetCache,
 	}
 
 	bootConfig := BootConfig{
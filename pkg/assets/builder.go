@@ -52,6 +52,9 @@ type AssetBuilder struct {
 
 	// StaticManifests records static manifests
 	StaticManifests []*StaticManifest
+
+	// verifiedImages maps the images whose signature was verified against the trust policy to their digest.
+	verifiedImages map[string]string
 }
 
 type StaticManifest struct {
@@ -72,6 +75,8 @@ type ImageAsset struct {
 	DownloadLocation string
 	// CanonicalLocation will be the source location of the image.
 	CanonicalLocation string
+	// Signed records that the image is covered by the trust policy, so its cosign signatures must be copied with it.
+	Signed bool
 }
 
 // FileAsset models a file's location.
@@ -202,6 +207,26 @@ func (a *AssetBuilder) RemapImage(image string) (string, error) {
 
 	a.ImageAssets = append(a.ImageAssets, asset)
 
+	if policy := a.imageTrustPolicy(asset.CanonicalLocation); policy != nil {
+		asset.Signed = true
+
+		// When getting assets, the images haven't been copied yet, so they are verified at their source.
+		location := image
+		if a.GetAssets {
+			location = asset.CanonicalLocation
+		}
+		digest, err := a.verifyImage(location, policy)
+		if err != nil {
+			return "", err
+		}
+
+		// Pin the digest that was verified
+		if strings.Contains(image, "@") {
+			return image, nil
+		}
+		return image + "@" + digest, nil
+	}
+
 	if !featureflag.ImageDigest.Enabled() || os.Getenv("KOPS_BASE_URL") != "" {
 		return image, nil
 	}
@@ -249,6 +274,10 @@ func (a *AssetBuilder) RemapFileAndSHA(fileURL *url.URL) (*url.URL, *hashing.Has
 	}
 	fileAsset.SHAValue = h.Hex()
 
+	if err := a.verifyFileHash(fileAsset.CanonicalURL, fileAsset.SHAValue); err != nil {
+		return nil, nil, err
+	}
+
 	klog.V(8).Infof("adding file: %+v", fileAsset)
 	a.FileAssets = append(a.FileAssets, fileAsset)
 
@@ -267,6 +296,10 @@ func (a *AssetBuilder) RemapFileAndSHAValue(fileURL *url.URL, shaValue string) (
 		SHAValue:     shaValue,
 	}
 
+	if err := a.verifyFileHash(fileAsset.CanonicalURL, fileAsset.SHAValue); err != nil {
+		return nil, err
+	}
+
 	if a.AssetsLocation != nil && a.AssetsLocation.FileRepository != nil {
 		normalizedFile, err := a.remapURL(fileURL)
 		if err != nil {
@@ -317,7 +350,7 @@ func (a *AssetBuilder) findHash(file *FileAsset) (*hashing.Hash, error) {
 			Steps:    backoffSteps,
 		}
 
-		for _, ext := range []string{".sha256", ".sha1"} {
+		for _, ext := range []string{".sha256", ".sha256sum", ".sha1"} {
 			for _, mirror := range mirrors.FindUrlMirrors(u.String()) {
 				hashURL := mirror + ext
 				klog.V(3).Infof("Trying to read hash fie: %q", hashURL)
@@ -19,6 +19,8 @@ package assets
 import (
 	"fmt"
 	"sort"
+	"strings"
+	"sync"
 
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/apis/kops"
@@ -28,7 +30,9 @@ type assetTask interface {
 	Run() error
 }
 
-func Copy(imageAssets []*ImageAsset, fileAssets []*FileAsset, cluster *kops.Cluster) error {
+// Copy copies the image and file assets from their canonical locations to their download locations,
+// running up to parallelism copies at a time.
+func Copy(imageAssets []*ImageAsset, fileAssets []*FileAsset, cluster *kops.Cluster, parallelism int) error {
 	tasks := map[string]assetTask{}
 
 	for _, imageAsset := range imageAssets {
@@ -37,6 +41,8 @@ func Copy(imageAssets []*ImageAsset, fileAssets []*FileAsset, cluster *kops.Clus
 				Name:        imageAsset.DownloadLocation,
 				SourceImage: imageAsset.CanonicalLocation,
 				TargetImage: imageAsset.DownloadLocation,
+
+				CopySignatures: imageAsset.Signed,
 			}
 
 			if existing, ok := tasks[copyImageTask.Name]; ok {
@@ -76,44 +82,49 @@ func Copy(imageAssets []*ImageAsset, fileAssets []*FileAsset, cluster *kops.Clus
 		}
 	}
 
-	ch := make(chan error, 5)
-	for i := 0; i < cap(ch); i++ {
-		ch <- nil
+	return runCopyTasks(tasks, parallelism)
+}
+
+// runCopyTasks runs the tasks, at most parallelism at a time.
+// A failed task doesn't stop the others; as tasks skip assets that are already present at their target,
+// running the copy again resumes it.
+func runCopyTasks(tasks map[string]assetTask, parallelism int) error {
+	if parallelism < 1 {
+		parallelism = 1
 	}
 
-	gotError := false
 	names := make([]string, 0, len(tasks))
 	for name := range tasks {
 		names = append(names, name)
 	}
 	sort.Strings(names)
+
+	var mutex sync.Mutex
+	var failed []string
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, parallelism)
 	for _, name := range names {
-		task := tasks[name]
-		err := <-ch
-		if err != nil {
-			klog.Warning(err)
-			gotError = true
-		}
+		sem <- struct{}{}
+		wg.Add(1)
 		go func(n string, t assetTask) {
-			err := t.Run()
-			if err != nil {
-				err = fmt.Errorf("%s: %v", n, err)
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if err := t.Run(); err != nil {
+				klog.Warningf("%s: %v", n, err)
+				mutex.Lock()
+				failed = append(failed, n)
+				mutex.Unlock()
 			}
-			ch <- err
-		}(name, task)
-	}
-
-	for i := 0; i < cap(ch); i++ {
-		err := <-ch
-		if err != nil {
-			klog.Warning(err)
-			gotError = true
-		}
+		}(name, tasks[name])
 	}
+	wg.Wait()
 
-	close(ch)
-	if gotError {
-		return fmt.Errorf("not all assets copied successfully")
+	if len(failed) != 0 {
+		sort.Strings(failed)
+		return fmt.Errorf("%d of %d assets were not copied successfully (run the copy again to resume): %s", len(failed), len(tasks), strings.Join(failed, ", "))
 	}
 	return nil
 }
@@ -0,0 +1,84 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package assets
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeCopyTask struct {
+	mutex   *sync.Mutex
+	running *int
+	maxSeen *int
+	ran     bool
+	err     error
+}
+
+func (t *fakeCopyTask) Run() error {
+	t.mutex.Lock()
+	*t.running++
+	if *t.running > *t.maxSeen {
+		*t.maxSeen = *t.running
+	}
+	t.mutex.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	t.mutex.Lock()
+	*t.running--
+	t.ran = true
+	t.mutex.Unlock()
+	return t.err
+}
+
+func TestRunCopyTasks(t *testing.T) {
+	var mutex sync.Mutex
+	var running, maxSeen int
+
+	fakeTasks := map[string]*fakeCopyTask{}
+	tasks := map[string]assetTask{}
+	for i := 0; i < 10; i++ {
+		task := &fakeCopyTask{mutex: &mutex, running: &running, maxSeen: &maxSeen}
+		if i == 3 || i == 7 {
+			task.err = fmt.Errorf("copy failed")
+		}
+		name := fmt.Sprintf("asset-%d", i)
+		fakeTasks[name] = task
+		tasks[name] = task
+	}
+
+	err := runCopyTasks(tasks, 3)
+	if err == nil {
+		t.Fatalf("expected error from failed tasks")
+	}
+	if !strings.Contains(err.Error(), "2 of 10 assets") || !strings.Contains(err.Error(), "asset-3, asset-7") {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	for name, task := range fakeTasks {
+		if !task.ran {
+			t.Errorf("task %s was not run", name)
+		}
+	}
+	if maxSeen > 3 {
+		t.Errorf("expected at most 3 tasks running at the same time, saw %d", maxSeen)
+	}
+}
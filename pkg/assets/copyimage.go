@@ -32,6 +32,9 @@ type CopyImage struct {
 	Name        string
 	SourceImage string
 	TargetImage string
+
+	// CopySignatures copies the cosign signatures of the image along with it.
+	CopySignatures bool
 }
 
 func (e *CopyImage) Run() error {
@@ -58,19 +61,38 @@ func (e *CopyImage) Run() error {
 	targetDesc, err := remote.Get(targetRef, options...)
 	if err == nil && desc.Digest.String() == targetDesc.Digest.String() {
 		klog.Infof("no need to copy image from %v to %v", sourceRef, targetRef)
-		return nil
+	} else {
+		switch desc.MediaType {
+		case types.OCIImageIndex, types.DockerManifestList:
+			// Handle indexes separately.
+			if err := copyIndex(desc, sourceRef, targetRef, options...); err != nil {
+				return fmt.Errorf("failed to copy index: %v", err)
+			}
+		default:
+			// Assume anything else is an image, since some registries don't set mediaTypes properly.
+			if err := copyImage(desc, sourceRef, targetRef, options...); err != nil {
+				return fmt.Errorf("failed to copy image: %v", err)
+			}
+		}
 	}
 
-	switch desc.MediaType {
-	case types.OCIImageIndex, types.DockerManifestList:
-		// Handle indexes separately.
-		if err := copyIndex(desc, sourceRef, targetRef, options...); err != nil {
-			return fmt.Errorf("failed to copy index: %v", err)
+	if e.CopySignatures {
+		// cosign stores the signatures in the repository of the image, under a tag derived from its digest
+		tag := cosignSignatureTag(desc.Digest)
+		sourceSignatureRef := sourceRef.Context().Tag(tag)
+		targetSignatureRef := targetRef.Context().Tag(tag)
+
+		signatureDesc, err := remote.Get(sourceSignatureRef, options...)
+		if err != nil {
+			return fmt.Errorf("fetching signatures %q: %v", sourceSignatureRef, err)
+		}
+		targetSignatureDesc, err := remote.Get(targetSignatureRef, options...)
+		if err == nil && signatureDesc.Digest.String() == targetSignatureDesc.Digest.String() {
+			klog.Infof("no need to copy signatures from %v to %v", sourceSignatureRef, targetSignatureRef)
+			return nil
 		}
-	default:
-		// Assume anything else is an image, since some registries don't set mediaTypes properly.
-		if err := copyImage(desc, sourceRef, targetRef, options...); err != nil {
-			return fmt.Errorf("failed to copy image: %v", err)
+		if err := copyImage(signatureDesc, sourceSignatureRef, targetSignatureRef, options...); err != nil {
+			return fmt.Errorf("failed to copy signatures: %v", err)
 		}
 	}
 
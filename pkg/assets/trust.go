@@ -0,0 +1,230 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package assets
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/pki"
+)
+
+const (
+	// cosignSimpleSigningMediaType is the media type of the layers of a cosign signature image.
+	cosignSimpleSigningMediaType = "application/vnd.dev.cosign.simplesigning.v1+json"
+	// cosignSignatureAnnotation is the layer annotation holding the base64-encoded signature of the layer.
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+)
+
+// cosignPayload is the simple signing payload that cosign signs.
+type cosignPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// imageTrustPolicy returns the trust policy for the image with the given canonical location, or nil if there is none.
+// When several policies match, the one with the longest prefix is used.
+func (a *AssetBuilder) imageTrustPolicy(canonicalLocation string) *kops.ImageTrustPolicy {
+	if a.AssetsLocation == nil || a.AssetsLocation.TrustPolicy == nil {
+		return nil
+	}
+
+	var match *kops.ImageTrustPolicy
+	for i := range a.AssetsLocation.TrustPolicy.Images {
+		policy := &a.AssetsLocation.TrustPolicy.Images[i]
+		if strings.HasPrefix(canonicalLocation, policy.Prefix) && (match == nil || len(policy.Prefix) > len(match.Prefix)) {
+			match = policy
+		}
+	}
+	return match
+}
+
+// verifyImage checks that the image has a cosign signature made with one of the keys of the policy,
+// returning the digest of the verified image.
+func (a *AssetBuilder) verifyImage(image string, policy *kops.ImageTrustPolicy) (string, error) {
+	if digest, found := a.verifiedImages[image]; found {
+		return digest, nil
+	}
+
+	var publicKeys []crypto.PublicKey
+	for _, data := range policy.PublicKeys {
+		publicKey, err := pki.ParsePEMPublicKey([]byte(data))
+		if err != nil {
+			return "", fmt.Errorf("parsing public key of trust policy for %q: %w", policy.Prefix, err)
+		}
+		publicKeys = append(publicKeys, publicKey.Key)
+	}
+
+	digest, err := verifyCosignSignature(image, publicKeys)
+	if err != nil {
+		return "", fmt.Errorf("verifying signature of image %q: %w", image, err)
+	}
+	klog.V(2).Infof("verified signature of image %q (%s)", image, digest)
+
+	if a.verifiedImages == nil {
+		a.verifiedImages = make(map[string]string)
+	}
+	a.verifiedImages[image] = digest
+	return digest, nil
+}
+
+// verifyCosignSignature looks for a cosign signature of the image made with one of the public keys,
+// returning the digest of the image.
+func verifyCosignSignature(image string, publicKeys []crypto.PublicKey) (string, error) {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return "", fmt.Errorf("parsing reference %q: %w", image, err)
+	}
+
+	options := []remote.Option{remote.WithAuthFromKeychain(authn.DefaultKeychain)}
+
+	desc, err := remote.Get(ref, options...)
+	if err != nil {
+		return "", fmt.Errorf("fetching %q: %w", image, err)
+	}
+
+	signatureRef := ref.Context().Tag(cosignSignatureTag(desc.Digest))
+	signatureImage, err := remote.Image(signatureRef, options...)
+	if err != nil {
+		return "", fmt.Errorf("fetching signatures from %q: %w", signatureRef, err)
+	}
+	manifest, err := signatureImage.Manifest()
+	if err != nil {
+		return "", fmt.Errorf("reading manifest of %q: %w", signatureRef, err)
+	}
+
+	for _, layer := range manifest.Layers {
+		if string(layer.MediaType) != cosignSimpleSigningMediaType {
+			continue
+		}
+		signature, found := layer.Annotations[cosignSignatureAnnotation]
+		if !found {
+			continue
+		}
+
+		payload, err := readLayer(signatureImage, layer.Digest)
+		if err != nil {
+			return "", fmt.Errorf("reading signature payload from %q: %w", signatureRef, err)
+		}
+
+		if err := verifyCosignPayload(payload, signature, desc.Digest.String(), publicKeys); err != nil {
+			klog.V(2).Infof("ignoring signature %s of %q: %v", layer.Digest, image, err)
+			continue
+		}
+		return desc.Digest.String(), nil
+	}
+
+	return "", fmt.Errorf("no signature made with a trusted key was found in %q", signatureRef)
+}
+
+// cosignSignatureTag returns the tag under which cosign stores the signatures of the image with the given digest.
+func cosignSignatureTag(digest v1.Hash) string {
+	return digest.Algorithm + "-" + digest.Hex + ".sig"
+}
+
+func readLayer(image v1.Image, digest v1.Hash) ([]byte, error) {
+	layer, err := image.LayerByDigest(digest)
+	if err != nil {
+		return nil, err
+	}
+	r, err := layer.Compressed()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// verifyCosignPayload checks that signature is a signature of payload made with one of the public keys,
+// and that payload is about the image with the given digest.
+func verifyCosignPayload(payload []byte, signature string, digest string, publicKeys []crypto.PublicKey) error {
+	signatureBytes, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("decoding signature: %w", err)
+	}
+
+	verified := false
+	for _, publicKey := range publicKeys {
+		if verifySignature(publicKey, payload, signatureBytes) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return fmt.Errorf("signature was not made with a trusted key")
+	}
+
+	var p cosignPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return fmt.Errorf("parsing signature payload: %w", err)
+	}
+	if p.Critical.Image.DockerManifestDigest != digest {
+		return fmt.Errorf("signature is for digest %q, not %q", p.Critical.Image.DockerManifestDigest, digest)
+	}
+	return nil
+}
+
+func verifySignature(publicKey crypto.PublicKey, payload []byte, signature []byte) bool {
+	hash := sha256.Sum256(payload)
+	switch k := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(k, hash[:], signature)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(k, crypto.SHA256, hash[:], signature) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(k, payload, signature)
+	default:
+		klog.Warningf("ignoring public key of unsupported type %T", publicKey)
+		return false
+	}
+}
+
+// verifyFileHash checks the hex-encoded hash of a file against the trust policy, if the policy pins it.
+func (a *AssetBuilder) verifyFileHash(canonicalURL *url.URL, hash string) error {
+	if a.AssetsLocation == nil || a.AssetsLocation.TrustPolicy == nil {
+		return nil
+	}
+
+	for _, policy := range a.AssetsLocation.TrustPolicy.Files {
+		if policy.URL != canonicalURL.String() {
+			continue
+		}
+		if !strings.EqualFold(hash, policy.Hash) {
+			return fmt.Errorf("hash of file %q is %q, but the trust policy requires %q", canonicalURL, hash, policy.Hash)
+		}
+		klog.V(2).Infof("verified hash of file %q", canonicalURL)
+	}
+	return nil
+}
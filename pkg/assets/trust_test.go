@@ -0,0 +1,119 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package assets
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/url"
+	"testing"
+
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+func TestVerifyCosignPayload(t *testing.T) {
+	trustedKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("error generating key: %v", err)
+	}
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("error generating key: %v", err)
+	}
+
+	digest := "sha256:aad3fd84879f4464bd205d0a3a9ec2e980e8f1ed33eb8b06a946bd7e93b766d6"
+	payload := []byte(`{"critical":{"identity":{"docker-reference":"registry.k8s.io/kube-apiserver"},"image":{"docker-manifest-digest":"` + digest + `"},"type":"cosign container image signature"},"optional":null}`)
+
+	sign := func(key *ecdsa.PrivateKey) string {
+		hash := sha256.Sum256(payload)
+		signature, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+		if err != nil {
+			t.Fatalf("error signing payload: %v", err)
+		}
+		return base64.StdEncoding.EncodeToString(signature)
+	}
+
+	publicKeys := []crypto.PublicKey{&trustedKey.PublicKey}
+
+	if err := verifyCosignPayload(payload, sign(trustedKey), digest, publicKeys); err != nil {
+		t.Errorf("unexpected error verifying payload: %v", err)
+	}
+	if err := verifyCosignPayload(payload, sign(otherKey), digest, publicKeys); err == nil {
+		t.Errorf("expected error verifying payload signed with an untrusted key")
+	}
+	if err := verifyCosignPayload(payload, sign(trustedKey), "sha256:0000000000000000000000000000000000000000000000000000000000000000", publicKeys); err == nil {
+		t.Errorf("expected error verifying payload for another digest")
+	}
+	if err := verifyCosignPayload(payload, "not base64!", digest, publicKeys); err == nil {
+		t.Errorf("expected error verifying invalid signature")
+	}
+}
+
+func TestImageTrustPolicy(t *testing.T) {
+	builder := buildAssetBuilder(t)
+	builder.AssetsLocation.TrustPolicy = &kops.AssetTrustPolicy{
+		Images: []kops.ImageTrustPolicy{
+			{Prefix: "registry.k8s.io/"},
+			{Prefix: "registry.k8s.io/kops/"},
+		},
+	}
+
+	grid := []struct {
+		image  string
+		prefix string
+	}{
+		{image: "registry.k8s.io/kube-apiserver:v1.24.0", prefix: "registry.k8s.io/"},
+		{image: "registry.k8s.io/kops/kops-controller:1.25.0", prefix: "registry.k8s.io/kops/"},
+		{image: "quay.io/cilium/cilium:v1.11.6"},
+	}
+	for _, g := range grid {
+		policy := builder.imageTrustPolicy(g.image)
+		prefix := ""
+		if policy != nil {
+			prefix = policy.Prefix
+		}
+		if prefix != g.prefix {
+			t.Errorf("unexpected trust policy for %q: expected prefix %q, got %q", g.image, g.prefix, prefix)
+		}
+	}
+}
+
+func TestRemapFileAndSHAValue_TrustPolicy(t *testing.T) {
+	builder := buildAssetBuilder(t)
+	builder.AssetsLocation.TrustPolicy = &kops.AssetTrustPolicy{
+		Files: []kops.FileTrustPolicy{
+			{URL: "https://example.com/nodeup", Hash: "aad3fd84879f4464bd205d0a3a9ec2e980e8f1ed33eb8b06a946bd7e93b766d6"},
+		},
+	}
+
+	nodeup, _ := url.Parse("https://example.com/nodeup")
+	other, _ := url.Parse("https://example.com/other")
+
+	if _, err := builder.RemapFileAndSHAValue(nodeup, "aad3fd84879f4464bd205d0a3a9ec2e980e8f1ed33eb8b06a946bd7e93b766d6"); err != nil {
+		t.Errorf("unexpected error for file matching the trust policy: %v", err)
+	}
+	if _, err := builder.RemapFileAndSHAValue(nodeup, "0000000000000000000000000000000000000000000000000000000000000000"); err == nil {
+		t.Errorf("expected error for file not matching the trust policy")
+	}
+	if _, err := builder.RemapFileAndSHAValue(other, "0000000000000000000000000000000000000000000000000000000000000000"); err != nil {
+		t.Errorf("unexpected error for file not covered by the trust policy: %v", err)
+	}
+}
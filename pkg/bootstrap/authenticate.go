@@ -18,6 +18,8 @@ package bootstrap
 
 import (
 	"context"
+	"fmt"
+	"strings"
 )
 
 // Authenticator generates authentication credentials for requests.
@@ -41,3 +43,21 @@ type VerifyResult struct {
 type Verifier interface {
 	VerifyToken(ctx context.Context, token string, body []byte, useInstanceIDForNodeName bool) (*VerifyResult, error)
 }
+
+// prefixVerifier passes each token to the Verifier registered for the prefix of the token.
+type prefixVerifier map[string]Verifier
+
+// NewPrefixVerifier returns a Verifier that accepts tokens with any of the prefixes of verifiers,
+// passing each token to the Verifier registered for its prefix.
+func NewPrefixVerifier(verifiers map[string]Verifier) Verifier {
+	return prefixVerifier(verifiers)
+}
+
+func (v prefixVerifier) VerifyToken(ctx context.Context, token string, body []byte, useInstanceIDForNodeName bool) (*VerifyResult, error) {
+	for prefix, verifier := range v {
+		if strings.HasPrefix(token, prefix) {
+			return verifier.VerifyToken(ctx, token, body, useInstanceIDForNodeName)
+		}
+	}
+	return nil, fmt.Errorf("incorrect authorization type")
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metal
+
+import (
+	"crypto"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"k8s.io/kops/pkg/bootstrap"
+	"k8s.io/kops/pkg/pki"
+)
+
+type hostAuthenticator struct {
+	certificate string
+	key         *pki.PrivateKey
+}
+
+var _ bootstrap.Authenticator = &hostAuthenticator{}
+
+// NewAuthenticator returns an authenticator that signs requests with the host certificate
+// installed by "kops toolbox enroll".
+func NewAuthenticator() (bootstrap.Authenticator, error) {
+	certificate, err := os.ReadFile(HostCertificatePath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading host certificate: %w", err)
+	}
+	keyBytes, err := os.ReadFile(HostKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading host key: %w", err)
+	}
+	key, err := pki.ParsePEMPrivateKey(keyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing host key: %w", err)
+	}
+
+	return &hostAuthenticator{
+		certificate: string(certificate),
+		key:         key,
+	}, nil
+}
+
+func (a *hostAuthenticator) CreateToken(body []byte) (string, error) {
+	t := token{
+		Certificate: a.certificate,
+		Timestamp:   time.Now().Unix(),
+	}
+
+	signature, err := a.key.Key.Sign(rand.Reader, signedDigest(t.Timestamp, body), crypto.SHA256)
+	if err != nil {
+		return "", fmt.Errorf("error signing token: %w", err)
+	}
+	t.Signature = signature
+
+	data, err := json.Marshal(&t)
+	if err != nil {
+		return "", fmt.Errorf("error encoding token: %w", err)
+	}
+	return MetalAuthenticationTokenPrefix + base64.StdEncoding.EncodeToString(data), nil
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metal
+
+import (
+	"strings"
+)
+
+// ProviderIDPrefix is the prefix of the provider IDs of the nodes of metal machines.
+const ProviderIDPrefix = "metal://"
+
+// ProviderID returns the provider ID the kubelet of a metal machine registers its node with.
+// Metal machines have no cloud provider, so the provider ID records the instance group of the node instead.
+func ProviderID(instanceGroupName string, nodeName string) string {
+	return ProviderIDPrefix + instanceGroupName + "/" + nodeName
+}
+
+// InstanceGroupFromProviderID returns the instance group recorded in the provider ID of a node,
+// or false if the node is not a metal machine.
+func InstanceGroupFromProviderID(providerID string) (string, bool) {
+	if !strings.HasPrefix(providerID, ProviderIDPrefix) {
+		return "", false
+	}
+	instanceGroupName, _, found := strings.Cut(strings.TrimPrefix(providerID, ProviderIDPrefix), "/")
+	if !found || instanceGroupName == "" {
+		return "", false
+	}
+	return instanceGroupName, true
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metal
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// MetalAuthenticationTokenPrefix is the prefix used for authentication using the host certificate of a metal machine
+const MetalAuthenticationTokenPrefix = "x-kops-metal "
+
+// MetalOrganization is the organization of host certificates; it carries no permissions in Kubernetes.
+const MetalOrganization = "kops:metal"
+
+const (
+	// HostCertificatePath is where "kops toolbox enroll" installs the host certificate of a metal machine.
+	HostCertificatePath = "/etc/kubernetes/kops-metal/host.crt"
+	// HostKeyPath is where "kops toolbox enroll" installs the private key of the host certificate.
+	HostKeyPath = "/etc/kubernetes/kops-metal/host.key"
+)
+
+// token is the authentication token of a metal machine, signed with the private key of its host certificate.
+type token struct {
+	// Certificate is the PEM-encoded host certificate.
+	Certificate string `json:"certificate"`
+	// Timestamp is the time the token was created, in seconds since the epoch.
+	Timestamp int64 `json:"timestamp"`
+	// Signature is the signature of the digest of the timestamp and the request body.
+	Signature []byte `json:"signature"`
+}
+
+// signedDigest returns the digest signed by a token.
+// Including the hash of the request body binds the token to the request.
+func signedDigest(timestamp int64, body []byte) []byte {
+	requestHash := sha256.Sum256(body)
+	digest := sha256.Sum256([]byte(strconv.FormatInt(timestamp, 10) + "/" + hex.EncodeToString(requestHash[:])))
+	return digest[:]
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metal
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"k8s.io/kops/pkg/bootstrap"
+	"k8s.io/kops/pkg/pki"
+)
+
+// VerifierOptions configures the verifier of metal machines.
+type VerifierOptions struct {
+	// CACertificatePath is the path to the CA certificates that sign host certificates.
+	CACertificatePath string `json:"caCertificatePath"`
+	// MaxTimeSkew is the maximum time skew to allow (in seconds).
+	MaxTimeSkew int64 `json:"maxTimeSkew"`
+}
+
+type hostVerifier struct {
+	opt VerifierOptions
+
+	// now can be replaced in tests.
+	now func() time.Time
+}
+
+// NewVerifier constructs a verifier for the host certificates of metal machines.
+func NewVerifier(opt *VerifierOptions) (bootstrap.Verifier, error) {
+	if opt.CACertificatePath == "" {
+		return nil, fmt.Errorf("caCertificatePath is required")
+	}
+	return &hostVerifier{
+		opt: *opt,
+		now: time.Now,
+	}, nil
+}
+
+var _ bootstrap.Verifier = &hostVerifier{}
+
+func (v *hostVerifier) VerifyToken(ctx context.Context, authToken string, body []byte, useInstanceIDForNodeName bool) (*bootstrap.VerifyResult, error) {
+	if !strings.HasPrefix(authToken, MetalAuthenticationTokenPrefix) {
+		return nil, fmt.Errorf("incorrect authorization type")
+	}
+	authToken = strings.TrimPrefix(authToken, MetalAuthenticationTokenPrefix)
+
+	data, err := base64.StdEncoding.DecodeString(authToken)
+	if err != nil {
+		return nil, fmt.Errorf("decoding authorization token: %w", err)
+	}
+	t := &token{}
+	if err := json.Unmarshal(data, t); err != nil {
+		return nil, fmt.Errorf("unmarshalling authorization token: %w", err)
+	}
+
+	// Guard against replay attacks; the signature covers the timestamp and the hash of the body
+	now := v.now()
+	timestamp := time.Unix(t.Timestamp, 0)
+	if skew := now.Sub(timestamp); skew > time.Duration(v.opt.MaxTimeSkew)*time.Second || skew < -time.Duration(v.opt.MaxTimeSkew)*time.Second {
+		return nil, fmt.Errorf("token timestamp %v is too far from the current time %v", timestamp, now)
+	}
+
+	certificate, err := pki.ParsePEMCertificate([]byte(t.Certificate))
+	if err != nil {
+		return nil, fmt.Errorf("parsing host certificate: %w", err)
+	}
+	if err := v.verifyCertificate(certificate.Certificate, now); err != nil {
+		return nil, err
+	}
+
+	if err := verifySignature(certificate.Certificate.PublicKey, signedDigest(t.Timestamp, body), t.Signature); err != nil {
+		return nil, err
+	}
+
+	subject := certificate.Certificate.Subject
+	if subject.CommonName == "" {
+		return nil, fmt.Errorf("host certificate has no common name")
+	}
+	if len(subject.OrganizationalUnit) != 1 {
+		return nil, fmt.Errorf("host certificate has %d organizational units, expected 1", len(subject.OrganizationalUnit))
+	}
+
+	result := &bootstrap.VerifyResult{
+		NodeName:          subject.CommonName,
+		InstanceGroupName: subject.OrganizationalUnit[0],
+	}
+	for _, ip := range certificate.Certificate.IPAddresses {
+		result.CertificateNames = append(result.CertificateNames, ip.String())
+	}
+	result.CertificateNames = append(result.CertificateNames, certificate.Certificate.DNSNames...)
+
+	return result, nil
+}
+
+// verifyCertificate checks that the host certificate was issued for a metal machine by one of the CAs.
+func (v *hostVerifier) verifyCertificate(certificate *x509.Certificate, now time.Time) error {
+	caCertificates, err := os.ReadFile(v.opt.CACertificatePath)
+	if err != nil {
+		return fmt.Errorf("reading CA certificates: %w", err)
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(caCertificates) {
+		return fmt.Errorf("no CA certificates found in %q", v.opt.CACertificatePath)
+	}
+
+	if _, err := certificate.Verify(x509.VerifyOptions{
+		Roots:       roots,
+		CurrentTime: now,
+		KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}); err != nil {
+		return fmt.Errorf("verifying host certificate: %w", err)
+	}
+
+	for _, organization := range certificate.Subject.Organization {
+		if organization == MetalOrganization {
+			return nil
+		}
+	}
+	return fmt.Errorf("certificate %q is not a host certificate", certificate.Subject.CommonName)
+}
+
+func verifySignature(publicKey crypto.PublicKey, digest []byte, signature []byte) error {
+	switch publicKey := publicKey.(type) {
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, digest, signature); err != nil {
+			return fmt.Errorf("verifying token signature: %w", err)
+		}
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(publicKey, digest, signature) {
+			return fmt.Errorf("verifying token signature: invalid signature")
+		}
+	default:
+		return fmt.Errorf("unsupported host certificate key type %T", publicKey)
+	}
+	return nil
+}
+
+// IssueHostCertificateRequest returns the request to issue the host certificate of a metal machine.
+// The certificate authenticates the machine as the node nodeName of the instance group instanceGroupName.
+func IssueHostCertificateRequest(nodeName string, instanceGroupName string, addresses []string) *pki.IssueCertRequest {
+	req := &pki.IssueCertRequest{
+		Signer: "kubernetes-ca",
+		Type:   "client",
+	}
+	req.Subject.CommonName = nodeName
+	req.Subject.Organization = []string{MetalOrganization}
+	req.Subject.OrganizationalUnit = []string{instanceGroupName}
+	req.AlternateNames = addresses
+	return req
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metal
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"k8s.io/kops/pkg/pki"
+)
+
+type caKeystore struct {
+	certificate *pki.Certificate
+	key         *pki.PrivateKey
+}
+
+func (k *caKeystore) FindPrimaryKeypair(name string) (*pki.Certificate, *pki.PrivateKey, error) {
+	return k.certificate, k.key, nil
+}
+
+func TestVerifyToken(t *testing.T) {
+	caRequest := &pki.IssueCertRequest{Type: "ca"}
+	caRequest.Subject.CommonName = "kubernetes-ca"
+	caCertificate, caKey, _, err := pki.IssueCert(caRequest, nil)
+	if err != nil {
+		t.Fatalf("error issuing CA certificate: %v", err)
+	}
+	caPath := filepath.Join(t.TempDir(), "ca.crt")
+	if err := caCertificate.WriteToFile(caPath, 0o644); err != nil {
+		t.Fatalf("error writing CA certificate: %v", err)
+	}
+
+	certificate, key, _, err := pki.IssueCert(IssueHostCertificateRequest("metal-1", "metal-nodes", []string{"192.0.2.10"}), &caKeystore{certificate: caCertificate, key: caKey})
+	if err != nil {
+		t.Fatalf("error issuing host certificate: %v", err)
+	}
+	certificateString, err := certificate.AsString()
+	if err != nil {
+		t.Fatalf("error encoding host certificate: %v", err)
+	}
+	authenticator := &hostAuthenticator{certificate: certificateString, key: key}
+
+	verifier, err := NewVerifier(&VerifierOptions{CACertificatePath: caPath, MaxTimeSkew: 300})
+	if err != nil {
+		t.Fatalf("error creating verifier: %v", err)
+	}
+
+	body := []byte("request")
+	token, err := authenticator.CreateToken(body)
+	if err != nil {
+		t.Fatalf("error creating token: %v", err)
+	}
+
+	result, err := verifier.VerifyToken(context.Background(), token, body, false)
+	if err != nil {
+		t.Fatalf("unexpected error verifying token: %v", err)
+	}
+	if result.NodeName != "metal-1" {
+		t.Errorf("unexpected node name %q", result.NodeName)
+	}
+	if result.InstanceGroupName != "metal-nodes" {
+		t.Errorf("unexpected instance group name %q", result.InstanceGroupName)
+	}
+	if len(result.CertificateNames) != 1 || result.CertificateNames[0] != "192.0.2.10" {
+		t.Errorf("unexpected certificate names %v", result.CertificateNames)
+	}
+
+	if _, err := verifier.VerifyToken(context.Background(), token, []byte("other request"), false); err == nil {
+		t.Errorf("expected error verifying token for a different body")
+	}
+
+	verifier.(*hostVerifier).now = func() time.Time { return time.Now().Add(time.Hour) }
+	if _, err := verifier.VerifyToken(context.Background(), token, body, false); err == nil {
+		t.Errorf("expected error verifying expired token")
+	}
+}
+
+func TestInstanceGroupFromProviderID(t *testing.T) {
+	grid := []struct {
+		providerID        string
+		instanceGroupName string
+		ok                bool
+	}{
+		{providerID: ProviderID("metal-nodes", "metal-1"), instanceGroupName: "metal-nodes", ok: true},
+		{providerID: "aws:///us-test-1a/i-0123456789abcdef0"},
+		{providerID: "metal:///metal-1"},
+		{providerID: ""},
+	}
+	for _, g := range grid {
+		instanceGroupName, ok := InstanceGroupFromProviderID(g.providerID)
+		if instanceGroupName != g.instanceGroupName || ok != g.ok {
+			t.Errorf("InstanceGroupFromProviderID(%q) = %q, %v; expected %q, %v", g.providerID, instanceGroupName, ok, g.instanceGroupName, g.ok)
+		}
+	}
+}
@@ -40,24 +40,44 @@ import (
 type RESTClientset struct {
 	BaseURL    *url.URL
 	KopsClient kopsinternalversion.KopsInterface
+
+	// Namespace is the namespace holding the objects of the cluster.
+	// If empty, each cluster is stored in a namespace named after the cluster.
+	Namespace string
 }
 
 // GetCluster implements the GetCluster method of Clientset for a kubernetes-API state store
 func (c *RESTClientset) GetCluster(ctx context.Context, name string) (*kops.Cluster, error) {
-	namespace := restNamespaceForClusterName(name)
+	namespace := c.namespaceForClusterName(name)
 	return c.KopsClient.Clusters(namespace).Get(ctx, name, metav1.GetOptions{})
 }
 
 // AddonsFor fetches the AddonsClient for the cluster
 func (c *RESTClientset) AddonsFor(cluster *kops.Cluster) simple.AddonsClient {
-	// We should manage these directly in the cluster
-	klog.Fatalf("AddonsFor not implemented for RESTClientset")
-	return nil
+	// We should manage these directly in the cluster; for now they are stored under the configBase
+	configBase, err := c.ConfigBaseFor(cluster)
+	if err != nil {
+		klog.Fatalf("error building ConfigBase for cluster %q: %v", cluster.Name, err)
+	}
+	return vfsclientset.NewAddonsClient(configBase, cluster)
 }
 
 // CreateCluster implements the CreateCluster method of Clientset for a kubernetes-API state store
 func (c *RESTClientset) CreateCluster(ctx context.Context, cluster *kops.Cluster) (*kops.Cluster, error) {
-	namespace := restNamespaceForClusterName(cluster.Name)
+	namespace := c.namespaceForClusterName(cluster.Name)
+	if c.Namespace != "" {
+		// The instance groups and keysets of the cluster are named without the cluster name,
+		// so a namespace can only hold a single cluster.
+		clusters, err := c.KopsClient.Clusters(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("error listing clusters in namespace %q: %v", namespace, err)
+		}
+		for _, existing := range clusters.Items {
+			if existing.Name != cluster.Name {
+				return nil, fmt.Errorf("namespace %q already holds cluster %q; each cluster needs its own namespace", namespace, existing.Name)
+			}
+		}
+	}
 	return c.KopsClient.Clusters(namespace).Create(ctx, cluster, metav1.CreateOptions{})
 }
 
@@ -72,7 +92,7 @@ func (c *RESTClientset) UpdateCluster(ctx context.Context, cluster *kops.Cluster
 		return nil, err
 	}
 
-	namespace := restNamespaceForClusterName(cluster.Name)
+	namespace := c.namespaceForClusterName(cluster.Name)
 	return c.KopsClient.Clusters(namespace).Update(ctx, cluster, metav1.UpdateOptions{})
 }
 
@@ -81,34 +101,33 @@ func (c *RESTClientset) ConfigBaseFor(cluster *kops.Cluster) (vfs.Path, error) {
 	if cluster.Spec.ConfigBase != "" {
 		return vfs.Context.BuildVfsPath(cluster.Spec.ConfigBase)
 	}
-	// URL for clusters looks like https://<server>/apis/kops/v1alpha2/namespaces/<cluster>/clusters/<cluster>
-	// We probably want to add a subresource for full resources
-	return vfs.Context.BuildVfsPath(c.BaseURL.String())
+	// The nodes read their configuration from the configBase, which the kubernetes API can't serve
+	return nil, fmt.Errorf("a configBase (--config-base) is required for clusters stored in the kubernetes API state store %q", c.BaseURL)
 }
 
 // ListClusters implements the ListClusters method of Clientset for a kubernetes-API state store
 func (c *RESTClientset) ListClusters(ctx context.Context, options metav1.ListOptions) (*kops.ClusterList, error) {
-	return c.KopsClient.Clusters(metav1.NamespaceAll).List(ctx, options)
+	return c.KopsClient.Clusters(c.Namespace).List(ctx, options)
 }
 
 // InstanceGroupsFor implements the InstanceGroupsFor method of Clientset for a kubernetes-API state store
 func (c *RESTClientset) InstanceGroupsFor(cluster *kops.Cluster) kopsinternalversion.InstanceGroupInterface {
-	namespace := restNamespaceForClusterName(cluster.Name)
+	namespace := c.namespaceForClusterName(cluster.Name)
 	return c.KopsClient.InstanceGroups(namespace)
 }
 
 func (c *RESTClientset) SecretStore(cluster *kops.Cluster) (fi.SecretStore, error) {
-	namespace := restNamespaceForClusterName(cluster.Name)
+	namespace := c.namespaceForClusterName(cluster.Name)
 	return secrets.NewClientsetSecretStore(cluster, c.KopsClient, namespace), nil
 }
 
 func (c *RESTClientset) KeyStore(cluster *kops.Cluster) (fi.CAStore, error) {
-	namespace := restNamespaceForClusterName(cluster.Name)
+	namespace := c.namespaceForClusterName(cluster.Name)
 	return fi.NewClientsetCAStore(cluster, c.KopsClient, namespace), nil
 }
 
 func (c *RESTClientset) SSHCredentialStore(cluster *kops.Cluster) (fi.SSHCredentialStore, error) {
-	namespace := restNamespaceForClusterName(cluster.Name)
+	namespace := c.namespaceForClusterName(cluster.Name)
 	return fi.NewClientsetSSHCredentialStore(cluster, c.KopsClient, namespace), nil
 }
 
@@ -124,7 +143,7 @@ func (c *RESTClientset) DeleteCluster(ctx context.Context, cluster *kops.Cluster
 	}
 
 	name := cluster.Name
-	namespace := restNamespaceForClusterName(name)
+	namespace := c.namespaceForClusterName(name)
 
 	{
 		keysets, err := c.KopsClient.Keysets(namespace).List(ctx, metav1.ListOptions{})
@@ -179,6 +198,14 @@ func (c *RESTClientset) DeleteCluster(ctx context.Context, cluster *kops.Cluster
 	return nil
 }
 
+// namespaceForClusterName returns the namespace holding the objects of the named cluster
+func (c *RESTClientset) namespaceForClusterName(clusterName string) string {
+	if c.Namespace != "" {
+		return c.Namespace
+	}
+	return restNamespaceForClusterName(clusterName)
+}
+
 func restNamespaceForClusterName(clusterName string) string {
 	// We are not allowed dots, so we map them to dashes
 	// This can conflict, but this will simply be a limitation that we pass on to the user
@@ -0,0 +1,96 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/client/clientset_generated/clientset/fake"
+)
+
+func newTestCluster(name string) *kops.Cluster {
+	return &kops.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+	}
+}
+
+func TestNamespaceForClusterName(t *testing.T) {
+	c := &RESTClientset{}
+	if namespace := c.namespaceForClusterName("a.example.com"); namespace != "a-example-com" {
+		t.Errorf("expected the namespace named after the cluster, got %q", namespace)
+	}
+
+	c.Namespace = "kops"
+	if namespace := c.namespaceForClusterName("a.example.com"); namespace != "kops" {
+		t.Errorf("expected the configured namespace, got %q", namespace)
+	}
+}
+
+func TestCreateClusterInNamespace(t *testing.T) {
+	ctx := context.Background()
+	c := &RESTClientset{
+		KopsClient: fake.NewSimpleClientset().Kops(),
+		Namespace:  "kops",
+	}
+
+	if _, err := c.CreateCluster(ctx, newTestCluster("a.example.com")); err != nil {
+		t.Fatalf("unexpected error creating cluster: %v", err)
+	}
+	if _, err := c.GetCluster(ctx, "a.example.com"); err != nil {
+		t.Errorf("unexpected error getting cluster: %v", err)
+	}
+	if _, err := c.KopsClient.Clusters("kops").Get(ctx, "a.example.com", metav1.GetOptions{}); err != nil {
+		t.Errorf("expected the cluster in the configured namespace: %v", err)
+	}
+
+	_, err := c.CreateCluster(ctx, newTestCluster("b.example.com"))
+	if err == nil || !strings.Contains(err.Error(), "already holds cluster \"a.example.com\"") {
+		t.Errorf("expected an error creating a second cluster in the namespace, got %v", err)
+	}
+
+	clusters, err := c.ListClusters(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error listing clusters: %v", err)
+	}
+	if len(clusters.Items) != 1 {
+		t.Errorf("expected a single cluster, got %d", len(clusters.Items))
+	}
+}
+
+func TestConfigBaseFor(t *testing.T) {
+	c := &RESTClientset{}
+
+	cluster := newTestCluster("a.example.com")
+	if _, err := c.ConfigBaseFor(cluster); err == nil {
+		t.Errorf("expected an error without a configBase")
+	}
+
+	cluster.Spec.ConfigBase = t.TempDir()
+	configBase, err := c.ConfigBaseFor(cluster)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if configBase.Path() != cluster.Spec.ConfigBase {
+		t.Errorf("expected configBase %q, got %q", cluster.Spec.ConfigBase, configBase.Path())
+	}
+}
@@ -70,4 +70,7 @@ type AddonsClient interface {
 
 	// List returns all the addon objects
 	List() (kubemanifest.ObjectList, error)
+
+	// ListUserAddons returns the manifests stored under the addons/user/ prefix, keyed by file name without extension
+	ListUserAddons() (map[string][]byte, error)
 }
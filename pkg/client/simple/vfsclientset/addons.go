@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"strings"
 
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/acls"
@@ -32,6 +33,9 @@ import (
 type vfsAddonsClient struct {
 	basePath vfs.Path
 
+	// userAddonsPath is where users store their own addon manifests
+	userAddonsPath vfs.Path
+
 	clusterName string
 	cluster     *kops.Cluster
 }
@@ -43,13 +47,22 @@ func newAddonsVFS(c *VFSClientset, cluster *kops.Cluster) *vfsAddonsClient {
 		klog.Fatalf("cluster / cluster.Name is required")
 	}
 
-	clusterName := cluster.Name
+	return newAddonsClient(c.basePath.Join(cluster.Name), cluster)
+}
 
+// NewAddonsClient returns an AddonsClient storing the addons of the cluster under its configBase.
+// It allows clientsets that do not store clusters in a VFS to keep their addons alongside the rest of the cluster configuration.
+func NewAddonsClient(configBase vfs.Path, cluster *kops.Cluster) simple.AddonsClient {
+	return newAddonsClient(configBase, cluster)
+}
+
+func newAddonsClient(configBase vfs.Path, cluster *kops.Cluster) *vfsAddonsClient {
 	r := &vfsAddonsClient{
 		cluster:     cluster,
-		clusterName: clusterName,
+		clusterName: cluster.Name,
 	}
-	r.basePath = c.basePath.Join(clusterName, "clusteraddons")
+	r.basePath = configBase.Join("clusteraddons")
+	r.userAddonsPath = configBase.Join("addons", "user")
 
 	return r
 }
@@ -94,3 +107,36 @@ func (c *vfsAddonsClient) List() (kubemanifest.ObjectList, error) {
 
 	return objects, nil
 }
+
+func (c *vfsAddonsClient) ListUserAddons() (map[string][]byte, error) {
+	files, err := c.userAddonsPath.ReadDir()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error listing user addons in %s: %v", c.userAddonsPath, err)
+	}
+
+	manifests := make(map[string][]byte)
+	for _, f := range files {
+		name := f.Base()
+		ext := ""
+		for _, e := range []string{".yaml", ".yml"} {
+			if strings.HasSuffix(name, e) {
+				ext = e
+			}
+		}
+		if ext == "" {
+			klog.Warningf("ignoring user addon file %s without a .yaml extension", f)
+			continue
+		}
+
+		b, err := f.ReadFile()
+		if err != nil {
+			return nil, fmt.Errorf("error reading user addon file %s: %v", f, err)
+		}
+		manifests[strings.TrimSuffix(name, ext)] = b
+	}
+
+	return manifests, nil
+}
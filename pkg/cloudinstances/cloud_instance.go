@@ -32,6 +32,12 @@ type State string
 // WarmPool means the instance is in the warm pool
 const WarmPool State = "WarmPool"
 
+// LifecycleSpot means the instance is a spot (or preemptible) instance
+const LifecycleSpot = "spot"
+
+// LifecycleOnDemand means the instance is an on-demand instance
+const LifecycleOnDemand = "on-demand"
+
 // CloudInstance describes an instance in a CloudInstanceGroup group.
 type CloudInstance struct {
 	// ID is a unique identifier for the instance, meaningful to the cloud
@@ -50,4 +56,10 @@ type CloudInstance struct {
 	PrivateIP string
 	// State is in which state the instance is in
 	State State
+	// LifecycleState is the state of the instance reported by its cloud group, such as InService.
+	LifecycleState string
+	// HealthStatus is the health of the instance reported by the cloud.
+	HealthStatus string
+	// Lifecycle is whether the instance is a spot or an on-demand instance, if known.
+	Lifecycle string
 }
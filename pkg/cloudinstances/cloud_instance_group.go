@@ -36,6 +36,9 @@ type CloudInstanceGroup struct {
 	TargetSize    int
 	MaxSize       int
 
+	// SpecHash is the hash of the spec the group was last applied from, if it was recorded.
+	SpecHash string
+
 	// Raw allows for the implementer to attach an object, for tracking additional state
 	Raw interface{}
 }
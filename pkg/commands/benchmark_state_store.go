@@ -0,0 +1,164 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/client/simple"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+// BenchmarkStateStoreOptions configures BenchmarkStateStore
+type BenchmarkStateStoreOptions struct {
+	// Iterations is the number of times each operation is performed
+	Iterations int
+	// ObjectSize is the size in bytes of the objects written and read back
+	ObjectSize int
+}
+
+// BenchmarkResult holds the timings of one state store operation
+type BenchmarkResult struct {
+	Operation string
+	Count     int
+	Errors    int
+	Min       time.Duration
+	Max       time.Duration
+	Total     time.Duration
+	// Bytes is the number of bytes transferred by the successful operations, if relevant
+	Bytes int64
+}
+
+// Average returns the mean duration of the successful operations
+func (r *BenchmarkResult) Average() time.Duration {
+	if r.Count == 0 {
+		return 0
+	}
+	return r.Total / time.Duration(r.Count)
+}
+
+// Throughput returns the bytes transferred per second, or 0 if the operation doesn't transfer data
+func (r *BenchmarkResult) Throughput() float64 {
+	if r.Bytes == 0 || r.Total == 0 {
+		return 0
+	}
+	return float64(r.Bytes) / r.Total.Seconds()
+}
+
+func (r *BenchmarkResult) record(start time.Time, bytes int, err error) {
+	if err != nil {
+		klog.Warningf("state store %s failed: %v", r.Operation, err)
+		r.Errors++
+		return
+	}
+	duration := time.Since(start)
+	if r.Count == 0 || duration < r.Min {
+		r.Min = duration
+	}
+	if duration > r.Max {
+		r.Max = duration
+	}
+	r.Count++
+	r.Total += duration
+	r.Bytes += int64(bytes)
+}
+
+// BenchmarkStateStore measures the latency of the state store operations kOps performs for a cluster:
+// listing clusters, reading the cluster spec, writing and reading back objects, and reading secrets and keys.
+// The objects written are removed afterwards.
+func BenchmarkStateStore(ctx context.Context, clientset simple.Clientset, cluster *kops.Cluster, options *BenchmarkStateStoreOptions) ([]*BenchmarkResult, error) {
+	if options.Iterations <= 0 {
+		return nil, fmt.Errorf("iterations must be greater than 0")
+	}
+	if options.ObjectSize < 0 {
+		return nil, fmt.Errorf("object size must not be negative")
+	}
+
+	configBase, err := clientset.ConfigBaseFor(cluster)
+	if err != nil {
+		return nil, err
+	}
+	keyStore, err := clientset.KeyStore(cluster)
+	if err != nil {
+		return nil, err
+	}
+	secretStore, err := clientset.SecretStore(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, options.ObjectSize)
+	if _, err := rand.Read(data); err != nil {
+		return nil, fmt.Errorf("error generating data: %w", err)
+	}
+	benchmarkDir := configBase.Join("benchmark")
+
+	listClusters := &BenchmarkResult{Operation: "list clusters"}
+	getCluster := &BenchmarkResult{Operation: "read cluster"}
+	write := &BenchmarkResult{Operation: "write object"}
+	read := &BenchmarkResult{Operation: "read object"}
+	listObjects := &BenchmarkResult{Operation: "list objects"}
+	remove := &BenchmarkResult{Operation: "remove object"}
+	listSecrets := &BenchmarkResult{Operation: "list secrets"}
+	readKeyset := &BenchmarkResult{Operation: "read keyset"}
+
+	for i := 0; i < options.Iterations; i++ {
+		start := time.Now()
+		_, err := clientset.ListClusters(ctx, metav1.ListOptions{})
+		listClusters.record(start, 0, err)
+
+		start = time.Now()
+		_, err = clientset.GetCluster(ctx, cluster.ObjectMeta.Name)
+		getCluster.record(start, 0, err)
+
+		p := benchmarkDir.Join(fmt.Sprintf("object-%d", i))
+		start = time.Now()
+		err = p.WriteFile(bytes.NewReader(data), nil)
+		write.record(start, len(data), err)
+
+		start = time.Now()
+		b, err := p.ReadFile()
+		read.record(start, len(b), err)
+
+		start = time.Now()
+		_, err = benchmarkDir.ReadDir()
+		listObjects.record(start, 0, err)
+
+		start = time.Now()
+		_, err = secretStore.ListSecrets()
+		listSecrets.record(start, 0, err)
+
+		start = time.Now()
+		_, err = keyStore.FindKeyset(fi.CertificateIDCA)
+		readKeyset.record(start, 0, err)
+	}
+
+	for i := 0; i < options.Iterations; i++ {
+		start := time.Now()
+		err := benchmarkDir.Join(fmt.Sprintf("object-%d", i)).Remove()
+		remove.record(start, 0, err)
+	}
+
+	return []*BenchmarkResult{listClusters, getCluster, write, read, listObjects, remove, listSecrets, readKeyset}, nil
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"k8s.io/kops/pkg/client/simple/vfsclientset"
+	"k8s.io/kops/pkg/testutils"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func TestBenchmarkStateStore(t *testing.T) {
+	ctx := context.Background()
+
+	vfs.Context.ResetMemfsContext(true)
+	basePath, err := vfs.Context.BuildVfsPath("memfs://benchmark")
+	if err != nil {
+		t.Fatalf("error building path: %v", err)
+	}
+	clientset := vfsclientset.NewVFSClientset(basePath)
+
+	cluster := testutils.BuildMinimalCluster("benchmark.example.com")
+	cluster.Spec.ConfigBase = "memfs://benchmark/benchmark.example.com"
+	if _, err := clientset.CreateCluster(ctx, cluster); err != nil {
+		t.Fatalf("error creating cluster: %v", err)
+	}
+
+	vfs.ResetOperationStats()
+	results, err := BenchmarkStateStore(ctx, clientset, cluster, &BenchmarkStateStoreOptions{Iterations: 3, ObjectSize: 1024})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	byOperation := make(map[string]*BenchmarkResult)
+	for _, result := range results {
+		byOperation[result.Operation] = result
+	}
+	for _, operation := range []string{"list clusters", "read cluster", "write object", "read object", "list objects", "remove object"} {
+		result := byOperation[operation]
+		if result == nil {
+			t.Errorf("missing result for %q", operation)
+			continue
+		}
+		if result.Count != 3 || result.Errors != 0 {
+			t.Errorf("unexpected result for %q: %+v", operation, result)
+		}
+	}
+	if bytes := byOperation["read object"].Bytes; bytes != 3*1024 {
+		t.Errorf("expected 3072 bytes read, got %d", bytes)
+	}
+
+	if _, err := basePath.Join("benchmark.example.com", "benchmark", "object-0").ReadFile(); !os.IsNotExist(err) {
+		t.Errorf("expected benchmark objects to be removed, got %v", err)
+	}
+
+	var memfsWrites int
+	for _, stats := range vfs.GetOperationStats() {
+		if stats.Backend == "memfs" && stats.Operation == "WriteFile" {
+			memfsWrites = stats.Count
+		}
+	}
+	if memfsWrites < 3 {
+		t.Errorf("expected the VFS layer to record at least 3 writes, got %d", memfsWrites)
+	}
+
+	if _, err := BenchmarkStateStore(ctx, clientset, cluster, &BenchmarkStateStoreOptions{}); err == nil {
+		t.Errorf("expected error for zero iterations")
+	}
+}
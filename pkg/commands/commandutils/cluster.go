@@ -18,6 +18,7 @@ package commandutils
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -33,14 +34,25 @@ func CompleteClusterName(f Factory, suppressIfArgs bool, suppressArgs bool) func
 
 		ConfigureKlogForCompletion()
 
-		client, err := f.Clientset()
-		if err != nil {
-			return CompletionError("getting clientset", err)
-		}
+		names, err := CachedCompletions(f, []string{"clusters"}, func() ([]string, error) {
+			client, err := f.Clientset()
+			if err != nil {
+				return nil, fmt.Errorf("getting clientset: %w", err)
+			}
 
-		list, err := client.ListClusters(context.TODO(), metav1.ListOptions{})
+			list, err := client.ListClusters(context.TODO(), metav1.ListOptions{})
+			if err != nil {
+				return nil, fmt.Errorf("listing clusters: %w", err)
+			}
+
+			var names []string
+			for _, cluster := range list.Items {
+				names = append(names, cluster.Name)
+			}
+			return names, nil
+		})
 		if err != nil {
-			return CompletionError("listing clusters", err)
+			return CompletionError("completing cluster names", err)
 		}
 
 		var clusterNames []string
@@ -48,9 +60,9 @@ func CompleteClusterName(f Factory, suppressIfArgs bool, suppressArgs bool) func
 		if suppressArgs {
 			alreadySelected = alreadySelected.Insert(args...)
 		}
-		for _, cluster := range list.Items {
-			if !alreadySelected.Has(cluster.Name) {
-				clusterNames = append(clusterNames, cluster.Name)
+		for _, name := range names {
+			if !alreadySelected.Has(name) {
+				clusterNames = append(clusterNames, name)
 			}
 		}
 
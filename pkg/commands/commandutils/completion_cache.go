@@ -0,0 +1,113 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commandutils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// CompletionCacheTTLEnv is the environment variable that sets how long completions that are listed from
+// the state store or the cloud are cached, for example "30s" or "10m".  A TTL of "0" disables the cache.
+const CompletionCacheTTLEnv = "KOPS_COMPLETION_CACHE_TTL"
+
+// DefaultCompletionCacheTTL is how long completions are cached, unless set by KOPS_COMPLETION_CACHE_TTL.
+const DefaultCompletionCacheTTL = 5 * time.Minute
+
+// CachedCompletions returns the completions for key in the state store of the factory, calling list when
+// they have not been cached or have expired.  Errors reading or writing the cache are not fatal; the
+// completions are listed instead.
+func CachedCompletions(f Factory, key []string, list func() ([]string, error)) ([]string, error) {
+	cache := newCompletionCache()
+	if cache == nil {
+		return list()
+	}
+	return cache.get(append([]string{f.KopsStateStore()}, key...), list)
+}
+
+type completionCache struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+}
+
+// newCompletionCache returns the completion cache in the user's cache directory, or nil if caching is disabled.
+func newCompletionCache() *completionCache {
+	ttl := DefaultCompletionCacheTTL
+	if s := os.Getenv(CompletionCacheTTLEnv); s != "" {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			klog.Warningf("ignoring invalid %s %q: %v", CompletionCacheTTLEnv, s, err)
+		} else {
+			ttl = d
+		}
+	}
+	if ttl <= 0 {
+		return nil
+	}
+
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		klog.V(2).Infof("not caching completions: %v", err)
+		return nil
+	}
+
+	return &completionCache{
+		dir: filepath.Join(dir, "kops", "completion"),
+		ttl: ttl,
+		now: time.Now,
+	}
+}
+
+func (c *completionCache) get(key []string, list func() ([]string, error)) ([]string, error) {
+	hash := sha256.Sum256([]byte(strings.Join(key, "\x00")))
+	p := filepath.Join(c.dir, hex.EncodeToString(hash[:])+".json")
+
+	if stat, err := os.Stat(p); err == nil && c.now().Sub(stat.ModTime()) < c.ttl {
+		if b, err := os.ReadFile(p); err == nil {
+			var completions []string
+			if err := json.Unmarshal(b, &completions); err == nil {
+				return completions, nil
+			}
+		}
+	}
+
+	completions, err := list()
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := json.Marshal(completions)
+	if err == nil {
+		err = os.MkdirAll(c.dir, 0o700)
+	}
+	if err == nil {
+		err = os.WriteFile(p, b, 0o600)
+	}
+	if err != nil {
+		klog.V(2).Infof("error caching completions: %v", err)
+	}
+
+	return completions, nil
+}
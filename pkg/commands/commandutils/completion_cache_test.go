@@ -0,0 +1,82 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commandutils
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCompletionCache(t *testing.T) {
+	now := time.Now()
+	cache := &completionCache{
+		dir: t.TempDir(),
+		ttl: time.Minute,
+		now: func() time.Time { return now },
+	}
+
+	calls := 0
+	list := func(completions ...string) func() ([]string, error) {
+		return func() ([]string, error) {
+			calls++
+			return completions, nil
+		}
+	}
+
+	key := []string{"s3://state-store", "instancegroups", "cluster.example.com"}
+	got, err := cache.get(key, list("nodes", "master-us-test-1a"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, []string{"nodes", "master-us-test-1a"}) || calls != 1 {
+		t.Fatalf("unexpected completions %v after %d calls", got, calls)
+	}
+
+	got, err = cache.get(key, list("changed"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, []string{"nodes", "master-us-test-1a"}) || calls != 1 {
+		t.Errorf("expected cached completions, got %v after %d calls", got, calls)
+	}
+
+	got, err = cache.get([]string{"s3://other-state-store", "instancegroups", "cluster.example.com"}, list("other"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, []string{"other"}) || calls != 2 {
+		t.Errorf("expected completions for another state store to be listed, got %v after %d calls", got, calls)
+	}
+
+	now = now.Add(2 * time.Minute)
+	got, err = cache.get(key, list("changed"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, []string{"changed"}) || calls != 3 {
+		t.Errorf("expected expired completions to be listed again, got %v after %d calls", got, calls)
+	}
+
+	_, err = cache.get([]string{"failing"}, func() ([]string, error) {
+		return nil, errors.New("listing failed")
+	})
+	if err == nil {
+		t.Errorf("expected error from list")
+	}
+}
@@ -20,4 +20,5 @@ import "k8s.io/kops/pkg/client/simple"
 
 type Factory interface {
 	Clientset() (simple.Clientset, error)
+	KopsStateStore() string
 }
@@ -0,0 +1,154 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+const (
+	// etcdBackupMetaFilename is the file etcd-manager writes alongside each backup
+	etcdBackupMetaFilename = "_etcd_backup.meta"
+	// etcdCommandFilename is the file holding a command in the etcd-manager control store
+	etcdCommandFilename = "_command.json"
+	// etcdControlDir is the directory of the backup store holding the etcd-manager control store
+	etcdControlDir = "control"
+	// etcdClusterSpecFilename is the file in the control store holding the spec kOps wrote for the etcd cluster
+	etcdClusterSpecFilename = "etcd-cluster-spec"
+)
+
+// EtcdBackup is a backup of an etcd cluster in the state store.
+type EtcdBackup struct {
+	// EtcdCluster is the name of the etcd cluster, e.g. main or events
+	EtcdCluster string
+	// Name is the name of the backup, as used by etcd-manager
+	Name string
+}
+
+// etcdClusterSpec mirrors the cluster spec of the etcd-manager control store.
+type etcdClusterSpec struct {
+	MemberCount int32  `json:"memberCount,omitempty"`
+	EtcdVersion string `json:"etcdVersion,omitempty"`
+}
+
+// etcdCommand mirrors the commands etcd-manager reads from its control store.
+type etcdCommand struct {
+	Timestamp     int64                     `json:"timestamp,string,omitempty"`
+	RestoreBackup *etcdRestoreBackupCommand `json:"restoreBackup,omitempty"`
+}
+
+type etcdRestoreBackupCommand struct {
+	ClusterSpec *etcdClusterSpec `json:"clusterSpec,omitempty"`
+	Backup      string           `json:"backup,omitempty"`
+}
+
+// EtcdBackupStore returns the backup store etcd-manager uses for an etcd cluster.
+func EtcdBackupStore(etcdCluster *kops.EtcdClusterSpec) (vfs.Path, error) {
+	store := etcdCluster.Backups.ActiveBackupStore()
+	if store == "" {
+		return nil, fmt.Errorf("etcd cluster %q does not have a backup store", etcdCluster.Name)
+	}
+	backupStore, err := vfs.Context.BuildVfsPath(store)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing backup store of etcd cluster %q: %v", etcdCluster.Name, err)
+	}
+	return backupStore, nil
+}
+
+// ListEtcdBackups lists the backups etcd-manager has made in a backup store, oldest first.
+func ListEtcdBackups(etcdClusterName string, backupStore vfs.Path) ([]EtcdBackup, error) {
+	paths, err := backupStore.ReadTree()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error listing backups in %q: %v", backupStore, err)
+	}
+
+	var names []string
+	for _, p := range paths {
+		if p.Base() != etcdBackupMetaFilename {
+			continue
+		}
+		relativePath, err := vfs.RelativePath(backupStore, p)
+		if err != nil {
+			return nil, err
+		}
+		name := path.Dir(relativePath)
+		if name == "." || strings.Contains(name, "/") {
+			continue
+		}
+		names = append(names, name)
+	}
+	// Backup names start with the time they were taken
+	sort.Strings(names)
+
+	var backups []EtcdBackup
+	for _, name := range names {
+		backups = append(backups, EtcdBackup{EtcdCluster: etcdClusterName, Name: name})
+	}
+	return backups, nil
+}
+
+// AddEtcdRestoreCommand asks etcd-manager to restore a backup, as "etcd-manager-ctl restore-backup" does.
+// The restore starts when etcd-manager next reads its control store, on restart of the control plane.
+func AddEtcdRestoreCommand(backupStore vfs.Path, backup string, now time.Time) error {
+	if _, err := backupStore.Join(backup, etcdBackupMetaFilename).ReadFile(); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("backup %q not found in %q", backup, backupStore)
+		}
+		return fmt.Errorf("error reading backup %q: %v", backup, err)
+	}
+
+	specPath := backupStore.Join(etcdControlDir, etcdClusterSpecFilename)
+	data, err := specPath.ReadFile()
+	if err != nil {
+		return fmt.Errorf("error reading etcd cluster spec %q: %v", specPath, err)
+	}
+	spec := &etcdClusterSpec{}
+	if err := json.Unmarshal(data, spec); err != nil {
+		return fmt.Errorf("error parsing etcd cluster spec %q: %v", specPath, err)
+	}
+
+	command := &etcdCommand{
+		Timestamp: now.UnixNano(),
+		RestoreBackup: &etcdRestoreBackupCommand{
+			ClusterSpec: spec,
+			Backup:      backup,
+		},
+	}
+	data, err = json.MarshalIndent(command, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error building restore command: %v", err)
+	}
+
+	p := backupStore.Join(etcdControlDir, now.UTC().Format(time.RFC3339Nano), etcdCommandFilename)
+	if err := p.WriteFile(bytes.NewReader(data), nil); err != nil {
+		return fmt.Errorf("error writing restore command %q: %v", p, err)
+	}
+	return nil
+}
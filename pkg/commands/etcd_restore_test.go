@@ -0,0 +1,101 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func TestListEtcdBackups(t *testing.T) {
+	vfs.Context.ResetMemfsContext(true)
+
+	backupStore, err := vfs.Context.BuildVfsPath("memfs://statestore/example.com/backups/etcd/main")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	writeTestFiles(t, backupStore, map[string]string{
+		"2022-06-01T10:30:00Z-000002/_etcd_backup.meta": "{}",
+		"2022-06-01T10:30:00Z-000002/etcd.backup.gz":    "data",
+		"2022-06-01T10:15:00Z-000001/_etcd_backup.meta": "{}",
+		"2022-06-01T10:15:00Z-000001/etcd.backup.gz":    "data",
+		"2022-06-01T10:45:00Z-000003/etcd.backup.gz":    "incomplete",
+		"control/etcd-cluster-spec":                     `{"memberCount": 3}`,
+	})
+
+	backups, err := ListEtcdBackups("main", backupStore)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []EtcdBackup{
+		{EtcdCluster: "main", Name: "2022-06-01T10:15:00Z-000001"},
+		{EtcdCluster: "main", Name: "2022-06-01T10:30:00Z-000002"},
+	}
+	if !reflect.DeepEqual(backups, expected) {
+		t.Errorf("unexpected backups: expected %v, got %v", expected, backups)
+	}
+}
+
+func TestAddEtcdRestoreCommand(t *testing.T) {
+	vfs.Context.ResetMemfsContext(true)
+
+	backupStore, err := vfs.Context.BuildVfsPath("memfs://statestore/example.com/backups/etcd/main")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	writeTestFiles(t, backupStore, map[string]string{
+		"2022-06-01T10:15:00Z-000001/_etcd_backup.meta": "{}",
+		"control/etcd-cluster-spec":                     `{"memberCount": 3, "etcdVersion": "3.5.4"}`,
+	})
+
+	now := time.Date(2022, 6, 2, 8, 0, 0, 0, time.UTC)
+
+	err = AddEtcdRestoreCommand(backupStore, "2022-06-01T10:30:00Z-000002", now)
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected not found error for missing backup, got %v", err)
+	}
+
+	if err := AddEtcdRestoreCommand(backupStore, "2022-06-01T10:15:00Z-000001", now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data := readTestFile(t, backupStore, "control/2022-06-02T08:00:00Z/_command.json")
+	var command map[string]interface{}
+	if err := json.Unmarshal([]byte(data), &command); err != nil {
+		t.Fatalf("error parsing command: %v", err)
+	}
+	expected := map[string]interface{}{
+		"timestamp": "1654156800000000000",
+		"restoreBackup": map[string]interface{}{
+			"clusterSpec": map[string]interface{}{
+				"memberCount": float64(3),
+				"etcdVersion": "3.5.4",
+			},
+			"backup": "2022-06-01T10:15:00Z-000001",
+		},
+	}
+	if !reflect.DeepEqual(command, expected) {
+		t.Errorf("unexpected command: expected %v, got %v", expected, command)
+	}
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"net"
+
+	api "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi/cloudup"
+	"k8s.io/kops/upup/pkg/fi/utils"
+)
+
+// ExpandCIDROptions holds the CIDRs a cluster is expanded to; empty values are left unchanged
+type ExpandCIDROptions struct {
+	// NonMasqueradeCIDR is the new CIDR of the internal network, which must contain the current one
+	NonMasqueradeCIDR string
+	// PodCIDR is the new CIDR from which pod IPs are allocated, which must contain the current one
+	PodCIDR string
+}
+
+// ExpandCIDRs widens the NonMasqueradeCIDR and PodCIDR of a cluster.
+// Each new CIDR must contain the current one, so that the pod CIDRs already allocated to nodes remain valid.
+// The ServiceClusterIPRange is pinned to its current value, as Kubernetes doesn't support changing it.
+// It returns a description of each change made to the cluster spec.
+func ExpandCIDRs(cluster *api.Cluster, options ExpandCIDROptions) ([]string, error) {
+	if err := expandCIDRSupported(cluster); err != nil {
+		return nil, err
+	}
+
+	oldNonMasqueradeCIDR, err := parseIPv4CIDR("nonMasqueradeCIDR", cluster.Spec.NonMasqueradeCIDR)
+	if err != nil {
+		return nil, err
+	}
+
+	oldPodCIDRString := cluster.Spec.PodCIDR
+	if cluster.Spec.KubeControllerManager != nil && cluster.Spec.KubeControllerManager.ClusterCIDR != "" {
+		oldPodCIDRString = cluster.Spec.KubeControllerManager.ClusterCIDR
+	}
+	if oldPodCIDRString == "" {
+		oldPodCIDRString = cloudup.DefaultPodCIDR(oldNonMasqueradeCIDR)
+	}
+	oldPodCIDR, err := parseIPv4CIDR("podCIDR", oldPodCIDRString)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceClusterIPRangeString := cluster.Spec.ServiceClusterIPRange
+	if serviceClusterIPRangeString == "" {
+		serviceClusterIPRangeString = cloudup.DefaultServiceClusterIPRange(oldNonMasqueradeCIDR)
+	}
+	serviceClusterIPRange, err := parseIPv4CIDR("serviceClusterIPRange", serviceClusterIPRangeString)
+	if err != nil {
+		return nil, err
+	}
+
+	newNonMasqueradeCIDR := oldNonMasqueradeCIDR
+	if options.NonMasqueradeCIDR != "" {
+		if newNonMasqueradeCIDR, err = parseIPv4CIDR("nonMasqueradeCIDR", options.NonMasqueradeCIDR); err != nil {
+			return nil, err
+		}
+		if !cidrContains(newNonMasqueradeCIDR, oldNonMasqueradeCIDR) {
+			return nil, fmt.Errorf("nonMasqueradeCIDR %s must contain the current nonMasqueradeCIDR %s", newNonMasqueradeCIDR, oldNonMasqueradeCIDR)
+		}
+	}
+
+	newPodCIDR := oldPodCIDR
+	if options.PodCIDR != "" {
+		if newPodCIDR, err = parseIPv4CIDR("podCIDR", options.PodCIDR); err != nil {
+			return nil, err
+		}
+		if !cidrContains(newPodCIDR, oldPodCIDR) {
+			return nil, fmt.Errorf("podCIDR %s must contain the current podCIDR %s", newPodCIDR, oldPodCIDR)
+		}
+	}
+
+	if !cidrContains(newNonMasqueradeCIDR, newPodCIDR) {
+		return nil, fmt.Errorf("podCIDR %s must be within nonMasqueradeCIDR %s", newPodCIDR, newNonMasqueradeCIDR)
+	}
+	if newPodCIDR.Contains(serviceClusterIPRange.IP) || serviceClusterIPRange.Contains(newPodCIDR.IP) {
+		return nil, fmt.Errorf("podCIDR %s must not overlap serviceClusterIPRange %s", newPodCIDR, serviceClusterIPRange)
+	}
+
+	if newNonMasqueradeCIDR.String() == oldNonMasqueradeCIDR.String() && newPodCIDR.String() == oldPodCIDR.String() {
+		return nil, nil
+	}
+
+	var changes []string
+	set := func(field string, value *string, newValue string) {
+		if *value != newValue {
+			changes = append(changes, fmt.Sprintf("%s: %q -> %q", field, *value, newValue))
+			*value = newValue
+		}
+	}
+
+	set("serviceClusterIPRange", &cluster.Spec.ServiceClusterIPRange, serviceClusterIPRange.String())
+	set("nonMasqueradeCIDR", &cluster.Spec.NonMasqueradeCIDR, newNonMasqueradeCIDR.String())
+	set("podCIDR", &cluster.Spec.PodCIDR, newPodCIDR.String())
+	if kcm := cluster.Spec.KubeControllerManager; kcm != nil && kcm.ClusterCIDR != "" {
+		set("kubeControllerManager.clusterCIDR", &kcm.ClusterCIDR, newPodCIDR.String())
+	}
+	if kubeProxy := cluster.Spec.KubeProxy; kubeProxy != nil && kubeProxy.ClusterCIDR != nil && *kubeProxy.ClusterCIDR == oldPodCIDR.String() {
+		set("kubeProxy.clusterCIDR", kubeProxy.ClusterCIDR, newPodCIDR.String())
+	}
+	if kubelet := cluster.Spec.Kubelet; kubelet != nil && kubelet.NonMasqueradeCIDR != nil {
+		set("kubelet.nonMasqueradeCIDR", kubelet.NonMasqueradeCIDR, newNonMasqueradeCIDR.String())
+	}
+	if kubelet := cluster.Spec.MasterKubelet; kubelet != nil && kubelet.NonMasqueradeCIDR != nil {
+		set("masterKubelet.nonMasqueradeCIDR", kubelet.NonMasqueradeCIDR, newNonMasqueradeCIDR.String())
+	}
+
+	return changes, nil
+}
+
+// expandCIDRSupported checks that the networking of the cluster allocates pod IPs from the pod CIDRs of the nodes,
+// which kube-controller-manager allocates from the cluster's PodCIDR.
+// Networking that keeps its own record of the pod IP range, or that uses cloud IPs for pods, can't be expanded.
+func expandCIDRSupported(cluster *api.Cluster) error {
+	if cluster.Spec.IsIPv6Only() {
+		return fmt.Errorf("expanding the CIDRs of IPv6 clusters is not supported")
+	}
+	if cluster.Spec.IsKopsControllerIPAM() {
+		return fmt.Errorf("expanding the CIDRs of clusters where kops-controller allocates pod CIDRs is not supported")
+	}
+
+	networking := cluster.Spec.Networking
+	switch {
+	case networking == nil:
+		return fmt.Errorf("networking not set")
+	case networking.Kubenet != nil, networking.Kopeio != nil, networking.Flannel != nil, networking.Kuberouter != nil:
+		return nil
+	case networking.Cilium != nil:
+		if networking.Cilium.IPAM != "" && networking.Cilium.IPAM != "kubernetes" {
+			return fmt.Errorf("expanding the CIDRs is only supported for Cilium with the %q IPAM mode", "kubernetes")
+		}
+		return nil
+	case networking.Calico != nil, networking.Canal != nil:
+		return fmt.Errorf("expanding the CIDRs is not supported with Calico, as its IP pool is fixed when it is installed")
+	default:
+		return fmt.Errorf("expanding the CIDRs is only supported with kubenet, kopeio, flannel, kube-router and cilium networking")
+	}
+}
+
+func parseIPv4CIDR(field string, s string) (*net.IPNet, error) {
+	if s == "" {
+		return nil, fmt.Errorf("%s is not set", field)
+	}
+	if utils.IsIPv6CIDR(s) {
+		return nil, fmt.Errorf("%s %q must be an IPv4 CIDR", field, s)
+	}
+	ip, cidr, err := net.ParseCIDR(s)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s %q: %v", field, s, err)
+	}
+	if !ip.Equal(cidr.IP) {
+		return nil, fmt.Errorf("%s %q is not a network address; did you mean %q?", field, s, cidr.String())
+	}
+	return cidr, nil
+}
+
+// cidrContains returns true if inner is entirely within outer
+func cidrContains(outer, inner *net.IPNet) bool {
+	outerOnes, _ := outer.Mask.Size()
+	innerOnes, _ := inner.Mask.Size()
+	return outerOnes <= innerOnes && outer.Contains(inner.IP)
+}
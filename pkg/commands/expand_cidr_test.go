@@ -0,0 +1,186 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	api "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func TestExpandCIDRs(t *testing.T) {
+	grid := []struct {
+		Description     string
+		Spec            api.ClusterSpec
+		Options         ExpandCIDROptions
+		ExpectedSpec    api.ClusterSpec
+		ExpectedChanges []string
+		ExpectedError   string
+	}{
+		{
+			Description: "defaulted pod and service CIDRs are pinned",
+			Spec: api.ClusterSpec{
+				Networking:        &api.NetworkingSpec{Kubenet: &api.KubenetNetworkingSpec{}},
+				NonMasqueradeCIDR: "100.64.0.0/10",
+			},
+			Options: ExpandCIDROptions{NonMasqueradeCIDR: "100.0.0.0/9"},
+			ExpectedSpec: api.ClusterSpec{
+				Networking:            &api.NetworkingSpec{Kubenet: &api.KubenetNetworkingSpec{}},
+				NonMasqueradeCIDR:     "100.0.0.0/9",
+				PodCIDR:               "100.96.0.0/11",
+				ServiceClusterIPRange: "100.64.0.0/13",
+			},
+			ExpectedChanges: []string{
+				`serviceClusterIPRange: "" -> "100.64.0.0/13"`,
+				`nonMasqueradeCIDR: "100.64.0.0/10" -> "100.0.0.0/9"`,
+				`podCIDR: "" -> "100.96.0.0/11"`,
+			},
+		},
+		{
+			Description: "explicit component settings are updated",
+			Spec: api.ClusterSpec{
+				Networking:            &api.NetworkingSpec{Cilium: &api.CiliumNetworkingSpec{}},
+				NonMasqueradeCIDR:     "10.0.0.0/14",
+				ServiceClusterIPRange: "10.0.0.0/20",
+				KubeControllerManager: &api.KubeControllerManagerConfig{ClusterCIDR: "10.2.0.0/16"},
+				KubeProxy:             &api.KubeProxyConfig{ClusterCIDR: fi.String("10.2.0.0/16")},
+				Kubelet:               &api.KubeletConfigSpec{NonMasqueradeCIDR: fi.String("10.0.0.0/14")},
+			},
+			Options: ExpandCIDROptions{NonMasqueradeCIDR: "10.0.0.0/13", PodCIDR: "10.2.0.0/15"},
+			ExpectedSpec: api.ClusterSpec{
+				Networking:            &api.NetworkingSpec{Cilium: &api.CiliumNetworkingSpec{}},
+				NonMasqueradeCIDR:     "10.0.0.0/13",
+				PodCIDR:               "10.2.0.0/15",
+				ServiceClusterIPRange: "10.0.0.0/20",
+				KubeControllerManager: &api.KubeControllerManagerConfig{ClusterCIDR: "10.2.0.0/15"},
+				KubeProxy:             &api.KubeProxyConfig{ClusterCIDR: fi.String("10.2.0.0/15")},
+				Kubelet:               &api.KubeletConfigSpec{NonMasqueradeCIDR: fi.String("10.0.0.0/13")},
+			},
+			ExpectedChanges: []string{
+				`nonMasqueradeCIDR: "10.0.0.0/14" -> "10.0.0.0/13"`,
+				`podCIDR: "" -> "10.2.0.0/15"`,
+				`kubeControllerManager.clusterCIDR: "10.2.0.0/16" -> "10.2.0.0/15"`,
+				`kubeProxy.clusterCIDR: "10.2.0.0/16" -> "10.2.0.0/15"`,
+				`kubelet.nonMasqueradeCIDR: "10.0.0.0/14" -> "10.0.0.0/13"`,
+			},
+		},
+		{
+			Description: "pod CIDR must contain the current pod CIDR",
+			Spec: api.ClusterSpec{
+				Networking:            &api.NetworkingSpec{Kubenet: &api.KubenetNetworkingSpec{}},
+				NonMasqueradeCIDR:     "10.0.0.0/14",
+				ServiceClusterIPRange: "10.0.0.0/20",
+				PodCIDR:               "10.2.0.0/16",
+			},
+			Options:       ExpandCIDROptions{PodCIDR: "10.1.0.0/16"},
+			ExpectedError: "podCIDR 10.1.0.0/16 must contain the current podCIDR 10.2.0.0/16",
+		},
+		{
+			Description: "pod CIDR must not overlap the service CIDR",
+			Spec: api.ClusterSpec{
+				Networking:        &api.NetworkingSpec{Kubenet: &api.KubenetNetworkingSpec{}},
+				NonMasqueradeCIDR: "100.64.0.0/10",
+			},
+			Options:       ExpandCIDROptions{PodCIDR: "100.64.0.0/10"},
+			ExpectedError: "podCIDR 100.64.0.0/10 must not overlap serviceClusterIPRange 100.64.0.0/13",
+		},
+		{
+			Description: "pod CIDR must be within the non-masquerade CIDR",
+			Spec: api.ClusterSpec{
+				Networking:            &api.NetworkingSpec{Kubenet: &api.KubenetNetworkingSpec{}},
+				NonMasqueradeCIDR:     "10.0.0.0/14",
+				ServiceClusterIPRange: "10.0.0.0/20",
+				PodCIDR:               "10.2.0.0/16",
+			},
+			Options:       ExpandCIDROptions{PodCIDR: "10.0.0.0/13"},
+			ExpectedError: "podCIDR 10.0.0.0/13 must be within nonMasqueradeCIDR 10.0.0.0/14",
+		},
+		{
+			Description: "CIDRs must be canonical",
+			Spec: api.ClusterSpec{
+				Networking:        &api.NetworkingSpec{Kubenet: &api.KubenetNetworkingSpec{}},
+				NonMasqueradeCIDR: "100.64.0.0/10",
+			},
+			Options:       ExpandCIDROptions{PodCIDR: "100.96.0.0/10"},
+			ExpectedError: "podCIDR \"100.96.0.0/10\" is not a network address; did you mean \"100.64.0.0/10\"?",
+		},
+		{
+			Description: "shrinking is rejected",
+			Spec: api.ClusterSpec{
+				Networking:        &api.NetworkingSpec{Kubenet: &api.KubenetNetworkingSpec{}},
+				NonMasqueradeCIDR: "100.64.0.0/10",
+			},
+			Options:       ExpandCIDROptions{NonMasqueradeCIDR: "100.64.0.0/11"},
+			ExpectedError: "nonMasqueradeCIDR 100.64.0.0/11 must contain the current nonMasqueradeCIDR 100.64.0.0/10",
+		},
+		{
+			Description: "calico is not supported",
+			Spec: api.ClusterSpec{
+				Networking:        &api.NetworkingSpec{Calico: &api.CalicoNetworkingSpec{}},
+				NonMasqueradeCIDR: "100.64.0.0/10",
+			},
+			Options:       ExpandCIDROptions{NonMasqueradeCIDR: "100.64.0.0/9"},
+			ExpectedError: "not supported with Calico",
+		},
+		{
+			Description: "cilium ENI is not supported",
+			Spec: api.ClusterSpec{
+				Networking:        &api.NetworkingSpec{Cilium: &api.CiliumNetworkingSpec{IPAM: api.CiliumIpamEni}},
+				NonMasqueradeCIDR: "100.64.0.0/10",
+			},
+			Options:       ExpandCIDROptions{NonMasqueradeCIDR: "100.64.0.0/9"},
+			ExpectedError: "only supported for Cilium with the \"kubernetes\" IPAM mode",
+		},
+		{
+			Description: "no changes",
+			Spec: api.ClusterSpec{
+				Networking:        &api.NetworkingSpec{Kubenet: &api.KubenetNetworkingSpec{}},
+				NonMasqueradeCIDR: "100.64.0.0/10",
+			},
+			Options: ExpandCIDROptions{NonMasqueradeCIDR: "100.64.0.0/10"},
+			ExpectedSpec: api.ClusterSpec{
+				Networking:        &api.NetworkingSpec{Kubenet: &api.KubenetNetworkingSpec{}},
+				NonMasqueradeCIDR: "100.64.0.0/10",
+			},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.Description, func(t *testing.T) {
+			cluster := &api.Cluster{Spec: g.Spec}
+			changes, err := ExpandCIDRs(cluster, g.Options)
+			if g.ExpectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), g.ExpectedError) {
+					t.Fatalf("expected error containing %q, got %v", g.ExpectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(changes, g.ExpectedChanges) {
+				t.Errorf("unexpected changes: expected %q, got %q", g.ExpectedChanges, changes)
+			}
+			if !reflect.DeepEqual(cluster.Spec, g.ExpectedSpec) {
+				t.Errorf("unexpected spec: expected %+v, got %+v", g.ExpectedSpec, cluster.Spec)
+			}
+		})
+	}
+}
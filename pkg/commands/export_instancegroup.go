@@ -0,0 +1,113 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+
+	"k8s.io/kops/pkg/apis/kops"
+	nodeidentityaws "k8s.io/kops/pkg/nodeidentity/aws"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awstasks"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+// InstanceGroupArtifacts holds what an external provisioner needs to launch instances into an instance group
+type InstanceGroupArtifacts struct {
+	// Name is the name of the instance group
+	Name string `json:"name"`
+	// LaunchTemplate is the name of the launch template kOps maintains for the instance group
+	LaunchTemplate string `json:"launchTemplate"`
+	// Image is the ID of the AMI
+	Image string `json:"image"`
+	// InstanceType is the instance type set in the launch template
+	InstanceType string `json:"instanceType,omitempty"`
+	// IAMInstanceProfile is the name of the IAM instance profile
+	IAMInstanceProfile string `json:"iamInstanceProfile,omitempty"`
+	// SecurityGroups are the IDs of the security groups, or their names if they don't exist yet
+	SecurityGroups []string `json:"securityGroups,omitempty"`
+	// Subnets are the IDs of the subnets, or their names if they don't exist yet
+	Subnets []string `json:"subnets,omitempty"`
+	// UserData is the user data that bootstraps the instances
+	UserData string `json:"userData"`
+}
+
+// BuildInstanceGroupArtifacts extracts the artifacts of an instance group from the tasks built by an update of the cluster.
+// The tasks must have been run, so that the user data has been rendered and the IDs of existing resources are known.
+func BuildInstanceGroupArtifacts(cloud awsup.AWSCloud, ig *kops.InstanceGroup, taskMap map[string]fi.Task) (*InstanceGroupArtifacts, error) {
+	var launchTemplate *awstasks.LaunchTemplate
+	subnets := make(map[string]*awstasks.Subnet)
+	for _, task := range taskMap {
+		switch task := task.(type) {
+		case *awstasks.LaunchTemplate:
+			if task.Tags[nodeidentityaws.CloudTagInstanceGroupName] == ig.ObjectMeta.Name {
+				launchTemplate = task
+			}
+		case *awstasks.Subnet:
+			subnets[fi.StringValue(task.ShortName)] = task
+		}
+	}
+	if launchTemplate == nil {
+		return nil, fmt.Errorf("unable to find launch template for instance group %q", ig.ObjectMeta.Name)
+	}
+
+	artifacts := &InstanceGroupArtifacts{
+		Name:           ig.ObjectMeta.Name,
+		LaunchTemplate: fi.StringValue(launchTemplate.Name),
+		InstanceType:   fi.StringValue(launchTemplate.InstanceType),
+	}
+
+	image, err := cloud.ResolveImage(fi.StringValue(launchTemplate.ImageID))
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve image %q: %v", fi.StringValue(launchTemplate.ImageID), err)
+	}
+	artifacts.Image = fi.StringValue(image.ImageId)
+
+	if launchTemplate.IAMInstanceProfile != nil {
+		artifacts.IAMInstanceProfile = fi.StringValue(launchTemplate.IAMInstanceProfile.Name)
+	}
+
+	for _, sg := range launchTemplate.SecurityGroups {
+		if sg.ID != nil {
+			artifacts.SecurityGroups = append(artifacts.SecurityGroups, *sg.ID)
+		} else {
+			artifacts.SecurityGroups = append(artifacts.SecurityGroups, fi.StringValue(sg.Name))
+		}
+	}
+
+	for _, name := range ig.Spec.Subnets {
+		subnet := subnets[name]
+		if subnet == nil {
+			return nil, fmt.Errorf("unable to find subnet %q of instance group %q", name, ig.ObjectMeta.Name)
+		}
+		if subnet.ID != nil {
+			artifacts.Subnets = append(artifacts.Subnets, *subnet.ID)
+		} else {
+			artifacts.Subnets = append(artifacts.Subnets, fi.StringValue(subnet.Name))
+		}
+	}
+
+	if launchTemplate.UserData != nil {
+		userData, err := fi.ResourceAsString(launchTemplate.UserData)
+		if err != nil {
+			return nil, fmt.Errorf("error rendering user data for instance group %q: %v", ig.ObjectMeta.Name, err)
+		}
+		artifacts.UserData = userData
+	}
+
+	return artifacts, nil
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/cloudmock/aws/mockec2"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awstasks"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+func TestBuildInstanceGroupArtifacts(t *testing.T) {
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	mockEC2 := &mockec2.MockEC2{}
+	cloud.MockEC2 = mockEC2
+	mockEC2.Images = append(mockEC2.Images, &ec2.Image{
+		CreationDate:   aws.String("2016-10-21T20:07:19.000Z"),
+		ImageId:        aws.String("ami-073c8c0760395aab8"),
+		Name:           aws.String("focal"),
+		OwnerId:        aws.String(awsup.WellKnownAccountUbuntu),
+		RootDeviceName: aws.String("/dev/xvda"),
+		Architecture:   aws.String("x86_64"),
+	})
+
+	ig := &kops.InstanceGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "batch"},
+		Spec: kops.InstanceGroupSpec{
+			Manager: kops.InstanceManagerExternal,
+			Role:    kops.InstanceGroupRoleNode,
+			Subnets: []string{"us-east-1a", "us-east-1b"},
+		},
+	}
+
+	taskMap := map[string]fi.Task{
+		"LaunchTemplate/batch.example.com": &awstasks.LaunchTemplate{
+			Name:               fi.String("batch.example.com"),
+			ImageID:            fi.String("099720109477/focal"),
+			InstanceType:       fi.String("m5.large"),
+			IAMInstanceProfile: &awstasks.IAMInstanceProfile{Name: fi.String("nodes.example.com")},
+			SecurityGroups: []*awstasks.SecurityGroup{
+				{Name: fi.String("nodes.example.com"), ID: fi.String("sg-12345678")},
+				{Name: fi.String("batch.example.com")},
+			},
+			Tags:     map[string]string{"kops.k8s.io/instancegroup": "batch"},
+			UserData: fi.NewStringResource("#!/bin/bash\necho nodeup\n"),
+		},
+		"LaunchTemplate/nodes.example.com": &awstasks.LaunchTemplate{
+			Name: fi.String("nodes.example.com"),
+			Tags: map[string]string{"kops.k8s.io/instancegroup": "nodes"},
+		},
+		"Subnet/us-east-1a.example.com": &awstasks.Subnet{
+			Name:      fi.String("us-east-1a.example.com"),
+			ShortName: fi.String("us-east-1a"),
+			ID:        fi.String("subnet-12345678"),
+		},
+		"Subnet/us-east-1b.example.com": &awstasks.Subnet{
+			Name:      fi.String("us-east-1b.example.com"),
+			ShortName: fi.String("us-east-1b"),
+		},
+	}
+
+	artifacts, err := BuildInstanceGroupArtifacts(cloud, ig, taskMap)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := &InstanceGroupArtifacts{
+		Name:               "batch",
+		LaunchTemplate:     "batch.example.com",
+		Image:              "ami-073c8c0760395aab8",
+		InstanceType:       "m5.large",
+		IAMInstanceProfile: "nodes.example.com",
+		SecurityGroups:     []string{"sg-12345678", "batch.example.com"},
+		Subnets:            []string{"subnet-12345678", "us-east-1b.example.com"},
+		UserData:           "#!/bin/bash\necho nodeup\n",
+	}
+	if !reflect.DeepEqual(artifacts, expected) {
+		t.Errorf("unexpected artifacts\n got: %+v\nwant: %+v", artifacts, expected)
+	}
+
+	ig.ObjectMeta.Name = "unknown"
+	if _, err := BuildInstanceGroupArtifacts(cloud, ig, taskMap); err == nil {
+		t.Errorf("expected error for instance group without a launch template")
+	}
+}
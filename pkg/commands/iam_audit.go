@@ -0,0 +1,296 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudtrail"
+	"github.com/aws/aws-sdk-go/service/cloudtrail/cloudtrailiface"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/model"
+	"k8s.io/kops/pkg/model/iam"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+// IAMAuditRole is an IAM role that kops attaches a policy to
+type IAMAuditRole struct {
+	// Name is the name of the IAM role
+	Name string
+	// Policy is the policy kops attaches to the role
+	Policy *iam.Policy
+}
+
+// IAMAuditStatement is the result of auditing a statement of the policy of a role
+type IAMAuditStatement struct {
+	// Role is the name of the IAM role the policy is attached to
+	Role string `json:"role"`
+	// Statement is the index of the statement in the policy
+	Statement int `json:"statement"`
+	// Actions are the actions granted by the statement
+	Actions []string `json:"actions"`
+	// Resources are the resources the actions are granted on
+	Resources []string `json:"resources"`
+	// UnusedActions are the actions granted by the statement that were not used
+	UnusedActions []string `json:"unusedActions,omitempty"`
+	// Removable is true if none of the actions granted by the statement were used
+	Removable bool `json:"removable"`
+}
+
+// BuildIAMAuditRoles renders the policies that kops attaches to the instance group roles of a cluster.
+// The cluster must be fully populated, as for "kops update cluster".
+func BuildIAMAuditRoles(cluster *kops.Cluster, instanceGroups []*kops.InstanceGroup, region, partition, accountID string) ([]*IAMAuditRole, error) {
+	modelContext := &model.KopsModelContext{
+		IAMModelContext: iam.IAMModelContext{Cluster: cluster},
+	}
+
+	// As in the IAM model, instance groups sharing an instance profile share a role,
+	// which needs lifecycle hook permissions if any of them has a warm pool with a lifecycle hook.
+	igRoles := make(map[string]kops.InstanceGroupRole)
+	lifecycleHooks := make(map[string]bool)
+	for _, ig := range instanceGroups {
+		name := modelContext.IAMName(ig.Spec.Role)
+		if ig.Spec.IAM != nil && ig.Spec.IAM.Profile != nil {
+			profileName, err := model.FindCustomAuthNameFromArn(fi.StringValue(ig.Spec.IAM.Profile))
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse instance profile name of instance group %q: %w", ig.ObjectMeta.Name, err)
+			}
+			name = profileName
+		}
+		if igRole, found := igRoles[name]; found && igRole != ig.Spec.Role {
+			return nil, fmt.Errorf("found IAM role %q assigned to multiple instance group roles %v and %v", name, igRole, ig.Spec.Role)
+		}
+		igRoles[name] = ig.Spec.Role
+
+		warmPool := cluster.Spec.WarmPool.ResolveDefaults(ig)
+		if warmPool.IsEnabled() && warmPool.EnableLifecycleHook {
+			lifecycleHooks[name] = true
+		}
+	}
+
+	// The hosted zone is only known here if the cluster spec refers to it by ID
+	hostedZoneID := ""
+	if cluster.Spec.DNSZone != "" && !strings.Contains(cluster.Spec.DNSZone, ".") {
+		hostedZoneID = cluster.Spec.DNSZone
+	}
+
+	var roles []*IAMAuditRole
+	for _, name := range sets.StringKeySet(igRoles).List() {
+		subject, err := iam.BuildNodeRoleSubject(igRoles[name], lifecycleHooks[name])
+		if err != nil {
+			return nil, err
+		}
+
+		b := &iam.PolicyBuilder{
+			Cluster:                               cluster,
+			HostedZoneID:                          hostedZoneID,
+			InstanceGroups:                        instanceGroups,
+			Region:                                region,
+			Partition:                             partition,
+			Role:                                  subject,
+			UseServiceAccountExternalPermisssions: modelContext.UseServiceAccountExternalPermissions(),
+			AccountID:                             accountID,
+		}
+		policy, err := b.BuildAWSPolicy()
+		if err != nil {
+			return nil, fmt.Errorf("error building IAM policy for role %q: %w", name, err)
+		}
+		// AsJSON adds the statements for the actions that are collected by the policy builder
+		if _, err := policy.AsJSON(); err != nil {
+			return nil, fmt.Errorf("error building IAM policy for role %q: %w", name, err)
+		}
+
+		roles = append(roles, &IAMAuditRole{
+			Name:   name,
+			Policy: policy,
+		})
+	}
+
+	return roles, nil
+}
+
+// IAMUsage holds the IAM actions that were used, by role name.
+// Actions recorded for the empty role name are considered used by all roles.
+// Actions may contain the wildcards "*" and "?".
+type IAMUsage map[string]sets.String
+
+// Add records actions as used by role
+func (u IAMUsage) Add(role string, actions ...string) {
+	if u[role] == nil {
+		u[role] = sets.NewString()
+	}
+	u[role].Insert(actions...)
+}
+
+// Merge records all the actions of other
+func (u IAMUsage) Merge(other IAMUsage) {
+	for role, actions := range other {
+		u.Add(role, actions.UnsortedList()...)
+	}
+}
+
+// Used returns true if action, which may contain wildcards, was used by role
+func (u IAMUsage) Used(role, action string) bool {
+	for _, key := range []string{"", role} {
+		for used := range u[key] {
+			if matchIAMAction(used, action) || matchIAMAction(action, used) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchIAMAction returns true if action matches pattern; IAM actions are case-insensitive
+func matchIAMAction(pattern, action string) bool {
+	// Actions don't contain "/", so path.Match implements the IAM wildcards
+	matched, err := path.Match(strings.ToLower(pattern), strings.ToLower(action))
+	return err == nil && matched
+}
+
+// ParseIAMAllowlist parses a static list of IAM actions that are known to be used.
+// Each line holds an action, which applies to all roles, or a role name followed by an action.
+// Empty lines and lines starting with "#" are ignored.
+func ParseIAMAllowlist(r io.Reader) (IAMUsage, error) {
+	usage := IAMUsage{}
+
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		role := ""
+		fields := strings.Fields(line)
+		switch len(fields) {
+		case 1:
+		case 2:
+			role = fields[0]
+		default:
+			return nil, fmt.Errorf("line %d: expected an action, optionally preceded by a role name, got %q", lineNumber, line)
+		}
+
+		action := fields[len(fields)-1]
+		if tokens := strings.Split(action, ":"); len(tokens) != 2 || tokens[0] == "" || tokens[1] == "" {
+			return nil, fmt.Errorf("line %d: action %q is not of the form service:action", lineNumber, action)
+		}
+		usage.Add(role, action)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading allowlist: %w", err)
+	}
+
+	return usage, nil
+}
+
+// cloudTrailEvent holds the fields of a CloudTrail event record that identify the action and the role calling it
+type cloudTrailEvent struct {
+	EventSource  string `json:"eventSource"`
+	EventName    string `json:"eventName"`
+	UserIdentity struct {
+		SessionContext struct {
+			SessionIssuer struct {
+				Type     string `json:"type"`
+				UserName string `json:"userName"`
+			} `json:"sessionIssuer"`
+		} `json:"sessionContext"`
+	} `json:"userIdentity"`
+}
+
+// CollectCloudTrailUsage records the actions called by the given roles in the CloudTrail management events since the given time.
+// Data events, such as reads of S3 objects, are not available from the CloudTrail event history.
+func CollectCloudTrailUsage(ctx context.Context, client cloudtrailiface.CloudTrailAPI, roles []string, since time.Time) (IAMUsage, error) {
+	usage := IAMUsage{}
+	roleNames := sets.NewString(roles...)
+
+	request := &cloudtrail.LookupEventsInput{
+		StartTime: aws.Time(since),
+	}
+	var parseErr error
+	err := client.LookupEventsPagesWithContext(ctx, request, func(page *cloudtrail.LookupEventsOutput, lastPage bool) bool {
+		for _, event := range page.Events {
+			record := &cloudTrailEvent{}
+			if err := json.Unmarshal([]byte(aws.StringValue(event.CloudTrailEvent)), record); err != nil {
+				parseErr = fmt.Errorf("error parsing CloudTrail event %q: %w", aws.StringValue(event.EventId), err)
+				return false
+			}
+
+			issuer := record.UserIdentity.SessionContext.SessionIssuer
+			if issuer.Type != "Role" || !roleNames.Has(issuer.UserName) {
+				continue
+			}
+			service := strings.TrimSuffix(record.EventSource, ".amazonaws.com")
+			usage.Add(issuer.UserName, service+":"+record.EventName)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error looking up CloudTrail events: %w", err)
+	}
+	if parseErr != nil {
+		return nil, parseErr
+	}
+
+	for _, role := range roles {
+		klog.V(2).Infof("found %d actions used by role %q in CloudTrail", usage[role].Len(), role)
+	}
+
+	return usage, nil
+}
+
+// AuditIAMPolicies cross-references the statements of the policies of roles with the actions that were used.
+// Statements granting only unused actions are reported as removable.
+func AuditIAMPolicies(roles []*IAMAuditRole, usage IAMUsage) []*IAMAuditStatement {
+	var results []*IAMAuditStatement
+	for _, role := range roles {
+		for i, statement := range role.Policy.Statement {
+			if statement.Effect != iam.StatementEffectAllow || statement.Action.IsEmpty() {
+				continue
+			}
+
+			result := &IAMAuditStatement{
+				Role:      role.Name,
+				Statement: i,
+				Actions:   statement.Action.Value(),
+				Resources: statement.Resource.Value(),
+			}
+			for _, action := range result.Actions {
+				if !usage.Used(role.Name, action) {
+					result.UnusedActions = append(result.UnusedActions, action)
+				}
+			}
+			sort.Strings(result.UnusedActions)
+			result.Removable = len(result.UnusedActions) == len(result.Actions)
+
+			results = append(results, result)
+		}
+	}
+	return results
+}
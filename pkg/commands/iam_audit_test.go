@@ -0,0 +1,205 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/cloudtrail"
+	"github.com/aws/aws-sdk-go/service/cloudtrail/cloudtrailiface"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	api "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/model/iam"
+	"k8s.io/kops/pkg/testutils"
+	"k8s.io/kops/pkg/util/stringorslice"
+)
+
+func TestParseIAMAllowlist(t *testing.T) {
+	allowlist := `
+# Actions used by all roles
+ec2:Describe*
+nodes.example.com   autoscaling:CompleteLifecycleAction
+`
+	usage, err := ParseIAMAllowlist(strings.NewReader(allowlist))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := IAMUsage{
+		"":                  sets.NewString("ec2:Describe*"),
+		"nodes.example.com": sets.NewString("autoscaling:CompleteLifecycleAction"),
+	}
+	if !reflect.DeepEqual(usage, expected) {
+		t.Errorf("unexpected usage: %v", usage)
+	}
+
+	for _, invalid := range []string{"ec2", "ec2:", "role ec2:DescribeRegions extra"} {
+		if _, err := ParseIAMAllowlist(strings.NewReader(invalid)); err == nil {
+			t.Errorf("expected error parsing %q", invalid)
+		}
+	}
+}
+
+func TestIAMUsageUsed(t *testing.T) {
+	usage := IAMUsage{
+		"":      sets.NewString("ec2:Describe*"),
+		"nodes": sets.NewString("kms:GenerateDataKey", "s3:getobject"),
+	}
+
+	grid := []struct {
+		Role     string
+		Action   string
+		Expected bool
+	}{
+		{Role: "masters", Action: "ec2:DescribeInstances", Expected: true},
+		{Role: "masters", Action: "ec2:AttachVolume", Expected: false},
+		{Role: "nodes", Action: "kms:GenerateDataKey*", Expected: true},
+		{Role: "masters", Action: "kms:GenerateDataKey*", Expected: false},
+		{Role: "nodes", Action: "s3:GetObject", Expected: true},
+		{Role: "nodes", Action: "s3:*", Expected: true},
+	}
+	for _, g := range grid {
+		if actual := usage.Used(g.Role, g.Action); actual != g.Expected {
+			t.Errorf("Used(%q, %q) = %v, expected %v", g.Role, g.Action, actual, g.Expected)
+		}
+	}
+}
+
+type mockCloudTrail struct {
+	cloudtrailiface.CloudTrailAPI
+	pages [][]*cloudtrail.Event
+}
+
+func (m *mockCloudTrail) LookupEventsPagesWithContext(ctx aws.Context, input *cloudtrail.LookupEventsInput, fn func(*cloudtrail.LookupEventsOutput, bool) bool, opts ...request.Option) error {
+	for i, events := range m.pages {
+		if !fn(&cloudtrail.LookupEventsOutput{Events: events}, i == len(m.pages)-1) {
+			break
+		}
+	}
+	return nil
+}
+
+func cloudTrailEventFor(role, eventSource, eventName string) *cloudtrail.Event {
+	record := `{"eventSource": "` + eventSource + `", "eventName": "` + eventName + `", "userIdentity": {"type": "AssumedRole", "sessionContext": {"sessionIssuer": {"type": "Role", "userName": "` + role + `"}}}}`
+	return &cloudtrail.Event{
+		EventId:         aws.String(role + "/" + eventName),
+		CloudTrailEvent: aws.String(record),
+	}
+}
+
+func TestCollectCloudTrailUsage(t *testing.T) {
+	client := &mockCloudTrail{
+		pages: [][]*cloudtrail.Event{
+			{
+				cloudTrailEventFor("nodes.example.com", "ec2.amazonaws.com", "DescribeInstances"),
+				cloudTrailEventFor("other-role", "ec2.amazonaws.com", "TerminateInstances"),
+			},
+			{
+				cloudTrailEventFor("masters.example.com", "elasticloadbalancing.amazonaws.com", "DescribeLoadBalancers"),
+				cloudTrailEventFor("nodes.example.com", "ec2.amazonaws.com", "DescribeInstances"),
+			},
+		},
+	}
+
+	usage, err := CollectCloudTrailUsage(context.Background(), client, []string{"masters.example.com", "nodes.example.com"}, time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := IAMUsage{
+		"masters.example.com": sets.NewString("elasticloadbalancing:DescribeLoadBalancers"),
+		"nodes.example.com":   sets.NewString("ec2:DescribeInstances"),
+	}
+	if !reflect.DeepEqual(usage, expected) {
+		t.Errorf("unexpected usage: %v", usage)
+	}
+}
+
+func TestAuditIAMPolicies(t *testing.T) {
+	policy := iam.NewPolicy("example.com", "aws")
+	policy.Statement = []*iam.Statement{
+		{
+			Effect:   iam.StatementEffectAllow,
+			Action:   stringorslice.Of("ec2:DescribeInstances", "ec2:DescribeRegions"),
+			Resource: stringorslice.String("*"),
+		},
+		{
+			Effect:   iam.StatementEffectAllow,
+			Action:   stringorslice.Of("ec2:AttachVolume"),
+			Resource: stringorslice.String("*"),
+		},
+	}
+	roles := []*IAMAuditRole{{Name: "nodes.example.com", Policy: policy}}
+	usage := IAMUsage{"nodes.example.com": sets.NewString("ec2:DescribeInstances")}
+
+	expected := []*IAMAuditStatement{
+		{
+			Role:          "nodes.example.com",
+			Statement:     0,
+			Actions:       []string{"ec2:DescribeInstances", "ec2:DescribeRegions"},
+			Resources:     []string{"*"},
+			UnusedActions: []string{"ec2:DescribeRegions"},
+			Removable:     false,
+		},
+		{
+			Role:          "nodes.example.com",
+			Statement:     1,
+			Actions:       []string{"ec2:AttachVolume"},
+			Resources:     []string{"*"},
+			UnusedActions: []string{"ec2:AttachVolume"},
+			Removable:     true,
+		},
+	}
+	if actual := AuditIAMPolicies(roles, usage); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("unexpected results: %v", actual)
+	}
+}
+
+func TestBuildIAMAuditRoles(t *testing.T) {
+	cluster := testutils.BuildMinimalCluster("example.com")
+	cluster.Spec.ConfigStore = "s3://kops-tests/example.com"
+	master := testutils.BuildMinimalMasterInstanceGroup("subnet-us-test-1a")
+	nodesA := testutils.BuildMinimalNodeInstanceGroup("nodes-a", "subnet-us-test-1a")
+	nodesB := testutils.BuildMinimalNodeInstanceGroup("nodes-b", "subnet-us-test-1a")
+	bastion := testutils.BuildMinimalBastionInstanceGroup("bastion", "subnet-us-test-1a")
+	bastion.Spec.Role = api.InstanceGroupRoleBastion
+	instanceGroups := []*api.InstanceGroup{&master, &nodesA, &nodesB, &bastion}
+
+	roles, err := BuildIAMAuditRoles(cluster, instanceGroups, "us-test-1", "aws", "123456789012")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var names []string
+	for _, role := range roles {
+		names = append(names, role.Name)
+		if len(role.Policy.Statement) == 0 {
+			t.Errorf("policy of role %q has no statements", role.Name)
+		}
+	}
+	expected := []string{"bastions.example.com", "masters.example.com", "nodes.example.com"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("unexpected roles: %v", names)
+	}
+}
@@ -0,0 +1,329 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/blang/semver/v4"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/kops/util"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+// LegacyMigration converts a construct that current versions of kOps no longer support into its modern equivalent.
+type LegacyMigration struct {
+	Item     string
+	Property string
+	Old      string
+	New      string
+
+	// Roll returns whether the instances of an instance group must be replaced for the migration to take effect.
+	Roll func(ig *kops.InstanceGroup) bool
+	// Apply changes the spec. It is nil for migrations that "kops update cluster" performs by itself.
+	Apply func()
+}
+
+// LegacyMigrationPlan is the set of migrations needed to bring a cluster created by an old version of kOps up to date.
+type LegacyMigrationPlan struct {
+	Migrations []*LegacyMigration
+	// Blockers are legacy constructs that can't safely be converted automatically and need manual attention.
+	Blockers []string
+}
+
+// RollingUpdate is an instance group whose instances must be replaced after the migrations are applied.
+type RollingUpdate struct {
+	InstanceGroup string
+	Reasons       []string
+}
+
+// FindLaunchConfigurations returns the names of the launch configurations used by the autoscaling groups of the
+// instance groups, keyed by instance group name. Only AWS has launch configurations.
+func FindLaunchConfigurations(cloud fi.Cloud, cluster *kops.Cluster, instanceGroups []*kops.InstanceGroup) (map[string]string, error) {
+	launchConfigurations := make(map[string]string)
+	if cluster.Spec.GetCloudProvider() != kops.CloudProviderAWS {
+		return launchConfigurations, nil
+	}
+
+	groups, err := cloud.GetCloudGroups(cluster, instanceGroups, false, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error listing autoscaling groups: %v", err)
+	}
+	for _, group := range groups {
+		asg, ok := group.Raw.(*autoscaling.Group)
+		if !ok || group.InstanceGroup == nil {
+			continue
+		}
+		if name := aws.StringValue(asg.LaunchConfigurationName); name != "" {
+			launchConfigurations[group.InstanceGroup.ObjectMeta.Name] = name
+		}
+	}
+	return launchConfigurations, nil
+}
+
+// PlanLegacyMigrations detects legacy constructs in the cluster and its instance groups.
+// kubernetesVersion is the version the cluster is being upgraded to.
+// launchConfigurations are the launch configurations still in use, as returned by FindLaunchConfigurations.
+func PlanLegacyMigrations(cluster *kops.Cluster, instanceGroups []*kops.InstanceGroup, kubernetesVersion semver.Version, launchConfigurations map[string]string) *LegacyMigrationPlan {
+	plan := &LegacyMigrationPlan{}
+	spec := &cluster.Spec
+
+	rollKubernetesNodes := func(ig *kops.InstanceGroup) bool { return !ig.IsBastion() }
+	rollNone := func(ig *kops.InstanceGroup) bool { return false }
+	rollControlPlane := func(ig *kops.InstanceGroup) bool { return ig.HasAPIServer() }
+
+	if spec.NodeAuthorization != nil {
+		plan.Migrations = append(plan.Migrations, &LegacyMigration{
+			Item:     "Cluster",
+			Property: "NodeAuthorization",
+			Old:      "set",
+			New:      "<removed>",
+			Roll:     rollNone,
+			Apply: func() {
+				spec.NodeAuthorization = nil
+			},
+		})
+	}
+
+	plan.planKubelet("Cluster", "Kubelet", spec.Kubelet, kubernetesVersion, rollKubernetesNodes)
+	plan.planKubelet("Cluster", "MasterKubelet", spec.MasterKubelet, kubernetesVersion, func(ig *kops.InstanceGroup) bool { return ig.IsMaster() })
+	for _, ig := range instanceGroups {
+		target := ig
+		plan.planKubelet("InstanceGroup/"+ig.ObjectMeta.Name, "Kubelet", ig.Spec.Kubelet, kubernetesVersion, func(ig *kops.InstanceGroup) bool { return ig == target })
+	}
+
+	if spec.ContainerRuntime == "docker" && util.IsKubernetesGTE("1.24", kubernetesVersion) {
+		var hooks []string
+		for _, hook := range spec.Hooks {
+			if strings.Contains(hook.Manifest, "docker ") {
+				hooks = append(hooks, "Cluster/"+hook.Name)
+			}
+		}
+		for _, ig := range instanceGroups {
+			for _, hook := range ig.Spec.Hooks {
+				if strings.Contains(hook.Manifest, "docker ") {
+					hooks = append(hooks, "InstanceGroup/"+ig.ObjectMeta.Name+"/"+hook.Name)
+				}
+			}
+		}
+		if len(hooks) > 0 {
+			plan.Blockers = append(plan.Blockers, fmt.Sprintf("spec.containerRuntime: Docker is not supported on Kubernetes 1.24+, but hooks %s run docker; convert them before switching to containerd", strings.Join(hooks, ", ")))
+		} else {
+			plan.Migrations = append(plan.Migrations, &LegacyMigration{
+				Item:     "Cluster",
+				Property: "ContainerRuntime",
+				Old:      "docker",
+				New:      "containerd",
+				Roll:     rollKubernetesNodes,
+				Apply: func() {
+					spec.ContainerRuntime = "containerd"
+				},
+			})
+		}
+	}
+
+	if spec.IAM != nil && spec.IAM.Legacy {
+		plan.Blockers = append(plan.Blockers, "spec.iam.legacy: legacy IAM permissions are no longer supported; add the permissions your workloads need to spec.additionalPolicies, then set legacy to false")
+	}
+
+	if spec.Networking != nil {
+		if spec.Networking.Romana != nil {
+			plan.Blockers = append(plan.Blockers, "spec.networking.romana: support for Romana has been removed; the cluster must be moved to another CNI")
+		}
+		if spec.Networking.LyftVPC != nil {
+			plan.Blockers = append(plan.Blockers, "spec.networking.lyftvpc: support for LyftVPC has been removed; the cluster must be moved to another CNI")
+		}
+		if calico := spec.Networking.Calico; calico != nil && calico.CrossSubnet != nil {
+			awsSrcDstCheck := calico.AWSSrcDstCheck
+			if fi.BoolValue(calico.CrossSubnet) && awsSrcDstCheck == "" {
+				awsSrcDstCheck = "Disable"
+			}
+			plan.Migrations = append(plan.Migrations, &LegacyMigration{
+				Item:     "Cluster",
+				Property: "Networking.Calico.CrossSubnet",
+				Old:      fmt.Sprintf("%v", fi.BoolValue(calico.CrossSubnet)),
+				New:      "<removed>",
+				Roll:     rollNone,
+				Apply: func() {
+					calico.CrossSubnet = nil
+					calico.AWSSrcDstCheck = awsSrcDstCheck
+				},
+			})
+		}
+	}
+
+	if spec.GetCloudProvider() == kops.CloudProviderAWS && spec.API != nil && spec.API.LoadBalancer != nil {
+		lb := spec.API.LoadBalancer
+		if lb.Class == "" || lb.Class == kops.LoadBalancerClassClassic {
+			var reasons []string
+			if lb.SecurityGroupOverride != nil {
+				reasons = append(reasons, "securityGroupOverride is set")
+			}
+			if lb.IdleTimeoutSeconds != nil {
+				reasons = append(reasons, "the idle timeout of a Network load balancer can't be configured")
+			}
+			if lb.HealthCheck != nil && fi.StringValue(lb.HealthCheck.Protocol) == "SSL" {
+				reasons = append(reasons, "a Network load balancer doesn't support SSL health checks")
+			}
+			if lb.UseForInternalAPI && lb.Type == kops.LoadBalancerTypeInternal {
+				reasons = append(reasons, "an internal Network load balancer can't be used for the internal API")
+			}
+
+			if len(reasons) > 0 {
+				plan.Blockers = append(plan.Blockers, fmt.Sprintf("spec.api.loadBalancer: the Classic load balancer can't be converted to a Network load balancer automatically: %s", strings.Join(reasons, ", ")))
+			} else {
+				plan.Migrations = append(plan.Migrations, &LegacyMigration{
+					Item:     "Cluster",
+					Property: "API.LoadBalancer.Class",
+					Old:      string(kops.LoadBalancerClassClassic),
+					New:      string(kops.LoadBalancerClassNetwork),
+					// The API server certificate must include the name of the new load balancer
+					Roll: rollControlPlane,
+					Apply: func() {
+						lb.Class = kops.LoadBalancerClassNetwork
+						if lb.ConnectionDrainingTimeoutSeconds != nil {
+							lb.DeregistrationDelaySeconds = lb.ConnectionDrainingTimeoutSeconds
+							lb.ConnectionDrainingTimeoutSeconds = nil
+						}
+					},
+				})
+			}
+		}
+	}
+
+	for _, ig := range instanceGroups {
+		name, found := launchConfigurations[ig.ObjectMeta.Name]
+		if !found {
+			continue
+		}
+		target := ig
+		plan.Migrations = append(plan.Migrations, &LegacyMigration{
+			Item:     "InstanceGroup/" + ig.ObjectMeta.Name,
+			Property: "LaunchConfiguration",
+			Old:      name,
+			New:      "<launch template>",
+			Roll:     func(ig *kops.InstanceGroup) bool { return ig == target },
+		})
+	}
+
+	return plan
+}
+
+// planKubelet plans the removal of kubelet flags that were removed from Kubernetes.
+func (p *LegacyMigrationPlan) planKubelet(item string, property string, kubelet *kops.KubeletConfigSpec, kubernetesVersion semver.Version, roll func(ig *kops.InstanceGroup) bool) {
+	if kubelet == nil {
+		return
+	}
+
+	if kubelet.CPUCFSQuotaPeriod != nil && util.IsKubernetesGTE("1.20", kubernetesVersion) {
+		p.Migrations = append(p.Migrations, &LegacyMigration{
+			Item:     item,
+			Property: property + ".CPUCFSQuotaPeriod",
+			Old:      kubelet.CPUCFSQuotaPeriod.Duration.String(),
+			New:      "<removed>",
+			Roll:     roll,
+			Apply: func() {
+				kubelet.CPUCFSQuotaPeriod = nil
+			},
+		})
+	}
+
+	if !util.IsKubernetesGTE("1.24", kubernetesVersion) {
+		return
+	}
+	if kubelet.NetworkPluginName != nil {
+		p.Migrations = append(p.Migrations, &LegacyMigration{
+			Item:     item,
+			Property: property + ".NetworkPluginName",
+			Old:      fi.StringValue(kubelet.NetworkPluginName),
+			New:      "<removed>",
+			Roll:     roll,
+			Apply: func() {
+				kubelet.NetworkPluginName = nil
+			},
+		})
+	}
+	if kubelet.NetworkPluginMTU != nil {
+		p.Migrations = append(p.Migrations, &LegacyMigration{
+			Item:     item,
+			Property: property + ".NetworkPluginMTU",
+			Old:      fmt.Sprintf("%d", *kubelet.NetworkPluginMTU),
+			New:      "<removed>",
+			Roll:     roll,
+			Apply: func() {
+				kubelet.NetworkPluginMTU = nil
+			},
+		})
+	}
+	if kubelet.NonMasqueradeCIDR != nil {
+		p.Migrations = append(p.Migrations, &LegacyMigration{
+			Item:     item,
+			Property: property + ".NonMasqueradeCIDR",
+			Old:      fi.StringValue(kubelet.NonMasqueradeCIDR),
+			New:      "<removed>",
+			Roll:     roll,
+			Apply: func() {
+				kubelet.NonMasqueradeCIDR = nil
+			},
+		})
+	}
+}
+
+// RollingUpdates returns the instance groups whose instances must be replaced once the migrations are applied,
+// in the order a rolling update replaces them: control plane first, then nodes.
+func (p *LegacyMigrationPlan) RollingUpdates(instanceGroups []*kops.InstanceGroup) []*RollingUpdate {
+	var updates []*RollingUpdate
+	for _, ig := range instanceGroups {
+		update := &RollingUpdate{InstanceGroup: ig.ObjectMeta.Name}
+		for _, migration := range p.Migrations {
+			if migration.Roll(ig) {
+				update.Reasons = append(update.Reasons, migration.Item+" "+migration.Property)
+			}
+		}
+		if len(update.Reasons) > 0 {
+			updates = append(updates, update)
+		}
+	}
+
+	rank := func(name string) int {
+		for _, ig := range instanceGroups {
+			if ig.ObjectMeta.Name != name {
+				continue
+			}
+			switch {
+			case ig.IsMaster():
+				return 0
+			case ig.IsAPIServerOnly():
+				return 1
+			case ig.IsBastion():
+				return 3
+			default:
+				return 2
+			}
+		}
+		return 4
+	}
+	sort.SliceStable(updates, func(i, j int) bool {
+		return rank(updates[i].InstanceGroup) < rank(updates[j].InstanceGroup)
+	})
+
+	return updates
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func buildLegacyCluster() (*kops.Cluster, []*kops.InstanceGroup) {
+	cluster := &kops.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "legacy.example.com"},
+		Spec: kops.ClusterSpec{
+			CloudProvider:     kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			ContainerRuntime:  "docker",
+			NodeAuthorization: &kops.NodeAuthorizationSpec{},
+			Kubelet: &kops.KubeletConfigSpec{
+				NetworkPluginName: fi.String("cni"),
+			},
+			API: &kops.AccessSpec{
+				LoadBalancer: &kops.LoadBalancerAccessSpec{
+					Type:                             kops.LoadBalancerTypePublic,
+					ConnectionDrainingTimeoutSeconds: fi.Int64(120),
+				},
+			},
+			Networking: &kops.NetworkingSpec{
+				Calico: &kops.CalicoNetworkingSpec{
+					CrossSubnet: fi.Bool(true),
+				},
+			},
+		},
+	}
+
+	instanceGroups := []*kops.InstanceGroup{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "nodes"},
+			Spec:       kops.InstanceGroupSpec{Role: kops.InstanceGroupRoleNode},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "bastions"},
+			Spec:       kops.InstanceGroupSpec{Role: kops.InstanceGroupRoleBastion},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "master-us-test-1a"},
+			Spec:       kops.InstanceGroupSpec{Role: kops.InstanceGroupRoleMaster},
+		},
+	}
+
+	return cluster, instanceGroups
+}
+
+func TestPlanLegacyMigrations(t *testing.T) {
+	cluster, instanceGroups := buildLegacyCluster()
+
+	plan := PlanLegacyMigrations(cluster, instanceGroups, semver.MustParse("1.24.0"), map[string]string{"bastions": "bastions.legacy.example.com-20160101"})
+	if len(plan.Blockers) != 0 {
+		t.Errorf("unexpected blockers: %v", plan.Blockers)
+	}
+
+	var properties []string
+	for _, migration := range plan.Migrations {
+		properties = append(properties, migration.Item+" "+migration.Property)
+	}
+	expectedProperties := []string{
+		"Cluster NodeAuthorization",
+		"Cluster Kubelet.NetworkPluginName",
+		"Cluster ContainerRuntime",
+		"Cluster Networking.Calico.CrossSubnet",
+		"Cluster API.LoadBalancer.Class",
+		"InstanceGroup/bastions LaunchConfiguration",
+	}
+	if !reflect.DeepEqual(properties, expectedProperties) {
+		t.Fatalf("unexpected migrations\n got: %v\nwant: %v", properties, expectedProperties)
+	}
+
+	rollingUpdates := plan.RollingUpdates(instanceGroups)
+	expectedRollingUpdates := []*RollingUpdate{
+		{
+			InstanceGroup: "master-us-test-1a",
+			Reasons:       []string{"Cluster Kubelet.NetworkPluginName", "Cluster ContainerRuntime", "Cluster API.LoadBalancer.Class"},
+		},
+		{
+			InstanceGroup: "nodes",
+			Reasons:       []string{"Cluster Kubelet.NetworkPluginName", "Cluster ContainerRuntime"},
+		},
+		{
+			InstanceGroup: "bastions",
+			Reasons:       []string{"InstanceGroup/bastions LaunchConfiguration"},
+		},
+	}
+	if !reflect.DeepEqual(rollingUpdates, expectedRollingUpdates) {
+		t.Errorf("unexpected rolling updates\n got: %+v\nwant: %+v", rollingUpdates, expectedRollingUpdates)
+	}
+
+	for _, migration := range plan.Migrations {
+		if migration.Apply != nil {
+			migration.Apply()
+		}
+	}
+	spec := &cluster.Spec
+	if spec.NodeAuthorization != nil {
+		t.Errorf("expected nodeAuthorization to be removed")
+	}
+	if spec.Kubelet.NetworkPluginName != nil {
+		t.Errorf("expected kubelet networkPluginName to be removed")
+	}
+	if spec.ContainerRuntime != "containerd" {
+		t.Errorf("expected containerRuntime containerd, got %q", spec.ContainerRuntime)
+	}
+	if spec.Networking.Calico.CrossSubnet != nil || spec.Networking.Calico.AWSSrcDstCheck != "Disable" {
+		t.Errorf("expected crossSubnet to be converted to awsSrcDstCheck, got %+v", spec.Networking.Calico)
+	}
+	lb := spec.API.LoadBalancer
+	if lb.Class != kops.LoadBalancerClassNetwork || lb.ConnectionDrainingTimeoutSeconds != nil || fi.Int64Value(lb.DeregistrationDelaySeconds) != 120 {
+		t.Errorf("expected Network load balancer with a deregistration delay of 120, got %+v", lb)
+	}
+
+	if plan := PlanLegacyMigrations(cluster, instanceGroups, semver.MustParse("1.24.0"), nil); len(plan.Migrations) != 0 || len(plan.Blockers) != 0 {
+		t.Errorf("expected nothing left to migrate, got %+v", plan)
+	}
+}
+
+func TestPlanLegacyMigrationsBeforeKubernetes124(t *testing.T) {
+	cluster, instanceGroups := buildLegacyCluster()
+
+	plan := PlanLegacyMigrations(cluster, instanceGroups, semver.MustParse("1.23.0"), nil)
+	for _, migration := range plan.Migrations {
+		if migration.Property == "ContainerRuntime" || migration.Property == "Kubelet.NetworkPluginName" {
+			t.Errorf("unexpected migration of %s before Kubernetes 1.24", migration.Property)
+		}
+	}
+}
+
+func TestPlanLegacyMigrationsBlockers(t *testing.T) {
+	cluster, instanceGroups := buildLegacyCluster()
+	cluster.Spec.IAM = &kops.IAMSpec{Legacy: true}
+	cluster.Spec.API.LoadBalancer.IdleTimeoutSeconds = fi.Int64(3600)
+	cluster.Spec.Hooks = []kops.HookSpec{
+		{Name: "prune", Manifest: "ExecStart=/usr/bin/docker system prune -f"},
+	}
+
+	plan := PlanLegacyMigrations(cluster, instanceGroups, semver.MustParse("1.24.0"), nil)
+	if len(plan.Blockers) != 3 {
+		t.Errorf("expected 3 blockers, got %v", plan.Blockers)
+	}
+	for _, migration := range plan.Migrations {
+		if migration.Property == "ContainerRuntime" || migration.Property == "API.LoadBalancer.Class" {
+			t.Errorf("unexpected migration of blocked %s", migration.Property)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/apis/kops/registry"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+// MirrorStateStoreOptions controls how a state store is mirrored
+type MirrorStateStoreOptions struct {
+	// DryRun reports the changes that would be made, without writing to the replica
+	DryRun bool
+	// Delete removes files from the replica that no longer exist in the source
+	Delete bool
+}
+
+// MirrorStateStoreResult records the changes made to the replica
+type MirrorStateStoreResult struct {
+	// Copied holds the relative paths of the files that were written to the replica
+	Copied []string
+	// Deleted holds the relative paths of the files that were removed from the replica
+	Deleted []string
+	// Unchanged is the number of files that were already up to date
+	Unchanged int
+}
+
+// MirrorStateStore copies the specs, keysets and secrets of a state store to a replica,
+// verifying every file it writes by reading it back.
+// The configBase of each cluster is rewritten to point at the replica, so the replica can be used
+// as a state store on its own.
+func MirrorStateStore(source vfs.Path, replica vfs.Path, options MirrorStateStoreOptions) (*MirrorStateStoreResult, error) {
+	sourceFiles, err := readTreeContents(source)
+	if err != nil {
+		return nil, fmt.Errorf("error reading state store %q: %v", source, err)
+	}
+	if len(sourceFiles) == 0 {
+		return nil, fmt.Errorf("state store %q is empty", source)
+	}
+
+	replicaFiles, err := readTreeContents(replica)
+	if err != nil {
+		return nil, fmt.Errorf("error reading replica %q: %v", replica, err)
+	}
+
+	result := &MirrorStateStoreResult{}
+
+	for _, relativePath := range sortedKeys(sourceFiles) {
+		data := rewriteConfigBase(relativePath, sourceFiles[relativePath], source, replica)
+
+		if existing, found := replicaFiles[relativePath]; found && bytes.Equal(existing, data) {
+			result.Unchanged++
+			continue
+		}
+
+		result.Copied = append(result.Copied, relativePath)
+		if options.DryRun {
+			continue
+		}
+
+		p := replica.Join(relativePath)
+		klog.V(2).Infof("copying %s to %s", relativePath, p)
+		if err := p.WriteFile(bytes.NewReader(data), nil); err != nil {
+			return result, fmt.Errorf("error writing %q: %v", p, err)
+		}
+		if err := verifyFile(p, data); err != nil {
+			return result, err
+		}
+	}
+
+	if options.Delete {
+		for _, relativePath := range sortedKeys(replicaFiles) {
+			if _, found := sourceFiles[relativePath]; found {
+				continue
+			}
+
+			result.Deleted = append(result.Deleted, relativePath)
+			if options.DryRun {
+				continue
+			}
+
+			p := replica.Join(relativePath)
+			klog.V(2).Infof("deleting %s", p)
+			if err := p.Remove(); err != nil {
+				return result, fmt.Errorf("error deleting %q: %v", p, err)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// readTreeContents reads all the files under base, keyed by their path relative to base
+func readTreeContents(base vfs.Path) (map[string][]byte, error) {
+	paths, err := base.ReadTree()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	files := make(map[string][]byte)
+	for _, p := range paths {
+		relativePath, err := vfs.RelativePath(base, p)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := p.ReadFile()
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("error reading %q: %v", p, err)
+		}
+		files[relativePath] = data
+	}
+	return files, nil
+}
+
+// rewriteConfigBase points the configBase of a cluster spec that lives in the source state store at the replica
+func rewriteConfigBase(relativePath string, data []byte, source vfs.Path, replica vfs.Path) []byte {
+	if path.Base(relativePath) != registry.PathCluster || strings.Count(relativePath, "/") != 1 {
+		return data
+	}
+
+	oldBase := []byte("configBase: " + strings.TrimSuffix(source.Path(), "/") + "/")
+	newBase := []byte("configBase: " + strings.TrimSuffix(replica.Path(), "/") + "/")
+	return bytes.ReplaceAll(data, oldBase, newBase)
+}
+
+// verifyFile reads back a file that was just written, and checks its contents match what was written
+func verifyFile(p vfs.Path, expected []byte) error {
+	actual, err := p.ReadFile()
+	if err != nil {
+		return fmt.Errorf("error verifying %q: %v", p, err)
+	}
+	if sha256.Sum256(actual) != sha256.Sum256(expected) {
+		return fmt.Errorf("integrity check failed for %q: contents read back do not match contents written", p)
+	}
+	return nil
+}
+
+func sortedKeys(m map[string][]byte) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
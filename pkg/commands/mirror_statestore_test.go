@@ -0,0 +1,111 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func writeTestFiles(t *testing.T, base vfs.Path, files map[string]string) {
+	for name, contents := range files {
+		if err := base.Join(name).WriteFile(bytes.NewReader([]byte(contents)), nil); err != nil {
+			t.Fatalf("error writing %s: %v", name, err)
+		}
+	}
+}
+
+func readTestFile(t *testing.T, base vfs.Path, name string) string {
+	data, err := base.Join(name).ReadFile()
+	if err != nil {
+		t.Fatalf("error reading %s: %v", name, err)
+	}
+	return string(data)
+}
+
+func TestMirrorStateStore(t *testing.T) {
+	vfs.Context.ResetMemfsContext(true)
+
+	source, err := vfs.Context.BuildVfsPath("memfs://primary")
+	if err != nil {
+		t.Fatal(err)
+	}
+	replica, err := vfs.Context.BuildVfsPath("memfs://replica")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	writeTestFiles(t, source, map[string]string{
+		"example.com/config":                                "spec:\n  configBase: memfs://primary/example.com\n",
+		"example.com/instancegroup/nodes":                   "spec:\n  role: Node\n",
+		"example.com/pki/private/kubernetes-ca/keyset.yaml": "keys: []\n",
+		"example.com/secrets/admin":                         "data: secret\n",
+	})
+	writeTestFiles(t, replica, map[string]string{
+		"example.com/instancegroup/nodes": "spec:\n  role: Node\n",
+		"example.com/instancegroup/old":   "spec:\n  role: Node\n",
+	})
+
+	// A dry run reports the changes without making them
+	result, err := MirrorStateStore(source, replica, MirrorStateStoreOptions{DryRun: true, Delete: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectedCopied := []string{"example.com/config", "example.com/pki/private/kubernetes-ca/keyset.yaml", "example.com/secrets/admin"}
+	if !reflect.DeepEqual(result.Copied, expectedCopied) {
+		t.Errorf("unexpected copied files: expected %v, got %v", expectedCopied, result.Copied)
+	}
+	if !reflect.DeepEqual(result.Deleted, []string{"example.com/instancegroup/old"}) {
+		t.Errorf("unexpected deleted files: %v", result.Deleted)
+	}
+	if result.Unchanged != 1 {
+		t.Errorf("expected 1 unchanged file, got %d", result.Unchanged)
+	}
+	if _, err := replica.Join("example.com/secrets/admin").ReadFile(); err == nil {
+		t.Errorf("dry run wrote to the replica")
+	}
+
+	result, err = MirrorStateStore(source, replica, MirrorStateStoreOptions{Delete: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(result.Copied, expectedCopied) {
+		t.Errorf("unexpected copied files: expected %v, got %v", expectedCopied, result.Copied)
+	}
+
+	if actual := readTestFile(t, replica, "example.com/config"); actual != "spec:\n  configBase: memfs://replica/example.com\n" {
+		t.Errorf("configBase was not rewritten: %q", actual)
+	}
+	if actual := readTestFile(t, replica, "example.com/secrets/admin"); actual != "data: secret\n" {
+		t.Errorf("unexpected secret contents: %q", actual)
+	}
+	if _, err := replica.Join("example.com/instancegroup/old").ReadFile(); err == nil {
+		t.Errorf("file that no longer exists in the source was not deleted")
+	}
+
+	// Mirroring again finds nothing to do
+	result, err = MirrorStateStore(source, replica, MirrorStateStoreOptions{Delete: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Copied) != 0 || len(result.Deleted) != 0 || result.Unchanged != 4 {
+		t.Errorf("expected no changes, got %+v", result)
+	}
+}
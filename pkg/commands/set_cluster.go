@@ -24,7 +24,8 @@ import (
 	"k8s.io/kops/util/pkg/reflectutils"
 )
 
-// SetClusterFields sets field values in the cluster
+// SetClusterFields sets field values in the cluster.
+// Fields of the form key+=value and key-=value add to and remove from slices and maps.
 func SetClusterFields(fields []string, cluster *api.Cluster) error {
 	for _, field := range fields {
 		kv := strings.SplitN(field, "=", 2)
@@ -35,9 +36,23 @@ func SetClusterFields(fields []string, cluster *api.Cluster) error {
 		key := kv[0]
 		key = strings.TrimPrefix(key, "cluster.")
 
-		if err := reflectutils.SetString(cluster, key, kv[1]); err != nil {
+		if err := setField(cluster, key, kv[1]); err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+// setField applies a field of the form key=value, key+=value or key-=value to the target.
+// key=value sets the field; key+=value appends to a slice or adds key=value pairs to a map;
+// key-=value removes values from a slice or keys from a map.
+func setField(target interface{}, key string, value string) error {
+	switch {
+	case strings.HasSuffix(key, "+"):
+		return reflectutils.AppendString(target, strings.TrimSuffix(key, "+"), value)
+	case strings.HasSuffix(key, "-"):
+		return reflectutils.RemoveString(target, strings.TrimSuffix(key, "-"), value)
+	default:
+		return reflectutils.SetString(target, key, value)
+	}
+}
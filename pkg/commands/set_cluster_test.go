@@ -274,6 +274,47 @@ func TestSetClusterFields(t *testing.T) {
 				},
 			},
 		},
+		{
+			Fields: []string{
+				"cluster.spec.kubeAPIServer.featureGates.Foo=true",
+				"cluster.spec.additionalPolicies.node=[]",
+			},
+			Input: kops.Cluster{},
+			Output: kops.Cluster{
+				Spec: kops.ClusterSpec{
+					KubeAPIServer: &kops.KubeAPIServerConfig{
+						FeatureGates: map[string]string{"Foo": "true"},
+					},
+					AdditionalPolicies: &map[string]string{"node": "[]"},
+				},
+			},
+		},
+		{
+			Fields: []string{
+				"cluster.spec.kubernetesAPIAccess+=10.0.0.0/8",
+				"cluster.spec.sshAccess-=0.0.0.0/0",
+				"cluster.spec.kubelet.featureGates+=Bar=false",
+				"cluster.spec.kubelet.featureGates-=Foo",
+			},
+			Input: kops.Cluster{
+				Spec: kops.ClusterSpec{
+					KubernetesAPIAccess: []string{"0.0.0.0/0"},
+					SSHAccess:           []string{"0.0.0.0/0", "192.168.0.0/16"},
+					Kubelet: &kops.KubeletConfigSpec{
+						FeatureGates: map[string]string{"Foo": "true"},
+					},
+				},
+			},
+			Output: kops.Cluster{
+				Spec: kops.ClusterSpec{
+					KubernetesAPIAccess: []string{"0.0.0.0/0", "10.0.0.0/8"},
+					SSHAccess:           []string{"192.168.0.0/16"},
+					Kubelet: &kops.KubeletConfigSpec{
+						FeatureGates: map[string]string{"Bar": "false"},
+					},
+				},
+			},
+		},
 	}
 
 	for _, g := range grid {
@@ -293,6 +334,17 @@ func TestSetClusterFields(t *testing.T) {
 	}
 }
 
+func TestSetClusterFieldsAppendToScalar(t *testing.T) {
+	fields := []string{
+		"spec.kubernetesVersion+=1.24.0",
+	}
+
+	err := SetClusterFields(fields, &kops.Cluster{})
+	if err == nil {
+		t.Errorf("expected an error appending to a string, but received none")
+	}
+}
+
 func TestSetCiliumFields(t *testing.T) {
 	grid := []struct {
 		Fields []string
@@ -21,7 +21,6 @@ import (
 	"strings"
 
 	api "k8s.io/kops/pkg/apis/kops"
-	"k8s.io/kops/util/pkg/reflectutils"
 )
 
 // SetInstancegroupFields sets field values in the instance group.
@@ -35,7 +34,7 @@ func SetInstancegroupFields(fields []string, instanceGroup *api.InstanceGroup) e
 		key := kv[0]
 		key = strings.TrimPrefix(key, "instancegroup.")
 
-		if err := reflectutils.SetString(instanceGroup, key, kv[1]); err != nil {
+		if err := setField(instanceGroup, key, kv[1]); err != nil {
 			return err
 		}
 	}
@@ -41,6 +41,22 @@ func TestSetInstanceGroupsFields(t *testing.T) {
 		Input  kops.InstanceGroup
 		Output kops.InstanceGroup
 	}{
+		{
+			Fields: []string{
+				"spec.nodeLabels.team=blue",
+				"spec.nodeLabels-=old",
+			},
+			Input: kops.InstanceGroup{
+				Spec: kops.InstanceGroupSpec{
+					NodeLabels: map[string]string{"old": "true"},
+				},
+			},
+			Output: kops.InstanceGroup{
+				Spec: kops.InstanceGroupSpec{
+					NodeLabels: map[string]string{"team": "blue"},
+				},
+			},
+		},
 		{
 			Fields: []string{
 				"spec.image=ami-test-2",
@@ -0,0 +1,115 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+// IsClusterStopped returns whether the cluster was stopped by "kops stop cluster" and not started since.
+func IsClusterStopped(cluster *kops.Cluster) bool {
+	_, found := cluster.ObjectMeta.Annotations[kops.AnnotationNameStopped]
+	return found
+}
+
+// CanStopInstanceGroup returns whether kOps controls the size of the instance group, and so can stop it.
+func CanStopInstanceGroup(ig *kops.InstanceGroup) bool {
+	return ig.Spec.Manager == "" || ig.Spec.Manager == kops.InstanceManagerCloudGroup
+}
+
+// StopCluster scales the instance groups of the cluster to zero, recording their sizes so StartCluster can restore them.
+// Instance groups whose size kOps doesn't control are left alone.
+func StopCluster(cluster *kops.Cluster, instanceGroups []*kops.InstanceGroup, now time.Time) error {
+	if IsClusterStopped(cluster) {
+		return fmt.Errorf("cluster %q is already stopped", cluster.ObjectMeta.Name)
+	}
+
+	for _, ig := range instanceGroups {
+		if !CanStopInstanceGroup(ig) {
+			continue
+		}
+
+		if ig.ObjectMeta.Annotations == nil {
+			ig.ObjectMeta.Annotations = make(map[string]string)
+		}
+		ig.ObjectMeta.Annotations[kops.AnnotationNameStoppedMinSize] = formatStoppedSize(ig.Spec.MinSize)
+		ig.ObjectMeta.Annotations[kops.AnnotationNameStoppedMaxSize] = formatStoppedSize(ig.Spec.MaxSize)
+		ig.Spec.MinSize = fi.Int32(0)
+		ig.Spec.MaxSize = fi.Int32(0)
+	}
+
+	if cluster.ObjectMeta.Annotations == nil {
+		cluster.ObjectMeta.Annotations = make(map[string]string)
+	}
+	cluster.ObjectMeta.Annotations[kops.AnnotationNameStopped] = now.UTC().Format(time.RFC3339)
+
+	return nil
+}
+
+// StartCluster restores the sizes the instance groups of the cluster had before it was stopped.
+func StartCluster(cluster *kops.Cluster, instanceGroups []*kops.InstanceGroup) error {
+	if !IsClusterStopped(cluster) {
+		return fmt.Errorf("cluster %q is not stopped", cluster.ObjectMeta.Name)
+	}
+
+	for _, ig := range instanceGroups {
+		minSize, foundMin := ig.ObjectMeta.Annotations[kops.AnnotationNameStoppedMinSize]
+		maxSize, foundMax := ig.ObjectMeta.Annotations[kops.AnnotationNameStoppedMaxSize]
+		if !foundMin || !foundMax {
+			// Created while the cluster was stopped, or not controlled by kOps
+			continue
+		}
+
+		var err error
+		if ig.Spec.MinSize, err = parseStoppedSize(minSize); err != nil {
+			return fmt.Errorf("invalid annotation %s on instance group %q: %v", kops.AnnotationNameStoppedMinSize, ig.ObjectMeta.Name, err)
+		}
+		if ig.Spec.MaxSize, err = parseStoppedSize(maxSize); err != nil {
+			return fmt.Errorf("invalid annotation %s on instance group %q: %v", kops.AnnotationNameStoppedMaxSize, ig.ObjectMeta.Name, err)
+		}
+		delete(ig.ObjectMeta.Annotations, kops.AnnotationNameStoppedMinSize)
+		delete(ig.ObjectMeta.Annotations, kops.AnnotationNameStoppedMaxSize)
+	}
+
+	delete(cluster.ObjectMeta.Annotations, kops.AnnotationNameStopped)
+
+	return nil
+}
+
+// formatStoppedSize records a size, using the empty string for the default size.
+func formatStoppedSize(size *int32) string {
+	if size == nil {
+		return ""
+	}
+	return strconv.FormatInt(int64(*size), 10)
+}
+
+func parseStoppedSize(s string) (*int32, error) {
+	if s == "" {
+		return nil, nil
+	}
+	size, err := strconv.ParseInt(s, 10, 32)
+	if err != nil {
+		return nil, err
+	}
+	return fi.Int32(int32(size)), nil
+}
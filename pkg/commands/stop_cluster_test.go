@@ -0,0 +1,99 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func TestStopStartCluster(t *testing.T) {
+	cluster := &kops.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "example.com"},
+	}
+	instanceGroups := []*kops.InstanceGroup{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "master-us-test-1a"},
+			Spec:       kops.InstanceGroupSpec{Role: kops.InstanceGroupRoleMaster, MinSize: fi.Int32(1), MaxSize: fi.Int32(1)},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "nodes"},
+			Spec:       kops.InstanceGroupSpec{Role: kops.InstanceGroupRoleNode, MinSize: fi.Int32(2), MaxSize: fi.Int32(5)},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "defaults"},
+			Spec:       kops.InstanceGroupSpec{Role: kops.InstanceGroupRoleNode},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "karpenter"},
+			Spec:       kops.InstanceGroupSpec{Role: kops.InstanceGroupRoleNode, Manager: kops.InstanceManagerKarpenter, MinSize: fi.Int32(3), MaxSize: fi.Int32(3)},
+		},
+	}
+
+	if err := StartCluster(cluster, instanceGroups); err == nil {
+		t.Errorf("expected error starting a cluster that isn't stopped")
+	}
+
+	if err := StopCluster(cluster, instanceGroups, time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("unexpected error stopping cluster: %v", err)
+	}
+	if !IsClusterStopped(cluster) {
+		t.Fatalf("expected cluster to be stopped")
+	}
+	if got := cluster.ObjectMeta.Annotations[kops.AnnotationNameStopped]; got != "2022-06-01T12:00:00Z" {
+		t.Errorf("unexpected %s annotation %q", kops.AnnotationNameStopped, got)
+	}
+	for _, ig := range instanceGroups[:3] {
+		if fi.Int32Value(ig.Spec.MinSize) != 0 || fi.Int32Value(ig.Spec.MaxSize) != 0 || ig.Spec.MaxSize == nil {
+			t.Errorf("expected instance group %q to be scaled to zero, got %v/%v", ig.ObjectMeta.Name, ig.Spec.MinSize, ig.Spec.MaxSize)
+		}
+	}
+	if fi.Int32Value(instanceGroups[3].Spec.MinSize) != 3 || len(instanceGroups[3].ObjectMeta.Annotations) != 0 {
+		t.Errorf("expected Karpenter-managed instance group to be left alone")
+	}
+
+	if err := StopCluster(cluster, instanceGroups, time.Now()); err == nil {
+		t.Errorf("expected error stopping a stopped cluster")
+	}
+
+	if err := StartCluster(cluster, instanceGroups); err != nil {
+		t.Fatalf("unexpected error starting cluster: %v", err)
+	}
+	if IsClusterStopped(cluster) {
+		t.Errorf("expected cluster to be started")
+	}
+	expected := map[string][2]*int32{
+		"master-us-test-1a": {fi.Int32(1), fi.Int32(1)},
+		"nodes":             {fi.Int32(2), fi.Int32(5)},
+		"defaults":          {nil, nil},
+		"karpenter":         {fi.Int32(3), fi.Int32(3)},
+	}
+	for _, ig := range instanceGroups {
+		sizes := expected[ig.ObjectMeta.Name]
+		if fi.Int32Value(ig.Spec.MinSize) != fi.Int32Value(sizes[0]) || (ig.Spec.MinSize == nil) != (sizes[0] == nil) ||
+			fi.Int32Value(ig.Spec.MaxSize) != fi.Int32Value(sizes[1]) || (ig.Spec.MaxSize == nil) != (sizes[1] == nil) {
+			t.Errorf("unexpected sizes for instance group %q after start: %v/%v", ig.ObjectMeta.Name, ig.Spec.MinSize, ig.Spec.MaxSize)
+		}
+		if _, found := ig.ObjectMeta.Annotations[kops.AnnotationNameStoppedMinSize]; found {
+			t.Errorf("expected annotations to be removed from instance group %q", ig.ObjectMeta.Name)
+		}
+	}
+}
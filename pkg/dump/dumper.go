@@ -59,6 +59,7 @@ func NewLogDumper(sshConfig *ssh.ClientConfig, artifactsDir string) *logDumper {
 		"node-problem-detector",
 		"kubelet",
 		"containerd",
+		"crio",
 		"docker",
 		"kops-configuration",
 		"protokube",
@@ -50,6 +50,8 @@ var (
 	// CacheNodeidentityInfo enables NodeidentityInfo caching
 	// in order to reduce the number of EC2 DescribeInstance calls.
 	CacheNodeidentityInfo = new("CacheNodeidentityInfo", Bool(false))
+	// KopsControllerMetrics serves the Prometheus metrics of kops-controller on the control plane nodes.
+	KopsControllerMetrics = new("KopsControllerMetrics", Bool(false))
 	// EnableSeparateConfigBase allows a config-base that is different from the state store
 	EnableSeparateConfigBase = new("EnableSeparateConfigBase", Bool(false))
 	// ExperimentalClusterDNS allows for setting the kubelet dns flag to experimental values.
@@ -92,6 +94,8 @@ var (
 	ImageDigest = new("ImageDigest", Bool(true))
 	// Hetzner toggles the Hetzner Cloud support.
 	Hetzner = new("Hetzner", Bool(false))
+	// SpecHashTags tags cloud resources with a hash of the spec they were last applied from.
+	SpecHashTags = new("SpecHashTags", Bool(false))
 )
 
 // FeatureFlag defines a feature flag
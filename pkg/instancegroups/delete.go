@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
 	api "k8s.io/kops/pkg/apis/kops"
@@ -32,13 +33,29 @@ type DeleteInstanceGroup struct {
 	Cluster   *api.Cluster
 	Cloud     fi.Cloud
 	Clientset simple.Clientset
+
+	// Drainer, if set, is used to drain the nodes of the group before its cloud resources are deleted.
+	Drainer *RollingUpdateCluster
+
+	// PreDeleteHook, if set, is called after draining and before the cloud resources are deleted.
+	// Deletion is aborted if it returns an error.
+	PreDeleteHook func(group *api.InstanceGroup) error
 }
 
 // DeleteInstanceGroup deletes a cloud instance group
 func (d *DeleteInstanceGroup) DeleteInstanceGroup(group *api.InstanceGroup) error {
 	ctx := context.TODO()
 
-	groups, err := d.Cloud.GetCloudGroups(d.Cluster, []*api.InstanceGroup{group}, false, nil)
+	var nodes []v1.Node
+	if d.Drainer != nil && !d.Drainer.CloudOnly {
+		nodeList, err := d.Drainer.K8sClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return fmt.Errorf("listing nodes in cluster: %v", err)
+		}
+		nodes = nodeList.Items
+	}
+
+	groups, err := d.Cloud.GetCloudGroups(d.Cluster, []*api.InstanceGroup{group}, false, nodes)
 	if err != nil {
 		return fmt.Errorf("error finding CloudInstanceGroups: %v", err)
 	}
@@ -49,7 +66,20 @@ func (d *DeleteInstanceGroup) DeleteInstanceGroup(group *api.InstanceGroup) erro
 		}
 	}
 
-	// TODO should we drain nodes and validate the cluster?
+	if d.Drainer != nil {
+		for _, g := range groups {
+			if err := d.Drainer.DrainInstanceGroup(g); err != nil {
+				return err
+			}
+		}
+	}
+
+	if d.PreDeleteHook != nil {
+		if err := d.PreDeleteHook(group); err != nil {
+			return fmt.Errorf("error running pre-delete hook for InstanceGroup %q: %v", group.ObjectMeta.Name, err)
+		}
+	}
+
 	for _, g := range groups {
 		klog.Infof("Deleting %q", group.ObjectMeta.Name)
 
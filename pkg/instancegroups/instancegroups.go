@@ -426,6 +426,7 @@ func (c *RollingUpdateCluster) drainTerminateAndWait(u *cloudinstances.CloudInst
 		klog.Errorf("error deleting instance %q, node %q: %v", instanceID, nodeName, err)
 		return err
 	}
+	c.reportInstanceProgress(ProgressEventTerminated, u)
 
 	if err := c.reconcileInstanceGroup(); err != nil {
 		klog.Errorf("error reconciling instance group %q: %v", u.CloudInstanceGroup.HumanName, err)
@@ -477,6 +478,11 @@ func (c *RollingUpdateCluster) maybeValidate(operation string, validateCount int
 			}
 
 			klog.Warningf("Cluster validation failed%s, proceeding since fail-on-validate is set to false: %v", operation, err)
+		} else {
+			c.reportProgress(ProgressEvent{
+				Type:          ProgressEventValidated,
+				InstanceGroup: group.InstanceGroup.Name,
+			})
 		}
 	}
 	return nil
@@ -617,15 +623,31 @@ func (c *RollingUpdateCluster) drainNode(u *cloudinstances.CloudInstance) error
 		return fmt.Errorf("node name not set")
 	}
 
+	timeout := c.DrainTimeout
+	pdbViolationPolicy := api.PDBViolationPolicyFail
+	if u.CloudInstanceGroup != nil && u.CloudInstanceGroup.InstanceGroup != nil && c.Cluster != nil {
+		settings := resolveSettings(c.Cluster, u.CloudInstanceGroup.InstanceGroup, 0)
+		if settings.DrainTimeout != nil {
+			timeout = settings.DrainTimeout.Duration
+		}
+		pdbViolationPolicy = settings.PDBViolationPolicy
+	}
+
+	// Keep the standard output for the progress events
+	out := os.Stdout
+	if c.Progress != nil {
+		out = os.Stderr
+	}
+
 	helper := &drain.Helper{
 		Ctx:                 c.Ctx,
 		Client:              c.K8sClient,
 		Force:               true,
 		GracePeriodSeconds:  -1,
 		IgnoreAllDaemonSets: true,
-		Out:                 os.Stdout,
+		Out:                 out,
 		ErrOut:              os.Stderr,
-		Timeout:             c.DrainTimeout,
+		Timeout:             timeout,
 
 		// We want to proceed even when pods are using emptyDir volumes
 		DeleteEmptyDirData: true,
@@ -637,6 +659,7 @@ func (c *RollingUpdateCluster) drainNode(u *cloudinstances.CloudInstance) error
 		}
 		return fmt.Errorf("error cordoning node: %v", err)
 	}
+	c.reportInstanceProgress(ProgressEventCordoned, u)
 
 	if err := c.patchExcludeFromLB(u.Node); err != nil {
 		if apierrors.IsNotFound(err) {
@@ -653,8 +676,25 @@ func (c *RollingUpdateCluster) drainNode(u *cloudinstances.CloudInstance) error
 		if apierrors.IsNotFound(err) {
 			return nil
 		}
-		return fmt.Errorf("error draining node: %v", err)
+		if pdbViolationPolicy != api.PDBViolationPolicyDelete {
+			return fmt.Errorf("error draining node: %v", err)
+		}
+
+		// Evictions that PodDisruptionBudgets keep blocking are retried until the timeout;
+		// delete the remaining pods instead, and record which ones were deleted
+		klog.Warningf("Draining node %q failed, deleting its remaining pods regardless of their PodDisruptionBudgets: %v", u.Node.Name, err)
+		helper.DisableEviction = true
+		helper.OnPodDeletedOrEvicted = func(pod *corev1.Pod, usingEviction bool) {
+			klog.Warningf("Force-deleted pod %s/%s of node %q, bypassing its PodDisruptionBudgets", pod.Namespace, pod.Name, u.Node.Name)
+		}
+		if err := drain.RunNodeDrain(helper, u.Node.Name); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return fmt.Errorf("error deleting the pods of node: %v", err)
+		}
 	}
+	c.reportInstanceProgress(ProgressEventDrained, u)
 
 	if c.PostDrainDelay > 0 {
 		klog.Infof("Waiting for %s for pods to stabilize after draining.", c.PostDrainDelay)
@@ -697,3 +737,32 @@ func (c *RollingUpdateCluster) UpdateSingleInstance(cloudMember *cloudinstances.
 
 	return c.drainTerminateAndWait(cloudMember, 0)
 }
+
+// DrainInstanceGroup cordons and drains the nodes of all instances in a cloud group, without terminating them.
+func (c *RollingUpdateCluster) DrainInstanceGroup(group *cloudinstances.CloudInstanceGroup) error {
+	if group.InstanceGroup.IsBastion() {
+		return nil
+	}
+	if c.CloudOnly {
+		klog.Warning("Not draining cluster nodes as 'cloudonly' flag is set.")
+		return nil
+	}
+
+	members := append(append([]*cloudinstances.CloudInstance{}, group.NeedUpdate...), group.Ready...)
+	for _, u := range members {
+		if u.Node == nil {
+			klog.Warningf("Skipping drain of instance %q, because it is not registered in kubernetes", u.ID)
+			continue
+		}
+
+		klog.Infof("Draining the node: %q.", u.Node.Name)
+		if err := c.drainNode(u); err != nil {
+			if c.FailOnDrainError {
+				return fmt.Errorf("failed to drain node %q: %v", u.Node.Name, err)
+			}
+			klog.Infof("Ignoring error draining node %q: %v", u.Node.Name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroups
+
+import (
+	"encoding/json"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"k8s.io/kops/pkg/cloudinstances"
+)
+
+// ProgressEventType is the step of a rolling update that a ProgressEvent reports.
+type ProgressEventType string
+
+const (
+	// ProgressEventCordoned is reported when the node of an instance is cordoned
+	ProgressEventCordoned ProgressEventType = "Cordoned"
+	// ProgressEventDrained is reported when the node of an instance is drained
+	ProgressEventDrained ProgressEventType = "Drained"
+	// ProgressEventTerminated is reported when an instance is terminated
+	ProgressEventTerminated ProgressEventType = "Terminated"
+	// ProgressEventValidated is reported when the cluster validates during the update of an instance group
+	ProgressEventValidated ProgressEventType = "Validated"
+)
+
+// ProgressEvent is a machine-readable record of the progress of a rolling update.
+type ProgressEvent struct {
+	// Time is when the step completed
+	Time time.Time `json:"time"`
+	// Type is the step that completed
+	Type ProgressEventType `json:"type"`
+	// InstanceGroup is the name of the instance group being updated
+	InstanceGroup string `json:"instanceGroup,omitempty"`
+	// Instance is the ID of the cloud instance, for the steps concerning a single instance
+	Instance string `json:"instance,omitempty"`
+	// Node is the name of the node of the instance, if it is registered in kubernetes
+	Node string `json:"node,omitempty"`
+}
+
+// reportInstanceProgress reports that a step of the update of an instance completed
+func (c *RollingUpdateCluster) reportInstanceProgress(eventType ProgressEventType, u *cloudinstances.CloudInstance) {
+	event := ProgressEvent{
+		Type:     eventType,
+		Instance: u.ID,
+	}
+	if u.CloudInstanceGroup != nil && u.CloudInstanceGroup.InstanceGroup != nil {
+		event.InstanceGroup = u.CloudInstanceGroup.InstanceGroup.Name
+	}
+	if u.Node != nil {
+		event.Node = u.Node.Name
+	}
+	c.reportProgress(event)
+}
+
+// reportProgress writes a progress event as a line of JSON, if progress is being reported
+func (c *RollingUpdateCluster) reportProgress(event ProgressEvent) {
+	if c.Progress == nil {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		klog.Warningf("error encoding progress event: %v", err)
+		return
+	}
+
+	// Instances of a group may be updated concurrently
+	c.progressMutex.Lock()
+	defer c.progressMutex.Unlock()
+	if _, err := c.Progress.Write(append(data, '\n')); err != nil {
+		klog.Warningf("error writing progress event: %v", err)
+	}
+}
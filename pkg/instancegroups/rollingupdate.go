@@ -19,6 +19,7 @@ package instancegroups
 import (
 	"context"
 	"fmt"
+	"io"
 	"sort"
 	"sync"
 	"time"
@@ -80,6 +81,14 @@ type RollingUpdateCluster struct {
 
 	// DrainTimeout is the maximum amount of time to wait while draining a node.
 	DrainTimeout time.Duration
+
+	// Strategy is the way the instances of the node instance groups are replaced; defaults to rolling.
+	Strategy RollingUpdateStrategy
+
+	// Progress, if set, receives a ProgressEvent as a line of JSON after each step of the update
+	Progress io.Writer
+	// progressMutex serializes the writes to Progress
+	progressMutex sync.Mutex
 }
 
 // AdjustNeedUpdate adjusts the set of instances that need updating, using factors outside those known by the cloud implementation
@@ -193,7 +202,12 @@ func (c *RollingUpdateCluster) RollingUpdate(groups map[string]*cloudinstances.C
 		}
 
 		for _, k := range sortGroups(nodeGroups) {
-			err := c.rollingUpdateInstanceGroup(nodeGroups[k], c.NodeInterval)
+			var err error
+			if c.Strategy == RollingUpdateStrategySurge {
+				err = c.surgeUpdateInstanceGroup(nodeGroups[k], c.NodeInterval)
+			} else {
+				err = c.rollingUpdateInstanceGroup(nodeGroups[k], c.NodeInterval)
+			}
 
 			results[k] = err
 
@@ -0,0 +1,68 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroups
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/cloudinstances"
+)
+
+func TestRollingUpdateProgress(t *testing.T) {
+	c, cloud := getTestSetup()
+	var progress bytes.Buffer
+	c.Progress = &progress
+
+	groups := make(map[string]*cloudinstances.CloudInstanceGroup)
+	makeGroup(groups, c.K8sClient, cloud, "node-1", kopsapi.InstanceGroupRoleNode, 2, 1)
+
+	err := c.RollingUpdate(groups, &kopsapi.InstanceGroupList{})
+	assert.NoError(t, err, "rolling update")
+
+	var events []ProgressEvent
+	scanner := bufio.NewScanner(&progress)
+	for scanner.Scan() {
+		var event ProgressEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("error parsing progress event %q: %v", scanner.Text(), err)
+		}
+		assert.False(t, event.Time.IsZero(), "time of event %q", scanner.Text())
+		events = append(events, event)
+	}
+
+	var types []ProgressEventType
+	for _, event := range events {
+		types = append(types, event.Type)
+		assert.Equal(t, "node-1", event.InstanceGroup, "instance group")
+		if event.Type != ProgressEventValidated {
+			assert.Equal(t, "node-1a", event.Instance, "instance")
+			assert.Equal(t, "node-1a.local", event.Node, "node")
+		}
+	}
+	assert.Equal(t, []ProgressEventType{
+		ProgressEventValidated,
+		ProgressEventCordoned,
+		ProgressEventDrained,
+		ProgressEventTerminated,
+		ProgressEventValidated,
+	}, types, "progress events")
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroups
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/autoscaling/autoscalingiface"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	v1meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	testingclient "k8s.io/client-go/testing"
+	"k8s.io/kops/cloudmock/aws/mockautoscaling"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/cloudinstances"
+)
+
+// surgeTestAutoscaling launches the instances of the autoscaling groups it creates, and registers their nodes
+type surgeTestAutoscaling struct {
+	autoscalingiface.AutoScalingAPI
+	k8sClient *fake.Clientset
+	launch    bool
+	created   []*autoscaling.CreateAutoScalingGroupInput
+}
+
+func (m *surgeTestAutoscaling) CreateAutoScalingGroup(input *autoscaling.CreateAutoScalingGroupInput) (*autoscaling.CreateAutoScalingGroupOutput, error) {
+	output, err := m.AutoScalingAPI.CreateAutoScalingGroup(input)
+	if err != nil {
+		return nil, err
+	}
+	m.created = append(m.created, input)
+
+	if m.launch {
+		groups, err := m.AutoScalingAPI.DescribeAutoScalingGroups(&autoscaling.DescribeAutoScalingGroupsInput{
+			AutoScalingGroupNames: []*string{input.AutoScalingGroupName},
+		})
+		if err != nil {
+			return nil, err
+		}
+		group := groups.AutoScalingGroups[0]
+		for i := 0; i < int(aws.Int64Value(input.DesiredCapacity)); i++ {
+			id := "surge" + string(rune('a'+i))
+			group.Instances = append(group.Instances, &autoscaling.Instance{
+				InstanceId:     aws.String(id),
+				LifecycleState: aws.String(autoscaling.LifecycleStateInService),
+			})
+			_ = m.k8sClient.Tracker().Add(&v1.Node{
+				ObjectMeta: v1meta.ObjectMeta{Name: id + ".local"},
+				Spec:       v1.NodeSpec{ProviderID: "aws:///us-east-1a/" + id},
+			})
+		}
+	}
+
+	return output, nil
+}
+
+func TestRollingUpdateSurgeStrategy(t *testing.T) {
+	c, cloud := getTestSetup()
+	c.Strategy = RollingUpdateStrategySurge
+
+	mockAutoscaling := cloud.MockAutoscaling.(*mockautoscaling.MockAutoscaling)
+	groups := make(map[string]*cloudinstances.CloudInstanceGroup)
+	makeGroup(groups, c.K8sClient, cloud, "node-1", kopsapi.InstanceGroupRoleNode, 3, 2)
+
+	surgeAutoscaling := &surgeTestAutoscaling{
+		AutoScalingAPI: cloud.MockAutoscaling,
+		k8sClient:      c.K8sClient.(*fake.Clientset),
+		launch:         true,
+	}
+	cloud.MockAutoscaling = surgeAutoscaling
+	mockAutoscaling.Groups["node-1"].Tags = []*autoscaling.TagDescription{
+		{Key: aws.String("KubernetesCluster"), Value: aws.String("test.k8s.local"), PropagateAtLaunch: aws.Bool(true)},
+		{Key: aws.String("k8s.io/cluster-autoscaler/enabled"), Value: aws.String("1"), PropagateAtLaunch: aws.Bool(false)},
+	}
+	_, err := mockAutoscaling.PutLifecycleHook(&autoscaling.PutLifecycleHookInput{
+		AutoScalingGroupName: aws.String("node-1"),
+		LifecycleHookName:    aws.String("kops-nth-lifecycle-hook"),
+		LifecycleTransition:  aws.String("autoscaling:EC2_INSTANCE_TERMINATING"),
+		DefaultResult:        aws.String("CONTINUE"),
+		HeartbeatTimeout:     aws.Int64(300),
+	})
+	assert.NoError(t, err, "creating lifecycle hook")
+
+	err = c.RollingUpdate(groups, &kopsapi.InstanceGroupList{})
+	assert.NoError(t, err, "rolling update")
+
+	if assert.Len(t, surgeAutoscaling.created, 1, "created autoscaling groups") {
+		created := surgeAutoscaling.created[0]
+		assert.Equal(t, "node-1-surge", aws.StringValue(created.AutoScalingGroupName))
+		assert.Equal(t, int64(2), aws.Int64Value(created.DesiredCapacity))
+		if assert.Len(t, created.Tags, 1, "tags of the surge group") {
+			assert.Equal(t, "KubernetesCluster", aws.StringValue(created.Tags[0].Key))
+		}
+		if assert.Len(t, created.LifecycleHookSpecificationList, 1, "lifecycle hooks of the surge group") {
+			hook := created.LifecycleHookSpecificationList[0]
+			assert.Equal(t, "kops-nth-lifecycle-hook", aws.StringValue(hook.LifecycleHookName))
+			assert.Equal(t, "autoscaling:EC2_INSTANCE_TERMINATING", aws.StringValue(hook.LifecycleTransition))
+			assert.Equal(t, int64(300), aws.Int64Value(hook.HeartbeatTimeout))
+		}
+	}
+	assert.NotContains(t, mockAutoscaling.Groups, "node-1-surge", "surge group is deleted")
+	assertGroupInstanceCount(t, cloud, "node-1", 1)
+
+	cordoned := map[string]bool{}
+	for _, action := range c.K8sClient.(*fake.Clientset).Actions() {
+		if patch, ok := action.(testingclient.PatchAction); ok && string(patch.GetPatch()) == cordonPatch {
+			cordoned[patch.GetName()] = true
+		}
+	}
+	assert.Equal(t, map[string]bool{
+		"node-1a.local": true,
+		"node-1b.local": true,
+		"surgea.local":  true,
+		"surgeb.local":  true,
+	}, cordoned, "cordoned nodes")
+}
+
+func TestRollingUpdateSurgeStrategyNoCapacity(t *testing.T) {
+	c, cloud := getTestSetup()
+	c.Strategy = RollingUpdateStrategySurge
+	c.ValidationTimeout = 10 * time.Millisecond
+
+	groups := make(map[string]*cloudinstances.CloudInstanceGroup)
+	makeGroup(groups, c.K8sClient, cloud, "node-1", kopsapi.InstanceGroupRoleNode, 3, 3)
+
+	cloud.MockAutoscaling = &surgeTestAutoscaling{
+		AutoScalingAPI: cloud.MockAutoscaling,
+		k8sClient:      c.K8sClient.(*fake.Clientset),
+	}
+
+	err := c.RollingUpdate(groups, &kopsapi.InstanceGroupList{})
+	assert.Error(t, err, "rolling update")
+
+	assertGroupInstanceCount(t, cloud, "node-1", 3)
+}
+
+func TestRollingUpdateSurgeStrategyMasters(t *testing.T) {
+	c, cloud := getTestSetup()
+	c.Strategy = RollingUpdateStrategySurge
+
+	groups := make(map[string]*cloudinstances.CloudInstanceGroup)
+	makeGroup(groups, c.K8sClient, cloud, "master-1", kopsapi.InstanceGroupRoleMaster, 2, 2)
+
+	surgeAutoscaling := &surgeTestAutoscaling{
+		AutoScalingAPI: cloud.MockAutoscaling,
+		k8sClient:      c.K8sClient.(*fake.Clientset),
+	}
+	cloud.MockAutoscaling = surgeAutoscaling
+
+	err := c.RollingUpdate(groups, &kopsapi.InstanceGroupList{})
+	assert.NoError(t, err, "rolling update")
+
+	assert.Empty(t, surgeAutoscaling.created, "created autoscaling groups")
+	assertGroupInstanceCount(t, cloud, "master-1", 0)
+}
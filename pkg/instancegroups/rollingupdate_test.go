@@ -32,7 +32,9 @@ import (
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	v1meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
@@ -1493,6 +1495,96 @@ func TestRollingUpdateMaxSurgeAllNeedUpdateMaxAlreadyDetached(t *testing.T) {
 	concurrentTest.AssertComplete()
 }
 
+func TestDrainInstanceGroup(t *testing.T) {
+	c, cloud := getTestSetup()
+
+	groups := make(map[string]*cloudinstances.CloudInstanceGroup)
+	makeGroup(groups, c.K8sClient, cloud, "node-1", kopsapi.InstanceGroupRoleNode, 3, 1)
+	err := c.DrainInstanceGroup(groups["node-1"])
+	assert.NoError(t, err, "drain instance group")
+
+	cordoned := map[string]bool{}
+	for _, action := range c.K8sClient.(*fake.Clientset).Actions() {
+		switch a := action.(type) {
+		case testingclient.PatchAction:
+			if string(a.GetPatch()) == cordonPatch {
+				assertCordon(t, a)
+				cordoned[a.GetName()] = true
+			} else {
+				assertExclude(t, a)
+			}
+		case testingclient.ListAction:
+			// Don't care
+		default:
+			t.Errorf("unexpected action %v", a)
+		}
+	}
+	assert.Equal(t, map[string]bool{"node-1a.local": true, "node-1b.local": true, "node-1c.local": true}, cordoned)
+
+	asgGroups, _ := cloud.Autoscaling().DescribeAutoScalingGroups(&autoscaling.DescribeAutoScalingGroupsInput{})
+	for _, group := range asgGroups.AutoScalingGroups {
+		assert.Len(t, group.Instances, 3, "instances were terminated in group %s", aws.StringValue(group.AutoScalingGroupName))
+	}
+}
+
+func TestDrainInstanceGroupCloudonly(t *testing.T) {
+	c, cloud := getTestSetup()
+	c.CloudOnly = true
+
+	groups := make(map[string]*cloudinstances.CloudInstanceGroup)
+	makeGroup(groups, c.K8sClient, cloud, "node-1", kopsapi.InstanceGroupRoleNode, 3, 0)
+	err := c.DrainInstanceGroup(groups["node-1"])
+	assert.NoError(t, err, "drain instance group")
+
+	assert.Empty(t, c.K8sClient.(*fake.Clientset).Actions())
+}
+
+func TestDrainNodePDBViolationPolicy(t *testing.T) {
+	for _, policy := range []kopsapi.PDBViolationPolicy{"", kopsapi.PDBViolationPolicyFail, kopsapi.PDBViolationPolicyDelete} {
+		t.Run(string(policy), func(t *testing.T) {
+			c, cloud := getTestSetup()
+
+			groups := make(map[string]*cloudinstances.CloudInstanceGroup)
+			makeGroup(groups, c.K8sClient, cloud, "node-1", kopsapi.InstanceGroupRoleNode, 1, 1)
+			group := groups["node-1"]
+			group.InstanceGroup.Spec.RollingUpdate = &kopsapi.RollingUpdate{
+				DrainTimeout:       &v1meta.Duration{Duration: time.Minute},
+				PDBViolationPolicy: policy,
+			}
+
+			fakeClient := c.K8sClient.(*fake.Clientset)
+			_ = fakeClient.Tracker().Add(&v1.Pod{
+				ObjectMeta: v1meta.ObjectMeta{Name: "guarded", Namespace: "default"},
+				Spec:       v1.PodSpec{NodeName: "node-1a.local"},
+			})
+			fakeClient.Resources = []*v1meta.APIResourceList{
+				{
+					GroupVersion: "v1",
+					APIResources: []v1meta.APIResource{
+						{Name: "pods/eviction", Kind: "Eviction", Group: "policy", Version: "v1"},
+					},
+				},
+			}
+			fakeClient.PrependReactor("create", "pods", func(action testingclient.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() != "eviction" {
+					return false, nil, nil
+				}
+				return true, nil, errors.New("cannot evict pod as it would violate the pod's disruption budget")
+			})
+
+			err := c.drainNode(group.NeedUpdate[0])
+			_, getErr := fakeClient.CoreV1().Pods("default").Get(c.Ctx, "guarded", v1meta.GetOptions{})
+			if policy == kopsapi.PDBViolationPolicyDelete {
+				assert.NoError(t, err, "drain node")
+				assert.True(t, apierrors.IsNotFound(getErr), "pod is deleted")
+			} else {
+				assert.Error(t, err, "drain node")
+				assert.NoError(t, getErr, "pod is not deleted")
+			}
+		})
+	}
+}
+
 func assertCordon(t *testing.T, action testingclient.PatchAction) {
 	assert.Equal(t, "nodes", action.GetResource().Resource)
 	assert.Equal(t, cordonPatch, string(action.GetPatch()))
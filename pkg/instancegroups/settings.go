@@ -40,12 +40,22 @@ func resolveSettings(cluster *kops.Cluster, group *kops.InstanceGroup, numInstan
 		if rollingUpdate.MaxSurge == nil {
 			rollingUpdate.MaxSurge = def.MaxSurge
 		}
+		if rollingUpdate.DrainTimeout == nil {
+			rollingUpdate.DrainTimeout = def.DrainTimeout
+		}
+		if rollingUpdate.PDBViolationPolicy == "" {
+			rollingUpdate.PDBViolationPolicy = def.PDBViolationPolicy
+		}
 	}
 
 	if rollingUpdate.DrainAndTerminate == nil {
 		rollingUpdate.DrainAndTerminate = fi.Bool(true)
 	}
 
+	if rollingUpdate.PDBViolationPolicy == "" {
+		rollingUpdate.PDBViolationPolicy = kops.PDBViolationPolicyFail
+	}
+
 	if rollingUpdate.MaxSurge == nil {
 		val := intstr.FromInt(0)
 		if cluster.Spec.GetCloudProvider() == kops.CloudProviderAWS && !featureflag.Spotinst.Enabled() && group.Spec.Manager != kops.InstanceManagerKarpenter {
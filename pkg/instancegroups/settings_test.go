@@ -20,8 +20,10 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/kops/pkg/apis/kops"
 )
@@ -223,3 +225,28 @@ func TestAWSDefault(t *testing.T) {
 	assert.Equal(t, intstr.Int, resolved.MaxUnavailable.Type)
 	assert.Equal(t, int32(0), resolved.MaxUnavailable.IntVal)
 }
+
+func TestDrainSettings(t *testing.T) {
+	cluster := &kops.Cluster{}
+	group := &kops.InstanceGroup{}
+
+	resolved := resolveSettings(cluster, group, 1)
+	assert.Nil(t, resolved.DrainTimeout, "drainTimeout")
+	assert.Equal(t, kops.PDBViolationPolicyFail, resolved.PDBViolationPolicy, "pdbViolationPolicy")
+
+	cluster.Spec.RollingUpdate = &kops.RollingUpdate{
+		DrainTimeout:       &metav1.Duration{Duration: 5 * time.Minute},
+		PDBViolationPolicy: kops.PDBViolationPolicyDelete,
+	}
+	resolved = resolveSettings(cluster, group, 1)
+	assert.Equal(t, &metav1.Duration{Duration: 5 * time.Minute}, resolved.DrainTimeout, "drainTimeout")
+	assert.Equal(t, kops.PDBViolationPolicyDelete, resolved.PDBViolationPolicy, "pdbViolationPolicy")
+
+	group.Spec.RollingUpdate = &kops.RollingUpdate{
+		DrainTimeout:       &metav1.Duration{Duration: time.Minute},
+		PDBViolationPolicy: kops.PDBViolationPolicyFail,
+	}
+	resolved = resolveSettings(cluster, group, 1)
+	assert.Equal(t, &metav1.Duration{Duration: time.Minute}, resolved.DrainTimeout, "drainTimeout")
+	assert.Equal(t, kops.PDBViolationPolicyFail, resolved.PDBViolationPolicy, "pdbViolationPolicy")
+}
@@ -0,0 +1,299 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroups
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	"k8s.io/kops/pkg/cloudinstances"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+// RollingUpdateStrategy is the way the instances of the node instance groups are replaced.
+type RollingUpdateStrategy string
+
+const (
+	// RollingUpdateStrategyRolling replaces the instances a few at a time, within their group.
+	RollingUpdateStrategyRolling RollingUpdateStrategy = "rolling"
+	// RollingUpdateStrategySurge launches the replacements of all the instances in a temporary duplicate
+	// of their autoscaling group, before draining the old instances.
+	RollingUpdateStrategySurge RollingUpdateStrategy = "surge"
+)
+
+// surgeGroupSuffix is appended to the name of the autoscaling group to name its temporary duplicate
+const surgeGroupSuffix = "-surge"
+
+// surgeUpdateInstanceGroup replaces the instances of an autoscaling group without losing capacity.
+// It launches as many instances as need updating in a temporary duplicate of the group, then drains and terminates
+// the old instances, which the group replaces with up-to-date instances, then drains and deletes the duplicate group.
+func (c *RollingUpdateCluster) surgeUpdateInstanceGroup(group *cloudinstances.CloudInstanceGroup, sleepAfterTerminate time.Duration) error {
+	awsCloud, ok := c.Cloud.(awsup.AWSCloud)
+	_, isASG := group.Raw.(*autoscaling.Group)
+	if !ok || !isASG {
+		klog.Warningf("Surge updates are only supported for AWS autoscaling groups; rolling instance group %q instead", group.InstanceGroup.Name)
+		return c.rollingUpdateInstanceGroup(group, sleepAfterTerminate)
+	}
+
+	// Do not need a k8s client if you are doing cloudonly.
+	if c.K8sClient == nil && !c.CloudOnly {
+		return fmt.Errorf("rollingUpdate is missing a k8s client")
+	}
+
+	update := group.NeedUpdate
+	if c.Force {
+		update = append(update, group.Ready...)
+	}
+
+	var nonWarmPool []*cloudinstances.CloudInstance
+	for _, instance := range update {
+		if instance.State == cloudinstances.WarmPool {
+			klog.Infof("deleting warm pool instance %q", instance.ID)
+			if err := c.Cloud.DeleteInstance(instance); err != nil {
+				return fmt.Errorf("failed to delete warm pool instance %q: %w", instance.ID, err)
+			}
+		} else {
+			nonWarmPool = append(nonWarmPool, instance)
+		}
+	}
+	update = nonWarmPool
+
+	if len(update) == 0 {
+		return nil
+	}
+
+	settings := resolveSettings(c.Cluster, group.InstanceGroup, len(group.Ready)+len(group.NeedUpdate))
+	if !*settings.DrainAndTerminate {
+		klog.Infof("Rolling updates for InstanceGroup %s are disabled", group.InstanceGroup.Name)
+		return nil
+	}
+
+	if err := c.maybeValidate("", 1, group); err != nil {
+		return err
+	}
+
+	if !c.CloudOnly {
+		if err := c.taintAllNeedUpdate(group, update); err != nil {
+			return err
+		}
+	}
+
+	surgeName := group.HumanName + surgeGroupSuffix
+	if err := createSurgeGroup(awsCloud, group.HumanName, surgeName, len(update)); err != nil {
+		return err
+	}
+
+	klog.Infof("Waiting for %d instances in autoscaling group %q.", len(update), surgeName)
+	surge, err := c.waitForSurgeCapacity(awsCloud, surgeName, len(update))
+	if err != nil {
+		return err
+	}
+	if err := c.maybeValidate(" after launching surge instances", c.ValidateCount, group); err != nil {
+		return err
+	}
+
+	for _, u := range update {
+		if err := c.drainTerminateAndWait(u, 0); err != nil {
+			return err
+		}
+	}
+	klog.Infof("waiting for %v after terminating instances", sleepAfterTerminate)
+	time.Sleep(sleepAfterTerminate)
+	if err := c.maybeValidate(" after terminating instances", c.ValidateCount, group); err != nil {
+		return err
+	}
+
+	surgeGroup, err := c.surgeCloudInstanceGroup(group, surge)
+	if err != nil {
+		return err
+	}
+	if err := c.DrainInstanceGroup(surgeGroup); err != nil {
+		return err
+	}
+
+	klog.Infof("Deleting autoscaling group %q.", surgeName)
+	if _, err := awsCloud.Autoscaling().DeleteAutoScalingGroup(&autoscaling.DeleteAutoScalingGroupInput{
+		AutoScalingGroupName: aws.String(surgeName),
+		ForceDelete:          aws.Bool(true),
+	}); err != nil {
+		return fmt.Errorf("error deleting autoscaling group %q: %v", surgeName, err)
+	}
+
+	return c.maybeValidate(" after deleting surge instances", c.ValidateCount, group)
+}
+
+// createSurgeGroup creates a duplicate of an autoscaling group, with the given number of instances.
+// An existing duplicate, left by an interrupted update, is reused.
+func createSurgeGroup(cloud awsup.AWSCloud, name string, surgeName string, size int) error {
+	existing, err := describeAutoScalingGroup(cloud, surgeName)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		klog.Infof("Reusing autoscaling group %q.", surgeName)
+		return nil
+	}
+
+	asg, err := describeAutoScalingGroup(cloud, name)
+	if err != nil {
+		return err
+	}
+	if asg == nil {
+		return fmt.Errorf("autoscaling group %q not found", name)
+	}
+
+	request := &autoscaling.CreateAutoScalingGroupInput{
+		AutoScalingGroupName:    aws.String(surgeName),
+		DesiredCapacity:         aws.Int64(int64(size)),
+		MinSize:                 aws.Int64(int64(size)),
+		MaxSize:                 aws.Int64(int64(size)),
+		LaunchConfigurationName: asg.LaunchConfigurationName,
+		MixedInstancesPolicy:    asg.MixedInstancesPolicy,
+		VPCZoneIdentifier:       asg.VPCZoneIdentifier,
+		LoadBalancerNames:       asg.LoadBalancerNames,
+		TargetGroupARNs:         asg.TargetGroupARNs,
+		HealthCheckType:         asg.HealthCheckType,
+		HealthCheckGracePeriod:  asg.HealthCheckGracePeriod,
+	}
+	if asg.LaunchTemplate != nil {
+		request.LaunchTemplate = &autoscaling.LaunchTemplateSpecification{
+			LaunchTemplateId: asg.LaunchTemplate.LaunchTemplateId,
+			Version:          asg.LaunchTemplate.Version,
+		}
+	}
+	for _, tag := range asg.Tags {
+		// The cluster autoscaler must not scale the temporary group
+		if strings.HasPrefix(aws.StringValue(tag.Key), "k8s.io/cluster-autoscaler/") {
+			continue
+		}
+		request.Tags = append(request.Tags, &autoscaling.Tag{
+			Key:               tag.Key,
+			Value:             tag.Value,
+			PropagateAtLaunch: tag.PropagateAtLaunch,
+			ResourceId:        aws.String(surgeName),
+			ResourceType:      tag.ResourceType,
+		})
+	}
+
+	// Lifecycle hooks, such as those of the node termination handler, must also apply to the surge instances
+	hooks, err := cloud.Autoscaling().DescribeLifecycleHooks(&autoscaling.DescribeLifecycleHooksInput{
+		AutoScalingGroupName: aws.String(name),
+	})
+	if err != nil {
+		return fmt.Errorf("error describing lifecycle hooks of autoscaling group %q: %v", name, err)
+	}
+	for _, hook := range hooks.LifecycleHooks {
+		request.LifecycleHookSpecificationList = append(request.LifecycleHookSpecificationList, &autoscaling.LifecycleHookSpecification{
+			LifecycleHookName:     hook.LifecycleHookName,
+			LifecycleTransition:   hook.LifecycleTransition,
+			DefaultResult:         hook.DefaultResult,
+			HeartbeatTimeout:      hook.HeartbeatTimeout,
+			NotificationMetadata:  hook.NotificationMetadata,
+			NotificationTargetARN: hook.NotificationTargetARN,
+			RoleARN:               hook.RoleARN,
+		})
+	}
+
+	klog.Infof("Creating autoscaling group %q with %d instances.", surgeName, size)
+	if _, err := cloud.Autoscaling().CreateAutoScalingGroup(request); err != nil {
+		return fmt.Errorf("error creating autoscaling group %q: %v", surgeName, err)
+	}
+	return nil
+}
+
+// waitForSurgeCapacity waits until the given number of instances of the autoscaling group are in service
+func (c *RollingUpdateCluster) waitForSurgeCapacity(cloud awsup.AWSCloud, name string, size int) (*autoscaling.Group, error) {
+	ctx, cancel := context.WithTimeout(c.Ctx, c.ValidationTimeout)
+	defer cancel()
+
+	for {
+		asg, err := describeAutoScalingGroup(cloud, name)
+		if err != nil {
+			return nil, err
+		}
+		if asg == nil {
+			return nil, fmt.Errorf("autoscaling group %q not found", name)
+		}
+
+		inService := 0
+		for _, instance := range asg.Instances {
+			if aws.StringValue(instance.LifecycleState) == autoscaling.LifecycleStateInService {
+				inService++
+			}
+		}
+		if inService >= size {
+			return asg, nil
+		}
+
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("autoscaling group %q has %d of %d instances in service after %s", name, inService, size, c.ValidationTimeout)
+		}
+		klog.Infof("Autoscaling group %q has %d of %d instances in service, will retry in %q.", name, inService, size, c.ValidateTickDuration)
+		time.Sleep(c.ValidateTickDuration)
+	}
+}
+
+// surgeCloudInstanceGroup returns the instances of the duplicate autoscaling group, with their nodes
+func (c *RollingUpdateCluster) surgeCloudInstanceGroup(group *cloudinstances.CloudInstanceGroup, surge *autoscaling.Group) (*cloudinstances.CloudInstanceGroup, error) {
+	surgeGroup := &cloudinstances.CloudInstanceGroup{
+		HumanName:     aws.StringValue(surge.AutoScalingGroupName),
+		InstanceGroup: group.InstanceGroup,
+		Raw:           surge,
+	}
+
+	nodes := make(map[string]*corev1.Node)
+	if !c.CloudOnly {
+		nodeList, err := c.K8sClient.CoreV1().Nodes().List(c.Ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("error listing nodes: %v", err)
+		}
+		for i := range nodeList.Items {
+			node := &nodeList.Items[i]
+			providerID := node.Spec.ProviderID
+			nodes[providerID[strings.LastIndex(providerID, "/")+1:]] = node
+		}
+	}
+
+	for _, instance := range surge.Instances {
+		id := aws.StringValue(instance.InstanceId)
+		surgeGroup.NewCloudInstance(id, cloudinstances.CloudInstanceStatusUpToDate, nodes[id])
+	}
+	return surgeGroup, nil
+}
+
+func describeAutoScalingGroup(cloud awsup.AWSCloud, name string) (*autoscaling.Group, error) {
+	response, err := cloud.Autoscaling().DescribeAutoScalingGroups(&autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: []*string{aws.String(name)},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error describing autoscaling group %q: %v", name, err)
+	}
+	for _, asg := range response.AutoScalingGroups {
+		if aws.StringValue(asg.AutoScalingGroupName) == name {
+			return asg, nil
+		}
+	}
+	return nil, nil
+}
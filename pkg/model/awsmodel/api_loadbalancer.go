@@ -25,6 +25,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/kops/model"
 	"k8s.io/kops/pkg/dns"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awstasks"
@@ -34,6 +35,9 @@ import (
 // LoadBalancerDefaultIdleTimeout is the default idle time for the ELB
 const LoadBalancerDefaultIdleTimeout = 5 * time.Minute
 
+// LoadBalancerDefaultConnectionDrainingTimeoutSeconds is the default connection draining timeout for the ELB
+const LoadBalancerDefaultConnectionDrainingTimeoutSeconds = 300
+
 // APILoadBalancerBuilder builds a LoadBalancer for accessing the API
 type APILoadBalancerBuilder struct {
 	*AWSModelContext
@@ -65,6 +69,8 @@ func (b *APILoadBalancerBuilder) Build(c *fi.ModelBuilderContext) error {
 		return fmt.Errorf("unhandled LoadBalancer type %q", lbSpec.Type)
 	}
 
+	healthcheckProtocol, healthcheckPort, healthcheckPath := b.apiHealthCheck()
+
 	var elbSubnets []*awstasks.Subnet
 	var nlbSubnetMappings []*awstasks.SubnetMapping
 	if len(lbSpec.Subnets) != 0 {
@@ -166,6 +172,7 @@ func (b *APILoadBalancerBuilder) Build(c *fi.ModelBuilderContext) error {
 		for k, v := range b.Cluster.Spec.CloudLabels {
 			tags[k] = v
 		}
+		b.addSpecHashTag(tags, b.ClusterSpecHash)
 		// Override the returned name to be the expected ELB name
 		tags["Name"] = "api." + b.ClusterName()
 
@@ -214,12 +221,20 @@ func (b *APILoadBalancerBuilder) Build(c *fi.ModelBuilderContext) error {
 
 			ConnectionDraining: &awstasks.ClassicLoadBalancerConnectionDraining{
 				Enabled: fi.Bool(true),
-				Timeout: fi.Int64(300),
+				Timeout: fi.Int64(LoadBalancerDefaultConnectionDrainingTimeoutSeconds),
 			},
 
 			Tags: tags,
 		}
 
+		if healthcheckProtocol != "" {
+			clb.HealthCheck.Target = fi.String(fmt.Sprintf("%s:%d%s", healthcheckProtocol, healthcheckPort, healthcheckPath))
+		}
+
+		if lbSpec.ConnectionDrainingTimeoutSeconds != nil {
+			clb.ConnectionDraining.Timeout = lbSpec.ConnectionDrainingTimeoutSeconds
+		}
+
 		if lbSpec.CrossZoneLoadBalancing == nil {
 			lbSpec.CrossZoneLoadBalancing = fi.Bool(false)
 		}
@@ -283,6 +298,8 @@ func (b *APILoadBalancerBuilder) Build(c *fi.ModelBuilderContext) error {
 				UnhealthyThreshold: fi.Int64(2),
 				Shared:             fi.Bool(false),
 			}
+			b.setTargetGroupHealthCheck(tg)
+			tg.DeregistrationDelay = lbSpec.DeregistrationDelaySeconds
 
 			c.AddTask(tg)
 
@@ -305,6 +322,8 @@ func (b *APILoadBalancerBuilder) Build(c *fi.ModelBuilderContext) error {
 					UnhealthyThreshold: fi.Int64(2),
 					Shared:             fi.Bool(false),
 				}
+				b.setTargetGroupHealthCheck(secondaryTG)
+				secondaryTG.DeregistrationDelay = lbSpec.DeregistrationDelaySeconds
 				c.AddTask(secondaryTG)
 				nlb.TargetGroups = append(nlb.TargetGroups, secondaryTG)
 			}
@@ -493,6 +512,18 @@ func (b *APILoadBalancerBuilder) Build(c *fi.ModelBuilderContext) error {
 				SourceGroup:   lbSG,
 				ToPort:        fi.Int64(443),
 			})
+
+			if healthcheckProtocol != "" && healthcheckPort != 443 {
+				c.AddTask(&awstasks.SecurityGroupRule{
+					Name:          fi.String(fmt.Sprintf("healthcheck-elb-to-master%s", suffix)),
+					Lifecycle:     b.SecurityLifecycle,
+					FromPort:      fi.Int64(healthcheckPort),
+					Protocol:      fi.String("tcp"),
+					SecurityGroup: masterGroup.Task,
+					SourceGroup:   lbSG,
+					ToPort:        fi.Int64(healthcheckPort),
+				})
+			}
 		}
 	} else if b.APILoadBalancerClass() == kops.LoadBalancerClassNetwork {
 		for _, masterGroup := range masterGroups {
@@ -517,6 +548,30 @@ func (b *APILoadBalancerBuilder) Build(c *fi.ModelBuilderContext) error {
 					CIDR:          fi.String(cidr),
 				})
 			}
+
+			// NLB health checks originate from the load balancer nodes within the VPC
+			if healthcheckProtocol != "" && healthcheckPort != 443 {
+				c.AddTask(&awstasks.SecurityGroupRule{
+					Name:          fi.String(fmt.Sprintf("healthcheck-elb-to-master%s", suffix)),
+					Lifecycle:     b.SecurityLifecycle,
+					FromPort:      fi.Int64(healthcheckPort),
+					Protocol:      fi.String("tcp"),
+					SecurityGroup: masterGroup.Task,
+					ToPort:        fi.Int64(healthcheckPort),
+					CIDR:          fi.String(b.Cluster.Spec.NetworkCIDR),
+				})
+				for _, cidr := range b.Cluster.Spec.AdditionalNetworkCIDRs {
+					c.AddTask(&awstasks.SecurityGroupRule{
+						Name:          fi.String(fmt.Sprintf("healthcheck-lb-to-master%s-%s", suffix, cidr)),
+						Lifecycle:     b.SecurityLifecycle,
+						FromPort:      fi.Int64(healthcheckPort),
+						Protocol:      fi.String("tcp"),
+						SecurityGroup: masterGroup.Task,
+						ToPort:        fi.Int64(healthcheckPort),
+						CIDR:          fi.String(cidr),
+					})
+				}
+			}
 		}
 	}
 
@@ -530,6 +585,58 @@ func (b *APILoadBalancerBuilder) Build(c *fi.ModelBuilderContext) error {
 	return nil
 }
 
+// apiHealthCheck returns the protocol, port and path the load balancer uses to check the apiserver.
+// When a path is configured, load balancers check the apiserver through the kube-apiserver-healthcheck sidecar,
+// unless overridden in the load balancer spec.  An empty protocol means the load balancer's default health check.
+func (b *APILoadBalancerBuilder) apiHealthCheck() (protocol string, port int64, path string) {
+	port = 443
+	if path = model.KubeAPIServerHealthcheckPath(b.Cluster); path != "" {
+		protocol = "HTTP"
+		port = int64(model.KubeAPIServerHealthcheckPort(b.Cluster))
+	}
+
+	override := b.Cluster.Spec.API.LoadBalancer.HealthCheck
+	if override == nil {
+		return protocol, port, path
+	}
+	if override.Protocol != nil {
+		protocol = *override.Protocol
+	} else if protocol == "" {
+		protocol = "TCP"
+	}
+	if override.Port != nil {
+		port = *override.Port
+	}
+	if override.Path != nil {
+		path = *override.Path
+	}
+
+	switch protocol {
+	case "HTTP", "HTTPS":
+		if path == "" {
+			path = "/healthz"
+		}
+	default:
+		path = ""
+	}
+	return protocol, port, path
+}
+
+// setTargetGroupHealthCheck configures the target group to check the apiserver as returned by apiHealthCheck.
+func (b *APILoadBalancerBuilder) setTargetGroupHealthCheck(tg *awstasks.TargetGroup) {
+	protocol, port, path := b.apiHealthCheck()
+	if protocol == "" {
+		// Set the default TCP check explicitly, so that a previously configured health check is reverted.
+		protocol, port = "TCP", fi.Int64Value(tg.Port)
+	}
+
+	tg.HealthCheckProtocol = fi.String(protocol)
+	tg.HealthCheckPort = fi.Int64(port)
+	if path != "" {
+		tg.HealthCheckPath = fi.String(path)
+	}
+}
+
 type scoredSubnet struct {
 	score  int
 	subnet *kops.ClusterSubnetSpec
@@ -33,6 +33,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awstasks"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	"k8s.io/kops/upup/pkg/fi/fitasks"
 )
 
 const (
@@ -74,12 +75,25 @@ func (b *AutoscalingGroupModelBuilder) Build(c *fi.ModelBuilderContext) error {
 			}
 		}
 
+		// @step: metal instance groups have no cloud resources; their machines are enrolled with the bootstrap script
+		if ig.Spec.Manager == kops.InstanceManagerMetal {
+			if err := b.buildMetalBootstrapScript(c, ig); err != nil {
+				return err
+			}
+			continue
+		}
+
 		task, err := b.buildLaunchTemplateTask(c, name, ig)
 		if err != nil {
 			return err
 		}
 		c.AddTask(task)
 
+		// @step: externally managed instance groups are launched from the launch template by another provisioner
+		if ig.Spec.Manager == kops.InstanceManagerExternal {
+			continue
+		}
+
 		// @step: now lets build the autoscaling group task
 		if ig.Spec.Manager != "Karpenter" {
 			tsk, err := b.buildAutoScalingGroupTask(c, name, ig)
@@ -131,6 +145,23 @@ func (b *AutoscalingGroupModelBuilder) Build(c *fi.ModelBuilderContext) error {
 	return nil
 }
 
+// buildMetalBootstrapScript writes the bootstrap script of a metal instance group to the state store,
+// from where "kops toolbox enroll" copies it to the machines it enrolls.
+func (b *AutoscalingGroupModelBuilder) buildMetalBootstrapScript(c *fi.ModelBuilderContext, ig *kops.InstanceGroup) error {
+	bootstrapScript, err := b.BootstrapScriptBuilder.ResourceNodeUp(c, ig)
+	if err != nil {
+		return err
+	}
+
+	c.AddTask(&fitasks.ManagedFile{
+		Name:      fi.String("bootstrap-" + ig.Name),
+		Lifecycle: b.Lifecycle,
+		Location:  fi.String(model.MetalBootstrapScriptLocation(ig.Name)),
+		Contents:  bootstrapScript,
+	})
+	return nil
+}
+
 // buildLaunchTemplateTask is responsible for creating the template task into the aws model
 func (b *AutoscalingGroupModelBuilder) buildLaunchTemplateTask(c *fi.ModelBuilderContext, name string, ig *kops.InstanceGroup) (*awstasks.LaunchTemplate, error) {
 	// @step: add the iam instance profile
@@ -199,8 +230,11 @@ func (b *AutoscalingGroupModelBuilder) buildLaunchTemplateTask(c *fi.ModelBuilde
 		Tags:                         tags,
 		UserData:                     userData,
 	}
+	if hash := b.InstanceGroupSpecHashes[ig.ObjectMeta.Name]; hash != "" {
+		lt.SpecHash = fi.String(hash)
+	}
 
-	if ig.Spec.Manager == kops.InstanceManagerCloudGroup {
+	if ig.Spec.Manager == kops.InstanceManagerCloudGroup || ig.Spec.Manager == kops.InstanceManagerExternal {
 		lt.InstanceType = fi.String(strings.Split(ig.Spec.MachineType, ",")[0])
 	}
 
@@ -236,6 +270,21 @@ func (b *AutoscalingGroupModelBuilder) buildLaunchTemplateTask(c *fi.ModelBuilde
 		}
 	}
 
+	// @step: add the Elastic Fabric Adapters, one per network card
+	if ig.Spec.EFA != nil && fi.BoolValue(ig.Spec.EFA.Enabled) {
+		lt.InterfaceType = fi.String(ec2.NetworkInterfaceTypeEfa)
+		for i := int64(1); i < int64(fi.Int32Value(ig.Spec.EFA.NetworkCards)); i++ {
+			lt.AdditionalNetworkInterfaces = append(lt.AdditionalNetworkInterfaces, &awstasks.LaunchTemplateNetworkInterface{
+				DeviceIndex:      fi.Int64(1),
+				NetworkCardIndex: fi.Int64(i),
+				InterfaceType:    fi.String(ec2.NetworkInterfaceTypeEfa),
+			})
+		}
+		if len(lt.AdditionalNetworkInterfaces) > 0 && fi.BoolValue(lt.AssociatePublicIP) {
+			return nil, fmt.Errorf("instance group %q: public IP addresses are not supported with more than one network card, use private subnets or set associatePublicIP to false", ig.ObjectMeta.Name)
+		}
+	}
+
 	// @step: add any additional block devices
 	for i := range ig.Spec.Volumes {
 		x := &ig.Spec.Volumes[i]
@@ -280,12 +329,14 @@ func (b *AutoscalingGroupModelBuilder) buildLaunchTemplateTask(c *fi.ModelBuilde
 		lt.InstanceMonitoring = ig.Spec.DetailedInstanceMonitoring
 	}
 
-	if ig.Spec.InstanceMetadata != nil && ig.Spec.InstanceMetadata.HTTPPutResponseHopLimit != nil {
-		lt.HTTPPutResponseHopLimit = ig.Spec.InstanceMetadata.HTTPPutResponseHopLimit
+	instanceMetadata := b.Cluster.Spec.InstanceMetadata.ResolveDefaults(ig)
+
+	if instanceMetadata != nil && instanceMetadata.HTTPPutResponseHopLimit != nil {
+		lt.HTTPPutResponseHopLimit = instanceMetadata.HTTPPutResponseHopLimit
 	}
 
-	if ig.Spec.InstanceMetadata != nil && ig.Spec.InstanceMetadata.HTTPTokens != nil {
-		lt.HTTPTokens = ig.Spec.InstanceMetadata.HTTPTokens
+	if instanceMetadata != nil && instanceMetadata.HTTPTokens != nil {
+		lt.HTTPTokens = instanceMetadata.HTTPTokens
 	}
 
 	if rootVolumeType == ec2.VolumeTypeIo1 || rootVolumeType == ec2.VolumeTypeIo2 {
@@ -326,7 +377,21 @@ func (b *AutoscalingGroupModelBuilder) buildLaunchTemplateTask(c *fi.ModelBuilde
 
 	if ig.Spec.Tenancy != "" {
 		lt.Tenancy = fi.String(ig.Spec.Tenancy)
+		lt.HostAffinity = ig.Spec.HostAffinity
+		lt.HostResourceGroupARN = ig.Spec.HostResourceGroupARN
+	}
+
+	for _, x := range ig.Spec.ElasticInferenceAccelerators {
+		count := x.AcceleratorCount
+		if count == 0 {
+			count = 1
+		}
+		lt.ElasticInferenceAccelerators = append(lt.ElasticInferenceAccelerators, &awstasks.LaunchTemplateElasticInferenceAccelerator{
+			Type:  fi.String(x.AcceleratorType),
+			Count: fi.Int64(count),
+		})
 	}
+	lt.EnclaveEnabled = ig.Spec.NitroEnclave
 
 	return lt, nil
 }
@@ -431,6 +496,7 @@ func (b *AutoscalingGroupModelBuilder) buildAutoScalingGroupTask(c *fi.ModelBuil
 	if err != nil {
 		return nil, fmt.Errorf("error building cloud tags: %v", err)
 	}
+	b.addSpecHashTag(tags, b.InstanceGroupSpecHashes[ig.ObjectMeta.Name])
 	t.Tags = tags
 
 	processes := []string{}
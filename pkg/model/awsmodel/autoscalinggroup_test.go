@@ -115,6 +115,204 @@ func TestRootVolumeOptimizationFlag(t *testing.T) {
 	}
 }
 
+// Tests that the cluster-wide instance metadata options apply to launch templates unless the instance group overrides them
+func TestInstanceMetadataDefaults(t *testing.T) {
+	cluster := buildMinimalCluster()
+	cluster.Spec.InstanceMetadata = &kops.InstanceMetadataOptions{
+		HTTPPutResponseHopLimit: fi.Int64(1),
+		HTTPTokens:              fi.String("required"),
+	}
+	ig := buildNodeInstanceGroup("subnet-us-mock-1a")
+	ig.Spec.InstanceMetadata = &kops.InstanceMetadataOptions{
+		HTTPPutResponseHopLimit: fi.Int64(3),
+	}
+
+	b := AutoscalingGroupModelBuilder{
+		AWSModelContext: &AWSModelContext{
+			KopsModelContext: &model.KopsModelContext{
+				IAMModelContext: iam.IAMModelContext{Cluster: cluster},
+				SSHPublicKeys:   [][]byte{[]byte(sshPublicKeyEntry)},
+				InstanceGroups:  []*kops.InstanceGroup{ig},
+			},
+		},
+		BootstrapScriptBuilder: &model.BootstrapScriptBuilder{
+			Lifecycle: fi.LifecycleSync,
+			Cluster: &kops.Cluster{
+				Spec: kops.ClusterSpec{
+					CloudProvider: kops.CloudProviderSpec{
+						AWS: &kops.AWSSpec{},
+					},
+					Networking:        &kops.NetworkingSpec{},
+					KubernetesVersion: "1.20.0",
+				},
+			},
+		},
+		Cluster: cluster,
+	}
+
+	c := &fi.ModelBuilderContext{
+		Tasks: make(map[string]fi.Task),
+	}
+
+	// We need the CA for the bootstrap script
+	for _, keypair := range []string{
+		fi.CertificateIDCA,
+		"etcd-clients-ca",
+	} {
+		c.AddTask(&fitasks.Keypair{
+			Name:    fi.String(keypair),
+			Subject: "cn=" + keypair,
+			Type:    "ca",
+		})
+	}
+
+	if err := b.Build(c); err != nil {
+		t.Fatalf("error from Build: %v", err)
+	}
+
+	lt := c.Tasks["LaunchTemplate/nodes.testcluster.test.com"].(*awstasks.LaunchTemplate)
+
+	if fi.StringValue(lt.HTTPTokens) != "required" {
+		t.Errorf("expected HTTPTokens to default to %q from the cluster, got %v", "required", fi.StringValue(lt.HTTPTokens))
+	}
+	if fi.Int64Value(lt.HTTPPutResponseHopLimit) != 3 {
+		t.Errorf("expected HTTPPutResponseHopLimit to be overridden to 3 by the instance group, got %v", fi.Int64Value(lt.HTTPPutResponseHopLimit))
+	}
+}
+
+func TestEFANetworkInterfaces(t *testing.T) {
+	cluster := buildMinimalCluster()
+	ig := buildNodeInstanceGroup("subnet-us-mock-1a")
+	ig.Spec.MachineType = "p4d.24xlarge"
+	ig.Spec.AssociatePublicIP = fi.Bool(false)
+	ig.Spec.EFA = &kops.EFASpec{
+		Enabled:      fi.Bool(true),
+		NetworkCards: fi.Int32(4),
+	}
+
+	b := AutoscalingGroupModelBuilder{
+		AWSModelContext: &AWSModelContext{
+			KopsModelContext: &model.KopsModelContext{
+				IAMModelContext: iam.IAMModelContext{Cluster: cluster},
+				SSHPublicKeys:   [][]byte{[]byte(sshPublicKeyEntry)},
+				InstanceGroups:  []*kops.InstanceGroup{ig},
+			},
+		},
+		BootstrapScriptBuilder: &model.BootstrapScriptBuilder{
+			Lifecycle: fi.LifecycleSync,
+			Cluster: &kops.Cluster{
+				Spec: kops.ClusterSpec{
+					CloudProvider: kops.CloudProviderSpec{
+						AWS: &kops.AWSSpec{},
+					},
+					Networking:        &kops.NetworkingSpec{},
+					KubernetesVersion: "1.20.0",
+				},
+			},
+		},
+		Cluster: cluster,
+	}
+
+	c := &fi.ModelBuilderContext{
+		Tasks: make(map[string]fi.Task),
+	}
+
+	// We need the CA for the bootstrap script
+	for _, keypair := range []string{
+		fi.CertificateIDCA,
+		"etcd-clients-ca",
+	} {
+		c.AddTask(&fitasks.Keypair{
+			Name:    fi.String(keypair),
+			Subject: "cn=" + keypair,
+			Type:    "ca",
+		})
+	}
+
+	if err := b.Build(c); err != nil {
+		t.Fatalf("error from Build: %v", err)
+	}
+
+	lt := c.Tasks["LaunchTemplate/nodes.testcluster.test.com"].(*awstasks.LaunchTemplate)
+
+	if fi.StringValue(lt.InterfaceType) != "efa" {
+		t.Errorf("expected the primary network interface to be an EFA, got %q", fi.StringValue(lt.InterfaceType))
+	}
+	if len(lt.AdditionalNetworkInterfaces) != 3 {
+		t.Fatalf("expected 3 additional network interfaces, got %d", len(lt.AdditionalNetworkInterfaces))
+	}
+	for i, ni := range lt.AdditionalNetworkInterfaces {
+		if fi.Int64Value(ni.NetworkCardIndex) != int64(i+1) || fi.Int64Value(ni.DeviceIndex) != 1 || fi.StringValue(ni.InterfaceType) != "efa" {
+			t.Errorf("unexpected network interface %d: card %d, device %d, type %q", i, fi.Int64Value(ni.NetworkCardIndex), fi.Int64Value(ni.DeviceIndex), fi.StringValue(ni.InterfaceType))
+		}
+	}
+}
+
+// Tests that externally managed instance groups get a launch template, but no autoscaling group
+func TestExternalInstanceManager(t *testing.T) {
+	cluster := buildMinimalCluster()
+	ig := buildNodeInstanceGroup("subnet-us-mock-1a")
+	ig.Spec.Manager = kops.InstanceManagerExternal
+	ig.Spec.MachineType = "m5.large"
+
+	b := AutoscalingGroupModelBuilder{
+		AWSModelContext: &AWSModelContext{
+			KopsModelContext: &model.KopsModelContext{
+				IAMModelContext: iam.IAMModelContext{Cluster: cluster},
+				SSHPublicKeys:   [][]byte{[]byte(sshPublicKeyEntry)},
+				InstanceGroups:  []*kops.InstanceGroup{ig},
+			},
+		},
+		BootstrapScriptBuilder: &model.BootstrapScriptBuilder{
+			Lifecycle: fi.LifecycleSync,
+			Cluster: &kops.Cluster{
+				Spec: kops.ClusterSpec{
+					CloudProvider: kops.CloudProviderSpec{
+						AWS: &kops.AWSSpec{},
+					},
+					Networking:        &kops.NetworkingSpec{},
+					KubernetesVersion: "1.20.0",
+				},
+			},
+		},
+		Cluster: cluster,
+	}
+
+	c := &fi.ModelBuilderContext{
+		Tasks: make(map[string]fi.Task),
+	}
+
+	// We need the CA for the bootstrap script
+	for _, keypair := range []string{
+		fi.CertificateIDCA,
+		"etcd-clients-ca",
+	} {
+		c.AddTask(&fitasks.Keypair{
+			Name:    fi.String(keypair),
+			Subject: "cn=" + keypair,
+			Type:    "ca",
+		})
+	}
+
+	if err := b.Build(c); err != nil {
+		t.Fatalf("error from Build: %v", err)
+	}
+
+	lt, ok := c.Tasks["LaunchTemplate/nodes.testcluster.test.com"].(*awstasks.LaunchTemplate)
+	if !ok {
+		t.Fatalf("expected a launch template for the externally managed instance group")
+	}
+	if fi.StringValue(lt.InstanceType) != "m5.large" {
+		t.Errorf("expected the launch template to set the instance type, got %q", fi.StringValue(lt.InstanceType))
+	}
+	if _, found := c.Tasks["AutoscalingGroup/nodes.testcluster.test.com"]; found {
+		t.Errorf("expected no autoscaling group for the externally managed instance group")
+	}
+	if _, found := c.Tasks["WarmPool/nodes.testcluster.test.com"]; found {
+		t.Errorf("expected no warm pool for the externally managed instance group")
+	}
+}
+
 func TestAPIServerAdditionalSecurityGroupsWithNLB(t *testing.T) {
 	const sgIDAPIServer = "sg-01234567890abcdef"
 
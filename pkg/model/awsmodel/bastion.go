@@ -334,13 +334,19 @@ func (b *BastionModelBuilder) Build(c *fi.ModelBuilderContext) error {
 	if publicName != "" {
 		// Here we implement the bastion CNAME logic
 		// By default bastions will create a CNAME that follows the `bastion-$clustername` formula
+		var bastionRecord *kops.DNSRecordSpec
+		if b.Cluster.Spec.DNSRecords != nil {
+			bastionRecord = b.Cluster.Spec.DNSRecords.Bastion
+		}
+		recordType, ttl := loadBalancerRecordType(bastionRecord)
 		t := &awstasks.DNSName{
 			Name:      fi.String(publicName),
 			Lifecycle: b.Lifecycle,
 
 			Zone:               b.LinkToDNSZone(),
 			ResourceName:       fi.String(publicName),
-			ResourceType:       fi.String("A"),
+			ResourceType:       fi.String(recordType),
+			TTL:                ttl,
 			TargetLoadBalancer: elb,
 		}
 		c.AddTask(t)
@@ -22,6 +22,7 @@ import (
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/model"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awstasks"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 )
 
 // AWSModelContext provides the context for the aws model
@@ -104,3 +105,10 @@ func (b *AWSModelContext) LinkToPrivateSubnetsInZone(zoneName string) ([]*awstas
 
 	return subnets, nil
 }
+
+// addSpecHashTag tags a resource with the hash of the spec it is applied from, if spec hashes are recorded.
+func (b *AWSModelContext) addSpecHashTag(tags map[string]string, hash string) {
+	if hash != "" {
+		tags[awsup.TagNameKopsSpecHash] = hash
+	}
+}
@@ -34,6 +34,22 @@ type DNSModelBuilder struct {
 
 var _ fi.ModelBuilder = &DNSModelBuilder{}
 
+// defaultCNAMETTL is the TTL of CNAME records pointing to load balancers, if the cluster doesn't configure one.
+const defaultCNAMETTL = 60
+
+// loadBalancerRecordType returns the type and TTL of a record pointing to a load balancer.
+// Alias records are A records and have no TTL.
+func loadBalancerRecordType(spec *kops.DNSRecordSpec) (string, *int64) {
+	if spec == nil || spec.Type != kops.DNSRecordTypeCNAME {
+		return "A", nil
+	}
+	ttl := int64(defaultCNAMETTL)
+	if spec.TTL != nil {
+		ttl = int64(*spec.TTL)
+	}
+	return "CNAME", &ttl
+}
+
 func (b *DNSModelBuilder) ensureDNSZone(c *fi.ModelBuilderContext) error {
 	if dns.IsGossipHostname(b.Cluster.Name) {
 		return nil
@@ -103,6 +119,12 @@ func (b *DNSModelBuilder) Build(c *fi.ModelBuilderContext) error {
 		}
 	}
 
+	var apiRecord, apiInternalRecord *kops.DNSRecordSpec
+	if b.Cluster.Spec.DNSRecords != nil {
+		apiRecord = b.Cluster.Spec.DNSRecords.API
+		apiInternalRecord = b.Cluster.Spec.DNSRecords.APIInternal
+	}
+
 	if b.UseLoadBalancerForAPI() {
 		// This will point our external DNS record to the load balancer, and put the
 		// pieces together for kubectl to work
@@ -112,15 +134,18 @@ func (b *DNSModelBuilder) Build(c *fi.ModelBuilderContext) error {
 				return err
 			}
 
+			recordType, ttl := loadBalancerRecordType(apiRecord)
 			c.AddTask(&awstasks.DNSName{
 				Name:               fi.String(b.Cluster.Spec.MasterPublicName),
 				ResourceName:       fi.String(b.Cluster.Spec.MasterPublicName),
 				Lifecycle:          b.Lifecycle,
 				Zone:               b.LinkToDNSZone(),
-				ResourceType:       fi.String("A"),
+				ResourceType:       fi.String(recordType),
+				TTL:                ttl,
 				TargetLoadBalancer: targetLoadBalancer,
 			})
-			if b.UseIPv6ForAPI() {
+			// A CNAME also resolves to the IPv6 addresses of the load balancer
+			if b.UseIPv6ForAPI() && recordType != "CNAME" {
 				c.AddTask(&awstasks.DNSName{
 					Name:               fi.String(b.Cluster.Spec.MasterPublicName + "-AAAA"),
 					ResourceName:       fi.String(b.Cluster.Spec.MasterPublicName),
@@ -142,6 +167,8 @@ func (b *DNSModelBuilder) Build(c *fi.ModelBuilderContext) error {
 				return err
 			}
 
+			recordType, ttl := loadBalancerRecordType(apiInternalRecord)
+
 			// Using EnsureTask as MasterInternalName and MasterPublicName could be the same
 			{
 				err := c.EnsureTask(&awstasks.DNSName{
@@ -149,14 +176,15 @@ func (b *DNSModelBuilder) Build(c *fi.ModelBuilderContext) error {
 					ResourceName:       fi.String(b.Cluster.Spec.MasterInternalName),
 					Lifecycle:          b.Lifecycle,
 					Zone:               b.LinkToDNSZone(),
-					ResourceType:       fi.String("A"),
+					ResourceType:       fi.String(recordType),
+					TTL:                ttl,
 					TargetLoadBalancer: targetLoadBalancer,
 				})
 				if err != nil {
 					return err
 				}
 			}
-			if b.UseIPv6ForAPI() {
+			if b.UseIPv6ForAPI() && recordType != "CNAME" {
 				err := c.EnsureTask(&awstasks.DNSName{
 					Name:               fi.String(b.Cluster.Spec.MasterInternalName + "-AAAA"),
 					ResourceName:       fi.String(b.Cluster.Spec.MasterInternalName),
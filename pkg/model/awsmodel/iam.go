@@ -217,6 +217,10 @@ func (b *IAMModelBuilder) buildIAMRole(role iam.Subject, iamName string, c *fi.M
 		iamRole.PermissionsBoundary = b.Cluster.Spec.IAM.PermissionsBoundary
 	}
 
+	if b.Cluster.Spec.IAM != nil && b.Cluster.Spec.IAM.Path != "" {
+		iamRole.Path = fi.String(b.Cluster.Spec.IAM.Path)
+	}
+
 	c.AddTask(iamRole)
 
 	return iamRole, nil
@@ -226,10 +230,12 @@ func (b *IAMModelBuilder) buildIAMRolePolicy(role iam.Subject, iamName string, i
 	iamPolicy := &iam.PolicyResource{
 		Builder: &iam.PolicyBuilder{
 			Cluster:                               b.Cluster,
+			InstanceGroups:                        b.InstanceGroups,
 			Role:                                  role,
 			Region:                                b.Region,
 			Partition:                             b.AWSPartition,
 			UseServiceAccountExternalPermisssions: b.UseServiceAccountExternalPermissions(),
+			AccountID:                             b.AWSAccountID,
 		},
 	}
 
@@ -283,6 +283,12 @@ func (b *NetworkModelBuilder) Build(c *fi.ModelBuilderContext) error {
 				subnet.AmazonIPv6CIDR = b.LinkToAmazonVPCIPv6CIDR()
 			}
 			subnet.IPv6CIDR = fi.String(subnetSpec.IPv6CIDR)
+
+			// Pods of IPv6 clusters are IPv6-only, so they reach IPv4-only destinations through DNS64 and NAT64.
+			// Utility subnets don't host pods.
+			if !sharedSubnet && (subnetSpec.CIDR == "" || b.IsIPv6Only() && subnetSpec.Type != kops.SubnetTypeUtility) {
+				subnet.DNS64 = fi.Bool(true)
+			}
 		}
 		if subnetSpec.ProviderID != "" {
 			subnet.ID = fi.String(subnetSpec.ProviderID)
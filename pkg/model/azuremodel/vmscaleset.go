@@ -49,16 +49,13 @@ func (b *VMScaleSetModelBuilder) Build(c *fi.ModelBuilderContext) error {
 		}
 		c.AddTask(vmss)
 
-		// Create tasks for assigning built-in roles to VM Scale Sets.
-		// See https://docs.microsoft.com/en-us/azure/role-based-access-control/built-in-roles
-		// for the ID definitions.
-		roleDefIDs := map[string]string{
-			// Owner
-			"owner": "8e3af657-a8ff-443c-a75c-2fe8c4bcb635",
-			// Storage Blob Data Contributor
-			"blob": "ba92f5b4-2d11-453d-a403-e96b0029c9fe",
+		if vmss.UserAssignedIdentity != nil {
+			// The roles of an existing identity are managed outside of kOps.
+			continue
 		}
-		for k, roleDefID := range roleDefIDs {
+
+		// Create tasks for assigning built-in roles to VM Scale Sets.
+		for k, roleDefID := range azure.InstanceRoleDefIDs() {
 			c.AddTask(b.buildRoleAssignmentTask(vmss, k, roleDefID))
 		}
 	}
@@ -144,6 +141,10 @@ func (b *VMScaleSetModelBuilder) buildVMScaleSetTask(
 
 	t.Tags = b.CloudTagsForInstanceGroup(ig)
 
+	if ig.Spec.IAM != nil && ig.Spec.IAM.Profile != nil {
+		t.UserAssignedIdentity = ig.Spec.IAM.Profile
+	}
+
 	return t, nil
 }
 
@@ -39,6 +39,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi/fitasks"
 	"k8s.io/kops/util/pkg/architectures"
 	"k8s.io/kops/util/pkg/mirrors"
+	"k8s.io/kops/util/pkg/vfs"
 )
 
 type NodeUpConfigBuilder interface {
@@ -52,8 +53,31 @@ type BootstrapScriptBuilder struct {
 	NodeUpAssets        map[architectures.Architecture]*mirrors.MirroredAsset
 	NodeUpConfigBuilder NodeUpConfigBuilder
 	Cluster             *kops.Cluster
+
+	// WindowsNodeUpAsset is the nodeup asset of the Windows instance groups.
+	WindowsNodeUpAsset *mirrors.MirroredAsset
+	// InstanceGroupNodeUpAssets are the nodeup assets of the instance groups that override NodeUpAssets, by name.
+	InstanceGroupNodeUpAssets map[string]map[architectures.Architecture]*mirrors.MirroredAsset
+
+	// MaxUserDataSize is the maximum size of the rendered user-data, or 0 if there is no limit.
+	MaxUserDataSize int
+	// UserDataOverflow is the behaviour when the rendered user-data is larger than MaxUserDataSize.
+	UserDataOverflow UserDataOverflowPolicy
 }
 
+// UserDataOverflowPolicy is the behaviour of the BootstrapScriptBuilder when the user-data is too large.
+type UserDataOverflowPolicy string
+
+const (
+	// UserDataOverflowFail fails to render the user-data.
+	UserDataOverflowFail UserDataOverflowPolicy = "Fail"
+	// UserDataOverflowCompress compresses the specs embedded in the user-data.
+	UserDataOverflowCompress UserDataOverflowPolicy = "Compress"
+	// UserDataOverflowOffload compresses the specs embedded in the user-data and, if that isn't enough,
+	// only embeds the hash of the cluster spec. Nodeup reads the full config from the state store.
+	UserDataOverflowOffload UserDataOverflowPolicy = "Offload"
+)
+
 type BootstrapScript struct {
 	Name      string
 	Lifecycle fi.Lifecycle
@@ -141,6 +165,13 @@ func (b *BootstrapScript) buildEnvironmentVariables(cluster *kops.Cluster) (map[
 		env["S3_SECRET_ACCESS_KEY"] = os.Getenv("S3_SECRET_ACCESS_KEY")
 	}
 
+	// Pass in the credentials of the S3 compatible state stores
+	for _, envVar := range vfs.S3EndpointEnvVars {
+		if os.Getenv(envVar) != "" {
+			env[envVar] = os.Getenv(envVar)
+		}
+	}
+
 	if cluster.Spec.GetCloudProvider() == kops.CloudProviderOpenstack {
 
 		osEnvs := []string{
@@ -215,6 +246,11 @@ func (b *BootstrapScript) buildEnvironmentVariables(cluster *kops.Cluster) (map[
 	return env, nil
 }
 
+// MetalBootstrapScriptLocation returns the location of the bootstrap script of a metal instance group, relative to the config base.
+func MetalBootstrapScriptLocation(instanceGroupName string) string {
+	return "igconfig/node/" + instanceGroupName + "/bootstrap.sh"
+}
+
 // ResourceNodeUp generates and returns a nodeup (bootstrap) script from a
 // template file, substituting in specific env vars & cluster spec configuration
 func (b *BootstrapScriptBuilder) ResourceNodeUp(c *fi.ModelBuilderContext, ig *kops.InstanceGroup) (fi.Resource, error) {
@@ -242,8 +278,8 @@ func (b *BootstrapScriptBuilder) ResourceNodeUp(c *fi.ModelBuilderContext, ig *k
 	if ig.IsBastion() {
 		keypairs = nil
 
-		// Bastions can have AdditionalUserData, but if there isn't any skip this part
-		if len(ig.Spec.AdditionalUserData) == 0 {
+		// Bastions can have AdditionalUserData, CloudInit and SSH authorized keys, but if there isn't any skip this part
+		if len(ig.Spec.AdditionalUserData) == 0 && len(b.sshAuthorizedKeys()) == 0 && ig.Spec.CloudInit == nil {
 			return fi.NewStringResource(""), nil
 		}
 	}
@@ -310,6 +346,15 @@ func (b *BootstrapScript) Run(c *fi.Context) error {
 
 	var nodeupScript resources.NodeUpScript
 	nodeupScript.NodeUpAssets = b.builder.NodeUpAssets
+	if b.ig.IsWindows() {
+		nodeupScript.Windows = true
+		nodeupScript.NodeUpAssets = map[architectures.Architecture]*mirrors.MirroredAsset{
+			architectures.ArchitectureAmd64: b.builder.WindowsNodeUpAsset,
+		}
+	}
+	if nodeUpAssets, found := b.builder.InstanceGroupNodeUpAssets[b.ig.Name]; found {
+		nodeupScript.NodeUpAssets = nodeUpAssets
+	}
 	nodeupScript.KubeEnv = config
 
 	{
@@ -328,7 +373,11 @@ func (b *BootstrapScript) Run(c *fi.Context) error {
 
 			var b bytes.Buffer
 			for _, k := range keys {
-				b.WriteString(fmt.Sprintf("export %s=%s\n", k, env[k]))
+				if nodeupScript.Windows {
+					b.WriteString(fmt.Sprintf("$env:%s = %s\n", k, powershellQuote(env[k])))
+				} else {
+					b.WriteString(fmt.Sprintf("export %s=%s\n", k, env[k]))
+				}
 			}
 			return b.String(), nil
 		}
@@ -348,6 +397,10 @@ func (b *BootstrapScript) Run(c *fi.Context) error {
 			spec["kubeProxy"] = cs.KubeProxy
 			spec["kubelet"] = cs.Kubelet
 
+			if cs.Crio != nil {
+				spec["crio"] = cs.Crio
+			}
+
 			if cs.KubeAPIServer != nil && cs.KubeAPIServer.EnableBootstrapAuthToken != nil {
 				spec["kubeAPIServer"] = map[string]interface{}{
 					"enableBootstrapAuthToken": cs.KubeAPIServer.EnableBootstrapAuthToken,
@@ -405,21 +458,108 @@ func (b *BootstrapScript) Run(c *fi.Context) error {
 	}
 
 	b.resource.Resource = fi.FunctionToResource(func() ([]byte, error) {
-		nodeupScript, err := fi.ResourceAsString(nodeupScriptResource)
+		userData, err := b.renderUserData(nodeupScriptResource)
 		if err != nil {
 			return nil, err
 		}
 
-		awsUserData, err := resources.AWSMultipartMIME(nodeupScript, b.ig)
-		if err != nil {
-			return nil, err
+		maxSize := b.builder.MaxUserDataSize
+		if maxSize <= 0 || len(userData) <= maxSize {
+			return userData, nil
+		}
+
+		// Render a copy, as the resource can be rendered more than once
+		script := nodeupScript
+		policy := b.builder.UserDataOverflow
+		if (policy == UserDataOverflowCompress || policy == UserDataOverflowOffload) && !script.CompressUserData {
+			klog.Infof("user-data of instance group %q is too large (%d bytes), compressing it", b.ig.Name, len(userData))
+			script.CompressUserData = true
+			if userData, err = b.renderNodeUpScript(&script); err != nil {
+				return nil, err
+			}
+		}
+
+		if policy == UserDataOverflowOffload && len(userData) > maxSize {
+			klog.Infof("user-data of instance group %q is too large (%d bytes), leaving the cluster spec in the state store", b.ig.Name, len(userData))
+			clusterSpec := script.ClusterSpec
+			script.ClusterSpec = func() (string, error) {
+				content, err := clusterSpec()
+				if err != nil {
+					return "", err
+				}
+				// The hash keeps the user-data changing with the spec, so that the instances are updated
+				sum256 := sha256.Sum256([]byte(content))
+				return "clusterSpecHash: " + base64.StdEncoding.EncodeToString(sum256[:]) + "\n", nil
+			}
+			if userData, err = b.renderNodeUpScript(&script); err != nil {
+				return nil, err
+			}
 		}
 
-		return []byte(awsUserData), nil
+		if len(userData) > maxSize {
+			return nil, fmt.Errorf("user-data of instance group %q is too large (%d bytes, the limit is %d bytes)", b.ig.Name, len(userData), maxSize)
+		}
+
+		return userData, nil
 	})
 	return nil
 }
 
+// renderNodeUpScript builds the nodeup script and renders it as user-data.
+func (b *BootstrapScript) renderNodeUpScript(nodeupScript *resources.NodeUpScript) ([]byte, error) {
+	nodeupScriptResource, err := nodeupScript.Build()
+	if err != nil {
+		return nil, err
+	}
+	return b.renderUserData(nodeupScriptResource)
+}
+
+// renderUserData renders the nodeup script as the user-data of the instance group.
+func (b *BootstrapScript) renderUserData(nodeupScriptResource fi.Resource) ([]byte, error) {
+	nodeupScript, err := fi.ResourceAsString(nodeupScriptResource)
+	if err != nil {
+		return nil, err
+	}
+
+	// Metal machines run the script directly, rather than through cloud-init.
+	// Windows machines don't use cloud-init, the PowerShell script is the whole user-data.
+	if b.ig.Spec.Manager == kops.InstanceManagerMetal || b.ig.IsWindows() {
+		return []byte(nodeupScript), nil
+	}
+
+	// Flatcar machines are provisioned by Ignition, which runs the script through a systemd unit.
+	if b.ig.UsesIgnition() {
+		ignitionConfig, err := resources.IgnitionConfig(nodeupScript, b.builder.sshAuthorizedKeys())
+		if err != nil {
+			return nil, err
+		}
+		return []byte(ignitionConfig), nil
+	}
+
+	awsUserData, err := resources.AWSMultipartMIME(nodeupScript, b.ig, b.builder.sshAuthorizedKeys())
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(awsUserData), nil
+}
+
+// sshAuthorizedKeys returns the SSH public keys that need to be installed through the user-data.
+// On AWS, only the primary key is imported as the EC2 key pair, so the other keys are added by cloud-init.
+// Changing them only changes the user-data, so a rolling update replaces the instances without
+// replacing the autoscaling groups.
+func (b *BootstrapScriptBuilder) sshAuthorizedKeys() []string {
+	if b.Cluster.Spec.GetCloudProvider() != kops.CloudProviderAWS || len(b.SSHPublicKeys) <= 1 {
+		return nil
+	}
+
+	var keys []string
+	for _, key := range b.SSHPublicKeys[1:] {
+		keys = append(keys, strings.TrimSpace(string(key)))
+	}
+	return keys
+}
+
 func (b *BootstrapScript) createProxyEnv(ps *kops.EgressProxySpec) (string, error) {
 	var buffer bytes.Buffer
 
@@ -481,3 +621,9 @@ func setSysctls() string {
 
 	return b.String()
 }
+
+// powershellQuote returns s as a single-quoted PowerShell string literal,
+// in which no variable expansion or escape sequences are interpreted.
+func powershellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
@@ -212,6 +212,141 @@ func TestBootstrapUserData(t *testing.T) {
 	}
 }
 
+func TestBootstrapUserDataOverflow(t *testing.T) {
+	render := func(maxSize int, policy UserDataOverflowPolicy) (string, error) {
+		cluster := makeTestCluster(nil, nil)
+		group := makeTestInstanceGroup(kops.InstanceGroupRoleMaster, nil, nil)
+		c := &fi.ModelBuilderContext{
+			Tasks: make(map[string]fi.Task),
+		}
+		for _, keypair := range []string{
+			fi.CertificateIDCA,
+			"apiserver-aggregator-ca",
+			"etcd-clients-ca",
+			"etcd-manager-ca-events",
+			"etcd-manager-ca-main",
+			"etcd-peers-ca-events",
+			"etcd-peers-ca-main",
+			"service-account",
+		} {
+			c.AddTask(&fitasks.Keypair{
+				Name:    fi.String(keypair),
+				Subject: "cn=" + keypair,
+				Type:    "ca",
+			})
+		}
+
+		bs := &BootstrapScriptBuilder{
+			KopsModelContext: &KopsModelContext{
+				IAMModelContext: iam.IAMModelContext{Cluster: cluster},
+				InstanceGroups:  []*kops.InstanceGroup{group},
+			},
+			NodeUpConfigBuilder: &nodeupConfigBuilder{cluster: cluster},
+			Cluster:             cluster,
+			MaxUserDataSize:     maxSize,
+			UserDataOverflow:    policy,
+		}
+
+		res, err := bs.ResourceNodeUp(c, group)
+		require.NoError(t, err, "creating nodeup resource")
+		err = c.Tasks["BootstrapScript/testIG"].Run(&fi.Context{Cluster: cluster})
+		require.NoError(t, err, "running task")
+
+		return fi.ResourceAsString(res)
+	}
+
+	uncompressed, err := render(0, UserDataOverflowFail)
+	require.NoError(t, err)
+	require.NotContains(t, uncompressed, "base64 -d")
+
+	_, err = render(len(uncompressed)-1, UserDataOverflowFail)
+	require.Error(t, err, "rendering too large user-data")
+
+	compressed, err := render(len(uncompressed)-1, UserDataOverflowCompress)
+	require.NoError(t, err)
+	require.Contains(t, compressed, "base64 -d")
+
+	_, err = render(len(compressed)-1, UserDataOverflowCompress)
+	require.Error(t, err, "rendering too large compressed user-data")
+
+	offloaded, err := render(len(compressed)-1, UserDataOverflowOffload)
+	require.NoError(t, err)
+	require.Less(t, len(offloaded), len(compressed))
+}
+
+func TestBootstrapUserDataWindows(t *testing.T) {
+	t.Setenv("S3_ENDPOINT", "https://s3.example.com")
+	t.Setenv("S3_SECRET_ACCESS_KEY", `pa"ss$word'with quotes`)
+
+	cluster := makeTestCluster(nil, nil)
+	group := makeTestInstanceGroup(kops.InstanceGroupRoleNode, nil, nil)
+	group.Spec.Image = "Windows_Server-2019-English-Full-ContainersLatest-2022.05.11"
+	c := &fi.ModelBuilderContext{
+		Tasks: make(map[string]fi.Task),
+	}
+	for _, keypair := range []string{
+		fi.CertificateIDCA,
+		"etcd-clients-ca",
+		"etcd-manager-ca-events",
+		"etcd-manager-ca-main",
+		"etcd-peers-ca-events",
+		"etcd-peers-ca-main",
+	} {
+		c.AddTask(&fitasks.Keypair{
+			Name:    fi.String(keypair),
+			Subject: "cn=" + keypair,
+			Type:    "ca",
+		})
+	}
+
+	nodeUpURL := "https://example.com/windows/amd64/nodeup.exe"
+	nodeUpHash := "833723369ad345a88dd85d61b1e77336d56e61b864557ded71b92b6e34158e6a"
+	bs := &BootstrapScriptBuilder{
+		KopsModelContext: &KopsModelContext{
+			IAMModelContext: iam.IAMModelContext{Cluster: cluster},
+			InstanceGroups:  []*kops.InstanceGroup{group},
+		},
+		NodeUpConfigBuilder: &nodeupConfigBuilder{cluster: cluster},
+		Cluster:             cluster,
+		WindowsNodeUpAsset: &mirrors.MirroredAsset{
+			Locations: []string{nodeUpURL},
+			Hash:      hashing.MustFromString(nodeUpHash),
+		},
+	}
+
+	res, err := bs.ResourceNodeUp(c, group)
+	require.NoError(t, err, "creating nodeup resource")
+	err = c.Tasks["BootstrapScript/testIG"].Run(&fi.Context{Cluster: cluster})
+	require.NoError(t, err, "running task")
+
+	userData, err := fi.ResourceAsString(res)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(userData, "<powershell>"), "user-data is a PowerShell script")
+	require.NotContains(t, userData, "MIMEBOUNDARY")
+	require.Contains(t, userData, `$NodeUpUrl = "`+nodeUpURL+`"`)
+	require.Contains(t, userData, `$NodeUpHash = "`+nodeUpHash+`"`)
+	require.Contains(t, userData, `$env:S3_ENDPOINT = 'https://s3.example.com'`)
+	require.Contains(t, userData, `$env:S3_SECRET_ACCESS_KEY = 'pa"ss$word''with quotes'`)
+}
+
+func TestPowershellQuote(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "", expected: `''`},
+		{input: "plain", expected: `'plain'`},
+		{input: `with "double" quotes`, expected: `'with "double" quotes'`},
+		{input: "$env:PATH", expected: `'$env:PATH'`},
+		{input: "it's", expected: `'it''s'`},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			require.Equal(t, test.expected, powershellQuote(test.input))
+		})
+	}
+}
+
 func makeTestCluster(hookSpecRoles []kops.InstanceGroupRole, fileAssetSpecRoles []kops.InstanceGroupRole) *kops.Cluster {
 	return &kops.Cluster{
 		Spec: kops.ClusterSpec{
@@ -160,6 +160,16 @@ func (b *KubeAPIServerOptionsBuilder) BuildOptions(o interface{}) error {
 		c.EnableAdmissionPlugins = append(c.EnableAdmissionPlugins, c.AppendAdmissionPlugins...)
 	}
 
+	// Admission plugins configured by kOps must also be enabled
+	if c.AdmissionPluginConfig != nil {
+		if c.AdmissionPluginConfig.EventRateLimit != nil && !c.HasAdmissionController("EventRateLimit") {
+			c.EnableAdmissionPlugins = append(c.EnableAdmissionPlugins, "EventRateLimit")
+		}
+		if c.AdmissionPluginConfig.ImagePolicyWebhook != nil && !c.HasAdmissionController("ImagePolicyWebhook") {
+			c.EnableAdmissionPlugins = append(c.EnableAdmissionPlugins, "ImagePolicyWebhook")
+		}
+	}
+
 	// We make sure to disable AnonymousAuth
 	c.AnonymousAuth = fi.Bool(false)
 
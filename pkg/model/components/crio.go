@@ -0,0 +1,72 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"fmt"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/loader"
+)
+
+// crioDefaultVersions maps the Kubernetes minor versions to the default CRI-O version.
+// CRI-O releases follow the Kubernetes minor versions, so each Kubernetes version gets a matching CRI-O version.
+var crioDefaultVersions = map[string]string{
+	"1.22": "1.22.5",
+	"1.23": "1.23.3",
+	"1.24": "1.24.1",
+	"1.25": "1.25.0",
+}
+
+// CrioOptionsBuilder adds options for CRI-O to the model
+type CrioOptionsBuilder struct {
+	*OptionsContext
+}
+
+var _ loader.OptionsBuilder = &CrioOptionsBuilder{}
+
+// BuildOptions is responsible for filling in the default setting for the CRI-O daemon
+func (b *CrioOptionsBuilder) BuildOptions(o interface{}) error {
+	clusterSpec := o.(*kops.ClusterSpec)
+
+	if clusterSpec.ContainerRuntime != "crio" {
+		return nil
+	}
+
+	if clusterSpec.Crio == nil {
+		clusterSpec.Crio = &kops.CrioConfig{}
+	}
+	crio := clusterSpec.Crio
+
+	// Set version based on Kubernetes version
+	if fi.StringValue(crio.Version) == "" {
+		minor := fmt.Sprintf("%d.%d", b.KubernetesVersion.Major, b.KubernetesVersion.Minor)
+		version, found := crioDefaultVersions[minor]
+		if !found {
+			return fmt.Errorf("no default CRI-O version for Kubernetes %s, set spec.crio.version", minor)
+		}
+		crio.Version = fi.String(version)
+	}
+
+	// Set default log level to INFO
+	if fi.StringValue(crio.LogLevel) == "" {
+		crio.LogLevel = fi.String("info")
+	}
+
+	return nil
+}
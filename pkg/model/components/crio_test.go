@@ -0,0 +1,117 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"testing"
+
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/kops/util"
+	"k8s.io/kops/pkg/assets"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func Test_Build_Crio_Options(t *testing.T) {
+	grid := []struct {
+		kubernetesVersion string
+		crio              *kopsapi.CrioConfig
+		expectedVersion   string
+		expectedError     bool
+	}{
+		{
+			kubernetesVersion: "1.23.8",
+			expectedVersion:   "1.23.3",
+		},
+		{
+			kubernetesVersion: "1.24.2",
+			expectedVersion:   "1.24.1",
+		},
+		{
+			kubernetesVersion: "1.24.2",
+			crio:              &kopsapi.CrioConfig{Version: fi.String("1.24.0")},
+			expectedVersion:   "1.24.0",
+		},
+		{
+			kubernetesVersion: "1.30.0",
+			expectedError:     true,
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.kubernetesVersion, func(t *testing.T) {
+			c := buildContainerdCluster(g.kubernetesVersion)
+			c.Spec.ContainerRuntime = "crio"
+			c.Spec.Crio = g.crio
+			b := assets.NewAssetBuilder(c, false)
+
+			version, err := util.ParseKubernetesVersion(g.kubernetesVersion)
+			if err != nil {
+				t.Fatalf("unexpected error from ParseKubernetesVersion %s: %v", g.kubernetesVersion, err)
+			}
+
+			ob := &CrioOptionsBuilder{
+				&OptionsContext{
+					AssetBuilder:      b,
+					KubernetesVersion: *version,
+				},
+			}
+
+			err = ob.BuildOptions(&c.Spec)
+			if g.expectedError {
+				if err == nil {
+					t.Fatalf("expected error from BuildOptions")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error from BuildOptions: %v", err)
+			}
+
+			if fi.StringValue(c.Spec.Crio.Version) != g.expectedVersion {
+				t.Errorf("expected CRI-O version %q, got %q", g.expectedVersion, fi.StringValue(c.Spec.Crio.Version))
+			}
+			if fi.StringValue(c.Spec.Crio.LogLevel) != "info" {
+				t.Errorf("expected log level %q, got %q", "info", fi.StringValue(c.Spec.Crio.LogLevel))
+			}
+		})
+	}
+}
+
+func Test_Build_Crio_Unneeded_Runtime(t *testing.T) {
+	c := buildContainerdCluster("1.24.2")
+	c.Spec.ContainerRuntime = "containerd"
+	b := assets.NewAssetBuilder(c, false)
+
+	version, err := util.ParseKubernetesVersion("1.24.2")
+	if err != nil {
+		t.Fatalf("unexpected error from ParseKubernetesVersion: %v", err)
+	}
+
+	ob := &CrioOptionsBuilder{
+		&OptionsContext{
+			AssetBuilder:      b,
+			KubernetesVersion: *version,
+		},
+	}
+
+	if err := ob.BuildOptions(&c.Spec); err != nil {
+		t.Fatalf("unexpected error from BuildOptions: %v", err)
+	}
+	if c.Spec.Crio != nil {
+		t.Errorf("expected no CRI-O config, got %v", c.Spec.Crio)
+	}
+}
@@ -61,6 +61,10 @@ func (b *DiscoveryOptionsBuilder) BuildOptions(o interface{}) error {
 				if err != nil {
 					return err
 				}
+			case *vfs.GSPath:
+				serviceAccountIssuer = base.GetHTTPsUrl()
+			case *vfs.AzureBlobPath:
+				serviceAccountIssuer = base.GetHTTPsUrl()
 			case *vfs.MemFSPath:
 				if !base.IsClusterReadable() {
 					// If this _is_ a test, we should call MarkClusterReadable
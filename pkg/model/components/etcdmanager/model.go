@@ -19,10 +19,12 @@ package etcdmanager
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/assets"
@@ -62,7 +64,7 @@ func (b *EtcdManagerBuilder) Build(c *fi.ModelBuilderContext) error {
 
 		backupStore := ""
 		if etcdCluster.Backups != nil {
-			backupStore = etcdCluster.Backups.BackupStore
+			backupStore = etcdCluster.Backups.ActiveBackupStore()
 		}
 		if backupStore == "" {
 			return fmt.Errorf("backupStore must be set for use with etcd-manager")
@@ -117,6 +119,8 @@ func (b *EtcdManagerBuilder) Build(c *fi.ModelBuilderContext) error {
 			Lifecycle: b.Lifecycle,
 			Subject:   "cn=etcd-manager-ca-" + etcdCluster.Name,
 			Type:      "ca",
+
+			ExternalIssuer: b.ExternalCertificateIssuer(),
 		})
 
 		// We create a CA for etcd peers and a separate one for clients
@@ -125,6 +129,8 @@ func (b *EtcdManagerBuilder) Build(c *fi.ModelBuilderContext) error {
 			Lifecycle: b.Lifecycle,
 			Subject:   "cn=etcd-peers-ca-" + etcdCluster.Name,
 			Type:      "ca",
+
+			ExternalIssuer: b.ExternalCertificateIssuer(),
 		})
 
 		// Because API server can only have a single client-cert, we need to share a client CA
@@ -133,6 +139,8 @@ func (b *EtcdManagerBuilder) Build(c *fi.ModelBuilderContext) error {
 			Lifecycle: b.Lifecycle,
 			Subject:   "cn=etcd-clients-ca",
 			Type:      "ca",
+
+			ExternalIssuer: b.ExternalCertificateIssuer(),
 		}); err != nil {
 			return err
 		}
@@ -143,6 +151,8 @@ func (b *EtcdManagerBuilder) Build(c *fi.ModelBuilderContext) error {
 				Lifecycle: b.Lifecycle,
 				Subject:   "cn=etcd-clients-ca-cilium",
 				Type:      "ca",
+
+				ExternalIssuer: b.ExternalCertificateIssuer(),
 			}
 			c.AddTask(clientsCaCilium)
 
@@ -269,7 +279,7 @@ func (b *EtcdManagerBuilder) buildPod(etcdCluster kops.EtcdClusterSpec) (*v1.Pod
 	clusterName := "etcd-" + etcdCluster.Name
 	backupStore := ""
 	if etcdCluster.Backups != nil {
-		backupStore = etcdCluster.Backups.BackupStore
+		backupStore = etcdCluster.Backups.ActiveBackupStore()
 	}
 
 	pod.Name = "etcd-manager-" + etcdCluster.Name
@@ -358,17 +368,6 @@ func (b *EtcdManagerBuilder) buildPod(etcdCluster kops.EtcdClusterSpec) (*v1.Pod
 		config.PeerUrls = fmt.Sprintf("%s://__name__:%d", scheme, ports.PeerPort)
 		config.ClientUrls = fmt.Sprintf("%s://%s:%d", scheme, clientHost, ports.ClientPort)
 		config.QuarantineClientUrls = fmt.Sprintf("%s://__name__:%d", scheme, ports.QuarantinedGRPCPort)
-
-		// TODO: We need to wire these into the etcd-manager spec
-		// // add timeout/heartbeat settings
-		if etcdCluster.LeaderElectionTimeout != nil {
-			//      envs = append(envs, v1.EnvVar{Name: "ETCD_ELECTION_TIMEOUT", Value: convEtcdSettingsToMs(etcdClusterSpec.LeaderElectionTimeout)})
-			return nil, fmt.Errorf("LeaderElectionTimeout not supported by etcd-manager")
-		}
-		if etcdCluster.HeartbeatInterval != nil {
-			//      envs = append(envs, v1.EnvVar{Name: "ETCD_HEARTBEAT_INTERVAL", Value: convEtcdSettingsToMs(etcdClusterSpec.HeartbeatInterval)})
-			return nil, fmt.Errorf("HeartbeatInterval not supported by etcd-manager")
-		}
 	}
 
 	{
@@ -475,6 +474,30 @@ func (b *EtcdManagerBuilder) buildPod(etcdCluster kops.EtcdClusterSpec) (*v1.Pod
 
 	container.Env = envMap.ToEnvVars()
 
+	// The S3 client of etcd-manager assumes the role to access a cross-account backup store
+	if etcdCluster.Backups != nil && etcdCluster.Backups.CrossAccountStore != nil && etcdCluster.Backups.CrossAccountStore.RoleARN != "" {
+		crossAccountStore := etcdCluster.Backups.CrossAccountStore
+		container.Env = append(container.Env, v1.EnvVar{Name: "S3_ROLE_ARN", Value: crossAccountStore.RoleARN})
+		if crossAccountStore.ExternalID != "" {
+			container.Env = append(container.Env, v1.EnvVar{Name: "S3_ROLE_EXTERNAL_ID", Value: crossAccountStore.ExternalID})
+		}
+	}
+
+	// etcd-manager passes the variables starting with ETCD_ down to etcd
+	for _, envVar := range buildEtcdEnvVars(etcdCluster) {
+		overwritten := false
+		if etcdCluster.Manager != nil {
+			for _, e := range etcdCluster.Manager.Env {
+				if e.Name == envVar.Name {
+					overwritten = true
+				}
+			}
+		}
+		if !overwritten {
+			container.Env = append(container.Env, envVar)
+		}
+	}
+
 	if etcdCluster.Manager != nil && len(etcdCluster.Manager.Env) > 0 {
 		for _, envVar := range etcdCluster.Manager.Env {
 			klog.Warningf("overloading ENV var in manifest %s with %s=%s", bundle, envVar.Name, envVar.Value)
@@ -511,6 +534,37 @@ func (b *EtcdManagerBuilder) buildPod(etcdCluster kops.EtcdClusterSpec) (*v1.Pod
 	return pod, nil
 }
 
+// buildEtcdEnvVars returns the variables that configure the tuning settings of etcd
+func buildEtcdEnvVars(etcdCluster kops.EtcdClusterSpec) []v1.EnvVar {
+	var envs []v1.EnvVar
+
+	if etcdCluster.LeaderElectionTimeout != nil {
+		envs = append(envs, v1.EnvVar{Name: "ETCD_ELECTION_TIMEOUT", Value: convEtcdSettingsToMs(etcdCluster.LeaderElectionTimeout)})
+	}
+	if etcdCluster.HeartbeatInterval != nil {
+		envs = append(envs, v1.EnvVar{Name: "ETCD_HEARTBEAT_INTERVAL", Value: convEtcdSettingsToMs(etcdCluster.HeartbeatInterval)})
+	}
+	if etcdCluster.QuotaBackendBytes != nil {
+		envs = append(envs, v1.EnvVar{Name: "ETCD_QUOTA_BACKEND_BYTES", Value: strconv.FormatInt(etcdCluster.QuotaBackendBytes.Value(), 10)})
+	}
+	if etcdCluster.AutoCompactionMode != "" {
+		envs = append(envs, v1.EnvVar{Name: "ETCD_AUTO_COMPACTION_MODE", Value: etcdCluster.AutoCompactionMode})
+	}
+	if etcdCluster.AutoCompactionRetention != "" {
+		envs = append(envs, v1.EnvVar{Name: "ETCD_AUTO_COMPACTION_RETENTION", Value: etcdCluster.AutoCompactionRetention})
+	}
+	if etcdCluster.SnapshotCount != nil {
+		envs = append(envs, v1.EnvVar{Name: "ETCD_SNAPSHOT_COUNT", Value: strconv.FormatInt(*etcdCluster.SnapshotCount, 10)})
+	}
+
+	return envs
+}
+
+// convEtcdSettingsToMs converts etcd settings to a string rep of int milliseconds
+func convEtcdSettingsToMs(dur *metav1.Duration) string {
+	return strconv.FormatInt(dur.Nanoseconds()/1000000, 10)
+}
+
 // config defines the flags for etcd-manager
 type config struct {
 	// LogLevel sets the log verbosity level
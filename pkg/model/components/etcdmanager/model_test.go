@@ -36,6 +36,8 @@ func Test_RunEtcdManagerBuilder(t *testing.T) {
 		"tests/pollinterval",
 		"tests/proxy",
 		"tests/overwrite_settings",
+		"tests/tuning",
+		"tests/crossaccount",
 	}
 	for _, basedir := range tests {
 		basedir := basedir
@@ -18,9 +18,12 @@ package kubeapiserver
 
 import (
 	"fmt"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/kops/pkg/apis/kops"
+	kopsmodel "k8s.io/kops/pkg/apis/kops/model"
 	"k8s.io/kops/pkg/assets"
 	"k8s.io/kops/pkg/k8scodecs"
 	"k8s.io/kops/pkg/model"
@@ -112,7 +115,8 @@ spec:
 func (b *KubeApiserverBuilder) buildHealthcheckSidecar() (*corev1.Pod, error) {
 	// TODO: pull from bundle
 	bundle := "(embedded kube-apiserver-healthcheck manifest)"
-	manifest := []byte(fmt.Sprintf(defaultManifest, wellknownports.KubeAPIServerHealthCheck))
+	port := kopsmodel.KubeAPIServerHealthcheckPort(b.Cluster)
+	manifest := []byte(fmt.Sprintf(defaultManifest, port))
 
 	var pod *corev1.Pod
 	var container *corev1.Container
@@ -145,5 +149,32 @@ func (b *KubeApiserverBuilder) buildHealthcheckSidecar() (*corev1.Pod, error) {
 		container.Image = remapped
 	}
 
+	if port != wellknownports.KubeAPIServerHealthCheck {
+		container.Args = append(container.Args, fmt.Sprintf("--port=%d", port))
+	}
+
+	if apiserver := b.Cluster.Spec.KubeAPIServer; apiserver != nil && apiserver.HealthcheckSidecar != nil {
+		sidecar := apiserver.HealthcheckSidecar
+		if len(sidecar.AdditionalChecks) != 0 {
+			container.Args = append(container.Args, "--additional-checks="+strings.Join(sidecar.AdditionalChecks, ","))
+		}
+
+		// When load balancers check the apiserver through the sidecar, reflect the same check in the container readiness,
+		// so that it is surfaced by kops validate
+		if path := kopsmodel.KubeAPIServerHealthcheckPath(b.Cluster); path != "" {
+			container.ReadinessProbe = &corev1.Probe{
+				ProbeHandler: corev1.ProbeHandler{
+					HTTPGet: &corev1.HTTPGetAction{
+						Host: "127.0.0.1",
+						Path: path,
+						Port: intstr.FromInt(port),
+					},
+				},
+				PeriodSeconds:  10,
+				TimeoutSeconds: 5,
+			}
+		}
+	}
+
 	return pod, nil
 }
@@ -33,6 +33,7 @@ func Test_RunKubeApiserverBuilder(t *testing.T) {
 	featureflag.ParseFlags("-ImageDigest")
 	tests := []string{
 		"tests/minimal",
+		"tests/healthcheck-sidecar",
 	}
 	for _, basedir := range tests {
 		basedir := basedir
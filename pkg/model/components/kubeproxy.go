@@ -39,6 +39,11 @@ func (b *KubeProxyOptionsBuilder) BuildOptions(o interface{}) error {
 
 	config := clusterSpec.KubeProxy
 
+	if config.Enabled == nil && clusterSpec.Networking != nil && clusterSpec.Networking.Calico != nil && clusterSpec.Networking.Calico.BPFEnabled {
+		// The Calico eBPF dataplane replaces kube-proxy
+		config.Enabled = fi.Bool(false)
+	}
+
 	if config.LogLevel == 0 {
 		// TODO: No way to set to 0?
 		config.LogLevel = 2
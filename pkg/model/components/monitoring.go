@@ -0,0 +1,113 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/loader"
+)
+
+// MonitoringOptionsBuilder adds options for the monitoring agents to the model.
+type MonitoringOptionsBuilder struct {
+	*OptionsContext
+}
+
+var _ loader.OptionsBuilder = &MonitoringOptionsBuilder{}
+
+// monitoringResources are the requests and limits applied by a resource preset.
+type monitoringResources struct {
+	CPURequest    string
+	MemoryRequest string
+	CPULimit      string
+	MemoryLimit   string
+}
+
+var nodeExporterPresets = map[string]monitoringResources{
+	kops.MonitoringResourcePresetSmall:  {CPURequest: "10m", MemoryRequest: "32Mi", CPULimit: "100m", MemoryLimit: "64Mi"},
+	kops.MonitoringResourcePresetMedium: {CPURequest: "50m", MemoryRequest: "64Mi", CPULimit: "200m", MemoryLimit: "128Mi"},
+	kops.MonitoringResourcePresetLarge:  {CPURequest: "100m", MemoryRequest: "128Mi", CPULimit: "500m", MemoryLimit: "256Mi"},
+}
+
+var cloudWatchAgentPresets = map[string]monitoringResources{
+	kops.MonitoringResourcePresetSmall:  {CPURequest: "50m", MemoryRequest: "128Mi", CPULimit: "200m", MemoryLimit: "256Mi"},
+	kops.MonitoringResourcePresetMedium: {CPURequest: "100m", MemoryRequest: "200Mi", CPULimit: "400m", MemoryLimit: "400Mi"},
+	kops.MonitoringResourcePresetLarge:  {CPURequest: "200m", MemoryRequest: "400Mi", CPULimit: "1", MemoryLimit: "800Mi"},
+}
+
+func (b *MonitoringOptionsBuilder) BuildOptions(o interface{}) error {
+	clusterSpec := o.(*kops.ClusterSpec)
+	if clusterSpec.Monitoring == nil {
+		return nil
+	}
+	monitoring := clusterSpec.Monitoring
+
+	if monitoring.NodeExporter == nil {
+		monitoring.NodeExporter = &kops.MonitoringAgentConfig{}
+	}
+	setMonitoringAgentDefaults(monitoring.NodeExporter, "quay.io/prometheus/node-exporter:v1.3.1", nodeExporterPresets)
+
+	if clusterSpec.GetCloudProvider() == kops.CloudProviderAWS {
+		if monitoring.CloudWatchAgent == nil {
+			monitoring.CloudWatchAgent = &kops.MonitoringAgentConfig{}
+		}
+		setMonitoringAgentDefaults(monitoring.CloudWatchAgent, "public.ecr.aws/cloudwatch-agent/cloudwatch-agent:1.247350.0b251780", cloudWatchAgentPresets)
+	}
+
+	return nil
+}
+
+func setMonitoringAgentDefaults(agent *kops.MonitoringAgentConfig, image string, presets map[string]monitoringResources) {
+	if agent.Enabled == nil {
+		agent.Enabled = fi.Bool(false)
+	}
+
+	if agent.Image == nil {
+		agent.Image = fi.String(image)
+	}
+
+	if agent.ResourcePreset == nil {
+		agent.ResourcePreset = fi.String(kops.MonitoringResourcePresetSmall)
+	}
+
+	preset, ok := presets[*agent.ResourcePreset]
+	if !ok {
+		// Rejected by validation
+		return
+	}
+
+	if agent.CPURequest == nil {
+		cpuRequest := resource.MustParse(preset.CPURequest)
+		agent.CPURequest = &cpuRequest
+	}
+
+	if agent.MemoryRequest == nil {
+		memoryRequest := resource.MustParse(preset.MemoryRequest)
+		agent.MemoryRequest = &memoryRequest
+	}
+
+	if agent.CPULimit == nil {
+		cpuLimit := resource.MustParse(preset.CPULimit)
+		agent.CPULimit = &cpuLimit
+	}
+
+	if agent.MemoryLimit == nil {
+		memoryLimit := resource.MustParse(preset.MemoryLimit)
+		agent.MemoryLimit = &memoryLimit
+	}
+}
@@ -48,6 +48,11 @@ type KopsModelContext struct {
 	InstanceGroups []*kops.InstanceGroup
 	Region         string
 	SSHPublicKeys  [][]byte
+
+	// ClusterSpecHash is the hash of the spec of the cluster being applied, if cloud resources are tagged with it.
+	ClusterSpecHash string
+	// InstanceGroupSpecHashes are the hashes of the specs of the instance groups being applied, by name.
+	InstanceGroupSpecHashes map[string]string
 }
 
 // GatherSubnets maps the subnet names in an InstanceGroup to the ClusterSubnetSpec objects (which are stored on the Cluster)
@@ -310,6 +315,22 @@ func (b *KopsModelContext) UseNetworkLoadBalancer() bool {
 	return b.Cluster.Spec.API.LoadBalancer.Class == kops.LoadBalancerClassNetwork
 }
 
+// ExternalCertificateIssuer returns the certificate authority service that issues the certificates of the cluster CAs,
+// or an empty string if kOps self-signs them.
+func (b *KopsModelContext) ExternalCertificateIssuer() string {
+	issuer := b.Cluster.Spec.CertificateIssuer
+	if issuer == nil {
+		return ""
+	}
+	if issuer.AWSPrivateCA != nil {
+		return issuer.AWSPrivateCA.ARN
+	}
+	if issuer.GCPCAS != nil {
+		return issuer.GCPCAS.CAPool
+	}
+	return ""
+}
+
 // UseSSHKey returns true if SSHKeyName from the cluster spec is set to a nonempty string
 // or there is an SSH public key provisioned in the key store.
 func (b *KopsModelContext) UseSSHKey() bool {
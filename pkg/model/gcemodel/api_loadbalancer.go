@@ -20,7 +20,7 @@ import (
 	"fmt"
 
 	"k8s.io/kops/pkg/apis/kops"
-	"k8s.io/kops/pkg/wellknownports"
+	"k8s.io/kops/pkg/apis/kops/model"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/gce"
 	"k8s.io/kops/upup/pkg/fi/cloudup/gcetasks"
@@ -46,7 +46,7 @@ func createPublicLB(b *APILoadBalancerBuilder, c *fi.ModelBuilderContext) error
 
 	healthCheck := &gcetasks.HTTPHealthcheck{
 		Name:      s(b.NameForHealthcheck("api")),
-		Port:      i64(wellknownports.KubeAPIServerHealthCheck),
+		Port:      i64(int64(model.KubeAPIServerHealthcheckPort(b.Cluster))),
 		Lifecycle: b.Lifecycle,
 	}
 
@@ -107,6 +107,12 @@ func (b *AutoscalingGroupModelBuilder) buildInstanceTemplate(c *fi.ModelBuilderC
 				},
 			}
 
+			// Flatcar reads the Ignition config from the user-data
+			if ig.UsesIgnition() {
+				delete(t.Metadata, "startup-script")
+				t.Metadata["user-data"] = startupScript
+			}
+
 			nodeRole, err := iam.BuildNodeRoleSubject(ig.Spec.Role, false)
 			if err != nil {
 				return nil, err
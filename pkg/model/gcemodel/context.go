@@ -136,6 +136,16 @@ func (c *GCEModelContext) NetworkingIsGCERoutes() bool {
 
 // LinkToServiceAccount returns a link to the GCE ServiceAccount object for VMs in the given role
 func (c *GCEModelContext) LinkToServiceAccount(ig *kops.InstanceGroup) *gcetasks.ServiceAccount {
+	if ig.Spec.IAM != nil && ig.Spec.IAM.Profile != nil {
+		// The instance group brings its own serviceaccount, which we don't manage
+		email := *ig.Spec.IAM.Profile
+		return &gcetasks.ServiceAccount{
+			Name:   s(email),
+			Email:  s(email),
+			Shared: fi.Bool(true),
+		}
+	}
+
 	if c.Cluster.Spec.CloudConfig.GCEServiceAccount != "" {
 		// This is a legacy setting because the nodes & control-plane run under the same serviceaccount
 		klog.Warningf("using legacy spec.cloudConfig.gceServiceAccount=%q setting", c.Cluster.Spec.CloudConfig.GCEServiceAccount)
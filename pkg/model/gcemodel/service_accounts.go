@@ -17,9 +17,12 @@ limitations under the License.
 package gcemodel
 
 import (
+	"strings"
+
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/gce"
 	"k8s.io/kops/upup/pkg/fi/cloudup/gcetasks"
 )
 
@@ -42,6 +45,18 @@ func (b *ServiceAccountsBuilder) Build(c *fi.ModelBuilderContext) error {
 		}
 		c.AddTask(serviceAccount)
 
+		// Instance groups can still bring their own service account
+		for _, ig := range b.InstanceGroups {
+			if ig.Spec.IAM == nil || ig.Spec.IAM.Profile == nil {
+				continue
+			}
+			link := b.LinkToServiceAccount(ig)
+			link.Lifecycle = b.Lifecycle
+			if err := c.EnsureTask(link); err != nil {
+				return err
+			}
+		}
+
 		return nil
 	}
 
@@ -49,7 +64,10 @@ func (b *ServiceAccountsBuilder) Build(c *fi.ModelBuilderContext) error {
 	for _, ig := range b.InstanceGroups {
 		link := b.LinkToServiceAccount(ig)
 		if fi.BoolValue(link.Shared) {
-			c.EnsureTask(link)
+			link.Lifecycle = b.Lifecycle
+			if err := c.EnsureTask(link); err != nil {
+				return err
+			}
 			continue
 		}
 
@@ -93,36 +111,34 @@ func (b *ServiceAccountsBuilder) Build(c *fi.ModelBuilderContext) error {
 func (b *ServiceAccountsBuilder) addInstanceGroupServiceAccountPermissions(c *fi.ModelBuilderContext, serviceAccountEmail string, role kops.InstanceGroupRole) error {
 	member := "serviceAccount:" + serviceAccountEmail
 
-	// Ideally we would use a custom role here, but the deletion of a custom role takes 7 days,
-	// which means we can't easily recycle cluster names.
-	// If we can find a solution, we can easily switch to a custom role.
-
+	name := ""
 	switch role {
 	case kops.InstanceGroupRoleMaster:
-		// We reuse the GKE role
+		name = "serviceaccount-control-plane"
+	case kops.InstanceGroupRoleNode:
+		name = "serviceaccount-nodes"
+	}
+
+	for _, projectRole := range gce.InstanceGroupServiceAccountRoles(role) {
 		c.AddTask(&gcetasks.ProjectIAMBinding{
-			Name:      s("serviceaccount-control-plane"),
+			Name:      s(name),
 			Lifecycle: b.Lifecycle,
 
 			Project: s(b.ProjectID),
 			Member:  s(member),
-			Role:    s("roles/container.serviceAgent"),
+			Role:    s(projectRole),
 		})
+	}
 
-	case kops.InstanceGroupRoleNode:
-		// Known permissions:
-		//  * compute.zones.list (to find out region; we could replace this with string manipulation)
-		//  * compute.instances.list (for discovery; we don't need in the case of a load balancer or DNS)
-
-		// We use the GCE viewer role
-
-		c.AddTask(&gcetasks.ProjectIAMBinding{
-			Name:      s("serviceaccount-nodes"),
+	// The control plane unwraps the private keys it reads from the keystore
+	if role == kops.InstanceGroupRoleMaster && strings.HasPrefix(b.Cluster.Spec.KeyStoreKMSKey, "projects/") {
+		c.AddTask(&gcetasks.CryptoKeyIAMBinding{
+			Name:      s(name + "-keystore-kms"),
 			Lifecycle: b.Lifecycle,
 
-			Project: s(b.ProjectID),
-			Member:  s(member),
-			Role:    s("roles/compute.viewer"),
+			CryptoKey: s(b.Cluster.Spec.KeyStoreKMSKey),
+			Member:    s(member),
+			Role:      s("roles/cloudkms.cryptoKeyDecrypter"),
 		})
 	}
 	return nil
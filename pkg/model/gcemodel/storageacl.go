@@ -174,7 +174,7 @@ func (b *StorageAclBuilder) Build(c *fi.ModelBuilderContext) error {
 		}
 
 		// Add bucket read permissions if we need to read from the bucket
-		readablePaths, err := iam.ReadableStatePaths(b.Cluster, nodeRole)
+		readablePaths, err := iam.ReadableStatePaths(b.Cluster, b.InstanceGroups, nodeRole)
 		if err != nil {
 			return err
 		}
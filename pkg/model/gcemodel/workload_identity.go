@@ -0,0 +1,82 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcemodel
+
+import (
+	"strings"
+
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/gce"
+	"k8s.io/kops/upup/pkg/fi/cloudup/gcetasks"
+)
+
+// WorkloadIdentityBuilder configures a GCP service account for each ServiceAccount with GCP permissions,
+// and allows the ServiceAccount to impersonate it through the workload identity provider
+type WorkloadIdentityBuilder struct {
+	*GCEModelContext
+
+	Lifecycle fi.Lifecycle
+}
+
+var _ fi.ModelBuilder = &WorkloadIdentityBuilder{}
+
+func (b *WorkloadIdentityBuilder) Build(c *fi.ModelBuilderContext) error {
+	iamSpec := b.Cluster.Spec.IAM
+	said := b.Cluster.Spec.ServiceAccountIssuerDiscovery
+	if iamSpec == nil || said == nil || said.GCPWorkloadIdentityProvider == "" {
+		return nil
+	}
+
+	for _, sa := range iamSpec.ServiceAccountExternalPermissions {
+		if sa.GCP == nil {
+			continue
+		}
+
+		accountID := gce.WorkloadIdentityServiceAccountName(sa.Namespace, sa.Name, b.ClusterName())
+		serviceAccount := &gcetasks.ServiceAccount{
+			Name:        s(accountID),
+			Lifecycle:   b.Lifecycle,
+			Email:       s(accountID + "@" + b.ProjectID + ".iam.gserviceaccount.com"),
+			Description: s(gce.WorkloadIdentityServiceAccountDescription(sa.Namespace, sa.Name, b.ClusterName())),
+			DisplayName: s(accountID),
+		}
+		c.AddTask(serviceAccount)
+
+		c.AddTask(&gcetasks.ServiceAccountIAMBinding{
+			Name:      s(accountID + "-workload-identity"),
+			Lifecycle: b.Lifecycle,
+
+			ServiceAccount: serviceAccount,
+			Member:         s(gce.WorkloadIdentityPrincipal(said.GCPWorkloadIdentityProvider, sa.Namespace, sa.Name)),
+			Role:           s(gce.WorkloadIdentityUserRole),
+		})
+
+		member := "serviceAccount:" + fi.StringValue(serviceAccount.Email)
+		for _, role := range sa.GCP.Roles {
+			c.AddTask(&gcetasks.ProjectIAMBinding{
+				Name:      s(accountID + "-" + strings.Replace(gce.LastComponent(role), ".", "-", -1)),
+				Lifecycle: b.Lifecycle,
+
+				Project: s(b.ProjectID),
+				Member:  s(member),
+				Role:    s(role),
+			})
+		}
+	}
+
+	return nil
+}
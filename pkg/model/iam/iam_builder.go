@@ -56,6 +56,71 @@ type Policy struct {
 	Statement                 []*Statement
 	partition                 string
 	Version                   string
+
+	// region and accountID are set when resources should be scoped to the cluster's region and account.
+	region    string
+	accountID string
+	// scopedStatements are the statements whose resources are rewritten when the policy is scoped.
+	scopedStatements []*Statement
+}
+
+// ScopeResources restricts the resources of statements conditioned on cluster tags
+// to ARNs in the given region and account, rather than to all resources.
+// If accountID is empty, resources are scoped to the region only.
+func (p *Policy) ScopeResources(region, accountID string) {
+	if accountID == "" {
+		accountID = "*"
+	}
+	p.region = region
+	p.accountID = accountID
+}
+
+// scopeResource returns the ARN restricting resource to the scoped region and account.
+func (p *Policy) scopeResource(service, resource string) string {
+	if resource == "*" {
+		return fmt.Sprintf("arn:%s:%s:%s:%s:*", p.partition, service, p.region, p.accountID)
+	}
+	tokens := strings.SplitN(resource, ":", 6)
+	if len(tokens) != 6 || tokens[0] != "arn" {
+		return resource
+	}
+	if tokens[3] == "*" {
+		tokens[3] = p.region
+	}
+	if tokens[4] == "*" {
+		tokens[4] = p.accountID
+	}
+	return strings.Join(tokens, ":")
+}
+
+// addClusterTaggedStatements adds statements granting actions on resources matching condition.
+// When the policy is scoped, one statement is added per service.
+func (p *Policy) addClusterTaggedStatements(actions sets.String, condition Condition) {
+	if p.region == "" {
+		p.Statement = append(p.Statement, &Statement{
+			Effect:    StatementEffectAllow,
+			Action:    stringorslice.Of(actions.List()...),
+			Resource:  stringorslice.String("*"),
+			Condition: condition,
+		})
+		return
+	}
+
+	byService := make(map[string][]string)
+	for _, action := range actions.List() {
+		service := strings.SplitN(action, ":", 2)[0]
+		byService[service] = append(byService[service], action)
+	}
+	for _, service := range sets.StringKeySet(byService).List() {
+		statement := &Statement{
+			Effect:    StatementEffectAllow,
+			Action:    stringorslice.Of(byService[service]...),
+			Resource:  stringorslice.String("*"),
+			Condition: condition,
+		}
+		p.Statement = append(p.Statement, statement)
+		p.scopedStatements = append(p.scopedStatements, statement)
+	}
 }
 
 func (p *Policy) AddUnconditionalActions(actions ...string) {
@@ -74,8 +139,8 @@ func (p *Policy) AddEC2CreateAction(actions, resources []string) {
 
 	p.clusterTaggedCreateAction.Insert(actualActions...)
 
-	p.Statement = append(p.Statement,
-		&Statement{
+	statements := []*Statement{
+		{
 			Effect:   StatementEffectAllow,
 			Action:   stringorslice.String("ec2:CreateTags"),
 			Resource: stringorslice.Slice(actualResources),
@@ -86,8 +151,7 @@ func (p *Policy) AddEC2CreateAction(actions, resources []string) {
 				},
 			},
 		},
-
-		&Statement{
+		{
 			Effect: StatementEffectAllow,
 			Action: stringorslice.Slice([]string{
 				"ec2:CreateTags",
@@ -103,7 +167,9 @@ func (p *Policy) AddEC2CreateAction(actions, resources []string) {
 				},
 			},
 		},
-	)
+	}
+	p.Statement = append(p.Statement, statements...)
+	p.scopedStatements = append(p.scopedStatements, statements...)
 }
 
 // AsJSON converts the policy document to JSON format (parsable by AWS)
@@ -116,36 +182,37 @@ func (p *Policy) AsJSON() (string, error) {
 		})
 	}
 	if len(p.clusterTaggedAction) > 0 {
-		p.Statement = append(p.Statement, &Statement{
-			Effect:   StatementEffectAllow,
-			Action:   stringorslice.Of(p.clusterTaggedAction.List()...),
-			Resource: stringorslice.String("*"),
-			Condition: Condition{
-				"StringEquals": map[string]string{
-					"aws:ResourceTag/KubernetesCluster": p.clusterName,
-				},
+		p.addClusterTaggedStatements(p.clusterTaggedAction, Condition{
+			"StringEquals": map[string]string{
+				"aws:ResourceTag/KubernetesCluster": p.clusterName,
 			},
 		})
 	}
 	if len(p.clusterTaggedCreateAction) > 0 {
-		p.Statement = append(p.Statement, &Statement{
-			Effect:   StatementEffectAllow,
-			Action:   stringorslice.Of(p.clusterTaggedCreateAction.List()...),
-			Resource: stringorslice.String("*"),
-			Condition: Condition{
-				"StringEquals": map[string]string{
-					"aws:RequestTag/KubernetesCluster": p.clusterName,
-				},
+		p.addClusterTaggedStatements(p.clusterTaggedCreateAction, Condition{
+			"StringEquals": map[string]string{
+				"aws:RequestTag/KubernetesCluster": p.clusterName,
 			},
 		})
 		// ec2:CreateSecurityGroup needs some special care as it also interacts with vpc, which do not support RequestTag.
 		// We also do not require VPCs to be tagged, so we are not sending any conditions, allowing SGs to be created in any VPC.
 		if p.clusterTaggedCreateAction.Has("ec2:CreateSecurityGroup") {
-			p.Statement = append(p.Statement, &Statement{
+			statement := &Statement{
 				Effect:   StatementEffectAllow,
 				Action:   stringorslice.Of("ec2:CreateSecurityGroup"),
 				Resource: stringorslice.String(fmt.Sprintf("arn:%s:ec2:*:*:vpc/*", p.partition)),
-			})
+			}
+			p.Statement = append(p.Statement, statement)
+			p.scopedStatements = append(p.scopedStatements, statement)
+		}
+	}
+	if p.region != "" {
+		for _, statement := range p.scopedStatements {
+			service := strings.SplitN(statement.Action.Value()[0], ":", 2)[0]
+			resources := statement.Resource.Value()
+			for i, resource := range resources {
+				resources[i] = p.scopeResource(service, resource)
+			}
 		}
 	}
 	if len(p.Statement) == 0 {
@@ -306,12 +373,15 @@ func (l *Statement) Equal(r *Statement) bool {
 type PolicyBuilder struct {
 	Cluster                               *kops.Cluster
 	HostedZoneID                          string
+	InstanceGroups                        []*kops.InstanceGroup
 	KMSKeys                               []string
 	Region                                string
 	Partition                             string
 	ResourceARN                           *string
 	Role                                  Subject
 	UseServiceAccountExternalPermisssions bool
+	// AccountID is the AWS account the cluster runs in, used when scoping resources.
+	AccountID string
 }
 
 // BuildAWSPolicy builds a set of IAM policy statements based on the
@@ -331,6 +401,10 @@ func (b *PolicyBuilder) BuildAWSPolicy() (*Policy, error) {
 		return nil, fmt.Errorf("failed to generate AWS IAM Policy: %v", err)
 	}
 
+	if b.Cluster.Spec.IAM != nil && b.Cluster.Spec.IAM.ScopeResourceARNs {
+		p.ScopeResources(b.Region, b.AccountID)
+	}
+
 	return p, nil
 }
 
@@ -370,13 +444,17 @@ func (r *NodeRoleAPIServer) BuildAWSPolicy(b *PolicyBuilder) (*Policy, error) {
 	}
 
 	if b.Cluster.Spec.Networking != nil && b.Cluster.Spec.Networking.Cilium != nil && b.Cluster.Spec.Networking.Cilium.IPAM == kops.CiliumIpamEni {
-		addCiliumEniPermissions(p)
+		addCiliumEniPermissions(p, b.Cluster.Spec.Networking.Cilium.ENI)
 	}
 
 	if b.Cluster.Spec.Networking != nil && b.Cluster.Spec.Networking.Calico != nil && b.Cluster.Spec.Networking.Calico.AWSSrcDstCheck != "DoNothing" {
 		addCalicoSrcDstCheckPermissions(p)
 	}
 
+	if b.cloudWatchAgentEnabled(kops.InstanceGroupRoleAPIServer) {
+		addCloudWatchAgentPermissions(p)
+	}
+
 	return p, nil
 }
 
@@ -387,6 +465,8 @@ func (r *NodeRoleMaster) BuildAWSPolicy(b *PolicyBuilder) (*Policy, error) {
 	p := NewPolicy(clusterName, b.Partition)
 
 	addEtcdManagerPermissions(p)
+	addEtcdCrossAccountStorePermissions(p, b.Cluster)
+	addKeystoreKMSPermissions(p, b.Cluster)
 	b.addNodeupPermissions(p, false)
 
 	if b.Cluster.Spec.IsKopsControllerIPAM() {
@@ -449,13 +529,21 @@ func (r *NodeRoleMaster) BuildAWSPolicy(b *PolicyBuilder) (*Policy, error) {
 	}
 
 	if b.Cluster.Spec.Networking != nil && b.Cluster.Spec.Networking.Cilium != nil && b.Cluster.Spec.Networking.Cilium.IPAM == kops.CiliumIpamEni {
-		addCiliumEniPermissions(p)
+		addCiliumEniPermissions(p, b.Cluster.Spec.Networking.Cilium.ENI)
 	}
 
 	if b.Cluster.Spec.Networking != nil && b.Cluster.Spec.Networking.Calico != nil && b.Cluster.Spec.Networking.Calico.AWSSrcDstCheck != "DoNothing" {
 		addCalicoSrcDstCheckPermissions(p)
 	}
 
+	if b.cloudWatchAgentEnabled(kops.InstanceGroupRoleMaster) {
+		addCloudWatchAgentPermissions(p)
+	}
+
+	if b.spotFallbackEnabled() {
+		addSpotFallbackPermissions(p)
+	}
+
 	return p, nil
 }
 
@@ -482,6 +570,10 @@ func (r *NodeRoleNode) BuildAWSPolicy(b *PolicyBuilder) (*Policy, error) {
 		addCalicoSrcDstCheckPermissions(p)
 	}
 
+	if b.cloudWatchAgentEnabled(kops.InstanceGroupRoleNode) {
+		addCloudWatchAgentPermissions(p)
+	}
+
 	return p, nil
 }
 
@@ -642,7 +734,7 @@ func (b *PolicyBuilder) buildS3WriteStatements(p *Policy, iamS3Path string) {
 }
 
 func (b *PolicyBuilder) buildS3GetStatements(p *Policy, iamS3Path string) error {
-	resources, err := ReadableStatePaths(b.Cluster, b.Role)
+	resources, err := ReadableStatePaths(b.Cluster, b.InstanceGroups, b.Role)
 	if err != nil {
 		return err
 	}
@@ -672,10 +764,14 @@ func WriteableVFSPaths(cluster *kops.Cluster, role Subject) ([]vfs.Path, error)
 	case *NodeRoleMaster:
 		backupStores := sets.NewString()
 		for _, c := range cluster.Spec.EtcdClusters {
-			if c.Backups == nil || c.Backups.BackupStore == "" || backupStores.Has(c.Backups.BackupStore) {
+			backupStore := c.Backups.ActiveBackupStore()
+			if backupStore == "" || backupStores.Has(backupStore) {
+				continue
+			}
+			// Access to a cross-account store comes from the role etcd-manager assumes
+			if c.Backups.CrossAccountStore != nil && c.Backups.CrossAccountStore.RoleARN != "" {
 				continue
 			}
-			backupStore := c.Backups.BackupStore
 
 			vfsPath, err := vfs.Context.BuildVfsPath(backupStore)
 			if err != nil {
@@ -692,7 +788,7 @@ func WriteableVFSPaths(cluster *kops.Cluster, role Subject) ([]vfs.Path, error)
 }
 
 // ReadableStatePaths returns the file paths that should be readable in the cluster's state store "directory"
-func ReadableStatePaths(cluster *kops.Cluster, role Subject) ([]string, error) {
+func ReadableStatePaths(cluster *kops.Cluster, instanceGroups []*kops.InstanceGroup, role Subject) ([]string, error) {
 	var paths []string
 
 	switch role.(type) {
@@ -707,6 +803,11 @@ func ReadableStatePaths(cluster *kops.Cluster, role Subject) ([]string, error) {
 			"/secrets/dockerconfig",
 		)
 
+		// Give access to the passwords of the containerd registry mirrors
+		for _, secret := range registryPasswordSecrets(cluster, instanceGroups) {
+			paths = append(paths, "/secrets/"+secret)
+		}
+
 		// Give access to keys for client certificates as needed.
 		if !model.UseKopsControllerForNodeBootstrap(cluster) {
 			paths = append(paths, "/pki/private/kube-proxy/*")
@@ -736,6 +837,31 @@ func ReadableStatePaths(cluster *kops.Cluster, role Subject) ([]string, error) {
 	return paths, nil
 }
 
+// registryPasswordSecrets returns the names of the secrets holding the passwords of the containerd registry mirrors of the nodes.
+func registryPasswordSecrets(cluster *kops.Cluster, instanceGroups []*kops.InstanceGroup) []string {
+	configs := []*kops.ContainerdConfig{cluster.Spec.Containerd}
+	for _, ig := range instanceGroups {
+		if ig.Spec.Role == kops.InstanceGroupRoleNode {
+			configs = append(configs, ig.Spec.Containerd)
+		}
+	}
+
+	secrets := sets.NewString()
+	for _, config := range configs {
+		if config == nil {
+			continue
+		}
+		for _, registry := range config.Registries {
+			for _, mirror := range registry.Mirrors {
+				if mirror.Auth != nil {
+					secrets.Insert(mirror.Auth.PasswordSecret)
+				}
+			}
+		}
+	}
+	return secrets.List()
+}
+
 // PolicyResource defines the PolicyBuilder and DNSZone to use when building the
 // IAM policy document for a given instance group role
 type PolicyResource struct {
@@ -821,6 +947,51 @@ func addCalicoSrcDstCheckPermissions(p *Policy) {
 	)
 }
 
+// cloudWatchAgentEnabled is true if any instance group with the given role runs the CloudWatch agent.
+func (b *PolicyBuilder) cloudWatchAgentEnabled(role kops.InstanceGroupRole) bool {
+	for _, ig := range b.InstanceGroups {
+		if ig.Spec.Role == role && model.CloudWatchAgentEnabled(b.Cluster, ig) {
+			return true
+		}
+	}
+	return false
+}
+
+func addCloudWatchAgentPermissions(p *Policy) {
+	p.unconditionalAction.Insert(
+		"cloudwatch:PutMetricData",
+		"ec2:DescribeTags",
+		"ec2:DescribeVolumes",
+		"logs:CreateLogGroup",
+		"logs:CreateLogStream",
+		"logs:DescribeLogGroups",
+		"logs:DescribeLogStreams",
+		"logs:PutLogEvents",
+	)
+}
+
+// spotFallbackEnabled is true if kops-controller manages the Spot fallback of any instance group.
+func (b *PolicyBuilder) spotFallbackEnabled() bool {
+	for _, ig := range b.InstanceGroups {
+		if model.SpotFallbackEnabled(b.Cluster, ig) {
+			return true
+		}
+	}
+	return false
+}
+
+func addSpotFallbackPermissions(p *Policy) {
+	p.clusterTaggedAction.Insert(
+		"autoscaling:CreateOrUpdateTags",
+		"autoscaling:DeleteTags",
+		"autoscaling:UpdateAutoScalingGroup",
+	)
+	p.unconditionalAction.Insert(
+		"autoscaling:DescribeAutoScalingGroups",
+		"autoscaling:DescribeScalingActivities",
+	)
+}
+
 func (b *PolicyBuilder) addNodeupPermissions(p *Policy, enableHookSupport bool) {
 	addCertIAMPolicies(p)
 	addKMSGenerateRandomPolicies(p)
@@ -849,21 +1020,54 @@ func addEtcdManagerPermissions(p *Policy) {
 		"ec2:DescribeVolumes", // aws.go
 	)
 
-	p.Statement = append(p.Statement,
-		&Statement{
-			Effect: StatementEffectAllow,
-			Action: stringorslice.Of(
-				"ec2:AttachVolume",
-			),
-			Resource: stringorslice.Slice([]string{"*"}),
-			Condition: Condition{
-				"StringEquals": map[string]string{
-					"aws:ResourceTag/k8s.io/role/master": "1",
-					"aws:ResourceTag/KubernetesCluster":  p.clusterName,
-				},
+	statement := &Statement{
+		Effect: StatementEffectAllow,
+		Action: stringorslice.Of(
+			"ec2:AttachVolume",
+		),
+		Resource: stringorslice.Slice([]string{"*"}),
+		Condition: Condition{
+			"StringEquals": map[string]string{
+				"aws:ResourceTag/k8s.io/role/master": "1",
+				"aws:ResourceTag/KubernetesCluster":  p.clusterName,
 			},
 		},
-	)
+	}
+	p.Statement = append(p.Statement, statement)
+	p.scopedStatements = append(p.scopedStatements, statement)
+}
+
+// addEtcdCrossAccountStorePermissions allows etcd-manager to assume the roles giving access to cross-account backup stores
+func addEtcdCrossAccountStorePermissions(p *Policy, cluster *kops.Cluster) {
+	roleARNs := sets.NewString()
+	for _, c := range cluster.Spec.EtcdClusters {
+		if c.Backups != nil && c.Backups.CrossAccountStore != nil && c.Backups.CrossAccountStore.RoleARN != "" {
+			roleARNs.Insert(c.Backups.CrossAccountStore.RoleARN)
+		}
+	}
+	if roleARNs.Len() == 0 {
+		return
+	}
+
+	p.Statement = append(p.Statement, &Statement{
+		Effect:   StatementEffectAllow,
+		Action:   stringorslice.Of("sts:AssumeRole"),
+		Resource: stringorslice.Slice(roleARNs.List()),
+	})
+}
+
+// addKeystoreKMSPermissions allows unwrapping the private keys read from the keystore
+func addKeystoreKMSPermissions(p *Policy, cluster *kops.Cluster) {
+	keyARN := cluster.Spec.KeyStoreKMSKey
+	if !strings.HasPrefix(keyARN, "arn:") {
+		return
+	}
+
+	p.Statement = append(p.Statement, &Statement{
+		Effect:   StatementEffectAllow,
+		Action:   stringorslice.Of("kms:Decrypt"),
+		Resource: stringorslice.Of(keyARN),
+	})
 }
 
 func AddLegacyCCMPermissions(p *Policy) {
@@ -1184,7 +1388,11 @@ func addCertIAMPolicies(p *Policy) {
 	)
 }
 
-func addCiliumEniPermissions(p *Policy) {
+func addCiliumEniPermissions(p *Policy, eni *kops.CiliumENISpec) {
+	if eni != nil && eni.UpdateEC2AdapterLimitViaAPI {
+		p.unconditionalAction.Insert("ec2:DescribeInstanceTypes")
+	}
+
 	p.unconditionalAction.Insert(
 		"ec2:DescribeSubnets",
 		"ec2:AttachNetworkInterface",
@@ -99,6 +99,12 @@ func TestPolicyGeneration(t *testing.T) {
 	grid := []struct {
 		Role                   Subject
 		AllowContainerRegistry bool
+		CloudWatchAgent        bool
+		SpotFallback           bool
+		ScopeResourceARNs      bool
+		EtcdCrossAccountStore  bool
+		RegistryPasswords      bool
+		KeystoreKMSKey         string
 		Policy                 string
 	}{
 		{
@@ -111,6 +117,30 @@ func TestPolicyGeneration(t *testing.T) {
 			AllowContainerRegistry: true,
 			Policy:                 "tests/iam_builder_master_strict_ecr.json",
 		},
+		{
+			Role:                   &NodeRoleMaster{},
+			AllowContainerRegistry: false,
+			SpotFallback:           true,
+			Policy:                 "tests/iam_builder_master_spot_fallback.json",
+		},
+		{
+			Role:                   &NodeRoleMaster{},
+			AllowContainerRegistry: false,
+			ScopeResourceARNs:      true,
+			Policy:                 "tests/iam_builder_master_scoped.json",
+		},
+		{
+			Role:                   &NodeRoleMaster{},
+			AllowContainerRegistry: false,
+			EtcdCrossAccountStore:  true,
+			Policy:                 "tests/iam_builder_master_etcd_cross_account.json",
+		},
+		{
+			Role:                   &NodeRoleMaster{},
+			AllowContainerRegistry: false,
+			KeystoreKMSKey:         "arn:aws-test:kms:us-test-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+			Policy:                 "tests/iam_builder_master_keystore_kms.json",
+		},
 		{
 			Role:                   &NodeRoleNode{},
 			AllowContainerRegistry: false,
@@ -121,6 +151,18 @@ func TestPolicyGeneration(t *testing.T) {
 			AllowContainerRegistry: true,
 			Policy:                 "tests/iam_builder_node_strict_ecr.json",
 		},
+		{
+			Role:                   &NodeRoleNode{},
+			AllowContainerRegistry: false,
+			CloudWatchAgent:        true,
+			Policy:                 "tests/iam_builder_node_cloudwatch_agent.json",
+		},
+		{
+			Role:                   &NodeRoleNode{},
+			AllowContainerRegistry: false,
+			RegistryPasswords:      true,
+			Policy:                 "tests/iam_builder_node_registry_passwords.json",
+		},
 		{
 			Role:                   &NodeRoleBastion{},
 			AllowContainerRegistry: false,
@@ -133,13 +175,18 @@ func TestPolicyGeneration(t *testing.T) {
 		},
 	}
 
+	// The keystore KMS key is taken from the cluster spec, never from the environment of the machine running kops
+	t.Setenv("KOPS_KEYSTORE_KMS_KEY", "")
+
 	for i, x := range grid {
 		b := &PolicyBuilder{
 			Cluster: &kops.Cluster{
 				Spec: kops.ClusterSpec{
-					ConfigStore: "s3://kops-tests/iam-builder-test.k8s.local",
+					ConfigStore:    "s3://kops-tests/iam-builder-test.k8s.local",
+					KeyStoreKMSKey: x.KeystoreKMSKey,
 					IAM: &kops.IAMSpec{
 						AllowContainerRegistry: x.AllowContainerRegistry,
+						ScopeResourceARNs:      x.ScopeResourceARNs,
 					},
 					EtcdClusters: []kops.EtcdClusterSpec{
 						{
@@ -174,9 +221,77 @@ func TestPolicyGeneration(t *testing.T) {
 				},
 			},
 			Role:      x.Role,
+			Region:    "us-test-1",
 			Partition: "aws-test",
+			AccountID: "123456789012",
 		}
 		b.Cluster.SetName("iam-builder-test.k8s.local")
+		if x.CloudWatchAgent {
+			b.Cluster.Spec.CloudProvider.AWS = &kops.AWSSpec{}
+			b.Cluster.Spec.Monitoring = &kops.MonitoringSpec{
+				CloudWatchAgent: &kops.MonitoringAgentConfig{
+					Enabled: fi.Bool(true),
+				},
+			}
+			b.InstanceGroups = []*kops.InstanceGroup{
+				{
+					Spec: kops.InstanceGroupSpec{
+						Role: kops.InstanceGroupRoleNode,
+					},
+				},
+			}
+		}
+		if x.EtcdCrossAccountStore {
+			b.Cluster.Spec.EtcdClusters[0].Backups = &kops.EtcdBackupSpec{
+				BackupStore: "s3://kops-tests/iam-builder-test.k8s.local/backups/etcd/main",
+				CrossAccountStore: &kops.EtcdCrossAccountStoreSpec{
+					BackupStore: "s3://etcd-backups/iam-builder-test.k8s.local/main",
+					RoleARN:     "arn:aws-test:iam::210987654321:role/etcd-backups",
+				},
+			}
+			b.Cluster.Spec.EtcdClusters[1].Backups = &kops.EtcdBackupSpec{
+				BackupStore: "s3://kops-tests/iam-builder-test.k8s.local/backups/etcd/events",
+				CrossAccountStore: &kops.EtcdCrossAccountStoreSpec{
+					BackupStore: "s3://etcd-backups/iam-builder-test.k8s.local/events",
+				},
+			}
+		}
+		if x.RegistryPasswords {
+			mirror := func(secret string) kops.ContainerdRegistryConfig {
+				return kops.ContainerdRegistryConfig{Mirrors: []kops.ContainerdRegistryMirror{
+					{Endpoint: "https://mirror.example.com", Auth: &kops.ContainerdRegistryAuth{Username: "user", PasswordSecret: secret}},
+				}}
+			}
+			b.Cluster.Spec.Containerd = &kops.ContainerdConfig{
+				Registries: map[string]kops.ContainerdRegistryConfig{"docker.io": mirror("docker-mirror-password")},
+			}
+			b.InstanceGroups = []*kops.InstanceGroup{
+				{
+					Spec: kops.InstanceGroupSpec{
+						Role: kops.InstanceGroupRoleNode,
+						Containerd: &kops.ContainerdConfig{
+							Registries: map[string]kops.ContainerdRegistryConfig{"quay.io": mirror("quay-mirror-password")},
+						},
+					},
+				},
+			}
+		}
+		if x.SpotFallback {
+			b.Cluster.Spec.CloudProvider.AWS = &kops.AWSSpec{}
+			b.InstanceGroups = []*kops.InstanceGroup{
+				{
+					Spec: kops.InstanceGroupSpec{
+						Role: kops.InstanceGroupRoleNode,
+						MixedInstancesPolicy: &kops.MixedInstancesPolicySpec{
+							OnDemandAboveBase: fi.Int64(0),
+							SpotFallback: &kops.SpotFallbackSpec{
+								Enabled: fi.Bool(true),
+							},
+						},
+					},
+				},
+			}
+		}
 
 		p, err := b.BuildAWSPolicy()
 		if err != nil {
@@ -123,7 +123,7 @@ func addServiceAccountRoleForAWS(context *IAMModelContext, podSpec *corev1.PodSp
 		return err
 	}
 
-	awsRoleARN := "arn:" + context.AWSPartition + ":iam::" + context.AWSAccountID + ":role/" + roleName
+	awsRoleARN := context.IAMRoleARN(roleName)
 	tokenDir := "/var/run/secrets/amazonaws.com/"
 	tokenName := "token"
 
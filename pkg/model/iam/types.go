@@ -56,6 +56,19 @@ func (b *IAMModelContext) IAMNameForServiceAccountRole(role Subject) (string, er
 	return name, nil
 }
 
+// IAMRoleARN returns the ARN of the IAM role with the given name, which is created under the IAM path of the cluster
+func (b *IAMModelContext) IAMRoleARN(roleName string) string {
+	return "arn:" + b.AWSPartition + ":iam::" + b.AWSAccountID + ":role" + IAMRolePath(b.Cluster) + roleName
+}
+
+// IAMRolePath returns the path of the IAM roles created for the cluster
+func IAMRolePath(cluster *kops.Cluster) string {
+	if cluster.Spec.IAM != nil && cluster.Spec.IAM.Path != "" {
+		return cluster.Spec.IAM.Path
+	}
+	return "/"
+}
+
 // ClusterName returns the cluster name
 func (b *IAMModelContext) ClusterName() string {
 	return b.Cluster.ObjectMeta.Name
@@ -123,7 +123,22 @@ func (o *OIDCKeys) GetDependencies(tasks map[string]fi.Task) []fi.Task {
 }
 
 func (o *OIDCKeys) Open() (io.Reader, error) {
-	keyset := o.SigningKey.Keyset()
+	keyResponse, err := BuildJWKS(o.SigningKey.Keyset())
+	if err != nil {
+		return nil, err
+	}
+
+	jsonBytes, err := json.MarshalIndent(keyResponse, "", "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json: %w", err)
+	}
+
+	return bytes.NewReader(jsonBytes), nil
+}
+
+// BuildJWKS returns the JSON Web Key Set of the trusted keys of the service-account keyset.
+// While the keyset is rotated, it contains both the previous and the new keys.
+func BuildJWKS(keyset *fi.Keyset) (*KeyResponse, error) {
 	var keys []jose.JSONWebKey
 
 	for _, item := range keyset.Items {
@@ -154,11 +169,5 @@ func (o *OIDCKeys) Open() (io.Reader, error) {
 		return keys[i].KeyID < keys[j].KeyID
 	})
 
-	keyResponse := KeyResponse{Keys: keys}
-	jsonBytes, err := json.MarshalIndent(keyResponse, "", "")
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal json: %w", err)
-	}
-
-	return bytes.NewReader(jsonBytes), nil
+	return &KeyResponse{Keys: keys}, nil
 }
@@ -42,6 +42,8 @@ func (b *PKIModelBuilder) Build(c *fi.ModelBuilderContext) error {
 		Lifecycle: b.Lifecycle,
 		Subject:   "cn=kubernetes-ca",
 		Type:      "ca",
+
+		ExternalIssuer: b.ExternalCertificateIssuer(),
 	}
 	c.AddTask(defaultCA)
 
@@ -87,6 +89,8 @@ func (b *PKIModelBuilder) Build(c *fi.ModelBuilderContext) error {
 			Lifecycle: b.Lifecycle,
 			Subject:   "cn=apiserver-aggregator-ca",
 			Type:      "ca",
+
+			ExternalIssuer: b.ExternalCertificateIssuer(),
 		}
 		c.AddTask(aggregatorCA)
 	}
@@ -0,0 +1,147 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/kops/pkg/systemd"
+)
+
+const (
+	// ignitionVersion is the version of the Ignition config spec, supported by Flatcar 3185 and later
+	ignitionVersion = "3.3.0"
+	// ignitionDefaultUser is the default user of Flatcar Container Linux
+	ignitionDefaultUser = "core"
+	// ignitionBootstrapScriptPath is the path where Ignition writes the nodeup (bootstrap) script
+	ignitionBootstrapScriptPath = "/opt/kops/bootstrap-script"
+	// ignitionBootstrapServiceName is the name of the unit that runs the nodeup (bootstrap) script
+	ignitionBootstrapServiceName = "kops-bootstrap.service"
+)
+
+type ignitionConfig struct {
+	Ignition ignitionMetadata `json:"ignition"`
+	Passwd   *ignitionPasswd  `json:"passwd,omitempty"`
+	Storage  ignitionStorage  `json:"storage"`
+	Systemd  ignitionSystemd  `json:"systemd"`
+}
+
+type ignitionMetadata struct {
+	Version string `json:"version"`
+}
+
+type ignitionPasswd struct {
+	Users []ignitionUser `json:"users"`
+}
+
+type ignitionUser struct {
+	Name              string   `json:"name"`
+	SSHAuthorizedKeys []string `json:"sshAuthorizedKeys"`
+}
+
+type ignitionStorage struct {
+	Files []ignitionFile `json:"files"`
+}
+
+type ignitionFile struct {
+	Path      string           `json:"path"`
+	Mode      int              `json:"mode"`
+	Overwrite bool             `json:"overwrite"`
+	Contents  ignitionResource `json:"contents"`
+}
+
+type ignitionResource struct {
+	Source      string `json:"source"`
+	Compression string `json:"compression,omitempty"`
+}
+
+type ignitionSystemd struct {
+	Units []ignitionUnit `json:"units"`
+}
+
+type ignitionUnit struct {
+	Name     string `json:"name"`
+	Enabled  bool   `json:"enabled"`
+	Contents string `json:"contents"`
+}
+
+// IgnitionConfig returns an Ignition config that writes the nodeup (bootstrap) script and runs it on first boot.
+// The sshAuthorizedKeys are added to the default user.
+func IgnitionConfig(bootScript string, sshAuthorizedKeys []string) (string, error) {
+	script, err := gzipBase64(bootScript)
+	if err != nil {
+		return "", err
+	}
+
+	// nodeup installs kops-configuration.service, which runs nodeup on the next boots
+	manifest := &systemd.Manifest{}
+	manifest.Set("Unit", "Description", "Run the kOps bootstrap script")
+	manifest.Set("Unit", "Documentation", "https://github.com/kubernetes/kops")
+	manifest.Set("Unit", "Wants", "network-online.target")
+	manifest.Set("Unit", "After", "network-online.target")
+	manifest.Set("Unit", "ConditionPathExists", "!/etc/systemd/system/kops-configuration.service")
+	manifest.Set("Service", "Type", "oneshot")
+	manifest.Set("Service", "RemainAfterExit", "yes")
+	manifest.Set("Service", "ExecStart", ignitionBootstrapScriptPath)
+	manifest.Set("Install", "WantedBy", "multi-user.target")
+
+	config := ignitionConfig{
+		Ignition: ignitionMetadata{
+			Version: ignitionVersion,
+		},
+		Storage: ignitionStorage{
+			Files: []ignitionFile{
+				{
+					Path:      ignitionBootstrapScriptPath,
+					Mode:      0755,
+					Overwrite: true,
+					Contents: ignitionResource{
+						Source:      "data:;base64," + script,
+						Compression: "gzip",
+					},
+				},
+			},
+		},
+		Systemd: ignitionSystemd{
+			Units: []ignitionUnit{
+				{
+					Name:     ignitionBootstrapServiceName,
+					Enabled:  true,
+					Contents: manifest.Render(),
+				},
+			},
+		},
+	}
+
+	if len(sshAuthorizedKeys) > 0 {
+		config.Passwd = &ignitionPasswd{
+			Users: []ignitionUser{
+				{
+					Name:              ignitionDefaultUser,
+					SSHAuthorizedKeys: sshAuthorizedKeys,
+				},
+			},
+		}
+	}
+
+	data, err := json.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("error building Ignition config: %v", err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_IgnitionConfig(t *testing.T) {
+	bootScript := "#!/bin/bash\necho nodeup\n"
+	keys := []string{"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIBoB6Gtu8zPAPO1yF4OwysWUD8ZSEQYzMpOT0YvF9qJV user@example"}
+
+	data, err := IgnitionConfig(bootScript, keys)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var config ignitionConfig
+	if err := json.Unmarshal([]byte(data), &config); err != nil {
+		t.Fatalf("error parsing Ignition config: %v", err)
+	}
+	if config.Ignition.Version != ignitionVersion {
+		t.Errorf("expected Ignition version %q, got %q", ignitionVersion, config.Ignition.Version)
+	}
+
+	if config.Passwd == nil || len(config.Passwd.Users) != 1 {
+		t.Fatalf("expected a single user, got %v", config.Passwd)
+	}
+	if user := config.Passwd.Users[0]; user.Name != "core" || !reflect.DeepEqual(user.SSHAuthorizedKeys, keys) {
+		t.Errorf("unexpected user %v", user)
+	}
+
+	if len(config.Storage.Files) != 1 {
+		t.Fatalf("expected a single file, got %v", config.Storage.Files)
+	}
+	file := config.Storage.Files[0]
+	if file.Path != ignitionBootstrapScriptPath || file.Mode != 0755 || file.Contents.Compression != "gzip" {
+		t.Errorf("unexpected file %v", file)
+	}
+	compressed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(file.Contents.Source, "data:;base64,"))
+	if err != nil {
+		t.Fatalf("error decoding file contents: %v", err)
+	}
+	r, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		t.Fatalf("error decompressing file contents: %v", err)
+	}
+	script, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("error decompressing file contents: %v", err)
+	}
+	if string(script) != bootScript {
+		t.Errorf("expected the bootstrap script, got %q", script)
+	}
+
+	if len(config.Systemd.Units) != 1 {
+		t.Fatalf("expected a single unit, got %v", config.Systemd.Units)
+	}
+	unit := config.Systemd.Units[0]
+	if unit.Name != ignitionBootstrapServiceName || !unit.Enabled || !strings.Contains(unit.Contents, "ExecStart="+ignitionBootstrapScriptPath+"\n") {
+		t.Errorf("unexpected unit %v", unit)
+	}
+
+	data, err = IgnitionConfig(bootScript, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(data, "passwd") {
+		t.Errorf("expected no users without SSH authorized keys, got %q", data)
+	}
+}
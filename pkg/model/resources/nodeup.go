@@ -31,6 +31,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/util/pkg/architectures"
 	"k8s.io/kops/util/pkg/mirrors"
+	"sigs.k8s.io/yaml"
 )
 
 var nodeUpTemplate = `#!/bin/bash
@@ -188,6 +189,8 @@ type NodeUpScript struct {
 	ProxyEnv             func() (string, error)
 	EnvironmentVariables func() (string, error)
 	ClusterSpec          func() (string, error)
+	// Windows renders a PowerShell script for Windows nodes, which only run on amd64.
+	Windows bool
 }
 
 func funcEmptyString() (string, error) {
@@ -256,6 +259,9 @@ func (b *NodeUpScript) Build() (fi.Resource, error) {
 		"ClusterSpec":          b.ClusterSpec,
 	}
 
+	if b.Windows {
+		return newTemplateResource("nodeup", nodeUpPowerShellTemplate, functions, nil)
+	}
 	return newTemplateResource("nodeup", nodeUpTemplate, functions, nil)
 }
 
@@ -280,11 +286,13 @@ func gzipBase64(data string) (string, error) {
 }
 
 // AWSMultipartMIME returns a MIME Multi Part Archive containing the nodeup (bootstrap) script
-// and any additional User Data passed to using AdditionalUserData in the IG Spec
-func AWSMultipartMIME(bootScript string, ig *kops.InstanceGroup) (string, error) {
+// and any additional User Data passed to using AdditionalUserData in the IG Spec.
+// The sshAuthorizedKeys are added to the default user through a cloud-config part,
+// which is merged with the cloud-config set in the CloudInit of the IG Spec.
+func AWSMultipartMIME(bootScript string, ig *kops.InstanceGroup, sshAuthorizedKeys []string) (string, error) {
 	userData := bootScript
 
-	if len(ig.Spec.AdditionalUserData) > 0 {
+	if len(ig.Spec.AdditionalUserData) > 0 || len(sshAuthorizedKeys) > 0 || ig.Spec.CloudInit != nil {
 		/* Create a buffer to hold the user-data*/
 		buffer := bytes.NewBufferString("")
 		writer := bufio.NewWriter(buffer)
@@ -308,6 +316,34 @@ func AWSMultipartMIME(bootScript string, ig *kops.InstanceGroup) (string, error)
 			}
 		}
 
+		cloudConfig := make(map[string]interface{})
+		cloudConfigName := "ssh-authorized-keys.cfg"
+		if len(sshAuthorizedKeys) > 0 {
+			keys := make([]interface{}, 0, len(sshAuthorizedKeys))
+			for _, key := range sshAuthorizedKeys {
+				keys = append(keys, key)
+			}
+			cloudConfig["ssh_authorized_keys"] = keys
+		}
+		if ig.Spec.CloudInit != nil && ig.Spec.CloudInit.CloudConfig != "" {
+			userCloudConfig := make(map[string]interface{})
+			if err := yaml.Unmarshal([]byte(ig.Spec.CloudInit.CloudConfig), &userCloudConfig); err != nil {
+				return "", fmt.Errorf("error parsing cloud-config of instance group %q: %v", ig.ObjectMeta.Name, err)
+			}
+			mergeCloudConfig(cloudConfig, userCloudConfig)
+			cloudConfigName = "cloud-config.cfg"
+		}
+		if len(cloudConfig) > 0 {
+			data, err := yaml.Marshal(cloudConfig)
+			if err != nil {
+				return "", fmt.Errorf("error building cloud-config: %v", err)
+			}
+			err = writeUserDataPart(mimeWriter, cloudConfigName, "text/cloud-config", append([]byte("#cloud-config\n"), data...))
+			if err != nil {
+				return "", err
+			}
+		}
+
 		for _, d := range ig.Spec.AdditionalUserData {
 			err = writeUserDataPart(mimeWriter, d.Name, d.Type, []byte(d.Content))
 			if err != nil {
@@ -326,6 +362,26 @@ func AWSMultipartMIME(bootScript string, ig *kops.InstanceGroup) (string, error)
 	return userData, nil
 }
 
+// mergeCloudConfig merges the src cloud-config into dst.
+// Lists are appended, maps are merged recursively and any other value in src replaces the one in dst.
+func mergeCloudConfig(dst, src map[string]interface{}) {
+	for k, v := range src {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			if existing, ok := dst[k].(map[string]interface{}); ok {
+				mergeCloudConfig(existing, v)
+				continue
+			}
+		case []interface{}:
+			if existing, ok := dst[k].([]interface{}); ok {
+				dst[k] = append(existing, v...)
+				continue
+			}
+		}
+		dst[k] = v
+	}
+}
+
 func writeUserDataPart(mimeWriter *multipart.Writer, fileName string, contentType string, content []byte) error {
 	header := textproto.MIMEHeader{}
 
@@ -17,8 +17,11 @@ limitations under the License.
 package resources
 
 import (
+	"reflect"
 	"strings"
 	"testing"
+
+	"k8s.io/kops/pkg/apis/kops"
 )
 
 func Test_NodeUpTabs(t *testing.T) {
@@ -27,4 +30,103 @@ func Test_NodeUpTabs(t *testing.T) {
 			t.Errorf("NodeUpTemplate contains unexpected character %q on line %d: %q", "\t", i, line)
 		}
 	}
+	for i, line := range strings.Split(nodeUpPowerShellTemplate, "\n") {
+		if strings.Contains(line, "\t") {
+			t.Errorf("NodeUpPowerShellTemplate contains unexpected character %q on line %d: %q", "\t", i, line)
+		}
+	}
+}
+
+func Test_AWSMultipartMIMESSHAuthorizedKeys(t *testing.T) {
+	ig := &kops.InstanceGroup{
+		Spec: kops.InstanceGroupSpec{
+			Role: kops.InstanceGroupRoleNode,
+		},
+	}
+
+	userData, err := AWSMultipartMIME("#!/bin/bash\necho nodeup\n", ig, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if userData != "#!/bin/bash\necho nodeup\n" {
+		t.Errorf("expected the bootstrap script without additional keys, got %q", userData)
+	}
+
+	userData, err = AWSMultipartMIME("#!/bin/bash\necho nodeup\n", ig, []string{"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIBoB6Gtu8zPAPO1yF4OwysWUD8ZSEQYzMpOT0YvF9qJV user@example"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, expected := range []string{
+		"Content-Type: multipart/mixed; boundary=\"MIMEBOUNDARY\"",
+		"Content-Disposition: attachment; filename=\"nodeup.sh\"",
+		"Content-Disposition: attachment; filename=\"ssh-authorized-keys.cfg\"",
+		"#cloud-config\nssh_authorized_keys:\n- ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIBoB6Gtu8zPAPO1yF4OwysWUD8ZSEQYzMpOT0YvF9qJV\n  user@example\n",
+	} {
+		if !strings.Contains(userData, expected) {
+			t.Errorf("expected user-data to contain %q, got %q", expected, userData)
+		}
+	}
+}
+
+func Test_AWSMultipartMIMECloudInit(t *testing.T) {
+	ig := &kops.InstanceGroup{
+		Spec: kops.InstanceGroupSpec{
+			Role: kops.InstanceGroupRoleNode,
+			CloudInit: &kops.CloudInitSpec{
+				CloudConfig: "ssh_authorized_keys:\n- ssh-rsa AAAA user@example\npackages:\n- nfs-common\n",
+			},
+		},
+	}
+
+	userData, err := AWSMultipartMIME("#!/bin/bash\necho nodeup\n", ig, []string{"ssh-ed25519 AAAA kops@example"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, expected := range []string{
+		"Content-Type: multipart/mixed; boundary=\"MIMEBOUNDARY\"",
+		"Content-Disposition: attachment; filename=\"nodeup.sh\"",
+		"Content-Disposition: attachment; filename=\"cloud-config.cfg\"",
+		"#cloud-config\npackages:\n- nfs-common\nssh_authorized_keys:\n- ssh-ed25519 AAAA kops@example\n- ssh-rsa AAAA user@example\n",
+	} {
+		if !strings.Contains(userData, expected) {
+			t.Errorf("expected user-data to contain %q, got %q", expected, userData)
+		}
+	}
+
+	ig.Spec.CloudInit.CloudConfig = "packages: nfs-common: true"
+	if _, err := AWSMultipartMIME("#!/bin/bash\necho nodeup\n", ig, nil); err == nil {
+		t.Errorf("expected an error for an invalid cloud-config")
+	}
+}
+
+func Test_MergeCloudConfig(t *testing.T) {
+	dst := map[string]interface{}{
+		"packages": []interface{}{"curl"},
+		"apt": map[string]interface{}{
+			"preserve_sources_list": false,
+		},
+		"hostname": "node",
+	}
+	src := map[string]interface{}{
+		"packages": []interface{}{"nfs-common"},
+		"apt": map[string]interface{}{
+			"http_proxy": "http://proxy:3128",
+		},
+		"hostname": "custom",
+		"timezone": "UTC",
+	}
+	mergeCloudConfig(dst, src)
+
+	expected := map[string]interface{}{
+		"packages": []interface{}{"curl", "nfs-common"},
+		"apt": map[string]interface{}{
+			"preserve_sources_list": false,
+			"http_proxy":            "http://proxy:3128",
+		},
+		"hostname": "custom",
+		"timezone": "UTC",
+	}
+	if !reflect.DeepEqual(dst, expected) {
+		t.Errorf("unexpected merged cloud-config: %v, expected %v", dst, expected)
+	}
 }
@@ -0,0 +1,124 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+// nodeUpPowerShellTemplate is the bootstrap script of Windows nodes.
+// It is run by EC2Launch, which executes the content of the <powershell> tag of the user-data.
+var nodeUpPowerShellTemplate = `<powershell>
+$ErrorActionPreference = "Stop"
+$ProgressPreference = "SilentlyContinue"
+
+$NodeUpUrl = "{{ NodeUpSourceAmd64 }}"
+$NodeUpHash = "{{ NodeUpSourceHashAmd64 }}"
+
+{{ EnvironmentVariables }}
+
+$InstallDir = "C:\kops"
+
+function Ensure-InstallDir {
+  New-Item -ItemType Directory -Force -Path "$InstallDir\bin" | Out-Null
+  New-Item -ItemType Directory -Force -Path "$InstallDir\conf" | Out-Null
+}
+
+# Retry a download until we get it. args: file, hash, urls
+function Download-OrBust([string]$File, [string]$Hash, [string]$Urls) {
+  if (Test-Path $File) {
+    if (Validate-Hash $File $Hash) {
+      return
+    }
+    Remove-Item -Force $File
+  }
+
+  while ($true) {
+    foreach ($url in $Urls.Split(",")) {
+      Write-Output "Attempting download of $url"
+      try {
+        Invoke-WebRequest -UseBasicParsing -Uri $url -OutFile $File -TimeoutSec 20
+      } catch {
+        Write-Output "== Download of $url failed: $_ =="
+        continue
+      }
+      if (Validate-Hash $File $Hash) {
+        Write-Output "== Downloaded $url (SHA256 = $Hash) =="
+        return
+      }
+      Write-Output "== Hash validation of $url failed. Retrying. =="
+      Remove-Item -Force $File
+    }
+
+    Write-Output "All downloads failed; sleeping before retrying"
+    Start-Sleep -Seconds 60
+  }
+}
+
+function Validate-Hash([string]$File, [string]$Expected) {
+  $actual = (Get-FileHash -Algorithm SHA256 -Path $File).Hash
+  if ($actual -ne $Expected) {
+    Write-Host "== $File corrupted, hash $actual doesn't match expected $Expected =="
+    return $false
+  }
+  return $true
+}
+
+function Expand-GzipBase64([string]$Data, [string]$File) {
+  $compressed = New-Object System.IO.MemoryStream(, [System.Convert]::FromBase64String($Data))
+  $gzip = New-Object System.IO.Compression.GZipStream($compressed, [System.IO.Compression.CompressionMode]::Decompress)
+  $output = [System.IO.File]::Create($File)
+  try {
+    $gzip.CopyTo($output)
+  } finally {
+    $output.Close()
+    $gzip.Close()
+  }
+}
+
+function Download-Release {
+  Download-OrBust "$InstallDir\bin\nodeup.exe" $NodeUpHash $NodeUpUrl
+
+  Write-Output "Running nodeup"
+  & "$InstallDir\bin\nodeup.exe" --conf="$InstallDir\conf\kube_env.yaml" --v=8
+  if ($LASTEXITCODE -ne 0) {
+    throw "nodeup failed with exit code $LASTEXITCODE"
+  }
+}
+
+####################################################################################
+
+Write-Output "== nodeup node config starting =="
+Ensure-InstallDir
+
+{{ if CompressUserData -}}
+Expand-GzipBase64 "{{ GzipBase64 ClusterSpec }}" "$InstallDir\conf\cluster_spec.yaml"
+{{- else -}}
+Set-Content -Path "$InstallDir\conf\cluster_spec.yaml" -Value @'
+{{ ClusterSpec }}
+'@
+{{- end }}
+
+{{ if CompressUserData -}}
+Expand-GzipBase64 "{{ GzipBase64 KubeEnv }}" "$InstallDir\conf\kube_env.yaml"
+{{- else -}}
+Set-Content -Path "$InstallDir\conf\kube_env.yaml" -Value @'
+{{ KubeEnv }}
+'@
+{{- end }}
+
+Download-Release
+Write-Output "== nodeup node config done =="
+</powershell>
+<persist>false</persist>
+`
@@ -145,11 +145,13 @@ func (i *nodeIdentifier) IdentifyNode(ctx context.Context, node *corev1.Node) (*
 	info := &nodeidentity.Info{
 		InstanceID: instanceID,
 		Labels:     labels,
+		Tags:       map[string]string{},
 	}
 
 	isKarpenterManaged := false
 	for _, tag := range instance.Tags {
 		key := aws.StringValue(tag.Key)
+		info.Tags[key] = aws.StringValue(tag.Value)
 		if strings.HasPrefix(key, ClusterAutoscalerNodeTemplateLabel) {
 			info.Labels[strings.TrimPrefix(aws.StringValue(tag.Key), ClusterAutoscalerNodeTemplateLabel)] = aws.StringValue(tag.Value)
 		}
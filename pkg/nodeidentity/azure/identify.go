@@ -105,9 +105,13 @@ func (i *nodeIdentifier) IdentifyNode(ctx context.Context, node *corev1.Node) (*
 	info := &nodeidentity.Info{
 		InstanceID: vmssName,
 		Labels:     labels,
+		Tags:       map[string]string{},
 	}
 
 	for k, v := range vmss.Tags {
+		if v != nil {
+			info.Tags[k] = *v
+		}
 		if !strings.HasPrefix(k, ClusterNodeTemplateLabel) {
 			continue
 		}
@@ -31,6 +31,7 @@ import (
 	"github.com/digitalocean/godo"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/kops/pkg/nodeidentity"
+	"k8s.io/kops/pkg/nodelabels"
 )
 
 // nodeIdentifier identifies a node from DO
@@ -39,8 +40,9 @@ type nodeIdentifier struct {
 }
 
 const (
-	dropletRegionMetadataURL    = "http://169.254.169.254/metadata/v1/region"
-	dropletTagInstanceGroupName = "kops-instancegroup"
+	dropletRegionMetadataURL      = "http://169.254.169.254/metadata/v1/region"
+	dropletTagInstanceGroupName   = "kops-instancegroup"
+	dropletTagClusterMasterPrefix = "KubernetesCluster-Master:"
 )
 
 // TokenSource implements oauth2.TokenSource
@@ -56,8 +58,8 @@ func (t *TokenSource) Token() (*oauth2.Token, error) {
 	return token, nil
 }
 
-// New creates and returns a nodeidentity.LegacyIdentifier for Nodes running on DO
-func New() (nodeidentity.LegacyIdentifier, error) {
+// New creates and returns a nodeidentity.Identifier for Nodes running on DO
+func New() (nodeidentity.Identifier, error) {
 	region, err := getMetadataRegion()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get droplet region: %s", err)
@@ -116,7 +118,7 @@ func getMetadata(url string) (string, error) {
 }
 
 // IdentifyNode queries DO for the node identity information
-func (i *nodeIdentifier) IdentifyNode(ctx context.Context, node *corev1.Node) (*nodeidentity.LegacyInfo, error) {
+func (i *nodeIdentifier) IdentifyNode(ctx context.Context, node *corev1.Node) (*nodeidentity.Info, error) {
 	providerID := node.Spec.ProviderID
 
 	if providerID == "" {
@@ -136,28 +138,38 @@ func (i *nodeIdentifier) IdentifyNode(ctx context.Context, node *corev1.Node) (*
 
 	dropletID, err := strconv.Atoi(provIDNum)
 	if err != nil {
-		return nil, fmt.Errorf("failed to convert provider ID number %q: %s", dropletID, err)
+		return nil, fmt.Errorf("failed to convert provider ID number %q: %s", provIDNum, err)
 	}
 
-	kopsGroup, err := i.getInstanceGroup(dropletID)
+	droplet, _, err := i.doClient.Droplets.Get(ctx, dropletID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve droplet via api for dropletid = %d. Error = %v", dropletID, err)
+	}
+
+	kopsGroup, err := getInstanceGroup(droplet.Tags)
 	if err != nil {
 		return nil, err
 	}
 
-	info := &nodeidentity.LegacyInfo{}
-	info.InstanceGroup = kopsGroup
+	labels := map[string]string{}
+	if isControlPlane(droplet.Tags) {
+		labels[nodelabels.RoleLabelControlPlane20] = ""
+	} else {
+		labels[nodelabels.RoleLabelNode16] = ""
+	}
+
+	info := &nodeidentity.Info{
+		InstanceID:    provIDNum,
+		Labels:        labels,
+		InstanceGroup: kopsGroup,
+	}
 
 	return info, nil
 }
 
-func (i *nodeIdentifier) getInstanceGroup(instanceID int) (string, error) {
-	ctx := context.TODO()
-	droplet, _, err := i.doClient.Droplets.Get(ctx, instanceID)
-	if err != nil {
-		return "", fmt.Errorf("failed to retrieve droplet via api for dropletid = %d. Error = %v", instanceID, err)
-	}
-
-	for _, dropletTag := range droplet.Tags {
+// getInstanceGroup returns the name of the instance group from the tags of a droplet
+func getInstanceGroup(dropletTags []string) (string, error) {
+	for _, dropletTag := range dropletTags {
 		if strings.Contains(dropletTag, dropletTagInstanceGroupName) {
 			instancegrouptag := strings.SplitN(dropletTag, ":", 2)
 			if len(instancegrouptag) < 2 {
@@ -170,3 +182,13 @@ func (i *nodeIdentifier) getInstanceGroup(instanceID int) (string, error) {
 
 	return "", errors.New("could not find tag 'kops-instancegroup' from instance metadata")
 }
+
+// isControlPlane returns true if the tags of a droplet mark it as a control plane droplet
+func isControlPlane(dropletTags []string) bool {
+	for _, dropletTag := range dropletTags {
+		if strings.HasPrefix(dropletTag, dropletTagClusterMasterPrefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -111,9 +111,15 @@ func (i *nodeIdentifier) IdentifyNode(ctx context.Context, node *corev1.Node) (*
 		}
 	}
 
+	instanceGroup := server.Labels[hetzner.TagKubernetesInstanceGroup]
+	if instanceGroup == "" {
+		return nil, fmt.Errorf("server %s(%d) does not have the %q label", server.Name, server.ID, hetzner.TagKubernetesInstanceGroup)
+	}
+
 	info := &nodeidentity.Info{
-		InstanceID: serverID,
-		Labels:     labels,
+		InstanceID:    serverID,
+		Labels:        labels,
+		InstanceGroup: instanceGroup,
 	}
 
 	// If caching is enabled add the nodeidentity.Info to cache.
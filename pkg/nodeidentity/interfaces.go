@@ -29,6 +29,13 @@ type Identifier interface {
 type Info struct {
 	InstanceID string
 	Labels     map[string]string
+
+	// InstanceGroup is the name of the instance group of the node, for clouds whose tags cannot hold the node labels.
+	// When set, the labels and taints of the node are also taken from the instance group spec.
+	InstanceGroup string
+
+	// Tags are the cloud tags of the instance, for clouds that support key-value tags.
+	Tags map[string]string
 }
 
 type LegacyIdentifier interface {
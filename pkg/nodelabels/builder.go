@@ -33,6 +33,9 @@ const (
 	RoleLabelNode16      = "node-role.kubernetes.io/node"
 
 	RoleLabelControlPlane20 = "node-role.kubernetes.io/control-plane"
+
+	// KopsVersionLabel records the version of kops that the node joined the cluster with
+	KopsVersionLabel = "kops.k8s.io/kops-version"
 )
 
 // BuildNodeLabels returns the node labels for the specified instance group
@@ -19,6 +19,9 @@ package pki
 import (
 	crypto_rand "crypto/rand"
 	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
 	"fmt"
 	"math/big"
 	"time"
@@ -106,3 +109,41 @@ func signNewCertificate(privateKey *PrivateKey, template *x509.Certificate, sign
 
 	return c, nil
 }
+
+var (
+	oidExtensionKeyUsage         = asn1.ObjectIdentifier{2, 5, 29, 15}
+	oidExtensionBasicConstraints = asn1.ObjectIdentifier{2, 5, 29, 19}
+)
+
+// BuildCACertificateRequest builds a PEM-encoded certificate signing request for a CA certificate,
+// for when the certificate is issued by an external certificate authority.
+func BuildCACertificateRequest(subject pkix.Name, privateKey *PrivateKey) ([]byte, error) {
+	// basicConstraints: CA:TRUE
+	basicConstraints, err := asn1.Marshal(struct {
+		IsCA bool
+	}{IsCA: true})
+	if err != nil {
+		return nil, fmt.Errorf("error encoding basic constraints: %v", err)
+	}
+
+	// keyUsage: keyCertSign (bit 5), cRLSign (bit 6)
+	keyUsage, err := asn1.Marshal(asn1.BitString{Bytes: []byte{0x06}, BitLength: 7})
+	if err != nil {
+		return nil, fmt.Errorf("error encoding key usage: %v", err)
+	}
+
+	template := &x509.CertificateRequest{
+		Subject: subject,
+		ExtraExtensions: []pkix.Extension{
+			{Id: oidExtensionBasicConstraints, Critical: true, Value: basicConstraints},
+			{Id: oidExtensionKeyUsage, Critical: true, Value: keyUsage},
+		},
+	}
+
+	der, err := x509.CreateCertificateRequest(crypto_rand.Reader, template, privateKey.Key)
+	if err != nil {
+		return nil, fmt.Errorf("error creating certificate request: %v", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}), nil
+}
@@ -599,6 +599,7 @@ func ListVolumes(cloud fi.Cloud, clusterName string) ([]*resources.Resource, err
 			Type:    "volume",
 			Deleter: DeleteVolume,
 			Shared:  HasSharedTag(ec2.ResourceTypeVolume+":"+id, volume.Tags, clusterName),
+			Obj:     volume,
 		}
 
 		var blocks []string
@@ -0,0 +1,135 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"k8s.io/kops/pkg/resources"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+// pvNameTag is the tag which Kubernetes adds to the volumes it provisions for PersistentVolumes
+const pvNameTag = "kubernetes.io/created-for/pv/name"
+
+// cniENIDescriptionPrefixes are the description prefixes of the network interfaces which CNI plugins manage themselves,
+// such as the warm pool of the Amazon VPC CNI and the interfaces allocated by Cilium ENI IPAM
+var cniENIDescriptionPrefixes = []string{"aws-K8S-", "Cilium-CNI"}
+
+// cniENITagPrefixes are the tag key prefixes of the network interfaces which CNI plugins manage themselves
+var cniENITagPrefixes = []string{"node.k8s.amazonaws.com/", "cluster.k8s.amazonaws.com/", "io.cilium/"}
+
+// ManagedResources are the names of the resources represented in the tasks built from the cluster configuration
+type ManagedResources struct {
+	LoadBalancerNames sets.String
+	VolumeNames       sets.String
+}
+
+// ListOrphanedResources returns the load balancers, volumes and network interfaces owned by the cluster
+// which are no longer represented in the cluster configuration.
+// Load balancers and volumes created by Kubernetes for Services and PersistentVolumes,
+// and network interfaces managed by CNI plugins, are never considered orphaned.
+func ListOrphanedResources(cloud fi.Cloud, clusterName string, resourceMap map[string]*resources.Resource, managed *ManagedResources) ([]*resources.OrphanedResource, error) {
+	var elbv2Tags map[string][]*elbv2.Tag
+
+	var orphans []*resources.OrphanedResource
+	for _, r := range resourceMap {
+		if r.Shared {
+			continue
+		}
+
+		reason := ""
+		switch obj := r.Obj.(type) {
+		case *elb.LoadBalancerDescription:
+			// kOps sets the Name tag of the load balancers it creates, the Kubernetes service controller does not
+			if r.Name != "" && !managed.LoadBalancerNames.Has(r.ID) {
+				reason = "load balancer is not in the cluster configuration"
+			}
+		case *elbv2.LoadBalancer:
+			if elbv2Tags == nil {
+				var err error
+				if _, elbv2Tags, err = DescribeELBV2s(cloud); err != nil {
+					return nil, err
+				}
+			}
+			name := aws.StringValue(obj.LoadBalancerName)
+			if _, found := awsup.FindELBV2Tag(elbv2Tags[r.ID], "Name"); found && !managed.LoadBalancerNames.Has(name) {
+				reason = "load balancer is not in the cluster configuration"
+			}
+		case *ec2.Volume:
+			if aws.StringValue(obj.State) == ec2.VolumeStateAvailable && !hasTag(obj.Tags, pvNameTag) && !managed.VolumeNames.Has(r.Name) {
+				reason = "volume is not attached and not in the cluster configuration"
+			}
+		case *ec2.NetworkInterface:
+			// Only the network interfaces which are not attached are listed
+			if HasOwnedTag(r.Type+":"+r.ID, obj.TagSet, clusterName) && !isCNIManagedENI(obj) {
+				reason = "network interface is not attached"
+			}
+		}
+
+		if reason != "" {
+			orphans = append(orphans, &resources.OrphanedResource{
+				Resource: r,
+				Reason:   reason,
+			})
+		}
+	}
+
+	sort.Slice(orphans, func(i, j int) bool {
+		if orphans[i].Type != orphans[j].Type {
+			return orphans[i].Type < orphans[j].Type
+		}
+		return orphans[i].ID < orphans[j].ID
+	})
+	return orphans, nil
+}
+
+// isCNIManagedENI returns true if the network interface was created by a CNI plugin, which deletes it when no longer needed
+func isCNIManagedENI(eni *ec2.NetworkInterface) bool {
+	description := aws.StringValue(eni.Description)
+	for _, prefix := range cniENIDescriptionPrefixes {
+		if strings.HasPrefix(description, prefix) {
+			return true
+		}
+	}
+	for _, tag := range eni.TagSet {
+		key := aws.StringValue(tag.Key)
+		for _, prefix := range cniENITagPrefixes {
+			if strings.HasPrefix(key, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hasTag(tags []*ec2.Tag, key string) bool {
+	for _, tag := range tags {
+		if aws.StringValue(tag.Key) == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"k8s.io/kops/cloudmock/aws/mockelbv2"
+	"k8s.io/kops/pkg/resources"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+)
+
+func TestListOrphanedResources(t *testing.T) {
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	c := &mockelbv2.MockELBV2{}
+	cloud.MockELBV2 = c
+
+	createNLB := func(name string, tags ...*elbv2.Tag) string {
+		response, err := c.CreateLoadBalancer(&elbv2.CreateLoadBalancerInput{
+			Name: aws.String(name),
+			Tags: tags,
+		})
+		if err != nil {
+			t.Fatalf("error creating load balancer: %v", err)
+		}
+		return aws.StringValue(response.LoadBalancers[0].LoadBalancerArn)
+	}
+	apiNLB := createNLB("api-me-example-com", &elbv2.Tag{Key: aws.String("Name"), Value: aws.String("api.me.example.com")})
+	oldNLB := createNLB("bastion-me-example-com", &elbv2.Tag{Key: aws.String("Name"), Value: aws.String("bastion.me.example.com")})
+	serviceNLB := createNLB("a0123456789abcdef0123456789abcd")
+
+	resourceMap := map[string]*resources.Resource{}
+	add := func(r *resources.Resource) {
+		resourceMap[r.Type+":"+r.ID] = r
+	}
+	add(&resources.Resource{Type: TypeLoadBalancer, ID: "api-old-classic", Name: "api.me.example.com", Obj: &elb.LoadBalancerDescription{}})
+	add(&resources.Resource{Type: TypeLoadBalancer, ID: "a0123456789abcdef0123456789abce", Obj: &elb.LoadBalancerDescription{}})
+	add(&resources.Resource{Type: TypeLoadBalancer, ID: apiNLB, Name: "api-me-example-com", Obj: &elbv2.LoadBalancer{LoadBalancerName: aws.String("api-me-example-com")}})
+	add(&resources.Resource{Type: TypeLoadBalancer, ID: oldNLB, Name: "bastion-me-example-com", Obj: &elbv2.LoadBalancer{LoadBalancerName: aws.String("bastion-me-example-com")}})
+	add(&resources.Resource{Type: TypeLoadBalancer, ID: serviceNLB, Name: "a0123456789abcdef0123456789abcd", Obj: &elbv2.LoadBalancer{LoadBalancerName: aws.String("a0123456789abcdef0123456789abcd")}})
+	add(&resources.Resource{Type: "volume", ID: "vol-etcd", Name: "a.etcd-main.me.example.com", Obj: &ec2.Volume{State: aws.String(ec2.VolumeStateAvailable)}})
+	add(&resources.Resource{Type: "volume", ID: "vol-old-etcd", Name: "d.etcd-main.me.example.com", Obj: &ec2.Volume{State: aws.String(ec2.VolumeStateAvailable)}})
+	add(&resources.Resource{Type: "volume", ID: "vol-attached", Obj: &ec2.Volume{State: aws.String(ec2.VolumeStateInUse)}})
+	add(&resources.Resource{Type: "volume", ID: "vol-pv", Obj: &ec2.Volume{
+		State: aws.String(ec2.VolumeStateAvailable),
+		Tags:  []*ec2.Tag{{Key: aws.String(pvNameTag), Value: aws.String("pvc-1")}},
+	}})
+	add(&resources.Resource{Type: "volume", ID: "vol-shared", Shared: true, Obj: &ec2.Volume{State: aws.String(ec2.VolumeStateAvailable)}})
+	ownedTag := &ec2.Tag{Key: aws.String("kubernetes.io/cluster/me.example.com"), Value: aws.String("owned")}
+	add(&resources.Resource{Type: ec2.ResourceTypeNetworkInterface, ID: "eni-1", Obj: &ec2.NetworkInterface{
+		TagSet: []*ec2.Tag{ownedTag},
+	}})
+	add(&resources.Resource{Type: ec2.ResourceTypeNetworkInterface, ID: "eni-legacy", Obj: &ec2.NetworkInterface{
+		TagSet: []*ec2.Tag{{Key: aws.String("KubernetesCluster"), Value: aws.String("me.example.com")}},
+	}})
+	add(&resources.Resource{Type: ec2.ResourceTypeNetworkInterface, ID: "eni-untagged", Obj: &ec2.NetworkInterface{}})
+	add(&resources.Resource{Type: ec2.ResourceTypeNetworkInterface, ID: "eni-other-cluster", Obj: &ec2.NetworkInterface{
+		TagSet: []*ec2.Tag{{Key: aws.String("kubernetes.io/cluster/other.example.com"), Value: aws.String("owned")}},
+	}})
+	add(&resources.Resource{Type: ec2.ResourceTypeNetworkInterface, ID: "eni-vpc-cni", Obj: &ec2.NetworkInterface{
+		Description: aws.String("aws-K8S-i-0123456789abcdef0"),
+		TagSet:      []*ec2.Tag{ownedTag},
+	}})
+	add(&resources.Resource{Type: ec2.ResourceTypeNetworkInterface, ID: "eni-vpc-cni-tagged", Obj: &ec2.NetworkInterface{
+		TagSet: []*ec2.Tag{ownedTag, {Key: aws.String("node.k8s.amazonaws.com/instance_id"), Value: aws.String("i-0123456789abcdef0")}},
+	}})
+	add(&resources.Resource{Type: ec2.ResourceTypeNetworkInterface, ID: "eni-cilium", Obj: &ec2.NetworkInterface{
+		Description: aws.String("Cilium-CNI (i-0123456789abcdef0)"),
+		TagSet:      []*ec2.Tag{ownedTag, {Key: aws.String("io.cilium/cilium-managed"), Value: aws.String("true")}},
+	}})
+	add(&resources.Resource{Type: ec2.ResourceTypeVpc, ID: "vpc-1", Obj: &ec2.Vpc{}})
+
+	managed := &ManagedResources{
+		LoadBalancerNames: sets.NewString("api-me-example-com"),
+		VolumeNames:       sets.NewString("a.etcd-main.me.example.com"),
+	}
+	orphans, err := ListOrphanedResources(cloud, "me.example.com", resourceMap, managed)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	actual := map[string]string{}
+	for _, orphan := range orphans {
+		actual[orphan.ID] = orphan.Reason
+	}
+	expected := map[string]string{
+		"api-old-classic": "load balancer is not in the cluster configuration",
+		oldNLB:            "load balancer is not in the cluster configuration",
+		"vol-old-etcd":    "volume is not attached and not in the cluster configuration",
+		"eni-1":           "network interface is not attached",
+		"eni-legacy":      "network interface is not attached",
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected orphaned resources %v, got %v", expected, actual)
+	}
+}
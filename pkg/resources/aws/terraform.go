@@ -0,0 +1,93 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	"k8s.io/kops/pkg/resources"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
+)
+
+// terraformResourceTypes maps the types of the resources to the types of the terraform resources of the terraform target.
+// Instances, network interfaces and DNS records are not listed, as they are not terraform resources of the target.
+var terraformResourceTypes = map[string]string{
+	TypeAutoscalingLaunchConfig:    "aws_launch_template",
+	TypeElasticIp:                  "aws_eip",
+	TypeNatGateway:                 "aws_nat_gateway",
+	TypeTargetGroup:                "aws_lb_target_group",
+	"autoscaling-group":            "aws_autoscaling_group",
+	"dhcp-options":                 "aws_vpc_dhcp_options",
+	"egress-only-internet-gateway": "aws_egress_only_internet_gateway",
+	"eventbridge":                  "aws_cloudwatch_event_rule",
+	"iam-instance-profile":         "aws_iam_instance_profile",
+	"iam-role":                     "aws_iam_role",
+	"internet-gateway":             "aws_internet_gateway",
+	"keypair":                      "aws_key_pair",
+	"sqs":                          "aws_sqs_queue",
+	ec2.ResourceTypeRouteTable:     "aws_route_table",
+	ec2.ResourceTypeSecurityGroup:  "aws_security_group",
+	ec2.ResourceTypeSubnet:         "aws_subnet",
+	ec2.ResourceTypeVolume:         "aws_ebs_volume",
+	ec2.ResourceTypeVpc:            "aws_vpc",
+}
+
+// ListTerraformImports maps the resources owned by the cluster to the addresses of the resources of the terraform target,
+// with the IDs to import them by. The addresses are derived from the names of the resources, following kOps naming.
+func ListTerraformImports(resourceMap map[string]*resources.Resource) []*resources.TerraformImport {
+	var imports []*resources.TerraformImport
+	for _, r := range resourceMap {
+		if r.Shared {
+			continue
+		}
+
+		resourceType := terraformResourceTypes[r.Type]
+		name := r.Name
+		importID := r.ID
+		switch r.Type {
+		case TypeLoadBalancer:
+			// Network load balancers are identified by their ARN, classic load balancers by their name
+			if strings.HasPrefix(r.ID, "arn:") {
+				resourceType = "aws_lb"
+			} else {
+				resourceType = "aws_elb"
+			}
+		case "keypair":
+			name = strings.ReplaceAll(name, ":", "")
+		case "sqs":
+			// The queue is identified by its URL
+			name = path.Base(r.ID)
+		}
+		if resourceType == "" || name == "" {
+			continue
+		}
+
+		imports = append(imports, &resources.TerraformImport{
+			Type:    r.Type,
+			ID:      importID,
+			Name:    r.Name,
+			Address: resourceType + "." + terraformWriter.SanitizeName(name),
+		})
+	}
+
+	sort.Slice(imports, func(i, j int) bool { return imports[i].Address < imports[j].Address })
+	return imports
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/kops/pkg/resources"
+)
+
+func TestListTerraformImports(t *testing.T) {
+	resourceMap := map[string]*resources.Resource{
+		"vpc:vpc-1":               {Name: "minimal.example.com", ID: "vpc-1", Type: "vpc"},
+		"subnet:subnet-1":         {Name: "us-test-1a.minimal.example.com", ID: "subnet-1", Type: "subnet"},
+		"subnet:subnet-2":         {Name: "shared", ID: "subnet-2", Type: "subnet", Shared: true},
+		"autoscaling-group:nodes": {Name: "nodes-us-test-1a.minimal.example.com", ID: "nodes-us-test-1a.minimal.example.com", Type: "autoscaling-group"},
+		"load-balancer:api":       {Name: "api.minimal.example.com", ID: "api-minimal-example-com-abc", Type: TypeLoadBalancer},
+		"load-balancer:nlb":       {Name: "api-minimal-example-com", ID: "arn:aws:elasticloadbalancing:us-test-1:123:loadbalancer/net/api/1", Type: TypeLoadBalancer},
+		"keypair:key-1":           {Name: "kubernetes.minimal.example.com-c4:a6", ID: "key-1", Type: "keypair"},
+		"sqs:queue":               {Name: "https://sqs.us-test-1.amazonaws.com/123/minimal-example-com-nth", ID: "https://sqs.us-test-1.amazonaws.com/123/minimal-example-com-nth", Type: "sqs"},
+		"instance:i-1":            {Name: "nodes.minimal.example.com", ID: "i-1", Type: "instance"},
+	}
+
+	expected := []*resources.TerraformImport{
+		{Type: "autoscaling-group", ID: "nodes-us-test-1a.minimal.example.com", Name: "nodes-us-test-1a.minimal.example.com", Address: "aws_autoscaling_group.nodes-us-test-1a-minimal-example-com"},
+		{Type: TypeLoadBalancer, ID: "api-minimal-example-com-abc", Name: "api.minimal.example.com", Address: "aws_elb.api-minimal-example-com"},
+		{Type: "keypair", ID: "key-1", Name: "kubernetes.minimal.example.com-c4:a6", Address: "aws_key_pair.kubernetes-minimal-example-com-c4a6"},
+		{Type: TypeLoadBalancer, ID: "arn:aws:elasticloadbalancing:us-test-1:123:loadbalancer/net/api/1", Name: "api-minimal-example-com", Address: "aws_lb.api-minimal-example-com"},
+		{Type: "sqs", ID: "https://sqs.us-test-1.amazonaws.com/123/minimal-example-com-nth", Name: "https://sqs.us-test-1.amazonaws.com/123/minimal-example-com-nth", Address: "aws_sqs_queue.minimal-example-com-nth"},
+		{Type: "subnet", ID: "subnet-1", Name: "us-test-1a.minimal.example.com", Address: "aws_subnet.us-test-1a-minimal-example-com"},
+		{Type: "vpc", ID: "vpc-1", Name: "minimal.example.com", Address: "aws_vpc.minimal-example-com"},
+	}
+
+	actual := ListTerraformImports(resourceMap)
+	if !reflect.DeepEqual(actual, expected) {
+		for _, i := range actual {
+			t.Logf("actual: %+v", *i)
+		}
+		t.Fatalf("unexpected terraform imports")
+	}
+}
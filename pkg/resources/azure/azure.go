@@ -268,7 +268,9 @@ func (g *resourceGetter) listVMScaleSetsAndRoleAssignments(ctx context.Context)
 		}
 		rs = append(rs, r)
 
-		principalIDs[*vmss.Identity.PrincipalID] = vmss
+		if vmss.Identity != nil && vmss.Identity.PrincipalID != nil {
+			principalIDs[*vmss.Identity.PrincipalID] = vmss
+		}
 	}
 
 	ras, err := g.listRoleAssignments(ctx, principalIDs)
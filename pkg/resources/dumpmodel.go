@@ -42,3 +42,11 @@ type Dump struct {
 	Subnets   []*Subnet     `json:"subnets,omitempty"`
 	VPC       *VPC          `json:"vpc,omitempty"`
 }
+
+// TerraformImport maps a cloud resource to the address of a resource of the terraform target
+type TerraformImport struct {
+	Type    string `json:"type,omitempty"`
+	ID      string `json:"id,omitempty"`
+	Name    string `json:"name,omitempty"`
+	Address string `json:"address,omitempty"`
+}
@@ -540,7 +540,7 @@ nextFirewallRule:
 
 		// We consider only firewall rules that target our cluster tags, which include the cluster name or hash
 		tagPrefix := gce.SafeClusterName(d.clusterName) + "-"
-		clusterNameHash := truncate.HashString(d.clusterName, 6)
+		clusterNameHash := truncate.HashString(gce.SafeClusterName(d.clusterName), 6)
 		if len(firewallRule.TargetTags) != 0 {
 			tagMatchCount := 0
 			for _, target := range firewallRule.TargetTags {
@@ -1010,6 +1010,7 @@ func (d *clusterDiscoveryGCE) listServiceAccounts() ([]*resources.Resource, erro
 			return nil, fmt.Errorf("Invalid service account email '%s'", gce.LastComponent(sa.Name))
 		}
 		accountID := tokens[0]
+		match := gce.IsWorkloadIdentityServiceAccount(accountID, sa.Description, d.clusterName)
 		names := []string{gce.ControlPlane, gce.Bastion, gce.Node}
 		for _, name := range names {
 			generatedName, err := gce.ServiceAccountName(name, d.clusterName)
@@ -1017,19 +1018,24 @@ func (d *clusterDiscoveryGCE) listServiceAccounts() ([]*resources.Resource, erro
 				return nil, err
 			}
 			if generatedName == accountID {
-				resourceTracker := &resources.Resource{
-					Name:    gce.LastComponent(sa.Name),
-					ID:      sa.Name,
-					Type:    typeServiceAccount,
-					Deleter: deleteServiceAccount,
-					Obj:     sa,
-				}
-
-				klog.V(4).Infof("found resource: %s", sa.Name)
-				resourceTrackers = append(resourceTrackers, resourceTracker)
+				match = true
 				break
 			}
 		}
+		if !match {
+			continue
+		}
+
+		resourceTracker := &resources.Resource{
+			Name:    gce.LastComponent(sa.Name),
+			ID:      sa.Name,
+			Type:    typeServiceAccount,
+			Deleter: deleteServiceAccount,
+			Obj:     sa,
+		}
+
+		klog.V(4).Infof("found resource: %s", sa.Name)
+		resourceTrackers = append(resourceTrackers, resourceTracker)
 	}
 	return resourceTrackers, nil
 }
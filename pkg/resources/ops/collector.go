@@ -56,3 +56,13 @@ func ListResources(cloud fi.Cloud, cluster *kops.Cluster, region string) (map[st
 		return nil, fmt.Errorf("delete on clusters on %q not (yet) supported", cloud.ProviderID())
 	}
 }
+
+// ListTerraformImports maps the resources to the addresses of the resources of the terraform target
+func ListTerraformImports(cloud fi.Cloud, resourceMap map[string]*resources.Resource) ([]*resources.TerraformImport, error) {
+	switch cloud.ProviderID() {
+	case kops.CloudProviderAWS:
+		return aws.ListTerraformImports(resourceMap), nil
+	default:
+		return nil, fmt.Errorf("terraform imports on %q not (yet) supported", cloud.ProviderID())
+	}
+}
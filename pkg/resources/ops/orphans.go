@@ -0,0 +1,53 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ops
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/resources"
+	"k8s.io/kops/pkg/resources/aws"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awstasks"
+)
+
+// ListOrphanedResources returns the resources, as previously collected by ListResources,
+// which are not represented in the tasks built from the cluster configuration
+func ListOrphanedResources(cloud fi.Cloud, cluster *kops.Cluster, resourceMap map[string]*resources.Resource, taskMap map[string]fi.Task) ([]*resources.OrphanedResource, error) {
+	switch cloud.ProviderID() {
+	case kops.CloudProviderAWS:
+		managed := &aws.ManagedResources{
+			LoadBalancerNames: sets.NewString(),
+			VolumeNames:       sets.NewString(),
+		}
+		for _, task := range taskMap {
+			switch t := task.(type) {
+			case *awstasks.ClassicLoadBalancer:
+				managed.LoadBalancerNames.Insert(fi.StringValue(t.LoadBalancerName))
+			case *awstasks.NetworkLoadBalancer:
+				managed.LoadBalancerNames.Insert(fi.StringValue(t.LoadBalancerName))
+			case *awstasks.EBSVolume:
+				managed.VolumeNames.Insert(fi.StringValue(t.Name))
+			}
+		}
+		return aws.ListOrphanedResources(cloud, cluster.ObjectMeta.Name, resourceMap, managed)
+	default:
+		return nil, fmt.Errorf("pruning orphaned resources on %q not (yet) supported", cloud.ProviderID())
+	}
+}
@@ -41,3 +41,11 @@ type Resource struct {
 
 	Obj interface{}
 }
+
+// OrphanedResource is a resource of the cluster which is no longer represented in the cluster configuration
+type OrphanedResource struct {
+	*Resource
+
+	// Reason explains why the resource is considered orphaned
+	Reason string
+}
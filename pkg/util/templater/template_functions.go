@@ -17,6 +17,7 @@ limitations under the License.
 package templater
 
 import (
+	"strings"
 	"text/template"
 
 	"github.com/Masterminds/sprig/v3"
@@ -25,6 +26,7 @@ import (
 	kopsapi "k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/apis/kops/util"
 	"k8s.io/kops/util/pkg/architectures"
+	"sigs.k8s.io/yaml"
 )
 
 // templateFuncsMap returns a map if the template functions for this template
@@ -33,6 +35,10 @@ func (r *Templater) templateFuncsMap(tm *template.Template) template.FuncMap {
 	funcs := sprig.TxtFuncMap()
 
 	funcs["indent"] = indentContent
+	// @step: add the functions that helm adds to sprig, so templates can be shared with helm charts
+	funcs["toYaml"] = toYAML
+	funcs["fromYaml"] = fromYAML
+	funcs["required"] = required
 	// @step: as far as i can see there's no native way in sprig in include external snippets of code
 	funcs["include"] = func(name string, context map[string]interface{}) string {
 		content, err := includeSnippet(tm, name, context)
@@ -69,3 +75,32 @@ func (r *Templater) templateFuncsMap(tm *template.Template) template.FuncMap {
 
 	return funcs
 }
+
+// toYAML encodes a value as YAML, without the trailing newline
+func toYAML(v interface{}) string {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		panic(err.Error())
+	}
+	return strings.TrimSuffix(string(data), "\n")
+}
+
+// fromYAML decodes a YAML document into a map
+func fromYAML(content string) map[string]interface{} {
+	m := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(content), &m); err != nil {
+		panic(err.Error())
+	}
+	return m
+}
+
+// required fails rendering with the message if the value is null or an empty string
+func required(message string, v interface{}) interface{} {
+	if v == nil {
+		panic(message)
+	}
+	if s, ok := v.(string); ok && s == "" {
+		panic(message)
+	}
+	return v
+}
@@ -27,6 +27,9 @@ import (
 
 const (
 	templateName = "mainTemplate"
+
+	// NoValue is rendered by a template in place of a value that is null
+	NoValue = "<no value>"
 )
 
 // Templater is golang template renders
@@ -82,6 +82,36 @@ func TestRenderIndent(t *testing.T) {
 	makeRenderTests(t, cases)
 }
 
+func TestRenderHelmFunctions(t *testing.T) {
+	cases := []renderTest{
+		{
+			Context:  map[string]interface{}{"labels": map[string]interface{}{"team": "blue"}},
+			Template: `{{ toYaml .labels }}`,
+			Expected: "team: blue",
+		},
+		{
+			Template: `{{ (fromYaml "team: blue").team }}`,
+			Expected: "blue",
+		},
+		{
+			Context:  map[string]interface{}{"name": "test"},
+			Template: `{{ required "name is required" .name }}`,
+			Expected: "test",
+		},
+		{
+			Context:  map[string]interface{}{"name": ""},
+			Template: `{{ required "name is required" .name }}`,
+			NotOK:    true,
+		},
+		{
+			Context:  map[string]interface{}{"versions": []interface{}{"1.24.0", "1.23.1"}},
+			Template: `{{ .versions | sortAlpha | first }}`,
+			Expected: "1.23.1",
+		},
+	}
+	makeRenderTests(t, cases)
+}
+
 func TestRenderChannelFunctions(t *testing.T) {
 	cases := []renderTest{
 		{
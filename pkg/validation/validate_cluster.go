@@ -172,6 +172,10 @@ func (v *clusterValidatorImpl) Validate() (*ValidationCluster, error) {
 		return nil, fmt.Errorf("cannot get pod health for %q: %v", clusterName, err)
 	}
 
+	if err := validation.collectValidationCheckFailures(ctx, v.k8sClient, v.cluster); err != nil {
+		return nil, err
+	}
+
 	return validation, nil
 }
 
@@ -244,9 +248,20 @@ func (v *ValidationCluster) collectPodFailures(ctx context.Context, client kuber
 		}
 		var notready []string
 		for _, container := range pod.Status.ContainerStatuses {
-			if !container.Ready {
-				notready = append(notready, container.Name)
+			if container.Ready {
+				continue
+			}
+			if app == "kube-apiserver" && container.Name == "healthcheck" {
+				// The kube-apiserver-healthcheck sidecar is what load balancers use to check the apiserver
+				v.addError(&ValidationError{
+					Kind:          "Pod",
+					Name:          pod.Namespace + "/" + pod.Name,
+					Message:       fmt.Sprintf("kube-apiserver-healthcheck in pod %q is not ready; load balancer health checks for this control-plane node will fail", pod.Name),
+					InstanceGroup: podNode,
+				})
+				continue
 			}
+			notready = append(notready, container.Name)
 		}
 		if len(notready) != 0 {
 			v.addError(&ValidationError{
@@ -376,7 +391,9 @@ func (v *ValidationCluster) validateNodes(cloudGroups map[string]*cloudinstances
 	}
 
 	for _, ig := range groups {
-		if !groupsSeen[ig.Name] {
+		// Externally managed and metal instance groups don't have a cloud group; their instances are launched by another provisioner
+		// or enrolled with "kops toolbox enroll"
+		if !groupsSeen[ig.Name] && ig.Spec.Manager != kops.InstanceManagerExternal && ig.Spec.Manager != kops.InstanceManagerMetal {
 			v.addError(&ValidationError{
 				Kind:          "InstanceGroup",
 				Name:          ig.Name,
@@ -22,6 +22,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -77,7 +78,10 @@ func testValidate(t *testing.T, groups map[string]*cloudinstances.CloudInstanceG
 			},
 		},
 	}
+	return testValidateCluster(t, cluster, groups, objects)
+}
 
+func testValidateCluster(t *testing.T, cluster *kopsapi.Cluster, groups map[string]*cloudinstances.CloudInstanceGroup, objects []runtime.Object) (*ValidationCluster, error) {
 	if len(groups) == 0 {
 		groups = make(map[string]*cloudinstances.CloudInstanceGroup)
 		groups["master-1"] = &cloudinstances.CloudInstanceGroup{
@@ -171,6 +175,39 @@ func Test_ValidateCloudGroupMissing(t *testing.T) {
 	}
 }
 
+func Test_ValidateCloudGroupExternal(t *testing.T) {
+	cluster := &kopsapi.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "testcluster.k8s.local"},
+		Spec: kopsapi.ClusterSpec{
+			ExternalDNS: &kopsapi.ExternalDNSConfig{
+				Provider: kopsapi.ExternalDNSProviderDNSController,
+			},
+		},
+	}
+
+	instanceGroups := []kopsapi.InstanceGroup{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "batch",
+			},
+			Spec: kopsapi.InstanceGroupSpec{
+				Manager: kopsapi.InstanceManagerExternal,
+				Role:    kopsapi.InstanceGroupRoleNode,
+			},
+		},
+	}
+
+	mockcloud := BuildMockCloud(t, nil, cluster, instanceGroups)
+
+	validator, err := NewClusterValidator(cluster, mockcloud, &kopsapi.InstanceGroupList{Items: instanceGroups}, "https://api.testcluster.k8s.local", fake.NewSimpleClientset())
+	require.NoError(t, err)
+	v, err := validator.Validate()
+	require.NoError(t, err)
+	if !assert.Empty(t, v.Failures) {
+		printDebug(t, v)
+	}
+}
+
 func Test_ValidateNodesNotEnough(t *testing.T) {
 	groups := make(map[string]*cloudinstances.CloudInstanceGroup)
 	groups["node-1"] = &cloudinstances.CloudInstanceGroup{
@@ -759,6 +796,46 @@ func Test_ValidatePodFailure(t *testing.T) {
 	}
 }
 
+func Test_ValidateAPIServerHealthcheckNotReady(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kube-apiserver-master-1a",
+			Namespace: "kube-system",
+			Labels:    map[string]string{"k8s-app": "kube-apiserver"},
+		},
+		Spec: v1.PodSpec{
+			PriorityClassName: "system-cluster-critical",
+		},
+		Status: v1.PodStatus{
+			Phase: v1.PodRunning,
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					Name:  "kube-apiserver",
+					Ready: true,
+				},
+				{
+					Name:  "healthcheck",
+					Ready: false,
+				},
+			},
+		},
+	}
+
+	v, err := testValidate(t, nil, []runtime.Object{pod})
+
+	expected := ValidationError{
+		Kind:    "Pod",
+		Name:    "kube-system/kube-apiserver-master-1a",
+		Message: "kube-apiserver-healthcheck in pod \"kube-apiserver-master-1a\" is not ready; load balancer health checks for this control-plane node will fail",
+	}
+
+	require.NoError(t, err)
+	if !assert.Len(t, v.Failures, 1) ||
+		!assert.Equal(t, &expected, v.Failures[0]) {
+		printDebug(t, v)
+	}
+}
+
 func printDebug(t *testing.T, v *ValidationCluster) {
 	t.Logf("cluster - %d failures", len(v.Failures))
 	for _, fail := range v.Failures {
@@ -901,3 +978,92 @@ func Test_ValidateDetachedNodesNotValidated(t *testing.T) {
 		printDebug(t, v)
 	}
 }
+
+func Test_ValidateValidationChecks(t *testing.T) {
+	cluster := &kopsapi.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "testcluster.k8s.local"},
+		Spec: kopsapi.ClusterSpec{
+			Subnets: []kopsapi.ClusterSubnetSpec{
+				{Name: "us-east-1a", Zone: "us-east-1a"},
+				{Name: "utility-us-east-1a", Zone: "us-east-1a"},
+				{Name: "us-east-1b", Zone: "us-east-1b"},
+			},
+			ValidationChecks: []kopsapi.ValidationCheck{
+				{Name: "missing", DaemonSet: &kopsapi.ValidationCheckObject{Name: "missing"}},
+				{Name: "node-exporter", DaemonSet: &kopsapi.ValidationCheckObject{Namespace: "monitoring", Name: "node-exporter"}},
+				{Name: "metrics-server", Deployment: &kopsapi.ValidationCheckObject{Name: "metrics-server"}},
+				{Name: "zones", MinNodesPerZone: fi.Int32(1)},
+			},
+		},
+	}
+
+	groups := make(map[string]*cloudinstances.CloudInstanceGroup)
+	groups["node-1"] = &cloudinstances.CloudInstanceGroup{
+		InstanceGroup: &kopsapi.InstanceGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "node-1",
+			},
+			Spec: kopsapi.InstanceGroupSpec{
+				Role: kopsapi.InstanceGroupRoleNode,
+			},
+		},
+		MinSize: 1,
+		Ready: []*cloudinstances.CloudInstance{
+			{
+				ID: "i-00001",
+				Node: &v1.Node{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "node-1a",
+						Labels: map[string]string{"topology.kubernetes.io/zone": "us-east-1a"},
+					},
+					Status: v1.NodeStatus{
+						Conditions: []v1.NodeCondition{
+							{Type: "Ready", Status: v1.ConditionTrue},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	objects := []runtime.Object{
+		&appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "monitoring", Name: "node-exporter"},
+			Status: appsv1.DaemonSetStatus{
+				DesiredNumberScheduled: 2,
+				NumberReady:            1,
+			},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "metrics-server"},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: fi.Int32(2),
+			},
+			Status: appsv1.DeploymentStatus{
+				AvailableReplicas: 2,
+			},
+		},
+	}
+
+	v, err := testValidateCluster(t, cluster, groups, objects)
+	require.NoError(t, err)
+	if !assert.Equal(t, []*ValidationError{
+		{
+			Kind:    "ValidationCheck",
+			Name:    "missing",
+			Message: "DaemonSet \"kube-system/missing\" not found",
+		},
+		{
+			Kind:    "ValidationCheck",
+			Name:    "node-exporter",
+			Message: "DaemonSet \"monitoring/node-exporter\" has 1 of 2 pods ready",
+		},
+		{
+			Kind:    "ValidationCheck",
+			Name:    "zones",
+			Message: "zones do not have 1 ready nodes: us-east-1b has 0",
+		},
+	}, v.Failures) {
+		printDebug(t, v)
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+// collectValidationCheckFailures runs the additional checks of the cluster spec.
+// Their failures aren't associated with an instance group, so they block the rolling update of every group.
+func (v *ValidationCluster) collectValidationCheckFailures(ctx context.Context, client kubernetes.Interface, cluster *kops.Cluster) error {
+	for _, check := range cluster.Spec.ValidationChecks {
+		var message string
+		var err error
+		switch {
+		case check.DaemonSet != nil:
+			message, err = checkDaemonSet(ctx, client, check.DaemonSet)
+		case check.Deployment != nil:
+			message, err = checkDeployment(ctx, client, check.Deployment)
+		case check.MinNodesPerZone != nil:
+			message = v.checkMinNodesPerZone(cluster, *check.MinNodesPerZone)
+		}
+		if err != nil {
+			return fmt.Errorf("error running validation check %q: %v", check.Name, err)
+		}
+
+		if message != "" {
+			v.addError(&ValidationError{
+				Kind:    "ValidationCheck",
+				Name:    check.Name,
+				Message: message,
+			})
+		}
+	}
+	return nil
+}
+
+// checkDaemonSet returns a failure message unless the desired pods of the DaemonSet are ready
+func checkDaemonSet(ctx context.Context, client kubernetes.Interface, object *kops.ValidationCheckObject) (string, error) {
+	namespace := validationCheckNamespace(object)
+	ds, err := client.AppsV1().DaemonSets(namespace).Get(ctx, object.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Sprintf("DaemonSet \"%s/%s\" not found", namespace, object.Name), nil
+		}
+		return "", err
+	}
+
+	if ds.Status.NumberReady < ds.Status.DesiredNumberScheduled {
+		return fmt.Sprintf("DaemonSet \"%s/%s\" has %d of %d pods ready", namespace, object.Name, ds.Status.NumberReady, ds.Status.DesiredNumberScheduled), nil
+	}
+	return "", nil
+}
+
+// checkDeployment returns a failure message unless the desired replicas of the Deployment are available
+func checkDeployment(ctx context.Context, client kubernetes.Interface, object *kops.ValidationCheckObject) (string, error) {
+	namespace := validationCheckNamespace(object)
+	deployment, err := client.AppsV1().Deployments(namespace).Get(ctx, object.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Sprintf("Deployment \"%s/%s\" not found", namespace, object.Name), nil
+		}
+		return "", err
+	}
+
+	desired := int32(1)
+	if deployment.Spec.Replicas != nil {
+		desired = *deployment.Spec.Replicas
+	}
+	if deployment.Status.AvailableReplicas < desired {
+		return fmt.Sprintf("Deployment \"%s/%s\" has %d of %d replicas available", namespace, object.Name, deployment.Status.AvailableReplicas, desired), nil
+	}
+	return "", nil
+}
+
+// checkMinNodesPerZone returns a failure message unless each zone of the cluster has the minimum number of ready nodes
+func (v *ValidationCluster) checkMinNodesPerZone(cluster *kops.Cluster, minimum int32) string {
+	readyNodes := map[string]int32{}
+	for _, node := range v.Nodes {
+		if node.Role == "node" && node.Status == v1.ConditionTrue {
+			readyNodes[node.Zone]++
+		}
+	}
+
+	var zones []string
+	seen := map[string]bool{}
+	for _, subnet := range cluster.Spec.Subnets {
+		if subnet.Zone != "" && !seen[subnet.Zone] {
+			seen[subnet.Zone] = true
+			zones = append(zones, subnet.Zone)
+		}
+	}
+	sort.Strings(zones)
+
+	var messages []string
+	for _, zone := range zones {
+		if readyNodes[zone] < minimum {
+			messages = append(messages, fmt.Sprintf("%s has %d", zone, readyNodes[zone]))
+		}
+	}
+	if len(messages) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("zones do not have %d ready nodes: %s", minimum, strings.Join(messages, ", "))
+}
+
+func validationCheckNamespace(object *kops.ValidationCheckObject) string {
+	if object.Namespace == "" {
+		return metav1.NamespaceSystem
+	}
+	return object.Namespace
+}
@@ -22,11 +22,13 @@ import (
 	"os"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/spf13/pflag"
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/wellknownports"
 	gossiputils "k8s.io/kops/protokube/pkg/gossip"
+	gossipazure "k8s.io/kops/protokube/pkg/gossip/azure"
 	gossipdns "k8s.io/kops/protokube/pkg/gossip/dns"
 	_ "k8s.io/kops/protokube/pkg/gossip/memberlist"
 	_ "k8s.io/kops/protokube/pkg/gossip/mesh"
@@ -56,8 +58,10 @@ func run() error {
 	var zones []string
 	var containerized, master, gossip bool
 	var cloud, clusterID, dnsInternalSuffix, gossipSecret, gossipListen, gossipProtocol, gossipSecretSecondary, gossipListenSecondary, gossipProtocolSecondary string
-	var flagChannels string
+	var flagChannels, gossipEncryptionKeysFile string
 	var dnsUpdateInterval int
+	var gossipSeedCacheTTL time.Duration
+	var gossipIntervals gossiputils.Intervals
 
 	flag.BoolVar(&containerized, "containerized", containerized, "Set if we are running containerized")
 	flag.BoolVar(&gossip, "gossip", gossip, "Set if we are using gossip dns")
@@ -73,6 +77,11 @@ func run() error {
 	flag.StringVar(&gossipProtocolSecondary, "gossip-protocol-secondary", "memberlist", "mesh/memberlist")
 	flag.StringVar(&gossipListenSecondary, "gossip-listen-secondary", fmt.Sprintf("0.0.0.0:%d", wellknownports.ProtokubeGossipMemberlist), "address:port on which to bind for gossip")
 	flags.StringVar(&gossipSecretSecondary, "gossip-secret-secondary", gossipSecret, "Secret to use to secure gossip")
+	flags.StringVar(&gossipEncryptionKeysFile, "gossip-encryption-keys-file", gossipEncryptionKeysFile, "File with the keys to use to encrypt memberlist gossip")
+	flags.DurationVar(&gossipIntervals.ProbeInterval, "gossip-probe-interval", gossipIntervals.ProbeInterval, "Interval between memberlist failure detection probes")
+	flags.DurationVar(&gossipIntervals.GossipInterval, "gossip-interval", gossipIntervals.GossipInterval, "Interval between memberlist gossip messages")
+	flags.DurationVar(&gossipIntervals.PushPullInterval, "gossip-push-pull-interval", gossipIntervals.PushPullInterval, "Interval between memberlist full state syncs")
+	flags.DurationVar(&gossipSeedCacheTTL, "gossip-seed-cache-ttl", gossipazure.DefaultSeedCacheTTL, "How long the instances listed for gossip seeds are cached (only on Azure)")
 	flags.StringSliceVarP(&zones, "zone", "z", []string{}, "Configure permitted zones and their mappings")
 
 	bootstrapMasterNodeLabels := false
@@ -134,7 +143,7 @@ func run() error {
 		cloudProvider = osCloudProvider
 
 	} else if cloud == "azure" {
-		azureVolumes, err := protokube.NewAzureCloudProvider()
+		azureVolumes, err := protokube.NewAzureCloudProvider(gossipSeedCacheTTL)
 		if err != nil {
 			klog.Errorf("Error initializing Azure: %q", err)
 			os.Exit(1)
@@ -184,15 +193,24 @@ func run() error {
 			klog.Errorf("error finding gossip seeds: %w", err)
 		}
 
+		var gossipKeys [][]byte
+		if gossipEncryptionKeysFile != "" {
+			gossipKeys, err = gossiputils.ReadEncryptionKeys(gossipEncryptionKeysFile)
+			if err != nil {
+				klog.Errorf("error initializing gossip: %v", err)
+				os.Exit(1)
+			}
+		}
+
 		channelName := "dns"
-		gossipState, err := gossiputils.GetGossipState(gossipProtocol, gossipListen, channelName, gossipName, []byte(gossipSecret), gossipSeeds)
+		gossipState, err := gossiputils.GetGossipState(gossipProtocol, gossipListen, channelName, gossipName, []byte(gossipSecret), gossipKeys, gossipSeeds, gossipIntervals)
 		if err != nil {
 			klog.Errorf("error initializing gossip: %w", err)
 			os.Exit(1)
 		}
 
 		if gossipProtocolSecondary != "" {
-			secondaryGossipState, err := gossiputils.GetGossipState(gossipProtocolSecondary, gossipListenSecondary, channelName, gossipName, []byte(gossipSecretSecondary), gossipSeeds)
+			secondaryGossipState, err := gossiputils.GetGossipState(gossipProtocolSecondary, gossipListenSecondary, channelName, gossipName, []byte(gossipSecretSecondary), gossipKeys, gossipSeeds, gossipIntervals)
 			if err != nil {
 				klog.Errorf("error initializing secondary gossip: %w", err)
 				os.Exit(1)
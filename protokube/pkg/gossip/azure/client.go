@@ -27,9 +27,13 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-06-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2020-06-01/resources"
 	"github.com/Azure/go-autorest/autorest/azure/auth"
+	"github.com/Azure/go-autorest/autorest/to"
 )
 
+const vmScaleSetResourceType = "Microsoft.Compute/virtualMachineScaleSets"
+
 type instanceComputeMetadata struct {
 	Name              string `json:"name"`
 	ResourceGroupName string `json:"resourceGroupName"`
@@ -79,6 +83,7 @@ type Client struct {
 	metadata         *instanceMetadata
 	vmssesClient     *compute.VirtualMachineScaleSetsClient
 	interfacesClient *network.InterfacesClient
+	resourcesClient  *resources.Client
 }
 
 // NewClient returns a new Client.
@@ -105,10 +110,14 @@ func NewClient() (*Client, error) {
 	interfacesClient := network.NewInterfacesClient(m.Compute.SubscriptionID)
 	interfacesClient.Authorizer = authorizer
 
+	resourcesClient := resources.NewClient(m.Compute.SubscriptionID)
+	resourcesClient.Authorizer = authorizer
+
 	return &Client{
 		metadata:         m,
 		vmssesClient:     &vmssesClient,
 		interfacesClient: &interfacesClient,
+		resourcesClient:  &resourcesClient,
 	}, nil
 }
 
@@ -154,6 +163,25 @@ func (c *Client) ListVMScaleSets(ctx context.Context) ([]compute.VirtualMachineS
 	return l, nil
 }
 
+// ListVMScaleSetNamesByTag returns the names of the VM ScaleSets in the resource group that have the specified tag.
+// The tag is filtered by Azure Resource Manager, so only the matching VM ScaleSets are returned.
+func (c *Client) ListVMScaleSetNamesByTag(ctx context.Context, tagName, tagValue string) ([]string, error) {
+	filter := fmt.Sprintf("tagName eq '%s' and tagValue eq '%s'", tagName, tagValue)
+	var l []string
+	for iter, err := c.resourcesClient.ListByResourceGroupComplete(ctx, c.resourceGroupName(), filter, "", nil); iter.NotDone(); err = iter.Next() {
+		if err != nil {
+			return nil, err
+		}
+		r := iter.Value()
+		// Tag filters can't be combined with resource type filters
+		if r.Name == nil || !strings.EqualFold(to.String(r.Type), vmScaleSetResourceType) {
+			continue
+		}
+		l = append(l, *r.Name)
+	}
+	return l, nil
+}
+
 // ListVMSSNetworkInterfaces returns the interfaces that the specified VM ScaleSet has.
 func (c *Client) ListVMSSNetworkInterfaces(ctx context.Context, vmScaleSetName string) ([]network.Interface, error) {
 	var l []network.Interface
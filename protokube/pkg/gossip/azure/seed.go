@@ -18,16 +18,33 @@ package azure
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-06-01/network"
+	"github.com/Azure/go-autorest/autorest"
 	"k8s.io/klog/v2"
 	"k8s.io/kops/protokube/pkg/gossip"
 )
 
+const (
+	// DefaultSeedCacheTTL is how long the VM Scale Sets and network interfaces listed for seeds are reused.
+	DefaultSeedCacheTTL = 5 * time.Minute
+
+	// minThrottleBackoff and maxThrottleBackoff bound how long no requests are made after Azure throttled one,
+	// when it doesn't say how long to wait.
+	minThrottleBackoff = 30 * time.Second
+	maxThrottleBackoff = 10 * time.Minute
+)
+
 type client interface {
 	ListVMScaleSets(ctx context.Context) ([]compute.VirtualMachineScaleSet, error)
+	ListVMScaleSetNamesByTag(ctx context.Context, tagName, tagValue string) ([]string, error)
 	ListVMSSNetworkInterfaces(ctx context.Context, vmScaleSetName string) ([]network.Interface, error)
 }
 
@@ -35,17 +52,34 @@ var _ client = &Client{}
 
 // SeedProvider is an Azure implementation of gossip.SeedProvider.
 type SeedProvider struct {
-	client client
-	tags   map[string]string
+	client   client
+	tags     map[string]string
+	cacheTTL time.Duration
+	now      func() time.Time
+
+	mutex sync.Mutex
+	// vmssNames and vmssIPs cache the results of listing, by the time they expire.
+	vmssNames    *cachedStrings
+	vmssIPs      map[string]*cachedStrings
+	throttled    int
+	backoffUntil time.Time
+}
+
+type cachedStrings struct {
+	values  []string
+	expires time.Time
 }
 
 var _ gossip.SeedProvider = &SeedProvider{}
 
-// NewSeedProvider returns a new SeedProvider.
-func NewSeedProvider(client client, tags map[string]string) (*SeedProvider, error) {
+// NewSeedProvider returns a new SeedProvider, which caches what it lists for cacheTTL.
+func NewSeedProvider(client client, tags map[string]string, cacheTTL time.Duration) (*SeedProvider, error) {
 	return &SeedProvider{
-		client: client,
-		tags:   tags,
+		client:   client,
+		tags:     tags,
+		cacheTTL: cacheTTL,
+		now:      time.Now,
+		vmssIPs:  make(map[string]*cachedStrings),
 	}, nil
 }
 
@@ -53,35 +87,123 @@ func NewSeedProvider(client client, tags map[string]string) (*SeedProvider, erro
 // This follows the implementation of AWS and creates seeds from
 // private IPs of VMs in the cluster.
 func (p *SeedProvider) GetSeeds() ([]string, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
 	ctx := context.TODO()
-	vmsses, err := p.client.ListVMScaleSets(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("error listing VM Scale Sets: %s", err)
+	now := p.now()
+	if now.Before(p.backoffUntil) {
+		return nil, fmt.Errorf("Azure API requests are throttled, not listing seeds until %s", p.backoffUntil.Format(time.RFC3339))
 	}
 
-	var vmssNames []string
-	for _, vmss := range vmsses {
-		if p.isVMSSForCluster(&vmss) {
-			vmssNames = append(vmssNames, *vmss.Name)
-		}
+	vmssNames, err := p.listVMScaleSetNames(ctx, now)
+	if err != nil {
+		return nil, p.checkThrottled(now, fmt.Errorf("error listing VM Scale Sets: %w", err))
 	}
-	klog.V(2).Infof("Found %d VM Scale Sets for the cluster (out of %d)", len(vmssNames), len(vmsses))
 
 	var seeds []string
 	for _, vmssName := range vmssNames {
-		ifaces, err := p.client.ListVMSSNetworkInterfaces(ctx, vmssName)
+		ips, err := p.listVMScaleSetIPs(ctx, now, vmssName)
 		if err != nil {
-			return nil, fmt.Errorf("error listing VMSS network interfaces: %s", err)
+			return nil, p.checkThrottled(now, fmt.Errorf("error listing VMSS network interfaces: %w", err))
 		}
-		for _, iface := range ifaces {
-			for _, i := range *iface.IPConfigurations {
-				seeds = append(seeds, *i.PrivateIPAddress)
-			}
+		seeds = append(seeds, ips...)
+	}
+	p.throttled = 0
+
+	// Forget VM Scale Sets that are gone
+	for vmssName, cached := range p.vmssIPs {
+		if !now.Before(cached.expires) {
+			delete(p.vmssIPs, vmssName)
 		}
 	}
+
 	return seeds, nil
 }
 
+// listVMScaleSetNames returns the names of the VM Scale Sets of the cluster.
+// A single tag is filtered by Azure; otherwise all VM Scale Sets are listed and filtered here.
+func (p *SeedProvider) listVMScaleSetNames(ctx context.Context, now time.Time) ([]string, error) {
+	if p.vmssNames != nil && now.Before(p.vmssNames.expires) {
+		return p.vmssNames.values, nil
+	}
+
+	var vmssNames []string
+	if len(p.tags) == 1 {
+		for k, v := range p.tags {
+			names, err := p.client.ListVMScaleSetNamesByTag(ctx, k, v)
+			if err != nil {
+				return nil, err
+			}
+			vmssNames = names
+		}
+		klog.V(2).Infof("Found %d VM Scale Sets for the cluster", len(vmssNames))
+	} else {
+		vmsses, err := p.client.ListVMScaleSets(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, vmss := range vmsses {
+			if p.isVMSSForCluster(&vmss) {
+				vmssNames = append(vmssNames, *vmss.Name)
+			}
+		}
+		klog.V(2).Infof("Found %d VM Scale Sets for the cluster (out of %d)", len(vmssNames), len(vmsses))
+	}
+	sort.Strings(vmssNames)
+
+	p.vmssNames = &cachedStrings{values: vmssNames, expires: now.Add(p.cacheTTL)}
+	return vmssNames, nil
+}
+
+// listVMScaleSetIPs returns the private IPs of the network interfaces of a VM Scale Set.
+func (p *SeedProvider) listVMScaleSetIPs(ctx context.Context, now time.Time, vmssName string) ([]string, error) {
+	if cached := p.vmssIPs[vmssName]; cached != nil && now.Before(cached.expires) {
+		return cached.values, nil
+	}
+
+	ifaces, err := p.client.ListVMSSNetworkInterfaces(ctx, vmssName)
+	if err != nil {
+		return nil, err
+	}
+	var ips []string
+	for _, iface := range ifaces {
+		if iface.InterfacePropertiesFormat == nil || iface.IPConfigurations == nil {
+			continue
+		}
+		for _, i := range *iface.IPConfigurations {
+			if i.InterfaceIPConfigurationPropertiesFormat != nil && i.PrivateIPAddress != nil {
+				ips = append(ips, *i.PrivateIPAddress)
+			}
+		}
+	}
+
+	p.vmssIPs[vmssName] = &cachedStrings{values: ips, expires: now.Add(p.cacheTTL)}
+	return ips, nil
+}
+
+// checkThrottled stops requests for a while if err means Azure throttled a request.
+// Azure's Retry-After is honoured; otherwise the backoff doubles each time, up to maxThrottleBackoff.
+func (p *SeedProvider) checkThrottled(now time.Time, err error) error {
+	var detailedErr autorest.DetailedError
+	if !errors.As(err, &detailedErr) || detailedErr.StatusCode != http.StatusTooManyRequests {
+		return err
+	}
+
+	backoff := minThrottleBackoff << p.throttled
+	if backoff > maxThrottleBackoff || backoff <= 0 {
+		backoff = maxThrottleBackoff
+	}
+	if detailedErr.Response != nil {
+		backoff = autorest.GetRetryAfter(detailedErr.Response, backoff)
+	}
+	p.throttled++
+	p.backoffUntil = now.Add(backoff)
+
+	klog.Warningf("Azure API requests are throttled, backing off for %s", backoff)
+	return err
+}
+
 func (p *SeedProvider) isVMSSForCluster(vmss *compute.VirtualMachineScaleSet) bool {
 	found := 0
 	for k, v := range vmss.Tags {
@@ -18,26 +18,57 @@ package azure
 
 import (
 	"context"
+	"errors"
+	"net/http"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-06-01/network"
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/to"
 )
 
 type mockClient struct {
 	vmss   []compute.VirtualMachineScaleSet
 	ifaces map[string][]network.Interface
+
+	// err is returned by all calls, if set.
+	err error
+	// calls counts the calls that list resources.
+	calls int
 }
 
 var _ client = &mockClient{}
 
 func (c *mockClient) ListVMScaleSets(ctx context.Context) ([]compute.VirtualMachineScaleSet, error) {
+	c.calls++
+	if c.err != nil {
+		return nil, c.err
+	}
 	return c.vmss, nil
 }
 
+func (c *mockClient) ListVMScaleSetNamesByTag(ctx context.Context, tagName, tagValue string) ([]string, error) {
+	c.calls++
+	if c.err != nil {
+		return nil, c.err
+	}
+	var names []string
+	for _, vmss := range c.vmss {
+		if v := vmss.Tags[tagName]; v != nil && *v == tagValue {
+			names = append(names, *vmss.Name)
+		}
+	}
+	return names, nil
+}
+
 func (c *mockClient) ListVMSSNetworkInterfaces(ctx context.Context, vmScaleSetName string) ([]network.Interface, error) {
+	c.calls++
+	if c.err != nil {
+		return nil, c.err
+	}
 	return c.ifaces[vmScaleSetName], nil
 }
 
@@ -94,18 +125,109 @@ func TestGetSeeds(t *testing.T) {
 			vmssNames[2]: newTestInterfaces(ips[2]),
 		},
 	}
-	provider := SeedProvider{
-		client: client,
-		tags: map[string]string{
-			clusterTag: clusterName,
+	for _, tags := range []map[string]string{
+		// Filtered by Azure
+		{clusterTag: clusterName},
+		// Filtered by the provider
+		{clusterTag: clusterName, "not-relevant-tag-key": "val"},
+	} {
+		provider, err := NewSeedProvider(client, tags, 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		actual, err := provider.GetSeeds()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		expected := []string{ips[0], ips[1]}
+		if len(tags) != 1 {
+			expected = []string{ips[1]}
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("expected seeds %+v for tags %v, but got %+v", expected, tags, actual)
+		}
+	}
+}
+
+func TestGetSeedsCached(t *testing.T) {
+	client := &mockClient{
+		vmss: []compute.VirtualMachineScaleSet{
+			{
+				Name: to.StringPtr("vmss0"),
+				Tags: map[string]*string{
+					"KubernetesCluster": to.StringPtr("test-cluster"),
+				},
+			},
+		},
+		ifaces: map[string][]network.Interface{
+			"vmss0": newTestInterfaces("ip0"),
 		},
 	}
-	actual, err := provider.GetSeeds()
+	provider, err := NewSeedProvider(client, map[string]string{"KubernetesCluster": "test-cluster"}, time.Minute)
 	if err != nil {
 		t.Fatalf("unexpected error: %s", err)
 	}
-	expected := []string{ips[0], ips[1]}
-	if !reflect.DeepEqual(actual, expected) {
-		t.Errorf("expected seeds %+v, but got %+v", expected, actual)
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	provider.now = func() time.Time { return now }
+
+	for _, step := range []struct {
+		advance       time.Duration
+		expectedCalls int
+	}{
+		{0, 2},
+		{30 * time.Second, 2},
+		{time.Minute, 4},
+	} {
+		now = now.Add(step.advance)
+		seeds, err := provider.GetSeeds()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if expected := []string{"ip0"}; !reflect.DeepEqual(seeds, expected) {
+			t.Errorf("expected seeds %+v, but got %+v", expected, seeds)
+		}
+		if client.calls != step.expectedCalls {
+			t.Errorf("expected %d calls after %s, but got %d", step.expectedCalls, step.advance, client.calls)
+		}
+	}
+}
+
+func TestGetSeedsThrottled(t *testing.T) {
+	client := &mockClient{
+		err: autorest.NewErrorWithError(errors.New("too many requests"), "resources.Client", "ListByResourceGroup", &http.Response{
+			StatusCode: http.StatusTooManyRequests,
+			Header:     http.Header{"Retry-After": []string{"120"}},
+		}, "Failure responding to request"),
+	}
+	provider, err := NewSeedProvider(client, map[string]string{"KubernetesCluster": "test-cluster"}, time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	provider.now = func() time.Time { return now }
+
+	if _, err := provider.GetSeeds(); err == nil {
+		t.Fatalf("expected error")
+	}
+	if client.calls != 1 {
+		t.Fatalf("expected 1 call, but got %d", client.calls)
+	}
+
+	// Backing off for the Retry-After of 2 minutes
+	now = now.Add(90 * time.Second)
+	if _, err := provider.GetSeeds(); err == nil {
+		t.Fatalf("expected error")
+	}
+	if client.calls != 1 {
+		t.Errorf("expected no calls while backing off, but got %d", client.calls-1)
+	}
+
+	now = now.Add(time.Minute)
+	client.err = nil
+	if _, err := provider.GetSeeds(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if client.calls != 2 {
+		t.Errorf("expected 2 calls after backing off, but got %d", client.calls)
 	}
 }
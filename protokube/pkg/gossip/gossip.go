@@ -17,8 +17,14 @@ limitations under the License.
 package gossip
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
 	"fmt"
+	"os"
+	"strings"
 	"sync"
+	"time"
 )
 
 type GossipStateSnapshot struct {
@@ -64,7 +70,18 @@ func (m *MultiGossipState) Start() error {
 	return <-errCh
 }
 
-type newGossipFunc func(listen, channelName, gossipName string, gossipSecret []byte, gossipSeeds SeedProvider) (GossipState, error)
+// Intervals tunes the timing of memberlist gossip; zero values keep the defaults.
+// Larger clusters generally need longer intervals, to limit the gossip and probe traffic each member sends.
+type Intervals struct {
+	// ProbeInterval is the interval between failure detection probes of a random member
+	ProbeInterval time.Duration
+	// GossipInterval is the interval between gossip messages to a few random members
+	GossipInterval time.Duration
+	// PushPullInterval is the interval between full state syncs with a random member
+	PushPullInterval time.Duration
+}
+
+type newGossipFunc func(listen, channelName, gossipName string, gossipSecret []byte, gossipKeys [][]byte, gossipSeeds SeedProvider, intervals Intervals) (GossipState, error)
 
 var (
 	gossipMap      = make(map[string]newGossipFunc)
@@ -81,7 +98,7 @@ func Register(name string, f newGossipFunc) {
 	gossipMap[name] = f
 }
 
-func GetGossipState(protocol, listen, channelName, gossipName string, gossipSecret []byte, gossipSeeds SeedProvider) (GossipState, error) {
+func GetGossipState(protocol, listen, channelName, gossipName string, gossipSecret []byte, gossipKeys [][]byte, gossipSeeds SeedProvider, intervals Intervals) (GossipState, error) {
 	gossipMapMutex.Lock()
 	f, ok := gossipMap[protocol]
 	gossipMapMutex.Unlock()
@@ -89,5 +106,44 @@ func GetGossipState(protocol, listen, channelName, gossipName string, gossipSecr
 		return nil, fmt.Errorf("Unknown gossip protocol: %s", protocol)
 	}
 
-	return f(listen, channelName, gossipName, gossipSecret, gossipSeeds)
+	return f(listen, channelName, gossipName, gossipSecret, gossipKeys, gossipSeeds, intervals)
+}
+
+// ReadEncryptionKeys reads the keys used to encrypt gossip from a file that holds one base64 encoded key per line.
+// The first key is the primary key, which is used to encrypt messages; all of the keys are used to decrypt messages.
+func ReadEncryptionKeys(p string) ([][]byte, error) {
+	b, err := os.ReadFile(p)
+	if err != nil {
+		return nil, fmt.Errorf("error reading gossip encryption keys: %w", err)
+	}
+	return ParseEncryptionKeys(b)
+}
+
+// ParseEncryptionKeys parses the contents of a gossip encryption keys file.
+func ParseEncryptionKeys(b []byte) ([][]byte, error) {
+	var keys [][]byte
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		key, err := base64.StdEncoding.DecodeString(line)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding gossip encryption key %d: %w", len(keys)+1, err)
+		}
+		switch len(key) {
+		case 16, 24, 32:
+		default:
+			return nil, fmt.Errorf("gossip encryption key %d must be 16, 24 or 32 bytes, was %d bytes", len(keys)+1, len(key))
+		}
+		keys = append(keys, key)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no gossip encryption keys found")
+	}
+	return keys, nil
 }
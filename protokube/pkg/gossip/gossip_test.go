@@ -0,0 +1,80 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gossip
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestParseEncryptionKeys(t *testing.T) {
+	grid := []struct {
+		name    string
+		data    string
+		keys    [][]byte
+		wantErr bool
+	}{
+		{
+			name: "single key",
+			data: "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY=\n",
+			keys: [][]byte{[]byte("0123456789abcdef0123456789abcdef")},
+		},
+		{
+			name: "primary and previous key",
+			data: "\nMDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY=\n  MDEyMzQ1Njc4OWFiY2RlZg==  \n\n",
+			keys: [][]byte{[]byte("0123456789abcdef0123456789abcdef"), []byte("0123456789abcdef")},
+		},
+		{
+			name:    "no keys",
+			data:    "\n",
+			wantErr: true,
+		},
+		{
+			name:    "not base64",
+			data:    "not a key\n",
+			wantErr: true,
+		},
+		{
+			name:    "wrong length",
+			data:    "c2hvcnQ=\n",
+			wantErr: true,
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			keys, err := ParseEncryptionKeys([]byte(g.data))
+			if g.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %q", keys)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(keys) != len(g.keys) {
+				t.Fatalf("unexpected keys: got %q, want %q", keys, g.keys)
+			}
+			for i := range keys {
+				if !bytes.Equal(keys[i], g.keys[i]) {
+					t.Errorf("unexpected key %d: got %q, want %q", i, keys[i], g.keys[i])
+				}
+			}
+		})
+	}
+}
@@ -56,6 +56,12 @@ func (p *SeedProvider) GetSeeds() ([]string, error) {
 	}
 
 	for _, server := range servers {
+		switch server.Status {
+		case hcloud.ServerStatusInitializing, hcloud.ServerStatusStarting, hcloud.ServerStatusRunning:
+		default:
+			klog.V(4).Infof("Skipping gossip seed %s(%d) with status %q", server.Name, server.ID, server.Status)
+			continue
+		}
 		if len(server.PrivateNet) == 0 {
 			klog.Warningf("failed to find private net of the server %s(%d)", server.Name, server.ID)
 			continue
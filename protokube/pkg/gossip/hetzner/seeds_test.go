@@ -0,0 +1,76 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hetzner
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/hetznercloud/hcloud-go/hcloud"
+	"github.com/hetznercloud/hcloud-go/hcloud/schema"
+)
+
+func TestGetSeeds(t *testing.T) {
+	const clusterName = "test-cluster"
+
+	var labelSelector string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/servers" {
+			http.NotFound(w, r)
+			return
+		}
+		labelSelector = r.URL.Query().Get("label_selector")
+
+		resp := struct {
+			schema.ServerListResponse
+			Meta schema.Meta `json:"meta"`
+		}{}
+		resp.Servers = []schema.Server{
+			{ID: 1, Name: "master", Status: "running", PrivateNet: []schema.ServerPrivateNet{{IP: "10.10.0.2"}}},
+			{ID: 2, Name: "starting", Status: "starting", PrivateNet: []schema.ServerPrivateNet{{IP: "10.10.0.3"}}},
+			{ID: 3, Name: "off", Status: "off", PrivateNet: []schema.ServerPrivateNet{{IP: "10.10.0.4"}}},
+			{ID: 4, Name: "no-private-net", Status: "running"},
+		}
+		resp.Meta.Pagination = &schema.MetaPagination{Page: 1, PerPage: 50, LastPage: 1, TotalEntries: len(resp.Servers)}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			t.Errorf("error encoding response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := hcloud.NewClient(hcloud.WithEndpoint(server.URL), hcloud.WithToken("token"))
+	provider, err := NewSeedProvider(client, clusterName)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	seeds, err := provider.GetSeeds()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if expected := "kops.k8s.io/cluster=" + clusterName; labelSelector != expected {
+		t.Errorf("expected label selector %q, but got %q", expected, labelSelector)
+	}
+	if expected := []string{"10.10.0.2", "10.10.0.3"}; !reflect.DeepEqual(seeds, expected) {
+		t.Errorf("expected seeds %v, but got %v", expected, seeds)
+	}
+}
@@ -31,21 +31,24 @@ import (
 )
 
 func init() {
-	gossip.Register("memberlist", func(listen, channelName, gossipName string, gossipSecret []byte, gossipSeeds gossip.SeedProvider) (gossip.GossipState, error) {
-		return NewMemberlistGossiper(listen, channelName, gossipName, gossipSecret, gossipSeeds)
+	gossip.Register("memberlist", func(listen, channelName, gossipName string, gossipSecret []byte, gossipKeys [][]byte, gossipSeeds gossip.SeedProvider, intervals gossip.Intervals) (gossip.GossipState, error) {
+		return NewMemberlistGossiper(listen, channelName, gossipName, gossipSecret, gossipKeys, gossipSeeds, intervals)
 	})
 }
 
 type MemberlistGossiper struct {
-	peer       *cluster.Peer
+	peer       meshPeer
 	seeds      gossip.SeedProvider
 	listenPort int
+	intervals  gossip.Intervals
 
 	state *state
 	bcast func([]byte)
 }
 
-func NewMemberlistGossiper(listen string, channelName string, nodeName string, password []byte, seeds gossip.SeedProvider) (*MemberlistGossiper, error) {
+// NewMemberlistGossiper builds a gossiper for the channel.  If keys are provided, gossip is encrypted with the first key,
+// and messages encrypted with any of the keys are accepted.
+func NewMemberlistGossiper(listen string, channelName string, nodeName string, password []byte, keys [][]byte, seeds gossip.SeedProvider, intervals gossip.Intervals) (*MemberlistGossiper, error) {
 	_, portString, err := net.SplitHostPort(listen)
 	if err != nil {
 		return nil, fmt.Errorf("cannot parse -listen flag: %v", listen)
@@ -66,28 +69,46 @@ func NewMemberlistGossiper(listen string, channelName string, nodeName string, p
 		}
 	}
 
+	intervals = withDefaultIntervals(intervals)
+
+	s := &state{}
+
+	if len(keys) != 0 {
+		peer, err := newKeyringPeer(listen, nodeName, initialPeers, keys, channelName, s, intervals)
+		if err != nil {
+			return nil, err
+		}
+		return &MemberlistGossiper{
+			peer:       peer,
+			seeds:      seeds,
+			listenPort: port,
+			intervals:  intervals,
+			state:      s,
+			bcast:      peer.Broadcast,
+		}, nil
+	}
+
 	peer, err := cluster.Create(
 		prometheus.DefaultRegisterer,
 		listen,
 		"", //*clusterAdvertiseAddr,
 		initialPeers,
 		true,
-		cluster.DefaultPushPullInterval,
-		cluster.DefaultGossipInterval,
+		intervals.PushPullInterval,
+		intervals.GossipInterval,
 		cluster.DefaultTcpTimeout,
 		cluster.DefaultProbeTimeout,
-		cluster.DefaultProbeInterval,
+		intervals.ProbeInterval,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	s := &state{}
-
 	return &MemberlistGossiper{
 		peer:       peer,
 		seeds:      seeds,
 		listenPort: port,
+		intervals:  intervals,
 		state:      s,
 		bcast:      peer.AddState(channelName, s, prometheus.DefaultRegisterer).Broadcast,
 	}, nil
@@ -97,7 +118,7 @@ func (g *MemberlistGossiper) Start() error {
 	if err := g.peer.Join(cluster.DefaultReconnectInterval, cluster.DefaultReconnectTimeout); err != nil {
 		return err
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), cluster.DefaultPushPullInterval)
+	ctx, cancel := context.WithTimeout(context.Background(), g.intervals.PushPullInterval)
 	defer func() {
 		cancel()
 		if err := g.peer.Leave(10 * time.Second); err != nil {
@@ -105,12 +126,26 @@ func (g *MemberlistGossiper) Start() error {
 		}
 	}()
 
-	g.peer.Settle(ctx, cluster.DefaultGossipInterval*10)
+	g.peer.Settle(ctx, g.intervals.GossipInterval*10)
 	g.runSeeding()
 
 	return nil
 }
 
+// withDefaultIntervals fills in the memberlistmesh defaults for any intervals that are not set
+func withDefaultIntervals(intervals gossip.Intervals) gossip.Intervals {
+	if intervals.ProbeInterval == 0 {
+		intervals.ProbeInterval = cluster.DefaultProbeInterval
+	}
+	if intervals.GossipInterval == 0 {
+		intervals.GossipInterval = cluster.DefaultGossipInterval
+	}
+	if intervals.PushPullInterval == 0 {
+		intervals.PushPullInterval = cluster.DefaultPushPullInterval
+	}
+	return intervals
+}
+
 func (g *MemberlistGossiper) runSeeding() {
 SEED_LOOP:
 	for {
@@ -0,0 +1,282 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package memberlist
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/hashicorp/memberlist"
+	cluster "github.com/jacksontj/memberlistmesh"
+	"github.com/jacksontj/memberlistmesh/clusterpb"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/protokube/pkg/gossip"
+)
+
+// maxGossipPacketSize matches memberlistmesh; larger messages are sent to each member over TCP.
+const maxGossipPacketSize = 1400
+
+// meshPeer is the membership of a memberlist cluster, as used by the gossiper.
+type meshPeer interface {
+	Join(reconnectInterval time.Duration, reconnectTimeout time.Duration) error
+	Settle(ctx context.Context, interval time.Duration)
+	Leave(timeout time.Duration) error
+	AddPeer(peerAddr string) error
+}
+
+var _ meshPeer = &cluster.Peer{}
+var _ meshPeer = &keyringPeer{}
+
+// keyringPeer is a member of a memberlist cluster that encrypts its traffic with a keyring.
+// It exchanges the same messages as the memberlistmesh peer, which doesn't allow configuring encryption,
+// so it can gossip the state of a channel with peers that use the same keys.
+type keyringPeer struct {
+	mlist        *memberlist.Memberlist
+	initialPeers []string
+
+	channelName string
+	state       *state
+
+	broadcasts *memberlist.TransmitLimitedQueue
+	oversized  chan []byte
+}
+
+var _ memberlist.Delegate = &keyringPeer{}
+
+func newKeyringPeer(listen string, nodeName string, initialPeers []string, keys [][]byte, channelName string, s *state, intervals gossip.Intervals) (*keyringPeer, error) {
+	host, portString, err := net.SplitHostPort(listen)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse listen address %q: %w", listen, err)
+	}
+	port, err := strconv.Atoi(portString)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse listen address %q: %w", listen, err)
+	}
+
+	keyring, err := memberlist.NewKeyring(keys, keys[0])
+	if err != nil {
+		return nil, fmt.Errorf("error building gossip keyring: %w", err)
+	}
+
+	retransmit := len(initialPeers) / 2
+	if retransmit < 3 {
+		retransmit = 3
+	}
+
+	p := &keyringPeer{
+		initialPeers: initialPeers,
+		channelName:  channelName,
+		state:        s,
+		oversized:    make(chan []byte, 200),
+	}
+	p.broadcasts = &memberlist.TransmitLimitedQueue{
+		NumNodes:       func() int { return p.mlist.NumMembers() },
+		RetransmitMult: retransmit,
+	}
+
+	cfg := memberlist.DefaultLANConfig()
+	cfg.Name = nodeName
+	cfg.BindAddr = host
+	cfg.BindPort = port
+	cfg.Delegate = p
+	cfg.GossipInterval = intervals.GossipInterval
+	cfg.PushPullInterval = intervals.PushPullInterval
+	cfg.TCPTimeout = cluster.DefaultTcpTimeout
+	cfg.ProbeTimeout = cluster.DefaultProbeTimeout
+	cfg.ProbeInterval = intervals.ProbeInterval
+	cfg.GossipNodes = retransmit
+	cfg.UDPBufferSize = maxGossipPacketSize
+	cfg.LogOutput = &logWriter{}
+	cfg.Keyring = keyring
+
+	mlist, err := memberlist.Create(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("error creating memberlist: %w", err)
+	}
+	p.mlist = mlist
+
+	go p.sendOversized()
+
+	return p, nil
+}
+
+// Join joins the initial peers.  Peers that can't be reached are joined again when the gossiper reseeds,
+// so failing to join is not an error.
+func (p *keyringPeer) Join(reconnectInterval time.Duration, reconnectTimeout time.Duration) error {
+	if len(p.initialPeers) == 0 {
+		return nil
+	}
+	n, err := p.mlist.Join(p.initialPeers)
+	if err != nil {
+		klog.Warningf("failed to join gossip cluster: %v", err)
+	} else {
+		klog.V(2).Infof("joined gossip cluster peers=%d", n)
+	}
+	return nil
+}
+
+// Settle waits until the number of members has been stable for a few intervals, or until ctx is done.
+func (p *keyringPeer) Settle(ctx context.Context, interval time.Duration) {
+	const numOkayRequired = 3
+
+	nMembers := 0
+	nOkay := 0
+	for nOkay < numOkayRequired {
+		select {
+		case <-ctx.Done():
+			klog.Infof("gossip not settled but continuing anyway")
+			return
+		case <-time.After(interval):
+		}
+		n := p.mlist.NumMembers()
+		if n == nMembers {
+			nOkay++
+		} else {
+			nOkay = 0
+		}
+		nMembers = n
+	}
+	klog.Infof("gossip settled with %d members", nMembers)
+}
+
+func (p *keyringPeer) Leave(timeout time.Duration) error {
+	if err := p.mlist.Leave(timeout); err != nil {
+		return err
+	}
+	return p.mlist.Shutdown()
+}
+
+// AddPeer joins the peer, unless it is already a member.
+func (p *keyringPeer) AddPeer(peerAddr string) error {
+	for _, member := range p.mlist.Members() {
+		if member.Address() == peerAddr {
+			return nil
+		}
+	}
+	_, err := p.mlist.Join([]string{peerAddr})
+	return err
+}
+
+// Broadcast sends the state of the channel to the other members.
+func (p *keyringPeer) Broadcast(b []byte) {
+	b, err := proto.Marshal(&clusterpb.Part{Key: p.channelName, Data: b})
+	if err != nil {
+		klog.Warningf("error encoding gossip broadcast: %v", err)
+		return
+	}
+
+	if len(b) > maxGossipPacketSize/2 {
+		select {
+		case p.oversized <- b:
+		default:
+			klog.Warningf("dropping oversized gossip broadcast; too many are queued")
+		}
+		return
+	}
+	p.broadcasts.QueueBroadcast(simpleBroadcast(b))
+}
+
+func (p *keyringPeer) sendOversized() {
+	for b := range p.oversized {
+		self := p.mlist.LocalNode().Name
+		for _, member := range p.mlist.Members() {
+			if member.Name == self {
+				continue
+			}
+			if err := p.mlist.SendReliable(member, b); err != nil {
+				klog.V(2).Infof("failed to send gossip broadcast to %v: %v", member.Name, err)
+			}
+		}
+	}
+}
+
+// NodeMeta implements memberlist.Delegate
+func (p *keyringPeer) NodeMeta(limit int) []byte {
+	return []byte{}
+}
+
+// NotifyMsg implements memberlist.Delegate
+func (p *keyringPeer) NotifyMsg(b []byte) {
+	var part clusterpb.Part
+	if err := proto.Unmarshal(b, &part); err != nil {
+		klog.Warningf("error decoding gossip broadcast: %v", err)
+		return
+	}
+	if part.Key != p.channelName {
+		return
+	}
+	if err := p.state.Merge(part.Data); err != nil {
+		klog.Warningf("error merging gossip broadcast: %v", err)
+	}
+}
+
+// GetBroadcasts implements memberlist.Delegate
+func (p *keyringPeer) GetBroadcasts(overhead, limit int) [][]byte {
+	return p.broadcasts.GetBroadcasts(overhead, limit)
+}
+
+// LocalState implements memberlist.Delegate
+func (p *keyringPeer) LocalState(join bool) []byte {
+	data, err := p.state.MarshalBinary()
+	if err != nil {
+		klog.Warningf("error encoding gossip state: %v", err)
+		return nil
+	}
+	b, err := proto.Marshal(&clusterpb.FullState{
+		Parts: []clusterpb.Part{{Key: p.channelName, Data: data}},
+	})
+	if err != nil {
+		klog.Warningf("error encoding gossip state: %v", err)
+		return nil
+	}
+	return b
+}
+
+// MergeRemoteState implements memberlist.Delegate
+func (p *keyringPeer) MergeRemoteState(buf []byte, join bool) {
+	var fs clusterpb.FullState
+	if err := proto.Unmarshal(buf, &fs); err != nil {
+		klog.Warningf("error decoding remote gossip state: %v", err)
+		return
+	}
+	for _, part := range fs.Parts {
+		if part.Key != p.channelName {
+			continue
+		}
+		if err := p.state.Merge(part.Data); err != nil {
+			klog.Warningf("error merging remote gossip state: %v", err)
+		}
+	}
+}
+
+// simpleBroadcast is a broadcast that is never invalidated by others.
+type simpleBroadcast []byte
+
+func (b simpleBroadcast) Message() []byte                       { return []byte(b) }
+func (b simpleBroadcast) Invalidates(memberlist.Broadcast) bool { return false }
+func (b simpleBroadcast) Finished()                             {}
+
+type logWriter struct{}
+
+func (l *logWriter) Write(b []byte) (int, error) {
+	klog.V(2).Infof("memberlist %s", string(b))
+	return len(b), nil
+}
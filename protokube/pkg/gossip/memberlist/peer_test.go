@@ -0,0 +1,120 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package memberlist
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"k8s.io/kops/protokube/pkg/gossip"
+)
+
+var (
+	key1 = []byte("0123456789abcdef0123456789abcdef")
+	key2 = []byte("fedcba9876543210fedcba9876543210")
+)
+
+func startKeyringPeer(t *testing.T, name string, keys [][]byte, initialPeers []string) *keyringPeer {
+	t.Helper()
+	p, err := newKeyringPeer("127.0.0.1:0", name, initialPeers, keys, "dns", &state{}, withDefaultIntervals(gossip.Intervals{}))
+	if err != nil {
+		t.Fatalf("error creating peer: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = p.Leave(time.Second)
+	})
+	if err := p.Join(0, 0); err != nil {
+		t.Fatalf("error joining: %v", err)
+	}
+	return p
+}
+
+func address(p *keyringPeer) string {
+	node := p.mlist.LocalNode()
+	return fmt.Sprintf("%s:%d", node.Addr, node.Port)
+}
+
+func TestKeyringPeerGossip(t *testing.T) {
+	grid := []struct {
+		name     string
+		keysA    [][]byte
+		keysB    [][]byte
+		joinable bool
+	}{
+		{
+			name:     "same key",
+			keysA:    [][]byte{key1},
+			keysB:    [][]byte{key1},
+			joinable: true,
+		},
+		{
+			name:     "rotating to a new primary key",
+			keysA:    [][]byte{key1, key2},
+			keysB:    [][]byte{key2, key1},
+			joinable: true,
+		},
+		{
+			name:     "different keys",
+			keysA:    [][]byte{key1},
+			keysB:    [][]byte{key2},
+			joinable: false,
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			a := startKeyringPeer(t, "a", g.keysA, nil)
+			b, err := newKeyringPeer("127.0.0.1:0", "b", nil, g.keysB, "dns", &state{}, withDefaultIntervals(gossip.Intervals{}))
+			if err != nil {
+				t.Fatalf("error creating peer: %v", err)
+			}
+			t.Cleanup(func() {
+				_ = b.Leave(time.Second)
+			})
+
+			err = b.AddPeer(address(a))
+			if !g.joinable {
+				if err == nil {
+					t.Fatalf("expected peers with different keys not to join")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error joining: %v", err)
+			}
+
+			a.state.updateValues(nil, map[string]string{"api.internal.example.com": "10.0.0.1"})
+			data, err := a.state.MarshalBinary()
+			if err != nil {
+				t.Fatalf("error encoding state: %v", err)
+			}
+			a.Broadcast(data)
+
+			deadline := time.Now().Add(10 * time.Second)
+			for {
+				if b.state.snapshot().Values["api.internal.example.com"] == "10.0.0.1" {
+					break
+				}
+				if time.Now().After(deadline) {
+					t.Fatalf("state was not gossiped: %v", b.state.snapshot().Values)
+				}
+				time.Sleep(100 * time.Millisecond)
+			}
+		})
+	}
+}
@@ -29,7 +29,8 @@ import (
 )
 
 func init() {
-	gossip.Register("mesh", func(listen, channelName, gossipName string, gossipSecret []byte, gossipSeeds gossip.SeedProvider) (gossip.GossipState, error) {
+	gossip.Register("mesh", func(listen, channelName, gossipName string, gossipSecret []byte, gossipKeys [][]byte, gossipSeeds gossip.SeedProvider, intervals gossip.Intervals) (gossip.GossipState, error) {
+		// Mesh gossip is secured with the secret; the encryption keys and intervals only apply to memberlist gossip.
 		return NewMeshGossiper(listen, channelName, gossipName, gossipSecret, gossipSeeds)
 	})
 }
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2020-06-01/network"
@@ -30,6 +31,7 @@ import (
 
 type client interface {
 	ListVMScaleSets(ctx context.Context) ([]compute.VirtualMachineScaleSet, error)
+	ListVMScaleSetNamesByTag(ctx context.Context, tagName, tagValue string) ([]string, error)
 	ListVMSSNetworkInterfaces(ctx context.Context, vmScaleSetName string) ([]network.Interface, error)
 	GetName() string
 	GetTags() (map[string]string, error)
@@ -45,12 +47,14 @@ type AzureCloudProvider struct {
 	clusterTag string
 	instanceID string
 	internalIP net.IP
+
+	seedCacheTTL time.Duration
 }
 
 var _ CloudProvider = &AzureCloudProvider{}
 
-// NewAzureCloudProvider returns a new AzureCloudProvider.
-func NewAzureCloudProvider() (*AzureCloudProvider, error) {
+// NewAzureCloudProvider returns a new AzureCloudProvider, which caches what it lists for gossip seeds for seedCacheTTL.
+func NewAzureCloudProvider(seedCacheTTL time.Duration) (*AzureCloudProvider, error) {
 	client, err := gossipazure.NewClient()
 	if err != nil {
 		return nil, fmt.Errorf("error creating a new Azure client: %s", err)
@@ -77,6 +81,8 @@ func NewAzureCloudProvider() (*AzureCloudProvider, error) {
 		clusterTag: clusterTag,
 		instanceID: instanceID,
 		internalIP: internalIP,
+
+		seedCacheTTL: seedCacheTTL,
 	}, nil
 }
 
@@ -95,5 +101,5 @@ func (a *AzureCloudProvider) GossipSeeds() (gossip.SeedProvider, error) {
 	tags := map[string]string{
 		azure.TagClusterName: a.clusterTag,
 	}
-	return gossipazure.NewSeedProvider(a.client, tags)
+	return gossipazure.NewSeedProvider(a.client, tags, a.seedCacheTTL)
 }
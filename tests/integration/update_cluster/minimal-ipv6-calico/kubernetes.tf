@@ -491,6 +491,7 @@ resource "aws_lb_listener" "api-minimal-ipv6-example-com-443" {
 resource "aws_lb_target_group" "tcp-minimal-ipv6-example--bne5ih" {
   health_check {
     healthy_threshold   = 2
+    port                = "443"
     protocol            = "TCP"
     unhealthy_threshold = 2
   }
@@ -977,6 +978,7 @@ resource "aws_security_group_rule" "icmpv6-pmtu-api-elb-__--0" {
 resource "aws_subnet" "us-test-1a-minimal-ipv6-example-com" {
   availability_zone                              = "us-test-1a"
   cidr_block                                     = "172.20.32.0/19"
+  enable_dns64                                   = true
   enable_resource_name_dns_a_record_on_launch    = true
   enable_resource_name_dns_aaaa_record_on_launch = true
   ipv6_cidr_block                                = "2001:db8:0:111::/64"
@@ -554,6 +554,7 @@ resource "aws_lb_listener" "api-minimal-ipv6-example-com-443" {
 resource "aws_lb_target_group" "tcp-minimal-ipv6-example--bne5ih" {
   health_check {
     healthy_threshold   = 2
+    port                = "443"
     protocol            = "TCP"
     unhealthy_threshold = 2
   }
@@ -1086,6 +1087,7 @@ resource "aws_security_group_rule" "icmpv6-pmtu-api-elb-__--0" {
 resource "aws_subnet" "dualstack-us-test-1a-minimal-ipv6-example-com" {
   availability_zone                              = "us-test-1a"
   cidr_block                                     = "172.20.32.0/19"
+  enable_dns64                                   = true
   enable_resource_name_dns_a_record_on_launch    = true
   enable_resource_name_dns_aaaa_record_on_launch = true
   ipv6_cidr_block                                = "2001:db8:0:113::/64"
@@ -1103,6 +1105,7 @@ resource "aws_subnet" "dualstack-us-test-1a-minimal-ipv6-example-com" {
 resource "aws_subnet" "dualstack-us-test-1b-minimal-ipv6-example-com" {
   availability_zone                              = "us-test-1b"
   cidr_block                                     = "172.20.64.0/19"
+  enable_dns64                                   = true
   enable_resource_name_dns_a_record_on_launch    = true
   enable_resource_name_dns_aaaa_record_on_launch = true
   ipv6_cidr_block                                = "2001:db8:0:114::/64"
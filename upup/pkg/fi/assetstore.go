@@ -93,6 +93,11 @@ func (r *assetResource) GetSource() *Source {
 type AssetStore struct {
 	cacheDir string
 	assets   []*asset
+
+	// sharedCacheDir is a directory shared with other instances, holding assets at <sharedCacheDir>/<hash>/<name>
+	sharedCacheDir string
+	// cacheURL is the base URL of a read-only cache, holding assets at <cacheURL>/<hash>/<name>
+	cacheURL string
 }
 
 func NewAssetStore(cacheDir string) *AssetStore {
@@ -102,6 +107,13 @@ func NewAssetStore(cacheDir string) *AssetStore {
 	return a
 }
 
+// SetSharedCache configures caches that are tried before the urls of an asset.
+// Either argument may be empty.
+func (a *AssetStore) SetSharedCache(dir string, url string) {
+	a.sharedCacheDir = dir
+	a.cacheURL = strings.TrimSuffix(url, "/")
+}
+
 func (a *AssetStore) FindMatches(expr *regexp.Regexp) map[string]Resource {
 	matches := make(map[string]Resource)
 
@@ -245,6 +257,17 @@ func (a *AssetStore) addURLs(urls []string, hash *hashing.Hash) error {
 	key := path.Base(primaryURL)
 	localFile := path.Join(a.cacheDir, hash.String()+"_"+utils.SanitizeString(key))
 
+	var sharedFile string
+	if a.sharedCacheDir != "" {
+		sharedFile = path.Join(a.sharedCacheDir, hash.Hex(), utils.SanitizeString(key))
+		if err := copyFileWithHash(sharedFile, localFile, hash); err != nil {
+			klog.Warningf("unable to use %q from shared cache: %v", sharedFile, err)
+		}
+	}
+	if a.cacheURL != "" {
+		urls = append([]string{a.cacheURL + "/" + hash.Hex() + "/" + key}, urls...)
+	}
+
 	for _, url := range urls {
 		_, err = DownloadURL(url, localFile, hash)
 		if err != nil {
@@ -258,6 +281,12 @@ func (a *AssetStore) addURLs(urls []string, hash *hashing.Hash) error {
 		return err
 	}
 
+	if sharedFile != "" {
+		if err := copyFileWithHash(localFile, sharedFile, hash); err != nil {
+			klog.Warningf("unable to store %q in shared cache: %v", sharedFile, err)
+		}
+	}
+
 	assetPath := primaryURL
 	r := NewFileResource(localFile)
 
@@ -327,7 +356,7 @@ func (a *AssetStore) addArchive(archiveSource *Source, archiveFile string) error
 			return fmt.Errorf("error finding relative path for %q: %v", localPath, err)
 		}
 
-		assetPath := path.Join(assetBase, relativePath)
+		assetPath := path.Join(assetBase, filepath.ToSlash(relativePath))
 		key := info.Name()
 		r := NewFileResource(localPath)
 
@@ -349,3 +378,54 @@ func (a *AssetStore) addArchive(archiveSource *Source, archiveFile string) error
 	}
 	return nil
 }
+
+// copyFileWithHash copies src to dest if src matches the hash and dest does not.
+// The copy is written to a temporary file that is then renamed, so that other readers of dest never see a partial file.
+func copyFileWithHash(src string, dest string, hash *hashing.Hash) error {
+	match, err := fileHasHash(dest, hash)
+	if err != nil || match {
+		return err
+	}
+	match, err = fileHasHash(src, hash)
+	if err != nil || !match {
+		return err
+	}
+
+	if err := os.MkdirAll(path.Dir(dest), 0o755); err != nil {
+		return fmt.Errorf("error creating directories for %q: %v", dest, err)
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("error opening %q: %v", src, err)
+	}
+	defer in.Close()
+
+	tmp := dest + ".tmp-" + strconv.FormatInt(time.Now().UnixNano(), 10)
+	out, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("error creating %q: %v", tmp, err)
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(tmp)
+		return fmt.Errorf("error copying %q to %q: %v", src, tmp, err)
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("error writing %q: %v", tmp, err)
+	}
+
+	match, err = fileHasHash(tmp, hash)
+	if err != nil || !match {
+		os.Remove(tmp)
+		if err == nil {
+			err = fmt.Errorf("copy of %q did not match expected hash %q", src, hash)
+		}
+		return err
+	}
+	if err := os.Rename(tmp, dest); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("error renaming %q to %q: %v", tmp, dest, err)
+	}
+	return nil
+}
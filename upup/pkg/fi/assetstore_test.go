@@ -0,0 +1,121 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fi
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"testing"
+
+	"k8s.io/kops/util/pkg/hashing"
+)
+
+func TestAssetStoreSharedCache(t *testing.T) {
+	contents := []byte("kubelet binary")
+	hash, err := hashing.HashAlgorithmSHA256.Hash(bytes.NewReader(contents))
+	if err != nil {
+		t.Fatalf("error hashing contents: %v", err)
+	}
+
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path)
+		switch r.URL.Path {
+		case "/release/kubelet", "/cache/" + hash.Hex() + "/kubelet":
+			w.Write(contents)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	asset := hash.String() + "@" + server.URL + "/release/kubelet"
+	sharedDir := t.TempDir()
+	sharedFile := path.Join(sharedDir, hash.Hex(), "kubelet")
+
+	// The first instance downloads the asset and stores it in the shared cache
+	{
+		requests = nil
+		store := NewAssetStore(t.TempDir())
+		store.SetSharedCache(sharedDir, "")
+		if err := store.Add(asset); err != nil {
+			t.Fatalf("error adding asset: %v", err)
+		}
+		if len(requests) != 1 || requests[0] != "/release/kubelet" {
+			t.Errorf("unexpected requests %v", requests)
+		}
+		b, err := os.ReadFile(sharedFile)
+		if err != nil {
+			t.Fatalf("asset was not stored in shared cache: %v", err)
+		}
+		if !bytes.Equal(b, contents) {
+			t.Errorf("unexpected contents in shared cache %q", b)
+		}
+	}
+
+	// Other instances copy it from the shared cache
+	{
+		requests = nil
+		store := NewAssetStore(t.TempDir())
+		store.SetSharedCache(sharedDir, "")
+		if err := store.Add(asset); err != nil {
+			t.Fatalf("error adding asset: %v", err)
+		}
+		if len(requests) != 0 {
+			t.Errorf("unexpected requests %v", requests)
+		}
+	}
+
+	// A corrupt file in the shared cache is ignored, and replaced
+	{
+		if err := os.WriteFile(sharedFile, []byte("corrupt"), 0o644); err != nil {
+			t.Fatalf("error writing file: %v", err)
+		}
+		requests = nil
+		store := NewAssetStore(t.TempDir())
+		store.SetSharedCache(sharedDir, "")
+		if err := store.Add(asset); err != nil {
+			t.Fatalf("error adding asset: %v", err)
+		}
+		if len(requests) != 1 {
+			t.Errorf("unexpected requests %v", requests)
+		}
+		b, err := os.ReadFile(sharedFile)
+		if err != nil {
+			t.Fatalf("error reading shared cache: %v", err)
+		}
+		if !bytes.Equal(b, contents) {
+			t.Errorf("corrupt file in shared cache was not replaced, got %q", b)
+		}
+	}
+
+	// The cache URL is tried before the asset's own URL
+	{
+		requests = nil
+		store := NewAssetStore(t.TempDir())
+		store.SetSharedCache("", server.URL+"/cache/")
+		if err := store.Add(asset); err != nil {
+			t.Fatalf("error adding asset: %v", err)
+		}
+		if len(requests) != 1 || requests[0] != "/cache/"+hash.Hex()+"/kubelet" {
+			t.Errorf("unexpected requests %v", requests)
+		}
+	}
+}
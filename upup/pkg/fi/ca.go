@@ -51,6 +51,9 @@ type Keyset struct {
 	// Primary is the KeysetItem that is considered the "active" key.
 	// It is guaranteed to be non-nil, if there are any keypairs.
 	Primary *KeysetItem
+
+	// Rotation is the state of the rotation of the keyset by "kops rotate ca", if one is in progress.
+	Rotation *kops.KeysetRotation
 }
 
 // KeysetItem is a certificate/key pair in a Keyset.
@@ -64,6 +67,10 @@ type KeysetItem struct {
 	Certificate *pki.Certificate
 	// PrivateKey is a reference to the keypair's private key.
 	PrivateKey *pki.PrivateKey
+	// ExternalCertificateID identifies the certificate in the certificate authority service that issued it, if any.
+	ExternalCertificateID string
+	// KMSKey is the cloud KMS key with which the private key is wrapped in the keystore, if any.
+	KMSKey string
 }
 
 // Keystore contains just the functions we need to issue keypairs, not to list / manage them
@@ -92,15 +99,29 @@ type CAStore interface {
 	ListKeysets() (map[string]*Keyset, error)
 }
 
+// CertificateIssuer is implemented by clouds whose certificate authority service can issue
+// the certificates of the cluster CAs.
+type CertificateIssuer interface {
+	// IssueCACertificate has the certificate authority identified by issuer sign a CA certificate
+	// for the PEM-encoded certificate request. It returns the certificate and its identifier in the service.
+	IssueCACertificate(issuer string, csr []byte, validity time.Duration) (*pki.Certificate, string, error)
+}
+
 // SSHCredentialStore holds SSHCredential objects
 type SSHCredentialStore interface {
 	// DeleteSSHCredential deletes the specified SSH credential.
 	DeleteSSHCredential() error
 
-	// AddSSHPublicKey adds an SSH public key.
+	// AddSSHPublicKey adds an SSH public key. The first key added is the primary key.
 	AddSSHPublicKey(data []byte) error
 
-	// FindSSHPublicKeys retrieves the SSH public keys.
+	// SetPrimarySSHPublicKey makes the SSH public key with the specified fingerprint the primary key.
+	SetPrimarySSHPublicKey(id string) error
+
+	// DeleteSSHPublicKey deletes the SSH public key with the specified fingerprint.
+	DeleteSSHPublicKey(id string) error
+
+	// FindSSHPublicKeys retrieves the SSH public keys, with the primary key first.
 	FindSSHPublicKeys() ([]*kops.SSHCredential, error)
 }
 
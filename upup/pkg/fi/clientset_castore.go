@@ -28,6 +28,7 @@ import (
 	"k8s.io/kops/pkg/apis/kops"
 	kopsinternalversion "k8s.io/kops/pkg/client/clientset_generated/clientset/typed/kops/internalversion"
 	"k8s.io/kops/pkg/pki"
+	"k8s.io/kops/pkg/sshcredentials"
 	"k8s.io/kops/util/pkg/vfs"
 )
 
@@ -75,7 +76,8 @@ func parseKeyset(o *kops.Keyset) (*Keyset, error) {
 
 	for _, key := range o.Spec.Keys {
 		ki := &KeysetItem{
-			Id: key.Id,
+			Id:                    key.Id,
+			ExternalCertificateID: key.ExternalCertificateID,
 		}
 		if key.DistrustTimestamp != nil {
 			distrustTimestamp := key.DistrustTimestamp.Time
@@ -90,7 +92,14 @@ func parseKeyset(o *kops.Keyset) (*Keyset, error) {
 			ki.Certificate = cert
 		}
 
-		if len(key.PrivateMaterial) != 0 {
+		if IsKMSWrappedPrivateKey(key.PrivateMaterial) {
+			privateKey, kmsKey, err := UnwrapPrivateKey(key.PrivateMaterial)
+			if err != nil {
+				return nil, fmt.Errorf("error loading private key %s/%s: %v", name, key.Id, err)
+			}
+			ki.PrivateKey = privateKey
+			ki.KMSKey = kmsKey
+		} else if len(key.PrivateMaterial) != 0 {
 			privateKey, err := pki.ParsePEMPrivateKey(key.PrivateMaterial)
 			if err != nil {
 				return nil, fmt.Errorf("error loading private key %s/%s: %v", name, key.Id, err)
@@ -102,6 +111,7 @@ func parseKeyset(o *kops.Keyset) (*Keyset, error) {
 	}
 
 	keyset.Primary = keyset.Items[FindPrimary(o).Id]
+	keyset.Rotation = o.Spec.Rotation.DeepCopy()
 
 	return keyset, nil
 }
@@ -209,7 +219,7 @@ func (c *ClientsetCAStore) storeKeyset(ctx context.Context, name string, keyset
 	create := false
 	client := c.clientset.Keysets(c.namespace)
 
-	kopsKeyset, err := keyset.ToAPIObject(name)
+	kopsKeyset, err := keyset.ToAPIObject(name, keyStoreKMSKey(c.cluster))
 	if err != nil {
 		return err
 	}
@@ -309,6 +319,39 @@ func (c *ClientsetCAStore) FindSSHPublicKeys() ([]*kops.SSHCredential, error) {
 	return items, nil
 }
 
+// SetPrimarySSHPublicKey implements SSHCredentialStore::SetPrimarySSHPublicKey
+// The registry holds a single SSH public key, which is always the primary key.
+func (c *ClientsetCAStore) SetPrimarySSHPublicKey(id string) error {
+	_, err := c.findSSHPublicKey(id)
+	return err
+}
+
+// DeleteSSHPublicKey implements SSHCredentialStore::DeleteSSHPublicKey
+func (c *ClientsetCAStore) DeleteSSHPublicKey(id string) error {
+	if _, err := c.findSSHPublicKey(id); err != nil {
+		return err
+	}
+	return c.deleteSSHCredential(context.TODO())
+}
+
+// findSSHPublicKey returns the SSH public key with the specified fingerprint.
+func (c *ClientsetCAStore) findSSHPublicKey(id string) (*kops.SSHCredential, error) {
+	keys, err := c.FindSSHPublicKeys()
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		keyID, err := sshcredentials.Fingerprint(key.Spec.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("error fingerprinting SSH public key: %v", err)
+		}
+		if normalizeSSHPublicKeyID(keyID) == normalizeSSHPublicKeyID(id) {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("SSH public key %q not found", id)
+}
+
 // DeleteSSHCredential implements SSHCredentialStore::DeleteSSHCredential
 func (c *ClientsetCAStore) DeleteSSHCredential() error {
 	ctx := context.TODO()
@@ -59,6 +59,7 @@ import (
 	"k8s.io/kops/pkg/wellknownports"
 	"k8s.io/kops/upup/models"
 	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awstasks"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/azure"
 	"k8s.io/kops/upup/pkg/fi/cloudup/bootstrapchannelbuilder"
@@ -67,6 +68,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi/cloudup/gce"
 	"k8s.io/kops/upup/pkg/fi/cloudup/hetzner"
 	"k8s.io/kops/upup/pkg/fi/cloudup/openstack"
+	"k8s.io/kops/upup/pkg/fi/cloudup/pulumi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 	"k8s.io/kops/util/pkg/architectures"
@@ -97,6 +99,15 @@ type ApplyClusterCmd struct {
 	// NodeUpAssets are the assets for downloading nodeup
 	NodeUpAssets map[architectures.Architecture]*mirrors.MirroredAsset
 
+	// InstanceGroupNodeUpAssets are the assets for downloading nodeup, for the instance groups that override them
+	InstanceGroupNodeUpAssets map[string]map[architectures.Architecture]*mirrors.MirroredAsset
+
+	// WindowsNodeUpAsset is the asset for downloading nodeup on Windows nodes
+	WindowsNodeUpAsset *mirrors.MirroredAsset
+
+	// WindowsAssets are the assets installed by nodeup on Windows nodes
+	WindowsAssets []*mirrors.MirroredAsset
+
 	// TargetName specifies how we are operating e.g. direct to GCE, or AWS, or dry-run, or terraform
 	TargetName string
 
@@ -106,6 +117,9 @@ type ApplyClusterCmd struct {
 	// OutDir is a local directory in which we place output, can cache files etc
 	OutDir string
 
+	// CloudformationNestedStacks splits cloudformation output into nested stacks, rather than a single template
+	CloudformationNestedStacks bool
+
 	// Assets is a list of sources for files (primarily when not using everything containerized)
 	// Formats:
 	//  raw url: http://... or https://...
@@ -137,6 +151,9 @@ type ApplyClusterCmd struct {
 	// GetAssets is whether this is called just to obtain the list of assets.
 	GetAssets bool
 
+	// Quiet suppresses the report of the changes a dry-run would make.
+	Quiet bool
+
 	// TaskMap is the map of tasks that we built (output)
 	TaskMap map[string]fi.Task
 
@@ -171,6 +188,24 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) error {
 		c.InstanceGroups = instanceGroups
 	}
 
+	// Hash the specs as they are in the state store, before they are populated
+	var clusterSpecHash string
+	var instanceGroupSpecHashes map[string]string
+	if featureflag.SpecHashTags.Enabled() {
+		var err error
+		clusterSpecHash, err = apiModel.ClusterSpecHash(c.Cluster)
+		if err != nil {
+			return err
+		}
+		instanceGroupSpecHashes = make(map[string]string)
+		for _, ig := range c.InstanceGroups {
+			instanceGroupSpecHashes[ig.ObjectMeta.Name], err = apiModel.InstanceGroupSpecHash(c.Cluster, ig)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	for _, ig := range c.InstanceGroups {
 		// Try to guess the path for additional third party volume plugins in Flatcar
 		image := strings.ToLower(ig.Spec.Image)
@@ -308,6 +343,10 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("error fetching addons: %v", err)
 	}
+	userAddons, err := addonsClient.ListUserAddons()
+	if err != nil {
+		return fmt.Errorf("error fetching user addons: %v", err)
+	}
 
 	// Normalize k8s version
 	versionWithoutV := strings.TrimSpace(cluster.Spec.KubernetesVersion)
@@ -357,6 +396,24 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) error {
 		encryptionConfigSecretHash = base64.URLEncoding.EncodeToString(hashBytes[:])
 	}
 
+	var gossipEncryptionKeys []string
+	if gossipEncryptionEnabled(c.Cluster) {
+		secret, err := secretStore.FindSecret(GossipEncryptionSecretName)
+		if err != nil {
+			return fmt.Errorf("could not load the %s secret: %w", GossipEncryptionSecretName, err)
+		}
+		if secret == nil {
+			fmt.Println("")
+			fmt.Println("You have gossip encryption enabled, but no gossipencryption secret has been set.")
+			fmt.Println("See `kops create secret gossipencryption -h`")
+			return fmt.Errorf("could not find %s secret", GossipEncryptionSecretName)
+		}
+		gossipEncryptionKeys, err = BuildGossipEncryptionKeys(secret)
+		if err != nil {
+			return err
+		}
+	}
+
 	ciliumSpec := c.Cluster.Spec.Networking.Cilium
 	if ciliumSpec != nil && ciliumSpec.EnableEncryption && ciliumSpec.EncryptionType == kops.CiliumEncryptionTypeIPSec {
 		secret, err := secretStore.FindSecret("ciliumpassword")
@@ -392,8 +449,10 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) error {
 	}
 
 	modelContext := &model.KopsModelContext{
-		IAMModelContext: iam.IAMModelContext{Cluster: cluster},
-		InstanceGroups:  c.InstanceGroups,
+		IAMModelContext:         iam.IAMModelContext{Cluster: cluster},
+		InstanceGroups:          c.InstanceGroups,
+		ClusterSpecHash:         clusterSpecHash,
+		InstanceGroupSpecHashes: instanceGroupSpecHashes,
 	}
 
 	switch cluster.Spec.GetCloudProvider() {
@@ -426,10 +485,6 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) error {
 			}
 			modelContext.AWSAccountID = accountID
 			modelContext.AWSPartition = partition
-
-			if len(sshPublicKeys) > 1 {
-				return fmt.Errorf("exactly one 'admin' SSH public key can be specified when running with AWS; please delete a key using `kops delete secret`")
-			}
 		}
 
 	case kops.CloudProviderAzure:
@@ -477,7 +532,7 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) error {
 		cloud:            cloud,
 	}
 
-	configBuilder, err := newNodeUpConfigBuilder(cluster, assetBuilder, c.Assets, encryptionConfigSecretHash)
+	configBuilder, err := newNodeUpConfigBuilder(cluster, assetBuilder, c.Assets, c.WindowsAssets, encryptionConfigSecretHash, gossipEncryptionKeys)
 	if err != nil {
 		return err
 	}
@@ -487,6 +542,13 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) error {
 		NodeUpConfigBuilder: configBuilder,
 		NodeUpAssets:        c.NodeUpAssets,
 		Cluster:             cluster,
+		UserDataOverflow:    model.UserDataOverflowOffload,
+
+		InstanceGroupNodeUpAssets: c.InstanceGroupNodeUpAssets,
+		WindowsNodeUpAsset:        c.WindowsNodeUpAsset,
+	}
+	if cluster.Spec.GetCloudProvider() == kops.CloudProviderAWS {
+		bootstrapScriptBuilder.MaxUserDataSize = awstasks.MaxUserDataSize
 	}
 
 	{
@@ -506,6 +568,7 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) error {
 			assetBuilder,
 			templates,
 			addons,
+			userAddons,
 		)
 
 		l.Builders = append(l.Builders,
@@ -624,6 +687,7 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) error {
 				&gcemodel.StorageAclBuilder{GCEModelContext: gceModelContext, Cloud: cloud.(gce.GCECloud), Lifecycle: storageACLLifecycle},
 				&gcemodel.AutoscalingGroupModelBuilder{GCEModelContext: gceModelContext, BootstrapScriptBuilder: bootstrapScriptBuilder, Lifecycle: clusterLifecycle},
 				&gcemodel.ServiceAccountsBuilder{GCEModelContext: gceModelContext, Lifecycle: clusterLifecycle},
+				&gcemodel.WorkloadIdentityBuilder{GCEModelContext: gceModelContext, Lifecycle: clusterLifecycle},
 			)
 		case kops.CloudProviderAzure:
 			azureModelContext := &azuremodel.AzureModelContext{
@@ -715,7 +779,9 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) error {
 	case TargetCloudformation:
 		checkExisting = false
 		outDir := c.OutDir
-		target = cloudformation.NewCloudformationTarget(cloud, project, outDir)
+		cf := cloudformation.NewCloudformationTarget(cloud, project, outDir)
+		cf.NestedStacks = c.CloudformationNestedStacks
+		target = cf
 
 		// Can cause conflicts with cloudformation management
 		shouldPrecreateDNS = false
@@ -728,9 +794,31 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) error {
 		fmt.Printf("%s\n", starline)
 		fmt.Printf("\n")
 
+	case TargetPulumi:
+		checkExisting = false
+		outDir := c.OutDir
+		if cluster.Spec.GetCloudProvider() != kops.CloudProviderAWS {
+			return fmt.Errorf("pulumi target not supported with CloudProvider:%q", cluster.Spec.GetCloudProvider())
+		}
+		pt := pulumi.NewPulumiTarget(cloud, project, cluster.ObjectMeta.Name, outDir)
+
+		// We include a few "util" variables in the program outputs
+		if err := pt.AddOutputVariable("region", pulumi.LiteralFromStringValue(cloud.Region())); err != nil {
+			return err
+		}
+
+		if err := pt.AddOutputVariable("clusterName", pulumi.LiteralFromStringValue(cluster.ObjectMeta.Name)); err != nil {
+			return err
+		}
+
+		target = pt
+
+		// Can cause conflicts with pulumi management
+		shouldPrecreateDNS = false
+
 	case TargetDryRun:
 		var out io.Writer = os.Stdout
-		if c.GetAssets {
+		if c.GetAssets || c.Quiet {
 			out = io.Discard
 		}
 		target = fi.NewDryRunTarget(assetBuilder, out)
@@ -1043,6 +1131,8 @@ func (c *ApplyClusterCmd) addFileAssets(assetBuilder *assets.AssetBuilder) error
 			containerRuntimeAssetUrl, containerRuntimeAssetHash, err = findDockerAsset(c.Cluster, assetBuilder, arch)
 		case "containerd":
 			containerRuntimeAssetUrl, containerRuntimeAssetHash, err = findContainerdAsset(c.Cluster, assetBuilder, arch)
+		case "crio":
+			containerRuntimeAssetUrl, containerRuntimeAssetHash, err = findCrioAsset(c.Cluster, assetBuilder, arch)
 		default:
 			err = fmt.Errorf("unknown container runtime: %q", c.Cluster.Spec.ContainerRuntime)
 		}
@@ -1063,6 +1153,14 @@ func (c *ApplyClusterCmd) addFileAssets(assetBuilder *assets.AssetBuilder) error
 			}
 		}
 
+		if usesImageCredentialProviders(c.Cluster, c.InstanceGroups) {
+			credentialProviderAssetURL, credentialProviderAssetHash, err := findCredentialProviderAsset(c.Cluster, assetBuilder, arch)
+			if err != nil {
+				return err
+			}
+			c.Assets[arch] = append(c.Assets[arch], mirrors.BuildMirroredAsset(credentialProviderAssetURL, credentialProviderAssetHash))
+		}
+
 		asset, err := NodeUpAsset(assetBuilder, arch)
 		if err != nil {
 			return err
@@ -1070,6 +1168,38 @@ func (c *ApplyClusterCmd) addFileAssets(assetBuilder *assets.AssetBuilder) error
 		c.NodeUpAssets[arch] = asset
 	}
 
+	if hasWindowsInstanceGroups(c.InstanceGroups) {
+		windowsAssets, err := findWindowsAssets(c.Cluster, assetBuilder)
+		if err != nil {
+			return err
+		}
+		c.WindowsAssets = windowsAssets
+
+		asset, err := WindowsNodeUpAsset(assetBuilder)
+		if err != nil {
+			return err
+		}
+		c.WindowsNodeUpAsset = asset
+	}
+
+	c.InstanceGroupNodeUpAssets = make(map[string]map[architectures.Architecture]*mirrors.MirroredAsset)
+	for _, ig := range c.InstanceGroups {
+		if ig.Spec.NodeUp == nil {
+			continue
+		}
+		defaults := c.NodeUpAssets
+		if ig.IsWindows() {
+			defaults = map[architectures.Architecture]*mirrors.MirroredAsset{
+				architectures.ArchitectureAmd64: c.WindowsNodeUpAsset,
+			}
+		}
+		nodeUpAssets, err := findInstanceGroupNodeUpAssets(assetBuilder, ig, defaults)
+		if err != nil {
+			return err
+		}
+		c.InstanceGroupNodeUpAssets[ig.ObjectMeta.Name] = nodeUpAssets
+	}
+
 	return nil
 }
 
@@ -1108,6 +1238,8 @@ type nodeUpConfigBuilder struct {
 	//  raw url: http://... or https://...
 	//  url with hash: <hex>@http://... or <hex>@https://...
 	assets map[architectures.Architecture][]*mirrors.MirroredAsset
+	// windowsAssets is the list of sources for files of the Windows nodes, which only run on amd64
+	windowsAssets []*mirrors.MirroredAsset
 
 	assetBuilder               *assets.AssetBuilder
 	channels                   []string
@@ -1118,9 +1250,10 @@ type nodeUpConfigBuilder struct {
 	protokubeAsset             map[architectures.Architecture][]*mirrors.MirroredAsset
 	channelsAsset              map[architectures.Architecture][]*mirrors.MirroredAsset
 	encryptionConfigSecretHash string
+	gossipEncryptionKeys       []string
 }
 
-func newNodeUpConfigBuilder(cluster *kops.Cluster, assetBuilder *assets.AssetBuilder, assets map[architectures.Architecture][]*mirrors.MirroredAsset, encryptionConfigSecretHash string) (model.NodeUpConfigBuilder, error) {
+func newNodeUpConfigBuilder(cluster *kops.Cluster, assetBuilder *assets.AssetBuilder, assets map[architectures.Architecture][]*mirrors.MirroredAsset, windowsAssets []*mirrors.MirroredAsset, encryptionConfigSecretHash string, gossipEncryptionKeys []string) (model.NodeUpConfigBuilder, error) {
 	configBase, err := vfs.Context.BuildVfsPath(cluster.Spec.ConfigBase)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing config base %q: %v", cluster.Spec.ConfigBase, err)
@@ -1253,6 +1386,7 @@ func newNodeUpConfigBuilder(cluster *kops.Cluster, assetBuilder *assets.AssetBui
 	configBuilder := nodeUpConfigBuilder{
 		assetBuilder:               assetBuilder,
 		assets:                     assets,
+		windowsAssets:              windowsAssets,
 		channels:                   channels,
 		configBase:                 configBase,
 		cluster:                    cluster,
@@ -1261,6 +1395,7 @@ func newNodeUpConfigBuilder(cluster *kops.Cluster, assetBuilder *assets.AssetBui
 		protokubeAsset:             protokubeAsset,
 		channelsAsset:              channelsAsset,
 		encryptionConfigSecretHash: encryptionConfigSecretHash,
+		gossipEncryptionKeys:       gossipEncryptionKeys,
 	}
 
 	return &configBuilder, nil
@@ -1288,10 +1423,18 @@ func (n *nodeUpConfigBuilder) BuildConfig(ig *kops.InstanceGroup, apiserverAddit
 	config.Assets = make(map[architectures.Architecture][]string)
 	for _, arch := range architectures.GetSupported() {
 		config.Assets[arch] = []string{}
+		if ig.IsWindows() {
+			continue
+		}
 		for _, a := range n.assets[arch] {
 			config.Assets[arch] = append(config.Assets[arch], a.CompactString())
 		}
 	}
+	if ig.IsWindows() {
+		for _, a := range n.windowsAssets {
+			config.Assets[architectures.ArchitectureAmd64] = append(config.Assets[architectures.ArchitectureAmd64], a.CompactString())
+		}
+	}
 
 	if role != kops.InstanceGroupRoleBastion {
 		if err := loadCertificates(keysets, fi.CertificateIDCA, config, true); err != nil {
@@ -1368,7 +1511,21 @@ func (n *nodeUpConfigBuilder) BuildConfig(ig *kops.InstanceGroup, apiserverAddit
 		}
 	}
 
-	useConfigServer := featureflag.KopsControllerStateStore.Enabled() && (role != kops.InstanceGroupRoleMaster)
+	if useGossip {
+		config.GossipEncryptionKeys = n.gossipEncryptionKeys
+	}
+
+	isMetal := ig.Spec.Manager == kops.InstanceManagerMetal
+	if isMetal {
+		// Metal machines have no cloud identity: nodeup authenticates to kops-controller with the
+		// host certificate installed by "kops toolbox enroll", and the kubelet runs without a cloud provider.
+		bootConfig.CloudProvider = string(kops.CloudProviderMetal)
+		config.KubeletConfig.CloudProvider = ""
+		config.KubeletConfig.ImageCredentialProviders = nil
+		config.UseInstanceIDForNodeName = false
+	}
+
+	useConfigServer := (featureflag.KopsControllerStateStore.Enabled() && (role != kops.InstanceGroupRoleMaster)) || isMetal
 	if useConfigServer {
 		baseURL := url.URL{
 			Scheme: "https",
@@ -1409,7 +1566,10 @@ func (n *nodeUpConfigBuilder) BuildConfig(ig *kops.InstanceGroup, apiserverAddit
 		})
 	}
 
-	config.Images = n.images[role]
+	// The images are linux images, that Windows nodes cannot load
+	if !ig.IsWindows() {
+		config.Images = n.images[role]
+	}
 	config.Channels = n.channels
 	config.EtcdManifests = n.etcdManifests[role]
 
@@ -1425,6 +1585,14 @@ func (n *nodeUpConfigBuilder) BuildConfig(ig *kops.InstanceGroup, apiserverAddit
 		config.WarmPoolImages = n.buildWarmPoolImages(ig)
 	}
 
+	if len(ig.Spec.PreloadImages) > 0 {
+		images, err := n.buildPreloadImages(ig)
+		if err != nil {
+			return nil, nil, err
+		}
+		config.PreloadImages = images
+	}
+
 	if ig.Spec.Packages != nil {
 		config.Packages = ig.Spec.Packages
 	}
@@ -1478,6 +1646,23 @@ func (n *nodeUpConfigBuilder) buildContainerdConfig(ig *kops.InstanceGroup) *kop
 	return config
 }
 
+// buildPreloadImages returns the container images of the instance group to pull at boot,
+// remapped to the container registry of the cluster when one is configured.
+func (n *nodeUpConfigBuilder) buildPreloadImages(ig *kops.InstanceGroup) ([]string, error) {
+	var images []string
+	for _, image := range ig.Spec.PreloadImages {
+		if n.assetBuilder != nil {
+			remapped, err := n.assetBuilder.RemapImage(image)
+			if err != nil {
+				return nil, fmt.Errorf("remapping preload image %q: %w", image, err)
+			}
+			image = remapped
+		}
+		images = append(images, image)
+	}
+	return images, nil
+}
+
 // buildWarmPoolImages returns a list of container images that should be pre-pulled during instance pre-initialization
 func (n *nodeUpConfigBuilder) buildWarmPoolImages(ig *kops.InstanceGroup) []string {
 	if ig == nil || ig.Spec.Role == kops.InstanceGroupRoleMaster {
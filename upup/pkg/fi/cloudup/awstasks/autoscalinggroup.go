@@ -29,6 +29,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/cloudformation"
+	"k8s.io/kops/upup/pkg/fi/cloudup/pulumi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 	"k8s.io/kops/util/pkg/maps"
@@ -191,12 +192,18 @@ func (e *AutoscalingGroup) Find(c *fi.Context) (*AutoscalingGroup, error) {
 	}
 	sort.Strings(actual.Metrics)
 
+	spotFallback := false
 	if len(g.Tags) != 0 {
 		actual.Tags = make(map[string]string)
 		for _, tag := range g.Tags {
 			if strings.HasPrefix(aws.StringValue(tag.Key), "aws:cloudformation:") {
 				continue
 			}
+			// The spot fallback state is managed by kops-controller
+			if aws.StringValue(tag.Key) == awsup.TagNameSpotFallback {
+				spotFallback = strings.HasPrefix(aws.StringValue(tag.Value), awsup.SpotFallbackStateOnDemand+"/")
+				continue
+			}
 			actual.Tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
 		}
 	}
@@ -237,6 +244,13 @@ func (e *AutoscalingGroup) Find(c *fi.Context) (*AutoscalingGroup, error) {
 	ir, _ := findInstanceRequirements(g)
 	actual.InstanceRequirements = ir
 
+	// Leave the On-Demand capacity of a group that kops-controller switched to On-Demand instances
+	// until kops-controller switches it back
+	if spotFallback {
+		actual.MixedOnDemandBase = e.MixedOnDemandBase
+		actual.MixedOnDemandAboveBase = e.MixedOnDemandAboveBase
+	}
+
 	if subnetSlicesEqualIgnoreOrder(actual.Subnets, e.Subnets) {
 		actual.Subnets = e.Subnets
 	}
@@ -1151,3 +1165,161 @@ func (_ *AutoscalingGroup) RenderCloudformation(t *cloudformation.Cloudformation
 func (e *AutoscalingGroup) CloudformationLink() *cloudformation.Literal {
 	return cloudformation.Ref("AWS::AutoScaling::AutoScalingGroup", fi.StringValue(e.Name))
 }
+
+type pulumiASGTag struct {
+	Key               *string `json:"key"`
+	Value             *string `json:"value"`
+	PropagateAtLaunch *bool   `json:"propagateAtLaunch"`
+}
+
+type pulumiAutoscalingLaunchTemplateSpecification struct {
+	// Id is the ID of the template to use.
+	Id *pulumi.Literal `json:"id,omitempty"`
+	// Version is the version of the Launch Template to use.
+	Version *pulumi.Literal `json:"version,omitempty"`
+}
+
+type pulumiAutoscalingMixedInstancesPolicyLaunchTemplateOverride struct {
+	// InstanceType is the instance to use
+	InstanceType *string `json:"instanceType,omitempty"`
+}
+
+type pulumiAutoscalingMixedInstancesPolicyLaunchTemplate struct {
+	// LaunchTemplateSpecification is the definition for a LT
+	LaunchTemplateSpecification *pulumiAutoscalingLaunchTemplateSpecification `json:"launchTemplateSpecification,omitempty"`
+	// Overrides are the machine type overrides
+	Overrides []*pulumiAutoscalingMixedInstancesPolicyLaunchTemplateOverride `json:"overrides,omitempty"`
+}
+
+type pulumiAutoscalingInstanceDistribution struct {
+	// OnDemandAllocationStrategy
+	OnDemandAllocationStrategy *string `json:"onDemandAllocationStrategy,omitempty"`
+	// OnDemandBaseCapacity is the base ondemand requirement
+	OnDemandBaseCapacity *int64 `json:"onDemandBaseCapacity,omitempty"`
+	// OnDemandPercentageAboveBaseCapacity is the percentage above base for on-demand instances
+	OnDemandPercentageAboveBaseCapacity *int64 `json:"onDemandPercentageAboveBaseCapacity,omitempty"`
+	// SpotAllocationStrategy is the spot allocation stratergy
+	SpotAllocationStrategy *string `json:"spotAllocationStrategy,omitempty"`
+	// SpotInstancePools is the number of pools
+	SpotInstancePools *int64 `json:"spotInstancePools,omitempty"`
+	// SpotMaxPrice is the max bid on spot instance, defaults to demand value
+	SpotMaxPrice *string `json:"spotMaxPrice,omitempty"`
+}
+
+type pulumiMixedInstancesPolicy struct {
+	// LaunchTemplate is the launch template spec
+	LaunchTemplate *pulumiAutoscalingMixedInstancesPolicyLaunchTemplate `json:"launchTemplate,omitempty"`
+	// InstancesDistribution is the distribution strategy
+	InstancesDistribution *pulumiAutoscalingInstanceDistribution `json:"instancesDistribution,omitempty"`
+}
+
+type pulumiAutoscalingGroup struct {
+	Name                 *string                                       `json:"name,omitempty"`
+	LaunchTemplate       *pulumiAutoscalingLaunchTemplateSpecification `json:"launchTemplate,omitempty"`
+	MaxSize              *int64                                        `json:"maxSize"`
+	MinSize              *int64                                        `json:"minSize"`
+	MixedInstancesPolicy *pulumiMixedInstancesPolicy                   `json:"mixedInstancesPolicy,omitempty"`
+	VpcZoneIdentifiers   []*pulumi.Literal                             `json:"vpcZoneIdentifiers,omitempty"`
+	Tags                 []*pulumiASGTag                               `json:"tags,omitempty"`
+	MetricsGranularity   *string                                       `json:"metricsGranularity,omitempty"`
+	EnabledMetrics       []*string                                     `json:"enabledMetrics,omitempty"`
+	SuspendedProcesses   []*string                                     `json:"suspendedProcesses,omitempty"`
+	ProtectFromScaleIn   *bool                                         `json:"protectFromScaleIn,omitempty"`
+	LoadBalancers        []*pulumi.Literal                             `json:"loadBalancers,omitempty"`
+	TargetGroupArns      []*pulumi.Literal                             `json:"targetGroupArns,omitempty"`
+}
+
+// RenderPulumi is responsible for rendering the pulumi program
+func (_ *AutoscalingGroup) RenderPulumi(t *pulumi.PulumiTarget, a, e, changes *AutoscalingGroup) error {
+	p := &pulumiAutoscalingGroup{
+		Name:               e.Name,
+		MinSize:            e.MinSize,
+		MaxSize:            e.MaxSize,
+		MetricsGranularity: e.Granularity,
+		EnabledMetrics:     aws.StringSlice(e.Metrics),
+		ProtectFromScaleIn: e.InstanceProtection,
+	}
+
+	for _, s := range e.Subnets {
+		p.VpcZoneIdentifiers = append(p.VpcZoneIdentifiers, s.PulumiLink())
+	}
+
+	for _, k := range maps.SortedKeys(e.Tags) {
+		v := e.Tags[k]
+		p.Tags = append(p.Tags, &pulumiASGTag{
+			Key:               fi.String(k),
+			Value:             fi.String(v),
+			PropagateAtLaunch: fi.Bool(true),
+		})
+	}
+
+	for _, k := range e.LoadBalancers {
+		p.LoadBalancers = append(p.LoadBalancers, k.PulumiLink())
+	}
+	pulumi.SortLiterals(p.LoadBalancers)
+
+	for _, tg := range e.TargetGroups {
+		p.TargetGroupArns = append(p.TargetGroupArns, tg.PulumiLink())
+	}
+	pulumi.SortLiterals(p.TargetGroupArns)
+
+	if e.UseMixedInstancesPolicy() {
+		p.MixedInstancesPolicy = &pulumiMixedInstancesPolicy{
+			LaunchTemplate: &pulumiAutoscalingMixedInstancesPolicyLaunchTemplate{
+				LaunchTemplateSpecification: &pulumiAutoscalingLaunchTemplateSpecification{
+					Id:      e.LaunchTemplate.PulumiLink(),
+					Version: e.LaunchTemplate.PulumiVersionLink(),
+				},
+			},
+			InstancesDistribution: &pulumiAutoscalingInstanceDistribution{
+				OnDemandAllocationStrategy:          e.MixedOnDemandAllocationStrategy,
+				OnDemandBaseCapacity:                e.MixedOnDemandBase,
+				OnDemandPercentageAboveBaseCapacity: e.MixedOnDemandAboveBase,
+				SpotAllocationStrategy:              e.MixedSpotAllocationStrategy,
+				SpotInstancePools:                   e.MixedSpotInstancePools,
+				SpotMaxPrice:                        e.MixedSpotMaxPrice,
+			},
+		}
+
+		for _, x := range e.MixedInstanceOverrides {
+			p.MixedInstancesPolicy.LaunchTemplate.Overrides = append(p.MixedInstancesPolicy.LaunchTemplate.Overrides, &pulumiAutoscalingMixedInstancesPolicyLaunchTemplateOverride{InstanceType: fi.String(x)})
+		}
+	} else if e.LaunchTemplate != nil {
+		p.LaunchTemplate = &pulumiAutoscalingLaunchTemplateSpecification{
+			Id:      e.LaunchTemplate.PulumiLink(),
+			Version: e.LaunchTemplate.PulumiVersionLink(),
+		}
+	} else {
+		return fmt.Errorf("could not find one of launch configuration, mixed instances policy, or launch template")
+	}
+
+	role := ""
+	for k := range e.Tags {
+		if strings.HasPrefix(k, CloudTagInstanceGroupRolePrefix) {
+			suffix := strings.TrimPrefix(k, CloudTagInstanceGroupRolePrefix)
+			if role != "" && role != suffix {
+				return fmt.Errorf("Found multiple role tags: %q vs %q", role, suffix)
+			}
+			role = suffix
+		}
+	}
+
+	if role != "" {
+		if err := t.AddOutputVariableArray(role+"AutoscalingGroupIds", e.PulumiLink()); err != nil {
+			return err
+		}
+	}
+
+	if e.SuspendProcesses != nil {
+		for _, sp := range *e.SuspendProcesses {
+			p.SuspendedProcesses = append(p.SuspendedProcesses, fi.String(sp))
+		}
+	}
+
+	return t.RenderResource("aws:autoscaling:Group", *e.Name, p)
+}
+
+// PulumiLink fills in the property
+func (e *AutoscalingGroup) PulumiLink() *pulumi.Literal {
+	return pulumi.LiteralProperty("aws:autoscaling:Group", fi.StringValue(e.Name), "id")
+}
@@ -17,11 +17,14 @@ limitations under the License.
 package awstasks
 
 import (
+	"reflect"
 	"sort"
 	"testing"
 
+	"k8s.io/kops/cloudmock/aws/mockautoscaling"
 	"k8s.io/kops/pkg/diff"
 	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
@@ -429,3 +432,179 @@ func TestAutoscalingGroupCloudformationRender(t *testing.T) {
 
 	doRenderTests(t, "RenderCloudformation", cases)
 }
+
+func TestAutoscalingGroupPulumiRender(t *testing.T) {
+	cases := []*renderTest{
+		{
+			Resource: &AutoscalingGroup{
+				Name:                   fi.String("test1"),
+				LaunchTemplate:         &LaunchTemplate{Name: fi.String("test_lt")},
+				MaxSize:                fi.Int64(10),
+				Metrics:                []string{"test"},
+				MinSize:                fi.Int64(5),
+				MixedInstanceOverrides: []string{"t2.medium", "t2.large"},
+				MixedOnDemandBase:      fi.Int64(4),
+				MixedOnDemandAboveBase: fi.Int64(30),
+				Subnets: []*Subnet{
+					{
+						Name: fi.String("test-sg"),
+						ID:   fi.String("sg-1111"),
+					},
+				},
+				Tags: map[string]string{
+					"test":    "tag",
+					"cluster": "test",
+				},
+			},
+			Expected: `description: kOps managed resources for cluster test.example.com
+name: test-example-com
+resources:
+  autoscaling-group-test1:
+    options:
+      provider: ${kops-provider}
+    properties:
+      enabledMetrics:
+      - test
+      maxSize: 10
+      minSize: 5
+      mixedInstancesPolicy:
+        instancesDistribution:
+          onDemandBaseCapacity: 4
+          onDemandPercentageAboveBaseCapacity: 30
+        launchTemplate:
+          launchTemplateSpecification:
+            id: ${ec2-launchtemplate-test_lt.id}
+            version: ${ec2-launchtemplate-test_lt.latestVersion}
+          overrides:
+          - instanceType: t2.medium
+          - instanceType: t2.large
+      name: test1
+      tags:
+      - key: cluster
+        propagateAtLaunch: true
+        value: test
+      - key: test
+        propagateAtLaunch: true
+        value: tag
+      vpcZoneIdentifiers:
+      - ${ec2-subnet-test-sg.id}
+    type: aws:autoscaling:Group
+  kops-provider:
+    properties:
+      region: eu-west-2
+    type: pulumi:providers:aws
+runtime: yaml
+`,
+		},
+	}
+
+	doRenderTests(t, "RenderPulumi", cases)
+}
+
+// spotFallbackTestAutoscaling adds the tagging of autoscaling groups to the mock
+type spotFallbackTestAutoscaling struct {
+	*mockautoscaling.MockAutoscaling
+}
+
+func (m *spotFallbackTestAutoscaling) CreateOrUpdateTags(input *autoscaling.CreateOrUpdateTagsInput) (*autoscaling.CreateOrUpdateTagsOutput, error) {
+	for _, tag := range input.Tags {
+		m.DeleteTags(&autoscaling.DeleteTagsInput{Tags: []*autoscaling.Tag{tag}})
+		g := m.Groups[aws.StringValue(tag.ResourceId)]
+		g.Tags = append(g.Tags, &autoscaling.TagDescription{Key: tag.Key, Value: tag.Value, ResourceId: tag.ResourceId})
+	}
+	return &autoscaling.CreateOrUpdateTagsOutput{}, nil
+}
+
+func (m *spotFallbackTestAutoscaling) DeleteTags(input *autoscaling.DeleteTagsInput) (*autoscaling.DeleteTagsOutput, error) {
+	for _, tag := range input.Tags {
+		g := m.Groups[aws.StringValue(tag.ResourceId)]
+		var tags []*autoscaling.TagDescription
+		for _, t := range g.Tags {
+			if aws.StringValue(t.Key) != aws.StringValue(tag.Key) {
+				tags = append(tags, t)
+			}
+		}
+		g.Tags = tags
+	}
+	return &autoscaling.DeleteTagsOutput{}, nil
+}
+
+func TestAutoscalingGroupSpotFallback(t *testing.T) {
+	cloud := awsup.BuildMockAWSCloud("us-east-1", "abc")
+	// The group as left by kops-controller after switching it to On-Demand instances
+	m := &mockautoscaling.MockAutoscaling{}
+	_, err := m.CreateAutoScalingGroup(&autoscaling.CreateAutoScalingGroupInput{
+		AutoScalingGroupName: aws.String("nodes"),
+		MinSize:              aws.Int64(1),
+		MaxSize:              aws.Int64(5),
+		Tags: []*autoscaling.Tag{
+			{Key: aws.String("KubernetesCluster"), Value: aws.String("test.k8s.local")},
+			{Key: aws.String(awsup.TagNameSpotFallback), Value: aws.String("on-demand/2022-06-01T12:00:00Z")},
+		},
+	})
+	if err != nil {
+		t.Fatalf("error creating autoscaling group: %v", err)
+	}
+	_, err = m.UpdateAutoScalingGroup(&autoscaling.UpdateAutoScalingGroupInput{
+		AutoScalingGroupName: aws.String("nodes"),
+		MixedInstancesPolicy: &autoscaling.MixedInstancesPolicy{
+			InstancesDistribution: &autoscaling.InstancesDistribution{
+				OnDemandBaseCapacity:                aws.Int64(0),
+				OnDemandPercentageAboveBaseCapacity: aws.Int64(100),
+			},
+			LaunchTemplate: &autoscaling.LaunchTemplate{
+				LaunchTemplateSpecification: &autoscaling.LaunchTemplateSpecification{
+					LaunchTemplateId:   aws.String("lt-1"),
+					LaunchTemplateName: aws.String("nodes"),
+				},
+				Overrides: []*autoscaling.LaunchTemplateOverrides{
+					{InstanceType: aws.String("t3.medium")},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("error updating autoscaling group: %v", err)
+	}
+
+	cloud.MockAutoscaling = &spotFallbackTestAutoscaling{MockAutoscaling: m}
+
+	asg := &AutoscalingGroup{
+		Name:                   aws.String("nodes"),
+		Lifecycle:              fi.LifecycleSync,
+		MinSize:                aws.Int64(1),
+		MaxSize:                aws.Int64(5),
+		LaunchTemplate:         &LaunchTemplate{Name: aws.String("nodes"), ID: aws.String("lt-1")},
+		MixedOnDemandBase:      aws.Int64(0),
+		MixedOnDemandAboveBase: aws.Int64(0),
+		MixedInstanceOverrides: []string{"t3.medium"},
+		SuspendProcesses:       &[]string{},
+		Tags:                   map[string]string{"KubernetesCluster": "test.k8s.local", "Name": "nodes"},
+	}
+
+	context, err := fi.NewContext(awsup.NewAWSAPITarget(cloud), nil, cloud, nil, nil, nil, true, map[string]fi.Task{"nodes": asg})
+	if err != nil {
+		t.Fatalf("error building context: %v", err)
+	}
+	defer context.Close()
+	if err := asg.Run(context); err != nil {
+		t.Fatalf("unexpected error during Run: %v", err)
+	}
+
+	g := m.Groups["nodes"]
+	if actual := aws.Int64Value(g.MixedInstancesPolicy.InstancesDistribution.OnDemandPercentageAboveBaseCapacity); actual != 100 {
+		t.Errorf("expected the On-Demand percentage above base capacity to stay at 100, got %d", actual)
+	}
+	tags := map[string]string{}
+	for _, tag := range g.Tags {
+		tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+	expectedTags := map[string]string{
+		"KubernetesCluster":       "test.k8s.local",
+		"Name":                    "nodes",
+		awsup.TagNameSpotFallback: "on-demand/2022-06-01T12:00:00Z",
+	}
+	if !reflect.DeepEqual(tags, expectedTags) {
+		t.Errorf("expected tags %v, got %v", expectedTags, tags)
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/cloudformation"
+	"k8s.io/kops/upup/pkg/fi/cloudup/pulumi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
@@ -160,6 +161,26 @@ func (_ *AutoscalingLifecycleHook) RenderCloudformation(t *cloudformation.Cloudf
 	return t.RenderResource("AWS::AutoScaling::LifecycleHook", *e.Name, tf)
 }
 
+type pulumiASGLifecycleHook struct {
+	Name                 *string         `json:"name"`
+	AutoscalingGroupName *pulumi.Literal `json:"autoscalingGroupName"`
+	DefaultResult        *string         `json:"defaultResult,omitempty"`
+	HeartbeatTimeout     *int64          `json:"heartbeatTimeout,omitempty"`
+	LifecycleTransition  *string         `json:"lifecycleTransition"`
+}
+
+func (_ *AutoscalingLifecycleHook) RenderPulumi(t *pulumi.PulumiTarget, a, e, changes *AutoscalingLifecycleHook) error {
+	p := &pulumiASGLifecycleHook{
+		Name:                 e.GetHookName(),
+		AutoscalingGroupName: e.AutoscalingGroup.PulumiLink(),
+		DefaultResult:        e.DefaultResult,
+		HeartbeatTimeout:     e.HeartbeatTimeout,
+		LifecycleTransition:  e.LifecycleTransition,
+	}
+
+	return t.RenderResource("aws:autoscaling:LifecycleHook", *e.Name, p)
+}
+
 func (h *AutoscalingLifecycleHook) GetHookName() *string {
 	if h.HookName != nil {
 		return h.HookName
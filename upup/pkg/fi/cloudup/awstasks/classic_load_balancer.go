@@ -30,8 +30,10 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/cloudformation"
+	"k8s.io/kops/upup/pkg/fi/cloudup/pulumi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
+	"k8s.io/kops/util/pkg/maps"
 	"k8s.io/kops/util/pkg/slice"
 )
 
@@ -909,3 +911,152 @@ func (e *ClassicLoadBalancer) CloudformationAttrCanonicalHostedZoneNameID() *clo
 func (e *ClassicLoadBalancer) CloudformationAttrDNSName() *cloudformation.Literal {
 	return cloudformation.GetAtt("AWS::ElasticLoadBalancing::LoadBalancer", *e.Name, "DNSName")
 }
+
+type pulumiLoadBalancer struct {
+	Name           *string                       `json:"name,omitempty"`
+	Listeners      []*pulumiLoadBalancerListener `json:"listeners,omitempty"`
+	SecurityGroups []*pulumi.Literal             `json:"securityGroups,omitempty"`
+	Subnets        []*pulumi.Literal             `json:"subnets,omitempty"`
+	Internal       *bool                         `json:"internal,omitempty"`
+
+	HealthCheck *pulumiLoadBalancerHealthCheck `json:"healthCheck,omitempty"`
+	AccessLogs  *pulumiLoadBalancerAccessLog   `json:"accessLogs,omitempty"`
+
+	ConnectionDraining        *bool  `json:"connectionDraining,omitempty"`
+	ConnectionDrainingTimeout *int64 `json:"connectionDrainingTimeout,omitempty"`
+
+	CrossZoneLoadBalancing *bool `json:"crossZoneLoadBalancing,omitempty"`
+
+	IdleTimeout *int64 `json:"idleTimeout,omitempty"`
+
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+type pulumiLoadBalancerListener struct {
+	InstancePort     int     `json:"instancePort"`
+	InstanceProtocol string  `json:"instanceProtocol"`
+	LbPort           int64   `json:"lbPort"`
+	LbProtocol       string  `json:"lbProtocol"`
+	SslCertificateId *string `json:"sslCertificateId,omitempty"`
+}
+
+type pulumiLoadBalancerHealthCheck struct {
+	Target             *string `json:"target"`
+	HealthyThreshold   *int64  `json:"healthyThreshold"`
+	UnhealthyThreshold *int64  `json:"unhealthyThreshold"`
+	Interval           *int64  `json:"interval"`
+	Timeout            *int64  `json:"timeout"`
+}
+
+func (_ *ClassicLoadBalancer) RenderPulumi(t *pulumi.PulumiTarget, a, e, changes *ClassicLoadBalancer) error {
+	shared := fi.BoolValue(e.Shared)
+	if shared {
+		return nil
+	}
+
+	cloud := t.Cloud.(awsup.AWSCloud)
+
+	if e.LoadBalancerName == nil {
+		return fi.RequiredField("LoadBalancerName")
+	}
+
+	p := &pulumiLoadBalancer{
+		Name: e.LoadBalancerName,
+	}
+	if fi.StringValue(e.Scheme) == "internal" {
+		p.Internal = fi.Bool(true)
+	}
+
+	for _, subnet := range e.Subnets {
+		p.Subnets = append(p.Subnets, subnet.PulumiLink())
+	}
+	pulumi.SortLiterals(p.Subnets)
+
+	for _, sg := range e.SecurityGroups {
+		p.SecurityGroups = append(p.SecurityGroups, sg.PulumiLink())
+	}
+	pulumi.SortLiterals(p.SecurityGroups)
+
+	for _, loadBalancerPort := range maps.SortedKeys(e.Listeners) {
+		listener := e.Listeners[loadBalancerPort]
+		loadBalancerPortInt, err := strconv.ParseInt(loadBalancerPort, 10, 64)
+		if err != nil {
+			return fmt.Errorf("error parsing load balancer listener port: %q", loadBalancerPort)
+		}
+
+		if listener.SSLCertificateID != "" {
+			p.Listeners = append(p.Listeners, &pulumiLoadBalancerListener{
+				InstanceProtocol: "SSL",
+				InstancePort:     listener.InstancePort,
+				LbPort:           loadBalancerPortInt,
+				LbProtocol:       "SSL",
+				SslCertificateId: &listener.SSLCertificateID,
+			})
+		} else {
+			p.Listeners = append(p.Listeners, &pulumiLoadBalancerListener{
+				InstanceProtocol: "TCP",
+				InstancePort:     listener.InstancePort,
+				LbPort:           loadBalancerPortInt,
+				LbProtocol:       "TCP",
+			})
+		}
+	}
+
+	if e.HealthCheck != nil {
+		p.HealthCheck = &pulumiLoadBalancerHealthCheck{
+			Target:             e.HealthCheck.Target,
+			HealthyThreshold:   e.HealthCheck.HealthyThreshold,
+			UnhealthyThreshold: e.HealthCheck.UnhealthyThreshold,
+			Interval:           e.HealthCheck.Interval,
+			Timeout:            e.HealthCheck.Timeout,
+		}
+	}
+
+	if e.AccessLog != nil && fi.BoolValue(e.AccessLog.Enabled) {
+		p.AccessLogs = &pulumiLoadBalancerAccessLog{
+			Interval:     e.AccessLog.EmitInterval,
+			Enabled:      e.AccessLog.Enabled,
+			Bucket:       e.AccessLog.S3BucketName,
+			BucketPrefix: e.AccessLog.S3BucketPrefix,
+		}
+	}
+
+	if e.ConnectionDraining != nil {
+		p.ConnectionDraining = e.ConnectionDraining.Enabled
+		p.ConnectionDrainingTimeout = e.ConnectionDraining.Timeout
+	}
+
+	if e.ConnectionSettings != nil {
+		p.IdleTimeout = e.ConnectionSettings.IdleTimeout
+	}
+
+	if e.CrossZoneLoadBalancing != nil {
+		p.CrossZoneLoadBalancing = e.CrossZoneLoadBalancing.Enabled
+	}
+
+	tags := cloud.BuildTags(e.Name)
+	for k, v := range e.Tags {
+		tags[k] = v
+	}
+	p.Tags = tags
+
+	return t.RenderResource("aws:elb:LoadBalancer", *e.Name, p)
+}
+
+func (e *ClassicLoadBalancer) PulumiLink(params ...string) *pulumi.Literal {
+	shared := fi.BoolValue(e.Shared)
+	if shared {
+		if e.LoadBalancerName == nil {
+			klog.Fatalf("Name must be set, if LB is shared: %s", e)
+		}
+
+		klog.V(4).Infof("reusing existing LB with name %q", *e.LoadBalancerName)
+		return pulumi.LiteralFromStringValue(*e.LoadBalancerName)
+	}
+
+	prop := "id"
+	if len(params) > 0 {
+		prop = params[0]
+	}
+	return pulumi.LiteralProperty("aws:elb:LoadBalancer", *e.Name, prop)
+}
@@ -44,6 +44,13 @@ type terraformLoadBalancerAccessLog struct {
 	S3BucketPrefix *string `cty:"bucket_prefix"`
 }
 
+type pulumiLoadBalancerAccessLog struct {
+	Enabled      *bool   `json:"enabled,omitempty"`
+	Interval     *int64  `json:"interval,omitempty"`
+	Bucket       *string `json:"bucket,omitempty"`
+	BucketPrefix *string `json:"bucketPrefix,omitempty"`
+}
+
 type cloudformationClassicLoadBalancerAccessLog struct {
 	EmitInterval   *int64  `json:"EmitInterval,omitempty"`
 	Enabled        *bool   `json:"Enabled,omitempty"`
@@ -26,6 +26,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/cloudformation"
+	"k8s.io/kops/upup/pkg/fi/cloudup/pulumi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
@@ -215,3 +216,26 @@ func (_ *DHCPOptions) RenderCloudformation(t *cloudformation.CloudformationTarge
 func (e *DHCPOptions) CloudformationLink() *cloudformation.Literal {
 	return cloudformation.Ref("AWS::EC2::DHCPOptions", *e.Name)
 }
+
+type pulumiDHCPOptions struct {
+	DomainName        *string           `json:"domainName,omitempty"`
+	DomainNameServers []string          `json:"domainNameServers,omitempty"`
+	Tags              map[string]string `json:"tags,omitempty"`
+}
+
+func (_ *DHCPOptions) RenderPulumi(t *pulumi.PulumiTarget, a, e, changes *DHCPOptions) error {
+	p := &pulumiDHCPOptions{
+		DomainName: e.DomainName,
+		Tags:       e.Tags,
+	}
+
+	if e.DomainNameServers != nil {
+		p.DomainNameServers = strings.Split(*e.DomainNameServers, ",")
+	}
+
+	return t.RenderResource("aws:ec2:VpcDhcpOptions", *e.Name, p)
+}
+
+func (e *DHCPOptions) PulumiLink() *pulumi.Literal {
+	return pulumi.LiteralProperty("aws:ec2:VpcDhcpOptions", *e.Name, "id")
+}
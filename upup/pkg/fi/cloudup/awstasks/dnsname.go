@@ -18,6 +18,7 @@ package awstasks
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -26,6 +27,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/cloudformation"
+	"k8s.io/kops/upup/pkg/fi/cloudup/pulumi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
@@ -39,7 +41,12 @@ type DNSName struct {
 	Zone         *DNSZone
 	ResourceName *string
 	ResourceType *string
+	// TTL is the time to live of the record; it is not set for alias records.
+	TTL *int64
 
+	// TargetLoadBalancer is the load balancer the record points to.
+	// If the ResourceType is CNAME, the record is a CNAME to the DNS name of the load balancer,
+	// otherwise it is an alias to the load balancer.
 	TargetLoadBalancer DNSTarget
 }
 
@@ -50,6 +57,7 @@ type DNSTarget interface {
 	CloudformationAttrDNSName() *cloudformation.Literal
 	CloudformationAttrCanonicalHostedZoneNameID() *cloudformation.Literal
 	TerraformLink(...string) *terraformWriter.Literal
+	PulumiLink(...string) *pulumi.Literal
 }
 
 func (e *DNSName) Find(c *fi.Context) (*DNSName, error) {
@@ -114,9 +122,18 @@ func (e *DNSName) Find(c *fi.Context) (*DNSName, error) {
 	actual.Zone = e.Zone
 	actual.ResourceName = e.ResourceName
 	actual.ResourceType = e.ResourceType
+	actual.TTL = found.TTL
 	actual.Lifecycle = e.Lifecycle
 
-	if found.AliasTarget != nil {
+	if findType == route53.RRTypeCname {
+		// We only need to know whether the record still points to the expected load balancer
+		if e.TargetLoadBalancer != nil && len(found.ResourceRecords) == 1 {
+			value := strings.TrimSuffix(aws.StringValue(found.ResourceRecords[0].Value), ".")
+			if value == strings.TrimSuffix(fi.StringValue(e.TargetLoadBalancer.getDNSName()), ".") {
+				actual.TargetLoadBalancer = e.TargetLoadBalancer
+			}
+		}
+	} else if found.AliasTarget != nil {
 		dnsName := aws.StringValue(found.AliasTarget.DNSName)
 		klog.Infof("AliasTarget for %q is %q", aws.StringValue(found.Name), dnsName)
 		if dnsName != "" {
@@ -190,6 +207,11 @@ func findDNSTargetELB(cloud awsup.AWSCloud, aliasTarget *route53.AliasTarget, dn
 	return nil, nil
 }
 
+// isCNAME returns true if the record is a CNAME to the load balancer, rather than an alias.
+func (e *DNSName) isCNAME() bool {
+	return e.TargetLoadBalancer != nil && fi.StringValue(e.ResourceType) == route53.RRTypeCname
+}
+
 func (e *DNSName) Run(c *fi.Context) error {
 	return fi.DefaultDeltaRunMethod(e, c)
 }
@@ -216,9 +238,14 @@ func (_ *DNSName) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *DNSName) error
 	rrs := &route53.ResourceRecordSet{
 		Name: e.ResourceName,
 		Type: e.ResourceType,
+		TTL:  e.TTL,
 	}
 
-	if e.TargetLoadBalancer != nil {
+	if e.isCNAME() {
+		rrs.ResourceRecords = []*route53.ResourceRecord{
+			{Value: e.TargetLoadBalancer.getDNSName()},
+		}
+	} else if e.TargetLoadBalancer != nil {
 		rrs.AliasTarget = &route53.AliasTarget{
 			DNSName:              e.TargetLoadBalancer.getDNSName(),
 			EvaluateTargetHealth: aws.Bool(false),
@@ -251,10 +278,10 @@ func (_ *DNSName) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *DNSName) error
 }
 
 type terraformRoute53Record struct {
-	Name    *string  `cty:"name"`
-	Type    *string  `cty:"type"`
-	TTL     *string  `cty:"ttl"`
-	Records []string `cty:"records"`
+	Name    *string                    `cty:"name"`
+	Type    *string                    `cty:"type"`
+	TTL     *int64                     `cty:"ttl"`
+	Records []*terraformWriter.Literal `cty:"records"`
 
 	Alias  *terraformAlias          `cty:"alias"`
 	ZoneID *terraformWriter.Literal `cty:"zone_id"`
@@ -272,9 +299,12 @@ func (_ *DNSName) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *D
 		Name:   e.ResourceName,
 		ZoneID: e.Zone.TerraformLink(),
 		Type:   e.ResourceType,
+		TTL:    e.TTL,
 	}
 
-	if e.TargetLoadBalancer != nil {
+	if e.isCNAME() {
+		tf.Records = []*terraformWriter.Literal{e.TargetLoadBalancer.TerraformLink("dns_name")}
+	} else if e.TargetLoadBalancer != nil {
 		tf.Alias = &terraformAlias{
 			Name:                 e.TargetLoadBalancer.TerraformLink("dns_name"),
 			EvaluateTargetHealth: aws.Bool(false),
@@ -289,11 +319,49 @@ func (e *DNSName) TerraformLink() *terraformWriter.Literal {
 	return terraformWriter.LiteralSelfLink("aws_route53_record", *e.Name)
 }
 
+type pulumiRoute53Record struct {
+	Name    *string           `json:"name"`
+	Type    *string           `json:"type"`
+	Ttl     *int64            `json:"ttl,omitempty"`
+	Records []*pulumi.Literal `json:"records,omitempty"`
+	Aliases []*pulumiAlias    `json:"aliases,omitempty"`
+	ZoneId  *pulumi.Literal   `json:"zoneId"`
+}
+
+type pulumiAlias struct {
+	Name                 *pulumi.Literal `json:"name"`
+	ZoneId               *pulumi.Literal `json:"zoneId"`
+	EvaluateTargetHealth *bool           `json:"evaluateTargetHealth"`
+}
+
+func (_ *DNSName) RenderPulumi(t *pulumi.PulumiTarget, a, e, changes *DNSName) error {
+	p := &pulumiRoute53Record{
+		Name:   e.ResourceName,
+		ZoneId: e.Zone.PulumiLink(),
+		Type:   e.ResourceType,
+		Ttl:    e.TTL,
+	}
+
+	if e.isCNAME() {
+		p.Records = []*pulumi.Literal{e.TargetLoadBalancer.PulumiLink("dnsName")}
+	} else if e.TargetLoadBalancer != nil {
+		p.Aliases = []*pulumiAlias{
+			{
+				Name:                 e.TargetLoadBalancer.PulumiLink("dnsName"),
+				EvaluateTargetHealth: aws.Bool(false),
+				ZoneId:               e.TargetLoadBalancer.PulumiLink("zoneId"),
+			},
+		}
+	}
+
+	return t.RenderResource("aws:route53:Record", *e.Name, p)
+}
+
 type cloudformationRoute53Record struct {
-	Name            *string  `json:"Name"`
-	Type            *string  `json:"Type"`
-	TTL             *string  `json:"TTL,omitempty"`
-	ResourceRecords []string `json:"ResourceRecords,omitempty"`
+	Name            *string                   `json:"Name"`
+	Type            *string                   `json:"Type"`
+	TTL             *string                   `json:"TTL,omitempty"`
+	ResourceRecords []*cloudformation.Literal `json:"ResourceRecords,omitempty"`
 
 	AliasTarget *cloudformationAlias    `json:"AliasTarget,omitempty"`
 	ZoneID      *cloudformation.Literal `json:"HostedZoneId"`
@@ -312,7 +380,12 @@ func (_ *DNSName) RenderCloudformation(t *cloudformation.CloudformationTarget, a
 		Type:   e.ResourceType,
 	}
 
-	if e.TargetLoadBalancer != nil {
+	if e.TTL != nil {
+		cf.TTL = fi.String(strconv.FormatInt(*e.TTL, 10))
+	}
+	if e.isCNAME() {
+		cf.ResourceRecords = []*cloudformation.Literal{e.TargetLoadBalancer.CloudformationAttrDNSName()}
+	} else if e.TargetLoadBalancer != nil {
 		cf.AliasTarget = &cloudformationAlias{
 			DNSName:              e.TargetLoadBalancer.CloudformationAttrDNSName(),
 			EvaluateTargetHealth: aws.Bool(false),
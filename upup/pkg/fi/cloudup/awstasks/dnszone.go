@@ -29,6 +29,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/cloudformation"
+	"k8s.io/kops/upup/pkg/fi/cloudup/pulumi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
@@ -299,6 +300,68 @@ func (e *DNSZone) TerraformLink() *terraformWriter.Literal {
 	return terraformWriter.LiteralSelfLink("aws_route53_zone", *e.Name)
 }
 
+type pulumiRoute53ZoneAssociation struct {
+	ZoneId *pulumi.Literal `json:"zoneId"`
+	VpcId  *pulumi.Literal `json:"vpcId"`
+}
+
+func (_ *DNSZone) RenderPulumi(t *pulumi.PulumiTarget, a, e, changes *DNSZone) error {
+	cloud := t.Cloud.(awsup.AWSCloud)
+
+	dnsName := fi.StringValue(e.DNSName)
+
+	// As with terraform, we only support re-using an existing zone
+	klog.Infof("Check for existing route53 zone to re-use with name %q", dnsName)
+	z, err := e.findExisting(cloud)
+	if err != nil {
+		return err
+	}
+
+	if z != nil {
+		klog.Infof("Existing zone %q found; will configure pulumi to reuse", aws.StringValue(z.HostedZone.Name))
+
+		e.ZoneID = z.HostedZone.Id
+
+		if e.PrivateVPC != nil {
+			assocNeeded := true
+			var vpcName string
+			if e.PrivateVPC.ID != nil {
+				vpcName = *e.PrivateVPC.ID
+				for _, vpc := range z.VPCs {
+					if *vpc.VPCId == vpcName {
+						klog.Infof("VPC %q already associated with zone %q", vpcName, aws.StringValue(z.HostedZone.Name))
+						assocNeeded = false
+					}
+				}
+			} else {
+				vpcName = *e.PrivateVPC.Name
+			}
+
+			if assocNeeded {
+				klog.Infof("No association between VPC %q and zone %q; adding", vpcName, aws.StringValue(z.HostedZone.Name))
+				p := &pulumiRoute53ZoneAssociation{
+					ZoneId: pulumi.LiteralFromStringValue(*e.ZoneID),
+					VpcId:  e.PrivateVPC.PulumiLink(),
+				}
+				return t.RenderResource("aws:route53:ZoneAssociation", *e.Name, p)
+			}
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("Creation of Route53 hosted zones is not supported for pulumi")
+}
+
+func (e *DNSZone) PulumiLink() *pulumi.Literal {
+	if e.ZoneID != nil {
+		klog.V(4).Infof("reusing existing route53 zone with id %q", *e.ZoneID)
+		return pulumi.LiteralFromStringValue(*e.ZoneID)
+	}
+
+	return pulumi.LiteralProperty("aws:route53:Zone", *e.Name, "zoneId")
+}
+
 type cloudformationRoute53Zone struct {
 	Name *string                   `json:"Name"`
 	VPCs []*cloudformation.Literal `json:"VPCs,omitempty"`
@@ -23,6 +23,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/cloudformation"
+	"k8s.io/kops/upup/pkg/fi/cloudup/pulumi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 
@@ -308,3 +309,33 @@ func (_ *EBSVolume) RenderCloudformation(t *cloudformation.CloudformationTarget,
 func (e *EBSVolume) CloudformationLink() *cloudformation.Literal {
 	return cloudformation.Ref("AWS::EC2::Volume", *e.Name)
 }
+
+type pulumiVolume struct {
+	AvailabilityZone *string           `json:"availabilityZone"`
+	Size             *int64            `json:"size,omitempty"`
+	Type             *string           `json:"type,omitempty"`
+	Iops             *int64            `json:"iops,omitempty"`
+	Throughput       *int64            `json:"throughput,omitempty"`
+	KmsKeyId         *string           `json:"kmsKeyId,omitempty"`
+	Encrypted        *bool             `json:"encrypted,omitempty"`
+	Tags             map[string]string `json:"tags,omitempty"`
+}
+
+func (_ *EBSVolume) RenderPulumi(t *pulumi.PulumiTarget, a, e, changes *EBSVolume) error {
+	p := &pulumiVolume{
+		AvailabilityZone: e.AvailabilityZone,
+		Size:             e.SizeGB,
+		Type:             e.VolumeType,
+		Iops:             e.VolumeIops,
+		Throughput:       e.VolumeThroughput,
+		KmsKeyId:         e.KmsKeyId,
+		Encrypted:        e.Encrypted,
+		Tags:             e.Tags,
+	}
+
+	return t.RenderResource("aws:ebs:Volume", *e.Name, p)
+}
+
+func (e *EBSVolume) PulumiLink() *pulumi.Literal {
+	return pulumi.LiteralProperty("aws:ebs:Volume", *e.Name, "id")
+}
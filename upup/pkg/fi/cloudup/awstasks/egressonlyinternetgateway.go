@@ -24,6 +24,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/cloudformation"
+	"k8s.io/kops/upup/pkg/fi/cloudup/pulumi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
@@ -226,3 +227,57 @@ func (_ *EgressOnlyInternetGateway) RenderCloudformation(t *cloudformation.Cloud
 	}
 	return nil
 }
+
+type pulumiEgressOnlyInternetGateway struct {
+	VpcId *pulumi.Literal   `json:"vpcId"`
+	Tags  map[string]string `json:"tags,omitempty"`
+}
+
+func (_ *EgressOnlyInternetGateway) RenderPulumi(t *pulumi.PulumiTarget, a, e, changes *EgressOnlyInternetGateway) error {
+	shared := fi.BoolValue(e.Shared)
+	if shared {
+		// Not pulumi owned / managed
+
+		// But ... attempt to discover the ID so PulumiLink works
+		if e.ID == nil {
+			request := &ec2.DescribeEgressOnlyInternetGatewaysInput{}
+			vpcID := fi.StringValue(e.VPC.ID)
+			if vpcID == "" {
+				return fmt.Errorf("VPC ID is required when EgressOnlyInternetGateway is shared")
+			}
+			request.Filters = []*ec2.Filter{awsup.NewEC2Filter("attachment.vpc-id", vpcID)}
+			igw, err := findEgressOnlyInternetGateway(t.Cloud.(awsup.AWSCloud), request)
+			if err != nil {
+				return err
+			}
+			if igw == nil {
+				klog.Warningf("Cannot find egress-only internet gateway for VPC %q", vpcID)
+			} else {
+				e.ID = igw.EgressOnlyInternetGatewayId
+			}
+		}
+
+		return nil
+	}
+
+	p := &pulumiEgressOnlyInternetGateway{
+		VpcId: e.VPC.PulumiLink(),
+		Tags:  e.Tags,
+	}
+
+	return t.RenderResource("aws:ec2:EgressOnlyInternetGateway", *e.Name, p)
+}
+
+func (e *EgressOnlyInternetGateway) PulumiLink() *pulumi.Literal {
+	shared := fi.BoolValue(e.Shared)
+	if shared {
+		if e.ID == nil {
+			klog.Fatalf("ID must be set, if EgressOnlyInternetGateway is shared: %s", e)
+		}
+
+		klog.V(4).Infof("reusing existing EgressOnlyInternetGateway with id %q", *e.ID)
+		return pulumi.LiteralFromStringValue(*e.ID)
+	}
+
+	return pulumi.LiteralProperty("aws:ec2:EgressOnlyInternetGateway", *e.Name, "id")
+}
@@ -27,6 +27,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/cloudformation"
+	"k8s.io/kops/upup/pkg/fi/cloudup/pulumi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
 )
 
@@ -341,3 +342,36 @@ func (e *ElasticIP) CloudformationAllocationID() *cloudformation.Literal {
 
 	return cloudformation.GetAtt("AWS::EC2::EIP", *e.Name, "AllocationId")
 }
+
+type pulumiElasticIP struct {
+	Vpc  *bool             `json:"vpc"`
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+func (_ *ElasticIP) RenderPulumi(t *pulumi.PulumiTarget, a, e, changes *ElasticIP) error {
+	if fi.BoolValue(e.Shared) {
+		if e.ID == nil {
+			return fmt.Errorf("ID must be set, if ElasticIP is shared: %v", e)
+		}
+		klog.V(4).Infof("reusing existing ElasticIP with id %q", aws.StringValue(e.ID))
+		return nil
+	}
+
+	p := &pulumiElasticIP{
+		Vpc:  aws.Bool(true),
+		Tags: e.Tags,
+	}
+
+	return t.RenderResource("aws:ec2:Eip", *e.Name, p)
+}
+
+func (e *ElasticIP) PulumiLink() *pulumi.Literal {
+	if fi.BoolValue(e.Shared) {
+		if e.ID == nil {
+			klog.Fatalf("ID must be set, if ElasticIP is shared: %v", e)
+		}
+		return pulumi.LiteralFromStringValue(*e.ID)
+	}
+
+	return pulumi.LiteralProperty("aws:ec2:Eip", *e.Name, "id")
+}
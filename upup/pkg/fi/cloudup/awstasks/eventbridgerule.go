@@ -26,6 +26,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/cloudformation"
+	"k8s.io/kops/upup/pkg/fi/cloudup/pulumi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
@@ -151,6 +152,31 @@ func (eb *EventBridgeRule) TerraformLink() *terraformWriter.Literal {
 	return terraformWriter.LiteralProperty("aws_cloudwatch_event_rule", fi.StringValue(eb.Name), "id")
 }
 
+type pulumiEventBridgeRule struct {
+	Name         *string           `json:"name"`
+	EventPattern *pulumi.Literal   `json:"eventPattern"`
+	Tags         map[string]string `json:"tags,omitempty"`
+}
+
+func (_ *EventBridgeRule) RenderPulumi(t *pulumi.PulumiTarget, a, e, changes *EventBridgeRule) error {
+	m, err := t.AddFileBytes("aws:cloudwatch:EventRule", *e.Name, "eventPattern", []byte(*e.EventPattern), false)
+	if err != nil {
+		return err
+	}
+
+	p := &pulumiEventBridgeRule{
+		Name:         e.Name,
+		EventPattern: m,
+		Tags:         e.Tags,
+	}
+
+	return t.RenderResource("aws:cloudwatch:EventRule", *e.Name, p)
+}
+
+func (eb *EventBridgeRule) PulumiLink() *pulumi.Literal {
+	return pulumi.LiteralProperty("aws:cloudwatch:EventRule", fi.StringValue(eb.Name), "name")
+}
+
 type cloudformationTarget struct {
 	Id  *string
 	Arn *cloudformation.Literal
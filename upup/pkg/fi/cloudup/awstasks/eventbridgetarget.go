@@ -27,6 +27,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/cloudformation"
+	"k8s.io/kops/upup/pkg/fi/cloudup/pulumi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
 )
 
@@ -141,6 +142,20 @@ func (_ *EventBridgeTarget) RenderTerraform(t *terraform.TerraformTarget, a, e,
 	return t.RenderResource("aws_cloudwatch_event_target", *e.Name, tf)
 }
 
+type pulumiEventBridgeTarget struct {
+	Rule *pulumi.Literal `json:"rule"`
+	Arn  *pulumi.Literal `json:"arn"`
+}
+
+func (_ *EventBridgeTarget) RenderPulumi(t *pulumi.PulumiTarget, a, e, changes *EventBridgeTarget) error {
+	p := &pulumiEventBridgeTarget{
+		Rule: e.Rule.PulumiLink(),
+		Arn:  e.SQSQueue.PulumiLink(),
+	}
+
+	return t.RenderResource("aws:cloudwatch:EventTarget", *e.Name, p)
+}
+
 func (_ *EventBridgeTarget) RenderCloudformation(t *cloudformation.CloudformationTarget, a, e, changes *EventBridgeTarget) error {
 	// There is no Cloudformation EventBridge Target resource. Instead it's included in Cloudformation's EventBridge Rule resource
 	return nil
@@ -22,6 +22,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/cloudformation"
+	"k8s.io/kops/upup/pkg/fi/cloudup/pulumi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 
@@ -199,3 +200,15 @@ func (e *IAMInstanceProfile) CloudformationLink() *cloudformation.Literal {
 	}
 	return cloudformation.Ref("AWS::IAM::InstanceProfile", fi.StringValue(e.Name))
 }
+
+func (_ *IAMInstanceProfile) RenderPulumi(t *pulumi.PulumiTarget, a, e, changes *IAMInstanceProfile) error {
+	// Done on IAMInstanceProfileRole
+	return nil
+}
+
+func (e *IAMInstanceProfile) PulumiLink() *pulumi.Literal {
+	if fi.BoolValue(e.Shared) {
+		return pulumi.LiteralFromStringValue(fi.StringValue(e.Name))
+	}
+	return pulumi.LiteralProperty("aws:iam:InstanceProfile", *e.Name, "id")
+}
@@ -26,6 +26,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/cloudformation"
+	"k8s.io/kops/upup/pkg/fi/cloudup/pulumi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
@@ -140,3 +141,19 @@ func (_ *IAMInstanceProfileRole) RenderCloudformation(t *cloudformation.Cloudfor
 
 	return t.RenderResource("AWS::IAM::InstanceProfile", *e.InstanceProfile.Name, cf)
 }
+
+type pulumiIAMInstanceProfile struct {
+	Name *string           `json:"name"`
+	Role *pulumi.Literal   `json:"role"`
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+func (_ *IAMInstanceProfileRole) RenderPulumi(t *pulumi.PulumiTarget, a, e, changes *IAMInstanceProfileRole) error {
+	p := &pulumiIAMInstanceProfile{
+		Name: e.InstanceProfile.Name,
+		Role: e.Role.PulumiLink(),
+		Tags: e.InstanceProfile.Tags,
+	}
+
+	return t.RenderResource("aws:iam:InstanceProfile", *e.InstanceProfile.Name, p)
+}
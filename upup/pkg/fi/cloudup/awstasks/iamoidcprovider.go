@@ -28,6 +28,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/cloudformation"
+	"k8s.io/kops/upup/pkg/fi/cloudup/pulumi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
@@ -251,3 +252,37 @@ func (e *IAMOIDCProvider) TerraformLink() *terraformWriter.Literal {
 func (_ *IAMOIDCProvider) RenderCloudformation(t *cloudformation.CloudformationTarget, a, e, changes *IAMOIDCProvider) error {
 	return errors.New("cloudformation does not support IAM OIDC Provider")
 }
+
+type pulumiIAMOIDCProvider struct {
+	Url             *string           `json:"url"`
+	ClientIdLists   []*string         `json:"clientIdLists"`
+	ThumbprintLists []*string         `json:"thumbprintLists"`
+	Tags            map[string]string `json:"tags,omitempty"`
+}
+
+func (p *IAMOIDCProvider) RenderPulumi(t *pulumi.PulumiTarget, a, e, changes *IAMOIDCProvider) error {
+	err := t.AddOutputVariable("iamOpenidConnectProviderArn", e.PulumiLink())
+	if err != nil {
+		return err
+	}
+
+	issuerSubs := strings.SplitAfter(aws.StringValue(e.URL), "://")
+	issuer := issuerSubs[len(issuerSubs)-1]
+	err = t.AddOutputVariable("iamOpenidConnectProviderIssuer", pulumi.LiteralFromStringValue(issuer))
+	if err != nil {
+		return err
+	}
+
+	pp := &pulumiIAMOIDCProvider{
+		Url:             e.URL,
+		ClientIdLists:   e.ClientIDs,
+		ThumbprintLists: e.Thumbprints,
+		Tags:            e.Tags,
+	}
+
+	return t.RenderResource("aws:iam:OpenIdConnectProvider", *e.Name, pp)
+}
+
+func (e *IAMOIDCProvider) PulumiLink() *pulumi.Literal {
+	return pulumi.LiteralProperty("aws:iam:OpenIdConnectProvider", *e.Name, "arn")
+}
@@ -30,6 +30,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/cloudformation"
+	"k8s.io/kops/upup/pkg/fi/cloudup/pulumi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
@@ -40,6 +41,7 @@ type IAMRole struct {
 	Lifecycle fi.Lifecycle
 
 	Name                *string
+	Path                *string
 	RolePolicyDocument  fi.Resource // "inline" IAM policy
 	PermissionsBoundary *string
 
@@ -74,6 +76,7 @@ func (e *IAMRole) Find(c *fi.Context) (*IAMRole, error) {
 	actual := &IAMRole{}
 	actual.ID = r.RoleId
 	actual.Name = r.RoleName
+	actual.Path = r.Path
 	if r.PermissionsBoundary != nil {
 		actual.PermissionsBoundary = r.PermissionsBoundary.PermissionsBoundaryArn
 	}
@@ -139,6 +142,9 @@ func (s *IAMRole) CheckChanges(a, e, changes *IAMRole) error {
 		if e.Name == nil {
 			return fi.RequiredField("Name")
 		}
+		if changes.Path != nil {
+			return fi.CannotChangeField("Path")
+		}
 	} else {
 		if changes.Name == nil {
 			return fi.CannotChangeField("Name")
@@ -240,6 +246,7 @@ func (_ *IAMRole) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *IAMRole) error
 		request := &iam.CreateRoleInput{}
 		request.AssumeRolePolicyDocument = aws.String(policy)
 		request.RoleName = e.Name
+		request.Path = e.Path
 		request.Tags = mapToIAMTags(e.Tags)
 
 		if e.PermissionsBoundary != nil {
@@ -333,6 +340,7 @@ func (_ *IAMRole) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *IAMRole) error
 
 type terraformIAMRole struct {
 	Name                *string                  `cty:"name"`
+	Path                *string                  `cty:"path"`
 	AssumeRolePolicy    *terraformWriter.Literal `cty:"assume_role_policy"`
 	PermissionsBoundary *string                  `cty:"permissions_boundary"`
 	Tags                map[string]string        `cty:"tags"`
@@ -346,6 +354,7 @@ func (_ *IAMRole) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *I
 
 	tf := &terraformIAMRole{
 		Name:             e.Name,
+		Path:             e.Path,
 		AssumeRolePolicy: policy,
 		Tags:             e.Tags,
 	}
@@ -368,6 +377,7 @@ func (e *IAMRole) TerraformLink() *terraformWriter.Literal {
 
 type cloudformationIAMRole struct {
 	RoleName                 *string `json:"RoleName"`
+	Path                     *string `json:"Path,omitempty"`
 	AssumeRolePolicyDocument map[string]interface{}
 	PermissionsBoundary      *string             `json:"PermissionsBoundary,omitempty"`
 	Tags                     []cloudformationTag `json:"Tags,omitempty"`
@@ -387,6 +397,7 @@ func (_ *IAMRole) RenderCloudformation(t *cloudformation.CloudformationTarget, a
 
 	cf := &cloudformationIAMRole{
 		RoleName:                 e.Name,
+		Path:                     e.Path,
 		AssumeRolePolicyDocument: data,
 		Tags:                     buildCloudformationTags(e.Tags),
 	}
@@ -401,3 +412,37 @@ func (_ *IAMRole) RenderCloudformation(t *cloudformation.CloudformationTarget, a
 func (e *IAMRole) CloudformationLink() *cloudformation.Literal {
 	return cloudformation.Ref("AWS::IAM::Role", *e.Name)
 }
+
+type pulumiIAMRole struct {
+	Name                *string           `json:"name"`
+	Path                *string           `json:"path,omitempty"`
+	AssumeRolePolicy    *pulumi.Literal   `json:"assumeRolePolicy"`
+	PermissionsBoundary *string           `json:"permissionsBoundary,omitempty"`
+	Tags                map[string]string `json:"tags,omitempty"`
+}
+
+func (_ *IAMRole) RenderPulumi(t *pulumi.PulumiTarget, a, e, changes *IAMRole) error {
+	policy, err := t.AddFileResource("aws:iam:Role", *e.Name, "policy", e.RolePolicyDocument, false)
+	if err != nil {
+		return fmt.Errorf("error rendering RolePolicyDocument: %v", err)
+	}
+
+	p := &pulumiIAMRole{
+		Name:                e.Name,
+		Path:                e.Path,
+		AssumeRolePolicy:    policy,
+		PermissionsBoundary: e.PermissionsBoundary,
+		Tags:                e.Tags,
+	}
+
+	if fi.StringValue(e.ExportWithID) != "" {
+		t.AddOutputVariable(*e.ExportWithID+"RoleArn", pulumi.LiteralProperty("aws:iam:Role", *e.Name, "arn"))
+		t.AddOutputVariable(*e.ExportWithID+"RoleName", e.PulumiLink())
+	}
+
+	return t.RenderResource("aws:iam:Role", *e.Name, p)
+}
+
+func (e *IAMRole) PulumiLink() *pulumi.Literal {
+	return pulumi.LiteralProperty("aws:iam:Role", *e.Name, "name")
+}
@@ -32,6 +32,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/cloudformation"
+	"k8s.io/kops/upup/pkg/fi/cloudup/pulumi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
@@ -403,3 +404,59 @@ func (_ *IAMRolePolicy) RenderCloudformation(t *cloudformation.CloudformationTar
 func (e *IAMRolePolicy) CloudformationLink() *cloudformation.Literal {
 	return cloudformation.Ref("AWS::IAM::Policy", *e.Name)
 }
+
+type pulumiIAMRolePolicy struct {
+	Name   *string         `json:"name"`
+	Role   *pulumi.Literal `json:"role"`
+	Policy *pulumi.Literal `json:"policy"`
+}
+
+type pulumiIAMRolePolicyAttachment struct {
+	Role      *pulumi.Literal `json:"role"`
+	PolicyArn *string         `json:"policyArn"`
+}
+
+func (_ *IAMRolePolicy) RenderPulumi(t *pulumi.PulumiTarget, a, e, changes *IAMRolePolicy) error {
+	if e.ExternalPolicies != nil && len(*e.ExternalPolicies) > 0 {
+		for _, policy := range *e.ExternalPolicies {
+			// create a hash of the arn
+			h := fnv.New32a()
+			h.Write([]byte(policy))
+
+			name := fmt.Sprintf("%s-%d", *e.Name, h.Sum32())
+
+			p := &pulumiIAMRolePolicyAttachment{
+				Role:      e.Role.PulumiLink(),
+				PolicyArn: s(policy),
+			}
+
+			err := t.RenderResource("aws:iam:RolePolicyAttachment", name, p)
+			if err != nil {
+				return fmt.Errorf("error rendering RolePolicyAttachment: %v", err)
+			}
+		}
+	}
+
+	policyString, err := e.policyDocumentString()
+	if err != nil {
+		return fmt.Errorf("error rendering PolicyDocument: %v", err)
+	}
+
+	if policyString == "" {
+		// A deletion; we simply don't render; pulumi will observe the removal
+		return nil
+	}
+
+	policy, err := t.AddFileResource("aws:iam:RolePolicy", *e.Name, "policy", e.PolicyDocument, false)
+	if err != nil {
+		return fmt.Errorf("error rendering PolicyDocument: %v", err)
+	}
+
+	p := &pulumiIAMRolePolicy{
+		Name:   e.Name,
+		Role:   e.Role.PulumiLink(),
+		Policy: policy,
+	}
+
+	return t.RenderResource("aws:iam:RolePolicy", *e.Name, p)
+}
@@ -24,6 +24,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/cloudformation"
+	"k8s.io/kops/upup/pkg/fi/cloudup/pulumi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
@@ -308,3 +309,57 @@ func (e *InternetGateway) CloudformationLink() *cloudformation.Literal {
 
 	return cloudformation.Ref("AWS::EC2::InternetGateway", *e.Name)
 }
+
+type pulumiInternetGateway struct {
+	VpcId *pulumi.Literal   `json:"vpcId"`
+	Tags  map[string]string `json:"tags,omitempty"`
+}
+
+func (_ *InternetGateway) RenderPulumi(t *pulumi.PulumiTarget, a, e, changes *InternetGateway) error {
+	shared := fi.BoolValue(e.Shared)
+	if shared {
+		// Not pulumi owned / managed
+
+		// But ... attempt to discover the ID so PulumiLink works
+		if e.ID == nil {
+			request := &ec2.DescribeInternetGatewaysInput{}
+			vpcID := fi.StringValue(e.VPC.ID)
+			if vpcID == "" {
+				return fmt.Errorf("VPC ID is required when InternetGateway is shared")
+			}
+			request.Filters = []*ec2.Filter{awsup.NewEC2Filter("attachment.vpc-id", vpcID)}
+			igw, err := findInternetGateway(t.Cloud.(awsup.AWSCloud), request)
+			if err != nil {
+				return err
+			}
+			if igw == nil {
+				klog.Warningf("Cannot find internet gateway for VPC %q", vpcID)
+			} else {
+				e.ID = igw.InternetGatewayId
+			}
+		}
+
+		return nil
+	}
+
+	p := &pulumiInternetGateway{
+		VpcId: e.VPC.PulumiLink(),
+		Tags:  e.Tags,
+	}
+
+	return t.RenderResource("aws:ec2:InternetGateway", *e.Name, p)
+}
+
+func (e *InternetGateway) PulumiLink() *pulumi.Literal {
+	shared := fi.BoolValue(e.Shared)
+	if shared {
+		if e.ID == nil {
+			klog.Fatalf("ID must be set, if InternetGateway is shared: %s", e)
+		}
+
+		klog.V(4).Infof("reusing existing InternetGateway with id %q", *e.ID)
+		return pulumi.LiteralFromStringValue(*e.ID)
+	}
+
+	return pulumi.LiteralProperty("aws:ec2:InternetGateway", *e.Name, "id")
+}
@@ -41,6 +41,14 @@ type LaunchTemplate struct {
 	BlockDeviceMappings []*BlockDeviceMapping
 	// CPUCredits is the credit option for CPU Usage on some instance types
 	CPUCredits *string
+	// ElasticInferenceAccelerators are the Elastic Inference accelerators attached to the instances
+	ElasticInferenceAccelerators []*LaunchTemplateElasticInferenceAccelerator
+	// EnclaveEnabled enables AWS Nitro Enclaves on the instances
+	EnclaveEnabled *bool
+	// HostAffinity is the affinity of the instances to a Dedicated Host, either default or host.
+	HostAffinity *string
+	// HostResourceGroupARN is the ARN of the host resource group in which to launch the instances.
+	HostResourceGroupARN *string
 	// HTTPPutResponseHopLimit is the desired HTTP PUT response hop limit for instance metadata requests.
 	HTTPPutResponseHopLimit *int64
 	// HTTPTokens is the state of token usage for your instance metadata requests.
@@ -58,6 +66,11 @@ type LaunchTemplate struct {
 	InstanceMonitoring *bool
 	// InstanceType is the type of instance we are using
 	InstanceType *string
+	// InterfaceType is the type of the primary network interface, e.g. "efa" for an Elastic Fabric Adapter.
+	InterfaceType *string
+	// AdditionalNetworkInterfaces are network interfaces attached in addition to the primary network interface,
+	// typically on the other network cards of instance types that have more than one.
+	AdditionalNetworkInterfaces []*LaunchTemplateNetworkInterface
 	// Ipv6AddressCount is the number of IPv6 addresses to assign with the primary network interface.
 	IPv6AddressCount *int64
 	// RootVolumeIops is the provisioned IOPS when the volume type is io1, io2 or gp3
@@ -82,14 +95,48 @@ type LaunchTemplate struct {
 	SpotPrice *string
 	// SpotDurationInMinutes is set for requesting spot blocks
 	SpotDurationInMinutes *int64
+	// SpecHash is the hash of the spec the launch template is applied from. It is only tagged on the launch template itself,
+	// so changing it doesn't create a new version of the launch template.
+	SpecHash *string
 	// Tags are the keypairs to apply to the instance and volume on launch as well as the launch template itself.
 	Tags map[string]string
-	// Tenancy. Can be either default or dedicated.
+	// Tenancy. Can be default, dedicated or host.
 	Tenancy *string
 	// UserData is the user data configuration
 	UserData fi.Resource
 }
 
+// LaunchTemplateNetworkInterface is an additional network interface of the instances.
+type LaunchTemplateNetworkInterface struct {
+	// DeviceIndex is the position of the network interface in the attachment order.
+	DeviceIndex *int64
+	// NetworkCardIndex is the index of the network card on which the network interface is attached.
+	NetworkCardIndex *int64
+	// InterfaceType is the type of the network interface, e.g. "efa" for an Elastic Fabric Adapter.
+	InterfaceType *string
+}
+
+// LaunchTemplateElasticInferenceAccelerator is an Elastic Inference accelerator attached to the instances.
+type LaunchTemplateElasticInferenceAccelerator struct {
+	// Type is the type of the accelerator, e.g. eia2.medium.
+	Type *string
+	// Count is the number of accelerators of the type.
+	Count *int64
+}
+
+// launchTemplateTags returns the tags of the launch template itself.
+func (t *LaunchTemplate) launchTemplateTags() map[string]string {
+	if t.SpecHash == nil {
+		return t.Tags
+	}
+	tags := make(map[string]string, len(t.Tags)+1)
+	for k, v := range t.Tags {
+		tags[k] = v
+	}
+	tags[awsup.TagNameKopsSpecHash] = *t.SpecHash
+	return tags
+}
+
 var (
 	_ fi.CompareWithID     = &LaunchTemplate{}
 	_ fi.ProducesDeletions = &LaunchTemplate{}
@@ -135,6 +182,19 @@ func (t *LaunchTemplate) buildRootDevice(cloud awsup.AWSCloud) (map[string]*Bloc
 	return bm, nil
 }
 
+// singleElasticInferenceAccelerator returns the type of the only Elastic Inference accelerator, for the targets
+// that cannot express more than one.
+func (t *LaunchTemplate) singleElasticInferenceAccelerator() (*string, error) {
+	if len(t.ElasticInferenceAccelerators) == 0 {
+		return nil, nil
+	}
+	x := t.ElasticInferenceAccelerators[0]
+	if len(t.ElasticInferenceAccelerators) > 1 || fi.Int64Value(x.Count) > 1 {
+		return nil, fmt.Errorf("launch template %q can have only one elastic inference accelerator", fi.StringValue(t.Name))
+	}
+	return x.Type, nil
+}
+
 // Run is responsible for
 func (t *LaunchTemplate) Run(c *fi.Context) error {
 	t.Normalize()
@@ -19,10 +19,13 @@ package awstasks
 import (
 	"encoding/base64"
 	"fmt"
+	"reflect"
 	"sort"
 	"strconv"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"k8s.io/klog/v2"
 
@@ -30,8 +33,22 @@ import (
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 )
 
+const (
+	// errorCodeLaunchTemplateLimitExceeded is returned when the region has reached its launch template quota
+	errorCodeLaunchTemplateLimitExceeded = "LaunchTemplateLimitExceeded"
+	// errorCodeVersionLimitExceeded is returned when a launch template has reached its version quota
+	errorCodeVersionLimitExceeded = "VersionLimitExceeded"
+
+	// launchTemplateCleanupMinAge protects launch templates created by the current update, whose autoscaling groups may not exist yet
+	launchTemplateCleanupMinAge = time.Hour
+	// launchTemplateVersionsToKeep is the number of most recent versions kept when old versions are deleted
+	launchTemplateVersionsToKeep = 10
+	// maxDeleteLaunchTemplateVersions is the maximum number of versions that can be deleted in one call
+	maxDeleteLaunchTemplateVersions = 200
+)
+
 // RenderAWS is responsible for performing creating / updating the launch template
-func (t *LaunchTemplate) RenderAWS(c *awsup.AWSAPITarget, a, e, changes *LaunchTemplate) error {
+func (t *LaunchTemplate) RenderAWS(ctx *fi.Context, c *awsup.AWSAPITarget, a, e, changes *LaunchTemplate) error {
 	// @step: resolve the image id to an AMI for us
 	image, err := c.Cloud.ResolveImage(fi.StringValue(t.ImageID))
 	if err != nil {
@@ -54,10 +71,29 @@ func (t *LaunchTemplate) RenderAWS(c *awsup.AWSAPITarget, a, e, changes *LaunchT
 				AssociatePublicIpAddress: t.AssociatePublicIP,
 				DeleteOnTermination:      aws.Bool(true),
 				DeviceIndex:              fi.Int64(0),
+				InterfaceType:            t.InterfaceType,
 				Ipv6AddressCount:         t.IPv6AddressCount,
 			},
 		},
 	}
+	for _, x := range t.AdditionalNetworkInterfaces {
+		data.NetworkInterfaces = append(data.NetworkInterfaces, &ec2.LaunchTemplateInstanceNetworkInterfaceSpecificationRequest{
+			DeleteOnTermination: aws.Bool(true),
+			DeviceIndex:         x.DeviceIndex,
+			InterfaceType:       x.InterfaceType,
+			NetworkCardIndex:    x.NetworkCardIndex,
+		})
+	}
+
+	for _, x := range t.ElasticInferenceAccelerators {
+		data.ElasticInferenceAccelerators = append(data.ElasticInferenceAccelerators, &ec2.LaunchTemplateElasticInferenceAccelerator{
+			Count: x.Count,
+			Type:  x.Type,
+		})
+	}
+	if t.EnclaveEnabled != nil {
+		data.EnclaveOptions = &ec2.LaunchTemplateEnclaveOptionsRequest{Enabled: t.EnclaveEnabled}
+	}
 
 	// @step: add the actual block device mappings
 	rootDevices, err := t.buildRootDevice(c.Cloud)
@@ -84,11 +120,17 @@ func (t *LaunchTemplate) RenderAWS(c *awsup.AWSAPITarget, a, e, changes *LaunchT
 	}
 	// @step: add the security groups
 	for _, sg := range t.SecurityGroups {
-		data.NetworkInterfaces[0].Groups = append(data.NetworkInterfaces[0].Groups, sg.ID)
+		for _, ni := range data.NetworkInterfaces {
+			ni.Groups = append(ni.Groups, sg.ID)
+		}
 	}
 	// @step: add any tenancy details
 	if t.Tenancy != nil {
-		data.Placement = &ec2.LaunchTemplatePlacementRequest{Tenancy: t.Tenancy}
+		data.Placement = &ec2.LaunchTemplatePlacementRequest{
+			Affinity:             t.HostAffinity,
+			HostResourceGroupArn: t.HostResourceGroupARN,
+			Tenancy:              t.Tenancy,
+		}
 	}
 	// @step: set the instance monitoring
 	data.Monitoring = &ec2.LaunchTemplatesMonitoringRequest{Enabled: fi.Bool(false)}
@@ -156,30 +198,53 @@ func (t *LaunchTemplate) RenderAWS(c *awsup.AWSAPITarget, a, e, changes *LaunchT
 				},
 			},
 		}
+		if t.SpecHash != nil {
+			input.TagSpecifications[0].Tags = append(input.TagSpecifications[0].Tags, &ec2.Tag{
+				Key:   aws.String(awsup.TagNameKopsSpecHash),
+				Value: t.SpecHash,
+			})
+		}
 		output, err := c.Cloud.EC2().CreateLaunchTemplate(input)
+		if awsup.AWSErrorCode(err) == errorCodeLaunchTemplateLimitExceeded {
+			klog.Warningf("Launch template quota reached creating %q, deleting unused launch templates of the cluster", fi.StringValue(t.Name))
+			if cleanupErr := deleteUnusedLaunchTemplates(c.Cloud, modelLaunchTemplateNames(ctx), time.Now().Add(-launchTemplateCleanupMinAge)); cleanupErr != nil {
+				return fmt.Errorf("error creating LaunchTemplate %q: %v (error deleting unused launch templates: %v)", fi.StringValue(t.Name), err, cleanupErr)
+			}
+			output, err = c.Cloud.EC2().CreateLaunchTemplate(input)
+		}
 		if err != nil || output.LaunchTemplate == nil {
 			return fmt.Errorf("error creating LaunchTemplate %q: %v", fi.StringValue(t.Name), err)
 		}
 		e.ID = output.LaunchTemplate.LaunchTemplateId
 	} else {
-		input := &ec2.CreateLaunchTemplateVersionInput{
-			LaunchTemplateName: t.Name,
-			LaunchTemplateData: data,
-		}
-		if version, err := c.Cloud.EC2().CreateLaunchTemplateVersion(input); err != nil {
-			return fmt.Errorf("error creating LaunchTemplateVersion: %v", err)
-		} else {
-			newDefault := strconv.FormatInt(*version.LaunchTemplateVersion.VersionNumber, 10)
-			input := &ec2.ModifyLaunchTemplateInput{
-				DefaultVersion:   &newDefault,
-				LaunchTemplateId: version.LaunchTemplateVersion.LaunchTemplateId,
+		if launchTemplateDataChanged(changes) {
+			input := &ec2.CreateLaunchTemplateVersionInput{
+				LaunchTemplateName: t.Name,
+				LaunchTemplateData: data,
 			}
-			if _, err := c.Cloud.EC2().ModifyLaunchTemplate(input); err != nil {
-				return fmt.Errorf("error updating launch template version: %w", err)
+			version, err := c.Cloud.EC2().CreateLaunchTemplateVersion(input)
+			if awsup.AWSErrorCode(err) == errorCodeVersionLimitExceeded {
+				klog.Warningf("Version quota reached for launch template %q, deleting old versions", fi.StringValue(t.Name))
+				if cleanupErr := deleteOldLaunchTemplateVersions(c.Cloud, a.ID); cleanupErr != nil {
+					return fmt.Errorf("error creating LaunchTemplateVersion: %v (error deleting old versions: %v)", err, cleanupErr)
+				}
+				version, err = c.Cloud.EC2().CreateLaunchTemplateVersion(input)
+			}
+			if err != nil {
+				return fmt.Errorf("error creating LaunchTemplateVersion: %v", err)
+			} else {
+				newDefault := strconv.FormatInt(*version.LaunchTemplateVersion.VersionNumber, 10)
+				input := &ec2.ModifyLaunchTemplateInput{
+					DefaultVersion:   &newDefault,
+					LaunchTemplateId: version.LaunchTemplateVersion.LaunchTemplateId,
+				}
+				if _, err := c.Cloud.EC2().ModifyLaunchTemplate(input); err != nil {
+					return fmt.Errorf("error updating launch template version: %w", err)
+				}
 			}
 		}
-		if changes.Tags != nil {
-			err = c.UpdateTags(fi.StringValue(a.ID), e.Tags)
+		if changes.Tags != nil || changes.SpecHash != nil {
+			err = c.UpdateTags(fi.StringValue(a.ID), e.launchTemplateTags())
 			if err != nil {
 				return fmt.Errorf("error updating LaunchTemplate tags: %v", err)
 			}
@@ -222,12 +287,26 @@ func (t *LaunchTemplate) Find(c *fi.Context) (*LaunchTemplate, error) {
 
 	// @step: check if any of the interfaces are public facing
 	for _, x := range lt.LaunchTemplateData.NetworkInterfaces {
+		interfaceType := x.InterfaceType
+		if aws.StringValue(interfaceType) == "interface" {
+			// "interface" is the default type
+			interfaceType = nil
+		}
+		if aws.Int64Value(x.DeviceIndex) != 0 || aws.Int64Value(x.NetworkCardIndex) != 0 {
+			actual.AdditionalNetworkInterfaces = append(actual.AdditionalNetworkInterfaces, &LaunchTemplateNetworkInterface{
+				DeviceIndex:      x.DeviceIndex,
+				NetworkCardIndex: x.NetworkCardIndex,
+				InterfaceType:    interfaceType,
+			})
+			continue
+		}
 		if aws.BoolValue(x.AssociatePublicIpAddress) {
 			actual.AssociatePublicIP = fi.Bool(true)
 		}
 		for _, id := range x.Groups {
 			actual.SecurityGroups = append(actual.SecurityGroups, &SecurityGroup{ID: id})
 		}
+		actual.InterfaceType = interfaceType
 		actual.IPv6AddressCount = x.Ipv6AddressCount
 	}
 	// In older Kops versions, security groups were added to LaunchTemplateData.SecurityGroupIds
@@ -241,6 +320,15 @@ func (t *LaunchTemplate) Find(c *fi.Context) (*LaunchTemplate, error) {
 	} else {
 		actual.CPUCredits = aws.String("")
 	}
+	for _, x := range lt.LaunchTemplateData.ElasticInferenceAccelerators {
+		actual.ElasticInferenceAccelerators = append(actual.ElasticInferenceAccelerators, &LaunchTemplateElasticInferenceAccelerator{
+			Count: x.Count,
+			Type:  x.Type,
+		})
+	}
+	if lt.LaunchTemplateData.EnclaveOptions != nil {
+		actual.EnclaveEnabled = lt.LaunchTemplateData.EnclaveOptions.Enabled
+	}
 	// @step: check if monitoring it enabled
 	if lt.LaunchTemplateData.Monitoring != nil {
 		actual.InstanceMonitoring = lt.LaunchTemplateData.Monitoring.Enabled
@@ -248,6 +336,8 @@ func (t *LaunchTemplate) Find(c *fi.Context) (*LaunchTemplate, error) {
 	// @step: add the tenancy
 	if lt.LaunchTemplateData.Placement != nil {
 		actual.Tenancy = lt.LaunchTemplateData.Placement.Tenancy
+		actual.HostAffinity = lt.LaunchTemplateData.Placement.Affinity
+		actual.HostResourceGroupARN = lt.LaunchTemplateData.Placement.HostResourceGroupArn
 	}
 	// @step: add the ssh if there is one
 	if lt.LaunchTemplateData.KeyName != nil {
@@ -333,6 +423,24 @@ func (t *LaunchTemplate) Find(c *fi.Context) (*LaunchTemplate, error) {
 		}
 	}
 
+	// @step: the spec hash is only tagged on the launch template itself
+	if t.SpecHash != nil {
+		templates, err := t.findAllLaunchTemplates(c)
+		if err != nil {
+			return nil, err
+		}
+		for _, template := range templates {
+			if aws.StringValue(template.LaunchTemplateName) != aws.StringValue(t.Name) {
+				continue
+			}
+			for _, tag := range template.Tags {
+				if aws.StringValue(tag.Key) == awsup.TagNameKopsSpecHash {
+					actual.SpecHash = tag.Value
+				}
+			}
+		}
+	}
+
 	if t.ID == nil {
 		t.ID = actual.ID
 	}
@@ -340,6 +448,17 @@ func (t *LaunchTemplate) Find(c *fi.Context) (*LaunchTemplate, error) {
 	return actual, nil
 }
 
+// launchTemplateDataChanged returns whether the changes need a new version of the launch template,
+// rather than only retagging the launch template itself.
+func launchTemplateDataChanged(changes *LaunchTemplate) bool {
+	c := *changes
+	c.ID = nil
+	c.Name = nil
+	c.Lifecycle = ""
+	c.SpecHash = nil
+	return !reflect.DeepEqual(c, LaunchTemplate{})
+}
+
 // findAllLaunchTemplates returns all the launch templates for us
 func (t *LaunchTemplate) findAllLaunchTemplates(c *fi.Context) ([]*ec2.LaunchTemplate, error) {
 	cloud, ok := c.Cloud.(awsup.AWSCloud)
@@ -397,6 +516,152 @@ func (t *LaunchTemplate) findLatestLaunchTemplateVersion(c *fi.Context) (*ec2.La
 	return output.LaunchTemplateVersions[0], nil
 }
 
+// modelLaunchTemplateNames returns the names of the launch templates in the model.
+func modelLaunchTemplateNames(c *fi.Context) map[string]bool {
+	names := make(map[string]bool)
+	for _, task := range c.AllTasks() {
+		if lt, ok := task.(*LaunchTemplate); ok {
+			names[fi.StringValue(lt.Name)] = true
+		}
+	}
+	return names
+}
+
+// deleteUnusedLaunchTemplates deletes the launch templates owned by the cluster which are not in the model,
+// are not used by any autoscaling group, and were created before the given time.
+// Launch templates in the model are kept even without an autoscaling group, as Karpenter and external
+// provisioners launch instances from them.
+func deleteUnusedLaunchTemplates(cloud awsup.AWSCloud, inModel map[string]bool, createdBefore time.Time) error {
+	clusterName := cloud.Tags()[awsup.TagClusterName]
+	if clusterName == "" {
+		return fmt.Errorf("unable to determine the cluster name")
+	}
+
+	var templates []*ec2.LaunchTemplate
+	err := cloud.EC2().DescribeLaunchTemplatesPages(&ec2.DescribeLaunchTemplatesInput{
+		Filters: []*ec2.Filter{awsup.NewEC2Filter("tag:"+awsup.TagNameClusterOwnershipPrefix+clusterName, "owned")},
+	}, func(p *ec2.DescribeLaunchTemplatesOutput, lastPage bool) (shouldContinue bool) {
+		templates = append(templates, p.LaunchTemplates...)
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("error listing launch templates: %v", err)
+	}
+
+	var groups []*autoscaling.Group
+	err = cloud.Autoscaling().DescribeAutoScalingGroupsPages(&autoscaling.DescribeAutoScalingGroupsInput{}, func(p *autoscaling.DescribeAutoScalingGroupsOutput, lastPage bool) (shouldContinue bool) {
+		groups = append(groups, p.AutoScalingGroups...)
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("error listing autoscaling groups: %v", err)
+	}
+
+	for _, lt := range unusedLaunchTemplates(templates, inModel, groups, createdBefore) {
+		klog.Infof("Deleting unused launch template %q", aws.StringValue(lt.LaunchTemplateName))
+		if _, err := cloud.EC2().DeleteLaunchTemplate(&ec2.DeleteLaunchTemplateInput{
+			LaunchTemplateId: lt.LaunchTemplateId,
+		}); err != nil {
+			return fmt.Errorf("error deleting launch template %q: %v", aws.StringValue(lt.LaunchTemplateName), err)
+		}
+	}
+
+	return nil
+}
+
+// unusedLaunchTemplates returns the launch templates which were created before the given time, are not in the model,
+// and are not used by any of the autoscaling groups.
+func unusedLaunchTemplates(templates []*ec2.LaunchTemplate, inModel map[string]bool, groups []*autoscaling.Group, createdBefore time.Time) []*ec2.LaunchTemplate {
+	used := make(map[string]bool)
+	addUsed := func(spec *autoscaling.LaunchTemplateSpecification) {
+		if spec == nil {
+			return
+		}
+		if spec.LaunchTemplateId != nil {
+			used[aws.StringValue(spec.LaunchTemplateId)] = true
+		}
+		if spec.LaunchTemplateName != nil {
+			used[aws.StringValue(spec.LaunchTemplateName)] = true
+		}
+	}
+	for _, group := range groups {
+		addUsed(group.LaunchTemplate)
+		if group.MixedInstancesPolicy != nil && group.MixedInstancesPolicy.LaunchTemplate != nil {
+			addUsed(group.MixedInstancesPolicy.LaunchTemplate.LaunchTemplateSpecification)
+		}
+	}
+
+	var unused []*ec2.LaunchTemplate
+	for _, lt := range templates {
+		if inModel[aws.StringValue(lt.LaunchTemplateName)] {
+			continue
+		}
+		if used[aws.StringValue(lt.LaunchTemplateId)] || used[aws.StringValue(lt.LaunchTemplateName)] {
+			continue
+		}
+		if lt.CreateTime == nil || !lt.CreateTime.Before(createdBefore) {
+			continue
+		}
+		unused = append(unused, lt)
+	}
+	return unused
+}
+
+// deleteOldLaunchTemplateVersions deletes all but the most recent versions of a launch template.
+// Autoscaling groups always use the latest version, so older versions are not in use.
+func deleteOldLaunchTemplateVersions(cloud awsup.AWSCloud, id *string) error {
+	var versions []*ec2.LaunchTemplateVersion
+	err := cloud.EC2().DescribeLaunchTemplateVersionsPages(&ec2.DescribeLaunchTemplateVersionsInput{
+		LaunchTemplateId: id,
+	}, func(p *ec2.DescribeLaunchTemplateVersionsOutput, lastPage bool) (shouldContinue bool) {
+		versions = append(versions, p.LaunchTemplateVersions...)
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("error listing launch template versions: %v", err)
+	}
+
+	old := oldLaunchTemplateVersions(versions, launchTemplateVersionsToKeep)
+	for len(old) > 0 {
+		batch := old
+		if len(batch) > maxDeleteLaunchTemplateVersions {
+			batch = batch[:maxDeleteLaunchTemplateVersions]
+		}
+		old = old[len(batch):]
+
+		klog.Infof("Deleting %d old versions of launch template %q", len(batch), aws.StringValue(id))
+		if _, err := cloud.EC2().DeleteLaunchTemplateVersions(&ec2.DeleteLaunchTemplateVersionsInput{
+			LaunchTemplateId: id,
+			Versions:         aws.StringSlice(batch),
+		}); err != nil {
+			return fmt.Errorf("error deleting launch template versions: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// oldLaunchTemplateVersions returns the version numbers that are neither the default version nor one of the keep most recent versions.
+func oldLaunchTemplateVersions(versions []*ec2.LaunchTemplateVersion, keep int) []string {
+	var numbers []int64
+	for _, version := range versions {
+		if aws.BoolValue(version.DefaultVersion) {
+			continue
+		}
+		numbers = append(numbers, aws.Int64Value(version.VersionNumber))
+	}
+	sort.Slice(numbers, func(i, j int) bool { return numbers[i] > numbers[j] })
+
+	var old []string
+	for i, number := range numbers {
+		if i < keep {
+			continue
+		}
+		old = append(old, strconv.FormatInt(number, 10))
+	}
+	return old
+}
+
 // deleteLaunchTemplate tracks a LaunchConfiguration that we're going to delete
 // It implements fi.Deletion
 type deleteLaunchTemplate struct {
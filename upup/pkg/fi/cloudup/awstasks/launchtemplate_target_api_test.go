@@ -0,0 +1,139 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func TestUnusedLaunchTemplates(t *testing.T) {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	createdBefore := now.Add(-launchTemplateCleanupMinAge)
+
+	template := func(id, name string, age time.Duration) *ec2.LaunchTemplate {
+		return &ec2.LaunchTemplate{
+			LaunchTemplateId:   aws.String(id),
+			LaunchTemplateName: aws.String(name),
+			CreateTime:         aws.Time(now.Add(-age)),
+		}
+	}
+	templates := []*ec2.LaunchTemplate{
+		template("lt-1", "nodes-a", 48*time.Hour),
+		template("lt-2", "nodes-b", 48*time.Hour),
+		template("lt-3", "nodes-c", 48*time.Hour),
+		template("lt-4", "nodes-d", 48*time.Hour),
+		template("lt-5", "nodes-e", 10*time.Minute),
+		template("lt-6", "karpenter-nodes", 48*time.Hour),
+	}
+
+	// Karpenter instance groups have a launch template in the model, but no autoscaling group
+	tasks := map[string]fi.Task{
+		"LaunchTemplate/nodes-a":         &LaunchTemplate{Name: fi.String("nodes-a")},
+		"AutoscalingGroup/nodes-a":       &AutoscalingGroup{Name: fi.String("nodes-a")},
+		"LaunchTemplate/nodes-c":         &LaunchTemplate{Name: fi.String("nodes-c")},
+		"AutoscalingGroup/nodes-c":       &AutoscalingGroup{Name: fi.String("nodes-c")},
+		"LaunchTemplate/karpenter-nodes": &LaunchTemplate{Name: fi.String("karpenter-nodes")},
+	}
+	context, err := fi.NewContext(nil, nil, nil, nil, nil, nil, true, tasks)
+	if err != nil {
+		t.Fatalf("error building context: %v", err)
+	}
+	inModel := modelLaunchTemplateNames(context)
+	groups := []*autoscaling.Group{
+		{
+			AutoScalingGroupName: aws.String("nodes-a"),
+			LaunchTemplate:       &autoscaling.LaunchTemplateSpecification{LaunchTemplateId: aws.String("lt-1")},
+		},
+		{
+			AutoScalingGroupName: aws.String("nodes-b"),
+			MixedInstancesPolicy: &autoscaling.MixedInstancesPolicy{
+				LaunchTemplate: &autoscaling.LaunchTemplate{
+					LaunchTemplateSpecification: &autoscaling.LaunchTemplateSpecification{LaunchTemplateName: aws.String("nodes-b")},
+				},
+			},
+		},
+		{
+			AutoScalingGroupName: aws.String("legacy"),
+		},
+	}
+
+	var actual []string
+	for _, lt := range unusedLaunchTemplates(templates, inModel, groups, createdBefore) {
+		actual = append(actual, aws.StringValue(lt.LaunchTemplateId))
+	}
+	expected := []string{"lt-4"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected unused launch templates %v, got %v", expected, actual)
+	}
+}
+
+func TestOldLaunchTemplateVersions(t *testing.T) {
+	var versions []*ec2.LaunchTemplateVersion
+	for i := int64(1); i <= 15; i++ {
+		versions = append(versions, &ec2.LaunchTemplateVersion{
+			VersionNumber:  aws.Int64(i),
+			DefaultVersion: aws.Bool(i == 3),
+		})
+	}
+
+	grid := []struct {
+		keep     int
+		expected []string
+	}{
+		{keep: 10, expected: []string{"5", "4", "2", "1"}},
+		{keep: 13, expected: []string{"1"}},
+		{keep: 20, expected: nil},
+	}
+	for _, g := range grid {
+		actual := oldLaunchTemplateVersions(versions, g.keep)
+		if !reflect.DeepEqual(actual, g.expected) {
+			t.Errorf("keep %d: expected %v, got %v", g.keep, g.expected, actual)
+		}
+	}
+}
+
+func TestLaunchTemplateDataChanged(t *testing.T) {
+	grid := []struct {
+		changes  *LaunchTemplate
+		expected bool
+	}{
+		{
+			changes:  &LaunchTemplate{Name: fi.String("nodes"), Lifecycle: fi.LifecycleSync, SpecHash: fi.String("0123456789abcdef")},
+			expected: false,
+		},
+		{
+			changes:  &LaunchTemplate{SpecHash: fi.String("0123456789abcdef"), Tags: map[string]string{"foo": "bar"}},
+			expected: true,
+		},
+		{
+			changes:  &LaunchTemplate{InstanceType: fi.String("t3.medium")},
+			expected: true,
+		},
+	}
+	for i, g := range grid {
+		if actual := launchTemplateDataChanged(g.changes); actual != g.expected {
+			t.Errorf("case %d: expected %v, got %v", i, g.expected, actual)
+		}
+	}
+}
@@ -32,8 +32,12 @@ type cloudformationLaunchTemplateNetworkInterface struct {
 	DeleteOnTermination *bool `json:"DeleteOnTermination,omitempty"`
 	// DeviceIndex is the device index for the network interface attachment.
 	DeviceIndex *int `json:"DeviceIndex,omitempty"`
+	// InterfaceType is the type of the network interface, e.g. "efa".
+	InterfaceType *string `json:"InterfaceType,omitempty"`
 	// Ipv6AddressCount is the number of IPv6 addresses to assign with the primary network interface.
 	Ipv6AddressCount *int64 `json:"Ipv6AddressCount,omitempty"`
+	// NetworkCardIndex is the index of the network card on which the network interface is attached.
+	NetworkCardIndex *int64 `json:"NetworkCardIndex,omitempty"`
 	// SecurityGroups is a list of security group ids.
 	SecurityGroups []*cloudformation.Literal `json:"Groups,omitempty"`
 }
@@ -52,6 +56,8 @@ type cloudformationLaunchTemplatePlacement struct {
 	GroupName *string `json:"GroupName,omitempty"`
 	// HostID is the ID of the Dedicated Host for the instance.
 	HostID *string `json:"HostId,omitempty"`
+	// HostResourceGroupARN is the ARN of the host resource group in which to launch the instance.
+	HostResourceGroupARN *string `json:"HostResourceGroupArn,omitempty"`
 	// SpreadDomain are reserved for future use.
 	SpreadDomain *string `json:"SpreadDomain,omitempty"`
 	// Tenancy ist he tenancy of the instance. Can be default, dedicated, or host.
@@ -86,6 +92,18 @@ type cloudformationLaunchTemplateCreditSpecification struct {
 	CPUCredits *string `json:"CpuCredits,omitempty"`
 }
 
+type cloudformationLaunchTemplateElasticInferenceAccelerator struct {
+	// Type is the type of the accelerator
+	Type *string `json:"Type,omitempty"`
+	// Count is the number of accelerators of the type
+	Count *int64 `json:"Count,omitempty"`
+}
+
+type cloudformationLaunchTemplateEnclaveOptions struct {
+	// Enabled enables Nitro Enclaves on the instances
+	Enabled *bool `json:"Enabled,omitempty"`
+}
+
 type cloudformationLaunchTemplateBlockDeviceEBS struct {
 	// VolumeType is the ebs type to use
 	VolumeType *string `json:"VolumeType,omitempty"`
@@ -133,6 +151,10 @@ type cloudformationLaunchTemplateData struct {
 	CreditSpecification *cloudformationLaunchTemplateCreditSpecification `json:"CreditSpecification,omitempty"`
 	// EBSOptimized indicates if the root device is ebs optimized
 	EBSOptimized *bool `json:"EbsOptimized,omitempty"`
+	// ElasticInferenceAccelerators are the Elastic Inference accelerators attached to the instances
+	ElasticInferenceAccelerators []*cloudformationLaunchTemplateElasticInferenceAccelerator `json:"ElasticInferenceAccelerators,omitempty"`
+	// EnclaveOptions are the Nitro Enclaves options
+	EnclaveOptions *cloudformationLaunchTemplateEnclaveOptions `json:"EnclaveOptions,omitempty"`
 	// IAMInstanceProfile is the IAM profile to assign to the nodes
 	IAMInstanceProfile *cloudformationLaunchTemplateIAMProfile `json:"IamInstanceProfile,omitempty"`
 	// ImageID is the ami to use for the instances
@@ -202,10 +224,19 @@ func (t *LaunchTemplate) RenderCloudformation(target *cloudformation.Cloudformat
 				AssociatePublicIPAddress: e.AssociatePublicIP,
 				DeleteOnTermination:      fi.Bool(true),
 				DeviceIndex:              fi.Int(0),
+				InterfaceType:            e.InterfaceType,
 				Ipv6AddressCount:         e.IPv6AddressCount,
 			},
 		},
 	}
+	for _, x := range e.AdditionalNetworkInterfaces {
+		launchTemplateData.NetworkInterfaces = append(launchTemplateData.NetworkInterfaces, &cloudformationLaunchTemplateNetworkInterface{
+			DeleteOnTermination: fi.Bool(true),
+			DeviceIndex:         fi.Int(int(fi.Int64Value(x.DeviceIndex))),
+			InterfaceType:       x.InterfaceType,
+			NetworkCardIndex:    x.NetworkCardIndex,
+		})
+	}
 
 	if fi.StringValue(e.SpotPrice) != "" {
 		marketSpotOptions := cloudformationLaunchTemplateMarketOptionsSpotOptions{MaxPrice: e.SpotPrice}
@@ -224,6 +255,18 @@ func (t *LaunchTemplate) RenderCloudformation(target *cloudformation.Cloudformat
 		}
 	}
 
+	for _, x := range e.ElasticInferenceAccelerators {
+		launchTemplateData.ElasticInferenceAccelerators = append(launchTemplateData.ElasticInferenceAccelerators, &cloudformationLaunchTemplateElasticInferenceAccelerator{
+			Type:  x.Type,
+			Count: x.Count,
+		})
+	}
+	if e.EnclaveEnabled != nil {
+		launchTemplateData.EnclaveOptions = &cloudformationLaunchTemplateEnclaveOptions{
+			Enabled: e.EnclaveEnabled,
+		}
+	}
+
 	cf := &cloudformationLaunchTemplate{
 		LaunchTemplateName: fi.String(fi.StringValue(e.Name)),
 		LaunchTemplateData: launchTemplateData,
@@ -231,13 +274,21 @@ func (t *LaunchTemplate) RenderCloudformation(target *cloudformation.Cloudformat
 	data := cf.LaunchTemplateData
 
 	for _, x := range e.SecurityGroups {
-		data.NetworkInterfaces[0].SecurityGroups = append(data.NetworkInterfaces[0].SecurityGroups, x.CloudformationLink())
+		for _, ni := range data.NetworkInterfaces {
+			ni.SecurityGroups = append(ni.SecurityGroups, x.CloudformationLink())
+		}
 	}
 	if e.SSHKey != nil {
 		data.KeyName = e.SSHKey.Name
 	}
 	if e.Tenancy != nil {
-		data.Placement = []*cloudformationLaunchTemplatePlacement{{Tenancy: e.Tenancy}}
+		data.Placement = []*cloudformationLaunchTemplatePlacement{
+			{
+				Affinity:             e.HostAffinity,
+				HostResourceGroupARN: e.HostResourceGroupARN,
+				Tenancy:              e.Tenancy,
+			},
+		}
 	}
 	if e.InstanceMonitoring != nil {
 		data.Monitoring = &cloudformationLaunchTemplateMonitoring{
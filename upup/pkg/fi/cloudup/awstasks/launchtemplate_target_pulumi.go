@@ -0,0 +1,361 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awstasks
+
+import (
+	"strconv"
+
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	"k8s.io/kops/upup/pkg/fi/cloudup/pulumi"
+)
+
+type pulumiLaunchTemplateNetworkInterface struct {
+	// AssociatePublicIpAddress associates a public ip address with the network interface.
+	AssociatePublicIpAddress *string `json:"associatePublicIpAddress,omitempty"`
+	// DeleteOnTermination indicates whether the network interface should be destroyed on instance termination.
+	DeleteOnTermination *string `json:"deleteOnTermination,omitempty"`
+	// DeviceIndex is the device index for the network interface attachment.
+	DeviceIndex *int64 `json:"deviceIndex,omitempty"`
+	// InterfaceType is the type of the network interface, e.g. "efa".
+	InterfaceType *string `json:"interfaceType,omitempty"`
+	// Ipv6AddressCount is the number of IPv6 addresses to assign with the primary network interface.
+	Ipv6AddressCount *int64 `json:"ipv6AddressCount,omitempty"`
+	// NetworkCardIndex is the index of the network card on which the network interface is attached.
+	NetworkCardIndex *int64 `json:"networkCardIndex,omitempty"`
+	// SecurityGroups is a list of security group ids.
+	SecurityGroups []*pulumi.Literal `json:"securityGroups,omitempty"`
+}
+
+type pulumiLaunchTemplateMonitoring struct {
+	// Enabled indicates that monitoring is enabled
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+type pulumiLaunchTemplatePlacement struct {
+	// Affinity is the affinity setting for an instance on a Dedicated Host.
+	Affinity *string `json:"affinity,omitempty"`
+	// HostResourceGroupArn is the ARN of the host resource group in which to launch the instance.
+	HostResourceGroupArn *string `json:"hostResourceGroupArn,omitempty"`
+	// Tenancy is the tenancy of the instance. Can be default, dedicated, or host.
+	Tenancy *string `json:"tenancy,omitempty"`
+}
+
+type pulumiLaunchTemplateIAMProfile struct {
+	// Name is the name of the profile
+	Name *pulumi.Literal `json:"name,omitempty"`
+}
+
+type pulumiLaunchTemplateMarketOptionsSpotOptions struct {
+	// BlockDurationMinutes is required duration in minutes. This value must be a multiple of 60.
+	BlockDurationMinutes *int64 `json:"blockDurationMinutes,omitempty"`
+	// InstanceInterruptionBehavior is the behavior when a Spot Instance is interrupted. Can be hibernate, stop, or terminate
+	InstanceInterruptionBehavior *string `json:"instanceInterruptionBehavior,omitempty"`
+	// MaxPrice is the maximum hourly price you're willing to pay for the Spot Instances
+	MaxPrice *string `json:"maxPrice,omitempty"`
+}
+
+type pulumiLaunchTemplateMarketOptions struct {
+	// MarketType is the option type
+	MarketType *string `json:"marketType,omitempty"`
+	// SpotOptions are the set of options
+	SpotOptions *pulumiLaunchTemplateMarketOptionsSpotOptions `json:"spotOptions,omitempty"`
+}
+
+type pulumiLaunchTemplateBlockDeviceEBS struct {
+	// VolumeType is the ebs type to use
+	VolumeType *string `json:"volumeType,omitempty"`
+	// VolumeSize is the volume size
+	VolumeSize *int64 `json:"volumeSize,omitempty"`
+	// Iops is the provisioned IOPS
+	Iops *int64 `json:"iops,omitempty"`
+	// Throughput is the gp3 volume throughput
+	Throughput *int64 `json:"throughput,omitempty"`
+	// DeleteOnTermination indicates the volume should die with the instance
+	DeleteOnTermination *string `json:"deleteOnTermination,omitempty"`
+	// Encrypted indicates the device should be encrypted
+	Encrypted *string `json:"encrypted,omitempty"`
+	// KmsKeyId is the encryption key identifier for the volume
+	KmsKeyId *string `json:"kmsKeyId,omitempty"`
+}
+
+type pulumiLaunchTemplateBlockDevice struct {
+	// DeviceName is the name of the device
+	DeviceName *string `json:"deviceName,omitempty"`
+	// VirtualName is used for the ephemeral devices
+	VirtualName *string `json:"virtualName,omitempty"`
+	// Ebs defines the ebs spec
+	Ebs *pulumiLaunchTemplateBlockDeviceEBS `json:"ebs,omitempty"`
+}
+
+type pulumiLaunchTemplateCreditSpecification struct {
+	CpuCredits *string `json:"cpuCredits,omitempty"`
+}
+
+type pulumiLaunchTemplateElasticInferenceAccelerator struct {
+	// Type is the type of the accelerator.
+	Type *string `json:"type,omitempty"`
+}
+
+type pulumiLaunchTemplateEnclaveOptions struct {
+	// Enabled enables Nitro Enclaves on the instances.
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+type pulumiLaunchTemplateTagSpecification struct {
+	// ResourceType is the type of resource to tag.
+	ResourceType *string `json:"resourceType,omitempty"`
+	// Tags are the tags to apply to the resource.
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+type pulumiLaunchTemplateInstanceMetadata struct {
+	// HttpEndpoint enables or disables the HTTP metadata endpoint on instances.
+	HttpEndpoint *string `json:"httpEndpoint,omitempty"`
+	// HttpPutResponseHopLimit is the desired HTTP PUT response hop limit for instance metadata requests.
+	HttpPutResponseHopLimit *int64 `json:"httpPutResponseHopLimit,omitempty"`
+	// HttpTokens is the state of token usage for your instance metadata requests.
+	HttpTokens *string `json:"httpTokens,omitempty"`
+	// HttpProtocolIpv6 enables the IPv6 instance metadata endpoint
+	HttpProtocolIpv6 *string `json:"httpProtocolIpv6,omitempty"`
+}
+
+type pulumiLaunchTemplate struct {
+	// Name is the name of the launch template
+	Name *string `json:"name,omitempty"`
+
+	// BlockDeviceMappings is the device mappings
+	BlockDeviceMappings []*pulumiLaunchTemplateBlockDevice `json:"blockDeviceMappings,omitempty"`
+	// CreditSpecification is the credit option for CPU Usage on some instance types
+	CreditSpecification *pulumiLaunchTemplateCreditSpecification `json:"creditSpecification,omitempty"`
+	// EbsOptimized indicates if the root device is ebs optimized
+	EbsOptimized *string `json:"ebsOptimized,omitempty"`
+	// ElasticInferenceAccelerator is the Elastic Inference accelerator attached to the instances
+	ElasticInferenceAccelerator *pulumiLaunchTemplateElasticInferenceAccelerator `json:"elasticInferenceAccelerator,omitempty"`
+	// EnclaveOptions are the Nitro Enclaves options
+	EnclaveOptions *pulumiLaunchTemplateEnclaveOptions `json:"enclaveOptions,omitempty"`
+	// IamInstanceProfile is the IAM profile to assign to the nodes
+	IamInstanceProfile *pulumiLaunchTemplateIAMProfile `json:"iamInstanceProfile,omitempty"`
+	// ImageId is the ami to use for the instances
+	ImageId *string `json:"imageId,omitempty"`
+	// InstanceType is the type of instance
+	InstanceType *string `json:"instanceType,omitempty"`
+	// KeyName is the ssh key to use
+	KeyName *pulumi.Literal `json:"keyName,omitempty"`
+	// InstanceMarketOptions are the spot pricing options
+	InstanceMarketOptions *pulumiLaunchTemplateMarketOptions `json:"instanceMarketOptions,omitempty"`
+	// MetadataOptions are the instance metadata options.
+	MetadataOptions *pulumiLaunchTemplateInstanceMetadata `json:"metadataOptions,omitempty"`
+	// Monitoring are the instance monitoring options
+	Monitoring *pulumiLaunchTemplateMonitoring `json:"monitoring,omitempty"`
+	// NetworkInterfaces are the networking options
+	NetworkInterfaces []*pulumiLaunchTemplateNetworkInterface `json:"networkInterfaces,omitempty"`
+	// Placement are the tenancy options
+	Placement *pulumiLaunchTemplatePlacement `json:"placement,omitempty"`
+	// Tags is a map of tags applied to the launch template itself
+	Tags map[string]string `json:"tags,omitempty"`
+	// TagSpecifications are the tags to apply to a resource when it is created.
+	TagSpecifications []*pulumiLaunchTemplateTagSpecification `json:"tagSpecifications,omitempty"`
+	// UserData is the user data for the instances
+	UserData *pulumi.Literal `json:"userData,omitempty"`
+}
+
+// pulumiBoolString renders a bool for the launch template properties that the provider models as strings
+func pulumiBoolString(b *bool) *string {
+	if b == nil {
+		return nil
+	}
+	return fi.String(strconv.FormatBool(*b))
+}
+
+// PulumiLink returns the pulumi reference
+func (t *LaunchTemplate) PulumiLink() *pulumi.Literal {
+	return pulumi.LiteralProperty("aws:ec2:LaunchTemplate", fi.StringValue(t.Name), "id")
+}
+
+// PulumiVersionLink returns the pulumi version reference
+func (t *LaunchTemplate) PulumiVersionLink() *pulumi.Literal {
+	return pulumi.LiteralProperty("aws:ec2:LaunchTemplate", fi.StringValue(t.Name), "latestVersion")
+}
+
+// RenderPulumi is responsible for rendering the pulumi program
+func (t *LaunchTemplate) RenderPulumi(target *pulumi.PulumiTarget, a, e, changes *LaunchTemplate) error {
+	var err error
+
+	cloud := target.Cloud.(awsup.AWSCloud)
+
+	var image *string
+	if e.ImageID != nil {
+		im, err := cloud.ResolveImage(fi.StringValue(e.ImageID))
+		if err != nil {
+			return err
+		}
+		image = im.ImageId
+	}
+
+	p := pulumiLaunchTemplate{
+		Name:         e.Name,
+		EbsOptimized: pulumiBoolString(e.RootVolumeOptimization),
+		ImageId:      image,
+		InstanceType: e.InstanceType,
+		MetadataOptions: &pulumiLaunchTemplateInstanceMetadata{
+			HttpEndpoint:            fi.String("enabled"),
+			HttpTokens:              e.HTTPTokens,
+			HttpPutResponseHopLimit: e.HTTPPutResponseHopLimit,
+			HttpProtocolIpv6:        e.HTTPProtocolIPv6,
+		},
+		NetworkInterfaces: []*pulumiLaunchTemplateNetworkInterface{
+			{
+				AssociatePublicIpAddress: pulumiBoolString(e.AssociatePublicIP),
+				DeleteOnTermination:      pulumiBoolString(fi.Bool(true)),
+				InterfaceType:            e.InterfaceType,
+				Ipv6AddressCount:         e.IPv6AddressCount,
+			},
+		},
+	}
+	for _, x := range e.AdditionalNetworkInterfaces {
+		p.NetworkInterfaces = append(p.NetworkInterfaces, &pulumiLaunchTemplateNetworkInterface{
+			DeleteOnTermination: pulumiBoolString(fi.Bool(true)),
+			DeviceIndex:         x.DeviceIndex,
+			InterfaceType:       x.InterfaceType,
+			NetworkCardIndex:    x.NetworkCardIndex,
+		})
+	}
+
+	if fi.StringValue(e.SpotPrice) != "" {
+		marketSpotOptions := pulumiLaunchTemplateMarketOptionsSpotOptions{MaxPrice: e.SpotPrice}
+		if e.SpotDurationInMinutes != nil {
+			marketSpotOptions.BlockDurationMinutes = e.SpotDurationInMinutes
+		}
+		if e.InstanceInterruptionBehavior != nil {
+			marketSpotOptions.InstanceInterruptionBehavior = e.InstanceInterruptionBehavior
+		}
+		p.InstanceMarketOptions = &pulumiLaunchTemplateMarketOptions{
+			MarketType:  fi.String("spot"),
+			SpotOptions: &marketSpotOptions,
+		}
+	}
+	if fi.StringValue(e.CPUCredits) != "" {
+		p.CreditSpecification = &pulumiLaunchTemplateCreditSpecification{
+			CpuCredits: e.CPUCredits,
+		}
+	}
+	acceleratorType, err := e.singleElasticInferenceAccelerator()
+	if err != nil {
+		return err
+	}
+	if acceleratorType != nil {
+		p.ElasticInferenceAccelerator = &pulumiLaunchTemplateElasticInferenceAccelerator{Type: acceleratorType}
+	}
+	if e.EnclaveEnabled != nil {
+		p.EnclaveOptions = &pulumiLaunchTemplateEnclaveOptions{Enabled: e.EnclaveEnabled}
+	}
+	for _, x := range e.SecurityGroups {
+		for _, ni := range p.NetworkInterfaces {
+			ni.SecurityGroups = append(ni.SecurityGroups, x.PulumiLink())
+		}
+	}
+	if e.SSHKey != nil {
+		p.KeyName = e.SSHKey.PulumiLink()
+	}
+	if e.Tenancy != nil {
+		p.Placement = &pulumiLaunchTemplatePlacement{
+			Affinity:             e.HostAffinity,
+			HostResourceGroupArn: e.HostResourceGroupARN,
+			Tenancy:              e.Tenancy,
+		}
+	}
+	if e.InstanceMonitoring != nil {
+		p.Monitoring = &pulumiLaunchTemplateMonitoring{Enabled: e.InstanceMonitoring}
+	}
+	if e.IAMInstanceProfile != nil {
+		p.IamInstanceProfile = &pulumiLaunchTemplateIAMProfile{Name: e.IAMInstanceProfile.PulumiLink()}
+	}
+	if e.UserData != nil {
+		d, err := fi.ResourceAsBytes(e.UserData)
+		if err != nil {
+			return err
+		}
+		if d != nil {
+			p.UserData, err = target.AddFileBytes("aws:ec2:LaunchTemplate", fi.StringValue(e.Name), "userData", d, true)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	devices, err := e.buildRootDevice(cloud)
+	if err != nil {
+		return err
+	}
+	for n, x := range devices {
+		p.BlockDeviceMappings = append(p.BlockDeviceMappings, &pulumiLaunchTemplateBlockDevice{
+			DeviceName: fi.String(n),
+			Ebs: &pulumiLaunchTemplateBlockDeviceEBS{
+				DeleteOnTermination: pulumiBoolString(fi.Bool(true)),
+				Encrypted:           pulumiBoolString(x.EbsEncrypted),
+				KmsKeyId:            x.EbsKmsKey,
+				Iops:                x.EbsVolumeIops,
+				Throughput:          x.EbsVolumeThroughput,
+				VolumeSize:          x.EbsVolumeSize,
+				VolumeType:          x.EbsVolumeType,
+			},
+		})
+	}
+	additionals, err := buildAdditionalDevices(e.BlockDeviceMappings)
+	if err != nil {
+		return err
+	}
+	for n, x := range additionals {
+		p.BlockDeviceMappings = append(p.BlockDeviceMappings, &pulumiLaunchTemplateBlockDevice{
+			DeviceName: fi.String(n),
+			Ebs: &pulumiLaunchTemplateBlockDeviceEBS{
+				DeleteOnTermination: pulumiBoolString(fi.Bool(true)),
+				Encrypted:           pulumiBoolString(x.EbsEncrypted),
+				Iops:                x.EbsVolumeIops,
+				Throughput:          x.EbsVolumeThroughput,
+				KmsKeyId:            x.EbsKmsKey,
+				VolumeSize:          x.EbsVolumeSize,
+				VolumeType:          x.EbsVolumeType,
+			},
+		})
+	}
+
+	devices, err = buildEphemeralDevices(cloud, fi.StringValue(e.InstanceType))
+	if err != nil {
+		return err
+	}
+	for n, x := range devices {
+		p.BlockDeviceMappings = append(p.BlockDeviceMappings, &pulumiLaunchTemplateBlockDevice{
+			VirtualName: x.VirtualName,
+			DeviceName:  fi.String(n),
+		})
+	}
+
+	if e.Tags != nil {
+		p.TagSpecifications = append(p.TagSpecifications, &pulumiLaunchTemplateTagSpecification{
+			ResourceType: fi.String("instance"),
+			Tags:         e.Tags,
+		})
+		p.TagSpecifications = append(p.TagSpecifications, &pulumiLaunchTemplateTagSpecification{
+			ResourceType: fi.String("volume"),
+			Tags:         e.Tags,
+		})
+		p.Tags = e.Tags
+	}
+
+	return target.RenderResource("aws:ec2:LaunchTemplate", fi.StringValue(e.Name), p)
+}
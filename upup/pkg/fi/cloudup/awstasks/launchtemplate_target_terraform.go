@@ -28,8 +28,14 @@ type terraformLaunchTemplateNetworkInterface struct {
 	AssociatePublicIPAddress *bool `cty:"associate_public_ip_address"`
 	// DeleteOnTermination indicates whether the network interface should be destroyed on instance termination.
 	DeleteOnTermination *bool `cty:"delete_on_termination"`
+	// DeviceIndex is the device index for the network interface attachment.
+	DeviceIndex *int64 `cty:"device_index"`
+	// InterfaceType is the type of the network interface, e.g. "efa".
+	InterfaceType *string `cty:"interface_type"`
 	// Ipv6AddressCount is the number of IPv6 addresses to assign with the primary network interface.
 	Ipv6AddressCount *int64 `cty:"ipv6_address_count"`
+	// NetworkCardIndex is the index of the network card on which the network interface is attached.
+	NetworkCardIndex *int64 `cty:"network_card_index"`
 	// SecurityGroups is a list of security group ids.
 	SecurityGroups []*terraformWriter.Literal `cty:"security_groups"`
 }
@@ -48,6 +54,8 @@ type terraformLaunchTemplatePlacement struct {
 	GroupName *string `cty:"group_name"`
 	// HostID is the ID of the Dedicated Host for the instance.
 	HostID *string `cty:"host_id"`
+	// HostResourceGroupARN is the ARN of the host resource group in which to launch the instance.
+	HostResourceGroupARN *string `cty:"host_resource_group_arn"`
 	// SpreadDomain are reserved for future use.
 	SpreadDomain *string `cty:"spread_domain"`
 	// Tenancy ist he tenancy of the instance. Can be default, dedicated, or host.
@@ -109,6 +117,16 @@ type terraformLaunchTemplateCreditSpecification struct {
 	CPUCredits *string `cty:"cpu_credits"`
 }
 
+type terraformLaunchTemplateElasticInferenceAccelerator struct {
+	// Type is the type of the accelerator.
+	Type *string `cty:"type"`
+}
+
+type terraformLaunchTemplateEnclaveOptions struct {
+	// Enabled enables Nitro Enclaves on the instances.
+	Enabled *bool `cty:"enabled"`
+}
+
 type terraformLaunchTemplateTagSpecification struct {
 	// ResourceType is the type of resource to tag.
 	ResourceType *string `cty:"resource_type"`
@@ -139,6 +157,10 @@ type terraformLaunchTemplate struct {
 	CreditSpecification *terraformLaunchTemplateCreditSpecification `cty:"credit_specification"`
 	// EBSOptimized indicates if the root device is ebs optimized
 	EBSOptimized *bool `cty:"ebs_optimized"`
+	// ElasticInferenceAccelerator is the Elastic Inference accelerator attached to the instances
+	ElasticInferenceAccelerator *terraformLaunchTemplateElasticInferenceAccelerator `cty:"elastic_inference_accelerator"`
+	// EnclaveOptions are the Nitro Enclaves options
+	EnclaveOptions *terraformLaunchTemplateEnclaveOptions `cty:"enclave_options"`
 	// IAMInstanceProfile is the IAM profile to assign to the nodes
 	IAMInstanceProfile []*terraformLaunchTemplateIAMProfile `cty:"iam_instance_profile"`
 	// ImageID is the ami to use for the instances
@@ -207,10 +229,19 @@ func (t *LaunchTemplate) RenderTerraform(target *terraform.TerraformTarget, a, e
 			{
 				AssociatePublicIPAddress: e.AssociatePublicIP,
 				DeleteOnTermination:      fi.Bool(true),
+				InterfaceType:            e.InterfaceType,
 				Ipv6AddressCount:         e.IPv6AddressCount,
 			},
 		},
 	}
+	for _, x := range e.AdditionalNetworkInterfaces {
+		tf.NetworkInterfaces = append(tf.NetworkInterfaces, &terraformLaunchTemplateNetworkInterface{
+			DeleteOnTermination: fi.Bool(true),
+			DeviceIndex:         x.DeviceIndex,
+			InterfaceType:       x.InterfaceType,
+			NetworkCardIndex:    x.NetworkCardIndex,
+		})
+	}
 
 	if fi.StringValue(e.SpotPrice) != "" {
 		marketSpotOptions := terraformLaunchTemplateMarketOptionsSpotOptions{MaxPrice: e.SpotPrice}
@@ -232,14 +263,36 @@ func (t *LaunchTemplate) RenderTerraform(target *terraform.TerraformTarget, a, e
 			CPUCredits: e.CPUCredits,
 		}
 	}
+	acceleratorType, err := e.singleElasticInferenceAccelerator()
+	if err != nil {
+		return err
+	}
+	if acceleratorType != nil {
+		tf.ElasticInferenceAccelerator = &terraformLaunchTemplateElasticInferenceAccelerator{
+			Type: acceleratorType,
+		}
+	}
+	if e.EnclaveEnabled != nil {
+		tf.EnclaveOptions = &terraformLaunchTemplateEnclaveOptions{
+			Enabled: e.EnclaveEnabled,
+		}
+	}
 	for _, x := range e.SecurityGroups {
-		tf.NetworkInterfaces[0].SecurityGroups = append(tf.NetworkInterfaces[0].SecurityGroups, x.TerraformLink())
+		for _, ni := range tf.NetworkInterfaces {
+			ni.SecurityGroups = append(ni.SecurityGroups, x.TerraformLink())
+		}
 	}
 	if e.SSHKey != nil {
 		tf.KeyName = e.SSHKey.TerraformLink()
 	}
 	if e.Tenancy != nil {
-		tf.Placement = []*terraformLaunchTemplatePlacement{{Tenancy: e.Tenancy}}
+		tf.Placement = []*terraformLaunchTemplatePlacement{
+			{
+				Affinity:             e.HostAffinity,
+				HostResourceGroupARN: e.HostResourceGroupARN,
+				Tenancy:              e.Tenancy,
+			},
+		}
 	}
 	if e.InstanceMonitoring != nil {
 		tf.Monitoring = []*terraformLaunchTemplateMonitoring{
@@ -326,6 +379,9 @@ func (t *LaunchTemplate) RenderTerraform(target *terraform.TerraformTarget, a, e
 		})
 		tf.Tags = e.Tags
 	}
+	if e.SpecHash != nil {
+		tf.Tags = e.launchTemplateTags()
+	}
 
 	return target.RenderResource("aws_launch_template", fi.StringValue(e.Name), tf)
 }
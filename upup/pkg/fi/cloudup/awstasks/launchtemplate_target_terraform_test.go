@@ -180,6 +180,150 @@ resource "aws_launch_template" "test" {
   }
 }
 
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "configuration_aliases" = [aws.files]
+      "source"                = "hashicorp/aws"
+      "version"               = ">= 4.0.0"
+    }
+  }
+}
+`,
+		},
+		{
+			Resource: &LaunchTemplate{
+				Name:              fi.String("test"),
+				AssociatePublicIP: fi.Bool(false),
+				ID:                fi.String("test-11"),
+				InstanceType:      fi.String("p4d.24xlarge"),
+				InterfaceType:     fi.String("efa"),
+				AdditionalNetworkInterfaces: []*LaunchTemplateNetworkInterface{
+					{DeviceIndex: fi.Int64(1), NetworkCardIndex: fi.Int64(1), InterfaceType: fi.String("efa")},
+				},
+				SecurityGroups: []*SecurityGroup{
+					{Name: fi.String("nodes-1"), ID: fi.String("1111")},
+				},
+			},
+			Expected: `provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_launch_template" "test" {
+  instance_type = "p4d.24xlarge"
+  lifecycle {
+    create_before_destroy = true
+  }
+  metadata_options {
+    http_endpoint = "enabled"
+  }
+  name = "test"
+  network_interfaces {
+    associate_public_ip_address = false
+    delete_on_termination       = true
+    interface_type              = "efa"
+    security_groups             = [aws_security_group.nodes-1.id]
+  }
+  network_interfaces {
+    delete_on_termination = true
+    device_index          = 1
+    interface_type        = "efa"
+    network_card_index    = 1
+    security_groups       = [aws_security_group.nodes-1.id]
+  }
+}
+
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "configuration_aliases" = [aws.files]
+      "source"                = "hashicorp/aws"
+      "version"               = ">= 4.0.0"
+    }
+  }
+}
+`,
+		},
+		{
+			Resource: &LaunchTemplate{
+				Name:                 fi.String("test"),
+				ID:                   fi.String("test-11"),
+				InstanceType:         fi.String("m5.large"),
+				Tenancy:              fi.String("host"),
+				HostAffinity:         fi.String("host"),
+				HostResourceGroupARN: fi.String("arn:aws:resource-groups:eu-west-2:123456789012:group/byol-hosts"),
+			},
+			Expected: `provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_launch_template" "test" {
+  instance_type = "m5.large"
+  lifecycle {
+    create_before_destroy = true
+  }
+  metadata_options {
+    http_endpoint = "enabled"
+  }
+  name = "test"
+  network_interfaces {
+    delete_on_termination = true
+  }
+  placement {
+    affinity                = "host"
+    host_resource_group_arn = "arn:aws:resource-groups:eu-west-2:123456789012:group/byol-hosts"
+    tenancy                 = "host"
+  }
+}
+
+terraform {
+  required_version = ">= 0.15.0"
+  required_providers {
+    aws = {
+      "configuration_aliases" = [aws.files]
+      "source"                = "hashicorp/aws"
+      "version"               = ">= 4.0.0"
+    }
+  }
+}
+`,
+		},
+		{
+			Resource: &LaunchTemplate{
+				Name:         fi.String("test"),
+				ID:           fi.String("test-11"),
+				InstanceType: fi.String("m5.xlarge"),
+				ElasticInferenceAccelerators: []*LaunchTemplateElasticInferenceAccelerator{
+					{Type: fi.String("eia2.medium"), Count: fi.Int64(1)},
+				},
+				EnclaveEnabled: fi.Bool(true),
+			},
+			Expected: `provider "aws" {
+  region = "eu-west-2"
+}
+
+resource "aws_launch_template" "test" {
+  elastic_inference_accelerator {
+    type = "eia2.medium"
+  }
+  enclave_options {
+    enabled = true
+  }
+  instance_type = "m5.xlarge"
+  lifecycle {
+    create_before_destroy = true
+  }
+  metadata_options {
+    http_endpoint = "enabled"
+  }
+  name = "test"
+  network_interfaces {
+    delete_on_termination = true
+  }
+}
+
 terraform {
   required_version = ">= 0.15.0"
   required_providers {
@@ -30,6 +30,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/cloudformation"
+	"k8s.io/kops/upup/pkg/fi/cloudup/pulumi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
@@ -802,6 +803,115 @@ func (e *NetworkLoadBalancer) TerraformLink(params ...string) *terraformWriter.L
 	return terraformWriter.LiteralProperty("aws_lb", *e.Name, prop)
 }
 
+type pulumiNetworkLoadBalancer struct {
+	Name                         string                                   `json:"name"`
+	Internal                     bool                                     `json:"internal"`
+	LoadBalancerType             string                                   `json:"loadBalancerType"`
+	SubnetMappings               []pulumiNetworkLoadBalancerSubnetMapping `json:"subnetMappings,omitempty"`
+	EnableCrossZoneLoadBalancing bool                                     `json:"enableCrossZoneLoadBalancing"`
+	AccessLogs                   *pulumiNetworkLoadBalancerAccessLog      `json:"accessLogs,omitempty"`
+
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+type pulumiNetworkLoadBalancerSubnetMapping struct {
+	SubnetId           *pulumi.Literal `json:"subnetId"`
+	AllocationId       *string         `json:"allocationId,omitempty"`
+	PrivateIpv4Address *string         `json:"privateIpv4Address,omitempty"`
+}
+
+type pulumiNetworkLoadBalancerListener struct {
+	LoadBalancerArn *pulumi.Literal                           `json:"loadBalancerArn"`
+	Port            int64                                     `json:"port"`
+	Protocol        string                                    `json:"protocol"`
+	CertificateArn  *string                                   `json:"certificateArn,omitempty"`
+	SslPolicy       *string                                   `json:"sslPolicy,omitempty"`
+	DefaultActions  []pulumiNetworkLoadBalancerListenerAction `json:"defaultActions"`
+}
+
+type pulumiNetworkLoadBalancerListenerAction struct {
+	Type           string          `json:"type"`
+	TargetGroupArn *pulumi.Literal `json:"targetGroupArn"`
+}
+
+func (_ *NetworkLoadBalancer) RenderPulumi(t *pulumi.PulumiTarget, a, e, changes *NetworkLoadBalancer) error {
+	nlb := &pulumiNetworkLoadBalancer{
+		Name:                         *e.LoadBalancerName,
+		Internal:                     fi.StringValue(e.Scheme) == elbv2.LoadBalancerSchemeEnumInternal,
+		LoadBalancerType:             elbv2.LoadBalancerTypeEnumNetwork,
+		Tags:                         e.Tags,
+		EnableCrossZoneLoadBalancing: fi.BoolValue(e.CrossZoneLoadBalancing),
+	}
+
+	for _, subnetMapping := range e.SubnetMappings {
+		nlb.SubnetMappings = append(nlb.SubnetMappings, pulumiNetworkLoadBalancerSubnetMapping{
+			SubnetId:           subnetMapping.Subnet.PulumiLink(),
+			AllocationId:       subnetMapping.AllocationID,
+			PrivateIpv4Address: subnetMapping.PrivateIPv4Address,
+		})
+	}
+
+	if e.AccessLog != nil && fi.BoolValue(e.AccessLog.Enabled) {
+		nlb.AccessLogs = &pulumiNetworkLoadBalancerAccessLog{
+			Enabled: e.AccessLog.Enabled,
+			Bucket:  e.AccessLog.S3BucketName,
+			Prefix:  e.AccessLog.S3BucketPrefix,
+		}
+	}
+
+	err := t.RenderResource("aws:lb:LoadBalancer", *e.Name, nlb)
+	if err != nil {
+		return err
+	}
+
+	for _, listener := range e.Listeners {
+		var listenerTG *TargetGroup
+		for _, tg := range e.TargetGroups {
+			if aws.StringValue(tg.Name) == listener.TargetGroupName {
+				listenerTG = tg
+				break
+			}
+		}
+		if listenerTG == nil {
+			return fmt.Errorf("target group not found for NLB listener %+v", e)
+		}
+		p := &pulumiNetworkLoadBalancerListener{
+			LoadBalancerArn: e.PulumiLink("arn"),
+			Port:            int64(listener.Port),
+			DefaultActions: []pulumiNetworkLoadBalancerListenerAction{
+				{
+					Type:           elbv2.ActionTypeEnumForward,
+					TargetGroupArn: listenerTG.PulumiLink(),
+				},
+			},
+		}
+		if listener.SSLCertificateID != "" {
+			p.CertificateArn = &listener.SSLCertificateID
+			p.Protocol = elbv2.ProtocolEnumTls
+			if listener.SSLPolicy != "" {
+				p.SslPolicy = &listener.SSLPolicy
+			}
+		} else {
+			p.Protocol = elbv2.ProtocolEnumTcp
+		}
+
+		err = t.RenderResource("aws:lb:Listener", fmt.Sprintf("%v-%v", *e.Name, listener.Port), p)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *NetworkLoadBalancer) PulumiLink(params ...string) *pulumi.Literal {
+	prop := "id"
+	if len(params) > 0 {
+		prop = params[0]
+	}
+	return pulumi.LiteralProperty("aws:lb:LoadBalancer", *e.Name, prop)
+}
+
 type cloudformationNetworkLoadBalancer struct {
 	Name                   string                                `json:"Name"`
 	Scheme                 string                                `json:"Scheme"`
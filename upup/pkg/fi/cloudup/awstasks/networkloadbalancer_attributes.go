@@ -43,6 +43,12 @@ type terraformNetworkLoadBalancerAccessLog struct {
 	S3BucketPrefix *string `cty:"prefix"`
 }
 
+type pulumiNetworkLoadBalancerAccessLog struct {
+	Enabled *bool   `json:"enabled,omitempty"`
+	Bucket  *string `json:"bucket"`
+	Prefix  *string `json:"prefix,omitempty"`
+}
+
 func findNetworkLoadBalancerAttributes(cloud awsup.AWSCloud, LoadBalancerArn string) ([]*elbv2.LoadBalancerAttribute, error) {
 	request := &elbv2.DescribeLoadBalancerAttributesInput{
 		LoadBalancerArn: aws.String(LoadBalancerArn),
@@ -25,6 +25,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/cloudformation"
+	"k8s.io/kops/upup/pkg/fi/cloudup/pulumi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
@@ -361,3 +362,42 @@ func (_ *Route) RenderCloudformation(t *cloudformation.CloudformationTarget, a,
 
 	return t.RenderResource("AWS::EC2::Route", *e.Name, tf)
 }
+
+type pulumiRoute struct {
+	RouteTableId             *pulumi.Literal `json:"routeTableId"`
+	DestinationCidrBlock     *string         `json:"destinationCidrBlock,omitempty"`
+	DestinationIpv6CidrBlock *string         `json:"destinationIpv6CidrBlock,omitempty"`
+	EgressOnlyGatewayId      *pulumi.Literal `json:"egressOnlyGatewayId,omitempty"`
+	GatewayId                *pulumi.Literal `json:"gatewayId,omitempty"`
+	NatGatewayId             *pulumi.Literal `json:"natGatewayId,omitempty"`
+	TransitGatewayId         *string         `json:"transitGatewayId,omitempty"`
+	VpcPeeringConnectionId   *string         `json:"vpcPeeringConnectionId,omitempty"`
+}
+
+func (_ *Route) RenderPulumi(t *pulumi.PulumiTarget, a, e, changes *Route) error {
+	p := &pulumiRoute{
+		RouteTableId:             e.RouteTable.PulumiLink(),
+		DestinationCidrBlock:     e.CIDR,
+		DestinationIpv6CidrBlock: e.IPv6CIDR,
+	}
+
+	if e.EgressOnlyInternetGateway == nil && e.InternetGateway == nil && e.NatGateway == nil && e.TransitGatewayID == nil && e.VPCPeeringConnectionID == nil {
+		return fmt.Errorf("missing target for route")
+	} else if e.EgressOnlyInternetGateway != nil {
+		p.EgressOnlyGatewayId = e.EgressOnlyInternetGateway.PulumiLink()
+	} else if e.InternetGateway != nil {
+		p.GatewayId = e.InternetGateway.PulumiLink()
+	} else if e.NatGateway != nil {
+		p.NatGatewayId = e.NatGateway.PulumiLink()
+	} else if e.TransitGatewayID != nil {
+		p.TransitGatewayId = e.TransitGatewayID
+	} else if e.VPCPeeringConnectionID != nil {
+		p.VpcPeeringConnectionId = e.VPCPeeringConnectionID
+	}
+
+	if e.Instance != nil {
+		return fmt.Errorf("instance pulumi routes not yet implemented")
+	}
+
+	return t.RenderResource("aws:ec2:Route", *e.Name, p)
+}
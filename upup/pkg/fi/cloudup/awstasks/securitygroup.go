@@ -27,6 +27,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/cloudformation"
+	"k8s.io/kops/upup/pkg/fi/cloudup/pulumi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
@@ -214,7 +215,7 @@ func (e *SecurityGroup) TerraformLink() *terraformWriter.Literal {
 	if shared {
 		// Not terraform owned / managed
 		if e.ID != nil {
-			return terraformWriter.LiteralFromStringValue(*e.ID)
+			return terraformWriter.LiteralFromSharedResource("aws_security_group", *e.Name, *e.ID)
 		} else {
 			klog.Warningf("ID not set on shared subnet %v", e)
 		}
@@ -261,6 +262,44 @@ func (e *SecurityGroup) CloudformationLink() *cloudformation.Literal {
 	return cloudformation.Ref("AWS::EC2::SecurityGroup", *e.Name)
 }
 
+type pulumiSecurityGroup struct {
+	Name        *string           `json:"name"`
+	VpcId       *pulumi.Literal   `json:"vpcId"`
+	Description *string           `json:"description,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+}
+
+func (_ *SecurityGroup) RenderPulumi(t *pulumi.PulumiTarget, a, e, changes *SecurityGroup) error {
+	shared := fi.BoolValue(e.Shared)
+	if shared {
+		// Not pulumi owned / managed
+		return nil
+	}
+
+	p := &pulumiSecurityGroup{
+		Name:        e.Name,
+		VpcId:       e.VPC.PulumiLink(),
+		Description: e.Description,
+		Tags:        e.Tags,
+	}
+
+	return t.RenderResource("aws:ec2:SecurityGroup", *e.Name, p)
+}
+
+func (e *SecurityGroup) PulumiLink() *pulumi.Literal {
+	shared := fi.BoolValue(e.Shared)
+	if shared {
+		// Not pulumi owned / managed
+		if e.ID != nil {
+			return pulumi.LiteralFromStringValue(*e.ID)
+		} else {
+			klog.Warningf("ID not set on shared subnet %v", e)
+		}
+	}
+
+	return pulumi.LiteralProperty("aws:ec2:SecurityGroup", *e.Name, "id")
+}
+
 type deleteSecurityGroupRule struct {
 	rule *ec2.SecurityGroupRule
 }
@@ -27,6 +27,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/cloudformation"
+	"k8s.io/kops/upup/pkg/fi/cloudup/pulumi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 	"k8s.io/kops/upup/pkg/fi/utils"
@@ -455,3 +456,59 @@ func (_ *SecurityGroupRule) RenderCloudformation(t *cloudformation.Cloudformatio
 
 	return t.RenderResource(cfType, *e.Name, tf)
 }
+
+type pulumiSecurityGroupRule struct {
+	Type                  *string         `json:"type"`
+	SecurityGroupId       *pulumi.Literal `json:"securityGroupId"`
+	SourceSecurityGroupId *pulumi.Literal `json:"sourceSecurityGroupId,omitempty"`
+
+	FromPort *int64 `json:"fromPort"`
+	ToPort   *int64 `json:"toPort"`
+
+	Protocol       *string  `json:"protocol"`
+	CidrBlocks     []string `json:"cidrBlocks,omitempty"`
+	Ipv6CidrBlocks []string `json:"ipv6CidrBlocks,omitempty"`
+	PrefixListIds  []string `json:"prefixListIds,omitempty"`
+}
+
+func (_ *SecurityGroupRule) RenderPulumi(t *pulumi.PulumiTarget, a, e, changes *SecurityGroupRule) error {
+	p := &pulumiSecurityGroupRule{
+		Type:            fi.String("ingress"),
+		SecurityGroupId: e.SecurityGroup.PulumiLink(),
+		FromPort:        e.FromPort,
+		ToPort:          e.ToPort,
+		Protocol:        e.Protocol,
+	}
+	if fi.BoolValue(e.Egress) {
+		p.Type = fi.String("egress")
+	}
+
+	if e.Protocol == nil {
+		p.Protocol = fi.String("-1")
+		p.FromPort = fi.Int64(0)
+		p.ToPort = fi.Int64(0)
+	}
+
+	if p.FromPort == nil {
+		p.FromPort = fi.Int64(0)
+	}
+	if p.ToPort == nil {
+		p.ToPort = fi.Int64(65535)
+	}
+
+	if e.SourceGroup != nil {
+		p.SourceSecurityGroupId = e.SourceGroup.PulumiLink()
+	}
+
+	if e.CIDR != nil {
+		p.CidrBlocks = append(p.CidrBlocks, *e.CIDR)
+	}
+	if e.IPv6CIDR != nil {
+		p.Ipv6CidrBlocks = append(p.Ipv6CidrBlocks, *e.IPv6CIDR)
+	}
+	if e.PrefixList != nil {
+		p.PrefixListIds = append(p.PrefixListIds, *e.PrefixList)
+	}
+
+	return t.RenderResource("aws:ec2:SecurityGroupRule", *e.Name, p)
+}
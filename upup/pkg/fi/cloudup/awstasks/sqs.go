@@ -31,6 +31,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/cloudformation"
+	"k8s.io/kops/upup/pkg/fi/cloudup/pulumi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
 )
 
@@ -214,6 +215,33 @@ func (e *SQS) TerraformLink() *terraformWriter.Literal {
 	return terraformWriter.LiteralProperty("aws_sqs_queue", *e.Name, "arn")
 }
 
+type pulumiSQSQueue struct {
+	Name                    *string           `json:"name"`
+	MessageRetentionSeconds int               `json:"messageRetentionSeconds"`
+	Policy                  *pulumi.Literal   `json:"policy"`
+	Tags                    map[string]string `json:"tags,omitempty"`
+}
+
+func (_ *SQS) RenderPulumi(t *pulumi.PulumiTarget, a, e, changes *SQS) error {
+	policy, err := t.AddFileResource("aws:sqs:Queue", *e.Name, "policy", e.Policy, false)
+	if err != nil {
+		return err
+	}
+
+	p := &pulumiSQSQueue{
+		Name:                    e.Name,
+		MessageRetentionSeconds: e.MessageRetentionPeriod,
+		Policy:                  policy,
+		Tags:                    e.Tags,
+	}
+
+	return t.RenderResource("aws:sqs:Queue", *e.Name, p)
+}
+
+func (e *SQS) PulumiLink() *pulumi.Literal {
+	return pulumi.LiteralProperty("aws:sqs:Queue", *e.Name, "arn")
+}
+
 type cloudformationSQSQueue struct {
 	QueueName              *string             `json:"QueueName"`
 	MessageRetentionPeriod int                 `json:"MessageRetentionPeriod"`
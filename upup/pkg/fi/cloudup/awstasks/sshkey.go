@@ -29,6 +29,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/cloudformation"
+	"k8s.io/kops/upup/pkg/fi/cloudup/pulumi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
 )
 
@@ -232,6 +233,41 @@ func (e *SSHKey) TerraformLink() *terraformWriter.Literal {
 	return terraformWriter.LiteralProperty("aws_key_pair", tfName, "id")
 }
 
+type pulumiSSHKey struct {
+	KeyName   *string           `json:"keyName"`
+	PublicKey *pulumi.Literal   `json:"publicKey"`
+	Tags      map[string]string `json:"tags,omitempty"`
+}
+
+func (_ *SSHKey) RenderPulumi(t *pulumi.PulumiTarget, a, e, changes *SSHKey) error {
+	// We don't want to render a key definition when we're using one that already exists
+	if e.IsExistingKey() {
+		return nil
+	}
+	publicKey, err := t.AddFileResource("aws:ec2:KeyPair", *e.Name, "publicKey", e.PublicKey, false)
+	if err != nil {
+		return fmt.Errorf("error rendering PublicKey: %v", err)
+	}
+
+	p := &pulumiSSHKey{
+		KeyName:   e.Name,
+		PublicKey: publicKey,
+		Tags:      e.Tags,
+	}
+
+	return t.RenderResource("aws:ec2:KeyPair", *e.Name, p)
+}
+
+func (e *SSHKey) PulumiLink() *pulumi.Literal {
+	if e.NoSSHKey() {
+		return nil
+	}
+	if e.IsExistingKey() {
+		return pulumi.LiteralFromStringValue(*e.Name)
+	}
+	return pulumi.LiteralProperty("aws:ec2:KeyPair", *e.Name, "id")
+}
+
 func (_ *SSHKey) RenderCloudformation(t *cloudformation.CloudformationTarget, a, e, changes *SSHKey) error {
 	if e.NoSSHKey() {
 		return nil
@@ -27,6 +27,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/cloudformation"
+	"k8s.io/kops/upup/pkg/fi/cloudup/pulumi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 	"k8s.io/kops/upup/pkg/fi/utils"
@@ -50,7 +51,9 @@ type Subnet struct {
 	CIDR                *string
 	IPv6CIDR            *string
 	ResourceBasedNaming *bool
-	Shared              *bool
+	// DNS64 enables the synthesis of IPv6 addresses for IPv4-only destinations by the Amazon DNS server
+	DNS64  *bool
+	Shared *bool
 
 	Tags map[string]string
 }
@@ -114,6 +117,8 @@ func (e *Subnet) Find(c *fi.Context) (*Subnet, error) {
 		}
 	}
 
+	actual.DNS64 = fi.Bool(aws.BoolValue(subnet.EnableDns64))
+
 	klog.V(2).Infof("found matching subnet %q", *actual.ID)
 	e.ID = actual.ID
 
@@ -220,6 +225,7 @@ func (s *Subnet) CheckChanges(a, e, changes *Subnet) error {
 func (_ *Subnet) ShouldCreate(a, e, changes *Subnet) (bool, error) {
 	if fi.BoolValue(e.Shared) {
 		changes.ResourceBasedNaming = nil
+		changes.DNS64 = nil
 		return changes.Tags != nil, nil
 	}
 	return true, nil
@@ -303,16 +309,7 @@ func (_ *Subnet) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *Subnet) error {
 			return fmt.Errorf("error modifying hostname type: %w", err)
 		}
 
-		if fi.StringValue(e.CIDR) == "" {
-			request = &ec2.ModifySubnetAttributeInput{
-				SubnetId:    e.ID,
-				EnableDns64: &ec2.AttributeBooleanValue{Value: aws.Bool(true)},
-			}
-			_, err = t.Cloud.EC2().ModifySubnetAttribute(request)
-			if err != nil {
-				return fmt.Errorf("error enabling DNS64: %w", err)
-			}
-		} else {
+		if fi.StringValue(e.CIDR) != "" {
 			request = &ec2.ModifySubnetAttributeInput{
 				SubnetId:                             e.ID,
 				EnableResourceNameDnsARecordOnLaunch: &ec2.AttributeBooleanValue{Value: changes.ResourceBasedNaming},
@@ -335,6 +332,17 @@ func (_ *Subnet) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *Subnet) error {
 		}
 	}
 
+	if changes.DNS64 != nil {
+		request := &ec2.ModifySubnetAttributeInput{
+			SubnetId:    e.ID,
+			EnableDns64: &ec2.AttributeBooleanValue{Value: changes.DNS64},
+		}
+		_, err := t.Cloud.EC2().ModifySubnetAttribute(request)
+		if err != nil {
+			return fmt.Errorf("error modifying DNS64: %w", err)
+		}
+	}
+
 	return t.AddAWSTags(*e.ID, e.Tags)
 }
 
@@ -383,7 +391,7 @@ func (_ *Subnet) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *Su
 		// We probably shouldn't output subnet_ids only in this case - we normally output them by role,
 		// but removing it now might break people.  We could always output subnet_ids though, if we
 		// ever get a request for that.
-		return t.AddOutputVariableArray("subnet_ids", terraformWriter.LiteralFromStringValue(*e.ID))
+		return t.AddOutputVariableArray("subnet_ids", e.TerraformLink())
 	}
 
 	if strings.HasPrefix(aws.StringValue(e.IPv6CIDR), "/") {
@@ -400,9 +408,11 @@ func (_ *Subnet) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *Su
 		Tags:             e.Tags,
 	}
 	if fi.StringValue(e.CIDR) == "" {
-		tf.EnableDNS64 = fi.Bool(true)
 		tf.IPv6Native = fi.Bool(true)
 	}
+	if fi.BoolValue(e.DNS64) {
+		tf.EnableDNS64 = e.DNS64
+	}
 	if e.ResourceBasedNaming != nil {
 		hostnameType := ec2.HostnameTypeIpName
 		if *e.ResourceBasedNaming {
@@ -428,7 +438,7 @@ func (e *Subnet) TerraformLink() *terraformWriter.Literal {
 		}
 
 		klog.V(4).Infof("reusing existing subnet with id %q", *e.ID)
-		return terraformWriter.LiteralFromStringValue(*e.ID)
+		return terraformWriter.LiteralFromSharedResource("aws_subnet", *e.Name, *e.ID)
 	}
 
 	return terraformWriter.LiteralProperty("aws_subnet", *e.Name, "id")
@@ -439,6 +449,7 @@ type cloudformationSubnet struct {
 	CIDR             *string                 `json:"CidrBlock,omitempty"`
 	IPv6CIDR         *string                 `json:"Ipv6CidrBlock,omitempty"`
 	AvailabilityZone *string                 `json:"AvailabilityZone,omitempty"`
+	EnableDNS64      *bool                   `json:"EnableDns64,omitempty"`
 	Tags             []cloudformationTag     `json:"Tags,omitempty"`
 }
 
@@ -463,6 +474,9 @@ func (_ *Subnet) RenderCloudformation(t *cloudformation.CloudformationTarget, a,
 		AvailabilityZone: e.AvailabilityZone,
 		Tags:             buildCloudformationTags(e.Tags),
 	}
+	if fi.BoolValue(e.DNS64) {
+		cf.EnableDNS64 = e.DNS64
+	}
 
 	return t.RenderResource("AWS::EC2::Subnet", *e.Name, cf)
 }
@@ -481,6 +495,73 @@ func (e *Subnet) CloudformationLink() *cloudformation.Literal {
 	return cloudformation.Ref("AWS::EC2::Subnet", *e.Name)
 }
 
+type pulumiSubnet struct {
+	VpcId                                   *pulumi.Literal   `json:"vpcId"`
+	CidrBlock                               *string           `json:"cidrBlock,omitempty"`
+	Ipv6CidrBlock                           *string           `json:"ipv6CidrBlock,omitempty"`
+	Ipv6Native                              *bool             `json:"ipv6Native,omitempty"`
+	AvailabilityZone                        *string           `json:"availabilityZone,omitempty"`
+	EnableDns64                             *bool             `json:"enableDns64,omitempty"`
+	EnableResourceNameDnsAaaaRecordOnLaunch *bool             `json:"enableResourceNameDnsAaaaRecordOnLaunch,omitempty"`
+	EnableResourceNameDnsARecordOnLaunch    *bool             `json:"enableResourceNameDnsARecordOnLaunch,omitempty"`
+	PrivateDnsHostnameTypeOnLaunch          *string           `json:"privateDnsHostnameTypeOnLaunch,omitempty"`
+	Tags                                    map[string]string `json:"tags,omitempty"`
+}
+
+func (_ *Subnet) RenderPulumi(t *pulumi.PulumiTarget, a, e, changes *Subnet) error {
+	shared := fi.BoolValue(e.Shared)
+	if shared {
+		// Not pulumi owned / managed
+		// We won't apply changes, but our validation (kops update) will still warn
+		return nil
+	}
+
+	if strings.HasPrefix(aws.StringValue(e.IPv6CIDR), "/") {
+		return fmt.Errorf("<cidrsubnet> in not supported with Pulumi target: %q", aws.StringValue(e.IPv6CIDR))
+	}
+
+	p := &pulumiSubnet{
+		VpcId:            e.VPC.PulumiLink(),
+		CidrBlock:        e.CIDR,
+		Ipv6CidrBlock:    e.IPv6CIDR,
+		AvailabilityZone: e.AvailabilityZone,
+		Tags:             e.Tags,
+	}
+	if fi.StringValue(e.CIDR) == "" {
+		p.EnableDns64 = fi.Bool(true)
+		p.Ipv6Native = fi.Bool(true)
+	}
+	if e.ResourceBasedNaming != nil {
+		hostnameType := ec2.HostnameTypeIpName
+		if *e.ResourceBasedNaming {
+			hostnameType = ec2.HostnameTypeResourceName
+		}
+		p.PrivateDnsHostnameTypeOnLaunch = fi.String(hostnameType)
+		if fi.StringValue(e.CIDR) != "" {
+			p.EnableResourceNameDnsARecordOnLaunch = e.ResourceBasedNaming
+		}
+		if fi.StringValue(e.IPv6CIDR) != "" {
+			p.EnableResourceNameDnsAaaaRecordOnLaunch = e.ResourceBasedNaming
+		}
+	}
+
+	return t.RenderResource("aws:ec2:Subnet", *e.Name, p)
+}
+
+func (e *Subnet) PulumiLink() *pulumi.Literal {
+	shared := fi.BoolValue(e.Shared)
+	if shared {
+		if e.ID == nil {
+			klog.Fatalf("ID must be set, if subnet is shared: %s", e)
+		}
+
+		klog.V(4).Infof("reusing existing subnet with id %q", *e.ID)
+		return pulumi.LiteralFromStringValue(*e.ID)
+	}
+
+	return pulumi.LiteralProperty("aws:ec2:Subnet", *e.Name, "id")
+}
+
 func (e *Subnet) FindDeletions(c *fi.Context) ([]fi.Deletion, error) {
 	if e.ID == nil || aws.BoolValue(e.Shared) {
 		return nil, nil
@@ -18,6 +18,7 @@ package awstasks
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
@@ -26,6 +27,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/cloudformation"
+	"k8s.io/kops/upup/pkg/fi/cloudup/pulumi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
@@ -47,8 +49,21 @@ type TargetGroup struct {
 
 	HealthyThreshold   *int64
 	UnhealthyThreshold *int64
+
+	// HealthCheckProtocol, HealthCheckPort and HealthCheckPath override the health check,
+	// which otherwise checks connectivity to the target port using the target group protocol.
+	HealthCheckProtocol *string
+	HealthCheckPort     *int64
+	HealthCheckPath     *string
+
+	// DeregistrationDelay is the time in seconds to wait before deregistering a draining target.
+	// It is left unmanaged if nil.
+	DeregistrationDelay *int64
 }
 
+// targetGroupDeregistrationDelayAttribute is the target group attribute that holds the deregistration delay.
+const targetGroupDeregistrationDelayAttribute = "deregistration_delay.timeout_seconds"
+
 var _ fi.CompareWithID = &TargetGroup{}
 
 func (e *TargetGroup) CompareWithID() *string {
@@ -94,6 +109,31 @@ func (e *TargetGroup) Find(c *fi.Context) (*TargetGroup, error) {
 	}
 	e.ARN = tg.TargetGroupArn
 
+	actual.HealthCheckProtocol = tg.HealthCheckProtocol
+	actual.HealthCheckPath = tg.HealthCheckPath
+	if fi.StringValue(tg.HealthCheckPort) == "traffic-port" {
+		actual.HealthCheckPort = tg.Port
+	} else if port, err := strconv.ParseInt(fi.StringValue(tg.HealthCheckPort), 10, 64); err == nil {
+		actual.HealthCheckPort = fi.Int64(port)
+	}
+
+	if e.DeregistrationDelay != nil {
+		attributesResp, err := cloud.ELBV2().DescribeTargetGroupAttributes(&elbv2.DescribeTargetGroupAttributesInput{
+			TargetGroupArn: tg.TargetGroupArn,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error describing attributes of targetgroup %s: %v", *e.Name, err)
+		}
+		for _, attribute := range attributesResp.Attributes {
+			if fi.StringValue(attribute.Key) != targetGroupDeregistrationDelayAttribute {
+				continue
+			}
+			if delay, err := strconv.ParseInt(fi.StringValue(attribute.Value), 10, 64); err == nil {
+				actual.DeregistrationDelay = fi.Int64(delay)
+			}
+		}
+	}
+
 	tagsResp, err := cloud.ELBV2().DescribeTags(&elbv2.DescribeTagsInput{
 		ResourceArns: []*string{tg.TargetGroupArn},
 	})
@@ -175,8 +215,13 @@ func (_ *TargetGroup) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *TargetGrou
 			VpcId:                   e.VPC.ID,
 			HealthyThresholdCount:   e.HealthyThreshold,
 			UnhealthyThresholdCount: e.UnhealthyThreshold,
+			HealthCheckProtocol:     e.HealthCheckProtocol,
+			HealthCheckPath:         e.HealthCheckPath,
 			Tags:                    awsup.ELBv2Tags(e.Tags),
 		}
+		if e.HealthCheckPort != nil {
+			request.HealthCheckPort = fi.String(strconv.FormatInt(*e.HealthCheckPort, 10))
+		}
 
 		klog.V(2).Infof("Creating Target Group for NLB")
 		response, err := t.Cloud.ELBV2().CreateTargetGroup(request)
@@ -186,16 +231,62 @@ func (_ *TargetGroup) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *TargetGrou
 
 		targetGroupArn := *response.TargetGroups[0].TargetGroupArn
 		e.ARN = fi.String(targetGroupArn)
+
+		if e.DeregistrationDelay != nil {
+			if err := modifyTargetGroupDeregistrationDelay(t, e); err != nil {
+				return err
+			}
+		}
 	} else {
 		if a.ARN != nil {
 			if err := t.AddELBV2Tags(fi.StringValue(a.ARN), e.Tags); err != nil {
 				return err
 			}
+
+			if changes.HealthCheckProtocol != nil || changes.HealthCheckPort != nil || changes.HealthCheckPath != nil {
+				request := &elbv2.ModifyTargetGroupInput{
+					TargetGroupArn:      a.ARN,
+					HealthCheckProtocol: e.HealthCheckProtocol,
+					HealthCheckPath:     e.HealthCheckPath,
+				}
+				if e.HealthCheckPort != nil {
+					request.HealthCheckPort = fi.String(strconv.FormatInt(*e.HealthCheckPort, 10))
+				}
+
+				klog.V(2).Infof("Modifying health check of Target Group %q", fi.StringValue(e.Name))
+				if _, err := t.Cloud.ELBV2().ModifyTargetGroup(request); err != nil {
+					return fmt.Errorf("error modifying target group health check: %v", err)
+				}
+			}
+
+			if changes.DeregistrationDelay != nil {
+				if err := modifyTargetGroupDeregistrationDelay(t, e); err != nil {
+					return err
+				}
+			}
 		}
 	}
 	return nil
 }
 
+func modifyTargetGroupDeregistrationDelay(t *awsup.AWSAPITarget, e *TargetGroup) error {
+	request := &elbv2.ModifyTargetGroupAttributesInput{
+		TargetGroupArn: e.ARN,
+		Attributes: []*elbv2.TargetGroupAttribute{
+			{
+				Key:   aws.String(targetGroupDeregistrationDelayAttribute),
+				Value: aws.String(strconv.FormatInt(*e.DeregistrationDelay, 10)),
+			},
+		},
+	}
+
+	klog.V(2).Infof("Modifying deregistration delay of Target Group %q", fi.StringValue(e.Name))
+	if _, err := t.Cloud.ELBV2().ModifyTargetGroupAttributes(request); err != nil {
+		return fmt.Errorf("error modifying target group attributes: %v", err)
+	}
+	return nil
+}
+
 // OrderTargetGroupsByName implements sort.Interface for []OrderTargetGroupsByName, based on port number
 type OrderTargetGroupsByName []*TargetGroup
 
@@ -212,12 +303,16 @@ type terraformTargetGroup struct {
 	VPCID       terraformWriter.Literal         `cty:"vpc_id"`
 	Tags        map[string]string               `cty:"tags"`
 	HealthCheck terraformTargetGroupHealthCheck `cty:"health_check"`
+
+	DeregistrationDelay *int64 `cty:"deregistration_delay"`
 }
 
 type terraformTargetGroupHealthCheck struct {
-	HealthyThreshold   int64  `cty:"healthy_threshold"`
-	UnhealthyThreshold int64  `cty:"unhealthy_threshold"`
-	Protocol           string `cty:"protocol"`
+	HealthyThreshold   int64   `cty:"healthy_threshold"`
+	UnhealthyThreshold int64   `cty:"unhealthy_threshold"`
+	Protocol           string  `cty:"protocol"`
+	Port               *string `cty:"port"`
+	Path               *string `cty:"path"`
 }
 
 func (_ *TargetGroup) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *TargetGroup) error {
@@ -242,6 +337,14 @@ func (_ *TargetGroup) RenderTerraform(t *terraform.TerraformTarget, a, e, change
 			Protocol:           elbv2.ProtocolEnumTcp,
 		},
 	}
+	if e.HealthCheckProtocol != nil {
+		tf.HealthCheck.Protocol = *e.HealthCheckProtocol
+		tf.HealthCheck.Path = e.HealthCheckPath
+		if e.HealthCheckPort != nil {
+			tf.HealthCheck.Port = fi.String(strconv.FormatInt(*e.HealthCheckPort, 10))
+		}
+	}
+	tf.DeregistrationDelay = e.DeregistrationDelay
 
 	return t.RenderResource("aws_lb_target_group", *e.Name, tf)
 }
@@ -265,9 +368,18 @@ type cloudformationTargetGroup struct {
 	VPCID    *cloudformation.Literal `json:"VpcId"`
 	Tags     []cloudformationTag     `json:"Tags"`
 
-	HealthCheckProtocol string `json:"HealthCheckProtocol"`
-	HealthyThreshold    int64  `json:"HealthyThresholdCount"`
-	UnhealthyThreshold  int64  `json:"UnhealthyThresholdCount"`
+	HealthCheckProtocol string  `json:"HealthCheckProtocol"`
+	HealthCheckPort     *string `json:"HealthCheckPort,omitempty"`
+	HealthCheckPath     *string `json:"HealthCheckPath,omitempty"`
+	HealthyThreshold    int64   `json:"HealthyThresholdCount"`
+	UnhealthyThreshold  int64   `json:"UnhealthyThresholdCount"`
+
+	TargetGroupAttributes []cloudformationTargetGroupAttribute `json:"TargetGroupAttributes,omitempty"`
+}
+
+type cloudformationTargetGroupAttribute struct {
+	Key   string `json:"Key"`
+	Value string `json:"Value"`
 }
 
 func (_ *TargetGroup) RenderCloudformation(t *cloudformation.CloudformationTarget, a, e, changes *TargetGroup) error {
@@ -286,6 +398,19 @@ func (_ *TargetGroup) RenderCloudformation(t *cloudformation.CloudformationTarge
 		HealthyThreshold:    *e.HealthyThreshold,
 		UnhealthyThreshold:  *e.UnhealthyThreshold,
 	}
+	if e.HealthCheckProtocol != nil {
+		cf.HealthCheckProtocol = *e.HealthCheckProtocol
+		cf.HealthCheckPath = e.HealthCheckPath
+		if e.HealthCheckPort != nil {
+			cf.HealthCheckPort = fi.String(strconv.FormatInt(*e.HealthCheckPort, 10))
+		}
+	}
+	if e.DeregistrationDelay != nil {
+		cf.TargetGroupAttributes = append(cf.TargetGroupAttributes, cloudformationTargetGroupAttribute{
+			Key:   targetGroupDeregistrationDelayAttribute,
+			Value: strconv.FormatInt(*e.DeregistrationDelay, 10),
+		})
+	}
 	return t.RenderResource("AWS::ElasticLoadBalancingV2::TargetGroup", *e.Name, cf)
 }
 
@@ -301,3 +426,68 @@ func (e *TargetGroup) CloudformationLink() *cloudformation.Literal {
 
 	return cloudformation.Ref("AWS::ElasticLoadBalancingV2::TargetGroup", *e.Name)
 }
+
+type pulumiTargetGroup struct {
+	Name        string                       `json:"name"`
+	Port        int64                        `json:"port"`
+	Protocol    string                       `json:"protocol"`
+	VpcId       *pulumi.Literal              `json:"vpcId"`
+	Tags        map[string]string            `json:"tags,omitempty"`
+	HealthCheck pulumiTargetGroupHealthCheck `json:"healthCheck"`
+
+	DeregistrationDelay *int64 `json:"deregistrationDelay,omitempty"`
+}
+
+type pulumiTargetGroupHealthCheck struct {
+	HealthyThreshold   int64   `json:"healthyThreshold"`
+	UnhealthyThreshold int64   `json:"unhealthyThreshold"`
+	Protocol           string  `json:"protocol"`
+	Port               *string `json:"port,omitempty"`
+	Path               *string `json:"path,omitempty"`
+}
+
+func (_ *TargetGroup) RenderPulumi(t *pulumi.PulumiTarget, a, e, changes *TargetGroup) error {
+	shared := fi.BoolValue(e.Shared)
+	if shared {
+		return nil
+	}
+
+	if e.VPC == nil {
+		return fmt.Errorf("Missing VPC task from target group:\n%v\n%v", e, e.VPC)
+	}
+
+	p := &pulumiTargetGroup{
+		Name:     *e.Name,
+		Port:     *e.Port,
+		Protocol: *e.Protocol,
+		VpcId:    e.VPC.PulumiLink(),
+		Tags:     e.Tags,
+		HealthCheck: pulumiTargetGroupHealthCheck{
+			HealthyThreshold:   *e.HealthyThreshold,
+			UnhealthyThreshold: *e.UnhealthyThreshold,
+			Protocol:           elbv2.ProtocolEnumTcp,
+		},
+	}
+	if e.HealthCheckProtocol != nil {
+		p.HealthCheck.Protocol = *e.HealthCheckProtocol
+		p.HealthCheck.Path = e.HealthCheckPath
+		if e.HealthCheckPort != nil {
+			p.HealthCheck.Port = fi.String(strconv.FormatInt(*e.HealthCheckPort, 10))
+		}
+	}
+	p.DeregistrationDelay = e.DeregistrationDelay
+
+	return t.RenderResource("aws:lb:TargetGroup", *e.Name, p)
+}
+
+func (e *TargetGroup) PulumiLink() *pulumi.Literal {
+	shared := fi.BoolValue(e.Shared)
+	if shared {
+		if e.ARN != nil {
+			return pulumi.LiteralFromStringValue(*e.ARN)
+		} else {
+			klog.Warningf("ID not set on shared Target Group %v", e)
+		}
+	}
+	return pulumi.LiteralProperty("aws:lb:TargetGroup", *e.Name, "arn")
+}
@@ -27,6 +27,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/cloudformation"
+	"k8s.io/kops/upup/pkg/fi/cloudup/pulumi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
@@ -324,7 +325,7 @@ func (e *VPC) TerraformLink() *terraformWriter.Literal {
 		}
 
 		klog.V(4).Infof("reusing existing VPC with id %q", *e.ID)
-		return terraformWriter.LiteralFromStringValue(*e.ID)
+		return terraformWriter.LiteralFromSharedResource("aws_vpc", *e.Name, *e.ID)
 	}
 
 	return terraformWriter.LiteralProperty("aws_vpc", *e.Name, "id")
@@ -369,6 +370,51 @@ func (e *VPC) CloudformationLink() *cloudformation.Literal {
 	return cloudformation.Ref("AWS::EC2::VPC", *e.Name)
 }
 
+type pulumiVPC struct {
+	CidrBlock                    *string           `json:"cidrBlock,omitempty"`
+	EnableDnsHostnames           *bool             `json:"enableDnsHostnames,omitempty"`
+	EnableDnsSupport             *bool             `json:"enableDnsSupport,omitempty"`
+	AssignGeneratedIpv6CidrBlock *bool             `json:"assignGeneratedIpv6CidrBlock,omitempty"`
+	Tags                         map[string]string `json:"tags,omitempty"`
+}
+
+func (_ *VPC) RenderPulumi(t *pulumi.PulumiTarget, a, e, changes *VPC) error {
+	if err := t.AddOutputVariable("vpcId", e.PulumiLink()); err != nil {
+		return err
+	}
+
+	shared := fi.BoolValue(e.Shared)
+	if shared {
+		// Not pulumi owned / managed
+		// We won't apply changes, but our validation (kops update) will still warn
+		return nil
+	}
+
+	p := &pulumiVPC{
+		CidrBlock:                    e.CIDR,
+		EnableDnsHostnames:           e.EnableDNSHostnames,
+		EnableDnsSupport:             e.EnableDNSSupport,
+		AssignGeneratedIpv6CidrBlock: e.AmazonIPv6,
+		Tags:                         e.Tags,
+	}
+
+	return t.RenderResource("aws:ec2:Vpc", *e.Name, p)
+}
+
+func (e *VPC) PulumiLink() *pulumi.Literal {
+	shared := fi.BoolValue(e.Shared)
+	if shared {
+		if e.ID == nil {
+			klog.Fatalf("ID must be set, if VPC is shared: %s", e)
+		}
+
+		klog.V(4).Infof("reusing existing VPC with id %q", *e.ID)
+		return pulumi.LiteralFromStringValue(*e.ID)
+	}
+
+	return pulumi.LiteralProperty("aws:ec2:Vpc", *e.Name, "id")
+}
+
 type deleteVPCCIDRBlock struct {
 	vpcID         *string
 	cidrBlock     *string
@@ -24,6 +24,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/cloudformation"
+	"k8s.io/kops/upup/pkg/fi/cloudup/pulumi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
 )
 
@@ -111,6 +112,11 @@ func (_ *VPCAmazonIPv6CIDRBlock) RenderTerraform(t *terraform.TerraformTarget, a
 	return nil
 }
 
+func (_ *VPCAmazonIPv6CIDRBlock) RenderPulumi(t *pulumi.PulumiTarget, a, e, changes *VPCAmazonIPv6CIDRBlock) error {
+	// As with terraform, this is done via the assignGeneratedIpv6CidrBlock property of the aws:ec2:Vpc resource
+	return nil
+}
+
 type cloudformationVPCAmazonIPv6CIDRBlock struct {
 	VPCID      *cloudformation.Literal `json:"VpcId"`
 	AmazonIPv6 *bool                   `json:"AmazonProvidedIpv6CidrBlock"`
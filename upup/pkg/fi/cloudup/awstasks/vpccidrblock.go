@@ -24,6 +24,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/cloudformation"
+	"k8s.io/kops/upup/pkg/fi/cloudup/pulumi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
@@ -184,3 +185,24 @@ func (_ *VPCCIDRBlock) RenderCloudformation(t *cloudformation.CloudformationTarg
 
 	return t.RenderResource("AWS::EC2::VPCCidrBlock", *e.Name, cf)
 }
+
+type pulumiVPCCIDRBlock struct {
+	VpcId     *pulumi.Literal `json:"vpcId"`
+	CidrBlock *string         `json:"cidrBlock"`
+}
+
+func (_ *VPCCIDRBlock) RenderPulumi(t *pulumi.PulumiTarget, a, e, changes *VPCCIDRBlock) error {
+	shared := aws.BoolValue(e.Shared)
+	if shared && a == nil {
+		// VPC not owned by kOps, no changes will be applied
+		// Verify that the CIDR block was found.
+		return fmt.Errorf("CIDR block %q not found", aws.StringValue(e.CIDRBlock))
+	}
+
+	p := &pulumiVPCCIDRBlock{
+		VpcId:     e.VPC.PulumiLink(),
+		CidrBlock: e.CIDRBlock,
+	}
+
+	return t.RenderResource("aws:ec2:VpcIpv4CidrBlockAssociation", *e.Name, p)
+}
@@ -38,6 +38,8 @@ import (
 	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/aws/aws-sdk-go/service/autoscaling/autoscalingiface"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/cloudtrail"
+	"github.com/aws/aws-sdk-go/service/cloudtrail/cloudtrailiface"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/elb"
@@ -106,6 +108,19 @@ const TagNameClusterOwnershipPrefix = "kubernetes.io/cluster/"
 
 const tagNameDetachedInstance = "kops.k8s.io/detached-from-asg"
 
+// TagNameKopsSpecHash is the AWS tag with the hash of the spec that a resource was last applied from
+const TagNameKopsSpecHash = "kops.k8s.io/spec-hash"
+
+const (
+	// TagNameSpotFallback is the autoscaling group tag in which kops-controller records the spot fallback state of the group,
+	// as "<state>/<RFC3339 time>"; it is not part of the cluster configuration.
+	TagNameSpotFallback = "kops-controller.kops.k8s.io/spot-fallback"
+	// SpotFallbackStateOnDemand is the spot fallback state of an autoscaling group switched to On-Demand instances.
+	SpotFallbackStateOnDemand = "on-demand"
+	// SpotFallbackStateReverted is the spot fallback state of an autoscaling group switched back to Spot instances.
+	SpotFallbackStateReverted = "reverted"
+)
+
 const (
 	WellKnownAccountAmazonLinux2 = "137112412989"
 	WellKnownAccountCentOS       = "125523088429"
@@ -135,6 +150,7 @@ type AWSCloud interface {
 	Spotinst() spotinst.Cloud
 	SQS() sqsiface.SQSAPI
 	EventBridge() eventbridgeiface.EventBridgeAPI
+	CloudTrail() cloudtrailiface.CloudTrailAPI
 
 	// TODO: Document and rationalize these tags/filters methods
 	AddTags(name *string, tags map[string]string)
@@ -204,6 +220,7 @@ type awsCloudImplementation struct {
 	sts         *sts.STS
 	sqs         *sqs.SQS
 	eventbridge *eventbridge.EventBridge
+	cloudtrail  *cloudtrail.CloudTrail
 
 	region string
 
@@ -383,6 +400,17 @@ func NewAWSCloud(region string, tags map[string]string) (AWSCloud, error) {
 		c.eventbridge.Handlers.Send.PushFront(requestLogger)
 		c.addHandlers(region, &c.eventbridge.Handlers)
 
+		sess, err = session.NewSessionWithOptions(session.Options{
+			Config:            *config,
+			SharedConfigState: session.SharedConfigEnable,
+		})
+		if err != nil {
+			return c, err
+		}
+		c.cloudtrail = cloudtrail.New(sess, config)
+		c.cloudtrail.Handlers.Send.PushFront(requestLogger)
+		c.addHandlers(region, &c.cloudtrail.Handlers)
+
 		awsCloudInstances[region] = c
 		raw = c
 	}
@@ -1121,6 +1149,11 @@ func awsBuildCloudInstanceGroup(c AWSCloud, cluster *kops.Cluster, ig *kops.Inst
 		MaxSize:       int(aws.Int64Value(g.MaxSize)),
 		Raw:           g,
 	}
+	for _, tag := range g.Tags {
+		if aws.StringValue(tag.Key) == TagNameKopsSpecHash {
+			cg.SpecHash = aws.StringValue(tag.Value)
+		}
+	}
 
 	for _, i := range g.Instances {
 		err := buildCloudInstance(i, instances, instanceSeen, nodeMap, cg, newConfigName)
@@ -1193,6 +1226,8 @@ func buildCloudInstance(i *autoscaling.Instance, instances map[string]*ec2.Insta
 	if strings.HasPrefix(*i.LifecycleState, "Warmed") {
 		cm.State = cloudinstances.WarmPool
 	}
+	cm.LifecycleState = aws.StringValue(i.LifecycleState)
+	cm.HealthStatus = aws.StringValue(i.HealthStatus)
 
 	addCloudInstanceData(cm, instances[id])
 	return nil
@@ -1200,6 +1235,11 @@ func buildCloudInstance(i *autoscaling.Instance, instances map[string]*ec2.Insta
 
 func addCloudInstanceData(cm *cloudinstances.CloudInstance, instance *ec2.Instance) {
 	cm.MachineType = aws.StringValue(instance.InstanceType)
+	if aws.StringValue(instance.InstanceLifecycle) == ec2.InstanceLifecycleTypeSpot {
+		cm.Lifecycle = cloudinstances.LifecycleSpot
+	} else {
+		cm.Lifecycle = cloudinstances.LifecycleOnDemand
+	}
 	for _, tag := range instance.Tags {
 		key := aws.StringValue(tag.Key)
 		if !strings.HasPrefix(key, TagNameRolePrefix) {
@@ -2138,6 +2178,10 @@ func (c *awsCloudImplementation) EventBridge() eventbridgeiface.EventBridgeAPI {
 	return c.eventbridge
 }
 
+func (c *awsCloudImplementation) CloudTrail() cloudtrailiface.CloudTrailAPI {
+	return c.cloudtrail
+}
+
 func (c *awsCloudImplementation) FindVPCInfo(vpcID string) (*fi.VPCInfo, error) {
 	return findVPCInfo(c, vpcID)
 }
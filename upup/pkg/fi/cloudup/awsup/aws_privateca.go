@@ -0,0 +1,126 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsup
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/acmpca"
+	"k8s.io/kops/pkg/pki"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+// privateCASubordinateCATemplate is the ACM Private CA template used for the cluster CAs:
+// subordinate CAs that can only issue end-entity certificates.
+const privateCASubordinateCATemplate = "template/SubordinateCACertificate_PathLen0/V1"
+
+var _ fi.CertificateIssuer = &awsCloudImplementation{}
+
+// IssueCACertificate implements fi.CertificateIssuer, issuing a subordinate CA certificate from an ACM Private CA.
+func (c *awsCloudImplementation) IssueCACertificate(issuer string, csr []byte, validity time.Duration) (*pki.Certificate, string, error) {
+	caARN, err := arn.Parse(issuer)
+	if err != nil {
+		return nil, "", fmt.Errorf("error parsing ACM Private CA ARN %q: %v", issuer, err)
+	}
+
+	// The private CA is not necessarily in the region of the cluster
+	config := aws.NewConfig().WithRegion(caARN.Region).WithCredentialsChainVerboseErrors(true)
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            *config,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	client := acmpca.New(sess, config)
+	client.Handlers.Send.PushFront(newRequestLogger(2))
+
+	response, err := client.DescribeCertificateAuthority(&acmpca.DescribeCertificateAuthorityInput{
+		CertificateAuthorityArn: aws.String(issuer),
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("error describing ACM Private CA %q: %v", issuer, err)
+	}
+	ca := response.CertificateAuthority
+	if status := aws.StringValue(ca.Status); status != acmpca.CertificateAuthorityStatusActive {
+		return nil, "", fmt.Errorf("ACM Private CA %q is %s, not %s", issuer, status, acmpca.CertificateAuthorityStatusActive)
+	}
+
+	var keyAlgorithm string
+	if ca.CertificateAuthorityConfiguration != nil {
+		keyAlgorithm = aws.StringValue(ca.CertificateAuthorityConfiguration.KeyAlgorithm)
+	}
+	signingAlgorithm, err := privateCASigningAlgorithm(keyAlgorithm)
+	if err != nil {
+		return nil, "", fmt.Errorf("ACM Private CA %q: %v", issuer, err)
+	}
+
+	notAfter := time.Now().Add(validity)
+	// A certificate cannot outlive the CA that issued it
+	if ca.NotAfter != nil && ca.NotAfter.Before(notAfter) {
+		notAfter = *ca.NotAfter
+	}
+
+	issued, err := client.IssueCertificate(&acmpca.IssueCertificateInput{
+		CertificateAuthorityArn: aws.String(issuer),
+		Csr:                     csr,
+		SigningAlgorithm:        aws.String(signingAlgorithm),
+		TemplateArn:             aws.String(fmt.Sprintf("arn:%s:acm-pca:::%s", caARN.Partition, privateCASubordinateCATemplate)),
+		Validity: &acmpca.Validity{
+			Type:  aws.String(acmpca.ValidityPeriodTypeAbsolute),
+			Value: aws.Int64(notAfter.Unix()),
+		},
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("error issuing certificate from ACM Private CA %q: %v", issuer, err)
+	}
+
+	request := &acmpca.GetCertificateInput{
+		CertificateArn:          issued.CertificateArn,
+		CertificateAuthorityArn: aws.String(issuer),
+	}
+	if err := client.WaitUntilCertificateIssued(request); err != nil {
+		return nil, "", fmt.Errorf("error waiting for certificate %q to be issued: %v", aws.StringValue(issued.CertificateArn), err)
+	}
+	certificate, err := client.GetCertificate(request)
+	if err != nil {
+		return nil, "", fmt.Errorf("error getting certificate %q: %v", aws.StringValue(issued.CertificateArn), err)
+	}
+
+	cert, err := pki.ParsePEMCertificate([]byte(aws.StringValue(certificate.Certificate)))
+	if err != nil {
+		return nil, "", fmt.Errorf("error parsing certificate %q: %v", aws.StringValue(issued.CertificateArn), err)
+	}
+
+	return cert, aws.StringValue(issued.CertificateArn), nil
+}
+
+// privateCASigningAlgorithm returns the signing algorithm to use with the key algorithm of an ACM Private CA.
+func privateCASigningAlgorithm(keyAlgorithm string) (string, error) {
+	switch keyAlgorithm {
+	case acmpca.KeyAlgorithmRsa2048, acmpca.KeyAlgorithmRsa4096:
+		return acmpca.SigningAlgorithmSha256withrsa, nil
+	case acmpca.KeyAlgorithmEcPrime256v1, acmpca.KeyAlgorithmEcSecp384r1:
+		return acmpca.SigningAlgorithmSha256withecdsa, nil
+	default:
+		return "", fmt.Errorf("unsupported key algorithm %q", keyAlgorithm)
+	}
+}
@@ -34,6 +34,8 @@ type AWSMachineTypeInfo struct {
 	MaxPods           int
 	InstanceENIs      int
 	InstanceIPsPerENI int
+	EFASupported      bool
+	NetworkCards      int
 }
 
 type EphemeralDevice struct {
@@ -84,6 +86,8 @@ func GetMachineTypeInfo(c AWSCloud, machineType string) (*AWSMachineTypeInfo, er
 		GPU:               info.GpuInfo != nil,
 		InstanceENIs:      intValue(info.NetworkInfo.MaximumNetworkInterfaces),
 		InstanceIPsPerENI: intValue(info.NetworkInfo.Ipv4AddressesPerInterface),
+		EFASupported:      aws.BoolValue(info.NetworkInfo.EfaSupported),
+		NetworkCards:      intValue(info.NetworkInfo.MaximumNetworkCards),
 	}
 	memoryGB := float64(intValue(info.MemoryInfo.SizeInMiB)) / 1024
 	machine.MemoryGB = float32(math.Round(memoryGB*100) / 100)
@@ -22,6 +22,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/autoscaling/autoscalingiface"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/cloudtrail/cloudtrailiface"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/elb"
@@ -86,6 +87,7 @@ type MockCloud struct {
 	MockSpotinst       spotinst.Cloud
 	MockSQS            sqsiface.SQSAPI
 	MockEventBridge    eventbridgeiface.EventBridgeAPI
+	MockCloudTrail     cloudtrailiface.CloudTrailAPI
 }
 
 func (c *MockAWSCloud) DeleteGroup(g *cloudinstances.CloudInstanceGroup) error {
@@ -301,6 +303,13 @@ func (c *MockAWSCloud) EventBridge() eventbridgeiface.EventBridgeAPI {
 	return c.MockEventBridge
 }
 
+func (c *MockAWSCloud) CloudTrail() cloudtrailiface.CloudTrailAPI {
+	if c.MockCloudTrail == nil {
+		klog.Fatalf("MockCloudTrail not set")
+	}
+	return c.MockCloudTrail
+}
+
 func (c *MockAWSCloud) FindVPCInfo(id string) (*fi.VPCInfo, error) {
 	return findVPCInfo(c, id)
 }
@@ -340,6 +349,11 @@ func (c *MockAWSCloud) DescribeInstanceType(instanceType string) (*ec2.InstanceT
 			DefaultVCpus: aws.Int64(2),
 		},
 	}
+	if instanceType == "p4d.24xlarge" {
+		info.GpuInfo = &ec2.GpuInfo{}
+		info.NetworkInfo.EfaSupported = aws.Bool(true)
+		info.NetworkInfo.MaximumNetworkCards = aws.Int64(4)
+	}
 	if instanceType == "m3.medium" {
 		info.InstanceStorageInfo = &ec2.InstanceStorageInfo{
 			Disks: []*ec2.DiskInfo{
@@ -352,7 +366,7 @@ func (c *MockAWSCloud) DescribeInstanceType(instanceType string) (*ec2.InstanceT
 	}
 
 	switch instanceType {
-	case "c5.large", "m3.medium", "m4.large", "m5.large", "m5.xlarge", "t3.micro", "t3.medium", "t3.large", "c4.large":
+	case "c5.large", "m3.medium", "m4.large", "m5.large", "m5.xlarge", "t3.micro", "t3.medium", "t3.large", "c4.large", "p4d.24xlarge":
 		info.ProcessorInfo = &ec2.ProcessorInfo{
 			SupportedArchitectures: []*string{
 				aws.String(ec2.ArchitectureTypeX8664),
@@ -52,9 +52,11 @@ type AzureCloud interface {
 	VMScaleSetVM() VMScaleSetVMsClient
 	Disk() DisksClient
 	RoleAssignment() RoleAssignmentsClient
+	UserAssignedIdentity() UserAssignedIdentitiesClient
 	NetworkInterface() NetworkInterfacesClient
 	LoadBalancer() LoadBalancersClient
 	PublicIPAddress() PublicIPAddressesClient
+	ResourceSku() ResourceSkusClient
 }
 
 type azureCloudImplementation struct {
@@ -69,9 +71,11 @@ type azureCloudImplementation struct {
 	vmscaleSetVMsClient     VMScaleSetVMsClient
 	disksClient             DisksClient
 	roleAssignmentsClient   RoleAssignmentsClient
+	identitiesClient        UserAssignedIdentitiesClient
 	networkInterfacesClient NetworkInterfacesClient
 	loadBalancersClient     LoadBalancersClient
 	publicIPAddressesClient PublicIPAddressesClient
+	resourceSkusClient      ResourceSkusClient
 }
 
 var _ fi.Cloud = &azureCloudImplementation{}
@@ -95,9 +99,11 @@ func NewAzureCloud(subscriptionID, location string, tags map[string]string) (Azu
 		vmscaleSetVMsClient:     newVMScaleSetVMsClientImpl(subscriptionID, authorizer),
 		disksClient:             newDisksClientImpl(subscriptionID, authorizer),
 		roleAssignmentsClient:   newRoleAssignmentsClientImpl(subscriptionID, authorizer),
+		identitiesClient:        newUserAssignedIdentitiesClientImpl(subscriptionID, authorizer),
 		networkInterfacesClient: newNetworkInterfacesClientImpl(subscriptionID, authorizer),
 		loadBalancersClient:     newLoadBalancersClientImpl(subscriptionID, authorizer),
 		publicIPAddressesClient: newPublicIPAddressesClientImpl(subscriptionID, authorizer),
+		resourceSkusClient:      newResourceSkusClientImpl(subscriptionID, authorizer),
 	}, nil
 }
 
@@ -290,6 +296,10 @@ func (c *azureCloudImplementation) RoleAssignment() RoleAssignmentsClient {
 	return c.roleAssignmentsClient
 }
 
+func (c *azureCloudImplementation) UserAssignedIdentity() UserAssignedIdentitiesClient {
+	return c.identitiesClient
+}
+
 func (c *azureCloudImplementation) NetworkInterface() NetworkInterfacesClient {
 	return c.networkInterfacesClient
 }
@@ -301,3 +311,7 @@ func (c *azureCloudImplementation) LoadBalancer() LoadBalancersClient {
 func (c *azureCloudImplementation) PublicIPAddress() PublicIPAddressesClient {
 	return c.publicIPAddressesClient
 }
+
+func (c *azureCloudImplementation) ResourceSku() ResourceSkusClient {
+	return c.resourceSkusClient
+}
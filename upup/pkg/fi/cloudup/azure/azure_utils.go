@@ -40,3 +40,17 @@ func ZoneToAvailabilityZoneNumber(zone string) (string, error) {
 	}
 	return l[1], nil
 }
+
+// MachineTypeHasNvidiaGPU returns true if the VM size has Nvidia GPUs: the NC, ND and NV series,
+// except for the NVv4 series, which has AMD GPUs.
+func MachineTypeHasNvidiaGPU(vmSize string) bool {
+	size := strings.ToLower(vmSize)
+	switch {
+	case strings.HasPrefix(size, "standard_nc"), strings.HasPrefix(size, "standard_nd"):
+		return true
+	case strings.HasPrefix(size, "standard_nv"):
+		return !strings.HasSuffix(size, "_v4")
+	default:
+		return false
+	}
+}
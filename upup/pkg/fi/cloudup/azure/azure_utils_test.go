@@ -102,3 +102,25 @@ func TestZoneToAvailabilityZoneNumber(t *testing.T) {
 		})
 	}
 }
+
+func TestMachineTypeHasNvidiaGPU(t *testing.T) {
+	testCases := []struct {
+		vmSize   string
+		expected bool
+	}{
+		{vmSize: "Standard_NC6s_v3", expected: true},
+		{vmSize: "Standard_NC4as_T4_v3", expected: true},
+		{vmSize: "Standard_ND96asr_v4", expected: true},
+		{vmSize: "Standard_NV12s_v3", expected: true},
+		{vmSize: "Standard_NV8as_v4", expected: false},
+		{vmSize: "Standard_B2ms", expected: false},
+		{vmSize: "Standard_D4s_v3", expected: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.vmSize, func(t *testing.T) {
+			if actual := MachineTypeHasNvidiaGPU(tc.vmSize); actual != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
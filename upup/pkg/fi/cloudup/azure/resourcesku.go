@@ -0,0 +1,54 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2020-06-01/compute"
+	"github.com/Azure/go-autorest/autorest"
+)
+
+// ResourceSkusClient is a client for listing the SKUs of compute resources.
+type ResourceSkusClient interface {
+	List(ctx context.Context, filter string) ([]compute.ResourceSku, error)
+}
+
+type resourceSkusClientImpl struct {
+	c *compute.ResourceSkusClient
+}
+
+var _ ResourceSkusClient = &resourceSkusClientImpl{}
+
+func (c *resourceSkusClientImpl) List(ctx context.Context, filter string) ([]compute.ResourceSku, error) {
+	var l []compute.ResourceSku
+	for iter, err := c.c.ListComplete(ctx, filter); iter.NotDone(); err = iter.Next() {
+		if err != nil {
+			return nil, err
+		}
+		l = append(l, iter.Value())
+	}
+	return l, nil
+}
+
+func newResourceSkusClientImpl(subscriptionID string, authorizer autorest.Authorizer) *resourceSkusClientImpl {
+	c := compute.NewResourceSkusClient(subscriptionID)
+	c.Authorizer = authorizer
+	return &resourceSkusClientImpl{
+		c: &c,
+	}
+}
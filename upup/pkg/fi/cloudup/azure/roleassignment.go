@@ -27,6 +27,25 @@ import (
 	"github.com/Azure/go-autorest/autorest"
 )
 
+// Built-in roles assigned to the identity of VM Scale Sets.
+// See https://docs.microsoft.com/en-us/azure/role-based-access-control/built-in-roles
+// for the ID definitions.
+const (
+	// RoleDefIDOwner is the ID of the Owner role.
+	RoleDefIDOwner = "8e3af657-a8ff-443c-a75c-2fe8c4bcb635"
+	// RoleDefIDStorageBlobDataContributor is the ID of the Storage Blob Data Contributor role.
+	RoleDefIDStorageBlobDataContributor = "ba92f5b4-2d11-453d-a403-e96b0029c9fe"
+)
+
+// InstanceRoleDefIDs returns the IDs of the roles that the identity of VM Scale Sets
+// requires, keyed by the short name used to name Role Assignments.
+func InstanceRoleDefIDs() map[string]string {
+	return map[string]string{
+		"owner": RoleDefIDOwner,
+		"blob":  RoleDefIDStorageBlobDataContributor,
+	}
+}
+
 // RoleAssignmentsClient is a client for managing Role Assignments
 type RoleAssignmentsClient interface {
 	Create(ctx context.Context, scope, roleAssignmentName string, parameters authz.RoleAssignmentCreateParameters) (*authz.RoleAssignment, error)
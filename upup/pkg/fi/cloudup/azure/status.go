@@ -183,16 +183,36 @@ func (c *azureCloudImplementation) buildCloudInstanceGroup(
 		// TODO(kenji): Set the status properly so that kops can
 		// tell whether a VM is up-to-date or not.
 		status := cloudinstances.CloudInstanceStatusUpToDate
-		_, err := cg.NewCloudInstance(*vm.Name, status, nodeMap[*vm.Name])
+		cm, err := cg.NewCloudInstance(*vm.Name, status, nodeMap[*vm.Name])
 		if err != nil {
 			return nil, fmt.Errorf("error creating cloud instance group member: %s", err)
 		}
 		// TODO(kenji): Set addCloudInstanceData.
+		cm.Lifecycle = vmssLifecycle(vmss)
+		if props := vm.VirtualMachineScaleSetVMProperties; props != nil {
+			cm.LifecycleState = fi.StringValue(props.ProvisioningState)
+			if props.InstanceView != nil && props.InstanceView.VMHealth != nil && props.InstanceView.VMHealth.Status != nil {
+				cm.HealthStatus = fi.StringValue(props.InstanceView.VMHealth.Status.Code)
+			}
+		}
 	}
 
 	return cg, nil
 }
 
+// vmssLifecycle returns whether the VMs of the VM Scale Set are spot or regular VMs
+func vmssLifecycle(vmss *compute.VirtualMachineScaleSet) string {
+	if vmss.VirtualMachineScaleSetProperties == nil || vmss.VirtualMachineProfile == nil {
+		return ""
+	}
+	switch vmss.VirtualMachineProfile.Priority {
+	case compute.Spot, compute.Low:
+		return cloudinstances.LifecycleSpot
+	default:
+		return cloudinstances.LifecycleOnDemand
+	}
+}
+
 func isOwnedByCluster(vmss *compute.VirtualMachineScaleSet, clusterName string) bool {
 	for k, v := range vmss.Tags {
 		if k == TagClusterName && *v == clusterName {
@@ -27,6 +27,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/cloudinstances"
 )
 
 type mockVMScaleSetsClient struct {
@@ -176,6 +177,11 @@ func TestGetCloudGroups(t *testing.T) {
 			Sku: &compute.Sku{
 				Capacity: to.Int64Ptr(2),
 			},
+			VirtualMachineScaleSetProperties: &compute.VirtualMachineScaleSetProperties{
+				VirtualMachineProfile: &compute.VirtualMachineScaleSetVMProfile{
+					Priority: compute.Spot,
+				},
+			},
 		},
 	)
 
@@ -183,6 +189,16 @@ func TestGetCloudGroups(t *testing.T) {
 	vmClient.vms = append(vmClient.vms,
 		compute.VirtualMachineScaleSetVM{
 			Name: to.StringPtr(masterVM),
+			VirtualMachineScaleSetVMProperties: &compute.VirtualMachineScaleSetVMProperties{
+				ProvisioningState: to.StringPtr("Succeeded"),
+				InstanceView: &compute.VirtualMachineScaleSetVMInstanceView{
+					VMHealth: &compute.VirtualMachineHealthStatus{
+						Status: &compute.InstanceViewStatus{
+							Code: to.StringPtr("HealthState/healthy"),
+						},
+					},
+				},
+			},
 		},
 		compute.VirtualMachineScaleSetVM{
 			Name: to.StringPtr(nodeVM0),
@@ -279,6 +295,16 @@ func TestGetCloudGroups(t *testing.T) {
 	if a, e := group.MaxSize, 1; a != e {
 		t.Fatalf("expected min size %d, but got %d", e, a)
 	}
+	instance := group.Ready[0]
+	if a, e := instance.LifecycleState, "Succeeded"; a != e {
+		t.Errorf("expected lifecycle state %q, but got %q", e, a)
+	}
+	if a, e := instance.HealthStatus, "HealthState/healthy"; a != e {
+		t.Errorf("expected health status %q, but got %q", e, a)
+	}
+	if a, e := instance.Lifecycle, ""; a != e {
+		t.Errorf("expected lifecycle %q, but got %q", e, a)
+	}
 
 	group = groups[nodeIG]
 	if a, e := group.HumanName, nodeVMSS; a != e {
@@ -293,4 +319,7 @@ func TestGetCloudGroups(t *testing.T) {
 	if a, e := group.MaxSize, 2; a != e {
 		t.Fatalf("expected min size %d, but got %d", e, a)
 	}
+	if a, e := group.Ready[0].Lifecycle, cloudinstances.LifecycleSpot; a != e {
+		t.Errorf("expected lifecycle %q, but got %q", e, a)
+	}
 }
@@ -0,0 +1,111 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2020-06-01/resources"
+	"github.com/Azure/go-autorest/autorest"
+)
+
+// userAssignedIdentityAPIVersion is the version of the Microsoft.ManagedIdentity API
+// used to read user-assigned identities through the generic resources API.
+const userAssignedIdentityAPIVersion = "2018-11-30"
+
+// UserAssignedIdentityID contains the resource ID/names required to construct a user-assigned managed identity ID.
+type UserAssignedIdentityID struct {
+	SubscriptionID    string
+	ResourceGroupName string
+	IdentityName      string
+}
+
+// String returns the user-assigned managed identity ID in the path format.
+func (i *UserAssignedIdentityID) String() string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.ManagedIdentity/userAssignedIdentities/%s",
+		i.SubscriptionID,
+		i.ResourceGroupName,
+		i.IdentityName)
+}
+
+// ParseUserAssignedIdentityID parses a given user-assigned managed identity ID string and returns a UserAssignedIdentityID.
+func ParseUserAssignedIdentityID(s string) (*UserAssignedIdentityID, error) {
+	l := strings.Split(s, "/")
+	if len(l) != 9 || l[0] != "" ||
+		!strings.EqualFold(l[1], "subscriptions") ||
+		!strings.EqualFold(l[3], "resourceGroups") ||
+		!strings.EqualFold(l[5], "providers") ||
+		!strings.EqualFold(l[6], "Microsoft.ManagedIdentity") ||
+		!strings.EqualFold(l[7], "userAssignedIdentities") {
+		return nil, fmt.Errorf("malformed format of user-assigned identity ID: %s", s)
+	}
+	if l[2] == "" || l[4] == "" || l[8] == "" {
+		return nil, fmt.Errorf("malformed format of user-assigned identity ID: %s", s)
+	}
+	return &UserAssignedIdentityID{
+		SubscriptionID:    l[2],
+		ResourceGroupName: l[4],
+		IdentityName:      l[8],
+	}, nil
+}
+
+// UserAssignedIdentity is a user-assigned managed identity.
+type UserAssignedIdentity struct {
+	ID          string
+	PrincipalID string
+	ClientID    string
+}
+
+// UserAssignedIdentitiesClient is a client for reading user-assigned managed identities.
+type UserAssignedIdentitiesClient interface {
+	Get(ctx context.Context, id string) (*UserAssignedIdentity, error)
+}
+
+type userAssignedIdentitiesClientImpl struct {
+	c *resources.Client
+}
+
+var _ UserAssignedIdentitiesClient = &userAssignedIdentitiesClientImpl{}
+
+func (c *userAssignedIdentitiesClientImpl) Get(ctx context.Context, id string) (*UserAssignedIdentity, error) {
+	r, err := c.c.GetByID(ctx, id, userAssignedIdentityAPIVersion)
+	if err != nil {
+		return nil, err
+	}
+	identity := &UserAssignedIdentity{ID: id}
+	if r.ID != nil {
+		identity.ID = *r.ID
+	}
+	if props, ok := r.Properties.(map[string]interface{}); ok {
+		identity.PrincipalID, _ = props["principalId"].(string)
+		identity.ClientID, _ = props["clientId"].(string)
+	}
+	if identity.PrincipalID == "" {
+		return nil, fmt.Errorf("user-assigned identity %q has no principal ID", id)
+	}
+	return identity, nil
+}
+
+func newUserAssignedIdentitiesClientImpl(subscriptionID string, authorizer autorest.Authorizer) *userAssignedIdentitiesClientImpl {
+	c := resources.NewClient(subscriptionID)
+	c.Authorizer = authorizer
+	return &userAssignedIdentitiesClientImpl{
+		c: &c,
+	}
+}
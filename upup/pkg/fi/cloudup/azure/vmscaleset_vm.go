@@ -36,7 +36,7 @@ var _ VMScaleSetVMsClient = &vmScaleSetVMsClientImpl{}
 
 func (c *vmScaleSetVMsClientImpl) List(ctx context.Context, resourceGroupName, vmssName string) ([]compute.VirtualMachineScaleSetVM, error) {
 	var l []compute.VirtualMachineScaleSetVM
-	for iter, err := c.c.ListComplete(ctx, resourceGroupName, vmssName, "", "", ""); iter.NotDone(); err = iter.Next() {
+	for iter, err := c.c.ListComplete(ctx, resourceGroupName, vmssName, "", "", "instanceView"); iter.NotDone(); err = iter.Next() {
 		if err != nil {
 			return nil, err
 		}
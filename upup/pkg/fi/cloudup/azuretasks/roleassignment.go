@@ -100,7 +100,7 @@ func (r *RoleAssignment) Find(c *fi.Context) (*RoleAssignment, error) {
 	}
 	var foundVMSS *compute.VirtualMachineScaleSet
 	for _, v := range vs {
-		if v.Identity == nil {
+		if v.Identity == nil || v.Identity.PrincipalID == nil {
 			continue
 		}
 		if *v.Identity.PrincipalID == principalID {
@@ -55,9 +55,11 @@ type MockAzureCloud struct {
 	VMScaleSetVMsClient     *MockVMScaleSetVMsClient
 	DisksClient             *MockDisksClient
 	RoleAssignmentsClient   *MockRoleAssignmentsClient
+	IdentitiesClient        *MockUserAssignedIdentitiesClient
 	NetworkInterfacesClient *MockNetworkInterfacesClient
 	LoadBalancersClient     *MockLoadBalancersClient
 	PublicIPAddressesClient *MockPublicIPAddressesClient
+	ResourceSkusClient      *MockResourceSkusClient
 }
 
 var _ azure.AzureCloud = &MockAzureCloud{}
@@ -90,6 +92,9 @@ func NewMockAzureCloud(location string) *MockAzureCloud {
 		RoleAssignmentsClient: &MockRoleAssignmentsClient{
 			RAs: map[string]authz.RoleAssignment{},
 		},
+		IdentitiesClient: &MockUserAssignedIdentitiesClient{
+			Identities: map[string]azure.UserAssignedIdentity{},
+		},
 		NetworkInterfacesClient: &MockNetworkInterfacesClient{
 			NIs: map[string]network.Interface{},
 		},
@@ -99,6 +104,7 @@ func NewMockAzureCloud(location string) *MockAzureCloud {
 		PublicIPAddressesClient: &MockPublicIPAddressesClient{
 			PubIPs: map[string]network.PublicIPAddress{},
 		},
+		ResourceSkusClient: &MockResourceSkusClient{},
 	}
 }
 
@@ -215,6 +221,11 @@ func (c *MockAzureCloud) RoleAssignment() azure.RoleAssignmentsClient {
 	return c.RoleAssignmentsClient
 }
 
+// UserAssignedIdentity returns the user-assigned identity client.
+func (c *MockAzureCloud) UserAssignedIdentity() azure.UserAssignedIdentitiesClient {
+	return c.IdentitiesClient
+}
+
 // NetworkInterface returns the network interface client.
 func (c *MockAzureCloud) NetworkInterface() azure.NetworkInterfacesClient {
 	return c.NetworkInterfacesClient
@@ -230,6 +241,11 @@ func (c *MockAzureCloud) PublicIPAddress() azure.PublicIPAddressesClient {
 	return c.PublicIPAddressesClient
 }
 
+// ResourceSku returns the resource SKU client.
+func (c *MockAzureCloud) ResourceSku() azure.ResourceSkusClient {
+	return c.ResourceSkusClient
+}
+
 // MockResourceGroupsClient is a mock implementation of resource group client.
 type MockResourceGroupsClient struct {
 	RGs map[string]resources.Group
@@ -389,7 +405,9 @@ func (c *MockVMScaleSetsClient) CreateOrUpdate(ctx context.Context, resourceGrou
 		return nil, fmt.Errorf("update not supported")
 	}
 	parameters.Name = &vmScaleSetName
-	parameters.Identity.PrincipalID = fi.String(uuid.New().String())
+	if parameters.Identity.Type == compute.ResourceIdentityTypeSystemAssigned {
+		parameters.Identity.PrincipalID = fi.String(uuid.New().String())
+	}
 	c.VMSSes[vmScaleSetName] = parameters
 	return &parameters, nil
 }
@@ -515,6 +533,22 @@ func (c *MockRoleAssignmentsClient) Delete(ctx context.Context, scope, raName st
 	return nil
 }
 
+// MockUserAssignedIdentitiesClient is a mock implementation of user-assigned identity client.
+type MockUserAssignedIdentitiesClient struct {
+	Identities map[string]azure.UserAssignedIdentity
+}
+
+var _ azure.UserAssignedIdentitiesClient = &MockUserAssignedIdentitiesClient{}
+
+// Get returns a user-assigned identity.
+func (c *MockUserAssignedIdentitiesClient) Get(ctx context.Context, id string) (*azure.UserAssignedIdentity, error) {
+	identity, ok := c.Identities[id]
+	if !ok {
+		return nil, fmt.Errorf("%s does not exist", id)
+	}
+	return &identity, nil
+}
+
 // MockNetworkInterfacesClient is a mock implementation of network interfaces client.
 type MockNetworkInterfacesClient struct {
 	NIs map[string]network.Interface
@@ -603,3 +637,16 @@ func (c *MockPublicIPAddressesClient) Delete(ctx context.Context, scope, publicI
 	delete(c.PubIPs, publicIPAddressName)
 	return nil
 }
+
+// MockResourceSkusClient is a mock implementation of resource SKU client.
+type MockResourceSkusClient struct {
+	SKUs []compute.ResourceSku
+}
+
+var _ azure.ResourceSkusClient = &MockResourceSkusClient{}
+
+// List returns a slice of resource SKUs.
+func (c *MockResourceSkusClient) List(ctx context.Context, filter string) ([]compute.ResourceSku, error) {
+	// Ignore filter for simplicity.
+	return c.SKUs, nil
+}
@@ -118,6 +118,9 @@ type VMScaleSet struct {
 	Tags        map[string]*string
 	Zones       []string
 	PrincipalID *string
+	// UserAssignedIdentity is the resource ID of an existing user-assigned managed identity
+	// assigned to the VMs instead of a system-assigned one.
+	UserAssignedIdentity *string
 }
 
 // VMScaleSetStorageProfile wraps *compute.VirtualMachineScaleSetStorageProfile
@@ -237,6 +240,9 @@ func (s *VMScaleSet) Find(c *fi.Context) (*VMScaleSet, error) {
 	if found.Zones != nil {
 		vmss.Zones = *found.Zones
 	}
+	for id := range found.Identity.UserAssignedIdentities {
+		vmss.UserAssignedIdentity = to.StringPtr(id)
+	}
 	return vmss, nil
 }
 
@@ -376,6 +382,15 @@ func (s *VMScaleSet) RenderAzure(t *azure.AzureAPITarget, a, e, changes *VMScale
 		Tags:  e.Tags,
 		Zones: &e.Zones,
 	}
+	if e.UserAssignedIdentity != nil {
+		// Use the existing identity instead; its role assignments are managed outside of kOps.
+		vmss.Identity = &compute.VirtualMachineScaleSetIdentity{
+			Type: compute.ResourceIdentityTypeUserAssigned,
+			UserAssignedIdentities: map[string]*compute.VirtualMachineScaleSetIdentityUserAssignedIdentitiesValue{
+				*e.UserAssignedIdentity: {},
+			},
+		}
+	}
 
 	result, err := t.Cloud.VMScaleSet().CreateOrUpdate(
 		context.TODO(),
@@ -24,6 +24,7 @@ import (
 
 	channelsapi "k8s.io/kops/channels/pkg/api"
 	"k8s.io/kops/pkg/apis/kops"
+	apiModel "k8s.io/kops/pkg/apis/kops/model"
 	"k8s.io/kops/pkg/assets"
 	"k8s.io/kops/pkg/featureflag"
 	"k8s.io/kops/pkg/kubemanifest"
@@ -51,6 +52,7 @@ import (
 type BootstrapChannelBuilder struct {
 	*model.KopsModelContext
 	ClusterAddons kubemanifest.ObjectList
+	UserAddons    map[string][]byte
 	Lifecycle     fi.Lifecycle
 	templates     *templates.Templates
 	assetBuilder  *assets.AssetBuilder
@@ -86,6 +88,7 @@ func NewBootstrapChannelBuilder(modelContext *model.KopsModelContext,
 	clusterLifecycle fi.Lifecycle, assetBuilder *assets.AssetBuilder,
 	templates *templates.Templates,
 	addons kubemanifest.ObjectList,
+	userAddons map[string][]byte,
 ) *BootstrapChannelBuilder {
 	return &BootstrapChannelBuilder{
 		KopsModelContext: modelContext,
@@ -93,6 +96,7 @@ func NewBootstrapChannelBuilder(modelContext *model.KopsModelContext,
 		assetBuilder:     assetBuilder,
 		templates:        templates,
 		ClusterAddons:    addons,
+		UserAddons:       userAddons,
 	}
 }
 
@@ -209,7 +213,10 @@ func (b *BootstrapChannelBuilder) Build(c *fi.ModelBuilderContext) error {
 		}
 	}
 
-	if b.ClusterAddons != nil {
+	templatedAddons := make(map[*channelsapi.AddonSpec]*fitasks.ManagedFile)
+	clusterAddons, templatedClusterAddons := splitTemplatedObjects(b.ClusterAddons)
+
+	if b.ClusterAddons != nil && (len(clusterAddons) != 0 || len(templatedClusterAddons) == 0) {
 		key := "cluster-addons.kops.k8s.io"
 		location := key + "/default.yaml"
 
@@ -222,7 +229,7 @@ func (b *BootstrapChannelBuilder) Build(c *fi.ModelBuilderContext) error {
 		name := b.Cluster.ObjectMeta.Name + "-addons-" + key
 		manifestPath := "addons/" + *a.Manifest
 
-		manifestBytes, err := b.ClusterAddons.ToYAML()
+		manifestBytes, err := clusterAddons.ToYAML()
 		if err != nil {
 			return fmt.Errorf("error serializing addons: %v", err)
 		}
@@ -248,6 +255,46 @@ func (b *BootstrapChannelBuilder) Build(c *fi.ModelBuilderContext) error {
 		addons.Add(a)
 	}
 
+	if len(templatedClusterAddons) != 0 {
+		key := "cluster-addons-templated.kops.k8s.io"
+		location := key + "/default.yaml"
+
+		a := &channelsapi.AddonSpec{
+			Name:     fi.String(key),
+			Selector: map[string]string{"k8s-addon": key},
+			Manifest: fi.String(location),
+		}
+
+		name := b.Cluster.ObjectMeta.Name + "-addons-" + key
+		manifestPath := "addons/" + *a.Manifest
+
+		manifestBytes, err := templatedClusterAddons.ToYAML()
+		if err != nil {
+			return fmt.Errorf("error serializing templated addons: %v", err)
+		}
+
+		// The manifest hash is computed once the template has been rendered
+		manifestFile := &fitasks.ManagedFile{
+			Contents:  fi.NewBytesResource([]byte(strings.TrimSpace(string(manifestBytes)))),
+			Lifecycle: b.Lifecycle,
+			Location:  fi.String(manifestPath),
+			Name:      fi.String(name),
+			Template:  fi.Bool(true),
+		}
+		c.AddTask(manifestFile)
+
+		addons.Add(a)
+		templatedAddons[a] = manifestFile
+	}
+
+	if err := b.addCNIManifestAddon(c, addons); err != nil {
+		return err
+	}
+
+	if err := b.addUserAddons(c, addons); err != nil {
+		return err
+	}
+
 	if err := b.addPruneDirectives(addons); err != nil {
 		return err
 	}
@@ -263,15 +310,24 @@ func (b *BootstrapChannelBuilder) Build(c *fi.ModelBuilderContext) error {
 		return err
 	}
 
-	addonsYAML, err := utils.YamlMarshal(addonsObject)
-	if err != nil {
-		return fmt.Errorf("error serializing addons yaml: %v", err)
+	var addonsResource fi.Resource
+	if len(templatedAddons) != 0 {
+		addonsResource = &templatedAddonsResource{
+			addons:    addonsObject,
+			templated: templatedAddons,
+		}
+	} else {
+		addonsYAML, err := utils.YamlMarshal(addonsObject)
+		if err != nil {
+			return fmt.Errorf("error serializing addons yaml: %v", err)
+		}
+		addonsResource = fi.NewBytesResource(addonsYAML)
 	}
 
 	name := b.Cluster.ObjectMeta.Name + "-addons-bootstrap"
 
 	c.AddTask(&fitasks.ManagedFile{
-		Contents:  fi.NewBytesResource(addonsYAML),
+		Contents:  addonsResource,
 		Lifecycle: b.Lifecycle,
 		Location:  fi.String("addons/bootstrap-channel.yaml"),
 		Name:      fi.String(name),
@@ -628,6 +684,61 @@ func (b *BootstrapChannelBuilder) buildAddons(c *fi.ModelBuilderContext) (*Addon
 		}
 	}
 
+	if b.Cluster.Spec.KubeAPIServer != nil && len(b.Cluster.Spec.KubeAPIServer.FlowSchemaPresets) > 0 {
+		key := "apiserver-flowcontrol.addons.k8s.io"
+
+		{
+			location := key + "/k8s-1.20.yaml"
+			id := "k8s-1.20"
+
+			addons.Add(&channelsapi.AddonSpec{
+				Name:     fi.String(key),
+				Selector: map[string]string{"k8s-addon": key},
+				Manifest: fi.String(location),
+				Id:       id,
+			})
+		}
+	}
+
+	nodeExporter := false
+	cloudWatchAgent := false
+	for _, ig := range b.KopsModelContext.InstanceGroups {
+		nodeExporter = nodeExporter || apiModel.NodeExporterEnabled(b.Cluster, ig)
+		cloudWatchAgent = cloudWatchAgent || apiModel.CloudWatchAgentEnabled(b.Cluster, ig)
+	}
+
+	if nodeExporter {
+		key := "node-exporter.addons.k8s.io"
+
+		{
+			location := key + "/k8s-1.17.yaml"
+			id := "k8s-1.17"
+
+			addons.Add(&channelsapi.AddonSpec{
+				Name:     fi.String(key),
+				Selector: map[string]string{"k8s-addon": key},
+				Manifest: fi.String(location),
+				Id:       id,
+			})
+		}
+	}
+
+	if cloudWatchAgent {
+		key := "cloudwatch-agent.addons.k8s.io"
+
+		{
+			location := key + "/k8s-1.17.yaml"
+			id := "k8s-1.17"
+
+			addons.Add(&channelsapi.AddonSpec{
+				Name:     fi.String(key),
+				Selector: map[string]string{"k8s-addon": key},
+				Manifest: fi.String(location),
+				Id:       id,
+			})
+		}
+	}
+
 	nvidia := b.Cluster.Spec.Containerd.NvidiaGPU
 	igNvidia := false
 	for _, ig := range b.KopsModelContext.InstanceGroups {
@@ -695,6 +806,22 @@ func (b *BootstrapChannelBuilder) buildAddons(c *fi.ModelBuilderContext) (*Addon
 		}
 	}
 
+	if b.Cluster.Spec.GetCloudProvider() == kops.CloudProviderGCE && b.useGCPWorkloadIdentity() {
+		key := "gcp-workload-identity.addons.k8s.io"
+
+		{
+			id := "k8s-1.16"
+			location := key + "/" + id + ".yaml"
+
+			addons.Add(&channelsapi.AddonSpec{
+				Name:     fi.String(key),
+				Selector: map[string]string{"k8s-addon": key},
+				Manifest: fi.String(location),
+				Id:       id,
+			})
+		}
+	}
+
 	if b.Cluster.Spec.GetCloudProvider() == kops.CloudProviderAWS {
 		key := "storage-aws.addons.k8s.io"
 
@@ -1162,3 +1289,17 @@ func (b *BootstrapChannelBuilder) buildAddons(c *fi.ModelBuilderContext) (*Addon
 	}
 	return addons, nil
 }
+
+// useGCPWorkloadIdentity returns true if any ServiceAccount is granted GCP permissions through a workload identity provider
+func (b *BootstrapChannelBuilder) useGCPWorkloadIdentity() bool {
+	said := b.Cluster.Spec.ServiceAccountIssuerDiscovery
+	if b.Cluster.Spec.IAM == nil || said == nil || said.GCPWorkloadIdentityProvider == "" {
+		return false
+	}
+	for _, sa := range b.Cluster.Spec.IAM.ServiceAccountExternalPermissions {
+		if sa.GCP != nil {
+			return true
+		}
+	}
+	return false
+}
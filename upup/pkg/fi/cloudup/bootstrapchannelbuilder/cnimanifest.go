@@ -0,0 +1,115 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrapchannelbuilder
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"k8s.io/klog/v2"
+	channelsapi "k8s.io/kops/channels/pkg/api"
+	"k8s.io/kops/pkg/model/components/addonmanifests"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/fitasks"
+	"k8s.io/kops/upup/pkg/fi/utils"
+	"k8s.io/kops/util/pkg/hashing"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+// cniManifestAddonKey is the name of the addon that installs the manifest set in spec.networking.cni.manifestURL.
+const cniManifestAddonKey = "networking.cni"
+
+// addCNIManifestAddon installs the CNI manifest supplied by the user as a networking addon.
+// The manifest is mirrored to the file repository like other file assets, and its hash is verified before it is used.
+func (b *BootstrapChannelBuilder) addCNIManifestAddon(c *fi.ModelBuilderContext, addons *AddonList) error {
+	cni := b.Cluster.Spec.Networking.CNI
+	if cni == nil || cni.ManifestURL == "" {
+		return nil
+	}
+
+	canonicalURL, err := url.Parse(cni.ManifestURL)
+	if err != nil {
+		return fmt.Errorf("unable to parse CNI manifest URL %q: %v", cni.ManifestURL, err)
+	}
+
+	downloadURL, err := b.assetBuilder.RemapFileAndSHAValue(canonicalURL, cni.ManifestSHA256)
+	if err != nil {
+		return err
+	}
+
+	// When copying assets, the manifest is not yet in the file repository
+	location := downloadURL.String()
+	if b.assetBuilder.GetAssets {
+		location = canonicalURL.String()
+	}
+
+	klog.V(2).Infof("Reading CNI manifest from %q", location)
+	manifestBytes, err := vfs.Context.ReadFile(location)
+	if err != nil {
+		return fmt.Errorf("error reading CNI manifest %q: %v", location, err)
+	}
+
+	expected, err := hashing.HashAlgorithmSHA256.FromString(cni.ManifestSHA256)
+	if err != nil {
+		return fmt.Errorf("invalid hash for CNI manifest: %v", err)
+	}
+	actual, err := hashing.HashAlgorithmSHA256.Hash(bytes.NewReader(manifestBytes))
+	if err != nil {
+		return fmt.Errorf("error hashing CNI manifest: %v", err)
+	}
+	if !actual.Equal(expected) {
+		return fmt.Errorf("CNI manifest %q has hash %s, expected %s", location, actual.Hex(), expected.Hex())
+	}
+
+	key := cniManifestAddonKey
+	a := &channelsapi.AddonSpec{
+		Name:     fi.String(key),
+		Selector: networkingSelector(),
+		Manifest: fi.String(key + "/default.yaml"),
+	}
+
+	manifestPath := "addons/" + *a.Manifest
+
+	manifestBytes, err = addonmanifests.RemapAddonManifest(a, b.KopsModelContext, b.assetBuilder, manifestBytes)
+	if err != nil {
+		return fmt.Errorf("error remapping CNI manifest: %v", err)
+	}
+
+	// Trim whitespace
+	manifestBytes = []byte(strings.TrimSpace(string(manifestBytes)))
+
+	manifestHash, err := utils.HashString(string(manifestBytes))
+	if err != nil {
+		return fmt.Errorf("error hashing manifest: %v", err)
+	}
+	a.ManifestHash = manifestHash
+
+	c.AddTask(&fitasks.ManagedFile{
+		Contents:  fi.NewBytesResource(manifestBytes),
+		Lifecycle: b.Lifecycle,
+		Location:  fi.String(manifestPath),
+		Name:      fi.String(b.Cluster.ObjectMeta.Name + "-addons-" + key),
+	})
+
+	addon := addons.Add(a)
+	addon.ManifestData = manifestBytes
+	addon.BuildPrune = true
+
+	return nil
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrapchannelbuilder
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	channelsapi "k8s.io/kops/channels/pkg/api"
+	"k8s.io/kops/pkg/kubemanifest"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/fitasks"
+	"k8s.io/kops/upup/pkg/fi/utils"
+)
+
+// TemplateAnnotation marks a cluster addon object as a Go template, rendered by fitasks.ManagedFile when the cluster is updated.
+const TemplateAnnotation = "addons.kops.k8s.io/template"
+
+// splitTemplatedObjects separates the objects annotated as templates from the others.
+func splitTemplatedObjects(objects kubemanifest.ObjectList) (kubemanifest.ObjectList, kubemanifest.ObjectList) {
+	var plain, templated kubemanifest.ObjectList
+	for _, object := range objects {
+		if object.ToUnstructured().GetAnnotations()[TemplateAnnotation] == "true" {
+			templated = append(templated, object)
+		} else {
+			plain = append(plain, object)
+		}
+	}
+	return plain, templated
+}
+
+// templatedAddonsResource renders the bootstrap channel after the templated addon manifests have been rendered,
+// so that their manifest hashes change whenever the values used by the templates change.
+type templatedAddonsResource struct {
+	addons    *channelsapi.Addons
+	templated map[*channelsapi.AddonSpec]*fitasks.ManagedFile
+}
+
+var (
+	_ fi.Resource        = &templatedAddonsResource{}
+	_ fi.HasDependencies = &templatedAddonsResource{}
+)
+
+func (r *templatedAddonsResource) Open() (io.Reader, error) {
+	for addon, manifestFile := range r.templated {
+		manifest, err := manifestFile.TemplatedContents()
+		if err != nil {
+			return nil, err
+		}
+		manifestHash, err := utils.HashString(strings.TrimSpace(string(manifest)))
+		if err != nil {
+			return nil, fmt.Errorf("error hashing manifest: %v", err)
+		}
+		addon.ManifestHash = manifestHash
+	}
+
+	addonsYAML, err := utils.YamlMarshal(r.addons)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing addons yaml: %v", err)
+	}
+	return bytes.NewReader(addonsYAML), nil
+}
+
+func (r *templatedAddonsResource) GetDependencies(tasks map[string]fi.Task) []fi.Task {
+	var deps []fi.Task
+	for _, manifestFile := range r.templated {
+		deps = append(deps, manifestFile)
+	}
+	return deps
+}
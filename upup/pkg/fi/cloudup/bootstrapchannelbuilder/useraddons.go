@@ -0,0 +1,88 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrapchannelbuilder
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	channelsapi "k8s.io/kops/channels/pkg/api"
+	"k8s.io/kops/pkg/model/components/addonmanifests"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/fitasks"
+	"k8s.io/kops/upup/pkg/fi/utils"
+)
+
+// userAddonSuffix is appended to the name of the manifests stored under addons/user/ to build the addon name.
+const userAddonSuffix = ".user.addons.kops.k8s.io"
+
+// addUserAddons registers the manifests stored under addons/user/ in the state store as addons of the bootstrap channel.
+// They are labelled and pruned like the addons managed by kops, so objects removed from a manifest are removed from the cluster.
+func (b *BootstrapChannelBuilder) addUserAddons(c *fi.ModelBuilderContext, addons *AddonList) error {
+	var names []string
+	for name := range b.UserAddons {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if errs := validation.IsDNS1123Label(name); len(errs) != 0 {
+			return fmt.Errorf("invalid name for user addon %q: %s", name, strings.Join(errs, ", "))
+		}
+
+		key := name + userAddonSuffix
+		location := key + "/default.yaml"
+
+		a := &channelsapi.AddonSpec{
+			Name:     fi.String(key),
+			Selector: map[string]string{"k8s-addon": key},
+			Manifest: fi.String(location),
+		}
+
+		manifestPath := "addons/" + location
+
+		manifestBytes, err := addonmanifests.RemapAddonManifest(a, b.KopsModelContext, b.assetBuilder, b.UserAddons[name])
+		if err != nil {
+			return fmt.Errorf("error remapping user addon %s: %v", name, err)
+		}
+
+		// Trim whitespace
+		manifestBytes = []byte(strings.TrimSpace(string(manifestBytes)))
+
+		manifestHash, err := utils.HashString(string(manifestBytes))
+		if err != nil {
+			return fmt.Errorf("error hashing manifest: %v", err)
+		}
+		a.ManifestHash = manifestHash
+
+		c.AddTask(&fitasks.ManagedFile{
+			Contents:  fi.NewBytesResource(manifestBytes),
+			Lifecycle: b.Lifecycle,
+			Location:  fi.String(manifestPath),
+			Name:      fi.String(b.Cluster.ObjectMeta.Name + "-addons-" + key),
+		})
+
+		addon := addons.Add(a)
+		addon.ManifestData = manifestBytes
+		addon.BuildPrune = true
+	}
+
+	return nil
+}
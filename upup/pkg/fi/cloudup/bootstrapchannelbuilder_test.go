@@ -17,10 +17,13 @@ limitations under the License.
 package cloudup
 
 import (
+	"bytes"
 	"os"
 	"path"
 	"testing"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	kopsapi "k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/assets"
 	"k8s.io/kops/pkg/client/simple/vfsclientset"
@@ -47,6 +50,8 @@ func TestBootstrapChannelBuilder_BuildTasks(t *testing.T) {
 	runChannelBuilderTest(t, "simple", []string{"kops-controller.addons.k8s.io-k8s-1.16"})
 	// Use cilium networking, proxy
 	runChannelBuilderTest(t, "cilium", []string{"kops-controller.addons.k8s.io-k8s-1.16"})
+	runChannelBuilderTest(t, "cilium-eni", []string{"networking.cilium.io-k8s-1.16"})
+	runChannelBuilderTest(t, "cilium-gateway-api", []string{"networking.cilium.io-k8s-1.16"})
 	runChannelBuilderTest(t, "weave", []string{})
 	runChannelBuilderTest(t, "amazonvpc", []string{"networking.amazon-vpc-routed-eni-k8s-1.16"})
 	runChannelBuilderTest(t, "amazonvpc-containerd", []string{"networking.amazon-vpc-routed-eni-k8s-1.16"})
@@ -55,6 +60,11 @@ func TestBootstrapChannelBuilder_BuildTasks(t *testing.T) {
 	runChannelBuilderTest(t, "metrics-server/insecure-1.19", []string{"metrics-server.addons.k8s.io-k8s-1.11"})
 	runChannelBuilderTest(t, "metrics-server/secure-1.19", []string{"metrics-server.addons.k8s.io-k8s-1.11"})
 	runChannelBuilderTest(t, "coredns", []string{"coredns.addons.k8s.io-k8s-1.12"})
+	runChannelBuilderTest(t, "nodelocaldns", []string{"nodelocaldns.addons.k8s.io-k8s-1.12"})
+	runChannelBuilderTest(t, "monitoring", []string{"node-exporter.addons.k8s.io-k8s-1.17", "cloudwatch-agent.addons.k8s.io-k8s-1.17"})
+	runChannelBuilderTest(t, "flowcontrol", []string{"apiserver-flowcontrol.addons.k8s.io-k8s-1.20"})
+	runChannelBuilderTest(t, "useraddons", []string{"example.user.addons.kops.k8s.io"})
+	runChannelBuilderTest(t, "cni-manifest", []string{"networking.cni"})
 }
 
 func TestBootstrapChannelBuilder_ServiceAccountIAM(t *testing.T) {
@@ -126,6 +136,34 @@ func runChannelBuilderTest(t *testing.T, key string, addonManifests []string) {
 	if err != nil {
 		t.Error(err)
 	}
+
+	// Any manifests in the user directory are stored as user addons
+	userAddonFiles, err := os.ReadDir(path.Join(basedir, "user"))
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatalf("error reading user addons: %v", err)
+	}
+	for _, f := range userAddonFiles {
+		b, err := os.ReadFile(path.Join(basedir, "user", f.Name()))
+		if err != nil {
+			t.Fatalf("error reading user addon %q: %v", f.Name(), err)
+		}
+		if err := basePath.Join(cluster.ObjectMeta.Name, "addons", "user", f.Name()).WriteFile(bytes.NewReader(b), nil); err != nil {
+			t.Fatalf("error writing user addon %q: %v", f.Name(), err)
+		}
+	}
+	// A CNI manifest is served from the memfs location set in spec.networking.cni.manifestURL
+	if b, err := os.ReadFile(path.Join(basedir, "cni.yaml")); err == nil {
+		if err := basePath.Join("cni-manifest.yaml").WriteFile(bytes.NewReader(b), nil); err != nil {
+			t.Fatalf("error writing CNI manifest: %v", err)
+		}
+	} else if !os.IsNotExist(err) {
+		t.Fatalf("error reading CNI manifest: %v", err)
+	}
+
+	userAddons, err := clientset.AddonsFor(cluster).ListUserAddons()
+	if err != nil {
+		t.Fatalf("error listing user addons: %v", err)
+	}
 	role := "arn:aws:iam::1234567890108:instance-profile/kops-custom-node-role"
 	kopsModel := model.KopsModelContext{
 		IAMModelContext: iam.IAMModelContext{
@@ -136,6 +174,9 @@ func runChannelBuilderTest(t *testing.T, key string, addonManifests []string) {
 		Region: "us-east-1",
 		InstanceGroups: []*kopsapi.InstanceGroup{
 			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "nodes-custom-role",
+				},
 				Spec: kopsapi.InstanceGroupSpec{
 					IAM: &kopsapi.IAMProfileSpec{
 						Profile: &role,
@@ -144,6 +185,9 @@ func runChannelBuilderTest(t *testing.T, key string, addonManifests []string) {
 				},
 			},
 			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "nodes",
+				},
 				Spec: kopsapi.InstanceGroupSpec{
 					Role: kopsapi.InstanceGroupRoleNode,
 				},
@@ -163,6 +207,7 @@ func runChannelBuilderTest(t *testing.T, key string, addonManifests []string) {
 		assets.NewAssetBuilder(cluster, false),
 		templates,
 		nil,
+		userAddons,
 	)
 
 	context := &fi.ModelBuilderContext{
@@ -0,0 +1,336 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudformation
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// MaxTemplateResources is the maximum number of resources CloudFormation allows in a single template
+const MaxTemplateResources = 500
+
+const (
+	// stackNetwork holds the VPC, subnets, routing and security groups
+	stackNetwork = "network"
+	// stackIAM holds the IAM roles, policies and instance profiles
+	stackIAM = "iam"
+	// stackCluster holds everything that is not in one of the other stacks (load balancers, DNS, volumes, ...)
+	stackCluster = "cluster"
+	// stackInstanceGroupPrefix is the prefix for the per-instancegroup stacks
+	stackInstanceGroupPrefix = "instancegroup-"
+)
+
+// nestedStack is one of the templates we render when splitting the output into nested stacks
+type nestedStack struct {
+	name string
+
+	resources  map[string]interface{}
+	parameters map[string]interface{}
+	outputs    map[string]interface{}
+
+	// parameterValues maps each of our parameters to the stack & output that supplies it
+	parameterValues map[string]stackOutputRef
+}
+
+// stackOutputRef identifies an output of a nested stack
+type stackOutputRef struct {
+	stack  string
+	output string
+}
+
+func newNestedStack(name string) *nestedStack {
+	return &nestedStack{
+		name:            name,
+		resources:       make(map[string]interface{}),
+		parameters:      make(map[string]interface{}),
+		outputs:         make(map[string]interface{}),
+		parameterValues: make(map[string]stackOutputRef),
+	}
+}
+
+// logicalID is the logical id of the AWS::CloudFormation::Stack resource in the root template
+func (s *nestedStack) logicalID() string {
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(s.name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String() + "Stack"
+}
+
+// templatePath is the path of the nested template, relative to the root template
+func (s *nestedStack) templatePath() string {
+	return path.Join("stacks", s.name+".json")
+}
+
+func (s *nestedStack) template() map[string]interface{} {
+	template := map[string]interface{}{
+		"Resources": s.resources,
+	}
+	if len(s.parameters) != 0 {
+		template["Parameters"] = s.parameters
+	}
+	if len(s.outputs) != 0 {
+		template["Outputs"] = s.outputs
+	}
+	return template
+}
+
+// assignStacks decides which nested stack each resource is rendered into
+func assignStacks(resources map[string]*cloudformationResource, refs map[string][]string) map[string]string {
+	stacks := make(map[string]string, len(resources))
+
+	// Instance groups are anchored on their AutoScalingGroup
+	for id, r := range resources {
+		if r.Type == "AWS::AutoScaling::AutoScalingGroup" {
+			stacks[id] = stackInstanceGroupPrefix + r.name
+		}
+	}
+
+	for id, r := range resources {
+		if stacks[id] != "" {
+			continue
+		}
+
+		switch {
+		case r.Type == "AWS::EC2::LaunchTemplate":
+			// The launch template belongs with the AutoScalingGroup that uses it
+			stacks[id] = stackCluster
+			for asgID, asgRefs := range refs {
+				if resources[asgID].Type == "AWS::AutoScaling::AutoScalingGroup" && containsString(asgRefs, id) {
+					stacks[id] = stacks[asgID]
+					break
+				}
+			}
+		case r.Type == "AWS::AutoScaling::LifecycleHook" || r.Type == "AWS::AutoScaling::WarmPool":
+			// Hooks & warm pools belong with the AutoScalingGroup they reference
+			stacks[id] = stackCluster
+			for _, ref := range refs[id] {
+				if resources[ref].Type == "AWS::AutoScaling::AutoScalingGroup" {
+					stacks[id] = stacks[ref]
+					break
+				}
+			}
+		case r.Type == "AWS::EC2::Volume":
+			stacks[id] = stackCluster
+		case strings.HasPrefix(r.Type, "AWS::EC2::"):
+			stacks[id] = stackNetwork
+		case strings.HasPrefix(r.Type, "AWS::IAM::"):
+			stacks[id] = stackIAM
+		default:
+			stacks[id] = stackCluster
+		}
+	}
+
+	return stacks
+}
+
+// buildNestedStacks splits the resources into nested stacks, wiring references between stacks
+// through outputs of the referenced stack and parameters of the referencing stack.
+func buildNestedStacks(resources map[string]*cloudformationResource) (map[string]*nestedStack, error) {
+	// We operate on the json form, so that we can find & rewrite references
+	generic := make(map[string]map[string]interface{}, len(resources))
+	refs := make(map[string][]string, len(resources))
+	for id, r := range resources {
+		b, err := json.Marshal(r)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling cloudformation resource %q: %v", id, err)
+		}
+		var m map[string]interface{}
+		if err := json.Unmarshal(b, &m); err != nil {
+			return nil, fmt.Errorf("error unmarshaling cloudformation resource %q: %v", id, err)
+		}
+		generic[id] = m
+
+		walkReferences(m, func(ref string, attribute string) interface{} {
+			if resources[ref] != nil {
+				refs[id] = append(refs[id], ref)
+			}
+			return nil
+		})
+	}
+
+	assignments := assignStacks(resources, refs)
+
+	stacks := make(map[string]*nestedStack)
+	for id, stackName := range assignments {
+		stack := stacks[stackName]
+		if stack == nil {
+			stack = newNestedStack(stackName)
+			stacks[stackName] = stack
+		}
+
+		resource := walkReferences(generic[id], func(ref string, attribute string) interface{} {
+			owner := assignments[ref]
+			if owner == "" || owner == stackName {
+				return nil
+			}
+
+			name := ref + sanitizeCloudformationResourceName(attribute)
+			var value interface{}
+			if attribute == "" {
+				value = map[string]interface{}{"Ref": ref}
+			} else {
+				value = map[string]interface{}{"Fn::GetAtt": []interface{}{ref, attribute}}
+			}
+
+			producer := stacks[owner]
+			if producer == nil {
+				producer = newNestedStack(owner)
+				stacks[owner] = producer
+			}
+			producer.outputs[name] = map[string]interface{}{"Value": value}
+
+			stack.parameters[name] = map[string]interface{}{"Type": "String"}
+			stack.parameterValues[name] = stackOutputRef{stack: owner, output: name}
+
+			return map[string]interface{}{"Ref": name}
+		})
+		stack.resources[id] = resource
+	}
+
+	if err := checkStackCycles(stacks); err != nil {
+		return nil, err
+	}
+
+	return stacks, nil
+}
+
+// buildRootTemplate builds the template that creates each of the nested stacks
+func buildRootTemplate(stacks map[string]*nestedStack) map[string]interface{} {
+	resources := make(map[string]interface{}, len(stacks))
+	for _, stack := range stacks {
+		properties := map[string]interface{}{
+			"TemplateURL": stack.templatePath(),
+		}
+		if len(stack.parameterValues) != 0 {
+			parameters := make(map[string]interface{}, len(stack.parameterValues))
+			for name, ref := range stack.parameterValues {
+				parameters[name] = map[string]interface{}{
+					"Fn::GetAtt": []interface{}{stacks[ref.stack].logicalID(), "Outputs." + ref.output},
+				}
+			}
+			properties["Parameters"] = parameters
+		}
+		resources[stack.logicalID()] = map[string]interface{}{
+			"Type":       "AWS::CloudFormation::Stack",
+			"Properties": properties,
+		}
+	}
+	return map[string]interface{}{
+		"Resources": resources,
+	}
+}
+
+// checkStackCycles returns an error if the nested stacks depend on each other in a cycle, which CloudFormation would reject
+func checkStackCycles(stacks map[string]*nestedStack) error {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int)
+
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch state[name] {
+		case visiting:
+			return fmt.Errorf("cannot split cloudformation output into nested stacks; stacks depend on each other: %s", strings.Join(append(path, name), " -> "))
+		case visited:
+			return nil
+		}
+		state[name] = visiting
+
+		var deps []string
+		for _, ref := range stacks[name].parameterValues {
+			deps = append(deps, ref.stack)
+		}
+		sort.Strings(deps)
+		for _, dep := range deps {
+			if err := visit(dep, append(path, name)); err != nil {
+				return err
+			}
+		}
+
+		state[name] = visited
+		return nil
+	}
+
+	var names []string
+	for name := range stacks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := visit(name, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// walkReferences calls fn for every Ref and Fn::GetAtt in the json value v.
+// If fn returns a non-nil value, the reference is replaced with that value in the returned copy of v.
+func walkReferences(v interface{}, fn func(ref string, attribute string) interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if len(v) == 1 {
+			if ref, ok := v["Ref"].(string); ok {
+				if replacement := fn(ref, ""); replacement != nil {
+					return replacement
+				}
+				return v
+			}
+			if getAtt, ok := v["Fn::GetAtt"].([]interface{}); ok && len(getAtt) == 2 {
+				ref, _ := getAtt[0].(string)
+				attribute, _ := getAtt[1].(string)
+				if replacement := fn(ref, attribute); replacement != nil {
+					return replacement
+				}
+				return v
+			}
+		}
+		out := make(map[string]interface{}, len(v))
+		for k, child := range v {
+			out[k] = walkReferences(child, fn)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, child := range v {
+			out[i] = walkReferences(child, fn)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+func containsString(l []string, s string) bool {
+	for _, v := range l {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudformation
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestBuildNestedStacks(t *testing.T) {
+	target := NewCloudformationTarget(nil, "", "")
+
+	render := func(resourceType, resourceName string, properties map[string]interface{}) {
+		if err := target.RenderResource(resourceType, resourceName, properties); err != nil {
+			t.Fatalf("error rendering %s %s: %v", resourceType, resourceName, err)
+		}
+	}
+
+	render("AWS::EC2::VPC", "minimal.example.com", map[string]interface{}{
+		"CidrBlock": LiteralString("172.20.0.0/16"),
+	})
+	render("AWS::EC2::Subnet", "us-test-1a.minimal.example.com", map[string]interface{}{
+		"VpcId": Ref("AWS::EC2::VPC", "minimal.example.com"),
+	})
+	render("AWS::EC2::SecurityGroup", "nodes.minimal.example.com", map[string]interface{}{
+		"VpcId": Ref("AWS::EC2::VPC", "minimal.example.com"),
+	})
+	render("AWS::IAM::Role", "nodes.minimal.example.com", map[string]interface{}{
+		"RoleName": LiteralString("nodes.minimal.example.com"),
+	})
+	render("AWS::IAM::InstanceProfile", "nodes.minimal.example.com", map[string]interface{}{
+		"Roles": []*Literal{Ref("AWS::IAM::Role", "nodes.minimal.example.com")},
+	})
+	render("AWS::EC2::LaunchTemplate", "nodes.minimal.example.com", map[string]interface{}{
+		"IamInstanceProfile": GetAtt("AWS::IAM::InstanceProfile", "nodes.minimal.example.com", "Arn"),
+		"SecurityGroupIds":   []*Literal{Ref("AWS::EC2::SecurityGroup", "nodes.minimal.example.com")},
+	})
+	render("AWS::AutoScaling::AutoScalingGroup", "nodes.minimal.example.com", map[string]interface{}{
+		"LaunchTemplateId": Ref("AWS::EC2::LaunchTemplate", "nodes.minimal.example.com"),
+		"Version":          GetAtt("AWS::EC2::LaunchTemplate", "nodes.minimal.example.com", "LatestVersionNumber"),
+		"VPCZoneIdentifier": []*Literal{
+			Ref("AWS::EC2::Subnet", "us-test-1a.minimal.example.com"),
+		},
+		"TargetGroupARNs": []*Literal{Ref("AWS::ElasticLoadBalancingV2::TargetGroup", "tcp-minimal-example-com")},
+	})
+	render("AWS::AutoScaling::LifecycleHook", "nodes-NTHLifecycleHook", map[string]interface{}{
+		"AutoScalingGroupName": Ref("AWS::AutoScaling::AutoScalingGroup", "nodes.minimal.example.com"),
+	})
+	render("AWS::ElasticLoadBalancingV2::TargetGroup", "tcp-minimal-example-com", map[string]interface{}{
+		"VpcId": Ref("AWS::EC2::VPC", "minimal.example.com"),
+	})
+
+	stacks, err := buildNestedStacks(target.resources)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedResources := map[string][]string{
+		"cluster": {
+			"AWSElasticLoadBalancingV2TargetGrouptcpminimalexamplecom",
+		},
+		"iam": {
+			"AWSIAMInstanceProfilenodesminimalexamplecom",
+			"AWSIAMRolenodesminimalexamplecom",
+		},
+		"instancegroup-nodes.minimal.example.com": {
+			"AWSAutoScalingAutoScalingGroupnodesminimalexamplecom",
+			"AWSAutoScalingLifecycleHooknodesNTHLifecycleHook",
+			"AWSEC2LaunchTemplatenodesminimalexamplecom",
+		},
+		"network": {
+			"AWSEC2SecurityGroupnodesminimalexamplecom",
+			"AWSEC2Subnetustest1aminimalexamplecom",
+			"AWSEC2VPCminimalexamplecom",
+		},
+	}
+	actualResources := make(map[string][]string)
+	for name, stack := range stacks {
+		actualResources[name] = keys(stack.resources)
+	}
+	if !reflect.DeepEqual(actualResources, expectedResources) {
+		t.Fatalf("unexpected stack resources\nactual:   %v\nexpected: %v", actualResources, expectedResources)
+	}
+
+	ig := stacks["instancegroup-nodes.minimal.example.com"]
+	expectedParameters := []string{
+		"AWSEC2SecurityGroupnodesminimalexamplecom",
+		"AWSEC2Subnetustest1aminimalexamplecom",
+		"AWSElasticLoadBalancingV2TargetGrouptcpminimalexamplecom",
+		"AWSIAMInstanceProfilenodesminimalexamplecomArn",
+	}
+	if actual := keys(ig.parameters); !reflect.DeepEqual(actual, expectedParameters) {
+		t.Errorf("unexpected instancegroup parameters %v, expected %v", actual, expectedParameters)
+	}
+
+	if actual := keys(stacks["iam"].outputs); !reflect.DeepEqual(actual, []string{"AWSIAMInstanceProfilenodesminimalexamplecomArn"}) {
+		t.Errorf("unexpected iam outputs %v", actual)
+	}
+
+	// References within a stack are left alone, references to other stacks use parameters
+	asg, err := json.Marshal(ig.resources["AWSAutoScalingAutoScalingGroupnodesminimalexamplecom"])
+	if err != nil {
+		t.Fatalf("error marshaling: %v", err)
+	}
+	expectedASG := `{"Properties":{"LaunchTemplateId":{"Ref":"AWSEC2LaunchTemplatenodesminimalexamplecom"},` +
+		`"TargetGroupARNs":[{"Ref":"AWSElasticLoadBalancingV2TargetGrouptcpminimalexamplecom"}],` +
+		`"VPCZoneIdentifier":[{"Ref":"AWSEC2Subnetustest1aminimalexamplecom"}],` +
+		`"Version":{"Fn::GetAtt":["AWSEC2LaunchTemplatenodesminimalexamplecom","LatestVersionNumber"]}},` +
+		`"Type":"AWS::AutoScaling::AutoScalingGroup"}`
+	if string(asg) != expectedASG {
+		t.Errorf("unexpected autoscaling group\nactual:   %s\nexpected: %s", asg, expectedASG)
+	}
+
+	lt, err := json.Marshal(ig.resources["AWSEC2LaunchTemplatenodesminimalexamplecom"])
+	if err != nil {
+		t.Fatalf("error marshaling: %v", err)
+	}
+	expectedLT := `{"Properties":{"IamInstanceProfile":{"Ref":"AWSIAMInstanceProfilenodesminimalexamplecomArn"},` +
+		`"SecurityGroupIds":[{"Ref":"AWSEC2SecurityGroupnodesminimalexamplecom"}]},` +
+		`"Type":"AWS::EC2::LaunchTemplate"}`
+	if string(lt) != expectedLT {
+		t.Errorf("unexpected launch template\nactual:   %s\nexpected: %s", lt, expectedLT)
+	}
+
+	root, err := json.Marshal(buildRootTemplate(stacks))
+	if err != nil {
+		t.Fatalf("error marshaling: %v", err)
+	}
+	var rootTemplate struct {
+		Resources map[string]struct {
+			Type       string
+			Properties struct {
+				TemplateURL string
+				Parameters  map[string]interface{}
+			}
+		}
+	}
+	if err := json.Unmarshal(root, &rootTemplate); err != nil {
+		t.Fatalf("error unmarshaling: %v", err)
+	}
+	igStack := rootTemplate.Resources["InstancegroupNodesMinimalExampleComStack"]
+	if igStack.Type != "AWS::CloudFormation::Stack" {
+		t.Errorf("unexpected type %q for instancegroup stack", igStack.Type)
+	}
+	if igStack.Properties.TemplateURL != "stacks/instancegroup-nodes.minimal.example.com.json" {
+		t.Errorf("unexpected TemplateURL %q", igStack.Properties.TemplateURL)
+	}
+	expectedValue := map[string]interface{}{
+		"Fn::GetAtt": []interface{}{"IamStack", "Outputs.AWSIAMInstanceProfilenodesminimalexamplecomArn"},
+	}
+	if actual := igStack.Properties.Parameters["AWSIAMInstanceProfilenodesminimalexamplecomArn"]; !reflect.DeepEqual(actual, expectedValue) {
+		t.Errorf("unexpected parameter value %v, expected %v", actual, expectedValue)
+	}
+	if len(rootTemplate.Resources) != 4 {
+		t.Errorf("expected 4 nested stacks, got %d", len(rootTemplate.Resources))
+	}
+}
+
+func TestBuildNestedStacksCycle(t *testing.T) {
+	target := NewCloudformationTarget(nil, "", "")
+
+	if err := target.RenderResource("AWS::EC2::SecurityGroup", "nodes", map[string]interface{}{
+		"Description": GetAtt("AWS::IAM::Role", "nodes", "Arn"),
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := target.RenderResource("AWS::IAM::Role", "nodes", map[string]interface{}{
+		"Description": Ref("AWS::EC2::SecurityGroup", "nodes"),
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := buildNestedStacks(target.resources); err == nil {
+		t.Fatalf("expected error for cyclic stack dependencies")
+	}
+}
+
+func keys(m map[string]interface{}) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
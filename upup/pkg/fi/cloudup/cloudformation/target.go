@@ -34,6 +34,10 @@ type CloudformationTarget struct {
 
 	outDir string
 
+	// NestedStacks splits the output into nested stacks (network, IAM, cluster & one per instancegroup),
+	// instead of rendering a single template.
+	NestedStacks bool
+
 	// mutex protects the following items (resources & files)
 	mutex     sync.Mutex
 	resources map[string]*cloudformationResource
@@ -53,6 +57,9 @@ var _ fi.Target = &CloudformationTarget{}
 type cloudformationResource struct {
 	Type       string
 	Properties interface{}
+
+	// name is the (unsanitized) name of the resource
+	name string
 }
 
 // A cloudformation resource name must be alphanumeric
@@ -73,6 +80,7 @@ func (t *CloudformationTarget) RenderResource(resourceType string, resourceName
 	res := &cloudformationResource{
 		Type:       resourceType,
 		Properties: e,
+		name:       resourceName,
 	}
 
 	name := resourceType + "::" + resourceName
@@ -137,24 +145,45 @@ func (t *CloudformationTarget) Finish(taskMap map[string]fi.Task) error {
 	//	providersByName["aws"] = providerAWS
 	//}
 
-	data := make(map[string]interface{})
-	data["Resources"] = t.resources
-	//if len(providersByName) != 0 {
-	//	data["provider"] = providersByName
-	//}
+	templates := make(map[string]interface{})
+	if t.NestedStacks {
+		stacks, err := buildNestedStacks(t.resources)
+		if err != nil {
+			return err
+		}
+		for _, stack := range stacks {
+			if len(stack.resources) > MaxTemplateResources {
+				klog.Warningf("cloudformation stack %q has %d resources, which exceeds the limit of %d", stack.name, len(stack.resources), MaxTemplateResources)
+			}
+			templates[stack.templatePath()] = stack.template()
+		}
+		templates["kubernetes.json"] = buildRootTemplate(stacks)
+	} else {
+		if len(t.resources) > MaxTemplateResources {
+			klog.Warningf("cloudformation template has %d resources, which exceeds the limit of %d; consider splitting the output into nested stacks with --cloudformation-nested-stacks", len(t.resources), MaxTemplateResources)
+		}
 
-	jsonBytes, err := json.MarshalIndent(data, "", "  ")
-	if err != nil {
-		return fmt.Errorf("error marshaling cloudformation data to json: %v", err)
+		data := make(map[string]interface{})
+		data["Resources"] = t.resources
+		//if len(providersByName) != 0 {
+		//	data["provider"] = providersByName
+		//}
+		templates["kubernetes.json"] = data
 	}
 
 	files := make(map[string][]byte)
-	files["kubernetes.json"] = jsonBytes
+	for relativePath, data := range templates {
+		jsonBytes, err := json.MarshalIndent(data, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error marshaling cloudformation data to json: %v", err)
+		}
+		files[relativePath] = jsonBytes
+	}
 
 	for relativePath, contents := range files {
 		p := path.Join(t.outDir, relativePath)
 
-		err = os.MkdirAll(path.Dir(p), os.FileMode(0o755))
+		err := os.MkdirAll(path.Dir(p), os.FileMode(0o755))
 		if err != nil {
 			return fmt.Errorf("error creating output directory %q: %v", path.Dir(p), err)
 		}
@@ -0,0 +1,72 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudup
+
+import (
+	"fmt"
+	"net/url"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/assets"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/util/pkg/architectures"
+	"k8s.io/kops/util/pkg/hashing"
+)
+
+const (
+	ecrCredentialProviderURL = "https://artifacts.k8s.io/binaries/cloud-provider-aws/v1.24.1/linux/%[1]s/ecr-credential-provider-linux-%[1]s"
+	gcpCredentialProviderURL = "https://storage.googleapis.com/k8s-staging-cloud-provider-gcp/auth-provider-gcp/linux-%[1]s/v0.0.1/auth-provider-gcp"
+	acrCredentialProviderURL = "https://github.com/kubernetes-sigs/cloud-provider-azure/releases/download/v1.24.4/azure-acr-credential-provider-linux-%[1]s"
+)
+
+// usesImageCredentialProviders returns true if the kubelet of any instance group uses a credential provider
+func usesImageCredentialProviders(c *kops.Cluster, instanceGroups []*kops.InstanceGroup) bool {
+	if c.Spec.Kubelet != nil && fi.BoolValue(c.Spec.Kubelet.ImageCredentialProviders) {
+		return true
+	}
+	if c.Spec.MasterKubelet != nil && fi.BoolValue(c.Spec.MasterKubelet.ImageCredentialProviders) {
+		return true
+	}
+	for _, ig := range instanceGroups {
+		if ig.Spec.Kubelet != nil && fi.BoolValue(ig.Spec.Kubelet.ImageCredentialProviders) {
+			return true
+		}
+	}
+	return false
+}
+
+// findCredentialProviderAsset returns the kubelet credential provider for the container registry of the cloud provider
+func findCredentialProviderAsset(c *kops.Cluster, assetBuilder *assets.AssetBuilder, arch architectures.Architecture) (*url.URL, *hashing.Hash, error) {
+	var assetURL string
+	switch c.Spec.GetCloudProvider() {
+	case kops.CloudProviderAWS:
+		assetURL = fmt.Sprintf(ecrCredentialProviderURL, arch)
+	case kops.CloudProviderGCE:
+		assetURL = fmt.Sprintf(gcpCredentialProviderURL, arch)
+	case kops.CloudProviderAzure:
+		assetURL = fmt.Sprintf(acrCredentialProviderURL, arch)
+	default:
+		return nil, nil, fmt.Errorf("image credential providers are not supported on %q", c.Spec.GetCloudProvider())
+	}
+
+	u, err := url.Parse(assetURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to parse credential provider URL %q: %w", assetURL, err)
+	}
+
+	return assetBuilder.RemapFileAndSHA(u)
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudup
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/blang/semver/v4"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/assets"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/util/pkg/architectures"
+	"k8s.io/kops/util/pkg/hashing"
+)
+
+const (
+	// CRI-O static bundles, containing crio, conmon, crun, runc, crictl and the CNI plugins
+	crioBundleUrlAmd64 = "https://storage.googleapis.com/cri-o/artifacts/cri-o.amd64.v%s.tar.gz"
+	crioBundleUrlArm64 = "https://storage.googleapis.com/cri-o/artifacts/cri-o.arm64.v%s.tar.gz"
+)
+
+func findCrioAsset(c *kops.Cluster, assetBuilder *assets.AssetBuilder, arch architectures.Architecture) (*url.URL, *hashing.Hash, error) {
+	if c.Spec.Crio == nil {
+		return nil, nil, fmt.Errorf("unable to find CRI-O config")
+	}
+	crio := c.Spec.Crio
+
+	if crio.Packages != nil {
+		if arch == architectures.ArchitectureAmd64 && crio.Packages.UrlAmd64 != nil && crio.Packages.HashAmd64 != nil {
+			assetUrl := fi.StringValue(crio.Packages.UrlAmd64)
+			assetHash := fi.StringValue(crio.Packages.HashAmd64)
+			return findAssetsUrlHash(assetBuilder, assetUrl, assetHash)
+		}
+		if arch == architectures.ArchitectureArm64 && crio.Packages.UrlArm64 != nil && crio.Packages.HashArm64 != nil {
+			assetUrl := fi.StringValue(crio.Packages.UrlArm64)
+			assetHash := fi.StringValue(crio.Packages.HashArm64)
+			return findAssetsUrlHash(assetBuilder, assetUrl, assetHash)
+		}
+	}
+
+	version := fi.StringValue(crio.Version)
+	if version == "" {
+		return nil, nil, fmt.Errorf("unable to find CRI-O version")
+	}
+	assetUrl, err := findCrioVersionUrl(arch, version)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	u, err := url.Parse(assetUrl)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to parse asset URL %q: %v", assetUrl, err)
+	}
+
+	// The hashes of the CRI-O bundles are published next to them
+	return assetBuilder.RemapFileAndSHA(u)
+}
+
+func findCrioVersionUrl(arch architectures.Architecture, version string) (string, error) {
+	sv, err := semver.ParseTolerant(version)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse version string: %q", version)
+	}
+	if sv.LT(semver.MustParse("1.22.0")) {
+		return "", fmt.Errorf("unsupported CRI-O version: %q", version)
+	}
+
+	var u string
+	switch arch {
+	case architectures.ArchitectureAmd64:
+		u = fmt.Sprintf(crioBundleUrlAmd64, version)
+	case architectures.ArchitectureArm64:
+		u = fmt.Sprintf(crioBundleUrlArm64, version)
+	default:
+		return "", fmt.Errorf("unknown arch: %q", arch)
+	}
+
+	return u, nil
+}
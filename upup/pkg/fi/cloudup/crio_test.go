@@ -0,0 +1,98 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudup
+
+import (
+	"testing"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/assets"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/util/pkg/architectures"
+)
+
+func TestCrioVersionUrl(t *testing.T) {
+	tests := []struct {
+		version string
+		arch    architectures.Architecture
+		url     string
+		err     bool
+	}{
+		{
+			version: "1.24.1",
+			arch:    architectures.ArchitectureAmd64,
+			url:     "https://storage.googleapis.com/cri-o/artifacts/cri-o.amd64.v1.24.1.tar.gz",
+		},
+		{
+			version: "1.23.3",
+			arch:    architectures.ArchitectureArm64,
+			url:     "https://storage.googleapis.com/cri-o/artifacts/cri-o.arm64.v1.23.3.tar.gz",
+		},
+		{
+			version: "1.21.7",
+			arch:    architectures.ArchitectureAmd64,
+			err:     true,
+		},
+		{
+			version: "latest",
+			arch:    architectures.ArchitectureAmd64,
+			err:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.version+"-"+string(test.arch), func(t *testing.T) {
+			url, err := findCrioVersionUrl(test.arch, test.version)
+			if test.err {
+				if err == nil {
+					t.Fatalf("expected error for version %q", test.version)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if url != test.url {
+				t.Errorf("expected url %q, got %q", test.url, url)
+			}
+		})
+	}
+}
+
+func TestCrioPackagesOverride(t *testing.T) {
+	cluster := &kops.Cluster{}
+	cluster.Spec.KubernetesVersion = "1.24.2"
+	cluster.Spec.Crio = &kops.CrioConfig{
+		Version: fi.String("1.24.1"),
+		Packages: &kops.PackagesConfig{
+			UrlAmd64:  fi.String("https://example.com/cri-o.amd64.tar.gz"),
+			HashAmd64: fi.String("7bde2ca3fa7d2bdd1a3d0cb6b0e1fb3bd0a7cbab7d8a6c2dbbac0ee59dc8d2b3"),
+		},
+	}
+	assetBuilder := assets.NewAssetBuilder(cluster, false)
+
+	u, h, err := findCrioAsset(cluster, assetBuilder, architectures.ArchitectureAmd64)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if u.String() != "https://example.com/cri-o.amd64.tar.gz" {
+		t.Errorf("unexpected url %q", u)
+	}
+	if h.Hex() != "7bde2ca3fa7d2bdd1a3d0cb6b0e1fb3bd0a7cbab7d8a6c2dbbac0ee59dc8d2b3" {
+		t.Errorf("unexpected hash %q", h.Hex())
+	}
+}
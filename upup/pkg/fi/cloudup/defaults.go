@@ -54,6 +54,16 @@ func PerformAssignments(c *kops.Cluster, cloud fi.Cloud) error {
 		return fmt.Errorf("cloud cannot be nil")
 	}
 
+	hook := ExternalIPAM
+	if hook == nil {
+		hook = ipamHookFromEnv()
+	}
+	if hook != nil {
+		if err := assignFromIPAMHook(ctx, c, hook); err != nil {
+			return err
+		}
+	}
+
 	if cloud.ProviderID() == kops.CloudProviderGCE {
 		if err := gce.PerformNetworkAssignments(ctx, c, cloud); err != nil {
 			return err
@@ -114,6 +124,10 @@ func PerformAssignments(c *kops.Cluster, cloud fi.Cloud) error {
 
 	// We only assign subnet CIDRs on AWS, OpenStack, and Azure.
 	pd := cloud.ProviderID()
+	if pd == kops.CloudProviderAWS && c.Spec.IsIPv6Only() {
+		assignIPv6CIDRsToSubnets(c)
+	}
+
 	if pd == kops.CloudProviderAWS || pd == kops.CloudProviderOpenstack || pd == kops.CloudProviderAzure {
 		// TODO: Use vpcInfo
 		err := assignCIDRsToSubnets(c, cloud)
@@ -27,6 +27,7 @@ type ComputeClient interface {
 	Projects() ProjectClient
 	Regions() RegionClient
 	Zones() ZoneClient
+	MachineTypes() MachineTypeClient
 	Networks() NetworkClient
 	Subnetworks() SubnetworkClient
 	Routes() RouteClient
@@ -78,6 +79,12 @@ func (c *computeClientImpl) Zones() ZoneClient {
 	}
 }
 
+func (c *computeClientImpl) MachineTypes() MachineTypeClient {
+	return &machineTypeClientImpl{
+		srv: c.srv.MachineTypes,
+	}
+}
+
 func (c *computeClientImpl) Networks() NetworkClient {
 	return &networkClientImpl{
 		srv: c.srv.Networks,
@@ -226,6 +233,28 @@ func (c *zoneClientImpl) List(ctx context.Context, project string) ([]*compute.Z
 	return zones, nil
 }
 
+type MachineTypeClient interface {
+	List(ctx context.Context, project, zone string) ([]*compute.MachineType, error)
+}
+
+type machineTypeClientImpl struct {
+	srv *compute.MachineTypesService
+}
+
+var _ MachineTypeClient = &machineTypeClientImpl{}
+
+func (c *machineTypeClientImpl) List(ctx context.Context, project, zone string) ([]*compute.MachineType, error) {
+	var machineTypes []*compute.MachineType
+	err := c.srv.List(project, zone).Pages(ctx, func(page *compute.MachineTypeList) error {
+		machineTypes = append(machineTypes, page.Items...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return machineTypes, nil
+}
+
 type NetworkClient interface {
 	Insert(project string, nw *compute.Network) (*compute.Operation, error)
 	Get(project, name string) (*compute.Network, error)
@@ -336,6 +365,7 @@ func (c *regionBackendServiceClientImpl) List(ctx context.Context, project, regi
 // ===
 
 type RouteClient interface {
+	Insert(project string, r *compute.Route) (*compute.Operation, error)
 	Delete(project, name string) (*compute.Operation, error)
 	List(ctx context.Context, project string) ([]*compute.Route, error)
 }
@@ -346,6 +376,10 @@ type routeClientImpl struct {
 
 var _ RouteClient = &routeClientImpl{}
 
+func (c *routeClientImpl) Insert(project string, r *compute.Route) (*compute.Operation, error) {
+	return c.srv.Insert(project, r).Do()
+}
+
 func (c *routeClientImpl) Delete(project, name string) (*compute.Operation, error) {
 	return c.srv.Delete(project, name).Do()
 }
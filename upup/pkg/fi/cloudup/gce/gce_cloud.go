@@ -25,6 +25,7 @@ import (
 	"strings"
 
 	"golang.org/x/oauth2/google"
+	cloudkms "google.golang.org/api/cloudkms/v1"
 	"google.golang.org/api/cloudresourcemanager/v1"
 	compute "google.golang.org/api/compute/v1"
 	oauth2 "google.golang.org/api/oauth2/v2"
@@ -53,6 +54,9 @@ type GCECloud interface {
 
 	// CloudResourceManager returns the client for the cloudresourcemanager API
 	CloudResourceManager() *cloudresourcemanager.Service
+
+	// CloudKMS returns the client for the cloudkms API
+	CloudKMS() *cloudkms.Service
 }
 
 type gceCloudImplementation struct {
@@ -64,6 +68,9 @@ type gceCloudImplementation struct {
 	// cloudResourceManager is the client for the cloudresourcemanager API
 	cloudResourceManager *cloudresourcemanager.Service
 
+	// cloudKMS is the client for the cloudkms API
+	cloudKMS *cloudkms.Service
+
 	region  string
 	project string
 
@@ -156,6 +163,12 @@ func NewGCECloud(region string, project string, labels map[string]string) (GCECl
 	}
 	c.cloudResourceManager = cloudResourceManager
 
+	cloudKMS, err := cloudkms.NewService(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error building cloudkms API client: %w", err)
+	}
+	c.cloudKMS = cloudKMS
+
 	CacheGCECloudInstance(region, project, c)
 
 	{
@@ -215,6 +228,11 @@ func (c *gceCloudImplementation) CloudResourceManager() *cloudresourcemanager.Se
 	return c.cloudResourceManager
 }
 
+// CloudKMS returns the client for the cloudkms API
+func (c *gceCloudImplementation) CloudKMS() *cloudkms.Service {
+	return c.cloudKMS
+}
+
 // Region returns private struct element region.
 func (c *gceCloudImplementation) Region() string {
 	return c.region
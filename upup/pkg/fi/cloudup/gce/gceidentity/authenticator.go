@@ -0,0 +1,48 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gceidentity
+
+import (
+	"fmt"
+	"net/url"
+
+	"cloud.google.com/go/compute/metadata"
+	"k8s.io/kops/pkg/bootstrap"
+)
+
+type identityAuthenticator struct{}
+
+var _ bootstrap.Authenticator = &identityAuthenticator{}
+
+// NewIdentityAuthenticator returns an authenticator that uses the identity token of the instance,
+// signed by Google and fetched from the metadata server.
+// Unlike the TPM authenticator, it works on instances without a vTPM.
+func NewIdentityAuthenticator() (bootstrap.Authenticator, error) {
+	return &identityAuthenticator{}, nil
+}
+
+func (a *identityAuthenticator) CreateToken(body []byte) (string, error) {
+	query := url.Values{}
+	query.Set("audience", Audience(body))
+	query.Set("format", "full")
+
+	token, err := metadata.Get("instance/service-accounts/default/identity?" + query.Encode())
+	if err != nil {
+		return "", fmt.Errorf("error getting identity token from metadata: %w", err)
+	}
+	return GCEIdentityAuthenticationTokenPrefix + token, nil
+}
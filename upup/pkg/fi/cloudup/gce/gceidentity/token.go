@@ -0,0 +1,47 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gceidentity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	gcetpm "k8s.io/kops/upup/pkg/fi/cloudup/gce/tpm"
+)
+
+// GCEIdentityAuthenticationTokenPrefix is the prefix used for authentication using a GCE instance identity token
+const GCEIdentityAuthenticationTokenPrefix = "x-gce-id "
+
+// Audience returns the audience of the identity token for a request.
+// Including the hash of the request body binds the token to the request.
+func Audience(body []byte) string {
+	requestHash := sha256.Sum256(body)
+	return gcetpm.AudienceNodeAuthentication + "/" + hex.EncodeToString(requestHash[:])
+}
+
+// identityClaims are the GCE specific claims of an identity token requested with format=full.
+// See https://cloud.google.com/compute/docs/instances/verifying-instance-identity#payload
+type identityClaims struct {
+	Google struct {
+		ComputeEngine struct {
+			ProjectID    string `json:"project_id"`
+			Zone         string `json:"zone"`
+			InstanceID   string `json:"instance_id"`
+			InstanceName string `json:"instance_name"`
+		} `json:"compute_engine"`
+	} `json:"google"`
+}
@@ -0,0 +1,262 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gceidentity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/bootstrap"
+	"k8s.io/kops/pkg/nodeidentity/gce"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/gce/gcemetadata"
+	gcetpm "k8s.io/kops/upup/pkg/fi/cloudup/gce/tpm"
+	"k8s.io/kops/upup/pkg/fi/cloudup/gce/tpm/gcetpmverifier"
+)
+
+// googleCertsURL is where Google publishes the keys that sign identity tokens.
+const googleCertsURL = "https://www.googleapis.com/oauth2/v3/certs"
+
+// minKeyRefreshInterval limits how often we fetch the keys when we see an unknown key ID.
+const minKeyRefreshInterval = time.Minute
+
+var googleIssuers = []string{"https://accounts.google.com", "accounts.google.com"}
+
+type identityVerifier struct {
+	opt gcetpm.TPMVerifierOptions
+
+	computeClient *compute.Service
+	httpClient    *http.Client
+
+	mutex       sync.Mutex
+	keys        jose.JSONWebKeySet
+	keysFetched time.Time
+
+	// fetchKeys and getInstance can be replaced in tests.
+	fetchKeys   func(ctx context.Context) (*jose.JSONWebKeySet, error)
+	getInstance func(ctx context.Context, project, zone, name string) (*compute.Instance, error)
+}
+
+// NewIdentityVerifier constructs a verifier for the identity tokens of GCE instances.
+func NewIdentityVerifier(opt *gcetpm.TPMVerifierOptions) (bootstrap.Verifier, error) {
+	ctx := context.Background()
+
+	computeClient, err := compute.NewService(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error building compute API client: %w", err)
+	}
+
+	v := &identityVerifier{
+		opt:           *opt,
+		computeClient: computeClient,
+		httpClient:    &http.Client{Timeout: 30 * time.Second},
+	}
+	v.fetchKeys = v.fetchGoogleKeys
+	v.getInstance = v.getComputeInstance
+	return v, nil
+}
+
+var _ bootstrap.Verifier = &identityVerifier{}
+
+func (v *identityVerifier) VerifyToken(ctx context.Context, authToken string, body []byte, useInstanceIDForNodeName bool) (*bootstrap.VerifyResult, error) {
+	if !strings.HasPrefix(authToken, GCEIdentityAuthenticationTokenPrefix) {
+		return nil, fmt.Errorf("incorrect authorization type")
+	}
+	authToken = strings.TrimPrefix(authToken, GCEIdentityAuthenticationTokenPrefix)
+
+	token, err := jwt.ParseSigned(authToken)
+	if err != nil {
+		return nil, fmt.Errorf("parsing identity token: %w", err)
+	}
+	if len(token.Headers) != 1 {
+		return nil, fmt.Errorf("identity token has %d signatures, expected 1", len(token.Headers))
+	}
+	if token.Headers[0].Algorithm != string(jose.RS256) {
+		return nil, fmt.Errorf("identity token has unexpected algorithm %q", token.Headers[0].Algorithm)
+	}
+
+	key, err := v.findKey(ctx, token.Headers[0].KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := jwt.Claims{}
+	gceClaims := identityClaims{}
+	if err := token.Claims(key, &claims, &gceClaims); err != nil {
+		return nil, fmt.Errorf("failed to verify identity token signature: %w", err)
+	}
+
+	// Guard against replay attacks; the audience includes the hash of the body
+	if err := claims.Validate(jwt.Expected{Audience: jwt.Audience{Audience(body)}, Time: time.Now()}); err != nil {
+		return nil, fmt.Errorf("invalid identity token: %w", err)
+	}
+	if !isGoogleIssuer(claims.Issuer) {
+		return nil, fmt.Errorf("incorrect Issuer %q", claims.Issuer)
+	}
+	if claims.IssuedAt == nil {
+		return nil, fmt.Errorf("identity token has no issue time")
+	}
+	timeSkew := math.Abs(time.Since(claims.IssuedAt.Time()).Seconds())
+	if timeSkew > float64(v.opt.MaxTimeSkew) {
+		return nil, fmt.Errorf("incorrect IssuedAt %v", claims.IssuedAt.Time())
+	}
+
+	instanceClaims := gceClaims.Google.ComputeEngine
+	if instanceClaims.ProjectID == "" || instanceClaims.Zone == "" || instanceClaims.InstanceName == "" || instanceClaims.InstanceID == "" {
+		return nil, fmt.Errorf("identity token does not have instance details, it must be requested with format=full")
+	}
+
+	// Verify node is in our cluster
+	if instanceClaims.ProjectID != v.opt.ProjectID {
+		return nil, fmt.Errorf("projectID does not match expected: got %q, want %q", instanceClaims.ProjectID, v.opt.ProjectID)
+	}
+	if !strings.HasPrefix(instanceClaims.Zone, v.opt.Region+"-") {
+		return nil, fmt.Errorf("instance was in zone %q, expected region %q", instanceClaims.Zone, v.opt.Region)
+	}
+
+	instance, err := v.getInstance(ctx, instanceClaims.ProjectID, instanceClaims.Zone, instanceClaims.InstanceName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Guard against a new instance that reuses the name of the instance the token was issued to
+	if fmt.Sprintf("%d", instance.Id) != instanceClaims.InstanceID {
+		return nil, fmt.Errorf("instance ID does not match expected: got %d, want %s", instance.Id, instanceClaims.InstanceID)
+	}
+
+	clusterName := ""
+	instanceGroupName := ""
+	if instance.Metadata != nil {
+		for _, item := range instance.Metadata.Items {
+			switch item.Key {
+			case gce.MetadataKeyInstanceGroupName:
+				instanceGroupName = fi.StringValue(item.Value)
+			case gcemetadata.MetadataKeyClusterName:
+				clusterName = fi.StringValue(item.Value)
+			}
+		}
+	}
+
+	if clusterName == "" {
+		return nil, fmt.Errorf("could not determine cluster for instance %s", instance.SelfLink)
+	}
+	if clusterName != v.opt.ClusterName {
+		return nil, fmt.Errorf("clusterName does not match expected: got %q, want %q", clusterName, v.opt.ClusterName)
+	}
+	if instanceGroupName == "" {
+		return nil, fmt.Errorf("could not determine instance group for instance %s", instance.SelfLink)
+	}
+
+	sans, err := gcetpmverifier.GetInstanceCertificateAlternateNames(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &bootstrap.VerifyResult{
+		NodeName:          instance.Name,
+		InstanceGroupName: instanceGroupName,
+		CertificateNames:  sans,
+	}
+
+	return result, nil
+}
+
+// findKey returns the public key with the given ID, refreshing the cached keys if we don't know it.
+// Google rotates the keys regularly, and publishes new keys before using them.
+func (v *identityVerifier) findKey(ctx context.Context, keyID string) (interface{}, error) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	if keys := v.keys.Key(keyID); len(keys) != 0 {
+		return keys[0].Public().Key, nil
+	}
+
+	if time.Since(v.keysFetched) < minKeyRefreshInterval {
+		return nil, fmt.Errorf("identity token signed with unknown key %q", keyID)
+	}
+
+	keys, err := v.fetchKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	v.keys = *keys
+	v.keysFetched = time.Now()
+	klog.V(2).Infof("fetched %d keys for identity token verification", len(keys.Keys))
+
+	if keys := v.keys.Key(keyID); len(keys) != 0 {
+		return keys[0].Public().Key, nil
+	}
+	return nil, fmt.Errorf("identity token signed with unknown key %q", keyID)
+}
+
+func (v *identityVerifier) fetchGoogleKeys(ctx context.Context) (*jose.JSONWebKeySet, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, googleCertsURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("building request for %q: %w", googleCertsURL, err)
+	}
+	response, err := v.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching %q: %w", googleCertsURL, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching %q: unexpected status %q", googleCertsURL, response.Status)
+	}
+
+	keys := &jose.JSONWebKeySet{}
+	if err := json.NewDecoder(response.Body).Decode(keys); err != nil {
+		return nil, fmt.Errorf("decoding keys from %q: %w", googleCertsURL, err)
+	}
+	return keys, nil
+}
+
+func (v *identityVerifier) getComputeInstance(ctx context.Context, project, zone, name string) (*compute.Instance, error) {
+	instance, err := v.computeClient.Instances.Get(project, zone, name).Context(ctx).Do()
+	if err != nil {
+		if isNotFound(err) {
+			return nil, fmt.Errorf("unable to find instance in compute API: %w", err)
+		}
+		return nil, fmt.Errorf("error fetching instance from compute API: %w", err)
+	}
+	return instance, nil
+}
+
+func isGoogleIssuer(issuer string) bool {
+	for _, s := range googleIssuers {
+		if issuer == s {
+			return true
+		}
+	}
+	return false
+}
+
+func isNotFound(err error) bool {
+	gerr, ok := err.(*googleapi.Error)
+	return ok && gerr.Code == http.StatusNotFound
+}
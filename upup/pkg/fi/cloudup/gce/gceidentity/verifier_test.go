@@ -0,0 +1,197 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gceidentity
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"strings"
+	"testing"
+	"time"
+
+	compute "google.golang.org/api/compute/v1"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+	"k8s.io/kops/pkg/nodeidentity/gce"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/gce/gcemetadata"
+	gcetpm "k8s.io/kops/upup/pkg/fi/cloudup/gce/tpm"
+)
+
+func TestVerifyToken(t *testing.T) {
+	googleKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("error generating key: %v", err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("error generating key: %v", err)
+	}
+
+	body := []byte(`{"certs":{}}`)
+
+	instance := &compute.Instance{
+		Id:   1234,
+		Name: "nodes-abcd",
+		Metadata: &compute.Metadata{
+			Items: []*compute.MetadataItems{
+				{Key: gcemetadata.MetadataKeyClusterName, Value: fi.String("minimal.example.com")},
+				{Key: gce.MetadataKeyInstanceGroupName, Value: fi.String("nodes")},
+			},
+		},
+		NetworkInterfaces: []*compute.NetworkInterface{{NetworkIP: "10.0.16.3"}},
+	}
+
+	type tokenOptions struct {
+		key       *rsa.PrivateKey
+		keyID     string
+		issuer    string
+		audience  string
+		issuedAt  time.Time
+		projectID string
+		zone      string
+		id        string
+	}
+	defaults := func() tokenOptions {
+		return tokenOptions{
+			key:       googleKey,
+			keyID:     "google-key",
+			issuer:    "https://accounts.google.com",
+			audience:  Audience(body),
+			issuedAt:  time.Now(),
+			projectID: "testproject",
+			zone:      "us-test1-a",
+			id:        "1234",
+		}
+	}
+
+	grid := []struct {
+		name    string
+		options func(o *tokenOptions)
+		body    []byte
+		wantErr string
+	}{
+		{
+			name:    "valid",
+			options: func(o *tokenOptions) {},
+		},
+		{
+			name:    "signed by another key",
+			options: func(o *tokenOptions) { o.key = otherKey },
+			wantErr: "failed to verify identity token signature",
+		},
+		{
+			name:    "unknown key",
+			options: func(o *tokenOptions) { o.keyID = "other-key" },
+			wantErr: "unknown key",
+		},
+		{
+			name:    "different body",
+			options: func(o *tokenOptions) {},
+			body:    []byte(`{"certs":{"other":""}}`),
+			wantErr: "invalid identity token",
+		},
+		{
+			name:    "wrong issuer",
+			options: func(o *tokenOptions) { o.issuer = "https://example.com" },
+			wantErr: "incorrect Issuer",
+		},
+		{
+			name:    "old token",
+			options: func(o *tokenOptions) { o.issuedAt = time.Now().Add(-10 * time.Minute) },
+			wantErr: "incorrect IssuedAt",
+		},
+		{
+			name:    "other project",
+			options: func(o *tokenOptions) { o.projectID = "otherproject" },
+			wantErr: "projectID does not match",
+		},
+		{
+			name:    "other region",
+			options: func(o *tokenOptions) { o.zone = "us-test2-a" },
+			wantErr: "expected region",
+		},
+		{
+			name:    "reused instance name",
+			options: func(o *tokenOptions) { o.id = "5678" },
+			wantErr: "instance ID does not match",
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			o := defaults()
+			g.options(&o)
+
+			signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: o.key}, (&jose.SignerOptions{}).WithHeader("kid", o.keyID))
+			if err != nil {
+				t.Fatalf("error building signer: %v", err)
+			}
+			gceClaims := identityClaims{}
+			gceClaims.Google.ComputeEngine.ProjectID = o.projectID
+			gceClaims.Google.ComputeEngine.Zone = o.zone
+			gceClaims.Google.ComputeEngine.InstanceID = o.id
+			gceClaims.Google.ComputeEngine.InstanceName = "nodes-abcd"
+			token, err := jwt.Signed(signer).Claims(jwt.Claims{
+				Issuer:   o.issuer,
+				Audience: jwt.Audience{o.audience},
+				IssuedAt: jwt.NewNumericDate(o.issuedAt),
+				Expiry:   jwt.NewNumericDate(o.issuedAt.Add(time.Hour)),
+			}).Claims(gceClaims).CompactSerialize()
+			if err != nil {
+				t.Fatalf("error signing token: %v", err)
+			}
+
+			v := &identityVerifier{
+				opt: gcetpm.TPMVerifierOptions{
+					ProjectID:   "testproject",
+					Region:      "us-test1",
+					ClusterName: "minimal.example.com",
+					MaxTimeSkew: 300,
+				},
+				fetchKeys: func(ctx context.Context) (*jose.JSONWebKeySet, error) {
+					return &jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{Key: &googleKey.PublicKey, KeyID: "google-key", Algorithm: string(jose.RS256)}}}, nil
+				},
+				getInstance: func(ctx context.Context, project, zone, name string) (*compute.Instance, error) {
+					return instance, nil
+				},
+			}
+
+			requestBody := body
+			if g.body != nil {
+				requestBody = g.body
+			}
+			result, err := v.VerifyToken(context.Background(), GCEIdentityAuthenticationTokenPrefix+token, requestBody, false)
+			if g.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), g.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", g.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.NodeName != "nodes-abcd" || result.InstanceGroupName != "nodes" {
+				t.Errorf("unexpected result %+v", result)
+			}
+			if len(result.CertificateNames) != 1 || result.CertificateNames[0] != "10.0.16.3" {
+				t.Errorf("unexpected certificate names %v", result.CertificateNames)
+			}
+		})
+	}
+}
@@ -55,6 +55,8 @@ type ServiceAccountClient interface {
 	Update(ctx context.Context, fqn string, sa *iam.ServiceAccount) (*iam.ServiceAccount, error)
 	Delete(saName string) (*iam.Empty, error)
 	List(ctx context.Context, project string) ([]*iam.ServiceAccount, error)
+	GetIamPolicy(ctx context.Context, fqn string) (*iam.Policy, error)
+	SetIamPolicy(ctx context.Context, fqn string, policy *iam.Policy) (*iam.Policy, error)
 }
 
 type serviceAccountClientImpl struct {
@@ -89,3 +91,11 @@ func (s *serviceAccountClientImpl) List(ctx context.Context, project string) ([]
 func (s *serviceAccountClientImpl) Delete(saName string) (*iam.Empty, error) {
 	return s.srv.Delete(saName).Do()
 }
+
+func (s *serviceAccountClientImpl) GetIamPolicy(ctx context.Context, fqn string) (*iam.Policy, error) {
+	return s.srv.GetIamPolicy(fqn).Context(ctx).Do()
+}
+
+func (s *serviceAccountClientImpl) SetIamPolicy(ctx context.Context, fqn string, policy *iam.Policy) (*iam.Policy, error) {
+	return s.srv.SetIamPolicy(fqn, &iam.SetIamPolicyRequest{Policy: policy}).Context(ctx).Do()
+}
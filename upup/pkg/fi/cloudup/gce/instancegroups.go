@@ -174,6 +174,16 @@ func getCloudGroups(c GCECloud, cluster *kops.Cluster, instancegroups []*kops.In
 				cm := &cloudinstances.CloudInstance{
 					ID:                 id,
 					CloudInstanceGroup: g,
+					LifecycleState:     i.InstanceStatus,
+				}
+				if i.CurrentAction != "" && i.CurrentAction != "NONE" {
+					cm.LifecycleState = i.CurrentAction
+				}
+				if len(i.InstanceHealth) != 0 {
+					cm.HealthStatus = i.InstanceHealth[0].DetailedHealthState
+				}
+				if i.Version != nil {
+					cm.Lifecycle = instanceTemplateLifecycle(instanceTemplates[i.Version.InstanceTemplate])
 				}
 
 				// Try first by provider ID
@@ -200,6 +210,17 @@ func getCloudGroups(c GCECloud, cluster *kops.Cluster, instancegroups []*kops.In
 	return groups, nil
 }
 
+// instanceTemplateLifecycle returns whether the instances of the template are spot or on-demand instances
+func instanceTemplateLifecycle(t *compute.InstanceTemplate) string {
+	if t == nil || t.Properties == nil {
+		return ""
+	}
+	if scheduling := t.Properties.Scheduling; scheduling != nil && (scheduling.Preemptible || scheduling.ProvisioningModel == "SPOT") {
+		return cloudinstances.LifecycleSpot
+	}
+	return cloudinstances.LifecycleOnDemand
+}
+
 // NameForInstanceGroupManager builds a name for an InstanceGroupManager in the specified zone
 func NameForInstanceGroupManager(c *kops.Cluster, ig *kops.InstanceGroup, zone string) string {
 	shortZone := zone
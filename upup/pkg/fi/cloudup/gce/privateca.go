@@ -0,0 +1,59 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gce
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	privateca "google.golang.org/api/privateca/v1"
+	"k8s.io/kops/pkg/pki"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+var _ fi.CertificateIssuer = &gceCloudImplementation{}
+
+// IssueCACertificate implements fi.CertificateIssuer, issuing a CA certificate from a Certificate Authority Service CA pool.
+// The issuance policy of the pool must allow CA certificates to be requested.
+func (c *gceCloudImplementation) IssueCACertificate(issuer string, csr []byte, validity time.Duration) (*pki.Certificate, string, error) {
+	ctx := context.Background()
+
+	service, err := privateca.NewService(ctx)
+	if err != nil {
+		return nil, "", fmt.Errorf("error building privateca API client: %v", err)
+	}
+
+	request := &privateca.Certificate{
+		PemCsr:   string(csr),
+		Lifetime: fmt.Sprintf("%ds", int64(validity.Seconds())),
+	}
+	// Certificate IDs are required by enterprise tier CA pools, and must be unique within the pool
+	certificateID := fmt.Sprintf("kops-%d", time.Now().UnixNano())
+
+	issued, err := service.Projects.Locations.CaPools.Certificates.Create(issuer, request).CertificateId(certificateID).Context(ctx).Do()
+	if err != nil {
+		return nil, "", fmt.Errorf("error issuing certificate from CA pool %q: %v", issuer, err)
+	}
+
+	cert, err := pki.ParsePEMCertificate([]byte(issued.PemCertificate))
+	if err != nil {
+		return nil, "", fmt.Errorf("error parsing certificate issued by CA pool %q: %v", issuer, err)
+	}
+
+	return cert, issued.Name, nil
+}
@@ -22,6 +22,7 @@ package gcetpmsigner
 import (
 	"fmt"
 	"io"
+	"os"
 
 	"github.com/google/go-tpm/tpm2"
 )
@@ -35,3 +36,8 @@ func openTPM() (io.ReadWriteCloser, error) {
 	}
 	return rw, nil
 }
+
+func hasTPM() bool {
+	_, err := os.Stat(tpmPath)
+	return err == nil
+}
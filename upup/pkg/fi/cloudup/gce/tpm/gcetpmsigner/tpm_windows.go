@@ -0,0 +1,33 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcetpmsigner
+
+import (
+	"fmt"
+	"io"
+)
+
+var tpmPath = "TPM"
+
+// openTPM isn't supported on Windows, which is only supported on AWS.
+func openTPM() (io.ReadWriteCloser, error) {
+	return nil, fmt.Errorf("TPM is not supported on Windows")
+}
+
+func hasTPM() bool {
+	return false
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/google/go-tpm-tools/client"
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/bootstrap"
+	"k8s.io/kops/upup/pkg/fi/cloudup/gce/gceidentity"
 	gcetpm "k8s.io/kops/upup/pkg/fi/cloudup/gce/tpm"
 )
 
@@ -42,6 +43,16 @@ type tpmAuthenticator struct {
 
 var _ bootstrap.Authenticator = &tpmAuthenticator{}
 
+// NewAuthenticator returns a TPM authenticator if the instance has a vTPM.
+// Otherwise it returns an authenticator that uses the instance identity token from the metadata server.
+func NewAuthenticator() (bootstrap.Authenticator, error) {
+	if !hasTPM() {
+		klog.Infof("%s not found, authenticating with the instance identity token", tpmPath)
+		return gceidentity.NewIdentityAuthenticator()
+	}
+	return NewTPMAuthenticator()
+}
+
 func NewTPMAuthenticator() (bootstrap.Authenticator, error) {
 	projectID, err := metadata.ProjectID()
 	if err != nil {
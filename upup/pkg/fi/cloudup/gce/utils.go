@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	"google.golang.org/api/googleapi"
+	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/truncate"
 )
 
@@ -109,6 +110,31 @@ func ServiceAccountName(name string, clusterName string) (string, error) {
 	return ClusterSuffixedName(name, clusterName, 30)
 }
 
+// InstanceGroupServiceAccountRoles returns the project roles granted to the service account
+// of instances with the given role.
+func InstanceGroupServiceAccountRoles(role kops.InstanceGroupRole) []string {
+	// Ideally we would use a custom role here, but the deletion of a custom role takes 7 days,
+	// which means we can't easily recycle cluster names.
+	// If we can find a solution, we can easily switch to a custom role.
+
+	switch role {
+	case kops.InstanceGroupRoleAPIServer, kops.InstanceGroupRoleMaster:
+		// We reuse the GKE role
+		return []string{"roles/container.serviceAgent"}
+
+	case kops.InstanceGroupRoleNode:
+		// Known permissions:
+		//  * compute.zones.list (to find out region; we could replace this with string manipulation)
+		//  * compute.instances.list (for discovery; we don't need in the case of a load balancer or DNS)
+
+		// We use the GCE viewer role
+		return []string{"roles/compute.viewer"}
+
+	default:
+		return nil
+	}
+}
+
 // LastComponent returns the last component of a URL, i.e. anything after the last slash
 // If there is no slash, returns the whole string
 func LastComponent(s string) string {
@@ -128,3 +154,14 @@ func ZoneToRegion(zone string) (string, error) {
 	region := tokens[0] + "-" + tokens[1]
 	return region, nil
 }
+
+// MachineTypeHasGPU returns true if instances of the machine type come with Nvidia GPUs attached,
+// as the A2 and G2 machine types do.
+func MachineTypeHasGPU(machineType string) bool {
+	return strings.HasPrefix(machineType, "a2-") || strings.HasPrefix(machineType, "g2-")
+}
+
+// IsNvidiaAccelerator returns true if the accelerator type is an Nvidia GPU, such as "nvidia-tesla-t4".
+func IsNvidiaAccelerator(acceleratorType string) bool {
+	return strings.HasPrefix(acceleratorType, "nvidia-")
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gce
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"k8s.io/kops/pkg/truncate"
+)
+
+// WorkloadIdentityUserRole is the role allowing a workload identity principal to impersonate a service account
+const WorkloadIdentityUserRole = "roles/iam.workloadIdentityUser"
+
+var workloadIdentityProviderName = regexp.MustCompile(`^projects/[0-9]+/locations/global/workloadIdentityPools/[a-z0-9-]+/providers/[a-z0-9-]+$`)
+
+// IsWorkloadIdentityProviderName returns true if the string is the resource name of a workload identity pool provider
+func IsWorkloadIdentityProviderName(provider string) bool {
+	return workloadIdentityProviderName.MatchString(provider)
+}
+
+// WorkloadIdentityPrincipal returns the IAM principal of a kubernetes ServiceAccount,
+// as authenticated by the given workload identity pool provider
+func WorkloadIdentityPrincipal(provider string, namespace string, name string) string {
+	pool := provider
+	if i := strings.Index(pool, "/providers/"); i != -1 {
+		pool = pool[:i]
+	}
+	return "principal://iam.googleapis.com/" + pool + "/subject/system:serviceaccount:" + namespace + ":" + name
+}
+
+// WorkloadIdentityServiceAccountName returns the account id of the GCP service account of a kubernetes ServiceAccount.
+// Account ids are limited to 30 characters, so we always add a hash of the cluster, namespace and name.
+func WorkloadIdentityServiceAccountName(namespace string, name string, clusterName string) string {
+	hash := truncate.HashString(clusterName+"/"+namespace+"/"+name, 6)
+
+	base := strings.ToLower(strings.Replace(namespace+"-"+name, ".", "-", -1))
+	maxBaseLength := 30 - len("wi-") - len(hash) - 1
+	if len(base) > maxBaseLength {
+		base = base[:maxBaseLength]
+	}
+	base = strings.TrimRight(base, "-")
+
+	return "wi-" + base + "-" + hash
+}
+
+// WorkloadIdentityServiceAccountDescription returns the description of the GCP service account of a kubernetes ServiceAccount.
+// We record the ServiceAccount there, so that we can find the GCP service accounts of a cluster when deleting it.
+func WorkloadIdentityServiceAccountDescription(namespace string, name string, clusterName string) string {
+	return fmt.Sprintf("kubernetes ServiceAccount %s/%s of cluster %s", namespace, name, clusterName)
+}
+
+// IsWorkloadIdentityServiceAccount returns true if the GCP service account is that of a kubernetes ServiceAccount of the cluster
+func IsWorkloadIdentityServiceAccount(accountID string, description string, clusterName string) bool {
+	if !strings.HasPrefix(accountID, "wi-") {
+		return false
+	}
+	var namespacedName, cluster string
+	if _, err := fmt.Sscanf(description, "kubernetes ServiceAccount %s of cluster %s", &namespacedName, &cluster); err != nil {
+		return false
+	}
+	namespace, name, found := strings.Cut(namespacedName, "/")
+	if !found || cluster != clusterName {
+		return false
+	}
+	return WorkloadIdentityServiceAccountName(namespace, name, clusterName) == accountID
+}
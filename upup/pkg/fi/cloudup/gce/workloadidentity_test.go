@@ -0,0 +1,67 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gce
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestWorkloadIdentityPrincipal(t *testing.T) {
+	provider := "projects/123/locations/global/workloadIdentityPools/kops/providers/cluster"
+	if !IsWorkloadIdentityProviderName(provider) {
+		t.Errorf("expected %q to be a workload identity provider name", provider)
+	}
+	if IsWorkloadIdentityProviderName("projects/my-project/locations/global/workloadIdentityPools/kops") {
+		t.Errorf("expected a pool not to be a workload identity provider name")
+	}
+
+	expected := "principal://iam.googleapis.com/projects/123/locations/global/workloadIdentityPools/kops/subject/system:serviceaccount:kube-system:external-dns"
+	if actual := WorkloadIdentityPrincipal(provider, "kube-system", "external-dns"); actual != expected {
+		t.Errorf("expected principal %q, got %q", expected, actual)
+	}
+}
+
+func TestWorkloadIdentityServiceAccountName(t *testing.T) {
+	accountID := regexp.MustCompile(`^[a-z][a-z0-9-]{4,28}[a-z0-9]$`)
+
+	grid := []struct {
+		Namespace string
+		Name      string
+	}{
+		{Namespace: "default", Name: "app"},
+		{Namespace: "kube-system", Name: "cluster-autoscaler"},
+		{Namespace: "a-very-long-namespace-name", Name: "with.a.dotted.name"},
+	}
+	for _, g := range grid {
+		name := WorkloadIdentityServiceAccountName(g.Namespace, g.Name, "minimal.example.com")
+		if !accountID.MatchString(name) {
+			t.Errorf("%q is not a valid service account id", name)
+		}
+		if other := WorkloadIdentityServiceAccountName(g.Namespace, g.Name, "other.example.com"); other == name {
+			t.Errorf("expected different names for different clusters, got %q", name)
+		}
+
+		description := WorkloadIdentityServiceAccountDescription(g.Namespace, g.Name, "minimal.example.com")
+		if !IsWorkloadIdentityServiceAccount(name, description, "minimal.example.com") {
+			t.Errorf("expected %q (%q) to be a service account of the cluster", name, description)
+		}
+		if IsWorkloadIdentityServiceAccount(name, description, "other.example.com") {
+			t.Errorf("expected %q (%q) not to be a service account of another cluster", name, description)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcetasks
+
+import (
+	"context"
+	"fmt"
+
+	cloudkms "google.golang.org/api/cloudkms/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/gce"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+)
+
+// CryptoKeyIAMBinding represents an IAM rule on a Cloud KMS crypto key
+// +kops:fitask
+type CryptoKeyIAMBinding struct {
+	Name      *string
+	Lifecycle fi.Lifecycle
+
+	// CryptoKey is the resource name of the key, projects/*/locations/*/keyRings/*/cryptoKeys/*
+	CryptoKey *string
+	Member    *string
+	Role      *string
+}
+
+var _ fi.CompareWithID = &CryptoKeyIAMBinding{}
+
+func (e *CryptoKeyIAMBinding) CompareWithID() *string {
+	return e.Name
+}
+
+func (e *CryptoKeyIAMBinding) Find(c *fi.Context) (*CryptoKeyIAMBinding, error) {
+	ctx := context.TODO()
+
+	cloud := c.Cloud.(gce.GCECloud)
+
+	cryptoKey := fi.StringValue(e.CryptoKey)
+	member := fi.StringValue(e.Member)
+	role := fi.StringValue(e.Role)
+
+	klog.V(2).Infof("Checking IAM for crypto key %q", cryptoKey)
+	policy, err := cloud.CloudKMS().Projects.Locations.KeyRings.CryptoKeys.GetIamPolicy(cryptoKey).Context(ctx).Do()
+	if err != nil {
+		if gce.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error checking IAM for crypto key %s: %w", cryptoKey, err)
+	}
+
+	changed := patchKMSPolicy(policy, member, role)
+	if changed {
+		return nil, nil
+	}
+
+	actual := &CryptoKeyIAMBinding{}
+	actual.CryptoKey = e.CryptoKey
+	actual.Member = e.Member
+	actual.Role = e.Role
+
+	// Ignore "system" fields
+	actual.Name = e.Name
+	actual.Lifecycle = e.Lifecycle
+
+	return actual, nil
+}
+
+func (e *CryptoKeyIAMBinding) Run(c *fi.Context) error {
+	return fi.DefaultDeltaRunMethod(e, c)
+}
+
+func (_ *CryptoKeyIAMBinding) CheckChanges(a, e, changes *CryptoKeyIAMBinding) error {
+	if fi.StringValue(e.CryptoKey) == "" {
+		return fi.RequiredField("CryptoKey")
+	}
+	if fi.StringValue(e.Member) == "" {
+		return fi.RequiredField("Member")
+	}
+	if fi.StringValue(e.Role) == "" {
+		return fi.RequiredField("Role")
+	}
+	return nil
+}
+
+func (_ *CryptoKeyIAMBinding) RenderGCE(t *gce.GCEAPITarget, a, e, changes *CryptoKeyIAMBinding) error {
+	ctx := context.TODO()
+
+	cryptoKey := fi.StringValue(e.CryptoKey)
+	member := fi.StringValue(e.Member)
+	role := fi.StringValue(e.Role)
+
+	policy, err := t.Cloud.CloudKMS().Projects.Locations.KeyRings.CryptoKeys.GetIamPolicy(cryptoKey).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("error getting IAM policy for crypto key %s: %w", cryptoKey, err)
+	}
+
+	changed := patchKMSPolicy(policy, member, role)
+
+	if !changed {
+		klog.Warningf("did not need to change policy (concurrent change?)")
+		return nil
+	}
+
+	klog.V(2).Infof("updating IAM for crypto key %s", cryptoKey)
+	if _, err := t.Cloud.CloudKMS().Projects.Locations.KeyRings.CryptoKeys.SetIamPolicy(cryptoKey, &cloudkms.SetIamPolicyRequest{Policy: policy}).Context(ctx).Do(); err != nil {
+		return fmt.Errorf("error updating IAM for crypto key %s: %w", cryptoKey, err)
+	}
+
+	return nil
+}
+
+// terraformCryptoKeyIAMMember is the model for a terraform google_kms_crypto_key_iam_member rule
+type terraformCryptoKeyIAMMember struct {
+	CryptoKeyID string `cty:"crypto_key_id"`
+	Role        string `cty:"role"`
+	Member      string `cty:"member"`
+}
+
+func (_ *CryptoKeyIAMBinding) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *CryptoKeyIAMBinding) error {
+	tf := &terraformCryptoKeyIAMMember{
+		CryptoKeyID: fi.StringValue(e.CryptoKey),
+		Role:        fi.StringValue(e.Role),
+		Member:      fi.StringValue(e.Member),
+	}
+
+	return t.RenderResource("google_kms_crypto_key_iam_member", *e.Name, tf)
+}
+
+func patchKMSPolicy(policy *cloudkms.Policy, wantMember string, wantRole string) bool {
+	for _, binding := range policy.Bindings {
+		if binding.Condition != nil {
+			continue
+		}
+		if binding.Role != wantRole {
+			continue
+		}
+		for _, member := range binding.Members {
+			if member == wantMember {
+				return false
+			}
+		}
+
+		binding.Members = append(binding.Members, wantMember)
+		return true
+	}
+
+	policy.Bindings = append(policy.Bindings, &cloudkms.Binding{
+		Members: []string{wantMember},
+		Role:    wantRole,
+	})
+	return true
+}
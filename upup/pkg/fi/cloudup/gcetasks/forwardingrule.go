@@ -90,6 +90,18 @@ func (e *ForwardingRule) Find(c *fi.Context) (*ForwardingRule, error) {
 		}
 		actual.IPAddress = address
 	}
+	if r.LoadBalancingScheme != "" {
+		actual.LoadBalancingScheme = fi.String(r.LoadBalancingScheme)
+	}
+	if r.Network != "" {
+		actual.Network = &Network{Name: fi.String(lastComponent(r.Network))}
+	}
+	if r.Subnetwork != "" {
+		actual.Subnetwork = &Subnet{Name: fi.String(lastComponent(r.Subnetwork))}
+	}
+	if r.BackendService != "" {
+		actual.BackendService = &BackendService{Name: fi.String(lastComponent(r.BackendService))}
+	}
 
 	// Ignore "system" fields
 	actual.Lifecycle = e.Lifecycle
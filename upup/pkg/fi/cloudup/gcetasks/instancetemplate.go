@@ -262,7 +262,7 @@ func (e *InstanceTemplate) mapToGCE(project string, region string) (*compute.Ins
 		}
 	}
 
-	if len(e.GuestAccelerators) > 0 {
+	if len(e.GuestAccelerators) > 0 || gce.MachineTypeHasGPU(fi.StringValue(e.MachineType)) {
 		// Instances with accelerators cannot be migrated.
 		scheduling.OnHostMaintenance = "TERMINATE"
 	}
@@ -0,0 +1,191 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcetasks
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/api/iam/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/gce"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
+)
+
+// ServiceAccountIAMBinding represents an IAM rule on a service account
+// +kops:fitask
+type ServiceAccountIAMBinding struct {
+	Name      *string
+	Lifecycle fi.Lifecycle
+
+	ServiceAccount *ServiceAccount
+	Member         *string
+	Role           *string
+}
+
+var _ fi.CompareWithID = &ServiceAccountIAMBinding{}
+
+func (e *ServiceAccountIAMBinding) CompareWithID() *string {
+	return e.Name
+}
+
+func (e *ServiceAccountIAMBinding) Find(c *fi.Context) (*ServiceAccountIAMBinding, error) {
+	ctx := context.TODO()
+
+	cloud := c.Cloud.(gce.GCECloud)
+
+	fqn, err := e.serviceAccountFQN()
+	if err != nil {
+		return nil, err
+	}
+	member := fi.StringValue(e.Member)
+	role := fi.StringValue(e.Role)
+
+	klog.V(2).Infof("Checking IAM for ServiceAccount %q", fqn)
+	policy, err := cloud.IAM().ServiceAccounts().GetIamPolicy(ctx, fqn)
+	if err != nil {
+		if gce.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error checking IAM for ServiceAccount %q: %w", fqn, err)
+	}
+
+	changed := patchIAMPolicy(policy, member, role)
+	if changed {
+		return nil, nil
+	}
+
+	actual := &ServiceAccountIAMBinding{}
+	actual.ServiceAccount = e.ServiceAccount
+	actual.Member = e.Member
+	actual.Role = e.Role
+
+	// Ignore "system" fields
+	actual.Name = e.Name
+	actual.Lifecycle = e.Lifecycle
+
+	return actual, nil
+}
+
+func (e *ServiceAccountIAMBinding) Run(c *fi.Context) error {
+	return fi.DefaultDeltaRunMethod(e, c)
+}
+
+func (_ *ServiceAccountIAMBinding) CheckChanges(a, e, changes *ServiceAccountIAMBinding) error {
+	if e.ServiceAccount == nil {
+		return fi.RequiredField("ServiceAccount")
+	}
+	if fi.StringValue(e.Member) == "" {
+		return fi.RequiredField("Member")
+	}
+	if fi.StringValue(e.Role) == "" {
+		return fi.RequiredField("Role")
+	}
+	return nil
+}
+
+func (_ *ServiceAccountIAMBinding) RenderGCE(t *gce.GCEAPITarget, a, e, changes *ServiceAccountIAMBinding) error {
+	ctx := context.TODO()
+
+	fqn, err := e.serviceAccountFQN()
+	if err != nil {
+		return err
+	}
+	member := fi.StringValue(e.Member)
+	role := fi.StringValue(e.Role)
+
+	policy, err := t.Cloud.IAM().ServiceAccounts().GetIamPolicy(ctx, fqn)
+	if err != nil {
+		return fmt.Errorf("error getting IAM policy for ServiceAccount %q: %w", fqn, err)
+	}
+
+	changed := patchIAMPolicy(policy, member, role)
+
+	if !changed {
+		klog.Warningf("did not need to change policy (concurrent change?)")
+		return nil
+	}
+
+	klog.V(2).Infof("updating IAM for ServiceAccount %q", fqn)
+	if _, err := t.Cloud.IAM().ServiceAccounts().SetIamPolicy(ctx, fqn, policy); err != nil {
+		return fmt.Errorf("error updating IAM for ServiceAccount %q: %w", fqn, err)
+	}
+
+	return nil
+}
+
+// terraformServiceAccountIAMBinding is the model for a terraform google_service_account_iam_member rule
+type terraformServiceAccountIAMBinding struct {
+	ServiceAccountID *terraformWriter.Literal `cty:"service_account_id"`
+	Role             string                   `cty:"role"`
+	Member           string                   `cty:"member"`
+}
+
+func (_ *ServiceAccountIAMBinding) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *ServiceAccountIAMBinding) error {
+	tf := &terraformServiceAccountIAMBinding{
+		Role:   fi.StringValue(e.Role),
+		Member: fi.StringValue(e.Member),
+	}
+	if fi.BoolValue(e.ServiceAccount.Shared) {
+		fqn, err := e.serviceAccountFQN()
+		if err != nil {
+			return err
+		}
+		tf.ServiceAccountID = terraformWriter.LiteralFromStringValue(fqn)
+	} else {
+		tf.ServiceAccountID = terraformWriter.LiteralProperty("google_service_account", *e.ServiceAccount.Name, "name")
+	}
+
+	return t.RenderResource("google_service_account_iam_member", *e.Name, tf)
+}
+
+// serviceAccountFQN returns the resource name of the service account the rule applies to
+func (e *ServiceAccountIAMBinding) serviceAccountFQN() (string, error) {
+	email := fi.StringValue(e.ServiceAccount.Email)
+	_, projectID, err := gce.SplitServiceAccountEmail(email)
+	if err != nil {
+		return "", err
+	}
+	return "projects/" + projectID + "/serviceAccounts/" + email, nil
+}
+
+func patchIAMPolicy(policy *iam.Policy, wantMember string, wantRole string) bool {
+	for _, binding := range policy.Bindings {
+		if binding.Condition != nil {
+			continue
+		}
+		if binding.Role != wantRole {
+			continue
+		}
+		for _, member := range binding.Members {
+			if member == wantMember {
+				return false
+			}
+		}
+
+		binding.Members = append(binding.Members, wantMember)
+		return true
+	}
+
+	policy.Bindings = append(policy.Bindings, &iam.Binding{
+		Members: []string{wantMember},
+		Role:    wantRole,
+	})
+	return true
+}
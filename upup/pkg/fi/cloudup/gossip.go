@@ -0,0 +1,55 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudup
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/dns"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+// GossipEncryptionSecretName is the name of the secret that holds the passphrases that memberlist gossip is encrypted with.
+const GossipEncryptionSecretName = "gossipencryption"
+
+// gossipEncryptionEnabled returns true if the cluster uses gossip DNS and encrypts memberlist gossip.
+func gossipEncryptionEnabled(cluster *kops.Cluster) bool {
+	return dns.IsGossipHostname(cluster.Spec.MasterInternalName) && cluster.Spec.GossipConfig != nil && fi.BoolValue(cluster.Spec.GossipConfig.Encryption)
+}
+
+// BuildGossipEncryptionKeys derives the gossip encryption keys from the gossipencryption secret.
+// The secret holds one passphrase per line; the first is the primary passphrase.  Each key is the
+// base64 encoded SHA-256 hash of its passphrase, so that every passphrase makes a valid AES-256 key.
+func BuildGossipEncryptionKeys(secret *fi.Secret) ([]string, error) {
+	var keys []string
+	for _, line := range strings.Split(string(secret.Data), "\n") {
+		passphrase := strings.TrimSpace(line)
+		if passphrase == "" {
+			continue
+		}
+		hash := sha256.Sum256([]byte(passphrase))
+		keys = append(keys, base64.StdEncoding.EncodeToString(hash[:]))
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("the %s secret does not contain any passphrases", GossipEncryptionSecretName)
+	}
+	return keys, nil
+}
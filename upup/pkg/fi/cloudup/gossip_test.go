@@ -0,0 +1,56 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudup
+
+import (
+	"encoding/base64"
+	"reflect"
+	"testing"
+
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func TestBuildGossipEncryptionKeys(t *testing.T) {
+	keys, err := BuildGossipEncryptionKeys(&fi.Secret{Data: []byte("new-passphrase\n\n  old-passphrase  \n")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(keys) != 2 {
+		t.Fatalf("expected 2 keys, got %v", keys)
+	}
+	for _, key := range keys {
+		b, err := base64.StdEncoding.DecodeString(key)
+		if err != nil {
+			t.Fatalf("key %q is not base64 encoded: %v", key, err)
+		}
+		if len(b) != 32 {
+			t.Errorf("key %q is %d bytes, expected 32", key, len(b))
+		}
+	}
+
+	again, err := BuildGossipEncryptionKeys(&fi.Secret{Data: []byte("new-passphrase\nold-passphrase")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(keys, again) {
+		t.Errorf("keys are not stable: %v != %v", keys, again)
+	}
+
+	if _, err := BuildGossipEncryptionKeys(&fi.Secret{Data: []byte("\n")}); err == nil {
+		t.Errorf("expected error for a secret without passphrases")
+	}
+}
@@ -0,0 +1,244 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+// IPAMHookEnvVar names the executable or http(s) URL of an external IPAM hook.
+const IPAMHookEnvVar = "KOPS_IPAM_HOOK"
+
+// IPAMHook supplies network ranges from an external IP address management system,
+// instead of kOps carving them out of the default ranges.
+type IPAMHook interface {
+	// Allocate returns the ranges to assign. Ranges that are left empty are assigned by kOps as usual.
+	Allocate(ctx context.Context, request *IPAMRequest) (*IPAMResponse, error)
+}
+
+// ExternalIPAM is the IPAM hook used by PerformAssignments.
+// If it is nil, the hook named by the KOPS_IPAM_HOOK environment variable is used, if any.
+var ExternalIPAM IPAMHook
+
+// IPAMRequest describes the cluster whose network ranges are being assigned.
+// Values that are already set in the cluster spec are included, and won't be changed.
+type IPAMRequest struct {
+	ClusterName           string        `json:"clusterName"`
+	CloudProvider         string        `json:"cloudProvider"`
+	NetworkID             string        `json:"networkID,omitempty"`
+	NetworkCIDR           string        `json:"networkCIDR,omitempty"`
+	NonMasqueradeCIDR     string        `json:"nonMasqueradeCIDR,omitempty"`
+	ServiceClusterIPRange string        `json:"serviceClusterIPRange,omitempty"`
+	PodCIDR               string        `json:"podCIDR,omitempty"`
+	Subnets               []*IPAMSubnet `json:"subnets,omitempty"`
+}
+
+// IPAMResponse holds the network ranges allocated by an IPAM hook.
+type IPAMResponse struct {
+	NetworkCIDR           string        `json:"networkCIDR,omitempty"`
+	NonMasqueradeCIDR     string        `json:"nonMasqueradeCIDR,omitempty"`
+	ServiceClusterIPRange string        `json:"serviceClusterIPRange,omitempty"`
+	PodCIDR               string        `json:"podCIDR,omitempty"`
+	Subnets               []*IPAMSubnet `json:"subnets,omitempty"`
+}
+
+// IPAMSubnet is a subnet of the cluster, identified by name.
+type IPAMSubnet struct {
+	Name string `json:"name"`
+	Zone string `json:"zone,omitempty"`
+	Type string `json:"type,omitempty"`
+	CIDR string `json:"cidr,omitempty"`
+}
+
+// ipamHookFromEnv returns the IPAM hook named by the KOPS_IPAM_HOOK environment variable, or nil if it isn't set.
+func ipamHookFromEnv() IPAMHook {
+	hook := os.Getenv(IPAMHookEnvVar)
+	if hook == "" {
+		return nil
+	}
+	if strings.HasPrefix(hook, "http://") || strings.HasPrefix(hook, "https://") {
+		return &webhookIPAMHook{URL: hook}
+	}
+	return &execIPAMHook{Path: hook}
+}
+
+// execIPAMHook runs an executable, which reads the IPAMRequest as JSON on stdin and writes the IPAMResponse as JSON to stdout.
+type execIPAMHook struct {
+	Path string
+}
+
+func (h *execIPAMHook) Allocate(ctx context.Context, request *IPAMRequest) (*IPAMResponse, error) {
+	requestJSON, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing IPAM request: %w", err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, h.Path)
+	cmd.Stdin = bytes.NewReader(requestJSON)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("error running IPAM hook %q: %w: %s", h.Path, err, strings.TrimSpace(stderr.String()))
+	}
+
+	response := &IPAMResponse{}
+	if err := json.Unmarshal(stdout.Bytes(), response); err != nil {
+		return nil, fmt.Errorf("error parsing output of IPAM hook %q: %w", h.Path, err)
+	}
+	return response, nil
+}
+
+// webhookIPAMHook POSTs the IPAMRequest as JSON to a URL, which responds with the IPAMResponse as JSON.
+type webhookIPAMHook struct {
+	URL string
+}
+
+func (h *webhookIPAMHook) Allocate(ctx context.Context, request *IPAMRequest) (*IPAMResponse, error) {
+	requestJSON, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing IPAM request: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, h.URL, bytes.NewReader(requestJSON))
+	if err != nil {
+		return nil, fmt.Errorf("error building request to IPAM webhook %q: %w", h.URL, err)
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+
+	httpResponse, err := http.DefaultClient.Do(httpRequest)
+	if err != nil {
+		return nil, fmt.Errorf("error calling IPAM webhook %q: %w", h.URL, err)
+	}
+	defer httpResponse.Body.Close()
+
+	body, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response from IPAM webhook %q: %w", h.URL, err)
+	}
+	if httpResponse.StatusCode < 200 || httpResponse.StatusCode >= 300 {
+		return nil, fmt.Errorf("IPAM webhook %q returned %s: %s", h.URL, httpResponse.Status, strings.TrimSpace(string(body)))
+	}
+
+	response := &IPAMResponse{}
+	if err := json.Unmarshal(body, response); err != nil {
+		return nil, fmt.Errorf("error parsing response from IPAM webhook %q: %w", h.URL, err)
+	}
+	return response, nil
+}
+
+// needsIPAMAssignments returns true if any of the ranges PerformAssignments would carve out are still unassigned.
+// The service and pod ranges are defaulted later from the nonMasqueradeCIDR, so they alone don't require calling the hook.
+func needsIPAMAssignments(c *kops.Cluster) bool {
+	if c.Spec.NonMasqueradeCIDR == "" || !allSubnetsHaveCIDRs(c) {
+		return true
+	}
+	switch c.Spec.GetCloudProvider() {
+	case kops.CloudProviderAWS, kops.CloudProviderAzure:
+		return c.Spec.NetworkCIDR == ""
+	}
+	return false
+}
+
+// assignFromIPAMHook asks the IPAM hook for the ranges that are not yet assigned, and sets them in the cluster spec.
+// Ranges that are already set are never changed, as they are immutable once the cluster is created.
+func assignFromIPAMHook(ctx context.Context, c *kops.Cluster, hook IPAMHook) error {
+	if !needsIPAMAssignments(c) {
+		return nil
+	}
+
+	request := &IPAMRequest{
+		ClusterName:           c.ObjectMeta.Name,
+		CloudProvider:         string(c.Spec.GetCloudProvider()),
+		NetworkID:             c.Spec.NetworkID,
+		NetworkCIDR:           c.Spec.NetworkCIDR,
+		NonMasqueradeCIDR:     c.Spec.NonMasqueradeCIDR,
+		ServiceClusterIPRange: c.Spec.ServiceClusterIPRange,
+		PodCIDR:               c.Spec.PodCIDR,
+	}
+	for i := range c.Spec.Subnets {
+		subnet := &c.Spec.Subnets[i]
+		request.Subnets = append(request.Subnets, &IPAMSubnet{
+			Name: subnet.Name,
+			Zone: subnet.Zone,
+			Type: string(subnet.Type),
+			CIDR: subnet.CIDR,
+		})
+	}
+
+	response, err := hook.Allocate(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	assign := func(field string, value *string, allocated string) error {
+		if allocated == "" || *value != "" {
+			return nil
+		}
+		if _, _, err := net.ParseCIDR(allocated); err != nil {
+			return fmt.Errorf("IPAM hook returned invalid %s %q: %w", field, allocated, err)
+		}
+		klog.Infof("Assigning %s %q from IPAM hook", field, allocated)
+		*value = allocated
+		return nil
+	}
+
+	if err := assign("networkCIDR", &c.Spec.NetworkCIDR, response.NetworkCIDR); err != nil {
+		return err
+	}
+	if err := assign("nonMasqueradeCIDR", &c.Spec.NonMasqueradeCIDR, response.NonMasqueradeCIDR); err != nil {
+		return err
+	}
+	if err := assign("serviceClusterIPRange", &c.Spec.ServiceClusterIPRange, response.ServiceClusterIPRange); err != nil {
+		return err
+	}
+	if err := assign("podCIDR", &c.Spec.PodCIDR, response.PodCIDR); err != nil {
+		return err
+	}
+
+	subnets := make(map[string]*kops.ClusterSubnetSpec)
+	for i := range c.Spec.Subnets {
+		subnets[c.Spec.Subnets[i].Name] = &c.Spec.Subnets[i]
+	}
+	for _, allocated := range response.Subnets {
+		subnet := subnets[allocated.Name]
+		if subnet == nil {
+			return fmt.Errorf("IPAM hook returned unknown subnet %q", allocated.Name)
+		}
+		if err := assign("CIDR of subnet "+subnet.Name, &subnet.CIDR, allocated.CIDR); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudup
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type fakeIPAMHook struct {
+	requests []*IPAMRequest
+	response *IPAMResponse
+}
+
+func (h *fakeIPAMHook) Allocate(ctx context.Context, request *IPAMRequest) (*IPAMResponse, error) {
+	h.requests = append(h.requests, request)
+	return h.response, nil
+}
+
+func TestPerformAssignments_IPAMHook(t *testing.T) {
+	cloud, c := buildMinimalCluster()
+	c.Spec.NetworkCIDR = ""
+	c.Spec.NonMasqueradeCIDR = ""
+	c.Spec.Subnets[0].CIDR = ""
+	c.Spec.Subnets[1].CIDR = ""
+
+	hook := &fakeIPAMHook{
+		response: &IPAMResponse{
+			NetworkCIDR:           "10.10.0.0/16",
+			NonMasqueradeCIDR:     "100.64.0.0/10",
+			ServiceClusterIPRange: "100.64.0.0/16",
+			Subnets: []*IPAMSubnet{
+				{Name: "subnet-us-mock-1a", CIDR: "10.10.1.0/24"},
+				{Name: "subnet-us-mock-1c", CIDR: "10.10.99.0/24"},
+			},
+		},
+	}
+	ExternalIPAM = hook
+	defer func() { ExternalIPAM = nil }()
+
+	if err := PerformAssignments(c, cloud); err != nil {
+		t.Fatalf("error from PerformAssignments: %v", err)
+	}
+
+	if len(hook.requests) != 1 {
+		t.Fatalf("expected the IPAM hook to be called once, was called %d times", len(hook.requests))
+	}
+	if hook.requests[0].ClusterName != "testcluster.test.com" || len(hook.requests[0].Subnets) != 3 {
+		t.Errorf("unexpected IPAM request: %+v", hook.requests[0])
+	}
+
+	if c.Spec.NetworkCIDR != "10.10.0.0/16" {
+		t.Errorf("unexpected NetworkCIDR: %q", c.Spec.NetworkCIDR)
+	}
+	if c.Spec.ServiceClusterIPRange != "100.64.0.0/16" {
+		t.Errorf("unexpected ServiceClusterIPRange: %q", c.Spec.ServiceClusterIPRange)
+	}
+	if c.Spec.Subnets[0].CIDR != "10.10.1.0/24" {
+		t.Errorf("unexpected CIDR for subnet allocated by the IPAM hook: %q", c.Spec.Subnets[0].CIDR)
+	}
+	if c.Spec.Subnets[1].CIDR == "" {
+		t.Errorf("expected kOps to assign the CIDR of the subnet the IPAM hook left empty")
+	}
+	if c.Spec.Subnets[2].CIDR != "172.20.3.0/24" {
+		t.Errorf("expected existing subnet CIDR to be unchanged, got %q", c.Spec.Subnets[2].CIDR)
+	}
+
+	// Once everything is assigned, the hook is no longer called
+	if err := PerformAssignments(c, cloud); err != nil {
+		t.Fatalf("error from PerformAssignments: %v", err)
+	}
+	if len(hook.requests) != 1 {
+		t.Errorf("expected the IPAM hook not to be called again, was called %d times", len(hook.requests))
+	}
+}
+
+func TestPerformAssignments_IPAMHookErrors(t *testing.T) {
+	grid := []struct {
+		Description   string
+		Response      *IPAMResponse
+		ExpectedError string
+	}{
+		{
+			Description:   "invalid CIDR",
+			Response:      &IPAMResponse{NonMasqueradeCIDR: "100.64.0.0"},
+			ExpectedError: "IPAM hook returned invalid nonMasqueradeCIDR",
+		},
+		{
+			Description:   "unknown subnet",
+			Response:      &IPAMResponse{Subnets: []*IPAMSubnet{{Name: "unknown", CIDR: "10.10.1.0/24"}}},
+			ExpectedError: "IPAM hook returned unknown subnet \"unknown\"",
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.Description, func(t *testing.T) {
+			cloud, c := buildMinimalCluster()
+			c.Spec.NonMasqueradeCIDR = ""
+
+			ExternalIPAM = &fakeIPAMHook{response: g.Response}
+			defer func() { ExternalIPAM = nil }()
+
+			err := PerformAssignments(c, cloud)
+			if err == nil || !strings.Contains(err.Error(), g.ExpectedError) {
+				t.Fatalf("expected error containing %q, got %v", g.ExpectedError, err)
+			}
+		})
+	}
+}
+
+func TestExecIPAMHook(t *testing.T) {
+	script := filepath.Join(t.TempDir(), "ipam-hook")
+	contents := "#!/bin/sh\ngrep -q '\"clusterName\":\"testcluster.test.com\"' || exit 1\necho '{\"nonMasqueradeCIDR\": \"100.64.0.0/10\"}'\n"
+	if err := os.WriteFile(script, []byte(contents), 0o755); err != nil {
+		t.Fatalf("error writing hook: %v", err)
+	}
+
+	response, err := (&execIPAMHook{Path: script}).Allocate(context.Background(), &IPAMRequest{ClusterName: "testcluster.test.com"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if response.NonMasqueradeCIDR != "100.64.0.0/10" {
+		t.Errorf("unexpected response: %+v", response)
+	}
+
+	if _, err := (&execIPAMHook{Path: script}).Allocate(context.Background(), &IPAMRequest{ClusterName: "other"}); err == nil {
+		t.Errorf("expected error when the hook fails")
+	}
+}
+
+func TestWebhookIPAMHook(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := &IPAMRequest{}
+		if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if request.ClusterName != "testcluster.test.com" {
+			http.Error(w, "unknown cluster", http.StatusForbidden)
+			return
+		}
+		json.NewEncoder(w).Encode(&IPAMResponse{PodCIDR: "100.96.0.0/11"})
+	}))
+	defer server.Close()
+
+	response, err := (&webhookIPAMHook{URL: server.URL}).Allocate(context.Background(), &IPAMRequest{ClusterName: "testcluster.test.com"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if response.PodCIDR != "100.96.0.0/11" {
+		t.Errorf("unexpected response: %+v", response)
+	}
+
+	_, err = (&webhookIPAMHook{URL: server.URL}).Allocate(context.Background(), &IPAMRequest{ClusterName: "other"})
+	if err == nil || !strings.Contains(err.Error(), "unknown cluster") {
+		t.Errorf("expected error containing the webhook's response, got %v", err)
+	}
+}
@@ -266,6 +266,7 @@ func (c *populateClusterSpec) run(clientset simple.Clientset) error {
 			codeModels = append(codeModels, &components.KubeAPIServerOptionsBuilder{OptionsContext: optionsContext})
 			codeModels = append(codeModels, &components.DockerOptionsBuilder{OptionsContext: optionsContext})
 			codeModels = append(codeModels, &components.ContainerdOptionsBuilder{OptionsContext: optionsContext})
+			codeModels = append(codeModels, &components.CrioOptionsBuilder{OptionsContext: optionsContext})
 			codeModels = append(codeModels, &components.NetworkingOptionsBuilder{Context: optionsContext})
 			codeModels = append(codeModels, &components.KubeDnsOptionsBuilder{Context: optionsContext})
 			codeModels = append(codeModels, &components.KubeletOptionsBuilder{OptionsContext: optionsContext})
@@ -280,6 +281,7 @@ func (c *populateClusterSpec) run(clientset simple.Clientset) error {
 			codeModels = append(codeModels, &components.ClusterAutoscalerOptionsBuilder{OptionsContext: optionsContext})
 			codeModels = append(codeModels, &components.NodeTerminationHandlerOptionsBuilder{OptionsContext: optionsContext})
 			codeModels = append(codeModels, &components.NodeProblemDetectorOptionsBuilder{OptionsContext: optionsContext})
+			codeModels = append(codeModels, &components.MonitoringOptionsBuilder{OptionsContext: optionsContext})
 			codeModels = append(codeModels, &components.AWSEBSCSIDriverOptionsBuilder{OptionsContext: optionsContext})
 			codeModels = append(codeModels, &components.AWSCloudControllerManagerOptionsBuilder{OptionsContext: optionsContext})
 			codeModels = append(codeModels, &components.GCPCloudControllerManagerOptionsBuilder{OptionsContext: optionsContext})
@@ -322,36 +324,49 @@ func (c *populateClusterSpec) assignSubnets(cluster *kopsapi.Cluster) error {
 	if err != nil {
 		return fmt.Errorf("error parsing NonMasqueradeCIDR %q: %v", cluster.Spec.NonMasqueradeCIDR, err)
 	}
-	nmOnes, nmBits := nonMasqueradeCIDR.Mask.Size()
+	_, nmBits := nonMasqueradeCIDR.Mask.Size()
 
 	if cluster.Spec.KubeControllerManager == nil {
 		cluster.Spec.KubeControllerManager = &kopsapi.KubeControllerManagerConfig{}
 	}
 
 	if cluster.Spec.PodCIDR == "" && nmBits == 32 {
-		// Allocate as big a range as possible: the NonMasqueradeCIDR mask + 1, with a '1' in the extra bit
-		ip := nonMasqueradeCIDR.IP.Mask(nonMasqueradeCIDR.Mask)
-		ip[nmOnes/8] |= 128 >> (nmOnes % 8)
-		cidr := net.IPNet{IP: ip, Mask: net.CIDRMask(nmOnes+1, nmBits)}
-		cluster.Spec.PodCIDR = cidr.String()
+		cluster.Spec.PodCIDR = DefaultPodCIDR(nonMasqueradeCIDR)
 		klog.V(2).Infof("Defaulted PodCIDR to %v", cluster.Spec.PodCIDR)
 	}
 
 	if cluster.Spec.ServiceClusterIPRange == "" {
-		if nmBits > 32 {
-			cluster.Spec.ServiceClusterIPRange = "fd00:5e4f:ce::/108"
-		} else {
-			// Allocate from the '0' subnet; but only carve off 1/4 of that (i.e. add 1 + 2 bits to the netmask)
-			serviceOnes := nmOnes + 3
-			// Max size of network is 20 bits
-			if nmBits-serviceOnes > 20 {
-				serviceOnes = nmBits - 20
-			}
-			cidr := net.IPNet{IP: nonMasqueradeCIDR.IP.Mask(nonMasqueradeCIDR.Mask), Mask: net.CIDRMask(serviceOnes, nmBits)}
-			cluster.Spec.ServiceClusterIPRange = cidr.String()
-		}
+		cluster.Spec.ServiceClusterIPRange = DefaultServiceClusterIPRange(nonMasqueradeCIDR)
 		klog.V(2).Infof("Defaulted ServiceClusterIPRange to %v", cluster.Spec.ServiceClusterIPRange)
 	}
 
 	return nil
 }
+
+// DefaultPodCIDR returns the PodCIDR assigned to a cluster with an IPv4 NonMasqueradeCIDR that doesn't specify one
+func DefaultPodCIDR(nonMasqueradeCIDR *net.IPNet) string {
+	nmOnes, nmBits := nonMasqueradeCIDR.Mask.Size()
+
+	// Allocate as big a range as possible: the NonMasqueradeCIDR mask + 1, with a '1' in the extra bit
+	ip := nonMasqueradeCIDR.IP.Mask(nonMasqueradeCIDR.Mask)
+	ip[nmOnes/8] |= 128 >> (nmOnes % 8)
+	cidr := net.IPNet{IP: ip, Mask: net.CIDRMask(nmOnes+1, nmBits)}
+	return cidr.String()
+}
+
+// DefaultServiceClusterIPRange returns the ServiceClusterIPRange assigned to a cluster that doesn't specify one
+func DefaultServiceClusterIPRange(nonMasqueradeCIDR *net.IPNet) string {
+	nmOnes, nmBits := nonMasqueradeCIDR.Mask.Size()
+	if nmBits > 32 {
+		return "fd00:5e4f:ce::/108"
+	}
+
+	// Allocate from the '0' subnet; but only carve off 1/4 of that (i.e. add 1 + 2 bits to the netmask)
+	serviceOnes := nmOnes + 3
+	// Max size of network is 20 bits
+	if nmBits-serviceOnes > 20 {
+		serviceOnes = nmBits - 20
+	}
+	cidr := net.IPNet{IP: nonMasqueradeCIDR.IP.Mask(nonMasqueradeCIDR.Mask), Mask: net.CIDRMask(serviceOnes, nmBits)}
+	return cidr.String()
+}
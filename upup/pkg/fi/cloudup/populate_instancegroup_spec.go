@@ -30,6 +30,8 @@ import (
 	"k8s.io/kops/pkg/featureflag"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
+	"k8s.io/kops/upup/pkg/fi/cloudup/azure"
+	"k8s.io/kops/upup/pkg/fi/cloudup/gce"
 	"k8s.io/kops/upup/pkg/fi/cloudup/openstack"
 	"k8s.io/kops/util/pkg/architectures"
 	"k8s.io/kops/util/pkg/reflectutils"
@@ -197,6 +199,19 @@ func PopulateInstanceGroupSpec(cluster *kops.Cluster, input *kops.InstanceGroup,
 			}
 			hasGPU = mt.GPU
 		}
+	case kops.CloudProviderGCE:
+		if clusterNvidia || igNvidia {
+			hasGPU = gce.MachineTypeHasGPU(ig.Spec.MachineType)
+			for _, accelerator := range ig.Spec.GuestAccelerators {
+				if gce.IsNvidiaAccelerator(accelerator.AcceleratorType) {
+					hasGPU = true
+				}
+			}
+		}
+	case kops.CloudProviderAzure:
+		if clusterNvidia || igNvidia {
+			hasGPU = azure.MachineTypeHasNvidiaGPU(ig.Spec.MachineType)
+		}
 	case kops.CloudProviderOpenstack:
 		if igNvidia {
 			hasGPU = true
@@ -219,6 +234,18 @@ func PopulateInstanceGroupSpec(cluster *kops.Cluster, input *kops.InstanceGroup,
 		}
 	}
 
+	if ig.Spec.EFA != nil && fi.BoolValue(ig.Spec.EFA.Enabled) && ig.Spec.EFA.NetworkCards == nil && cluster.Spec.GetCloudProvider() == kops.CloudProviderAWS {
+		mt, err := awsup.GetMachineTypeInfo(cloud.(awsup.AWSCloud), ig.Spec.MachineType)
+		if err != nil {
+			return ig, fmt.Errorf("error looking up machine type info: %v", err)
+		}
+		networkCards := mt.NetworkCards
+		if networkCards < 1 {
+			networkCards = 1
+		}
+		ig.Spec.EFA.NetworkCards = fi.Int32(int32(networkCards))
+	}
+
 	if ig.Spec.Manager == "" {
 		ig.Spec.Manager = kops.InstanceManagerCloudGroup
 	}
@@ -0,0 +1,69 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pulumi
+
+import (
+	"encoding/json"
+	"sort"
+
+	"k8s.io/klog/v2"
+)
+
+// Literal represents a literal in Pulumi YAML syntax
+type Literal struct {
+	// value is either a plain value, an "${resource.property}" interpolation,
+	// or a map representing a Pulumi YAML builtin function (e.g. fn::readFile)
+	value interface{}
+}
+
+var _ json.Marshaler = &Literal{}
+
+func (l *Literal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&l.value)
+}
+
+// LiteralProperty returns a reference to a property of another resource in the program
+func LiteralProperty(resourceType, resourceName, prop string) *Literal {
+	return &Literal{value: "${" + ResourceName(resourceType, resourceName) + "." + prop + "}"}
+}
+
+// LiteralFromStringValue returns a Literal holding a constant string
+func LiteralFromStringValue(s string) *Literal {
+	return &Literal{value: escapeInterpolation(s)}
+}
+
+// LiteralFunction returns a Literal that invokes a Pulumi YAML builtin function
+func LiteralFunction(fn string, arg interface{}) *Literal {
+	return &Literal{value: map[string]interface{}{"fn::" + fn: arg}}
+}
+
+// SortLiterals sorts a list of Literal, by their serialized representation.  It does so in-place
+func SortLiterals(v []*Literal) {
+	keys := make(map[*Literal]string, len(v))
+	for _, l := range v {
+		k, err := json.Marshal(l)
+		if err != nil {
+			// Very unexpected
+			klog.Fatalf("error processing pulumi Literal: %v", err)
+		}
+		keys[l] = string(k)
+	}
+
+	sort.SliceStable(v, func(i, j int) bool {
+		return keys[v[i]] < keys[v[j]]
+	})
+}
@@ -0,0 +1,255 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pulumi
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+	"sigs.k8s.io/yaml"
+)
+
+// PulumiTarget renders the task graph as a Pulumi YAML program
+type PulumiTarget struct {
+	Cloud   fi.Cloud
+	Project string
+
+	ClusterName string
+
+	outDir string
+
+	// mutex protects the following items (resources, outputs & files)
+	mutex     sync.Mutex
+	resources map[string]*pulumiResource
+	outputs   map[string]*pulumiOutputVariable
+	files     map[string][]byte
+}
+
+func NewPulumiTarget(cloud fi.Cloud, project string, clusterName string, outDir string) *PulumiTarget {
+	return &PulumiTarget{
+		Cloud:       cloud,
+		Project:     project,
+		ClusterName: clusterName,
+		outDir:      outDir,
+		resources:   make(map[string]*pulumiResource),
+		outputs:     make(map[string]*pulumiOutputVariable),
+		files:       make(map[string][]byte),
+	}
+}
+
+var _ fi.Target = &PulumiTarget{}
+
+type pulumiResource struct {
+	Type       string                 `json:"type"`
+	Properties interface{}            `json:"properties,omitempty"`
+	Options    *pulumiResourceOptions `json:"options,omitempty"`
+}
+
+type pulumiResourceOptions struct {
+	Provider string `json:"provider,omitempty"`
+}
+
+type pulumiOutputVariable struct {
+	Value      *Literal
+	ValueArray []*Literal
+}
+
+// providerResourceName is the name of the explicit provider resource that all resources are bound to
+const providerResourceName = "kops-provider"
+
+// ResourceName returns the logical name of a resource in the Pulumi program.
+// The logical name must be unique across all resource types, so we prefix it with the module and kind of the resource;
+// the kind alone is ambiguous, e.g. aws:elb:LoadBalancer and aws:lb:LoadBalancer.
+func ResourceName(resourceType, resourceName string) string {
+	kind := resourceType
+	if i := strings.Index(kind, ":"); i != -1 {
+		kind = kind[i+1:]
+	}
+	kind = strings.NewReplacer(":", "-", "/", "-").Replace(kind)
+	return strings.ToLower(kind) + "-" + sanitizeName(resourceName)
+}
+
+// sanitizeName ensures resource names do not contain characters that are significant in interpolations
+func sanitizeName(name string) string {
+	return strings.NewReplacer(".", "-", "/", "--", ":", "_").Replace(name)
+}
+
+// escapeInterpolation escapes any sequences that Pulumi YAML would otherwise treat as an interpolation
+func escapeInterpolation(s string) string {
+	return strings.ReplaceAll(s, "${", "$${")
+}
+
+func (t *PulumiTarget) ProcessDeletions() bool {
+	// Pulumi tracks & performs deletions itself
+	return false
+}
+
+func (t *PulumiTarget) RenderResource(resourceType string, resourceName string, e interface{}) error {
+	res := &pulumiResource{
+		Type:       resourceType,
+		Properties: e,
+		Options: &pulumiResourceOptions{
+			Provider: "${" + providerResourceName + "}",
+		},
+	}
+
+	name := ResourceName(resourceType, resourceName)
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.resources[name] != nil {
+		return fmt.Errorf("resource %q already exists in pulumi program", name)
+	}
+	t.resources[name] = res
+
+	return nil
+}
+
+// AddFileResource renders a resource to a file alongside the program, returning a Literal that reads it
+func (t *PulumiTarget) AddFileResource(resourceType string, resourceName string, key string, r fi.Resource, base64 bool) (*Literal, error) {
+	d, err := fi.ResourceAsBytes(r)
+	if err != nil {
+		id := resourceType + "_" + resourceName + "_" + key
+		return nil, fmt.Errorf("error rending resource %s %v", id, err)
+	}
+
+	return t.AddFileBytes(resourceType, resourceName, key, d, base64)
+}
+
+// AddFileBytes writes data to a file alongside the program, returning a Literal that reads it
+func (t *PulumiTarget) AddFileBytes(resourceType string, resourceName string, key string, data []byte, base64 bool) (*Literal, error) {
+	id := sanitizeName(ResourceName(resourceType, resourceName) + "_" + key)
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	p := path.Join("data", id)
+	t.files[p] = data
+
+	l := LiteralFunction("readFile", "./"+p)
+	if base64 {
+		l = LiteralFunction("toBase64", l)
+	}
+	return l, nil
+}
+
+func (t *PulumiTarget) AddOutputVariable(key string, literal *Literal) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.outputs[key] != nil {
+		return fmt.Errorf("duplicate variable: %q", key)
+	}
+	t.outputs[key] = &pulumiOutputVariable{Value: literal}
+
+	return nil
+}
+
+func (t *PulumiTarget) AddOutputVariableArray(key string, literal *Literal) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.outputs[key] == nil {
+		t.outputs[key] = &pulumiOutputVariable{}
+	}
+	if t.outputs[key].Value != nil {
+		return fmt.Errorf("variable %q is both an array and a scalar", key)
+	}
+
+	t.outputs[key].ValueArray = append(t.outputs[key].ValueArray, literal)
+
+	return nil
+}
+
+func (t *PulumiTarget) Finish(taskMap map[string]fi.Task) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	providerType := "pulumi:providers:" + string(t.Cloud.ProviderID())
+	providerProperties := map[string]interface{}{
+		"region": t.Cloud.Region(),
+	}
+	if t.Cloud.ProviderID() == kops.CloudProviderGCE {
+		providerType = "pulumi:providers:gcp"
+		providerProperties["project"] = t.Project
+	}
+
+	resources := make(map[string]*pulumiResource, len(t.resources)+1)
+	for k, v := range t.resources {
+		resources[k] = v
+	}
+	resources[providerResourceName] = &pulumiResource{
+		Type:       providerType,
+		Properties: providerProperties,
+	}
+
+	outputs := make(map[string]interface{}, len(t.outputs))
+	for k, v := range t.outputs {
+		if v.Value != nil {
+			outputs[k] = v.Value
+		} else {
+			SortLiterals(v.ValueArray)
+			outputs[k] = v.ValueArray
+		}
+	}
+
+	program := map[string]interface{}{
+		"name":        sanitizeName(t.ClusterName),
+		"runtime":     "yaml",
+		"description": fmt.Sprintf("kOps managed resources for cluster %s", t.ClusterName),
+		"resources":   resources,
+	}
+	if len(outputs) != 0 {
+		program["outputs"] = outputs
+	}
+
+	yamlBytes, err := yaml.Marshal(program)
+	if err != nil {
+		return fmt.Errorf("error marshaling pulumi program to yaml: %v", err)
+	}
+
+	files := make(map[string][]byte, len(t.files)+1)
+	for k, v := range t.files {
+		files[k] = v
+	}
+	files["Pulumi.yaml"] = yamlBytes
+
+	for relativePath, contents := range files {
+		p := path.Join(t.outDir, relativePath)
+
+		err = os.MkdirAll(path.Dir(p), os.FileMode(0o755))
+		if err != nil {
+			return fmt.Errorf("error creating output directory %q: %v", path.Dir(p), err)
+		}
+
+		err = os.WriteFile(p, contents, os.FileMode(0o644))
+		if err != nil {
+			return fmt.Errorf("error writing pulumi data to output file %q: %v", p, err)
+		}
+	}
+
+	klog.Infof("Pulumi output is in %s", t.outDir)
+
+	return nil
+}
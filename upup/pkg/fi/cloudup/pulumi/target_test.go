@@ -0,0 +1,53 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pulumi
+
+import "testing"
+
+func TestResourceName(t *testing.T) {
+	grid := []struct {
+		resourceType string
+		resourceName string
+		expected     string
+	}{
+		{
+			resourceType: "aws:ec2:LaunchTemplate",
+			resourceName: "nodes.minimal.example.com",
+			expected:     "ec2-launchtemplate-nodes-minimal-example-com",
+		},
+		{
+			resourceType: "aws:elb:LoadBalancer",
+			resourceName: "api.minimal.example.com",
+			expected:     "elb-loadbalancer-api-minimal-example-com",
+		},
+		{
+			resourceType: "aws:lb:LoadBalancer",
+			resourceName: "api.minimal.example.com",
+			expected:     "lb-loadbalancer-api-minimal-example-com",
+		},
+		{
+			resourceType: "gcp:compute/instanceTemplate:InstanceTemplate",
+			resourceName: "nodes",
+			expected:     "compute-instancetemplate-instancetemplate-nodes",
+		},
+	}
+	for _, g := range grid {
+		if actual := ResourceName(g.resourceType, g.resourceName); actual != g.expected {
+			t.Errorf("ResourceName(%q, %q): expected %q, got %q", g.resourceType, g.resourceName, g.expected, actual)
+		}
+	}
+}
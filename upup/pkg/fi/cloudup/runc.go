@@ -40,9 +40,11 @@ func findRuncAsset(c *kops.Cluster, assetBuilder *assets.AssetBuilder, arch arch
 		return nil, nil, fmt.Errorf("unable to find containerd version, used to determine runc version")
 	}
 
-	containerdVersion, err := semver.ParseTolerant(fi.StringValue(c.Spec.Containerd.Version))
+	containerd := c.Spec.Containerd
+
+	containerdVersion, err := semver.ParseTolerant(fi.StringValue(containerd.Version))
 	if err != nil {
-		return nil, nil, fmt.Errorf("unable to parse version string: %q", fi.StringValue(c.Spec.Containerd.Version))
+		return nil, nil, fmt.Errorf("unable to parse version string: %q", fi.StringValue(containerd.Version))
 	}
 	// The a compatible runc binary is bundled with containerd builds < v1.6.0
 	// https://github.com/containerd/containerd/issues/6541
@@ -50,7 +52,24 @@ func findRuncAsset(c *kops.Cluster, assetBuilder *assets.AssetBuilder, arch arch
 		return nil, nil, nil
 	}
 
+	runc := containerd.Runc
+	if runc != nil && runc.Packages != nil {
+		if arch == architectures.ArchitectureAmd64 && runc.Packages.UrlAmd64 != nil && runc.Packages.HashAmd64 != nil {
+			assetUrl := fi.StringValue(runc.Packages.UrlAmd64)
+			assetHash := fi.StringValue(runc.Packages.HashAmd64)
+			return findAssetsUrlHash(assetBuilder, assetUrl, assetHash)
+		}
+		if arch == architectures.ArchitectureArm64 && runc.Packages.UrlArm64 != nil && runc.Packages.HashArm64 != nil {
+			assetUrl := fi.StringValue(runc.Packages.UrlArm64)
+			assetHash := fi.StringValue(runc.Packages.HashArm64)
+			return findAssetsUrlHash(assetBuilder, assetUrl, assetHash)
+		}
+	}
+
 	version := runcVersion
+	if runc != nil && runc.Version != nil {
+		version = fi.StringValue(runc.Version)
+	}
 	assetUrl, assetHash, err := findRuncVersionUrlHash(arch, version)
 	if err != nil {
 		return nil, nil, err
@@ -73,7 +92,7 @@ func findRuncVersionUrlHash(arch architectures.Architecture, version string) (u
 			return "", "", err
 		}
 	} else {
-		return "", "", fmt.Errorf("unknown url and hash for runc version: %s - %s", arch, version)
+		return "", "", fmt.Errorf("unknown url and hash for runc version: %s - %s, set the URL and hash in spec.containerd.runc.packages", arch, version)
 	}
 
 	return runcAssetUrl, runcAssetHash, nil
@@ -18,8 +18,12 @@ package cloudup
 
 import (
 	"os"
+	"strings"
 	"testing"
 
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/assets"
+	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/util/pkg/architectures"
 )
 
@@ -52,3 +56,95 @@ func TestRuncVersionsHashesArm64(t *testing.T) {
 		})
 	}
 }
+
+func TestFindRuncAsset(t *testing.T) {
+	tests := []struct {
+		name              string
+		containerd        *kops.ContainerdConfig
+		arch              architectures.Architecture
+		expectedURL       string
+		expectedHash      string
+		expectedErrorText string
+	}{
+		{
+			name:       "bundled with containerd",
+			containerd: &kops.ContainerdConfig{Version: fi.String("1.4.13")},
+			arch:       architectures.ArchitectureAmd64,
+		},
+		{
+			name:         "default version",
+			containerd:   &kops.ContainerdConfig{Version: fi.String("1.6.6")},
+			arch:         architectures.ArchitectureAmd64,
+			expectedURL:  "https://github.com/opencontainers/runc/releases/download/v1.1.3/runc.amd64",
+			expectedHash: "6e8b24be90fffce6b025d254846da9d2ca6d65125f9139b6354bab0272253d01",
+		},
+		{
+			name: "version override",
+			containerd: &kops.ContainerdConfig{
+				Version: fi.String("1.6.6"),
+				Runc:    &kops.Runc{Version: fi.String("1.1.2")},
+			},
+			arch:         architectures.ArchitectureArm64,
+			expectedURL:  "https://github.com/opencontainers/runc/releases/download/v1.1.2/runc.arm64",
+			expectedHash: "6ebd968d46d00a3886e9a0cae2e0a7b399e110cf5d7b26e63ce23c1d81ea10ef",
+		},
+		{
+			name: "unknown version",
+			containerd: &kops.ContainerdConfig{
+				Version: fi.String("1.6.6"),
+				Runc:    &kops.Runc{Version: fi.String("1.9.0")},
+			},
+			arch:              architectures.ArchitectureAmd64,
+			expectedErrorText: "unknown url and hash for runc version",
+		},
+		{
+			name: "packages override",
+			containerd: &kops.ContainerdConfig{
+				Version: fi.String("1.6.6"),
+				Runc: &kops.Runc{
+					Version: fi.String("1.9.0"),
+					Packages: &kops.PackagesConfig{
+						UrlAmd64:  fi.String("https://example.com/runc/v1.9.0/runc.amd64"),
+						HashAmd64: fi.String("db772be63147a4e747b4fe286c7c16a2edc4a8458bd3092ea46aaee77750e8ce"),
+					},
+				},
+			},
+			arch:         architectures.ArchitectureAmd64,
+			expectedURL:  "https://example.com/runc/v1.9.0/runc.amd64",
+			expectedHash: "db772be63147a4e747b4fe286c7c16a2edc4a8458bd3092ea46aaee77750e8ce",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := &kops.Cluster{}
+			cluster.Spec.KubernetesVersion = "1.24.0"
+			cluster.Spec.Containerd = test.containerd
+			assetBuilder := assets.NewAssetBuilder(cluster, false)
+
+			u, h, err := findRuncAsset(cluster, assetBuilder, test.arch)
+			if test.expectedErrorText != "" {
+				if err == nil || !strings.Contains(err.Error(), test.expectedErrorText) {
+					t.Fatalf("expected error containing %q, got %v", test.expectedErrorText, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if test.expectedURL == "" {
+				if u != nil || h != nil {
+					t.Errorf("expected no runc asset, got %v %v", u, h)
+				}
+				return
+			}
+			if u == nil || u.String() != test.expectedURL {
+				t.Errorf("expected url %q, got %v", test.expectedURL, u)
+			}
+			if h == nil || h.Hex() != test.expectedHash {
+				t.Errorf("expected hash %q, got %v", test.expectedHash, h)
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"net"
 	"sort"
+	"strings"
 
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/apis/kops"
@@ -215,3 +216,32 @@ func allSubnetsHaveCIDRs(c *kops.Cluster) bool {
 
 	return true
 }
+
+// assignIPv6CIDRsToSubnets assigns IPv6 CIDRs, in the "/64#N" format, to the subnets of an IPv6 cluster that don't have one.
+// Shared subnets are skipped, as are clusters where some subnet has an explicit IPv6 CIDR that could collide with the assignments.
+func assignIPv6CIDRsToSubnets(c *kops.Cluster) {
+	used := make(map[string]bool)
+	for i := range c.Spec.Subnets {
+		ipv6CIDR := c.Spec.Subnets[i].IPv6CIDR
+		if ipv6CIDR != "" && !strings.HasPrefix(ipv6CIDR, "/") {
+			klog.V(4).Infof("Subnet %q has explicit IPv6 CIDR %q; skipping IPv6 assignment logic", c.Spec.Subnets[i].Name, ipv6CIDR)
+			return
+		}
+		used[ipv6CIDR] = true
+	}
+
+	n := 0
+	for i := range c.Spec.Subnets {
+		subnet := &c.Spec.Subnets[i]
+		if subnet.IPv6CIDR != "" || subnet.ProviderID != "" {
+			continue
+		}
+
+		for used[fmt.Sprintf("/64#%x", n)] {
+			n++
+		}
+		subnet.IPv6CIDR = fmt.Sprintf("/64#%x", n)
+		used[subnet.IPv6CIDR] = true
+		klog.Infof("Assigned IPv6 CIDR %s to subnet %s", subnet.IPv6CIDR, subnet.Name)
+	}
+}
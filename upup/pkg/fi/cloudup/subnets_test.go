@@ -144,3 +144,49 @@ func Test_AssignSubnets(t *testing.T) {
 		}
 	}
 }
+
+func Test_AssignIPv6Subnets(t *testing.T) {
+	tests := []struct {
+		subnets  []kops.ClusterSubnetSpec
+		expected []string
+	}{
+		{
+			subnets: []kops.ClusterSubnetSpec{
+				{Name: "a", Zone: "a", Type: kops.SubnetTypePrivate},
+				{Name: "a", Zone: "a", Type: kops.SubnetTypeUtility},
+			},
+			expected: []string{"/64#0", "/64#1"},
+		},
+		{
+			subnets: []kops.ClusterSubnetSpec{
+				{Name: "a", Zone: "a", Type: kops.SubnetTypePrivate},
+				{Name: "b", Zone: "b", IPv6CIDR: "/64#0", Type: kops.SubnetTypePrivate},
+				{Name: "c", Zone: "c", ProviderID: "subnet-12345678", Type: kops.SubnetTypePrivate},
+				{Name: "a", Zone: "a", Type: kops.SubnetTypeUtility},
+			},
+			expected: []string{"/64#1", "/64#0", "", "/64#2"},
+		},
+		{
+			subnets: []kops.ClusterSubnetSpec{
+				{Name: "a", Zone: "a", Type: kops.SubnetTypePrivate},
+				{Name: "b", Zone: "b", IPv6CIDR: "2001:db8::/64", Type: kops.SubnetTypePrivate},
+			},
+			expected: []string{"", "2001:db8::/64"},
+		},
+	}
+	for i, test := range tests {
+		c := &kops.Cluster{}
+		c.Spec.NonMasqueradeCIDR = "::/0"
+		c.Spec.Subnets = test.subnets
+
+		assignIPv6CIDRsToSubnets(c)
+
+		var actual []string
+		for _, subnet := range c.Spec.Subnets {
+			actual = append(actual, subnet.IPv6CIDR)
+		}
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Fatalf("unexpected result of IPv6 network allocation (#%d): actual=%v, expected=%v", i+1, actual, test.expected)
+		}
+	}
+}
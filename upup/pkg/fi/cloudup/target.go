@@ -21,4 +21,5 @@ const (
 	TargetDryRun         = "dryrun"
 	TargetTerraform      = "terraform"
 	TargetCloudformation = "cloudformation"
+	TargetPulumi         = "pulumi"
 )
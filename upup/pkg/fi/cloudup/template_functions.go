@@ -38,11 +38,13 @@ import (
 	"strconv"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/Masterminds/sprig/v3"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/flagbuilder"
@@ -53,6 +55,7 @@ import (
 	apiModel "k8s.io/kops/pkg/apis/kops/model"
 	"k8s.io/kops/pkg/apis/kops/util"
 	"k8s.io/kops/pkg/apis/nodeup"
+	"k8s.io/kops/pkg/bootstrap/metal"
 	"k8s.io/kops/pkg/dns"
 	"k8s.io/kops/pkg/featureflag"
 	"k8s.io/kops/pkg/kubemanifest"
@@ -102,6 +105,7 @@ func (tf *TemplateFunctions) AddTo(dest template.FuncMap, secretStore fi.SecretS
 	dest["contains"] = sprigTxtFuncMap["contains"]
 	dest["trimPrefix"] = sprigTxtFuncMap["trimPrefix"]
 	dest["semverCompare"] = sprigTxtFuncMap["semverCompare"]
+	dest["sha256sum"] = sprigTxtFuncMap["sha256sum"]
 
 	dest["ClusterName"] = tf.ClusterName
 	dest["WithDefaultBool"] = func(v *bool, defaultValue bool) bool {
@@ -114,6 +118,8 @@ func (tf *TemplateFunctions) AddTo(dest template.FuncMap, secretStore fi.SecretS
 	dest["GetCloudProvider"] = cluster.Spec.GetCloudProvider
 	dest["GetInstanceGroup"] = tf.GetInstanceGroup
 	dest["GetNodeInstanceGroups"] = tf.GetNodeInstanceGroups
+	dest["NodeExporterInstanceGroups"] = tf.NodeExporterInstanceGroups
+	dest["CloudWatchAgentInstanceGroups"] = tf.CloudWatchAgentInstanceGroups
 	dest["HasHighlyAvailableControlPlane"] = tf.HasHighlyAvailableControlPlane
 	dest["ControlPlaneControllerReplicas"] = tf.ControlPlaneControllerReplicas
 	dest["APIServerNodeRole"] = tf.APIServerNodeRole
@@ -142,6 +148,16 @@ func (tf *TemplateFunctions) AddTo(dest template.FuncMap, secretStore fi.SecretS
 	dest["NodeLocalDNSHealthCheck"] = func() string {
 		return fmt.Sprintf("%d", wellknownports.NodeLocalDNSHealthCheck)
 	}
+	dest["NodeLocalDNSListenIPs"] = func() []string {
+		ips := []string{cluster.Spec.KubeDNS.NodeLocalDNS.LocalIP}
+		if nodeLocalDNSInterceptsKubeDNS(cluster) {
+			ips = append(ips, cluster.Spec.KubeDNS.ServerIP)
+		}
+		return ips
+	}
+	dest["NodeLocalDNSSetupIPTables"] = func() bool {
+		return nodeLocalDNSInterceptsKubeDNS(cluster)
+	}
 
 	dest["KopsControllerArgv"] = tf.KopsControllerArgv
 	dest["KopsControllerConfig"] = tf.KopsControllerConfig
@@ -249,6 +265,21 @@ func (tf *TemplateFunctions) AddTo(dest template.FuncMap, secretStore fi.SecretS
 		}
 	}
 
+	gossipEncryptionKeys := ""
+	if gossipEncryptionEnabled(cluster) {
+		secret, err := secretStore.Secret(GossipEncryptionSecretName)
+		if err != nil {
+			return fmt.Errorf("could not load the %s secret: %w", GossipEncryptionSecretName, err)
+		}
+		keys, err := BuildGossipEncryptionKeys(secret)
+		if err != nil {
+			return err
+		}
+		gossipEncryptionKeys = base64.StdEncoding.EncodeToString([]byte(strings.Join(keys, "\n") + "\n"))
+	}
+	// DnsControllerGossipEncryptionKeys returns the base64 encoded keys file for dns-controller, or "" if gossip is not encrypted
+	dest["DnsControllerGossipEncryptionKeys"] = func() string { return gossipEncryptionKeys }
+
 	if cluster.Spec.Networking != nil && cluster.Spec.Networking.Cilium != nil {
 		ciliumsecretString := ""
 		ciliumsecret, _ := secretStore.Secret("ciliumpassword")
@@ -298,6 +329,17 @@ func (tf *TemplateFunctions) AddTo(dest template.FuncMap, secretStore fi.SecretS
 		return strings.Join(labels, ",")
 	}
 
+	dest["CiliumENISubnetTagsFilter"] = func() string {
+		var tags []string
+		if networking := cluster.Spec.Networking; networking != nil && networking.Cilium != nil && networking.Cilium.ENI != nil {
+			for k, v := range networking.Cilium.ENI.SubnetTagsFilter {
+				tags = append(tags, fmt.Sprintf("%s=%s", k, v))
+			}
+		}
+		sort.Strings(tags)
+		return strings.Join(tags, ",")
+	}
+
 	dest["IsIPv6Only"] = tf.IsIPv6Only
 	dest["UseServiceAccountExternalPermissions"] = tf.UseServiceAccountExternalPermissions
 
@@ -326,8 +368,13 @@ func (tf *TemplateFunctions) AddTo(dest template.FuncMap, secretStore fi.SecretS
 	dest["KarpenterInstanceTypes"] = func(ig kops.InstanceGroupSpec) ([]string, error) {
 		return karpenterInstanceTypes(tf.cloud.(awsup.AWSCloud), ig)
 	}
+	dest["KarpenterCapacityTypes"] = karpenterCapacityTypes
+	dest["KarpenterSubnetSelector"] = func(ig kops.InstanceGroupSpec) map[string]string {
+		return karpenterSubnetSelector(cluster, ig)
+	}
 
 	dest["PodIdentityWebhookConfigMapData"] = tf.podIdentityWebhookConfigMapData
+	dest["GCPWorkloadIdentityCredentialConfigs"] = tf.gcpWorkloadIdentityCredentialConfigs
 
 	dest["HasSnapshotController"] = func() bool {
 		sc := cluster.Spec.SnapshotController
@@ -476,6 +523,10 @@ func (tf *TemplateFunctions) DNSControllerArgv() ([]string, error) {
 	if dns.IsGossipHostname(cluster.Spec.MasterInternalName) {
 		argv = append(argv, "--dns=gossip")
 
+		if gossipEncryptionEnabled(cluster) {
+			argv = append(argv, "--gossip-encryption-keys-file=/etc/dns-controller/gossip-encryption/keys")
+		}
+
 		// Configuration specifically for the DNS controller gossip
 		if cluster.Spec.DNSControllerGossipConfig != nil {
 			if cluster.Spec.DNSControllerGossipConfig.Protocol != nil {
@@ -494,6 +545,16 @@ func (tf *TemplateFunctions) DNSControllerArgv() ([]string, error) {
 				argv = append(argv, fmt.Sprintf("--gossip-seed=127.0.0.1:%d", wellknownports.ProtokubeGossipWeaveMesh))
 			}
 
+			if cluster.Spec.DNSControllerGossipConfig.ProbeInterval != nil {
+				argv = append(argv, "--gossip-probe-interval="+cluster.Spec.DNSControllerGossipConfig.ProbeInterval.Duration.String())
+			}
+			if cluster.Spec.DNSControllerGossipConfig.GossipInterval != nil {
+				argv = append(argv, "--gossip-interval="+cluster.Spec.DNSControllerGossipConfig.GossipInterval.Duration.String())
+			}
+			if cluster.Spec.DNSControllerGossipConfig.PushPullInterval != nil {
+				argv = append(argv, "--gossip-push-pull-interval="+cluster.Spec.DNSControllerGossipConfig.PushPullInterval.Duration.String())
+			}
+
 			if cluster.Spec.DNSControllerGossipConfig.Secondary != nil {
 				if cluster.Spec.DNSControllerGossipConfig.Secondary.Protocol != nil {
 					argv = append(argv, "--gossip-protocol-secondary="+*cluster.Spec.DNSControllerGossipConfig.Secondary.Protocol)
@@ -575,6 +636,17 @@ func (tf *TemplateFunctions) KopsControllerConfig() (string, error) {
 		config.CacheNodeidentityInfo = true
 	}
 
+	if cluster.Spec.NodeLabelsFromCloudTags != nil {
+		config.NodeLabelsFromCloudTags = &kopscontrollerconfig.NodeLabelsFromCloudTagsOptions{
+			Tags:   cluster.Spec.NodeLabelsFromCloudTags.Tags,
+			Prefix: cluster.Spec.NodeLabelsFromCloudTags.LabelPrefix(),
+		}
+	}
+
+	if featureflag.KopsControllerMetrics.Enabled() {
+		config.MetricsBindAddress = fmt.Sprintf(":%d", wellknownports.KopsControllerMetrics)
+	}
+
 	if tf.UseKopsControllerForNodeBootstrap() {
 		certNames := []string{"kubelet", "kubelet-server"}
 		signingCAs := []string{fi.CertificateIDCA}
@@ -598,11 +670,22 @@ func (tf *TemplateFunctions) KopsControllerConfig() (string, error) {
 			SigningCAs:            signingCAs,
 			CertNames:             certNames,
 		}
+		if cluster.Spec.NodeCertificates != nil {
+			config.Server.NodeCertificateValidity = cluster.Spec.NodeCertificates.Validity
+		}
 
 		switch cluster.Spec.GetCloudProvider() {
 		case kops.CloudProviderAWS:
 			nodesRoles := sets.String{}
 			for _, ig := range tf.InstanceGroups {
+				if ig.Spec.Manager == kops.InstanceManagerMetal {
+					// Metal machines authenticate with their host certificate
+					config.Server.Provider.Metal = &metal.VerifierOptions{
+						CACertificatePath: path.Join(pkiDir, fi.CertificateIDCA+".crt"),
+						MaxTimeSkew:       300,
+					}
+					continue
+				}
 				if ig.Spec.Role == kops.InstanceGroupRoleNode || ig.Spec.Role == kops.InstanceGroupRoleAPIServer {
 					profile, err := tf.LinkToIAMInstanceProfile(ig)
 					if err != nil {
@@ -653,6 +736,45 @@ func (tf *TemplateFunctions) KopsControllerConfig() (string, error) {
 		config.EnableCloudIPAM = true
 	}
 
+	if cluster.Spec.GetCloudProvider() == kops.CloudProviderAWS {
+		var spotFallbackInstanceGroups []kopscontrollerconfig.SpotFallbackInstanceGroup
+		for _, ig := range tf.InstanceGroups {
+			if !apiModel.SpotFallbackEnabled(cluster, ig) {
+				continue
+			}
+			policy := ig.Spec.MixedInstancesPolicy
+			spotFallback := kopscontrollerconfig.SpotFallbackInstanceGroup{
+				Name:                 ig.ObjectMeta.Name,
+				AutoscalingGroupName: tf.AutoscalingGroupName(ig),
+				OnDemandBase:         fi.Int64Value(policy.OnDemandBase),
+				// AWS defaults to On-Demand instances above the base capacity
+				OnDemandAboveBase: 100,
+				FailureThreshold:  3,
+				FailureWindow:     metav1.Duration{Duration: 15 * time.Minute},
+				RevertAfter:       metav1.Duration{Duration: time.Hour},
+			}
+			if policy.OnDemandAboveBase != nil {
+				spotFallback.OnDemandAboveBase = *policy.OnDemandAboveBase
+			}
+			if policy.SpotFallback.FailureThreshold != nil {
+				spotFallback.FailureThreshold = int(*policy.SpotFallback.FailureThreshold)
+			}
+			if policy.SpotFallback.FailureWindow != nil {
+				spotFallback.FailureWindow = *policy.SpotFallback.FailureWindow
+			}
+			if policy.SpotFallback.RevertAfter != nil {
+				spotFallback.RevertAfter = *policy.SpotFallback.RevertAfter
+			}
+			spotFallbackInstanceGroups = append(spotFallbackInstanceGroups, spotFallback)
+		}
+		if len(spotFallbackInstanceGroups) > 0 {
+			config.SpotFallback = &kopscontrollerconfig.SpotFallbackOptions{
+				Region:         tf.Region,
+				InstanceGroups: spotFallbackInstanceGroups,
+			}
+		}
+	}
+
 	if dns.IsGossipHostname(cluster.Spec.MasterInternalName) {
 		config.Discovery = &kopscontrollerconfig.DiscoveryOptions{
 			Enabled: true,
@@ -803,6 +925,30 @@ func (tf *TemplateFunctions) GetNodeInstanceGroups() map[string]kops.InstanceGro
 	return nodegroups
 }
 
+// NodeExporterInstanceGroups returns the sorted names of the instance groups that run node-exporter.
+func (tf *TemplateFunctions) NodeExporterInstanceGroups() []string {
+	var names []string
+	for _, ig := range tf.KopsModelContext.InstanceGroups {
+		if apiModel.NodeExporterEnabled(tf.Cluster, ig) {
+			names = append(names, ig.ObjectMeta.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CloudWatchAgentInstanceGroups returns the sorted names of the instance groups that run the CloudWatch agent.
+func (tf *TemplateFunctions) CloudWatchAgentInstanceGroups() []string {
+	var names []string
+	for _, ig := range tf.KopsModelContext.InstanceGroups {
+		if apiModel.CloudWatchAgentEnabled(tf.Cluster, ig) {
+			names = append(names, ig.ObjectMeta.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
 func (tf *TemplateFunctions) architectureOfAMI(amiID string) string {
 	image, _ := tf.cloud.(awsup.AWSCloud).ResolveImage(amiID)
 	switch *image.Architecture {
@@ -828,7 +974,7 @@ func (tf *TemplateFunctions) podIdentityWebhookConfigMapData() (string, error) {
 		}
 		key := sa.Namespace + "/" + sa.Name
 		mappings[key] = podIdentityWebhookMapping{
-			RoleARN:        fmt.Sprintf("arn:%s:iam::%s:role/%s", tf.AWSPartition, tf.AWSAccountID, iam.IAMNameForServiceAccountRole(sa.Name, sa.Namespace, tf.ClusterName())),
+			RoleARN:        tf.IAMRoleARN(iam.IAMNameForServiceAccountRole(sa.Name, sa.Namespace, tf.ClusterName())),
 			Audience:       "amazonaws.com",
 			UseRegionalSTS: true,
 		}
@@ -837,6 +983,126 @@ func (tf *TemplateFunctions) podIdentityWebhookConfigMapData() (string, error) {
 	return fmt.Sprintf("%q", jsonBytes), err
 }
 
+// gcpWorkloadIdentityCredentialSource is the path of the projected ServiceAccount token used to authenticate to GCP
+const gcpWorkloadIdentityCredentialSource = "/var/run/secrets/workload-identity/token"
+
+type gcpCredentialConfig struct {
+	Type                           string                    `json:"type"`
+	Audience                       string                    `json:"audience"`
+	SubjectTokenType               string                    `json:"subject_token_type"`
+	TokenURL                       string                    `json:"token_url"`
+	ServiceAccountImpersonationURL string                    `json:"service_account_impersonation_url"`
+	CredentialSource               gcpCredentialConfigSource `json:"credential_source"`
+}
+
+type gcpCredentialConfigSource struct {
+	File string `json:"file"`
+}
+
+// gcpWorkloadIdentityCredentialConfigs returns the credential configuration files of the ServiceAccounts with GCP permissions,
+// keyed by namespace and ServiceAccount name.
+func (tf *TemplateFunctions) gcpWorkloadIdentityCredentialConfigs() (map[string]map[string]string, error) {
+	provider := tf.Cluster.Spec.ServiceAccountIssuerDiscovery.GCPWorkloadIdentityProvider
+	projectID := tf.cloud.(gce.GCECloud).Project()
+
+	configs := make(map[string]map[string]string)
+	for _, sa := range tf.Cluster.Spec.IAM.ServiceAccountExternalPermissions {
+		if sa.GCP == nil {
+			continue
+		}
+		email := gce.WorkloadIdentityServiceAccountName(sa.Namespace, sa.Name, tf.ClusterName()) + "@" + projectID + ".iam.gserviceaccount.com"
+		config := gcpCredentialConfig{
+			Type:                           "external_account",
+			Audience:                       "//iam.googleapis.com/" + provider,
+			SubjectTokenType:               "urn:ietf:params:oauth:token-type:jwt",
+			TokenURL:                       "https://sts.googleapis.com/v1/token",
+			ServiceAccountImpersonationURL: "https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/" + email + ":generateAccessToken",
+			CredentialSource: gcpCredentialConfigSource{
+				File: gcpWorkloadIdentityCredentialSource,
+			},
+		}
+		jsonBytes, err := json.Marshal(config)
+		if err != nil {
+			return nil, err
+		}
+		if configs[sa.Namespace] == nil {
+			configs[sa.Namespace] = make(map[string]string)
+		}
+		configs[sa.Namespace][sa.Name] = fmt.Sprintf("%q", jsonBytes)
+	}
+	return configs, nil
+}
+
+// karpenterCapacityTypes returns the capacity types Karpenter may launch for an instance group.
+// Karpenter instance groups use Spot capacity unless the mixed instances policy asks for On-Demand capacity.
+func karpenterCapacityTypes(ig kops.InstanceGroupSpec) []string {
+	if ig.MixedInstancesPolicy == nil || ig.MixedInstancesPolicy.OnDemandAboveBase == nil {
+		return []string{"spot"}
+	}
+	switch *ig.MixedInstancesPolicy.OnDemandAboveBase {
+	case 0:
+		return []string{"spot"}
+	case 100:
+		return []string{"on-demand"}
+	default:
+		return []string{"spot", "on-demand"}
+	}
+}
+
+// karpenterSubnetSelector returns the tags Karpenter uses to find the subnets of an instance group.
+// A selector matches a single value per tag, so we can only select the exact subnets of the instance group
+// if it uses a single subnet, or all the subnets of one type; otherwise we fall back to the internal ELB subnets.
+func karpenterSubnetSelector(cluster *kops.Cluster, ig kops.InstanceGroupSpec) map[string]string {
+	clusterTag := "kubernetes.io/cluster/" + cluster.ObjectMeta.Name
+	fallback := map[string]string{
+		clusterTag:                        "*",
+		"kubernetes.io/role/internal-elb": "1",
+	}
+
+	if (cluster.Spec.TagSubnets != nil && !*cluster.Spec.TagSubnets) || len(ig.Subnets) == 0 {
+		return fallback
+	}
+
+	subnets := make(map[string]*kops.ClusterSubnetSpec)
+	for i := range cluster.Spec.Subnets {
+		subnets[cluster.Spec.Subnets[i].Name] = &cluster.Spec.Subnets[i]
+	}
+
+	var subnetType kops.SubnetType
+	for _, name := range ig.Subnets {
+		subnet := subnets[name]
+		if subnet == nil {
+			return fallback
+		}
+		if subnetType != "" && subnet.Type != subnetType {
+			return fallback
+		}
+		subnetType = subnet.Type
+	}
+
+	if len(ig.Subnets) == 1 && subnets[ig.Subnets[0]].ProviderID == "" {
+		// Shared subnets don't get a Name tag
+		return map[string]string{
+			clusterTag: "*",
+			"Name":     ig.Subnets[0] + "." + cluster.ObjectMeta.Name,
+		}
+	}
+
+	count := 0
+	for _, subnet := range cluster.Spec.Subnets {
+		if subnet.Type == subnetType {
+			count++
+		}
+	}
+	if count != len(ig.Subnets) {
+		return fallback
+	}
+	return map[string]string{
+		clusterTag:   "*",
+		"SubnetType": string(subnetType),
+	}
+}
+
 func karpenterInstanceTypes(cloud awsup.AWSCloud, ig kops.InstanceGroupSpec) ([]string, error) {
 	var mixedInstancesPolicy *kops.MixedInstancesPolicySpec
 
@@ -944,3 +1210,19 @@ func karpenterInstanceTypes(cloud awsup.AWSCloud, ig kops.InstanceGroupSpec) ([]
 
 	return []string{ig.MachineType}, nil
 }
+
+// nodeLocalDNSInterceptsKubeDNS returns true if node-local-dns should also listen on the kube-dns service IP.
+// This is only possible when kube-proxy runs in iptables mode, where node-local-dns installs NOTRACK rules
+// so that queries for the kube-dns service IP reach the cache on the node instead of being load-balanced.
+// In IPVS mode, or when Cilium handles services, the service IP can't be bound on the node and pods only
+// use the cache through the kubelet's cluster DNS setting.
+func nodeLocalDNSInterceptsKubeDNS(cluster *kops.Cluster) bool {
+	if cluster.Spec.Networking != nil && cluster.Spec.Networking.Cilium != nil {
+		return false
+	}
+	kubeProxy := cluster.Spec.KubeProxy
+	if kubeProxy == nil || (kubeProxy.Enabled != nil && !*kubeProxy.Enabled) {
+		return false
+	}
+	return kubeProxy.ProxyMode == "" || kubeProxy.ProxyMode == "iptables"
+}
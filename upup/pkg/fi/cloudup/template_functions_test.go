@@ -318,3 +318,146 @@ func Test_KarpenterInstanceTypes(t *testing.T) {
 		t.Errorf("failed to fetch instance types: %v", err)
 	}
 }
+
+func Test_NodeLocalDNSInterceptsKubeDNS(t *testing.T) {
+	tests := []struct {
+		desc       string
+		kubeProxy  *kops.KubeProxyConfig
+		networking *kops.NetworkingSpec
+		expected   bool
+	}{
+		{
+			desc:      "iptables mode by default",
+			kubeProxy: &kops.KubeProxyConfig{},
+			expected:  true,
+		},
+		{
+			desc:      "explicit iptables mode",
+			kubeProxy: &kops.KubeProxyConfig{Enabled: fi.Bool(true), ProxyMode: "iptables"},
+			expected:  true,
+		},
+		{
+			desc:      "ipvs mode",
+			kubeProxy: &kops.KubeProxyConfig{ProxyMode: "ipvs"},
+			expected:  false,
+		},
+		{
+			desc:      "kube-proxy disabled",
+			kubeProxy: &kops.KubeProxyConfig{Enabled: fi.Bool(false)},
+			expected:  false,
+		},
+		{
+			desc:       "cilium",
+			kubeProxy:  &kops.KubeProxyConfig{},
+			networking: &kops.NetworkingSpec{Cilium: &kops.CiliumNetworkingSpec{}},
+			expected:   false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			cluster := &kops.Cluster{Spec: kops.ClusterSpec{
+				KubeProxy:  test.kubeProxy,
+				Networking: test.networking,
+			}}
+			if actual := nodeLocalDNSInterceptsKubeDNS(cluster); actual != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func Test_KarpenterCapacityTypes(t *testing.T) {
+	tests := []struct {
+		onDemandAboveBase *int64
+		expected          []string
+	}{
+		{
+			expected: []string{"spot"},
+		},
+		{
+			onDemandAboveBase: fi.Int64(0),
+			expected:          []string{"spot"},
+		},
+		{
+			onDemandAboveBase: fi.Int64(100),
+			expected:          []string{"on-demand"},
+		},
+		{
+			onDemandAboveBase: fi.Int64(50),
+			expected:          []string{"spot", "on-demand"},
+		},
+	}
+
+	for _, test := range tests {
+		ig := kops.InstanceGroupSpec{
+			MixedInstancesPolicy: &kops.MixedInstancesPolicySpec{OnDemandAboveBase: test.onDemandAboveBase},
+		}
+		if actual := karpenterCapacityTypes(ig); !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("onDemandAboveBase %v: expected %v, got %v", fi.Int64Value(test.onDemandAboveBase), test.expected, actual)
+		}
+	}
+}
+
+func Test_KarpenterSubnetSelector(t *testing.T) {
+	cluster := &kops.Cluster{}
+	cluster.ObjectMeta.Name = "minimal.example.com"
+	cluster.Spec.Subnets = []kops.ClusterSubnetSpec{
+		{Name: "us-test-1a", Type: kops.SubnetTypePrivate},
+		{Name: "us-test-1b", Type: kops.SubnetTypePrivate},
+		{Name: "utility-us-test-1a", Type: kops.SubnetTypeUtility},
+		{Name: "shared-us-test-1a", Type: kops.SubnetTypePublic, ProviderID: "subnet-12345678"},
+	}
+
+	fallback := map[string]string{
+		"kubernetes.io/cluster/minimal.example.com": "*",
+		"kubernetes.io/role/internal-elb":           "1",
+	}
+
+	tests := []struct {
+		desc     string
+		subnets  []string
+		expected map[string]string
+	}{
+		{
+			desc:    "single subnet",
+			subnets: []string{"us-test-1a"},
+			expected: map[string]string{
+				"kubernetes.io/cluster/minimal.example.com": "*",
+				"Name": "us-test-1a.minimal.example.com",
+			},
+		},
+		{
+			desc:    "all subnets of a type",
+			subnets: []string{"us-test-1a", "us-test-1b"},
+			expected: map[string]string{
+				"kubernetes.io/cluster/minimal.example.com": "*",
+				"SubnetType": "Private",
+			},
+		},
+		{
+			desc:     "subnets of different types",
+			subnets:  []string{"us-test-1a", "utility-us-test-1a"},
+			expected: fallback,
+		},
+		{
+			desc:     "single shared subnet",
+			subnets:  []string{"shared-us-test-1a"},
+			expected: map[string]string{"kubernetes.io/cluster/minimal.example.com": "*", "SubnetType": "Public"},
+		},
+		{
+			desc:     "unknown subnet",
+			subnets:  []string{"us-test-1c"},
+			expected: fallback,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			actual := karpenterSubnetSelector(cluster, kops.InstanceGroupSpec{Subnets: test.subnets})
+			if !reflect.DeepEqual(actual, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, actual)
+			}
+		})
+	}
+}
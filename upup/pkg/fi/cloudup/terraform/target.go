@@ -51,6 +51,9 @@ func NewTerraformTarget(cloud fi.Cloud, project string, filesProvider *vfs.Terra
 		filesProvider:     filesProvider,
 	}
 	target.InitTerraformWriter()
+	if clusterSpecTarget != nil && clusterSpecTarget.Terraform != nil {
+		target.SharedResourcesAsDataSources = fi.BoolValue(clusterSpecTarget.Terraform.SharedResourcesAsDataSources)
+	}
 	return &target
 }
 
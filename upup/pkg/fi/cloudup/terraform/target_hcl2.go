@@ -32,6 +32,12 @@ func (t *TerraformTarget) finishHCL2() error {
 	f := hclwrite.NewEmptyFile()
 	rootBody := f.Body()
 
+	// Data sources must be resolved first, as they change the literals referencing shared resources
+	dataSources, err := t.GetDataSources()
+	if err != nil {
+		return err
+	}
+
 	outputs, err := t.GetOutputs()
 	if err != nil {
 		return err
@@ -57,6 +63,12 @@ func (t *TerraformTarget) finishHCL2() error {
 		return err
 	}
 
+	for _, dataSource := range dataSources {
+		dataBlock := rootBody.AppendNewBlock("data", []string{dataSource.Type, dataSource.Name})
+		dataBlock.Body().SetAttributeValue("id", cty.StringVal(dataSource.ID))
+		rootBody.AppendNewline()
+	}
+
 	resourcesByType, err := t.GetResourcesByType()
 	if err != nil {
 		return err
@@ -0,0 +1,126 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package terraformWriter
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// DataSource is a terraform data source that looks up a shared resource by ID.
+type DataSource struct {
+	Type string
+	Name string
+	ID   string
+}
+
+// LiteralFromSharedResource returns a literal for the ID of a resource that is not managed by kOps.
+// The literal is the ID itself, unless the writer looks up shared resources with data sources,
+// in which case it is resolved to the ID attribute of a data source of the given type.
+func LiteralFromSharedResource(dataSourceType, name, id string) *Literal {
+	return &Literal{
+		Value: id,
+		dataSource: &DataSource{
+			Type: dataSourceType,
+			Name: SanitizeName(name),
+			ID:   id,
+		},
+	}
+}
+
+// GetDataSources returns the data sources for the shared resources referenced by the resources and outputs,
+// and points the literals that reference them at the data sources.
+// It must be called before the resources and outputs are rendered.
+func (t *TerraformWriter) GetDataSources() ([]*DataSource, error) {
+	if !t.SharedResourcesAsDataSources {
+		return nil, nil
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	dataSources := make(map[string]*DataSource)
+	var err error
+	resolve := func(l *Literal) {
+		ds := l.dataSource
+		if ds == nil {
+			return
+		}
+		key := ds.Type + "." + ds.Name
+		if existing := dataSources[key]; existing != nil && existing.ID != ds.ID && err == nil {
+			err = fmt.Errorf("data source %s refers to both %q and %q", key, existing.ID, ds.ID)
+		}
+		dataSources[key] = ds
+
+		l.Tokens = []string{"data", ds.Type, ds.Name, "id"}
+		l.Value = "${data." + ds.Type + "." + ds.Name + ".id}"
+	}
+
+	for _, res := range t.resources {
+		visitLiterals(reflect.ValueOf(res.Item), resolve)
+	}
+	for _, output := range t.outputs {
+		visitLiterals(reflect.ValueOf(output.Value), resolve)
+		visitLiterals(reflect.ValueOf(output.ValueArray), resolve)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*DataSource
+	for _, ds := range dataSources {
+		result = append(result, ds)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Type != result[j].Type {
+			return result[i].Type < result[j].Type
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// visitLiterals calls fn for every Literal reachable from v.
+func visitLiterals(v reflect.Value, fn func(*Literal)) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+		if l, ok := v.Interface().(*Literal); ok {
+			fn(l)
+			return
+		}
+		visitLiterals(v.Elem(), fn)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				visitLiterals(v.Field(i), fn)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			visitLiterals(v.Index(i), fn)
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			visitLiterals(iter.Value(), fn)
+		}
+	}
+}
@@ -39,6 +39,9 @@ type Literal struct {
 	// FnArgs contains string representations of arguments to the function call.
 	// Any string arguments must be quoted.
 	FnArgs []string `cty:"fn_arg"`
+
+	// dataSource is set for the IDs of shared resources, which can be looked up with a data source.
+	dataSource *DataSource
 }
 
 var _ json.Marshaler = &Literal{}
@@ -60,7 +63,7 @@ func LiteralSelfLink(resourceType, resourceName string) *Literal {
 }
 
 func LiteralProperty(resourceType, resourceName, prop string) *Literal {
-	tfName := sanitizeName(resourceName)
+	tfName := SanitizeName(resourceName)
 	expr := "${" + resourceType + "." + tfName + "." + prop + "}"
 	return &Literal{
 		Value:  expr,
@@ -33,6 +33,9 @@ type TerraformWriter struct {
 	outputs map[string]*terraformOutputVariable
 	// Files is a map of TF resource Files that should be created
 	Files map[string][]byte
+
+	// SharedResourcesAsDataSources makes references to shared resources use data sources instead of their IDs
+	SharedResourcesAsDataSources bool
 }
 
 type OutputValue struct {
@@ -52,8 +55,8 @@ type terraformOutputVariable struct {
 	ValueArray []*Literal
 }
 
-// sanitizeName ensures terraform resource names don't start with digits or contain any invalid characters
-func sanitizeName(name string) string {
+// SanitizeName ensures terraform resource names don't start with digits or contain any invalid characters
+func SanitizeName(name string) string {
 	// Terraform resource names cannot start with a digit
 	if _, err := strconv.Atoi(string(name[0])); err == nil {
 		name = fmt.Sprintf("prefix_%v", name)
@@ -144,7 +147,7 @@ func (t *TerraformWriter) GetResourcesByType() (map[string]map[string]interface{
 			resourcesByType[res.ResourceType] = resources
 		}
 
-		tfName := sanitizeName(res.ResourceName)
+		tfName := SanitizeName(res.ResourceName)
 
 		if resources[tfName] != nil {
 			return nil, fmt.Errorf("duplicate resource found: %s.%s", res.ResourceType, tfName)
@@ -159,7 +162,7 @@ func (t *TerraformWriter) GetResourcesByType() (map[string]map[string]interface{
 func (t *TerraformWriter) GetOutputs() (map[string]OutputValue, error) {
 	values := map[string]OutputValue{}
 	for _, v := range t.outputs {
-		tfName := sanitizeName(v.Key)
+		tfName := SanitizeName(v.Key)
 		if _, found := values[tfName]; found {
 			return nil, fmt.Errorf("duplicate variable found: %s", tfName)
 		}
@@ -118,3 +118,64 @@ func TestGetOutputs(t *testing.T) {
 		})
 	}
 }
+
+func TestGetDataSources(t *testing.T) {
+	type subnet struct {
+		VPCID *Literal          `cty:"vpc_id"`
+		Tags  map[string]string `cty:"tags"`
+	}
+	type instance struct {
+		SecurityGroups []*Literal `cty:"security_groups"`
+	}
+
+	newWriter := func() *TerraformWriter {
+		w := &TerraformWriter{}
+		w.InitTerraformWriter()
+		w.SharedResourcesAsDataSources = true
+		require.NoError(t, w.RenderResource("aws_subnet", "us-test-1a.example.com", &subnet{
+			VPCID: LiteralFromSharedResource("aws_vpc", "example.com", "vpc-12345678"),
+		}))
+		require.NoError(t, w.RenderResource("aws_instance", "bastion", &instance{
+			SecurityGroups: []*Literal{
+				LiteralFromSharedResource("aws_security_group", "bastion.example.com", "sg-12345678"),
+				LiteralProperty("aws_security_group", "nodes.example.com", "id"),
+			},
+		}))
+		require.NoError(t, w.AddOutputVariable("vpc_id", LiteralFromSharedResource("aws_vpc", "example.com", "vpc-12345678")))
+		return w
+	}
+
+	w := newWriter()
+	dataSources, err := w.GetDataSources()
+	require.NoError(t, err)
+	assert.Equal(t, []*DataSource{
+		{Type: "aws_security_group", Name: "bastion-example-com", ID: "sg-12345678"},
+		{Type: "aws_vpc", Name: "example-com", ID: "vpc-12345678"},
+	}, dataSources)
+
+	resources, err := w.GetResourcesByType()
+	require.NoError(t, err)
+	assert.Equal(t, "${data.aws_vpc.example-com.id}", resources["aws_subnet"]["us-test-1a-example-com"].(*subnet).VPCID.Value)
+	securityGroups := resources["aws_instance"]["bastion"].(*instance).SecurityGroups
+	assert.Equal(t, []string{"data", "aws_security_group", "bastion-example-com", "id"}, securityGroups[0].Tokens)
+	assert.Equal(t, "${aws_security_group.nodes-example-com.id}", securityGroups[1].Value)
+	outputs, err := w.GetOutputs()
+	require.NoError(t, err)
+	assert.Equal(t, "${data.aws_vpc.example-com.id}", outputs["vpc_id"].Value.Value)
+
+	// Without data sources, the IDs are used
+	w = newWriter()
+	w.SharedResourcesAsDataSources = false
+	dataSources, err = w.GetDataSources()
+	require.NoError(t, err)
+	assert.Empty(t, dataSources)
+	outputs, err = w.GetOutputs()
+	require.NoError(t, err)
+	assert.Equal(t, "vpc-12345678", outputs["vpc_id"].Value.Value)
+
+	// A data source can't refer to two resources
+	w = newWriter()
+	require.NoError(t, w.AddOutputVariable("other_vpc_id", LiteralFromSharedResource("aws_vpc", "example.com", "vpc-87654321")))
+	_, err = w.GetDataSources()
+	assert.Error(t, err)
+}
@@ -24,7 +24,9 @@ import (
 
 	"k8s.io/klog/v2"
 	"k8s.io/kops"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/assets"
+	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/util/pkg/architectures"
 	"k8s.io/kops/util/pkg/hashing"
 	"k8s.io/kops/util/pkg/mirrors"
@@ -104,6 +106,37 @@ func NodeUpAsset(assetsBuilder *assets.AssetBuilder, arch architectures.Architec
 	return nodeUpAsset[arch], nil
 }
 
+// findInstanceGroupNodeUpAssets returns the nodeup assets of an instance group, applying its overrides to the cluster defaults.
+func findInstanceGroupNodeUpAssets(assetBuilder *assets.AssetBuilder, ig *kopsapi.InstanceGroup, defaults map[architectures.Architecture]*mirrors.MirroredAsset) (map[architectures.Architecture]*mirrors.MirroredAsset, error) {
+	nodeUpAssets := make(map[architectures.Architecture]*mirrors.MirroredAsset)
+	for arch, asset := range defaults {
+		nodeUpAssets[arch] = asset
+	}
+
+	nodeUp := ig.Spec.NodeUp
+	for arch := range nodeUpAssets {
+		var assetUrl, assetHash *string
+		switch arch {
+		case architectures.ArchitectureAmd64:
+			assetUrl, assetHash = nodeUp.UrlAmd64, nodeUp.HashAmd64
+		case architectures.ArchitectureArm64:
+			assetUrl, assetHash = nodeUp.UrlArm64, nodeUp.HashArm64
+		}
+		if assetUrl == nil || assetHash == nil {
+			continue
+		}
+
+		u, h, err := findAssetsUrlHash(assetBuilder, fi.StringValue(assetUrl), fi.StringValue(assetHash))
+		if err != nil {
+			return nil, fmt.Errorf("unable to find nodeup asset of instance group %q: %v", ig.ObjectMeta.Name, err)
+		}
+		klog.V(8).Infof("Using nodeup location for instance group %q and %s: %q", ig.ObjectMeta.Name, arch, u.String())
+		nodeUpAssets[arch] = mirrors.BuildMirroredAsset(u, h)
+	}
+
+	return nodeUpAssets, nil
+}
+
 // ProtokubeAsset returns the url and hash of the protokube binary
 func ProtokubeAsset(assetsBuilder *assets.AssetBuilder, arch architectures.Architecture) (*mirrors.MirroredAsset, error) {
 	if protokubeAsset == nil {
@@ -22,7 +22,12 @@ import (
 	"reflect"
 	"testing"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/kops"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/assets"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/util/pkg/architectures"
 	"k8s.io/kops/util/pkg/hashing"
 	"k8s.io/kops/util/pkg/mirrors"
 )
@@ -79,3 +84,42 @@ func Test_BuildMirroredAsset(t *testing.T) {
 		})
 	}
 }
+
+func Test_FindInstanceGroupNodeUpAssets(t *testing.T) {
+	defaults := map[architectures.Architecture]*mirrors.MirroredAsset{
+		architectures.ArchitectureAmd64: {
+			Locations: []string{"https://artifacts.k8s.io/binaries/kops/1.24.0/linux/amd64/nodeup"},
+			Hash:      hashing.MustFromString("833723369ad345a88dd85d61b1e77336d56e61b864557ded71b92b6e34158e6a"),
+		},
+		architectures.ArchitectureArm64: {
+			Locations: []string{"https://artifacts.k8s.io/binaries/kops/1.24.0/linux/arm64/nodeup"},
+			Hash:      hashing.MustFromString("e525c28a65ff0ce4f95f9e730195b4e67fdcb15ceb1f36b5ad6921a8a4490c71"),
+		},
+	}
+	ig := &kopsapi.InstanceGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "canary"},
+		Spec: kopsapi.InstanceGroupSpec{
+			NodeUp: &kopsapi.PackagesConfig{
+				UrlAmd64:  fi.String("https://example.com/canary/linux/amd64/nodeup"),
+				HashAmd64: fi.String("0000000000000000000000000000000000000000000000000000000000000000"),
+			},
+		},
+	}
+	cluster := &kopsapi.Cluster{}
+	cluster.Spec.KubernetesVersion = "1.24.0"
+	assetBuilder := assets.NewAssetBuilder(cluster, false)
+
+	actual, err := findInstanceGroupNodeUpAssets(assetBuilder, ig, defaults)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(actual[architectures.ArchitectureAmd64].Locations, []string{"https://example.com/canary/linux/amd64/nodeup"}) {
+		t.Errorf("unexpected amd64 locations: %v", actual[architectures.ArchitectureAmd64].Locations)
+	}
+	if actual[architectures.ArchitectureAmd64].Hash.Hex() != "0000000000000000000000000000000000000000000000000000000000000000" {
+		t.Errorf("unexpected amd64 hash: %v", actual[architectures.ArchitectureAmd64].Hash)
+	}
+	if actual[architectures.ArchitectureArm64] != defaults[architectures.ArchitectureArm64] {
+		t.Errorf("expected the default arm64 asset, got %v", actual[architectures.ArchitectureArm64])
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudup
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/assets"
+	"k8s.io/kops/pkg/model/components"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/util/pkg/mirrors"
+)
+
+const (
+	// containerdWindowsReleaseUrl is the containerd package URL for Windows, available for v1.6.x+
+	containerdWindowsReleaseUrl = "https://github.com/containerd/containerd/releases/download/v%s/containerd-%s-windows-amd64.tar.gz"
+	// defaultCNIAssetWindows is the CNI tarball for Windows nodes
+	defaultCNIAssetWindows = "https://storage.googleapis.com/k8s-artifacts-cni/release/v0.9.1/cni-plugins-windows-amd64-v0.9.1.tgz"
+)
+
+// hasWindowsInstanceGroups returns true if any of the instance groups runs Windows
+func hasWindowsInstanceGroups(instanceGroups []*kops.InstanceGroup) bool {
+	for _, ig := range instanceGroups {
+		if ig.IsWindows() {
+			return true
+		}
+	}
+	return false
+}
+
+// WindowsNodeUpAsset returns the url and hash of the nodeup binary for Windows
+func WindowsNodeUpAsset(assetsBuilder *assets.AssetBuilder) (*mirrors.MirroredAsset, error) {
+	u, hash, err := KopsFileURL("windows/amd64/nodeup.exe", assetsBuilder)
+	if err != nil {
+		return nil, err
+	}
+	klog.V(8).Infof("Using default nodeup location for windows: %q", u.String())
+
+	return mirrors.BuildMirroredAsset(u, hash), nil
+}
+
+// findWindowsAssets returns the assets that nodeup installs on Windows nodes
+func findWindowsAssets(c *kops.Cluster, assetBuilder *assets.AssetBuilder) ([]*mirrors.MirroredAsset, error) {
+	var windowsAssets []*mirrors.MirroredAsset
+
+	var baseURL string
+	if components.IsBaseURL(c.Spec.KubernetesVersion) {
+		baseURL = c.Spec.KubernetesVersion
+	} else {
+		baseURL = "https://storage.googleapis.com/kubernetes-release/release/v" + c.Spec.KubernetesVersion
+	}
+
+	for _, an := range []string{"/bin/windows/amd64/kubelet.exe", "/bin/windows/amd64/kubectl.exe"} {
+		k, err := url.Parse(baseURL)
+		if err != nil {
+			return nil, err
+		}
+		k.Path = path.Join(k.Path, an)
+
+		u, hash, err := assetBuilder.RemapFileAndSHA(k)
+		if err != nil {
+			return nil, err
+		}
+		windowsAssets = append(windowsAssets, mirrors.BuildMirroredAsset(u, hash))
+	}
+
+	cniURL, err := url.Parse(defaultCNIAssetWindows)
+	if err != nil {
+		return nil, err
+	}
+	u, hash, err := assetBuilder.RemapFileAndSHA(cniURL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to remap CNI plugin binaries asset: %v", err)
+	}
+	windowsAssets = append(windowsAssets, mirrors.BuildMirroredAsset(u, hash))
+
+	if c.Spec.Containerd == nil || fi.StringValue(c.Spec.Containerd.Version) == "" {
+		return nil, fmt.Errorf("unable to find containerd version")
+	}
+	version := fi.StringValue(c.Spec.Containerd.Version)
+	containerdURL, err := url.Parse(fmt.Sprintf(containerdWindowsReleaseUrl, version, version))
+	if err != nil {
+		return nil, err
+	}
+	u, hash, err = assetBuilder.RemapFileAndSHA(containerdURL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to remap containerd asset: %v", err)
+	}
+	windowsAssets = append(windowsAssets, mirrors.BuildMirroredAsset(u, hash))
+
+	return windowsAssets, nil
+}
@@ -28,6 +28,10 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 )
 
+// externalCAValidity is the validity requested for CA certificates issued by a certificate authority service,
+// matching the validity of the CA certificates self-signed by kOps.
+const externalCAValidity = 10 * 365 * 24 * time.Hour
+
 // +kops:fitask
 type Keypair struct {
 	// Name is the name of the keypair
@@ -46,6 +50,9 @@ type Keypair struct {
 	Type string `json:"type"`
 	// LegacyFormat is whether the keypair is stored in a legacy format.
 	LegacyFormat bool `json:"oldFormat"`
+	// ExternalIssuer identifies the certificate authority service that issues the certificate of a CA keypair,
+	// such as the ARN of an ACM Private CA. If empty, kOps self-signs the CA certificate.
+	ExternalIssuer string `json:"externalIssuer,omitempty"`
 
 	certificates *fi.TaskDependentResource
 	keyset       *fi.Keyset
@@ -106,6 +113,8 @@ func (e *Keypair) Find(c *fi.Context) (*Keypair, error) {
 
 	// Avoid spurious changes
 	actual.Lifecycle = e.Lifecycle
+	// The issuer only matters when the certificate is created; CAs are not reissued when it changes
+	actual.ExternalIssuer = e.ExternalIssuer
 
 	if err := e.setResources(keyset); err != nil {
 		return nil, fmt.Errorf("error setting resources: %v", err)
@@ -235,24 +244,34 @@ func (_ *Keypair) Render(c *fi.Context, a, e, changes *Keypair) error {
 			return fmt.Errorf("subject name was empty for SSL keypair %q", *e.Name)
 		}
 
-		req := pki.IssueCertRequest{
-			Signer:         signer,
-			Type:           e.Type,
-			Subject:        *subjectPkix,
-			AlternateNames: e.AlternateNames,
-			PrivateKey:     privateKey,
-			Serial:         serial,
-		}
-		cert, privateKey, _, err := pki.IssueCert(&req, c.Keystore)
-		if err != nil {
-			return err
+		var cert *pki.Certificate
+		var externalCertificateID string
+		if e.ExternalIssuer != "" && e.Type == "ca" {
+			cert, privateKey, externalCertificateID, err = issueExternalCACertificate(c, e.ExternalIssuer, subjectPkix, privateKey)
+			if err != nil {
+				return err
+			}
+		} else {
+			req := pki.IssueCertRequest{
+				Signer:         signer,
+				Type:           e.Type,
+				Subject:        *subjectPkix,
+				AlternateNames: e.AlternateNames,
+				PrivateKey:     privateKey,
+				Serial:         serial,
+			}
+			cert, privateKey, _, err = pki.IssueCert(&req, c.Keystore)
+			if err != nil {
+				return err
+			}
 		}
 
 		serialString := cert.Certificate.SerialNumber.String()
 		ki := &fi.KeysetItem{
-			Id:          serialString,
-			Certificate: cert,
-			PrivateKey:  privateKey,
+			Id:                    serialString,
+			Certificate:           cert,
+			PrivateKey:            privateKey,
+			ExternalCertificateID: externalCertificateID,
 		}
 
 		keyset.LegacyFormat = false
@@ -297,6 +316,39 @@ func (_ *Keypair) Render(c *fi.Context, a, e, changes *Keypair) error {
 	return nil
 }
 
+// issueExternalCACertificate has the certificate authority service of the cloud issue a CA certificate.
+// Only the private key of the new CA is generated by kOps; the issuing CA never leaves the service.
+func issueExternalCACertificate(c *fi.Context, issuer string, subject *pkix.Name, privateKey *pki.PrivateKey) (*pki.Certificate, *pki.PrivateKey, string, error) {
+	certificateIssuer, ok := c.Cloud.(fi.CertificateIssuer)
+	if !ok {
+		return nil, nil, "", fmt.Errorf("cloud provider %q cannot issue certificates from %q", c.Cloud.ProviderID(), issuer)
+	}
+
+	if privateKey == nil {
+		var err error
+		privateKey, err = pki.GeneratePrivateKey()
+		if err != nil {
+			return nil, nil, "", err
+		}
+	}
+
+	csr, err := pki.BuildCACertificateRequest(*subject, privateKey)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	klog.Infof("Requesting certificate for %q from %q", subject.CommonName, issuer)
+	cert, id, err := certificateIssuer.IssueCACertificate(issuer, csr, externalCAValidity)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("error issuing certificate from %q: %v", issuer, err)
+	}
+	if !cert.IsCA {
+		return nil, nil, "", fmt.Errorf("certificate %q issued by %q is not a CA certificate", id, issuer)
+	}
+
+	return cert, privateKey, id, nil
+}
+
 func parsePkixName(s string) (*pkix.Name, error) {
 	name := new(pkix.Name)
 
@@ -20,9 +20,11 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"text/template"
 
 	"k8s.io/kops/pkg/featureflag"
 
+	storage "google.golang.org/api/storage/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/kops/pkg/acls"
 	"k8s.io/kops/upup/pkg/fi"
@@ -45,6 +47,25 @@ type ManagedFile struct {
 
 	// Public controls whether the object is world-readable
 	Public *bool
+
+	// Template renders Contents as a Go template when the task runs.
+	// The template is executed with ManagedFileTemplateValues, and can read secrets with {{ Secret "name" }}.
+	Template *bool
+
+	// rendered holds the contents after template rendering
+	rendered []byte
+}
+
+// ManagedFileTemplateValues are the cluster values available to ManagedFile templates.
+type ManagedFileTemplateValues struct {
+	ClusterName           string
+	CloudProvider         string
+	ConfigBase            string
+	KubernetesVersion     string
+	MasterInternalName    string
+	NonMasqueradeCIDR     string
+	PodCIDR               string
+	ServiceClusterIPRange string
 }
 
 func (e *ManagedFile) Find(c *fi.Context) (*ManagedFile, error) {
@@ -87,6 +108,23 @@ func (e *ManagedFile) Find(c *fi.Context) (*ManagedFile, error) {
 		}
 	}
 
+	if gsfile, ok := filePath.(*vfs.GSPath); ok {
+		public, err := gsfile.IsPublic()
+		if err != nil {
+			return nil, err
+		}
+		actual.Public = &public
+
+		if e.Public == nil {
+			e.Public = fi.Bool(false)
+		}
+	}
+
+	if _, ok := filePath.(*vfs.AzureBlobPath); ok {
+		// The public access of blobs is set on their container
+		actual.Public = e.Public
+	}
+
 	if memfsfile, ok := filePath.(*vfs.MemFSPath); ok {
 		public, err := memfsfile.IsPublic()
 		if err != nil {
@@ -101,14 +139,81 @@ func (e *ManagedFile) Find(c *fi.Context) (*ManagedFile, error) {
 
 	// Avoid spurious changes
 	actual.Lifecycle = e.Lifecycle
+	actual.Template = e.Template
 
 	return actual, nil
 }
 
 func (e *ManagedFile) Run(c *fi.Context) error {
+	if fi.BoolValue(e.Template) && e.rendered == nil {
+		rendered, err := e.renderTemplate(c)
+		if err != nil {
+			return err
+		}
+		if rendered == nil {
+			rendered = []byte{}
+		}
+		e.rendered = rendered
+		e.Contents = fi.NewBytesResource(rendered)
+	}
 	return fi.DefaultDeltaRunMethod(e, c)
 }
 
+// TemplatedContents returns the contents of a templated file, once the task has run.
+// It must not be named Render*, as those methods are invoked as renderers by fi.Context.
+func (e *ManagedFile) TemplatedContents() ([]byte, error) {
+	if e.rendered == nil {
+		return nil, fmt.Errorf("contents of ManagedFile %q read before they were rendered", fi.StringValue(e.Name))
+	}
+	return e.rendered, nil
+}
+
+func (e *ManagedFile) renderTemplate(c *fi.Context) ([]byte, error) {
+	data, err := fi.ResourceAsBytes(e.Contents)
+	if err != nil {
+		return nil, fmt.Errorf("error reading contents of ManagedFile: %v", err)
+	}
+
+	funcs := template.FuncMap{
+		"Secret": func(name string) (string, error) {
+			if c.SecretStore == nil {
+				return "", fmt.Errorf("secret store is not available")
+			}
+			secret, err := c.SecretStore.FindSecret(name)
+			if err != nil {
+				return "", fmt.Errorf("error reading secret %q: %v", name, err)
+			}
+			if secret == nil {
+				return "", fmt.Errorf("secret %q not found", name)
+			}
+			return string(secret.Data), nil
+		},
+	}
+
+	t, err := template.New(fi.StringValue(e.Location)).Funcs(funcs).Option("missingkey=error").Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing template of ManagedFile %q: %v", fi.StringValue(e.Name), err)
+	}
+
+	cluster := c.Cluster
+	values := &ManagedFileTemplateValues{
+		ClusterName:           cluster.ObjectMeta.Name,
+		CloudProvider:         string(cluster.Spec.GetCloudProvider()),
+		ConfigBase:            cluster.Spec.ConfigBase,
+		KubernetesVersion:     cluster.Spec.KubernetesVersion,
+		MasterInternalName:    cluster.Spec.MasterInternalName,
+		NonMasqueradeCIDR:     cluster.Spec.NonMasqueradeCIDR,
+		PodCIDR:               cluster.Spec.PodCIDR,
+		ServiceClusterIPRange: cluster.Spec.ServiceClusterIPRange,
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, values); err != nil {
+		return nil, fmt.Errorf("error rendering template of ManagedFile %q: %v", fi.StringValue(e.Name), err)
+	}
+	return buf.Bytes(), nil
+}
+
 func (s *ManagedFile) CheckChanges(a, e, changes *ManagedFile) error {
 	if a != nil {
 		if changes.Name != nil {
@@ -136,6 +241,15 @@ func (e *ManagedFile) getACL(c *fi.Context, p vfs.Path) (vfs.ACL, error) {
 			acl = &vfs.S3Acl{
 				RequestACL: fi.String("public-read"),
 			}
+		case *vfs.GSPath:
+			acl = &vfs.GSAcl{
+				Acl: []*storage.ObjectAccessControl{
+					{Entity: "allUsers", Role: "READER"},
+				},
+			}
+		case *vfs.AzureBlobPath:
+			// The container must allow anonymous read access to blobs
+			return nil, nil
 		default:
 			return nil, fmt.Errorf("the %q path does not support public ACL", p.Path())
 		}
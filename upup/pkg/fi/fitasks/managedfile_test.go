@@ -0,0 +1,92 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fitasks
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/secrets"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func TestManagedFileTemplate(t *testing.T) {
+	cluster := &kops.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "minimal.example.com"},
+		Spec: kops.ClusterSpec{
+			CloudProvider:         kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+			KubernetesVersion:     "1.24.0",
+			ServiceClusterIPRange: "100.64.0.0/13",
+		},
+	}
+	secretStore := secrets.NewVFSSecretStore(cluster, vfs.NewMemFSPath(vfs.NewMemFSContext(), "secrets"))
+	if _, _, err := secretStore.GetOrCreateSecret("db-password", &fi.Secret{Data: []byte("hunter2")}); err != nil {
+		t.Fatalf("error creating secret: %v", err)
+	}
+	c := &fi.Context{
+		Cluster:     cluster,
+		SecretStore: secretStore,
+	}
+
+	grid := []struct {
+		template      string
+		expected      string
+		expectedError string
+	}{
+		{
+			template: "cluster: {{ .ClusterName }}\ncloud: {{ .CloudProvider }}\nversion: {{ .KubernetesVersion }}\nservices: {{ .ServiceClusterIPRange }}",
+			expected: "cluster: minimal.example.com\ncloud: aws\nversion: 1.24.0\nservices: 100.64.0.0/13",
+		},
+		{
+			template: `password: {{ Secret "db-password" }}`,
+			expected: "password: hunter2",
+		},
+		{
+			template:      `password: {{ Secret "missing" }}`,
+			expectedError: `secret "missing" not found`,
+		},
+		{
+			template:      "region: {{ .Region }}",
+			expectedError: "can't evaluate field Region",
+		},
+	}
+	for _, g := range grid {
+		e := &ManagedFile{
+			Name:     fi.String("test"),
+			Location: fi.String("addons/test.yaml"),
+			Contents: fi.NewStringResource(g.template),
+			Template: fi.Bool(true),
+		}
+		actual, err := e.renderTemplate(c)
+		if g.expectedError != "" {
+			if err == nil || !strings.Contains(err.Error(), g.expectedError) {
+				t.Errorf("template %q: expected error containing %q, got %v", g.template, g.expectedError, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("template %q: unexpected error: %v", g.template, err)
+			continue
+		}
+		if string(actual) != g.expected {
+			t.Errorf("template %q: expected %q, got %q", g.template, g.expected, string(actual))
+		}
+	}
+}
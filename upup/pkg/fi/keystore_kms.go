@@ -0,0 +1,204 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fi
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/kms"
+	cloudkms "google.golang.org/api/cloudkms/v1"
+
+	"k8s.io/kops/pkg/pki"
+)
+
+const (
+	// kmsWrappedPrivateKeyType is the PEM block type of a private key wrapped with a cloud KMS key
+	kmsWrappedPrivateKeyType = "KOPS KMS WRAPPED PRIVATE KEY"
+	// kmsKeyHeader is the PEM header naming the KMS key that wrapped the private key
+	kmsKeyHeader = "KMS-Key"
+)
+
+// KMSKeyWrapper encrypts and decrypts private keys with a cloud KMS key.
+type KMSKeyWrapper interface {
+	// Wrap encrypts the plaintext with the KMS key
+	Wrap(plaintext []byte) ([]byte, error)
+	// Unwrap decrypts ciphertext returned by Wrap
+	Unwrap(ciphertext []byte) ([]byte, error)
+}
+
+// NewKMSKeyWrapper builds the KMSKeyWrapper for the named KMS key; it is a variable so that tests can replace it.
+var NewKMSKeyWrapper = newKMSKeyWrapper
+
+func newKMSKeyWrapper(keyID string) (KMSKeyWrapper, error) {
+	switch {
+	case strings.HasPrefix(keyID, "arn:"):
+		return newAWSKMSKeyWrapper(keyID)
+	case strings.HasPrefix(keyID, "projects/"):
+		return newGCPKMSKeyWrapper(keyID)
+	default:
+		return nil, fmt.Errorf("unsupported KMS key %q: expected an AWS KMS key ARN or a GCP KMS key resource name", keyID)
+	}
+}
+
+// IsKMSWrappedPrivateKey returns true if the PEM data is a private key wrapped with a KMS key.
+func IsKMSWrappedPrivateKey(data []byte) bool {
+	block, _ := pem.Decode(data)
+	return block != nil && block.Type == kmsWrappedPrivateKeyType
+}
+
+// WrapPrivateKey encrypts a private key with the KMS key, returning it as PEM.
+func WrapPrivateKey(privateKey *pki.PrivateKey, keyID string) ([]byte, error) {
+	var plaintext bytes.Buffer
+	if _, err := privateKey.WriteTo(&plaintext); err != nil {
+		return nil, err
+	}
+
+	wrapper, err := NewKMSKeyWrapper(keyID)
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := wrapper.Wrap(plaintext.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("error wrapping private key with KMS key %q: %v", keyID, err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{
+		Type:    kmsWrappedPrivateKeyType,
+		Headers: map[string]string{kmsKeyHeader: keyID},
+		Bytes:   ciphertext,
+	}), nil
+}
+
+// UnwrapPrivateKey decrypts a private key returned by WrapPrivateKey.
+// It returns the private key and the name of the KMS key that wrapped it.
+func UnwrapPrivateKey(data []byte) (*pki.PrivateKey, string, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != kmsWrappedPrivateKeyType {
+		return nil, "", fmt.Errorf("data is not a KMS wrapped private key")
+	}
+	keyID := block.Headers[kmsKeyHeader]
+	if keyID == "" {
+		return nil, "", fmt.Errorf("KMS wrapped private key does not name its KMS key")
+	}
+
+	wrapper, err := NewKMSKeyWrapper(keyID)
+	if err != nil {
+		return nil, "", err
+	}
+	plaintext, err := wrapper.Unwrap(block.Bytes)
+	if err != nil {
+		return nil, "", fmt.Errorf("error unwrapping private key with KMS key %q: %v", keyID, err)
+	}
+
+	privateKey, err := pki.ParsePEMPrivateKey(plaintext)
+	if err != nil {
+		return nil, "", err
+	}
+	return privateKey, keyID, nil
+}
+
+// awsKMSKeyWrapper wraps private keys with an AWS KMS key
+type awsKMSKeyWrapper struct {
+	client *kms.KMS
+	keyID  string
+}
+
+func newAWSKMSKeyWrapper(keyID string) (*awsKMSKeyWrapper, error) {
+	keyARN, err := arn.Parse(keyID)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing KMS key ARN %q: %v", keyID, err)
+	}
+
+	config := aws.NewConfig().WithCredentialsChainVerboseErrors(true).WithRegion(keyARN.Region)
+	sess, err := session.NewSession(config)
+	if err != nil {
+		return nil, fmt.Errorf("error starting AWS session: %v", err)
+	}
+
+	return &awsKMSKeyWrapper{
+		client: kms.New(sess, config),
+		keyID:  keyID,
+	}, nil
+}
+
+func (w *awsKMSKeyWrapper) Wrap(plaintext []byte) ([]byte, error) {
+	response, err := w.client.Encrypt(&kms.EncryptInput{
+		KeyId:     aws.String(w.keyID),
+		Plaintext: plaintext,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response.CiphertextBlob, nil
+}
+
+func (w *awsKMSKeyWrapper) Unwrap(ciphertext []byte) ([]byte, error) {
+	response, err := w.client.Decrypt(&kms.DecryptInput{
+		KeyId:          aws.String(w.keyID),
+		CiphertextBlob: ciphertext,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response.Plaintext, nil
+}
+
+// gcpKMSKeyWrapper wraps private keys with a GCP KMS key
+type gcpKMSKeyWrapper struct {
+	service *cloudkms.Service
+	keyID   string
+}
+
+func newGCPKMSKeyWrapper(keyID string) (*gcpKMSKeyWrapper, error) {
+	service, err := cloudkms.NewService(context.TODO())
+	if err != nil {
+		return nil, fmt.Errorf("error building GCP KMS client: %v", err)
+	}
+
+	return &gcpKMSKeyWrapper{
+		service: service,
+		keyID:   keyID,
+	}, nil
+}
+
+func (w *gcpKMSKeyWrapper) Wrap(plaintext []byte) ([]byte, error) {
+	response, err := w.service.Projects.Locations.KeyRings.CryptoKeys.Encrypt(w.keyID, &cloudkms.EncryptRequest{
+		Plaintext: base64.StdEncoding.EncodeToString(plaintext),
+	}).Do()
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(response.Ciphertext)
+}
+
+func (w *gcpKMSKeyWrapper) Unwrap(ciphertext []byte) ([]byte, error) {
+	response, err := w.service.Projects.Locations.KeyRings.CryptoKeys.Decrypt(w.keyID, &cloudkms.DecryptRequest{
+		Ciphertext: base64.StdEncoding.EncodeToString(ciphertext),
+	}).Do()
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(response.Plaintext)
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fi
+
+import (
+	"bytes"
+	"crypto/x509/pkix"
+	"fmt"
+	"strings"
+	"testing"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/pki"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+// fakeKMSKeyWrapper wraps by prefixing the name of the key
+type fakeKMSKeyWrapper struct {
+	keyID string
+}
+
+func (w *fakeKMSKeyWrapper) Wrap(plaintext []byte) ([]byte, error) {
+	return append([]byte(w.keyID+":"), plaintext...), nil
+}
+
+func (w *fakeKMSKeyWrapper) Unwrap(ciphertext []byte) ([]byte, error) {
+	if !bytes.HasPrefix(ciphertext, []byte(w.keyID+":")) {
+		return nil, fmt.Errorf("ciphertext was not wrapped with %q", w.keyID)
+	}
+	return ciphertext[len(w.keyID)+1:], nil
+}
+
+func TestVFSCAStoreKMSWrappedPrivateKey(t *testing.T) {
+	vfs.Context.ResetMemfsContext(true)
+	defer func(f func(string) (KMSKeyWrapper, error)) { NewKMSKeyWrapper = f }(NewKMSKeyWrapper)
+	NewKMSKeyWrapper = func(keyID string) (KMSKeyWrapper, error) {
+		return &fakeKMSKeyWrapper{keyID: keyID}, nil
+	}
+
+	basePath, err := vfs.Context.BuildVfsPath("memfs://tests")
+	if err != nil {
+		t.Fatalf("error building vfspath: %v", err)
+	}
+
+	privateKey, err := pki.GeneratePrivateKey()
+	if err != nil {
+		t.Fatalf("error generating private key: %v", err)
+	}
+	cert, _, _, err := pki.IssueCert(&pki.IssueCertRequest{
+		Type:       "ca",
+		Subject:    pkix.Name{CommonName: "kubernetes-ca"},
+		PrivateKey: privateKey,
+	}, nil)
+	if err != nil {
+		t.Fatalf("error issuing certificate: %v", err)
+	}
+	keyset, err := NewKeyset(cert, privateKey)
+	if err != nil {
+		t.Fatalf("error building keyset: %v", err)
+	}
+
+	kmsKey := "arn:aws:kms:us-east-1:123456789012:key/test"
+	cluster := &kops.Cluster{Spec: kops.ClusterSpec{KeyStoreKMSKey: kmsKey}}
+	if err := NewVFSCAStore(cluster, basePath).StoreKeyset("kubernetes-ca", keyset); err != nil {
+		t.Fatalf("error from StoreKeyset: %v", err)
+	}
+
+	keysetPath := "memfs://tests/private/kubernetes-ca/keyset.yaml"
+	p, err := vfs.Context.BuildVfsPath(keysetPath)
+	if err != nil {
+		t.Fatalf("error building vfspath: %v", err)
+	}
+	data, err := p.ReadFile()
+	if err != nil {
+		t.Fatalf("error reading %s: %v", keysetPath, err)
+	}
+	stored, _, err := NewVFSCAStore(nil, basePath).parseKeysetYaml(data)
+	if err != nil {
+		t.Fatalf("error parsing %s: %v", keysetPath, err)
+	}
+	privateMaterial := stored.Spec.Keys[0].PrivateMaterial
+	if !IsKMSWrappedPrivateKey(privateMaterial) || !strings.Contains(string(privateMaterial), kmsKeyHeader+": "+kmsKey) {
+		t.Errorf("expected private material wrapped with %q, got:\n%s", kmsKey, privateMaterial)
+	}
+
+	// The private key stays wrapped with its KMS key when rewritten, even once the cluster no longer names it
+	loaded, err := NewVFSCAStore(nil, basePath).FindKeyset("kubernetes-ca")
+	if err != nil {
+		t.Fatalf("error from FindKeyset: %v", err)
+	}
+	if loaded.Primary.KMSKey != kmsKey {
+		t.Errorf("expected KMS key %q, got %q", kmsKey, loaded.Primary.KMSKey)
+	}
+	if !privateKeysEqual(t, loaded.Primary.PrivateKey, privateKey) {
+		t.Errorf("unwrapped private key does not match")
+	}
+	o, err := loaded.ToAPIObject("kubernetes-ca", "")
+	if err != nil {
+		t.Fatalf("error from ToAPIObject: %v", err)
+	}
+	if !IsKMSWrappedPrivateKey(o.Spec.Keys[0].PrivateMaterial) {
+		t.Errorf("expected private material wrapped with %q, got:\n%s", kmsKey, o.Spec.Keys[0].PrivateMaterial)
+	}
+}
+
+func TestUnwrapPrivateKeyWrongKey(t *testing.T) {
+	defer func(f func(string) (KMSKeyWrapper, error)) { NewKMSKeyWrapper = f }(NewKMSKeyWrapper)
+	NewKMSKeyWrapper = func(keyID string) (KMSKeyWrapper, error) {
+		return &fakeKMSKeyWrapper{keyID: keyID}, nil
+	}
+
+	privateKey, err := pki.GeneratePrivateKey()
+	if err != nil {
+		t.Fatalf("error generating private key: %v", err)
+	}
+	wrapped, err := WrapPrivateKey(privateKey, "projects/p/locations/global/keyRings/r/cryptoKeys/a")
+	if err != nil {
+		t.Fatalf("error from WrapPrivateKey: %v", err)
+	}
+
+	wrapped = bytes.Replace(wrapped, []byte("cryptoKeys/a"), []byte("cryptoKeys/b"), 1)
+	if _, _, err := UnwrapPrivateKey(wrapped); err == nil {
+		t.Errorf("expected an error unwrapping with another KMS key")
+	}
+}
+
+func TestNewKMSKeyWrapperUnsupported(t *testing.T) {
+	if _, err := newKMSKeyWrapper("alias/my-key"); err == nil {
+		t.Errorf("expected an error for a KMS key alias")
+	}
+}
+
+func privateKeysEqual(t *testing.T, a, b *pki.PrivateKey) bool {
+	var aData, bData bytes.Buffer
+	if _, err := a.WriteTo(&aData); err != nil {
+		t.Fatalf("error writing private key: %v", err)
+	}
+	if _, err := b.WriteTo(&bData); err != nil {
+		t.Fatalf("error writing private key: %v", err)
+	}
+	return bytes.Equal(aData.Bytes(), bData.Bytes())
+}
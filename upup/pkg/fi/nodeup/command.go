@@ -27,6 +27,7 @@ import (
 	"net/url"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -41,6 +42,7 @@ import (
 	"k8s.io/kops/pkg/apis/nodeup"
 	"k8s.io/kops/pkg/assets"
 	"k8s.io/kops/pkg/bootstrap"
+	"k8s.io/kops/pkg/bootstrap/metal"
 	"k8s.io/kops/pkg/configserver"
 	"k8s.io/kops/pkg/kopscodecs"
 	"k8s.io/kops/pkg/resolver"
@@ -204,6 +206,9 @@ func (c *NodeUpCommand) Run(out io.Writer) error {
 
 	configAssets := nodeupConfig.Assets[architecture]
 	assetStore := fi.NewAssetStore(c.CacheDir)
+	if nodeupConfig.AssetCache != nil {
+		assetStore.SetSharedCache(fi.StringValue(nodeupConfig.AssetCache.Path), fi.StringValue(nodeupConfig.AssetCache.URL))
+	}
 	for _, asset := range configAssets {
 		err := assetStore.Add(asset)
 		if err != nil {
@@ -211,6 +216,13 @@ func (c *NodeUpCommand) Run(out io.Writer) error {
 		}
 	}
 
+	if c.Target == "direct" && shouldRunConnectivityChecks(c.cluster, kubeletKubeConfigPath) {
+		checks := buildConnectivityChecks(c.cluster, &bootConfig, configAssets)
+		if err := runConnectivityChecks(out, checks, net.DialTimeout); err != nil {
+			return err
+		}
+	}
+
 	var cloud fi.Cloud
 
 	if cloudProvider == api.CloudProviderAWS {
@@ -301,6 +313,18 @@ func (c *NodeUpCommand) Run(out io.Writer) error {
 				modelContext.GPUVendor = architectures.GPUVendorNvidia
 			}
 		}
+	} else if cloudProvider == api.CloudProviderGCE || cloudProvider == api.CloudProviderAzure {
+		// If Nvidia is enabled, check if this instance has an Nvidia GPU attached.
+		if nodeupConfig.NvidiaGPU != nil && fi.BoolValue(nodeupConfig.NvidiaGPU.Enabled) {
+			hasGPU, err := hasNvidiaGPU()
+			if err != nil {
+				return fmt.Errorf("error looking for GPUs: %w", err)
+			}
+			if hasGPU {
+				klog.Info("instance supports GPU acceleration")
+				modelContext.GPUVendor = architectures.GPUVendorNvidia
+			}
+		}
 	} else if cloudProvider == api.CloudProviderOpenstack {
 		// NvidiaGPU possible to enable only in instance group level in OpenStack. When we assume that GPU is supported
 		if nodeupConfig.NvidiaGPU != nil && fi.BoolValue(nodeupConfig.NvidiaGPU.Enabled) {
@@ -309,45 +333,55 @@ func (c *NodeUpCommand) Run(out io.Writer) error {
 		}
 	}
 
-	if err := loadKernelModules(modelContext); err != nil {
-		return err
-	}
-
 	loader := &Loader{}
-	loader.Builders = append(loader.Builders, &dns.GossipBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.NTPBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.MiscUtilsBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.DirectoryBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.UpdateServiceBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.VolumesBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.ContainerdBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.DockerBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.ProtokubeBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.CloudConfigBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.FileAssetsBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.HookBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.KubeletBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.KubectlBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.LogrotateBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.ManifestsBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.PackagesBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.NvidiaBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.SecretBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.FirewallBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.SysctlBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.KubeAPIServerBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.KubeControllerManagerBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.KubeSchedulerBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.EtcdManagerTLSBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.KubeProxyBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.KopsControllerBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.WarmPoolBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &model.PrefixBuilder{NodeupModelContext: modelContext})
-
-	loader.Builders = append(loader.Builders, &networking.CommonBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &networking.CalicoBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &networking.CiliumBuilder{NodeupModelContext: modelContext})
-	loader.Builders = append(loader.Builders, &networking.KuberouterBuilder{NodeupModelContext: modelContext})
+	if distribution.IsWindows() {
+		loader.Builders = append(loader.Builders, &model.SecretBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.CloudConfigBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.WindowsContainerdBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.WindowsKubeletBuilder{NodeupModelContext: modelContext})
+	} else {
+		if err := loadKernelModules(modelContext); err != nil {
+			return err
+		}
+
+		loader.Builders = append(loader.Builders, &dns.GossipBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.NTPBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.MiscUtilsBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.DirectoryBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.UpdateServiceBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.VolumesBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.ContainerdBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.CrioBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.DockerBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.ProtokubeBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.CloudConfigBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.FileAssetsBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.HookBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.KubeletBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.KubectlBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.LogrotateBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.ManifestsBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.PackagesBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.NvidiaBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.SecretBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.FirewallBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.SysctlBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.KubeAPIServerBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.KubeControllerManagerBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.KubeSchedulerBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.EtcdManagerTLSBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.KubeProxyBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.KopsControllerBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.CertificateRenewalBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.WarmPoolBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.PreloadImagesBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.PrefixBuilder{NodeupModelContext: modelContext})
+
+		loader.Builders = append(loader.Builders, &networking.CommonBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &networking.CalicoBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &networking.CiliumBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &networking.KuberouterBuilder{NodeupModelContext: modelContext})
+	}
 
 	loader.Builders = append(loader.Builders, &model.BootstrapClientBuilder{NodeupModelContext: modelContext})
 	taskMap, err := loader.Build()
@@ -430,6 +464,32 @@ func getMachineType() (string, error) {
 	return instanceTypeName, err
 }
 
+// nvidiaPCIVendorID is the PCI vendor ID of Nvidia devices.
+const nvidiaPCIVendorID = "0x10de"
+
+// hasNvidiaGPU returns true if one of the PCI devices of the instance is an Nvidia display controller.
+func hasNvidiaGPU() (bool, error) {
+	devices, err := filepath.Glob("/sys/bus/pci/devices/*")
+	if err != nil {
+		return false, err
+	}
+	for _, device := range devices {
+		vendor, err := os.ReadFile(filepath.Join(device, "vendor"))
+		if err != nil || strings.TrimSpace(string(vendor)) != nvidiaPCIVendorID {
+			continue
+		}
+		// Display controllers have the base class 0x03; Nvidia GPUs also expose audio devices, which don't
+		class, err := os.ReadFile(filepath.Join(device, "class"))
+		if err != nil {
+			continue
+		}
+		if strings.HasPrefix(strings.TrimSpace(string(class)), "0x03") {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func completeWarmingLifecycleAction(cloud awsup.AWSCloud, modelContext *model.NodeupModelContext) error {
 	asgName := modelContext.BootConfig.InstanceGroupName + "." + modelContext.Cluster.GetName()
 	hookName := "kops-warmpool"
@@ -720,7 +780,7 @@ func getNodeConfigFromServer(ctx context.Context, bootConfig *nodeup.BootConfig,
 		}
 		authenticator = a
 	case api.CloudProviderGCE:
-		a, err := gcetpmsigner.NewTPMAuthenticator()
+		a, err := gcetpmsigner.NewAuthenticator()
 		if err != nil {
 			return nil, err
 		}
@@ -731,6 +791,12 @@ func getNodeConfigFromServer(ctx context.Context, bootConfig *nodeup.BootConfig,
 			return nil, err
 		}
 		resolver = discovery
+	case api.CloudProviderMetal:
+		a, err := metal.NewAuthenticator()
+		if err != nil {
+			return nil, err
+		}
+		authenticator = a
 	default:
 		return nil, fmt.Errorf("unsupported cloud provider for node configuration %s", bootConfig.CloudProvider)
 	}
@@ -49,6 +49,8 @@ type BootstrapClientTask struct {
 
 	// Client holds the client wrapper for the kops-bootstrap protocol
 	Client *KopsBootstrapClient
+	// Renew is true if the node has already bootstrapped, so is renewing its certificates.
+	Renew bool
 
 	keys map[string]*pki.PrivateKey
 }
@@ -118,7 +120,13 @@ func (b *BootstrapClientTask) Run(c *fi.Context) error {
 		req.Certs[name] = string(pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: pkData}))
 	}
 
-	resp, err := b.Client.QueryBootstrap(ctx, &req)
+	var resp *nodeup.BootstrapResponse
+	var err error
+	if b.Renew {
+		resp, err = b.Client.QueryRenew(ctx, &req)
+	} else {
+		resp, err = b.Client.QueryBootstrap(ctx, &req)
+	}
 	if err != nil {
 		return err
 	}
@@ -188,7 +196,17 @@ func (b *KopsBootstrapClient) dial(ctx context.Context, network, addr string) (n
 	return nil, errors[0]
 }
 
+// QueryBootstrap requests the certificates, and optionally the configuration, of a node that is joining the cluster.
 func (b *KopsBootstrapClient) QueryBootstrap(ctx context.Context, req *nodeup.BootstrapRequest) (*nodeup.BootstrapResponse, error) {
+	return b.query(ctx, "bootstrap", req)
+}
+
+// QueryRenew requests new certificates for a node that has already bootstrapped.
+func (b *KopsBootstrapClient) QueryRenew(ctx context.Context, req *nodeup.BootstrapRequest) (*nodeup.BootstrapResponse, error) {
+	return b.query(ctx, "renew", req)
+}
+
+func (b *KopsBootstrapClient) query(ctx context.Context, requestPath string, req *nodeup.BootstrapRequest) (*nodeup.BootstrapResponse, error) {
 	if b.httpClient == nil {
 		certPool := x509.NewCertPool()
 		certPool.AppendCertsFromPEM(b.CAs)
@@ -230,7 +248,7 @@ func (b *KopsBootstrapClient) QueryBootstrap(ctx context.Context, req *nodeup.Bo
 	}
 
 	bootstrapURL := b.BaseURL
-	bootstrapURL.Path = path.Join(bootstrapURL.Path, "/bootstrap")
+	bootstrapURL.Path = path.Join(bootstrapURL.Path, requestPath)
 	httpReq, err := http.NewRequestWithContext(ctx, "POST", bootstrapURL.String(), bytes.NewReader(reqBytes))
 	if err != nil {
 		return nil, err
@@ -259,7 +277,7 @@ func (b *KopsBootstrapClient) QueryBootstrap(ctx context.Context, req *nodeup.Bo
 				detail = scanner.Text()
 			}
 		}
-		return nil, fmt.Errorf("bootstrap returned status code %d: %s", resp.StatusCode, detail)
+		return nil, fmt.Errorf("%s returned status code %d: %s", requestPath, resp.StatusCode, detail)
 	}
 
 	var bootstrapResp nodeup.BootstrapResponse
@@ -0,0 +1,54 @@
+//go:build !windows
+
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodetasks
+
+import (
+	"os"
+	"strconv"
+	"syscall"
+
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+// findFileOwner sets the owner and group of the actual file from its stat.
+func findFileOwner(actual *File, stat os.FileInfo) error {
+	uid := int(stat.Sys().(*syscall.Stat_t).Uid)
+	owner, err := fi.LookupUserByID(uid)
+	if err != nil {
+		return err
+	}
+	if owner != nil {
+		actual.Owner = fi.String(owner.Name)
+	} else {
+		actual.Owner = fi.String(strconv.Itoa(uid))
+	}
+
+	gid := int(stat.Sys().(*syscall.Stat_t).Gid)
+	group, err := fi.LookupGroupByID(gid)
+	if err != nil {
+		return err
+	}
+	if group != nil {
+		actual.Group = fi.String(group.Name)
+	} else {
+		actual.Group = fi.String(strconv.Itoa(gid))
+	}
+
+	return nil
+}
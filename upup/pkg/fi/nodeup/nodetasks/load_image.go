@@ -92,6 +92,10 @@ func (_ *LoadImageTask) RenderLocal(t *local.LocalTarget, a, e, changes *LoadIma
 	if runtime != "docker" && runtime != "containerd" {
 		return fmt.Errorf("no runtime specified")
 	}
+	if runtime == "crio" {
+		// CRI-O has no command to import image archives
+		return fmt.Errorf("loading container images is not supported with CRI-O")
+	}
 
 	hash, err := hashing.FromString(e.Hash)
 	if err != nil {
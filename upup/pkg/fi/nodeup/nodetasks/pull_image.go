@@ -29,6 +29,9 @@ import (
 type PullImageTask struct {
 	Name    string
 	Runtime string
+
+	// BeforeServices are the services that are started only once the image has been pulled.
+	BeforeServices []string
 }
 
 var (
@@ -48,6 +51,9 @@ func (t *PullImageTask) GetDependencies(tasks map[string]fi.Task) []fi.Task {
 		if svc, ok := v.(*Service); ok && svc.Name == dockerService {
 			deps = append(deps, v)
 		}
+		if svc, ok := v.(*Service); ok && svc.Name == crioService {
+			deps = append(deps, v)
+		}
 	}
 	return deps
 }
@@ -61,7 +67,7 @@ func (t *PullImageTask) GetName() *string {
 
 func (e *PullImageTask) Run(c *fi.Context) error {
 	runtime := e.Runtime
-	if runtime != "docker" && runtime != "containerd" {
+	if runtime != "docker" && runtime != "containerd" && runtime != "crio" {
 		return fmt.Errorf("no runtime specified")
 	}
 
@@ -72,6 +78,8 @@ func (e *PullImageTask) Run(c *fi.Context) error {
 		args = []string{"docker", "pull", e.Name}
 	case "containerd":
 		args = []string{"ctr", "--namespace", "k8s.io", "images", "pull", e.Name}
+	case "crio":
+		args = []string{"crictl", "--runtime-endpoint", "unix:///var/run/crio/crio.sock", "pull", e.Name}
 	default:
 		return fmt.Errorf("unknown container runtime: %s", runtime)
 	}
@@ -45,6 +45,7 @@ const (
 	containerosSystemdSystemPath = "/etc/systemd/system"
 
 	containerdService = "containerd.service"
+	crioService       = "crio.service"
 	dockerService     = "docker.service"
 	protokubeService  = "protokube.service"
 )
@@ -76,8 +77,14 @@ func (p *Service) GetDependencies(tasks map[string]fi.Task) []fi.Task {
 		switch v := v.(type) {
 		case *Package, *UpdatePackages, *UserTask, *GroupTask, *Chattr, *BindMount, *Archive, *Prefix, *dnstasks.UpdateEtcHostsTask:
 			deps = append(deps, v)
-		case *Service, *LoadImageTask, *PullImageTask, *IssueCert, *BootstrapClientTask, *KubeConfig:
+		case *Service, *LoadImageTask, *IssueCert, *BootstrapClientTask, *KubeConfig:
 			// ignore
+		case *PullImageTask:
+			for _, s := range v.BeforeServices {
+				if p.Name == s {
+					deps = append(deps, v)
+				}
+			}
 		case *File:
 			if len(v.BeforeServices) > 0 {
 				for _, s := range v.BeforeServices {
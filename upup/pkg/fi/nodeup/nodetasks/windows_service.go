@@ -0,0 +1,178 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodetasks
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"k8s.io/klog/v2"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/nodeup/cloudinit"
+	"k8s.io/kops/upup/pkg/fi/nodeup/local"
+)
+
+// WindowsService is a service registered with the Windows service control manager
+type WindowsService struct {
+	Name        string
+	DisplayName string `json:"displayName,omitempty"`
+	// BinaryPath is the command line that starts the service
+	BinaryPath string `json:"binaryPath,omitempty"`
+	// Dependencies are the names of the services that must be started before this service
+	Dependencies []string `json:"dependencies,omitempty"`
+}
+
+var (
+	_ fi.HasDependencies = &WindowsService{}
+	_ fi.HasName         = &WindowsService{}
+)
+
+func (p *WindowsService) GetDependencies(tasks map[string]fi.Task) []fi.Task {
+	var deps []fi.Task
+	for _, v := range tasks {
+		switch v := v.(type) {
+		case *File:
+			if len(v.BeforeServices) > 0 {
+				for _, s := range v.BeforeServices {
+					if p.Name == s {
+						deps = append(deps, v)
+					}
+				}
+			} else {
+				deps = append(deps, v)
+			}
+		case *WindowsService:
+			for _, s := range p.Dependencies {
+				if v.Name == s {
+					deps = append(deps, v)
+				}
+			}
+		}
+	}
+	return deps
+}
+
+func (s *WindowsService) String() string {
+	return fmt.Sprintf("WindowsService: %s", s.Name)
+}
+
+func (f *WindowsService) GetName() *string {
+	return &f.Name
+}
+
+func (e *WindowsService) Find(c *fi.Context) (*WindowsService, error) {
+	output, err := exec.Command("sc.exe", "qc", e.Name).CombinedOutput()
+	if err != nil {
+		klog.V(2).Infof("service %q not found: %v: %s", e.Name, err, string(output))
+		return nil, nil
+	}
+
+	actual := parseServiceConfig(string(output))
+	actual.Name = e.Name
+	return actual, nil
+}
+
+// parseServiceConfig parses the output of "sc.exe qc", e.g.
+//
+//	SERVICE_NAME: kubelet
+//	        BINARY_PATH_NAME   : C:\k\kubelet.exe --windows-service
+//	        DISPLAY_NAME       : kubelet
+//	        DEPENDENCIES       : containerd
+func parseServiceConfig(output string) *WindowsService {
+	service := &WindowsService{}
+	key := ""
+	for _, line := range strings.Split(output, "\n") {
+		tokens := strings.SplitN(line, ":", 2)
+		if len(tokens) != 2 {
+			continue
+		}
+		// Lists continue on the next lines, with an empty key
+		if k := strings.TrimSpace(tokens[0]); k != "" {
+			key = k
+		}
+		value := strings.TrimSpace(tokens[1])
+		switch key {
+		case "BINARY_PATH_NAME":
+			service.BinaryPath = value
+		case "DISPLAY_NAME":
+			service.DisplayName = value
+		case "DEPENDENCIES":
+			if value != "" {
+				service.Dependencies = append(service.Dependencies, value)
+			}
+		}
+	}
+	return service
+}
+
+func (e *WindowsService) Run(c *fi.Context) error {
+	return fi.DefaultDeltaRunMethod(e, c)
+}
+
+func (s *WindowsService) CheckChanges(a, e, changes *WindowsService) error {
+	return nil
+}
+
+func (_ *WindowsService) RenderLocal(t *local.LocalTarget, a, e, changes *WindowsService) error {
+	args := []string{
+		"binPath=", e.BinaryPath,
+		"start=", "auto",
+		"DisplayName=", e.DisplayName,
+		"depend=", strings.Join(e.Dependencies, "/"),
+	}
+
+	if a == nil {
+		klog.Infof("Creating service %q", e.Name)
+		if err := runServiceControl(append([]string{"create", e.Name}, args...)...); err != nil {
+			return err
+		}
+	} else if changes.BinaryPath != "" || changes.DisplayName != "" || changes.Dependencies != nil {
+		klog.Infof("Updating service %q", e.Name)
+		if err := runServiceControl(append([]string{"config", e.Name}, args...)...); err != nil {
+			return err
+		}
+	}
+
+	// Restart the service when it fails
+	if err := runServiceControl("failure", e.Name, "reset=", "0", "actions=", "restart/10000"); err != nil {
+		return err
+	}
+
+	// Restart the service, so that it picks up its new configuration
+	klog.Infof("Restarting service %q", e.Name)
+	cmd := exec.Command("powershell.exe", "-NoProfile", "-Command", "Restart-Service", "-Force", "-Name", e.Name)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("error restarting service %q: %v\nOutput: %s", e.Name, err, output)
+	}
+
+	return nil
+}
+
+func runServiceControl(args ...string) error {
+	cmd := exec.Command("sc.exe", args...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("error doing 'sc.exe %v': %v\nOutput: %s", args, err, output)
+	}
+	return nil
+}
+
+func (_ *WindowsService) RenderCloudInit(t *cloudinit.CloudInitTarget, a, e, changes *WindowsService) error {
+	return fmt.Errorf("rendering Windows services to cloud-init is not supported")
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodetasks
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func TestWindowsServiceTask_Deps(t *testing.T) {
+	s := &WindowsService{Name: "kubelet", Dependencies: []string{"containerd"}}
+
+	tasks := make(map[string]fi.Task)
+	tasks["FileTask1"] = &File{BeforeServices: []string{"kubelet"}}
+	tasks["FileTask2"] = &File{BeforeServices: []string{"containerd"}}
+	tasks["WindowsService1"] = &WindowsService{Name: "containerd"}
+
+	deps := s.GetDependencies(tasks)
+	if len(deps) != 2 {
+		t.Fatalf("unexpected deps: %v", deps)
+	}
+	for _, name := range []string{"FileTask1", "WindowsService1"} {
+		found := false
+		for _, dep := range deps {
+			if dep == tasks[name] {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected dependency on %s, actual=%v", name, deps)
+		}
+	}
+}
+
+func TestParseServiceConfig(t *testing.T) {
+	output := "[SC] QueryServiceConfig SUCCESS\r\n" +
+		"\r\n" +
+		"SERVICE_NAME: kubelet\r\n" +
+		"        TYPE               : 10  WIN32_OWN_PROCESS\r\n" +
+		"        START_TYPE         : 2   AUTO_START\r\n" +
+		"        ERROR_CONTROL      : 1   NORMAL\r\n" +
+		"        BINARY_PATH_NAME   : C:\\k\\kubelet.exe --windows-service\r\n" +
+		"        LOAD_ORDER_GROUP   :\r\n" +
+		"        TAG                : 0\r\n" +
+		"        DISPLAY_NAME       : Kubernetes kubelet\r\n" +
+		"        DEPENDENCIES       : containerd\r\n" +
+		"                           : tcpip\r\n" +
+		"        SERVICE_START_NAME : LocalSystem\r\n"
+
+	expected := &WindowsService{
+		DisplayName:  "Kubernetes kubelet",
+		BinaryPath:   `C:\k\kubelet.exe --windows-service`,
+		Dependencies: []string{"containerd", "tcpip"},
+	}
+	actual := parseServiceConfig(output)
+	if !reflect.DeepEqual(expected, actual) {
+		t.Fatalf("unexpected service config.  expected=%+v, actual=%+v", expected, actual)
+	}
+}
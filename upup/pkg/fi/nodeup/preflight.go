@@ -0,0 +1,203 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeup
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+	api "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/kops/model"
+	"k8s.io/kops/pkg/apis/nodeup"
+	"k8s.io/kops/pkg/dns"
+	"k8s.io/kops/pkg/wellknownports"
+)
+
+// connectivityCheckTimeout is how long we wait for each connection attempt
+const connectivityCheckTimeout = 10 * time.Second
+
+// kubeletKubeConfigPath is written when nodeup first configures the instance. Once it exists, nodeup is being rerun,
+// for example by the certificate renewal timer, so the connectivity checks are skipped.
+const kubeletKubeConfigPath = "/var/lib/kubelet/kubeconfig"
+
+// connectivityCheck is an endpoint the instance needs to reach to join the cluster.
+// The check passes if any of the addresses can be reached.
+type connectivityCheck struct {
+	Description string
+	Addresses   []string
+	// Optional checks only report a warning when they fail, as the instance can do without the endpoint,
+	// for example when its images are preloaded.
+	Optional bool
+}
+
+// shouldRunConnectivityChecks returns true if the checks are enabled and nodeup is configuring the instance for the first time.
+func shouldRunConnectivityChecks(cluster *api.Cluster, kubeletKubeConfig string) bool {
+	if cluster.Spec.DisableConnectivityChecks {
+		return false
+	}
+	if _, err := os.Stat(kubeletKubeConfig); err == nil {
+		return false
+	}
+	return true
+}
+
+// buildConnectivityChecks returns the endpoints the instance needs to reach before installation starts.
+// The state store (or config server) is not included, as it has already been read by the time these checks run.
+func buildConnectivityChecks(cluster *api.Cluster, bootConfig *nodeup.BootConfig, assets []string) []connectivityCheck {
+	var checks []connectivityCheck
+
+	if proxy := cluster.Spec.EgressProxy; proxy != nil && proxy.HTTPProxy.Host != "" {
+		checks = append(checks, connectivityCheck{
+			Description: "egress proxy",
+			Addresses:   []string{net.JoinHostPort(proxy.HTTPProxy.Host, strconv.Itoa(proxy.HTTPProxy.Port))},
+		})
+	} else {
+		// With an egress proxy, the registry and assets are reached through the proxy, so we can only check the proxy itself
+		registry := "registry.k8s.io"
+		if cluster.Spec.Assets != nil && cluster.Spec.Assets.ContainerProxy != nil {
+			registry = *cluster.Spec.Assets.ContainerProxy
+		} else if cluster.Spec.Assets != nil && cluster.Spec.Assets.ContainerRegistry != nil {
+			registry = *cluster.Spec.Assets.ContainerRegistry
+		}
+		var addresses []string
+		if address := hostPortFromURL(registry); address != "" {
+			addresses = append(addresses, address)
+		}
+		if containerd := cluster.Spec.Containerd; containerd != nil {
+			// Images can also be pulled through any of the mirrors configured for the registry
+			registryName := strings.SplitN(registry, "/", 2)[0]
+			for _, name := range []string{registryName, "*"} {
+				for _, mirror := range containerd.RegistryMirrors[name] {
+					if address := hostPortFromURL(mirror); address != "" {
+						addresses = append(addresses, address)
+					}
+				}
+				for _, mirror := range containerd.Registries[name].Mirrors {
+					if address := hostPortFromURL(mirror.Endpoint); address != "" {
+						addresses = append(addresses, address)
+					}
+				}
+			}
+		}
+		if len(addresses) != 0 {
+			checks = append(checks, connectivityCheck{
+				Description: "container registry",
+				Addresses:   addresses,
+				Optional:    true,
+			})
+		}
+
+		seen := make(map[string]bool)
+		for _, asset := range assets {
+			var addresses []string
+			for _, u := range strings.Split(asset[strings.Index(asset, "@")+1:], ",") {
+				if address := hostPortFromURL(u); address != "" {
+					addresses = append(addresses, address)
+				}
+			}
+			key := strings.Join(addresses, ",")
+			if len(addresses) == 0 || seen[key] {
+				continue
+			}
+			seen[key] = true
+			checks = append(checks, connectivityCheck{
+				Description: "asset repository",
+				Addresses:   addresses,
+			})
+		}
+	}
+
+	// The control plane starts the API and kops-controller itself, and in gossip mode their names only resolve once protokube runs
+	if bootConfig.InstanceGroupRole == api.InstanceGroupRoleNode && !dns.IsGossipHostname(cluster.Spec.MasterInternalName) {
+		checks = append(checks, connectivityCheck{
+			Description: "API server",
+			Addresses:   []string{net.JoinHostPort(cluster.Spec.MasterInternalName, strconv.Itoa(wellknownports.KubeAPIServer))},
+		})
+		if model.UseKopsControllerForNodeBootstrap(cluster) {
+			checks = append(checks, connectivityCheck{
+				Description: "kops-controller",
+				Addresses:   []string{net.JoinHostPort("kops-controller.internal."+cluster.ObjectMeta.Name, strconv.Itoa(wellknownports.KopsControllerPort))},
+			})
+		}
+	}
+
+	return checks
+}
+
+// runConnectivityChecks attempts a TCP connection for each check, reporting the result of each to out.
+// It returns an error describing every failed check that is not optional.
+func runConnectivityChecks(out io.Writer, checks []connectivityCheck, dial func(network, address string, timeout time.Duration) (net.Conn, error)) error {
+	var failures []string
+	for _, check := range checks {
+		var lastErr error
+		for _, address := range check.Addresses {
+			conn, err := dial("tcp", address, connectivityCheckTimeout)
+			if err != nil {
+				lastErr = err
+				continue
+			}
+			conn.Close()
+			lastErr = nil
+			break
+		}
+
+		if lastErr != nil && check.Optional {
+			fmt.Fprintf(out, "connectivity check warning: cannot reach %s at %s: %v\n", check.Description, strings.Join(check.Addresses, " or "), lastErr)
+		} else if lastErr != nil {
+			fmt.Fprintf(out, "connectivity check failed: cannot reach %s at %s: %v\n", check.Description, strings.Join(check.Addresses, " or "), lastErr)
+			failures = append(failures, check.Description)
+		} else {
+			klog.Infof("connectivity check passed: %s at %s", check.Description, strings.Join(check.Addresses, " or "))
+		}
+	}
+
+	if len(failures) != 0 {
+		return fmt.Errorf("instance cannot reach %s; check security groups, routing, DNS and proxy settings", strings.Join(failures, ", "))
+	}
+	return nil
+}
+
+// hostPortFromURL returns the host:port to connect to for a URL, or for a bare registry name such as registry.k8s.io
+func hostPortFromURL(s string) string {
+	if !strings.Contains(s, "://") {
+		s = "https://" + s
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.Hostname() == "" {
+		return ""
+	}
+
+	port := u.Port()
+	if port == "" {
+		switch u.Scheme {
+		case "http":
+			port = "80"
+		case "https":
+			port = "443"
+		default:
+			return ""
+		}
+	}
+	return net.JoinHostPort(u.Hostname(), port)
+}
@@ -0,0 +1,322 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeup
+
+import (
+	"bytes"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	api "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/nodeup"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func TestBuildConnectivityChecks(t *testing.T) {
+	grid := []struct {
+		name     string
+		spec     api.ClusterSpec
+		role     api.InstanceGroupRole
+		assets   []string
+		expected []connectivityCheck
+	}{
+		{
+			name: "control plane with default registry",
+			spec: api.ClusterSpec{MasterInternalName: "api.internal.example.com"},
+			role: api.InstanceGroupRoleMaster,
+			assets: []string{
+				"0123456789abcdef@https://storage.googleapis.com/kubernetes-release/release/v1.24.0/bin/linux/amd64/kubelet",
+				"fedcba9876543210@https://storage.googleapis.com/kubernetes-release/release/v1.24.0/bin/linux/amd64/kubectl",
+				"0011223344556677@https://mirror.example.com:8443/containerd.tar.gz,https://github.com/containerd/containerd/releases/download/v1.6.6/containerd.tar.gz",
+			},
+			expected: []connectivityCheck{
+				{Description: "container registry", Addresses: []string{"registry.k8s.io:443"}, Optional: true},
+				{Description: "asset repository", Addresses: []string{"storage.googleapis.com:443"}},
+				{Description: "asset repository", Addresses: []string{"mirror.example.com:8443", "github.com:443"}},
+			},
+		},
+		{
+			name: "container proxy and registry mirrors",
+			spec: api.ClusterSpec{
+				MasterInternalName: "api.internal.example.com",
+				Assets:             &api.Assets{ContainerProxy: fi.String("proxy.example.com:5000/k8s")},
+				Containerd: &api.ContainerdConfig{
+					RegistryMirrors: map[string][]string{
+						"proxy.example.com:5000": {"https://mirror.example.com"},
+						"*":                      {"http://10.0.0.1:5000"},
+						"docker.io":              {"https://docker-mirror.example.com"},
+					},
+				},
+			},
+			role: api.InstanceGroupRoleMaster,
+			expected: []connectivityCheck{
+				{Description: "container registry", Addresses: []string{"proxy.example.com:5000", "mirror.example.com:443", "10.0.0.1:5000"}, Optional: true},
+			},
+		},
+		{
+			name: "egress proxy",
+			spec: api.ClusterSpec{
+				MasterInternalName: "api.internal.example.com",
+				EgressProxy:        &api.EgressProxySpec{HTTPProxy: api.HTTPProxy{Host: "proxy.example.com", Port: 3128}},
+			},
+			role:   api.InstanceGroupRoleMaster,
+			assets: []string{"0123456789abcdef@https://storage.googleapis.com/kubelet"},
+			expected: []connectivityCheck{
+				{Description: "egress proxy", Addresses: []string{"proxy.example.com:3128"}},
+			},
+		},
+		{
+			name: "node on aws",
+			spec: api.ClusterSpec{
+				CloudProvider:      api.CloudProviderSpec{AWS: &api.AWSSpec{}},
+				MasterInternalName: "api.internal.example.com",
+				Assets:             &api.Assets{ContainerRegistry: fi.String("registry.example.com")},
+			},
+			role: api.InstanceGroupRoleNode,
+			expected: []connectivityCheck{
+				{Description: "container registry", Addresses: []string{"registry.example.com:443"}, Optional: true},
+				{Description: "API server", Addresses: []string{"api.internal.example.com:443"}},
+				{Description: "kops-controller", Addresses: []string{"kops-controller.internal.example.com:3988"}},
+			},
+		},
+		{
+			name: "node with gossip",
+			spec: api.ClusterSpec{
+				CloudProvider:      api.CloudProviderSpec{AWS: &api.AWSSpec{}},
+				MasterInternalName: "api.internal.example.k8s.local",
+			},
+			role: api.InstanceGroupRoleNode,
+			expected: []connectivityCheck{
+				{Description: "container registry", Addresses: []string{"registry.k8s.io:443"}, Optional: true},
+			},
+		},
+		{
+			name: "containerd registries",
+			spec: api.ClusterSpec{
+				MasterInternalName: "api.internal.example.com",
+				Containerd: &api.ContainerdConfig{
+					RegistryMirrors: map[string][]string{
+						"registry.k8s.io": {"https://mirror.example.com"},
+					},
+					Registries: map[string]api.ContainerdRegistryConfig{
+						"registry.k8s.io": {Mirrors: []api.ContainerdRegistryMirror{{Endpoint: "https://registry-mirror.example.com:8443"}}},
+						"*":               {Mirrors: []api.ContainerdRegistryMirror{{Endpoint: "http://10.0.0.1:5000"}}},
+						"docker.io":       {Mirrors: []api.ContainerdRegistryMirror{{Endpoint: "https://docker-mirror.example.com"}}},
+					},
+				},
+			},
+			role: api.InstanceGroupRoleMaster,
+			expected: []connectivityCheck{
+				{Description: "container registry", Addresses: []string{"registry.k8s.io:443", "mirror.example.com:443", "registry-mirror.example.com:8443", "10.0.0.1:5000"}, Optional: true},
+			},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			cluster := &api.Cluster{Spec: g.spec}
+			cluster.ObjectMeta.Name = "example.com"
+			bootConfig := &nodeup.BootConfig{InstanceGroupRole: g.role}
+
+			actual := buildConnectivityChecks(cluster, bootConfig, g.assets)
+			if !reflect.DeepEqual(actual, g.expected) {
+				t.Errorf("unexpected checks\nexpected: %+v\nactual:   %+v", g.expected, actual)
+			}
+		})
+	}
+}
+
+// timeoutError is a net.Error reporting a timeout, as returned by net.DialTimeout.
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestRunConnectivityChecks(t *testing.T) {
+	refused := &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}
+	timeout := &net.OpError{Op: "dial", Net: "tcp", Err: timeoutError{}}
+
+	// dial fails with the error configured for the address, and succeeds for the other addresses
+	dialErrors := map[string]error{
+		"refused.example.com:443": refused,
+		"timeout.example.com:443": timeout,
+	}
+	var dialed []string
+	dial := func(network, address string, timeout time.Duration) (net.Conn, error) {
+		dialed = append(dialed, address)
+		if network != "tcp" {
+			t.Errorf("unexpected network %q", network)
+		}
+		if timeout != connectivityCheckTimeout {
+			t.Errorf("unexpected timeout %v", timeout)
+		}
+		if err := dialErrors[address]; err != nil {
+			return nil, err
+		}
+		client, server := net.Pipe()
+		server.Close()
+		return client, nil
+	}
+
+	grid := []struct {
+		name           string
+		checks         []connectivityCheck
+		expectedDialed []string
+		expectedError  string
+		expectedOutput []string
+	}{
+		{
+			name: "success",
+			checks: []connectivityCheck{
+				{Description: "container registry", Addresses: []string{"registry.k8s.io:443"}},
+				{Description: "API server", Addresses: []string{"api.internal.example.com:443"}},
+			},
+			expectedDialed: []string{"registry.k8s.io:443", "api.internal.example.com:443"},
+		},
+		{
+			name: "fallback to second address",
+			checks: []connectivityCheck{
+				{Description: "container registry", Addresses: []string{"refused.example.com:443", "registry.k8s.io:443", "unused.example.com:443"}},
+			},
+			expectedDialed: []string{"refused.example.com:443", "registry.k8s.io:443"},
+		},
+		{
+			name: "timeout",
+			checks: []connectivityCheck{
+				{Description: "API server", Addresses: []string{"timeout.example.com:443"}},
+			},
+			expectedDialed: []string{"timeout.example.com:443"},
+			expectedError:  "instance cannot reach API server",
+			expectedOutput: []string{"cannot reach API server at timeout.example.com:443", "i/o timeout"},
+		},
+		{
+			name: "refused",
+			checks: []connectivityCheck{
+				{Description: "asset repository", Addresses: []string{"refused.example.com:443"}},
+				{Description: "kops-controller", Addresses: []string{"kops-controller.internal.example.com:3988"}},
+			},
+			expectedDialed: []string{"refused.example.com:443", "kops-controller.internal.example.com:3988"},
+			expectedError:  "instance cannot reach asset repository;",
+			expectedOutput: []string{"cannot reach asset repository at refused.example.com:443", "connection refused"},
+		},
+		{
+			name: "optional check fails",
+			checks: []connectivityCheck{
+				{Description: "container registry", Addresses: []string{"refused.example.com:443"}, Optional: true},
+				{Description: "kops-controller", Addresses: []string{"kops-controller.internal.example.com:3988"}},
+			},
+			expectedDialed: []string{"refused.example.com:443", "kops-controller.internal.example.com:3988"},
+			expectedOutput: []string{"connectivity check warning: cannot reach container registry at refused.example.com:443", "connection refused"},
+		},
+		{
+			name: "all addresses fail",
+			checks: []connectivityCheck{
+				{Description: "asset repository", Addresses: []string{"refused.example.com:443", "timeout.example.com:443"}},
+				{Description: "container registry", Addresses: []string{"timeout.example.com:443"}, Optional: true},
+				{Description: "API server", Addresses: []string{"timeout.example.com:443"}},
+			},
+			expectedDialed: []string{"refused.example.com:443", "timeout.example.com:443", "timeout.example.com:443", "timeout.example.com:443"},
+			expectedError:  "instance cannot reach asset repository, API server;",
+			expectedOutput: []string{"refused.example.com:443 or timeout.example.com:443: dial tcp: i/o timeout", "warning: cannot reach container registry"},
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			dialed = nil
+			var out bytes.Buffer
+
+			err := runConnectivityChecks(&out, g.checks, dial)
+			if g.expectedError == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				if len(g.expectedOutput) == 0 && out.Len() != 0 {
+					t.Errorf("unexpected output: %q", out.String())
+				}
+			} else if err == nil {
+				t.Errorf("expected error %q, got none", g.expectedError)
+			} else if !strings.Contains(err.Error(), g.expectedError) {
+				t.Errorf("expected error containing %q, got %q", g.expectedError, err.Error())
+			}
+			for _, s := range g.expectedOutput {
+				if !strings.Contains(out.String(), s) {
+					t.Errorf("expected output containing %q, got %q", s, out.String())
+				}
+			}
+			if !reflect.DeepEqual(dialed, g.expectedDialed) {
+				t.Errorf("expected to dial %v, dialed %v", g.expectedDialed, dialed)
+			}
+		})
+	}
+}
+
+func TestShouldRunConnectivityChecks(t *testing.T) {
+	kubeconfig := filepath.Join(t.TempDir(), "kubeconfig")
+
+	enabled := &api.Cluster{}
+	disabled := &api.Cluster{Spec: api.ClusterSpec{DisableConnectivityChecks: true}}
+
+	if !shouldRunConnectivityChecks(enabled, kubeconfig) {
+		t.Errorf("expected checks to run when configuring the instance for the first time")
+	}
+	if shouldRunConnectivityChecks(disabled, kubeconfig) {
+		t.Errorf("expected checks not to run when disabled in the cluster spec")
+	}
+
+	// The kubelet kubeconfig exists once nodeup has configured the instance
+	if err := os.WriteFile(kubeconfig, nil, 0o600); err != nil {
+		t.Fatalf("error writing %s: %v", kubeconfig, err)
+	}
+	if shouldRunConnectivityChecks(enabled, kubeconfig) {
+		t.Errorf("expected checks not to run when nodeup is rerun")
+	}
+}
+
+func TestHostPortFromURL(t *testing.T) {
+	grid := []struct {
+		input    string
+		expected string
+	}{
+		{input: "registry.k8s.io", expected: "registry.k8s.io:443"},
+		{input: "registry.example.com:5000", expected: "registry.example.com:5000"},
+		{input: "registry.example.com/k8s", expected: "registry.example.com:443"},
+		{input: "https://mirror.example.com", expected: "mirror.example.com:443"},
+		{input: "http://mirror.example.com", expected: "mirror.example.com:80"},
+		{input: "https://mirror.example.com:8443/path", expected: "mirror.example.com:8443"},
+		{input: "http://10.0.0.1:5000", expected: "10.0.0.1:5000"},
+		{input: "https://[2001:db8::1]/path", expected: "[2001:db8::1]:443"},
+		{input: "http://[2001:db8::1]:5000", expected: "[2001:db8::1]:5000"},
+		{input: "[2001:db8::1]:5000", expected: "[2001:db8::1]:5000"},
+		{input: "s3://bucket/path", expected: ""},
+		{input: "https://", expected: ""},
+		{input: "", expected: ""},
+	}
+
+	for _, g := range grid {
+		if actual := hostPortFromURL(g.input); actual != g.expected {
+			t.Errorf("hostPortFromURL(%q): expected %q, got %q", g.input, g.expected, actual)
+		}
+	}
+}
@@ -124,7 +124,9 @@ func (c *VFSCAStore) loadKeyset(p vfs.Path) (*Keyset, error) {
 	return keyset, nil
 }
 
-func (k *Keyset) ToAPIObject(name string) (*kops.Keyset, error) {
+// ToAPIObject converts the Keyset to its API form. Private keys are wrapped with the KMS key that wrapped them when they
+// were read, or otherwise with kmsKey; they are written in the clear when both are empty.
+func (k *Keyset) ToAPIObject(name string, kmsKey string) (*kops.Keyset, error) {
 	o := &kops.Keyset{}
 	o.Name = name
 	o.Spec.Type = kops.SecretTypeKeypair
@@ -144,8 +146,9 @@ func (k *Keyset) ToAPIObject(name string) (*kops.Keyset, error) {
 			distrustTimestamp = &metav1.Time{Time: *ki.DistrustTimestamp}
 		}
 		oki := kops.KeysetItem{
-			Id:                ki.Id,
-			DistrustTimestamp: distrustTimestamp,
+			Id:                    ki.Id,
+			DistrustTimestamp:     distrustTimestamp,
+			ExternalCertificateID: ki.ExternalCertificateID,
 		}
 
 		if ki.Certificate != nil {
@@ -157,12 +160,25 @@ func (k *Keyset) ToAPIObject(name string) (*kops.Keyset, error) {
 		}
 
 		if ki.PrivateKey != nil {
-			var privateMaterial bytes.Buffer
-			if _, err := ki.PrivateKey.WriteTo(&privateMaterial); err != nil {
-				return nil, err
+			wrapKey := ki.KMSKey
+			if wrapKey == "" {
+				wrapKey = kmsKey
 			}
 
-			oki.PrivateMaterial = privateMaterial.Bytes()
+			if wrapKey != "" {
+				privateMaterial, err := WrapPrivateKey(ki.PrivateKey, wrapKey)
+				if err != nil {
+					return nil, err
+				}
+				oki.PrivateMaterial = privateMaterial
+			} else {
+				var privateMaterial bytes.Buffer
+				if _, err := ki.PrivateKey.WriteTo(&privateMaterial); err != nil {
+					return nil, err
+				}
+
+				oki.PrivateMaterial = privateMaterial.Bytes()
+			}
 		}
 
 		o.Spec.Keys = append(o.Spec.Keys, oki)
@@ -170,6 +186,7 @@ func (k *Keyset) ToAPIObject(name string) (*kops.Keyset, error) {
 	if k.Primary != nil {
 		o.Spec.PrimaryID = k.Primary.Id
 	}
+	o.Spec.Rotation = k.Rotation.DeepCopy()
 	return o, nil
 }
 
@@ -177,7 +194,7 @@ func (k *Keyset) ToAPIObject(name string) (*kops.Keyset, error) {
 func writeKeysetBundle(cluster *kops.Cluster, p vfs.Path, name string, keyset *Keyset) error {
 	p = p.Join("keyset.yaml")
 
-	o, err := keyset.ToAPIObject(name)
+	o, err := keyset.ToAPIObject(name, keyStoreKMSKey(cluster))
 	if err != nil {
 		return err
 	}
@@ -194,6 +211,14 @@ func writeKeysetBundle(cluster *kops.Cluster, p vfs.Path, name string, keyset *K
 	return p.WriteFile(bytes.NewReader(objectData), acl)
 }
 
+// keyStoreKMSKey returns the KMS key with which the cluster's private keys are wrapped, if any.
+func keyStoreKMSKey(cluster *kops.Cluster) string {
+	if cluster == nil {
+		return ""
+	}
+	return cluster.Spec.KeyStoreKMSKey
+}
+
 // serializeKeysetBundle converts a Keyset bundle to yaml, for writing to VFS.
 func serializeKeysetBundle(o *kops.Keyset) ([]byte, error) {
 	var objectData bytes.Buffer
@@ -399,6 +424,28 @@ func (c *VFSCAStore) AddSSHPublicKey(pubkey []byte) error {
 		return fmt.Errorf("error fingerprinting SSH public key: %v", err)
 	}
 
+	// Record the current primary key before adding another one,
+	// so that adding a key never changes which key is primary.
+	primary, err := c.findPrimarySSHPublicKeyID()
+	if err != nil {
+		return err
+	}
+	if primary == "" {
+		keys, err := c.FindSSHPublicKeys()
+		if err != nil {
+			return err
+		}
+		if len(keys) != 0 {
+			existing, err := sshcredentials.Fingerprint(keys[0].Spec.PublicKey)
+			if err != nil {
+				return fmt.Errorf("error fingerprinting SSH public key: %v", err)
+			}
+			if err := c.SetPrimarySSHPublicKey(existing); err != nil {
+				return err
+			}
+		}
+	}
+
 	p := c.buildSSHPublicKeyPath(id)
 
 	acl, err := acls.GetACL(p, c.cluster)
@@ -409,12 +456,57 @@ func (c *VFSCAStore) AddSSHPublicKey(pubkey []byte) error {
 	return p.WriteFile(bytes.NewReader(pubkey), acl)
 }
 
+// SetPrimarySSHPublicKey marks the stored SSH public key with the specified fingerprint as the primary key
+func (c *VFSCAStore) SetPrimarySSHPublicKey(id string) error {
+	id = normalizeSSHPublicKeyID(id)
+
+	if _, err := c.buildSSHPublicKeyPath(id).ReadFile(); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("SSH public key %q not found", id)
+		}
+		return fmt.Errorf("error reading SSH public key %q: %v", id, err)
+	}
+
+	p := c.buildPrimarySSHPublicKeyPath()
+	acl, err := acls.GetACL(p, c.cluster)
+	if err != nil {
+		return err
+	}
+
+	return p.WriteFile(bytes.NewReader([]byte(id)), acl)
+}
+
+// findPrimarySSHPublicKeyID returns the fingerprint (without colons) of the primary SSH public key,
+// or an empty string if none was recorded.
+func (c *VFSCAStore) findPrimarySSHPublicKeyID() (string, error) {
+	data, err := c.buildPrimarySSHPublicKeyPath().ReadFile()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("error reading primary SSH public key: %v", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
 func (c *VFSCAStore) buildSSHPublicKeyPath(id string) vfs.Path {
+	return c.basedir.Join("ssh", "public", "admin", normalizeSSHPublicKeyID(id))
+}
+
+// buildPrimarySSHPublicKeyPath returns the path of the file recording the primary SSH public key.
+// It is kept outside of the directory holding the keys, so that older versions of kOps do not treat it as a key.
+func (c *VFSCAStore) buildPrimarySSHPublicKeyPath() vfs.Path {
+	return c.basedir.Join("ssh", "primary", "admin")
+}
+
+// normalizeSSHPublicKeyID converts an SSH public key fingerprint to the form used in the store.
+func normalizeSSHPublicKeyID(id string) string {
 	// id is fingerprint with colons, but we store without colons
-	id = strings.Replace(id, ":", "", -1)
-	return c.basedir.Join("ssh", "public", "admin", id)
+	return strings.Replace(id, ":", "", -1)
 }
 
+// FindSSHPublicKeys returns the stored SSH public keys. The primary key comes first,
+// followed by the other keys ordered by fingerprint.
 func (c *VFSCAStore) FindSSHPublicKeys() ([]*kops.SSHCredential, error) {
 	p := c.basedir.Join("ssh", "public", "admin")
 
@@ -426,6 +518,18 @@ func (c *VFSCAStore) FindSSHPublicKeys() ([]*kops.SSHCredential, error) {
 		return nil, err
 	}
 
+	primary, err := c.findPrimarySSHPublicKeyID()
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].Base() == primary || files[j].Base() == primary {
+			return files[i].Base() == primary
+		}
+		return files[i].Base() < files[j].Base()
+	})
+
 	var items []*kops.SSHCredential
 
 	for _, f := range files {
@@ -447,6 +551,43 @@ func (c *VFSCAStore) FindSSHPublicKeys() ([]*kops.SSHCredential, error) {
 	return items, nil
 }
 
+// DeleteSSHPublicKey deletes the stored SSH public key with the specified fingerprint.
+// The primary key can only be deleted if it is the only key.
+func (c *VFSCAStore) DeleteSSHPublicKey(id string) error {
+	id = normalizeSSHPublicKeyID(id)
+
+	keys, err := c.FindSSHPublicKeys()
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, key := range keys {
+		keyID, err := sshcredentials.Fingerprint(key.Spec.PublicKey)
+		if err != nil {
+			return fmt.Errorf("error fingerprinting SSH public key: %v", err)
+		}
+		if normalizeSSHPublicKeyID(keyID) == id {
+			found = true
+		}
+	}
+	if !found {
+		return fmt.Errorf("SSH public key %q not found", id)
+	}
+	if len(keys) == 1 {
+		return c.DeleteSSHCredential()
+	}
+
+	primary, err := c.findPrimarySSHPublicKeyID()
+	if err != nil {
+		return err
+	}
+	if primary == id {
+		return fmt.Errorf("cannot delete the primary SSH public key %q; make another key primary first", id)
+	}
+
+	return c.buildSSHPublicKeyPath(id).Remove()
+}
+
 func (c *VFSCAStore) DeleteSSHCredential() error {
 	p := c.basedir.Join("ssh", "public", "admin")
 
@@ -462,5 +603,9 @@ func (c *VFSCAStore) DeleteSSHCredential() error {
 			return err
 		}
 	}
+
+	if err := c.buildPrimarySSHPublicKeyPath().Remove(); err != nil && !os.IsNotExist(err) {
+		return err
+	}
 	return nil
 }
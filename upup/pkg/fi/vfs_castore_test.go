@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"k8s.io/kops/pkg/pki"
+	"k8s.io/kops/pkg/sshcredentials"
 	"k8s.io/kops/util/pkg/vfs"
 )
 
@@ -268,3 +269,74 @@ spec:
 		}
 	}
 }
+
+func TestVFSCAStoreSSHPublicKeys(t *testing.T) {
+	vfs.Context.ResetMemfsContext(true)
+
+	basePath, err := vfs.Context.BuildVfsPath("memfs://tests")
+	if err != nil {
+		t.Fatalf("error building vfspath: %v", err)
+	}
+
+	s := &VFSCAStore{
+		basedir: basePath,
+	}
+
+	rsaKey := "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQDF2sghZsClUBXJB4mBMIw8rb0hJWjg1Vz4eUeXwYmTdi92Gf1zNc5xISSip9Y+PWX/jJokPB7tgPnMD/2JOAKhG1bi4ZqB15pYRmbbBekVpM4o4E0dx+czbqjiAm6wlccTrINK5LYenbucAAQt19eH+D0gJwzYUK9SYz1hWnlGS+qurt2bz7rrsG73lN8E2eiNvGtIXqv3GabW/Hea3acOBgCUJQWUDTRu0OmmwxzKbFN/UpNKeRaHlCqwZWjVAsmqA8TX8LIocq7Np7MmIBwt7EpEeZJxThcmC8DEJs9ClAjD+jlLIvMPXKC3JWCPgwCLGxHjy7ckSGFCSzbyPduh"
+	ed25519Key := "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIBoB6Gtu8zPAPO1yF4OwysWUD8ZSEQYzMpOT0YvF9qJV user@example"
+
+	assertKeys := func(expected ...string) {
+		t.Helper()
+		keys, err := s.FindSSHPublicKeys()
+		if err != nil {
+			t.Fatalf("error listing SSH public keys: %v", err)
+		}
+		var actual []string
+		for _, key := range keys {
+			actual = append(actual, key.Spec.PublicKey)
+		}
+		if strings.Join(actual, "\n") != strings.Join(expected, "\n") {
+			t.Fatalf("unexpected SSH public keys; expected %q, got %q", expected, actual)
+		}
+	}
+
+	// The first key added stays primary when another key is added
+	for _, key := range []string{ed25519Key, rsaKey} {
+		if err := s.AddSSHPublicKey([]byte(key)); err != nil {
+			t.Fatalf("error adding SSH public key: %v", err)
+		}
+	}
+	assertKeys(ed25519Key, rsaKey)
+
+	rsaID, err := sshcredentials.Fingerprint(rsaKey)
+	if err != nil {
+		t.Fatalf("error fingerprinting SSH public key: %v", err)
+	}
+	ed25519ID, err := sshcredentials.Fingerprint(ed25519Key)
+	if err != nil {
+		t.Fatalf("error fingerprinting SSH public key: %v", err)
+	}
+
+	if err := s.SetPrimarySSHPublicKey(rsaID); err != nil {
+		t.Fatalf("error setting primary SSH public key: %v", err)
+	}
+	assertKeys(rsaKey, ed25519Key)
+
+	if err := s.SetPrimarySSHPublicKey("00:11:22"); err == nil {
+		t.Fatalf("expected error setting an unknown SSH public key as primary")
+	}
+
+	if err := s.DeleteSSHPublicKey(rsaID); err == nil {
+		t.Fatalf("expected error deleting the primary SSH public key")
+	}
+
+	if err := s.DeleteSSHPublicKey(ed25519ID); err != nil {
+		t.Fatalf("error deleting SSH public key: %v", err)
+	}
+	assertKeys(rsaKey)
+
+	if err := s.DeleteSSHPublicKey(rsaID); err != nil {
+		t.Fatalf("error deleting the last SSH public key: %v", err)
+	}
+	assertKeys()
+}
@@ -50,6 +50,7 @@ var (
 	DistributionRocky8       = Distribution{packageFormat: "rpm", project: "rocky", id: "rocky8", version: 8}
 	DistributionFlatcar      = Distribution{packageFormat: "", project: "flatcar", id: "flatcar", version: 0}
 	DistributionContainerOS  = Distribution{packageFormat: "", project: "containeros", id: "containeros", version: 0}
+	DistributionWindows      = Distribution{packageFormat: "", project: "windows", id: "windows", version: 0}
 )
 
 // IsDebianFamily returns true if this distribution uses deb packages and generally follows debian package names
@@ -69,7 +70,12 @@ func (d *Distribution) IsRHELFamily() bool {
 
 // IsSystemd returns true if this distribution uses systemd
 func (d *Distribution) IsSystemd() bool {
-	return true
+	return d.project != "windows"
+}
+
+// IsWindows returns true if this distribution is Windows
+func (d *Distribution) IsWindows() bool {
+	return d.project == "windows"
 }
 
 // DefaultUsers returns the name of the system users for this distribution
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"runtime"
 	"strings"
 
 	"k8s.io/klog/v2"
@@ -27,6 +28,11 @@ import (
 
 // FindDistribution identifies the distribution on which we are running
 func FindDistribution(rootfs string) (Distribution, error) {
+	// Windows doesn't have an /etc/os-release file
+	if runtime.GOOS == "windows" {
+		return DistributionWindows, nil
+	}
+
 	// All supported distros have an /etc/os-release file
 	osReleaseBytes, err := os.ReadFile(path.Join(rootfs, "etc/os-release"))
 	osRelease := make(map[string]string)
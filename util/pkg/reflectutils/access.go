@@ -23,7 +23,36 @@ import (
 	"strings"
 )
 
+// SetString sets the field at targetPath to newValue, converting it to the type of the field.
+// Comma-separated values are appended to slices, and comma-separated key=value pairs are added to maps.
+// The last element of targetPath can be the key of an entry of a map.
 func SetString(target interface{}, targetPath string, newValue string) error {
+	return updateField(target, targetPath, "set", func(v reflect.Value) error {
+		return setPrimitive(v, newValue)
+	})
+}
+
+// AppendString appends the comma-separated values to the slice at targetPath,
+// or adds the comma-separated key=value pairs to the map at targetPath.
+func AppendString(target interface{}, targetPath string, values string) error {
+	return updateField(target, targetPath, "append to", func(v reflect.Value) error {
+		if kind := indirectKind(v.Type()); kind != reflect.Slice && kind != reflect.Map {
+			return fmt.Errorf("unhandled type %q, must be a slice or map", v.Type())
+		}
+		return setPrimitive(v, values)
+	})
+}
+
+// RemoveString removes the comma-separated values from the slice at targetPath,
+// or the comma-separated keys from the map at targetPath.
+func RemoveString(target interface{}, targetPath string, values string) error {
+	return updateField(target, targetPath, "remove from", func(v reflect.Value) error {
+		return removePrimitive(v, values)
+	})
+}
+
+// updateField calls update with the field at targetPath, creating the structs and maps along the path
+func updateField(target interface{}, targetPath string, verb string, update func(v reflect.Value) error) error {
 	targetValue := reflect.ValueOf(target)
 
 	targetFieldPath, err := ParseFieldPath(targetPath)
@@ -40,21 +69,54 @@ func SetString(target interface{}, targetPath string, newValue string) error {
 
 		if targetFieldPath.Matches(path) {
 			if !v.CanSet() {
-				return fmt.Errorf("cannot set field %q (marked immutable)", path)
+				return fmt.Errorf("cannot %s field %q (marked immutable)", verb, path)
 			}
 
-			if err := setPrimitive(v, newValue); err != nil {
-				return fmt.Errorf("cannot set field %q: %v", path, err)
+			if err := update(v); err != nil {
+				return fmt.Errorf("cannot %s field %q: %v", verb, path, err)
 			}
 
 			fieldSet = true
-			return nil
+			return SkipReflection
+		}
+
+		// Partial match of the entry of a map, which we replace as map values are not addressable
+		if v.Kind() == reflect.Map && len(targetFieldPath.elements) == len(path.elements)+1 {
+			element := targetFieldPath.elements[len(path.elements)]
+			keyPath := path.Extend(element)
+			if element.Type != FieldPathElementTypeField && element.Type != FieldPathElementTypeMapKey {
+				return fmt.Errorf("cannot %s field %q: not a map key", verb, keyPath)
+			}
+			if !v.CanSet() {
+				return fmt.Errorf("cannot %s field %q (marked immutable)", verb, path)
+			}
+			if v.IsNil() {
+				v.Set(reflect.MakeMap(v.Type()))
+			}
+
+			key := reflect.ValueOf(element.token)
+			if !key.Type().ConvertibleTo(v.Type().Key()) {
+				return fmt.Errorf("cannot %s field %q: unhandled key type %q", verb, keyPath, v.Type().Key())
+			}
+			key = key.Convert(v.Type().Key())
+
+			entry := reflect.New(v.Type().Elem()).Elem()
+			if existing := v.MapIndex(key); existing.IsValid() {
+				entry.Set(existing)
+			}
+			if err := update(entry); err != nil {
+				return fmt.Errorf("cannot %s field %q: %v", verb, keyPath, err)
+			}
+			v.SetMapIndex(key, entry)
+
+			fieldSet = true
+			return SkipReflection
 		}
 
 		// Partial match, check for nil struct and auto-populate
 		if v.Kind() == reflect.Ptr && v.IsNil() {
 			if !v.CanSet() {
-				return fmt.Errorf("cannot set field %q (marked immutable)", path)
+				return fmt.Errorf("cannot %s field %q (marked immutable)", verb, path)
 			}
 
 			t := v.Type().String()
@@ -62,7 +124,7 @@ func SetString(target interface{}, targetPath string, newValue string) error {
 			var newV reflect.Value
 
 			switch v.Type().Elem().Kind() {
-			case reflect.Struct:
+			case reflect.Struct, reflect.Map:
 				newV = reflect.New(v.Type().Elem())
 
 			default:
@@ -89,6 +151,14 @@ func SetString(target interface{}, targetPath string, newValue string) error {
 	return nil
 }
 
+// indirectKind returns the kind of the type, or of the type it points to
+func indirectKind(t reflect.Type) reflect.Kind {
+	if t.Kind() == reflect.Ptr {
+		return t.Elem().Kind()
+	}
+	return t.Kind()
+}
+
 func setPrimitive(v reflect.Value, newValue string) error {
 	if !v.CanSet() {
 		return fmt.Errorf("cannot set value")
@@ -117,8 +187,34 @@ func setPrimitive(v reflect.Value, newValue string) error {
 		return nil
 	}
 
+	if v.Type().Kind() == reflect.Map {
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		for _, token := range strings.Split(newValue, ",") {
+			kv := strings.SplitN(token, "=", 2)
+			if len(kv) != 2 {
+				return fmt.Errorf("cannot interpret %q as key=value", token)
+			}
+			key := reflect.New(v.Type().Key())
+			if err := setPrimitive(key.Elem(), kv[0]); err != nil {
+				return err
+			}
+			value := reflect.New(v.Type().Elem())
+			if err := setPrimitive(value.Elem(), kv[1]); err != nil {
+				return err
+			}
+			v.SetMapIndex(key.Elem(), value.Elem())
+		}
+		return nil
+	}
+
 	if v.Type().Kind() == reflect.Ptr {
 		val := reflect.New(v.Type().Elem())
+		if v.Type().Elem().Kind() == reflect.Map && !v.IsNil() {
+			// Add to the existing map
+			val = v
+		}
 		if err := setPrimitive(val.Elem(), newValue); err != nil {
 			return err
 		}
@@ -174,6 +270,61 @@ func setPrimitive(v reflect.Value, newValue string) error {
 	return nil
 }
 
+// removePrimitive removes the comma-separated values from a slice, or the comma-separated keys from a map
+func removePrimitive(v reflect.Value, values string) error {
+	if v.Type().Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	tokens := strings.Split(values, ",")
+	switch v.Type().Kind() {
+	case reflect.Slice:
+		var remove []reflect.Value
+		for _, s := range tokens {
+			valueItem := reflect.New(v.Type().Elem())
+			if err := setPrimitive(valueItem.Elem(), s); err != nil {
+				return err
+			}
+			remove = append(remove, valueItem.Elem())
+		}
+
+		valueArray := reflect.MakeSlice(v.Type(), 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			removed := false
+			for _, r := range remove {
+				if reflect.DeepEqual(v.Index(i).Interface(), r.Interface()) {
+					removed = true
+					break
+				}
+			}
+			if !removed {
+				valueArray = reflect.Append(valueArray, v.Index(i))
+			}
+		}
+		if valueArray.Len() == 0 {
+			valueArray = reflect.Zero(v.Type())
+		}
+		v.Set(valueArray)
+		return nil
+
+	case reflect.Map:
+		for _, s := range tokens {
+			key := reflect.New(v.Type().Key())
+			if err := setPrimitive(key.Elem(), s); err != nil {
+				return err
+			}
+			v.SetMapIndex(key.Elem(), reflect.Value{})
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unhandled type %q, must be a slice or map", v.Type())
+	}
+}
+
 func Unset(target interface{}, targetPath string) error {
 	targetValue := reflect.ValueOf(target)
 
@@ -199,6 +350,23 @@ func Unset(target interface{}, targetPath string) error {
 			return nil
 		}
 
+		// Partial match of the entry of a map, which we remove
+		if v.Kind() == reflect.Map && len(targetFieldPath.elements) == len(path.elements)+1 {
+			element := targetFieldPath.elements[len(path.elements)]
+			if element.Type != FieldPathElementTypeField && element.Type != FieldPathElementTypeMapKey {
+				return nil
+			}
+			key := reflect.ValueOf(element.token)
+			if !key.Type().ConvertibleTo(v.Type().Key()) {
+				return fmt.Errorf("cannot unset field %q: unhandled key type %q", path.Extend(element), v.Type().Key())
+			}
+			if !v.IsNil() {
+				v.SetMapIndex(key.Convert(v.Type().Key()), reflect.Value{})
+			}
+			fieldUnset = true
+			return SkipReflection
+		}
+
 		return nil
 	}
 
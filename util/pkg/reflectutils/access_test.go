@@ -169,6 +169,27 @@ func TestSet(t *testing.T) {
 			Path:     "spec.containers[0].enumSlice",
 			Value:    "GHI,JKL",
 		},
+		{
+			Name:     "set map entry",
+			Input:    "{ 'spec': { 'containers': [ {} ] } }",
+			Expected: "{ 'spec': { 'containers': [ { 'resources': { 'limits': { 'cpu': 2 } } } ] } }",
+			Path:     "spec.containers[0].resources.limits.cpu",
+			Value:    "2",
+		},
+		{
+			Name:     "replace map entry",
+			Input:    "{ 'spec': { 'containers': [ { 'resources': { 'limits': { 'cpu': 1, 'memory': 3 } } } ] } }",
+			Expected: "{ 'spec': { 'containers': [ { 'resources': { 'limits': { 'cpu': 2, 'memory': 3 } } } ] } }",
+			Path:     "spec.containers[0].resources.limits[cpu]",
+			Value:    "2",
+		},
+		{
+			Name:     "add map entries",
+			Input:    "{ 'spec': { 'containers': [ { 'resources': { 'limits': { 'cpu': 1 } } } ] } }",
+			Expected: "{ 'spec': { 'containers': [ { 'resources': { 'limits': { 'cpu': 1, 'memory': 3, 'gpu': 4 } } } ] } }",
+			Path:     "spec.containers[0].resources.limits",
+			Value:    "memory=3,gpu=4",
+		},
 		// Not sure if we should do this...
 		// {
 		// 	Name:     "creating missing array elements",
@@ -207,6 +228,128 @@ func TestSet(t *testing.T) {
 	}
 }
 
+func TestAppend(t *testing.T) {
+	grid := []struct {
+		Name     string
+		Input    string
+		Expected string
+		Path     string
+		Value    string
+	}{
+		{
+			Name:     "append enum slice",
+			Input:    "{ 'spec': { 'containers': [ { 'enumSlice': [ 'ABC' ] } ] } }",
+			Expected: "{ 'spec': { 'containers': [ { 'enumSlice': [ 'ABC', 'DEF', 'GHI' ] } ] } }",
+			Path:     "spec.containers[0].enumSlice",
+			Value:    "DEF,GHI",
+		},
+		{
+			Name:     "add map entries",
+			Input:    "{ 'spec': { 'containers': [ {} ] } }",
+			Expected: "{ 'spec': { 'containers': [ { 'resources': { 'requests': { 'cpu': 1, 'memory': 2 } } } ] } }",
+			Path:     "spec.containers[0].resources.requests",
+			Value:    "cpu=1,memory=2",
+		},
+	}
+
+	for _, g := range grid {
+		g := g
+
+		t.Run(g.Name, func(t *testing.T) {
+			c := &fakeObject{}
+			if err := json.Unmarshal(toJSON(g.Input), c); err != nil {
+				t.Fatalf("failed to unmarshal input: %v", err)
+			}
+
+			if err := AppendString(c, g.Path, g.Value); err != nil {
+				t.Fatalf("error from AppendString: %v", err)
+			}
+
+			expected := &fakeObject{}
+			if err := json.Unmarshal(toJSON(g.Expected), expected); err != nil {
+				t.Fatalf("failed to unmarshal expected: %v", err)
+			}
+
+			if !reflect.DeepEqual(c, expected) {
+				t.Fatalf("comparison failed; expected %+v, was %+v", expected, c)
+			}
+		})
+	}
+
+	c := &fakeObject{}
+	if err := json.Unmarshal(toJSON("{ 'spec': { 'containers': [ {} ] } }"), c); err != nil {
+		t.Fatalf("failed to unmarshal input: %v", err)
+	}
+	err := AppendString(c, "spec.containers[0].image", "hello-world")
+	expectedError := `cannot append to field "spec.containers[0].image": unhandled type "string", must be a slice or map`
+	if err == nil || err.Error() != expectedError {
+		t.Fatalf("Expected Error: %s\n Actual Error: %v", expectedError, err)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	grid := []struct {
+		Name     string
+		Input    string
+		Expected string
+		Path     string
+		Value    string
+	}{
+		{
+			Name:     "remove from enum slice",
+			Input:    "{ 'spec': { 'containers': [ { 'enumSlice': [ 'ABC', 'DEF', 'GHI', 'DEF' ] } ] } }",
+			Expected: "{ 'spec': { 'containers': [ { 'enumSlice': [ 'GHI' ] } ] } }",
+			Path:     "spec.containers[0].enumSlice",
+			Value:    "ABC,DEF",
+		},
+		{
+			Name:     "remove all from enum slice",
+			Input:    "{ 'spec': { 'containers': [ { 'enumSlice': [ 'ABC' ] } ] } }",
+			Expected: "{ 'spec': { 'containers': [ {} ] } }",
+			Path:     "spec.containers[0].enumSlice",
+			Value:    "ABC",
+		},
+		{
+			Name:     "remove missing from enum slice",
+			Input:    "{ 'spec': { 'containers': [ {} ] } }",
+			Expected: "{ 'spec': { 'containers': [ {} ] } }",
+			Path:     "spec.containers[0].enumSlice",
+			Value:    "ABC",
+		},
+		{
+			Name:     "remove map entries",
+			Input:    "{ 'spec': { 'containers': [ { 'resources': { 'limits': { 'cpu': 1, 'memory': 2, 'gpu': 3 } } } ] } }",
+			Expected: "{ 'spec': { 'containers': [ { 'resources': { 'limits': { 'gpu': 3 } } } ] } }",
+			Path:     "spec.containers[0].resources.limits",
+			Value:    "cpu,memory",
+		},
+	}
+
+	for _, g := range grid {
+		g := g
+
+		t.Run(g.Name, func(t *testing.T) {
+			c := &fakeObject{}
+			if err := json.Unmarshal(toJSON(g.Input), c); err != nil {
+				t.Fatalf("failed to unmarshal input: %v", err)
+			}
+
+			if err := RemoveString(c, g.Path, g.Value); err != nil {
+				t.Fatalf("error from RemoveString: %v", err)
+			}
+
+			expected := &fakeObject{}
+			if err := json.Unmarshal(toJSON(g.Expected), expected); err != nil {
+				t.Fatalf("failed to unmarshal expected: %v", err)
+			}
+
+			if !reflect.DeepEqual(c, expected) {
+				t.Fatalf("comparison failed; expected %+v, was %+v", expected, c)
+			}
+		})
+	}
+}
+
 func TestSetInvalidPath(t *testing.T) {
 	grid := []struct {
 		Name          string
@@ -340,6 +483,12 @@ func TestUnset(t *testing.T) {
 			Expected: "{ 'spec': { 'containers': [ {} ] } }",
 			Path:     "spec.containers[0].enumSlice",
 		},
+		{
+			Name:     "unset map entry",
+			Input:    "{ 'spec': { 'containers': [ { 'resources': { 'limits': { 'cpu': 1, 'memory': 2 } } } ] } }",
+			Expected: "{ 'spec': { 'containers': [ { 'resources': { 'limits': { 'memory': 2 } } } ] } }",
+			Path:     "spec.containers[0].resources.limits.cpu",
+		},
 	}
 
 	for _, g := range grid {
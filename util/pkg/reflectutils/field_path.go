@@ -108,6 +108,23 @@ func ParseFieldPath(s string) (*FieldPath, error) {
 						Type: FieldPathElementTypeWildcardIndex,
 					})
 
+				case scanner.Ident:
+					elements = append(elements, FieldPathElement{
+						Type:  FieldPathElementTypeMapKey,
+						token: scan.TokenText(),
+					})
+
+				case scanner.String:
+					v := scan.TokenText()
+					key, err := strconv.Unquote(v)
+					if err != nil {
+						return nil, fmt.Errorf("cannot parse token %q as map-key", v)
+					}
+					elements = append(elements, FieldPathElement{
+						Type:  FieldPathElementTypeMapKey,
+						token: key,
+					})
+
 				case scanner.Int:
 					v := scan.TokenText()
 					n, err := strconv.Atoi(v)
@@ -25,6 +25,7 @@ func TestParseFieldPath(t *testing.T) {
 		{"Spec.Containers.Image"},
 		{"Spec.Containers[0].Image"},
 		{"Spec.Containers[*].Image"},
+		{"Spec.Labels[role]"},
 	}
 
 	for _, g := range grid {
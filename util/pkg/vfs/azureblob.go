@@ -26,6 +26,7 @@ import (
 	"path"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/Azure/azure-storage-blob-go/azblob"
 	"k8s.io/kops/util/pkg/hashing"
@@ -86,6 +87,12 @@ func (p *AzureBlobPath) Path() string {
 	return fmt.Sprintf("azureblob://%s/%s", p.container, p.key)
 }
 
+// GetHTTPsUrl returns the HTTPS URL of the blob.
+// The blob is only publicly readable if the container allows anonymous read access to blobs.
+func (p *AzureBlobPath) GetHTTPsUrl() string {
+	return strings.TrimSuffix(fmt.Sprintf("https://%s.blob.core.windows.net/%s/%s", p.client.accountName, p.container, p.key), "/")
+}
+
 // Join returns a new path that joins the current path and given relative paths.
 func (p *AzureBlobPath) Join(relativePath ...string) Path {
 	args := []string{p.key}
@@ -100,6 +107,8 @@ func (p *AzureBlobPath) Join(relativePath ...string) Path {
 
 // ReadFile returns the content of the blob.
 func (p *AzureBlobPath) ReadFile() ([]byte, error) {
+	defer recordOperation("azureblob", "ReadFile", p.Path(), time.Now())
+
 	var b bytes.Buffer
 	_, err := p.WriteTo(&b)
 	if err != nil {
@@ -158,6 +167,8 @@ func (p *AzureBlobPath) CreateFile(data io.ReadSeeker, acl ACL) error {
 //
 // TODO(kenji): Support ACL.
 func (p *AzureBlobPath) WriteFile(data io.ReadSeeker, acl ACL) error {
+	defer recordOperation("azureblob", "WriteFile", p.Path(), time.Now())
+
 	md5Hash, err := hashing.HashAlgorithmMD5.Hash(data)
 	if err != nil {
 		return err
@@ -191,6 +202,8 @@ func (p *AzureBlobPath) WriteFile(data io.ReadSeeker, acl ACL) error {
 
 // Remove deletes the blob.
 func (p *AzureBlobPath) Remove() error {
+	defer recordOperation("azureblob", "Remove", p.Path(), time.Now())
+
 	cURL, err := p.client.newContainerURL(p.container)
 	if err != nil {
 		return err
@@ -212,6 +225,8 @@ func (p *AzureBlobPath) RemoveAllVersions() error {
 
 // ReadDir lists the blobs under the current Path.
 func (p *AzureBlobPath) ReadDir() ([]Path, error) {
+	defer recordOperation("azureblob", "ReadDir", p.Path(), time.Now())
+
 	var paths []Path
 	cURL, err := p.client.newContainerURL(p.container)
 	if err != nil {
@@ -270,6 +285,8 @@ func (p *AzureBlobPath) ReadDir() ([]Path, error) {
 
 // ReadTree lists all blobs (recursively) in the subtree rooted at the current Path.
 func (p *AzureBlobPath) ReadTree() ([]Path, error) {
+	defer recordOperation("azureblob", "ReadTree", p.Path(), time.Now())
+
 	var paths []Path
 	cURL, err := p.client.newContainerURL(p.container)
 	if err != nil {
@@ -96,3 +96,11 @@ func TestAzureBlobPathJoin(t *testing.T) {
 		t.Errorf("expected %s, but got %s", e, a)
 	}
 }
+
+func TestAzureBlobPathGetHTTPsUrl(t *testing.T) {
+	client := &azureClient{accountName: "account"}
+	p := NewAzureBlobPath(client, "c", "/foo/bar/")
+	if a, e := p.GetHTTPsUrl(), "https://account.blob.core.windows.net/c/foo/bar"; a != e {
+		t.Errorf("expected %s, but got %s", e, a)
+	}
+}
@@ -154,6 +154,14 @@ func (c *VFSContext) BuildVfsPath(p string) (Path, error) {
 		return c.buildDOPath(p)
 	}
 
+	if strings.HasPrefix(p, "oci://") {
+		return c.buildS3EndpointPath(p, newOCIEndpoint)
+	}
+
+	if strings.HasPrefix(p, "radosgw://") {
+		return c.buildS3EndpointPath(p, newRadosGWEndpoint)
+	}
+
 	if strings.HasPrefix(p, "memfs://") {
 		return c.buildMemFSPath(p)
 	}
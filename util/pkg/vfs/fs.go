@@ -24,6 +24,7 @@ import (
 	"path"
 	"sync"
 	"syscall"
+	"time"
 
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/try"
@@ -51,6 +52,8 @@ func (p *FSPath) Join(relativePath ...string) Path {
 }
 
 func (p *FSPath) WriteFile(data io.ReadSeeker, acl ACL) error {
+	defer recordOperation("file", "WriteFile", p.Path(), time.Now())
+
 	dir := path.Dir(p.location)
 	err := os.MkdirAll(dir, 0o755)
 	if err != nil {
@@ -115,6 +118,8 @@ func (p *FSPath) CreateFile(data io.ReadSeeker, acl ACL) error {
 
 // ReadFile implements Path::ReadFile
 func (p *FSPath) ReadFile() ([]byte, error) {
+	defer recordOperation("file", "ReadFile", p.Path(), time.Now())
+
 	file, err := os.ReadFile(p.location)
 	if errors.Is(err, syscall.ENOENT) {
 		err = os.ErrNotExist
@@ -134,6 +139,8 @@ func (p *FSPath) WriteTo(out io.Writer) (int64, error) {
 }
 
 func (p *FSPath) ReadDir() ([]Path, error) {
+	defer recordOperation("file", "ReadDir", p.Path(), time.Now())
+
 	files, err := os.ReadDir(p.location)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -149,6 +156,8 @@ func (p *FSPath) ReadDir() ([]Path, error) {
 }
 
 func (p *FSPath) ReadTree() ([]Path, error) {
+	defer recordOperation("file", "ReadTree", p.Path(), time.Now())
+
 	var paths []Path
 	err := readTree(p.location, &paths)
 	if err != nil {
@@ -191,6 +200,8 @@ func (p *FSPath) String() string {
 }
 
 func (p *FSPath) Remove() error {
+	defer recordOperation("file", "Remove", p.Path(), time.Now())
+
 	return os.Remove(p.location)
 }
 
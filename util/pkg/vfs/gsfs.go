@@ -107,6 +107,23 @@ func (p *GSPath) Object() string {
 	return p.key
 }
 
+// GetHTTPsUrl returns the public HTTPS URL of the object.
+func (p *GSPath) GetHTTPsUrl() string {
+	return strings.TrimSuffix(fmt.Sprintf("https://storage.googleapis.com/%s/%s", p.bucket, p.key), "/")
+}
+
+// IsPublic returns true if the object is readable by all users.
+func (p *GSPath) IsPublic() (bool, error) {
+	acl, err := p.client.ObjectAccessControls.Get(p.bucket, p.key, "allUsers").Do()
+	if err != nil {
+		if isGCSNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("error reading ACL of %s: %v", p, err)
+	}
+	return acl.Role == "READER" || acl.Role == "OWNER", nil
+}
+
 // Client returns the storage.Service bound to this path
 func (p *GSPath) Client() *storage.Service {
 	return p.client
@@ -126,6 +143,8 @@ func (p *GSPath) TerraformProvider() (*TerraformProvider, error) {
 }
 
 func (p *GSPath) Remove() error {
+	defer recordOperation("gs", "Remove", p.Path(), time.Now())
+
 	done, err := RetryWithBackoff(gcsWriteBackoff, func() (bool, error) {
 		err := p.client.Objects.Delete(p.bucket, p.key).Do()
 		if err != nil {
@@ -161,7 +180,14 @@ func (p *GSPath) Join(relativePath ...string) Path {
 	}
 }
 
+// getGCSKMSKeyName returns the customer-managed KMS key that the objects of the state store are encrypted with, if set
+func getGCSKMSKeyName() string {
+	return strings.TrimSpace(os.Getenv("KOPS_STATE_GCS_KMS_KEY"))
+}
+
 func (p *GSPath) WriteFile(data io.ReadSeeker, acl ACL) error {
+	defer recordOperation("gs", "WriteFile", p.Path(), time.Now())
+
 	md5Hash, err := hashing.HashAlgorithmMD5.Hash(data)
 	if err != nil {
 		return err
@@ -188,7 +214,11 @@ func (p *GSPath) WriteFile(data io.ReadSeeker, acl ACL) error {
 			return false, fmt.Errorf("error seeking to start of data stream for write to %s: %v", p, err)
 		}
 
-		_, err = p.client.Objects.Insert(p.bucket, obj).Media(data).Do()
+		call := p.client.Objects.Insert(p.bucket, obj).Media(data)
+		if kmsKeyName := getGCSKMSKeyName(); kmsKeyName != "" {
+			call = call.KmsKeyName(kmsKeyName)
+		}
+		_, err = call.Do()
 		if err != nil {
 			return false, fmt.Errorf("error writing %s: %v", p, err)
 		}
@@ -230,6 +260,8 @@ func (p *GSPath) CreateFile(data io.ReadSeeker, acl ACL) error {
 
 // ReadFile implements Path::ReadFile
 func (p *GSPath) ReadFile() ([]byte, error) {
+	defer recordOperation("gs", "ReadFile", p.Path(), time.Now())
+
 	var b bytes.Buffer
 	done, err := RetryWithBackoff(gcsReadBackoff, func() (bool, error) {
 		b.Reset()
@@ -275,6 +307,8 @@ func (p *GSPath) WriteTo(out io.Writer) (int64, error) {
 
 // ReadDir implements Path::ReadDir
 func (p *GSPath) ReadDir() ([]Path, error) {
+	defer recordOperation("gs", "ReadDir", p.Path(), time.Now())
+
 	var ret []Path
 	done, err := RetryWithBackoff(gcsReadBackoff, func() (bool, error) {
 		prefix := p.key
@@ -318,6 +352,8 @@ func (p *GSPath) ReadDir() ([]Path, error) {
 
 // ReadTree implements Path::ReadTree
 func (p *GSPath) ReadTree() ([]Path, error) {
+	defer recordOperation("gs", "ReadTree", p.Path(), time.Now())
+
 	var ret []Path
 	done, err := RetryWithBackoff(gcsReadBackoff, func() (bool, error) {
 		// No delimiter for recursive search
@@ -22,6 +22,7 @@ import (
 	"io"
 	"path"
 	"strings"
+	"time"
 
 	"k8s.io/kops/util/pkg/hashing"
 )
@@ -67,6 +68,8 @@ func (p *KubernetesPath) String() string {
 }
 
 func (p *KubernetesPath) Remove() error {
+	defer recordOperation("k8s", "Remove", p.Path(), time.Now())
+
 	return fmt.Errorf("KubernetesPath::Remove not supported")
 }
 
@@ -86,6 +89,8 @@ func (p *KubernetesPath) Join(relativePath ...string) Path {
 }
 
 func (p *KubernetesPath) WriteFile(data io.ReadSeeker, acl ACL) error {
+	defer recordOperation("k8s", "WriteFile", p.Path(), time.Now())
+
 	return fmt.Errorf("KubernetesPath::WriteFile not supported")
 }
 
@@ -95,6 +100,8 @@ func (p *KubernetesPath) CreateFile(data io.ReadSeeker, acl ACL) error {
 
 // ReadFile implements Path::ReadFile
 func (p *KubernetesPath) ReadFile() ([]byte, error) {
+	defer recordOperation("k8s", "ReadFile", p.Path(), time.Now())
+
 	var b bytes.Buffer
 	_, err := p.WriteTo(&b)
 	if err != nil {
@@ -109,10 +116,14 @@ func (p *KubernetesPath) WriteTo(out io.Writer) (int64, error) {
 }
 
 func (p *KubernetesPath) ReadDir() ([]Path, error) {
+	defer recordOperation("k8s", "ReadDir", p.Path(), time.Now())
+
 	return nil, fmt.Errorf("KubernetesPath::ReadDir not supported")
 }
 
 func (p *KubernetesPath) ReadTree() ([]Path, error) {
+	defer recordOperation("k8s", "ReadTree", p.Path(), time.Now())
+
 	return nil, fmt.Errorf("KubernetesPath::ReadTree not supported")
 }
 
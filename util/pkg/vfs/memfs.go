@@ -23,6 +23,7 @@ import (
 	"path"
 	"strings"
 	"sync"
+	"time"
 
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
@@ -105,6 +106,8 @@ func (p *MemFSPath) Join(relativePath ...string) Path {
 }
 
 func (p *MemFSPath) WriteFile(r io.ReadSeeker, acl ACL) error {
+	defer recordOperation("memfs", "WriteFile", p.Path(), time.Now())
+
 	data, err := io.ReadAll(r)
 	if err != nil {
 		return fmt.Errorf("error reading data: %v", err)
@@ -125,6 +128,8 @@ func (p *MemFSPath) CreateFile(data io.ReadSeeker, acl ACL) error {
 
 // ReadFile implements Path::ReadFile
 func (p *MemFSPath) ReadFile() ([]byte, error) {
+	defer recordOperation("memfs", "ReadFile", p.Path(), time.Now())
+
 	if p.contents == nil {
 		return nil, os.ErrNotExist
 	}
@@ -142,6 +147,8 @@ func (p *MemFSPath) WriteTo(out io.Writer) (int64, error) {
 }
 
 func (p *MemFSPath) ReadDir() ([]Path, error) {
+	defer recordOperation("memfs", "ReadDir", p.Path(), time.Now())
+
 	p.mutex.Lock()
 	defer p.mutex.Unlock()
 
@@ -153,6 +160,8 @@ func (p *MemFSPath) ReadDir() ([]Path, error) {
 }
 
 func (p *MemFSPath) ReadTree() ([]Path, error) {
+	defer recordOperation("memfs", "ReadTree", p.Path(), time.Now())
+
 	var paths []Path
 	p.readTree(&paths)
 	return paths, nil
@@ -183,6 +192,8 @@ func (p *MemFSPath) String() string {
 }
 
 func (p *MemFSPath) Remove() error {
+	defer recordOperation("memfs", "Remove", p.Path(), time.Now())
+
 	p.contents = nil
 	return nil
 }
@@ -0,0 +1,115 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vfs
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// OperationStats holds the timings of one kind of VFS operation against one backend
+type OperationStats struct {
+	// Backend is the VFS implementation, for example "s3" or "gs"
+	Backend string
+	// Operation is the Path method, for example "ReadFile" or "ReadDir"
+	Operation string
+	// Count is the number of operations performed
+	Count int
+	// Total is the total time spent in the operations
+	Total time.Duration
+	// Max is the duration of the slowest operation
+	Max time.Duration
+}
+
+// Average returns the mean duration of the operations
+func (s *OperationStats) Average() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Total / time.Duration(s.Count)
+}
+
+type operationKey struct {
+	backend   string
+	operation string
+}
+
+var operationStats = struct {
+	mutex sync.Mutex
+	stats map[operationKey]*OperationStats
+}{
+	stats: make(map[operationKey]*OperationStats),
+}
+
+// recordOperation records the duration of a VFS operation that started at start.
+// It is intended to be deferred at the start of the operation.
+func recordOperation(backend string, operation string, path string, start time.Time) {
+	duration := time.Since(start)
+	klog.V(8).Infof("vfs %s %s %s took %v", backend, operation, path, duration)
+
+	operationStats.mutex.Lock()
+	defer operationStats.mutex.Unlock()
+
+	key := operationKey{backend: backend, operation: operation}
+	stats := operationStats.stats[key]
+	if stats == nil {
+		stats = &OperationStats{Backend: backend, Operation: operation}
+		operationStats.stats[key] = stats
+	}
+	stats.Count++
+	stats.Total += duration
+	if duration > stats.Max {
+		stats.Max = duration
+	}
+}
+
+// GetOperationStats returns the timings of the VFS operations performed so far, sorted by backend and operation
+func GetOperationStats() []OperationStats {
+	operationStats.mutex.Lock()
+	defer operationStats.mutex.Unlock()
+
+	var result []OperationStats
+	for _, stats := range operationStats.stats {
+		result = append(result, *stats)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Backend != result[j].Backend {
+			return result[i].Backend < result[j].Backend
+		}
+		return result[i].Operation < result[j].Operation
+	})
+	return result
+}
+
+// ResetOperationStats discards the timings recorded so far
+func ResetOperationStats() {
+	operationStats.mutex.Lock()
+	defer operationStats.mutex.Unlock()
+
+	operationStats.stats = make(map[operationKey]*OperationStats)
+}
+
+// LogOperationStats logs a summary of the VFS operations performed so far, to help tell whether
+// time is being spent talking to the state store or elsewhere
+func LogOperationStats() {
+	for _, stats := range GetOperationStats() {
+		klog.Infof("vfs %s %s: count=%d total=%v avg=%v max=%v", stats.Backend, stats.Operation, stats.Count, stats.Total, stats.Average(), stats.Max)
+	}
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vfs
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestOperationStats(t *testing.T) {
+	ResetOperationStats()
+
+	p := NewMemFSContext().root.Join("metrics", "file")
+	for i := 0; i < 2; i++ {
+		if err := p.WriteFile(bytes.NewReader([]byte("data")), nil); err != nil {
+			t.Fatalf("error writing file: %v", err)
+		}
+	}
+	if _, err := p.ReadFile(); err != nil {
+		t.Fatalf("error reading file: %v", err)
+	}
+
+	stats := GetOperationStats()
+	if len(stats) != 2 {
+		t.Fatalf("expected stats for 2 operations, got %+v", stats)
+	}
+	if stats[0].Backend != "memfs" || stats[0].Operation != "ReadFile" || stats[0].Count != 1 {
+		t.Errorf("unexpected ReadFile stats: %+v", stats[0])
+	}
+	if stats[1].Backend != "memfs" || stats[1].Operation != "WriteFile" || stats[1].Count != 2 {
+		t.Errorf("unexpected WriteFile stats: %+v", stats[1])
+	}
+	if stats[1].Max > stats[1].Total || stats[1].Average() > stats[1].Max {
+		t.Errorf("inconsistent WriteFile timings: %+v", stats[1])
+	}
+
+	ResetOperationStats()
+	if stats := GetOperationStats(); len(stats) != 0 {
+		t.Errorf("expected no stats after reset, got %+v", stats)
+	}
+}
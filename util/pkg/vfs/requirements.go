@@ -0,0 +1,179 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vfs
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	storage "google.golang.org/api/storage/v1"
+	"k8s.io/klog/v2"
+)
+
+// StateStoreRequirement is a property that the bucket of the state store must have.
+type StateStoreRequirement string
+
+const (
+	// RequireVersioning requires versioning to be enabled on the bucket
+	RequireVersioning StateStoreRequirement = "versioning"
+	// RequireObjectLock requires S3 Object Lock, or a GCS retention policy, on the bucket
+	RequireObjectLock StateStoreRequirement = "object-lock"
+	// RequireEncryption requires the bucket to encrypt objects by default with a KMS key (SSE-KMS on S3, CMEK on GCS)
+	RequireEncryption StateStoreRequirement = "encryption"
+)
+
+// StateStoreRequirements returns the requirements listed in KOPS_STATE_STORE_REQUIRE, separated by commas.
+func StateStoreRequirements() ([]StateStoreRequirement, error) {
+	var requirements []StateStoreRequirement
+	for _, s := range strings.Split(os.Getenv("KOPS_STATE_STORE_REQUIRE"), ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		requirement := StateStoreRequirement(s)
+		switch requirement {
+		case RequireVersioning, RequireObjectLock, RequireEncryption:
+			requirements = append(requirements, requirement)
+		default:
+			return nil, fmt.Errorf("unknown state store requirement %q in KOPS_STATE_STORE_REQUIRE; supported values are %q, %q and %q", s, RequireVersioning, RequireObjectLock, RequireEncryption)
+		}
+	}
+	return requirements, nil
+}
+
+// VerifyStateStoreRequirements checks that the bucket holding the path meets the requirements in KOPS_STATE_STORE_REQUIRE.
+func VerifyStateStoreRequirements(p Path) error {
+	requirements, err := StateStoreRequirements()
+	if err != nil {
+		return err
+	}
+	if len(requirements) == 0 {
+		return nil
+	}
+
+	var failures []string
+	switch p := p.(type) {
+	case *S3Path:
+		failures, err = p.unmetRequirements(requirements)
+	case *GSPath:
+		failures, err = p.unmetRequirements(requirements)
+	default:
+		return fmt.Errorf("KOPS_STATE_STORE_REQUIRE is only supported for S3 and GCS state stores, not %s", p)
+	}
+	if err != nil {
+		return err
+	}
+	if len(failures) != 0 {
+		return fmt.Errorf("state store %s does not meet the requirements of KOPS_STATE_STORE_REQUIRE: %s", p, strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+func (p *S3Path) unmetRequirements(requirements []StateStoreRequirement) ([]string, error) {
+	client, err := p.client()
+	if err != nil {
+		return nil, err
+	}
+
+	var failures []string
+	for _, requirement := range requirements {
+		klog.V(2).Infof("Checking S3 bucket %q for %s", p.bucket, requirement)
+		var failure string
+		switch requirement {
+		case RequireVersioning:
+			versioning, err := client.GetBucketVersioning(&s3.GetBucketVersioningInput{Bucket: aws.String(p.bucket)})
+			if err != nil {
+				return nil, fmt.Errorf("error reading versioning of bucket %q: %v", p.bucket, err)
+			}
+			failure = s3VersioningFailure(versioning)
+		case RequireObjectLock:
+			objectLock, err := client.GetObjectLockConfiguration(&s3.GetObjectLockConfigurationInput{Bucket: aws.String(p.bucket)})
+			if err != nil && AWSErrorCode(err) != "ObjectLockConfigurationNotFoundError" {
+				return nil, fmt.Errorf("error reading object lock configuration of bucket %q: %v", p.bucket, err)
+			}
+			failure = s3ObjectLockFailure(objectLock)
+		case RequireEncryption:
+			encryption, err := client.GetBucketEncryption(&s3.GetBucketEncryptionInput{Bucket: aws.String(p.bucket)})
+			if err != nil && AWSErrorCode(err) != "ServerSideEncryptionConfigurationNotFoundError" {
+				return nil, fmt.Errorf("error reading default encryption of bucket %q: %v", p.bucket, err)
+			}
+			failure = s3EncryptionFailure(encryption)
+		}
+		if failure != "" {
+			failures = append(failures, failure)
+		}
+	}
+	return failures, nil
+}
+
+func s3VersioningFailure(versioning *s3.GetBucketVersioningOutput) string {
+	if versioning == nil || aws.StringValue(versioning.Status) != s3.BucketVersioningStatusEnabled {
+		return "versioning is not enabled"
+	}
+	return ""
+}
+
+func s3ObjectLockFailure(objectLock *s3.GetObjectLockConfigurationOutput) string {
+	if objectLock == nil || objectLock.ObjectLockConfiguration == nil || aws.StringValue(objectLock.ObjectLockConfiguration.ObjectLockEnabled) != s3.ObjectLockEnabledEnabled {
+		return "object lock is not enabled"
+	}
+	return ""
+}
+
+func s3EncryptionFailure(encryption *s3.GetBucketEncryptionOutput) string {
+	if encryption != nil && encryption.ServerSideEncryptionConfiguration != nil {
+		for _, rule := range encryption.ServerSideEncryptionConfiguration.Rules {
+			if rule.ApplyServerSideEncryptionByDefault != nil && strings.HasPrefix(aws.StringValue(rule.ApplyServerSideEncryptionByDefault.SSEAlgorithm), s3.ServerSideEncryptionAwsKms) {
+				return ""
+			}
+		}
+	}
+	return "default encryption with a KMS key (SSE-KMS) is not enabled"
+}
+
+func (p *GSPath) unmetRequirements(requirements []StateStoreRequirement) ([]string, error) {
+	klog.V(2).Infof("Checking GCS bucket %q for %v", p.bucket, requirements)
+	bucket, err := p.client.Buckets.Get(p.bucket).Do()
+	if err != nil {
+		return nil, fmt.Errorf("error reading bucket %q: %v", p.bucket, err)
+	}
+	return gcsBucketFailures(bucket, requirements), nil
+}
+
+func gcsBucketFailures(bucket *storage.Bucket, requirements []StateStoreRequirement) []string {
+	var failures []string
+	for _, requirement := range requirements {
+		switch requirement {
+		case RequireVersioning:
+			if bucket.Versioning == nil || !bucket.Versioning.Enabled {
+				failures = append(failures, "versioning is not enabled")
+			}
+		case RequireObjectLock:
+			if bucket.RetentionPolicy == nil {
+				failures = append(failures, "no retention policy is set")
+			}
+		case RequireEncryption:
+			if bucket.Encryption == nil || bucket.Encryption.DefaultKmsKeyName == "" {
+				failures = append(failures, "no default customer-managed encryption key (CMEK) is set")
+			}
+		}
+	}
+	return failures
+}
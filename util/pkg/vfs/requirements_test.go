@@ -0,0 +1,115 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vfs
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	storage "google.golang.org/api/storage/v1"
+)
+
+func TestStateStoreRequirements(t *testing.T) {
+	t.Setenv("KOPS_STATE_STORE_REQUIRE", "")
+	requirements, err := StateStoreRequirements()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(requirements) != 0 {
+		t.Errorf("expected no requirements, got %v", requirements)
+	}
+
+	t.Setenv("KOPS_STATE_STORE_REQUIRE", "versioning, encryption,")
+	requirements, err = StateStoreRequirements()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []StateStoreRequirement{RequireVersioning, RequireEncryption}; !reflect.DeepEqual(requirements, expected) {
+		t.Errorf("expected requirements %v, got %v", expected, requirements)
+	}
+
+	t.Setenv("KOPS_STATE_STORE_REQUIRE", "replication")
+	if _, err := StateStoreRequirements(); err == nil {
+		t.Errorf("expected an error for an unknown requirement")
+	}
+}
+
+func TestVerifyStateStoreRequirementsUnsupported(t *testing.T) {
+	t.Setenv("KOPS_STATE_STORE_REQUIRE", "")
+	if err := VerifyStateStoreRequirements(NewFSPath(t.TempDir())); err != nil {
+		t.Errorf("unexpected error without requirements: %v", err)
+	}
+
+	t.Setenv("KOPS_STATE_STORE_REQUIRE", "versioning")
+	if err := VerifyStateStoreRequirements(NewFSPath(t.TempDir())); err == nil {
+		t.Errorf("expected an error for a local state store")
+	}
+}
+
+func TestS3RequirementFailures(t *testing.T) {
+	if failure := s3VersioningFailure(&s3.GetBucketVersioningOutput{Status: aws.String(s3.BucketVersioningStatusEnabled)}); failure != "" {
+		t.Errorf("unexpected failure for enabled versioning: %s", failure)
+	}
+	if failure := s3VersioningFailure(&s3.GetBucketVersioningOutput{Status: aws.String(s3.BucketVersioningStatusSuspended)}); failure == "" {
+		t.Errorf("expected a failure for suspended versioning")
+	}
+
+	objectLock := &s3.GetObjectLockConfigurationOutput{
+		ObjectLockConfiguration: &s3.ObjectLockConfiguration{ObjectLockEnabled: aws.String(s3.ObjectLockEnabledEnabled)},
+	}
+	if failure := s3ObjectLockFailure(objectLock); failure != "" {
+		t.Errorf("unexpected failure for enabled object lock: %s", failure)
+	}
+	if failure := s3ObjectLockFailure(nil); failure == "" {
+		t.Errorf("expected a failure without object lock configuration")
+	}
+
+	encryption := func(algorithm string) *s3.GetBucketEncryptionOutput {
+		return &s3.GetBucketEncryptionOutput{
+			ServerSideEncryptionConfiguration: &s3.ServerSideEncryptionConfiguration{
+				Rules: []*s3.ServerSideEncryptionRule{
+					{ApplyServerSideEncryptionByDefault: &s3.ServerSideEncryptionByDefault{SSEAlgorithm: aws.String(algorithm)}},
+				},
+			},
+		}
+	}
+	if failure := s3EncryptionFailure(encryption(s3.ServerSideEncryptionAwsKms)); failure != "" {
+		t.Errorf("unexpected failure for SSE-KMS: %s", failure)
+	}
+	if failure := s3EncryptionFailure(encryption(s3.ServerSideEncryptionAes256)); failure == "" {
+		t.Errorf("expected a failure for SSE-S3")
+	}
+}
+
+func TestGCSBucketFailures(t *testing.T) {
+	requirements := []StateStoreRequirement{RequireVersioning, RequireObjectLock, RequireEncryption}
+
+	if failures := gcsBucketFailures(&storage.Bucket{}, requirements); len(failures) != 3 {
+		t.Errorf("expected 3 failures for a bucket without protection, got %v", failures)
+	}
+
+	bucket := &storage.Bucket{
+		Versioning:      &storage.BucketVersioning{Enabled: true},
+		RetentionPolicy: &storage.BucketRetentionPolicy{RetentionPeriod: 86400},
+		Encryption:      &storage.BucketEncryption{DefaultKmsKeyName: "projects/p/locations/global/keyRings/r/cryptoKeys/k"},
+	}
+	if failures := gcsBucketFailures(bucket, requirements); len(failures) != 0 {
+		t.Errorf("unexpected failures %v", failures)
+	}
+}
@@ -28,6 +28,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
 	"github.com/aws/aws-sdk-go/aws/ec2metadata"
 	"github.com/aws/aws-sdk-go/aws/endpoints"
 	"github.com/aws/aws-sdk-go/aws/session"
@@ -64,12 +65,16 @@ type S3Context struct {
 	mutex         sync.Mutex
 	clients       map[string]*s3.S3
 	bucketDetails map[string]*S3BucketDetails
+
+	// endpointClients are the clients of the S3 compatible services, keyed by endpoint URL
+	endpointClients map[string]*s3.S3
 }
 
 func NewS3Context() *S3Context {
 	return &S3Context{
-		clients:       make(map[string]*s3.S3),
-		bucketDetails: make(map[string]*S3BucketDetails),
+		clients:         make(map[string]*s3.S3),
+		bucketDetails:   make(map[string]*S3BucketDetails),
+		endpointClients: make(map[string]*s3.S3),
 	}
 }
 
@@ -101,6 +106,18 @@ func (s *S3Context) getClient(region string) (*s3.S3, error) {
 		if err != nil {
 			return nil, fmt.Errorf("error starting new AWS session: %v", err)
 		}
+
+		// Assume a role, typically to access buckets in another account
+		if roleARN := os.Getenv("S3_ROLE_ARN"); roleARN != "" && endpoint == "" {
+			klog.Infof("Found S3_ROLE_ARN=%q, assuming role to access S3", roleARN)
+			externalID := os.Getenv("S3_ROLE_EXTERNAL_ID")
+			config = config.Copy().WithCredentials(stscreds.NewCredentials(sess, roleARN, func(p *stscreds.AssumeRoleProvider) {
+				if externalID != "" {
+					p.ExternalID = aws.String(externalID)
+				}
+			}))
+		}
+
 		s3Client = s3.New(sess, config)
 		s.clients[region] = s3Client
 	}
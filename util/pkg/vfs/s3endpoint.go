@@ -0,0 +1,158 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vfs
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"k8s.io/klog/v2"
+)
+
+// S3EndpointEnvVars are the env variables configuring access to the S3 compatible services, which are passed on to the nodes
+var S3EndpointEnvVars = []string{
+	"OCI_ACCESS_KEY_ID", "OCI_SECRET_ACCESS_KEY",
+	"RADOSGW_ACCESS_KEY_ID", "RADOSGW_SECRET_ACCESS_KEY", "RADOSGW_REGION", "RADOSGW_CA_BUNDLE",
+}
+
+// s3Endpoint is an S3 compatible service other than AWS S3.
+// Its paths name the service in the host, followed by the bucket: <scheme>://<host>/<bucket>/<key>
+type s3Endpoint struct {
+	// host is the host of the paths of the service
+	host string
+	// url is the URL of the S3 API of the service
+	url string
+	// region is the region that requests are signed for
+	region string
+
+	// accessKeyIDEnv and secretAccessKeyEnv are the env variables holding the credentials for the service
+	accessKeyIDEnv     string
+	secretAccessKeyEnv string
+	// caBundleEnv is the env variable holding the path of the CA bundle that the service's certificate is verified with, if set
+	caBundleEnv string
+}
+
+// newOCIEndpoint returns the S3 compatibility API of Oracle Cloud Object Storage, for hosts of the form <namespace>@<region>
+func newOCIEndpoint(u *url.URL) (*s3Endpoint, error) {
+	namespace := u.User.Username()
+	region := u.Hostname()
+	if namespace == "" || region == "" {
+		return nil, fmt.Errorf("invalid oci path: %q; expected oci://<namespace>@<region>/<bucket>/<key>", u)
+	}
+	return &s3Endpoint{
+		host:               namespace + "@" + region,
+		url:                "https://" + namespace + ".compat.objectstorage." + region + ".oraclecloud.com",
+		region:             region,
+		accessKeyIDEnv:     "OCI_ACCESS_KEY_ID",
+		secretAccessKeyEnv: "OCI_SECRET_ACCESS_KEY",
+	}, nil
+}
+
+// newRadosGWEndpoint returns the S3 API of a Ceph RADOS Gateway, for hosts of the form <host>[:<port>]
+func newRadosGWEndpoint(u *url.URL) (*s3Endpoint, error) {
+	if u.Host == "" || u.User != nil {
+		return nil, fmt.Errorf("invalid radosgw path: %q; expected radosgw://<host>[:<port>]/<bucket>/<key>", u)
+	}
+	region := os.Getenv("RADOSGW_REGION")
+	if region == "" {
+		region = "us-east-1"
+	}
+	return &s3Endpoint{
+		host:               u.Host,
+		url:                "https://" + u.Host,
+		region:             region,
+		accessKeyIDEnv:     "RADOSGW_ACCESS_KEY_ID",
+		secretAccessKeyEnv: "RADOSGW_SECRET_ACCESS_KEY",
+		caBundleEnv:        "RADOSGW_CA_BUNDLE",
+	}, nil
+}
+
+// buildS3EndpointPath builds a path on an S3 compatible service, from a path of the form <scheme>://<host>/<bucket>/<key>
+func (c *VFSContext) buildS3EndpointPath(p string, newEndpoint func(u *url.URL) (*s3Endpoint, error)) (*S3Path, error) {
+	u, err := url.Parse(p)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path: %q", p)
+	}
+
+	endpoint, err := newEndpoint(u)
+	if err != nil {
+		return nil, err
+	}
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(u.Path, "/"), "/")
+	if bucket == "" {
+		return nil, fmt.Errorf("invalid %s path: %q; the bucket is required", u.Scheme, p)
+	}
+
+	s3path := newS3Path(c.s3Context, u.Scheme, bucket, key, false)
+	s3path.endpoint = endpoint
+	return s3path, nil
+}
+
+// getEndpointClient returns the client for an S3 compatible service.
+// The requests use path-style addressing, which these services support regardless of their DNS configuration.
+func (s *S3Context) getEndpointClient(endpoint *s3Endpoint) (*s3.S3, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if client := s.endpointClients[endpoint.url]; client != nil {
+		return client, nil
+	}
+
+	accessKeyID := os.Getenv(endpoint.accessKeyIDEnv)
+	secretAccessKey := os.Getenv(endpoint.secretAccessKeyEnv)
+	if accessKeyID == "" || secretAccessKey == "" {
+		return nil, fmt.Errorf("%s and %s must be set to access %s", endpoint.accessKeyIDEnv, endpoint.secretAccessKeyEnv, endpoint.url)
+	}
+
+	config := &aws.Config{
+		Credentials:      credentials.NewStaticCredentials(accessKeyID, secretAccessKey, ""),
+		Endpoint:         aws.String(endpoint.url),
+		Region:           aws.String(endpoint.region),
+		S3ForcePathStyle: aws.Bool(true),
+	}
+	config = config.WithCredentialsChainVerboseErrors(true)
+
+	options := session.Options{
+		Config: *config,
+	}
+	if endpoint.caBundleEnv != "" {
+		if caBundle := os.Getenv(endpoint.caBundleEnv); caBundle != "" {
+			f, err := os.Open(caBundle)
+			if err != nil {
+				return nil, fmt.Errorf("error reading %s=%q: %v", endpoint.caBundleEnv, caBundle, err)
+			}
+			defer f.Close()
+			options.CustomCABundle = f
+		}
+	}
+
+	klog.V(2).Infof("Using S3 compatible endpoint %q", endpoint.url)
+	sess, err := session.NewSessionWithOptions(options)
+	if err != nil {
+		return nil, fmt.Errorf("error starting new AWS session: %v", err)
+	}
+	client := s3.New(sess, config)
+	s.endpointClients[endpoint.url] = client
+	return client, nil
+}
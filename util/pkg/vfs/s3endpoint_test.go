@@ -0,0 +1,113 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vfs
+
+import "testing"
+
+func Test_S3EndpointPath_Parse(t *testing.T) {
+	grid := []struct {
+		Input          string
+		ExpectError    bool
+		ExpectedBucket string
+		ExpectedPath   string
+		ExpectedURL    string
+		ExpectedRegion string
+	}{
+		{
+			Input:          "oci://mynamespace@us-ashburn-1/bucket/path/subpath",
+			ExpectedBucket: "bucket",
+			ExpectedPath:   "path/subpath",
+			ExpectedURL:    "https://mynamespace.compat.objectstorage.us-ashburn-1.oraclecloud.com",
+			ExpectedRegion: "us-ashburn-1",
+		},
+		{
+			Input:          "oci://mynamespace@us-ashburn-1/bucket",
+			ExpectedBucket: "bucket",
+			ExpectedPath:   "",
+			ExpectedURL:    "https://mynamespace.compat.objectstorage.us-ashburn-1.oraclecloud.com",
+			ExpectedRegion: "us-ashburn-1",
+		},
+		{
+			Input:       "oci://us-ashburn-1/bucket/path",
+			ExpectError: true,
+		},
+		{
+			Input:       "oci://mynamespace@us-ashburn-1/",
+			ExpectError: true,
+		},
+		{
+			Input:          "radosgw://rgw.example.com:7480/bucket/path",
+			ExpectedBucket: "bucket",
+			ExpectedPath:   "path",
+			ExpectedURL:    "https://rgw.example.com:7480",
+			ExpectedRegion: "us-east-1",
+		},
+		{
+			Input:       "radosgw:///bucket/path",
+			ExpectError: true,
+		},
+	}
+	for _, g := range grid {
+		p, err := Context.BuildVfsPath(g.Input)
+		if g.ExpectError {
+			if err == nil {
+				t.Errorf("expected error parsing %q", g.Input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("unexpected error parsing %q: %v", g.Input, err)
+		}
+		s3path, ok := p.(*S3Path)
+		if !ok {
+			t.Fatalf("unexpected path type %T for %q", p, g.Input)
+		}
+		if s3path.bucket != g.ExpectedBucket || s3path.key != g.ExpectedPath {
+			t.Errorf("unexpected bucket %q and key %q for %q", s3path.bucket, s3path.key, g.Input)
+		}
+		if s3path.endpoint.url != g.ExpectedURL || s3path.endpoint.region != g.ExpectedRegion {
+			t.Errorf("unexpected endpoint %+v for %q", s3path.endpoint, g.Input)
+		}
+		if s3path.sse {
+			t.Errorf("unexpected server side encryption for %q", g.Input)
+		}
+
+		// The path must round-trip, as it is stored in the cluster spec
+		child := p.Join("child")
+		reparsed, err := Context.BuildVfsPath(child.Path())
+		if err != nil {
+			t.Fatalf("unexpected error parsing %q: %v", child.Path(), err)
+		}
+		if reparsed.Path() != child.Path() || reparsed.(*S3Path).endpoint.url != g.ExpectedURL {
+			t.Errorf("path %q did not round-trip: %q", child.Path(), reparsed.Path())
+		}
+	}
+}
+
+func Test_S3EndpointPath_GetHTTPsUrl(t *testing.T) {
+	p, err := Context.BuildVfsPath("radosgw://rgw.example.com/bucket/cluster/file")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	url, err := p.(*S3Path).GetHTTPsUrl(false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := "https://rgw.example.com/bucket/cluster/file"; url != expected {
+		t.Errorf("expected %q, got %q", expected, url)
+	}
+}
@@ -23,8 +23,10 @@ import (
 	"io"
 	"os"
 	"path"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
@@ -47,6 +49,8 @@ type S3Path struct {
 	scheme string
 	// sse specifies if server side encryption should be enabled
 	sse bool
+	// endpoint is the S3 compatible service holding the bucket, if it is not AWS S3
+	endpoint *s3Endpoint
 }
 
 var (
@@ -74,6 +78,9 @@ func newS3Path(s3Context *S3Context, scheme string, bucket string, key string, s
 }
 
 func (p *S3Path) Path() string {
+	if p.endpoint != nil {
+		return p.scheme + "://" + p.endpoint.host + "/" + p.bucket + "/" + p.key
+	}
 	return p.scheme + "://" + p.bucket + "/" + p.key
 }
 
@@ -91,6 +98,9 @@ func (p *S3Path) String() string {
 
 // TerraformProvider returns the provider name and necessary arguments
 func (p *S3Path) TerraformProvider() (*TerraformProvider, error) {
+	if p.endpoint != nil {
+		return nil, fmt.Errorf("terraform is not supported for %s paths", p.scheme)
+	}
 	if err := p.ensureBucketDetails(); err != nil {
 		return nil, err
 	}
@@ -105,6 +115,8 @@ func (p *S3Path) TerraformProvider() (*TerraformProvider, error) {
 }
 
 func (p *S3Path) Remove() error {
+	defer recordOperation("s3", "Remove", p.Path(), time.Now())
+
 	client, err := p.client()
 	if err != nil {
 		return err
@@ -207,6 +219,7 @@ func (p *S3Path) Join(relativePath ...string) Path {
 		key:       joined,
 		scheme:    p.scheme,
 		sse:       p.sse,
+		endpoint:  p.endpoint,
 	}
 }
 
@@ -217,6 +230,10 @@ func (p *S3Path) getServerSideEncryption() (sse *string, sseLog string, err erro
 	// instead, we honor that - it is likely to be a higher encryption
 	// standard.
 	sseLog = "-"
+	if p.sse && getS3KMSKeyID() != "" {
+		// A KMS key configured for the state store takes precedence over the default encryption of the bucket
+		return aws.String(s3.ServerSideEncryptionAwsKms), s3.ServerSideEncryptionAwsKms, nil
+	}
 	if p.sse {
 		err := p.ensureBucketDetails()
 		if err != nil {
@@ -234,6 +251,22 @@ func (p *S3Path) getServerSideEncryption() (sse *string, sseLog string, err erro
 	return sse, sseLog, nil
 }
 
+// getS3KMSKeyID returns the KMS key that the objects of the state store are encrypted with, if set
+func getS3KMSKeyID() string {
+	return strings.TrimSpace(os.Getenv("KOPS_STATE_S3_SSE_KMS_KEY_ID"))
+}
+
+// getS3BucketKeyEnabled returns whether S3 Bucket Keys are used for the objects encrypted with KMS, if set
+func getS3BucketKeyEnabled() *bool {
+	if getS3KMSKeyID() == "" {
+		return nil
+	}
+	if enabled, err := strconv.ParseBool(os.Getenv("KOPS_STATE_S3_SSE_BUCKET_KEY")); err == nil {
+		return aws.Bool(enabled)
+	}
+	return nil
+}
+
 func (p *S3Path) getRequestACL(aclObj ACL) (*string, error) {
 	acl := os.Getenv("KOPS_STATE_S3_ACL")
 	acl = strings.TrimSpace(acl)
@@ -251,6 +284,8 @@ func (p *S3Path) getRequestACL(aclObj ACL) (*string, error) {
 }
 
 func (p *S3Path) WriteFile(data io.ReadSeeker, aclObj ACL) error {
+	defer recordOperation("s3", "WriteFile", p.Path(), time.Now())
+
 	client, err := p.client()
 	if err != nil {
 		return err
@@ -265,6 +300,10 @@ func (p *S3Path) WriteFile(data io.ReadSeeker, aclObj ACL) error {
 
 	var sseLog string
 	request.ServerSideEncryption, sseLog, _ = p.getServerSideEncryption()
+	if aws.StringValue(request.ServerSideEncryption) == s3.ServerSideEncryptionAwsKms {
+		request.SSEKMSKeyId = aws.String(getS3KMSKeyID())
+		request.BucketKeyEnabled = getS3BucketKeyEnabled()
+	}
 
 	request.ACL, err = p.getRequestACL(aclObj)
 	if err != nil {
@@ -311,6 +350,8 @@ func (p *S3Path) CreateFile(data io.ReadSeeker, acl ACL) error {
 
 // ReadFile implements Path::ReadFile
 func (p *S3Path) ReadFile() ([]byte, error) {
+	defer recordOperation("s3", "ReadFile", p.Path(), time.Now())
+
 	var b bytes.Buffer
 	_, err := p.WriteTo(&b)
 	if err != nil {
@@ -349,6 +390,8 @@ func (p *S3Path) WriteTo(out io.Writer) (int64, error) {
 }
 
 func (p *S3Path) ReadDir() ([]Path, error) {
+	defer recordOperation("s3", "ReadDir", p.Path(), time.Now())
+
 	client, err := p.client()
 	if err != nil {
 		return nil, err
@@ -383,6 +426,7 @@ func (p *S3Path) ReadDir() ([]Path, error) {
 				etag:      o.ETag,
 				scheme:    p.scheme,
 				sse:       p.sse,
+				endpoint:  p.endpoint,
 			}
 			paths = append(paths, child)
 		}
@@ -396,6 +440,8 @@ func (p *S3Path) ReadDir() ([]Path, error) {
 }
 
 func (p *S3Path) ReadTree() ([]Path, error) {
+	defer recordOperation("s3", "ReadTree", p.Path(), time.Now())
+
 	client, err := p.client()
 	if err != nil {
 		return nil, err
@@ -421,6 +467,7 @@ func (p *S3Path) ReadTree() ([]Path, error) {
 				etag:      o.ETag,
 				scheme:    p.scheme,
 				sse:       p.sse,
+				endpoint:  p.endpoint,
 			}
 			paths = append(paths, child)
 		}
@@ -446,6 +493,10 @@ func (p *S3Path) ensureBucketDetails() error {
 }
 
 func (p *S3Path) client() (*s3.S3, error) {
+	if p.endpoint != nil {
+		return p.s3Context.getEndpointClient(p.endpoint)
+	}
+
 	err := p.ensureBucketDetails()
 	if err != nil {
 		return nil, err
@@ -487,6 +538,9 @@ func (p *S3Path) Hash(a hashing.HashAlgorithm) (*hashing.Hash, error) {
 }
 
 func (p *S3Path) GetHTTPsUrl(dualstack bool) (string, error) {
+	if p.endpoint != nil {
+		return p.endpoint.url + "/" + p.bucket + "/" + p.Key(), nil
+	}
 	if p.bucketDetails == nil {
 		bucketDetails, err := p.s3Context.getDetailsForBucket(p.bucket)
 		if err != nil {
@@ -525,12 +579,14 @@ func (p *S3Path) IsPublic() (bool, error) {
 }
 
 type terraformS3File struct {
-	Bucket   string                   `json:"bucket" cty:"bucket"`
-	Key      string                   `json:"key" cty:"key"`
-	Content  *terraformWriter.Literal `json:"content,omitempty" cty:"content"`
-	Acl      *string                  `json:"acl,omitempty" cty:"acl"`
-	SSE      *string                  `json:"server_side_encryption,omitempty" cty:"server_side_encryption"`
-	Provider *terraformWriter.Literal `json:"provider,omitempty" cty:"provider"`
+	Bucket           string                   `json:"bucket" cty:"bucket"`
+	Key              string                   `json:"key" cty:"key"`
+	Content          *terraformWriter.Literal `json:"content,omitempty" cty:"content"`
+	Acl              *string                  `json:"acl,omitempty" cty:"acl"`
+	SSE              *string                  `json:"server_side_encryption,omitempty" cty:"server_side_encryption"`
+	KMSKeyID         *string                  `json:"kms_key_id,omitempty" cty:"kms_key_id"`
+	BucketKeyEnabled *bool                    `json:"bucket_key_enabled,omitempty" cty:"bucket_key_enabled"`
+	Provider         *terraformWriter.Literal `json:"provider,omitempty" cty:"provider"`
 }
 
 func (p *S3Path) RenderTerraform(w *terraformWriter.TerraformWriter, name string, data io.Reader, acl ACL) error {
@@ -562,6 +618,10 @@ func (p *S3Path) RenderTerraform(w *terraformWriter.TerraformWriter, name string
 		Acl:      requestACL,
 		Provider: terraformWriter.LiteralTokens("aws", "files"),
 	}
+	if aws.StringValue(sse) == s3.ServerSideEncryptionAwsKms {
+		tf.KMSKeyID = aws.String(getS3KMSKeyID())
+		tf.BucketKeyEnabled = getS3BucketKeyEnabled()
+	}
 	return w.RenderResource("aws_s3_object", name, tf)
 }
 
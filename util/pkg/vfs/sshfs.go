@@ -24,6 +24,7 @@ import (
 	"os"
 	"path"
 	"sync"
+	"time"
 
 	"github.com/pkg/sftp"
 	"golang.org/x/crypto/ssh"
@@ -95,6 +96,8 @@ func (p *SSHPath) String() string {
 }
 
 func (p *SSHPath) Remove() error {
+	defer recordOperation("ssh", "Remove", p.Path(), time.Now())
+
 	sftpClient, err := p.newClient()
 	if err != nil {
 		return err
@@ -151,6 +154,8 @@ func mkdirAll(sftpClient *sftp.Client, dir string) error {
 }
 
 func (p *SSHPath) WriteFile(data io.ReadSeeker, acl ACL) error {
+	defer recordOperation("ssh", "WriteFile", p.Path(), time.Now())
+
 	sftpClient, err := p.newClient()
 	if err != nil {
 		return err
@@ -250,6 +255,8 @@ func (p *SSHPath) CreateFile(data io.ReadSeeker, acl ACL) error {
 
 // ReadFile implements Path::ReadFile
 func (p *SSHPath) ReadFile() ([]byte, error) {
+	defer recordOperation("ssh", "ReadFile", p.Path(), time.Now())
+
 	var b bytes.Buffer
 	_, err := p.WriteTo(&b)
 	if err != nil {
@@ -276,6 +283,8 @@ func (p *SSHPath) WriteTo(out io.Writer) (int64, error) {
 }
 
 func (p *SSHPath) ReadDir() ([]Path, error) {
+	defer recordOperation("ssh", "ReadDir", p.Path(), time.Now())
+
 	sftpClient, err := p.newClient()
 	if err != nil {
 		return nil, err
@@ -296,6 +305,8 @@ func (p *SSHPath) ReadDir() ([]Path, error) {
 }
 
 func (p *SSHPath) ReadTree() ([]Path, error) {
+	defer recordOperation("ssh", "ReadTree", p.Path(), time.Now())
+
 	sftpClient, err := p.newClient()
 	if err != nil {
 		return nil, err
@@ -277,6 +277,8 @@ func (p *SwiftPath) String() string {
 }
 
 func (p *SwiftPath) Remove() error {
+	defer recordOperation("swift", "Remove", p.Path(), time.Now())
+
 	done, err := RetryWithBackoff(swiftWriteBackoff, func() (bool, error) {
 		opt := swiftobject.DeleteOpts{}
 		_, err := swiftobject.Delete(p.client, p.bucket, p.key, opt).Extract()
@@ -314,6 +316,8 @@ func (p *SwiftPath) Join(relativePath ...string) Path {
 }
 
 func (p *SwiftPath) WriteFile(data io.ReadSeeker, acl ACL) error {
+	defer recordOperation("swift", "WriteFile", p.Path(), time.Now())
+
 	done, err := RetryWithBackoff(swiftWriteBackoff, func() (bool, error) {
 		klog.V(4).Infof("Writing file %q", p)
 		if _, err := data.Seek(0, 0); err != nil {
@@ -400,6 +404,8 @@ func (p *SwiftPath) createBucket() error {
 
 // ReadFile implements Path::ReadFile
 func (p *SwiftPath) ReadFile() ([]byte, error) {
+	defer recordOperation("swift", "ReadFile", p.Path(), time.Now())
+
 	var b bytes.Buffer
 	done, err := RetryWithBackoff(swiftReadBackoff, func() (bool, error) {
 		b.Reset()
@@ -484,6 +490,8 @@ func (p *SwiftPath) readPath(opt swiftobject.ListOpts) ([]Path, error) {
 
 // ReadDir implements Path::ReadDir.
 func (p *SwiftPath) ReadDir() ([]Path, error) {
+	defer recordOperation("swift", "ReadDir", p.Path(), time.Now())
+
 	prefix := p.key
 	if prefix != "" && !strings.HasSuffix(prefix, "/") {
 		prefix += "/"
@@ -497,6 +505,8 @@ func (p *SwiftPath) ReadDir() ([]Path, error) {
 
 // ReadTree implements Path::ReadTree.
 func (p *SwiftPath) ReadTree() ([]Path, error) {
+	defer recordOperation("swift", "ReadTree", p.Path(), time.Now())
+
 	prefix := p.key
 	if prefix != "" && !strings.HasSuffix(prefix, "/") {
 		prefix += "/"
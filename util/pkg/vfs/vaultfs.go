@@ -24,6 +24,7 @@ import (
 	"os"
 	"path"
 	"strings"
+	"time"
 
 	vault "github.com/hashicorp/vault/api"
 	"k8s.io/klog/v2"
@@ -62,6 +63,8 @@ func newVaultPath(client *vault.Client, scheme string, path string) (*VaultPath,
 }
 
 func (p *VaultPath) WriteFile(data io.ReadSeeker, acl ACL) error {
+	defer recordOperation("vault", "WriteFile", p.Path(), time.Now())
+
 	klog.V(4).Infof("Writing file %q", p)
 
 	file, err := encodeData(data)
@@ -89,6 +92,8 @@ func (p *VaultPath) CreateFile(data io.ReadSeeker, acl ACL) error {
 }
 
 func (p *VaultPath) ReadFile() ([]byte, error) {
+	defer recordOperation("vault", "ReadFile", p.Path(), time.Now())
+
 	secret, err := p.vaultClient.Logical().Read(p.dataPath())
 	if err != nil {
 		return nil, err
@@ -105,6 +110,8 @@ func (p *VaultPath) ReadFile() ([]byte, error) {
 }
 
 func (p *VaultPath) Remove() error {
+	defer recordOperation("vault", "Remove", p.Path(), time.Now())
+
 	klog.V(8).Infof("removing file %s", p)
 	_, err := p.vaultClient.Logical().Delete(p.dataPath())
 	return err
@@ -142,6 +149,8 @@ func (p *VaultPath) Path() string {
 }
 
 func (p *VaultPath) ReadDir() ([]Path, error) {
+	defer recordOperation("vault", "ReadDir", p.Path(), time.Now())
+
 	secret, err := p.vaultClient.Logical().List(p.metadataPath())
 	if err != nil {
 		return nil, err
@@ -159,6 +168,8 @@ func (p *VaultPath) ReadDir() ([]Path, error) {
 }
 
 func (p *VaultPath) ReadTree() ([]Path, error) {
+	defer recordOperation("vault", "ReadTree", p.Path(), time.Now())
+
 	content, err := p.ReadDir()
 	files := make([]Path, 0)
 	if os.IsNotExist(err) {